@@ -0,0 +1,45 @@
+package authenticate
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+// publishAuditEvent publishes an audit event for the session of the request.
+func publishAuditEvent(r *http.Request, eventType, message string, s *sessions.State, email string) {
+	evt := &audit.Event{
+		Type:    eventType,
+		Message: message,
+		Email:   email,
+		Ip:      getClientIP(r),
+		Url:     urlutil.GetAbsoluteURL(r).String(),
+	}
+	if s != nil {
+		evt.UserId = s.UserID(s.IdentityProviderID)
+		evt.SessionId = s.ID
+		if s.IdentityProviderID != "" {
+			evt.Details = map[string]string{"identity_provider_id": s.IdentityProviderID}
+		}
+	}
+	audit.Publish(r.Context(), evt)
+}
+
+// getClientIP returns the address of the client. Envoy appends the address of the downstream
+// connection to the X-Forwarded-For header, so it's the last one.
+func getClientIP(r *http.Request) string {
+	if xff := r.Header.Get(httputil.HeaderForwardedFor); xff != "" {
+		parts := strings.Split(xff, ",")
+		return strings.TrimSpace(parts[len(parts)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
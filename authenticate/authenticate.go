@@ -9,6 +9,7 @@ import (
 
 	"github.com/pomerium/pomerium/authenticate/handlers/webauthn"
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/identity/health"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 )
@@ -38,6 +39,7 @@ type Authenticate struct {
 	options  *config.AtomicOptions
 	state    *atomicAuthenticateState
 	webauthn *webauthn.Handler
+	health   *health.Checker
 }
 
 // New validates and creates a new authenticate service from a set of Options.
@@ -46,8 +48,10 @@ func New(cfg *config.Config, options ...Option) (*Authenticate, error) {
 		cfg:     getAuthenticateConfig(options...),
 		options: config.NewAtomicOptions(),
 		state:   newAtomicAuthenticateState(newAuthenticateState()),
+		health:  health.New(),
 	}
 	a.webauthn = webauthn.New(a.getWebauthnState)
+	a.health.OnCheck(a.publishIdentityProviderHealth)
 
 	state, err := newAuthenticateStateFromConfig(cfg)
 	if err != nil {
@@ -65,9 +69,15 @@ func (a *Authenticate) OnConfigChange(ctx context.Context, cfg *config.Config) {
 	}
 
 	a.options.Store(cfg.Options)
+	a.health.UpdateConfig(cfg.Options.IdentityProviderHealthCheckInterval, cfg.Options.GetAllIdentityProviders())
 	if state, err := newAuthenticateStateFromConfig(cfg); err != nil {
 		log.Error(ctx).Err(err).Msg("authenticate: failed to update state")
 	} else {
 		a.state.Store(state)
 	}
 }
+
+// Run runs the authenticate service's background tasks, like identity provider health checks.
+func (a *Authenticate) Run(ctx context.Context) error {
+	return a.health.Run(ctx)
+}
@@ -0,0 +1,195 @@
+package authenticate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	identitypb "github.com/pomerium/pomerium/pkg/grpc/identity"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+type logoutTokenVerifier interface {
+	VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*oidc.LogoutToken, error)
+}
+
+// BackChannelLogout receives a logout token from the identity provider and deletes the
+// sessions it identifies from the databroker.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRequest
+func (a *Authenticate) BackChannelLogout(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	w.Header().Set("Cache-Control", "no-store")
+
+	rawLogoutToken := r.PostFormValue("logout_token")
+	if rawLogoutToken == "" {
+		return httputil.NewError(http.StatusBadRequest, fmt.Errorf("missing logout_token"))
+	}
+
+	token, err := a.verifyLogoutToken(ctx, rawLogoutToken)
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+
+	cnt, err := a.deleteLogoutTokenSessions(ctx, token)
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+
+	log.Info(ctx).
+		Str("issuer", token.Issuer).
+		Str("subject", token.Subject).
+		Str("sid", token.SessionID).
+		Int("sessions", cnt).
+		Msg("authenticate: back-channel logout")
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// verifyLogoutToken verifies the logout token using the identity providers whose client id
+// is in the token's audience.
+func (a *Authenticate) verifyLogoutToken(ctx context.Context, rawLogoutToken string) (*oidc.LogoutToken, error) {
+	options := a.options.Load()
+
+	tok, err := jwt.ParseSigned(rawLogoutToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", oidc.ErrInvalidLogoutToken, err)
+	}
+	var unverified jwt.Claims
+	if err := tok.UnsafeClaimsWithoutVerification(&unverified); err != nil {
+		return nil, fmt.Errorf("%w: %v", oidc.ErrInvalidLogoutToken, err)
+	}
+
+	err = fmt.Errorf("%w: no identity provider found for audience", oidc.ErrInvalidLogoutToken)
+	for _, idp := range getAllIdentityProviders(options) {
+		if !unverified.Audience.Contains(idp.GetClientId()) {
+			continue
+		}
+
+		authenticator, e := a.cfg.getIdentityProvider(options, idp.GetId())
+		if e != nil {
+			return nil, e
+		}
+		verifier, ok := authenticator.(logoutTokenVerifier)
+		if !ok {
+			continue
+		}
+
+		var token *oidc.LogoutToken
+		token, err = verifier.VerifyLogoutToken(ctx, rawLogoutToken)
+		if err == nil {
+			return token, nil
+		}
+	}
+	return nil, err
+}
+
+// logoutTokenSessionsPageSize is the number of sessions queried at a time for a logout token.
+const logoutTokenSessionsPageSize = 100
+
+// deleteLogoutTokenSessions deletes all the sessions matching the logout token and returns
+// the number of deleted sessions.
+func (a *Authenticate) deleteLogoutTokenSessions(ctx context.Context, token *oidc.LogoutToken) (int, error) {
+	client := a.state.Load().dataBrokerClient
+
+	// only the sessions containing the sid, or else the sub, of the logout token are queried,
+	// and then checked against their claims
+	query := token.SessionID
+	if query == "" {
+		query = token.Subject
+	}
+	if query == "" {
+		return 0, nil
+	}
+
+	var sessionIDs []string
+	for offset := int64(0); ; offset += logoutTokenSessionsPageSize {
+		res, err := client.Query(ctx, &databroker.QueryRequest{
+			Type:   protoutil.GetTypeURL(new(session.Session)),
+			Query:  query,
+			Offset: offset,
+			Limit:  logoutTokenSessionsPageSize,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("authenticate: error querying sessions: %w", err)
+		}
+
+		for _, record := range res.GetRecords() {
+			var s session.Session
+			if err := record.GetData().UnmarshalTo(&s); err != nil {
+				continue
+			}
+			if isLogoutTokenSession(&s, token) {
+				sessionIDs = append(sessionIDs, s.GetId())
+			}
+		}
+
+		if len(res.GetRecords()) == 0 || offset+int64(len(res.GetRecords())) >= res.GetTotalCount() {
+			break
+		}
+	}
+
+	// the sessions are deleted once they've all been found, so the pages don't shift
+	for i, sessionID := range sessionIDs {
+		if err := session.Delete(ctx, client, sessionID); err != nil {
+			return i, fmt.Errorf("authenticate: error deleting session: %w", err)
+		}
+	}
+	return len(sessionIDs), nil
+}
+
+// isLogoutTokenSession returns true if the session was issued by the logout token's issuer and
+// matches its subject and session id.
+func isLogoutTokenSession(s *session.Session, token *oidc.LogoutToken) bool {
+	if !hasSessionClaim(s, "iss", token.Issuer) {
+		return false
+	}
+	if token.Subject != "" && s.GetIdToken().GetSubject() != token.Subject && !hasSessionClaim(s, "sub", token.Subject) {
+		return false
+	}
+	if token.SessionID != "" && !hasSessionClaim(s, "sid", token.SessionID) {
+		return false
+	}
+	return true
+}
+
+func hasSessionClaim(s *session.Session, name, value string) bool {
+	for _, v := range s.GetClaims()[name].GetValues() {
+		if v.GetStringValue() == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getAllIdentityProviders returns the default identity provider, the named identity providers
+// and the identity providers customized by policies.
+func getAllIdentityProviders(options *config.Options) []*identitypb.Provider {
+	var idps []*identitypb.Provider
+	seen := map[string]struct{}{}
+	add := func(idp *identitypb.Provider) {
+		if _, ok := seen[idp.GetId()]; ok {
+			return
+		}
+		seen[idp.GetId()] = struct{}{}
+		idps = append(idps, idp)
+	}
+
+	add(options.GetIdentityProviderForPolicy(nil))
+	for _, name := range options.GetIdentityProviderNames() {
+		idp, _ := options.GetIdentityProviderForName(name)
+		add(idp)
+	}
+	for _, policy := range options.GetAllPolicies() {
+		add(options.GetIdentityProviderForPolicy(&policy)) //nolint
+	}
+	return idps
+}
@@ -0,0 +1,128 @@
+package authenticate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+func TestIsLogoutTokenSession(t *testing.T) {
+	s := &session.Session{
+		Id:      "SESSION",
+		IdToken: &session.IDToken{Subject: "SUBJECT"},
+	}
+	s.AddClaims(identity.FlattenedClaims{
+		"iss": {"https://idp.example.com"},
+		"sid": {"SID"},
+	})
+
+	for _, tc := range []struct {
+		name   string
+		token  oidc.LogoutToken
+		expect bool
+	}{
+		{"subject", oidc.LogoutToken{Issuer: "https://idp.example.com", Subject: "SUBJECT"}, true},
+		{"sid", oidc.LogoutToken{Issuer: "https://idp.example.com", SessionID: "SID"}, true},
+		{"subject and sid", oidc.LogoutToken{Issuer: "https://idp.example.com", Subject: "SUBJECT", SessionID: "SID"}, true},
+		{"other issuer", oidc.LogoutToken{Issuer: "https://other.example.com", Subject: "SUBJECT"}, false},
+		{"other subject", oidc.LogoutToken{Issuer: "https://idp.example.com", Subject: "OTHER"}, false},
+		{"other sid", oidc.LogoutToken{Issuer: "https://idp.example.com", Subject: "SUBJECT", SessionID: "OTHER"}, false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, isLogoutTokenSession(s, &tc.token))
+		})
+	}
+}
+
+func TestDeleteLogoutTokenSessions(t *testing.T) {
+	ctx := context.Background()
+
+	records := map[string]*databroker.Record{}
+	var queries []*databroker.QueryRequest
+	client := mockDataBrokerServiceClient{
+		put: func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
+			for _, record := range in.GetRecords() {
+				if record.GetDeletedAt() != nil {
+					delete(records, record.GetId())
+				} else {
+					records[record.GetId()] = record
+				}
+			}
+			return &databroker.PutResponse{Records: in.GetRecords()}, nil
+		},
+		query: func(ctx context.Context, in *databroker.QueryRequest, opts ...grpc.CallOption) (*databroker.QueryResponse, error) {
+			queries = append(queries, in)
+			var ids []string
+			for id, record := range records {
+				if storage.MatchAny(record.GetData(), strings.ToLower(in.GetQuery())) {
+					ids = append(ids, id)
+				}
+			}
+			sort.Strings(ids)
+			res := &databroker.QueryResponse{TotalCount: int64(len(ids))}
+			for i := in.GetOffset(); i < int64(len(ids)) && i < in.GetOffset()+in.GetLimit(); i++ {
+				res.Records = append(res.Records, records[ids[i]])
+			}
+			return res, nil
+		},
+	}
+	put := func(id, iss, sub, sid string) {
+		s := &session.Session{Id: id, IdToken: &session.IDToken{Subject: sub}}
+		s.AddClaims(identity.FlattenedClaims{"iss": {iss}, "sid": {sid}})
+		_, err := session.Put(ctx, client, s)
+		require.NoError(t, err)
+	}
+	for i := 0; i < logoutTokenSessionsPageSize+5; i++ {
+		put(fmt.Sprintf("SESSION_%03d", i), "https://idp.example.com", "SUBJECT", fmt.Sprintf("SID_%03d", i))
+	}
+	put("OTHER_ISSUER", "https://other.example.com", "SUBJECT", "SID_OTHER")
+	put("OTHER_SUBJECT", "https://idp.example.com", "OTHER", "SID_OTHER")
+
+	a := &Authenticate{
+		state: newAtomicAuthenticateState(&authenticateState{
+			dataBrokerClient: client,
+		}),
+	}
+
+	t.Run("sid", func(t *testing.T) {
+		queries = nil
+		cnt, err := a.deleteLogoutTokenSessions(ctx, &oidc.LogoutToken{
+			Issuer:    "https://idp.example.com",
+			SessionID: "SID_000",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, cnt)
+		assert.NotContains(t, records, "SESSION_000")
+		if assert.Len(t, queries, 1) {
+			assert.Equal(t, "SID_000", queries[0].GetQuery())
+		}
+	})
+	t.Run("subject", func(t *testing.T) {
+		queries = nil
+		cnt, err := a.deleteLogoutTokenSessions(ctx, &oidc.LogoutToken{
+			Issuer:  "https://idp.example.com",
+			Subject: "SUBJECT",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, logoutTokenSessionsPageSize+4, cnt)
+		assert.Len(t, records, 2)
+		assert.Contains(t, records, "OTHER_ISSUER")
+		assert.Contains(t, records, "OTHER_SUBJECT")
+		if assert.Len(t, queries, 2) {
+			assert.Equal(t, "SUBJECT", queries[0].GetQuery())
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package authenticate
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/ui"
+)
+
+// withBranding adds the branding of the route the user is signing in to to the request
+// context, so the pages served by the authenticate service use it.
+func (a *Authenticate) withBranding(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only the query string is used, so the request body isn't read
+		var redirectURL *url.URL
+		rawRedirectURL := r.URL.Query().Get(urlutil.QueryRedirectURI)
+		if rawRedirectURL == "" {
+			if c, err := r.Cookie(urlutil.QueryRedirectURI); err == nil {
+				rawRedirectURL = c.Value
+			}
+		}
+		if rawRedirectURL != "" {
+			redirectURL, _ = urlutil.ParseAndValidateURL(rawRedirectURL)
+		}
+
+		if branding := a.options.Load().GetBranding(redirectURL); branding != nil {
+			ctx := ui.WithBranding(r.Context(), branding.Localize(r.Header.Get("Accept-Language")))
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,284 @@
+package authenticate
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/authenticate/handlers"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+// The OAuth device authorization grant lets devices without a browser, like CLIs running on
+// servers, sign in. The device requests a user code which the user enters on another device
+// with a browser, and the device polls the token endpoint until the user approves it.
+//
+// https://datatracker.ietf.org/doc/html/rfc8628
+const (
+	// DeviceAuthorizationPath is the path of the device authorization endpoint.
+	DeviceAuthorizationPath = "/oauth2/device_authorization"
+	// TokenPath is the path of the token endpoint.
+	TokenPath = "/oauth2/token"
+	// DeviceVerificationPath is the path of the page where users approve devices.
+	DeviceVerificationPath = "/.pomerium/device"
+
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	deviceAuthorizationLifetime     = 10 * time.Minute
+	deviceAuthorizationPollInterval = 5 * time.Second
+
+	// user codes only use consonants so they can't spell words and are easy to type
+	userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength  = 8
+)
+
+// DeviceAuthorization starts a device authorization grant.
+func (a *Authenticate) DeviceAuthorization(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	state := a.state.Load()
+	w.Header().Set("Cache-Control", "no-store")
+
+	userCode, err := newUserCode()
+	if err != nil {
+		return err
+	}
+	// the device code is prefixed with the user code, so the grant can be looked up
+	deviceCode := userCode + "." + base64.RawURLEncoding.EncodeToString(cryptutil.NewKey())
+
+	now := time.Now()
+	_, err = session.PutDeviceAuthorization(ctx, state.dataBrokerClient, &session.DeviceAuthorization{
+		Id:                 userCode,
+		DeviceCodeHash:     hashDeviceCode(deviceCode),
+		CreatedAt:          timestamppb.New(now),
+		ExpiresAt:          timestamppb.New(now.Add(deviceAuthorizationLifetime)),
+		IdentityProviderId: r.FormValue(urlutil.QueryIdentityProviderID),
+	})
+	if err != nil {
+		return fmt.Errorf("authenticate: error saving device authorization: %w", err)
+	}
+
+	verificationURL := state.redirectURL.ResolveReference(&url.URL{Path: DeviceVerificationPath})
+	q := url.Values{}
+	if idpID := r.FormValue(urlutil.QueryIdentityProviderID); idpID != "" {
+		q.Set(urlutil.QueryIdentityProviderID, idpID)
+	}
+	verificationURL.RawQuery = q.Encode()
+	verificationURLComplete := *verificationURL
+	q.Set("user_code", formatUserCode(userCode))
+	verificationURLComplete.RawQuery = q.Encode()
+
+	httputil.RenderJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":               deviceCode,
+		"user_code":                 formatUserCode(userCode),
+		"verification_uri":          verificationURL.String(),
+		"verification_uri_complete": verificationURLComplete.String(),
+		"expires_in":                int(deviceAuthorizationLifetime.Seconds()),
+		"interval":                  int(deviceAuthorizationPollInterval.Seconds()),
+	})
+	return nil
+}
+
+// Token issues tokens. The device code and token exchange grant types are supported.
+func (a *Authenticate) Token(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+
+	switch grantType := r.PostFormValue("grant_type"); grantType {
+	case deviceCodeGrantType:
+		return a.deviceCodeToken(w, r)
+	case tokenExchangeGrantType:
+		return a.tokenExchangeToken(w, r)
+	default:
+		renderOAuthError(w, "unsupported_grant_type", fmt.Sprintf("unsupported grant type: %q", grantType))
+		return nil
+	}
+}
+
+func (a *Authenticate) deviceCodeToken(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	state := a.state.Load()
+
+	deviceCode := r.PostFormValue("device_code")
+	userCode, _, _ := strings.Cut(deviceCode, ".")
+	da, err := session.GetDeviceAuthorization(ctx, state.dataBrokerClient, userCode)
+	if status.Code(err) == codes.NotFound {
+		renderOAuthError(w, "invalid_grant", "invalid device code")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("authenticate: error getting device authorization: %w", err)
+	}
+	if subtle.ConstantTimeCompare(da.GetDeviceCodeHash(), hashDeviceCode(deviceCode)) != 1 {
+		renderOAuthError(w, "invalid_grant", "invalid device code")
+		return nil
+	}
+
+	now := time.Now()
+	switch {
+	case da.IsExpired(now):
+		err = session.DeleteDeviceAuthorization(ctx, state.dataBrokerClient, da.GetId())
+		renderOAuthError(w, "expired_token", "the device code has expired")
+		return err
+	case da.GetDenied():
+		err = session.DeleteDeviceAuthorization(ctx, state.dataBrokerClient, da.GetId())
+		renderOAuthError(w, "access_denied", "the device authorization was denied")
+		return err
+	case da.GetSessionId() == "":
+		errorCode := "authorization_pending"
+		if da.GetLastPolledAt() != nil && now.Sub(da.GetLastPolledAt().AsTime()) < deviceAuthorizationPollInterval {
+			errorCode = "slow_down"
+		}
+		da.LastPolledAt = timestamppb.New(now)
+		if _, err := session.PutDeviceAuthorization(ctx, state.dataBrokerClient, da); err != nil {
+			return fmt.Errorf("authenticate: error saving device authorization: %w", err)
+		}
+		renderOAuthError(w, errorCode, "")
+		return nil
+	}
+
+	// device codes can only be used once
+	if err := session.DeleteDeviceAuthorization(ctx, state.dataBrokerClient, da.GetId()); err != nil {
+		return fmt.Errorf("authenticate: error deleting device authorization: %w", err)
+	}
+
+	s, err := session.Get(ctx, state.dataBrokerClient, da.GetSessionId())
+	if status.Code(err) == codes.NotFound {
+		renderOAuthError(w, "access_denied", "the session which approved the device authorization no longer exists")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("authenticate: error getting session: %w", err)
+	}
+
+	// the device shares the session of the user, like programmatic sign ins
+	deviceSession := &sessions.State{
+		ID:                 s.GetId(),
+		Subject:            s.GetIdToken().GetSubject(),
+		IdentityProviderID: da.GetIdentityProviderId(),
+	}
+	newSession := deviceSession.WithNewIssuer(state.redirectURL.Host, []string{state.redirectURL.Host})
+	signedJWT, err := state.sharedEncoder.Marshal(newSession)
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx).
+		Str("session_id", s.GetId()).
+		Str("user_id", s.GetUserId()).
+		Msg("authenticate: issued device authorization token")
+
+	httputil.RenderJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": string(signedJWT),
+		"token_type":   "Pomerium",
+		"expires_in":   int(time.Until(s.GetExpiresAt().AsTime()).Seconds()),
+	})
+	return nil
+}
+
+// DeviceVerification renders the page where users enter the user code of a device, and
+// approves or denies the device authorization when the form is submitted.
+func (a *Authenticate) DeviceVerification(w http.ResponseWriter, r *http.Request) error {
+	data := handlers.DeviceVerificationData{
+		UserCode: r.FormValue("user_code"),
+	}
+	if r.Method != http.MethodPost {
+		handlers.DeviceVerification(data).ServeHTTP(w, r)
+		return nil
+	}
+
+	ctx := r.Context()
+	state := a.state.Load()
+
+	s, err := a.getSessionFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	da, err := session.GetDeviceAuthorization(ctx, state.dataBrokerClient, normalizeUserCode(data.UserCode))
+	if err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("authenticate: error getting device authorization: %w", err)
+	}
+	if err != nil || da.IsExpired(time.Now()) || da.GetSessionId() != "" || da.GetDenied() {
+		data.Error = "Invalid or expired code."
+		handlers.DeviceVerification(data).ServeHTTP(w, r)
+		return nil
+	}
+
+	if r.PostFormValue("action") == "approve" {
+		da.SessionId = s.ID
+		da.IdentityProviderId = s.IdentityProviderID
+		data.Result = "approved"
+	} else {
+		da.Denied = true
+		data.Result = "denied"
+	}
+	if _, err := session.PutDeviceAuthorization(ctx, state.dataBrokerClient, da); err != nil {
+		return fmt.Errorf("authenticate: error saving device authorization: %w", err)
+	}
+
+	log.Info(ctx).
+		Str("session_id", s.ID).
+		Str("result", data.Result).
+		Msg("authenticate: device authorization")
+
+	handlers.DeviceVerification(data).ServeHTTP(w, r)
+	return nil
+}
+
+// renderOAuthError renders an OAuth error response.
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func renderOAuthError(w http.ResponseWriter, errorCode, description string) {
+	res := map[string]interface{}{"error": errorCode}
+	if description != "" {
+		res["error_description"] = description
+	}
+	httputil.RenderJSON(w, http.StatusBadRequest, res)
+}
+
+func hashDeviceCode(deviceCode string) []byte {
+	return cryptutil.Hash("device code", []byte(deviceCode))
+}
+
+func newUserCode() (string, error) {
+	max := big.NewInt(int64(len(userCodeCharset)))
+	var sb strings.Builder
+	for i := 0; i < userCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("authenticate: error generating user code: %w", err)
+		}
+		sb.WriteByte(userCodeCharset[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// formatUserCode formats a user code as two groups of characters, e.g. BCDF-GHJK.
+func formatUserCode(userCode string) string {
+	return userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+}
+
+// normalizeUserCode removes the separator and other characters users may enter.
+func normalizeUserCode(userCode string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		if strings.ContainsRune(userCodeCharset, r) {
+			return r
+		}
+		return -1
+	}, userCode)
+}
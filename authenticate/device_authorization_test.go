@@ -0,0 +1,145 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+func TestUserCode(t *testing.T) {
+	userCode, err := newUserCode()
+	require.NoError(t, err)
+	assert.Len(t, userCode, userCodeLength)
+	assert.Equal(t, userCode, normalizeUserCode(formatUserCode(userCode)))
+	assert.Equal(t, userCode, normalizeUserCode(strings.ToLower(formatUserCode(userCode))))
+
+	assert.Equal(t, "BCDF-GHJK", formatUserCode("BCDFGHJK"))
+	assert.Equal(t, "BCDFGHJK", normalizeUserCode(" bcdf-ghjk "))
+}
+
+func TestDeviceCodeToken(t *testing.T) {
+	ctx := context.Background()
+
+	records := map[string]*databroker.Record{}
+	client := mockDataBrokerServiceClient{
+		get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+			record, ok := records[in.GetType()+"/"+in.GetId()]
+			if !ok {
+				return nil, status.Error(codes.NotFound, "not found")
+			}
+			return &databroker.GetResponse{Record: record}, nil
+		},
+		put: func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
+			for _, record := range in.GetRecords() {
+				if record.GetDeletedAt() != nil {
+					delete(records, record.GetType()+"/"+record.GetId())
+				} else {
+					records[record.GetType()+"/"+record.GetId()] = record
+				}
+			}
+			return &databroker.PutResponse{Records: in.GetRecords()}, nil
+		},
+	}
+	_, err := session.Put(ctx, client, &session.Session{
+		Id:        "SESSION_ID",
+		UserId:    "USER_ID",
+		IdToken:   &session.IDToken{Subject: "SUBJECT"},
+		ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	signer, err := jws.NewHS256Signer(nil)
+	require.NoError(t, err)
+	a := &Authenticate{
+		state: newAtomicAuthenticateState(&authenticateState{
+			redirectURL:      mustParseURL("https://authenticate.example.com"),
+			sharedEncoder:    signer,
+			dataBrokerClient: client,
+		}),
+	}
+
+	token := func(deviceCode string) (int, map[string]interface{}) {
+		form := url.Values{"grant_type": {deviceCodeGrantType}, "device_code": {deviceCode}}
+		r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		require.NoError(t, a.Token(w, r))
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+		return w.Code, res
+	}
+
+	r := httptest.NewRequest(http.MethodPost, DeviceAuthorizationPath, nil)
+	w := httptest.NewRecorder()
+	require.NoError(t, a.DeviceAuthorization(w, r))
+	require.Equal(t, http.StatusOK, w.Code)
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	deviceCode := res["device_code"].(string)
+	userCode := normalizeUserCode(res["user_code"].(string))
+	assert.Equal(t, "https://authenticate.example.com/.pomerium/device", res["verification_uri"])
+	assert.Equal(t, "https://authenticate.example.com/.pomerium/device?user_code="+res["user_code"].(string), res["verification_uri_complete"])
+
+	code, res := token(userCode + ".INVALID")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalid_grant", res["error"])
+
+	_, res = token(deviceCode)
+	assert.Equal(t, "authorization_pending", res["error"])
+	_, res = token(deviceCode)
+	assert.Equal(t, "slow_down", res["error"])
+
+	jwt, err := signer.Marshal(&sessions.State{ID: "SESSION_ID"})
+	require.NoError(t, err)
+	r = httptest.NewRequest(http.MethodPost, DeviceVerificationPath, strings.NewReader(url.Values{
+		"user_code": {formatUserCode(userCode)},
+		"action":    {"approve"},
+	}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r = r.WithContext(sessions.NewContext(r.Context(), string(jwt), nil))
+	w = httptest.NewRecorder()
+	require.NoError(t, a.DeviceVerification(w, r))
+
+	code, res = token(deviceCode)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, "Pomerium", res["token_type"])
+	var accessToken sessions.State
+	require.NoError(t, signer.Unmarshal([]byte(res["access_token"].(string)), &accessToken))
+	assert.Equal(t, "SESSION_ID", accessToken.ID)
+	assert.Equal(t, "SUBJECT", accessToken.Subject)
+
+	_, res = token(deviceCode)
+	assert.Equal(t, "invalid_grant", res["error"], "device codes should only be used once")
+
+	code, res = token("")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalid_grant", res["error"])
+}
+
+func TestToken(t *testing.T) {
+	a := &Authenticate{}
+	r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader("grant_type=password"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	require.NoError(t, a.Token(w, r))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unsupported_grant_type")
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-jose/go-jose/v3"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
@@ -19,20 +21,28 @@ import (
 	"github.com/pomerium/csrf"
 	"github.com/pomerium/pomerium/authenticate/handlers"
 	"github.com/pomerium/pomerium/authenticate/handlers/webauthn"
+	"github.com/pomerium/pomerium/internal/adminapi"
+	"github.com/pomerium/pomerium/internal/audit"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/identity/ldap"
 	"github.com/pomerium/pomerium/internal/identity/manager"
 	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/identity/saml"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/middleware"
+	"github.com/pomerium/pomerium/internal/scim"
 	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/signingkey"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
 	"github.com/pomerium/pomerium/pkg/grpc/directory"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/webauthnutil"
 )
 
 // Handler returns the authenticate service's handler chain.
@@ -46,11 +56,12 @@ func (a *Authenticate) Handler() http.Handler {
 func (a *Authenticate) Mount(r *mux.Router) {
 	r.StrictSlash(true)
 	r.Use(middleware.SetHeaders(httputil.HeadersContentSecurityPolicy))
+	r.Use(a.withBranding)
 	r.Use(func(h http.Handler) http.Handler {
 		options := a.options.Load()
 		state := a.state.Load()
 		csrfKey := fmt.Sprintf("%s_csrf", options.CookieName)
-		return csrf.Protect(
+		protect := csrf.Protect(
 			state.cookieSecret,
 			csrf.Secure(options.CookieSecure),
 			csrf.Path("/"),
@@ -64,6 +75,30 @@ func (a *Authenticate) Mount(r *mux.Router) {
 			csrf.SameSite(csrf.SameSiteLaxMode),
 			csrf.ErrorHandler(httputil.HandlerFunc(httputil.CSRFFailureHandler)),
 		)(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// SAML responses are posted by the identity provider and must be in response
+			// to the request of the relay state
+			if r.URL.Path == saml.AssertionConsumerServicePath {
+				r = csrf.UnsafeSkipCheck(r)
+			}
+			// logout tokens are posted by the identity provider and are signed
+			if r.URL.Path == oidc.BackChannelLogoutPath {
+				r = csrf.UnsafeSkipCheck(r)
+			}
+			// SCIM requests are authenticated using a bearer token
+			if strings.HasPrefix(r.URL.Path, scim.BasePath+"/") {
+				r = csrf.UnsafeSkipCheck(r)
+			}
+			// admin API requests are authenticated using a bearer token
+			if strings.HasPrefix(r.URL.Path, adminapi.BasePath+"/") {
+				r = csrf.UnsafeSkipCheck(r)
+			}
+			// device authorization requests are made by devices without a browser
+			if r.URL.Path == DeviceAuthorizationPath || r.URL.Path == TokenPath {
+				r = csrf.UnsafeSkipCheck(r)
+			}
+			protect.ServeHTTP(w, r)
+		})
 	})
 
 	// redirect / to /.pomerium/
@@ -72,17 +107,47 @@ func (a *Authenticate) Mount(r *mux.Router) {
 	r.Path("/robots.txt").HandlerFunc(a.RobotsTxt).Methods(http.MethodGet)
 	// Identity Provider (IdP) endpoints
 	r.Path("/oauth2/callback").Handler(httputil.HandlerFunc(a.OAuthCallback)).Methods(http.MethodGet)
+	r.Path(saml.AssertionConsumerServicePath).Handler(httputil.HandlerFunc(a.SAMLAssertionConsumerService)).Methods(http.MethodPost)
+	r.Path(saml.MetadataPath).Handler(httputil.HandlerFunc(a.SAMLMetadata)).Methods(http.MethodGet)
+	r.Path(ldap.SignInPath).Handler(httputil.HandlerFunc(a.LDAPSignIn)).Methods(http.MethodGet)
+	r.Path(ldap.SignInPath).Handler(httputil.HandlerFunc(a.LDAPSignInSubmit)).Methods(http.MethodPost)
+	r.Path(oidc.BackChannelLogoutPath).Handler(httputil.HandlerFunc(a.BackChannelLogout)).Methods(http.MethodPost)
+	r.PathPrefix(scim.BasePath + "/").Handler(httputil.HandlerFunc(a.SCIM))
+	r.PathPrefix(adminapi.BasePath + "/").Handler(httputil.HandlerFunc(a.AdminAPI))
+	r.Path(DeviceAuthorizationPath).Handler(httputil.HandlerFunc(a.DeviceAuthorization)).Methods(http.MethodPost)
+	r.Path(TokenPath).Handler(httputil.HandlerFunc(a.Token)).Methods(http.MethodPost)
+	r.Path(PasskeySignInPath).Handler(httputil.HandlerFunc(a.PasskeySignIn)).Methods(http.MethodPost)
 
 	a.mountDashboard(r)
 	a.mountWellKnown(r)
 }
 
+// SCIM serves the SCIM provisioning endpoint, if it's enabled.
+func (a *Authenticate) SCIM(w http.ResponseWriter, r *http.Request) error {
+	h := a.state.Load().scimHandler
+	if h == nil {
+		return httputil.NewError(http.StatusNotFound, fmt.Errorf("scim is not enabled"))
+	}
+	h.ServeHTTP(w, r)
+	return nil
+}
+
+// AdminAPI serves the admin API, if it's enabled.
+func (a *Authenticate) AdminAPI(w http.ResponseWriter, r *http.Request) error {
+	h := a.state.Load().adminAPIHandler
+	if h == nil {
+		return httputil.NewError(http.StatusNotFound, fmt.Errorf("admin api is not enabled"))
+	}
+	h.ServeHTTP(w, r)
+	return nil
+}
+
 func (a *Authenticate) mountDashboard(r *mux.Router) {
 	sr := httputil.DashboardSubrouter(r)
 	c := cors.New(cors.Options{
 		AllowOriginRequestFunc: func(r *http.Request, _ string) bool {
 			state := a.state.Load()
-			err := middleware.ValidateRequestURL(a.getExternalRequest(r), state.sharedKey)
+			err := middleware.ValidateRequestURL(a.getExternalRequest(r), state.acceptedSharedKeys...)
 			if err != nil {
 				log.FromRequest(r).Info().Err(err).Msg("authenticate: origin blocked")
 			}
@@ -108,6 +173,9 @@ func (a *Authenticate) mountDashboard(r *mux.Router) {
 		return nil
 	}))
 
+	sr.Path("/device").Handler(httputil.HandlerFunc(a.DeviceVerification)).Methods(http.MethodGet, http.MethodPost)
+	sr.Path("/impersonation").Handler(httputil.HandlerFunc(a.Impersonation)).Methods(http.MethodGet, http.MethodPost)
+
 	cr := sr.PathPrefix("/callback").Subrouter()
 	cr.Path("/").Handler(a.requireValidSignature(a.Callback)).Methods(http.MethodGet)
 }
@@ -124,13 +192,19 @@ func (a *Authenticate) mountWellKnown(r *mux.Router) {
 func (a *Authenticate) wellKnown(w http.ResponseWriter, r *http.Request) error {
 	state := a.state.Load()
 	wellKnownURLS := struct {
-		OAuth2Callback        string `json:"authentication_callback_endpoint"` // RFC6749
-		JSONWebKeySetURL      string `json:"jwks_uri"`                         // RFC7517
-		FrontchannelLogoutURI string `json:"frontchannel_logout_uri"`          // https://openid.net/specs/openid-connect-frontchannel-1_0.html
+		OAuth2Callback         string `json:"authentication_callback_endpoint"` // RFC6749
+		JSONWebKeySetURL       string `json:"jwks_uri"`                         // RFC7517
+		FrontchannelLogoutURI  string `json:"frontchannel_logout_uri"`          // https://openid.net/specs/openid-connect-frontchannel-1_0.html
+		BackchannelLogoutURI   string `json:"backchannel_logout_uri"`           // https://openid.net/specs/openid-connect-backchannel-1_0.html
+		DeviceAuthorizationURL string `json:"device_authorization_endpoint"`    // RFC8628
+		TokenURL               string `json:"token_endpoint"`                   // RFC6749
 	}{
 		state.redirectURL.ResolveReference(&url.URL{Path: "/oauth2/callback"}).String(),
 		state.redirectURL.ResolveReference(&url.URL{Path: "/.well-known/pomerium/jwks.json"}).String(),
 		state.redirectURL.ResolveReference(&url.URL{Path: "/.pomerium/sign_out"}).String(),
+		state.redirectURL.ResolveReference(&url.URL{Path: oidc.BackChannelLogoutPath}).String(),
+		state.redirectURL.ResolveReference(&url.URL{Path: DeviceAuthorizationPath}).String(),
+		state.redirectURL.ResolveReference(&url.URL{Path: TokenPath}).String(),
 	}
 	w.Header().Set("X-CSRF-Token", csrf.Token(r))
 	httputil.RenderJSON(w, http.StatusOK, wellKnownURLS)
@@ -142,10 +216,38 @@ func (a *Authenticate) wellKnown(w http.ResponseWriter, r *http.Request) error {
 //
 // https://tools.ietf.org/html/rfc8414
 func (a *Authenticate) jwks(w http.ResponseWriter, r *http.Request) error {
-	httputil.RenderJSON(w, http.StatusOK, a.state.Load().jwk)
+	jwks, err := a.getJWKS(r.Context())
+	if err != nil {
+		return httputil.NewError(http.StatusInternalServerError, err)
+	}
+	httputil.RenderJSON(w, http.StatusOK, jwks)
 	return nil
 }
 
+// getJWKS returns the public keys of the attestation JWT signing keys.
+func (a *Authenticate) getJWKS(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	options := a.options.Load()
+	if options.SigningKeyRotationInterval <= 0 {
+		return a.state.Load().jwk, nil
+	}
+
+	// when signing keys are rotated automatically, the active key and the keys rotated
+	// within the overlap are published
+	keys, err := signingkey.List(ctx, a.state.Load().dataBrokerClient)
+	if err != nil {
+		return nil, err
+	}
+	jwks := new(jose.JSONWebKeySet)
+	for _, key := range signingkey.Published(keys, time.Now(), options.SigningKeyRotationOverlap) {
+		jwk, err := signingkey.PublicJWK(key)
+		if err != nil {
+			return nil, err
+		}
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+	return jwks, nil
+}
+
 // RetrieveSession is the middleware used retrieve session by the sessionLoaders
 func (a *Authenticate) RetrieveSession(next http.Handler) http.Handler {
 	return sessions.RetrieveSession(a.state.Load().sessionLoaders...)(next)
@@ -170,7 +272,7 @@ func (a *Authenticate) VerifySession(next http.Handler) http.Handler {
 			return a.reauthenticateOrFail(w, r, err)
 		}
 
-		if sessionState.IdentityProviderID != idpID {
+		if !isRequestIdentityProvider(r, sessionState.IdentityProviderID) {
 			log.FromRequest(r).Info().
 				Str("idp_id", idpID).
 				Str("id", sessionState.ID).
@@ -181,7 +283,8 @@ func (a *Authenticate) VerifySession(next http.Handler) http.Handler {
 		if state.dataBrokerClient == nil {
 			return errors.New("authenticate: databroker client cannot be nil")
 		}
-		if _, err = session.Get(ctx, state.dataBrokerClient, sessionState.ID); err != nil {
+		s, err := session.Get(ctx, state.dataBrokerClient, sessionState.ID)
+		if err != nil {
 			log.FromRequest(r).Info().
 				Err(err).
 				Str("idp_id", idpID).
@@ -190,11 +293,46 @@ func (a *Authenticate) VerifySession(next http.Handler) http.Handler {
 			return a.reauthenticateOrFail(w, r, err)
 		}
 
+		if !isRequestSessionIssuedAfter(r, s) {
+			log.FromRequest(r).Info().
+				Str("idp_id", idpID).
+				Str("id", sessionState.ID).
+				Msg("authenticate: session must be renewed")
+			return a.reauthenticateOrFail(w, r, err)
+		}
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 		return nil
 	})
 }
 
+// isRequestSessionIssuedAfter returns false if the request requires a session issued after a
+// given time, and the session is older. Authorize uses this to replace sessions which are too
+// old or idle for a route.
+func isRequestSessionIssuedAfter(r *http.Request, s *session.Session) bool {
+	issuedAfter, err := strconv.ParseInt(r.FormValue(urlutil.QuerySessionIssuedAfter), 10, 64)
+	if err != nil {
+		return true
+	}
+	return s.GetIssuedAt().GetSeconds() > issuedAfter
+}
+
+// setSignInURLPrompt sets the OpenID Connect prompt parameter of the identity provider sign in
+// URL. A prompt of "login" requires the user to sign in again even if they're already signed in
+// to the identity provider.
+//
+// https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+func setSignInURLPrompt(signinURL, prompt string) (string, error) {
+	u, err := url.Parse(signinURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("prompt", prompt)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
 // RobotsTxt handles the /robots.txt route.
 func (a *Authenticate) RobotsTxt(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
@@ -237,8 +375,8 @@ func (a *Authenticate) SignIn(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	// start over if this is a different identity provider
-	if s == nil || s.IdentityProviderID != r.FormValue(urlutil.QueryIdentityProviderID) {
-		s = sessions.NewState(urlutil.QueryIdentityProviderID)
+	if s == nil || !isRequestIdentityProvider(r, s.IdentityProviderID) {
+		s = sessions.NewState(r.FormValue(urlutil.QueryIdentityProviderID))
 	}
 
 	newSession := s.WithNewIssuer(state.redirectURL.Host, jwtAudience)
@@ -275,7 +413,7 @@ func (a *Authenticate) SignIn(w http.ResponseWriter, r *http.Request) error {
 // Handles both GET and POST.
 func (a *Authenticate) SignOut(w http.ResponseWriter, r *http.Request) error {
 	// check for an HMAC'd URL. If none is found, show a confirmation page.
-	err := middleware.ValidateRequestURL(a.getExternalRequest(r), a.state.Load().sharedKey)
+	err := middleware.ValidateRequestURL(a.getExternalRequest(r), a.state.Load().acceptedSharedKeys...)
 	if err != nil {
 		authenticateURL, err := a.options.Load().GetAuthenticateURL()
 		if err != nil {
@@ -345,6 +483,10 @@ func (a *Authenticate) signOutRedirect(w http.ResponseWriter, r *http.Request) e
 // https://tools.ietf.org/html/rfc6749#section-4.2.1
 // https://developer.mozilla.org/en-US/docs/Web/API/XMLHttpRequest
 func (a *Authenticate) reauthenticateOrFail(w http.ResponseWriter, r *http.Request, err error) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.reauthenticateOrFail")
+	defer span.End()
+	r = r.WithContext(ctx)
+
 	// If request AJAX/XHR request, return a 401 instead because the redirect
 	// will almost certainly violate their CORs policy
 	if reqType := r.Header.Get("X-Requested-With"); strings.EqualFold(reqType, "XmlHttpRequest") {
@@ -354,6 +496,17 @@ func (a *Authenticate) reauthenticateOrFail(w http.ResponseWriter, r *http.Reque
 	options := a.options.Load()
 	state := a.state.Load()
 
+	// let the user choose an identity provider if the route allows several
+	if len(r.Form[urlutil.QueryIdentityProviderChoices]) > 0 {
+		return a.selectIdentityProvider(w, r)
+	}
+
+	// sign in with the failover identity provider if the requested one is down
+	if failoverURL, ok := a.getIdentityProviderFailoverURL(r); ok {
+		httputil.Redirect(w, r, failoverURL.String(), http.StatusFound)
+		return nil
+	}
+
 	idp, err := a.cfg.getIdentityProvider(options, r.FormValue(urlutil.QueryIdentityProviderID))
 	if err != nil {
 		return err
@@ -372,10 +525,94 @@ func (a *Authenticate) reauthenticateOrFail(w http.ResponseWriter, r *http.Reque
 		return httputil.NewError(http.StatusInternalServerError,
 			fmt.Errorf("failed to get sign in url: %w", err))
 	}
+	// authorize requires a fresh sign in when a route's max session age is exceeded
+	if prompt := r.FormValue(urlutil.QueryPrompt); prompt != "" {
+		signinURL, err = setSignInURLPrompt(signinURL, prompt)
+		if err != nil {
+			return httputil.NewError(http.StatusInternalServerError,
+				fmt.Errorf("failed to get sign in url: %w", err))
+		}
+	}
+	// let the user sign in with a passkey instead of the identity provider
+	if options.PasskeySignIn {
+		return a.passkeySignIn(w, r, signinURL)
+	}
 	httputil.Redirect(w, r, signinURL, http.StatusFound)
 	return nil
 }
 
+// selectIdentityProvider renders a page which lets the user choose one of the identity
+// providers allowed for the route. Each choice links back to the same url with the chosen
+// identity provider set, signed again since the url has changed.
+func (a *Authenticate) selectIdentityProvider(w http.ResponseWriter, r *http.Request) error {
+	options := a.options.Load()
+	state := a.state.Load()
+
+	names := map[string]string{}
+	for _, name := range options.GetIdentityProviderNames() {
+		idp, _ := options.GetIdentityProviderForName(name)
+		names[idp.GetId()] = name
+	}
+
+	var data handlers.SelectIdentityProviderData
+	for _, idpID := range r.Form[urlutil.QueryIdentityProviderChoices] {
+		name, ok := names[idpID]
+		if !ok {
+			continue
+		}
+
+		u := state.redirectURL.ResolveReference(r.URL)
+		q := u.Query()
+		q.Set(urlutil.QueryIdentityProviderID, idpID)
+		q.Del(urlutil.QueryIdentityProviderChoices)
+		q.Del(urlutil.QueryHmacSignature)
+		u.RawQuery = q.Encode()
+
+		data.IdentityProviders = append(data.IdentityProviders, handlers.IdentityProviderChoice{
+			Name: name,
+			URL:  urlutil.NewSignedURL(state.sharedKey, u).String(),
+		})
+	}
+	if len(data.IdentityProviders) == 0 {
+		return httputil.NewError(http.StatusBadRequest, fmt.Errorf("no valid identity provider choices"))
+	}
+
+	handlers.SelectIdentityProvider(data).ServeHTTP(w, r)
+	return nil
+}
+
+// getIdentityProviderFailoverURL returns the sign in url, signed again with the failover
+// identity provider set, if the requested identity provider is unhealthy.
+func (a *Authenticate) getIdentityProviderFailoverURL(r *http.Request) (*url.URL, bool) {
+	options := a.options.Load()
+	state := a.state.Load()
+
+	failover, ok := options.GetIdentityProviderFailover()
+	if !ok {
+		return nil, false
+	}
+	idpID := options.GetIdentityProviderForID(r.FormValue(urlutil.QueryIdentityProviderID)).GetId()
+	if idpID == failover.GetId() || a.health.IsHealthy(idpID) {
+		return nil, false
+	}
+	// authorize only accepts failover sessions for routes which allow them
+	if !isIdentityProviderFailoverAllowed(options, r.FormValue(urlutil.QueryRedirectURI)) {
+		return nil, false
+	}
+
+	log.Warn(r.Context()).
+		Str("idp_id", idpID).
+		Str("failover_idp_id", failover.GetId()).
+		Msg("authenticate: identity provider is unhealthy, using failover identity provider")
+
+	u := state.redirectURL.ResolveReference(r.URL)
+	q := u.Query()
+	q.Set(urlutil.QueryIdentityProviderID, failover.GetId())
+	q.Del(urlutil.QueryHmacSignature)
+	u.RawQuery = q.Encode()
+	return urlutil.NewSignedURL(state.sharedKey, u).Sign(), true
+}
+
 // OAuthCallback handles the callback from the identity provider.
 //
 // https://openid.net/specs/openid-connect-core-1_0.html#CodeFlowSteps
@@ -486,6 +723,8 @@ func (a *Authenticate) getOAuthCallback(w http.ResponseWriter, r *http.Request)
 	if err := state.sessionStore.SaveSession(w, r, &newState); err != nil {
 		return nil, fmt.Errorf("failed saving new session: %w", err)
 	}
+	email, _ := claims.Claims["email"].(string)
+	publishAuditEvent(r, audit.EventTypeLogin, "user logged in", &newState, email)
 	return redirectURL, nil
 }
 
@@ -576,20 +815,37 @@ func (a *Authenticate) getUserInfoData(r *http.Request) (handlers.UserInfoData,
 		groups = append(groups, pbDirectoryGroup)
 	}
 
+	var deviceCredentials []*device.Credential
+	for _, deviceCredentialID := range pbUser.GetDeviceCredentialIds() {
+		deviceCredential, err := device.GetCredential(r.Context(), state.dataBrokerClient, deviceCredentialID)
+		if err != nil {
+			continue
+		}
+		deviceCredentials = append(deviceCredentials, deviceCredential)
+	}
+
 	creationOptions, requestOptions, _ := a.webauthn.GetOptions(r.Context())
 
-	return handlers.UserInfoData{
-		CSRFToken:       csrf.Token(r),
-		DirectoryGroups: groups,
-		DirectoryUser:   pbDirectoryUser,
-		IsImpersonated:  isImpersonated,
-		Session:         pbSession,
-		User:            pbUser,
+	data := handlers.UserInfoData{
+		CSRFToken:         csrf.Token(r),
+		DeviceCredentials: deviceCredentials,
+		DirectoryGroups:   groups,
+		DirectoryUser:     pbDirectoryUser,
+		IsImpersonated:    isImpersonated,
+		Session:           pbSession,
+		User:              pbUser,
 
 		WebAuthnCreationOptions: creationOptions,
 		WebAuthnRequestOptions:  requestOptions,
 		WebAuthnURL:             urlutil.WebAuthnURL(r, authenticateURL, state.sharedKey, r.URL.Query()),
-	}, nil
+	}
+	if a.options.Load().PasskeySignIn {
+		data.PasskeyCreationOptions, _, _ = a.webauthn.GetOptionsForDeviceType(r.Context(), webauthnutil.PasskeyDeviceType)
+		q := r.URL.Query()
+		q.Set(urlutil.QueryDeviceType, webauthnutil.PasskeyDeviceType)
+		data.PasskeyURL = urlutil.WebAuthnURL(r, authenticateURL, state.sharedKey, q)
+	}
+	return data, nil
 }
 
 func (a *Authenticate) saveSessionToDataBroker(
@@ -602,7 +858,7 @@ func (a *Authenticate) saveSessionToDataBroker(
 	state := a.state.Load()
 	options := a.options.Load()
 
-	idp, err := a.cfg.getIdentityProvider(options, r.FormValue(urlutil.QueryIdentityProviderID))
+	idp, err := a.cfg.getIdentityProvider(options, sessionState.IdentityProviderID)
 	if err != nil {
 		return err
 	}
@@ -624,6 +880,11 @@ func (a *Authenticate) saveSessionToDataBroker(
 		},
 		OauthToken: manager.ToOAuthToken(accessToken),
 		Audience:   sessionState.Audience,
+		IpAddress:  getClientIP(r),
+		UserAgent:  r.UserAgent(),
+
+		IdentityProviderId:           sessionState.IdentityProviderID,
+		ClientCertificateFingerprint: getClientCertificateFingerprint(r),
 	}
 	s.SetRawIDToken(claims.RawIDToken)
 	s.AddClaims(claims.Flatten())
@@ -663,6 +924,22 @@ func (a *Authenticate) saveSessionToDataBroker(
 	return nil
 }
 
+// getClientCertificateFingerprint returns the fingerprint of the client certificate envoy passed
+// to the authenticate service, so the session is bound to it when it's created. An empty string
+// is returned when no client certificate was presented.
+func getClientCertificateFingerprint(r *http.Request) string {
+	rawClientCertificate, err := url.QueryUnescape(r.Header.Get(httputil.HeaderPomeriumClientCertificate))
+	if err != nil || rawClientCertificate == "" {
+		return ""
+	}
+	fingerprint, err := session.GetClientCertificateFingerprint(rawClientCertificate)
+	if err != nil {
+		log.Warn(r.Context()).Err(err).Msg("authenticate: invalid client certificate")
+		return ""
+	}
+	return fingerprint
+}
+
 // revokeSession always clears the local session and tries to revoke the associated session stored in the
 // databroker. If successful, it returns the original `id_token` of the session, if failed, returns
 // and empty string.
@@ -683,6 +960,7 @@ func (a *Authenticate) revokeSession(ctx context.Context, w http.ResponseWriter,
 	if err != nil {
 		return rawIDToken
 	}
+	publishAuditEvent(r.WithContext(ctx), audit.EventTypeLogout, "user logged out", sessionState, "")
 
 	if s, _ := session.Get(ctx, state.dataBrokerClient, sessionState.ID); s != nil && s.OauthToken != nil {
 		rawIDToken = s.GetIdToken().GetRaw()
@@ -758,17 +1036,24 @@ func (a *Authenticate) getWebauthnState(ctx context.Context) (*webauthn.State, e
 		InternalAuthenticateURL: internalAuthenticateURL,
 		SharedKey:               state.sharedKey,
 		Client:                  state.dataBrokerClient,
+		MaxDevicesPerUser:       a.options.Load().MaxDevicesPerUser,
 		PomeriumDomains:         pomeriumDomains,
 		Session:                 s,
 		SessionState:            ss,
 		SessionStore:            state.sessionStore,
 		RelyingParty:            state.webauthnRelyingParty,
+
+		AcceptedSharedKeys: state.acceptedSharedKeys,
 	}, nil
 }
 
 // Callback handles the result of a successful call to the authenticate service
 // and is responsible setting per-route sessions.
 func (a *Authenticate) Callback(w http.ResponseWriter, r *http.Request) error {
+	ctx, span := trace.StartSpan(r.Context(), "authenticate.Callback")
+	defer span.End()
+	r = r.WithContext(ctx)
+
 	redirectURLString := r.FormValue(urlutil.QueryRedirectURI)
 	encryptedSession := r.FormValue(urlutil.QuerySessionEncrypted)
 
@@ -803,7 +1088,7 @@ func (a *Authenticate) saveCallbackSession(w http.ResponseWriter, r *http.Reques
 		return nil, fmt.Errorf("proxy: malfromed callback token: %w", err)
 	}
 	// 2. decrypt the JWT using the cipher using the _shared_ secret key
-	rawJWT, err := cryptutil.Decrypt(state.sharedCipher, encryptedJWT, nil)
+	rawJWT, err := cryptutil.DecryptWithAny(state.acceptedSharedCiphers, encryptedJWT, nil)
 	if err != nil {
 		return nil, fmt.Errorf("proxy: callback token decrypt error: %w", err)
 	}
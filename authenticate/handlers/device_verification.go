@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/ui"
+)
+
+// DeviceVerificationData is the data for the DeviceVerification page.
+type DeviceVerificationData struct {
+	UserCode string
+	Error    string
+	// Result is set to "approved" or "denied" once the form is submitted.
+	Result string
+}
+
+// ToJSON converts the data into a JSON map.
+func (data DeviceVerificationData) ToJSON() map[string]interface{} {
+	m := map[string]interface{}{
+		"userCode": data.UserCode,
+	}
+	if data.Error != "" {
+		m["error"] = data.Error
+	}
+	if data.Result != "" {
+		m["result"] = data.Result
+	}
+	return m
+}
+
+// DeviceVerification returns a handler that renders the device verification page.
+func DeviceVerification(data DeviceVerificationData) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return ui.ServePage(w, r, "DeviceVerification", data.ToJSON())
+	})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/ui"
+)
+
+// ImpersonationData is the data for the Impersonation page.
+type ImpersonationData struct {
+	// SelfSessionID is the id of the session of the signed in user.
+	SelfSessionID        string
+	ImpersonateSessionID string
+	Reason               string
+	Duration             string
+	// SessionID is the id of the session which requested the impersonation to approve.
+	SessionID string
+	Error     string
+	// Result is set to "requested" or "approved" once the form is submitted.
+	Result    string
+	ExpiresAt string
+}
+
+// ToJSON converts the data into a JSON map.
+func (data ImpersonationData) ToJSON() map[string]interface{} {
+	m := map[string]interface{}{
+		"selfSessionId":        data.SelfSessionID,
+		"impersonateSessionId": data.ImpersonateSessionID,
+		"reason":               data.Reason,
+		"duration":             data.Duration,
+		"sessionId":            data.SessionID,
+	}
+	if data.Error != "" {
+		m["error"] = data.Error
+	}
+	if data.Result != "" {
+		m["result"] = data.Result
+	}
+	if data.ExpiresAt != "" {
+		m["expiresAt"] = data.ExpiresAt
+	}
+	return m
+}
+
+// Impersonation returns a handler that renders the impersonation page.
+func Impersonation(data ImpersonationData) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return ui.ServePage(w, r, "Impersonation", data.ToJSON())
+	})
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/ui"
+)
+
+// LDAPSignInData is the data for the LDAPSignIn page.
+type LDAPSignInData struct {
+	State string
+	Error string
+}
+
+// ToJSON converts the data into a JSON map.
+func (data LDAPSignInData) ToJSON() map[string]interface{} {
+	m := map[string]interface{}{
+		"state": data.State,
+	}
+	if data.Error != "" {
+		m["error"] = data.Error
+	}
+	return m
+}
+
+// LDAPSignIn returns a handler that renders the LDAP sign in page.
+func LDAPSignIn(data LDAPSignInData) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return ui.ServePage(w, r, "LDAPSignIn", data.ToJSON())
+	})
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/ui"
+	"github.com/pomerium/webauthn"
+)
+
+// PasskeySignInData is the data for the PasskeySignIn page.
+type PasskeySignInData struct {
+	// PasskeyURL is the url the passkey assertion is posted to.
+	PasskeyURL     string
+	RequestOptions *webauthn.PublicKeyCredentialRequestOptions
+	// SignInURL is the identity provider sign in url.
+	SignInURL string
+}
+
+// ToJSON converts the data into a JSON map.
+func (data PasskeySignInData) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"passkeyUrl":     data.PasskeyURL,
+		"requestOptions": data.RequestOptions,
+		"signInUrl":      data.SignInURL,
+	}
+}
+
+// PasskeySignIn returns a handler that renders the passkey sign in page.
+func PasskeySignIn(data PasskeySignInData) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return ui.ServePage(w, r, "PasskeySignIn", data.ToJSON())
+	})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/ui"
+)
+
+// An IdentityProviderChoice is an identity provider the user may sign in with.
+type IdentityProviderChoice struct {
+	Name string
+	URL  string
+}
+
+// SelectIdentityProviderData is the data for the SelectIdentityProvider page.
+type SelectIdentityProviderData struct {
+	IdentityProviders []IdentityProviderChoice
+}
+
+// ToJSON converts the data into a JSON map.
+func (data SelectIdentityProviderData) ToJSON() map[string]interface{} {
+	idps := make([]interface{}, 0, len(data.IdentityProviders))
+	for _, idp := range data.IdentityProviders {
+		idps = append(idps, map[string]interface{}{
+			"name": idp.Name,
+			"url":  idp.URL,
+		})
+	}
+	return map[string]interface{}{
+		"identityProviders": idps,
+	}
+}
+
+// SelectIdentityProvider returns a handler that renders the select identity provider page.
+func SelectIdentityProvider(data SelectIdentityProviderData) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return ui.ServePage(w, r, "SelectIdentityProvider", data.ToJSON())
+	})
+}
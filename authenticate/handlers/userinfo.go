@@ -8,6 +8,7 @@ import (
 
 	"github.com/pomerium/pomerium/internal/directory"
 	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 	"github.com/pomerium/pomerium/ui"
@@ -16,22 +17,40 @@ import (
 
 // UserInfoData is the data for the UserInfo page.
 type UserInfoData struct {
-	CSRFToken       string
-	DirectoryGroups []*directory.Group
-	DirectoryUser   *directory.User
-	IsImpersonated  bool
-	Session         *session.Session
-	User            *user.User
+	CSRFToken         string
+	DeviceCredentials []*device.Credential
+	DirectoryGroups   []*directory.Group
+	DirectoryUser     *directory.User
+	IsImpersonated    bool
+	Session           *session.Session
+	User              *user.User
 
 	WebAuthnCreationOptions *webauthn.PublicKeyCredentialCreationOptions
 	WebAuthnRequestOptions  *webauthn.PublicKeyCredentialRequestOptions
 	WebAuthnURL             string
+
+	// passkey registration is only available when passkey sign in is enabled
+	PasskeyCreationOptions *webauthn.PublicKeyCredentialCreationOptions
+	PasskeyURL             string
 }
 
 // ToJSON converts the data into a JSON map.
 func (data UserInfoData) ToJSON() map[string]interface{} {
 	m := map[string]interface{}{}
 	m["csrfToken"] = data.CSRFToken
+	var deviceCredentials []json.RawMessage
+	for _, deviceCredential := range data.DeviceCredentials {
+		// only include the fields needed to display the credential
+		if bs, err := protojson.Marshal(&device.Credential{
+			Id:         deviceCredential.GetId(),
+			TypeId:     deviceCredential.GetTypeId(),
+			Name:       deviceCredential.GetName(),
+			LastUsedAt: deviceCredential.GetLastUsedAt(),
+		}); err == nil {
+			deviceCredentials = append(deviceCredentials, json.RawMessage(bs))
+		}
+	}
+	m["deviceCredentials"] = deviceCredentials
 	var directoryGroups []json.RawMessage
 	for _, directoryGroup := range data.DirectoryGroups {
 		if bs, err := protojson.Marshal(directoryGroup); err == nil {
@@ -52,6 +71,10 @@ func (data UserInfoData) ToJSON() map[string]interface{} {
 	m["webAuthnCreationOptions"] = data.WebAuthnCreationOptions
 	m["webAuthnRequestOptions"] = data.WebAuthnRequestOptions
 	m["webAuthnUrl"] = data.WebAuthnURL
+	if data.PasskeyCreationOptions != nil {
+		m["passkeyCreationOptions"] = data.PasskeyCreationOptions
+		m["passkeyUrl"] = data.PasskeyURL
+	}
 	return m
 }
 
@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -32,7 +33,7 @@ import (
 	"github.com/pomerium/webauthn"
 )
 
-const maxAuthenticateResponses = 5
+const maxDeviceCredentialNameLength = 100
 
 var (
 	errMissingDeviceCredentialID = httputil.NewError(http.StatusBadRequest, errors.New(
@@ -43,6 +44,8 @@ var (
 		urlutil.QueryRedirectURI+" is a required parameter"))
 	errInvalidDeviceCredential = httputil.NewError(http.StatusBadRequest, errors.New(
 		"invalid device credential"))
+	errDeviceLimitReached = httputil.NewError(http.StatusForbidden, errors.New(
+		"the maximum number of devices has been registered"))
 )
 
 // State is the state needed by the Handler to handle requests.
@@ -50,12 +53,15 @@ type State struct {
 	AuthenticateURL         *url.URL
 	InternalAuthenticateURL *url.URL
 	Client                  databroker.DataBrokerServiceClient
+	MaxDevicesPerUser       uint32
 	PomeriumDomains         []string
 	RelyingParty            *webauthn.RelyingParty
 	Session                 *session.Session
 	SessionState            *sessions.State
 	SessionStore            sessions.SessionStore
 	SharedKey               []byte
+	// AcceptedSharedKeys are the shared keys accepted for signed URLs, the SharedKey first.
+	AcceptedSharedKeys [][]byte
 }
 
 // A StateProvider provides state for the handler.
@@ -78,13 +84,23 @@ func (h *Handler) GetOptions(ctx context.Context) (
 	creationOptions *webauthn.PublicKeyCredentialCreationOptions,
 	requestOptions *webauthn.PublicKeyCredentialRequestOptions,
 	err error,
+) {
+	return h.GetOptionsForDeviceType(ctx, webauthnutil.DefaultDeviceType)
+}
+
+// GetOptionsForDeviceType returns the creation and request options for WebAuthn for the given
+// device type.
+func (h *Handler) GetOptionsForDeviceType(ctx context.Context, deviceTypeID string) (
+	creationOptions *webauthn.PublicKeyCredentialCreationOptions,
+	requestOptions *webauthn.PublicKeyCredentialRequestOptions,
+	err error,
 ) {
 	state, err := h.getState(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return h.getOptions(ctx, state, webauthnutil.DefaultDeviceType)
+	return h.getOptions(ctx, state, deviceTypeID)
 }
 
 // ServeHTTP serves the HTTP handler.
@@ -125,7 +141,7 @@ func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 
 	err = middleware.ValidateRequestURL(
 		urlutil.GetExternalRequest(s.InternalAuthenticateURL, s.AuthenticateURL, r),
-		s.SharedKey,
+		s.AcceptedSharedKeys...,
 	)
 	if err != nil {
 		return err
@@ -140,6 +156,8 @@ func (h *Handler) handle(w http.ResponseWriter, r *http.Request) error {
 		return h.handleRegister(w, r, s)
 	case r.FormValue("action") == "unregister":
 		return h.handleUnregister(w, r, s)
+	case r.FormValue("action") == "rename":
+		return h.handleRename(w, r, s)
 	}
 
 	return httputil.NewError(http.StatusNotFound, errors.New(http.StatusText(http.StatusNotFound)))
@@ -216,13 +234,8 @@ func (h *Handler) handleAuthenticate(w http.ResponseWriter, r *http.Request, sta
 			continue
 		}
 
-		// add the response to the list and cap it, removing the oldest responses
-		webauthnCredential.AuthenticateResponse = append(webauthnCredential.AuthenticateResponse, credentialJSON)
-		for len(webauthnCredential.AuthenticateResponse) > maxAuthenticateResponses {
-			webauthnCredential.AuthenticateResponse = webauthnCredential.AuthenticateResponse[1:]
-		}
-
 		// store the updated device credential
+		webauthnutil.AddAuthenticateResponse(deviceCredential, credentialJSON)
 		err = device.PutCredential(ctx, state.Client, deviceCredential)
 		if err != nil {
 			return err
@@ -230,13 +243,15 @@ func (h *Handler) handleAuthenticate(w http.ResponseWriter, r *http.Request, sta
 	}
 
 	// update the session
-	state.Session.DeviceCredentials = append(state.Session.DeviceCredentials, &session.Session_DeviceCredential{
-		TypeId: deviceType.GetId(),
-		Credential: &session.Session_DeviceCredential_Id{
-			Id: webauthnutil.GetDeviceCredentialID(serverCredential.ID),
-		},
+	return h.saveSessionAndRedirect(w, r, state, redirectURIParam, func(s *session.Session) error {
+		s.DeviceCredentials = append(s.DeviceCredentials, &session.Session_DeviceCredential{
+			TypeId: deviceType.GetId(),
+			Credential: &session.Session_DeviceCredential_Id{
+				Id: webauthnutil.GetDeviceCredentialID(serverCredential.ID),
+			},
+		})
+		return nil
 	})
-	return h.saveSessionAndRedirect(w, r, state, redirectURIParam)
 }
 
 func (h *Handler) handleRegister(w http.ResponseWriter, r *http.Request, state *State) error {
@@ -269,6 +284,17 @@ func (h *Handler) handleRegister(w http.ResponseWriter, r *http.Request, state *
 		return fmt.Errorf("error retrieving user record: %w", err)
 	}
 
+	// enforce the device limit
+	if state.MaxDevicesPerUser > 0 {
+		knownDeviceCredentials, err := getKnownDeviceCredentials(ctx, state.Client, u.GetDeviceCredentialIds()...)
+		if err != nil {
+			return err
+		}
+		if len(knownDeviceCredentials) >= int(state.MaxDevicesPerUser) {
+			return errDeviceLimitReached
+		}
+	}
+
 	// get the stored device type
 	deviceType := webauthnutil.GetDeviceType(ctx, state.Client, deviceTypeParam)
 
@@ -303,11 +329,17 @@ func (h *Handler) handleRegister(w http.ResponseWriter, r *http.Request, state *
 	}
 
 	// save the credential
+	name := r.FormValue("name")
+	if name == "" {
+		name = deviceType.GetName()
+	}
 	deviceCredential := &device.Credential{
-		Id:           deviceCredentialID,
-		TypeId:       deviceType.GetId(),
-		EnrollmentId: deviceEnrollment.GetId(),
-		UserId:       u.GetId(),
+		Id:                 deviceCredentialID,
+		TypeId:             deviceType.GetId(),
+		EnrollmentId:       deviceEnrollment.GetId(),
+		UserId:             u.GetId(),
+		Name:               name,
+		IdentityProviderId: state.Session.GetIdentityProviderId(),
 		Specifier: &device.Credential_Webauthn{
 			Webauthn: &device.Credential_WebAuthn{
 				Id:        serverCredential.ID,
@@ -331,14 +363,15 @@ func (h *Handler) handleRegister(w http.ResponseWriter, r *http.Request, state *
 	}
 
 	// update the session
-	state.Session.DeviceCredentials = append(state.Session.DeviceCredentials, &session.Session_DeviceCredential{
-		TypeId: deviceType.GetId(),
-		Credential: &session.Session_DeviceCredential_Id{
-			Id: webauthnutil.GetDeviceCredentialID(serverCredential.ID),
-		},
+	return h.saveSessionAndRedirect(w, r, state, redirectURIParam, func(s *session.Session) error {
+		s.DeviceCredentials = append(s.DeviceCredentials, &session.Session_DeviceCredential{
+			TypeId: deviceType.GetId(),
+			Credential: &session.Session_DeviceCredential_Id{
+				Id: webauthnutil.GetDeviceCredentialID(serverCredential.ID),
+			},
+		})
+		return nil
 	})
-
-	return h.saveSessionAndRedirect(w, r, state, redirectURIParam)
 }
 
 func (h *Handler) handleUnregister(w http.ResponseWriter, r *http.Request, state *State) error {
@@ -380,10 +413,55 @@ func (h *Handler) handleUnregister(w http.ResponseWriter, r *http.Request, state
 	}
 
 	// remove the credential from the session
-	state.Session.DeviceCredentials = removeSessionDeviceCredential(state.Session.DeviceCredentials, deviceCredentialID)
 	return h.saveSessionAndRedirect(w, r, state, urlutil.GetAbsoluteURL(r).ResolveReference(&url.URL{
 		Path: "/.pomerium",
-	}).String())
+	}).String(), func(s *session.Session) error {
+		s.DeviceCredentials = removeSessionDeviceCredential(s.DeviceCredentials, deviceCredentialID)
+		return nil
+	})
+}
+
+func (h *Handler) handleRename(w http.ResponseWriter, r *http.Request, state *State) error {
+	ctx := r.Context()
+
+	// get the user information
+	u, err := user.Get(ctx, state.Client, state.Session.GetUserId())
+	if err != nil {
+		return err
+	}
+
+	deviceCredentialID := r.FormValue(urlutil.QueryDeviceCredentialID)
+	if deviceCredentialID == "" {
+		return errMissingDeviceCredentialID
+	}
+
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		return httputil.NewError(http.StatusBadRequest, errors.New("name is a required parameter"))
+	}
+	if len(name) > maxDeviceCredentialNameLength {
+		return httputil.NewError(http.StatusBadRequest, errors.New("name is too long"))
+	}
+
+	// ensure we only allow renaming a device credential the user owns
+	if !containsString(u.GetDeviceCredentialIds(), deviceCredentialID) {
+		return errInvalidDeviceCredential
+	}
+
+	deviceCredential, err := device.GetCredential(ctx, state.Client, deviceCredentialID)
+	if err != nil {
+		return err
+	}
+	deviceCredential.Name = name
+	err = device.PutCredential(ctx, state.Client, deviceCredential)
+	if err != nil {
+		return err
+	}
+
+	httputil.Redirect(w, r, urlutil.GetAbsoluteURL(r).ResolveReference(&url.URL{
+		Path: "/.pomerium",
+	}).String(), http.StatusFound)
+	return nil
 }
 
 func (h *Handler) handleView(w http.ResponseWriter, r *http.Request, state *State) error {
@@ -406,9 +484,15 @@ func (h *Handler) handleView(w http.ResponseWriter, r *http.Request, state *Stat
 	})
 }
 
-func (h *Handler) saveSessionAndRedirect(w http.ResponseWriter, r *http.Request, state *State, rawRedirectURI string) error {
-	// save the session to the databroker
-	res, err := session.Put(r.Context(), state.Client, state.Session)
+func (h *Handler) saveSessionAndRedirect(
+	w http.ResponseWriter,
+	r *http.Request,
+	state *State,
+	rawRedirectURI string,
+	update func(s *session.Session) error,
+) error {
+	// update the session in the databroker, other fields of the session may change concurrently
+	res, err := session.Update(r.Context(), state.Client, state.Session.GetId(), update)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,8 @@ package authenticate
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,11 +14,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-jose/go-jose/v3"
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
@@ -31,9 +35,11 @@ import (
 	"github.com/pomerium/pomerium/internal/encoding/mock"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/identity/health"
 	"github.com/pomerium/pomerium/internal/identity/oidc"
 	"github.com/pomerium/pomerium/internal/sessions"
 	mstore "github.com/pomerium/pomerium/internal/sessions/mock"
+	"github.com/pomerium/pomerium/internal/signingkey"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
@@ -612,7 +618,7 @@ func TestWellKnownEndpoint(t *testing.T) {
 	rr := httptest.NewRecorder()
 	h.ServeHTTP(rr, req)
 	body := rr.Body.String()
-	expected := "{\"authentication_callback_endpoint\":\"https://auth.example.com/oauth2/callback\",\"jwks_uri\":\"https://auth.example.com/.well-known/pomerium/jwks.json\",\"frontchannel_logout_uri\":\"https://auth.example.com/.pomerium/sign_out\"}\n"
+	expected := "{\"authentication_callback_endpoint\":\"https://auth.example.com/oauth2/callback\",\"jwks_uri\":\"https://auth.example.com/.well-known/pomerium/jwks.json\",\"frontchannel_logout_uri\":\"https://auth.example.com/.pomerium/sign_out\",\"backchannel_logout_uri\":\"https://auth.example.com/oauth2/backchannel_logout\",\"device_authorization_endpoint\":\"https://auth.example.com/oauth2/device_authorization\",\"token_endpoint\":\"https://auth.example.com/oauth2/token\"}\n"
 	assert.Equal(t, body, expected)
 }
 
@@ -637,6 +643,49 @@ func TestJwksEndpoint(t *testing.T) {
 	assert.Equal(t, expected, body)
 }
 
+func TestJwksEndpoint_rotation(t *testing.T) {
+	sharedKey := cryptutil.NewKey()
+	now := time.Now()
+	active, err := signingkey.New(sharedKey, jose.ES256, now)
+	require.NoError(t, err)
+	rotated, err := signingkey.New(sharedKey, jose.EdDSA, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+	rotated.RotatedAt = timestamppb.New(now.Add(-time.Minute))
+	expired, err := signingkey.New(sharedKey, jose.ES256, now.Add(-4*time.Hour))
+	require.NoError(t, err)
+	expired.RotatedAt = timestamppb.New(now.Add(-2 * time.Hour))
+
+	var a Authenticate
+	a.state = newAtomicAuthenticateState(&authenticateState{
+		dataBrokerClient: mockDataBrokerServiceClient{
+			query: func(ctx context.Context, in *databroker.QueryRequest, opts ...grpc.CallOption) (*databroker.QueryResponse, error) {
+				return &databroker.QueryResponse{Records: []*databroker.Record{
+					databroker.NewRecord(expired),
+					databroker.NewRecord(rotated),
+					databroker.NewRecord(active),
+				}}, nil
+			},
+		},
+	})
+	a.options = config.NewAtomicOptions()
+	a.options.Store(&config.Options{
+		SigningKeyRotationInterval: 24 * time.Hour,
+		SigningKeyRotationOverlap:  time.Hour,
+	})
+
+	w := httptest.NewRecorder()
+	err = a.jwks(w, httptest.NewRequest("GET", "/.well-known/pomerium/jwks.json", nil))
+	require.NoError(t, err)
+
+	var jwks jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+	if assert.Len(t, jwks.Keys, 2) {
+		assert.Equal(t, active.GetId(), jwks.Keys[0].KeyID)
+		assert.Equal(t, rotated.GetId(), jwks.Keys[1].KeyID)
+		assert.Equal(t, "EdDSA", jwks.Keys[1].Algorithm)
+	}
+}
+
 func TestAuthenticate_userInfo(t *testing.T) {
 	t.Parallel()
 
@@ -754,8 +803,9 @@ func TestAuthenticate_userInfo(t *testing.T) {
 type mockDataBrokerServiceClient struct {
 	databroker.DataBrokerServiceClient
 
-	get func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error)
-	put func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error)
+	get   func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error)
+	put   func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error)
+	query func(ctx context.Context, in *databroker.QueryRequest, opts ...grpc.CallOption) (*databroker.QueryResponse, error)
 }
 
 func (m mockDataBrokerServiceClient) Get(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
@@ -766,6 +816,10 @@ func (m mockDataBrokerServiceClient) Put(ctx context.Context, in *databroker.Put
 	return m.put(ctx, in, opts...)
 }
 
+func (m mockDataBrokerServiceClient) Query(ctx context.Context, in *databroker.QueryRequest, opts ...grpc.CallOption) (*databroker.QueryResponse, error) {
+	return m.query(ctx, in, opts...)
+}
+
 type mockDirectoryServiceClient struct {
 	directory.DirectoryServiceClient
 
@@ -786,3 +840,107 @@ func mustParseURL(rawurl string) *url.URL {
 	}
 	return u
 }
+
+func TestIsRequestSessionIssuedAfter(t *testing.T) {
+	s := &session.Session{IssuedAt: timestamppb.New(time.Unix(1000, 500))}
+	for _, tc := range []struct {
+		query  string
+		expect bool
+	}{
+		{"", true},
+		{"pomerium_session_issued_after=999", true},
+		{"pomerium_session_issued_after=1000", false},
+		{"pomerium_session_issued_after=1001", false},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/?"+tc.query, nil)
+		assert.Equal(t, tc.expect, isRequestSessionIssuedAfter(r, s), tc.query)
+	}
+}
+
+func TestSetSignInURLPrompt(t *testing.T) {
+	signinURL, err := setSignInURLPrompt("https://idp.example.com/authorize?client_id=CLIENT_ID&prompt=consent", "login")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/authorize?client_id=CLIENT_ID&prompt=login", signinURL)
+}
+
+func TestAuthenticate_getIdentityProviderFailoverURL(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	to, err := config.ParseWeightedUrls("https://to.example.com")
+	require.NoError(t, err)
+
+	a := testAuthenticate()
+	a.health = health.New()
+	opts := &config.Options{
+		SharedKey:   cryptutil.NewBase64Key(),
+		Provider:    "oidc",
+		ProviderURL: down.URL,
+		IdentityProviders: map[string]config.IdentityProvider{
+			"backup": {Provider: "oidc", ProviderURL: "https://backup.example.com"},
+		},
+		IdentityProviderFailover: "backup",
+		Policies: []config.Policy{
+			{From: "https://app.example.com", To: to, AllowIdentityProviderFailover: true},
+			{From: "https://other.example.com", To: to},
+		},
+	}
+	for i := range opts.Policies {
+		require.NoError(t, opts.Policies[i].Validate())
+	}
+	a.options.Store(opts)
+	primary := opts.GetIdentityProviderForPolicy(nil)
+	failover, _ := opts.GetIdentityProviderFailover()
+
+	signInURL := func(idpID, redirectURI string) string {
+		return "/.pomerium/sign_in?" + url.Values{
+			urlutil.QueryIdentityProviderID: {idpID},
+			urlutil.QueryRedirectURI:        {redirectURI},
+		}.Encode()
+	}
+	r := httptest.NewRequest(http.MethodGet, signInURL(primary.GetId(), "https://app.example.com/"), nil)
+	_, ok := a.getIdentityProviderFailoverURL(r)
+	assert.False(t, ok, "should not fail over when health checks are disabled")
+
+	a.health.UpdateConfig(time.Minute, opts.GetAllIdentityProviders())
+	go func() { _ = a.health.Run(ctx) }()
+	assert.Eventually(t, func() bool {
+		return !a.health.IsHealthy(primary.GetId())
+	}, time.Second*5, time.Millisecond*10)
+
+	u, ok := a.getIdentityProviderFailoverURL(r)
+	if assert.True(t, ok) {
+		assert.Equal(t, failover.GetId(), u.Query().Get(urlutil.QueryIdentityProviderID))
+		assert.NoError(t, urlutil.NewSignedURL(a.state.Load().sharedKey, u).Validate())
+	}
+
+	r = httptest.NewRequest(http.MethodGet, signInURL(primary.GetId(), "https://other.example.com/"), nil)
+	_, ok = a.getIdentityProviderFailoverURL(r)
+	assert.False(t, ok, "should not fail over for routes which don't allow it")
+
+	r = httptest.NewRequest(http.MethodGet, signInURL(failover.GetId(), "https://app.example.com/"), nil)
+	_, ok = a.getIdentityProviderFailoverURL(r)
+	assert.False(t, ok, "should not fail over from the failover identity provider")
+}
+
+func TestGetClientCertificateFingerprint(t *testing.T) {
+	cert, err := cryptutil.GenerateSelfSignedCertificate("client.example.com")
+	require.NoError(t, err)
+	rawCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}))
+	fingerprint, err := session.GetClientCertificateFingerprint(rawCert)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/oauth2/callback", nil)
+	assert.Empty(t, getClientCertificateFingerprint(r))
+
+	r.Header.Set(httputil.HeaderPomeriumClientCertificate, url.QueryEscape(rawCert))
+	assert.Equal(t, fingerprint, getClientCertificateFingerprint(r))
+
+	r.Header.Set(httputil.HeaderPomeriumClientCertificate, "INVALID")
+	assert.Empty(t, getClientCertificateFingerprint(r))
+}
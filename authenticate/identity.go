@@ -1,9 +1,12 @@
 package authenticate
 
 import (
+	"net/http"
+
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/identity"
 	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/urlutil"
 )
 
@@ -29,5 +32,88 @@ func defaultGetIdentityProvider(options *config.Options, idpID string) (identity
 		Scopes:          idp.GetScopes(),
 		ServiceAccount:  idp.GetServiceAccount(),
 		AuthCodeOptions: idp.GetRequestParams(),
+
+		SAMLCertificate:      idp.GetSamlCertificate(),
+		SAMLKey:              idp.GetSamlKey(),
+		SAMLAttributeMapping: idp.GetSamlAttributeMapping(),
+
+		ClaimsMapping: idp.GetClaimsMapping(),
 	})
 }
+
+// getRequestIdentityProviderIDs returns the ids of the identity providers which may be used
+// for the request. The first id is the default choice.
+func getRequestIdentityProviderIDs(r *http.Request) []string {
+	idpIDs := []string{r.FormValue(urlutil.QueryIdentityProviderID)}
+	for _, idpID := range r.Form[urlutil.QueryIdentityProviderChoices] {
+		if idpID != idpIDs[0] {
+			idpIDs = append(idpIDs, idpID)
+		}
+	}
+	return idpIDs
+}
+
+func isRequestIdentityProvider(r *http.Request, idpID string) bool {
+	for _, id := range getRequestIdentityProviderIDs(r) {
+		if id == idpID {
+			return true
+		}
+	}
+	return false
+}
+
+// identityProviderSessionStore stores the sessions of named identity providers in separate
+// cookies, so a user can be signed in with several identity providers at the same time.
+type identityProviderSessionStore struct {
+	defaultStore sessions.SessionStore
+	// stores are keyed by identity provider id
+	stores map[string]sessions.SessionStore
+}
+
+func (s *identityProviderSessionStore) getStore(idpID string) sessions.SessionStore {
+	if store, ok := s.stores[idpID]; ok {
+		return store
+	}
+	return s.defaultStore
+}
+
+func (s *identityProviderSessionStore) getRequestStores(r *http.Request) []sessions.SessionStore {
+	var stores []sessions.SessionStore
+	seen := map[sessions.SessionStore]bool{}
+	for _, idpID := range getRequestIdentityProviderIDs(r) {
+		store := s.getStore(idpID)
+		if !seen[store] {
+			seen[store] = true
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+// LoadSession loads the session for the first identity provider of the request with a session.
+func (s *identityProviderSessionStore) LoadSession(r *http.Request) (string, error) {
+	err := sessions.ErrNoSessionFound
+	for _, store := range s.getRequestStores(r) {
+		var raw string
+		raw, err = store.LoadSession(r)
+		if err == nil {
+			return raw, nil
+		}
+	}
+	return "", err
+}
+
+// ClearSession clears the sessions for the identity providers of the request.
+func (s *identityProviderSessionStore) ClearSession(w http.ResponseWriter, r *http.Request) {
+	for _, store := range s.getRequestStores(r) {
+		store.ClearSession(w, r)
+	}
+}
+
+// SaveSession saves the session using the store for the session's identity provider.
+func (s *identityProviderSessionStore) SaveSession(w http.ResponseWriter, r *http.Request, x interface{}) error {
+	if state, ok := x.(*sessions.State); ok {
+		return s.getStore(state.IdentityProviderID).SaveSession(w, r, x)
+	}
+	return s.defaultStore.SaveSession(w, r, x)
+}
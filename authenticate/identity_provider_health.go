@@ -0,0 +1,57 @@
+package authenticate
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
+
+// publishIdentityProviderHealth stores the health of the identity providers in the databroker
+// after they're checked, so authorize only accepts sessions from the failover identity provider
+// while the identity provider of a route is unhealthy.
+func (a *Authenticate) publishIdentityProviderHealth(ctx context.Context) {
+	options := a.options.Load()
+	failover, ok := options.GetIdentityProviderFailover()
+	if !ok {
+		return
+	}
+
+	now := timestamppb.Now()
+	var records []*databroker.Record
+	for _, idp := range options.GetAllIdentityProviders() {
+		if idp.GetId() == failover.GetId() {
+			continue
+		}
+		records = append(records, databroker.NewRecord(&identity.ProviderHealth{
+			Id:        idp.GetId(),
+			Healthy:   a.health.IsHealthy(idp.GetId()),
+			CheckedAt: now,
+		}))
+	}
+
+	_, err := a.state.Load().dataBrokerClient.Put(ctx, &databroker.PutRequest{Records: records})
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("authenticate: error storing identity provider health")
+	}
+}
+
+// isIdentityProviderFailoverAllowed returns true if the route of the redirect url allows sessions
+// from the failover identity provider.
+func isIdentityProviderFailoverAllowed(options *config.Options, rawRedirectURL string) bool {
+	redirectURL, err := urlutil.ParseAndValidateURL(rawRedirectURL)
+	if err != nil {
+		return false
+	}
+	for _, policy := range options.GetAllPolicies() {
+		if policy.Matches(*redirectURL) {
+			return policy.AllowIdentityProviderFailover
+		}
+	}
+	return false
+}
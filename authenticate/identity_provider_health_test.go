@@ -0,0 +1,50 @@
+package authenticate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/identity/health"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
+
+func TestAuthenticate_publishIdentityProviderHealth(t *testing.T) {
+	var put []*identity.ProviderHealth
+	a := testAuthenticate()
+	a.health = health.New()
+	a.state.Load().dataBrokerClient = mockDataBrokerServiceClient{
+		put: func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
+			for _, record := range in.GetRecords() {
+				var h identity.ProviderHealth
+				require.NoError(t, record.GetData().UnmarshalTo(&h))
+				put = append(put, &h)
+			}
+			return new(databroker.PutResponse), nil
+		},
+	}
+	opts := &config.Options{
+		Provider:    "oidc",
+		ProviderURL: "https://idp.example.com",
+		IdentityProviders: map[string]config.IdentityProvider{
+			"backup": {Provider: "oidc", ProviderURL: "https://backup.example.com"},
+		},
+	}
+	a.options.Store(opts)
+
+	a.publishIdentityProviderHealth(context.Background())
+	assert.Empty(t, put, "should not store the health without a failover identity provider")
+
+	opts.IdentityProviderFailover = "backup"
+	a.publishIdentityProviderHealth(context.Background())
+	if assert.Len(t, put, 1, "should not store the health of the failover identity provider") {
+		assert.Equal(t, opts.GetIdentityProviderForPolicy(nil).GetId(), put[0].GetId())
+		assert.True(t, put[0].GetHealthy())
+		assert.NotNil(t, put[0].GetCheckedAt())
+	}
+}
@@ -0,0 +1,76 @@
+package authenticate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/sessions/cookie"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestIdentityProviderSessionStore(t *testing.T) {
+	encoder, err := jws.NewHS256Signer(cryptutil.NewKey())
+	require.NoError(t, err)
+	newCookieStore := func(name string) sessions.SessionStore {
+		store, err := cookie.NewStore(func() cookie.Options {
+			return cookie.Options{Name: name}
+		}, encoder)
+		require.NoError(t, err)
+		return store
+	}
+	store := &identityProviderSessionStore{
+		defaultStore: newCookieStore("_pomerium"),
+		stores: map[string]sessions.SessionStore{
+			"IDP1": newCookieStore("_pomerium_idp_one"),
+			"IDP2": newCookieStore("_pomerium_idp_two"),
+		},
+	}
+
+	w := httptest.NewRecorder()
+	err = store.SaveSession(w, httptest.NewRequest(http.MethodGet, "/", nil), &sessions.State{ID: "SESSION2", IdentityProviderID: "IDP2"})
+	require.NoError(t, err)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "_pomerium_idp_two", cookies[0].Name)
+
+	load := func(rawURL string) (*sessions.State, error) {
+		r := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		for _, c := range cookies {
+			r.AddCookie(c)
+		}
+		raw, err := store.LoadSession(r)
+		if err != nil {
+			return nil, err
+		}
+		var s sessions.State
+		err = encoder.Unmarshal([]byte(raw), &s)
+		return &s, err
+	}
+
+	s, err := load("/?pomerium_idp_id=IDP2")
+	require.NoError(t, err)
+	assert.Equal(t, "SESSION2", s.ID)
+
+	s, err = load("/?pomerium_idp_id=IDP1&pomerium_idp_choices=IDP1&pomerium_idp_choices=IDP2")
+	require.NoError(t, err)
+	assert.Equal(t, "SESSION2", s.ID, "should load a session for any of the identity provider choices")
+
+	_, err = load("/?pomerium_idp_id=IDP1")
+	assert.Error(t, err)
+	_, err = load("/")
+	assert.Error(t, err, "should not use the session of a named identity provider for the default identity provider")
+}
+
+func TestIsRequestIdentityProvider(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?pomerium_idp_id=IDP1&pomerium_idp_choices=IDP1&pomerium_idp_choices=IDP2", nil)
+	assert.True(t, isRequestIdentityProvider(r, "IDP1"))
+	assert.True(t, isRequestIdentityProvider(r, "IDP2"))
+	assert.False(t, isRequestIdentityProvider(r, "IDP3"))
+	assert.False(t, isRequestIdentityProvider(r, ""))
+}
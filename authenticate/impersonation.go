@@ -0,0 +1,102 @@
+package authenticate
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/authenticate/handlers"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// ImpersonationPath is the path of the page where users request to impersonate other sessions,
+// and approve the impersonations requested by other users.
+const ImpersonationPath = "/.pomerium/impersonation"
+
+// Impersonation renders the impersonation page, and requests or approves an impersonation as
+// the signed in user when the form is submitted.
+func (a *Authenticate) Impersonation(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	state := a.state.Load()
+
+	s, err := a.getSessionFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := handlers.ImpersonationData{
+		SelfSessionID:        s.ID,
+		ImpersonateSessionID: r.FormValue("impersonate_session_id"),
+		Reason:               r.FormValue("reason"),
+		Duration:             r.FormValue("duration"),
+		SessionID:            r.FormValue("session_id"),
+	}
+	if r.Method != http.MethodPost {
+		handlers.Impersonation(data).ServeHTTP(w, r)
+		return nil
+	}
+
+	// the databroker takes the requester and the approver from the session of the caller
+	ctx = grpcutil.WithOutgoingSessionID(ctx, s.ID)
+
+	var impersonation *session.Impersonation
+	switch r.PostFormValue("action") {
+	case "request":
+		req := &session.RequestImpersonationRequest{
+			ImpersonateSessionId: data.ImpersonateSessionID,
+			Reason:               data.Reason,
+		}
+		if data.Duration != "" {
+			duration, err := time.ParseDuration(data.Duration)
+			if err != nil {
+				data.Error = "Invalid duration."
+				handlers.Impersonation(data).ServeHTTP(w, r)
+				return nil
+			}
+			req.Duration = durationpb.New(duration)
+		}
+		var res *session.RequestImpersonationResponse
+		res, err = state.impersonationClient.RequestImpersonation(ctx, req)
+		impersonation = res.GetImpersonation()
+		data.Result = "requested"
+	case "approve":
+		var res *session.ApproveImpersonationResponse
+		res, err = state.impersonationClient.ApproveImpersonation(ctx, &session.ApproveImpersonationRequest{
+			SessionId: data.SessionID,
+		})
+		impersonation = res.GetImpersonation()
+		data.Result = "approved"
+	default:
+		data.Error = "Invalid action."
+		handlers.Impersonation(data).ServeHTTP(w, r)
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.FailedPrecondition:
+		data.Result = ""
+		data.Error = status.Convert(err).Message()
+		handlers.Impersonation(data).ServeHTTP(w, r)
+		return nil
+	default:
+		return fmt.Errorf("authenticate: error updating impersonation: %w", err)
+	}
+	if impersonation.GetExpiresAt() != nil {
+		data.ExpiresAt = impersonation.GetExpiresAt().AsTime().Format(time.RFC3339)
+	}
+
+	log.Info(ctx).
+		Str("session_id", s.ID).
+		Str("impersonation_session_id", impersonation.GetId()).
+		Str("result", data.Result).
+		Msg("authenticate: impersonation")
+
+	handlers.Impersonation(data).ServeHTTP(w, r)
+	return nil
+}
@@ -0,0 +1,108 @@
+package authenticate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func TestImpersonation(t *testing.T) {
+	var requests []interface{}
+	var callers []string
+	client := mockImpersonationServiceClient{
+		requestImpersonation: func(ctx context.Context, in *session.RequestImpersonationRequest, opts ...grpc.CallOption) (*session.RequestImpersonationResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			callers = append(callers, md.Get(grpcutil.SessionIDMetadataKey)...)
+			requests = append(requests, in)
+			if in.GetImpersonateSessionId() == "MISSING" {
+				return nil, status.Error(codes.NotFound, "session not found")
+			}
+			return &session.RequestImpersonationResponse{
+				Impersonation: &session.Impersonation{Id: "SESSION_ID", ImpersonateSessionId: in.GetImpersonateSessionId()},
+			}, nil
+		},
+		approveImpersonation: func(ctx context.Context, in *session.ApproveImpersonationRequest, opts ...grpc.CallOption) (*session.ApproveImpersonationResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			callers = append(callers, md.Get(grpcutil.SessionIDMetadataKey)...)
+			requests = append(requests, in)
+			return &session.ApproveImpersonationResponse{
+				Impersonation: &session.Impersonation{Id: in.GetSessionId(), ExpiresAt: timestamppb.Now()},
+			}, nil
+		},
+	}
+
+	signer, err := jws.NewHS256Signer(nil)
+	require.NoError(t, err)
+	a := &Authenticate{
+		state: newAtomicAuthenticateState(&authenticateState{
+			sharedEncoder:       signer,
+			impersonationClient: client,
+		}),
+	}
+	jwt, err := signer.Marshal(&sessions.State{ID: "SESSION_ID"})
+	require.NoError(t, err)
+
+	submit := func(form url.Values) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, ImpersonationPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r = r.WithContext(sessions.NewContext(r.Context(), string(jwt), nil))
+		w := httptest.NewRecorder()
+		require.NoError(t, a.Impersonation(w, r))
+		return w
+	}
+
+	w := submit(url.Values{"action": {"request"}, "impersonate_session_id": {"USER"}, "duration": {"30m"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"result":"requested"`)
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "USER", requests[0].(*session.RequestImpersonationRequest).GetImpersonateSessionId())
+		assert.Equal(t, "30m0s", requests[0].(*session.RequestImpersonationRequest).GetDuration().AsDuration().String())
+	}
+
+	w = submit(url.Values{"action": {"request"}, "impersonate_session_id": {"USER"}, "duration": {"forever"}})
+	assert.Contains(t, w.Body.String(), `"error":"Invalid duration."`)
+	assert.Len(t, requests, 1, "should not request an impersonation with an invalid duration")
+
+	w = submit(url.Values{"action": {"request"}, "impersonate_session_id": {"MISSING"}})
+	assert.Contains(t, w.Body.String(), `"error":"session not found"`)
+
+	w = submit(url.Values{"action": {"approve"}, "session_id": {"ADMIN"}})
+	assert.Contains(t, w.Body.String(), `"result":"approved"`)
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, "ADMIN", requests[2].(*session.ApproveImpersonationRequest).GetSessionId())
+	}
+
+	assert.Equal(t, []string{"SESSION_ID", "SESSION_ID", "SESSION_ID"}, callers,
+		"should call the databroker as the signed in session")
+}
+
+type mockImpersonationServiceClient struct {
+	session.ImpersonationServiceClient
+
+	requestImpersonation func(ctx context.Context, in *session.RequestImpersonationRequest, opts ...grpc.CallOption) (*session.RequestImpersonationResponse, error)
+	approveImpersonation func(ctx context.Context, in *session.ApproveImpersonationRequest, opts ...grpc.CallOption) (*session.ApproveImpersonationResponse, error)
+}
+
+func (m mockImpersonationServiceClient) RequestImpersonation(ctx context.Context, in *session.RequestImpersonationRequest, opts ...grpc.CallOption) (*session.RequestImpersonationResponse, error) {
+	return m.requestImpersonation(ctx, in, opts...)
+}
+
+func (m mockImpersonationServiceClient) ApproveImpersonation(ctx context.Context, in *session.ApproveImpersonationRequest, opts ...grpc.CallOption) (*session.ApproveImpersonationResponse, error) {
+	return m.approveImpersonation(ctx, in, opts...)
+}
@@ -0,0 +1,49 @@
+package authenticate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/pomerium/pomerium/authenticate/handlers"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/ldap"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// LDAPSignIn renders the LDAP login form. The state created by SignIn is passed through the
+// form so that it can be validated by the oauth callback.
+func (a *Authenticate) LDAPSignIn(w http.ResponseWriter, r *http.Request) error {
+	state := r.FormValue("state")
+	if state == "" {
+		return httputil.NewError(http.StatusBadRequest, fmt.Errorf("missing state"))
+	}
+	handlers.LDAPSignIn(handlers.LDAPSignInData{State: state}).ServeHTTP(w, r)
+	return nil
+}
+
+// LDAPSignInSubmit verifies the credentials submitted with the LDAP login form. The
+// credentials are passed to the identity provider as the authorization code, so the rest of
+// the sign in is the same as the oauth callback. The csrf token is verified using the state.
+func (a *Authenticate) LDAPSignInSubmit(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	r.Form.Set("code", ldap.EncodeCredentials(r.PostForm.Get("username"), r.PostForm.Get("password")))
+	r.Form.Del("password")
+	r.PostForm.Del("password")
+
+	redirect, err := a.getOAuthCallback(w, r)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		log.FromRequest(r).Info().Str("username", r.PostForm.Get("username")).Msg("authenticate: invalid ldap credentials")
+		handlers.LDAPSignIn(handlers.LDAPSignInData{
+			State: r.PostForm.Get("state"),
+			Error: "Invalid username or password.",
+		}).ServeHTTP(w, r)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("authenticate.LDAPSignInSubmit: %w", err)
+	}
+	httputil.Redirect(w, r, redirect.String(), http.StatusFound)
+	return nil
+}
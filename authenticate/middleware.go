@@ -13,7 +13,7 @@ import (
 func (a *Authenticate) requireValidSignatureOnRedirect(next httputil.HandlerFunc) http.Handler {
 	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
 		if r.FormValue(urlutil.QueryRedirectURI) != "" || r.FormValue(urlutil.QueryHmacSignature) != "" {
-			err := middleware.ValidateRequestURL(a.getExternalRequest(r), a.state.Load().sharedKey)
+			err := middleware.ValidateRequestURL(a.getExternalRequest(r), a.state.Load().acceptedSharedKeys...)
 			if err != nil {
 				return httputil.NewError(http.StatusBadRequest, err)
 			}
@@ -25,7 +25,7 @@ func (a *Authenticate) requireValidSignatureOnRedirect(next httputil.HandlerFunc
 // requireValidSignature validates the pomerium_signature.
 func (a *Authenticate) requireValidSignature(next httputil.HandlerFunc) http.Handler {
 	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
-		err := middleware.ValidateRequestURL(a.getExternalRequest(r), a.state.Load().sharedKey)
+		err := middleware.ValidateRequestURL(a.getExternalRequest(r), a.state.Load().acceptedSharedKeys...)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,208 @@
+package authenticate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/authenticate/handlers"
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/webauthnutil"
+	"github.com/pomerium/webauthn"
+)
+
+// PasskeySignInPath is the path passkey sign ins are submitted to.
+const PasskeySignInPath = "/.pomerium/passkey"
+
+var errInvalidPasskey = httputil.NewError(http.StatusBadRequest, errors.New("invalid passkey"))
+
+// passkeySignIn renders a page which lets the user sign in with a passkey, or continue to the
+// identity provider.
+func (a *Authenticate) passkeySignIn(w http.ResponseWriter, r *http.Request, signInURL string) error {
+	state := a.state.Load()
+
+	deviceType := webauthnutil.GetDeviceType(r.Context(), state.dataBrokerClient, webauthnutil.PasskeyDeviceType)
+	// no credentials are allowed explicitly, so the browser offers all the user's passkeys
+	requestOptions := webauthnutil.GenerateRequestOptions(state.sharedKey, deviceType, nil)
+
+	passkeyURL := state.redirectURL.ResolveReference(&url.URL{
+		Path: PasskeySignInPath,
+		RawQuery: url.Values{
+			urlutil.QueryRedirectURI:        {state.redirectURL.ResolveReference(r.URL).String()},
+			urlutil.QueryIdentityProviderID: {r.FormValue(urlutil.QueryIdentityProviderID)},
+		}.Encode(),
+	})
+
+	handlers.PasskeySignIn(handlers.PasskeySignInData{
+		PasskeyURL:     passkeyURL.String(),
+		RequestOptions: requestOptions,
+		SignInURL:      signInURL,
+	}).ServeHTTP(w, r)
+	return nil
+}
+
+// PasskeySignIn verifies a passkey assertion and creates a new session for the user who owns
+// the passkey, then redirects back to the sign in url.
+func (a *Authenticate) PasskeySignIn(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	options := a.options.Load()
+	state := a.state.Load()
+
+	if !options.PasskeySignIn {
+		return httputil.NewError(http.StatusNotFound, errors.New("passkey sign in is not enabled"))
+	}
+
+	// only redirect back to the authenticate service, which continues the sign in
+	redirectURL, err := urlutil.ParseAndValidateURL(r.FormValue(urlutil.QueryRedirectURI))
+	if err != nil || redirectURL.Host != state.redirectURL.Host {
+		return httputil.NewError(http.StatusBadRequest, errors.New("invalid redirect uri"))
+	}
+	idpID := r.FormValue(urlutil.QueryIdentityProviderID)
+
+	var credential webauthn.PublicKeyAssertionCredential
+	err = json.Unmarshal([]byte(r.FormValue("authenticate_response")), &credential)
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, errors.New("invalid authenticate response"))
+	}
+	credentialJSON, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+
+	deviceCredential, err := device.GetCredential(ctx, state.dataBrokerClient,
+		webauthnutil.GetDeviceCredentialID(credential.RawID))
+	if status.Code(err) == codes.NotFound {
+		return errInvalidPasskey
+	} else if err != nil {
+		return fmt.Errorf("authenticate: error retrieving device credential: %w", err)
+	}
+
+	// only passkeys can be used to sign in, other device credentials only prove possession of a
+	// device by a session which is already signed in
+	if deviceCredential.GetTypeId() != webauthnutil.PasskeyDeviceType {
+		return errInvalidPasskey
+	}
+	// the user handle identifies the user when using discoverable credentials
+	if !bytes.Equal(credential.Response.UserHandle, webauthnutil.GetUserEntityID(deviceCredential.GetUserId())) {
+		return errInvalidPasskey
+	}
+	if deviceCredential.GetIdentityProviderId() != idpID {
+		return httputil.NewError(http.StatusForbidden,
+			errors.New("the passkey was not registered with this identity provider"))
+	}
+
+	u, err := user.Get(ctx, state.dataBrokerClient, deviceCredential.GetUserId())
+	if status.Code(err) == codes.NotFound {
+		return errInvalidPasskey
+	} else if err != nil {
+		return fmt.Errorf("authenticate: error retrieving user: %w", err)
+	}
+	if !containsString(u.GetDeviceCredentialIds(), deviceCredential.GetId()) {
+		return errInvalidPasskey
+	}
+
+	deviceType := webauthnutil.GetDeviceType(ctx, state.dataBrokerClient, webauthnutil.PasskeyDeviceType)
+	requestOptions, err := webauthnutil.GetRequestOptionsForCredential(
+		state.sharedKey,
+		deviceType,
+		[]*device.Credential{deviceCredential},
+		&credential,
+	)
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, fmt.Errorf("invalid request options: %w", err))
+	}
+
+	_, err = state.webauthnRelyingParty.VerifyAuthenticationCeremony(ctx, requestOptions, &credential)
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, fmt.Errorf("error verifying passkey: %w", err))
+	}
+	// challenges are stateless, so they're marked as used to prevent replaying the assertion
+	err = webauthnutil.UseChallenge(ctx, state.dataBrokerClient, requestOptions.Challenge)
+	if errors.Is(err, webauthnutil.ErrChallengeUsed) {
+		return httputil.NewError(http.StatusBadRequest, err)
+	} else if err != nil {
+		return fmt.Errorf("authenticate: error verifying passkey: %w", err)
+	}
+
+	webauthnutil.AddAuthenticateResponse(deviceCredential, credentialJSON)
+	if err := device.PutCredential(ctx, state.dataBrokerClient, deviceCredential); err != nil {
+		return fmt.Errorf("authenticate: error saving device credential: %w", err)
+	}
+
+	// the session has no oauth token, so it isn't refreshed and lasts until it expires
+	now := time.Now()
+	sessionExpiry := timestamppb.New(now.Add(options.CookieExpire))
+	s := &session.Session{
+		Id:         uuid.NewString(),
+		UserId:     u.GetId(),
+		IssuedAt:   timestamppb.New(now),
+		AccessedAt: timestamppb.New(now),
+		ExpiresAt:  sessionExpiry,
+		IdToken: &session.IDToken{
+			Issuer:    state.redirectURL.Host,
+			Subject:   u.GetId(),
+			ExpiresAt: sessionExpiry,
+			IssuedAt:  timestamppb.New(now),
+		},
+		Claims: u.GetClaims(),
+		DeviceCredentials: []*session.Session_DeviceCredential{{
+			TypeId:     deviceCredential.GetTypeId(),
+			Credential: &session.Session_DeviceCredential_Id{Id: deviceCredential.GetId()},
+		}},
+		IdentityProviderId:           idpID,
+		IpAddress:                    getClientIP(r),
+		UserAgent:                    r.UserAgent(),
+		ClientCertificateFingerprint: getClientCertificateFingerprint(r),
+	}
+	res, err := session.Put(ctx, state.dataBrokerClient, s)
+	if err != nil {
+		return fmt.Errorf("authenticate: error saving session: %w", err)
+	}
+
+	sessionState := &sessions.State{
+		ID:                 s.GetId(),
+		Subject:            u.GetId(),
+		IdentityProviderID: idpID,
+	}
+	newState := sessionState.WithNewIssuer(state.redirectURL.Hostname(), []string{state.redirectURL.Hostname()})
+	newState.DatabrokerServerVersion = res.GetServerVersion()
+	newState.DatabrokerRecordVersion = res.GetRecord().GetVersion()
+	if err := state.sessionStore.SaveSession(w, r, &newState); err != nil {
+		return httputil.NewError(http.StatusInternalServerError, fmt.Errorf("failed saving new session: %w", err))
+	}
+	publishAuditEvent(r, audit.EventTypeLogin, "user logged in with a passkey", &newState, u.GetEmail())
+
+	log.Info(ctx).
+		Str("user_id", u.GetId()).
+		Str("session_id", s.GetId()).
+		Str("device_credential_id", deviceCredential.GetId()).
+		Msg("authenticate: signed in with passkey")
+
+	httputil.Redirect(w, r, redirectURL.String(), http.StatusFound)
+	return nil
+}
+
+func containsString(elements []string, value string) bool {
+	for _, element := range elements {
+		if element == value {
+			return true
+		}
+	}
+	return false
+}
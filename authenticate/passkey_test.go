@@ -0,0 +1,109 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/webauthnutil"
+	"github.com/pomerium/webauthn"
+)
+
+func TestPasskeySignIn(t *testing.T) {
+	credentialID := []byte("CREDENTIAL")
+	client := mockDataBrokerServiceClient{
+		get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+			switch in.GetId() {
+			case webauthnutil.GetDeviceCredentialID(credentialID):
+				return &databroker.GetResponse{Record: databroker.NewRecord(&device.Credential{
+					Id:                 in.GetId(),
+					TypeId:             webauthnutil.PasskeyDeviceType,
+					UserId:             "USER_ID",
+					IdentityProviderId: "IDP1",
+				})}, nil
+			case webauthnutil.GetDeviceCredentialID([]byte("SECURITY_KEY")):
+				return &databroker.GetResponse{Record: databroker.NewRecord(&device.Credential{
+					Id:                 in.GetId(),
+					TypeId:             webauthnutil.DefaultDeviceType,
+					UserId:             "USER_ID",
+					IdentityProviderId: "IDP1",
+				})}, nil
+			}
+			return nil, status.Error(codes.NotFound, "not found")
+		},
+	}
+
+	newRequest := func(passkeySignIn bool, redirectURI, idpID string, credential *webauthn.PublicKeyAssertionCredential) error {
+		o := config.NewAtomicOptions()
+		o.Store(&config.Options{PasskeySignIn: passkeySignIn})
+		a := &Authenticate{
+			options: o,
+			state: newAtomicAuthenticateState(&authenticateState{
+				redirectURL:      mustParseURL("https://authenticate.example.com"),
+				dataBrokerClient: client,
+			}),
+		}
+
+		bs, err := json.Marshal(credential)
+		require.NoError(t, err)
+		form := url.Values{
+			"pomerium_redirect_uri": {redirectURI},
+			"pomerium_idp_id":       {idpID},
+			"authenticate_response": {string(bs)},
+		}
+		r := httptest.NewRequest(http.MethodPost, PasskeySignInPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return a.PasskeySignIn(httptest.NewRecorder(), r)
+	}
+	getStatus := func(err error) int {
+		var httpErr *httputil.HTTPError
+		if assert.ErrorAs(t, err, &httpErr) {
+			return httpErr.Status
+		}
+		return 0
+	}
+
+	credential := &webauthn.PublicKeyAssertionCredential{
+		RawID: credentialID,
+		Response: webauthn.AuthenticatorAssertionResponse{
+			UserHandle: webauthnutil.GetUserEntityID("USER_ID"),
+		},
+	}
+	signInURL := "https://authenticate.example.com/.pomerium/sign_in"
+
+	assert.Equal(t, http.StatusNotFound, getStatus(newRequest(false, signInURL, "IDP1", credential)),
+		"should require passkey sign in to be enabled")
+	assert.Equal(t, http.StatusBadRequest, getStatus(newRequest(true, "https://www.example.com", "IDP1", credential)),
+		"should only redirect to the authenticate service")
+	assert.Equal(t, http.StatusBadRequest, getStatus(newRequest(true, signInURL, "IDP1", &webauthn.PublicKeyAssertionCredential{
+		RawID: []byte("OTHER"),
+	})), "should reject unknown credentials")
+	assert.Equal(t, http.StatusBadRequest, getStatus(newRequest(true, signInURL, "IDP1", &webauthn.PublicKeyAssertionCredential{
+		RawID: credentialID,
+		Response: webauthn.AuthenticatorAssertionResponse{
+			UserHandle: webauthnutil.GetUserEntityID("OTHER_USER_ID"),
+		},
+	})), "should reject credentials for other users")
+	assert.Equal(t, http.StatusBadRequest, getStatus(newRequest(true, signInURL, "IDP1", &webauthn.PublicKeyAssertionCredential{
+		RawID: []byte("SECURITY_KEY"),
+		Response: webauthn.AuthenticatorAssertionResponse{
+			UserHandle: webauthnutil.GetUserEntityID("USER_ID"),
+		},
+	})), "should reject device credentials which aren't passkeys")
+	assert.Equal(t, http.StatusForbidden, getStatus(newRequest(true, signInURL, "IDP2", credential)),
+		"should reject credentials for other identity providers")
+}
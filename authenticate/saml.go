@@ -0,0 +1,64 @@
+package authenticate
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/saml"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+// SAMLAssertionConsumerService receives a SAML response from the identity provider using
+// the HTTP-POST binding. The response and the relay state are redeemed like an authorization
+// code and the state of the oauth callback, so the rest of the sign in is the same as the oauth
+// callback.
+func (a *Authenticate) SAMLAssertionConsumerService(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	samlResponse := r.PostForm.Get("SAMLResponse")
+	if samlResponse == "" {
+		return httputil.NewError(http.StatusBadRequest, fmt.Errorf("identity provider returned empty SAML response"))
+	}
+	relayState := r.PostForm.Get("RelayState")
+	code, err := saml.EncodeResponse(samlResponse, relayState)
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	r.Form.Set("code", code)
+	r.Form.Set("state", relayState)
+	// used assertions are stored in the databroker so they can't be replayed
+	r = r.WithContext(saml.WithDataBrokerClient(r.Context(), a.state.Load().dataBrokerClient))
+
+	redirect, err := a.getOAuthCallback(w, r)
+	if err != nil {
+		return fmt.Errorf("authenticate.SAMLAssertionConsumerService: %w", err)
+	}
+	httputil.Redirect(w, r, redirect.String(), http.StatusFound)
+	return nil
+}
+
+// SAMLMetadata returns the SAML service provider metadata.
+func (a *Authenticate) SAMLMetadata(w http.ResponseWriter, r *http.Request) error {
+	options := a.options.Load()
+
+	idp, err := a.cfg.getIdentityProvider(options, r.FormValue(urlutil.QueryIdentityProviderID))
+	if err != nil {
+		return err
+	}
+	sp, ok := idp.(interface {
+		GetServiceProviderMetadata() ([]byte, error)
+	})
+	if !ok {
+		return httputil.NewError(http.StatusNotFound, fmt.Errorf("identity provider %s does not support SAML", idp.Name()))
+	}
+
+	metadata, err := sp.GetServiceProviderMetadata()
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(metadata)
+	return nil
+}
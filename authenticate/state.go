@@ -11,9 +11,11 @@ import (
 	"github.com/go-jose/go-jose/v3"
 
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/adminapi"
 	"github.com/pomerium/pomerium/internal/encoding"
 	"github.com/pomerium/pomerium/internal/encoding/ecjson"
 	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/scim"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/sessions/cookie"
 	"github.com/pomerium/pomerium/internal/sessions/header"
@@ -22,6 +24,7 @@ import (
 	"github.com/pomerium/pomerium/pkg/grpc"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/webauthnutil"
 	"github.com/pomerium/webauthn"
 )
@@ -35,8 +38,12 @@ type authenticateState struct {
 	sharedEncoder encoding.MarshalUnmarshaler
 	// sharedKey is the secret to encrypt and authenticate data shared between services
 	sharedKey []byte
+	// acceptedSharedKeys are the secrets accepted to authenticate data shared between services
+	acceptedSharedKeys [][]byte
 	// sharedCipher is the cipher to use to encrypt/decrypt data shared between services
 	sharedCipher cipher.AEAD
+	// acceptedSharedCiphers are the ciphers of the accepted shared keys, the sharedCipher first
+	acceptedSharedCiphers []cipher.AEAD
 	// cookieSecret is the secret to encrypt and authenticate session data
 	cookieSecret []byte
 	// cookieCipher is the cipher to use to encrypt/decrypt session data
@@ -51,10 +58,16 @@ type authenticateState struct {
 
 	jwk *jose.JSONWebKeySet
 
-	dataBrokerClient databroker.DataBrokerServiceClient
-	directoryClient  directory.DirectoryServiceClient
+	dataBrokerClient    databroker.DataBrokerServiceClient
+	directoryClient     directory.DirectoryServiceClient
+	impersonationClient session.ImpersonationServiceClient
 
 	webauthnRelyingParty *webauthn.RelyingParty
+
+	// scimHandler serves the SCIM provisioning endpoint, it is nil when SCIM is disabled
+	scimHandler *scim.Handler
+	// adminAPIHandler serves the admin API, it is nil when the admin API is disabled
+	adminAPIHandler *adminapi.Handler
 }
 
 func newAuthenticateState() *authenticateState {
@@ -89,19 +102,31 @@ func newAuthenticateStateFromConfig(cfg *config.Config) (*authenticateState, err
 		return nil, err
 	}
 
+	state.acceptedSharedKeys, err = cfg.Options.GetAcceptedSharedKeys()
+	if err != nil {
+		return nil, err
+	}
+
 	state.sharedCipher, err = cryptutil.NewAEADCipher(state.sharedKey)
 	if err != nil {
 		return nil, err
 	}
+	for _, key := range state.acceptedSharedKeys {
+		c, err := cryptutil.NewAEADCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		state.acceptedSharedCiphers = append(state.acceptedSharedCiphers, c)
+	}
 
 	// shared state encoder setup
-	state.sharedEncoder, err = jws.NewHS256Signer(state.sharedKey)
+	state.sharedEncoder, err = jws.NewHS256Signer(state.sharedKey, state.acceptedSharedKeys[1:]...)
 	if err != nil {
 		return nil, err
 	}
 
 	// private state encoder setup, used to encrypt oauth2 tokens
-	state.cookieSecret, err = base64.StdEncoding.DecodeString(cfg.Options.CookieSecret)
+	state.cookieSecret, err = cfg.Options.GetCookieSecret()
 	if err != nil {
 		return nil, err
 	}
@@ -115,18 +140,34 @@ func newAuthenticateStateFromConfig(cfg *config.Config) (*authenticateState, err
 
 	headerStore := header.NewStore(state.encryptedEncoder)
 
-	cookieStore, err := cookie.NewStore(func() cookie.Options {
-		return cookie.Options{
-			Name:     cfg.Options.CookieName,
-			Domain:   cfg.Options.CookieDomain,
-			Secure:   cfg.Options.CookieSecure,
-			HTTPOnly: cfg.Options.CookieHTTPOnly,
-			Expire:   cfg.Options.CookieExpire,
-		}
-	}, state.sharedEncoder)
+	newCookieStore := func(name string) (sessions.SessionStore, error) {
+		return cookie.NewStore(func() cookie.Options {
+			return cookie.Options{
+				Name:     name,
+				Domain:   cfg.Options.CookieDomain,
+				Secure:   cfg.Options.CookieSecure,
+				HTTPOnly: cfg.Options.CookieHTTPOnly,
+				Expire:   cfg.Options.CookieExpire,
+				Compress: cfg.Options.CookieCompression,
+			}
+		}, state.sharedEncoder)
+	}
+
+	cookieStore := &identityProviderSessionStore{
+		stores: make(map[string]sessions.SessionStore),
+	}
+	cookieStore.defaultStore, err = newCookieStore(cfg.Options.CookieName)
 	if err != nil {
 		return nil, err
 	}
+	// sessions for named identity providers are stored in separate cookies
+	for _, name := range cfg.Options.GetIdentityProviderNames() {
+		idp, _ := cfg.Options.GetIdentityProviderForName(name)
+		cookieStore.stores[idp.GetId()], err = newCookieStore(cfg.Options.CookieName + "_idp_" + name)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	state.sessionStore = cookieStore
 	state.sessionLoaders = []sessions.SessionLoader{headerStore, cookieStore}
@@ -142,6 +183,12 @@ func newAuthenticateStateFromConfig(cfg *config.Config) (*authenticateState, err
 		}
 		state.jwk.Keys = append(state.jwk.Keys, *jwk)
 	}
+	signingKeySigner, err := cfg.Options.GetSigningKeySigner(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: invalid signing key: %w", err)
+	} else if signingKeySigner != nil {
+		state.jwk.Keys = append(state.jwk.Keys, *signingKeySigner.Public())
+	}
 
 	sharedKey, err := cfg.Options.GetSharedKey()
 	if err != nil {
@@ -160,12 +207,20 @@ func newAuthenticateStateFromConfig(cfg *config.Config) (*authenticateState, err
 
 	state.dataBrokerClient = databroker.NewDataBrokerServiceClient(dataBrokerConn)
 	state.directoryClient = directory.NewDirectoryServiceClient(dataBrokerConn)
+	state.impersonationClient = session.NewImpersonationServiceClient(dataBrokerConn)
 
 	state.webauthnRelyingParty = webauthn.NewRelyingParty(
 		authenticateURL.String(),
 		webauthnutil.NewCredentialStorage(state.dataBrokerClient),
 	)
 
+	if cfg.Options.SCIMBearerToken != "" {
+		state.scimHandler = scim.New(state.dataBrokerClient, cfg.Options.SCIMBearerToken)
+	}
+	if cfg.Options.AdminAPIBearerToken != "" {
+		state.adminAPIHandler = adminapi.New(state.dataBrokerClient, cfg.Options.AdminAPIBearerToken, state.sharedEncoder)
+	}
+
 	return state, nil
 }
 
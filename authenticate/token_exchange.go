@@ -0,0 +1,263 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/signingkey"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+)
+
+// Token exchange lets upstream applications exchange the attestation JWT for a token for
+// another audience, so they can call other internal APIs on behalf of the user without
+// sharing the user's session. The applications authenticate as the client of a token exchange
+// policy, the issued token is signed with the attestation JWT signing key, and the exchanging
+// clients are recorded in the act claim.
+//
+// https://datatracker.ietf.org/doc/html/rfc8693
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	jwtTokenType           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+var errNoSigningKey = errors.New("authenticate: no signing key")
+
+func (a *Authenticate) tokenExchangeToken(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	options := a.options.Load()
+
+	if tokenType := r.PostFormValue("subject_token_type"); tokenType != jwtTokenType {
+		renderOAuthError(w, "invalid_request", fmt.Sprintf("unsupported subject token type: %q", tokenType))
+		return nil
+	}
+	if tokenType := r.PostFormValue("requested_token_type"); tokenType != "" && tokenType != jwtTokenType {
+		renderOAuthError(w, "invalid_request", fmt.Sprintf("unsupported requested token type: %q", tokenType))
+		return nil
+	}
+
+	clientID, clientSecret := getClientCredentials(r)
+	if !options.IsTokenExchangeClient(clientID, clientSecret) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="pomerium"`)
+		httputil.RenderJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"error":             "invalid_client",
+			"error_description": "invalid client credentials",
+		})
+		return nil
+	}
+
+	signingKey, err := a.getSigningKey(ctx)
+	if errors.Is(err, errNoSigningKey) {
+		renderOAuthError(w, "unsupported_grant_type", "token exchange requires a signing key")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	jwks, err := a.getJWKS(ctx)
+	if err != nil {
+		return err
+	}
+	authenticateURL, err := options.GetInternalAuthenticateURL()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	subjectToken, err := jwt.ParseSigned(r.PostFormValue("subject_token"))
+	if err != nil || len(subjectToken.Headers) != 1 {
+		renderOAuthError(w, "invalid_request", "invalid subject token")
+		return nil
+	}
+	keys := jwks.Key(subjectToken.Headers[0].KeyID)
+	if len(keys) == 0 {
+		renderOAuthError(w, "invalid_request", "invalid subject token")
+		return nil
+	}
+	var subjectClaims jwt.Claims
+	claims := map[string]interface{}{}
+	err = subjectToken.Claims(keys[0].Public(), &subjectClaims, &claims)
+	if err == nil {
+		err = subjectClaims.ValidateWithLeeway(jwt.Expected{
+			Issuer: authenticateURL.Host,
+			Time:   now,
+		}, 0)
+	}
+	if err != nil || subjectClaims.Expiry == nil {
+		renderOAuthError(w, "invalid_request", "invalid subject token")
+		return nil
+	}
+	// the session of the subject token must not have been signed out
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		renderOAuthError(w, "invalid_request", "invalid subject token")
+		return nil
+	}
+	if ok, err := a.isSessionValid(ctx, sessionID, now); err != nil {
+		return err
+	} else if !ok {
+		renderOAuthError(w, "invalid_grant", "the session of the subject token is no longer valid")
+		return nil
+	}
+
+	audience := r.PostFormValue("audience")
+	policy, ok := options.GetTokenExchangePolicy(clientID, subjectClaims.Audience, audience)
+	if !ok {
+		renderOAuthError(w, "invalid_target", fmt.Sprintf("token exchange is not allowed for audience: %q", audience))
+		return nil
+	}
+	scopes := strings.Fields(r.PostFormValue("scope"))
+	if len(scopes) == 0 {
+		scopes = policy.Scopes
+	}
+	for _, scope := range scopes {
+		if !containsString(policy.Scopes, scope) {
+			renderOAuthError(w, "invalid_scope", fmt.Sprintf("scope is not allowed: %q", scope))
+			return nil
+		}
+	}
+
+	// the issued token keeps the user claims of the subject token, and never outlives it, so
+	// tokens can't be renewed by exchanging them again
+	expiry := now.Add(policy.GetLifetime())
+	if subjectExpiry := subjectClaims.Expiry.Time(); subjectExpiry.Before(expiry) {
+		expiry = subjectExpiry
+	}
+	claims["aud"] = audience
+	claims["iat"] = now.Unix()
+	claims["exp"] = expiry.Unix()
+	claims["jti"] = uuid.NewString()
+	delete(claims, "scope")
+	if len(scopes) > 0 {
+		claims["scope"] = strings.Join(scopes, " ")
+	}
+	act := map[string]interface{}{"sub": policy.ClientID}
+	if previous, ok := claims["act"]; ok {
+		act["act"] = previous
+	}
+	claims["act"] = act
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.SignatureAlgorithm(signingKey.Algorithm),
+		Key:       signingKey,
+	}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return err
+	}
+	rawJWT, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx).
+		Str("subject", subjectClaims.Subject).
+		Str("client-id", policy.ClientID).
+		Str("from-audience", policy.FromAudience).
+		Str("to-audience", audience).
+		Strs("scopes", scopes).
+		Msg("authenticate: token exchanged")
+
+	res := map[string]interface{}{
+		"access_token":      rawJWT,
+		"issued_token_type": jwtTokenType,
+		"token_type":        "Bearer",
+		"expires_in":        int(expiry.Sub(now).Seconds()),
+	}
+	if len(scopes) > 0 {
+		res["scope"] = strings.Join(scopes, " ")
+	}
+	httputil.RenderJSON(w, http.StatusOK, res)
+	return nil
+}
+
+// isSessionValid returns true if the session, or service account, with the given id exists and
+// hasn't expired.
+func (a *Authenticate) isSessionValid(ctx context.Context, sessionID string, now time.Time) (bool, error) {
+	client := a.state.Load().dataBrokerClient
+
+	s, err := session.Get(ctx, client, sessionID)
+	if err == nil {
+		return s.GetExpiresAt().AsTime().After(now), nil
+	} else if status.Code(err) != codes.NotFound {
+		return false, err
+	}
+
+	sa, err := user.GetServiceAccount(ctx, client, sessionID)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return !sa.IsExpired(now), nil
+}
+
+// getClientCredentials returns the client id and secret of the request, from HTTP basic
+// authentication or from the form.
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-2.3.1
+func getClientCredentials(r *http.Request) (clientID, clientSecret string) {
+	if username, password, ok := r.BasicAuth(); ok {
+		// the credentials are form encoded before they're base64 encoded
+		clientID, err := url.QueryUnescape(username)
+		if err != nil {
+			return "", ""
+		}
+		clientSecret, err := url.QueryUnescape(password)
+		if err != nil {
+			return "", ""
+		}
+		return clientID, clientSecret
+	}
+	return r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+}
+
+// getSigningKey returns the private key used to sign attestation JWTs. The generated keys
+// authorize uses when no signing key is set aren't available.
+func (a *Authenticate) getSigningKey(ctx context.Context) (*jose.JSONWebKey, error) {
+	options := a.options.Load()
+	if options.SigningKeyRotationInterval > 0 {
+		keys, err := signingkey.List(ctx, a.state.Load().dataBrokerClient)
+		if err != nil {
+			return nil, err
+		}
+		active := signingkey.Active(keys, time.Now())
+		if active == nil {
+			return nil, errNoSigningKey
+		}
+		sharedKeys, err := options.GetAcceptedSharedKeys()
+		if err != nil {
+			return nil, err
+		}
+		return signingkey.PrivateJWK(sharedKeys, active)
+	}
+
+	signingKeySigner, err := options.GetSigningKeySigner(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: invalid signing key: %w", err)
+	} else if signingKeySigner != nil {
+		return cryptutil.PrivateJWKFromSigner(signingKeySigner), nil
+	}
+
+	if options.SigningKey == "" {
+		return nil, errNoSigningKey
+	}
+	decodedCert, err := base64.StdEncoding.DecodeString(options.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("authenticate: failed to decode signing key: %w", err)
+	}
+	return cryptutil.PrivateJWKFromBytes(decodedCert)
+}
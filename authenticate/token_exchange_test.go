@@ -0,0 +1,201 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func TestTokenExchangeToken(t *testing.T) {
+	o := newTestOptions(t)
+	o.TokenExchangePolicies = []config.TokenExchangePolicy{{
+		FromAudience: "app.example.com",
+		ToAudience:   "api.example.com",
+		Scopes:       []string{"read", "write"},
+		Lifetime:     time.Minute,
+		ClientID:     "app",
+		ClientSecret: "app-secret",
+	}, {
+		FromAudience: "other.example.com",
+		ToAudience:   "api.example.com",
+		ClientID:     "other",
+		ClientSecret: "other-secret",
+	}}
+	a, err := New(&config.Config{Options: o})
+	require.NoError(t, err)
+	a.options.Store(o)
+	a.state.Load().dataBrokerClient = mockDataBrokerServiceClient{
+		get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+			if in.GetType() != protoutil.GetTypeURL(new(session.Session)) || in.GetId() != "session-1" {
+				return nil, status.Error(codes.NotFound, "not found")
+			}
+			return &databroker.GetResponse{Record: &databroker.Record{
+				Type: in.GetType(),
+				Id:   in.GetId(),
+				Data: protoutil.NewAny(&session.Session{
+					Id:        "session-1",
+					UserId:    "user-1",
+					ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+				}),
+			}}, nil
+		},
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(o.SigningKey)
+	require.NoError(t, err)
+	jwk, err := cryptutil.PrivateJWKFromBytes(rawKey)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jwk}, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	signSubjectToken := func(sessionID string, expiry time.Time) string {
+		subjectToken, err := jwt.Signed(signer).Claims(map[string]interface{}{
+			"iss":   "authenticate.example",
+			"aud":   "app.example.com",
+			"sub":   "user-1",
+			"sid":   sessionID,
+			"email": "user@example.com",
+			"iat":   now.Unix(),
+			"exp":   expiry.Unix(),
+		}).CompactSerialize()
+		require.NoError(t, err)
+		return subjectToken
+	}
+	subjectToken := signSubjectToken("session-1", now.Add(2*time.Minute))
+
+	exchangeAs := func(clientID, clientSecret string, values url.Values) (int, map[string]interface{}) {
+		values.Set("grant_type", tokenExchangeGrantType)
+		values.Set("subject_token_type", jwtTokenType)
+		r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if clientID != "" {
+			r.SetBasicAuth(clientID, clientSecret)
+		}
+		w := httptest.NewRecorder()
+		require.NoError(t, a.Token(w, r))
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		return w.Code, res
+	}
+	exchange := func(values url.Values) (int, map[string]interface{}) {
+		return exchangeAs("app", "app-secret", values)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		code, res := exchange(url.Values{
+			"subject_token": {subjectToken},
+			"audience":      {"api.example.com"},
+			"scope":         {"read"},
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		assert.Equal(t, jwtTokenType, res["issued_token_type"])
+		assert.Equal(t, "Bearer", res["token_type"])
+		assert.Equal(t, float64(60), res["expires_in"])
+		assert.Equal(t, "read", res["scope"])
+
+		issued, err := jwt.ParseSigned(res["access_token"].(string))
+		require.NoError(t, err)
+		var claims map[string]interface{}
+		require.NoError(t, issued.Claims(jwk.Public(), &claims))
+		assert.Equal(t, "api.example.com", claims["aud"])
+		assert.Equal(t, "user-1", claims["sub"])
+		assert.Equal(t, "user@example.com", claims["email"])
+		assert.Equal(t, map[string]interface{}{"sub": "app"}, claims["act"])
+	})
+	t.Run("client secret post", func(t *testing.T) {
+		code, res := exchangeAs("", "", url.Values{
+			"client_id":     {"app"},
+			"client_secret": {"app-secret"},
+			"subject_token": {subjectToken},
+			"audience":      {"api.example.com"},
+		})
+		assert.Equal(t, http.StatusOK, code, res)
+	})
+	t.Run("invalid client", func(t *testing.T) {
+		for _, credentials := range [][2]string{{"", ""}, {"app", "other-secret"}, {"unknown", "app-secret"}} {
+			code, res := exchangeAs(credentials[0], credentials[1], url.Values{
+				"subject_token": {subjectToken},
+				"audience":      {"api.example.com"},
+			})
+			assert.Equal(t, http.StatusUnauthorized, code, credentials)
+			assert.Equal(t, "invalid_client", res["error"], credentials)
+		}
+	})
+	t.Run("other client", func(t *testing.T) {
+		code, res := exchangeAs("other", "other-secret", url.Values{
+			"subject_token": {subjectToken},
+			"audience":      {"api.example.com"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_target", res["error"], "should only use the policies of the client")
+	})
+	t.Run("invalid subject token", func(t *testing.T) {
+		code, res := exchange(url.Values{
+			"subject_token": {"not-a-jwt"},
+			"audience":      {"api.example.com"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_request", res["error"])
+	})
+	t.Run("subject token expiry", func(t *testing.T) {
+		expiry := now.Add(30 * time.Second)
+		code, res := exchange(url.Values{
+			"subject_token": {signSubjectToken("session-1", expiry)},
+			"audience":      {"api.example.com"},
+		})
+		require.Equal(t, http.StatusOK, code, res)
+		assert.LessOrEqual(t, res["expires_in"], float64(30))
+
+		issued, err := jwt.ParseSigned(res["access_token"].(string))
+		require.NoError(t, err)
+		var claims jwt.Claims
+		require.NoError(t, issued.Claims(jwk.Public(), &claims))
+		assert.Equal(t, expiry.Unix(), claims.Expiry.Time().Unix(), "should not outlive the subject token")
+	})
+	t.Run("signed out session", func(t *testing.T) {
+		code, res := exchange(url.Values{
+			"subject_token": {signSubjectToken("session-2", now.Add(time.Minute))},
+			"audience":      {"api.example.com"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_grant", res["error"])
+	})
+	t.Run("invalid target", func(t *testing.T) {
+		code, res := exchange(url.Values{
+			"subject_token": {subjectToken},
+			"audience":      {"other.example.com"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_target", res["error"])
+	})
+	t.Run("invalid scope", func(t *testing.T) {
+		code, res := exchange(url.Values{
+			"subject_token": {subjectToken},
+			"audience":      {"api.example.com"},
+			"scope":         {"read admin"},
+		})
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_scope", res["error"])
+	})
+}
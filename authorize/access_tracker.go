@@ -158,13 +158,13 @@ func (tracker *AccessTracker) updateSession(
 	ctx, clearTimeout := context.WithTimeout(ctx, accessTrackerUpdateTimeout)
 	defer clearTimeout()
 
-	s, err := session.Get(ctx, client, sessionID)
+	// other fields of the session, like the refresh token, may change concurrently
+	_, err := session.Update(ctx, client, sessionID, func(s *session.Session) error {
+		s.AccessedAt = timestamppb.Now()
+		return nil
+	})
 	if status.Code(err) == codes.NotFound {
 		return nil
-	} else if err != nil {
-		return err
 	}
-	s.AccessedAt = timestamppb.Now()
-	_, err = session.Put(ctx, client, s)
 	return err
 }
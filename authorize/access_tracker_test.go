@@ -140,3 +140,46 @@ func TestAccessTracker(t *testing.T) {
 			serviceAccounts["service-account-2"].GetAccessedAt().IsValid()
 	}, time.Second*10, time.Millisecond*100)
 }
+
+func TestAccessTracker_updateSession(t *testing.T) {
+	ctx := context.Background()
+
+	stored := &session.Session{Id: "session-0", OauthToken: &session.OAuthToken{RefreshToken: "refresh-token-1"}}
+	version := uint64(1)
+	puts := 0
+	client := &mockDataBrokerServiceClient{
+		get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+			return &databroker.GetResponse{
+				Record: &databroker.Record{
+					Version: version,
+					Type:    in.GetType(),
+					Id:      in.GetId(),
+					Data:    protoutil.NewAny(stored),
+				},
+			}, nil
+		},
+		put: func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
+			puts++
+			if puts == 1 {
+				// the identity manager refreshes the session concurrently
+				stored = &session.Session{Id: "session-0", OauthToken: &session.OAuthToken{RefreshToken: "refresh-token-2"}}
+				version++
+			}
+			record := in.GetRecords()[0]
+			if !in.GetCompareVersions() || record.GetVersion() != version {
+				return nil, status.Error(codes.FailedPrecondition, "record version mismatch")
+			}
+			data, _ := record.GetData().UnmarshalNew()
+			stored = data.(*session.Session)
+			version++
+			return &databroker.PutResponse{Records: []*databroker.Record{record}}, nil
+		},
+	}
+
+	tracker := NewAccessTracker(&testAccessTrackerProvider{dataBrokerServiceClient: client}, 1, time.Second)
+	assert.NoError(t, tracker.updateSession(ctx, client, "session-0"))
+	assert.Equal(t, 2, puts)
+	assert.Equal(t, "refresh-token-2", stored.GetOauthToken().GetRefreshToken(),
+		"should not write an old refresh token back")
+	assert.True(t, stored.GetAccessedAt().IsValid())
+}
@@ -0,0 +1,58 @@
+package authorize
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+)
+
+// publishAccessDeniedEvent publishes an audit event when the request is denied. Requests which
+// are redirected to sign in, or which aren't authenticated, aren't audited.
+func publishAccessDeniedEvent(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest, out *envoy_service_auth_v3.CheckResponse,
+	res *evaluator.Result, s sessionOrServiceAccount, u *user.User,
+) {
+	denied := out.GetDeniedResponse()
+	if denied == nil || res == nil {
+		return
+	}
+	code := int(denied.GetStatus().GetCode())
+	if code == http.StatusFound || code == http.StatusUnauthorized {
+		return
+	}
+
+	reasons := res.Allow.Reasons
+	if res.Deny.Value {
+		reasons = res.Deny.Reasons
+	}
+	hattrs := in.GetAttributes().GetRequest().GetHttp()
+	evt := &audit.Event{
+		Type:    audit.EventTypeAccessDenied,
+		Message: "access denied",
+		UserId:  u.GetId(),
+		Email:   u.GetEmail(),
+		Ip:      in.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress(),
+		Url:     hattrs.GetScheme() + "://" + hattrs.GetHost() + stripQueryString(hattrs.GetPath()),
+		Details: map[string]string{
+			"method":      hattrs.GetMethod(),
+			"status_code": strconv.Itoa(code),
+			"reasons":     strings.Join(reasons.Strings(), ","),
+		},
+	}
+	switch s := s.(type) {
+	case *session.Session:
+		evt.SessionId = s.GetId()
+	case *user.ServiceAccount:
+		evt.Details["service_account_id"] = s.GetId()
+	}
+	audit.Publish(ctx, evt)
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/pomerium/pomerium/authorize/internal/store"
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/revocation"
 	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc"
+	"github.com/pomerium/pomerium/pkg/grpc/crypt"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 )
 
@@ -25,6 +27,11 @@ type Authorize struct {
 	store          *store.Store
 	currentOptions *config.AtomicOptions
 	accessTracker  *AccessTracker
+	// localRateLimiter applies the local rate limits keyed by client IP address or user.
+	localRateLimiter *localRateLimiter
+	// revocationChecker checks whether client certificates are revoked. It's kept across
+	// config changes, so fetched CRLs and OCSP responses aren't lost.
+	revocationChecker *revocation.Checker
 
 	dataBrokerInitialSync chan struct{}
 
@@ -32,6 +39,9 @@ type Authorize struct {
 	// This should provide a consistent view of the data at a given server/record version and
 	// avoid partial updates.
 	stateLock sync.RWMutex
+	// signingKeys are synced from the databroker when signing keys are rotated automatically.
+	// They are guarded by the stateLock.
+	signingKeys map[string]*crypt.SigningKey
 }
 
 // New validates and creates a new Authorize service from a set of config options.
@@ -40,10 +50,16 @@ func New(cfg *config.Config) (*Authorize, error) {
 		currentOptions:        config.NewAtomicOptions(),
 		store:                 store.New(),
 		dataBrokerInitialSync: make(chan struct{}),
+		signingKeys:           map[string]*crypt.SigningKey{},
+		localRateLimiter:      newLocalRateLimiter(),
+		revocationChecker:     revocation.New(),
 	}
 	a.accessTracker = NewAccessTracker(a, accessTrackerMaxSize, accessTrackerDebouncePeriod)
 
-	state, err := newAuthorizeStateFromConfig(cfg, a.store)
+	if err := updateRevocationChecker(a.revocationChecker, cfg.Options); err != nil {
+		return nil, err
+	}
+	state, err := newAuthorizeStateFromConfig(cfg, a.store, a.revocationChecker, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +76,7 @@ func (a *Authorize) GetDataBrokerServiceClient() databroker.DataBrokerServiceCli
 // Run runs the authorize service.
 func (a *Authorize) Run(ctx context.Context) error {
 	go a.accessTracker.Run(ctx)
+	go a.revocationChecker.Run(ctx)
 	_ = grpc.WaitForReady(ctx, a.state.Load().dataBrokerClientConnection, time.Second*10)
 	return newDataBrokerSyncer(a).Run(ctx)
 }
@@ -85,8 +102,14 @@ func validateOptions(o *config.Options) error {
 	return nil
 }
 
-// newPolicyEvaluator returns an policy evaluator.
-func newPolicyEvaluator(opts *config.Options, store *store.Store) (*evaluator.Evaluator, error) {
+// newPolicyEvaluator returns an policy evaluator. The evaluators of the unchanged policies of the
+// previous policy evaluator are reused.
+func newPolicyEvaluator(
+	opts *config.Options,
+	store *store.Store,
+	revocationChecker *revocation.Checker,
+	previous *evaluator.Evaluator,
+) (*evaluator.Evaluator, error) {
 	metrics.AddPolicyCountCallback("pomerium-authorize", func() int64 {
 		return int64(len(opts.GetAllPolicies()))
 	})
@@ -104,22 +127,58 @@ func newPolicyEvaluator(opts *config.Options, store *store.Store) (*evaluator.Ev
 		return nil, fmt.Errorf("authorize: invalid authenticate url: %w", err)
 	}
 
-	return evaluator.New(ctx, store,
+	clientRevocationMode, err := revocation.ParseMode(opts.ClientRevocationMode)
+	if err != nil {
+		return nil, fmt.Errorf("authorize: invalid client revocation mode: %w", err)
+	}
+
+	signingKeySigner, err := opts.GetSigningKeySigner(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authorize: invalid signing key: %w", err)
+	}
+
+	return evaluator.New(ctx, store, previous,
 		evaluator.WithPolicies(opts.GetAllPolicies()),
+		evaluator.WithDefaultPolicy(opts.DefaultPolicy),
 		evaluator.WithClientCA(clientCA),
+		evaluator.WithRevocationChecker(revocationChecker),
+		evaluator.WithClientRevocationMode(clientRevocationMode),
 		evaluator.WithSigningKey(opts.SigningKey),
+		evaluator.WithSigningKeyAlgorithm(opts.SigningKeyAlgorithm),
+		evaluator.WithSigningKeySigner(signingKeySigner),
 		evaluator.WithAuthenticateURL(authenticateURL.String()),
 		evaluator.WithGoogleCloudServerlessAuthenticationServiceAccount(opts.GetGoogleCloudServerlessAuthenticationServiceAccount()),
 		evaluator.WithJWTClaimsHeaders(opts.JWTClaimsHeaders),
+		evaluator.WithDecisionCacheTTL(opts.AuthorizeDecisionCacheTTL),
 	)
 }
 
 // OnConfigChange updates internal structures based on config.Options
 func (a *Authorize) OnConfigChange(ctx context.Context, cfg *config.Config) {
 	a.currentOptions.Store(cfg.Options)
-	if state, err := newAuthorizeStateFromConfig(cfg, a.store); err != nil {
+	if err := updateRevocationChecker(a.revocationChecker, cfg.Options); err != nil {
+		log.Error(ctx).Err(err).Msg("authorize: error updating client certificate revocation checks")
+	}
+	// the current state is used until the new one, with the changed policies compiled, is ready
+	if state, err := newAuthorizeStateFromConfig(cfg, a.store, a.revocationChecker, a.state.Load().evaluator); err != nil {
 		log.Error(ctx).Err(err).Msg("authorize: error updating state")
 	} else {
 		a.state.Store(state)
 	}
+	a.updateSigningKey(ctx)
+}
+
+// updateRevocationChecker updates the checker for revoked client certificates from the options.
+func updateRevocationChecker(checker *revocation.Checker, opts *config.Options) error {
+	crls, err := opts.GetClientCRLs()
+	if err != nil {
+		return fmt.Errorf("authorize: invalid client CRL: %w", err)
+	}
+	checker.UpdateConfig(revocation.Config{
+		CRLs:               crls,
+		CRLURLs:            opts.ClientCRLURLs,
+		CRLRefreshInterval: opts.ClientCRLRefreshInterval,
+		OCSP:               opts.ClientOCSP,
+	})
+	return nil
 }
@@ -0,0 +1,119 @@
+package authorize
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	authorizepb "github.com/pomerium/pomerium/pkg/grpc/authorize"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+)
+
+// BatchCheck evaluates multiple requests on behalf of a session in a single call. It allows
+// trusted services to re-use Pomerium policies for their own authorization decisions. A
+// NotFound error is returned if the session doesn't exist.
+func (a *Authorize) BatchCheck(ctx context.Context, req *authorizepb.BatchCheckRequest) (*authorizepb.BatchCheckResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "authorize.grpc.BatchCheck")
+	defer span.End()
+
+	state := a.state.Load()
+	if err := grpcutil.RequireSignedJWT(ctx, state.acceptedSharedKeys...); err != nil {
+		return nil, err
+	}
+
+	// wait for the initial sync to complete so that data is available for evaluation
+	if err := a.WaitForInitialSync(ctx); err != nil {
+		return nil, err
+	}
+
+	var s sessionOrServiceAccount
+	if req.GetSessionId() != "" {
+		var err error
+		s, _, err = a.forceSync(ctx, &sessions.State{ID: req.GetSessionId()})
+		if err != nil {
+			// the checks aren't evaluated anonymously when the session can't be loaded
+			return nil, status.Errorf(codes.NotFound, "session %q: %v", req.GetSessionId(), err)
+		}
+	}
+	now := time.Now()
+	used := false
+
+	res := new(authorizepb.BatchCheckResponse)
+	for _, check := range req.GetChecks() {
+		requestURL, err := urlutil.ParseAndValidateURL(check.GetUrl())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid url %q: %v", check.GetUrl(), err)
+		}
+		method := check.GetMethod()
+		if method == "" {
+			method = http.MethodGet
+		}
+		headers := make(map[string]string, len(check.GetHeaders()))
+		for k, v := range check.GetHeaders() {
+			headers[http.CanonicalHeaderKey(k)] = v
+		}
+
+		evalReq := &evaluator.Request{
+			Policy: a.getMatchingPolicy(*requestURL),
+			HTTP:   evaluator.NewRequestHTTP(method, *requestURL, headers, "", ""),
+		}
+		useSession := s != nil
+		if sa, ok := s.(*user.ServiceAccount); ok && !isServiceAccountAllowed(sa, *requestURL, now) {
+			useSession = false
+		}
+		if useSession {
+			// checks don't include a client certificate, so routes which bind sessions to client
+			// certificates are denied
+			switch a.checkSession(ctx, evalReq.Policy, s, "", now) {
+			case sessionCheckOK:
+				evalReq.Session.ID = req.GetSessionId()
+				used = true
+			case sessionCheckRenew, sessionCheckReauthenticate:
+				// the user has to sign in again, so the check is evaluated as if they weren't signed in
+			case sessionCheckImpersonationDenied:
+				res.Results = append(res.Results, &authorizepb.BatchCheckResponse_Result{
+					Reasons: []string{criteria.ReasonUserUnauthorized},
+				})
+				continue
+			case sessionCheckClientCertificateMismatch:
+				res.Results = append(res.Results, &authorizepb.BatchCheckResponse_Result{
+					Reasons: []string{criteria.ReasonInvalidClientCertificate},
+				})
+				continue
+			}
+		}
+
+		a.stateLock.RLock()
+		evalRes, err := state.evaluator.Evaluate(ctx, evalReq)
+		a.stateLock.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+
+		result := new(authorizepb.BatchCheckResponse_Result)
+		switch {
+		case evalRes.Deny.Value:
+			result.Reasons = evalRes.Deny.Reasons.Strings()
+		case evalRes.Allow.Value:
+			result.Allow = true
+			result.Reasons = evalRes.Allow.Reasons.Strings()
+		default:
+			result.Reasons = evalRes.Allow.Reasons.Strings()
+		}
+		res.Results = append(res.Results, result)
+	}
+	if used {
+		a.trackAccess(s)
+	}
+
+	return res, nil
+}
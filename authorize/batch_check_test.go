@@ -0,0 +1,192 @@
+package authorize
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/authorize/internal/store"
+	"github.com/pomerium/pomerium/config"
+	authorizepb "github.com/pomerium/pomerium/pkg/grpc/authorize"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+)
+
+func TestAuthorize_BatchCheck(t *testing.T) {
+	maxSessionAge := time.Hour
+	opt := &config.Options{
+		AuthenticateURLString: "https://authenticate.example.com",
+		Policies: []config.Policy{
+			{
+				Source:       &config.StringURL{URL: &url.URL{Scheme: "https", Host: "a.example.com"}},
+				To:           mustParseWeightedURLs(t, "https://to.example.com"),
+				AllowedUsers: []string{"foo@example.com"},
+			},
+			{
+				Source:       &config.StringURL{URL: &url.URL{Scheme: "https", Host: "b.example.com"}},
+				To:           mustParseWeightedURLs(t, "https://to.example.com"),
+				AllowedUsers: []string{"bar@example.com"},
+			},
+			{
+				Source:        &config.StringURL{URL: &url.URL{Scheme: "https", Host: "c.example.com"}},
+				To:            mustParseWeightedURLs(t, "https://to.example.com"),
+				AllowedUsers:  []string{"foo@example.com"},
+				MaxSessionAge: &maxSessionAge,
+			},
+			{
+				Source:                         &config.StringURL{URL: &url.URL{Scheme: "https", Host: "d.example.com"}},
+				To:                             mustParseWeightedURLs(t, "https://to.example.com"),
+				AllowedUsers:                   []string{"foo@example.com"},
+				BindSessionToClientCertificate: true,
+			},
+		},
+	}
+	a := &Authorize{
+		currentOptions: config.NewAtomicOptions(),
+		state: newAtomicAuthorizeState(&authorizeState{
+			dataBrokerClient: mockDataBrokerServiceClient{
+				get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+					return nil, status.Error(codes.NotFound, "not found")
+				},
+			},
+		}),
+		dataBrokerInitialSync: make(chan struct{}),
+	}
+	a.accessTracker = NewAccessTracker(a, accessTrackerMaxSize, accessTrackerDebouncePeriod)
+	close(a.dataBrokerInitialSync)
+	a.currentOptions.Store(opt)
+	a.store = store.NewFromProtos(0,
+		&session.Session{
+			Id:       "SESSION_ID",
+			UserId:   "USER_ID",
+			IssuedAt: timestamppb.Now(),
+		},
+		&session.Session{
+			Id:       "OLD_SESSION_ID",
+			UserId:   "USER_ID",
+			IssuedAt: timestamppb.New(time.Now().Add(-2 * time.Hour)),
+		},
+		&session.Session{
+			Id:                   "IMPERSONATING_SESSION_ID",
+			UserId:               "USER_ID",
+			IssuedAt:             timestamppb.Now(),
+			ImpersonateSessionId: proto.String("OTHER_SESSION_ID"),
+		},
+		&session.Impersonation{
+			Id:                   "IMPERSONATING_SESSION_ID",
+			ImpersonateSessionId: "OTHER_SESSION_ID",
+			RequestedBy:          "USER_ID",
+			RequestedAt:          timestamppb.Now(),
+		},
+		&user.User{
+			Id:    "USER_ID",
+			Email: "foo@example.com",
+		},
+	)
+	pe, err := newPolicyEvaluator(opt, a.store, a.revocationChecker, nil)
+	require.NoError(t, err)
+	a.state.Load().evaluator = pe
+
+	t.Run("results", func(t *testing.T) {
+		res, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "SESSION_ID",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://a.example.com/some/path"},
+				{Method: "POST", Url: "https://b.example.com"},
+				{Url: "https://a.example.com", Headers: map[string]string{"x-foo": "bar"}},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 3)
+		assert.True(t, res.GetResults()[0].GetAllow())
+		assert.False(t, res.GetResults()[1].GetAllow())
+		assert.True(t, res.GetResults()[2].GetAllow())
+	})
+	t.Run("max session age", func(t *testing.T) {
+		res, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "SESSION_ID",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://c.example.com"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 1)
+		assert.True(t, res.GetResults()[0].GetAllow())
+
+		res, err = a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "OLD_SESSION_ID",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://a.example.com"},
+				{Url: "https://c.example.com"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 2)
+		assert.True(t, res.GetResults()[0].GetAllow())
+		assert.False(t, res.GetResults()[1].GetAllow(), "should evaluate the check anonymously")
+	})
+	t.Run("impersonation", func(t *testing.T) {
+		res, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "IMPERSONATING_SESSION_ID",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://a.example.com"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 1)
+		assert.False(t, res.GetResults()[0].GetAllow(), "should deny impersonation with a grant which isn't approved")
+		assert.Equal(t, []string{criteria.ReasonUserUnauthorized}, res.GetResults()[0].GetReasons())
+	})
+	t.Run("client certificate binding", func(t *testing.T) {
+		res, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "SESSION_ID",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://d.example.com"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 1)
+		assert.False(t, res.GetResults()[0].GetAllow(), "should deny routes which bind sessions to client certificates")
+		assert.Equal(t, []string{criteria.ReasonInvalidClientCertificate}, res.GetResults()[0].GetReasons())
+	})
+	t.Run("no session", func(t *testing.T) {
+		res, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://a.example.com"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 1)
+		assert.False(t, res.GetResults()[0].GetAllow())
+	})
+	t.Run("unknown session", func(t *testing.T) {
+		res, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "UNKNOWN",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "https://a.example.com"},
+			},
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, res)
+	})
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := a.BatchCheck(context.Background(), &authorizepb.BatchCheckRequest{
+			SessionId: "SESSION_ID",
+			Checks: []*authorizepb.BatchCheckRequest_Check{
+				{Url: "not a url"},
+			},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
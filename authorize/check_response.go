@@ -8,7 +8,9 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
@@ -17,22 +19,76 @@ import (
 	"github.com/tniswong/go.rfcx/rfc7231"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/telemetry/requestid"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
 	"github.com/pomerium/pomerium/pkg/policy/criteria"
 	"github.com/pomerium/pomerium/pkg/webauthnutil"
+	"github.com/pomerium/pomerium/ui"
 )
 
 func (a *Authorize) handleResultAllowed(
 	ctx context.Context,
 	in *envoy_service_auth_v3.CheckRequest,
+	request *evaluator.Request,
 	result *evaluator.Result,
 ) (*envoy_service_auth_v3.CheckResponse, error) {
-	return a.okResponse(result.Headers), nil
+	tracing := a.currentOptions.Load().TracingProvider != ""
+	signing := request.Policy != nil && request.Policy.HMACSigning != nil
+	if tracing || signing {
+		result = withClonedHeaders(result)
+	}
+	// the check's span is the parent of the upstream's spans, so requests can be traced from
+	// end to end
+	if tracing {
+		trace.SetTraceContextHeaders(ctx, result.Headers)
+	}
+	if signing {
+		err := setHMACSignatureHeader(result.Headers, request.Policy.HMACSigning, in, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := a.okResponse(result)
+	res.DynamicMetadata = getErrorPageMetadata(request.Policy)
+	return res, nil
+}
+
+// withClonedHeaders returns a copy of the result with a copy of its headers, so headers of the
+// request can be added to it. Results are shared by the requests they're cached for.
+func withClonedHeaders(result *evaluator.Result) *evaluator.Result {
+	dup := *result
+	dup.Headers = result.Headers.Clone()
+	if dup.Headers == nil {
+		dup.Headers = make(http.Header)
+	}
+	return &dup
+}
+
+// getErrorPageMetadata returns the dynamic metadata envoy uses to select the error pages of the
+// policy.
+func getErrorPageMetadata(policy *config.Policy) *structpb.Struct {
+	if policy == nil || len(policy.ErrorPages) == 0 {
+		return nil
+	}
+	routeID, err := policy.RouteID()
+	if err != nil {
+		return nil
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			config.ErrorPageRouteIDMetadataKey: structpb.NewStringValue(strconv.FormatUint(routeID, 10)),
+		},
+	}
 }
 
 func (a *Authorize) handleResultDenied(
@@ -66,23 +122,160 @@ func (a *Authorize) handleResultDenied(
 		denyStatusText = httputil.DetailsText(httputil.StatusInvalidClientCertificate)
 	}
 
+	// the deny response only replaces the response of requests denied by the policy, so the
+	// statuses of unauthorized devices, unknown routes and invalid certificates are kept
+	if denyStatusCode == http.StatusForbidden && request.Policy != nil && request.Policy.DenyResponse != nil {
+		return a.customDeniedResponse(ctx, in, request, reasons)
+	}
+	if page := request.Policy.GetErrorPage(int(denyStatusCode)); page != nil {
+		return a.errorPageResponse(ctx, request, page, int(denyStatusCode), reasons)
+	}
+
 	return a.deniedResponse(ctx, in, denyStatusCode, denyStatusText, nil)
 }
 
-func (a *Authorize) okResponse(headers http.Header) *envoy_service_auth_v3.CheckResponse {
+// maintenanceResponse returns the 503 returned for routes in maintenance mode, which is the
+// route's error page for 503s if it has one.
+func (a *Authorize) maintenanceResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	request *evaluator.Request,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	if page := request.Policy.GetErrorPage(http.StatusServiceUnavailable); page != nil {
+		return a.errorPageResponse(ctx, request, page, http.StatusServiceUnavailable, nil)
+	}
+	return a.deniedResponse(ctx, in, http.StatusServiceUnavailable, "This route is down for maintenance", nil)
+}
+
+// customDeniedResponse returns the deny response configured on the request's policy.
+func (a *Authorize) customDeniedResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	request *evaluator.Request,
+	reasons criteria.Reasons,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	denyResponse := request.Policy.DenyResponse
+	code := denyResponse.GetStatus()
+
+	body, err := denyResponse.RenderBody(a.getDeniedResponseData(ctx, request, code, reasons))
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("error executing deny response template")
+		return nil, err
+	}
+
+	hdrs := make(http.Header)
+	hdrs.Set("Content-Type", denyResponse.GetContentType())
+	hdrs.Set(httputil.HeaderPomeriumResponse, "true")
+	for k, v := range denyResponse.Headers {
+		hdrs.Set(k, v)
+	}
+
+	return customHTTPResponse(code, hdrs, body), nil
+}
+
+// errorPageResponse returns the error page configured on the request's policy for the status
+// code of the denied request.
+func (a *Authorize) errorPageResponse(
+	ctx context.Context,
+	request *evaluator.Request,
+	page *config.PolicyErrorPage,
+	code int,
+	reasons criteria.Reasons,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	body, err := page.RenderBody(a.getDeniedResponseData(ctx, request, code, reasons))
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("error executing error page template")
+		return nil, err
+	}
+
+	hdrs := make(http.Header)
+	hdrs.Set("Content-Type", page.GetContentType())
+	hdrs.Set(httputil.HeaderPomeriumResponse, "true")
+
+	return customHTTPResponse(code, hdrs, body), nil
+}
+
+// getDeniedResponseData returns the data deny response and error page templates are rendered
+// with.
+func (a *Authorize) getDeniedResponseData(
+	ctx context.Context,
+	request *evaluator.Request,
+	code int,
+	reasons criteria.Reasons,
+) map[string]interface{} {
+	return map[string]interface{}{
+		"Status":     code,
+		"StatusText": http.StatusText(code),
+		"Reasons":    reasons.Strings(),
+		"RequestID":  requestid.FromContext(ctx),
+		"URL":        request.HTTP.URL,
+		"User":       a.getDeniedResponseUser(request.Session.ID),
+	}
+}
+
+func customHTTPResponse(code int, hdrs http.Header, body string) *envoy_service_auth_v3.CheckResponse {
+	return &envoy_service_auth_v3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.PermissionDenied), Message: "Access Denied"},
+		HttpResponse: &envoy_service_auth_v3.CheckResponse_DeniedResponse{
+			DeniedResponse: &envoy_service_auth_v3.DeniedHttpResponse{
+				Status: &envoy_type_v3.HttpStatus{
+					Code: envoy_type_v3.StatusCode(code),
+				},
+				Headers: toEnvoyHeaders(hdrs),
+				Body:    body,
+			},
+		},
+	}
+}
+
+// getDeniedResponseUser returns the identity of the user associated with the given session
+// for use in a deny response template.
+func (a *Authorize) getDeniedResponseUser(sessionID string) map[string]string {
+	info := map[string]string{"ID": "", "Email": "", "Name": ""}
+	if sessionID == "" {
+		return info
+	}
+
+	s, ok := a.store.GetRecordData(grpcutil.GetTypeURL(new(session.Session)), sessionID).(*session.Session)
+	if !ok {
+		return info
+	}
+	info["ID"] = s.GetUserId()
+
+	u, ok := a.store.GetRecordData(grpcutil.GetTypeURL(new(user.User)), s.GetUserId()).(*user.User)
+	if !ok {
+		return info
+	}
+	info["Email"] = u.GetEmail()
+	info["Name"] = u.GetName()
+	return info
+}
+
+func (a *Authorize) okResponse(result *evaluator.Result) *envoy_service_auth_v3.CheckResponse {
 	var requestHeaders []*envoy_config_core_v3.HeaderValueOption
-	for k, vs := range headers {
+	for k, vs := range result.Headers {
 		requestHeaders = append(requestHeaders, mkHeader(k, strings.Join(vs, ","), false))
 	}
 	// ensure request headers are sorted by key for deterministic output
 	sort.Slice(requestHeaders, func(i, j int) bool {
 		return requestHeaders[i].Header.Key < requestHeaders[j].Header.Value
 	})
+	var responseHeaders []*envoy_config_core_v3.HeaderValueOption
+	for k, vs := range result.ResponseHeaders {
+		responseHeaders = append(responseHeaders, mkHeader(k, strings.Join(vs, ","), false))
+	}
+	for k, vs := range result.AppendResponseHeaders {
+		responseHeaders = append(responseHeaders, mkHeader(k, strings.Join(vs, ","), true))
+	}
+	sort.SliceStable(responseHeaders, func(i, j int) bool {
+		return responseHeaders[i].Header.Key < responseHeaders[j].Header.Key
+	})
 	return &envoy_service_auth_v3.CheckResponse{
 		Status: &status.Status{Code: int32(codes.OK), Message: "OK"},
 		HttpResponse: &envoy_service_auth_v3.CheckResponse_OkResponse{
 			OkResponse: &envoy_service_auth_v3.OkHttpResponse{
-				Headers: requestHeaders,
+				Headers:              requestHeaders,
+				ResponseHeadersToAdd: responseHeaders,
 			},
 		},
 	}
@@ -96,6 +289,9 @@ func (a *Authorize) deniedResponse(
 	// create a http response writer recorder
 	w := httptest.NewRecorder()
 	r := getHTTPRequestFromCheckRequest(in)
+	if branding := a.currentOptions.Load().GetBranding(r.URL); branding != nil {
+		r = r.WithContext(ui.WithBranding(r.Context(), branding.Localize(r.Header.Get("Accept-Language"))))
+	}
 
 	// build the user info / debug endpoint
 	debugEndpoint, _ := a.userInfoEndpointURL(in) // if there's an error, we just wont display it
@@ -144,6 +340,46 @@ func (a *Authorize) requireLoginResponse(
 	in *envoy_service_auth_v3.CheckRequest,
 	request *evaluator.Request,
 	isForwardAuthVerify bool,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	return a.requireSignInResponse(ctx, in, request, isForwardAuthVerify, nil)
+}
+
+// requireSessionRenewalResponse redirects to authenticate to replace a session which is too old
+// or has been idle for too long for the request's policy.
+func (a *Authorize) requireSessionRenewalResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	request *evaluator.Request,
+	s *session.Session,
+	isForwardAuthVerify bool,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	// authenticate will not reuse the session, or any older session
+	params := url.Values{}
+	params.Set(urlutil.QuerySessionIssuedAfter, strconv.FormatInt(s.GetIssuedAt().GetSeconds(), 10))
+	return a.requireSignInResponse(ctx, in, request, isForwardAuthVerify, params)
+}
+
+// requireReauthenticationResponse redirects to authenticate to replace a session, and requires
+// the user to sign in to the identity provider again.
+func (a *Authorize) requireReauthenticationResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	request *evaluator.Request,
+	s *session.Session,
+	isForwardAuthVerify bool,
+) (*envoy_service_auth_v3.CheckResponse, error) {
+	params := url.Values{}
+	params.Set(urlutil.QuerySessionIssuedAfter, strconv.FormatInt(s.GetIssuedAt().GetSeconds(), 10))
+	params.Set(urlutil.QueryPrompt, "login")
+	return a.requireSignInResponse(ctx, in, request, isForwardAuthVerify, params)
+}
+
+func (a *Authorize) requireSignInResponse(
+	ctx context.Context,
+	in *envoy_service_auth_v3.CheckRequest,
+	request *evaluator.Request,
+	isForwardAuthVerify bool,
+	params url.Values,
 ) (*envoy_service_auth_v3.CheckResponse, error) {
 	opts := a.currentOptions.Load()
 	state := a.state.Load()
@@ -167,6 +403,10 @@ func (a *Authorize) requireLoginResponse(
 
 	q.Set(urlutil.QueryRedirectURI, checkRequestURL.String())
 	q.Set(urlutil.QueryIdentityProviderID, opts.GetIdentityProviderForPolicy(request.Policy).GetId())
+	setIdentityProviderChoices(q, opts, request.Policy)
+	for k, vs := range params {
+		q[k] = vs
+	}
 	signinURL.RawQuery = q.Encode()
 	redirectTo := urlutil.NewSignedURL(state.sharedKey, signinURL).String()
 
@@ -211,6 +451,7 @@ func (a *Authorize) requireWebAuthnResponse(
 	}
 	q.Set(urlutil.QueryRedirectURI, checkRequestURL.String())
 	q.Set(urlutil.QueryIdentityProviderID, opts.GetIdentityProviderForPolicy(request.Policy).GetId())
+	setIdentityProviderChoices(q, opts, request.Policy)
 	signinURL.RawQuery = q.Encode()
 	redirectTo := urlutil.NewSignedURL(state.sharedKey, signinURL).String()
 
@@ -219,6 +460,18 @@ func (a *Authorize) requireWebAuthnResponse(
 	})
 }
 
+// setIdentityProviderChoices adds the identity providers the user may choose from when the
+// policy allows more than one.
+func setIdentityProviderChoices(q url.Values, opts *config.Options, policy *config.Policy) {
+	idps := opts.GetIdentityProvidersForPolicy(policy)
+	if len(idps) < 2 {
+		return
+	}
+	for _, idp := range idps {
+		q.Add(urlutil.QueryIdentityProviderChoices, idp.GetId())
+	}
+}
+
 func mkHeader(k, v string, shouldAppend bool) *envoy_config_core_v3.HeaderValueOption {
 	return &envoy_config_core_v3.HeaderValueOption{
 		Header: &envoy_config_core_v3.HeaderValue{
@@ -2,8 +2,10 @@ package authorize
 
 import (
 	"context"
+	"encoding/base64"
 	"net/http"
 	"net/url"
+	"strconv"
 	"testing"
 
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -19,9 +21,13 @@ import (
 	"github.com/pomerium/pomerium/authorize/internal/store"
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/internal/testutil"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
 )
 
 func TestAuthorize_okResponse(t *testing.T) {
@@ -51,7 +57,7 @@ func TestAuthorize_okResponse(t *testing.T) {
 			Email: "foo@example.com",
 		},
 	)
-	pe, err := newPolicyEvaluator(opt, a.store)
+	pe, err := newPolicyEvaluator(opt, a.store, a.revocationChecker, nil)
 	require.NoError(t, err)
 	a.state.Load().evaluator = pe
 
@@ -88,11 +94,30 @@ func TestAuthorize_okResponse(t *testing.T) {
 				Status: &status.Status{Code: 0, Message: "OK"},
 			},
 		},
+		{
+			"ok reply with response headers",
+			&evaluator.Result{
+				Allow:                 evaluator.NewRuleResult(true),
+				ResponseHeaders:       http.Header{"X-User": {"foo@example.com"}},
+				AppendResponseHeaders: http.Header{"Cache-Control": {"private"}},
+			},
+			&envoy_service_auth_v3.CheckResponse{
+				Status: &status.Status{Code: 0, Message: "OK"},
+				HttpResponse: &envoy_service_auth_v3.CheckResponse_OkResponse{
+					OkResponse: &envoy_service_auth_v3.OkHttpResponse{
+						ResponseHeadersToAdd: []*envoy_config_core_v3.HeaderValueOption{
+							mkHeader("Cache-Control", "private", true),
+							mkHeader("X-User", "foo@example.com", false),
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := a.okResponse(tc.reply.Headers)
+			got := a.okResponse(tc.reply)
 			assert.Equal(t, tc.want.Status.Code, got.Status.Code)
 			assert.Equal(t, tc.want.Status.Message, got.Status.Message)
 			want, _ := protojson.Marshal(tc.want.GetOkResponse())
@@ -158,6 +183,196 @@ func TestAuthorize_deniedResponse(t *testing.T) {
 	}
 }
 
+func TestAuthorize_customDeniedResponse(t *testing.T) {
+	a := &Authorize{currentOptions: config.NewAtomicOptions(), state: newAtomicAuthorizeState(new(authorizeState))}
+	a.store = store.NewFromProtos(0,
+		&session.Session{
+			Id:     "SESSION_ID",
+			UserId: "USER_ID",
+		},
+		&user.User{
+			Id:    "USER_ID",
+			Name:  "foo",
+			Email: "foo@example.com",
+		},
+	)
+
+	policy := &config.Policy{
+		From: "https://example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		DenyResponse: &config.PolicyDenyResponse{
+			Status: http.StatusUnauthorized,
+			Headers: map[string]string{
+				"content-type": "application/json",
+				"X-Custom":     "custom",
+			},
+			Body: `{"status":{{.Status}},"user":"{{.User.Email}}","reasons":"{{range .Reasons}}{{.}}{{end}}"}`,
+		},
+	}
+	require.NoError(t, policy.Validate())
+
+	got, err := a.handleResultDenied(context.Background(), nil, &evaluator.Request{
+		Policy:  policy,
+		Session: evaluator.RequestSession{ID: "SESSION_ID"},
+	}, nil, false, criteria.NewReasons(criteria.ReasonEmailUnauthorized))
+	require.NoError(t, err)
+	testutil.AssertProtoEqual(t, &envoy_service_auth_v3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.PermissionDenied), Message: "Access Denied"},
+		HttpResponse: &envoy_service_auth_v3.CheckResponse_DeniedResponse{
+			DeniedResponse: &envoy_service_auth_v3.DeniedHttpResponse{
+				Status: &envoy_type_v3.HttpStatus{
+					Code: envoy_type_v3.StatusCode_Unauthorized,
+				},
+				Headers: []*envoy_config_core_v3.HeaderValueOption{
+					mkHeader("Content-Type", "application/json", false),
+					mkHeader("X-Custom", "custom", false),
+					mkHeader("X-Pomerium-Intercepted-Response", "true", false),
+				},
+				Body: `{"status":401,"user":"foo@example.com","reasons":"email-unauthorized"}`,
+			},
+		},
+	}, got)
+
+	for reason, code := range map[criteria.Reason]envoy_type_v3.StatusCode{
+		criteria.ReasonDeviceUnauthorized:       httputil.StatusDeviceUnauthorized,
+		criteria.ReasonRouteNotFound:            envoy_type_v3.StatusCode_NotFound,
+		criteria.ReasonInvalidClientCertificate: httputil.StatusInvalidClientCertificate,
+	} {
+		got, err := a.handleResultDenied(context.Background(), &envoy_service_auth_v3.CheckRequest{}, &evaluator.Request{
+			Policy:  policy,
+			Session: evaluator.RequestSession{ID: "SESSION_ID"},
+		}, nil, false, criteria.NewReasons(reason))
+		require.NoError(t, err)
+		assert.Equal(t, code, got.GetDeniedResponse().GetStatus().GetCode(),
+			"should keep the status of %s", reason)
+	}
+}
+
+func TestAuthorize_errorPageResponse(t *testing.T) {
+	a := &Authorize{currentOptions: config.NewAtomicOptions(), state: newAtomicAuthorizeState(new(authorizeState))}
+	a.store = store.NewFromProtos(0)
+
+	policy := &config.Policy{
+		From: "https://example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		ErrorPages: []config.PolicyErrorPage{{
+			StatusCodes: []int{http.StatusForbidden},
+			ContentType: "text/plain",
+			Body:        `{{.Status}} {{.StatusText}}: {{range .Reasons}}{{.}}{{end}}`,
+		}},
+	}
+	require.NoError(t, policy.Validate())
+
+	got, err := a.handleResultDenied(context.Background(), nil, &evaluator.Request{
+		Policy: policy,
+	}, nil, false, criteria.NewReasons(criteria.ReasonEmailUnauthorized))
+	require.NoError(t, err)
+	testutil.AssertProtoEqual(t, &envoy_service_auth_v3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.PermissionDenied), Message: "Access Denied"},
+		HttpResponse: &envoy_service_auth_v3.CheckResponse_DeniedResponse{
+			DeniedResponse: &envoy_service_auth_v3.DeniedHttpResponse{
+				Status: &envoy_type_v3.HttpStatus{
+					Code: envoy_type_v3.StatusCode_Forbidden,
+				},
+				Headers: []*envoy_config_core_v3.HeaderValueOption{
+					mkHeader("Content-Type", "text/plain", false),
+					mkHeader("X-Pomerium-Intercepted-Response", "true", false),
+				},
+				Body: `403 Forbidden: email-unauthorized`,
+			},
+		},
+	}, got)
+}
+
+func TestAuthorize_maintenanceResponse(t *testing.T) {
+	a := &Authorize{currentOptions: config.NewAtomicOptions(), state: newAtomicAuthorizeState(new(authorizeState))}
+	a.store = store.NewFromProtos(0)
+
+	policy := &config.Policy{
+		From:        "https://example.com",
+		To:          mustParseWeightedURLs(t, "https://to.example.com"),
+		Maintenance: true,
+	}
+	require.NoError(t, policy.Validate())
+
+	got, err := a.maintenanceResponse(context.Background(), &envoy_service_auth_v3.CheckRequest{}, &evaluator.Request{
+		Policy: policy,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, envoy_type_v3.StatusCode_ServiceUnavailable, got.GetDeniedResponse().GetStatus().GetCode())
+
+	policy.ErrorPages = []config.PolicyErrorPage{{
+		StatusCodes: []int{http.StatusServiceUnavailable},
+		ContentType: "text/plain",
+		Body:        `down for maintenance`,
+	}}
+	require.NoError(t, policy.Validate())
+	got, err = a.maintenanceResponse(context.Background(), &envoy_service_auth_v3.CheckRequest{}, &evaluator.Request{
+		Policy: policy,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, envoy_type_v3.StatusCode_ServiceUnavailable, got.GetDeniedResponse().GetStatus().GetCode())
+	assert.Equal(t, "down for maintenance", got.GetDeniedResponse().GetBody())
+}
+
+func TestAuthorize_handleResultAllowed(t *testing.T) {
+	opts := config.NewDefaultOptions()
+	opts.TracingProvider = "jaeger"
+	a := &Authorize{currentOptions: config.NewAtomicOptions(), state: newAtomicAuthorizeState(new(authorizeState))}
+	a.currentOptions.Store(opts)
+
+	result := &evaluator.Result{
+		Allow:   evaluator.NewRuleResult(true),
+		Headers: http.Header{"X-A": {"a"}},
+	}
+	ctx, span := trace.StartSpan(context.Background(), "test")
+	defer span.End()
+	res, err := a.handleResultAllowed(ctx, &envoy_service_auth_v3.CheckRequest{}, &evaluator.Request{}, result)
+	require.NoError(t, err)
+	assert.Equal(t, http.Header{"X-A": {"a"}}, result.Headers, "should not modify the result")
+	assert.Len(t, res.GetOkResponse().GetHeaders(), 2, "should add the trace context header")
+
+	a.currentOptions.Store(config.NewDefaultOptions())
+	res, err = a.handleResultAllowed(context.Background(), &envoy_service_auth_v3.CheckRequest{}, &evaluator.Request{
+		Policy: &config.Policy{HMACSigning: &config.PolicyHMACSigning{
+			Secret: base64.StdEncoding.EncodeToString(cryptutil.NewKey()),
+		}},
+	}, result)
+	require.NoError(t, err)
+	assert.Equal(t, http.Header{"X-A": {"a"}}, result.Headers, "should not modify the result")
+	assert.Len(t, res.GetOkResponse().GetHeaders(), 2, "should add the signature header")
+}
+
+func Test_requiresSignIn(t *testing.T) {
+	assert.True(t, requiresSignIn(&evaluator.Result{
+		Allow: evaluator.NewRuleResult(false, criteria.ReasonUserUnauthenticated),
+	}))
+	assert.False(t, requiresSignIn(&evaluator.Result{
+		Allow: evaluator.NewRuleResult(true, criteria.ReasonPomeriumRoute),
+	}))
+	assert.False(t, requiresSignIn(&evaluator.Result{
+		Allow: evaluator.NewRuleResult(false, criteria.ReasonEmailUnauthorized),
+	}))
+	assert.True(t, requiresSignIn(&evaluator.Result{
+		Deny: evaluator.NewRuleResult(true, criteria.ReasonUserUnauthenticated),
+	}))
+}
+
+func Test_getErrorPageMetadata(t *testing.T) {
+	assert.Nil(t, getErrorPageMetadata(nil))
+	assert.Nil(t, getErrorPageMetadata(&config.Policy{From: "https://example.com"}))
+
+	policy := &config.Policy{
+		From:       "https://example.com",
+		To:         mustParseWeightedURLs(t, "https://to.example.com"),
+		ErrorPages: []config.PolicyErrorPage{{StatusCodes: []int{http.StatusServiceUnavailable}}},
+	}
+	routeID, err := policy.RouteID()
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatUint(routeID, 10),
+		getErrorPageMetadata(policy).GetFields()[config.ErrorPageRouteIDMetadataKey].GetStringValue())
+}
+
 func mustParseWeightedURLs(t *testing.T, urls ...string) []config.WeightedURL {
 	wu, err := config.ParseWeightedUrls(urls...)
 	require.NoError(t, err)
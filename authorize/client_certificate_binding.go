@@ -0,0 +1,43 @@
+package authorize
+
+import (
+	"context"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+// isClientCertificateBindingAllowed returns false if the policy binds sessions to client
+// certificates and the request's client certificate doesn't match the session's certificate.
+// Sessions are bound to the client certificate presented to the authenticate service when
+// signing in.
+func isClientCertificateBindingAllowed(
+	ctx context.Context,
+	policy *config.Policy,
+	s sessionOrServiceAccount,
+	rawClientCertificate string,
+) bool {
+	ss, ok := s.(*session.Session)
+	if policy == nil || !policy.BindSessionToClientCertificate || !ok {
+		return true
+	}
+
+	if rawClientCertificate == "" {
+		return false
+	}
+	fingerprint, err := session.GetClientCertificateFingerprint(rawClientCertificate)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("authorize: invalid client certificate")
+		return false
+	}
+	return ss.GetClientCertificateFingerprint() == fingerprint
+}
+
+// requiresClientCertificateBinding returns true if the policy binds sessions to client
+// certificates and the session was created without one, so the user has to sign in again with
+// the client certificate to bind the session to it.
+func requiresClientCertificateBinding(policy *config.Policy, s *session.Session, rawClientCertificate string) bool {
+	return policy != nil && policy.BindSessionToClientCertificate &&
+		rawClientCertificate != "" && s.GetClientCertificateFingerprint() == ""
+}
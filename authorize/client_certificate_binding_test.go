@@ -0,0 +1,59 @@
+package authorize
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+)
+
+func TestIsClientCertificateBindingAllowed(t *testing.T) {
+	ctx := context.Background()
+
+	newCertificate := func() string {
+		cert, err := cryptutil.GenerateSelfSignedCertificate("client.example.com")
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}))
+	}
+	cert1, cert2 := newCertificate(), newCertificate()
+	fingerprint1, err := session.GetClientCertificateFingerprint(cert1)
+	require.NoError(t, err)
+
+	bindingPolicy := &config.Policy{BindSessionToClientCertificate: true}
+	bound := &session.Session{Id: "SESSION", ClientCertificateFingerprint: fingerprint1}
+	unbound := &session.Session{Id: "SESSION"}
+
+	assert.True(t, isClientCertificateBindingAllowed(ctx, &config.Policy{}, unbound, ""),
+		"should allow routes which don't bind sessions")
+	assert.True(t, isClientCertificateBindingAllowed(ctx, bindingPolicy, &user.ServiceAccount{Id: "SERVICE_ACCOUNT"}, ""),
+		"should allow service accounts")
+	assert.False(t, isClientCertificateBindingAllowed(ctx, bindingPolicy, bound, ""),
+		"should require a client certificate")
+	assert.False(t, isClientCertificateBindingAllowed(ctx, bindingPolicy, bound, "INVALID"),
+		"should reject invalid client certificates")
+	assert.True(t, isClientCertificateBindingAllowed(ctx, bindingPolicy, bound, cert1))
+	assert.False(t, isClientCertificateBindingAllowed(ctx, bindingPolicy, bound, cert2),
+		"should reject other certificates")
+	assert.False(t, isClientCertificateBindingAllowed(ctx, bindingPolicy, unbound, cert1),
+		"should not bind sessions on first use")
+}
+
+func TestRequiresClientCertificateBinding(t *testing.T) {
+	bindingPolicy := &config.Policy{BindSessionToClientCertificate: true}
+	bound := &session.Session{Id: "SESSION", ClientCertificateFingerprint: "FINGERPRINT"}
+	unbound := &session.Session{Id: "SESSION"}
+
+	assert.True(t, requiresClientCertificateBinding(bindingPolicy, unbound, "CERTIFICATE"))
+	assert.False(t, requiresClientCertificateBinding(bindingPolicy, bound, "CERTIFICATE"))
+	assert.False(t, requiresClientCertificateBinding(bindingPolicy, unbound, ""),
+		"should reject requests without a client certificate instead")
+	assert.False(t, requiresClientCertificateBinding(&config.Policy{}, unbound, "CERTIFICATE"))
+	assert.False(t, requiresClientCertificateBinding(nil, unbound, "CERTIFICATE"))
+}
@@ -1,16 +1,27 @@
 package evaluator
 
 import (
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/revocation"
 )
 
 type evaluatorConfig struct {
 	policies                                          []config.Policy
+	defaultPolicy                                     *config.PPLPolicy
 	clientCA                                          []byte
+	revocationChecker                                 *revocation.Checker
+	clientRevocationMode                              revocation.Mode
 	signingKey                                        string
+	signingKeyAlgorithm                               string
+	signingKeySigner                                  jose.OpaqueSigner
 	authenticateURL                                   string
 	googleCloudServerlessAuthenticationServiceAccount string
 	jwtClaimsHeaders                                  config.JWTClaimHeaders
+	decisionCacheTTL                                  time.Duration
 }
 
 // An Option customizes the evaluator config.
@@ -31,6 +42,13 @@ func WithPolicies(policies []config.Policy) Option {
 	}
 }
 
+// WithDefaultPolicy sets the default policy in the config.
+func WithDefaultPolicy(defaultPolicy *config.PPLPolicy) Option {
+	return func(cfg *evaluatorConfig) {
+		cfg.defaultPolicy = defaultPolicy
+	}
+}
+
 // WithClientCA sets the client CA in the config.
 func WithClientCA(clientCA []byte) Option {
 	return func(cfg *evaluatorConfig) {
@@ -38,6 +56,21 @@ func WithClientCA(clientCA []byte) Option {
 	}
 }
 
+// WithRevocationChecker sets the checker for revoked client certificates in the config.
+func WithRevocationChecker(checker *revocation.Checker) Option {
+	return func(cfg *evaluatorConfig) {
+		cfg.revocationChecker = checker
+	}
+}
+
+// WithClientRevocationMode sets the client revocation mode in the config, which routes can
+// override.
+func WithClientRevocationMode(mode revocation.Mode) Option {
+	return func(cfg *evaluatorConfig) {
+		cfg.clientRevocationMode = mode
+	}
+}
+
 // WithSigningKey sets the signing key and algorithm in the config.
 func WithSigningKey(signingKey string) Option {
 	return func(cfg *evaluatorConfig) {
@@ -45,6 +78,21 @@ func WithSigningKey(signingKey string) Option {
 	}
 }
 
+// WithSigningKeyAlgorithm sets the signing key algorithm in the config.
+func WithSigningKeyAlgorithm(algorithm string) Option {
+	return func(cfg *evaluatorConfig) {
+		cfg.signingKeyAlgorithm = algorithm
+	}
+}
+
+// WithSigningKeySigner sets the signer for a signing key kept on a PKCS#11 token or in a
+// cloud KMS in the config.
+func WithSigningKeySigner(signer jose.OpaqueSigner) Option {
+	return func(cfg *evaluatorConfig) {
+		cfg.signingKeySigner = signer
+	}
+}
+
 // WithAuthenticateURL sets the authenticate URL in the config.
 func WithAuthenticateURL(authenticateURL string) Option {
 	return func(cfg *evaluatorConfig) {
@@ -66,3 +114,10 @@ func WithJWTClaimsHeaders(headers config.JWTClaimHeaders) Option {
 		cfg.jwtClaimsHeaders = headers
 	}
 }
+
+// WithDecisionCacheTTL sets the decision cache TTL in the config. A TTL of 0 disables the cache.
+func WithDecisionCacheTTL(ttl time.Duration) Option {
+	return func(cfg *evaluatorConfig) {
+		cfg.decisionCacheTTL = ttl
+	}
+}
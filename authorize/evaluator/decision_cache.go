@@ -0,0 +1,242 @@
+package evaluator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/authorize/internal/store"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+)
+
+// decisionCacheSize is the maximum number of decisions stored in the decision cache.
+const decisionCacheSize = 10000
+
+// A decisionCache stores the results of evaluation so that identical requests don't need to be
+// re-evaluated. Entries are keyed by the route, the session and the parts of the request the
+// route's policies read. An entry is reused until one of the databroker records read during its
+// evaluation changes, and expires at the latest when the JWT assertion of the result does, which
+// is also when the session expires.
+type decisionCache struct {
+	ttl   time.Duration
+	store *store.Store
+	cache *lru.Cache
+	now   func() time.Time
+}
+
+type decisionCacheEntry struct {
+	result   *Result
+	versions *store.RecordVersions
+	expiry   time.Time
+}
+
+func newDecisionCache(ttl time.Duration, store *store.Store) *decisionCache {
+	cache, _ := lru.New(decisionCacheSize)
+	return &decisionCache{
+		ttl:   ttl,
+		store: store,
+		cache: cache,
+		now:   time.Now,
+	}
+}
+
+// decisionCacheKey contains the parts of a request which change the result of the evaluation.
+type decisionCacheKey struct {
+	RouteID           uint64            `json:"route_id"`
+	SessionID         string            `json:"session_id"`
+	Method            string            `json:"method,omitempty"`
+	Path              string            `json:"path,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	ClientCertificate string            `json:"client_certificate,omitempty"`
+	IP                string            `json:"ip,omitempty"`
+	Subdomain         string            `json:"subdomain,omitempty"`
+	// the JWT assertion of the request is carried over to the result
+	JWTAssertion    string `json:"jwt_assertion,omitempty"`
+	JWTAssertionFor string `json:"jwt_assertion_for,omitempty"`
+}
+
+// key returns the cache key for the given request, which only contains the inputs read by the
+// policies evaluated for the route.
+func (c *decisionCache) key(routeID uint64, req *Request, subdomain string, inputs policyInputs) (string, error) {
+	k := decisionCacheKey{
+		RouteID:         routeID,
+		SessionID:       req.Session.ID,
+		JWTAssertion:    req.HTTP.Headers[http.CanonicalHeaderKey(httputil.HeaderPomeriumJWTAssertion)],
+		JWTAssertionFor: req.HTTP.Headers[http.CanonicalHeaderKey(httputil.HeaderPomeriumJWTAssertionFor)],
+	}
+	if inputs.reads("http", "method") {
+		k.Method = req.HTTP.Method
+	}
+	if inputs.reads("http", "path") {
+		k.Path = req.HTTP.Path
+	}
+	if inputs.reads("http", "url") {
+		k.URL = req.HTTP.URL
+	}
+	if inputs.reads("http", "headers") {
+		k.Headers = req.HTTP.Headers
+	}
+	// the validity of the client certificate depends on the certificate
+	if inputs.reads("http", "client_certificate") || inputs.reads("is_valid_client_certificate") {
+		k.ClientCertificate = req.HTTP.ClientCertificate
+	}
+	if inputs.reads("http", "ip") {
+		k.IP = req.HTTP.IP
+	}
+	// the subdomain is also used by header templates
+	if inputs.reads("subdomain") || req.Policy.HasRequestHeaderTemplates() || req.Policy.HasResponseHeaderTemplates() {
+		k.Subdomain = subdomain
+	}
+
+	bs, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	return string(cryptutil.Hash("authorize-decision-cache", bs)), nil
+}
+
+// get returns the cached result for the given key if none of the records read to compute it
+// changed.
+func (c *decisionCache) get(key string) (*Result, bool) {
+	v, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	entry := v.(decisionCacheEntry)
+	if !c.now().Before(entry.expiry) || !c.store.IsCurrent(entry.versions) {
+		c.cache.Remove(key)
+		return nil, false
+	}
+	return cloneResult(entry.result), true
+}
+
+// set stores the result for the given key, computed using the records with the given versions.
+func (c *decisionCache) set(key string, versions *store.RecordVersions, result *Result) {
+	// the data changed during evaluation, so the result may already be stale
+	if !c.store.IsCurrent(versions) {
+		return
+	}
+
+	expiry := c.now().Add(c.ttl)
+	if jwtExpiry, ok := getJWTAssertionExpiry(result.Headers); ok && jwtExpiry.Before(expiry) {
+		expiry = jwtExpiry
+	}
+	c.cache.Add(key, decisionCacheEntry{
+		result:   cloneResult(result),
+		versions: versions,
+		expiry:   expiry,
+	})
+}
+
+// policyInputs are the fields of the input read by policies, like "http.method". A field which
+// is read also includes all of its nested fields, and the empty field is the whole input.
+type policyInputs map[string]struct{}
+
+// getPolicyInputs returns the fields of the input read by the rego script.
+func getPolicyInputs(script string) policyInputs {
+	inputs := policyInputs{}
+	module, err := ast.ParseModule("pomerium.policy", script)
+	if err != nil {
+		// scripts may also not have a package
+		module, err = ast.ParseModule("pomerium.policy", "package pomerium.policy\n\n"+script)
+	}
+	if err != nil {
+		inputs[""] = struct{}{}
+		return inputs
+	}
+
+	ast.WalkRefs(module, func(ref ast.Ref) bool {
+		if !ref.HasPrefix(ast.InputRootRef) {
+			return false
+		}
+		// the field is the path of the constant keys of the ref, up to the fields of the http input
+		var path []string
+		for _, term := range ref[1:] {
+			key, ok := term.Value.(ast.String)
+			if !ok || len(path) == 2 {
+				break
+			}
+			path = append(path, string(key))
+		}
+		inputs[strings.Join(path, ".")] = struct{}{}
+		return false
+	})
+	return inputs
+}
+
+// reads returns true if the field with the path, or one of its parents, is read.
+func (inputs policyInputs) reads(path ...string) bool {
+	for i := 0; i <= len(path); i++ {
+		if _, ok := inputs[strings.Join(path[:i], ".")]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// merge adds the fields of the other inputs.
+func (inputs policyInputs) merge(other policyInputs) policyInputs {
+	merged := make(policyInputs, len(inputs)+len(other))
+	for k := range inputs {
+		merged[k] = struct{}{}
+	}
+	for k := range other {
+		merged[k] = struct{}{}
+	}
+	return merged
+}
+
+// getJWTAssertionExpiry returns the expiry of the JWT assertion in the headers. The JWT was
+// signed by the evaluator, so it isn't verified.
+func getJWTAssertionExpiry(headers http.Header) (time.Time, bool) {
+	rawJWT := headers.Get(httputil.HeaderPomeriumJWTAssertion)
+	if rawJWT == "" {
+		return time.Time{}, false
+	}
+	tok, err := jwt.ParseSigned(rawJWT)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims jwt.Claims
+	if err := tok.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Expiry == nil {
+		return time.Time{}, false
+	}
+	return claims.Expiry.Time(), true
+}
+
+// cloneResult returns a copy of the result, so the cached result isn't modified by the callers
+// adding headers to the result.
+func cloneResult(res *Result) *Result {
+	dup := *res
+	dup.Allow = cloneRuleResult(res.Allow)
+	dup.Deny = cloneRuleResult(res.Deny)
+	dup.Headers = res.Headers.Clone()
+	dup.ResponseHeaders = res.ResponseHeaders.Clone()
+	dup.AppendResponseHeaders = res.AppendResponseHeaders.Clone()
+	return &dup
+}
+
+func cloneRuleResult(res RuleResult) RuleResult {
+	dup := res
+	if res.Reasons != nil {
+		dup.Reasons = make(criteria.Reasons, len(res.Reasons))
+		for reason := range res.Reasons {
+			dup.Reasons.Add(reason)
+		}
+	}
+	if res.AdditionalData != nil {
+		dup.AdditionalData = make(map[string]interface{}, len(res.AdditionalData))
+		for k, v := range res.AdditionalData {
+			dup.AdditionalData[k] = v
+		}
+	}
+	return dup
+}
@@ -0,0 +1,204 @@
+package evaluator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/authorize/internal/store"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func TestDecisionCache(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := store.New()
+	c := newDecisionCache(time.Minute, s)
+	c.now = func() time.Time { return now }
+
+	req := &Request{
+		Policy: &config.Policy{},
+		HTTP: RequestHTTP{
+			Method:  http.MethodGet,
+			URL:     "https://from.example.com",
+			Headers: map[string]string{"User-Agent": "agent-1"},
+		},
+		Session: RequestSession{ID: "SESSION_ID"},
+	}
+	inputs := policyInputs{"http.method": {}, "session.id": {}}
+	key, err := c.key(1, req, "", inputs)
+	require.NoError(t, err)
+
+	t.Run("key", func(t *testing.T) {
+		otherKey, err := c.key(2, req, "", inputs)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, otherKey, "should include the route id")
+
+		otherReq := *req
+		otherReq.Session.ID = "OTHER_SESSION_ID"
+		otherKey, err = c.key(1, &otherReq, "", inputs)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, otherKey, "should include the session id")
+
+		otherReq = *req
+		otherReq.HTTP.Method = http.MethodPost
+		otherKey, err = c.key(1, &otherReq, "", inputs)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, otherKey, "should include the inputs read by the policy")
+
+		otherReq = *req
+		otherReq.HTTP.Headers = map[string]string{"User-Agent": "agent-2"}
+		otherKey, err = c.key(1, &otherReq, "", inputs)
+		require.NoError(t, err)
+		assert.Equal(t, key, otherKey, "should not include the inputs the policy doesn't read")
+		otherKey, err = c.key(1, &otherReq, "", policyInputs{"http": {}})
+		require.NoError(t, err)
+		assert.NotEqual(t, key, otherKey)
+
+		otherReq = *req
+		otherReq.HTTP.Headers = map[string]string{"X-Pomerium-Jwt-Assertion": "JWT"}
+		otherKey, err = c.key(1, &otherReq, "", inputs)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, otherKey, "should include the jwt assertion carried over to the result")
+	})
+
+	res := &Result{Allow: NewRuleResult(true)}
+
+	_, ok := c.get(key)
+	assert.False(t, ok)
+	c.set(key, s.NewRecordVersions(), res)
+	got, ok := c.get(key)
+	assert.True(t, ok)
+	assert.Equal(t, res, got)
+
+	t.Run("copy", func(t *testing.T) {
+		c.set(key, s.NewRecordVersions(), &Result{Allow: NewRuleResult(true), Headers: http.Header{"X-A": {"a"}}})
+		got, ok := c.get(key)
+		require.True(t, ok)
+		got.Headers.Set("X-B", "b")
+		got.Allow.Reasons.Add(criteria.ReasonEmailOK)
+
+		got, ok = c.get(key)
+		require.True(t, ok)
+		assert.Equal(t, http.Header{"X-A": {"a"}}, got.Headers, "should not modify the cached result")
+		assert.Empty(t, got.Allow.Reasons)
+	})
+	t.Run("jwt expiry", func(t *testing.T) {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")}, nil)
+		require.NoError(t, err)
+		rawJWT, err := jwt.Signed(signer).Claims(jwt.Claims{Expiry: jwt.NewNumericDate(now.Add(30 * time.Second))}).CompactSerialize()
+		require.NoError(t, err)
+
+		c.set(key, s.NewRecordVersions(), &Result{Headers: http.Header{"X-Pomerium-Jwt-Assertion": {rawJWT}}})
+		_, ok := c.get(key)
+		assert.True(t, ok)
+		now = now.Add(45 * time.Second)
+		_, ok = c.get(key)
+		assert.False(t, ok, "should expire with the jwt")
+	})
+	t.Run("expired", func(t *testing.T) {
+		c.set(key, s.NewRecordVersions(), res)
+		now = now.Add(2 * time.Minute)
+		_, ok := c.get(key)
+		assert.False(t, ok)
+	})
+	t.Run("stale set", func(t *testing.T) {
+		versions := s.NewRecordVersions()
+		s.ClearRecords()
+		c.set(key, versions, res)
+		_, ok := c.get(key)
+		assert.False(t, ok, "should not store results computed with old data")
+	})
+}
+
+func TestEvaluator_decisionCache(t *testing.T) {
+	ctx := context.Background()
+
+	signingKey, err := cryptutil.NewSigningKey()
+	require.NoError(t, err)
+	encodedSigningKey, err := cryptutil.EncodePrivateKey(signingKey)
+	require.NoError(t, err)
+	privateJWK, err := cryptutil.PrivateJWKFromBytes(encodedSigningKey)
+	require.NoError(t, err)
+
+	s := store.New()
+	s.UpdateSigningKey(privateJWK)
+	updateRecord := func(version uint64, id string, msg proto.Message) {
+		data := protoutil.NewAny(msg)
+		s.UpdateRecord(1, &databroker.Record{Version: version, Type: data.GetTypeUrl(), Id: id, Data: data})
+	}
+	updateRecord(1, "s1", &session.Session{Id: "s1", UserId: "u1"})
+	updateRecord(2, "s2", &session.Session{Id: "s2", UserId: "u2"})
+	updateRecord(3, "u1", &user.User{Id: "u1", Email: "a@example.com"})
+
+	policy := config.Policy{
+		To:           config.WeightedURLs{{URL: *mustParseURL("https://to.example.com")}},
+		AllowedUsers: []string{"a@example.com"},
+	}
+	e, err := New(ctx, s, nil,
+		WithAuthenticateURL("https://authn.example.com"),
+		WithPolicies([]config.Policy{policy}),
+		WithDecisionCacheTTL(time.Minute))
+	require.NoError(t, err)
+
+	req := &Request{
+		Policy:  &policy,
+		HTTP:    NewRequestHTTP(http.MethodGet, *mustParseURL("https://from.example.com"), nil, "", ""),
+		Session: RequestSession{ID: "s1"},
+	}
+	res, err := e.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, res.Allow.Value)
+
+	id, err := policy.RouteID()
+	require.NoError(t, err)
+	key, err := e.decisionCache.key(id, req, "", e.policyInputs[id])
+	require.NoError(t, err)
+
+	updateRecord(4, "s2", &session.Session{Id: "s2", UserId: "u2", AccessedAt: timestamppb.Now()})
+	_, ok := e.decisionCache.get(key)
+	assert.True(t, ok, "should keep decisions of other sessions")
+
+	updateRecord(5, "u1", &user.User{Id: "u1", Email: "b@example.com"})
+	_, ok = e.decisionCache.get(key)
+	assert.False(t, ok, "should invalidate decisions of the user")
+	res, err = e.Evaluate(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, res.Allow.Value)
+}
+
+func TestGetPolicyInputs(t *testing.T) {
+	assert.Equal(t, policyInputs{"http.method": {}, "http.headers": {}, "session.id": {}}, getPolicyInputs(`
+package pomerium.policy
+
+allow {
+	input.http.method == "GET"
+	object.get(input.http.headers, "Origin", "") != ""
+	input.session.id != ""
+}
+`))
+	assert.Equal(t, policyInputs{"http": {}}, getPolicyInputs(`allow { input.http[k] == "v" }`),
+		"should read all the fields for dynamic keys")
+	assert.Equal(t, policyInputs{"": {}}, getPolicyInputs(`allow { x := input; x.http.ip == "" }`),
+		"should read everything when the whole input is used")
+	assert.Equal(t, policyInputs{"": {}}, getPolicyInputs(`allow { `), "should read everything for invalid scripts")
+
+	inputs := policyInputs{"http.method": {}, "session": {}}
+	assert.True(t, inputs.reads("http", "method"))
+	assert.False(t, inputs.reads("http", "path"))
+	assert.True(t, inputs.reads("session", "id"))
+	assert.True(t, policyInputs{"": {}}.reads("http", "path"))
+}
@@ -9,12 +9,15 @@ import (
 	"net/url"
 
 	"github.com/go-jose/go-jose/v3"
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 
 	"github.com/pomerium/pomerium/authorize/internal/store"
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/hashutil"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/revocation"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
@@ -72,6 +75,13 @@ type Result struct {
 	Allow   RuleResult
 	Deny    RuleResult
 	Headers http.Header
+	// ResponseHeaders replace and AppendResponseHeaders are appended to the headers of the
+	// upstream response.
+	ResponseHeaders       http.Header
+	AppendResponseHeaders http.Header
+	// Maintenance is true when the route is in maintenance mode and the user isn't allowed to
+	// access it during maintenance.
+	Maintenance bool
 
 	DataBrokerServerVersion, DataBrokerRecordVersion uint64
 }
@@ -80,12 +90,31 @@ type Result struct {
 type Evaluator struct {
 	store             *store.Store
 	policyEvaluators  map[uint64]*PolicyEvaluator
+	defaultEvaluator  *PolicyEvaluator
 	headersEvaluators *HeadersEvaluator
+	decisionCache     *decisionCache
 	clientCA          []byte
+
+	revocationChecker    *revocation.Checker
+	clientRevocationMode revocation.Mode
+
+	// maintenanceEvaluators evaluate whether a user may access a route in maintenance mode
+	maintenanceEvaluators map[uint64]*PolicyEvaluator
+	// headersRequests are the parts of the headers.rego inputs which come from the policies
+	headersRequests map[uint64]*HeadersRequest
+	// policyInputs are the fields of the input read by the policies evaluated for each route
+	policyInputs map[uint64]policyInputs
+
+	// the checksums of the policies the evaluators were compiled from, so that an evaluator
+	// created for a new config only compiles the policies which changed
+	policyChecksums       map[uint64]uint64
+	defaultPolicyChecksum uint64
 }
 
-// New creates a new Evaluator.
-func New(ctx context.Context, store *store.Store, options ...Option) (*Evaluator, error) {
+// New creates a new Evaluator. The policy evaluators of the previous evaluator, if there is one,
+// are reused for the policies which didn't change, since compiling policies is slow. The
+// previous evaluator can still be used while the new one is created.
+func New(ctx context.Context, store *store.Store, previous *Evaluator, options ...Option) (*Evaluator, error) {
 	e := &Evaluator{store: store}
 
 	cfg := getConfig(options...)
@@ -95,29 +124,118 @@ func New(ctx context.Context, store *store.Store, options ...Option) (*Evaluator
 		return nil, err
 	}
 
+	// the prepared queries of the previous evaluator read from its store
+	if previous != nil && previous.store != store {
+		previous = nil
+	}
+
 	e.headersEvaluators, err = NewHeadersEvaluator(ctx, store)
 	if err != nil {
 		return nil, err
 	}
 
 	e.policyEvaluators = make(map[uint64]*PolicyEvaluator)
+	e.maintenanceEvaluators = make(map[uint64]*PolicyEvaluator)
+	e.headersRequests = make(map[uint64]*HeadersRequest)
+	e.policyChecksums = make(map[uint64]uint64)
+	var reused int
 	for _, configPolicy := range cfg.policies {
 		id, err := configPolicy.RouteID()
 		if err != nil {
 			return nil, fmt.Errorf("authorize: error computing policy route id: %w", err)
 		}
+		e.headersRequests[id] = NewHeadersRequestFromPolicy(&configPolicy) //nolint
+
+		checksum, err := hashutil.Hash(configPolicy)
+		if err == nil {
+			e.policyChecksums[id] = checksum
+			if previous.hasPolicyEvaluator(id, checksum) {
+				e.policyEvaluators[id] = previous.policyEvaluators[id]
+				if maintenanceEvaluator, ok := previous.maintenanceEvaluators[id]; ok {
+					e.maintenanceEvaluators[id] = maintenanceEvaluator
+				}
+				reused++
+				continue
+			}
+		}
+
 		policyEvaluator, err := NewPolicyEvaluator(ctx, store, &configPolicy) //nolint
 		if err != nil {
 			return nil, err
 		}
 		e.policyEvaluators[id] = policyEvaluator
+
+		if configPolicy.Maintenance && len(configPolicy.MaintenanceAllowedGroups) > 0 {
+			e.maintenanceEvaluators[id], err = NewPolicyEvaluator(ctx, store, &config.Policy{
+				From:          configPolicy.From,
+				AllowedGroups: configPolicy.MaintenanceAllowedGroups,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("authorize: error creating maintenance policy evaluator: %w", err)
+			}
+		}
+	}
+	log.Debug(ctx).
+		Int("policies", len(cfg.policies)).
+		Int("reused", reused).
+		Msg("authorize: created policy evaluators")
+
+	if cfg.defaultPolicy != nil {
+		checksum, hashErr := hashutil.Hash(cfg.defaultPolicy)
+		if hashErr == nil {
+			e.defaultPolicyChecksum = checksum
+		}
+		if hashErr == nil && previous != nil && previous.defaultEvaluator != nil &&
+			previous.defaultPolicyChecksum == checksum {
+			e.defaultEvaluator = previous.defaultEvaluator
+		} else {
+			e.defaultEvaluator, err = NewPolicyEvaluator(ctx, store, &config.Policy{
+				From:   "default_policy",
+				Policy: cfg.defaultPolicy,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("authorize: error creating default policy evaluator: %w", err)
+			}
+		}
 	}
 
 	e.clientCA = cfg.clientCA
+	e.revocationChecker = cfg.revocationChecker
+	e.clientRevocationMode = cfg.clientRevocationMode
+
+	if cfg.decisionCacheTTL > 0 {
+		e.decisionCache = newDecisionCache(cfg.decisionCacheTTL, store)
+		e.policyInputs = make(map[uint64]policyInputs, len(e.policyEvaluators))
+		for _, configPolicy := range cfg.policies {
+			id, _ := configPolicy.RouteID()
+			inputs := e.policyEvaluators[id].inputs
+			if e.defaultEvaluator != nil && !configPolicy.SkipDefaultPolicy {
+				inputs = inputs.merge(e.defaultEvaluator.inputs)
+			}
+			if maintenanceEvaluator, ok := e.maintenanceEvaluators[id]; ok {
+				inputs = inputs.merge(maintenanceEvaluator.inputs)
+			}
+			e.policyInputs[id] = inputs
+		}
+	}
 
 	return e, nil
 }
 
+// hasPolicyEvaluator returns true if the evaluator has a policy evaluator for the route compiled
+// from a policy with the checksum.
+func (e *Evaluator) hasPolicyEvaluator(id, checksum uint64) bool {
+	if e == nil {
+		return false
+	}
+	previousChecksum, ok := e.policyChecksums[id]
+	if !ok || previousChecksum != checksum {
+		return false
+	}
+	_, ok = e.policyEvaluators[id]
+	return ok
+}
+
 // Evaluate evaluates the rego for the given policy and generates the identity headers.
 func (e *Evaluator) Evaluate(ctx context.Context, req *Request) (*Result, error) {
 	_, span := trace.StartSpan(ctx, "authorize.Evaluator.Evaluate")
@@ -137,43 +255,179 @@ func (e *Evaluator) Evaluate(ctx context.Context, req *Request) (*Result, error)
 		return notFoundOutput, nil
 	}
 
+	if e.decisionCache == nil {
+		return e.evaluate(ctx, id, req, policyEvaluator)
+	}
+
+	key, err := e.decisionCache.key(id, req, getRequestSubdomain(req), e.policyInputs[id])
+	if err != nil {
+		return nil, fmt.Errorf("authorize: error computing decision cache key: %w", err)
+	}
+	if res, ok := e.decisionCache.get(key); ok {
+		return res, nil
+	}
+
+	// collect the versions of the records read during evaluation, so the cached result is
+	// only reused until one of them changes
+	versions := e.store.NewRecordVersions()
+	res, err := e.evaluate(store.WithRecordVersions(ctx, versions), id, req, policyEvaluator)
+	if err != nil {
+		return nil, err
+	}
+	e.decisionCache.set(key, versions, res)
+	return res, nil
+}
+
+func (e *Evaluator) evaluate(ctx context.Context, id uint64, req *Request, policyEvaluator *PolicyEvaluator) (*Result, error) {
 	clientCA, err := e.getClientCA(req.Policy)
 	if err != nil {
 		return nil, err
 	}
 
-	isValidClientCertificate, err := isValidClientCertificate(clientCA, req.HTTP.ClientCertificate)
+	isValidClientCertificate, err := isValidClientCertificate(ctx, clientCA, req.HTTP.ClientCertificate,
+		e.revocationChecker, e.getClientRevocationMode(req.Policy))
 	if err != nil {
 		return nil, fmt.Errorf("authorize: error validating client certificate: %w", err)
 	}
 
-	policyOutput, err := policyEvaluator.Evaluate(ctx, &PolicyRequest{
+	subdomain := getRequestSubdomain(req)
+	policyReq := &PolicyRequest{
 		HTTP:                     req.HTTP,
 		Session:                  req.Session,
+		Subdomain:                subdomain,
 		IsValidClientCertificate: isValidClientCertificate,
-	})
+	}
+	// the same input is used for the route, default and maintenance policies
+	policyInput := policyReq.value()
+	policyOutput, err := policyEvaluator.evaluate(ctx, policyInput)
 	if err != nil {
 		return nil, err
 	}
 
-	headersReq := NewHeadersRequestFromPolicy(req.Policy)
+	// the default policy must also allow the request
+	if e.defaultEvaluator != nil && !req.Policy.SkipDefaultPolicy {
+		defaultOutput, err := e.defaultEvaluator.evaluate(ctx, policyInput)
+		if err != nil {
+			return nil, err
+		}
+		policyOutput.Allow = MergeRuleResultsWithAnd(policyOutput.Allow, defaultOutput.Allow)
+		policyOutput.Deny = MergeRuleResultsWithOr(policyOutput.Deny, defaultOutput.Deny)
+	}
+
+	headersReq := e.getHeadersRequest(id, req.Policy)
 	headersReq.Session = req.Session
-	headersOutput, err := e.headersEvaluators.Evaluate(ctx, headersReq)
+	headersOutput, err := e.headersEvaluators.Evaluate(ctx, &headersReq)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenance, err := e.isMaintenance(ctx, id, req.Policy, policyInput)
 	if err != nil {
 		return nil, err
 	}
 
 	carryOverJWTAssertion(headersOutput.Headers, req.HTTP.Headers)
+	if req.Policy.HasRequestHeaderTemplates() {
+		setRequestHeaderTemplates(ctx, req.Policy, subdomain, headersOutput)
+	}
 
 	res := &Result{
-		Allow:   policyOutput.Allow,
-		Deny:    policyOutput.Deny,
-		Headers: headersOutput.Headers,
+		Allow:       policyOutput.Allow,
+		Deny:        policyOutput.Deny,
+		Headers:     headersOutput.Headers,
+		Maintenance: maintenance,
+	}
+	if req.Policy.HasResponseHeaderTemplates() {
+		setResponseHeaderTemplates(ctx, req.Policy, subdomain, headersOutput, res)
 	}
 	res.DataBrokerServerVersion, res.DataBrokerRecordVersion = e.store.GetDataBrokerVersions()
 	return res, nil
 }
 
+// isMaintenance returns true if the route is in maintenance mode and the user isn't a member of
+// one of the groups allowed to access it during maintenance.
+func (e *Evaluator) isMaintenance(ctx context.Context, id uint64, policy *config.Policy, input ast.Value) (bool, error) {
+	if !policy.Maintenance {
+		return false, nil
+	}
+	maintenanceEvaluator, ok := e.maintenanceEvaluators[id]
+	if !ok {
+		return true, nil
+	}
+	output, err := maintenanceEvaluator.evaluate(ctx, input)
+	if err != nil {
+		return false, err
+	}
+	return !output.Allow.Value || output.Deny.Value, nil
+}
+
+// getHeadersRequest returns a copy of the headers request of the policy, which was created
+// when the evaluator was, so that it isn't created again for every request.
+func (e *Evaluator) getHeadersRequest(id uint64, policy *config.Policy) HeadersRequest {
+	if headersReq, ok := e.headersRequests[id]; ok {
+		return *headersReq
+	}
+	return *NewHeadersRequestFromPolicy(policy)
+}
+
+// setRequestHeaderTemplates renders the set_request_headers templates of the policy, which
+// replace any headers with the same name.
+func setRequestHeaderTemplates(ctx context.Context, policy *config.Policy, subdomain string, headersOutput *HeadersResponse) {
+	headers, err := policy.RenderRequestHeaderTemplates(getHeaderTemplateData(policy, subdomain, headersOutput))
+	if err != nil {
+		log.Debug(ctx).Err(err).Str("policy", policy.String()).Msg("authorize: error rendering request header templates")
+	}
+	for k, v := range headers {
+		headersOutput.Headers.Set(k, v)
+	}
+}
+
+// setResponseHeaderTemplates renders the set_response_headers and append_response_headers
+// templates of the policy, which are added to the response by envoy.
+func setResponseHeaderTemplates(ctx context.Context, policy *config.Policy, subdomain string, headersOutput *HeadersResponse, res *Result) {
+	set, appended, err := policy.RenderResponseHeaderTemplates(getHeaderTemplateData(policy, subdomain, headersOutput))
+	if err != nil {
+		log.Debug(ctx).Err(err).Str("policy", policy.String()).Msg("authorize: error rendering response header templates")
+	}
+	res.ResponseHeaders = make(http.Header)
+	for k, v := range set {
+		res.ResponseHeaders.Set(k, v)
+	}
+	res.AppendResponseHeaders = make(http.Header)
+	for k, v := range appended {
+		res.AppendResponseHeaders.Set(k, v)
+	}
+}
+
+// getHeaderTemplateData returns the data header templates are rendered with, which is the
+// user's identity and the route.
+func getHeaderTemplateData(policy *config.Policy, subdomain string, headersOutput *HeadersResponse) map[string]interface{} {
+	data := make(map[string]interface{}, len(headersOutput.TemplateData)+1)
+	for k, v := range headersOutput.TemplateData {
+		data[k] = v
+	}
+	data["route"] = map[string]interface{}{
+		"from":      policy.From,
+		"name":      policy.EnvoyOpts.GetName(),
+		"subdomain": subdomain,
+	}
+	return data
+}
+
+// getRequestSubdomain returns the subdomain of the request captured by the wildcard of the
+// policy, if it has one.
+func getRequestSubdomain(req *Request) string {
+	if req.Policy == nil {
+		return ""
+	}
+	u, err := url.Parse(req.HTTP.URL)
+	if err != nil {
+		return ""
+	}
+	subdomain, _ := req.Policy.GetWildcardSubdomain(u.Host)
+	return subdomain
+}
+
 func (e *Evaluator) getClientCA(policy *config.Policy) (string, error) {
 	if policy != nil && policy.TLSDownstreamClientCA != "" {
 		bs, err := base64.StdEncoding.DecodeString(policy.TLSDownstreamClientCA)
@@ -186,6 +440,13 @@ func (e *Evaluator) getClientCA(policy *config.Policy) (string, error) {
 	return string(e.clientCA), nil
 }
 
+func (e *Evaluator) getClientRevocationMode(policy *config.Policy) revocation.Mode {
+	if policy != nil && policy.TLSDownstreamClientRevocationMode != "" {
+		return revocation.Mode(policy.TLSDownstreamClientRevocationMode)
+	}
+	return e.clientRevocationMode
+}
+
 func (e *Evaluator) updateStore(cfg *evaluatorConfig) error {
 	jwk, err := getJWK(cfg)
 	if err != nil {
@@ -209,37 +470,55 @@ func (e *Evaluator) updateStore(cfg *evaluatorConfig) error {
 }
 
 func getJWK(cfg *evaluatorConfig) (*jose.JSONWebKey, error) {
-	var decodedCert []byte
-	// if we don't have a signing key, generate one
-	if cfg.signingKey == "" {
-		key, err := cryptutil.NewSigningKey()
+	var jwk *jose.JSONWebKey
+	if cfg.signingKeySigner != nil {
+		jwk = cryptutil.PrivateJWKFromSigner(cfg.signingKeySigner)
+		if cfg.signingKeyAlgorithm != "" && jwk.Algorithm != cfg.signingKeyAlgorithm {
+			return nil, fmt.Errorf("signing key is for %s, but the signing key algorithm is %s",
+				jwk.Algorithm, cfg.signingKeyAlgorithm)
+		}
+	} else if cfg.signingKey == "" {
+		// if we don't have a signing key, generate one
+		alg := jose.SignatureAlgorithm(cfg.signingKeyAlgorithm)
+		if alg == "" {
+			alg = jose.ES256
+		}
+		var err error
+		jwk, err = cryptutil.NewPrivateJWK(alg)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't generate signing key: %w", err)
 		}
-		decodedCert, err = cryptutil.EncodePrivateKey(key)
+	} else {
+		decodedCert, err := base64.StdEncoding.DecodeString(cfg.signingKey)
 		if err != nil {
 			return nil, fmt.Errorf("bad signing key: %w", err)
 		}
-	} else {
-		var err error
-		decodedCert, err = base64.StdEncoding.DecodeString(cfg.signingKey)
+		jwk, err = cryptutil.PrivateJWKFromBytes(decodedCert)
 		if err != nil {
-			return nil, fmt.Errorf("bad signing key: %w", err)
+			return nil, fmt.Errorf("couldn't generate signing key: %w", err)
+		}
+		if cfg.signingKeyAlgorithm != "" && jwk.Algorithm != cfg.signingKeyAlgorithm {
+			return nil, fmt.Errorf("signing key is for %s, but the signing key algorithm is %s",
+				jwk.Algorithm, cfg.signingKeyAlgorithm)
 		}
 	}
 
-	jwk, err := cryptutil.PrivateJWKFromBytes(decodedCert)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate signing key: %w", err)
-	}
 	log.Info(context.TODO()).Str("Algorithm", jwk.Algorithm).
 		Str("KeyID", jwk.KeyID).
-		Interface("Public Key", jwk.Public()).
+		Interface("Public Key", getPublicJWK(jwk)).
 		Msg("authorize: signing key")
 
 	return jwk, nil
 }
 
+func getPublicJWK(jwk *jose.JSONWebKey) jose.JSONWebKey {
+	// the public keys of keys on a token or in a KMS are only known by their signers
+	if signer, ok := jwk.Key.(jose.OpaqueSigner); ok {
+		return *signer.Public()
+	}
+	return jwk.Public()
+}
+
 func safeEval(ctx context.Context, q rego.PreparedEvalQuery, options ...rego.EvalOption) (resultSet rego.ResultSet, err error) {
 	defer func() {
 		if e := recover(); e != nil {
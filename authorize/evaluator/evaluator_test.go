@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"math"
 	"net/http"
@@ -40,7 +41,7 @@ func TestEvaluator(t *testing.T) {
 		store.UpdateIssuer("authenticate.example.com")
 		store.UpdateJWTClaimHeaders(config.NewJWTClaimHeaders("email", "groups", "user", "CUSTOM_KEY"))
 		store.UpdateSigningKey(privateJWK)
-		e, err := New(context.Background(), store, options...)
+		e, err := New(context.Background(), store, nil, options...)
 		require.NoError(t, err)
 		return e.Evaluate(context.Background(), req)
 	}
@@ -502,6 +503,181 @@ func TestEvaluator(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, res.Allow.Value)
 	})
+	t.Run("default policy", func(t *testing.T) {
+		defaultPolicy := &config.PPLPolicy{
+			Policy: &parser.Policy{
+				Rules: []parser.Rule{{
+					Action: parser.ActionAllow,
+					Or: []parser.Criterion{{
+						Name: "http_method", Data: parser.Object{
+							"is": parser.String("GET"),
+						},
+					}},
+				}},
+			},
+		}
+		policy := config.Policy{
+			To:                               config.WeightedURLs{{URL: *mustParseURL("https://to12.example.com")}},
+			AllowPublicUnauthenticatedAccess: true,
+		}
+		skipPolicy := config.Policy{
+			To:                               config.WeightedURLs{{URL: *mustParseURL("https://to13.example.com")}},
+			AllowPublicUnauthenticatedAccess: true,
+			SkipDefaultPolicy:                true,
+		}
+		options := []Option{
+			WithAuthenticateURL("https://authn.example.com"),
+			WithPolicies([]config.Policy{policy, skipPolicy}),
+			WithDefaultPolicy(defaultPolicy),
+		}
+		t.Run("allowed", func(t *testing.T) {
+			res, err := eval(t, options, []proto.Message{}, &Request{
+				Policy: &policy,
+				HTTP:   RequestHTTP{Method: "GET", URL: "https://from.example.com"},
+			})
+			require.NoError(t, err)
+			assert.True(t, res.Allow.Value)
+		})
+		t.Run("denied", func(t *testing.T) {
+			res, err := eval(t, options, []proto.Message{}, &Request{
+				Policy: &policy,
+				HTTP:   RequestHTTP{Method: "POST", URL: "https://from.example.com"},
+			})
+			require.NoError(t, err)
+			assert.False(t, res.Allow.Value)
+			assert.True(t, res.Allow.Reasons.Has(criteria.ReasonHTTPMethodUnauthorized))
+		})
+		t.Run("skipped", func(t *testing.T) {
+			res, err := eval(t, options, []proto.Message{}, &Request{
+				Policy: &skipPolicy,
+				HTTP:   RequestHTTP{Method: "POST", URL: "https://from.example.com"},
+			})
+			require.NoError(t, err)
+			assert.True(t, res.Allow.Value)
+		})
+	})
+	t.Run("maintenance", func(t *testing.T) {
+		policy := config.Policy{
+			To:                               config.WeightedURLs{{URL: *mustParseURL("https://to14.example.com")}},
+			AllowPublicUnauthenticatedAccess: true,
+			Maintenance:                      true,
+			MaintenanceAllowedGroups:         []string{"group1@example.com"},
+		}
+		options := []Option{
+			WithAuthenticateURL("https://authn.example.com"),
+			WithPolicies([]config.Policy{policy}),
+		}
+		data := []proto.Message{
+			&session.Session{Id: "session1", UserId: "user1"},
+			&session.Session{Id: "session2", UserId: "user2"},
+			&user.User{Id: "user1", Email: "a@example.com"},
+			&user.User{Id: "user2", Email: "b@example.com"},
+			&directory.User{Id: "user1", GroupIds: []string{"group1"}},
+			&directory.Group{Id: "group1", Name: "group1name", Email: "group1@example.com"},
+		}
+		for _, tc := range []struct {
+			name      string
+			sessionID string
+			expect    bool
+		}{
+			{"anonymous", "", true},
+			{"not in group", "session2", true},
+			{"in group", "session1", false},
+		} {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				res, err := eval(t, options, data, &Request{
+					Policy:  &policy,
+					Session: RequestSession{ID: tc.sessionID},
+					HTTP:    RequestHTTP{Method: "GET", URL: "https://from.example.com"},
+				})
+				require.NoError(t, err)
+				assert.True(t, res.Allow.Value)
+				assert.Equal(t, tc.expect, res.Maintenance)
+			})
+		}
+
+		policy.Maintenance = false
+		res, err := eval(t, []Option{
+			WithAuthenticateURL("https://authn.example.com"),
+			WithPolicies([]config.Policy{policy}),
+		}, data, &Request{
+			Policy: &policy,
+			HTTP:   RequestHTTP{Method: "GET", URL: "https://from.example.com"},
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Maintenance)
+	})
+	t.Run("response header templates", func(t *testing.T) {
+		policy := config.Policy{
+			From:                             "https://from.example.com",
+			To:                               config.WeightedURLs{{URL: *mustParseURL("https://to14.example.com")}},
+			AllowPublicUnauthenticatedAccess: true,
+			SetResponseHeaders: map[string]string{
+				"X-Static": "static",
+				"X-User":   "{{.email}}",
+			},
+			AppendResponseHeaders: map[string]string{
+				"X-Route": "{{.route.from}}",
+			},
+		}
+		res, err := eval(t, []Option{
+			WithAuthenticateURL("https://authn.example.com"),
+			WithPolicies([]config.Policy{policy}),
+		}, []proto.Message{
+			&session.Session{Id: "session1", UserId: "user1"},
+			&user.User{Id: "user1", Email: "a@example.com"},
+		}, &Request{
+			Policy:  &policy,
+			Session: RequestSession{ID: "session1"},
+			HTTP:    RequestHTTP{Method: "GET", URL: "https://from.example.com"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.Header{"X-User": {"a@example.com"}}, res.ResponseHeaders)
+		assert.Equal(t, http.Header{"X-Route": {"https://from.example.com"}}, res.AppendResponseHeaders)
+	})
+	t.Run("wildcard subdomain", func(t *testing.T) {
+		policy := config.Policy{
+			From: "https://*.apps.example.com",
+			To:   config.WeightedURLs{{URL: *mustParseURL("https://*.internal.example.com")}},
+			SetRequestHeaders: map[string]string{
+				"X-App": "{{.route.subdomain}}",
+			},
+			Policy: &config.PPLPolicy{
+				Policy: &parser.Policy{
+					Rules: []parser.Rule{{
+						Action: parser.ActionAllow,
+						Or: []parser.Criterion{{
+							Name: "subdomain", Data: parser.Object{
+								"is": parser.String("app1"),
+							},
+						}},
+					}},
+				},
+			},
+		}
+		require.NoError(t, policy.Validate())
+		options := []Option{
+			WithAuthenticateURL("https://authn.example.com"),
+			WithPolicies([]config.Policy{policy}),
+		}
+
+		res, err := eval(t, options, []proto.Message{}, &Request{
+			Policy: &policy,
+			HTTP:   RequestHTTP{Method: "GET", URL: "https://app1.apps.example.com"},
+		})
+		require.NoError(t, err)
+		assert.True(t, res.Allow.Value)
+		assert.Equal(t, "app1", res.Headers.Get("X-App"))
+
+		res, err = eval(t, options, []proto.Message{}, &Request{
+			Policy: &policy,
+			HTTP:   RequestHTTP{Method: "GET", URL: "https://app2.apps.example.com"},
+		})
+		require.NoError(t, err)
+		assert.False(t, res.Allow.Value)
+		assert.True(t, res.Allow.Reasons.Has(criteria.ReasonSubdomainUnauthorized))
+	})
 }
 
 func mustParseURL(str string) *url.URL {
@@ -512,6 +688,94 @@ func mustParseURL(str string) *url.URL {
 	return u
 }
 
+func TestNew_ReusePolicyEvaluators(t *testing.T) {
+	ctx := context.Background()
+	s := store.New()
+	policies := []config.Policy{
+		{
+			To:           config.WeightedURLs{{URL: *mustParseURL("https://to1.example.com")}},
+			AllowedUsers: []string{"user1"},
+		},
+		{
+			To:           config.WeightedURLs{{URL: *mustParseURL("https://to2.example.com")}},
+			AllowedUsers: []string{"user2"},
+		},
+	}
+	defaultPolicy := &config.PPLPolicy{
+		Policy: &parser.Policy{
+			Rules: []parser.Rule{{
+				Action: parser.ActionAllow,
+				Or: []parser.Criterion{{
+					Name: "http_method", Data: parser.Object{
+						"is": parser.String("GET"),
+					},
+				}},
+			}},
+		},
+	}
+	options := func(policies []config.Policy) []Option {
+		return []Option{
+			WithAuthenticateURL("https://authn.example.com"),
+			WithPolicies(policies),
+			WithDefaultPolicy(defaultPolicy),
+		}
+	}
+	getEvaluators := func(e *Evaluator) []*PolicyEvaluator {
+		var evaluators []*PolicyEvaluator
+		for i := range policies {
+			id, err := policies[i].RouteID()
+			require.NoError(t, err)
+			evaluators = append(evaluators, e.policyEvaluators[id])
+		}
+		return evaluators
+	}
+
+	previous, err := New(ctx, s, nil, options(policies)...)
+	require.NoError(t, err)
+
+	changed := make([]config.Policy, len(policies))
+	copy(changed, policies)
+	changed[1].AllowedUsers = []string{"user3"}
+	e, err := New(ctx, s, previous, options(changed)...)
+	require.NoError(t, err)
+
+	previousEvaluators, evaluators := getEvaluators(previous), getEvaluators(e)
+	assert.Same(t, previousEvaluators[0], evaluators[0], "should reuse the unchanged policy")
+	assert.NotSame(t, previousEvaluators[1], evaluators[1], "should compile the changed policy")
+	assert.Same(t, previous.defaultEvaluator, e.defaultEvaluator, "should reuse the default policy")
+
+	e, err = New(ctx, store.New(), previous, options(policies)...)
+	require.NoError(t, err)
+	evaluators = getEvaluators(e)
+	assert.NotSame(t, previousEvaluators[0], evaluators[0], "should not reuse evaluators of another store")
+}
+
+func TestGetJWK(t *testing.T) {
+	t.Run("generated", func(t *testing.T) {
+		jwk, err := getJWK(&evaluatorConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, "ES256", jwk.Algorithm)
+
+		jwk, err = getJWK(&evaluatorConfig{signingKeyAlgorithm: "EdDSA"})
+		require.NoError(t, err)
+		assert.Equal(t, "EdDSA", jwk.Algorithm)
+	})
+	t.Run("mismatched algorithm", func(t *testing.T) {
+		signingKey, err := cryptutil.NewSigningKey()
+		require.NoError(t, err)
+		encodedSigningKey, err := cryptutil.EncodePrivateKey(signingKey)
+		require.NoError(t, err)
+
+		cfg := &evaluatorConfig{signingKey: base64.StdEncoding.EncodeToString(encodedSigningKey)}
+		_, err = getJWK(cfg)
+		assert.NoError(t, err)
+
+		cfg.signingKeyAlgorithm = "RS256"
+		_, err = getJWK(cfg)
+		assert.Error(t, err)
+	})
+}
+
 func BenchmarkEvaluator_Evaluate(b *testing.B) {
 	store := store.New()
 
@@ -529,7 +793,7 @@ func BenchmarkEvaluator_Evaluate(b *testing.B) {
 		WithPolicies(policies),
 	}
 
-	e, err := New(context.Background(), store, options...)
+	e, err := New(context.Background(), store, nil, options...)
 	if !assert.NoError(b, err) {
 		return
 	}
@@ -4,16 +4,26 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/revocation"
 )
 
 var isValidClientCertificateCache, _ = lru.New2Q(100)
 
-func isValidClientCertificate(ca, cert string) (bool, error) {
+// isValidClientCertificate returns true if the client certificate is signed by the CA and, when
+// revocation checks are configured, isn't revoked. Depending on the revocation mode a
+// certificate whose revocation status is unknown is accepted or rejected.
+func isValidClientCertificate(
+	ctx context.Context,
+	ca, cert string,
+	revocationChecker *revocation.Checker,
+	revocationMode revocation.Mode,
+) (bool, error) {
 	// when ca is the empty string, client certificates are always accepted
 	if ca == "" {
 		return true, nil
@@ -24,11 +34,39 @@ func isValidClientCertificate(ca, cert string) (bool, error) {
 		return false, nil
 	}
 
+	chain, err := verifyClientCertificate(ca, cert)
+	if err != nil || chain == nil {
+		return false, err
+	}
+
+	// the revocation status isn't cached with the verified chain, since it changes over time
+	if revocationChecker == nil || !revocationChecker.Enabled() || len(chain) < 2 {
+		return true, nil
+	}
+	err = revocationChecker.Check(ctx, chain[0], chain[1])
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, revocation.ErrRevoked):
+		log.Debug(ctx).Str("serial_number", chain[0].SerialNumber.String()).Msg("client certificate is revoked")
+		return false, nil
+	case revocationMode == revocation.HardFail:
+		log.Warn(ctx).Err(err).Msg("rejecting client certificate with an unknown revocation status")
+		return false, nil
+	default:
+		log.Warn(ctx).Err(err).Msg("accepting client certificate with an unknown revocation status")
+		return true, nil
+	}
+}
+
+// verifyClientCertificate returns the verified chain of the client certificate, or nil when it
+// isn't signed by the CA.
+func verifyClientCertificate(ca, cert string) ([]*x509.Certificate, error) {
 	cacheKey := [2]string{ca, cert}
 
 	value, ok := isValidClientCertificateCache.Get(cacheKey)
 	if ok {
-		return value.(bool), nil
+		return value.([]*x509.Certificate), nil
 	}
 
 	roots := x509.NewCertPool()
@@ -36,22 +74,23 @@ func isValidClientCertificate(ca, cert string) (bool, error) {
 
 	xcert, err := parseCertificate(cert)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	_, verifyErr := xcert.Verify(x509.VerifyOptions{
+	chains, verifyErr := xcert.Verify(x509.VerifyOptions{
 		Roots:     roots,
 		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	})
-	valid := verifyErr == nil
-
+	var chain []*x509.Certificate
 	if verifyErr != nil {
 		log.Debug(context.Background()).Err(verifyErr).Msg("client certificate failed verification: %w")
+	} else {
+		chain = chains[0]
 	}
 
-	isValidClientCertificateCache.Add(cacheKey, valid)
+	isValidClientCertificateCache.Add(cacheKey, chain)
 
-	return valid, nil
+	return chain, nil
 }
 
 func parseCertificate(pemStr string) (*x509.Certificate, error) {
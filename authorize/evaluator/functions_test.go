@@ -1,9 +1,21 @@
 package evaluator
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/revocation"
 )
 
 const (
@@ -95,28 +107,85 @@ Y+E5W+FKfIBv9yvdNBYZsL6IZ0Yh1ctKwB5gnajO8+swx5BeaCIbBrCtOBSB
 
 func Test_isValidClientCertificate(t *testing.T) {
 	t.Run("no ca", func(t *testing.T) {
-		valid, err := isValidClientCertificate("", "WHATEVER!")
+		valid, err := isValidClientCertificate(context.Background(), "", "WHATEVER!", nil, revocation.SoftFail)
 		assert.NoError(t, err, "should not return an error")
 		assert.True(t, valid, "should return true")
 	})
 	t.Run("no cert", func(t *testing.T) {
-		valid, err := isValidClientCertificate(testCA, "")
+		valid, err := isValidClientCertificate(context.Background(), testCA, "", nil, revocation.SoftFail)
 		assert.NoError(t, err, "should not return an error")
 		assert.False(t, valid, "should return false")
 	})
 	t.Run("valid cert", func(t *testing.T) {
-		valid, err := isValidClientCertificate(testCA, testValidCert)
+		valid, err := isValidClientCertificate(context.Background(), testCA, testValidCert, nil, revocation.SoftFail)
 		assert.NoError(t, err, "should not return an error")
 		assert.True(t, valid, "should return true")
 	})
 	t.Run("unsigned cert", func(t *testing.T) {
-		valid, err := isValidClientCertificate(testCA, testUnsignedCert)
+		valid, err := isValidClientCertificate(context.Background(), testCA, testUnsignedCert, nil, revocation.SoftFail)
 		assert.NoError(t, err, "should not return an error")
 		assert.False(t, valid, "should return false")
 	})
 	t.Run("not a cert", func(t *testing.T) {
-		valid, err := isValidClientCertificate(testCA, "WHATEVER!")
+		valid, err := isValidClientCertificate(context.Background(), testCA, "WHATEVER!", nil, revocation.SoftFail)
 		assert.Error(t, err, "should return an error")
 		assert.False(t, valid, "should return false")
 	})
 }
+
+func Test_isValidClientCertificate_revoked(t *testing.T) {
+	newCert := func(tpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		if parent == nil {
+			parent, parentKey = tpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert, key
+	}
+	ca, caKey := newCert(&x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, nil)
+	cert, _ := newCert(&x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificates: []pkix.RevokedCertificate{
+			{SerialNumber: cert.SerialNumber, RevocationTime: time.Now()},
+		},
+	}, ca, caKey)
+	require.NoError(t, err)
+	crl, err := x509.ParseCRL(crlDER)
+	require.NoError(t, err)
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}))
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	ctx := context.Background()
+
+	checker := revocation.New()
+	checker.UpdateConfig(revocation.Config{OCSP: true})
+	valid, err := isValidClientCertificate(ctx, caPEM, certPEM, checker, revocation.SoftFail)
+	assert.NoError(t, err)
+	assert.True(t, valid, "should accept an unknown revocation status in soft fail mode")
+	valid, err = isValidClientCertificate(ctx, caPEM, certPEM, checker, revocation.HardFail)
+	assert.NoError(t, err)
+	assert.False(t, valid, "should reject an unknown revocation status in hard fail mode")
+
+	checker.UpdateConfig(revocation.Config{CRLs: []*pkix.CertificateList{crl}})
+	valid, err = isValidClientCertificate(ctx, caPEM, certPEM, checker, revocation.SoftFail)
+	assert.NoError(t, err)
+	assert.False(t, valid, "should reject a revoked certificate")
+}
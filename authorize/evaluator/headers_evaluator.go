@@ -20,20 +20,30 @@ import (
 type HeadersRequest struct {
 	EnableGoogleCloudServerlessAuthentication bool           `json:"enable_google_cloud_serverless_authentication"`
 	EnableRoutingKey                          bool           `json:"enable_routing_key"`
+	RoutingKeyHashOn                          string         `json:"routing_key_hash_on,omitempty"`
 	FromAudience                              string         `json:"from_audience"`
 	KubernetesServiceAccountToken             string         `json:"kubernetes_service_account_token"`
 	ToAudience                                string         `json:"to_audience"`
 	Session                                   RequestSession `json:"session"`
 	PassAccessToken                           bool           `json:"pass_access_token"`
 	PassIDToken                               bool           `json:"pass_id_token"`
+	JWTAudience                               string         `json:"jwt_audience"`
+	JWTClaims                                 []string       `json:"jwt_claims"`
 }
 
 // NewHeadersRequestFromPolicy creates a new HeadersRequest from a policy.
 func NewHeadersRequestFromPolicy(policy *config.Policy) *HeadersRequest {
 	input := new(HeadersRequest)
 	input.EnableGoogleCloudServerlessAuthentication = policy.EnableGoogleCloudServerlessAuthentication
-	input.EnableRoutingKey = policy.EnvoyOpts.GetLbPolicy() == envoy_config_cluster_v3.Cluster_RING_HASH ||
-		policy.EnvoyOpts.GetLbPolicy() == envoy_config_cluster_v3.Cluster_MAGLEV
+	input.EnableRoutingKey = policy.GetLbPolicy() == envoy_config_cluster_v3.Cluster_RING_HASH ||
+		policy.GetLbPolicy() == envoy_config_cluster_v3.Cluster_MAGLEV
+	if policy.SessionAffinity != nil {
+		input.RoutingKeyHashOn = policy.SessionAffinity.GetHashOn()
+		// cookie affinity is handled by envoy
+		if input.RoutingKeyHashOn == config.SessionAffinityHashOnCookie {
+			input.EnableRoutingKey = false
+		}
+	}
 	if u, err := urlutil.ParseAndValidateURL(policy.From); err == nil {
 		input.FromAudience = u.Hostname()
 	}
@@ -43,12 +53,16 @@ func NewHeadersRequestFromPolicy(policy *config.Policy) *HeadersRequest {
 	}
 	input.PassAccessToken = policy.GetSetAuthorizationHeader() == configpb.Route_ACCESS_TOKEN
 	input.PassIDToken = policy.GetSetAuthorizationHeader() == configpb.Route_ID_TOKEN
+	input.JWTAudience = policy.JWTAudience
+	input.JWTClaims = policy.JWTClaims
 	return input
 }
 
 // HeadersResponse is the output from the headers.rego script.
 type HeadersResponse struct {
 	Headers http.Header
+	// TemplateData is the data set_request_headers templates are rendered with.
+	TemplateData map[string]interface{}
 }
 
 // A HeadersEvaluator evaluates the headers.rego script.
@@ -64,6 +78,7 @@ func NewHeadersEvaluator(ctx context.Context, store *store.Store) (*HeadersEvalu
 		rego.Query("result = data.pomerium.headers"),
 		getGoogleCloudServerlessHeadersRegoOption,
 		store.GetDataBrokerRecordOption(),
+		store.GetSignJWTOption(),
 	)
 
 	q, err := r.PrepareForEval(ctx)
@@ -80,7 +95,7 @@ func NewHeadersEvaluator(ctx context.Context, store *store.Store) (*HeadersEvalu
 func (e *HeadersEvaluator) Evaluate(ctx context.Context, req *HeadersRequest) (*HeadersResponse, error) {
 	_, span := trace.StartSpan(ctx, "authorize.HeadersEvaluator.Evaluate")
 	defer span.End()
-	rs, err := safeEval(ctx, e.q, rego.EvalInput(req))
+	rs, err := safeEval(ctx, e.q, rego.EvalParsedInput(req.value()))
 	if err != nil {
 		return nil, fmt.Errorf("authorize: error evaluating headers.rego: %w", err)
 	}
@@ -90,7 +105,8 @@ func (e *HeadersEvaluator) Evaluate(ctx context.Context, req *HeadersRequest) (*
 	}
 
 	return &HeadersResponse{
-		Headers: e.getHeader(rs[0].Bindings),
+		Headers:      e.getHeader(rs[0].Bindings),
+		TemplateData: e.getTemplateData(rs[0].Bindings),
 	}, nil
 }
 
@@ -118,3 +134,13 @@ func (e *HeadersEvaluator) getHeader(vars rego.Vars) http.Header {
 	}
 	return h
 }
+
+func (e *HeadersEvaluator) getTemplateData(vars rego.Vars) map[string]interface{} {
+	m, ok := vars["result"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	data, _ := m["template_data"].(map[string]interface{})
+	return data
+}
@@ -2,10 +2,13 @@ package evaluator
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"math"
 	"testing"
 	"time"
 
+	"github.com/go-jose/go-jose/v3"
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,14 +32,31 @@ func TestNewHeadersRequestFromPolicy(t *testing.T) {
 				URL: *mustParseURL("http://to.example.com"),
 			},
 		},
+		JWTAudience: "aud.example.com",
+		JWTClaims:   []string{"email"},
 	})
 	assert.Equal(t, &HeadersRequest{
 		EnableGoogleCloudServerlessAuthentication: true,
 		FromAudience: "from.example.com",
 		ToAudience:   "https://to.example.com",
+		JWTAudience:  "aud.example.com",
+		JWTClaims:    []string{"email"},
 	}, req)
 }
 
+func TestNewHeadersRequestFromPolicySessionAffinity(t *testing.T) {
+	req := NewHeadersRequestFromPolicy(&config.Policy{
+		SessionAffinity: &config.PolicySessionAffinity{},
+	})
+	assert.True(t, req.EnableRoutingKey)
+	assert.Equal(t, config.SessionAffinityHashOnUser, req.RoutingKeyHashOn)
+
+	req = NewHeadersRequestFromPolicy(&config.Policy{
+		SessionAffinity: &config.PolicySessionAffinity{HashOn: config.SessionAffinityHashOnCookie},
+	})
+	assert.False(t, req.EnableRoutingKey)
+}
+
 func TestHeadersEvaluator(t *testing.T) {
 	type A = []interface{}
 	type M = map[string]interface{}
@@ -114,6 +134,101 @@ func TestHeadersEvaluator(t *testing.T) {
 		assert.Equal(t, "n1", claims["name"], "should set name")
 	})
 
+	t.Run("jwt audience and claims", func(t *testing.T) {
+		output, err := eval(t,
+			[]proto.Message{
+				&session.Session{Id: "s1", UserId: "u1"},
+				&user.User{Id: "u1", Email: "u1@example.com"},
+			},
+			&HeadersRequest{
+				FromAudience: "from.example.com",
+				ToAudience:   "to.example.com",
+				Session:      RequestSession{ID: "s1"},
+				JWTAudience:  "api://example",
+				JWTClaims:    []string{"aud", "sub", "email"},
+			})
+		require.NoError(t, err)
+
+		rawJWT, err := jwt.ParseSigned(output.Headers.Get("X-Pomerium-Jwt-Assertion"))
+		require.NoError(t, err)
+
+		var claims M
+		err = rawJWT.Claims(publicJWK, &claims)
+		require.NoError(t, err)
+
+		assert.Equal(t, M{
+			"aud":   "api://example",
+			"sub":   "u1",
+			"email": "u1@example.com",
+		}, claims)
+		assert.Equal(t, "u1@example.com", output.Headers.Get("X-Pomerium-Claim-Email"),
+			"should not filter claim headers")
+	})
+
+	t.Run("request header templates", func(t *testing.T) {
+		output, err := eval(t,
+			[]proto.Message{
+				&session.Session{Id: "s1", UserId: "u1", Claims: map[string]*structpb.ListValue{
+					"department": {Values: []*structpb.Value{
+						structpb.NewStringValue("Engineering"),
+					}},
+				}},
+				&user.User{Id: "u1", Email: "u1@example.com"},
+				&directory.User{Id: "u1", GroupIds: []string{"g1", "g2"}},
+			},
+			&HeadersRequest{
+				FromAudience: "from.example.com",
+				ToAudience:   "to.example.com",
+				Session:      RequestSession{ID: "s1"},
+			})
+		require.NoError(t, err)
+
+		policy := &config.Policy{SetRequestHeaders: map[string]string{
+			"Remote-User":     "{{.email}}",
+			"X-Remote-Groups": `{{.groups | join ","}}`,
+			"X-Department":    "{{.claims.department | lower}}",
+			"X-Session":       "{{.session.id}}",
+			"X-Missing":       "{{.claims.missing}}",
+			"X-Static-Header": "static",
+		}}
+		headers, err := policy.RenderRequestHeaderTemplates(output.TemplateData)
+		assert.Error(t, err, "should return an error for the missing claim")
+		assert.Equal(t, map[string]string{
+			"Remote-User":     "u1@example.com",
+			"X-Remote-Groups": "g1,g2",
+			"X-Department":    "engineering",
+			"X-Session":       "s1",
+		}, headers)
+	})
+
+	t.Run("eddsa", func(t *testing.T) {
+		privateJWK, err := cryptutil.NewPrivateJWK(jose.EdDSA)
+		require.NoError(t, err)
+
+		store := store.NewFromProtos(math.MaxUint64, &session.Session{Id: "s1", UserId: "u1"})
+		store.UpdateIssuer("authenticate.example.com")
+		store.UpdateSigningKey(privateJWK)
+		e, err := NewHeadersEvaluator(context.Background(), store)
+		require.NoError(t, err)
+		output, err := e.Evaluate(context.Background(), &HeadersRequest{
+			FromAudience: "from.example.com",
+			Session:      RequestSession{ID: "s1"},
+		})
+		require.NoError(t, err)
+
+		rawJWT, err := jwt.ParseSigned(output.Headers.Get("X-Pomerium-Jwt-Assertion"))
+		require.NoError(t, err)
+		require.Len(t, rawJWT.Headers, 1)
+		assert.Equal(t, "EdDSA", rawJWT.Headers[0].Algorithm)
+		assert.Equal(t, privateJWK.KeyID, rawJWT.Headers[0].KeyID)
+
+		var claims M
+		err = rawJWT.Claims(privateJWK.Public(), &claims)
+		require.NoError(t, err)
+		assert.Equal(t, "from.example.com", claims["aud"])
+		assert.Equal(t, "u1", claims["sub"])
+	})
+
 	t.Run("access token", func(t *testing.T) {
 		output, err := eval(t,
 			[]proto.Message{
@@ -149,4 +264,23 @@ func TestHeadersEvaluator(t *testing.T) {
 
 		assert.Equal(t, "Bearer ID_TOKEN", output.Headers.Get("Authorization"))
 	})
+	t.Run("routing key", func(t *testing.T) {
+		data := []proto.Message{
+			&session.Session{Id: "s1", UserId: "u1"},
+		}
+		output, err := eval(t, data, &HeadersRequest{
+			EnableRoutingKey: true,
+			Session:          RequestSession{ID: "s1"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte("s1"))), output.Headers.Get("X-Pomerium-Routing-Key"))
+
+		output, err = eval(t, data, &HeadersRequest{
+			EnableRoutingKey: true,
+			RoutingKeyHashOn: config.SessionAffinityHashOnUser,
+			Session:          RequestSession{ID: "s1"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte("u1"))), output.Headers.Get("X-Pomerium-Routing-Key"))
+	})
 }
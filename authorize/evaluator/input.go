@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"github.com/open-policy-agent/opa/ast"
+)
+
+// The rego inputs are built directly as ast values. Passing the requests to rego.EvalInput
+// round trips them through JSON on every evaluation, which is most of the allocations of
+// building the input.
+
+func (req *PolicyRequest) value() ast.Value {
+	return ast.NewObject(
+		ast.Item(ast.StringTerm("http"), ast.NewTerm(req.HTTP.value())),
+		ast.Item(ast.StringTerm("session"), ast.NewTerm(req.Session.value())),
+		ast.Item(ast.StringTerm("subdomain"), ast.StringTerm(req.Subdomain)),
+		ast.Item(ast.StringTerm("is_valid_client_certificate"), ast.BooleanTerm(req.IsValidClientCertificate)),
+	)
+}
+
+func (req *RequestHTTP) value() ast.Value {
+	return ast.NewObject(
+		ast.Item(ast.StringTerm("method"), ast.StringTerm(req.Method)),
+		ast.Item(ast.StringTerm("path"), ast.StringTerm(req.Path)),
+		ast.Item(ast.StringTerm("url"), ast.StringTerm(req.URL)),
+		ast.Item(ast.StringTerm("headers"), stringMapTerm(req.Headers)),
+		ast.Item(ast.StringTerm("client_certificate"), ast.StringTerm(req.ClientCertificate)),
+		ast.Item(ast.StringTerm("ip"), ast.StringTerm(req.IP)),
+	)
+}
+
+func (req *RequestSession) value() ast.Value {
+	return ast.NewObject(
+		ast.Item(ast.StringTerm("id"), ast.StringTerm(req.ID)),
+	)
+}
+
+func (req *HeadersRequest) value() ast.Value {
+	obj := ast.NewObject(
+		ast.Item(ast.StringTerm("enable_google_cloud_serverless_authentication"),
+			ast.BooleanTerm(req.EnableGoogleCloudServerlessAuthentication)),
+		ast.Item(ast.StringTerm("enable_routing_key"), ast.BooleanTerm(req.EnableRoutingKey)),
+		ast.Item(ast.StringTerm("from_audience"), ast.StringTerm(req.FromAudience)),
+		ast.Item(ast.StringTerm("kubernetes_service_account_token"), ast.StringTerm(req.KubernetesServiceAccountToken)),
+		ast.Item(ast.StringTerm("to_audience"), ast.StringTerm(req.ToAudience)),
+		ast.Item(ast.StringTerm("session"), ast.NewTerm(req.Session.value())),
+		ast.Item(ast.StringTerm("pass_access_token"), ast.BooleanTerm(req.PassAccessToken)),
+		ast.Item(ast.StringTerm("pass_id_token"), ast.BooleanTerm(req.PassIDToken)),
+		ast.Item(ast.StringTerm("jwt_audience"), ast.StringTerm(req.JWTAudience)),
+		ast.Item(ast.StringTerm("jwt_claims"), stringSliceTerm(req.JWTClaims)),
+	)
+	if req.RoutingKeyHashOn != "" {
+		obj.Insert(ast.StringTerm("routing_key_hash_on"), ast.StringTerm(req.RoutingKeyHashOn))
+	}
+	return obj
+}
+
+// stringMapTerm returns the term of a map, which like in JSON is null when the map is nil.
+func stringMapTerm(m map[string]string) *ast.Term {
+	if m == nil {
+		return ast.NullTerm()
+	}
+	items := make([][2]*ast.Term, 0, len(m))
+	for k, v := range m {
+		items = append(items, ast.Item(ast.StringTerm(k), ast.StringTerm(v)))
+	}
+	return ast.NewTerm(ast.NewObject(items...))
+}
+
+// stringSliceTerm returns the term of a slice, which like in JSON is null when the slice is nil.
+func stringSliceTerm(s []string) *ast.Term {
+	if s == nil {
+		return ast.NullTerm()
+	}
+	terms := make([]*ast.Term, 0, len(s))
+	for _, v := range s {
+		terms = append(terms, ast.StringTerm(v))
+	}
+	return ast.ArrayTerm(terms...)
+}
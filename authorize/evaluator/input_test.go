@@ -0,0 +1,64 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputValues(t *testing.T) {
+	// the values must be the same as the ones rego.EvalInput creates with a JSON round trip
+	roundTrip := func(t *testing.T, input interface{}) ast.Value {
+		t.Helper()
+
+		var v interface{} = input
+		require.NoError(t, util.RoundTrip(&v))
+		value, err := ast.InterfaceToValue(v)
+		require.NoError(t, err)
+		return value
+	}
+
+	t.Run("policy request", func(t *testing.T) {
+		for _, req := range []*PolicyRequest{
+			{},
+			{
+				HTTP: RequestHTTP{
+					Method:            "GET",
+					Path:              "/path",
+					URL:               "https://from.example.com/path",
+					Headers:           map[string]string{"X-A": "1", "X-B": "2"},
+					ClientCertificate: "CERT",
+					IP:                "127.0.0.1",
+				},
+				Session:                  RequestSession{ID: "SESSION_ID"},
+				Subdomain:                "from",
+				IsValidClientCertificate: true,
+			},
+		} {
+			assert.Equal(t, 0, roundTrip(t, req).Compare(req.value()))
+		}
+	})
+	t.Run("headers request", func(t *testing.T) {
+		for _, req := range []*HeadersRequest{
+			{},
+			{
+				EnableGoogleCloudServerlessAuthentication: true,
+				EnableRoutingKey:              true,
+				RoutingKeyHashOn:              "header",
+				FromAudience:                  "from.example.com",
+				KubernetesServiceAccountToken: "TOKEN",
+				ToAudience:                    "https://to.example.com",
+				Session:                       RequestSession{ID: "SESSION_ID"},
+				PassAccessToken:               true,
+				PassIDToken:                   true,
+				JWTAudience:                   "audience",
+				JWTClaims:                     []string{"email", "groups"},
+			},
+		} {
+			assert.Equal(t, 0, roundTrip(t, req).Compare(req.value()))
+		}
+	})
+}
@@ -3,7 +3,10 @@ package pomerium.headers
 # input:
 #   enable_google_cloud_serverless_authentication: boolean
 #   enable_routing_key: boolean
+#   routing_key_hash_on: string
 #   from_audience: string
+#   jwt_audience: string
+#   jwt_claims: []string
 #   kubernetes_service_account_token: string
 #   session:
 #     id: string
@@ -14,17 +17,16 @@ package pomerium.headers
 # data:
 #   issuer: string
 #   jwt_claim_headers: map[string]string
-#   signing_key:
-#     alg: string
-#     kid: string
 #
 # functions:
 #   get_databroker_record
 #   get_google_cloud_serverless_headers
+#   sign_jwt
 #
 #
 # output:
 #   identity_headers: map[string][]string
+#   template_data: map[string]any
 
 # 5 minutes from now in seconds
 five_minutes := round((time.now_ns() / 1e9) + (60 * 5))
@@ -74,13 +76,10 @@ group_ids = gs {
 
 groups := array.concat(group_ids, array.concat(get_databroker_group_names(group_ids), get_databroker_group_emails(group_ids)))
 
-jwt_headers = {
-	"typ": "JWT",
-	"alg": data.signing_key.alg,
-	"kid": data.signing_key.kid,
-}
-
 jwt_payload_aud = v {
+	v := input.jwt_audience
+	v != ""
+} else = v {
 	v := input.from_audience
 } else = "" {
 	true
@@ -186,13 +185,60 @@ additional_jwt_claims := [[k, v] |
 
 jwt_claims := array.concat(base_jwt_claims, additional_jwt_claims)
 
+# when jwt_claims is set, only those claims are included in the jwt
+jwt_selected_claims = v {
+	v := input.jwt_claims
+	is_array(v)
+	count(v) > 0
+} else = null {
+	true
+}
+
+is_jwt_claim_selected(key) = true {
+	jwt_selected_claims == null
+} else = true {
+	jwt_selected_claims[_] == key
+} else = false {
+	true
+}
+
 jwt_payload = {key: value |
 	# use a comprehension over an array to remove nil values
 	[key, value] := jwt_claims[_]
 	value != null
+	is_jwt_claim_selected(key)
+}
+
+signed_jwt = sign_jwt(jwt_payload)
+
+# the data set_request_headers templates are rendered with: the jwt claims, the identity
+# provider claims and the session
+template_claims = {key: value |
+	[key, value] := jwt_claims[_]
+	value != null
+}
+
+template_identity_claims = {key: value |
+	claims := object.union(object.get(user, "claims", {}), object.get(session, "claims", {}))
+	some key
+	value := get_template_claim_value(claims[key])
 }
 
-signed_jwt = io.jwt.encode_sign(jwt_headers, jwt_payload, data.signing_key)
+template_session_expires_at = v {
+	v = round(session.expires_at.seconds)
+} else = null {
+	true
+}
+
+template_data = object.union(template_claims, {
+	"claims": template_identity_claims,
+	"session": {
+		"id": jwt_payload_sid,
+		"user_id": jwt_payload_user,
+		"issued_at": jwt_payload_iat,
+		"expires_at": template_session_expires_at,
+	},
+})
 
 kubernetes_headers = h {
 	input.kubernetes_service_account_token != ""
@@ -219,6 +265,10 @@ google_cloud_serverless_headers = h {
 }
 
 routing_key_headers = h {
+	input.enable_routing_key
+	input.routing_key_hash_on == "user"
+	h := [["x-pomerium-routing-key", crypto.sha256(session.user_id)]]
+} else = h {
 	input.enable_routing_key
 	h := [["x-pomerium-routing-key", crypto.sha256(input.session.id)]]
 } else = [] {
@@ -280,6 +330,15 @@ get_databroker_group_emails(ids) = gs {
 	gs := [email | id := ids[i]; group := get_databroker_record("type.googleapis.com/directory.Group", id); email := group.email]
 }
 
+# claims are stored as lists, use the value of single value claims
+get_template_claim_value(obj) = v {
+	is_array(obj)
+	count(obj) == 1
+	v := obj[0]
+} else = obj {
+	true
+}
+
 get_header_string_value(obj) = s {
 	is_array(obj)
 	s := concat(",", obj)
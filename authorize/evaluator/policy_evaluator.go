@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 	octrace "go.opencensus.io/trace"
 
@@ -21,6 +22,7 @@ import (
 type PolicyRequest struct {
 	HTTP                     RequestHTTP    `json:"http"`
 	Session                  RequestSession `json:"session"`
+	Subdomain                string         `json:"subdomain"`
 	IsValidClientCertificate bool           `json:"is_valid_client_certificate"`
 }
 
@@ -87,20 +89,39 @@ func MergeRuleResultsWithOr(results ...RuleResult) RuleResult {
 	return merged
 }
 
+// MergeRuleResultsWithAnd merges all the results using `and`.
+func MergeRuleResultsWithAnd(results ...RuleResult) RuleResult {
+	merged := NewRuleResult(true)
+
+	var falseResults []RuleResult
+	for _, result := range results {
+		if !result.Value {
+			falseResults = append(falseResults, result)
+		}
+	}
+
+	if len(falseResults) > 0 {
+		merged.Value = false
+		results = falseResults
+	}
+	for _, result := range results {
+		merged.Reasons = merged.Reasons.Union(result.Reasons)
+		for k, v := range result.AdditionalData {
+			merged.AdditionalData[k] = v
+		}
+	}
+
+	return merged
+}
+
 type policyQuery struct {
 	rego.PreparedEvalQuery
 	checksum string
 }
 
-// A PolicyEvaluator evaluates policies.
-type PolicyEvaluator struct {
-	queries []policyQuery
-}
-
-// NewPolicyEvaluator creates a new PolicyEvaluator.
-func NewPolicyEvaluator(ctx context.Context, store *store.Store, configPolicy *config.Policy) (*PolicyEvaluator, error) {
-	e := new(PolicyEvaluator)
-
+// GetPolicyScripts returns the rego scripts evaluated for the policy: the rego generated from
+// the policy, followed by its custom rego.
+func GetPolicyScripts(configPolicy *config.Policy) ([]string, error) {
 	// generate the base rego script for the policy
 	ppl := configPolicy.ToPPL()
 	base, err := policy.GenerateRegoFromPolicy(ppl)
@@ -120,6 +141,24 @@ func NewPolicyEvaluator(ctx context.Context, store *store.Store, configPolicy *c
 			scripts = append(scripts, src)
 		}
 	}
+	return scripts, nil
+}
+
+// A PolicyEvaluator evaluates policies.
+type PolicyEvaluator struct {
+	queries []policyQuery
+	// inputs are the fields of the input read by the queries
+	inputs policyInputs
+}
+
+// NewPolicyEvaluator creates a new PolicyEvaluator.
+func NewPolicyEvaluator(ctx context.Context, store *store.Store, configPolicy *config.Policy) (*PolicyEvaluator, error) {
+	e := &PolicyEvaluator{inputs: policyInputs{}}
+
+	scripts, err := GetPolicyScripts(configPolicy)
+	if err != nil {
+		return nil, err
+	}
 
 	// for each script, create a rego and prepare a query.
 	for _, script := range scripts {
@@ -157,17 +196,30 @@ func NewPolicyEvaluator(ctx context.Context, store *store.Store, configPolicy *c
 			PreparedEvalQuery: q,
 			checksum:          fmt.Sprintf("%x", cryptutil.Hash("script", []byte(script))),
 		})
+		e.inputs = e.inputs.merge(getPolicyInputs(script))
 	}
 
 	return e, nil
 }
 
+// ValidatePolicy returns an error if the rego scripts of the policy don't compile.
+func ValidatePolicy(ctx context.Context, configPolicy *config.Policy) error {
+	_, err := NewPolicyEvaluator(ctx, store.New(), configPolicy)
+	return err
+}
+
 // Evaluate evaluates the policy rego scripts.
 func (e *PolicyEvaluator) Evaluate(ctx context.Context, req *PolicyRequest) (*PolicyResponse, error) {
+	return e.evaluate(ctx, req.value())
+}
+
+// evaluate evaluates the policy rego scripts with an input built from a PolicyRequest, so
+// several policies can be evaluated with the same input.
+func (e *PolicyEvaluator) evaluate(ctx context.Context, input ast.Value) (*PolicyResponse, error) {
 	res := NewPolicyResponse()
 	// run each query and merge the results
 	for _, query := range e.queries {
-		o, err := e.evaluateQuery(ctx, req, query)
+		o, err := e.evaluateQuery(ctx, input, query)
 		if err != nil {
 			return nil, err
 		}
@@ -177,12 +229,12 @@ func (e *PolicyEvaluator) Evaluate(ctx context.Context, req *PolicyRequest) (*Po
 	return res, nil
 }
 
-func (e *PolicyEvaluator) evaluateQuery(ctx context.Context, req *PolicyRequest, query policyQuery) (*PolicyResponse, error) {
+func (e *PolicyEvaluator) evaluateQuery(ctx context.Context, input ast.Value, query policyQuery) (*PolicyResponse, error) {
 	_, span := trace.StartSpan(ctx, "authorize.PolicyEvaluator.evaluateQuery")
 	defer span.End()
 	span.AddAttributes(octrace.StringAttribute("script_checksum", query.checksum))
 
-	rs, err := safeEval(ctx, query.PreparedEvalQuery, rego.EvalInput(req))
+	rs, err := safeEval(ctx, query.PreparedEvalQuery, rego.EvalParsedInput(input))
 	if err != nil {
 		return nil, fmt.Errorf("authorize: error evaluating policy.rego: %w", err)
 	}
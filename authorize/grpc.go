@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	octrace "go.opencensus.io/trace"
 
 	"github.com/pomerium/pomerium/authorize/evaluator"
 	"github.com/pomerium/pomerium/config"
@@ -16,11 +18,23 @@ import (
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
 )
 
+// identityProviderHealthMaxAge is how many health check intervals the health of an identity
+// provider stored by authenticate is used for.
+const identityProviderHealthMaxAge = 3
+
 // Check implements the envoy auth server gRPC endpoint.
 func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRequest) (out *envoy_service_auth_v3.CheckResponse, err error) {
-	ctx, span := trace.StartSpan(ctx, "authorize.grpc.Check")
+	// convert the incoming envoy-style http request into a go-style http request
+	hreq := getHTTPRequestFromCheckRequest(in)
+
+	ctx, span := a.startCheckSpan(ctx, hreq)
 	defer span.End()
 
 	// wait for the initial sync to complete so that data is available for evaluation
@@ -30,9 +44,6 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 
 	state := a.state.Load()
 
-	// convert the incoming envoy-style http request into a go-style http request
-	hreq := getHTTPRequestFromCheckRequest(in)
-
 	isForwardAuth := a.isForwardAuth(in)
 	if isForwardAuth {
 		// update the incoming http request's uri to match the forwarded URI
@@ -52,6 +63,15 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 	if err != nil {
 		log.Warn(ctx).Err(err).Msg("clearing session due to force sync failed")
 		sessionState = nil
+		// the session was revoked, so its JWT is verified again if it's reused
+		if state.jwtVerificationCache != nil {
+			state.jwtVerificationCache.Invalidate(rawJWT)
+		}
+	}
+	if sa, ok := s.(*user.ServiceAccount); ok && !isServiceAccountAllowed(sa, getCheckRequestURL(in), time.Now()) {
+		log.Warn(ctx).Str("service-account-id", sa.GetId()).
+			Msg("clearing session due to expired service account or audience mismatch")
+		sessionState, s, u = nil, nil, nil
 	}
 
 	req, err := a.getEvaluatorRequestFromCheckRequest(in, sessionState)
@@ -60,6 +80,16 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 		return nil, err
 	}
 
+	// rate limited requests are rejected before they're evaluated
+	if !a.localRateLimiter.allow(req, s) {
+		return a.deniedResponse(ctx, in, http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests),
+			map[string]string{"Retry-After": "1"})
+	}
+	if allowed, retryAfter := a.checkRateLimit(ctx, req, s); !allowed {
+		return a.deniedResponse(ctx, in, http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests),
+			map[string]string{"Retry-After": getRetryAfter(retryAfter)})
+	}
+
 	// take the state lock here so we don't update while evaluating
 	a.stateLock.RLock()
 	res, err := state.evaluator.Evaluate(ctx, req)
@@ -69,11 +99,32 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 		return nil, err
 	}
 	defer func() {
+		a.setAccessLogMetadata(out, res, s, u)
 		a.logAuthorizeCheck(ctx, in, out, res, s, u)
+		publishAccessDeniedEvent(ctx, in, out, res, s, u)
 	}()
 
 	isForwardAuthVerify := isForwardAuth && hreq.URL.Path == "/verify"
 
+	switch a.checkSession(ctx, req.Policy, s, req.HTTP.ClientCertificate, time.Now()) {
+	case sessionCheckRenew:
+		return a.requireSessionRenewalResponse(ctx, in, req, s.(*session.Session), isForwardAuthVerify)
+	case sessionCheckReauthenticate:
+		return a.requireReauthenticationResponse(ctx, in, req, s.(*session.Session), isForwardAuthVerify)
+	case sessionCheckImpersonationDenied:
+		return a.deniedResponse(ctx, in, http.StatusForbidden, "Impersonation is not approved or has expired", nil)
+	case sessionCheckClientCertificateMismatch:
+		return a.deniedResponse(ctx, in, httputil.StatusInvalidClientCertificate,
+			"The session is bound to a different client certificate", nil)
+	}
+	a.trackAccess(s)
+
+	// routes in maintenance mode are unavailable, once the user has signed in if the route
+	// requires it, so the users allowed to access the route during maintenance are recognized.
+	if res.Maintenance && !requiresSignIn(res) {
+		return a.maintenanceResponse(ctx, in, req)
+	}
+
 	// if there's a deny, the result is denied using the deny reasons.
 	if res.Deny.Value {
 		return a.handleResultDenied(ctx, in, req, res, isForwardAuthVerify, res.Deny.Reasons)
@@ -81,13 +132,33 @@ func (a *Authorize) Check(ctx context.Context, in *envoy_service_auth_v3.CheckRe
 
 	// if there's an allow, the result is allowed.
 	if res.Allow.Value {
-		return a.handleResultAllowed(ctx, in, res)
+		return a.handleResultAllowed(ctx, in, req, res)
 	}
 
 	// otherwise, the result is denied using the allow reasons.
 	return a.handleResultDenied(ctx, in, req, res, isForwardAuthVerify, res.Allow.Reasons)
 }
 
+// startCheckSpan starts the span of the check. The embedded envoy can't export spans with
+// OTLP, so with the otlp provider the span continues the trace propagated in the request's
+// headers instead, like a traceparent header set by a browser or another service.
+func (a *Authorize) startCheckSpan(ctx context.Context, hreq *http.Request) (context.Context, *octrace.Span) {
+	if a.currentOptions.Load().TracingProvider == trace.OTLPTracingProviderName {
+		if parent, ok := trace.SpanContextFromHeaders(hreq.Header); ok {
+			return trace.StartSpanWithRemoteParent(ctx, "authorize.grpc.Check", parent)
+		}
+	}
+	return trace.StartSpan(ctx, "authorize.grpc.Check")
+}
+
+// requiresSignIn returns true if the result is denied because the user isn't signed in.
+func requiresSignIn(res *evaluator.Result) bool {
+	if res.Deny.Value {
+		return res.Deny.Reasons.Has(criteria.ReasonUserUnauthenticated)
+	}
+	return !res.Allow.Value && res.Allow.Reasons.Has(criteria.ReasonUserUnauthenticated)
+}
+
 func getForwardAuthURL(r *http.Request) *url.URL {
 	urqQuery := r.URL.Query().Get("uri")
 	u, _ := urlutil.ParseAndValidateURL(urqQuery)
@@ -136,15 +207,69 @@ func (a *Authorize) getEvaluatorRequestFromCheckRequest(
 			in.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress(),
 		),
 	}
-	if sessionState != nil {
+	req.Policy = a.getMatchingPolicy(requestURL)
+	if sessionState != nil && a.isIdentityProviderAllowed(req.Policy, sessionState.IdentityProviderID) {
 		req.Session = evaluator.RequestSession{
 			ID: sessionState.ID,
 		}
 	}
-	req.Policy = a.getMatchingPolicy(requestURL)
 	return req, nil
 }
 
+// isIdentityProviderAllowed returns true if a session from the given identity provider may be
+// used for the policy. Policies which don't select identity providers only allow sessions from the
+// default identity provider. Sessions from the failover identity provider are only allowed for
+// policies which allow failover, while one of their identity providers is unhealthy.
+func (a *Authorize) isIdentityProviderAllowed(policy *config.Policy, idpID string) bool {
+	// pomerium's own routes are used by sessions from any identity provider
+	if policy == nil {
+		return true
+	}
+
+	options := a.currentOptions.Load()
+	idps := options.GetIdentityProvidersForPolicy(policy)
+	if len(idps) == 0 {
+		// service accounts aren't issued by an identity provider
+		if idpID == "" {
+			return true
+		}
+		idps = append(idps, options.GetIdentityProviderForPolicy(policy))
+	}
+	for _, idp := range idps {
+		if idp.GetId() == idpID {
+			return true
+		}
+	}
+
+	// authenticate signs users in with the failover identity provider when the route's
+	// identity provider is unhealthy
+	failover, ok := options.GetIdentityProviderFailover()
+	if !ok || failover.GetId() != idpID || !policy.AllowIdentityProviderFailover {
+		return false
+	}
+	for _, idp := range idps {
+		if a.isIdentityProviderUnhealthy(idp.GetId()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentityProviderUnhealthy returns true if authenticate found the identity provider unhealthy
+// on its most recent health checks. Health which wasn't checked for a few intervals is ignored, so
+// failover stops when authenticate stops checking.
+func (a *Authorize) isIdentityProviderUnhealthy(idpID string) bool {
+	interval := a.currentOptions.Load().IdentityProviderHealthCheckInterval
+	if interval <= 0 {
+		return false
+	}
+	health, ok := a.store.GetRecordData(grpcutil.GetTypeURL(new(identity.ProviderHealth)), idpID).(*identity.ProviderHealth)
+	if !ok || health.GetHealthy() {
+		return false
+	}
+	return time.Since(health.GetCheckedAt().AsTime()) < identityProviderHealthMaxAge*interval
+}
+
 func (a *Authorize) getMatchingPolicy(requestURL url.URL) *config.Policy {
 	options := a.currentOptions.Load()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/google/go-cmp/cmp"
@@ -12,13 +13,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/authorize/internal/store"
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/encoding/jws"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
 )
 
 const certPEM = `
@@ -104,6 +108,68 @@ func Test_getEvaluatorRequest(t *testing.T) {
 	assert.Equal(t, expect, actual)
 }
 
+func TestAuthorize_isIdentityProviderAllowed(t *testing.T) {
+	options := config.NewDefaultOptions()
+	options.Provider = "oidc"
+	options.ProviderURL = "https://default.example.com"
+	options.IdentityProviders = map[string]config.IdentityProvider{
+		"employees":   {Provider: "okta", ProviderURL: "https://employees.example.com", ClientID: "EMPLOYEES"},
+		"contractors": {Provider: "azure", ProviderURL: "https://contractors.example.com", ClientID: "CONTRACTORS"},
+	}
+	a := &Authorize{currentOptions: config.NewAtomicOptions(), state: newAtomicAuthorizeState(new(authorizeState)), store: store.New()}
+	a.currentOptions.Store(options)
+
+	defaultIDP := options.GetIdentityProviderForPolicy(nil).GetId()
+	employees, _ := options.GetIdentityProviderForName("employees")
+	contractors, _ := options.GetIdentityProviderForName("contractors")
+
+	t.Run("default", func(t *testing.T) {
+		policy := &config.Policy{From: "https://from.example.com"}
+		assert.True(t, a.isIdentityProviderAllowed(policy, defaultIDP))
+		assert.False(t, a.isIdentityProviderAllowed(policy, employees.GetId()),
+			"should only allow the default identity provider")
+		assert.True(t, a.isIdentityProviderAllowed(policy, ""), "should allow service accounts")
+	})
+	t.Run("selected", func(t *testing.T) {
+		policy := &config.Policy{From: "https://from.example.com", IdentityProviders: []string{"employees"}}
+		assert.True(t, a.isIdentityProviderAllowed(policy, employees.GetId()))
+		assert.False(t, a.isIdentityProviderAllowed(policy, contractors.GetId()))
+		assert.False(t, a.isIdentityProviderAllowed(policy, defaultIDP))
+	})
+	t.Run("failover", func(t *testing.T) {
+		options.IdentityProviderFailover = "contractors"
+		options.IdentityProviderHealthCheckInterval = time.Minute
+		defer func() {
+			options.IdentityProviderFailover = ""
+			options.IdentityProviderHealthCheckInterval = 0
+		}()
+		setHealth := func(healthy bool, checkedAt time.Time) {
+			a.store.UpdateRecord(0, databroker.NewRecord(&identity.ProviderHealth{
+				Id:        defaultIDP,
+				Healthy:   healthy,
+				CheckedAt: timestamppb.New(checkedAt),
+			}))
+		}
+		policy := &config.Policy{From: "https://from.example.com", AllowIdentityProviderFailover: true}
+
+		assert.False(t, a.isIdentityProviderAllowed(policy, contractors.GetId()),
+			"should not allow the failover identity provider before a health check")
+		setHealth(true, time.Now())
+		assert.False(t, a.isIdentityProviderAllowed(policy, contractors.GetId()),
+			"should not allow the failover identity provider while the identity provider is healthy")
+		setHealth(false, time.Now())
+		assert.True(t, a.isIdentityProviderAllowed(policy, contractors.GetId()))
+		assert.False(t, a.isIdentityProviderAllowed(&config.Policy{From: "https://from.example.com"}, contractors.GetId()),
+			"should only allow the failover identity provider for routes which allow it")
+		setHealth(false, time.Now().Add(-time.Hour))
+		assert.False(t, a.isIdentityProviderAllowed(policy, contractors.GetId()),
+			"should ignore stale health checks")
+	})
+	t.Run("pomerium routes", func(t *testing.T) {
+		assert.True(t, a.isIdentityProviderAllowed(nil, employees.GetId()))
+	})
+}
+
 func Test_handleForwardAuth(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -314,8 +380,9 @@ func Test_getEvaluatorRequestWithPortInHostHeader(t *testing.T) {
 type mockDataBrokerServiceClient struct {
 	databroker.DataBrokerServiceClient
 
-	get func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error)
-	put func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error)
+	get       func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error)
+	put       func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error)
+	rateLimit func(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error)
 }
 
 func (m mockDataBrokerServiceClient) Get(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
@@ -326,6 +393,10 @@ func (m mockDataBrokerServiceClient) Put(ctx context.Context, in *databroker.Put
 	return m.put(ctx, in, opts...)
 }
 
+func (m mockDataBrokerServiceClient) RateLimit(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error) {
+	return m.rateLimit(ctx, in, opts...)
+}
+
 func TestAuthorize_Check(t *testing.T) {
 	opt := config.NewDefaultOptions()
 	opt.AuthenticateURLString = "https://authenticate.example.com"
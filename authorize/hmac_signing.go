@@ -0,0 +1,34 @@
+package authorize
+
+import (
+	"net/http"
+	"time"
+
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// setHMACSignatureHeader sets the header of the HMAC signature of the method, path, body and
+// timestamp of the request, for the upstreams of routes with HMAC signing.
+func setHMACSignatureHeader(
+	headers http.Header,
+	signing *config.PolicyHMACSigning,
+	in *envoy_service_auth_v3.CheckRequest,
+	now time.Time,
+) error {
+	key, err := signing.GetSecret()
+	if err != nil {
+		return err
+	}
+
+	hattrs := in.GetAttributes().GetRequest().GetHttp()
+	body := hattrs.GetRawBody()
+	if body == nil {
+		body = []byte(hattrs.GetBody())
+	}
+	headers.Set(signing.GetHeader(), cryptutil.SignRequest(key,
+		hattrs.GetMethod(), hattrs.GetPath(), body, now, cryptutil.NewRandomStringN(16)))
+	return nil
+}
@@ -0,0 +1,44 @@
+package authorize
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func Test_setHMACSignatureHeader(t *testing.T) {
+	key := cryptutil.NewKey()
+	signing := &config.PolicyHMACSigning{Secret: base64.StdEncoding.EncodeToString(key)}
+	in := &envoy_service_auth_v3.CheckRequest{
+		Attributes: &envoy_service_auth_v3.AttributeContext{
+			Request: &envoy_service_auth_v3.AttributeContext_Request{
+				Http: &envoy_service_auth_v3.AttributeContext_HttpRequest{
+					Method:  "POST",
+					Path:    "/api/items?limit=10",
+					RawBody: []byte(`{"name":"item"}`),
+				},
+			},
+		},
+	}
+
+	headers := make(http.Header)
+	require.NoError(t, setHMACSignatureHeader(headers, signing, in, time.Now()))
+	signature := headers.Get(config.DefaultHMACSignatureHeader)
+	assert.NoError(t, cryptutil.VerifyRequestSignature(key, signature, "POST", "/api/items?limit=10", []byte(`{"name":"item"}`)))
+	assert.Error(t, cryptutil.VerifyRequestSignature(key, signature, "POST", "/api/items?limit=10", nil),
+		"should sign the body")
+
+	headers = make(http.Header)
+	signing.Header = "X-Signature"
+	require.NoError(t, setHMACSignatureHeader(headers, signing, in, time.Now()))
+	assert.NotEmpty(t, headers.Get("X-Signature"))
+	assert.NotEqual(t, signature, headers.Get("X-Signature"), "should use a unique nonce")
+}
@@ -0,0 +1,36 @@
+package authorize
+
+import (
+	"time"
+
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// getImpersonation returns the impersonation grant for the given session, if one exists.
+func (a *Authorize) getImpersonation(s *session.Session) (*session.Impersonation, bool) {
+	impersonation, ok := a.store.GetRecordData(
+		grpcutil.GetTypeURL(new(session.Impersonation)),
+		s.GetId(),
+	).(*session.Impersonation)
+	return impersonation, ok
+}
+
+// isImpersonationAllowed returns false if the session is impersonating another session via an
+// impersonation grant that has not been approved, has expired, or is for a different session.
+//
+// Sessions which impersonate another session without a grant are left to the policy.
+func (a *Authorize) isImpersonationAllowed(s sessionOrServiceAccount, now time.Time) bool {
+	ss, ok := s.(*session.Session)
+	if !ok || ss.GetImpersonateSessionId() == "" {
+		return true
+	}
+
+	impersonation, ok := a.getImpersonation(ss)
+	if !ok {
+		return true
+	}
+
+	return impersonation.IsActive(now) &&
+		impersonation.GetImpersonateSessionId() == ss.GetImpersonateSessionId()
+}
@@ -0,0 +1,57 @@
+package authorize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/authorize/internal/store"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+)
+
+func TestAuthorize_isImpersonationAllowed(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &Authorize{}
+	a.store = store.NewFromProtos(0,
+		&session.Impersonation{
+			Id:                   "ACTIVE",
+			ImpersonateSessionId: "TARGET",
+			ApprovedAt:           timestamppb.New(now.Add(-time.Minute)),
+			ExpiresAt:            timestamppb.New(now.Add(time.Minute)),
+		},
+		&session.Impersonation{
+			Id:                   "EXPIRED",
+			ImpersonateSessionId: "TARGET",
+			ApprovedAt:           timestamppb.New(now.Add(-time.Hour)),
+			ExpiresAt:            timestamppb.New(now.Add(-time.Minute)),
+		},
+		&session.Impersonation{
+			Id:                   "PENDING",
+			ImpersonateSessionId: "TARGET",
+		},
+	)
+
+	for _, tc := range []struct {
+		name   string
+		s      sessionOrServiceAccount
+		expect bool
+	}{
+		{"no session", nil, true},
+		{"service account", &user.ServiceAccount{Id: "SERVICE_ACCOUNT"}, true},
+		{"not impersonating", &session.Session{Id: "ACTIVE"}, true},
+		{"no grant", &session.Session{Id: "NONE", ImpersonateSessionId: proto.String("TARGET")}, true},
+		{"active grant", &session.Session{Id: "ACTIVE", ImpersonateSessionId: proto.String("TARGET")}, true},
+		{"different target", &session.Session{Id: "ACTIVE", ImpersonateSessionId: proto.String("OTHER")}, false},
+		{"expired grant", &session.Session{Id: "EXPIRED", ImpersonateSessionId: proto.String("TARGET")}, false},
+		{"pending grant", &session.Session{Id: "PENDING", ImpersonateSessionId: proto.String("TARGET")}, false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, a.isImpersonationAllowed(tc.s, now))
+		})
+	}
+}
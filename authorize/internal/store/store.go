@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"sync/atomic"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/google/uuid"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/storage"
@@ -24,19 +26,41 @@ import (
 	"github.com/pomerium/pomerium/pkg/protoutil"
 )
 
+// recentRecordsCacheSize is the number of records retrieved ahead of the sync which are cached.
+const recentRecordsCacheSize = 1 << 12
+
 // A Store stores data for the OPA rego policy evaluation.
 type Store struct {
 	storage.Store
-	index *index
+	index    *index
+	versions *versionIndex
+
+	// recentMu guards the recent records against changes of the synced record version
+	recentMu sync.Mutex
+	// recent contains the records retrieved from the databroker before they were synced, until
+	// the sync catches up with them
+	recent *lru.Cache
 
 	dataBrokerServerVersion, dataBrokerRecordVersion uint64
+	// generation changes when the data in the store changes other than by updating records
+	generation uint64
+
+	signer atomic.Value // jose.Signer
+}
+
+type recentRecord struct {
+	version uint64
+	msg     proto.Message
 }
 
 // New creates a new Store.
 func New() *Store {
+	recent, _ := lru.New(recentRecordsCacheSize) // the only error is for a non-positive size
 	return &Store{
-		Store: inmem.New(),
-		index: newIndex(),
+		Store:    inmem.New(),
+		index:    newIndex(),
+		versions: newVersionIndex(),
+		recent:   recent,
 	}
 }
 
@@ -62,7 +86,12 @@ func NewFromProtos(serverVersion uint64, msgs ...proto.Message) *Store {
 
 // ClearRecords removes all the records from the store.
 func (s *Store) ClearRecords() {
+	s.recentMu.Lock()
+	s.recent.Purge()
+	s.recentMu.Unlock()
 	s.index.clear()
+	s.versions.clear()
+	atomic.AddUint64(&s.generation, 1)
 }
 
 // GetDataBrokerVersions gets the databroker versions.
@@ -74,48 +103,97 @@ func (s *Store) GetDataBrokerVersions() (serverVersion, recordVersion uint64) {
 // GetRecordData gets a record's data from the store. `nil` is returned
 // if no record exists for the given type and id.
 func (s *Store) GetRecordData(typeURL, idOrValue string) proto.Message {
+	// recent records are newer than the synced ones
+	if v, ok := s.recent.Get(getRecentRecordKey(typeURL, idOrValue)); ok {
+		return v.(*recentRecord).msg
+	}
 	return s.index.find(typeURL, idOrValue)
 }
 
+// AddRecentRecord adds a record retrieved from the databroker, so it can be used before the
+// sync catches up with it. Records which were already synced are ignored.
+func (s *Store) AddRecentRecord(record *databroker.Record) {
+	if record.GetDeletedAt() != nil {
+		return
+	}
+	msg, err := record.GetData().UnmarshalNew()
+	if err != nil {
+		return
+	}
+
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+
+	if record.GetVersion() <= atomic.LoadUint64(&s.dataBrokerRecordVersion) {
+		return
+	}
+	s.recent.Add(getRecentRecordKey(record.GetType(), record.GetId()), &recentRecord{
+		version: record.GetVersion(),
+		msg:     msg,
+	})
+}
+
 // UpdateIssuer updates the issuer in the store. The issuer is used as part of JWT construction.
 func (s *Store) UpdateIssuer(issuer string) {
 	s.write("/issuer", issuer)
+	atomic.AddUint64(&s.generation, 1)
 }
 
 // UpdateGoogleCloudServerlessAuthenticationServiceAccount updates the google cloud serverless authentication
 // service account in the store.
 func (s *Store) UpdateGoogleCloudServerlessAuthenticationServiceAccount(serviceAccount string) {
 	s.write("/google_cloud_serverless_authentication_service_account", serviceAccount)
+	atomic.AddUint64(&s.generation, 1)
 }
 
 // UpdateJWTClaimHeaders updates the jwt claim headers in the store.
 func (s *Store) UpdateJWTClaimHeaders(jwtClaimHeaders map[string]string) {
 	s.write("/jwt_claim_headers", jwtClaimHeaders)
+	atomic.AddUint64(&s.generation, 1)
 }
 
 // UpdateRoutePolicies updates the route policies in the store.
 func (s *Store) UpdateRoutePolicies(routePolicies []config.Policy) {
 	s.write("/route_policies", routePolicies)
+	atomic.AddUint64(&s.generation, 1)
 }
 
 // UpdateRecord updates a record in the store.
 func (s *Store) UpdateRecord(serverVersion uint64, record *databroker.Record) {
 	if record.GetDeletedAt() != nil {
 		s.index.delete(record.GetType(), record.GetId())
+		s.versions.delete(record.GetType(), record.GetId(), record.GetVersion())
 	} else {
 		msg, _ := record.GetData().UnmarshalNew()
 		s.index.set(record.GetType(), record.GetId(), msg)
+		s.versions.set(record.GetType(), record.GetId(), record.GetVersion())
 	}
 	s.write("/databroker_server_version", fmt.Sprint(serverVersion))
 	s.write("/databroker_record_version", fmt.Sprint(record.GetVersion()))
+
+	s.recentMu.Lock()
+	// once the sync reaches the version of a recent record, the synced record is at least as new
+	key := getRecentRecordKey(record.GetType(), record.GetId())
+	if v, ok := s.recent.Peek(key); ok && record.GetVersion() >= v.(*recentRecord).version {
+		s.recent.Remove(key)
+	}
 	atomic.StoreUint64(&s.dataBrokerServerVersion, serverVersion)
 	atomic.StoreUint64(&s.dataBrokerRecordVersion, record.GetVersion())
+	s.recentMu.Unlock()
 }
 
-// UpdateSigningKey updates the signing key stored in the database. Signing operations
-// in rego use JWKs, so we take in that format.
+// UpdateSigningKey updates the signing key used by the sign_jwt rego function.
 func (s *Store) UpdateSigningKey(signingKey *jose.JSONWebKey) {
-	s.write("/signing_key", signingKey)
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.SignatureAlgorithm(signingKey.Algorithm),
+		Key:       signingKey,
+	}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		log.Error(context.TODO()).Err(err).Msg("opa-store: error creating signer")
+		return
+	}
+	s.signer.Store(signer)
+	atomic.AddUint64(&s.generation, 1)
 }
 
 func (s *Store) write(rawPath string, value interface{}) {
@@ -172,6 +250,11 @@ func (s *Store) GetDataBrokerRecordOption() func(*rego.Rego) {
 			return nil, fmt.Errorf("invalid record id: %T", op2)
 		}
 
+		// the version is read first, so if the record changes while it's read the result of the
+		// evaluation isn't reused
+		if rv := getRecordVersions(bctx.Context); rv != nil {
+			s.addRecordVersion(rv, string(recordType), string(recordID))
+		}
 		msg := s.GetRecordData(string(recordType), string(recordID))
 		if msg == nil {
 			return ast.NullTerm(), nil
@@ -187,6 +270,46 @@ func (s *Store) GetDataBrokerRecordOption() func(*rego.Rego) {
 	})
 }
 
+// GetSignJWTOption returns a function option that can sign a JWT payload with the signing key.
+// Signing is done in Go, rather than with io.jwt.encode_sign, since rego doesn't support EdDSA.
+func (s *Store) GetSignJWTOption() func(*rego.Rego) {
+	return rego.Function1(&rego.Function{
+		Name: "sign_jwt",
+		Decl: types.NewFunction(
+			types.Args(types.NewObject(nil, types.NewDynamicProperty(types.S, types.A))),
+			types.S,
+		),
+	}, func(bctx rego.BuiltinContext, op1 *ast.Term) (*ast.Term, error) {
+		signer, ok := s.signer.Load().(jose.Signer)
+		if !ok {
+			return nil, fmt.Errorf("no signing key")
+		}
+
+		payload, err := ast.JSON(op1.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload: %w", err)
+		}
+		bs, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		jws, err := signer.Sign(bs)
+		if err != nil {
+			return nil, err
+		}
+		rawJWT, err := jws.CompactSerialize()
+		if err != nil {
+			return nil, err
+		}
+		return ast.StringTerm(rawJWT), nil
+	})
+}
+
+func getRecentRecordKey(typeURL, id string) string {
+	return typeURL + "\x00" + id
+}
+
 func toMap(msg proto.Message) map[string]interface{} {
 	bs, _ := json.Marshal(msg)
 	var obj map[string]interface{}
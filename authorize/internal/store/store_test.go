@@ -62,6 +62,82 @@ func TestStore(t *testing.T) {
 		v = s.GetRecordData(any.GetTypeUrl(), u.GetId())
 		assert.Nil(t, v)
 	})
+	t.Run("recent records", func(t *testing.T) {
+		s := New()
+		u := &user.User{Id: "u1", Name: "recent"}
+		any := protoutil.NewAny(u)
+		s.UpdateRecord(0, &databroker.Record{Version: 1})
+
+		s.AddRecentRecord(&databroker.Record{
+			Version: 1,
+			Type:    any.GetTypeUrl(),
+			Id:      u.GetId(),
+			Data:    any,
+		})
+		assert.Nil(t, s.GetRecordData(any.GetTypeUrl(), u.GetId()), "should ignore synced records")
+
+		s.AddRecentRecord(&databroker.Record{
+			Version: 3,
+			Type:    any.GetTypeUrl(),
+			Id:      u.GetId(),
+			Data:    any,
+		})
+		assert.Equal(t, "recent", s.GetRecordData(any.GetTypeUrl(), u.GetId()).(*user.User).GetName())
+
+		old := protoutil.NewAny(&user.User{Id: "u1", Name: "old"})
+		s.UpdateRecord(0, &databroker.Record{
+			Version: 2,
+			Type:    old.GetTypeUrl(),
+			Id:      u.GetId(),
+			Data:    old,
+		})
+		assert.Equal(t, "recent", s.GetRecordData(any.GetTypeUrl(), u.GetId()).(*user.User).GetName(),
+			"should prefer the recent record until the sync catches up")
+
+		s.UpdateRecord(0, &databroker.Record{
+			Version:   3,
+			Type:      any.GetTypeUrl(),
+			Id:        u.GetId(),
+			Data:      any,
+			DeletedAt: timestamppb.Now(),
+		})
+		assert.Nil(t, s.GetRecordData(any.GetTypeUrl(), u.GetId()), "should be invalidated by the sync")
+	})
+	t.Run("record versions", func(t *testing.T) {
+		s := New()
+		u1 := protoutil.NewAny(&user.User{Id: "u1"})
+		u2 := protoutil.NewAny(&user.User{Id: "u2"})
+		s.UpdateRecord(0, &databroker.Record{Version: 1, Type: u1.GetTypeUrl(), Id: "u1", Data: u1})
+
+		found := s.NewRecordVersions()
+		s.addRecordVersion(found, u1.GetTypeUrl(), "u1")
+		missing := s.NewRecordVersions()
+		s.addRecordVersion(missing, u1.GetTypeUrl(), "u2")
+		assert.True(t, s.IsCurrent(found))
+		assert.True(t, s.IsCurrent(missing))
+
+		s.UpdateRecord(0, &databroker.Record{Version: 2, Type: "OTHER", Id: "u1"})
+		assert.True(t, s.IsCurrent(found), "should ignore other records")
+		assert.True(t, s.IsCurrent(missing), "should ignore other record types")
+
+		s.UpdateRecord(0, &databroker.Record{Version: 3, Type: u2.GetTypeUrl(), Id: "u3", Data: u2})
+		assert.True(t, s.IsCurrent(found))
+		assert.False(t, s.IsCurrent(missing), "should be invalidated by changes to the record type")
+
+		s.AddRecentRecord(&databroker.Record{Version: 4, Type: u1.GetTypeUrl(), Id: "u1", Data: u1})
+		assert.False(t, s.IsCurrent(found), "should be invalidated by recent records")
+
+		found = s.NewRecordVersions()
+		s.addRecordVersion(found, u1.GetTypeUrl(), "u1")
+		assert.True(t, s.IsCurrent(found))
+		s.UpdateIssuer("authenticate.example.com")
+		assert.False(t, s.IsCurrent(found), "should be invalidated by other changes to the store")
+
+		found = s.NewRecordVersions()
+		s.addRecordVersion(found, u1.GetTypeUrl(), "u1")
+		s.ClearRecords()
+		assert.False(t, s.IsCurrent(found), "should be invalidated by clearing the records")
+	})
 	t.Run("cidr", func(t *testing.T) {
 		s := New()
 		any := protoutil.NewAny(&structpb.Struct{Fields: map[string]*structpb.Value{
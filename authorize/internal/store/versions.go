@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// versionIndex stores the versions of the records in the store, and for each record type the
+// version of the last change to a record of the type.
+type versionIndex struct {
+	mu       sync.RWMutex
+	byRecord map[string]uint64
+	byType   map[string]uint64
+}
+
+func newVersionIndex() *versionIndex {
+	idx := new(versionIndex)
+	idx.clear()
+	return idx
+}
+
+func (idx *versionIndex) clear() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.byRecord = map[string]uint64{}
+	idx.byType = map[string]uint64{}
+}
+
+func (idx *versionIndex) delete(typeURL, id string, version uint64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.byRecord, getRecentRecordKey(typeURL, id))
+	idx.byType[typeURL] = version
+}
+
+func (idx *versionIndex) set(typeURL, id string, version uint64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.byRecord[getRecentRecordKey(typeURL, id)] = version
+	idx.byType[typeURL] = version
+}
+
+func (idx *versionIndex) getRecord(typeURL, id string) (uint64, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	version, ok := idx.byRecord[getRecentRecordKey(typeURL, id)]
+	return version, ok
+}
+
+func (idx *versionIndex) getType(typeURL string) uint64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.byType[typeURL]
+}
+
+// RecordVersions are the versions of the records read with get_databroker_record during an
+// evaluation, so the result of the evaluation can be reused until one of the records changes.
+type RecordVersions struct {
+	generation uint64
+
+	mu sync.Mutex
+	// records are the versions of the records which were found by id
+	records map[recordVersionsKey]uint64
+	// types are the versions of the record types of the records which weren't found by id, since
+	// they may be found by another value, like an ip address, or created later
+	types map[recordVersionsKey]uint64
+}
+
+type recordVersionsKey struct {
+	typeURL, id string
+}
+
+// NewRecordVersions creates a new RecordVersions to collect the versions of the records read
+// during an evaluation.
+func (s *Store) NewRecordVersions() *RecordVersions {
+	return &RecordVersions{
+		generation: atomic.LoadUint64(&s.generation),
+		records:    map[recordVersionsKey]uint64{},
+		types:      map[recordVersionsKey]uint64{},
+	}
+}
+
+type recordVersionsContextKey struct{}
+
+// WithRecordVersions returns a context which collects the versions of the records read by
+// get_databroker_record with the context in the record versions.
+func WithRecordVersions(ctx context.Context, rv *RecordVersions) context.Context {
+	return context.WithValue(ctx, recordVersionsContextKey{}, rv)
+}
+
+func getRecordVersions(ctx context.Context) *RecordVersions {
+	rv, _ := ctx.Value(recordVersionsContextKey{}).(*RecordVersions)
+	return rv
+}
+
+// IsCurrent returns true if none of the records read during the evaluation changed since.
+func (s *Store) IsCurrent(rv *RecordVersions) bool {
+	if rv.generation != atomic.LoadUint64(&s.generation) {
+		return false
+	}
+
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+
+	for k, version := range rv.records {
+		if current, ok := s.getRecordVersion(k.typeURL, k.id); !ok || current != version {
+			return false
+		}
+	}
+	for k, version := range rv.types {
+		if _, ok := s.getRecordVersion(k.typeURL, k.id); ok || s.versions.getType(k.typeURL) != version {
+			return false
+		}
+	}
+	return true
+}
+
+// addRecordVersion adds the version of a record read during an evaluation.
+func (s *Store) addRecordVersion(rv *RecordVersions, typeURL, id string) {
+	key := recordVersionsKey{typeURL: typeURL, id: id}
+	version, ok := s.getRecordVersion(typeURL, id)
+
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+
+	if ok {
+		rv.records[key] = version
+	} else {
+		rv.types[key] = s.versions.getType(typeURL)
+	}
+}
+
+// getRecordVersion returns the version of the record with the id, which may be a recent record.
+func (s *Store) getRecordVersion(typeURL, id string) (uint64, bool) {
+	if v, ok := s.recent.Peek(getRecentRecordKey(typeURL, id)); ok {
+		return v.(*recentRecord).version, true
+	}
+	return s.versions.getRecord(typeURL, id)
+}
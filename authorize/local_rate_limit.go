@@ -0,0 +1,84 @@
+package authorize
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+)
+
+// localRateLimiterSize is the maximum number of keys tracked by the local rate limiter. When
+// exceeded the least recently used keys are forgotten, which resets their limits.
+const localRateLimiterSize = 65536
+
+// A localRateLimiter applies the local rate limits of routes which are keyed by client IP address
+// or user. Local rate limits of routes as a whole are applied by envoy.
+type localRateLimiter struct {
+	mu      sync.Mutex
+	buckets *lru.Cache
+	now     func() time.Time
+}
+
+type localRateLimitBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newLocalRateLimiter() *localRateLimiter {
+	buckets, _ := lru.New(localRateLimiterSize)
+	return &localRateLimiter{
+		buckets: buckets,
+		now:     time.Now,
+	}
+}
+
+// allow returns false if the request exceeds the local rate limit of its route.
+func (l *localRateLimiter) allow(req *evaluator.Request, s sessionOrServiceAccount) bool {
+	if l == nil || req.Policy == nil {
+		return true
+	}
+	rl := req.Policy.LocalRateLimit
+	if rl == nil || rl.KeyBy == "" {
+		return true
+	}
+	routeID, err := req.Policy.RouteID()
+	if err != nil {
+		return true
+	}
+
+	key := strconv.FormatUint(routeID, 10) + "|" + getRateLimitKey(rl.KeyBy, req, s)
+	rate, burst := float64(rl.RequestsPerSecond), float64(rl.GetBurst())
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket := &localRateLimitBucket{tokens: burst, last: now}
+	if v, ok := l.buckets.Get(key); ok {
+		bucket = v.(*localRateLimitBucket)
+		bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+		bucket.last = now
+	} else {
+		l.buckets.Add(key, bucket)
+	}
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// getRateLimitKey returns the key the requests are limited by. Requests without a user are
+// limited by their IP address.
+func getRateLimitKey(keyBy string, req *evaluator.Request, s sessionOrServiceAccount) string {
+	if keyBy == config.LocalRateLimitKeyByUser && s != nil && s.GetUserId() != "" {
+		return "user|" + s.GetUserId()
+	}
+	return "ip|" + req.HTTP.IP
+}
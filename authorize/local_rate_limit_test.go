@@ -0,0 +1,54 @@
+package authorize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+func TestLocalRateLimiter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newLocalRateLimiter()
+	l.now = func() time.Time { return now }
+
+	policy := &config.Policy{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		LocalRateLimit: &config.PolicyLocalRateLimit{
+			RequestsPerSecond: 1,
+			Burst:             2,
+			KeyBy:             config.LocalRateLimitKeyByUser,
+		},
+	}
+	req1 := &evaluator.Request{Policy: policy, HTTP: evaluator.RequestHTTP{IP: "1.1.1.1"}}
+	req2 := &evaluator.Request{Policy: policy, HTTP: evaluator.RequestHTTP{IP: "2.2.2.2"}}
+	s1 := &session.Session{UserId: "user1"}
+	s2 := &session.Session{UserId: "user2"}
+
+	assert.True(t, l.allow(req1, s1))
+	assert.True(t, l.allow(req1, s1))
+	assert.False(t, l.allow(req1, s1), "should limit requests after the burst")
+	assert.False(t, l.allow(req2, s1), "should limit the user regardless of the IP address")
+	assert.True(t, l.allow(req1, s2), "should not limit other users")
+	assert.True(t, l.allow(req1, nil), "should limit requests without a user by IP address")
+
+	now = now.Add(time.Second)
+	assert.True(t, l.allow(req1, s1), "should refill tokens")
+	assert.False(t, l.allow(req1, s1))
+
+	t.Run("unkeyed", func(t *testing.T) {
+		req := &evaluator.Request{Policy: &config.Policy{
+			From:           "https://from.example.com",
+			To:             mustParseWeightedURLs(t, "https://to.example.com"),
+			LocalRateLimit: &config.PolicyLocalRateLimit{RequestsPerSecond: 1},
+		}}
+		for i := 0; i < 3; i++ {
+			assert.True(t, l.allow(req, nil), "should leave route rate limits to envoy")
+		}
+	})
+}
@@ -6,8 +6,10 @@ import (
 
 	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/telemetry/requestid"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
@@ -28,6 +30,14 @@ func (a *Authorize) logAuthorizeCheck(
 	hdrs := getCheckRequestHeaders(in)
 	hattrs := in.GetAttributes().GetRequest().GetHttp()
 	evt := log.Info(ctx).Str("service", "authorize")
+	// allowed and denied requests are sampled separately
+	category := "authorize_deny"
+	if res != nil && res.Allow.Value && !res.Deny.Value {
+		category = "authorize_allow"
+	}
+	if !log.Sample(category) {
+		evt = evt.Discard()
+	}
 	// request
 	evt = evt.Str("request-id", requestid.FromContext(ctx))
 	evt = evt.Str("check-request-id", hdrs["X-Request-Id"])
@@ -59,6 +69,9 @@ func (a *Authorize) logAuthorizeCheck(
 		} else {
 			evt = evt.Strs("deny-why-false", res.Deny.Reasons.Strings())
 		}
+		if res.Maintenance {
+			evt = evt.Bool("maintenance", true)
+		}
 		evt = evt.Str("user", u.GetId())
 		evt = evt.Str("email", u.GetEmail())
 		evt = evt.Uint64("databroker_server_version", res.DataBrokerServerVersion)
@@ -66,7 +79,7 @@ func (a *Authorize) logAuthorizeCheck(
 	}
 
 	// potentially sensitive, only log if debug mode
-	if zerolog.GlobalLevel() <= zerolog.DebugLevel {
+	if log.GetModuleLevel("authorize") <= zerolog.DebugLevel {
 		evt = evt.Interface("headers", hdrs)
 	}
 
@@ -96,6 +109,85 @@ func (a *Authorize) logAuthorizeCheck(
 	}
 }
 
+// setAccessLogMetadata sets the ext_authz dynamic metadata to the access log fields known by the
+// authorize service, so envoy's access log can include them.
+func (a *Authorize) setAccessLogMetadata(
+	out *envoy_service_auth_v3.CheckResponse,
+	res *evaluator.Result, s sessionOrServiceAccount, u *user.User,
+) {
+	if out == nil || res == nil {
+		return
+	}
+
+	fields := map[string]interface{}{}
+	for _, field := range a.currentOptions.Load().GetAccessLogFields() {
+		switch {
+		case field == "allow":
+			fields[field] = res.Allow.Value
+		case field == "deny":
+			fields[field] = res.Deny.Value
+		case field == "reasons":
+			reasons := res.Allow.Reasons
+			if res.Deny.Value {
+				reasons = res.Deny.Reasons
+			}
+			fields[field] = toInterfaces(reasons.Strings())
+		case field == "user":
+			fields[field] = u.GetId()
+		case field == "email":
+			fields[field] = u.GetEmail()
+		case field == "session-id":
+			if s, ok := s.(*session.Session); ok {
+				fields[field] = s.GetId()
+			}
+		case strings.HasPrefix(field, config.AccessLogFieldClaimPrefix):
+			if v, ok := getClaim(strings.TrimPrefix(field, config.AccessLogFieldClaimPrefix), s, u); ok {
+				fields[field] = v
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	value, err := structpb.NewStruct(fields)
+	if err != nil {
+		return
+	}
+	if out.DynamicMetadata == nil {
+		out.DynamicMetadata = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	}
+	out.DynamicMetadata.Fields[config.AccessLogMetadataKey] = structpb.NewStructValue(value)
+}
+
+// getClaim returns the claim of the session, or of the user if the session doesn't have it. Claims
+// with a single value are returned as the value.
+func getClaim(name string, s sessionOrServiceAccount, u *user.User) (interface{}, bool) {
+	var lv *structpb.ListValue
+	if s, ok := s.(*session.Session); ok {
+		lv = s.GetClaims()[name]
+	}
+	if lv == nil {
+		lv = u.GetClaims()[name]
+	}
+	if lv == nil {
+		return nil, false
+	}
+	values := lv.AsSlice()
+	if len(values) == 1 {
+		return values[0], true
+	}
+	return values, true
+}
+
+func toInterfaces(strs []string) []interface{} {
+	values := make([]interface{}, len(strs))
+	for i, str := range strs {
+		values[i] = str
+	}
+	return values
+}
+
 func (a *Authorize) populateLogSessionDetails(evt *zerolog.Event, s *session.Session) *zerolog.Event {
 	evt = evt.Str("session-id", s.GetId())
 	if s.GetImpersonateSessionId() == "" {
@@ -103,6 +195,14 @@ func (a *Authorize) populateLogSessionDetails(evt *zerolog.Event, s *session.Ses
 	}
 
 	evt = evt.Str("impersonate-session-id", s.GetImpersonateSessionId())
+	if impersonation, ok := a.getImpersonation(s); ok {
+		evt = evt.Str("impersonate-requested-by", impersonation.GetRequestedBy())
+		evt = evt.Str("impersonate-approved-by", impersonation.GetApprovedBy())
+		evt = evt.Str("impersonate-reason", impersonation.GetReason())
+		if impersonation.GetExpiresAt() != nil {
+			evt = evt.Time("impersonate-expires-at", impersonation.GetExpiresAt().AsTime())
+		}
+	}
 	impersonatedSession, ok := a.store.GetRecordData(
 		grpcutil.GetTypeURL(new(session.Session)),
 		s.GetImpersonateSessionId(),
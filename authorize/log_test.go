@@ -0,0 +1,45 @@
+package authorize
+
+import (
+	"testing"
+
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/policy/criteria"
+)
+
+func TestAuthorize_setAccessLogMetadata(t *testing.T) {
+	a := &Authorize{currentOptions: config.NewAtomicOptions(), state: newAtomicAuthorizeState(new(authorizeState))}
+	res := &evaluator.Result{
+		Allow: evaluator.RuleResult{Value: true, Reasons: criteria.NewReasons(criteria.ReasonEmailOK)},
+	}
+	s := &session.Session{Id: "SESSION", Claims: map[string]*structpb.ListValue{
+		"groups": {Values: []*structpb.Value{structpb.NewStringValue("a"), structpb.NewStringValue("b")}},
+	}}
+	u := &user.User{Id: "USER", Email: "user@example.com", Claims: map[string]*structpb.ListValue{
+		"name": {Values: []*structpb.Value{structpb.NewStringValue("User")}},
+	}}
+
+	out := new(envoy_service_auth_v3.CheckResponse)
+	a.setAccessLogMetadata(out, res, s, u)
+	assert.Nil(t, out.DynamicMetadata, "the default fields aren't set by the authorize service")
+
+	opts := config.NewDefaultOptions()
+	opts.AccessLogFields = []string{"path", "allow", "reasons", "email", "session-id", "claim.groups", "claim.name", "claim.missing"}
+	a.currentOptions.Store(opts)
+	a.setAccessLogMetadata(out, res, s, u)
+	assert.Equal(t, map[string]interface{}{
+		"allow":        true,
+		"reasons":      []interface{}{"email-ok"},
+		"email":        "user@example.com",
+		"session-id":   "SESSION",
+		"claim.groups": []interface{}{"a", "b"},
+		"claim.name":   "User",
+	}, out.DynamicMetadata.GetFields()[config.AccessLogMetadataKey].GetStructValue().AsMap())
+}
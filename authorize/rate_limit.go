@@ -0,0 +1,58 @@
+package authorize
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// checkRateLimit returns false if the request exceeds the rate limit of its route, which is shared
+// by all the instances of authorize through the databroker. When the databroker can't be
+// reached, requests are allowed, so an outage of the databroker doesn't take the routes down.
+func (a *Authorize) checkRateLimit(ctx context.Context, req *evaluator.Request, s sessionOrServiceAccount) (allowed bool, retryAfter time.Duration) {
+	if req.Policy == nil || req.Policy.RateLimit == nil {
+		return true, 0
+	}
+	rl := req.Policy.RateLimit
+	routeID, err := req.Policy.RouteID()
+	if err != nil {
+		return true, 0
+	}
+
+	start := time.Now()
+	res, err := a.state.Load().dataBrokerClient.RateLimit(ctx, &databroker.RateLimitRequest{
+		Key:    strconv.FormatUint(routeID, 16) + "|" + getRateLimitKey(rl.KeyBy, req, s),
+		Limit:  rl.Requests,
+		Window: durationpb.New(rl.GetWindow()),
+	})
+	result := metrics.RateLimitAllowed
+	switch {
+	case err != nil:
+		log.Error(ctx).Err(err).Msg("authorize: error checking rate limit")
+		result = metrics.RateLimitError
+	case !res.GetAllowed():
+		result = metrics.RateLimitLimited
+	}
+	metrics.RecordRateLimitCheck(ctx, a.currentOptions.Load().GetMetricsRouteTags(req.Policy), result, time.Since(start))
+
+	if result != metrics.RateLimitLimited {
+		return true, 0
+	}
+	return false, res.GetRetryAfter().AsDuration()
+}
+
+// getRetryAfter returns the value of the Retry-After header, in seconds, for the duration.
+func getRetryAfter(d time.Duration) string {
+	secs := int64((d + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return strconv.FormatInt(secs, 10)
+}
@@ -0,0 +1,69 @@
+package authorize
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+func TestAuthorize_checkRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var reqs []*databroker.RateLimitRequest
+	var err error
+	a := &Authorize{
+		currentOptions: config.NewAtomicOptions(),
+		state: newAtomicAuthorizeState(&authorizeState{
+			dataBrokerClient: mockDataBrokerServiceClient{
+				rateLimit: func(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error) {
+					reqs = append(reqs, in)
+					if err != nil {
+						return nil, err
+					}
+					if len(reqs) > 1 {
+						return &databroker.RateLimitResponse{RetryAfter: durationpb.New(1500 * time.Millisecond)}, nil
+					}
+					return &databroker.RateLimitResponse{Allowed: true}, nil
+				},
+			},
+		}),
+	}
+
+	policy := &config.Policy{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+	}
+	req := &evaluator.Request{Policy: policy, HTTP: evaluator.RequestHTTP{IP: "1.1.1.1"}}
+	s := &session.Session{UserId: "user1"}
+
+	allowed, _ := a.checkRateLimit(ctx, req, s)
+	assert.True(t, allowed, "should allow routes without a rate limit")
+	assert.Empty(t, reqs)
+
+	policy.RateLimit = &config.PolicyRateLimit{Requests: 10, KeyBy: config.LocalRateLimitKeyByUser}
+	allowed, _ = a.checkRateLimit(ctx, req, s)
+	assert.True(t, allowed)
+	if assert.Len(t, reqs, 1) {
+		assert.Contains(t, reqs[0].GetKey(), "user|user1")
+		assert.Equal(t, uint32(10), reqs[0].GetLimit())
+		assert.Equal(t, time.Minute, reqs[0].GetWindow().AsDuration())
+	}
+
+	allowed, retryAfter := a.checkRateLimit(ctx, req, s)
+	assert.False(t, allowed)
+	assert.Equal(t, "2", getRetryAfter(retryAfter))
+
+	err = errors.New("unavailable")
+	allowed, _ = a.checkRateLimit(ctx, req, s)
+	assert.True(t, allowed, "should allow requests when the databroker is unavailable")
+}
@@ -1,8 +1,11 @@
 package authorize
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/encoding"
@@ -11,6 +14,8 @@ import (
 	"github.com/pomerium/pomerium/internal/sessions/header"
 	"github.com/pomerium/pomerium/internal/sessions/queryparam"
 	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
 )
 
 func loadRawSession(req *http.Request, options *config.Options, encoder encoding.MarshalUnmarshaler) ([]byte, error) {
@@ -54,6 +59,7 @@ func getCookieStore(options *config.Options, encoder encoding.MarshalUnmarshaler
 			Secure:   options.CookieSecure,
 			HTTPOnly: options.CookieHTTPOnly,
 			Expire:   options.CookieExpire,
+			Compress: options.CookieCompression,
 		}
 	}, encoder)
 	if err != nil {
@@ -61,3 +67,91 @@ func getCookieStore(options *config.Options, encoder encoding.MarshalUnmarshaler
 	}
 	return cookieStore, nil
 }
+
+// A sessionCheck is the result of checking a session against the requirements of a route which
+// are checked before its policy is evaluated.
+type sessionCheck int
+
+const (
+	// sessionCheckOK means the session can be used to evaluate the policy.
+	sessionCheckOK sessionCheck = iota
+	// sessionCheckRenew means the session is older than the session lifetime, or was idle for
+	// longer than the session idle timeout, so it has to be renewed.
+	sessionCheckRenew
+	// sessionCheckReauthenticate means the user signed in longer ago than the max session age,
+	// or the session has to be bound to the client certificate, so the user has to sign in again.
+	sessionCheckReauthenticate
+	// sessionCheckImpersonationDenied means the session impersonates another session with a
+	// grant which isn't approved or has expired.
+	sessionCheckImpersonationDenied
+	// sessionCheckClientCertificateMismatch means the session is bound to another client
+	// certificate.
+	sessionCheckClientCertificateMismatch
+)
+
+// checkSession checks the session against the requirements of the route's policy which aren't
+// part of its evaluation. Check and BatchCheck both use it, so neither accepts a session the
+// other rejects.
+func (a *Authorize) checkSession(
+	ctx context.Context,
+	policy *config.Policy,
+	s sessionOrServiceAccount,
+	rawClientCertificate string,
+	now time.Time,
+) sessionCheck {
+	ss, isSession := s.(*session.Session)
+	switch {
+	case isSession && !isSessionLifetimeAllowed(a.currentOptions.Load(), policy, ss, now):
+		return sessionCheckRenew
+	case isSession && !isSessionAuthTimeAllowed(policy, ss, now):
+		return sessionCheckReauthenticate
+	case !a.isImpersonationAllowed(s, now):
+		return sessionCheckImpersonationDenied
+	case isSession && requiresClientCertificateBinding(policy, ss, rawClientCertificate):
+		return sessionCheckReauthenticate
+	case !isClientCertificateBindingAllowed(ctx, policy, s, rawClientCertificate):
+		return sessionCheckClientCertificateMismatch
+	}
+	return sessionCheckOK
+}
+
+// isSessionLifetimeAllowed returns false if the session is older than the policy's session
+// lifetime, or hasn't been used for longer than the session idle timeout.
+func isSessionLifetimeAllowed(options *config.Options, policy *config.Policy, s *session.Session, now time.Time) bool {
+	if policy != nil && policy.SessionLifetime != nil && s.GetIssuedAt().IsValid() &&
+		now.Sub(s.GetIssuedAt().AsTime()) > *policy.SessionLifetime {
+		return false
+	}
+	if idleTimeout := options.GetSessionIdleTimeout(policy); idleTimeout > 0 && s.GetAccessedAt().IsValid() &&
+		now.Sub(s.GetAccessedAt().AsTime()) > idleTimeout {
+		return false
+	}
+	return true
+}
+
+// isSessionAuthTimeAllowed returns false if the user signed in to the identity provider longer
+// ago than the policy's max session age.
+func isSessionAuthTimeAllowed(policy *config.Policy, s *session.Session, now time.Time) bool {
+	if policy == nil || policy.MaxSessionAge == nil {
+		return true
+	}
+	return now.Sub(getSessionAuthTime(s)) <= *policy.MaxSessionAge
+}
+
+// getSessionAuthTime returns the time the user signed in to the identity provider, using the
+// auth_time claim when the identity provider includes it, and otherwise the time the session was
+// created.
+func getSessionAuthTime(s *session.Session) time.Time {
+	if values := s.GetClaims()["auth_time"].GetValues(); len(values) > 0 {
+		if secs := values[0].GetNumberValue(); secs > 0 {
+			return time.Unix(int64(secs), 0)
+		}
+	}
+	return s.GetIssuedAt().AsTime()
+}
+
+// isServiceAccountAllowed returns false if the service account has expired, or isn't allowed
+// to be used for the request's host.
+func isServiceAccountAllowed(sa *user.ServiceAccount, requestURL url.URL, now time.Time) bool {
+	return !sa.IsExpired(now) && sa.IsAudienceAllowed(urlutil.StripPort(requestURL.Host))
+}
@@ -3,13 +3,18 @@ package authorize
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/identity"
 	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
 )
 
 func TestLoadSession(t *testing.T) {
@@ -74,3 +79,73 @@ func TestLoadSession(t *testing.T) {
 		assert.NotNil(t, sess)
 	})
 }
+
+func TestIsSessionLifetimeAllowed(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	minute, hour, day := time.Minute, time.Hour, 24*time.Hour
+	s := &session.Session{
+		IssuedAt:   timestamppb.New(now.Add(-2 * time.Hour)),
+		AccessedAt: timestamppb.New(now.Add(-5 * time.Minute)),
+	}
+
+	for _, tc := range []struct {
+		name        string
+		idleTimeout time.Duration
+		policy      *config.Policy
+		s           *session.Session
+		expect      bool
+	}{
+		{"no policy", 0, nil, s, true},
+		{"no limits", 0, &config.Policy{}, s, true},
+		{"within lifetime", 0, &config.Policy{SessionLifetime: &day}, s, true},
+		{"exceeds lifetime", 0, &config.Policy{SessionLifetime: &hour}, s, false},
+		{"within idle timeout", 0, &config.Policy{SessionIdleTimeout: &hour}, s, true},
+		{"exceeds idle timeout", 0, &config.Policy{SessionIdleTimeout: &minute}, s, false},
+		{"exceeds global idle timeout", time.Minute, nil, s, false},
+		{"policy overrides global idle timeout", time.Minute, &config.Policy{SessionIdleTimeout: &hour}, s, true},
+		{"no timestamps", 0, &config.Policy{SessionLifetime: &minute, SessionIdleTimeout: &minute}, &session.Session{}, true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			options := config.NewDefaultOptions()
+			options.SessionIdleTimeout = tc.idleTimeout
+			assert.Equal(t, tc.expect, isSessionLifetimeAllowed(options, tc.policy, tc.s, now))
+		})
+	}
+}
+
+func TestIsSessionAuthTimeAllowed(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	hour := time.Hour
+	policy := &config.Policy{MaxSessionAge: &hour}
+
+	s := &session.Session{IssuedAt: timestamppb.New(now.Add(-time.Minute))}
+	assert.True(t, isSessionAuthTimeAllowed(nil, s, now))
+	assert.True(t, isSessionAuthTimeAllowed(&config.Policy{}, s, now))
+	assert.True(t, isSessionAuthTimeAllowed(policy, s, now))
+
+	s.AddClaims(identity.FlattenedClaims{
+		"auth_time": {float64(now.Add(-2 * time.Hour).Unix())},
+	})
+	assert.False(t, isSessionAuthTimeAllowed(policy, s, now), "should use the auth_time claim")
+
+	s = &session.Session{IssuedAt: timestamppb.New(now.Add(-2 * time.Hour))}
+	assert.False(t, isSessionAuthTimeAllowed(policy, s, now))
+}
+
+func TestIsServiceAccountAllowed(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	requestURL := url.URL{Scheme: "https", Host: "app.example.com:443"}
+
+	assert.True(t, isServiceAccountAllowed(&user.ServiceAccount{}, requestURL, now))
+	assert.True(t, isServiceAccountAllowed(&user.ServiceAccount{
+		ExpiresAt: timestamppb.New(now.Add(time.Minute)),
+		Audiences: []string{"other.example.com", "app.example.com"},
+	}, requestURL, now))
+	assert.False(t, isServiceAccountAllowed(&user.ServiceAccount{
+		ExpiresAt: timestamppb.New(now),
+	}, requestURL, now), "should not allow expired service accounts")
+	assert.False(t, isServiceAccountAllowed(&user.ServiceAccount{
+		Audiences: []string{"other.example.com"},
+	}, requestURL, now), "should not allow other audiences")
+}
@@ -0,0 +1,64 @@
+package authorize
+
+import (
+	"context"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/signingkey"
+	"github.com/pomerium/pomerium/pkg/grpc/crypt"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+var signingKeyTypeURL = grpcutil.GetTypeURL(new(crypt.SigningKey))
+
+// updateSigningKeyRecord updates the synced signing keys from a databroker record. The
+// stateLock must be held.
+func (a *Authorize) updateSigningKeyRecord(ctx context.Context, record *databroker.Record) {
+	if record.GetDeletedAt() != nil {
+		delete(a.signingKeys, record.GetId())
+		return
+	}
+
+	key := new(crypt.SigningKey)
+	if err := record.GetData().UnmarshalTo(key); err != nil {
+		log.Error(ctx).Err(err).Msg("authorize: error unmarshaling signing key")
+		return
+	}
+	a.signingKeys[record.GetId()] = key
+}
+
+// updateSigningKey updates the store with the active signing key when signing keys are
+// rotated automatically. Until the first signing key is synced, the evaluator's generated
+// key is used.
+func (a *Authorize) updateSigningKey(ctx context.Context) {
+	opts := a.currentOptions.Load()
+	if opts.SigningKeyRotationInterval <= 0 {
+		return
+	}
+	sharedKeys, err := opts.GetAcceptedSharedKeys()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("authorize: error loading signing key")
+		return
+	}
+
+	a.stateLock.Lock()
+	defer a.stateLock.Unlock()
+
+	keys := make([]*crypt.SigningKey, 0, len(a.signingKeys))
+	for _, key := range a.signingKeys {
+		keys = append(keys, key)
+	}
+	active := signingkey.Active(keys, time.Now())
+	if active == nil {
+		return
+	}
+
+	jwk, err := signingkey.PrivateJWK(sharedKeys, active)
+	if err != nil {
+		log.Error(ctx).Err(err).Str("key-id", active.GetId()).Msg("authorize: error loading signing key")
+		return
+	}
+	a.store.UpdateSigningKey(jwk)
+}
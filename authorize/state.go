@@ -12,6 +12,7 @@ import (
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/encoding"
 	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/revocation"
 	"github.com/pomerium/pomerium/pkg/grpc"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/protoutil"
@@ -19,16 +20,26 @@ import (
 
 var outboundGRPCConnection = new(grpc.CachedOutboundGRPClientConn)
 
+// jwtVerificationCacheSize is the number of session JWT verifications cached.
+const jwtVerificationCacheSize = 1 << 14
+
 type authorizeState struct {
 	sharedKey                  []byte
+	acceptedSharedKeys         [][]byte
 	evaluator                  *evaluator.Evaluator
 	encoder                    encoding.MarshalUnmarshaler
+	jwtVerificationCache       *jws.CachedVerifier
 	dataBrokerClientConnection *googlegrpc.ClientConn
 	dataBrokerClient           databroker.DataBrokerServiceClient
 	auditEncryptor             *protoutil.Encryptor
 }
 
-func newAuthorizeStateFromConfig(cfg *config.Config, store *store.Store) (*authorizeState, error) {
+func newAuthorizeStateFromConfig(
+	cfg *config.Config,
+	store *store.Store,
+	revocationChecker *revocation.Checker,
+	previousPolicyEvaluator *evaluator.Evaluator,
+) (*authorizeState, error) {
 	if err := validateOptions(cfg.Options); err != nil {
 		return nil, fmt.Errorf("authorize: bad options: %w", err)
 	}
@@ -37,7 +48,7 @@ func newAuthorizeStateFromConfig(cfg *config.Config, store *store.Store) (*autho
 
 	var err error
 
-	state.evaluator, err = newPolicyEvaluator(cfg.Options, store)
+	state.evaluator, err = newPolicyEvaluator(cfg.Options, store, revocationChecker, previousPolicyEvaluator)
 	if err != nil {
 		return nil, fmt.Errorf("authorize: failed to update policy with options: %w", err)
 	}
@@ -46,11 +57,21 @@ func newAuthorizeStateFromConfig(cfg *config.Config, store *store.Store) (*autho
 	if err != nil {
 		return nil, err
 	}
+	state.acceptedSharedKeys, err = cfg.Options.GetAcceptedSharedKeys()
+	if err != nil {
+		return nil, err
+	}
 
-	state.encoder, err = jws.NewHS256Signer(state.sharedKey)
+	encoder, err := jws.NewHS256Signer(state.sharedKey, state.acceptedSharedKeys[1:]...)
+	if err != nil {
+		return nil, err
+	}
+	// the cache is recreated with the state, so verifications don't outlive a shared key change
+	state.jwtVerificationCache, err = jws.NewCachedVerifier(jwtVerificationCacheSize, encoder)
 	if err != nil {
 		return nil, err
 	}
+	state.encoder = state.jwtVerificationCache
 
 	sharedKey, err := cfg.Options.GetSharedKey()
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/pkg/grpc/crypt"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
@@ -49,15 +50,24 @@ func (syncer *dataBrokerSyncer) GetDataBrokerServiceClient() databroker.DataBrok
 func (syncer *dataBrokerSyncer) ClearRecords(ctx context.Context) {
 	syncer.authorize.stateLock.Lock()
 	syncer.authorize.store.ClearRecords()
+	syncer.authorize.signingKeys = map[string]*crypt.SigningKey{}
 	syncer.authorize.stateLock.Unlock()
 }
 
 func (syncer *dataBrokerSyncer) UpdateRecords(ctx context.Context, serverVersion uint64, records []*databroker.Record) {
+	signingKeysUpdated := false
 	syncer.authorize.stateLock.Lock()
 	for _, record := range records {
 		syncer.authorize.store.UpdateRecord(serverVersion, record)
+		if record.GetType() == signingKeyTypeURL {
+			syncer.authorize.updateSigningKeyRecord(ctx, record)
+			signingKeysUpdated = true
+		}
 	}
 	syncer.authorize.stateLock.Unlock()
+	if signingKeysUpdated {
+		syncer.authorize.updateSigningKey(ctx)
+	}
 
 	// the first time we update records we signal the initial sync
 	syncer.signalOnce.Do(func() {
@@ -119,13 +129,11 @@ func (a *Authorize) forceSyncSession(ctx context.Context, sessionID string) sess
 
 	s, ok := a.store.GetRecordData(grpcutil.GetTypeURL(new(session.Session)), sessionID).(*session.Session)
 	if ok {
-		a.accessTracker.TrackSessionAccess(sessionID)
 		return s
 	}
 
 	sa, ok := a.store.GetRecordData(grpcutil.GetTypeURL(new(user.ServiceAccount)), sessionID).(*user.ServiceAccount)
 	if ok {
-		a.accessTracker.TrackServiceAccountAccess(sessionID)
 		return sa
 	}
 
@@ -138,10 +146,19 @@ func (a *Authorize) forceSyncSession(ctx context.Context, sessionID string) sess
 	if !ok {
 		return nil
 	}
-	a.accessTracker.TrackSessionAccess(sessionID)
 	return s
 }
 
+// trackAccess records that the session or service account was used.
+func (a *Authorize) trackAccess(s sessionOrServiceAccount) {
+	switch s := s.(type) {
+	case *session.Session:
+		a.accessTracker.TrackSessionAccess(s.GetId())
+	case *user.ServiceAccount:
+		a.accessTracker.TrackServiceAccountAccess(s.GetId())
+	}
+}
+
 func (a *Authorize) forceSyncUser(ctx context.Context, userID string) *user.User {
 	ctx, span := trace.StartSpan(ctx, "authorize.forceSyncUser")
 	defer span.End()
@@ -180,7 +197,7 @@ func (a *Authorize) waitForRecordSync(ctx context.Context, recordTypeURL, record
 			return current, nil
 		}
 
-		_, err := a.state.Load().dataBrokerClient.Get(ctx, &databroker.GetRequest{
+		res, err := a.state.Load().dataBrokerClient.Get(ctx, &databroker.GetRequest{
 			Type: recordTypeURL,
 			Id:   recordID,
 		})
@@ -196,6 +213,12 @@ func (a *Authorize) waitForRecordSync(ctx context.Context, recordTypeURL, record
 			return nil, err
 		}
 
+		// use the record until the sync catches up with it, rather than waiting for the sync
+		a.store.AddRecentRecord(res.GetRecord())
+		if current := a.store.GetRecordData(recordTypeURL, recordID); current != nil {
+			return current, nil
+		}
+
 		select {
 		case <-ctx.Done():
 			log.Warn(ctx).
@@ -114,10 +114,32 @@ func TestAuthorize_waitForRecordSync(t *testing.T) {
 		}
 		a.waitForRecordSync(ctx, grpcutil.GetTypeURL(new(session.Session)), "SESSION_ID")
 	})
+	t.Run("recent record", func(t *testing.T) {
+		a, err := New(&config.Config{Options: o})
+		require.NoError(t, err)
+
+		callCount := 0
+		a.state.Load().dataBrokerClient = mockDataBrokerServiceClient{
+			get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+				callCount++
+				s := &session.Session{Id: "SESSION_ID"}
+				return &databroker.GetResponse{Record: newRecord(s)}, nil
+			},
+		}
+		for i := 0; i < 3; i++ {
+			msg, err := a.waitForRecordSync(ctx, grpcutil.GetTypeURL(new(session.Session)), "SESSION_ID")
+			assert.NoError(t, err)
+			assert.NotNil(t, msg, "should use the record before the sync catches up with it")
+		}
+		assert.Equal(t, 1, callCount, "should be called once")
+	})
 	t.Run("timeout", func(t *testing.T) {
 		a, err := New(&config.Config{Options: o})
 		require.NoError(t, err)
 
+		// the sync is past the record, so it isn't used until it's synced
+		a.store.UpdateRecord(0, &databroker.Record{Version: 2})
+
 		tctx, clearTimeout := context.WithTimeout(ctx, time.Millisecond*100)
 		defer clearTimeout()
 
@@ -5,16 +5,29 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
 
+	"github.com/pomerium/pomerium/internal/cmd/auditcmd"
+	"github.com/pomerium/pomerium/internal/cmd/bench"
+	"github.com/pomerium/pomerium/internal/cmd/configcmd"
+	"github.com/pomerium/pomerium/internal/cmd/databrokercmd"
+	"github.com/pomerium/pomerium/internal/cmd/devices"
+	"github.com/pomerium/pomerium/internal/cmd/ipsets"
+	"github.com/pomerium/pomerium/internal/cmd/leases"
 	"github.com/pomerium/pomerium/internal/cmd/pomerium"
+	"github.com/pomerium/pomerium/internal/cmd/routes"
+	"github.com/pomerium/pomerium/internal/cmd/serviceaccounts"
+	"github.com/pomerium/pomerium/internal/cmd/sessions"
+	"github.com/pomerium/pomerium/internal/cmd/validate"
 	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/fips"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/version"
 )
 
 var (
 	versionFlag = flag.Bool("version", false, "prints the version")
-	configFile  = flag.String("config", "", "Specify configuration file location")
+	configFile  = flag.String("config", "", "Specify configuration file or directory location")
 )
 
 func main() {
@@ -22,10 +35,89 @@ func main() {
 	if *versionFlag {
 		fmt.Println("pomerium:", version.FullVersion())
 		fmt.Println("envoy:", files.FullVersion())
+		fmt.Println("fips:", fips.Enabled())
 		return
 	}
 
 	ctx := context.Background()
+	if flag.Arg(0) == "service-accounts" {
+		if err := serviceaccounts.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "audit" {
+		if err := auditcmd.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "databroker" {
+		if err := databrokercmd.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "devices" {
+		if err := devices.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "ip-sets" {
+		if err := ipsets.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "sessions" {
+		if err := sessions.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "config" {
+		if err := configcmd.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "leases" {
+		if err := leases.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "validate" {
+		if err := validate.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "bench" {
+		if err := bench.Run(ctx, *configFile, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "routes" {
+		if err := routes.Run(ctx, flag.Args()[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := run(ctx); !errors.Is(err, context.Canceled) {
 		log.Fatal().Err(err).Msg("cmd/pomerium")
 	}
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// Access log sink types.
+const (
+	AccessLogSinkTypeStdout = "stdout"
+	AccessLogSinkTypeFile   = "file"
+	AccessLogSinkTypeSyslog = "syslog"
+)
+
+// Access log formats.
+const (
+	// AccessLogFormatJSON writes each entry as a JSON object on its own line.
+	AccessLogFormatJSON = "json"
+	// AccessLogFormatCombined writes each entry in the NCSA combined log format.
+	AccessLogFormatCombined = "combined"
+	// AccessLogFormatCEF writes each entry in the ArcSight Common Event Format.
+	AccessLogFormatCEF = "cef"
+	// AccessLogFormatLEEF writes each entry in the QRadar Log Event Extended Format.
+	AccessLogFormatLEEF = "leef"
+	// AccessLogFormatECS writes each entry as an Elastic Common Schema JSON document.
+	AccessLogFormatECS = "ecs"
+)
+
+// AccessLogMetadataKey is the key of the ext_authz dynamic metadata set to the access log fields
+// known by the authorize service, like the user and the outcome of the policy evaluation.
+const AccessLogMetadataKey = "access_log"
+
+// Prefixes of the access log fields of request headers, response headers and claims, like
+// request.header.x-forwarded-proto.
+const (
+	AccessLogFieldRequestHeaderPrefix  = "request.header."
+	AccessLogFieldResponseHeaderPrefix = "response.header."
+	AccessLogFieldClaimPrefix          = "claim."
+)
+
+// DefaultAccessLogFields are the fields of the access logs when access_log_fields isn't set.
+var DefaultAccessLogFields = []string{
+	"upstream-cluster",
+	"method",
+	"authority",
+	"path",
+	"user-agent",
+	"referer",
+	"forwarded-for",
+	"request-id",
+	"duration",
+	"size",
+	"response-code",
+	"response-code-details",
+}
+
+// accessLogFields are the fields which can be added to access logs, besides headers and claims.
+var accessLogFields = map[string]bool{
+	"upstream-cluster":             false,
+	"method":                       false,
+	"protocol":                     false,
+	"authority":                    false,
+	"path":                         false,
+	"user-agent":                   false,
+	"referer":                      false,
+	"forwarded-for":                false,
+	"remote-address":               false,
+	"request-id":                   false,
+	"duration":                     false,
+	"request-duration":             false,
+	"upstream-request-duration":    false,
+	"upstream-first-byte-duration": false,
+	"upstream-duration":            false,
+	"first-byte-duration":          false,
+	"size":                         false,
+	"response-code":                false,
+	"response-code-details":        false,
+	// set by the authorize service
+	"allow":      true,
+	"deny":       true,
+	"reasons":    true,
+	"user":       true,
+	"email":      true,
+	"session-id": true,
+}
+
+// IsAccessLogAuthorizeField returns true if the access log field is set by the authorize
+// service.
+func IsAccessLogAuthorizeField(field string) bool {
+	return accessLogFields[field] || strings.HasPrefix(field, AccessLogFieldClaimPrefix)
+}
+
+// GetAccessLogFields returns the fields of the access logs.
+func (o *Options) GetAccessLogFields() []string {
+	if len(o.AccessLogFields) == 0 {
+		return DefaultAccessLogFields
+	}
+	return o.AccessLogFields
+}
+
+// GetAccessLogHeaders returns the names of the request and response headers of the access log
+// fields.
+func (o *Options) GetAccessLogHeaders() (requestHeaders, responseHeaders []string) {
+	for _, field := range o.GetAccessLogFields() {
+		if name := strings.TrimPrefix(field, AccessLogFieldRequestHeaderPrefix); name != field {
+			requestHeaders = append(requestHeaders, name)
+		}
+		if name := strings.TrimPrefix(field, AccessLogFieldResponseHeaderPrefix); name != field {
+			responseHeaders = append(responseHeaders, name)
+		}
+	}
+	return requestHeaders, responseHeaders
+}
+
+func (o *Options) validateAccessLogFields() error {
+	for _, field := range o.AccessLogFields {
+		var name string
+		switch {
+		case strings.HasPrefix(field, AccessLogFieldRequestHeaderPrefix):
+			name = strings.TrimPrefix(field, AccessLogFieldRequestHeaderPrefix)
+		case strings.HasPrefix(field, AccessLogFieldResponseHeaderPrefix):
+			name = strings.TrimPrefix(field, AccessLogFieldResponseHeaderPrefix)
+		case strings.HasPrefix(field, AccessLogFieldClaimPrefix):
+			if field == AccessLogFieldClaimPrefix {
+				return fmt.Errorf("config: access log field %s requires a claim name", field)
+			}
+			continue
+		default:
+			if _, ok := accessLogFields[field]; !ok {
+				return fmt.Errorf("config: unknown access log field: %s", field)
+			}
+			continue
+		}
+		// envoy logs the headers by their lowercase names
+		if name == "" || name != strings.ToLower(name) {
+			return fmt.Errorf("config: access log field %s requires a lowercase header name", field)
+		}
+	}
+	return nil
+}
+
+// An AccessLogSink is a destination of access logs.
+type AccessLogSink struct {
+	// Type is stdout, file or syslog.
+	Type string `mapstructure:"type" yaml:"type"`
+	// Format is json, combined, cef, leef or ecs. Defaults to json.
+	Format string `mapstructure:"format" yaml:"format,omitempty"`
+
+	// Path is the path of the access log file.
+	Path string `mapstructure:"path" yaml:"path,omitempty"`
+	// MaxSize is the size in megabytes at which the file is rotated. When zero the file isn't
+	// rotated.
+	MaxSize int64 `mapstructure:"max_size" yaml:"max_size,omitempty"`
+	// MaxAge is how long rotated files are kept. When zero they're kept regardless of age.
+	MaxAge time.Duration `mapstructure:"max_age" yaml:"max_age,omitempty"`
+	// MaxBackups is the number of rotated files kept. When zero they're kept regardless of
+	// number.
+	MaxBackups int `mapstructure:"max_backups" yaml:"max_backups,omitempty"`
+
+	// Network is udp or tcp. Defaults to udp.
+	Network string `mapstructure:"network" yaml:"network,omitempty"`
+	// Address is the host:port of the syslog server.
+	Address string `mapstructure:"address" yaml:"address,omitempty"`
+	// Tag is the syslog app name. Defaults to pomerium.
+	Tag string `mapstructure:"tag" yaml:"tag,omitempty"`
+}
+
+// NewAccessLogSinkFromProto creates a new AccessLogSink from a protobuf message.
+func NewAccessLogSinkFromProto(pb *configpb.Settings_AccessLogSink) AccessLogSink {
+	sink := AccessLogSink{
+		Type:       pb.GetType(),
+		Format:     pb.GetFormat(),
+		Path:       pb.GetPath(),
+		MaxSize:    pb.GetMaxSize(),
+		MaxBackups: int(pb.GetMaxBackups()),
+		Network:    pb.GetNetwork(),
+		Address:    pb.GetAddress(),
+		Tag:        pb.GetTag(),
+	}
+	if pb.MaxAge != nil {
+		sink.MaxAge = pb.GetMaxAge().AsDuration()
+	}
+	return sink
+}
+
+// ToProto converts the AccessLogSink to a protobuf message.
+func (sink AccessLogSink) ToProto() *configpb.Settings_AccessLogSink {
+	pb := &configpb.Settings_AccessLogSink{
+		Type:       sink.Type,
+		Format:     sink.Format,
+		Path:       sink.Path,
+		MaxSize:    sink.MaxSize,
+		MaxBackups: int32(sink.MaxBackups),
+		Network:    sink.Network,
+		Address:    sink.Address,
+		Tag:        sink.Tag,
+	}
+	if sink.MaxAge != 0 {
+		pb.MaxAge = durationpb.New(sink.MaxAge)
+	}
+	return pb
+}
+
+// GetFormat returns the format of the sink.
+func (sink AccessLogSink) GetFormat() string {
+	if sink.Format == "" {
+		return AccessLogFormatJSON
+	}
+	return sink.Format
+}
+
+// GetNetwork returns the network of the syslog server.
+func (sink AccessLogSink) GetNetwork() string {
+	if sink.Network == "" {
+		return "udp"
+	}
+	return sink.Network
+}
+
+// GetTag returns the syslog app name.
+func (sink AccessLogSink) GetTag() string {
+	if sink.Tag == "" {
+		return "pomerium"
+	}
+	return sink.Tag
+}
+
+// Validate validates the access log sink.
+func (sink AccessLogSink) Validate() error {
+	switch sink.GetFormat() {
+	case AccessLogFormatJSON, AccessLogFormatCombined, AccessLogFormatCEF, AccessLogFormatLEEF, AccessLogFormatECS:
+	default:
+		return fmt.Errorf("unknown format: %s", sink.Format)
+	}
+
+	switch sink.Type {
+	case AccessLogSinkTypeStdout:
+	case AccessLogSinkTypeFile:
+		if sink.Path == "" {
+			return fmt.Errorf("path is required")
+		}
+		if sink.MaxSize < 0 || sink.MaxAge < 0 || sink.MaxBackups < 0 {
+			return fmt.Errorf("max_size, max_age and max_backups must not be negative")
+		}
+	case AccessLogSinkTypeSyslog:
+		switch sink.GetNetwork() {
+		case "udp", "tcp":
+		default:
+			return fmt.Errorf("unknown network: %s", sink.Network)
+		}
+		if _, _, err := net.SplitHostPort(sink.Address); err != nil {
+			return fmt.Errorf("invalid address: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown type: %s", sink.Type)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/telemetry"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// An AuditSink is a destination of audit events.
+type AuditSink struct {
+	// Type is webhook, kafka, sqs or pubsub.
+	Type string `mapstructure:"type" yaml:"type"`
+	// URL is the URL of a webhook or an SQS queue.
+	URL string `mapstructure:"url" yaml:"url,omitempty"`
+	// Headers are added to webhook requests.
+	Headers map[string]string `mapstructure:"headers" yaml:"headers,omitempty"`
+	// Brokers are the bootstrap brokers of a Kafka cluster, as host:port.
+	Brokers []string `mapstructure:"brokers" yaml:"brokers,omitempty"`
+	// Topic is the Kafka topic, or the Pub/Sub topic, like projects/my-project/topics/my-topic.
+	Topic string `mapstructure:"topic" yaml:"topic,omitempty"`
+	// TLS enables TLS for Kafka.
+	TLS bool `mapstructure:"tls" yaml:"tls,omitempty"`
+	// Username and Password enable SASL/PLAIN authentication for Kafka.
+	Username string `mapstructure:"username" yaml:"username,omitempty"`
+	Password string `mapstructure:"password" yaml:"password,omitempty"`
+	// Region is the AWS region of an SQS queue. Defaults to the region of the queue URL.
+	Region string `mapstructure:"region" yaml:"region,omitempty"`
+	// Format is json, cef, leef or ecs. Defaults to json.
+	Format string `mapstructure:"format" yaml:"format,omitempty"`
+}
+
+// NewAuditSinkFromProto creates a new AuditSink from a protobuf message.
+func NewAuditSinkFromProto(pb *configpb.Settings_AuditSink) AuditSink {
+	return AuditSink{
+		Type:     pb.GetType(),
+		URL:      pb.GetUrl(),
+		Headers:  pb.GetHeaders(),
+		Brokers:  pb.GetBrokers(),
+		Topic:    pb.GetTopic(),
+		TLS:      pb.GetTls(),
+		Username: pb.GetUsername(),
+		Password: pb.GetPassword(),
+		Region:   pb.GetRegion(),
+		Format:   pb.GetFormat(),
+	}
+}
+
+// Validate validates the audit sink.
+func (sink AuditSink) Validate() error {
+	switch sink.Format {
+	case "", audit.FormatJSON, audit.FormatCEF, audit.FormatLEEF, audit.FormatECS:
+	default:
+		return fmt.Errorf("unknown format: %s", sink.Format)
+	}
+
+	switch sink.Type {
+	case audit.SinkTypeWebhook, audit.SinkTypeSQS:
+		if sink.URL == "" {
+			return fmt.Errorf("url is required")
+		}
+	case audit.SinkTypeKafka:
+		if len(sink.Brokers) == 0 || sink.Topic == "" {
+			return fmt.Errorf("brokers and topic are required")
+		}
+	case audit.SinkTypePubSub:
+		if sink.Topic == "" {
+			return fmt.Errorf("topic is required")
+		}
+	default:
+		return fmt.Errorf("unknown type: %s", sink.Type)
+	}
+	return nil
+}
+
+// GetAuditOptions returns the options of the audit event pipeline.
+func (o *Options) GetAuditOptions() *audit.Options {
+	opts := &audit.Options{
+		Service:        telemetry.ServiceName(o.Services),
+		SpillDirectory: o.AuditSpillDirectory,
+	}
+	for _, sink := range o.AuditSinks {
+		opts.Sinks = append(opts.Sinks, audit.SinkOptions{
+			Type:     sink.Type,
+			URL:      sink.URL,
+			Headers:  sink.Headers,
+			Brokers:  sink.Brokers,
+			Topic:    sink.Topic,
+			TLS:      sink.TLS,
+			Username: sink.Username,
+			Password: sink.Password,
+			Region:   sink.Region,
+			Format:   sink.Format,
+		})
+	}
+	return opts
+}
+
+// An AuditManager configures the audit event pipeline based on options, and publishes an event
+// when the configuration changes.
+type AuditManager struct {
+	mu       sync.Mutex
+	checksum uint64
+}
+
+// NewAuditManager creates a new AuditManager.
+func NewAuditManager(ctx context.Context, src Source) *AuditManager {
+	mgr := &AuditManager{}
+	src.OnConfigChange(ctx, mgr.OnConfigChange)
+	mgr.OnConfigChange(ctx, src.GetConfig())
+	return mgr
+}
+
+// Close stops the audit event pipeline.
+func (mgr *AuditManager) Close() error {
+	audit.Close()
+	return nil
+}
+
+// OnConfigChange is called whenever configuration changes.
+func (mgr *AuditManager) OnConfigChange(ctx context.Context, cfg *Config) {
+	if cfg == nil || cfg.Options == nil {
+		return
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	audit.Update(ctx, cfg.Options.GetAuditOptions())
+
+	checksum := cfg.Checksum()
+	if mgr.checksum != 0 && checksum != mgr.checksum {
+		audit.Publish(ctx, &audit.Event{
+			Type:    audit.EventTypeConfigChanged,
+			Message: "configuration changed",
+			Details: map[string]string{
+				"previous_checksum": strconv.FormatUint(mgr.checksum, 16),
+				"checksum":          strconv.FormatUint(checksum, 16),
+			},
+		})
+	}
+	mgr.checksum = checksum
+}
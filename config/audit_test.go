@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/internal/audit"
+)
+
+func TestAuditSink_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		sink AuditSink
+		err  bool
+	}{
+		{"webhook", AuditSink{Type: "webhook", URL: "https://audit.example.com"}, false},
+		{"webhook without a url", AuditSink{Type: "webhook"}, true},
+		{"kafka", AuditSink{Type: "kafka", Brokers: []string{"kafka:9092"}, Topic: "audit"}, false},
+		{"kafka without a topic", AuditSink{Type: "kafka", Brokers: []string{"kafka:9092"}}, true},
+		{"sqs", AuditSink{Type: "sqs", URL: "https://sqs.us-east-1.amazonaws.com/123456789012/audit"}, false},
+		{"pubsub", AuditSink{Type: "pubsub", Topic: "projects/p/topics/audit"}, false},
+		{"unknown", AuditSink{Type: "syslog"}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.sink.Validate()
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOptions_GetAuditOptions(t *testing.T) {
+	o := NewDefaultOptions()
+	o.Services = "authorize"
+	o.AuditSpillDirectory = "/var/lib/pomerium/audit"
+	o.AuditSinks = []AuditSink{{Type: "webhook", URL: "https://audit.example.com", Headers: map[string]string{"X-Token": "t"}}}
+	assert.Equal(t, &audit.Options{
+		Service: "pomerium-authorize",
+		Sinks: []audit.SinkOptions{{
+			Type:    "webhook",
+			URL:     "https://audit.example.com",
+			Headers: map[string]string{"X-Token": "t"},
+		}},
+		SpillDirectory: "/var/lib/pomerium/audit",
+	}, o.GetAuditOptions())
+}
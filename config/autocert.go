@@ -50,13 +50,39 @@ type AutocertOptions struct {
 	// defaults to $XDG_DATA_HOME/pomerium
 	Folder string `mapstructure:"autocert_dir" yaml:"autocert_dir,omitempty"`
 
+	// Storage is where certificates, ACME account keys and pending challenges are stored: file,
+	// in Folder, or databroker, so they're shared by all the instances of pomerium and only one
+	// of them obtains each certificate. Defaults to file.
+	Storage string `mapstructure:"autocert_storage" yaml:"autocert_storage,omitempty"`
+
 	// TrustedCA is the base64-encoded certificate (bundle) to trust when communicating with an ACME CA.
 	TrustedCA string `mapstructure:"autocert_trusted_ca" yaml:"autocert_trusted_ca,omitempty"`
 
 	// TrustedCAFile points to a file that contains the certificate (bundle) to trust when communicating with an ACME CA.
 	TrustedCAFile string `mapstructure:"autocert_trusted_ca_file" yaml:"autocert_trusted_ca_file,omitempty"`
+
+	// DNSProvider is the DNS provider used to solve ACME DNS-01 challenges. When set, the
+	// DNS-01 challenge is used instead of the HTTP-01 challenge, so certificates can be issued
+	// for wildcard routes and when Pomerium isn't reachable from the internet.
+	DNSProvider string `mapstructure:"autocert_dns_provider" yaml:"autocert_dns_provider,omitempty"`
+
+	// DNSProviderOptions are the options of the DNS provider, like its credentials.
+	DNSProviderOptions map[string]string `mapstructure:"autocert_dns_provider_options" yaml:"autocert_dns_provider_options,omitempty"`
 }
 
+// Autocert storage types.
+const (
+	AutocertStorageFile       = "file"
+	AutocertStorageDataBroker = "databroker"
+)
+
+// DNS providers which can solve ACME DNS-01 challenges.
+const (
+	AutocertDNSProviderCloudflare     = "cloudflare"
+	AutocertDNSProviderGoogleCloudDNS = "google_cloud_dns"
+	AutocertDNSProviderRoute53        = "route53"
+)
+
 // Validate ensures the Options fields are valid, and hydrated.
 func (o *AutocertOptions) Validate() error {
 
@@ -94,5 +120,26 @@ func (o *AutocertOptions) Validate() error {
 		}
 	}
 
+	// validate the DNS provider
+	switch o.DNSProvider {
+	case "", AutocertDNSProviderCloudflare, AutocertDNSProviderGoogleCloudDNS, AutocertDNSProviderRoute53:
+	default:
+		return fmt.Errorf("config: unsupported autocert dns provider: %s", o.DNSProvider)
+	}
+	if o.DNSProvider == "" && len(o.DNSProviderOptions) > 0 {
+		return errors.New("config: autocert dns provider options require an autocert dns provider")
+	}
+
+	switch o.Storage {
+	case "", AutocertStorageFile, AutocertStorageDataBroker:
+	default:
+		return fmt.Errorf("config: unknown autocert storage: %s", o.Storage)
+	}
+
 	return nil
 }
+
+// UseDataBrokerStorage returns true if autocert stores its data in the databroker.
+func (o *AutocertOptions) UseDataBrokerStorage() bool {
+	return o.Storage == AutocertStorageDataBroker
+}
@@ -57,6 +57,10 @@ func TestAutocertOptions_Validate(t *testing.T) {
 		Folder        string
 		TrustedCA     string
 		TrustedCAFile string
+		Storage       string
+
+		DNSProvider        string
+		DNSProviderOptions map[string]string
 	}
 	type test struct {
 		fields  fields
@@ -103,6 +107,46 @@ func TestAutocertOptions_Validate(t *testing.T) {
 				cleanup: func() { os.Remove(f.Name()) },
 			}
 		},
+		"ok/dns-provider": func(t *testing.T) test {
+			return test{
+				fields: fields{
+					DNSProvider:        AutocertDNSProviderCloudflare,
+					DNSProviderOptions: map[string]string{"api_token": "TOKEN"},
+				},
+				wantErr: false,
+			}
+		},
+		"fail/unsupported-dns-provider": func(t *testing.T) test {
+			return test{
+				fields: fields{
+					DNSProvider: "bind",
+				},
+				wantErr: true,
+			}
+		},
+		"fail/dns-provider-options-without-provider": func(t *testing.T) test {
+			return test{
+				fields: fields{
+					DNSProviderOptions: map[string]string{"api_token": "TOKEN"},
+				},
+				wantErr: true,
+			}
+		},
+		"ok/databroker-storage": func(t *testing.T) test {
+			return test{
+				fields: fields{
+					Storage: AutocertStorageDataBroker,
+				},
+			}
+		},
+		"fail/unknown-storage": func(t *testing.T) test {
+			return test{
+				fields: fields{
+					Storage: "s3",
+				},
+				wantErr: true,
+			}
+		},
 		"fail/missing-eab-key": func(t *testing.T) test {
 			return test{
 				fields: fields{
@@ -173,6 +217,10 @@ func TestAutocertOptions_Validate(t *testing.T) {
 				Folder:        tc.fields.Folder,
 				TrustedCA:     tc.fields.TrustedCA,
 				TrustedCAFile: tc.fields.TrustedCAFile,
+				Storage:       tc.fields.Storage,
+
+				DNSProvider:        tc.fields.DNSProvider,
+				DNSProviderOptions: tc.fields.DNSProviderOptions,
 			}
 			if err := o.Validate(); (err != nil) != tc.wantErr {
 				t.Errorf("AutocertOptions.Validate() error = %v, wantErr %v", err, tc.wantErr)
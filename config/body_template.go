@@ -0,0 +1,152 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+)
+
+// errBodyTemplateNotCompiled is returned when a body template is rendered before it's validated.
+var errBodyTemplateNotCompiled = errors.New("body template is not compiled")
+
+// bodyTemplate is a compiled template of the body of a response.
+type bodyTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// jsonBodyTemplateFuncs are the functions of the templates of JSON bodies.
+var jsonBodyTemplateFuncs = texttemplate.FuncMap{
+	"json":              templateJSON,
+	"_escapeJSONString": escapeJSONString,
+}
+
+// compileBodyTemplate compiles the template of the body of a response with the content type.
+// Unless the content type is known not to be HTML, the template is compiled with html/template,
+// so the request data rendered in it is escaped. JSON templates escape the data rendered in them
+// as JSON strings.
+func compileBodyTemplate(name, body, contentType string) (bodyTemplate, error) {
+	switch {
+	case isHTMLContentType(contentType):
+		return htmltemplate.New(name).Parse(body)
+	case isJSONContentType(contentType):
+		return compileJSONBodyTemplate(name, body)
+	}
+	return texttemplate.New(name).Parse(body)
+}
+
+// compileJSONBodyTemplate compiles a template of a JSON body. Like html/template, the output of
+// every action is passed to an escaping function, so the data can't change the structure of the
+// JSON document when it's rendered inside a string. The json function renders a whole JSON value
+// instead, for use outside of strings.
+func compileJSONBodyTemplate(name, body string) (bodyTemplate, error) {
+	tpl, err := texttemplate.New(name).Funcs(jsonBodyTemplateFuncs).Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tpl.Templates() {
+		escapeJSONNode(t.Tree.Root)
+	}
+	return tpl, nil
+}
+
+func escapeJSONNode(node parse.Node) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, n := range node.Nodes {
+			escapeJSONNode(n)
+		}
+	case *parse.ActionNode:
+		// variable declarations don't output anything
+		if len(node.Pipe.Decl) > 0 {
+			return
+		}
+		cmds := node.Pipe.Cmds
+		if len(cmds) > 0 && isIdentifierCommand(cmds[len(cmds)-1], "json") {
+			return
+		}
+		node.Pipe.Cmds = append(cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      node.Pos,
+			Args:     []parse.Node{parse.NewIdentifier("_escapeJSONString").SetTree(nil).SetPos(node.Pos)},
+		})
+	case *parse.IfNode:
+		escapeJSONNode(node.List)
+		escapeJSONNode(node.ElseList)
+	case *parse.RangeNode:
+		escapeJSONNode(node.List)
+		escapeJSONNode(node.ElseList)
+	case *parse.WithNode:
+		escapeJSONNode(node.List)
+		escapeJSONNode(node.ElseList)
+	}
+}
+
+func isIdentifierCommand(cmd *parse.CommandNode, name string) bool {
+	if len(cmd.Args) == 0 {
+		return false
+	}
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	return ok && ident.Ident == name
+}
+
+// templateJSON renders the value as JSON.
+func templateJSON(v interface{}) (string, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// escapeJSONString renders the arguments like text/template does, escaped to be used inside of a
+// JSON string.
+func escapeJSONString(args ...interface{}) (string, error) {
+	bs, err := json.Marshal(fmt.Sprint(args...))
+	if err != nil {
+		return "", err
+	}
+	return string(bs[1 : len(bs)-1]), nil
+}
+
+// renderBodyTemplate renders a body template compiled with compileBodyTemplate.
+func renderBodyTemplate(tpl bodyTemplate, data interface{}) (string, error) {
+	if tpl == nil {
+		return "", errBodyTemplateNotCompiled
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	mediaType = strings.ToLower(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+	switch strings.ToLower(mediaType) {
+	case "text/html", "application/xhtml+xml", "image/svg+xml":
+		return true
+	}
+	return false
+}
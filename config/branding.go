@@ -0,0 +1,253 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/proto"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+var brandingColorRE = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Branding customizes the pages served by Pomerium, like the sign in, error and sign out
+// pages. A global branding can be set, and routes can override it for the pages shown to
+// users of the route.
+type Branding struct {
+	// Title is the title of the pages.
+	Title string `mapstructure:"title" yaml:"title,omitempty" json:"title,omitempty"`
+	// LogoURL is the URL of the logo shown in the header.
+	LogoURL string `mapstructure:"logo_url" yaml:"logo_url,omitempty" json:"logo_url,omitempty"`
+	// FaviconURL is the URL of the favicon.
+	FaviconURL string `mapstructure:"favicon_url" yaml:"favicon_url,omitempty" json:"favicon_url,omitempty"`
+	// PrimaryColor and SecondaryColor are the theme colors, as hex colors like #6F43E7.
+	PrimaryColor   string `mapstructure:"primary_color" yaml:"primary_color,omitempty" json:"primary_color,omitempty"`
+	SecondaryColor string `mapstructure:"secondary_color" yaml:"secondary_color,omitempty" json:"secondary_color,omitempty"`
+	// SupportURL replaces the links to Pomerium in the footer with a link to the given URL.
+	SupportURL string `mapstructure:"support_url" yaml:"support_url,omitempty" json:"support_url,omitempty"`
+	// Texts override the default texts of the pages.
+	Texts map[string]string `mapstructure:"texts" yaml:"texts,omitempty" json:"texts,omitempty"`
+	// LanguagePacks are texts by language tag. The language pack best matching the user's
+	// Accept-Language is used, falling back to Texts.
+	LanguagePacks map[string]map[string]string `mapstructure:"language_packs" yaml:"language_packs,omitempty" json:"language_packs,omitempty"`
+}
+
+// NewBrandingFromProto creates a new Branding from a protobuf message.
+func NewBrandingFromProto(pb *configpb.Branding) *Branding {
+	if pb == nil {
+		return nil
+	}
+	b := &Branding{
+		Title:          pb.GetTitle(),
+		LogoURL:        pb.GetLogoUrl(),
+		FaviconURL:     pb.GetFaviconUrl(),
+		PrimaryColor:   pb.GetPrimaryColor(),
+		SecondaryColor: pb.GetSecondaryColor(),
+		SupportURL:     pb.GetSupportUrl(),
+		Texts:          pb.GetTexts(),
+	}
+	if len(pb.GetLanguagePacks()) > 0 {
+		b.LanguagePacks = make(map[string]map[string]string, len(pb.GetLanguagePacks()))
+		for tag, pack := range pb.GetLanguagePacks() {
+			b.LanguagePacks[tag] = pack.GetTexts()
+		}
+	}
+	return b
+}
+
+// ToProto converts the Branding to a protobuf message.
+func (b *Branding) ToProto() *configpb.Branding {
+	if b == nil {
+		return nil
+	}
+	pb := &configpb.Branding{
+		Texts: b.Texts,
+	}
+	if b.Title != "" {
+		pb.Title = proto.String(b.Title)
+	}
+	if b.LogoURL != "" {
+		pb.LogoUrl = proto.String(b.LogoURL)
+	}
+	if b.FaviconURL != "" {
+		pb.FaviconUrl = proto.String(b.FaviconURL)
+	}
+	if b.PrimaryColor != "" {
+		pb.PrimaryColor = proto.String(b.PrimaryColor)
+	}
+	if b.SecondaryColor != "" {
+		pb.SecondaryColor = proto.String(b.SecondaryColor)
+	}
+	if b.SupportURL != "" {
+		pb.SupportUrl = proto.String(b.SupportURL)
+	}
+	if len(b.LanguagePacks) > 0 {
+		pb.LanguagePacks = make(map[string]*configpb.Branding_LanguagePack, len(b.LanguagePacks))
+		for tag, texts := range b.LanguagePacks {
+			pb.LanguagePacks[tag] = &configpb.Branding_LanguagePack{Texts: texts}
+		}
+	}
+	return pb
+}
+
+// Validate checks the validity of the branding.
+func (b *Branding) Validate() error {
+	if b == nil {
+		return nil
+	}
+	for _, rawURL := range []string{b.LogoURL, b.FaviconURL, b.SupportURL} {
+		if rawURL == "" {
+			continue
+		}
+		if _, err := url.Parse(rawURL); err != nil {
+			return fmt.Errorf("invalid url %q: %w", rawURL, err)
+		}
+	}
+	for _, color := range []string{b.PrimaryColor, b.SecondaryColor} {
+		if color != "" && !brandingColorRE.MatchString(color) {
+			return fmt.Errorf("invalid color %q, expected a hex color like #6F43E7", color)
+		}
+	}
+	for tag := range b.LanguagePacks {
+		if _, err := language.Parse(tag); err != nil {
+			return fmt.Errorf("invalid language pack %q: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// Merge returns a new Branding with the fields set in override replacing the fields of the
+// branding. Texts and language packs are merged by key.
+func (b *Branding) Merge(override *Branding) *Branding {
+	if b == nil {
+		return override
+	}
+	if override == nil {
+		return b
+	}
+
+	merged := *b
+	if override.Title != "" {
+		merged.Title = override.Title
+	}
+	if override.LogoURL != "" {
+		merged.LogoURL = override.LogoURL
+	}
+	if override.FaviconURL != "" {
+		merged.FaviconURL = override.FaviconURL
+	}
+	if override.PrimaryColor != "" {
+		merged.PrimaryColor = override.PrimaryColor
+	}
+	if override.SecondaryColor != "" {
+		merged.SecondaryColor = override.SecondaryColor
+	}
+	if override.SupportURL != "" {
+		merged.SupportURL = override.SupportURL
+	}
+	merged.Texts = mergeBrandingTexts(b.Texts, override.Texts)
+	if len(override.LanguagePacks) > 0 {
+		merged.LanguagePacks = make(map[string]map[string]string)
+		for tag, texts := range b.LanguagePacks {
+			merged.LanguagePacks[tag] = texts
+		}
+		for tag, texts := range override.LanguagePacks {
+			merged.LanguagePacks[tag] = mergeBrandingTexts(merged.LanguagePacks[tag], texts)
+		}
+	}
+	return &merged
+}
+
+// Localize returns the page data for the branding, with the texts of the language pack best
+// matching the given Accept-Language header.
+func (b *Branding) Localize(acceptLanguage string) map[string]interface{} {
+	if b == nil {
+		return nil
+	}
+
+	data := map[string]interface{}{}
+	for k, v := range map[string]string{
+		"title":          b.Title,
+		"logoUrl":        b.LogoURL,
+		"faviconUrl":     b.FaviconURL,
+		"primaryColor":   b.PrimaryColor,
+		"secondaryColor": b.SecondaryColor,
+		"supportUrl":     b.SupportURL,
+	} {
+		if v != "" {
+			data[k] = v
+		}
+	}
+
+	texts := b.Texts
+	if tag, ok := b.matchLanguage(acceptLanguage); ok {
+		data["language"] = tag
+		texts = mergeBrandingTexts(texts, b.LanguagePacks[tag])
+	}
+	if len(texts) > 0 {
+		data["texts"] = texts
+	}
+	return data
+}
+
+func (b *Branding) matchLanguage(acceptLanguage string) (string, bool) {
+	if len(b.LanguagePacks) == 0 || acceptLanguage == "" {
+		return "", false
+	}
+	desired, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(desired) == 0 {
+		return "", false
+	}
+
+	var tags []string
+	for tag := range b.LanguagePacks {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	// the first supported language is the fallback, but only an actual match should be used
+	supported := []language.Tag{language.Und}
+	for _, tag := range tags {
+		// language packs are validated, so this shouldn't fail
+		t, _ := language.Parse(tag)
+		supported = append(supported, t)
+	}
+	_, idx, confidence := language.NewMatcher(supported).Match(desired...)
+	if idx == 0 || confidence == language.No {
+		return "", false
+	}
+	return tags[idx-1], true
+}
+
+func mergeBrandingTexts(texts, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return texts
+	}
+	merged := make(map[string]string, len(texts)+len(overrides))
+	for k, v := range texts {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// GetBranding returns the branding for pages shown to users of the route matching the given
+// URL. nil is returned if no branding is configured.
+func (o *Options) GetBranding(u *url.URL) *Branding {
+	b := o.Branding
+	if u == nil {
+		return b
+	}
+	for _, p := range o.GetAllPolicies() {
+		if p.Branding != nil && p.Matches(*u) {
+			return b.Merge(p.Branding)
+		}
+	}
+	return b
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranding_Validate(t *testing.T) {
+	assert.NoError(t, (*Branding)(nil).Validate())
+	assert.NoError(t, (&Branding{PrimaryColor: "#6F43E7", SecondaryColor: "#fff"}).Validate())
+	assert.Error(t, (&Branding{PrimaryColor: "purple"}).Validate())
+	assert.Error(t, (&Branding{LanguagePacks: map[string]map[string]string{"not a language": {}}}).Validate())
+}
+
+func TestBranding_Localize(t *testing.T) {
+	b := &Branding{
+		Title: "Example",
+		Texts: map[string]string{"signOut.title": "Sign out?", "signOut.cancel": "Cancel"},
+		LanguagePacks: map[string]map[string]string{
+			"de": {"signOut.title": "Abmelden?"},
+			"fr": {"signOut.title": "Se déconnecter ?"},
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"title": "Example",
+		"texts": map[string]string{"signOut.title": "Sign out?", "signOut.cancel": "Cancel"},
+	}, b.Localize(""))
+	assert.Equal(t, map[string]interface{}{
+		"title":    "Example",
+		"language": "de",
+		"texts":    map[string]string{"signOut.title": "Abmelden?", "signOut.cancel": "Cancel"},
+	}, b.Localize("de-CH, fr;q=0.8"))
+	assert.Equal(t, "fr", b.Localize("es, fr;q=0.5")["language"])
+	assert.NotContains(t, b.Localize("ja"), "language", "should not use a language pack for other languages")
+}
+
+func TestOptions_GetBranding(t *testing.T) {
+	o := NewDefaultOptions()
+	o.Branding = &Branding{Title: "Example", PrimaryColor: "#111111"}
+	o.Policies = []Policy{
+		{From: "https://a.example.com", To: mustParseWeightedURLs(t, "https://to.example.com"), Branding: &Branding{PrimaryColor: "#222222"}},
+		{From: "https://b.example.com", To: mustParseWeightedURLs(t, "https://to.example.com")},
+	}
+	for i := range o.Policies {
+		require.NoError(t, o.Policies[i].Validate())
+	}
+
+	assert.Equal(t, &Branding{Title: "Example", PrimaryColor: "#222222"},
+		o.GetBranding(&url.URL{Scheme: "https", Host: "a.example.com", Path: "/"}))
+	assert.Equal(t, o.Branding, o.GetBranding(&url.URL{Scheme: "https", Host: "b.example.com", Path: "/"}))
+	assert.Equal(t, o.Branding, o.GetBranding(nil))
+}
@@ -0,0 +1,255 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+const certificateMonitorInterval = time.Hour
+
+// The usages of monitored certificates.
+const (
+	certificateUsageDownstream     = "downstream"
+	certificateUsageAutocert       = "autocert"
+	certificateUsageUpstreamClient = "upstream_client"
+	certificateUsageDataBroker     = "databroker"
+	certificateUsageMetrics        = "metrics"
+)
+
+type certificateExpiryLevel int
+
+const (
+	certificateExpiryOK certificateExpiryLevel = iota
+	certificateExpiryWarning
+	certificateExpiryCritical
+	certificateExpiryExpired
+)
+
+func (level certificateExpiryLevel) String() string {
+	switch level {
+	case certificateExpiryWarning:
+		return "warning"
+	case certificateExpiryCritical:
+		return "critical"
+	case certificateExpiryExpired:
+		return "expired"
+	}
+	return "ok"
+}
+
+type monitoredCertificate struct {
+	usage string
+	name  string
+	cert  *x509.Certificate
+}
+
+func (c monitoredCertificate) key() string {
+	return c.usage + "/" + c.name + "/" + c.cert.SerialNumber.Text(16)
+}
+
+// A CertificateMonitor exports the expiry of the configured and autocert certificates as
+// metrics. When a certificate gets within the certificate_expiry_warning_threshold or the
+// certificate_expiry_critical_threshold of its expiry, or expires, it logs and publishes an
+// audit event once.
+type CertificateMonitor struct {
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	cfg    *Config
+	levels map[string]certificateExpiryLevel
+}
+
+// NewCertificateMonitor creates a new CertificateMonitor.
+func NewCertificateMonitor(ctx context.Context, src Source) *CertificateMonitor {
+	ctx = log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
+		return c.Str("service", "certificate_monitor")
+	})
+	ctx, cancel := context.WithCancel(ctx)
+	mon := &CertificateMonitor{
+		cancel: cancel,
+		levels: map[string]certificateExpiryLevel{},
+	}
+	src.OnConfigChange(ctx, mon.OnConfigChange)
+	mon.OnConfigChange(ctx, src.GetConfig())
+	go mon.run(ctx)
+	return mon
+}
+
+// Close stops the certificate monitor.
+func (mon *CertificateMonitor) Close() error {
+	mon.cancel()
+	return nil
+}
+
+// OnConfigChange checks the certificates of the new config.
+func (mon *CertificateMonitor) OnConfigChange(ctx context.Context, cfg *Config) {
+	if cfg == nil || cfg.Options == nil {
+		return
+	}
+
+	mon.mu.Lock()
+	mon.cfg = cfg
+	mon.mu.Unlock()
+
+	mon.check(ctx, time.Now())
+}
+
+func (mon *CertificateMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(certificateMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			mon.check(ctx, time.Now())
+		}
+	}
+}
+
+func (mon *CertificateMonitor) check(ctx context.Context, now time.Time) {
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+
+	if mon.cfg == nil {
+		return
+	}
+
+	certs := getMonitoredCertificates(ctx, mon.cfg)
+	expiries := make([]metrics.CertificateExpiry, 0, len(certs))
+	levels := make(map[string]certificateExpiryLevel, len(certs))
+	for _, c := range certs {
+		key := c.key()
+		if _, ok := levels[key]; ok {
+			continue
+		}
+
+		expiries = append(expiries, metrics.CertificateExpiry{
+			Usage:    c.usage,
+			Name:     c.name,
+			Serial:   c.cert.SerialNumber.Text(16),
+			NotAfter: c.cert.NotAfter,
+		})
+
+		level := getCertificateExpiryLevel(mon.cfg.Options, c.cert, now)
+		if level > mon.levels[key] {
+			publishCertificateExpiry(ctx, c, level, now)
+		}
+		levels[key] = level
+	}
+	mon.levels = levels
+
+	metrics.RecordCertificateExpiries(expiries)
+}
+
+func getCertificateExpiryLevel(o *Options, cert *x509.Certificate, now time.Time) certificateExpiryLevel {
+	remaining := cert.NotAfter.Sub(now)
+	switch {
+	case remaining <= 0:
+		return certificateExpiryExpired
+	case remaining <= o.CertificateExpiryCriticalThreshold:
+		return certificateExpiryCritical
+	case remaining <= o.CertificateExpiryWarningThreshold:
+		return certificateExpiryWarning
+	}
+	return certificateExpiryOK
+}
+
+func publishCertificateExpiry(ctx context.Context, c monitoredCertificate, level certificateExpiryLevel, now time.Time) {
+	serial := c.cert.SerialNumber.Text(16)
+	msg := fmt.Sprintf("certificate expires in %s", c.cert.NotAfter.Sub(now).Round(time.Minute))
+	if level == certificateExpiryExpired {
+		msg = "certificate expired"
+	}
+
+	evt := log.Warn(ctx)
+	if level != certificateExpiryWarning {
+		evt = log.Error(ctx)
+	}
+	evt.Str("usage", c.usage).
+		Str("name", c.name).
+		Str("serial", serial).
+		Time("not-after", c.cert.NotAfter).
+		Msg(msg)
+
+	audit.Publish(ctx, &audit.Event{
+		Type:    audit.EventTypeCertificateExpiring,
+		Message: msg,
+		Details: map[string]string{
+			"usage":     c.usage,
+			"name":      c.name,
+			"serial":    serial,
+			"not_after": c.cert.NotAfter.UTC().Format(time.RFC3339),
+			"level":     level.String(),
+		},
+	})
+}
+
+// getMonitoredCertificates returns the leaf certificates of the downstream, autocert, upstream
+// client, databroker and metrics certificates in the config.
+func getMonitoredCertificates(ctx context.Context, cfg *Config) []monitoredCertificate {
+	var certs []monitoredCertificate
+	add := func(usage, name string, tlsCert *tls.Certificate) {
+		if tlsCert == nil || len(tlsCert.Certificate) == 0 {
+			return
+		}
+		cert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+		if err != nil {
+			log.Error(ctx).Err(err).Str("usage", usage).Msg("config: invalid certificate")
+			return
+		}
+		if name == "" {
+			name = getCertificateName(cert)
+		}
+		certs = append(certs, monitoredCertificate{usage: usage, name: name, cert: cert})
+	}
+
+	downstream, err := cfg.Options.GetCertificates()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("config: error loading downstream certificates")
+	}
+	for i := range downstream {
+		add(certificateUsageDownstream, "", &downstream[i])
+	}
+	for i := range cfg.AutoCertificates {
+		add(certificateUsageAutocert, "", &cfg.AutoCertificates[i])
+	}
+	for _, p := range cfg.Options.GetAllPolicies() {
+		add(certificateUsageUpstreamClient, p.From, p.ClientCertificate)
+	}
+
+	dataBroker, err := cfg.Options.GetDataBrokerCertificate()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("config: error loading databroker certificate")
+	}
+	add(certificateUsageDataBroker, "", dataBroker)
+
+	metricsCert, err := cfg.Options.GetMetricsCertificate()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("config: error loading metrics certificate")
+	}
+	add(certificateUsageMetrics, "", metricsCert)
+
+	return certs
+}
+
+func getCertificateName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return cert.Subject.String()
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateMonitor(t *testing.T) {
+	now := time.Now()
+	newCert := func(serial int64, notAfter time.Time) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "example.com"},
+			NotBefore:    now.Add(-time.Hour),
+			NotAfter:     notAfter,
+		}, &x509.Certificate{SerialNumber: big.NewInt(1)}, &key.PublicKey, key)
+		require.NoError(t, err)
+		return tls.Certificate{Certificate: [][]byte{der}}
+	}
+
+	mon := &CertificateMonitor{levels: map[string]certificateExpiryLevel{}}
+	mon.cfg = &Config{
+		Options: NewDefaultOptions(),
+		AutoCertificates: []tls.Certificate{
+			newCert(1, now.Add(60*24*time.Hour)),
+			newCert(2, now.Add(10*24*time.Hour)),
+			newCert(3, now.Add(time.Hour)),
+			newCert(4, now.Add(-time.Hour)),
+		},
+	}
+	mon.check(context.Background(), now)
+	assert.Equal(t, map[string]certificateExpiryLevel{
+		"autocert/example.com/1": certificateExpiryOK,
+		"autocert/example.com/2": certificateExpiryWarning,
+		"autocert/example.com/3": certificateExpiryCritical,
+		"autocert/example.com/4": certificateExpiryExpired,
+	}, mon.levels)
+
+	mon.check(context.Background(), now.Add(20*24*time.Hour))
+	assert.Equal(t, map[string]certificateExpiryLevel{
+		"autocert/example.com/1": certificateExpiryOK,
+		"autocert/example.com/2": certificateExpiryExpired,
+		"autocert/example.com/3": certificateExpiryExpired,
+		"autocert/example.com/4": certificateExpiryExpired,
+	}, mon.levels)
+
+	mon.cfg = &Config{Options: NewDefaultOptions()}
+	mon.check(context.Background(), now)
+	assert.Empty(t, mon.levels, "should forget removed certificates")
+}
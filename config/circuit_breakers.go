@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// CircuitBreakerThresholds are the limits of the connections and requests to the upstreams of
+// a route. When a limit is reached requests fail fast instead of queueing, so one slow upstream
+// can't exhaust the resources of the proxy. Unset limits use the envoy defaults.
+type CircuitBreakerThresholds struct {
+	// MaxConnections is the maximum number of connections to the upstreams.
+	MaxConnections *uint32 `mapstructure:"max_connections" yaml:"max_connections,omitempty" json:"max_connections,omitempty"`
+	// MaxPendingRequests is the maximum number of requests waiting for a connection.
+	MaxPendingRequests *uint32 `mapstructure:"max_pending_requests" yaml:"max_pending_requests,omitempty" json:"max_pending_requests,omitempty"`
+	// MaxRequests is the maximum number of concurrent requests.
+	MaxRequests *uint32 `mapstructure:"max_requests" yaml:"max_requests,omitempty" json:"max_requests,omitempty"`
+	// MaxRetries is the maximum number of concurrent retries.
+	MaxRetries *uint32 `mapstructure:"max_retries" yaml:"max_retries,omitempty" json:"max_retries,omitempty"`
+	// MaxConnectionPools is the maximum number of connection pools.
+	MaxConnectionPools *uint32 `mapstructure:"max_connection_pools" yaml:"max_connection_pools,omitempty" json:"max_connection_pools,omitempty"`
+}
+
+// NewCircuitBreakerThresholdsFromProto creates a new CircuitBreakerThresholds from a protobuf
+// message.
+func NewCircuitBreakerThresholdsFromProto(pb *configpb.CircuitBreakerThresholds) *CircuitBreakerThresholds {
+	if pb == nil {
+		return nil
+	}
+	return &CircuitBreakerThresholds{
+		MaxConnections:     pb.MaxConnections,
+		MaxPendingRequests: pb.MaxPendingRequests,
+		MaxRequests:        pb.MaxRequests,
+		MaxRetries:         pb.MaxRetries,
+		MaxConnectionPools: pb.MaxConnectionPools,
+	}
+}
+
+// ToProto converts the thresholds to a protobuf message.
+func (t *CircuitBreakerThresholds) ToProto() *configpb.CircuitBreakerThresholds {
+	if t == nil {
+		return nil
+	}
+	return &configpb.CircuitBreakerThresholds{
+		MaxConnections:     t.MaxConnections,
+		MaxPendingRequests: t.MaxPendingRequests,
+		MaxRequests:        t.MaxRequests,
+		MaxRetries:         t.MaxRetries,
+		MaxConnectionPools: t.MaxConnectionPools,
+	}
+}
+
+// Validate checks the validity of the thresholds.
+func (t *CircuitBreakerThresholds) Validate() error {
+	if t == nil {
+		return nil
+	}
+	for _, limit := range []struct {
+		name  string
+		value *uint32
+	}{
+		{"max_connections", t.MaxConnections},
+		{"max_pending_requests", t.MaxPendingRequests},
+		{"max_requests", t.MaxRequests},
+		{"max_connection_pools", t.MaxConnectionPools},
+	} {
+		if limit.value != nil && *limit.value == 0 {
+			return fmt.Errorf("%s must be greater than 0", limit.name)
+		}
+	}
+	return nil
+}
+
+// Merge returns new thresholds with the limits set in override replacing the limits of the
+// thresholds.
+func (t *CircuitBreakerThresholds) Merge(override *CircuitBreakerThresholds) *CircuitBreakerThresholds {
+	if t == nil {
+		return override
+	}
+	if override == nil {
+		return t
+	}
+
+	merged := *t
+	if override.MaxConnections != nil {
+		merged.MaxConnections = override.MaxConnections
+	}
+	if override.MaxPendingRequests != nil {
+		merged.MaxPendingRequests = override.MaxPendingRequests
+	}
+	if override.MaxRequests != nil {
+		merged.MaxRequests = override.MaxRequests
+	}
+	if override.MaxRetries != nil {
+		merged.MaxRetries = override.MaxRetries
+	}
+	if override.MaxConnectionPools != nil {
+		merged.MaxConnectionPools = override.MaxConnectionPools
+	}
+	return &merged
+}
+
+// GetCircuitBreakerThresholds returns the circuit breaker thresholds of the policy, which
+// override the global thresholds.
+func (o *Options) GetCircuitBreakerThresholds(p *Policy) *CircuitBreakerThresholds {
+	return o.CircuitBreakerThresholds.Merge(p.CircuitBreakerThresholds)
+}
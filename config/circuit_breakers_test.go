@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCircuitBreakerThresholds(t *testing.T) {
+	t.Parallel()
+
+	o := NewDefaultOptions()
+	p := &Policy{}
+	assert.Nil(t, o.GetCircuitBreakerThresholds(p))
+
+	o.CircuitBreakerThresholds = &CircuitBreakerThresholds{
+		MaxConnections: proto.Uint32(100),
+		MaxRequests:    proto.Uint32(200),
+	}
+	assert.Equal(t, o.CircuitBreakerThresholds, o.GetCircuitBreakerThresholds(p))
+
+	p.CircuitBreakerThresholds = &CircuitBreakerThresholds{
+		MaxRequests: proto.Uint32(50),
+		MaxRetries:  proto.Uint32(1),
+	}
+	assert.Equal(t, &CircuitBreakerThresholds{
+		MaxConnections: proto.Uint32(100),
+		MaxRequests:    proto.Uint32(50),
+		MaxRetries:     proto.Uint32(1),
+	}, o.GetCircuitBreakerThresholds(p))
+
+	assert.Equal(t, p.CircuitBreakerThresholds, NewCircuitBreakerThresholdsFromProto(p.CircuitBreakerThresholds.ToProto()))
+
+	assert.NoError(t, (&CircuitBreakerThresholds{MaxRetries: proto.Uint32(0)}).Validate())
+	assert.EqualError(t, (&CircuitBreakerThresholds{MaxConnections: proto.Uint32(0)}).Validate(),
+		"max_connections must be greater than 0")
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// configDirListKeys are the keys whose lists are concatenated when the files of a config
+// directory are merged, instead of being replaced by the last file.
+var configDirListKeys = []string{"policy", "routes"}
+
+// isConfigDir returns true if the config file is a directory of config files.
+func isConfigDir(configFile string) bool {
+	fi, err := os.Stat(configFile)
+	return err == nil && fi.IsDir()
+}
+
+// getConfigDirFiles returns the YAML, JSON and TOML files of the config directory, sorted by
+// name. Hidden files are skipped, like the symlinks of mounted Kubernetes ConfigMaps.
+func getConfigDirFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml", ".json", ".toml":
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no config files found in %s", dir)
+	}
+	return files, nil
+}
+
+// readConfigDir reads the files of the config directory into the viper instance. The files are
+// merged in the order of their names, so settings in later files take precedence, except for
+// routes and policies, which are concatenated.
+func readConfigDir(v *viper.Viper, dir string) error {
+	files, err := getConfigDirFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	lists := map[string][]interface{}{}
+	for _, f := range files {
+		fv := viper.New()
+		fv.SetConfigFile(f)
+		if err := fv.ReadInConfig(); err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+
+		for _, key := range configDirListKeys {
+			raw := fv.Get(key)
+			if raw == nil {
+				continue
+			}
+			items, ok := raw.([]interface{})
+			if !ok {
+				return fmt.Errorf("%s: %s must be a list", f, key)
+			}
+			lists[key] = append(lists[key], items...)
+		}
+
+		if err := v.MergeConfigMap(fv.AllSettings()); err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+	}
+
+	for key, items := range lists {
+		v.Set(key, items)
+	}
+	return nil
+}
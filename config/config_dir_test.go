@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsFromConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"00-global.yaml": "insecure_server: true\nautocert_dir: ''\nlog_level: info\nroutes:\n- from: https://a.example.com\n  to: https://a.internal\n",
+		"10-team-b.yml":  "routes:\n- from: https://b.example.com\n  to: https://b.internal\n",
+		"20-team-c.json": `{"log_level":"debug","routes":[{"from":"https://c.example.com","to":"https://c.internal"}]}`,
+		".hidden.yaml":   "routes: []\nlog_level: error\n",
+		"README.md":      "not config",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600))
+	}
+
+	o, err := optionsFromViper(dir)
+	require.NoError(t, err)
+
+	var froms []string
+	for _, p := range o.Routes {
+		froms = append(froms, p.From)
+	}
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}, froms)
+	assert.Equal(t, "debug", o.LogLevel)
+	assert.True(t, o.InsecureServer)
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := optionsFromViper(t.TempDir())
+		assert.Error(t, err)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("routes: {"), 0o600))
+		_, err := optionsFromViper(dir)
+		assert.Error(t, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/google/uuid"
@@ -87,7 +88,8 @@ func (src *StaticSource) OnConfigChange(ctx context.Context, li ChangeListener)
 	src.lis = append(src.lis, li)
 }
 
-// A FileOrEnvironmentSource retrieves config options from a file or the environment.
+// A FileOrEnvironmentSource retrieves config options from a file, a directory of files or the
+// environment.
 type FileOrEnvironmentSource struct {
 	configFile string
 
@@ -136,12 +138,62 @@ func NewFileOrEnvironmentSource(
 		configFile: configFile,
 		config:     cfg,
 	}
-	options.viper.OnConfigChange(src.onConfigChange(ctx))
-	go options.viper.WatchConfig()
+	if isConfigDir(configFile) {
+		// viper only watches a single file, so the directory is watched instead
+		watcher := fileutil.NewWatcher()
+		watcher.Add(configFile)
+		ch := watcher.Bind()
+		onConfigChange := src.onConfigChange(ctx)
+		go func() {
+			for range ch {
+				onConfigChange(fsnotify.Event{Name: configFile})
+			}
+		}()
+	} else {
+		options.viper.OnConfigChange(src.onConfigChange(ctx))
+		go options.viper.WatchConfig()
+	}
+	go src.refreshSecrets(ctx)
 
 	return src, nil
 }
 
+// refreshSecrets periodically re-reads the config when it has secret references, and applies it
+// if any of the secrets were rotated.
+func (src *FileOrEnvironmentSource) refreshSecrets(ctx context.Context) {
+	for {
+		src.mu.RLock()
+		options := src.config.Options
+		src.mu.RUnlock()
+
+		time.Sleep(options.GetSecretsRefreshInterval())
+		if !options.hasSecretReferences {
+			continue
+		}
+
+		newOptions, err := newOptionsFromConfig(src.configFile)
+		if err != nil {
+			// the previous secrets are kept until they can be read again
+			log.Error(ctx).Err(err).Msg("config: error refreshing secrets")
+			continue
+		}
+
+		src.mu.Lock()
+		cfg := src.config.Clone()
+		cfg.Options = newOptions
+		if cfg.Checksum() == src.config.Checksum() {
+			src.mu.Unlock()
+			continue
+		}
+		src.config = cfg
+		src.mu.Unlock()
+
+		log.Info(ctx).Msg("config: secrets updated, reconfiguring...")
+		metrics.SetConfigInfo(ctx, cfg.Options.Services, "local", cfg.Checksum(), true)
+		src.Trigger(ctx, cfg)
+	}
+}
+
 func (src *FileOrEnvironmentSource) onConfigChange(ctx context.Context) func(fsnotify.Event) {
 	return func(evt fsnotify.Event) {
 		ctx := log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
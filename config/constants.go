@@ -9,8 +9,10 @@ import (
 )
 
 const (
-	toKey        = "to"
-	envoyOptsKey = "_envoy_opts"
+	toKey             = "to"
+	mirrorToKey       = "mirror_to"
+	upstreamGroupsKey = "upstream_groups"
+	envoyOptsKey      = "_envoy_opts"
 )
 
 var (
@@ -20,7 +22,7 @@ var (
 	errHostnameMustBeSpecified    = errors.New("endpoint hostname must be specified")
 	errSchemeMustBeSpecified      = errors.New("url scheme must be provided")
 	errEmptyUrls                  = errors.New("url list is empty")
-	errEitherToOrRedirectRequired = errors.New("policy should have either `to` or `redirect` defined")
+	errEitherToOrRedirectRequired = errors.New("policy should have either `to`, `redirect` or `response` defined")
 )
 
 var protoPartial = protojson.UnmarshalOptions{AllowPartial: true, DiscardUnknown: true}
@@ -313,6 +313,20 @@ func (urls WeightedURLs) Validate() (HasWeight, error) {
 	return true, nil
 }
 
+// rawStrings returns the urls in the form they're parsed from, with the load balancing
+// weight appended to the url.
+func (urls WeightedURLs) rawStrings() []string {
+	var strs []string
+	for _, u := range urls {
+		if u.LbWeight > 0 {
+			strs = append(strs, fmt.Sprintf("%s,%d", u.URL.String(), u.LbWeight))
+		} else {
+			strs = append(strs, u.URL.String())
+		}
+	}
+	return strs
+}
+
 // Flatten converts weighted url array into indidual arrays of urls and weights
 func (urls WeightedURLs) Flatten() ([]string, []uint32, error) {
 	hasWeight, err := urls.Validate()
@@ -436,11 +450,16 @@ func DecodePolicyHookFunc() mapstructure.DecodeHookFunc {
 func parsePolicy(src map[string]interface{}) (out map[string]interface{}, err error) {
 	out = make(map[string]interface{}, len(src))
 	for k, v := range src {
-		if k == toKey {
+		if k == toKey || k == mirrorToKey {
 			if v, err = parseTo(v); err != nil {
 				return nil, err
 			}
 		}
+		if k == upstreamGroupsKey {
+			if v, err = parseUpstreamGroups(v); err != nil {
+				return nil, err
+			}
+		}
 		out[k] = v
 	}
 
@@ -467,6 +486,34 @@ func parseTo(raw interface{}) ([]WeightedURL, error) {
 	return ParseWeightedUrls(slc...)
 }
 
+func parseUpstreamGroups(raw interface{}) (interface{}, error) {
+	groups, ok := raw.([]interface{})
+	if !ok {
+		return raw, nil
+	}
+
+	out := make([]interface{}, 0, len(groups))
+	for _, rawGroup := range groups {
+		group, ok := rawGroup.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid upstream group: %v", rawGroup)
+		}
+		parsed := make(map[string]interface{}, len(group))
+		for k, v := range group {
+			if k == toKey {
+				to, err := parseTo(v)
+				if err != nil {
+					return nil, err
+				}
+				v = to
+			}
+			parsed[k] = v
+		}
+		out = append(out, parsed)
+	}
+	return out, nil
+}
+
 // parses URL followed by weighted
 func weightedString(str string) (string, uint32, error) {
 	i := strings.IndexRune(str, ',')
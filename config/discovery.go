@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The URL schemes of upstreams whose endpoints are discovered instead of configured.
+const (
+	DiscoverySchemePrefixSRV        = "srv+"
+	DiscoverySchemePrefixConsul     = "consul+"
+	DiscoverySchemePrefixKubernetes = "k8s+"
+	DiscoverySchemePrefixNomad      = "nomad+"
+)
+
+// DefaultConsulAddress is the address of the local Consul agent.
+const DefaultConsulAddress = "http://127.0.0.1:8500"
+
+// DefaultNomadAddress is the address of the local Nomad agent.
+const DefaultNomadAddress = "http://127.0.0.1:4646"
+
+// DefaultDiscoveryRefreshInterval is how often discovered upstream endpoints are re-resolved.
+const DefaultDiscoveryRefreshInterval = 30 * time.Second
+
+// IsDiscoveryURL returns true if the endpoints of the upstream URL are discovered with DNS SRV
+// records, like `srv+https://_api._tcp.example.com`, a Consul service, like
+// `consul+http://api`, the EndpointSlices of a Kubernetes service, like
+// `k8s+http://api.namespace?port=http`, or a Nomad service, like `nomad+http://api`.
+func IsDiscoveryURL(u *url.URL) bool {
+	return strings.HasPrefix(u.Scheme, DiscoverySchemePrefixSRV) ||
+		strings.HasPrefix(u.Scheme, DiscoverySchemePrefixConsul) ||
+		strings.HasPrefix(u.Scheme, DiscoverySchemePrefixKubernetes) ||
+		strings.HasPrefix(u.Scheme, DiscoverySchemePrefixNomad)
+}
+
+// GetDiscoveryUpstreamURL returns the URL the discovered endpoints of the upstream URL are
+// connected to with. Its host is the name used to verify the certificates of the endpoints,
+// either the SRV name without the service and protocol labels, the Consul or Nomad service name
+// or the cluster DNS name of the Kubernetes service.
+func GetDiscoveryUpstreamURL(u *url.URL) *url.URL {
+	host := u.Hostname()
+	scheme := u.Scheme
+	switch {
+	case strings.HasPrefix(u.Scheme, DiscoverySchemePrefixSRV):
+		scheme = strings.TrimPrefix(scheme, DiscoverySchemePrefixSRV)
+		for strings.HasPrefix(host, "_") && strings.Contains(host, ".") {
+			host = host[strings.Index(host, ".")+1:]
+		}
+	case strings.HasPrefix(u.Scheme, DiscoverySchemePrefixConsul):
+		scheme = strings.TrimPrefix(scheme, DiscoverySchemePrefixConsul)
+	case strings.HasPrefix(u.Scheme, DiscoverySchemePrefixKubernetes):
+		scheme = strings.TrimPrefix(scheme, DiscoverySchemePrefixKubernetes)
+		if strings.Contains(host, ".") {
+			host += ".svc"
+		}
+	case strings.HasPrefix(u.Scheme, DiscoverySchemePrefixNomad):
+		scheme = strings.TrimPrefix(scheme, DiscoverySchemePrefixNomad)
+	}
+	return &url.URL{Scheme: scheme, Host: host}
+}
+
+// IsConsulConnectURL returns true if the upstream URL is a Consul Connect service, which it is
+// for consul+ upstreams when a Consul Connect service name is set.
+func (o *Options) IsConsulConnectURL(u *url.URL) bool {
+	return o.ConsulConnectServiceName != "" && strings.HasPrefix(u.Scheme, DiscoverySchemePrefixConsul)
+}
+
+// GetConsulAddress returns the address of the Consul agent.
+func (o *Options) GetConsulAddress() string {
+	if o.ConsulAddress == "" {
+		return DefaultConsulAddress
+	}
+	return o.ConsulAddress
+}
+
+// GetNomadAddress returns the address of the Nomad agent.
+func (o *Options) GetNomadAddress() string {
+	if o.NomadAddress == "" {
+		return DefaultNomadAddress
+	}
+	return o.NomadAddress
+}
+
+// GetDiscoveryRefreshInterval returns how often the endpoints of discovered upstreams are
+// re-resolved.
+func (o *Options) GetDiscoveryRefreshInterval() time.Duration {
+	if o.DiscoveryRefreshInterval <= 0 {
+		return DefaultDiscoveryRefreshInterval
+	}
+	return o.DiscoveryRefreshInterval
+}
+
+func validateDiscoveryURLs(urls WeightedURLs) error {
+	discovered := 0
+	for _, u := range urls {
+		if !IsDiscoveryURL(&u.URL) {
+			continue
+		}
+		discovered++
+
+		switch GetDiscoveryUpstreamURL(&u.URL).Scheme {
+		case "http", "https":
+		default:
+			return fmt.Errorf("%s: unsupported scheme %s", u.URL.String(), u.URL.Scheme)
+		}
+		if u.URL.Port() != "" {
+			return fmt.Errorf("%s: the port of discovered upstreams cannot be set", u.URL.String())
+		}
+		if u.URL.Path != "" && u.URL.Path != "/" {
+			return fmt.Errorf("%s: the path of discovered upstreams cannot be set", u.URL.String())
+		}
+	}
+	if discovered > 0 && discovered != len(urls) {
+		return fmt.Errorf("discovered upstreams cannot be combined with other upstreams")
+	}
+	return nil
+}
+
+// validateConsulConnectURLs verifies the consul+ upstreams use https, since the Connect proxies
+// of the services require mTLS.
+func validateConsulConnectURLs(urls WeightedURLs) error {
+	for _, u := range urls {
+		if strings.HasPrefix(u.URL.Scheme, DiscoverySchemePrefixConsul) && GetDiscoveryUpstreamURL(&u.URL).Scheme != "https" {
+			return fmt.Errorf("%s: consul connect upstreams must use %shttps", u.URL.String(), DiscoverySchemePrefixConsul)
+		}
+	}
+	return nil
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/telemetry"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/pkg/protoutil"
 )
 
 // BuildBootstrapAdmin builds the admin config for the envoy bootstrap.
@@ -122,3 +124,43 @@ func (b *Builder) BuildBootstrapStatsConfig(cfg *config.Config) (*envoy_config_m
 	}}
 	return statsCfg, nil
 }
+
+// BuildBootstrapStatsSinks builds the stats sinks of the envoy bootstrap, which push envoy's
+// metrics to the statsd server of the pushed metrics. The OpenTelemetry stats sink isn't
+// available in this version of envoy, so envoy's metrics aren't pushed with OTLP.
+func (b *Builder) BuildBootstrapStatsSinks(cfg *config.Config) ([]*envoy_config_metrics_v3.StatsSink, error) {
+	if cfg.Options.MetricsStatsdAddress == "" {
+		return nil, nil
+	}
+
+	addr, err := parseAddress(cfg.Options.MetricsStatsdAddress)
+	if err != nil {
+		return nil, fmt.Errorf("envoyconfig: invalid metrics statsd address: %w", err)
+	}
+	prefix := cfg.Options.MetricsStatsdPrefix
+	if prefix == "" {
+		prefix = "pomerium"
+	}
+	prefix += ".envoy"
+
+	if cfg.Options.MetricsStatsdFlavor == metrics.StatsdFlavorDogStatsd {
+		return []*envoy_config_metrics_v3.StatsSink{{
+			Name: "envoy.stat_sinks.dog_statsd",
+			ConfigType: &envoy_config_metrics_v3.StatsSink_TypedConfig{
+				TypedConfig: protoutil.NewAny(&envoy_config_metrics_v3.DogStatsdSink{
+					DogStatsdSpecifier: &envoy_config_metrics_v3.DogStatsdSink_Address{Address: addr},
+					Prefix:             prefix,
+				}),
+			},
+		}}, nil
+	}
+	return []*envoy_config_metrics_v3.StatsSink{{
+		Name: "envoy.stat_sinks.statsd",
+		ConfigType: &envoy_config_metrics_v3.StatsSink_TypedConfig{
+			TypedConfig: protoutil.NewAny(&envoy_config_metrics_v3.StatsdSink{
+				StatsdSpecifier: &envoy_config_metrics_v3.StatsdSink_Address{Address: addr},
+				Prefix:          prefix,
+			}),
+		},
+	}}, nil
+}
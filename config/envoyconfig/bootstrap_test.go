@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
@@ -127,3 +128,35 @@ func TestBuilder_BuildBootstrapStatsConfig(t *testing.T) {
 		`, statsCfg)
 	})
 }
+
+func TestBuilder_BuildBootstrapStatsSinks(t *testing.T) {
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	t.Run("disabled", func(t *testing.T) {
+		sinks, err := b.BuildBootstrapStatsSinks(&config.Config{Options: &config.Options{}})
+		assert.NoError(t, err)
+		assert.Nil(t, sinks)
+	})
+	t.Run("dogstatsd", func(t *testing.T) {
+		sinks, err := b.BuildBootstrapStatsSinks(&config.Config{Options: &config.Options{
+			MetricsStatsdAddress: "127.0.0.1:8125",
+			MetricsStatsdFlavor:  "dogstatsd",
+		}})
+		assert.NoError(t, err)
+		require.Len(t, sinks, 1)
+		testutil.AssertProtoJSONEqual(t, `
+			{
+				"name": "envoy.stat_sinks.dog_statsd",
+				"typedConfig": {
+					"@type": "type.googleapis.com/envoy.config.metrics.v3.DogStatsdSink",
+					"address": {
+						"socketAddress": {
+							"address": "127.0.0.1",
+							"portValue": 8125
+						}
+					},
+					"prefix": "pomerium.envoy"
+				}
+			}
+		`, sinks[0])
+	})
+}
@@ -0,0 +1,58 @@
+package envoyconfig
+
+import (
+	"math"
+
+	envoy_extensions_filters_http_buffer_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	envoy_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+const bufferFilterName = "envoy.filters.http.buffer"
+
+func hasRequestBodyLimitPolicy(options *config.Options) bool {
+	for _, p := range options.GetAllPolicies() {
+		if p.MaxRequestBodyBytes != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildBufferFilter builds the buffer filter, which rejects requests with bodies larger than
+// the limit with a 413. The filter is disabled on every virtual host and only enabled for
+// the routes with a request body limit, so the limit set here isn't used.
+func buildBufferFilter() *envoy_http_connection_manager.HttpFilter {
+	return &envoy_http_connection_manager.HttpFilter{
+		Name: bufferFilterName,
+		ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
+			TypedConfig: marshalAny(&envoy_extensions_filters_http_buffer_v3.Buffer{
+				MaxRequestBytes: wrapperspb.UInt32(math.MaxUint32),
+			}),
+		},
+	}
+}
+
+func buildBufferPerRouteDisabled() *any.Any {
+	return marshalAny(&envoy_extensions_filters_http_buffer_v3.BufferPerRoute{
+		Override: &envoy_extensions_filters_http_buffer_v3.BufferPerRoute_Disabled{
+			Disabled: true,
+		},
+	})
+}
+
+func buildPolicyBufferPerRoute(policy *config.Policy) *any.Any {
+	if policy.MaxRequestBodyBytes == nil {
+		return nil
+	}
+	return marshalAny(&envoy_extensions_filters_http_buffer_v3.BufferPerRoute{
+		Override: &envoy_extensions_filters_http_buffer_v3.BufferPerRoute_Buffer{
+			Buffer: &envoy_extensions_filters_http_buffer_v3.Buffer{
+				MaxRequestBytes: wrapperspb.UInt32(*policy.MaxRequestBodyBytes),
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package envoyconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func Test_buildPolicyBufferPerRoute(t *testing.T) {
+	assert.Nil(t, buildPolicyBufferPerRoute(&config.Policy{}))
+	testutil.AssertProtoJSONEqual(t, `{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.buffer.v3.BufferPerRoute",
+		"buffer": { "maxRequestBytes": 1024 }
+	}`, buildPolicyBufferPerRoute(&config.Policy{MaxRequestBodyBytes: proto.Uint32(1024)}))
+}
+
+func Test_buildVirtualHost_BufferDisabled(t *testing.T) {
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+
+	vh, err := b.buildVirtualHost(&config.Options{}, "example.com", "example.com")
+	require.NoError(t, err)
+	assert.Empty(t, vh.TypedPerFilterConfig)
+
+	vh, err = b.buildVirtualHost(&config.Options{
+		Policies: []config.Policy{{
+			From:                "https://from.example.com",
+			To:                  mustParseWeightedURLs(t, "https://to.example.com"),
+			MaxRequestBodyBytes: proto.Uint32(1024),
+		}},
+	}, "example.com", "example.com")
+	require.NoError(t, err)
+	testutil.AssertProtoJSONEqual(t, `{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.buffer.v3.BufferPerRoute",
+		"disabled": true
+	}`, vh.TypedPerFilterConfig[bufferFilterName])
+}
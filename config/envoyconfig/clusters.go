@@ -19,8 +19,10 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/fips"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
 )
 
 // BuildClusters builds envoy clusters from the given config.
@@ -75,8 +77,11 @@ func (b *Builder) BuildClusters(ctx context.Context, cfg *config.Config) ([]*env
 		return nil, err
 	}
 	if len(databrokerURLs) > 1 {
-		authorizeCluster.HealthChecks = grpcHealthChecks("pomerium-databroker")
-		authorizeCluster.OutlierDetection = grpcAuthorizeOutlierDetection()
+		databrokerCluster.HealthChecks = grpcHealthChecks("pomerium-databroker")
+		databrokerCluster.OutlierDetection = grpcAuthorizeOutlierDetection()
+		if cfg.Options.DataBrokerLoadBalancingPolicy == grpcutil.PickFirst {
+			setFailoverPriorities(databrokerCluster)
+		}
 	}
 
 	clusters := []*envoy_config_cluster_v3.Cluster{
@@ -94,19 +99,41 @@ func (b *Builder) BuildClusters(ctx context.Context, cfg *config.Config) ([]*env
 		clusters = append(clusters, tracingCluster)
 	}
 
+	spiffeCluster, err := buildSPIFFEWorkloadAPICluster(cfg.Options)
+	if err != nil {
+		return nil, err
+	} else if spiffeCluster != nil {
+		clusters = append(clusters, spiffeCluster)
+	}
+
 	if config.IsProxy(cfg.Options.Services) {
 		for i, p := range cfg.Options.GetAllPolicies() {
 			policy := p
 			if policy.EnvoyOpts == nil {
 				policy.EnvoyOpts = newDefaultEnvoyClusterConfig()
 			}
-			if len(policy.To) > 0 {
+			// wildcard destinations are re-proxied by the control plane, so they don't have a cluster
+			if len(policy.To) > 0 && !policy.HasWildcardDestination() {
 				cluster, err := b.buildPolicyCluster(ctx, cfg.Options, &policy)
 				if err != nil {
 					return nil, fmt.Errorf("policy #%d: %w", i, err)
 				}
 				clusters = append(clusters, cluster)
 			}
+			if len(policy.MirrorTo) > 0 {
+				cluster, err := b.buildPolicyUpstreamCluster(ctx, cfg.Options, &policy, policy.MirrorTo, "mirror")
+				if err != nil {
+					return nil, fmt.Errorf("policy #%d mirror: %w", i, err)
+				}
+				clusters = append(clusters, cluster)
+			}
+			for _, g := range policy.UpstreamGroups {
+				cluster, err := b.buildPolicyUpstreamCluster(ctx, cfg.Options, &policy, g.To, g.Name)
+				if err != nil {
+					return nil, fmt.Errorf("policy #%d upstream group %s: %w", i, g.Name, err)
+				}
+				clusters = append(clusters, cluster)
+			}
 		}
 	}
 
@@ -167,6 +194,14 @@ func (b *Builder) buildPolicyCluster(ctx context.Context, options *config.Option
 		}
 	}
 
+	if cluster.CircuitBreakers == nil {
+		cluster.CircuitBreakers = buildCircuitBreakers(options.GetCircuitBreakerThresholds(policy))
+	}
+	cluster.LbPolicy = policy.GetLbPolicy()
+	if cluster.PerConnectionBufferLimitBytes == nil && policy.ResponseBufferLimitBytes != nil {
+		cluster.PerConnectionBufferLimitBytes = wrapperspb.UInt32(*policy.ResponseBufferLimitBytes)
+	}
+
 	cluster.AltStatName = getClusterStatsName(policy)
 	upstreamProtocol := getUpstreamProtocolForPolicy(ctx, policy)
 
@@ -184,10 +219,42 @@ func (b *Builder) buildPolicyCluster(ctx context.Context, options *config.Option
 		cluster.DnsLookupFamily = envoy_config_cluster_v3.Cluster_V4_ONLY
 	}
 
+	// passively health check routes with multiple upstreams, so dead upstreams are ejected
+	// without having to configure outlier detection
+	if cluster.OutlierDetection == nil && (len(endpoints) > 1 || hasDiscoveredUpstreams(policy)) {
+		cluster.OutlierDetection = new(envoy_config_cluster_v3.OutlierDetection)
+	}
+
 	if err := b.buildCluster(cluster, name, endpoints, upstreamProtocol); err != nil {
 		return nil, err
 	}
 
+	if hasDiscoveredUpstreams(policy) {
+		setClusterEDS(cluster)
+		if err := cluster.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// buildPolicyUpstreamCluster builds the cluster for other upstreams of the policy, like the
+// mirror upstreams. It uses the same settings as the policy cluster.
+func (b *Builder) buildPolicyUpstreamCluster(
+	ctx context.Context,
+	options *config.Options,
+	policy *config.Policy,
+	to config.WeightedURLs,
+	statsSuffix string,
+) (*envoy_config_cluster_v3.Cluster, error) {
+	cluster, err := b.buildPolicyCluster(ctx, options, getUpstreamPolicy(policy, to))
+	if err != nil {
+		return nil, err
+	}
+	if cluster.AltStatName != "" {
+		cluster.AltStatName += "-" + statsSuffix
+	}
 	return cluster, nil
 }
 
@@ -198,11 +265,16 @@ func (b *Builder) buildPolicyEndpoints(
 ) ([]Endpoint, error) {
 	var endpoints []Endpoint
 	for _, dst := range policy.To {
-		ts, err := b.buildPolicyTransportSocket(ctx, options, policy, dst.URL)
+		u := dst.URL
+		if config.IsDiscoveryURL(&u) {
+			// the endpoints are sent with EDS, but the transport socket matches are part of the cluster
+			u = *config.GetDiscoveryUpstreamURL(&u)
+		}
+		ts, err := b.buildPolicyTransportSocket(ctx, options, policy, u)
 		if err != nil {
 			return nil, err
 		}
-		endpoints = append(endpoints, NewEndpoint(&dst.URL, ts, dst.LbWeight))
+		endpoints = append(endpoints, NewEndpoint(&u, ts, dst.LbWeight))
 	}
 	return endpoints, nil
 }
@@ -271,7 +343,7 @@ func (b *Builder) buildPolicyTransportSocket(
 	}
 	tlsContext := &envoy_extensions_transport_sockets_tls_v3.UpstreamTlsContext{
 		CommonTlsContext: &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext{
-			TlsParams: &envoy_extensions_transport_sockets_tls_v3.TlsParameters{
+			TlsParams: restrictTLSParams(&envoy_extensions_transport_sockets_tls_v3.TlsParameters{
 				CipherSuites: []string{
 					"ECDHE-ECDSA-AES256-GCM-SHA384",
 					"ECDHE-RSA-AES256-GCM-SHA384",
@@ -294,7 +366,7 @@ func (b *Builder) buildPolicyTransportSocket(
 					"P-384",
 					"P-521",
 				},
-			},
+			}, fips.Enabled()),
 			AlpnProtocols: buildUpstreamALPN(upstreamProtocol),
 			ValidationContextType: &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_ValidationContext{
 				ValidationContext: vc,
@@ -306,6 +378,20 @@ func (b *Builder) buildPolicyTransportSocket(
 		tlsContext.CommonTlsContext.TlsCertificates = append(tlsContext.CommonTlsContext.TlsCertificates,
 			b.envoyTLSCertificateFromGoTLSCertificate(ctx, policy.ClientCertificate))
 	}
+	if policy.TLSUpstreamSPIFFEID != "" {
+		tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs = append(tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs,
+			buildSPIFFESdsSecretConfig(policy.TLSUpstreamSPIFFEID))
+	}
+	if policy.TLSUpstreamSPIFFETrustDomain != "" {
+		tlsContext.CommonTlsContext.ValidationContextType = buildSPIFFEValidationContext(policy)
+	}
+	if len(policy.To) > 0 && options.IsConsulConnectURL(&policy.To[0].URL) {
+		tlsContext.CommonTlsContext.TlsCertificates = nil
+		tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs = []*envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig{
+			buildConsulConnectSdsSecretConfig(consulConnectCertificateSecretName),
+		}
+		tlsContext.CommonTlsContext.ValidationContextType = buildConsulConnectValidationContext(dst.Hostname())
+	}
 
 	tlsConfig := marshalAny(tlsContext)
 	return &envoy_config_core_v3.TransportSocket{
@@ -399,6 +485,32 @@ func (b *Builder) buildCluster(
 	return cluster.Validate()
 }
 
+// buildCircuitBreakers builds the circuit breakers of a policy cluster. The remaining capacity is
+// always tracked so it's available in the cluster metrics.
+func buildCircuitBreakers(thresholds *config.CircuitBreakerThresholds) *envoy_config_cluster_v3.CircuitBreakers {
+	t := &envoy_config_cluster_v3.CircuitBreakers_Thresholds{
+		Priority:       envoy_config_core_v3.RoutingPriority_DEFAULT,
+		TrackRemaining: true,
+	}
+	if thresholds != nil {
+		t.MaxConnections = uint32Value(thresholds.MaxConnections)
+		t.MaxPendingRequests = uint32Value(thresholds.MaxPendingRequests)
+		t.MaxRequests = uint32Value(thresholds.MaxRequests)
+		t.MaxRetries = uint32Value(thresholds.MaxRetries)
+		t.MaxConnectionPools = uint32Value(thresholds.MaxConnectionPools)
+	}
+	return &envoy_config_cluster_v3.CircuitBreakers{
+		Thresholds: []*envoy_config_cluster_v3.CircuitBreakers_Thresholds{t},
+	}
+}
+
+func uint32Value(v *uint32) *wrapperspb.UInt32Value {
+	if v == nil {
+		return nil
+	}
+	return wrapperspb.UInt32(*v)
+}
+
 // grpcAuthorizeOutlierDetection defines slightly more aggressive malfunction detection for authorize endpoints
 func grpcAuthorizeOutlierDetection() *envoy_config_cluster_v3.OutlierDetection {
 	return &envoy_config_cluster_v3.OutlierDetection{
@@ -423,6 +535,21 @@ func grpcAuthorizeOutlierDetection() *envoy_config_cluster_v3.OutlierDetection {
 	}
 }
 
+// setFailoverPriorities gives each endpoint of a cluster its own priority, in order, so requests
+// go to the first healthy endpoint.
+func setFailoverPriorities(cluster *envoy_config_cluster_v3.Cluster) {
+	var localities []*envoy_config_endpoint_v3.LocalityLbEndpoints
+	for _, locality := range cluster.GetLoadAssignment().GetEndpoints() {
+		for _, lbe := range locality.GetLbEndpoints() {
+			localities = append(localities, &envoy_config_endpoint_v3.LocalityLbEndpoints{
+				LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{lbe},
+				Priority:    uint32(len(localities)),
+			})
+		}
+	}
+	cluster.LoadAssignment.Endpoints = localities
+}
+
 func grpcHealthChecks(name string) []*envoy_config_core_v3.HealthCheck {
 	return []*envoy_config_core_v3.HealthCheck{{
 		Timeout:               durationpb.New(time.Second * 10),
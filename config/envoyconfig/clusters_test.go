@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/volatiletech/null/v9"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/pomerium/pomerium/config"
@@ -958,8 +959,199 @@ func Test_bindConfig(t *testing.T) {
 	})
 }
 
+func Test_buildPolicyUpstreamCluster(t *testing.T) {
+	ctx := context.Background()
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	policy := &config.Policy{
+		From:      "https://from.example.com",
+		To:        mustParseWeightedURLs(t, "https://to.example.com"),
+		MirrorTo:  mustParseWeightedURLs(t, "https://mirror.example.com"),
+		EnvoyOpts: &envoy_config_cluster_v3.Cluster{Name: "example"},
+	}
+	cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, policy)
+	require.NoError(t, err)
+	mirrorCluster, err := b.buildPolicyUpstreamCluster(ctx, &config.Options{}, policy, policy.MirrorTo, "mirror")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, cluster.GetName(), mirrorCluster.GetName())
+	assert.Equal(t, getClusterID(getUpstreamPolicy(policy, policy.MirrorTo)), mirrorCluster.GetName())
+	assert.Equal(t, "example-mirror", mirrorCluster.GetAltStatName())
+	assert.Equal(t, "mirror.example.com",
+		mirrorCluster.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+}
+
+func Test_circuitBreakers(t *testing.T) {
+	ctx := context.Background()
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	t.Run("defaults", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `
+			{
+				"thresholds": [{ "trackRemaining": true }]
+			}
+		`, cluster.CircuitBreakers)
+	})
+	t.Run("thresholds", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{
+			CircuitBreakerThresholds: &config.CircuitBreakerThresholds{
+				MaxConnections: proto.Uint32(100),
+				MaxRetries:     proto.Uint32(5),
+			},
+		}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to.example.com"),
+			CircuitBreakerThresholds: &config.CircuitBreakerThresholds{
+				MaxPendingRequests: proto.Uint32(10),
+				MaxRetries:         proto.Uint32(1),
+			},
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `
+			{
+				"thresholds": [{
+					"maxConnections": 100,
+					"maxPendingRequests": 10,
+					"maxRetries": 1,
+					"trackRemaining": true
+				}]
+			}
+		`, cluster.CircuitBreakers)
+	})
+	t.Run("envoy options", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to.example.com"),
+			EnvoyOpts: &envoy_config_cluster_v3.Cluster{
+				CircuitBreakers: &envoy_config_cluster_v3.CircuitBreakers{
+					Thresholds: []*envoy_config_cluster_v3.CircuitBreakers_Thresholds{{
+						MaxRequests: wrapperspb.UInt32(1),
+					}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `
+			{
+				"thresholds": [{ "maxRequests": 1 }]
+			}
+		`, cluster.CircuitBreakers)
+	})
+}
+
+func Test_outlierDetection(t *testing.T) {
+	ctx := context.Background()
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	t.Run("single upstream", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, cluster.OutlierDetection)
+	})
+	t.Run("multiple upstreams", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to1.example.com", "https://to2.example.com"),
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `{}`, cluster.OutlierDetection)
+	})
+	t.Run("envoy options", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to1.example.com", "https://to2.example.com"),
+			EnvoyOpts: &envoy_config_cluster_v3.Cluster{
+				OutlierDetection: &envoy_config_cluster_v3.OutlierDetection{
+					Consecutive_5Xx: wrapperspb.UInt32(12),
+				},
+			},
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `{ "consecutive5xx": 12 }`, cluster.OutlierDetection)
+	})
+}
+
+func Test_responseBufferLimit(t *testing.T) {
+	ctx := context.Background()
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	t.Run("unset", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		})
+		require.NoError(t, err)
+		assert.Nil(t, cluster.PerConnectionBufferLimitBytes)
+	})
+	t.Run("limit", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From:                     "https://from.example.com",
+			To:                       mustParseWeightedURLs(t, "https://to.example.com"),
+			ResponseBufferLimitBytes: proto.Uint32(1024),
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `1024`, cluster.PerConnectionBufferLimitBytes)
+	})
+	t.Run("envoy options", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, &config.Options{}, &config.Policy{
+			From:                     "https://from.example.com",
+			To:                       mustParseWeightedURLs(t, "https://to.example.com"),
+			ResponseBufferLimitBytes: proto.Uint32(1024),
+			EnvoyOpts: &envoy_config_cluster_v3.Cluster{
+				PerConnectionBufferLimitBytes: wrapperspb.UInt32(2048),
+			},
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `2048`, cluster.PerConnectionBufferLimitBytes)
+	})
+}
+
 func mustParseWeightedURLs(t *testing.T, urls ...string) []config.WeightedURL {
 	wu, err := config.ParseWeightedUrls(urls...)
 	require.NoError(t, err)
 	return wu
 }
+
+func Test_databrokerCluster(t *testing.T) {
+	ctx := context.Background()
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	getCluster := func(t *testing.T, options *config.Options) *envoy_config_cluster_v3.Cluster {
+		clusters, err := b.BuildClusters(ctx, &config.Config{Options: options})
+		require.NoError(t, err)
+		for _, cluster := range clusters {
+			if cluster.GetName() == "pomerium-databroker" {
+				return cluster
+			}
+		}
+		t.Fatal("missing databroker cluster")
+		return nil
+	}
+
+	t.Run("round robin", func(t *testing.T) {
+		options := config.NewDefaultOptions()
+		options.DataBrokerURLStrings = []string{"http://databroker1.example.com", "http://databroker2.example.com"}
+		cluster := getCluster(t, options)
+		assert.Len(t, cluster.GetHealthChecks(), 1)
+		assert.NotNil(t, cluster.GetOutlierDetection())
+		if assert.Len(t, cluster.GetLoadAssignment().GetEndpoints(), 1) {
+			assert.Len(t, cluster.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints(), 2)
+		}
+	})
+	t.Run("pick first", func(t *testing.T) {
+		options := config.NewDefaultOptions()
+		options.DataBrokerURLStrings = []string{"http://databroker1.example.com", "http://databroker2.example.com"}
+		options.DataBrokerLoadBalancingPolicy = "pick_first"
+		cluster := getCluster(t, options)
+		localities := cluster.GetLoadAssignment().GetEndpoints()
+		if assert.Len(t, localities, 2) {
+			for i, locality := range localities {
+				assert.Equal(t, uint32(i), locality.GetPriority())
+				assert.Len(t, locality.GetLbEndpoints(), 1)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package envoyconfig
+
+import (
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	"github.com/pomerium/pomerium/internal/discovery"
+)
+
+// The names of the secrets of the Consul Connect certificates. They're sent to envoy with SDS, so
+// the certificates are rotated without rebuilding the clusters of upstreams.
+const (
+	consulConnectCertificateSecretName = "pomerium-consul-connect-certificate"
+	consulConnectRootsSecretName       = "pomerium-consul-connect-roots"
+)
+
+// BuildConsulConnectSecrets builds the secrets sent to envoy with SDS for the Consul Connect
+// certificates.
+func (b *Builder) BuildConsulConnectSecrets(
+	certs *discovery.ConsulConnectCertificates,
+) []*envoy_extensions_transport_sockets_tls_v3.Secret {
+	if certs == nil {
+		return nil
+	}
+
+	return []*envoy_extensions_transport_sockets_tls_v3.Secret{{
+		Name: consulConnectCertificateSecretName,
+		Type: &envoy_extensions_transport_sockets_tls_v3.Secret_TlsCertificate{
+			TlsCertificate: &envoy_extensions_transport_sockets_tls_v3.TlsCertificate{
+				CertificateChain: b.filemgr.BytesDataSource("consul-connect-cert.pem", certs.CertificatePEM),
+				PrivateKey:       b.filemgr.BytesDataSource("consul-connect-key.pem", certs.PrivateKeyPEM),
+			},
+		},
+	}, {
+		Name: consulConnectRootsSecretName,
+		Type: &envoy_extensions_transport_sockets_tls_v3.Secret_ValidationContext{
+			ValidationContext: &envoy_extensions_transport_sockets_tls_v3.CertificateValidationContext{
+				TrustedCa: b.filemgr.BytesDataSource("consul-connect-roots.pem", certs.RootsPEM),
+			},
+		},
+	}}
+}
+
+func buildConsulConnectSdsSecretConfig(name string) *envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig {
+	return &envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig{
+		Name: name,
+		SdsConfig: &envoy_config_core_v3.ConfigSource{
+			ResourceApiVersion:    envoy_config_core_v3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoy_config_core_v3.ConfigSource_Ads{},
+		},
+	}
+}
+
+// buildConsulConnectValidationContext builds a validation context verifying the upstream
+// presents a Connect certificate of the service. Its SPIFFE ID ends with the service name, like
+// `spiffe://<trust domain>/ns/default/dc/dc1/svc/<service>`.
+func buildConsulConnectValidationContext(service string) *envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_CombinedValidationContext {
+	return &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_CombinedValidationContext{
+		CombinedValidationContext: &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_CombinedCertificateValidationContext{
+			DefaultValidationContext: &envoy_extensions_transport_sockets_tls_v3.CertificateValidationContext{
+				MatchTypedSubjectAltNames: []*envoy_extensions_transport_sockets_tls_v3.SubjectAltNameMatcher{{
+					SanType: envoy_extensions_transport_sockets_tls_v3.SubjectAltNameMatcher_URI,
+					Matcher: &envoy_type_matcher_v3.StringMatcher{
+						MatchPattern: &envoy_type_matcher_v3.StringMatcher_Suffix{
+							Suffix: "/svc/" + service,
+						},
+					},
+				}},
+			},
+			ValidationContextSdsSecretConfig: buildConsulConnectSdsSecretConfig(consulConnectRootsSecretName),
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package envoyconfig
+
+import (
+	"context"
+	"testing"
+
+	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/discovery"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func TestBuildConsulConnectSecrets(t *testing.T) {
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	assert.Nil(t, b.BuildConsulConnectSecrets(nil))
+
+	secrets := b.BuildConsulConnectSecrets(&discovery.ConsulConnectCertificates{
+		CertificatePEM: []byte("CERT"),
+		PrivateKeyPEM:  []byte("KEY"),
+		RootsPEM:       []byte("ROOTS"),
+	})
+	if assert.Len(t, secrets, 2) {
+		assert.Equal(t, consulConnectCertificateSecretName, secrets[0].GetName())
+		assert.NotNil(t, secrets[0].GetTlsCertificate().GetPrivateKey())
+		assert.Equal(t, consulConnectRootsSecretName, secrets[1].GetName())
+		assert.NotNil(t, secrets[1].GetValidationContext().GetTrustedCa())
+	}
+}
+
+func Test_buildPolicyTransportSocketConsulConnect(t *testing.T) {
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	options := config.NewDefaultOptions()
+	options.ConsulConnectServiceName = "pomerium"
+	policy := &config.Policy{
+		To: mustParseWeightedURLs(t, "consul+https://api"),
+	}
+	ts, err := b.buildPolicyTransportSocket(context.Background(), options, policy, *mustParseURL(t, "https://api"))
+	require.NoError(t, err)
+
+	var tlsContext envoy_extensions_transport_sockets_tls_v3.UpstreamTlsContext
+	require.NoError(t, ts.GetTypedConfig().UnmarshalTo(&tlsContext))
+
+	sdsConfig := `{
+		"resourceApiVersion": "V3",
+		"ads": {}
+	}`
+	testutil.AssertProtoJSONEqual(t, `[{
+		"name": "pomerium-consul-connect-certificate",
+		"sdsConfig": `+sdsConfig+`
+	}]`, tlsContext.GetCommonTlsContext().GetTlsCertificateSdsSecretConfigs())
+	testutil.AssertProtoJSONEqual(t, `{
+		"defaultValidationContext": {
+			"matchTypedSubjectAltNames": [{
+				"sanType": "URI",
+				"matcher": { "suffix": "/svc/api" }
+			}]
+		},
+		"validationContextSdsSecretConfig": {
+			"name": "pomerium-consul-connect-roots",
+			"sdsConfig": `+sdsConfig+`
+		}
+	}`, tlsContext.GetCommonTlsContext().GetCombinedValidationContext())
+
+	t.Run("disabled", func(t *testing.T) {
+		ts, err := b.buildPolicyTransportSocket(context.Background(), config.NewDefaultOptions(), policy, *mustParseURL(t, "https://api"))
+		require.NoError(t, err)
+
+		var tlsContext envoy_extensions_transport_sockets_tls_v3.UpstreamTlsContext
+		require.NoError(t, ts.GetTypedConfig().UnmarshalTo(&tlsContext))
+		assert.Empty(t, tlsContext.GetCommonTlsContext().GetTlsCertificateSdsSecretConfigs())
+	})
+}
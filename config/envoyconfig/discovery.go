@@ -0,0 +1,114 @@
+package envoyconfig
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/discovery"
+)
+
+// BuildClusterLoadAssignments builds the load assignments sent to envoy with EDS for the clusters
+// of discovered upstreams.
+func (b *Builder) BuildClusterLoadAssignments(
+	ctx context.Context,
+	cfg *config.Config,
+	getEndpoints func(*url.URL) []discovery.Endpoint,
+) ([]*envoy_config_endpoint_v3.ClusterLoadAssignment, error) {
+	if !config.IsProxy(cfg.Options.Services) {
+		return nil, nil
+	}
+
+	var assignments []*envoy_config_endpoint_v3.ClusterLoadAssignment
+	for i, p := range cfg.Options.GetAllPolicies() {
+		policy := p
+		if policy.EnvoyOpts == nil {
+			policy.EnvoyOpts = newDefaultEnvoyClusterConfig()
+		}
+
+		upstreams := []*config.Policy{&policy}
+		if len(policy.MirrorTo) > 0 {
+			upstreams = append(upstreams, getUpstreamPolicy(&policy, policy.MirrorTo))
+		}
+		for _, g := range policy.UpstreamGroups {
+			upstreams = append(upstreams, getUpstreamPolicy(&policy, g.To))
+		}
+
+		for _, upstream := range upstreams {
+			if !hasDiscoveredUpstreams(upstream) {
+				continue
+			}
+			assignment, err := b.buildClusterLoadAssignment(ctx, cfg.Options, upstream, getEndpoints)
+			if err != nil {
+				return nil, fmt.Errorf("policy #%d: %w", i, err)
+			}
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+func (b *Builder) buildClusterLoadAssignment(
+	ctx context.Context,
+	options *config.Options,
+	policy *config.Policy,
+	getEndpoints func(*url.URL) []discovery.Endpoint,
+) (*envoy_config_endpoint_v3.ClusterLoadAssignment, error) {
+	var endpoints []Endpoint
+	var discovered []discovery.Endpoint
+	for _, dst := range policy.To {
+		upstreamURL := config.GetDiscoveryUpstreamURL(&dst.URL)
+		ts, err := b.buildPolicyTransportSocket(ctx, options, policy, *upstreamURL)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range getEndpoints(&dst.URL) {
+			u := &url.URL{Scheme: upstreamURL.Scheme, Host: e.Address}
+			endpoints = append(endpoints, NewEndpoint(u, ts, dst.LbWeight))
+			discovered = append(discovered, e)
+		}
+	}
+
+	lbEndpoints, err := b.buildLbEndpoints(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	for i, lbe := range lbEndpoints {
+		lbe.GetEndpoint().Hostname = discovered[i].Hostname
+		if discovered[i].Unready {
+			lbe.HealthStatus = envoy_config_core_v3.HealthStatus_UNHEALTHY
+		}
+	}
+
+	name := getClusterID(policy)
+	return &envoy_config_endpoint_v3.ClusterLoadAssignment{
+		ClusterName: name,
+		Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{{
+			LbEndpoints: lbEndpoints,
+		}},
+	}, nil
+}
+
+// setClusterEDS switches the cluster of discovered upstreams to EDS, so their endpoints are
+// updated without rebuilding the cluster.
+func setClusterEDS(cluster *envoy_config_cluster_v3.Cluster) {
+	cluster.ClusterDiscoveryType = &envoy_config_cluster_v3.Cluster_Type{Type: envoy_config_cluster_v3.Cluster_EDS}
+	cluster.EdsClusterConfig = &envoy_config_cluster_v3.Cluster_EdsClusterConfig{
+		EdsConfig: &envoy_config_core_v3.ConfigSource{
+			ResourceApiVersion:    envoy_config_core_v3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoy_config_core_v3.ConfigSource_Ads{},
+		},
+		ServiceName: cluster.Name,
+	}
+	cluster.LoadAssignment = nil
+	cluster.RespectDnsTtl = false
+}
+
+func hasDiscoveredUpstreams(policy *config.Policy) bool {
+	return len(policy.To) > 0 && config.IsDiscoveryURL(&policy.To[0].URL)
+}
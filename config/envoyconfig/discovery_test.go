@@ -0,0 +1,78 @@
+package envoyconfig
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/discovery"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func TestBuildClusterLoadAssignments(t *testing.T) {
+	defer func(f func(*config.Policy) string) {
+		getClusterID = f
+	}(getClusterID)
+	getClusterID = func(*config.Policy) string { return "policy" }
+
+	ctx := context.Background()
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	policy := config.Policy{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "srv+http://_api._tcp.example.com"),
+	}
+	require.NoError(t, policy.Validate())
+
+	t.Run("cluster", func(t *testing.T) {
+		cluster, err := b.buildPolicyCluster(ctx, config.NewDefaultOptions(), &policy)
+		require.NoError(t, err)
+		assert.Nil(t, cluster.GetLoadAssignment())
+		assert.Equal(t, envoy_config_cluster_v3.Cluster_EDS, cluster.GetType())
+		testutil.AssertProtoJSONEqual(t, `{
+			"edsConfig": {
+				"ads": {},
+				"resourceApiVersion": "V3"
+			},
+			"serviceName": "policy"
+		}`, cluster.GetEdsClusterConfig())
+	})
+	t.Run("load assignments", func(t *testing.T) {
+		assignments, err := b.BuildClusterLoadAssignments(ctx, &config.Config{Options: &config.Options{
+			Services: "all",
+			Policies: []config.Policy{policy},
+		}}, func(u *url.URL) []discovery.Endpoint {
+			assert.Equal(t, "srv+http://_api._tcp.example.com", u.String())
+			return []discovery.Endpoint{
+				{Hostname: "api-1.example.com", Address: "10.0.0.1:8080"},
+				{Hostname: "api-2.example.com", Address: "10.0.0.2:8080", Unready: true},
+			}
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `[{
+			"clusterName": "policy",
+			"endpoints": [{
+				"lbEndpoints": [
+					{
+						"endpoint": {
+							"address": { "socketAddress": { "address": "10.0.0.1", "ipv4Compat": true, "portValue": 8080 } },
+							"hostname": "api-1.example.com"
+						}
+					},
+					{
+						"endpoint": {
+							"address": { "socketAddress": { "address": "10.0.0.2", "ipv4Compat": true, "portValue": 8080 } },
+							"hostname": "api-2.example.com"
+						},
+						"healthStatus": "UNHEALTHY"
+					}
+				]
+			}]
+		}]`, assignments)
+	})
+}
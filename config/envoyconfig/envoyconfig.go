@@ -84,13 +84,16 @@ func buildAccessLogs(options *config.Options) []*envoy_config_accesslog_v3.Acces
 		lvl = "debug"
 	}
 
-	switch lvl {
-	case "trace", "debug", "info":
+	switch {
+	case len(options.AccessLogSinks) > 0, len(options.MetricsRoutes) > 0:
+		// access log sinks and per-route metrics don't depend on the log level
+	case lvl == "trace", lvl == "debug", lvl == "info":
 	default:
 		// don't log access requests for levels > info
 		return nil
 	}
 
+	requestHeaders, responseHeaders := options.GetAccessLogHeaders()
 	tc := marshalAny(&envoy_extensions_access_loggers_grpc_v3.HttpGrpcAccessLogConfig{
 		CommonConfig: &envoy_extensions_access_loggers_grpc_v3.CommonGrpcAccessLogConfig{
 			LogName: "ingress-http",
@@ -103,6 +106,8 @@ func buildAccessLogs(options *config.Options) []*envoy_config_accesslog_v3.Acces
 			},
 			TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
 		},
+		AdditionalRequestHeadersToLog:  requestHeaders,
+		AdditionalResponseHeadersToLog: responseHeaders,
 	})
 	return []*envoy_config_accesslog_v3.AccessLog{{
 		Name:       "envoy.access_loggers.http_grpc",
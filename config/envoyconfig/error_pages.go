@@ -0,0 +1,143 @@
+package envoyconfig
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	envoy_config_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+// error page template fields are rendered as placeholders and replaced by envoy command operators
+// after the rest of the page has been rendered. The placeholders are only made of characters
+// which aren't escaped by html/template.
+var errorPageCommandOperators = map[string]string{
+	"POMERIUM_ERROR_PAGE_REQUEST_ID": "%REQ(X-REQUEST-ID)%",
+	"POMERIUM_ERROR_PAGE_URL":        "%REQ(X-FORWARDED-PROTO)%://%REQ(:AUTHORITY)%%REQ(:PATH)%",
+	"POMERIUM_ERROR_PAGE_DETAILS":    "%RESPONSE_CODE_DETAILS%",
+}
+
+// buildErrorPageMappers builds the local reply mappers replacing the responses generated by envoy
+// with the error pages of the routes. Requests are matched to their route by the dynamic metadata
+// set by authorize.
+func buildErrorPageMappers(
+	options *config.Options,
+	headers []*envoy_config_core_v3.HeaderValueOption,
+) ([]*envoy_http_connection_manager.ResponseMapper, error) {
+	var mappers []*envoy_http_connection_manager.ResponseMapper
+	for _, p := range options.GetAllPolicies() {
+		policy := p
+		if len(policy.ErrorPages) == 0 {
+			continue
+		}
+		routeID, err := policy.RouteID()
+		if err != nil {
+			return nil, err
+		}
+		for i := range policy.ErrorPages {
+			page := &policy.ErrorPages[i]
+			for _, code := range page.StatusCodes {
+				body, err := renderErrorPageFormat(page, code)
+				if err != nil {
+					return nil, err
+				}
+				mappers = append(mappers, &envoy_http_connection_manager.ResponseMapper{
+					Filter: buildErrorPageFilter(strconv.FormatUint(routeID, 10), code),
+					BodyFormatOverride: &envoy_config_core_v3.SubstitutionFormatString{
+						Format: &envoy_config_core_v3.SubstitutionFormatString_TextFormatSource{
+							TextFormatSource: &envoy_config_core_v3.DataSource{
+								Specifier: &envoy_config_core_v3.DataSource_InlineString{
+									InlineString: body,
+								},
+							},
+						},
+						ContentType: page.GetContentType(),
+					},
+					HeadersToAdd: headers,
+				})
+			}
+		}
+	}
+	return mappers, nil
+}
+
+func buildErrorPageFilter(routeID string, code int) *envoy_config_accesslog_v3.AccessLogFilter {
+	return &envoy_config_accesslog_v3.AccessLogFilter{
+		FilterSpecifier: &envoy_config_accesslog_v3.AccessLogFilter_AndFilter{
+			AndFilter: &envoy_config_accesslog_v3.AndFilter{
+				Filters: []*envoy_config_accesslog_v3.AccessLogFilter{
+					{
+						FilterSpecifier: &envoy_config_accesslog_v3.AccessLogFilter_StatusCodeFilter{
+							StatusCodeFilter: &envoy_config_accesslog_v3.StatusCodeFilter{
+								Comparison: &envoy_config_accesslog_v3.ComparisonFilter{
+									Op: envoy_config_accesslog_v3.ComparisonFilter_EQ,
+									Value: &envoy_config_core_v3.RuntimeUInt32{
+										DefaultValue: uint32(code),
+										RuntimeKey:   "pomerium.error_page.status_code",
+									},
+								},
+							},
+						},
+					},
+					{
+						FilterSpecifier: &envoy_config_accesslog_v3.AccessLogFilter_MetadataFilter{
+							MetadataFilter: &envoy_config_accesslog_v3.MetadataFilter{
+								Matcher: &envoy_type_matcher_v3.MetadataMatcher{
+									Filter: "envoy.filters.http.ext_authz",
+									Path: []*envoy_type_matcher_v3.MetadataMatcher_PathSegment{{
+										Segment: &envoy_type_matcher_v3.MetadataMatcher_PathSegment_Key{
+											Key: config.ErrorPageRouteIDMetadataKey,
+										},
+									}},
+									Value: &envoy_type_matcher_v3.ValueMatcher{
+										MatchPattern: &envoy_type_matcher_v3.ValueMatcher_StringMatch{
+											StringMatch: &envoy_type_matcher_v3.StringMatcher{
+												MatchPattern: &envoy_type_matcher_v3.StringMatcher_Exact{
+													Exact: routeID,
+												},
+											},
+										},
+									},
+								},
+								MatchIfKeyNotFound: &wrappers.BoolValue{Value: false},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// renderErrorPageFormat renders the error page as an envoy format string. The fields only known
+// when the response is generated are replaced by envoy command operators. Envoy doesn't escape
+// them, so the URL, which is set by the client, is left empty in HTML and JSON pages.
+func renderErrorPageFormat(page *config.PolicyErrorPage, code int) (string, error) {
+	requestURL := "POMERIUM_ERROR_PAGE_URL"
+	if page.IsHTML() || page.IsJSON() {
+		requestURL = ""
+	}
+	body, err := page.RenderBody(map[string]interface{}{
+		"Status":     code,
+		"StatusText": http.StatusText(code),
+		"Reasons":    []string{"POMERIUM_ERROR_PAGE_DETAILS"},
+		"RequestID":  "POMERIUM_ERROR_PAGE_REQUEST_ID",
+		"URL":        requestURL,
+		"User":       map[string]string{"ID": "", "Email": "", "Name": ""},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	body = strings.ReplaceAll(body, "%", "%%")
+	for placeholder, operator := range errorPageCommandOperators {
+		body = strings.ReplaceAll(body, placeholder, operator)
+	}
+	return body, nil
+}
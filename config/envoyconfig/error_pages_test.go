@@ -0,0 +1,102 @@
+package envoyconfig
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func Test_renderErrorPageFormat(t *testing.T) {
+	t.Run("html", func(t *testing.T) {
+		page := &config.PolicyErrorPage{
+			StatusCodes: []int{503},
+			Body:        `<p>{{.Status}} {{.StatusText}} (100%): {{.URL}} {{.RequestID}} {{range .Reasons}}{{.}}{{end}}</p>`,
+		}
+		require.NoError(t, page.Validate())
+		body, err := renderErrorPageFormat(page, 503)
+		require.NoError(t, err)
+		assert.Equal(t, `<p>503 Service Unavailable (100%%):  %REQ(X-REQUEST-ID)% %RESPONSE_CODE_DETAILS%</p>`, body)
+	})
+	t.Run("json", func(t *testing.T) {
+		page := &config.PolicyErrorPage{
+			StatusCodes: []int{503},
+			ContentType: "application/json",
+			Body:        `{"status":{{.Status}},"url":"{{.URL}}","request_id":"{{.RequestID}}","reasons":{{json .Reasons}}}`,
+		}
+		require.NoError(t, page.Validate())
+		body, err := renderErrorPageFormat(page, 503)
+		require.NoError(t, err)
+		assert.Equal(t, `{"status":503,"url":"","request_id":"%REQ(X-REQUEST-ID)%","reasons":["%RESPONSE_CODE_DETAILS%"]}`, body)
+	})
+	t.Run("text", func(t *testing.T) {
+		page := &config.PolicyErrorPage{
+			StatusCodes: []int{503},
+			ContentType: "text/plain",
+			Body:        `{{.Status}} {{.StatusText}} (100%): {{.URL}} {{.RequestID}} {{range .Reasons}}{{.}}{{end}}`,
+		}
+		require.NoError(t, page.Validate())
+		body, err := renderErrorPageFormat(page, 503)
+		require.NoError(t, err)
+		assert.Equal(t, `503 Service Unavailable (100%%): `+
+			`%REQ(X-FORWARDED-PROTO)%://%REQ(:AUTHORITY)%%REQ(:PATH)% %REQ(X-REQUEST-ID)% %RESPONSE_CODE_DETAILS%`, body)
+	})
+}
+
+func Test_buildErrorPageMappers(t *testing.T) {
+	policy := config.Policy{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		ErrorPages: []config.PolicyErrorPage{{
+			StatusCodes: []int{503},
+			ContentType: "text/plain",
+			Body:        "{{.Status}}",
+		}},
+	}
+	require.NoError(t, policy.Validate())
+	routeID, err := policy.RouteID()
+	require.NoError(t, err)
+
+	mappers, err := buildErrorPageMappers(&config.Options{
+		Policies: []config.Policy{{From: "https://other.example.com"}, policy},
+	}, nil)
+	require.NoError(t, err)
+	if assert.Len(t, mappers, 1) {
+		testutil.AssertProtoJSONEqual(t, `{
+			"filter": {
+				"andFilter": {
+					"filters": [
+						{
+							"statusCodeFilter": {
+								"comparison": {
+									"value": {
+										"defaultValue": 503,
+										"runtimeKey": "pomerium.error_page.status_code"
+									}
+								}
+							}
+						},
+						{
+							"metadataFilter": {
+								"matcher": {
+									"filter": "envoy.filters.http.ext_authz",
+									"path": [{ "key": "error_page_route_id" }],
+									"value": { "stringMatch": { "exact": "`+strconv.FormatUint(routeID, 10)+`" } }
+								},
+								"matchIfKeyNotFound": false
+							}
+						}
+					]
+				}
+			},
+			"bodyFormatOverride": {
+				"textFormatSource": { "inlineString": "503" },
+				"contentType": "text/plain"
+			}
+		}`, mappers[0])
+	}
+}
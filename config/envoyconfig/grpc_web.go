@@ -0,0 +1,96 @@
+package envoyconfig
+
+import (
+	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoy_extensions_filters_http_cors_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
+	envoy_extensions_filters_http_grpc_web_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
+	envoy_extensions_filters_http_lua_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+const (
+	grpcWebAllowMethods  = "POST, OPTIONS"
+	grpcWebAllowHeaders  = "authorization,content-type,grpc-timeout,keep-alive,user-agent,x-grpc-web,x-user-agent"
+	grpcWebExposeHeaders = "grpc-message,grpc-status,grpc-status-details-bin"
+	grpcWebMaxAge        = "1728000"
+)
+
+func hasGRPCWebPolicy(options *config.Options) bool {
+	for _, p := range options.GetAllPolicies() {
+		if p.GRPCWeb {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGRPCWebCORSFilter builds the CORS filter, which answers the preflight requests of
+// cross-origin gRPC-Web requests. It must come before ext_authz as browsers don't send
+// credentials with preflight requests.
+func buildGRPCWebCORSFilter() *envoy_http_connection_manager.HttpFilter {
+	return &envoy_http_connection_manager.HttpFilter{
+		Name: "envoy.filters.http.cors",
+		ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
+			TypedConfig: marshalAny(&envoy_extensions_filters_http_cors_v3.Cors{}),
+		},
+	}
+}
+
+// buildGRPCWebFilters builds the filters translating gRPC-Web requests to gRPC. The filter
+// can't be disabled per route, so the gRPC-Web content type is hidden from it for routes
+// which haven't enabled gRPC-Web.
+func buildGRPCWebFilters() []*envoy_http_connection_manager.HttpFilter {
+	disableLua := marshalAny(&envoy_extensions_filters_http_lua_v3.Lua{
+		InlineCode: luascripts.GRPCWebDisable,
+	})
+	restoreLua := marshalAny(&envoy_extensions_filters_http_lua_v3.Lua{
+		InlineCode: luascripts.GRPCWebRestore,
+	})
+	return []*envoy_http_connection_manager.HttpFilter{
+		{
+			Name: "envoy.filters.http.lua",
+			ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
+				TypedConfig: disableLua,
+			},
+		},
+		{
+			Name: "envoy.filters.http.grpc_web",
+			ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
+				TypedConfig: marshalAny(&envoy_extensions_filters_http_grpc_web_v3.GrpcWeb{}),
+			},
+		},
+		{
+			Name: "envoy.filters.http.lua",
+			ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
+				TypedConfig: restoreLua,
+			},
+		},
+	}
+}
+
+func buildGRPCWebCORSPolicy(policy *config.Policy) *envoy_config_route_v3.CorsPolicy {
+	if !policy.GRPCWeb || len(policy.GRPCWebAllowedOrigins) == 0 {
+		return nil
+	}
+
+	var origins []*envoy_type_matcher_v3.StringMatcher
+	for _, origin := range policy.GRPCWebAllowedOrigins {
+		origins = append(origins, &envoy_type_matcher_v3.StringMatcher{
+			MatchPattern: &envoy_type_matcher_v3.StringMatcher_Exact{
+				Exact: origin,
+			},
+		})
+	}
+	return &envoy_config_route_v3.CorsPolicy{
+		AllowOriginStringMatch: origins,
+		AllowMethods:           grpcWebAllowMethods,
+		AllowHeaders:           grpcWebAllowHeaders,
+		ExposeHeaders:          grpcWebExposeHeaders,
+		MaxAge:                 grpcWebMaxAge,
+		AllowCredentials:       &wrappers.BoolValue{Value: true},
+	}
+}
@@ -0,0 +1,38 @@
+package envoyconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func Test_buildGRPCWebCORSPolicy(t *testing.T) {
+	assert.Nil(t, buildGRPCWebCORSPolicy(&config.Policy{
+		GRPCWebAllowedOrigins: []string{"https://app.example.com"},
+	}), "should not set a cors policy when grpc-web is disabled")
+	assert.Nil(t, buildGRPCWebCORSPolicy(&config.Policy{
+		GRPCWeb: true,
+	}), "should not set a cors policy without allowed origins")
+
+	testutil.AssertProtoJSONEqual(t, `{
+		"allowOriginStringMatch": [
+			{ "exact": "https://app.example.com" }
+		],
+		"allowMethods": "POST, OPTIONS",
+		"allowHeaders": "authorization,content-type,grpc-timeout,keep-alive,user-agent,x-grpc-web,x-user-agent",
+		"exposeHeaders": "grpc-message,grpc-status,grpc-status-details-bin",
+		"maxAge": "1728000",
+		"allowCredentials": true
+	}`, buildGRPCWebCORSPolicy(&config.Policy{
+		GRPCWeb:               true,
+		GRPCWebAllowedOrigins: []string{"https://app.example.com"},
+	}))
+}
+
+func Test_getUpstreamProtocolForPolicy_GRPCWeb(t *testing.T) {
+	assert.Equal(t, upstreamProtocolHTTP2, getUpstreamProtocolForPolicy(context.Background(), &config.Policy{GRPCWeb: true}))
+}
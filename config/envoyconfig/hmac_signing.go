@@ -0,0 +1,57 @@
+package envoyconfig
+
+import (
+	envoy_extensions_filters_http_ext_authz_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+// hmacSigningMaxRequestBytes is the maximum size of the bodies of requests to routes with
+// HMAC signing. Larger requests are rejected with a 413.
+const hmacSigningMaxRequestBytes = 1024 * 1024
+
+func hasHMACSigningPolicy(options *config.Options) bool {
+	for _, p := range options.GetAllPolicies() {
+		if p.HMACSigning != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// buildExtAuthzRequestBody returns the settings of the request bodies sent to authorize, so the
+// bodies of the requests to routes with HMAC signing can be signed. The bodies of other
+// requests aren't buffered, see buildExtAuthzPerRouteRequestBodyDisabled.
+func buildExtAuthzRequestBody(options *config.Options) *envoy_extensions_filters_http_ext_authz_v3.BufferSettings {
+	if !hasHMACSigningPolicy(options) {
+		return nil
+	}
+	return &envoy_extensions_filters_http_ext_authz_v3.BufferSettings{
+		MaxRequestBytes: hmacSigningMaxRequestBytes,
+		PackAsBytes:     true,
+	}
+}
+
+func buildExtAuthzPerRouteRequestBodyDisabled() *any.Any {
+	return marshalAny(&envoy_extensions_filters_http_ext_authz_v3.ExtAuthzPerRoute{
+		Override: &envoy_extensions_filters_http_ext_authz_v3.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &envoy_extensions_filters_http_ext_authz_v3.CheckSettings{
+				DisableRequestBodyBuffering: true,
+			},
+		},
+	})
+}
+
+// buildPolicyExtAuthzPerRoute returns the ext_authz config of the routes with HMAC signing,
+// which enables the request body buffering disabled on their virtual host.
+func buildPolicyExtAuthzPerRoute(policy *config.Policy) *any.Any {
+	if policy.HMACSigning == nil {
+		return nil
+	}
+	return marshalAny(&envoy_extensions_filters_http_ext_authz_v3.ExtAuthzPerRoute{
+		Override: &envoy_extensions_filters_http_ext_authz_v3.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &envoy_extensions_filters_http_ext_authz_v3.CheckSettings{},
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package envoyconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func Test_buildExtAuthzRequestBody(t *testing.T) {
+	assert.Nil(t, buildExtAuthzRequestBody(&config.Options{}))
+
+	options := &config.Options{
+		Policies: []config.Policy{{
+			From:        "https://from.example.com",
+			To:          mustParseWeightedURLs(t, "https://to.example.com"),
+			HMACSigning: &config.PolicyHMACSigning{Secret: "c2VjcmV0"},
+		}},
+	}
+	testutil.AssertProtoJSONEqual(t, `{
+		"maxRequestBytes": 1048576,
+		"packAsBytes": true
+	}`, buildExtAuthzRequestBody(options))
+
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	vh, err := b.buildVirtualHost(options, "example.com", "from.example.com")
+	require.NoError(t, err)
+	testutil.AssertProtoJSONEqual(t, `{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+		"checkSettings": { "disableRequestBodyBuffering": true }
+	}`, vh.TypedPerFilterConfig["envoy.filters.http.ext_authz"])
+}
+
+func Test_buildPolicyExtAuthzPerRoute(t *testing.T) {
+	assert.Nil(t, buildPolicyExtAuthzPerRoute(&config.Policy{}))
+	testutil.AssertProtoJSONEqual(t, `{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+		"checkSettings": {}
+	}`, buildPolicyExtAuthzPerRoute(&config.Policy{HMACSigning: &config.PolicyHMACSigning{Secret: "c2VjcmV0"}}))
+}
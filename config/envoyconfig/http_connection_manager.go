@@ -5,6 +5,7 @@ import (
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/ptypes/any"
 
 	"github.com/pomerium/pomerium/config"
 )
@@ -26,6 +27,21 @@ func (b *Builder) buildVirtualHost(
 	}
 	vh.Routes = append(vh.Routes, rs...)
 
+	// the buffer filter is only enabled for routes with a request body limit
+	if hasRequestBodyLimitPolicy(options) {
+		vh.TypedPerFilterConfig = map[string]*any.Any{
+			bufferFilterName: buildBufferPerRouteDisabled(),
+		}
+	}
+
+	// request bodies are only sent to authorize for routes with HMAC signing
+	if hasHMACSigningPolicy(options) {
+		if vh.TypedPerFilterConfig == nil {
+			vh.TypedPerFilterConfig = make(map[string]*any.Any)
+		}
+		vh.TypedPerFilterConfig["envoy.filters.http.ext_authz"] = buildExtAuthzPerRouteRequestBodyDisabled()
+	}
+
 	// if we're the proxy or authenticate service, add our global headers
 	if config.IsProxy(options.Services) || config.IsAuthenticate(options.Services) {
 		vh.ResponseHeadersToAdd = toEnvoyHeaders(options.GetSetResponseHeaders())
@@ -38,7 +54,7 @@ func (b *Builder) buildVirtualHost(
 // coming directly from envoy
 func (b *Builder) buildLocalReplyConfig(
 	options *config.Options,
-) *envoy_http_connection_manager.LocalReplyConfig {
+) (*envoy_http_connection_manager.LocalReplyConfig, error) {
 	// add global headers for HSTS headers (#2110)
 	var headers []*envoy_config_core_v3.HeaderValueOption
 	// if we're the proxy or authenticate service, add our global headers
@@ -46,14 +62,25 @@ func (b *Builder) buildLocalReplyConfig(
 		headers = toEnvoyHeaders(options.GetSetResponseHeaders())
 	}
 
-	return &envoy_http_connection_manager.LocalReplyConfig{
-		Mappers: []*envoy_http_connection_manager.ResponseMapper{{
-			Filter: &envoy_config_accesslog_v3.AccessLogFilter{
-				FilterSpecifier: &envoy_config_accesslog_v3.AccessLogFilter_ResponseFlagFilter{
-					ResponseFlagFilter: &envoy_config_accesslog_v3.ResponseFlagFilter{},
-				},
-			},
-			HeadersToAdd: headers,
-		}},
+	// the first matching mapper is used, so the error pages of the routes come first
+	var mappers []*envoy_http_connection_manager.ResponseMapper
+	if config.IsProxy(options.Services) {
+		var err error
+		mappers, err = buildErrorPageMappers(options, headers)
+		if err != nil {
+			return nil, err
+		}
 	}
+	mappers = append(mappers, &envoy_http_connection_manager.ResponseMapper{
+		Filter: &envoy_config_accesslog_v3.AccessLogFilter{
+			FilterSpecifier: &envoy_config_accesslog_v3.AccessLogFilter_ResponseFlagFilter{
+				ResponseFlagFilter: &envoy_config_accesslog_v3.ResponseFlagFilter{},
+			},
+		},
+		HeadersToAdd: headers,
+	})
+
+	return &envoy_http_connection_manager.LocalReplyConfig{
+		Mappers: mappers,
+	}, nil
 }
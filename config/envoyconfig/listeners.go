@@ -24,6 +24,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/fips"
 	"github.com/pomerium/pomerium/internal/hashutil"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/sets"
@@ -34,7 +35,7 @@ const listenerBufferLimit uint32 = 32 * 1024
 
 var (
 	disableExtAuthz *any.Any
-	tlsParams       = &envoy_extensions_transport_sockets_tls_v3.TlsParameters{
+	tlsParams       = restrictTLSParams(&envoy_extensions_transport_sockets_tls_v3.TlsParameters{
 		CipherSuites: []string{
 			"ECDHE-ECDSA-AES256-GCM-SHA384",
 			"ECDHE-RSA-AES256-GCM-SHA384",
@@ -44,7 +45,7 @@ var (
 			"ECDHE-RSA-CHACHA20-POLY1305",
 		},
 		TlsMinimumProtocolVersion: envoy_extensions_transport_sockets_tls_v3.TlsParameters_TLSv1_2,
-	}
+	}, fips.Enabled())
 )
 
 func init() {
@@ -105,12 +106,7 @@ func (b *Builder) buildMainListener(ctx context.Context, cfg *config.Config) (*e
 	}
 
 	if cfg.Options.InsecureServer {
-		allDomains, err := getAllRouteableDomains(cfg.Options, cfg.Options.Addr)
-		if err != nil {
-			return nil, err
-		}
-
-		filter, err := b.buildMainHTTPConnectionManagerFilter(cfg.Options, allDomains, "")
+		filter, err := b.buildMainHTTPConnectionManagerFilter(cfg.Options, "")
 		if err != nil {
 			return nil, err
 		}
@@ -136,7 +132,7 @@ func (b *Builder) buildMainListener(ctx context.Context, cfg *config.Config) (*e
 
 	chains, err := b.buildFilterChains(cfg.Options, cfg.Options.Addr,
 		func(tlsDomain string, httpDomains []string) (*envoy_config_listener_v3.FilterChain, error) {
-			filter, err := b.buildMainHTTPConnectionManagerFilter(cfg.Options, httpDomains, tlsDomain)
+			filter, err := b.buildMainHTTPConnectionManagerFilter(cfg.Options, tlsDomain)
 			if err != nil {
 				return nil, err
 			}
@@ -288,11 +284,60 @@ func (b *Builder) buildFilterChains(
 	return chains, nil
 }
 
-func (b *Builder) buildMainHTTPConnectionManagerFilter(
+// BuildRouteConfigurations builds the route configurations of the main listener. They are
+// served with RDS rather than inline in the listener, so that route changes update only the
+// route configurations, instead of replacing the listener and draining its connections.
+func (b *Builder) BuildRouteConfigurations(
+	ctx context.Context,
+	cfg *config.Config,
+) ([]*envoy_config_route_v3.RouteConfiguration, error) {
+	if !config.IsAuthenticate(cfg.Options.Services) && !config.IsProxy(cfg.Options.Services) {
+		return nil, nil
+	}
+
+	if cfg.Options.InsecureServer {
+		allDomains, err := getAllRouteableDomains(cfg.Options, cfg.Options.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		rc, err := b.buildMainRouteConfiguration(cfg.Options, allDomains, "")
+		if err != nil {
+			return nil, err
+		}
+		return []*envoy_config_route_v3.RouteConfiguration{rc}, nil
+	}
+
+	var rcs []*envoy_config_route_v3.RouteConfiguration
+	_, err := b.buildFilterChains(cfg.Options, cfg.Options.Addr,
+		func(tlsDomain string, httpDomains []string) (*envoy_config_listener_v3.FilterChain, error) {
+			rc, err := b.buildMainRouteConfiguration(cfg.Options, httpDomains, tlsDomain)
+			if err != nil {
+				return nil, err
+			}
+			rcs = append(rcs, rc)
+			return nil, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return rcs, nil
+}
+
+// getMainRouteConfigurationName returns the name of the route configuration of the filter chain
+// of the TLS domain.
+func getMainRouteConfigurationName(tlsDomain string) string {
+	if tlsDomain == "" || tlsDomain == "*" {
+		return "main"
+	}
+	return "main-" + tlsDomain
+}
+
+func (b *Builder) buildMainRouteConfiguration(
 	options *config.Options,
 	domains []string,
 	tlsDomain string,
-) (*envoy_config_listener_v3.Filter, error) {
+) (*envoy_config_route_v3.RouteConfiguration, error) {
 	authorizeURLs, err := options.GetInternalAuthorizeURLs()
 	if err != nil {
 		return nil, err
@@ -342,6 +387,13 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 	}
 	virtualHosts = append(virtualHosts, vh)
 
+	return b.buildRouteConfiguration(getMainRouteConfigurationName(tlsDomain), virtualHosts)
+}
+
+func (b *Builder) buildMainHTTPConnectionManagerFilter(
+	options *config.Options,
+	tlsDomain string,
+) (*envoy_config_listener_v3.Filter, error) {
 	var grpcClientTimeout *durationpb.Duration
 	if options.GRPCClientTimeout != 0 {
 		grpcClientTimeout = durationpb.New(options.GRPCClientTimeout)
@@ -365,6 +417,7 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 		},
 		IncludePeerCertificate: true,
 		TransportApiVersion:    envoy_config_core_v3.ApiVersion_V3,
+		WithRequestBody:        buildExtAuthzRequestBody(options),
 	})
 
 	extAuthzSetCookieLua := marshalAny(&envoy_extensions_filters_http_lua_v3.Lua{
@@ -380,6 +433,8 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 		InlineCode: luascripts.RewriteHeaders,
 	})
 
+	grpcWeb := hasGRPCWebPolicy(options)
+
 	filters := []*envoy_http_connection_manager.HttpFilter{
 		{
 			Name: "envoy.filters.http.lua",
@@ -387,6 +442,14 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 				TypedConfig: removeImpersonateHeadersLua,
 			},
 		},
+	}
+	if grpcWeb {
+		filters = append(filters, buildGRPCWebCORSFilter())
+	}
+	if hasLocalRateLimitPolicy(options) {
+		filters = append(filters, buildLocalRateLimitFilter())
+	}
+	filters = append(filters, []*envoy_http_connection_manager.HttpFilter{
 		{
 			Name: "envoy.filters.http.ext_authz",
 			ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
@@ -411,6 +474,12 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 				TypedConfig: rewriteHeadersLua,
 			},
 		},
+	}...)
+	if grpcWeb {
+		filters = append(filters, buildGRPCWebFilters()...)
+	}
+	if hasRequestBodyLimitPolicy(options) {
+		filters = append(filters, buildBufferFilter())
 	}
 	if tlsDomain != "" && tlsDomain != "*" {
 		fixMisdirectedLua := marshalAny(&envoy_extensions_filters_http_lua_v3.Lua{
@@ -432,11 +501,11 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 		maxStreamDuration = durationpb.New(options.WriteTimeout)
 	}
 
-	rc, err := b.buildRouteConfiguration("main", virtualHosts)
+	tracingProvider, err := buildTracingHTTP(options)
 	if err != nil {
 		return nil, err
 	}
-	tracingProvider, err := buildTracingHTTP(options)
+	localReplyConfig, err := b.buildLocalReplyConfig(options)
 	if err != nil {
 		return nil, err
 	}
@@ -445,8 +514,14 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 
 		CodecType:  options.GetCodecType().ToEnvoy(),
 		StatPrefix: "ingress",
-		RouteSpecifier: &envoy_http_connection_manager.HttpConnectionManager_RouteConfig{
-			RouteConfig: rc,
+		RouteSpecifier: &envoy_http_connection_manager.HttpConnectionManager_Rds{
+			Rds: &envoy_http_connection_manager.Rds{
+				ConfigSource: &envoy_config_core_v3.ConfigSource{
+					ResourceApiVersion:    envoy_config_core_v3.ApiVersion_V3,
+					ConfigSourceSpecifier: &envoy_config_core_v3.ConfigSource_Ads{},
+				},
+				RouteConfigName: getMainRouteConfigurationName(tlsDomain),
+			},
 		},
 		HttpFilters: filters,
 		AccessLog:   buildAccessLogs(options),
@@ -463,7 +538,7 @@ func (b *Builder) buildMainHTTPConnectionManagerFilter(
 		UseRemoteAddress:  &wrappers.BoolValue{Value: true},
 		SkipXffAppend:     options.SkipXffAppend,
 		XffNumTrustedHops: options.XffNumTrustedHops,
-		LocalReplyConfig:  b.buildLocalReplyConfig(options),
+		LocalReplyConfig:  localReplyConfig,
 	})
 
 	return &envoy_config_listener_v3.Filter{
@@ -629,6 +704,8 @@ func (b *Builder) buildRouteConfiguration(name string, virtualHosts []*envoy_con
 		VirtualHosts: virtualHosts,
 		// disable cluster validation since the order of LDS/CDS updates isn't guaranteed
 		ValidateClusters: &wrappers.BoolValue{Value: false},
+		// the headers of routes take precedence over the global headers of the virtual hosts
+		MostSpecificHeaderMutationsWins: true,
 	}, nil
 }
 
@@ -692,6 +769,11 @@ func (b *Builder) buildDownstreamValidationContext(ctx context.Context,
 			}
 		}
 	}
+	// sessions are bound to the client certificate presented when signing in
+	if !needsClientCert && cfg.Options.BindsSessionsToClientCertificates() {
+		authenticateURL, err := cfg.Options.GetInternalAuthenticateURL()
+		needsClientCert = err == nil && hostMatchesDomain(authenticateURL, domain)
+	}
 
 	if !needsClientCert {
 		return nil
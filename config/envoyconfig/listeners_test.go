@@ -56,6 +56,7 @@ func Test_buildMetricsHTTPConnectionManagerFilter(t *testing.T) {
 				"routeConfig": {
 					"name": "metrics",
 					"validateClusters": false,
+					"mostSpecificHeaderMutationsWins": true,
 					"virtualHosts": [{
 						"name": "metrics",
 						"domains": ["*"],
@@ -113,7 +114,7 @@ func Test_buildMainHTTPConnectionManagerFilter(t *testing.T) {
 	options := config.NewDefaultOptions()
 	options.SkipXffAppend = true
 	options.XffNumTrustedHops = 1
-	filter, err := b.buildMainHTTPConnectionManagerFilter(options, []string{"example.com"}, "*")
+	filter, err := b.buildMainHTTPConnectionManagerFilter(options, "*")
 	require.NoError(t, err)
 	testutil.AssertProtoJSONEqual(t, `{
 		"name": "envoy.filters.network.http_connection_manager",
@@ -190,293 +191,12 @@ func Test_buildMainHTTPConnectionManagerFilter(t *testing.T) {
 				}
 			],
 			"requestTimeout": "30s",
-			"routeConfig": {
-				"name": "main",
-				"virtualHosts": [
-					{
-						"name": "example.com",
-						"domains": ["example.com"],
-						"responseHeadersToAdd": [{
-							"append": false,
-							"header": {
-								"key": "Strict-Transport-Security",
-								"value": "max-age=31536000; includeSubDomains; preload"
-							}
-						},
-						{
-							"append": false,
-							"header": {
-								"key": "X-Frame-Options",
-								"value": "SAMEORIGIN"
-							}
-						},
-						{
-							"append": false,
-							"header": {
-								"key": "X-XSS-Protection",
-								"value": "1; mode=block"
-							}
-						}],
-						"routes": [
-							{
-								"name": "pomerium-path-/.pomerium/jwt",
-								"match": {
-									"path": "/.pomerium/jwt"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								}
-							},
-							{
-								"name": "pomerium-path-/ping",
-								"match": {
-									"path": "/ping"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/healthz",
-								"match": {
-									"path": "/healthz"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/.pomerium",
-								"match": {
-									"path": "/.pomerium"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-prefix-/.pomerium/",
-								"match": {
-									"prefix": "/.pomerium/"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/.well-known/pomerium",
-								"match": {
-									"path": "/.well-known/pomerium"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-prefix-/.well-known/pomerium/",
-								"match": {
-									"prefix": "/.well-known/pomerium/"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/robots.txt",
-								"match": {
-									"path": "/robots.txt"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							}
-						]
-					},
-					{
-						"name": "catch-all",
-						"domains": ["*"],
-						"responseHeadersToAdd": [{
-							"append": false,
-							"header": {
-								"key": "Strict-Transport-Security",
-								"value": "max-age=31536000; includeSubDomains; preload"
-							}
-						},
-						{
-							"append": false,
-							"header": {
-								"key": "X-Frame-Options",
-								"value": "SAMEORIGIN"
-							}
-						},
-						{
-							"append": false,
-							"header": {
-								"key": "X-XSS-Protection",
-								"value": "1; mode=block"
-							}
-						}],
-						"routes": [
-							{
-								"name": "pomerium-path-/.pomerium/jwt",
-								"match": {
-									"path": "/.pomerium/jwt"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								}
-							},
-							{
-								"name": "pomerium-path-/ping",
-								"match": {
-									"path": "/ping"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/healthz",
-								"match": {
-									"path": "/healthz"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/.pomerium",
-								"match": {
-									"path": "/.pomerium"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-prefix-/.pomerium/",
-								"match": {
-									"prefix": "/.pomerium/"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/.well-known/pomerium",
-								"match": {
-									"path": "/.well-known/pomerium"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-prefix-/.well-known/pomerium/",
-								"match": {
-									"prefix": "/.well-known/pomerium/"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							},
-							{
-								"name": "pomerium-path-/robots.txt",
-								"match": {
-									"path": "/robots.txt"
-								},
-								"route": {
-									"cluster": "pomerium-control-plane-http"
-								},
-								"typedPerFilterConfig": {
-									"envoy.filters.http.ext_authz": {
-										"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
-										"disabled": true
-									}
-								}
-							}
-						]
-					}
-				],
-				"validateClusters": false
+			"rds": {
+				"configSource": {
+					"ads": {},
+					"resourceApiVersion": "V3"
+				},
+				"routeConfigName": "main"
 			},
 			"statPrefix": "ingress",
 			"tracing": {
@@ -523,6 +243,229 @@ func Test_buildMainHTTPConnectionManagerFilter(t *testing.T) {
 	}`, filter)
 }
 
+func Test_BuildRouteConfigurations(t *testing.T) {
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+
+	pomeriumRoutes := `
+		{
+			"name": "pomerium-path-/.pomerium/jwt",
+			"match": {
+				"path": "/.pomerium/jwt"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			}
+		}, {
+			"name": "pomerium-path-/ping",
+			"match": {
+				"path": "/ping"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-path-/healthz",
+			"match": {
+				"path": "/healthz"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-path-/.pomerium",
+			"match": {
+				"path": "/.pomerium"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-prefix-/.pomerium/",
+			"match": {
+				"prefix": "/.pomerium/"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-path-/.well-known/pomerium",
+			"match": {
+				"path": "/.well-known/pomerium"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-prefix-/.well-known/pomerium/",
+			"match": {
+				"prefix": "/.well-known/pomerium/"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-path-/robots.txt",
+			"match": {
+				"path": "/robots.txt"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}`
+	authenticateRoutes := `
+		{
+			"name": "pomerium-path-/oauth2/callback",
+			"match": {
+				"path": "/oauth2/callback"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}, {
+			"name": "pomerium-path-/",
+			"match": {
+				"path": "/"
+			},
+			"route": {
+				"cluster": "pomerium-control-plane-http"
+			},
+			"typedPerFilterConfig": {
+				"envoy.filters.http.ext_authz": {
+					"@type": "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute",
+					"disabled": true
+				}
+			}
+		}`
+	virtualHost := func(domain string, routes string) string {
+		name := domain
+		if domain == "*" {
+			name = "catch-all"
+		}
+		return `{
+			"name": "` + name + `",
+			"domains": ["` + domain + `"],
+			"responseHeadersToAdd": [{
+				"append": false,
+				"header": {
+					"key": "Strict-Transport-Security",
+					"value": "max-age=31536000; includeSubDomains; preload"
+				}
+			},
+			{
+				"append": false,
+				"header": {
+					"key": "X-Frame-Options",
+					"value": "SAMEORIGIN"
+				}
+			},
+			{
+				"append": false,
+				"header": {
+					"key": "X-XSS-Protection",
+					"value": "1; mode=block"
+				}
+			}],
+			"routes": [` + routes + `]
+		}`
+	}
+	routeConfiguration := func(name string) string {
+		return `{
+			"name": "` + name + `",
+			"virtualHosts": [
+				` + virtualHost("authenticate.example.com", pomeriumRoutes+","+authenticateRoutes) + `,
+				` + virtualHost("authenticate.example.com:443", pomeriumRoutes+","+authenticateRoutes) + `,
+				` + virtualHost("*", pomeriumRoutes) + `
+			],
+			"validateClusters": false,
+			"mostSpecificHeaderMutationsWins": true
+		}`
+	}
+
+	newConfig := func() *config.Config {
+		options := config.NewDefaultOptions()
+		options.Services = "authenticate"
+		options.AuthenticateURLString = "https://authenticate.example.com"
+		options.Cert = aExampleComCert
+		options.Key = aExampleComKey
+		return &config.Config{Options: options}
+	}
+
+	t.Run("tls", func(t *testing.T) {
+		routeConfigurations, err := b.BuildRouteConfigurations(context.Background(), newConfig())
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `[
+			`+routeConfiguration("main-authenticate.example.com")+`,
+			`+routeConfiguration("main")+`
+		]`, routeConfigurations)
+	})
+	t.Run("insecure", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Options.InsecureServer = true
+		routeConfigurations, err := b.BuildRouteConfigurations(context.Background(), cfg)
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `[`+routeConfiguration("main")+`]`, routeConfigurations)
+	})
+	t.Run("authorize", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Options.Services = "authorize"
+		routeConfigurations, err := b.BuildRouteConfigurations(context.Background(), cfg)
+		require.NoError(t, err)
+		assert.Empty(t, routeConfigurations, "should not build route configurations without a main listener")
+	})
+
+	assert.Equal(t, "main", getMainRouteConfigurationName(""))
+	assert.Equal(t, "main", getMainRouteConfigurationName("*"))
+	assert.Equal(t, "main-example.com", getMainRouteConfigurationName("example.com"))
+}
+
 func Test_buildDownstreamTLSContext(t *testing.T) {
 	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
 
@@ -710,6 +653,21 @@ func Test_buildDownstreamTLSContext(t *testing.T) {
 			}
 		}`, downstreamTLSContext)
 	})
+	t.Run("bind session to client certificate", func(t *testing.T) {
+		cfg := &config.Config{Options: &config.Options{
+			AuthenticateURLString: "https://authenticate.example.com",
+			Policies: []config.Policy{{
+				From:                           "https://from.example.com",
+				To:                             mustParseWeightedURLs(t, "https://to.example.com"),
+				BindSessionToClientCertificate: true,
+			}},
+		}}
+
+		testutil.AssertProtoJSONEqual(t, `{
+			"trustChainVerification": "ACCEPT_UNTRUSTED"
+		}`, b.buildDownstreamValidationContext(context.Background(), cfg, "authenticate.example.com").ValidationContext)
+		assert.Nil(t, b.buildDownstreamValidationContext(context.Background(), cfg, "a.example.com"))
+	})
 }
 
 func Test_getAllDomains(t *testing.T) {
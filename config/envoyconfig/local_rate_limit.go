@@ -0,0 +1,72 @@
+package envoyconfig
+
+import (
+	"time"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_extensions_filters_http_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	envoy_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+const (
+	localRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+	localRateLimitStatPrefix = "local_rate_limit"
+)
+
+// hasLocalRateLimitPolicy returns true if any policy has a local rate limit applied by envoy. Rate
+// limits keyed by IP address or user are applied by authorize.
+func hasLocalRateLimitPolicy(options *config.Options) bool {
+	for _, p := range options.GetAllPolicies() {
+		if p.LocalRateLimit != nil && p.LocalRateLimit.KeyBy == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildLocalRateLimitFilter builds the local rate limit filter. It comes before ext_authz so
+// bursts are rejected before they're authorized. Without a token bucket the filter is disabled,
+// it's only enabled for the routes with a local rate limit.
+func buildLocalRateLimitFilter() *envoy_http_connection_manager.HttpFilter {
+	return &envoy_http_connection_manager.HttpFilter{
+		Name: localRateLimitFilterName,
+		ConfigType: &envoy_http_connection_manager.HttpFilter_TypedConfig{
+			TypedConfig: marshalAny(&envoy_extensions_filters_http_local_ratelimit_v3.LocalRateLimit{
+				StatPrefix: localRateLimitStatPrefix,
+			}),
+		},
+	}
+}
+
+func buildPolicyLocalRateLimitPerRoute(policy *config.Policy) *any.Any {
+	rl := policy.LocalRateLimit
+	if rl == nil || rl.KeyBy != "" {
+		return nil
+	}
+	return marshalAny(&envoy_extensions_filters_http_local_ratelimit_v3.LocalRateLimit{
+		StatPrefix: localRateLimitStatPrefix,
+		TokenBucket: &envoy_type_v3.TokenBucket{
+			MaxTokens:     rl.GetBurst(),
+			TokensPerFill: wrapperspb.UInt32(rl.RequestsPerSecond),
+			FillInterval:  durationpb.New(time.Second),
+		},
+		FilterEnabled:  buildLocalRateLimitFractionalPercent("local_rate_limit_enabled"),
+		FilterEnforced: buildLocalRateLimitFractionalPercent("local_rate_limit_enforced"),
+	})
+}
+
+func buildLocalRateLimitFractionalPercent(runtimeKey string) *envoy_config_core_v3.RuntimeFractionalPercent {
+	return &envoy_config_core_v3.RuntimeFractionalPercent{
+		DefaultValue: &envoy_type_v3.FractionalPercent{
+			Numerator:   100,
+			Denominator: envoy_type_v3.FractionalPercent_HUNDRED,
+		},
+		RuntimeKey: runtimeKey,
+	}
+}
@@ -0,0 +1,37 @@
+package envoyconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func Test_buildPolicyLocalRateLimitPerRoute(t *testing.T) {
+	assert.Nil(t, buildPolicyLocalRateLimitPerRoute(&config.Policy{}))
+	assert.Nil(t, buildPolicyLocalRateLimitPerRoute(&config.Policy{
+		LocalRateLimit: &config.PolicyLocalRateLimit{RequestsPerSecond: 10, KeyBy: config.LocalRateLimitKeyByIP},
+	}), "should leave keyed rate limits to authorize")
+
+	testutil.AssertProtoJSONEqual(t, `{
+		"@type": "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit",
+		"statPrefix": "local_rate_limit",
+		"tokenBucket": {
+			"maxTokens": 20,
+			"tokensPerFill": 10,
+			"fillInterval": "1s"
+		},
+		"filterEnabled": {
+			"defaultValue": { "numerator": 100 },
+			"runtimeKey": "local_rate_limit_enabled"
+		},
+		"filterEnforced": {
+			"defaultValue": { "numerator": 100 },
+			"runtimeKey": "local_rate_limit_enforced"
+		}
+	}`, buildPolicyLocalRateLimitPerRoute(&config.Policy{
+		LocalRateLimit: &config.PolicyLocalRateLimit{RequestsPerSecond: 10, Burst: 20},
+	}))
+}
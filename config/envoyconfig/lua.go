@@ -14,6 +14,8 @@ var luascripts struct {
 	RemoveImpersonateHeaders string
 	RewriteHeaders           string
 	FixMisdirected           string
+	GRPCWebDisable           string
+	GRPCWebRestore           string
 }
 
 func init() {
@@ -23,6 +25,8 @@ func init() {
 		"luascripts/remove-impersonate-headers.lua": &luascripts.RemoveImpersonateHeaders,
 		"luascripts/rewrite-headers.lua":            &luascripts.RewriteHeaders,
 		"luascripts/fix-misdirected.lua":            &luascripts.FixMisdirected,
+		"luascripts/grpc-web-disable.lua":           &luascripts.GRPCWebDisable,
+		"luascripts/grpc-web-restore.lua":           &luascripts.GRPCWebRestore,
 	}
 
 	err := fs.WalkDir(luaFS, "luascripts", func(p string, d fs.DirEntry, err error) error {
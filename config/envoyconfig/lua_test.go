@@ -96,6 +96,44 @@ func TestLuaFixMisdirected(t *testing.T) {
 		}, handle)
 		require.NoError(t, err)
 	})
+	t.Run("wildcard", func(t *testing.T) {
+		for _, tc := range []struct {
+			authority string
+			status    string
+		}{
+			{"app1.example.com", "404"},
+			{"example.com", "421"},
+			{"app1.example.org", "421"},
+		} {
+			L := lua.NewState()
+
+			bs, err := luaFS.ReadFile("luascripts/fix-misdirected.lua")
+			require.NoError(t, err)
+
+			err = L.DoString(fmt.Sprintf(string(bs), "*.example.com"))
+			require.NoError(t, err)
+
+			headers := map[string]string{
+				":status": "404",
+			}
+			metadata := map[string]interface{}{}
+			dynamicMetadata := map[string]map[string]interface{}{
+				"envoy.filters.http.lua": {
+					"request.authority": tc.authority,
+				},
+			}
+			handle := newLuaResponseHandle(L, headers, metadata, dynamicMetadata)
+
+			err = L.CallByParam(lua.P{
+				Fn:      L.GetGlobal("envoy_on_response"),
+				NRet:    0,
+				Protect: true,
+			}, handle)
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, headers[":status"], tc.authority)
+			L.Close()
+		}
+	})
 }
 
 func TestLuaRewriteHeaders(t *testing.T) {
@@ -137,6 +175,58 @@ func TestLuaRewriteHeaders(t *testing.T) {
 	assert.Equal(t, "https://frontend/one/some/uri/", headers["Location"])
 }
 
+func TestLuaGRPCWeb(t *testing.T) {
+	callOnRequest := func(t *testing.T, file string, headers map[string]string, metadata map[string]interface{}) {
+		L := lua.NewState()
+		defer L.Close()
+
+		bs, err := luaFS.ReadFile(file)
+		require.NoError(t, err)
+
+		err = L.DoString(string(bs))
+		require.NoError(t, err)
+
+		handle := newLuaResponseHandle(L, headers, metadata, nil)
+
+		err = L.CallByParam(lua.P{
+			Fn:      L.GetGlobal("envoy_on_request"),
+			NRet:    0,
+			Protect: true,
+		}, handle)
+		require.NoError(t, err)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		headers := map[string]string{
+			"content-type": "application/grpc-web+proto",
+		}
+		callOnRequest(t, "luascripts/grpc-web-disable.lua", headers, map[string]interface{}{})
+		assert.Equal(t, map[string]string{
+			"content-type":                     "application/x-pomerium-grpc-web-disabled",
+			"x-pomerium-grpc-web-content-type": "application/grpc-web+proto",
+		}, headers)
+
+		callOnRequest(t, "luascripts/grpc-web-restore.lua", headers, map[string]interface{}{})
+		assert.Equal(t, map[string]string{
+			"content-type": "application/grpc-web+proto",
+		}, headers)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		headers := map[string]string{
+			"content-type":                     "application/grpc-web+proto",
+			"x-pomerium-grpc-web-content-type": "text/plain",
+		}
+		metadata := map[string]interface{}{
+			"grpc_web": true,
+		}
+		callOnRequest(t, "luascripts/grpc-web-disable.lua", headers, metadata)
+		callOnRequest(t, "luascripts/grpc-web-restore.lua", headers, metadata)
+		assert.Equal(t, map[string]string{
+			"content-type": "application/grpc-web+proto",
+		}, headers)
+	})
+}
+
 func newLuaResponseHandle(L *lua.LState,
 	headers map[string]string,
 	metadata map[string]interface{},
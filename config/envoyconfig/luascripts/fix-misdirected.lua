@@ -8,6 +8,20 @@ function envoy_on_request(request_handle)
     dynamic_meta:set("envoy.filters.http.lua", "request.authority", authority)
 end
 
+local function authority_matches(authority, expected_authority)
+    if authority == expected_authority then
+        return true
+    end
+
+    -- wildcard domains match any of their subdomains
+    if authority ~= nil and string.sub(expected_authority, 1, 2) == "*." then
+        local suffix = string.sub(expected_authority, 2)
+        return #authority > #suffix and string.sub(authority, -#suffix) == suffix
+    end
+
+    return false
+end
+
 function envoy_on_response(response_handle)
     local headers = response_handle:headers()
     local dynamic_meta = response_handle:streamInfo():dynamicMetadata()
@@ -22,7 +36,7 @@ function envoy_on_response(response_handle)
 
     -- if we got a 404 (no route found) and the authority header doesn't match
     -- assume we've coalesced http/2 connections and return a 421
-    if headers:get(":status") == "404" and authority ~= expected_authority then
+    if headers:get(":status") == "404" and not authority_matches(authority, expected_authority) then
         headers:replace(":status", "421")
     end
 end
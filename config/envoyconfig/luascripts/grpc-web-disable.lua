@@ -0,0 +1,25 @@
+function has_prefix(str, prefix)
+    return str ~= nil and str:sub(1, #prefix) == prefix
+end
+
+function envoy_on_request(request_handle)
+    local headers = request_handle:headers()
+    local metadata = request_handle:metadata()
+
+    headers:remove("x-pomerium-grpc-web-content-type")
+
+    -- the grpc_web filter translates all gRPC-Web requests, so hide the content type from it
+    -- for routes which haven't enabled gRPC-Web. It's restored by grpc-web-restore.lua.
+    if metadata:get("grpc_web") then
+        return
+    end
+
+    local content_type = headers:get("content-type")
+    if has_prefix(content_type, "application/grpc-web") then
+        headers:replace("x-pomerium-grpc-web-content-type", content_type)
+        headers:replace("content-type", "application/x-pomerium-grpc-web-disabled")
+    end
+end
+
+function envoy_on_response(response_handle)
+end
@@ -0,0 +1,12 @@
+function envoy_on_request(request_handle)
+    local headers = request_handle:headers()
+
+    local content_type = headers:get("x-pomerium-grpc-web-content-type")
+    if content_type ~= nil then
+        headers:replace("content-type", content_type)
+        headers:remove("x-pomerium-grpc-web-content-type")
+    end
+end
+
+function envoy_on_response(response_handle)
+end
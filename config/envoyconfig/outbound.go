@@ -9,6 +9,7 @@ import (
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_http_connection_manager "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/pomerium/pomerium/config"
 )
@@ -83,8 +84,9 @@ func (b *Builder) buildOutboundRouteConfiguration() (*envoy_config_route_v3.Rout
 
 func (b *Builder) buildOutboundRoutes() []*envoy_config_route_v3.Route {
 	type Def struct {
-		Cluster  string
-		Prefixes []string
+		Cluster     string
+		Prefixes    []string
+		RetryPolicy *envoy_config_route_v3.RetryPolicy
 	}
 	defs := []Def{
 		{
@@ -100,6 +102,11 @@ func (b *Builder) buildOutboundRoutes() []*envoy_config_route_v3.Route {
 				"/directory.DirectoryService/",
 				"/registry.Registry/",
 			},
+			// retry requests which didn't reach a databroker on another one
+			RetryPolicy: &envoy_config_route_v3.RetryPolicy{
+				RetryOn:    "connect-failure,refused-stream,unavailable",
+				NumRetries: wrapperspb.UInt32(2),
+			},
 		},
 		{
 			Cluster: "pomerium-control-plane-grpc",
@@ -125,6 +132,7 @@ func (b *Builder) buildOutboundRoutes() []*envoy_config_route_v3.Route {
 						// disable the timeout to support grpc streaming
 						Timeout:     durationpb.New(0),
 						IdleTimeout: durationpb.New(0),
+						RetryPolicy: def.RetryPolicy,
 					},
 				},
 			})
@@ -103,5 +103,9 @@ func getUpstreamProtocolForPolicy(ctx context.Context, policy *config.Policy) up
 		log.Info(ctx).Msg("envoyconfig: forcing http/1.1 due to web socket support")
 		upstreamProtocol = upstreamProtocolHTTP1
 	}
+	if policy.GRPCWeb {
+		// gRPC requires HTTP/2
+		upstreamProtocol = upstreamProtocolHTTP2
+	}
 	return upstreamProtocol
 }
@@ -3,14 +3,17 @@ package envoyconfig
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"sort"
 
 	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoy_type_v3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -83,6 +86,12 @@ func (b *Builder) buildPomeriumHTTPRoutes(options *config.Options, domain string
 			b.buildControlPlanePathRoute(options.AuthenticateCallbackPath, false),
 			b.buildControlPlanePathRoute("/", false),
 		)
+		// sessions are bound to the client certificate presented when signing in
+		if options.BindsSessionsToClientCertificates() {
+			for _, route := range routes {
+				setClientCertificateHeader(route)
+			}
+		}
 	}
 	// if we're the proxy and this is the forward-auth url
 	forwardAuthURL, err := options.GetForwardAuthURL()
@@ -119,6 +128,19 @@ func (b *Builder) buildPomeriumHTTPRoutes(options *config.Options, domain string
 	return routes, nil
 }
 
+// setClientCertificateHeader passes the downstream client certificate to the control plane in a
+// header. The header is removed from the request first, so it can't be set by clients.
+func setClientCertificateHeader(route *envoy_config_route_v3.Route) {
+	route.RequestHeadersToRemove = append(route.RequestHeadersToRemove, httputil.HeaderPomeriumClientCertificate)
+	route.RequestHeadersToAdd = append(route.RequestHeadersToAdd, &envoy_config_core_v3.HeaderValueOption{
+		Header: &envoy_config_core_v3.HeaderValue{
+			Key:   httputil.HeaderPomeriumClientCertificate,
+			Value: "%DOWNSTREAM_PEER_CERT%",
+		},
+		Append: wrapperspb.Bool(false),
+	})
+}
+
 func (b *Builder) buildControlPlaneProtectedPrefixRoute(prefix string) (*envoy_config_route_v3.Route, error) {
 	return &envoy_config_route_v3.Route{
 		Name: "pomerium-protected-prefix-" + prefix,
@@ -219,6 +241,21 @@ var getClusterID = func(policy *config.Policy) string {
 	return fmt.Sprintf("%s-%x", prefix, id)
 }
 
+// PolicyClusterName returns the name of the envoy cluster of the policy.
+func PolicyClusterName(policy *config.Policy) string {
+	return getClusterID(policy)
+}
+
+// getUpstreamPolicy returns a copy of the policy sending requests to the given upstreams. It's
+// used for the clusters of the mirror upstreams and upstream groups.
+func getUpstreamPolicy(policy *config.Policy, to config.WeightedURLs) *config.Policy {
+	upstream := *policy
+	upstream.To = to
+	upstream.MirrorTo = nil
+	upstream.UpstreamGroups = nil
+	return &upstream
+}
+
 // getClusterStatsName returns human readable name that would be used by envoy to emit statistics, available as envoy_cluster_name label
 func getClusterStatsName(policy *config.Policy) string {
 	if policy.EnvoyOpts != nil && policy.EnvoyOpts.Name != "" {
@@ -227,6 +264,12 @@ func getClusterStatsName(policy *config.Policy) string {
 	return ""
 }
 
+// PolicyRouteName returns the name of the route of the policy with the given index in
+// GetAllPolicies.
+func PolicyRouteName(i int) string {
+	return fmt.Sprintf("policy-%d", i)
+}
+
 func (b *Builder) buildPolicyRoutes(options *config.Options, domain string) ([]*envoy_config_route_v3.Route, error) {
 	var routes []*envoy_config_route_v3.Route
 
@@ -238,14 +281,21 @@ func (b *Builder) buildPolicyRoutes(options *config.Options, domain string) ([]*
 
 		match := mkRouteMatch(&policy)
 		envoyRoute := &envoy_config_route_v3.Route{
-			Name:                   fmt.Sprintf("policy-%d", i),
-			Match:                  match,
-			Metadata:               &envoy_config_core_v3.Metadata{},
-			RequestHeadersToAdd:    toEnvoyHeaders(policy.SetRequestHeaders),
-			RequestHeadersToRemove: getRequestHeadersToRemove(options, &policy),
-			ResponseHeadersToAdd:   toEnvoyHeaders(policy.SetResponseHeaders),
-		}
-		if policy.Redirect != nil {
+			Name:                    PolicyRouteName(i),
+			Match:                   match,
+			Metadata:                &envoy_config_core_v3.Metadata{},
+			RequestHeadersToAdd:     toEnvoyHeaders(policy.GetStaticRequestHeaders()),
+			RequestHeadersToRemove:  getRequestHeadersToRemove(options, &policy),
+			ResponseHeadersToAdd:    getResponseHeadersToAdd(&policy),
+			ResponseHeadersToRemove: policy.RemoveResponseHeaders,
+		}
+		if policy.Response != nil {
+			envoyRoute.Action = &envoy_config_route_v3.Route_DirectResponse{
+				DirectResponse: buildPolicyRouteDirectResponseAction(policy.Response),
+			}
+			envoyRoute.ResponseHeadersToAdd = append(envoyRoute.ResponseHeadersToAdd,
+				toEnvoyHeaders(policy.Response.Headers)...)
+		} else if policy.Redirect != nil {
 			action, err := b.buildPolicyRouteRedirectAction(policy.Redirect)
 			if err != nil {
 				return nil, err
@@ -263,6 +313,14 @@ func (b *Builder) buildPolicyRoutes(options *config.Options, domain string) ([]*
 			"rewrite_response_headers": getRewriteHeadersMetadata(policy.RewriteResponseHeaders),
 		}
 
+		if policy.GRPCWeb {
+			luaMetadata["grpc_web"] = &structpb.Value{
+				Kind: &structpb.Value_BoolValue{
+					BoolValue: true,
+				},
+			}
+		}
+
 		// disable authentication entirely when the proxy is fronting authenticate
 		isFrontingAuthenticate, err := isProxyFrontingAuthenticate(options, domain)
 		if err != nil {
@@ -290,7 +348,28 @@ func (b *Builder) buildPolicyRoutes(options *config.Options, domain string) ([]*
 			}
 		}
 
-		if policy.IsForKubernetes() {
+		if bufferPerRoute := buildPolicyBufferPerRoute(&policy); bufferPerRoute != nil {
+			if envoyRoute.TypedPerFilterConfig == nil {
+				envoyRoute.TypedPerFilterConfig = make(map[string]*any.Any)
+			}
+			envoyRoute.TypedPerFilterConfig[bufferFilterName] = bufferPerRoute
+		}
+		if localRateLimitPerRoute := buildPolicyLocalRateLimitPerRoute(&policy); localRateLimitPerRoute != nil {
+			if envoyRoute.TypedPerFilterConfig == nil {
+				envoyRoute.TypedPerFilterConfig = make(map[string]*any.Any)
+			}
+			envoyRoute.TypedPerFilterConfig[localRateLimitFilterName] = localRateLimitPerRoute
+		}
+		if extAuthzPerRoute := buildPolicyExtAuthzPerRoute(&policy); extAuthzPerRoute != nil && !isFrontingAuthenticate {
+			if envoyRoute.TypedPerFilterConfig == nil {
+				envoyRoute.TypedPerFilterConfig = make(map[string]*any.Any)
+			}
+			envoyRoute.TypedPerFilterConfig["envoy.filters.http.ext_authz"] = extAuthzPerRoute
+		}
+
+		// kubernetes, udp and wildcard destination requests are re-proxied by the http control
+		// plane, which looks up the policy of the request with these headers
+		if isReproxiedPolicy(&policy) {
 			policyID, _ := policy.RouteID()
 			for _, hdr := range b.reproxy.GetPolicyIDHeaders(policyID) {
 				envoyRoute.RequestHeadersToAdd = append(envoyRoute.RequestHeadersToAdd,
@@ -303,12 +382,25 @@ func (b *Builder) buildPolicyRoutes(options *config.Options, domain string) ([]*
 					})
 			}
 		}
+		if policy.HasWildcardDestination() {
+			// the subdomain of the destination is captured from the host before it's rewritten
+			envoyRoute.RequestHeadersToAdd = append(envoyRoute.RequestHeadersToAdd,
+				&envoy_config_core_v3.HeaderValueOption{
+					Header: &envoy_config_core_v3.HeaderValue{
+						Key:   httputil.HeaderPomeriumReproxyHost,
+						Value: "%REQ(:authority)%",
+					},
+					Append: wrapperspb.Bool(false),
+				})
+		}
 
 		envoyRoute.Metadata.FilterMetadata = map[string]*structpb.Struct{
 			"envoy.filters.http.lua": {Fields: luaMetadata},
 		}
 
-		routes = append(routes, envoyRoute)
+		policyRoutes := append(buildPolicyUpstreamGroupOverrideRoutes(&policy, envoyRoute), envoyRoute)
+		routes = append(routes, buildPolicyWebsocketRoutes(&policy, policyRoutes)...)
+		routes = append(routes, policyRoutes...)
 	}
 	return routes, nil
 }
@@ -340,6 +432,18 @@ func (b *Builder) buildPolicyRouteRedirectAction(r *config.PolicyRedirect) (*env
 		action.PathRewriteSpecifier = &envoy_config_route_v3.RedirectAction_PrefixRewrite{
 			PrefixRewrite: *r.PrefixRewrite,
 		}
+	case r.RegexRewritePattern != nil:
+		action.PathRewriteSpecifier = &envoy_config_route_v3.RedirectAction_RegexRewrite{
+			RegexRewrite: &envoy_type_matcher_v3.RegexMatchAndSubstitute{
+				Pattern: &envoy_type_matcher_v3.RegexMatcher{
+					EngineType: &envoy_type_matcher_v3.RegexMatcher_GoogleRe2{
+						GoogleRe2: &envoy_type_matcher_v3.RegexMatcher_GoogleRE2{},
+					},
+					Regex: *r.RegexRewritePattern,
+				},
+				Substitution: r.GetRegexRewriteSubstitution(),
+			},
+		}
 	}
 	if r.ResponseCode != nil {
 		action.ResponseCode = envoy_config_route_v3.RedirectAction_RedirectResponseCode(*r.ResponseCode)
@@ -350,10 +454,25 @@ func (b *Builder) buildPolicyRouteRedirectAction(r *config.PolicyRedirect) (*env
 	return action, nil
 }
 
+func buildPolicyRouteDirectResponseAction(r *config.PolicyDirectResponse) *envoy_config_route_v3.DirectResponseAction {
+	action := &envoy_config_route_v3.DirectResponseAction{
+		Status: uint32(r.GetStatus()),
+	}
+	if r.Body != "" {
+		action.Body = &envoy_config_core_v3.DataSource{
+			Specifier: &envoy_config_core_v3.DataSource_InlineString{
+				InlineString: r.Body,
+			},
+		}
+	}
+	return action
+}
+
 func (b *Builder) buildPolicyRouteRouteAction(options *config.Options, policy *config.Policy) (*envoy_config_route_v3.RouteAction, error) {
 	clusterName := getClusterID(policy)
-	// kubernetes requests are sent to the http control plane to be reproxied
-	if policy.IsForKubernetes() {
+	// kubernetes, udp and wildcard destination requests are sent to the http control plane to
+	// be reproxied
+	if isReproxiedPolicy(policy) {
 		clusterName = httpCluster
 	}
 	routeTimeout := getRouteTimeout(options, policy)
@@ -376,6 +495,13 @@ func (b *Builder) buildPolicyRouteRouteAction(options *config.Options, policy *c
 			ConnectConfig: &envoy_config_route_v3.RouteAction_UpgradeConfig_ConnectConfig{},
 		})
 	}
+	if urlutil.IsUDP(policy.Source.URL) {
+		// the CONNECT request is forwarded to the http control plane, which relays the datagrams
+		upgradeConfigs = append(upgradeConfigs, &envoy_config_route_v3.RouteAction_UpgradeConfig{
+			UpgradeType: "CONNECT",
+			Enabled:     &wrappers.BoolValue{Value: true},
+		})
+	}
 	action := &envoy_config_route_v3.RouteAction{
 		ClusterSpecifier: &envoy_config_route_v3.RouteAction_Cluster{
 			Cluster: clusterName,
@@ -388,30 +514,237 @@ func (b *Builder) buildPolicyRouteRouteAction(options *config.Options, policy *c
 		IdleTimeout:   idleTimeout,
 		PrefixRewrite: prefixRewrite,
 		RegexRewrite:  regexRewrite,
-		HashPolicy: []*envoy_config_route_v3.RouteAction_HashPolicy{
-			// hash by the routing key, which is added by authorize.
-			{
-				PolicySpecifier: &envoy_config_route_v3.RouteAction_HashPolicy_Header_{
-					Header: &envoy_config_route_v3.RouteAction_HashPolicy_Header{
-						HeaderName: httputil.HeaderPomeriumRoutingKey,
+		Cors:          buildGRPCWebCORSPolicy(policy),
+
+		RequestMirrorPolicies: buildPolicyRouteRequestMirrorPolicies(policy),
+		RetryPolicy:           buildPolicyRouteRetryPolicy(policy),
+		HashPolicy:            buildPolicyRouteHashPolicy(policy),
+	}
+	setHostRewriteOptions(policy, action)
+	setUpstreamGroupWeightedClusters(policy, action)
+
+	return action, nil
+}
+
+func isReproxiedPolicy(policy *config.Policy) bool {
+	return policy.IsForKubernetes() || urlutil.IsUDP(policy.Source.URL) || policy.HasWildcardDestination()
+}
+
+// setUpstreamGroupWeightedClusters splits the requests of routes with upstream groups across the
+// cluster of the policy and the clusters of the groups.
+func setUpstreamGroupWeightedClusters(policy *config.Policy, action *envoy_config_route_v3.RouteAction) {
+	if len(policy.UpstreamGroups) == 0 || policy.IsForKubernetes() {
+		return
+	}
+
+	var clusters []*envoy_config_route_v3.WeightedCluster_ClusterWeight
+	if weight := policy.GetToWeight(); weight > 0 {
+		clusters = append(clusters, &envoy_config_route_v3.WeightedCluster_ClusterWeight{
+			Name:   action.GetCluster(),
+			Weight: wrapperspb.UInt32(weight),
+		})
+	}
+	for _, g := range policy.UpstreamGroups {
+		if g.Weight == 0 {
+			continue
+		}
+		clusters = append(clusters, &envoy_config_route_v3.WeightedCluster_ClusterWeight{
+			Name:   getClusterID(getUpstreamPolicy(policy, g.To)),
+			Weight: wrapperspb.UInt32(g.Weight),
+		})
+	}
+	action.ClusterSpecifier = &envoy_config_route_v3.RouteAction_WeightedClusters{
+		WeightedClusters: &envoy_config_route_v3.WeightedCluster{
+			Clusters:    clusters,
+			TotalWeight: wrapperspb.UInt32(100),
+		},
+	}
+}
+
+// buildPolicyUpstreamGroupOverrideRoutes builds the routes sending requests with the override
+// headers of an upstream group to the group. They must come before the route of the policy.
+func buildPolicyUpstreamGroupOverrideRoutes(policy *config.Policy, route *envoy_config_route_v3.Route) []*envoy_config_route_v3.Route {
+	action := route.GetRoute()
+	if action == nil || policy.IsForKubernetes() {
+		return nil
+	}
+
+	var routes []*envoy_config_route_v3.Route
+	for _, g := range policy.UpstreamGroups {
+		if len(g.OverrideHeaders) == 0 {
+			continue
+		}
+
+		overrideRoute := proto.Clone(route).(*envoy_config_route_v3.Route)
+		overrideRoute.Name = route.GetName() + "-" + g.Name
+		var names []string
+		for name := range g.OverrideHeaders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			overrideRoute.Match.Headers = append(overrideRoute.Match.Headers, &envoy_config_route_v3.HeaderMatcher{
+				Name: name,
+				HeaderMatchSpecifier: &envoy_config_route_v3.HeaderMatcher_StringMatch{
+					StringMatch: &envoy_type_matcher_v3.StringMatcher{
+						MatchPattern: &envoy_type_matcher_v3.StringMatcher_Exact{
+							Exact: g.OverrideHeaders[name],
+						},
 					},
 				},
-				Terminal: true,
-			},
-			// if the routing key is missing, hash by the ip.
-			{
-				PolicySpecifier: &envoy_config_route_v3.RouteAction_HashPolicy_ConnectionProperties_{
-					ConnectionProperties: &envoy_config_route_v3.RouteAction_HashPolicy_ConnectionProperties{
-						SourceIp: true,
+			})
+		}
+		overrideRoute.GetRoute().ClusterSpecifier = &envoy_config_route_v3.RouteAction_Cluster{
+			Cluster: getClusterID(getUpstreamPolicy(policy, g.To)),
+		}
+		routes = append(routes, overrideRoute)
+	}
+	return routes
+}
+
+// buildPolicyWebsocketRoutes builds the routes matching the websocket upgrade requests of
+// routes with websocket options, so websocket connections get their own timeouts.
+func buildPolicyWebsocketRoutes(policy *config.Policy, routes []*envoy_config_route_v3.Route) []*envoy_config_route_v3.Route {
+	if policy.Websocket == nil || !policy.AllowWebsockets {
+		return nil
+	}
+
+	var websocketRoutes []*envoy_config_route_v3.Route
+	for _, route := range routes {
+		if route.GetRoute() == nil {
+			continue
+		}
+
+		websocketRoute := proto.Clone(route).(*envoy_config_route_v3.Route)
+		websocketRoute.Name = route.GetName() + "-websocket"
+		websocketRoute.Match.Headers = append(websocketRoute.Match.Headers, &envoy_config_route_v3.HeaderMatcher{
+			Name: "upgrade",
+			HeaderMatchSpecifier: &envoy_config_route_v3.HeaderMatcher_StringMatch{
+				StringMatch: &envoy_type_matcher_v3.StringMatcher{
+					MatchPattern: &envoy_type_matcher_v3.StringMatcher_Exact{
+						Exact: "websocket",
 					},
+					IgnoreCase: true,
+				},
+			},
+		})
+
+		action := websocketRoute.GetRoute()
+		action.Timeout = durationpb.New(0)
+		action.IdleTimeout = durationpb.New(0)
+		if policy.Websocket.IdleTimeout != nil {
+			action.IdleTimeout = durationpb.New(*policy.Websocket.IdleTimeout)
+		}
+		if policy.Websocket.MaxConnectionDuration != nil {
+			action.MaxStreamDuration = &envoy_config_route_v3.RouteAction_MaxStreamDuration{
+				MaxStreamDuration: durationpb.New(*policy.Websocket.MaxConnectionDuration),
+			}
+		}
+		websocketRoutes = append(websocketRoutes, websocketRoute)
+	}
+	return websocketRoutes
+}
+
+func buildPolicyRouteRequestMirrorPolicies(policy *config.Policy) []*envoy_config_route_v3.RouteAction_RequestMirrorPolicy {
+	if len(policy.MirrorTo) == 0 {
+		return nil
+	}
+
+	mirrorPolicy := &envoy_config_route_v3.RouteAction_RequestMirrorPolicy{
+		Cluster: getClusterID(getUpstreamPolicy(policy, policy.MirrorTo)),
+	}
+	if policy.MirrorPercent != nil && *policy.MirrorPercent < 100 {
+		mirrorPolicy.RuntimeFraction = &envoy_config_core_v3.RuntimeFractionalPercent{
+			DefaultValue: &envoy_type_v3.FractionalPercent{
+				Numerator:   uint32(*policy.MirrorPercent * 10000),
+				Denominator: envoy_type_v3.FractionalPercent_MILLION,
+			},
+		}
+	}
+	return []*envoy_config_route_v3.RouteAction_RequestMirrorPolicy{mirrorPolicy}
+}
+
+func buildPolicyRouteHashPolicy(policy *config.Policy) []*envoy_config_route_v3.RouteAction_HashPolicy {
+	hashPolicy := []*envoy_config_route_v3.RouteAction_HashPolicy{
+		// hash by the routing key, which is added by authorize.
+		{
+			PolicySpecifier: &envoy_config_route_v3.RouteAction_HashPolicy_Header_{
+				Header: &envoy_config_route_v3.RouteAction_HashPolicy_Header{
+					HeaderName: httputil.HeaderPomeriumRoutingKey,
+				},
+			},
+			Terminal: true,
+		},
+		// if the routing key is missing, hash by the ip.
+		{
+			PolicySpecifier: &envoy_config_route_v3.RouteAction_HashPolicy_ConnectionProperties_{
+				ConnectionProperties: &envoy_config_route_v3.RouteAction_HashPolicy_ConnectionProperties{
+					SourceIp: true,
 				},
-				Terminal: true,
 			},
+			Terminal: true,
 		},
 	}
-	setHostRewriteOptions(policy, action)
 
-	return action, nil
+	sa := policy.SessionAffinity
+	if sa == nil || sa.GetHashOn() != config.SessionAffinityHashOnCookie {
+		return hashPolicy
+	}
+
+	// envoy generates the cookie when it's missing, a zero ttl generates a session cookie.
+	ttl := durationpb.New(0)
+	if sa.CookieTTL != nil {
+		ttl = durationpb.New(*sa.CookieTTL)
+	}
+	return []*envoy_config_route_v3.RouteAction_HashPolicy{{
+		PolicySpecifier: &envoy_config_route_v3.RouteAction_HashPolicy_Cookie_{
+			Cookie: &envoy_config_route_v3.RouteAction_HashPolicy_Cookie{
+				Name: sa.GetCookieName(),
+				Ttl:  ttl,
+				Path: sa.CookiePath,
+			},
+		},
+		Terminal: true,
+	}}
+}
+
+func buildPolicyRouteRetryPolicy(policy *config.Policy) *envoy_config_route_v3.RetryPolicy {
+	if policy.RetryPolicy == nil {
+		return nil
+	}
+
+	retryPolicy := &envoy_config_route_v3.RetryPolicy{
+		RetryOn:              policy.RetryPolicy.GetRetryOn(),
+		RetriableStatusCodes: policy.RetryPolicy.RetriableStatusCodes,
+	}
+	if policy.RetryPolicy.NumRetries != nil {
+		retryPolicy.NumRetries = wrapperspb.UInt32(*policy.RetryPolicy.NumRetries)
+	}
+	if policy.RetryPolicy.PerTryTimeout != nil {
+		retryPolicy.PerTryTimeout = durationpb.New(*policy.RetryPolicy.PerTryTimeout)
+	}
+	return retryPolicy
+}
+
+// getResponseHeadersToAdd returns the security_headers, set_response_headers and
+// append_response_headers of the policy which aren't templates. Templates are rendered by
+// authorize.
+func getResponseHeadersToAdd(policy *config.Policy) []*envoy_config_core_v3.HeaderValueOption {
+	set := make(map[string]string)
+	for name, value := range policy.SecurityHeaders.GetHeaders() {
+		set[http.CanonicalHeaderKey(name)] = value
+	}
+	// set_response_headers take precedence over the security headers
+	for name, value := range policy.GetStaticResponseHeaders() {
+		delete(set, http.CanonicalHeaderKey(name))
+		set[name] = value
+	}
+	headers := toEnvoyHeaders(set)
+	for _, h := range toEnvoyHeaders(policy.GetStaticAppendResponseHeaders()) {
+		h.Append = &wrappers.BoolValue{Value: true}
+		headers = append(headers, h)
+	}
+	return headers
 }
 
 func mkEnvoyHeader(k, v string) *envoy_config_core_v3.HeaderValueOption {
@@ -441,7 +774,7 @@ func toEnvoyHeaders(headers map[string]string) []*envoy_config_core_v3.HeaderVal
 func mkRouteMatch(policy *config.Policy) *envoy_config_route_v3.RouteMatch {
 	match := &envoy_config_route_v3.RouteMatch{}
 	switch {
-	case urlutil.IsTCP(policy.Source.URL):
+	case urlutil.IsTCP(policy.Source.URL), urlutil.IsUDP(policy.Source.URL):
 		match.PathSpecifier = &envoy_config_route_v3.RouteMatch_ConnectMatcher_{
 			ConnectMatcher: &envoy_config_route_v3.RouteMatch_ConnectMatcher{},
 		}
@@ -506,8 +839,10 @@ func getRouteIdleTimeout(policy *config.Policy) *durationpb.Duration {
 }
 
 func shouldDisableStreamIdleTimeout(policy *config.Policy) bool {
-	return policy.AllowWebsockets ||
+	// routes with websocket options have separate routes for websocket connections
+	return (policy.AllowWebsockets && policy.Websocket == nil) ||
 		urlutil.IsTCP(policy.Source.URL) ||
+		urlutil.IsUDP(policy.Source.URL) ||
 		policy.IsForKubernetes() // disable for kubernetes so that tailing logs works (#2182)
 }
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/httputil/reproxy"
 	"github.com/pomerium/pomerium/internal/testutil"
 )
 
@@ -110,6 +111,31 @@ func Test_buildPomeriumHTTPRoutes(t *testing.T) {
 		require.NoError(t, err)
 		testutil.AssertProtoJSONEqual(t, "null", routes)
 	})
+	t.Run("bind session to client certificate", func(t *testing.T) {
+		options := &config.Options{
+			Services:                 "all",
+			AuthenticateURLString:    "https://authenticate.example.com",
+			AuthenticateCallbackPath: "/oauth2/callback",
+			Policies: []config.Policy{{
+				From:                           "https://from.example.com",
+				To:                             mustParseWeightedURLs(t, "https://to.example.com"),
+				BindSessionToClientCertificate: true,
+			}},
+		}
+		routes, err := b.buildPomeriumHTTPRoutes(options, "authenticate.example.com")
+		require.NoError(t, err)
+		require.NotEmpty(t, routes)
+		for _, route := range routes {
+			testutil.AssertProtoJSONEqual(t, `[{
+				"append": false,
+				"header": {
+					"key": "x-pomerium-client-certificate",
+					"value": "%DOWNSTREAM_PEER_CERT%"
+				}
+			}]`, route.GetRequestHeadersToAdd(), route.GetName())
+			assert.Equal(t, []string{"x-pomerium-client-certificate"}, route.GetRequestHeadersToRemove(), route.GetName())
+		}
+	})
 
 	t.Run("with robots", func(t *testing.T) {
 		options := &config.Options{
@@ -1381,6 +1407,21 @@ func Test_buildPolicyRouteRedirectAction(t *testing.T) {
 			},
 		}, action)
 	})
+	t.Run("RegexRewrite", func(t *testing.T) {
+		action, err := b.buildPolicyRouteRedirectAction(&config.PolicyRedirect{
+			HostRedirect:             proto.String("new.example.com"),
+			RegexRewritePattern:      proto.String("^/old/(.*)$"),
+			RegexRewriteSubstitution: proto.String("/$1"),
+		})
+		require.NoError(t, err)
+		testutil.AssertProtoJSONEqual(t, `{
+			"hostRedirect": "new.example.com",
+			"regexRewrite": {
+				"pattern": { "googleRe2": {}, "regex": "^/old/(.*)$" },
+				"substitution": "/\\1"
+			}
+		}`, action)
+	})
 	t.Run("ResponseCode", func(t *testing.T) {
 		action, err := b.buildPolicyRouteRedirectAction(&config.PolicyRedirect{
 			ResponseCode: proto.Int32(301),
@@ -1401,6 +1442,291 @@ func Test_buildPolicyRouteRedirectAction(t *testing.T) {
 	})
 }
 
+func Test_buildPolicyRouteRequestMirrorPolicies(t *testing.T) {
+	defer func(f func(*config.Policy) string) {
+		getClusterID = f
+	}(getClusterID)
+	getClusterID = func(policy *config.Policy) string {
+		return "cluster-" + policy.To[0].URL.Host
+	}
+
+	assert.Nil(t, buildPolicyRouteRequestMirrorPolicies(&config.Policy{
+		To: mustParseWeightedURLs(t, "https://to.example.com"),
+	}))
+
+	testutil.AssertProtoJSONEqual(t, `[{
+		"cluster": "cluster-mirror.example.com"
+	}]`, buildPolicyRouteRequestMirrorPolicies(&config.Policy{
+		To:       mustParseWeightedURLs(t, "https://to.example.com"),
+		MirrorTo: mustParseWeightedURLs(t, "https://mirror.example.com"),
+	}))
+
+	mirrorPercent := 12.5
+	testutil.AssertProtoJSONEqual(t, `[{
+		"cluster": "cluster-mirror.example.com",
+		"runtimeFraction": {
+			"defaultValue": { "numerator": 125000, "denominator": "MILLION" }
+		}
+	}]`, buildPolicyRouteRequestMirrorPolicies(&config.Policy{
+		To:            mustParseWeightedURLs(t, "https://to.example.com"),
+		MirrorTo:      mustParseWeightedURLs(t, "https://mirror.example.com"),
+		MirrorPercent: &mirrorPercent,
+	}))
+}
+
+func Test_buildPolicyRouteHashPolicy(t *testing.T) {
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "header": { "headerName": "x-pomerium-routing-key" }, "terminal": true },
+		{ "connectionProperties": { "sourceIp": true }, "terminal": true }
+	]`, buildPolicyRouteHashPolicy(&config.Policy{
+		SessionAffinity: &config.PolicySessionAffinity{HashOn: config.SessionAffinityHashOnUser},
+	}))
+
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "cookie": { "name": "_pomerium_affinity", "ttl": "0s" }, "terminal": true }
+	]`, buildPolicyRouteHashPolicy(&config.Policy{
+		SessionAffinity: &config.PolicySessionAffinity{HashOn: config.SessionAffinityHashOnCookie},
+	}))
+
+	ttl := time.Hour
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "cookie": { "name": "affinity", "ttl": "3600s", "path": "/app" }, "terminal": true }
+	]`, buildPolicyRouteHashPolicy(&config.Policy{
+		SessionAffinity: &config.PolicySessionAffinity{
+			HashOn:     config.SessionAffinityHashOnCookie,
+			CookieName: "affinity",
+			CookieTTL:  &ttl,
+			CookiePath: "/app",
+		},
+	}))
+}
+
+func Test_buildPolicyRouteRetryPolicy(t *testing.T) {
+	assert.Nil(t, buildPolicyRouteRetryPolicy(&config.Policy{}))
+
+	testutil.AssertProtoJSONEqual(t, `{
+		"retryOn": "connect-failure,refused-stream"
+	}`, buildPolicyRouteRetryPolicy(&config.Policy{
+		RetryPolicy: &config.PolicyRetryPolicy{},
+	}))
+
+	numRetries := uint32(3)
+	perTryTimeout := time.Second * 2
+	testutil.AssertProtoJSONEqual(t, `{
+		"retryOn": "gateway-error,reset,retriable-status-codes",
+		"numRetries": 3,
+		"perTryTimeout": "2s",
+		"retriableStatusCodes": [409, 425]
+	}`, buildPolicyRouteRetryPolicy(&config.Policy{
+		RetryPolicy: &config.PolicyRetryPolicy{
+			RetryOn:              "gateway-error, reset",
+			NumRetries:           &numRetries,
+			PerTryTimeout:        &perTryTimeout,
+			RetriableStatusCodes: []uint32{409, 425},
+		},
+	}))
+}
+
+func Test_buildPolicyRoutesUpstreamGroups(t *testing.T) {
+	defer func(f func(*config.Policy) string) {
+		getClusterID = f
+	}(getClusterID)
+	getClusterID = func(policy *config.Policy) string {
+		return "cluster-" + policy.To[0].URL.Host
+	}
+
+	b := &Builder{filemgr: filemgr.NewManager()}
+	oneMinute := time.Minute
+	routes, err := b.buildPolicyRoutes(&config.Options{
+		DefaultUpstreamTimeout: time.Second * 3,
+		Policies: []config.Policy{{
+			Source:              &config.StringURL{URL: mustParseURL(t, "https://example.com")},
+			To:                  mustParseWeightedURLs(t, "https://stable.example.com"),
+			PassIdentityHeaders: true,
+			UpstreamTimeout:     &oneMinute,
+			UpstreamGroups: []config.PolicyUpstreamGroup{
+				{
+					Name:            "canary",
+					To:              mustParseWeightedURLs(t, "https://canary.example.com"),
+					Weight:          10,
+					OverrideHeaders: map[string]string{"x-canary": "always"},
+				},
+				{
+					Name: "staging",
+					To:   mustParseWeightedURLs(t, "https://staging.example.com"),
+				},
+			},
+		}},
+	}, "example.com")
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+
+	assert.Equal(t, "policy-0-canary", routes[0].GetName())
+	testutil.AssertProtoJSONEqual(t, `[{
+		"name": "x-canary",
+		"stringMatch": { "exact": "always" }
+	}]`, routes[0].GetMatch().GetHeaders())
+	assert.Equal(t, "cluster-canary.example.com", routes[0].GetRoute().GetCluster())
+	assert.Equal(t, routes[1].GetTypedPerFilterConfig(), routes[0].GetTypedPerFilterConfig())
+
+	assert.Equal(t, "policy-0", routes[1].GetName())
+	assert.Empty(t, routes[1].GetMatch().GetHeaders())
+	testutil.AssertProtoJSONEqual(t, `{
+		"clusters": [
+			{ "name": "cluster-stable.example.com", "weight": 90 },
+			{ "name": "cluster-canary.example.com", "weight": 10 }
+		],
+		"totalWeight": 100
+	}`, routes[1].GetRoute().GetWeightedClusters())
+}
+
+func Test_buildPolicyRoutesWebsocket(t *testing.T) {
+	b := &Builder{filemgr: filemgr.NewManager()}
+	oneMinute := time.Minute
+	oneHour := time.Hour
+	routes, err := b.buildPolicyRoutes(&config.Options{
+		DefaultUpstreamTimeout: time.Second * 3,
+		Policies: []config.Policy{{
+			Source:          &config.StringURL{URL: mustParseURL(t, "https://example.com")},
+			To:              mustParseWeightedURLs(t, "https://to.example.com"),
+			AllowWebsockets: true,
+			Websocket: &config.PolicyWebsocket{
+				IdleTimeout:           &oneMinute,
+				MaxConnectionDuration: &oneHour,
+			},
+		}},
+	}, "example.com")
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+
+	assert.Equal(t, "policy-0-websocket", routes[0].GetName())
+	testutil.AssertProtoJSONEqual(t, `[{
+		"name": "upgrade",
+		"stringMatch": { "exact": "websocket", "ignoreCase": true }
+	}]`, routes[0].GetMatch().GetHeaders())
+	testutil.AssertProtoJSONEqual(t, `"0s"`, routes[0].GetRoute().GetTimeout())
+	testutil.AssertProtoJSONEqual(t, `"60s"`, routes[0].GetRoute().GetIdleTimeout())
+	testutil.AssertProtoJSONEqual(t, `{
+		"maxStreamDuration": "3600s"
+	}`, routes[0].GetRoute().GetMaxStreamDuration())
+
+	assert.Equal(t, "policy-0", routes[1].GetName())
+	assert.Empty(t, routes[1].GetMatch().GetHeaders())
+	testutil.AssertProtoJSONEqual(t, `"3s"`, routes[1].GetRoute().GetTimeout())
+	assert.Nil(t, routes[1].GetRoute().GetIdleTimeout())
+	assert.Nil(t, routes[1].GetRoute().GetMaxStreamDuration())
+}
+
+func Test_buildPolicyRoutesUDP(t *testing.T) {
+	b := &Builder{filemgr: filemgr.NewManager(), reproxy: reproxy.New()}
+	routes, err := b.buildPolicyRoutes(&config.Options{
+		DefaultUpstreamTimeout: time.Second * 3,
+		Policies: []config.Policy{{
+			Source: &config.StringURL{URL: mustParseURL(t, "udp+https://dns.example.com:53")},
+			To:     mustParseWeightedURLs(t, "udp://10.0.0.1:53"),
+		}},
+	}, "dns.example.com:53")
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+
+	testutil.AssertProtoJSONEqual(t, `{
+		"connectMatcher": {}
+	}`, routes[0].GetMatch())
+	assert.Equal(t, httpCluster, routes[0].GetRoute().GetCluster())
+	testutil.AssertProtoJSONEqual(t, `"0s"`, routes[0].GetRoute().GetTimeout())
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "enabled": false, "upgradeType": "websocket" },
+		{ "enabled": false, "upgradeType": "spdy/3.1" },
+		{ "enabled": true, "upgradeType": "CONNECT" }
+	]`, routes[0].GetRoute().GetUpgradeConfigs())
+
+	var headers []string
+	for _, hdr := range routes[0].GetRequestHeadersToAdd() {
+		headers = append(headers, hdr.GetHeader().GetKey())
+	}
+	assert.Contains(t, headers, "x-pomerium-reproxy-policy")
+	assert.Contains(t, headers, "x-pomerium-reproxy-policy-hmac")
+}
+
+func Test_buildPolicyRoutesWildcard(t *testing.T) {
+	b := &Builder{filemgr: filemgr.NewManager(), reproxy: reproxy.New()}
+	routes, err := b.buildPolicyRoutes(&config.Options{
+		DefaultUpstreamTimeout: time.Second * 3,
+		Policies: []config.Policy{{
+			Source: &config.StringURL{URL: mustParseURL(t, "https://*.apps.example.com")},
+			To:     mustParseWeightedURLs(t, "http://*.apps.svc.cluster.local:8080"),
+		}},
+	}, "*.apps.example.com")
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+
+	assert.Equal(t, httpCluster, routes[0].GetRoute().GetCluster())
+
+	headers := map[string]string{}
+	for _, hdr := range routes[0].GetRequestHeadersToAdd() {
+		headers[hdr.GetHeader().GetKey()] = hdr.GetHeader().GetValue()
+	}
+	assert.Contains(t, headers, "x-pomerium-reproxy-policy")
+	assert.Contains(t, headers, "x-pomerium-reproxy-policy-hmac")
+	assert.Equal(t, "%REQ(:authority)%", headers["x-pomerium-reproxy-host"])
+}
+
+func Test_buildPolicyRoutesDirectResponse(t *testing.T) {
+	b := &Builder{filemgr: filemgr.NewManager()}
+	routes, err := b.buildPolicyRoutes(&config.Options{
+		Policies: []config.Policy{{
+			Source:                           &config.StringURL{URL: mustParseURL(t, "https://example.com")},
+			Path:                             "/robots.txt",
+			AllowPublicUnauthenticatedAccess: true,
+			Response: &config.PolicyDirectResponse{
+				Headers: map[string]string{"Content-Type": "text/plain"},
+				Body:    "User-agent: *\nDisallow: /\n",
+			},
+		}},
+	}, "example.com")
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+
+	assert.Nil(t, routes[0].GetRoute())
+	testutil.AssertProtoJSONEqual(t, `{
+		"status": 200,
+		"body": { "inlineString": "User-agent: *\nDisallow: /\n" }
+	}`, routes[0].GetDirectResponse())
+	testutil.AssertProtoJSONEqual(t, `[{
+		"header": { "key": "Content-Type", "value": "text/plain" },
+		"append": false
+	}]`, routes[0].GetResponseHeadersToAdd())
+
+	testutil.AssertProtoJSONEqual(t, `{
+		"status": 503
+	}`, buildPolicyRouteDirectResponseAction(&config.PolicyDirectResponse{Status: 503}))
+}
+
+func Test_getResponseHeadersToAdd(t *testing.T) {
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "header": { "key": "X-Frame-Options", "value": "DENY" }, "append": false },
+		{ "header": { "key": "Cache-Control", "value": "private" }, "append": true }
+	]`, getResponseHeadersToAdd(&config.Policy{
+		SetResponseHeaders: map[string]string{
+			"X-Frame-Options": "DENY",
+			"X-User":          "{{.email}}",
+		},
+		AppendResponseHeaders: map[string]string{
+			"Cache-Control": "private",
+		},
+	}))
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "header": { "key": "Referrer-Policy", "value": "strict-origin-when-cross-origin" }, "append": false },
+		{ "header": { "key": "Strict-Transport-Security", "value": "max-age=31536000" }, "append": false },
+		{ "header": { "key": "x-frame-options", "value": "DENY" }, "append": false }
+	]`, getResponseHeadersToAdd(&config.Policy{
+		SecurityHeaders: &config.PolicySecurityHeaders{Preset: config.SecurityHeadersPresetLegacy},
+		SetResponseHeaders: map[string]string{
+			"x-frame-options": "DENY",
+		},
+	}), "set_response_headers should take precedence over the security headers")
+}
+
 func TestPolicyName(t *testing.T) {
 	// policy names should form a unique ID when converted to envoy cluster names
 	// however for metrics purposes we keep original name if present
@@ -0,0 +1,114 @@
+package envoyconfig
+
+import (
+	"fmt"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+const spiffeWorkloadAPIClusterName = "pomerium-spiffe-workload-api"
+
+// buildSPIFFEWorkloadAPICluster builds the cluster for the SPIFFE Workload API. Envoy fetches the
+// SVIDs and trust bundles of routes from it via SDS, which also delivers their rotations.
+func buildSPIFFEWorkloadAPICluster(options *config.Options) (*envoy_config_cluster_v3.Cluster, error) {
+	if options.SPIFFEWorkloadAPIAddress == "" {
+		return nil, nil
+	}
+
+	network, address, err := config.ParseSPIFFEWorkloadAPIAddress(options.SPIFFEWorkloadAPIAddress)
+	if err != nil {
+		return nil, fmt.Errorf("envoyconfig: %w", err)
+	}
+
+	var addr *envoy_config_core_v3.Address
+	switch network {
+	case "unix":
+		addr = &envoy_config_core_v3.Address{
+			Address: &envoy_config_core_v3.Address_Pipe{
+				Pipe: &envoy_config_core_v3.Pipe{Path: address},
+			},
+		}
+	default:
+		addr, err = parseAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("envoyconfig: invalid spiffe workload api address: %w", err)
+		}
+	}
+
+	return &envoy_config_cluster_v3.Cluster{
+		Name: spiffeWorkloadAPIClusterName,
+		ConnectTimeout: &durationpb.Duration{
+			Seconds: 5,
+		},
+		ClusterDiscoveryType: &envoy_config_cluster_v3.Cluster_Type{Type: envoy_config_cluster_v3.Cluster_STATIC},
+		LoadAssignment: &envoy_config_endpoint_v3.ClusterLoadAssignment{
+			ClusterName: spiffeWorkloadAPIClusterName,
+			Endpoints: []*envoy_config_endpoint_v3.LocalityLbEndpoints{{
+				LbEndpoints: []*envoy_config_endpoint_v3.LbEndpoint{{
+					HostIdentifier: &envoy_config_endpoint_v3.LbEndpoint_Endpoint{
+						Endpoint: &envoy_config_endpoint_v3.Endpoint{
+							Address: addr,
+						},
+					},
+				}},
+			}},
+		},
+		TypedExtensionProtocolOptions: buildTypedExtensionProtocolOptions(nil, upstreamProtocolHTTP2),
+	}, nil
+}
+
+// buildSPIFFESdsSecretConfig builds the config of an SDS secret fetched from the SPIFFE Workload
+// API. SVIDs are named by their SPIFFE ID and trust bundles by the SPIFFE ID of their trust domain.
+func buildSPIFFESdsSecretConfig(name string) *envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig {
+	return &envoy_extensions_transport_sockets_tls_v3.SdsSecretConfig{
+		Name: name,
+		SdsConfig: &envoy_config_core_v3.ConfigSource{
+			ResourceApiVersion: envoy_config_core_v3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoy_config_core_v3.ConfigSource_ApiConfigSource{
+				ApiConfigSource: &envoy_config_core_v3.ApiConfigSource{
+					ApiType:             envoy_config_core_v3.ApiConfigSource_GRPC,
+					TransportApiVersion: envoy_config_core_v3.ApiVersion_V3,
+					GrpcServices: []*envoy_config_core_v3.GrpcService{{
+						TargetSpecifier: &envoy_config_core_v3.GrpcService_EnvoyGrpc_{
+							EnvoyGrpc: &envoy_config_core_v3.GrpcService_EnvoyGrpc{
+								ClusterName: spiffeWorkloadAPIClusterName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// buildSPIFFEValidationContext builds a validation context verifying the upstream presents an
+// SVID of the trust domain of the policy.
+func buildSPIFFEValidationContext(policy *config.Policy) *envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_CombinedValidationContext {
+	trustDomainID := "spiffe://" + policy.TLSUpstreamSPIFFETrustDomain
+	defaultValidationContext := &envoy_extensions_transport_sockets_tls_v3.CertificateValidationContext{
+		MatchTypedSubjectAltNames: []*envoy_extensions_transport_sockets_tls_v3.SubjectAltNameMatcher{{
+			SanType: envoy_extensions_transport_sockets_tls_v3.SubjectAltNameMatcher_URI,
+			Matcher: &envoy_type_matcher_v3.StringMatcher{
+				MatchPattern: &envoy_type_matcher_v3.StringMatcher_Prefix{
+					Prefix: trustDomainID + "/",
+				},
+			},
+		}},
+	}
+	if policy.TLSSkipVerify {
+		defaultValidationContext.TrustChainVerification = envoy_extensions_transport_sockets_tls_v3.CertificateValidationContext_ACCEPT_UNTRUSTED
+	}
+	return &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_CombinedValidationContext{
+		CombinedValidationContext: &envoy_extensions_transport_sockets_tls_v3.CommonTlsContext_CombinedCertificateValidationContext{
+			DefaultValidationContext:         defaultValidationContext,
+			ValidationContextSdsSecretConfig: buildSPIFFESdsSecretConfig(trustDomainID),
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package envoyconfig
+
+import (
+	"context"
+	"testing"
+
+	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func Test_buildSPIFFEWorkloadAPICluster(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		cluster, err := buildSPIFFEWorkloadAPICluster(&config.Options{})
+		assert.NoError(t, err)
+		assert.Nil(t, cluster)
+	})
+	t.Run("unix", func(t *testing.T) {
+		cluster, err := buildSPIFFEWorkloadAPICluster(&config.Options{
+			SPIFFEWorkloadAPIAddress: "unix:///run/spire/sockets/agent.sock",
+		})
+		require.NoError(t, err)
+		assert.NoError(t, cluster.Validate())
+		testutil.AssertProtoJSONEqual(t, `{
+			"clusterName": "pomerium-spiffe-workload-api",
+			"endpoints": [{
+				"lbEndpoints": [{
+					"endpoint": {
+						"address": {
+							"pipe": { "path": "/run/spire/sockets/agent.sock" }
+						}
+					}
+				}]
+			}]
+		}`, cluster.GetLoadAssignment())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := buildSPIFFEWorkloadAPICluster(&config.Options{
+			SPIFFEWorkloadAPIAddress: "/run/spire/sockets/agent.sock",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func Test_buildPolicyTransportSocketSPIFFE(t *testing.T) {
+	b := New("local-grpc", "local-http", "local-metrics", filemgr.NewManager(), nil)
+	ts, err := b.buildPolicyTransportSocket(context.Background(), config.NewDefaultOptions(), &config.Policy{
+		TLSUpstreamSPIFFEID:          "spiffe://example.org/pomerium",
+		TLSUpstreamSPIFFETrustDomain: "example.org",
+	}, *mustParseURL(t, "https://example.com"))
+	require.NoError(t, err)
+
+	var tlsContext envoy_extensions_transport_sockets_tls_v3.UpstreamTlsContext
+	require.NoError(t, ts.GetTypedConfig().UnmarshalTo(&tlsContext))
+
+	sdsConfig := `{
+		"resourceApiVersion": "V3",
+		"apiConfigSource": {
+			"apiType": "GRPC",
+			"transportApiVersion": "V3",
+			"grpcServices": [{
+				"envoyGrpc": { "clusterName": "pomerium-spiffe-workload-api" }
+			}]
+		}
+	}`
+	testutil.AssertProtoJSONEqual(t, `[{
+		"name": "spiffe://example.org/pomerium",
+		"sdsConfig": `+sdsConfig+`
+	}]`, tlsContext.GetCommonTlsContext().GetTlsCertificateSdsSecretConfigs())
+	testutil.AssertProtoJSONEqual(t, `{
+		"defaultValidationContext": {
+			"matchTypedSubjectAltNames": [{
+				"sanType": "URI",
+				"matcher": { "prefix": "spiffe://example.org/" }
+			}]
+		},
+		"validationContextSdsSecretConfig": {
+			"name": "spiffe://example.org",
+			"sdsConfig": `+sdsConfig+`
+		}
+	}`, tlsContext.GetCommonTlsContext().GetCombinedValidationContext())
+}
@@ -15,6 +15,37 @@ import (
 
 var oidMustStaple = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
 
+// the FIPS approved cipher suites and curves, which envoy is restricted to in FIPS mode
+var (
+	fipsCipherSuites = []string{
+		"ECDHE-ECDSA-AES256-GCM-SHA384",
+		"ECDHE-RSA-AES256-GCM-SHA384",
+		"ECDHE-ECDSA-AES128-GCM-SHA256",
+		"ECDHE-RSA-AES128-GCM-SHA256",
+	}
+	fipsEcdhCurves = []string{
+		"P-256",
+		"P-384",
+	}
+)
+
+// restrictTLSParams restricts the TLS parameters to TLS 1.2 or later and the FIPS approved
+// cipher suites and curves when FIPS mode is enabled.
+func restrictTLSParams(
+	params *envoy_extensions_transport_sockets_tls_v3.TlsParameters,
+	fipsEnabled bool,
+) *envoy_extensions_transport_sockets_tls_v3.TlsParameters {
+	if !fipsEnabled {
+		return params
+	}
+	return &envoy_extensions_transport_sockets_tls_v3.TlsParameters{
+		TlsMinimumProtocolVersion: envoy_extensions_transport_sockets_tls_v3.TlsParameters_TLSv1_2,
+		TlsMaximumProtocolVersion: params.GetTlsMaximumProtocolVersion(),
+		CipherSuites:              fipsCipherSuites,
+		EcdhCurves:                fipsEcdhCurves,
+	}
+}
+
 func (b *Builder) buildSubjectAltNameMatcher(
 	dst *url.URL,
 	overrideName string,
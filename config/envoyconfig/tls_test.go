@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"testing"
 
+	envoy_extensions_transport_sockets_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -56,3 +57,21 @@ func TestValidateCertificate(t *testing.T) {
 
 	assert.Error(t, validateCertificate(cert), "should return an error for a must-staple TLS certificate that has no stapled OCSP response")
 }
+
+func TestRestrictTLSParams(t *testing.T) {
+	params := &envoy_extensions_transport_sockets_tls_v3.TlsParameters{
+		CipherSuites: []string{"ECDHE-ECDSA-CHACHA20-POLY1305", "AES128-SHA"},
+		EcdhCurves:   []string{"X25519", "P-256"},
+	}
+	assert.Same(t, params, restrictTLSParams(params, false))
+	testutil.AssertProtoJSONEqual(t, `{
+		"tlsMinimumProtocolVersion": "TLSv1_2",
+		"cipherSuites": [
+			"ECDHE-ECDSA-AES256-GCM-SHA384",
+			"ECDHE-RSA-AES256-GCM-SHA384",
+			"ECDHE-ECDSA-AES128-GCM-SHA256",
+			"ECDHE-RSA-AES128-GCM-SHA256"
+		],
+		"ecdhCurves": ["P-256", "P-384"]
+	}`, restrictTLSParams(params, true))
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// DefaultErrorPageContentType is the content type of error pages which don't set one.
+const DefaultErrorPageContentType = "text/html; charset=UTF-8"
+
+// ErrorPageRouteIDMetadataKey is the key of the ext_authz dynamic metadata set to the route ID of
+// allowed requests to routes with error pages. Envoy matches it to use the error pages of the
+// route for the responses it generates itself.
+const ErrorPageRouteIDMetadataKey = "error_page_route_id"
+
+// PolicyErrorPage is a custom page returned instead of the default error page for responses with
+// one of the status codes.
+type PolicyErrorPage struct {
+	StatusCodes []int  `mapstructure:"status_codes" yaml:"status_codes" json:"status_codes"`
+	ContentType string `mapstructure:"content_type" yaml:"content_type,omitempty" json:"content_type,omitempty"`
+	// Body is a go template rendered with the status, the request ID and the deny reasons. It's
+	// escaped as HTML unless the content type isn't HTML, and as JSON for JSON content types.
+	Body string `mapstructure:"body" yaml:"body,omitempty" json:"body,omitempty"`
+
+	compiledBody bodyTemplate
+}
+
+// NewPolicyErrorPagesFromProto creates new PolicyErrorPages from protobuf messages.
+func NewPolicyErrorPagesFromProto(pbs []*configpb.RouteErrorPage) []PolicyErrorPage {
+	var pages []PolicyErrorPage
+	for _, pb := range pbs {
+		page := PolicyErrorPage{
+			ContentType: pb.GetContentType(),
+			Body:        pb.GetBody(),
+		}
+		for _, code := range pb.GetStatusCodes() {
+			page.StatusCodes = append(page.StatusCodes, int(code))
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// ToProto converts the error page to a protobuf message.
+func (ep *PolicyErrorPage) ToProto() *configpb.RouteErrorPage {
+	pb := &configpb.RouteErrorPage{
+		ContentType: ep.ContentType,
+		Body:        ep.Body,
+	}
+	for _, code := range ep.StatusCodes {
+		pb.StatusCodes = append(pb.StatusCodes, int32(code))
+	}
+	return pb
+}
+
+// Validate checks the validity of the error page and compiles the body template.
+func (ep *PolicyErrorPage) Validate() error {
+	if len(ep.StatusCodes) == 0 {
+		return fmt.Errorf("status_codes is required")
+	}
+	for _, code := range ep.StatusCodes {
+		if code < 400 || code > 599 {
+			return fmt.Errorf("invalid status code: %d", code)
+		}
+	}
+	tpl, err := compileBodyTemplate("error_page", ep.Body, ep.GetContentType())
+	if err != nil {
+		return fmt.Errorf("invalid body template: %w", err)
+	}
+	ep.compiledBody = tpl
+	return nil
+}
+
+// GetContentType returns the content type of the error page.
+func (ep *PolicyErrorPage) GetContentType() string {
+	if ep.ContentType == "" {
+		return DefaultErrorPageContentType
+	}
+	return ep.ContentType
+}
+
+// IsHTML returns true if the content type of the error page is HTML.
+func (ep *PolicyErrorPage) IsHTML() bool {
+	return isHTMLContentType(ep.GetContentType())
+}
+
+// IsJSON returns true if the content type of the error page is JSON.
+func (ep *PolicyErrorPage) IsJSON() bool {
+	return isJSONContentType(ep.GetContentType())
+}
+
+// RenderBody renders the body template using the given data. The error page must be validated
+// first.
+func (ep *PolicyErrorPage) RenderBody(data interface{}) (string, error) {
+	return renderBodyTemplate(ep.compiledBody, data)
+}
+
+// GetErrorPage returns the error page of the policy for the given status code, or nil if there
+// isn't one.
+func (p *Policy) GetErrorPage(code int) *PolicyErrorPage {
+	if p == nil {
+		return nil
+	}
+	for i := range p.ErrorPages {
+		for _, c := range p.ErrorPages[i].StatusCodes {
+			if c == code {
+				return &p.ErrorPages[i]
+			}
+		}
+	}
+	return nil
+}
+
+func validateErrorPages(pages []PolicyErrorPage) error {
+	seen := map[int]struct{}{}
+	for i := range pages {
+		if err := pages[i].Validate(); err != nil {
+			return err
+		}
+		for _, code := range pages[i].StatusCodes {
+			if _, ok := seen[code]; ok {
+				return fmt.Errorf("duplicate status code: %d", code)
+			}
+			seen[code] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultGitOpsRef is the branch routes are pulled from.
+const DefaultGitOpsRef = "main"
+
+// DefaultGitOpsPollInterval is how often the git repository of routes is polled for changes.
+const DefaultGitOpsPollInterval = time.Minute
+
+// GetGitOpsRef returns the branch or tag routes are pulled from.
+func (o *Options) GetGitOpsRef() string {
+	if o.GitOpsRef == "" {
+		return DefaultGitOpsRef
+	}
+	return o.GitOpsRef
+}
+
+// GetGitOpsPollInterval returns how often the git repository of routes is polled for changes.
+func (o *Options) GetGitOpsPollInterval() time.Duration {
+	if o.GitOpsPollInterval <= 0 {
+		return DefaultGitOpsPollInterval
+	}
+	return o.GitOpsPollInterval
+}
+
+func (o *Options) validateGitOps() error {
+	if o.GitOpsRepository == "" {
+		if o.GitOpsWebhookAddress != "" {
+			return fmt.Errorf("config: gitops_webhook_address requires gitops_repository")
+		}
+		return nil
+	}
+
+	if strings.HasPrefix(o.GitOpsRef, "-") {
+		return fmt.Errorf("config: invalid gitops_ref %s", o.GitOpsRef)
+	}
+	if p := path.Clean(o.GitOpsPath); path.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+		return fmt.Errorf("config: gitops_path must be a relative path within the repository")
+	}
+	if o.GitOpsPollInterval < 0 {
+		return fmt.Errorf("config: gitops_poll_interval must not be negative")
+	}
+	if o.GitOpsWebhookAddress != "" {
+		if o.GitOpsWebhookSecret == "" {
+			return fmt.Errorf("config: gitops_webhook_address requires gitops_webhook_secret")
+		}
+		if _, _, err := net.SplitHostPort(o.GitOpsWebhookAddress); err != nil {
+			return fmt.Errorf("config: bad gitops_webhook_address %s: %w", o.GitOpsWebhookAddress, err)
+		}
+	}
+	return nil
+}
+
+// ReadPoliciesFromPath reads the routes and policies of a config file, or a directory of config
+// files, which only defines routes. Each policy is validated.
+func ReadPoliciesFromPath(configPath string) ([]Policy, error) {
+	v := viper.New()
+	if isConfigDir(configPath) {
+		if err := readConfigDir(v, configPath); err != nil {
+			return nil, err
+		}
+	} else {
+		v.SetConfigFile(configPath)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	o := &Options{viper: v}
+	if err := o.parsePolicy(); err != nil {
+		return nil, err
+	}
+	return append(o.Policies, o.Routes...), nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_validateGitOps(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		options Options
+		err     bool
+	}{
+		{"disabled", Options{}, false},
+		{"repository", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsPath: "routes"}, false},
+		{"webhook", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsWebhookAddress: ":9443", GitOpsWebhookSecret: "secret"}, false},
+		{"webhook without repository", Options{GitOpsWebhookAddress: ":9443", GitOpsWebhookSecret: "secret"}, true},
+		{"webhook without secret", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsWebhookAddress: ":9443"}, true},
+		{"bad webhook address", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsWebhookAddress: "9443", GitOpsWebhookSecret: "secret"}, true},
+		{"bad ref", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsRef: "--upload-pack=x"}, true},
+		{"absolute path", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsPath: "/etc"}, true},
+		{"parent path", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsPath: "routes/../.."}, true},
+		{"negative poll interval", Options{GitOpsRepository: "https://git.example.com/routes.git", GitOpsPollInterval: -1}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.options.validateGitOps()
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadPoliciesFromPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"),
+		[]byte("routes:\n- from: https://a.example.com\n  to: https://a.internal\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"),
+		[]byte("policy:\n- from: https://b.example.com\n  to: https://b.internal\n"), 0o600))
+
+	policies, err := ReadPoliciesFromPath(dir)
+	require.NoError(t, err)
+	var froms []string
+	for _, p := range policies {
+		froms = append(froms, p.From)
+	}
+	assert.ElementsMatch(t, []string{"https://a.example.com", "https://b.example.com"}, froms)
+
+	policies, err = ReadPoliciesFromPath(filepath.Join(dir, "a.yaml"))
+	require.NoError(t, err)
+	assert.Len(t, policies, 1)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.yaml"),
+		[]byte("routes:\n- from: https://c.example.com\n"), 0o600))
+	_, err = ReadPoliciesFromPath(dir)
+	assert.Error(t, err, "routes without a destination should be rejected")
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/pomerium/pomerium/internal/identity/claimsmap"
+)
+
+// IsHeaderTemplate returns true if the header value is a template.
+func IsHeaderTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func compileHeaderTemplates(headers map[string]string) (map[string]*template.Template, error) {
+	var templates map[string]*template.Template
+	for name, value := range headers {
+		if !IsHeaderTemplate(value) {
+			continue
+		}
+		tpl, err := template.New(name).
+			Option("missingkey=error").
+			Funcs(claimsmap.TemplateFuncs).
+			Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for %s: %w", name, err)
+		}
+		if templates == nil {
+			templates = make(map[string]*template.Template)
+		}
+		templates[name] = tpl
+	}
+	return templates, nil
+}
+
+func getStaticHeaders(headers map[string]string) map[string]string {
+	var static map[string]string
+	for name, value := range headers {
+		if IsHeaderTemplate(value) {
+			continue
+		}
+		if static == nil {
+			static = make(map[string]string)
+		}
+		static[name] = value
+	}
+	return static
+}
+
+func hasHeaderTemplates(headers map[string]string) bool {
+	for _, value := range headers {
+		if IsHeaderTemplate(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderHeaderTemplates renders the header templates using the given data. Headers whose
+// templates fail to render, for example because they reference a claim the user doesn't have,
+// aren't set and the errors are returned with the other headers.
+func renderHeaderTemplates(
+	templates map[string]*template.Template,
+	headers map[string]string,
+	data interface{},
+) (map[string]string, error) {
+	if templates == nil {
+		var err error
+		templates, err = compileHeaderTemplates(headers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	rendered := make(map[string]string, len(templates))
+	for name, tpl := range templates {
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error rendering template for %s: %w", name, err))
+			continue
+		}
+		rendered[name] = buf.String()
+	}
+	return rendered, errs
+}
+
+// GetStaticRequestHeaders returns the set_request_headers which aren't templates. These are
+// added to requests by envoy.
+func (p *Policy) GetStaticRequestHeaders() map[string]string {
+	return getStaticHeaders(p.SetRequestHeaders)
+}
+
+// HasRequestHeaderTemplates returns true if any of the set_request_headers are templates.
+func (p *Policy) HasRequestHeaderTemplates() bool {
+	return hasHeaderTemplates(p.SetRequestHeaders)
+}
+
+// RenderRequestHeaderTemplates renders the set_request_headers which are templates using the
+// given data. Headers whose templates fail to render, for example because they reference a
+// claim the user doesn't have, aren't set and the errors are returned with the other headers.
+func (p *Policy) RenderRequestHeaderTemplates(data interface{}) (map[string]string, error) {
+	return renderHeaderTemplates(p.compiledRequestHeaderTemplates, p.SetRequestHeaders, data)
+}
+
+// GetStaticResponseHeaders returns the set_response_headers which aren't templates. These are
+// added to responses by envoy.
+func (p *Policy) GetStaticResponseHeaders() map[string]string {
+	return getStaticHeaders(p.SetResponseHeaders)
+}
+
+// GetStaticAppendResponseHeaders returns the append_response_headers which aren't templates.
+// These are added to responses by envoy.
+func (p *Policy) GetStaticAppendResponseHeaders() map[string]string {
+	return getStaticHeaders(p.AppendResponseHeaders)
+}
+
+// HasResponseHeaderTemplates returns true if any of the set_response_headers or
+// append_response_headers are templates.
+func (p *Policy) HasResponseHeaderTemplates() bool {
+	return hasHeaderTemplates(p.SetResponseHeaders) || hasHeaderTemplates(p.AppendResponseHeaders)
+}
+
+// RenderResponseHeaderTemplates renders the set_response_headers and append_response_headers
+// which are templates using the given data, like RenderRequestHeaderTemplates.
+func (p *Policy) RenderResponseHeaderTemplates(data interface{}) (set, appended map[string]string, err error) {
+	var errs error
+	set, err = renderHeaderTemplates(p.compiledResponseHeaderTemplates, p.SetResponseHeaders, data)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	appended, err = renderHeaderTemplates(p.compiledAppendResponseHeaderTemplates, p.AppendResponseHeaders, data)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return set, appended, errs
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_RequestHeaderTemplates(t *testing.T) {
+	p := &Policy{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		SetRequestHeaders: map[string]string{
+			"X-Static":        "static",
+			"X-Remote-User":   "{{.email}}",
+			"X-Remote-Groups": `{{.groups | join ","}}`,
+		},
+	}
+	require.NoError(t, p.Validate())
+
+	assert.True(t, p.HasRequestHeaderTemplates())
+	assert.Equal(t, map[string]string{"X-Static": "static"}, p.GetStaticRequestHeaders())
+
+	headers, err := p.RenderRequestHeaderTemplates(map[string]interface{}{
+		"email":  "user@example.com",
+		"groups": []interface{}{"admins", "developers"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"X-Remote-User":   "user@example.com",
+		"X-Remote-Groups": "admins,developers",
+	}, headers)
+
+	p.SetRequestHeaders["X-Invalid"] = "{{.email"
+	assert.Error(t, p.Validate())
+}
+
+func TestPolicy_ResponseHeaderTemplates(t *testing.T) {
+	p := &Policy{
+		From: "https://from.example.com",
+		To:   mustParseWeightedURLs(t, "https://to.example.com"),
+		SetResponseHeaders: map[string]string{
+			"X-Static": "static",
+			"X-User":   "{{.email}}",
+		},
+		AppendResponseHeaders: map[string]string{
+			"Cache-Control": "private",
+			"X-Route":       "{{.route.name}}",
+		},
+	}
+	require.NoError(t, p.Validate())
+
+	assert.True(t, p.HasResponseHeaderTemplates())
+	assert.Equal(t, map[string]string{"X-Static": "static"}, p.GetStaticResponseHeaders())
+	assert.Equal(t, map[string]string{"Cache-Control": "private"}, p.GetStaticAppendResponseHeaders())
+
+	set, appended, err := p.RenderResponseHeaderTemplates(map[string]interface{}{
+		"email": "user@example.com",
+		"route": map[string]interface{}{"name": "app"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"X-User": "user@example.com"}, set)
+	assert.Equal(t, map[string]string{"X-Route": "app"}, appended)
+
+	p.AppendResponseHeaders["X-Invalid"] = "{{.email"
+	assert.Error(t, p.Validate())
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// DefaultHMACSignatureHeader is the header of the HMAC signature of requests to upstreams.
+const DefaultHMACSignatureHeader = "X-Pomerium-Signature"
+
+// PolicyHMACSigning configures the HMAC signing of the requests to the upstreams of a route, so
+// upstreams which can't verify the X-Pomerium-Jwt-Assertion JWT can verify requests came
+// through pomerium and weren't replayed.
+type PolicyHMACSigning struct {
+	// Secret is the base64 encoded key requests are signed with.
+	Secret string `mapstructure:"secret" yaml:"secret,omitempty" json:"secret,omitempty"`
+	// Header is the header of the signature, which defaults to X-Pomerium-Signature.
+	Header string `mapstructure:"header" yaml:"header,omitempty" json:"header,omitempty"`
+}
+
+// NewPolicyHMACSigningFromProto creates a new PolicyHMACSigning from a protobuf message.
+func NewPolicyHMACSigningFromProto(pb *configpb.RouteHMACSigning) *PolicyHMACSigning {
+	if pb == nil {
+		return nil
+	}
+	return &PolicyHMACSigning{
+		Secret: pb.GetSecret(),
+		Header: pb.GetHeader(),
+	}
+}
+
+// ToProto converts the HMAC signing to a protobuf message.
+func (s *PolicyHMACSigning) ToProto() *configpb.RouteHMACSigning {
+	if s == nil {
+		return nil
+	}
+	return &configpb.RouteHMACSigning{
+		Secret: s.Secret,
+		Header: s.Header,
+	}
+}
+
+// Validate checks the validity of the HMAC signing.
+func (s *PolicyHMACSigning) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Secret == "" {
+		return fmt.Errorf("secret is required")
+	}
+	if _, err := s.GetSecret(); err != nil {
+		return fmt.Errorf("invalid secret: %w", err)
+	}
+	return nil
+}
+
+// GetSecret returns the decoded secret.
+func (s *PolicyHMACSigning) GetSecret() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(s.Secret)
+}
+
+// GetHeader returns the header of the signature.
+func (s *PolicyHMACSigning) GetHeader() string {
+	if s.Header == "" {
+		return DefaultHMACSignatureHeader
+	}
+	return s.Header
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyHMACSigning(t *testing.T) {
+	t.Parallel()
+
+	t.Run("proto", func(t *testing.T) {
+		s := &PolicyHMACSigning{Secret: "c2VjcmV0", Header: "X-Signature"}
+		assert.Equal(t, s, NewPolicyHMACSigningFromProto(s.ToProto()))
+	})
+	t.Run("validate", func(t *testing.T) {
+		assert.EqualError(t, (&PolicyHMACSigning{}).Validate(), "secret is required")
+		assert.Error(t, (&PolicyHMACSigning{Secret: "not base64!"}).Validate())
+		assert.NoError(t, (&PolicyHMACSigning{Secret: "c2VjcmV0"}).Validate())
+	})
+	t.Run("header", func(t *testing.T) {
+		assert.Equal(t, DefaultHMACSignatureHeader, (&PolicyHMACSigning{}).GetHeader())
+		assert.Equal(t, "X-Signature", (&PolicyHMACSigning{Header: "X-Signature"}).GetHeader())
+	})
+}
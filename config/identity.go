@@ -1,12 +1,133 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/pomerium/pomerium/internal/identity/claimsmap"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
 	"github.com/pomerium/pomerium/pkg/grpc/identity"
 )
 
+var identityProviderNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+// An IdentityProvider is an additional identity provider which can be selected by routes
+// using its name.
+type IdentityProvider struct {
+	Provider       string            `mapstructure:"provider" yaml:"provider,omitempty"`
+	ProviderURL    string            `mapstructure:"provider_url" yaml:"provider_url,omitempty"`
+	ClientID       string            `mapstructure:"client_id" yaml:"client_id,omitempty"`
+	ClientSecret   string            `mapstructure:"client_secret" yaml:"client_secret,omitempty"`
+	Scopes         []string          `mapstructure:"scopes" yaml:"scopes,omitempty"`
+	ServiceAccount string            `mapstructure:"service_account" yaml:"service_account,omitempty"`
+	RequestParams  map[string]string `mapstructure:"request_params" yaml:"request_params,omitempty"`
+}
+
+// NewIdentityProviderFromProto creates a new IdentityProvider from a protobuf message.
+func NewIdentityProviderFromProto(pb *configpb.Settings_IdentityProvider) IdentityProvider {
+	return IdentityProvider{
+		Provider:       pb.GetProvider(),
+		ProviderURL:    pb.GetProviderUrl(),
+		ClientID:       pb.GetClientId(),
+		ClientSecret:   pb.GetClientSecret(),
+		Scopes:         pb.GetScopes(),
+		ServiceAccount: pb.GetServiceAccount(),
+		RequestParams:  pb.GetRequestParams(),
+	}
+}
+
+// ToProto converts the IdentityProvider to a protobuf message.
+func (idp IdentityProvider) ToProto() *configpb.Settings_IdentityProvider {
+	return &configpb.Settings_IdentityProvider{
+		Provider:       idp.Provider,
+		ProviderUrl:    idp.ProviderURL,
+		ClientId:       idp.ClientID,
+		ClientSecret:   idp.ClientSecret,
+		Scopes:         idp.Scopes,
+		ServiceAccount: idp.ServiceAccount,
+		RequestParams:  idp.RequestParams,
+	}
+}
+
+// A ClaimMapping sets a claim using the values of other claims or a template, so that identity
+// provider claims can be renamed, combined and normalized.
+type ClaimMapping struct {
+	// Claim is the name of the claim to set.
+	Claim string `mapstructure:"claim" yaml:"claim"`
+	// From are the claims whose values are used. If neither From nor Template is set, the
+	// claim's own value is used.
+	From []string `mapstructure:"from" yaml:"from,omitempty"`
+	// Template is a Go text template executed with the claims.
+	Template string `mapstructure:"template" yaml:"template,omitempty"`
+	// Split splits string values using the separator.
+	Split string `mapstructure:"split" yaml:"split,omitempty"`
+	// Lowercase converts string values to lowercase.
+	Lowercase bool `mapstructure:"lowercase" yaml:"lowercase,omitempty"`
+	// Rename removes the From claims.
+	Rename bool `mapstructure:"rename" yaml:"rename,omitempty"`
+}
+
+// NewClaimMappingFromProto creates a new ClaimMapping from a protobuf message.
+func NewClaimMappingFromProto(pb *configpb.Settings_ClaimMapping) ClaimMapping {
+	return ClaimMapping{
+		Claim:     pb.GetClaim(),
+		From:      pb.GetFrom(),
+		Template:  pb.GetTemplate(),
+		Split:     pb.GetSplit(),
+		Lowercase: pb.GetLowercase(),
+		Rename:    pb.GetRename(),
+	}
+}
+
+// ToProto converts the ClaimMapping to a protobuf message.
+func (cm ClaimMapping) ToProto() *configpb.Settings_ClaimMapping {
+	return &configpb.Settings_ClaimMapping{
+		Claim:     cm.Claim,
+		From:      cm.From,
+		Template:  cm.Template,
+		Split:     cm.Split,
+		Lowercase: cm.Lowercase,
+		Rename:    cm.Rename,
+	}
+}
+
+// GetClaimsMapping returns the claims mapping setting as identity protobuf messages.
+func (o *Options) GetClaimsMapping() []*identity.ClaimMapping {
+	if len(o.ClaimsMapping) == 0 {
+		return nil
+	}
+	mappings := make([]*identity.ClaimMapping, len(o.ClaimsMapping))
+	for i, cm := range o.ClaimsMapping {
+		mappings[i] = &identity.ClaimMapping{
+			Claim:     cm.Claim,
+			From:      cm.From,
+			Template:  cm.Template,
+			Split:     cm.Split,
+			Lowercase: cm.Lowercase,
+			Rename:    cm.Rename,
+		}
+	}
+	return mappings
+}
+
+func (o *Options) validateClaimsMapping() error {
+	if _, err := claimsmap.New(o.GetClaimsMapping()); err != nil {
+		return fmt.Errorf("config: invalid claims_mapping: %w", err)
+	}
+	return nil
+}
+
 // GetIdentityProviderForID returns the identity provider associated with the given IDP id.
 // If none is found the default provider is returned.
 func (o *Options) GetIdentityProviderForID(idpID string) *identity.Provider {
+	for _, name := range o.GetIdentityProviderNames() {
+		idp, _ := o.GetIdentityProviderForName(name)
+		if idp.GetId() == idpID {
+			return idp
+		}
+	}
+
 	for _, policy := range o.GetAllPolicies() {
 		idp := o.GetIdentityProviderForPolicy(&policy) //nolint
 		if idp.GetId() == idpID {
@@ -17,9 +138,76 @@ func (o *Options) GetIdentityProviderForID(idpID string) *identity.Provider {
 	return o.GetIdentityProviderForPolicy(nil)
 }
 
+// GetAllIdentityProviders returns the default identity provider, followed by the identity
+// providers used by routes and the named identity providers. Each provider is only returned once.
+func (o *Options) GetAllIdentityProviders() []*identity.Provider {
+	idps := []*identity.Provider{o.GetIdentityProviderForPolicy(nil)}
+	for _, policy := range o.GetAllPolicies() {
+		idps = append(idps, o.GetIdentityProviderForPolicy(&policy)) //nolint
+	}
+	for _, name := range o.GetIdentityProviderNames() {
+		idp, _ := o.GetIdentityProviderForName(name)
+		idps = append(idps, idp)
+	}
+
+	seen := map[string]struct{}{}
+	unique := idps[:0]
+	for _, idp := range idps {
+		if _, ok := seen[idp.GetId()]; ok {
+			continue
+		}
+		seen[idp.GetId()] = struct{}{}
+		unique = append(unique, idp)
+	}
+	return unique
+}
+
+// GetIdentityProviderFailover returns the identity provider selected by idp_failover.
+func (o *Options) GetIdentityProviderFailover() (*identity.Provider, bool) {
+	if o.IdentityProviderFailover == "" {
+		return nil, false
+	}
+	return o.GetIdentityProviderForName(o.IdentityProviderFailover)
+}
+
+// GetIdentityProviderForName returns the named identity provider from the identity_providers setting.
+func (o *Options) GetIdentityProviderForName(name string) (*identity.Provider, bool) {
+	cfg, ok := o.IdentityProviders[name]
+	if !ok {
+		return nil, false
+	}
+	idp := &identity.Provider{
+		ClientId:       cfg.ClientID,
+		ClientSecret:   cfg.ClientSecret,
+		Type:           cfg.Provider,
+		Scopes:         cfg.Scopes,
+		ServiceAccount: cfg.ServiceAccount,
+		Url:            cfg.ProviderURL,
+		RequestParams:  cfg.RequestParams,
+		ClaimsMapping:  o.GetClaimsMapping(),
+	}
+	idp.Id = idp.Hash()
+	return idp, true
+}
+
+// GetIdentityProviderNames returns the sorted names of the identity_providers setting.
+func (o *Options) GetIdentityProviderNames() []string {
+	names := make([]string, 0, len(o.IdentityProviders))
+	for name := range o.IdentityProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetIdentityProviderForPolicy gets the identity provider associated with the given policy.
 // If policy is nil, or changes none of the default settings, the default provider is returned.
+// If the policy allows several identity providers, the first one is returned.
 func (o *Options) GetIdentityProviderForPolicy(policy *Policy) *identity.Provider {
+	if idps := o.GetIdentityProvidersForPolicy(policy); len(idps) > 0 {
+		return idps[0]
+	}
+
 	idp := &identity.Provider{
 		ClientId:       o.ClientID,
 		ClientSecret:   o.ClientSecret,
@@ -28,6 +216,11 @@ func (o *Options) GetIdentityProviderForPolicy(policy *Policy) *identity.Provide
 		ServiceAccount: o.ServiceAccount,
 		Url:            o.ProviderURL,
 		RequestParams:  o.RequestParams,
+
+		SamlCertificate:      o.SAMLCertificate,
+		SamlKey:              o.SAMLKey,
+		SamlAttributeMapping: o.SAMLAttributeMapping,
+		ClaimsMapping:        o.GetClaimsMapping(),
 	}
 	if policy != nil {
 		if policy.IDPClientID != "" {
@@ -40,3 +233,44 @@ func (o *Options) GetIdentityProviderForPolicy(policy *Policy) *identity.Provide
 	idp.Id = idp.Hash()
 	return idp
 }
+
+// GetIdentityProvidersForPolicy returns the named identity providers selected by the given policy.
+// If the policy doesn't select any identity providers, nil is returned, and the policy uses the
+// default identity provider returned by GetIdentityProviderForPolicy.
+func (o *Options) GetIdentityProvidersForPolicy(policy *Policy) []*identity.Provider {
+	if policy == nil {
+		return nil
+	}
+
+	var idps []*identity.Provider
+	for _, name := range policy.IdentityProviders {
+		if idp, ok := o.GetIdentityProviderForName(name); ok {
+			idps = append(idps, idp)
+		}
+	}
+	return idps
+}
+
+func (o *Options) validateIdentityProviders() error {
+	for name, idp := range o.IdentityProviders {
+		if !identityProviderNameRegex.MatchString(name) {
+			return fmt.Errorf("config: invalid identity provider name: %q", name)
+		}
+		if idp.Provider == "" {
+			return fmt.Errorf("config: identity provider %s is missing a provider", name)
+		}
+	}
+	if o.IdentityProviderFailover != "" {
+		if _, ok := o.IdentityProviders[o.IdentityProviderFailover]; !ok {
+			return fmt.Errorf("config: idp_failover references unknown identity provider: %s", o.IdentityProviderFailover)
+		}
+	}
+	for _, policy := range o.GetAllPolicies() {
+		for _, name := range policy.IdentityProviders {
+			if _, ok := o.IdentityProviders[name]; !ok {
+				return fmt.Errorf("config: route %s references unknown identity provider: %s", policy.String(), name)
+			}
+		}
+	}
+	return nil
+}
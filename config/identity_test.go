@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_GetIdentityProviderForPolicy(t *testing.T) {
+	options := NewDefaultOptions()
+	options.Provider = "oidc"
+	options.ProviderURL = "https://default.example.com"
+	options.IdentityProviders = map[string]IdentityProvider{
+		"employees":   {Provider: "okta", ProviderURL: "https://employees.example.com", ClientID: "EMPLOYEES"},
+		"contractors": {Provider: "azure", ProviderURL: "https://contractors.example.com", ClientID: "CONTRACTORS"},
+	}
+
+	defaultIDP := options.GetIdentityProviderForPolicy(nil)
+	assert.Equal(t, "https://default.example.com", defaultIDP.GetUrl())
+
+	employees := options.GetIdentityProviderForPolicy(&Policy{IdentityProviders: []string{"employees"}})
+	assert.Equal(t, "okta", employees.GetType())
+	assert.Equal(t, "EMPLOYEES", employees.GetClientId())
+	assert.NotEqual(t, defaultIDP.GetId(), employees.GetId())
+
+	idps := options.GetIdentityProvidersForPolicy(&Policy{IdentityProviders: []string{"contractors", "employees"}})
+	if assert.Len(t, idps, 2) {
+		assert.Equal(t, "CONTRACTORS", idps[0].GetClientId())
+		assert.Equal(t, "EMPLOYEES", idps[1].GetClientId())
+	}
+	assert.Nil(t, options.GetIdentityProvidersForPolicy(&Policy{}))
+
+	assert.Equal(t, employees.GetId(), options.GetIdentityProviderForID(employees.GetId()).GetId())
+	assert.Equal(t, defaultIDP.GetId(), options.GetIdentityProviderForID("UNKNOWN").GetId())
+	assert.Equal(t, []string{"contractors", "employees"}, options.GetIdentityProviderNames())
+}
+
+func TestOptions_validateIdentityProviders(t *testing.T) {
+	options := NewDefaultOptions()
+	options.IdentityProviders = map[string]IdentityProvider{
+		"employees": {Provider: "okta"},
+	}
+	options.Policies = []Policy{{From: "https://from.example.com", IdentityProviders: []string{"employees"}}}
+	assert.NoError(t, options.validateIdentityProviders())
+
+	options.Policies[0].IdentityProviders = []string{"contractors"}
+	assert.Error(t, options.validateIdentityProviders(), "should fail for unknown identity providers")
+
+	options.Policies = nil
+	options.IdentityProviders["not valid"] = IdentityProvider{Provider: "okta"}
+	assert.Error(t, options.validateIdentityProviders(), "should fail for invalid names")
+
+	delete(options.IdentityProviders, "not valid")
+	options.IdentityProviders["contractors"] = IdentityProvider{}
+	assert.Error(t, options.validateIdentityProviders(), "should fail for missing provider")
+}
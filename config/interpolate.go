@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// interpolationNameRE matches the names of environment variables which can be interpolated.
+// References which don't match, like the `${1}` capture groups of regex_rewrite_substitution,
+// are left as they are.
+var interpolationNameRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// interpolateConfig replaces the `${ENV_VAR}` and `${file:/path}` references in the string
+// values of the viper instance with the value of the environment variable or the contents of the
+// file. `$${` is replaced with a literal `${`.
+func interpolateConfig(v *viper.Viper) error {
+	return mapConfigStrings(v, interpolateString)
+}
+
+// mapConfigStrings replaces every string value of the viper instance, including the strings
+// nested in lists and maps like routes, with the result of fn.
+func mapConfigStrings(v *viper.Viper, fn func(string) (string, error)) error {
+	keys := v.AllKeys()
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, changed, err := mapStrings(v.Get(key), fn)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", key, err)
+		}
+		if changed {
+			v.Set(key, value)
+		}
+	}
+	return nil
+}
+
+func mapStrings(value interface{}, fn func(string) (string, error)) (interface{}, bool, error) {
+	switch value := value.(type) {
+	case string:
+		s, err := fn(value)
+		return s, s != value, err
+	case []interface{}:
+		var changed bool
+		items := make([]interface{}, len(value))
+		for i := range value {
+			item, itemChanged, err := mapStrings(value[i], fn)
+			if err != nil {
+				return nil, false, fmt.Errorf("[%d]: %w", i, err)
+			}
+			items[i], changed = item, changed || itemChanged
+		}
+		return items, changed, nil
+	case map[string]interface{}:
+		var changed bool
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			item, itemChanged, err := mapStrings(v, fn)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", k, err)
+			}
+			m[k], changed = item, changed || itemChanged
+		}
+		return m, changed, nil
+	case map[interface{}]interface{}:
+		var changed bool
+		m := make(map[interface{}]interface{}, len(value))
+		for k, v := range value {
+			item, itemChanged, err := mapStrings(v, fn)
+			if err != nil {
+				return nil, false, fmt.Errorf("%v: %w", k, err)
+			}
+			m[k], changed = item, changed || itemChanged
+		}
+		return m, changed, nil
+	}
+	return value, false, nil
+}
+
+func interpolateString(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+
+		// $${ is an escaped ${
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i-1])
+			b.WriteString("${")
+			s = s[i+2:]
+			continue
+		}
+
+		b.WriteString(s[:i])
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated reference in %q", s[i:])
+		}
+		ref := s[i+2 : i+end]
+		s = s[i+end+1:]
+
+		value, ok, err := resolveReference(ref)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			b.WriteString("${" + ref + "}")
+			continue
+		}
+		b.WriteString(value)
+	}
+}
+
+// resolveReference returns the value of an interpolated reference, or false if it isn't a
+// reference.
+func resolveReference(ref string) (string, bool, error) {
+	if strings.HasPrefix(ref, "file:") {
+		path := strings.TrimPrefix(ref, "file:")
+		if path == "" {
+			return "", false, fmt.Errorf("missing file name in ${%s}", ref)
+		}
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("error reading ${%s}: %w", ref, err)
+		}
+		// files like Kubernetes and Docker secrets usually end in a newline
+		return strings.TrimRight(string(bs), "\r\n"), true, nil
+	}
+
+	if !interpolationNameRE.MatchString(ref) {
+		return "", false, nil
+	}
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", false, fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return value, true, nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateString(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("s3cr3t\n"), 0o600))
+	t.Setenv("POMERIUM_TEST_HOST", "example.com")
+
+	for _, tc := range []struct {
+		in     string
+		expect string
+		err    bool
+	}{
+		{"https://${POMERIUM_TEST_HOST}/path", "https://example.com/path", false},
+		{"${POMERIUM_TEST_HOST}${POMERIUM_TEST_HOST}", "example.comexample.com", false},
+		{"${file:" + secretFile + "}", "s3cr3t", false},
+		{"$${POMERIUM_TEST_HOST}", "${POMERIUM_TEST_HOST}", false},
+		{"/$1/${1}", "/$1/${1}", false},
+		{"$POMERIUM_TEST_HOST", "$POMERIUM_TEST_HOST", false},
+		{"${POMERIUM_TEST_MISSING}", "", true},
+		{"${file:" + secretFile + ".missing}", "", true},
+		{"${file:}", "", true},
+		{"${POMERIUM_TEST_HOST", "", true},
+	} {
+		actual, err := interpolateString(tc.in)
+		if tc.err {
+			assert.Error(t, err, tc.in)
+			continue
+		}
+		assert.NoError(t, err, tc.in)
+		assert.Equal(t, tc.expect, actual, tc.in)
+	}
+}
+
+func TestOptionsFromViperInterpolation(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "client-secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("CLIENT_SECRET\n"), 0o600))
+	t.Setenv("POMERIUM_TEST_DOMAIN", "example.com")
+
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+insecure_server: true
+autocert_dir: ''
+idp_client_secret: ${file:`+secretFile+`}
+routes:
+- from: https://app.${POMERIUM_TEST_DOMAIN}
+  to: https://app.internal
+  set_request_headers:
+    X-Domain: ${POMERIUM_TEST_DOMAIN}
+`), 0o600))
+
+	o, err := optionsFromViper(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "CLIENT_SECRET", o.ClientSecret)
+	require.Len(t, o.Routes, 1)
+	assert.Equal(t, "https://app.example.com", o.Routes[0].From)
+	assert.Equal(t, map[string]string{"X-Domain": "example.com"}, o.Routes[0].SetRequestHeaders)
+
+	t.Run("missing", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configFile, []byte("insecure_server: true\nidp_client_secret: ${POMERIUM_TEST_MISSING}\n"), 0o600))
+		_, err := optionsFromViper(configFile)
+		assert.ErrorContains(t, err, "idp_client_secret: environment variable POMERIUM_TEST_MISSING is not set")
+	})
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// The keys local rate limits can be applied by.
+const (
+	LocalRateLimitKeyByIP   = "ip"
+	LocalRateLimitKeyByUser = "user"
+)
+
+// PolicyLocalRateLimit limits the rate of requests to a route, to absorb bursts of requests before
+// they reach the upstreams. The limit is applied by each instance of the proxy and authorize.
+type PolicyLocalRateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests.
+	RequestsPerSecond uint32 `mapstructure:"requests_per_second" yaml:"requests_per_second" json:"requests_per_second"`
+	// Burst is the maximum number of requests allowed at once. Defaults to RequestsPerSecond.
+	Burst uint32 `mapstructure:"burst" yaml:"burst,omitempty" json:"burst,omitempty"`
+	// KeyBy applies the limit separately to each client IP address or user instead of to the
+	// route as a whole.
+	KeyBy string `mapstructure:"key_by" yaml:"key_by,omitempty" json:"key_by,omitempty"`
+}
+
+// NewPolicyLocalRateLimitFromProto creates a new PolicyLocalRateLimit from a protobuf message.
+func NewPolicyLocalRateLimitFromProto(pb *configpb.RouteLocalRateLimit) *PolicyLocalRateLimit {
+	if pb == nil {
+		return nil
+	}
+	return &PolicyLocalRateLimit{
+		RequestsPerSecond: pb.GetRequestsPerSecond(),
+		Burst:             pb.GetBurst(),
+		KeyBy:             pb.GetKeyBy(),
+	}
+}
+
+// ToProto converts the local rate limit to a protobuf message.
+func (rl *PolicyLocalRateLimit) ToProto() *configpb.RouteLocalRateLimit {
+	if rl == nil {
+		return nil
+	}
+	return &configpb.RouteLocalRateLimit{
+		RequestsPerSecond: rl.RequestsPerSecond,
+		Burst:             rl.Burst,
+		KeyBy:             rl.KeyBy,
+	}
+}
+
+// Validate checks the validity of the local rate limit.
+func (rl *PolicyLocalRateLimit) Validate() error {
+	if rl == nil {
+		return nil
+	}
+	if rl.RequestsPerSecond == 0 {
+		return fmt.Errorf("requests_per_second must be greater than 0")
+	}
+	switch rl.KeyBy {
+	case "", LocalRateLimitKeyByIP, LocalRateLimitKeyByUser:
+	default:
+		return fmt.Errorf("unknown key_by: %s", rl.KeyBy)
+	}
+	return nil
+}
+
+// GetBurst returns the maximum number of requests allowed at once.
+func (rl *PolicyLocalRateLimit) GetBurst() uint32 {
+	if rl.Burst == 0 {
+		return rl.RequestsPerSecond
+	}
+	return rl.Burst
+}
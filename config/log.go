@@ -43,4 +43,5 @@ func (mgr *LogManager) OnConfigChange(ctx context.Context, cfg *Config) {
 	if cfg.Options.LogLevel != "" {
 		log.SetLevel(cfg.Options.LogLevel)
 	}
+	log.SetSampling(cfg.Options.LogSampling)
 }
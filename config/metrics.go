@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
+	"strconv"
 	"sync"
 
 	"github.com/pomerium/pomerium/internal/log"
@@ -15,6 +17,10 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// MetricsRouteOther is the route ID and name of the per-route metrics of the routes which aren't
+// in metrics_routes.
+const MetricsRouteOther = "other"
+
 // A MetricsManager manages metrics for a given configuration.
 type MetricsManager struct {
 	mu                sync.RWMutex
@@ -24,6 +30,8 @@ type MetricsManager struct {
 	basicAuth         string
 	envoyAdminAddress string
 	handler           http.Handler
+	pushOptions       *metrics.PushOptions
+	pusher            *metrics.Pusher
 }
 
 // NewMetricsManager creates a new MetricsManager.
@@ -38,8 +46,14 @@ func NewMetricsManager(ctx context.Context, src Source) *MetricsManager {
 	return mgr
 }
 
-// Close closes any underlying http server.
+// Close closes any underlying http server, and stops pushing metrics.
 func (mgr *MetricsManager) Close() error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.pusher != nil {
+		return mgr.pusher.Close()
+	}
 	return nil
 }
 
@@ -50,6 +64,8 @@ func (mgr *MetricsManager) OnConfigChange(ctx context.Context, cfg *Config) {
 
 	mgr.updateInfo(ctx, cfg)
 	mgr.updateServer(ctx, cfg)
+	mgr.updatePusher(ctx, cfg)
+	mgr.updateRouteLatencyBuckets(ctx, cfg)
 }
 
 func (mgr *MetricsManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -116,3 +132,54 @@ func (mgr *MetricsManager) updateServer(ctx context.Context, cfg *Config) {
 
 	mgr.handler = handler
 }
+
+func (mgr *MetricsManager) updatePusher(ctx context.Context, cfg *Config) {
+	pushOptions, err := cfg.Options.GetMetricsPushOptions()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("metrics: invalid push options")
+		return
+	}
+	if reflect.DeepEqual(pushOptions, mgr.pushOptions) {
+		return
+	}
+	mgr.pushOptions = pushOptions
+
+	if mgr.pusher != nil {
+		_ = mgr.pusher.Close()
+		mgr.pusher = nil
+	}
+
+	if !pushOptions.Enabled() {
+		return
+	}
+
+	mgr.pusher, err = metrics.NewPusher(pushOptions)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("metrics: failed to start pushing metrics")
+		return
+	}
+	log.Info(ctx).Dur("interval", pushOptions.Interval).Msg("metrics: pushing metrics")
+}
+
+func (mgr *MetricsManager) updateRouteLatencyBuckets(ctx context.Context, cfg *Config) {
+	if err := metrics.SetRouteLatencyBuckets(cfg.Options.MetricsRouteLatencyBuckets); err != nil {
+		log.Error(ctx).Err(err).Msg("metrics: invalid route latency buckets")
+	}
+}
+
+// GetMetricsRouteTags returns the tags of the per-route metrics of the policy: its route ID, and
+// its name, or from URL when it doesn't have one. Policies which aren't in metrics_routes, by any
+// of these, share the MetricsRouteOther tags.
+func (o *Options) GetMetricsRouteTags(policy *Policy) metrics.RouteTags {
+	routeID, _ := policy.RouteID()
+	tags := metrics.RouteTags{ID: strconv.FormatUint(routeID, 16), Name: policy.From}
+	if policy.EnvoyOpts != nil && policy.EnvoyOpts.Name != "" {
+		tags.Name = policy.EnvoyOpts.Name
+	}
+	for _, route := range o.MetricsRoutes {
+		if route == "*" || route == tags.ID || route == tags.Name || route == policy.From {
+			return tags
+		}
+	}
+	return metrics.RouteTags{ID: MetricsRouteOther, Name: MetricsRouteOther}
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 )
 
 func TestMetricsManager(t *testing.T) {
@@ -59,3 +64,45 @@ func TestMetricsManagerBasicAuth(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
 }
+
+func TestMetricsManagerPush(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	src := NewStaticSource(&Config{
+		Options: &Options{
+			MetricsPushInterval:  time.Second,
+			MetricsStatsdAddress: conn.LocalAddr().String(),
+		},
+	})
+	mgr := NewMetricsManager(context.Background(), src)
+	require.NotNil(t, mgr.pusher)
+	assert.NoError(t, mgr.Close(), "close should push the metrics")
+
+	buf := make([]byte, 1500)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf[:n]), "pomerium.")
+}
+
+func TestOptions_GetMetricsRouteTags(t *testing.T) {
+	p1 := &Policy{From: "https://a.example.com", To: mustParseWeightedURLs(t, "https://a.internal")}
+	p2 := &Policy{From: "https://b.example.com", To: mustParseWeightedURLs(t, "https://b.internal")}
+	require.NoError(t, p1.Validate())
+	require.NoError(t, p2.Validate())
+	p2.EnvoyOpts = &envoy_config_cluster_v3.Cluster{Name: "app-b"}
+	id1, _ := p1.RouteID()
+
+	o := NewDefaultOptions()
+	o.MetricsRoutes = []string{"https://a.example.com"}
+	assert.Equal(t, metrics.RouteTags{ID: fmt.Sprintf("%x", id1), Name: "https://a.example.com"}, o.GetMetricsRouteTags(p1))
+	assert.Equal(t, metrics.RouteTags{ID: MetricsRouteOther, Name: MetricsRouteOther}, o.GetMetricsRouteTags(p2))
+
+	o.MetricsRoutes = []string{"app-b"}
+	assert.Equal(t, "app-b", o.GetMetricsRouteTags(p2).Name)
+
+	o.MetricsRoutes = []string{"*"}
+	assert.Equal(t, fmt.Sprintf("%x", id1), o.GetMetricsRouteTags(p1).ID)
+}
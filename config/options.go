@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -25,15 +28,20 @@ import (
 	"github.com/pomerium/pomerium/internal/directory/google"
 	"github.com/pomerium/pomerium/internal/directory/okta"
 	"github.com/pomerium/pomerium/internal/directory/onelogin"
+	"github.com/pomerium/pomerium/internal/fips"
 	"github.com/pomerium/pomerium/internal/hashutil"
 	"github.com/pomerium/pomerium/internal/identity/oauth"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/revocation"
+	"github.com/pomerium/pomerium/internal/secretmem"
 	"github.com/pomerium/pomerium/internal/sets"
 	"github.com/pomerium/pomerium/internal/telemetry"
 	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/internal/telemetry/otlpexport"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
 )
 
 // DisableHeaderKey is the key used to check whether to disable setting header
@@ -69,9 +77,40 @@ type Options struct {
 	// Possible options are "info","warn", and "error". Defaults to the value of `LogLevel`.
 	ProxyLogLevel string `mapstructure:"proxy_log_level" yaml:"proxy_log_level,omitempty"`
 
+	// LogSampling are the rates, between 0 and 1, at which the logs of a category are written.
+	// Categories are the modules of the log messages (e.g. "authorize"), "authorize_allow" and
+	// "authorize_deny".
+	LogSampling map[string]float64 `mapstructure:"log_sampling" yaml:"log_sampling,omitempty"`
+
+	// AccessLogSinks are where the access logs of the proxy are written to, instead of the
+	// pomerium log. Each sink has its own format.
+	AccessLogSinks []AccessLogSink `mapstructure:"access_log_sinks" yaml:"access_log_sinks,omitempty"`
+	// AccessLogFields are the fields of the access logs, like request.header.x-forwarded-proto.
+	// Defaults to DefaultAccessLogFields.
+	AccessLogFields []string `mapstructure:"access_log_fields" yaml:"access_log_fields,omitempty"`
+
+	// AuditSinks are where audit events, like logins and denied requests, are delivered to.
+	AuditSinks []AuditSink `mapstructure:"audit_sinks" yaml:"audit_sinks,omitempty"`
+	// AuditSpillDirectory is the directory audit events are spilled to when they can't be
+	// delivered, to be delivered again later. When empty, they're dropped.
+	AuditSpillDirectory string `mapstructure:"audit_spill_directory" yaml:"audit_spill_directory,omitempty"`
+	// AuditTrail records privileged audit events, like impersonations and revocations, in a
+	// hash-chained audit trail stored in the databroker.
+	AuditTrail bool `mapstructure:"audit_trail" yaml:"audit_trail,omitempty"`
+
+	// ImpersonationRequesterGroups are the directory groups, by id, name or email, of the users
+	// allowed to request impersonations. When empty, impersonations can't be requested.
+	ImpersonationRequesterGroups []string `mapstructure:"impersonation_requester_groups" yaml:"impersonation_requester_groups,omitempty"`
+	// ImpersonationApproverGroups are the directory groups, by id, name or email, of the users
+	// allowed to approve impersonations.
+	ImpersonationApproverGroups []string `mapstructure:"impersonation_approver_groups" yaml:"impersonation_approver_groups,omitempty"`
+
 	// SharedKey is the shared secret authorization key used to mutually authenticate
 	// requests between services.
 	SharedKey string `mapstructure:"shared_secret" yaml:"shared_secret,omitempty"`
+	// AcceptedSharedKeys are additional shared secrets accepted from other services, but not
+	// used to sign requests, so the shared secret can be rotated without downtime.
+	AcceptedSharedKeys []string `mapstructure:"accepted_shared_secrets" yaml:"accepted_shared_secrets,omitempty"`
 
 	// Services is a list enabled service mode. If none are selected, "all" is used.
 	// Available options are : "all", "authenticate", "proxy".
@@ -109,6 +148,10 @@ type Options struct {
 	WriteTimeout time.Duration `mapstructure:"timeout_write" yaml:"timeout_write,omitempty"`
 	IdleTimeout  time.Duration `mapstructure:"timeout_idle" yaml:"timeout_idle,omitempty"`
 
+	// ListenerDrainTimeout is how long envoy drains the connections of listeners which are
+	// changed or replaced, when the configuration is reloaded, before closing them.
+	ListenerDrainTimeout time.Duration `mapstructure:"listener_drain_timeout" yaml:"listener_drain_timeout,omitempty"`
+
 	// Policies define per-route configuration and access control policies.
 	Policies   []Policy `mapstructure:"policy"`
 	PolicyFile string   `mapstructure:"policy_file" yaml:"policy_file,omitempty"`
@@ -117,6 +160,10 @@ type Options struct {
 	// AdditionalPolicies are any additional policies added to the options.
 	AdditionalPolicies []Policy `yaml:"-"`
 
+	// DefaultPolicy is a policy that is combined using `and` with the policy of every route,
+	// unless a route opts out via `skip_default_policy`.
+	DefaultPolicy *PPLPolicy `mapstructure:"default_policy" yaml:"default_policy,omitempty"`
+
 	// AuthenticateURL represents the externally accessible http endpoints
 	// used for authentication requests and callbacks
 	AuthenticateURLString         string `mapstructure:"authenticate_service_url" yaml:"authenticate_service_url,omitempty"`
@@ -138,6 +185,11 @@ type Options struct {
 	CookieSecure   bool          `mapstructure:"cookie_secure" yaml:"cookie_secure,omitempty"`
 	CookieHTTPOnly bool          `mapstructure:"cookie_http_only" yaml:"cookie_http_only,omitempty"`
 	CookieExpire   time.Duration `mapstructure:"cookie_expire" yaml:"cookie_expire,omitempty"`
+	// CookieCompression compresses session cookies, so large sessions are split across fewer cookies.
+	CookieCompression bool `mapstructure:"cookie_compression" yaml:"cookie_compression,omitempty"`
+	// SessionIdleTimeout ends sessions which haven't been used for the duration, even if they
+	// haven't expired. Routes may override it.
+	SessionIdleTimeout time.Duration `mapstructure:"session_idle_timeout" yaml:"session_idle_timeout,omitempty"`
 
 	// Identity provider configuration variables as specified by RFC6749
 	// https://openid.net/specs/openid-connect-basic-1_0.html#RFC6749
@@ -147,10 +199,42 @@ type Options struct {
 	ProviderURL    string   `mapstructure:"idp_provider_url" yaml:"idp_provider_url,omitempty"`
 	Scopes         []string `mapstructure:"idp_scopes" yaml:"idp_scopes,omitempty"`
 	ServiceAccount string   `mapstructure:"idp_service_account" yaml:"idp_service_account,omitempty"`
+	// SAML service provider settings used when the identity provider is "saml". The certificate
+	// and key are base64 encoded PEM and are used to sign authentication requests and decrypt
+	// assertions. The attribute mapping maps claim names to SAML assertion attribute names.
+	SAMLCertificate      string            `mapstructure:"idp_saml_certificate" yaml:"idp_saml_certificate,omitempty"`
+	SAMLKey              string            `mapstructure:"idp_saml_key" yaml:"idp_saml_key,omitempty"`
+	SAMLAttributeMapping map[string]string `mapstructure:"idp_saml_attribute_mapping" yaml:"idp_saml_attribute_mapping,omitempty"`
+	// IdentityProviders are additional identity providers, keyed by name, which routes can
+	// select instead of the default identity provider.
+	IdentityProviders map[string]IdentityProvider `mapstructure:"identity_providers" yaml:"identity_providers,omitempty"`
+	// ClaimsMapping transforms the claims returned by identity providers before they're stored in
+	// sessions, so policies and upstream applications see normalized claims.
+	ClaimsMapping []ClaimMapping `mapstructure:"claims_mapping" yaml:"claims_mapping,omitempty"`
 	// Identity provider refresh directory interval/timeout settings.
 	RefreshDirectoryTimeout  time.Duration `mapstructure:"idp_refresh_directory_timeout" yaml:"idp_refresh_directory_timeout,omitempty"`
 	RefreshDirectoryInterval time.Duration `mapstructure:"idp_refresh_directory_interval" yaml:"idp_refresh_directory_interval,omitempty"`
 	QPS                      float64       `mapstructure:"idp_qps" yaml:"idp_qps"`
+	// IdentityProviderHealthCheckInterval enables probing the identity providers' discovery,
+	// JWKS and token endpoints at the interval. Zero disables health checks.
+	IdentityProviderHealthCheckInterval time.Duration `mapstructure:"idp_health_check_interval" yaml:"idp_health_check_interval,omitempty"`
+	// IdentityProviderFailover is the name of an identity provider from identity_providers
+	// users sign in with when the identity provider of a route is unhealthy.
+	IdentityProviderFailover string `mapstructure:"idp_failover" yaml:"idp_failover,omitempty"`
+	// IdentityProviderGraceMode keeps sessions until they expire when they can't be refreshed
+	// because their identity provider is unhealthy.
+	IdentityProviderGraceMode bool `mapstructure:"idp_grace_mode" yaml:"idp_grace_mode,omitempty"`
+	// SCIMBearerToken enables the SCIM provisioning endpoint on the authenticate service. Requests
+	// must use the token as a bearer token. Directory sync is disabled when SCIM is enabled.
+	SCIMBearerToken string `mapstructure:"scim_bearer_token" yaml:"scim_bearer_token,omitempty"`
+	// AdminAPIBearerToken enables the admin API on the authenticate service. Requests must use the
+	// token as a bearer token.
+	AdminAPIBearerToken string `mapstructure:"admin_api_bearer_token" yaml:"admin_api_bearer_token,omitempty"`
+	// PasskeySignIn lets users register passkeys and use them to sign in without being
+	// redirected to the identity provider.
+	PasskeySignIn bool `mapstructure:"passkey_sign_in" yaml:"passkey_sign_in,omitempty"`
+	// MaxDevicesPerUser limits how many device credentials a user can register. 0 means no limit.
+	MaxDevicesPerUser uint32 `mapstructure:"max_devices_per_user" yaml:"max_devices_per_user,omitempty"`
 
 	// RequestParams are custom request params added to the signin request as
 	// part of an Oauth2 code flow.
@@ -166,14 +250,48 @@ type Options struct {
 	AuthorizeURLStrings        []string `mapstructure:"authorize_service_urls" yaml:"authorize_service_urls,omitempty"`
 	AuthorizeInternalURLString string   `mapstructure:"authorize_internal_service_url" yaml:"authorize_internal_service_url,omitempty"`
 
+	// AuthorizeDecisionCacheTTL is how long authorization decisions are cached for. Cached decisions
+	// are discarded whenever databroker data changes. A value of zero disables the cache.
+	AuthorizeDecisionCacheTTL time.Duration `mapstructure:"authorize_decision_cache_ttl" yaml:"authorize_decision_cache_ttl,omitempty"`
+
 	// Settings to enable custom behind-the-ingress service communication
 	OverrideCertificateName string `mapstructure:"override_certificate_name" yaml:"override_certificate_name,omitempty"`
 	CA                      string `mapstructure:"certificate_authority" yaml:"certificate_authority,omitempty"`
 	CAFile                  string `mapstructure:"certificate_authority_file" yaml:"certificate_authority_file,omitempty"`
 
+	// SPIFFEWorkloadAPIAddress is the address of the SPIFFE Workload API, e.g. a SPIRE agent,
+	// routes fetch their upstream client certificates and trust bundles from.
+	SPIFFEWorkloadAPIAddress string `mapstructure:"spiffe_workload_api_address" yaml:"spiffe_workload_api_address,omitempty"`
+
 	// SigningKey is the private key used to add a JWT-signature to upstream requests.
 	// https://www.pomerium.io/docs/topics/getting-users-identity.html
 	SigningKey string `mapstructure:"signing_key" yaml:"signing_key,omitempty"`
+	// SigningKeyAlgorithm is the algorithm used to sign the JWT added to upstream requests. One
+	// of ES256, RS256 or EdDSA. When no signing key is set, a key of this type is generated.
+	SigningKeyAlgorithm string `mapstructure:"signing_key_algorithm" yaml:"signing_key_algorithm,omitempty"`
+	// SigningKeyRotationInterval enables automatic rotation of signing keys, which are stored
+	// in the databroker. It can't be used with SigningKey.
+	SigningKeyRotationInterval time.Duration `mapstructure:"signing_key_rotation_interval" yaml:"signing_key_rotation_interval,omitempty"`
+	// SigningKeyRotationOverlap is how long a rotated signing key is still published in the JWKS.
+	SigningKeyRotationOverlap time.Duration `mapstructure:"signing_key_rotation_overlap" yaml:"signing_key_rotation_overlap,omitempty"`
+	// SigningKeyPKCS11Label is the label of a signing key stored on a PKCS#11 token, like an
+	// HSM. JWTs are signed by the token, so the key never leaves it. It can't be used with
+	// SigningKey or SigningKeyRotationInterval.
+	SigningKeyPKCS11Label string `mapstructure:"signing_key_pkcs11_label" yaml:"signing_key_pkcs11_label,omitempty"`
+	// PKCS11ModulePath is the path of the PKCS#11 module used to access the token.
+	PKCS11ModulePath string `mapstructure:"pkcs11_module_path" yaml:"pkcs11_module_path,omitempty"`
+	// PKCS11TokenLabel is the label of the PKCS#11 token. By default the first token is used.
+	PKCS11TokenLabel string `mapstructure:"pkcs11_token_label" yaml:"pkcs11_token_label,omitempty"`
+	// PKCS11PIN is the user PIN of the PKCS#11 token.
+	PKCS11PIN     string `mapstructure:"pkcs11_pin" yaml:"pkcs11_pin,omitempty"`
+	PKCS11PINFile string `mapstructure:"pkcs11_pin_file" yaml:"pkcs11_pin_file,omitempty"`
+	// SigningKeyKMS is the URL of a signing key managed by AWS KMS, Google Cloud KMS or Azure
+	// Key Vault. JWTs are signed by the service, so the key never leaves it. It can't be used
+	// with SigningKey or SigningKeyRotationInterval.
+	SigningKeyKMS string `mapstructure:"signing_key_kms" yaml:"signing_key_kms,omitempty"`
+	// TokenExchangePolicies allow upstream applications to exchange the attestation JWT for a
+	// token for another audience at the authenticate service's token endpoint.
+	TokenExchangePolicies []TokenExchangePolicy `mapstructure:"token_exchange_policies" yaml:"token_exchange_policies,omitempty"`
 
 	HeadersEnv string `yaml:",omitempty"`
 	// SetResponseHeaders to set on all proxied requests. Add a 'disable' key map to turn off.
@@ -196,6 +314,42 @@ type Options struct {
 	MetricsClientCA           string `mapstructure:"metrics_client_ca" yaml:"metrics_client_ca,omitempty"`
 	MetricsClientCAFile       string `mapstructure:"metrics_client_ca_file" yaml:"metrics_client_ca_file,omitempty"`
 
+	// Metrics are pushed to an OpenTelemetry collector, a statsd server, or both, for
+	// environments where the metrics endpoint can't be scraped.
+	MetricsPushInterval  time.Duration     `mapstructure:"metrics_push_interval" yaml:"metrics_push_interval,omitempty"`
+	MetricsOTLPEndpoint  string            `mapstructure:"metrics_otlp_endpoint" yaml:"metrics_otlp_endpoint,omitempty"`
+	MetricsOTLPProtocol  string            `mapstructure:"metrics_otlp_protocol" yaml:"metrics_otlp_protocol,omitempty"`
+	MetricsOTLPHeaders   map[string]string `mapstructure:"metrics_otlp_headers" yaml:"metrics_otlp_headers,omitempty"`
+	MetricsStatsdAddress string            `mapstructure:"metrics_statsd_address" yaml:"metrics_statsd_address,omitempty"`
+	// MetricsStatsdFlavor is statsd or dogstatsd, which also sends labels as tags.
+	MetricsStatsdFlavor string `mapstructure:"metrics_statsd_flavor" yaml:"metrics_statsd_flavor,omitempty"`
+	MetricsStatsdPrefix string `mapstructure:"metrics_statsd_prefix" yaml:"metrics_statsd_prefix,omitempty"`
+	// MetricsResourceAttributes are added to the pushed metrics, like the environment.
+	MetricsResourceAttributes map[string]string `mapstructure:"metrics_resource_attributes" yaml:"metrics_resource_attributes,omitempty"`
+	// MetricsRoutes are the IDs, names or from URLs of the routes with their own per-route
+	// metrics, or * for every route. The metrics of the other routes are combined.
+	MetricsRoutes []string `mapstructure:"metrics_routes" yaml:"metrics_routes,omitempty"`
+
+	// CertificateExpiryWarningThreshold and CertificateExpiryCriticalThreshold are how long
+	// before a configured certificate expires a warning and an error are logged and published
+	// as audit events.
+	CertificateExpiryWarningThreshold  time.Duration `mapstructure:"certificate_expiry_warning_threshold" yaml:"certificate_expiry_warning_threshold,omitempty"`
+	CertificateExpiryCriticalThreshold time.Duration `mapstructure:"certificate_expiry_critical_threshold" yaml:"certificate_expiry_critical_threshold,omitempty"`
+	// MetricsRouteLatencyBuckets are the buckets, in ms, of the per-route request duration
+	// histogram.
+	MetricsRouteLatencyBuckets []float64 `mapstructure:"metrics_route_latency_buckets" yaml:"metrics_route_latency_buckets,omitempty"`
+
+	// DiagnosticsAddr is the address of the diagnostics endpoint, which serves pprof profiles and
+	// the runtime state of pomerium. Clients must present a certificate signed by the
+	// DiagnosticsClientCA.
+	DiagnosticsAddr         string `mapstructure:"diagnostics_address" yaml:"diagnostics_address,omitempty"`
+	DiagnosticsClientCA     string `mapstructure:"diagnostics_client_ca" yaml:"diagnostics_client_ca,omitempty"`
+	DiagnosticsClientCAFile string `mapstructure:"diagnostics_client_ca_file" yaml:"diagnostics_client_ca_file,omitempty"`
+
+	// HealthCheckToken is the bearer token which enables the deep checks of the health check
+	// endpoints. Deep checks are disabled when it's empty.
+	HealthCheckToken string `mapstructure:"health_check_token" yaml:"health_check_token,omitempty"`
+
 	// Tracing shared settings
 	TracingProvider   string  `mapstructure:"tracing_provider" yaml:"tracing_provider,omitempty"`
 	TracingSampleRate float64 `mapstructure:"tracing_sample_rate" yaml:"tracing_sample_rate,omitempty"`
@@ -218,6 +372,16 @@ type Options struct {
 	// Example: http://zipkin:9411/api/v2/spans
 	ZipkinEndpoint string `mapstructure:"tracing_zipkin_endpoint" yaml:"tracing_zipkin_endpoint"`
 
+	// OpenTelemetry
+	//
+	// TracingOTLPEndpoint is the url of the OpenTelemetry collector.
+	// Example: http://otel-collector:4317
+	TracingOTLPEndpoint string `mapstructure:"tracing_otlp_endpoint" yaml:"tracing_otlp_endpoint,omitempty"`
+	// TracingOTLPProtocol is the protocol used to export spans, grpc or http/protobuf.
+	TracingOTLPProtocol string `mapstructure:"tracing_otlp_protocol" yaml:"tracing_otlp_protocol,omitempty"`
+	// TracingOTLPHeaders are sent with each export, like an API key of the collector.
+	TracingOTLPHeaders map[string]string `mapstructure:"tracing_otlp_headers" yaml:"tracing_otlp_headers,omitempty"`
+
 	// GRPC Service Settings
 
 	// GRPCAddr specifies the host and port on which the server should serve
@@ -243,6 +407,9 @@ type Options struct {
 	DataBrokerURLString         string   `mapstructure:"databroker_service_url" yaml:"databroker_service_url,omitempty"`
 	DataBrokerURLStrings        []string `mapstructure:"databroker_service_urls" yaml:"databroker_service_urls,omitempty"`
 	DataBrokerInternalURLString string   `mapstructure:"databroker_internal_service_url" yaml:"databroker_internal_service_url,omitempty"`
+	// DataBrokerLoadBalancingPolicy is how requests are spread across the databroker service urls.
+	// Supported policies: round_robin, pick_first
+	DataBrokerLoadBalancingPolicy string `mapstructure:"databroker_load_balancing_policy" yaml:"databroker_load_balancing_policy,omitempty"`
 	// DataBrokerStorageType is the storage backend type that databroker will use.
 	// Supported type: memory, redis
 	DataBrokerStorageType string `mapstructure:"databroker_storage_type" yaml:"databroker_storage_type,omitempty"`
@@ -252,6 +419,12 @@ type Options struct {
 	DataBrokerStorageCertKeyFile      string `mapstructure:"databroker_storage_key_file" yaml:"databroker_storage_key_file,omitempty"`
 	DataBrokerStorageCAFile           string `mapstructure:"databroker_storage_ca_file" yaml:"databroker_storage_ca_file,omitempty"`
 	DataBrokerStorageCertSkipVerify   bool   `mapstructure:"databroker_storage_tls_skip_verify" yaml:"databroker_storage_tls_skip_verify,omitempty"`
+	// DataBrokerStorageCacheSize is the number of reads of the storage backend cached by the
+	// databroker. 0 disables the cache.
+	DataBrokerStorageCacheSize int `mapstructure:"databroker_storage_cache_size" yaml:"databroker_storage_cache_size,omitempty"`
+	// DataBrokerStorageSnapshotParallelism is the maximum number of ranges of records the
+	// postgres storage backend reads in parallel when syncing the latest records.
+	DataBrokerStorageSnapshotParallelism int `mapstructure:"databroker_storage_snapshot_parallelism" yaml:"databroker_storage_snapshot_parallelism,omitempty"`
 
 	// ClientCA is the base64-encoded certificate authority to validate client mTLS certificates against.
 	ClientCA string `mapstructure:"client_ca" yaml:"client_ca,omitempty"`
@@ -261,6 +434,16 @@ type Options struct {
 	ClientCRL string `mapstructure:"client_crl" yaml:"client_crl,omitempty"`
 	// ClientCRLFile points to a file that contains the certificate revocation list for client mTLS certificates.
 	ClientCRLFile string `mapstructure:"client_crl_file" yaml:"client_crl_file,omitempty"`
+	// ClientCRLURLs are the URLs of certificate revocation lists for client mTLS certificates,
+	// which are fetched every ClientCRLRefreshInterval.
+	ClientCRLURLs            []string      `mapstructure:"client_crl_urls" yaml:"client_crl_urls,omitempty"`
+	ClientCRLRefreshInterval time.Duration `mapstructure:"client_crl_refresh_interval" yaml:"client_crl_refresh_interval,omitempty"`
+	// ClientOCSP enables checking client mTLS certificates with the OCSP responders in the
+	// certificates.
+	ClientOCSP bool `mapstructure:"client_ocsp" yaml:"client_ocsp,omitempty"`
+	// ClientRevocationMode is soft_fail or hard_fail, and determines whether client mTLS
+	// certificates are accepted when their revocation status is unknown. Defaults to soft_fail.
+	ClientRevocationMode string `mapstructure:"client_revocation_mode" yaml:"client_revocation_mode,omitempty"`
 
 	// GoogleCloudServerlessAuthenticationServiceAccount is the service account to use for GCP serverless authentication.
 	// If unset, the GCP metadata server will be used to query for identity tokens.
@@ -270,6 +453,8 @@ type Options struct {
 	UseProxyProtocol bool `mapstructure:"use_proxy_protocol" yaml:"use_proxy_protocol,omitempty" json:"use_proxy_protocol,omitempty"`
 
 	viper *viper.Viper
+	// hasSecretReferences is true when any value was read from a secret reference.
+	hasSecretReferences bool
 
 	AutocertOptions `mapstructure:",squash" yaml:",inline"`
 
@@ -290,6 +475,49 @@ type Options struct {
 	// ProgrammaticRedirectDomainWhitelist restricts the allowed redirect URLs when using programmatic login.
 	ProgrammaticRedirectDomainWhitelist []string `mapstructure:"programmatic_redirect_domain_whitelist" yaml:"programmatic_redirect_domain_whitelist,omitempty" json:"programmatic_redirect_domain_whitelist,omitempty"` //nolint
 
+	// Branding customizes the pages served by Pomerium. Routes may override it.
+	Branding *Branding `mapstructure:"branding" yaml:"branding,omitempty" json:"branding,omitempty"`
+
+	// CircuitBreakerThresholds are the default circuit breaker thresholds of routes.
+	CircuitBreakerThresholds *CircuitBreakerThresholds `mapstructure:"circuit_breaker_thresholds" yaml:"circuit_breaker_thresholds,omitempty" json:"circuit_breaker_thresholds,omitempty"`
+
+	// ConsulAddress is the address of the Consul agent the endpoints of consul+ upstreams are
+	// discovered with. ConsulToken is the ACL token used to query it.
+	ConsulAddress string `mapstructure:"consul_address" yaml:"consul_address,omitempty" json:"consul_address,omitempty"`
+	ConsulToken   string `mapstructure:"consul_token" yaml:"consul_token,omitempty" json:"consul_token,omitempty"`
+	// ConsulConnectServiceName is the name of the Consul Connect service Pomerium connects to
+	// consul+ upstreams as. When set, consul+ upstreams are Connect services, connected to with
+	// the service's leaf certificate if their intentions allow it.
+	ConsulConnectServiceName string `mapstructure:"consul_connect_service_name" yaml:"consul_connect_service_name,omitempty" json:"consul_connect_service_name,omitempty"`
+	// NomadAddress is the address of the Nomad agent the endpoints of nomad+ upstreams are
+	// discovered with. NomadToken is the ACL token used to query it.
+	NomadAddress string `mapstructure:"nomad_address" yaml:"nomad_address,omitempty" json:"nomad_address,omitempty"`
+	NomadToken   string `mapstructure:"nomad_token" yaml:"nomad_token,omitempty" json:"nomad_token,omitempty"`
+	// DiscoveryRefreshInterval is how often the endpoints of discovered upstreams are re-resolved.
+	DiscoveryRefreshInterval time.Duration `mapstructure:"discovery_refresh_interval" yaml:"discovery_refresh_interval,omitempty" json:"discovery_refresh_interval,omitempty"`
+
+	// GitOpsRepository is the URL of a git repository routes are pulled from. GitOpsRef is the
+	// branch or tag, and GitOpsPath the file or directory of the routes in the repository.
+	GitOpsRepository   string        `mapstructure:"gitops_repository" yaml:"gitops_repository,omitempty" json:"gitops_repository,omitempty"`
+	GitOpsRef          string        `mapstructure:"gitops_ref" yaml:"gitops_ref,omitempty" json:"gitops_ref,omitempty"`
+	GitOpsPath         string        `mapstructure:"gitops_path" yaml:"gitops_path,omitempty" json:"gitops_path,omitempty"`
+	GitOpsPollInterval time.Duration `mapstructure:"gitops_poll_interval" yaml:"gitops_poll_interval,omitempty" json:"gitops_poll_interval,omitempty"`
+	// GitOpsAllowedSignersFile is an SSH allowed signers file. When set, only commits signed
+	// by one of its keys are applied.
+	GitOpsAllowedSignersFile string `mapstructure:"gitops_allowed_signers_file" yaml:"gitops_allowed_signers_file,omitempty" json:"gitops_allowed_signers_file,omitempty"`
+	// GitOpsWebhookAddress is the address of a listener for push webhooks, which trigger a pull
+	// immediately. Webhooks are authenticated with an HMAC of the GitOpsWebhookSecret.
+	GitOpsWebhookAddress string `mapstructure:"gitops_webhook_address" yaml:"gitops_webhook_address,omitempty" json:"gitops_webhook_address,omitempty"`
+	GitOpsWebhookSecret  string `mapstructure:"gitops_webhook_secret" yaml:"gitops_webhook_secret,omitempty" json:"gitops_webhook_secret,omitempty"`
+
+	// KubernetesGatewayClassName is the name of the GatewayClass of the Gateway API resources
+	// routes are programmed from, when running in a kubernetes cluster.
+	KubernetesGatewayClassName string `mapstructure:"kubernetes_gateway_class_name" yaml:"kubernetes_gateway_class_name,omitempty" json:"kubernetes_gateway_class_name,omitempty"`
+
+	// SecretsRefreshInterval is how often the values of secret references are re-read, so
+	// rotated secrets are applied.
+	SecretsRefreshInterval time.Duration `mapstructure:"secrets_refresh_interval" yaml:"secrets_refresh_interval,omitempty" json:"secrets_refresh_interval,omitempty"`
+
 	// CodecType is the codec to use for downstream connections.
 	CodecType CodecType `mapstructure:"codec_type" yaml:"codec_type"`
 
@@ -321,6 +549,7 @@ var defaultOptions = Options{
 	ReadTimeout:              30 * time.Second,
 	WriteTimeout:             0, // support streaming by default
 	IdleTimeout:              5 * time.Minute,
+	ListenerDrainTimeout:     time.Minute,
 	GRPCAddr:                 ":443",
 	GRPCClientTimeout:        10 * time.Second, // Try to withstand transient service failures for a single request
 	GRPCClientDNSRoundRobin:  true,
@@ -330,6 +559,12 @@ var defaultOptions = Options{
 	RefreshDirectoryTimeout:  1 * time.Minute,
 	QPS:                      1.0,
 
+	SigningKeyRotationOverlap: time.Hour,
+	ClientCRLRefreshInterval:  revocation.DefaultCRLRefreshInterval,
+
+	CertificateExpiryWarningThreshold:  30 * 24 * time.Hour,
+	CertificateExpiryCriticalThreshold: 7 * 24 * time.Hour,
+
 	AutocertOptions: AutocertOptions{
 		Folder: dataDir(),
 	},
@@ -375,13 +610,25 @@ func optionsFromViper(configFile string) (*Options, error) {
 		return nil, fmt.Errorf("failed to bind options to env vars: %w", err)
 	}
 
-	if configFile != "" {
+	if configFile != "" && isConfigDir(configFile) {
+		if err := readConfigDir(v, configFile); err != nil {
+			return nil, fmt.Errorf("failed to read config directory: %w", err)
+		}
+	} else if configFile != "" {
 		v.SetConfigFile(configFile)
 		if err := v.ReadInConfig(); err != nil {
 			return nil, fmt.Errorf("failed to read config: %w", err)
 		}
 	}
 
+	if err := interpolateConfig(v); err != nil {
+		return nil, fmt.Errorf("failed to interpolate config: %w", err)
+	}
+	hasSecretReferences, err := resolveSecretReferences(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
 	var metadata mapstructure.Metadata
 	if err := v.Unmarshal(o, ViperPolicyHooks, func(c *mapstructure.DecoderConfig) { c.Metadata = &metadata }); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -390,6 +637,7 @@ func optionsFromViper(configFile string) (*Options, error) {
 
 	// This is necessary because v.Unmarshal will overwrite .viper field.
 	o.viper = v
+	o.hasSecretReferences = hasSecretReferences
 
 	if err := o.Validate(); err != nil {
 		return nil, fmt.Errorf("validation error %w", err)
@@ -538,6 +786,12 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("config: %s is an invalid service type", o.Services)
 	}
 
+	switch o.DataBrokerLoadBalancingPolicy {
+	case "", grpcutil.RoundRobin, grpcutil.PickFirst:
+	default:
+		return fmt.Errorf("config: unknown databroker load balancing policy: %s", o.DataBrokerLoadBalancingPolicy)
+	}
+
 	switch o.DataBrokerStorageType {
 	case StorageInMemoryName:
 	case StorageRedisName, StoragePostgresName:
@@ -552,6 +806,9 @@ func (o *Options) Validate() error {
 	if err != nil {
 		return fmt.Errorf("config: invalid shared secret: %w", err)
 	}
+	if _, err := o.GetAcceptedSharedKeys(); err != nil {
+		return fmt.Errorf("config: invalid accepted shared secret: %w", err)
+	}
 
 	if o.AuthenticateURLString != "" {
 		_, err := urlutil.ParseAndValidateURL(o.AuthenticateURLString)
@@ -585,6 +842,38 @@ func (o *Options) Validate() error {
 			return fmt.Errorf("config: bad authorize-internal-url %s : %w", o.AuthorizeInternalURLString, err)
 		}
 	}
+	if o.AuthorizeDecisionCacheTTL < 0 {
+		return fmt.Errorf("config: authorize_decision_cache_ttl must not be negative: %s", o.AuthorizeDecisionCacheTTL)
+	}
+	if o.SessionIdleTimeout < 0 {
+		return fmt.Errorf("config: session_idle_timeout must not be negative: %s", o.SessionIdleTimeout)
+	}
+	if o.ListenerDrainTimeout < 0 {
+		return fmt.Errorf("config: listener_drain_timeout must not be negative: %s", o.ListenerDrainTimeout)
+	}
+	if o.IdentityProviderHealthCheckInterval < 0 {
+		return fmt.Errorf("config: idp_health_check_interval must not be negative: %s", o.IdentityProviderHealthCheckInterval)
+	}
+	switch o.SigningKeyAlgorithm {
+	case "", "ES256", "RS256", "EdDSA":
+	default:
+		return fmt.Errorf("config: unsupported signing_key_algorithm: %s", o.SigningKeyAlgorithm)
+	}
+	if fips.Enabled() && o.SigningKeyAlgorithm == "EdDSA" {
+		return fmt.Errorf("config: signing_key_algorithm EdDSA is not FIPS approved")
+	}
+	if o.SigningKeyRotationInterval < 0 {
+		return fmt.Errorf("config: signing_key_rotation_interval must not be negative: %s", o.SigningKeyRotationInterval)
+	}
+	if o.SigningKeyRotationInterval > 0 && o.SigningKey != "" {
+		return fmt.Errorf("config: signing_key and signing_key_rotation_interval cannot both be set")
+	}
+	if o.SigningKeyRotationOverlap < 0 {
+		return fmt.Errorf("config: signing_key_rotation_overlap must not be negative: %s", o.SigningKeyRotationOverlap)
+	}
+	if o.SigningKeyRotationInterval > 0 && o.SigningKeyRotationInterval <= o.SigningKeyRotationOverlap {
+		return fmt.Errorf("config: signing_key_rotation_interval must be longer than signing_key_rotation_overlap")
+	}
 
 	if o.DataBrokerURLString != "" {
 		_, err := urlutil.ParseAndValidateURL(o.DataBrokerURLString)
@@ -617,6 +906,64 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("config: failed to parse headers: %w", err)
 	}
 
+	if err := o.validateIdentityProviders(); err != nil {
+		return err
+	}
+
+	if err := o.validateClaimsMapping(); err != nil {
+		return err
+	}
+
+	if err := o.validateSigningKeySigners(); err != nil {
+		return err
+	}
+
+	if err := o.validateTokenExchangePolicies(); err != nil {
+		return err
+	}
+
+	if err := o.validateAccessLogFields(); err != nil {
+		return err
+	}
+
+	for i, sink := range o.AccessLogSinks {
+		if err := sink.Validate(); err != nil {
+			return fmt.Errorf("config: invalid access_log_sinks[%d]: %w", i, err)
+		}
+	}
+
+	for i, sink := range o.AuditSinks {
+		if err := sink.Validate(); err != nil {
+			return fmt.Errorf("config: invalid audit_sinks[%d]: %w", i, err)
+		}
+	}
+
+	if err := o.Branding.Validate(); err != nil {
+		return fmt.Errorf("config: invalid branding: %w", err)
+	}
+
+	if err := o.CircuitBreakerThresholds.Validate(); err != nil {
+		return fmt.Errorf("config: invalid circuit_breaker_thresholds: %w", err)
+	}
+
+	if o.ConsulAddress != "" {
+		if _, err := urlutil.ParseAndValidateURL(o.ConsulAddress); err != nil {
+			return fmt.Errorf("config: bad consul_address %s: %w", o.ConsulAddress, err)
+		}
+	}
+	if o.NomadAddress != "" {
+		if _, err := urlutil.ParseAndValidateURL(o.NomadAddress); err != nil {
+			return fmt.Errorf("config: bad nomad_address %s: %w", o.NomadAddress, err)
+		}
+	}
+	if o.DiscoveryRefreshInterval < 0 {
+		return fmt.Errorf("config: discovery_refresh_interval must not be negative")
+	}
+
+	if err := o.validateGitOps(); err != nil {
+		return err
+	}
+
 	hasCert := false
 
 	if o.Cert != "" || o.Key != "" {
@@ -686,6 +1033,49 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	for _, rawURL := range o.ClientCRLURLs {
+		if _, err := urlutil.ParseAndValidateURL(rawURL); err != nil {
+			return fmt.Errorf("config: bad client crl url %s: %w", rawURL, err)
+		}
+	}
+	if o.ClientCRLRefreshInterval < 0 {
+		return fmt.Errorf("config: client_crl_refresh_interval must not be negative: %s", o.ClientCRLRefreshInterval)
+	}
+	if _, err := revocation.ParseMode(o.ClientRevocationMode); err != nil {
+		return fmt.Errorf("config: bad client_revocation_mode: %w", err)
+	}
+
+	if o.CertificateExpiryWarningThreshold < 0 || o.CertificateExpiryCriticalThreshold < 0 {
+		return fmt.Errorf("config: certificate_expiry_warning_threshold and certificate_expiry_critical_threshold must not be negative")
+	}
+	if o.CertificateExpiryCriticalThreshold > o.CertificateExpiryWarningThreshold {
+		return fmt.Errorf("config: certificate_expiry_critical_threshold must not be greater than certificate_expiry_warning_threshold")
+	}
+
+	if o.SPIFFEWorkloadAPIAddress != "" {
+		if _, _, err := ParseSPIFFEWorkloadAPIAddress(o.SPIFFEWorkloadAPIAddress); err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+	} else {
+		for _, p := range o.GetAllPolicies() {
+			if p.TLSUpstreamSPIFFEID != "" || p.TLSUpstreamSPIFFETrustDomain != "" {
+				return fmt.Errorf("config: spiffe route settings require `spiffe_workload_api_address`")
+			}
+		}
+	}
+
+	if o.ConsulConnectServiceName != "" {
+		for _, p := range o.GetAllPolicies() {
+			urls := append(append(WeightedURLs{}, p.To...), p.MirrorTo...)
+			for _, g := range p.UpstreamGroups {
+				urls = append(urls, g.To...)
+			}
+			if err := validateConsulConnectURLs(urls); err != nil {
+				return fmt.Errorf("config: %w", err)
+			}
+		}
+	}
+
 	// if no service account was defined, there should not be any policies that
 	// assert group membership (except for azure which can be derived from the client
 	// id, secret and provider url)
@@ -749,6 +1139,26 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	if _, err := o.GetMetricsPushOptions(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	if err := metrics.ValidateRouteLatencyBuckets(o.MetricsRouteLatencyBuckets); err != nil {
+		return fmt.Errorf("config: invalid metrics_route_latency_buckets: %w", err)
+	}
+
+	if o.DiagnosticsAddr != "" {
+		if _, err := o.GetDiagnosticsClientCA(); err != nil {
+			return fmt.Errorf("config: invalid diagnostics_client_ca: %w", err)
+		}
+	}
+
+	for category, rate := range o.LogSampling {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("config: invalid log_sampling rate for %s: %v, must be between 0 and 1", category, rate)
+		}
+	}
+
 	// validate the Autocert options
 	err = o.AutocertOptions.Validate()
 	if err != nil {
@@ -818,6 +1228,47 @@ func (o *Options) GetInternalDataBrokerURLs() ([]*url.URL, error) {
 	return o.getURLs(rawurl)
 }
 
+// GetDataBrokerPoolOptions returns the options for a pool of connections to the DataBrokerURLs.
+// Calls are retried on another databroker when one is unavailable, and slow reads are hedged.
+func (o *Options) GetDataBrokerPoolOptions(serviceName string) (*grpcutil.PoolOptions, error) {
+	sharedKey, err := o.GetSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	dataBrokerURLs, err := o.GetDataBrokerURLs()
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcutil.PoolOptions{
+		Options: grpcutil.Options{
+			OverrideCertificateName: o.OverrideCertificateName,
+			CA:                      o.CA,
+			CAFile:                  o.CAFile,
+			RequestTimeout:          o.GRPCClientTimeout,
+			ServiceName:             serviceName,
+			SignedJWTKey:            sharedKey,
+		},
+		Addresses:              dataBrokerURLs,
+		LoadBalancingPolicy:    o.DataBrokerLoadBalancingPolicy,
+		HealthCheckServiceName: "pomerium-databroker",
+		RetriedServices: []string{
+			"databroker.DataBrokerService",
+			"directory.DirectoryService",
+			"pomerium.device.DeviceService",
+			"registry.Registry",
+			"session.ImpersonationService",
+			"session.SessionService",
+			"user.ServiceAccountService",
+		},
+		HedgedMethods: []string{
+			"/databroker.DataBrokerService/Get",
+			"/databroker.DataBrokerService/Query",
+			"/databroker.DataBrokerService/ServerInfo",
+		},
+	}, nil
+}
+
 func (o *Options) getURLs(strs ...string) ([]*url.URL, error) {
 	var urls []*url.URL
 	if o != nil {
@@ -874,6 +1325,53 @@ func (o *Options) GetSignOutRedirectURL() (*url.URL, error) {
 	return urlutil.ParseAndValidateURL(rawurl)
 }
 
+// GetMetricsPushOptions returns the options for pushing metrics.
+func (o *Options) GetMetricsPushOptions() (*metrics.PushOptions, error) {
+	opts := &metrics.PushOptions{
+		Service:            telemetry.ServiceName(o.Services),
+		Interval:           o.MetricsPushInterval,
+		OTLPProtocol:       o.MetricsOTLPProtocol,
+		OTLPHeaders:        o.MetricsOTLPHeaders,
+		StatsdAddress:      o.MetricsStatsdAddress,
+		StatsdFlavor:       o.MetricsStatsdFlavor,
+		StatsdPrefix:       o.MetricsStatsdPrefix,
+		ResourceAttributes: o.MetricsResourceAttributes,
+	}
+	if opts.Interval == 0 {
+		opts.Interval = metrics.DefaultPushInterval
+	}
+	if opts.StatsdPrefix == "" {
+		opts.StatsdPrefix = "pomerium"
+	}
+
+	if o.MetricsPushInterval != 0 && o.MetricsPushInterval < time.Second {
+		return nil, fmt.Errorf("metrics_push_interval must be at least 1s")
+	}
+	if o.MetricsOTLPEndpoint != "" {
+		u, err := urlutil.ParseAndValidateURL(o.MetricsOTLPEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics_otlp_endpoint: %w", err)
+		}
+		opts.OTLPEndpoint = u
+	}
+	switch o.MetricsOTLPProtocol {
+	case "", otlpexport.ProtocolGRPC, otlpexport.ProtocolHTTP:
+	default:
+		return nil, fmt.Errorf("unknown metrics_otlp_protocol: %s", o.MetricsOTLPProtocol)
+	}
+	if o.MetricsStatsdAddress != "" {
+		if _, _, err := net.SplitHostPort(o.MetricsStatsdAddress); err != nil {
+			return nil, fmt.Errorf("invalid metrics_statsd_address: %w", err)
+		}
+	}
+	switch o.MetricsStatsdFlavor {
+	case "", metrics.StatsdFlavorStatsd, metrics.StatsdFlavorDogStatsd:
+	default:
+		return nil, fmt.Errorf("unknown metrics_statsd_flavor: %s", o.MetricsStatsdFlavor)
+	}
+	return opts, nil
+}
+
 // GetMetricsCertificate returns the metrics certificate to use for TLS. `nil` will be
 // returned if there is no certificate.
 func (o *Options) GetMetricsCertificate() (*tls.Certificate, error) {
@@ -903,9 +1401,26 @@ func (o *Options) GetOauthOptions() (oauth.Options, error) {
 		ClientSecret:   o.ClientSecret,
 		Scopes:         o.Scopes,
 		ServiceAccount: o.ServiceAccount,
+
+		SAMLCertificate:      o.SAMLCertificate,
+		SAMLKey:              o.SAMLKey,
+		SAMLAttributeMapping: o.SAMLAttributeMapping,
+
+		ClaimsMapping: o.GetClaimsMapping(),
 	}, nil
 }
 
+// BindsSessionsToClientCertificates returns true if any policy binds sessions to client
+// certificates.
+func (o *Options) BindsSessionsToClientCertificates() bool {
+	for _, p := range o.GetAllPolicies() {
+		if p.BindSessionToClientCertificate {
+			return true
+		}
+	}
+	return false
+}
+
 // GetAllPolicies gets all the policies in the options.
 func (o *Options) GetAllPolicies() []Policy {
 	if o == nil {
@@ -949,6 +1464,50 @@ func (o *Options) GetClientCA() ([]byte, error) {
 	return nil, nil
 }
 
+// GetClientCRLs returns the certificate revocation lists for client mTLS certificates.
+func (o *Options) GetClientCRLs() ([]*pkix.CertificateList, error) {
+	var crls []*pkix.CertificateList
+	if o.ClientCRL != "" {
+		crl, err := cryptutil.CRLFromBase64(o.ClientCRL)
+		if err != nil {
+			return nil, err
+		}
+		crls = append(crls, crl)
+	}
+	if o.ClientCRLFile != "" {
+		crl, err := cryptutil.CRLFromFile(o.ClientCRLFile)
+		if err != nil {
+			return nil, err
+		}
+		crls = append(crls, crl)
+	}
+	return crls, nil
+}
+
+// GetDiagnosticsClientCA returns the certificate pool of the CAs of the clients of the diagnostics
+// endpoint, which is required.
+func (o *Options) GetDiagnosticsClientCA() (*x509.CertPool, error) {
+	var bs []byte
+	var err error
+	switch {
+	case o.DiagnosticsClientCA != "":
+		bs, err = base64.StdEncoding.DecodeString(o.DiagnosticsClientCA)
+	case o.DiagnosticsClientCAFile != "":
+		bs, err = os.ReadFile(o.DiagnosticsClientCAFile)
+	default:
+		return nil, fmt.Errorf("diagnostics_client_ca or diagnostics_client_ca_file is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bs) {
+		return nil, fmt.Errorf("no PEM encoded certificates found")
+	}
+	return pool, nil
+}
+
 // GetDataBrokerCertificate gets the optional databroker certificate. This method will return nil if no certificate is
 // specified.
 func (o *Options) GetDataBrokerCertificate() (*tls.Certificate, error) {
@@ -988,20 +1547,56 @@ func (o *Options) GetCertificates() ([]tls.Certificate, error) {
 	return certs, nil
 }
 
-// GetSharedKey gets the decoded shared key.
+// GetSharedKey gets the decoded shared key. It's kept in locked memory, and must not be
+// modified.
 func (o *Options) GetSharedKey() ([]byte, error) {
 	sharedKey := o.SharedKey
 	// mutual auth between services on the same host can be generated at runtime
 	if IsAll(o.Services) && o.SharedKey == "" && o.DataBrokerStorageType == StorageInMemoryName {
 		sharedKey = randomSharedKey
 	}
+	return decodeSharedKey(sharedKey)
+}
+
+// GetAcceptedSharedKeys gets the decoded shared key, followed by the decoded accepted shared
+// keys. Requests signed by any of them are accepted.
+func (o *Options) GetAcceptedSharedKeys() ([][]byte, error) {
+	sharedKey, err := o.GetSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	keys := [][]byte{sharedKey}
+	for _, raw := range o.AcceptedSharedKeys {
+		key, err := decodeSharedKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func decodeSharedKey(sharedKey string) ([]byte, error) {
 	if sharedKey == "" {
 		return nil, errors.New("empty shared secret")
 	}
 	if strings.TrimSpace(sharedKey) != sharedKey {
 		return nil, errors.New("shared secret contains whitespace")
 	}
-	return base64.StdEncoding.DecodeString(sharedKey)
+	return secretmem.DecodeBase64(sharedKey)
+}
+
+// GetSecrets gets the base64-encoded secrets which are decoded into locked memory, so the buffers
+// of secrets which are no longer used can be destroyed.
+func (o *Options) GetSecrets() []string {
+	secrets := []string{o.SharedKey, randomSharedKey, o.CookieSecret}
+	return append(secrets, o.AcceptedSharedKeys...)
+}
+
+// GetCookieSecret gets the decoded cookie secret. It's kept in locked memory, and must not be
+// modified.
+func (o *Options) GetCookieSecret() ([]byte, error) {
+	return secretmem.DecodeBase64(o.CookieSecret)
 }
 
 // GetGoogleCloudServerlessAuthenticationServiceAccount gets the GoogleCloudServerlessAuthenticationServiceAccount.
@@ -1020,6 +1615,15 @@ func (o *Options) GetSetResponseHeaders() map[string]string {
 	return o.SetResponseHeaders
 }
 
+// GetSessionIdleTimeout gets the session idle timeout for the policy, which may be nil. Zero
+// means sessions don't time out.
+func (o *Options) GetSessionIdleTimeout(policy *Policy) time.Duration {
+	if policy != nil && policy.SessionIdleTimeout != nil {
+		return *policy.SessionIdleTimeout
+	}
+	return o.SessionIdleTimeout
+}
+
 // GetQPS gets the QPS.
 func (o *Options) GetQPS() float64 {
 	if o.QPS < 1 {
@@ -1254,9 +1858,42 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.ProxyLogLevel != nil {
 		o.ProxyLogLevel = settings.GetProxyLogLevel()
 	}
+	if len(settings.LogSampling) > 0 {
+		o.LogSampling = settings.GetLogSampling()
+	}
+	if len(settings.AccessLogSinks) > 0 {
+		o.AccessLogSinks = make([]AccessLogSink, len(settings.AccessLogSinks))
+		for i, sink := range settings.AccessLogSinks {
+			o.AccessLogSinks[i] = NewAccessLogSinkFromProto(sink)
+		}
+	}
+	if len(settings.AccessLogFields) > 0 {
+		o.AccessLogFields = settings.GetAccessLogFields()
+	}
+	if len(settings.AuditSinks) > 0 {
+		o.AuditSinks = make([]AuditSink, len(settings.AuditSinks))
+		for i, sink := range settings.AuditSinks {
+			o.AuditSinks[i] = NewAuditSinkFromProto(sink)
+		}
+	}
+	if settings.AuditSpillDirectory != nil {
+		o.AuditSpillDirectory = settings.GetAuditSpillDirectory()
+	}
+	if settings.AuditTrail != nil {
+		o.AuditTrail = settings.GetAuditTrail()
+	}
+	if len(settings.ImpersonationRequesterGroups) > 0 {
+		o.ImpersonationRequesterGroups = settings.GetImpersonationRequesterGroups()
+	}
+	if len(settings.ImpersonationApproverGroups) > 0 {
+		o.ImpersonationApproverGroups = settings.GetImpersonationApproverGroups()
+	}
 	if settings.SharedSecret != nil {
 		o.SharedKey = settings.GetSharedSecret()
 	}
+	if len(settings.AcceptedSharedSecrets) > 0 {
+		o.AcceptedSharedKeys = settings.GetAcceptedSharedSecrets()
+	}
 	if settings.Services != nil {
 		o.Services = settings.GetServices()
 	}
@@ -1282,6 +1919,9 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.TimeoutIdle != nil {
 		o.IdleTimeout = settings.GetTimeoutIdle().AsDuration()
 	}
+	if settings.ListenerDrainTimeout != nil {
+		o.ListenerDrainTimeout = settings.GetListenerDrainTimeout().AsDuration()
+	}
 	if settings.AuthenticateServiceUrl != nil {
 		o.AuthenticateURLString = settings.GetAuthenticateServiceUrl()
 	}
@@ -1309,6 +1949,12 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.CookieExpire != nil {
 		o.CookieExpire = settings.GetCookieExpire().AsDuration()
 	}
+	if settings.CookieCompression != nil {
+		o.CookieCompression = settings.GetCookieCompression()
+	}
+	if settings.SessionIdleTimeout != nil {
+		o.SessionIdleTimeout = settings.GetSessionIdleTimeout().AsDuration()
+	}
 	if settings.IdpClientId != nil {
 		o.ClientID = settings.GetIdpClientId()
 	}
@@ -1327,12 +1973,54 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.IdpServiceAccount != nil {
 		o.ServiceAccount = settings.GetIdpServiceAccount()
 	}
+	if settings.IdpSamlCertificate != nil {
+		o.SAMLCertificate = settings.GetIdpSamlCertificate()
+	}
+	if settings.IdpSamlKey != nil {
+		o.SAMLKey = settings.GetIdpSamlKey()
+	}
+	if len(settings.IdpSamlAttributeMapping) > 0 {
+		o.SAMLAttributeMapping = settings.IdpSamlAttributeMapping
+	}
+	if len(settings.IdentityProviders) > 0 {
+		o.IdentityProviders = make(map[string]IdentityProvider, len(settings.IdentityProviders))
+		for name, idp := range settings.IdentityProviders {
+			o.IdentityProviders[name] = NewIdentityProviderFromProto(idp)
+		}
+	}
+	if len(settings.ClaimsMapping) > 0 {
+		o.ClaimsMapping = make([]ClaimMapping, len(settings.ClaimsMapping))
+		for i, cm := range settings.ClaimsMapping {
+			o.ClaimsMapping[i] = NewClaimMappingFromProto(cm)
+		}
+	}
 	if settings.IdpRefreshDirectoryTimeout != nil {
 		o.RefreshDirectoryTimeout = settings.GetIdpRefreshDirectoryTimeout().AsDuration()
 	}
 	if settings.IdpRefreshDirectoryInterval != nil {
 		o.RefreshDirectoryInterval = settings.GetIdpRefreshDirectoryInterval().AsDuration()
 	}
+	if settings.IdpHealthCheckInterval != nil {
+		o.IdentityProviderHealthCheckInterval = settings.GetIdpHealthCheckInterval().AsDuration()
+	}
+	if settings.IdpFailover != nil {
+		o.IdentityProviderFailover = settings.GetIdpFailover()
+	}
+	if settings.IdpGraceMode != nil {
+		o.IdentityProviderGraceMode = settings.GetIdpGraceMode()
+	}
+	if settings.ScimBearerToken != nil {
+		o.SCIMBearerToken = settings.GetScimBearerToken()
+	}
+	if settings.AdminApiBearerToken != nil {
+		o.AdminAPIBearerToken = settings.GetAdminApiBearerToken()
+	}
+	if settings.PasskeySignIn != nil {
+		o.PasskeySignIn = settings.GetPasskeySignIn()
+	}
+	if settings.MaxDevicesPerUser != nil {
+		o.MaxDevicesPerUser = settings.GetMaxDevicesPerUser()
+	}
 	if settings.RequestParams != nil && len(settings.RequestParams) > 0 {
 		o.RequestParams = settings.RequestParams
 	}
@@ -1342,6 +2030,9 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.AuthorizeInternalServiceUrl != nil {
 		o.AuthorizeInternalURLString = settings.GetAuthorizeInternalServiceUrl()
 	}
+	if settings.AuthorizeDecisionCacheTtl != nil {
+		o.AuthorizeDecisionCacheTTL = settings.GetAuthorizeDecisionCacheTtl().AsDuration()
+	}
 	if settings.OverrideCertificateName != nil {
 		o.OverrideCertificateName = settings.GetOverrideCertificateName()
 	}
@@ -1351,9 +2042,45 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.CertificateAuthorityFile != nil {
 		o.CAFile = settings.GetCertificateAuthorityFile()
 	}
+	if settings.SpiffeWorkloadApiAddress != nil {
+		o.SPIFFEWorkloadAPIAddress = settings.GetSpiffeWorkloadApiAddress()
+	}
 	if settings.SigningKey != nil {
 		o.SigningKey = settings.GetSigningKey()
 	}
+	if settings.SigningKeyAlgorithm != nil {
+		o.SigningKeyAlgorithm = settings.GetSigningKeyAlgorithm()
+	}
+	if settings.SigningKeyRotationInterval != nil {
+		o.SigningKeyRotationInterval = settings.GetSigningKeyRotationInterval().AsDuration()
+	}
+	if settings.SigningKeyRotationOverlap != nil {
+		o.SigningKeyRotationOverlap = settings.GetSigningKeyRotationOverlap().AsDuration()
+	}
+	if settings.SigningKeyPkcs11Label != nil {
+		o.SigningKeyPKCS11Label = settings.GetSigningKeyPkcs11Label()
+	}
+	if settings.Pkcs11ModulePath != nil {
+		o.PKCS11ModulePath = settings.GetPkcs11ModulePath()
+	}
+	if settings.Pkcs11TokenLabel != nil {
+		o.PKCS11TokenLabel = settings.GetPkcs11TokenLabel()
+	}
+	if settings.Pkcs11Pin != nil {
+		o.PKCS11PIN = settings.GetPkcs11Pin()
+	}
+	if settings.Pkcs11PinFile != nil {
+		o.PKCS11PINFile = settings.GetPkcs11PinFile()
+	}
+	if settings.SigningKeyKms != nil {
+		o.SigningKeyKMS = settings.GetSigningKeyKms()
+	}
+	if len(settings.TokenExchangePolicies) > 0 {
+		o.TokenExchangePolicies = make([]TokenExchangePolicy, len(settings.TokenExchangePolicies))
+		for i, policy := range settings.TokenExchangePolicies {
+			o.TokenExchangePolicies[i] = NewTokenExchangePolicyFromProto(policy)
+		}
+	}
 	if settings.SetResponseHeaders != nil && len(settings.SetResponseHeaders) > 0 {
 		o.SetResponseHeaders = settings.SetResponseHeaders
 	}
@@ -1387,6 +2114,54 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.GetMetricsClientCaFile() != "" {
 		o.MetricsClientCAFile = settings.GetMetricsClientCaFile()
 	}
+	if settings.MetricsPushInterval != nil {
+		o.MetricsPushInterval = settings.GetMetricsPushInterval().AsDuration()
+	}
+	if settings.MetricsOtlpEndpoint != nil {
+		o.MetricsOTLPEndpoint = settings.GetMetricsOtlpEndpoint()
+	}
+	if settings.MetricsOtlpProtocol != nil {
+		o.MetricsOTLPProtocol = settings.GetMetricsOtlpProtocol()
+	}
+	if len(settings.MetricsOtlpHeaders) > 0 {
+		o.MetricsOTLPHeaders = settings.MetricsOtlpHeaders
+	}
+	if settings.MetricsStatsdAddress != nil {
+		o.MetricsStatsdAddress = settings.GetMetricsStatsdAddress()
+	}
+	if settings.MetricsStatsdFlavor != nil {
+		o.MetricsStatsdFlavor = settings.GetMetricsStatsdFlavor()
+	}
+	if settings.MetricsStatsdPrefix != nil {
+		o.MetricsStatsdPrefix = settings.GetMetricsStatsdPrefix()
+	}
+	if len(settings.MetricsResourceAttributes) > 0 {
+		o.MetricsResourceAttributes = settings.MetricsResourceAttributes
+	}
+	if len(settings.MetricsRoutes) > 0 {
+		o.MetricsRoutes = settings.GetMetricsRoutes()
+	}
+	if settings.CertificateExpiryWarningThreshold != nil {
+		o.CertificateExpiryWarningThreshold = settings.GetCertificateExpiryWarningThreshold().AsDuration()
+	}
+	if settings.CertificateExpiryCriticalThreshold != nil {
+		o.CertificateExpiryCriticalThreshold = settings.GetCertificateExpiryCriticalThreshold().AsDuration()
+	}
+	if len(settings.MetricsRouteLatencyBuckets) > 0 {
+		o.MetricsRouteLatencyBuckets = settings.GetMetricsRouteLatencyBuckets()
+	}
+	if settings.DiagnosticsAddress != nil {
+		o.DiagnosticsAddr = settings.GetDiagnosticsAddress()
+	}
+	if settings.DiagnosticsClientCa != nil {
+		o.DiagnosticsClientCA = settings.GetDiagnosticsClientCa()
+	}
+	if settings.DiagnosticsClientCaFile != nil {
+		o.DiagnosticsClientCAFile = settings.GetDiagnosticsClientCaFile()
+	}
+	if settings.HealthCheckToken != nil {
+		o.HealthCheckToken = settings.GetHealthCheckToken()
+	}
 	if settings.TracingProvider != nil {
 		o.TracingProvider = settings.GetTracingProvider()
 	}
@@ -1402,6 +2177,15 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.TracingZipkinEndpoint != nil {
 		o.ZipkinEndpoint = settings.GetTracingZipkinEndpoint()
 	}
+	if settings.TracingOtlpEndpoint != nil {
+		o.TracingOTLPEndpoint = settings.GetTracingOtlpEndpoint()
+	}
+	if settings.TracingOtlpProtocol != nil {
+		o.TracingOTLPProtocol = settings.GetTracingOtlpProtocol()
+	}
+	if len(settings.TracingOtlpHeaders) > 0 {
+		o.TracingOTLPHeaders = settings.TracingOtlpHeaders
+	}
 	if settings.GrpcAddress != nil {
 		o.GRPCAddr = settings.GetGrpcAddress()
 	}
@@ -1414,6 +2198,9 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if len(settings.DatabrokerServiceUrls) > 0 {
 		o.DataBrokerURLStrings = settings.GetDatabrokerServiceUrls()
 	}
+	if settings.DatabrokerLoadBalancingPolicy != nil {
+		o.DataBrokerLoadBalancingPolicy = settings.GetDatabrokerLoadBalancingPolicy()
+	}
 	if settings.DatabrokerInternalServiceUrl != nil {
 		o.DataBrokerInternalURLString = settings.GetDatabrokerInternalServiceUrl()
 	}
@@ -1456,6 +2243,15 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.AutocertTrustedCaFile != nil {
 		o.AutocertOptions.TrustedCAFile = settings.GetAutocertTrustedCaFile()
 	}
+	if settings.AutocertDnsProvider != nil {
+		o.AutocertOptions.DNSProvider = settings.GetAutocertDnsProvider()
+	}
+	if settings.AutocertStorage != nil {
+		o.AutocertOptions.Storage = settings.GetAutocertStorage()
+	}
+	if len(settings.AutocertDnsProviderOptions) > 0 {
+		o.AutocertOptions.DNSProviderOptions = settings.AutocertDnsProviderOptions
+	}
 	if settings.SkipXffAppend != nil {
 		o.SkipXffAppend = settings.GetSkipXffAppend()
 	}
@@ -1465,6 +2261,54 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if len(settings.ProgrammaticRedirectDomainWhitelist) > 0 {
 		o.ProgrammaticRedirectDomainWhitelist = settings.GetProgrammaticRedirectDomainWhitelist()
 	}
+	if settings.Branding != nil {
+		o.Branding = NewBrandingFromProto(settings.GetBranding())
+	}
+	if settings.CircuitBreakerThresholds != nil {
+		o.CircuitBreakerThresholds = NewCircuitBreakerThresholdsFromProto(settings.GetCircuitBreakerThresholds())
+	}
+	if settings.ConsulAddress != nil {
+		o.ConsulAddress = settings.GetConsulAddress()
+	}
+	if settings.ConsulToken != nil {
+		o.ConsulToken = settings.GetConsulToken()
+	}
+	if settings.ConsulConnectServiceName != nil {
+		o.ConsulConnectServiceName = settings.GetConsulConnectServiceName()
+	}
+	if settings.NomadAddress != nil {
+		o.NomadAddress = settings.GetNomadAddress()
+	}
+	if settings.NomadToken != nil {
+		o.NomadToken = settings.GetNomadToken()
+	}
+	if settings.DiscoveryRefreshInterval != nil {
+		o.DiscoveryRefreshInterval = settings.GetDiscoveryRefreshInterval().AsDuration()
+	}
+	if settings.GitopsRepository != nil {
+		o.GitOpsRepository = settings.GetGitopsRepository()
+	}
+	if settings.GitopsRef != nil {
+		o.GitOpsRef = settings.GetGitopsRef()
+	}
+	if settings.GitopsPath != nil {
+		o.GitOpsPath = settings.GetGitopsPath()
+	}
+	if settings.GitopsPollInterval != nil {
+		o.GitOpsPollInterval = settings.GetGitopsPollInterval().AsDuration()
+	}
+	if settings.GitopsAllowedSignersFile != nil {
+		o.GitOpsAllowedSignersFile = settings.GetGitopsAllowedSignersFile()
+	}
+	if settings.GitopsWebhookAddress != nil {
+		o.GitOpsWebhookAddress = settings.GetGitopsWebhookAddress()
+	}
+	if settings.GitopsWebhookSecret != nil {
+		o.GitOpsWebhookSecret = settings.GetGitopsWebhookSecret()
+	}
+	if settings.KubernetesGatewayClassName != nil {
+		o.KubernetesGatewayClassName = settings.GetKubernetesGatewayClassName()
+	}
 	if settings.AuditKey != nil {
 		o.AuditKey = &PublicKeyEncryptionKeyOptions{
 			ID:   settings.AuditKey.GetId(),
@@ -1480,6 +2324,18 @@ func (o *Options) ApplySettings(ctx context.Context, settings *config.Settings)
 	if settings.ClientCrlFile != nil {
 		o.ClientCRLFile = settings.GetClientCrlFile()
 	}
+	if len(settings.ClientCrlUrls) > 0 {
+		o.ClientCRLURLs = settings.GetClientCrlUrls()
+	}
+	if settings.ClientCrlRefreshInterval != nil {
+		o.ClientCRLRefreshInterval = settings.GetClientCrlRefreshInterval().AsDuration()
+	}
+	if settings.ClientOcsp != nil {
+		o.ClientOCSP = settings.GetClientOcsp()
+	}
+	if settings.ClientRevocationMode != nil {
+		o.ClientRevocationMode = settings.GetClientRevocationMode()
+	}
 }
 
 func dataDir() string {
@@ -45,9 +45,38 @@ func Test_Validate(t *testing.T) {
 	invalidStorageType.DataBrokerStorageType = "foo"
 	missingStorageDSN := testOptions()
 	missingStorageDSN.DataBrokerStorageType = "redis"
+	invalidLoadBalancingPolicy := testOptions()
+	invalidLoadBalancingPolicy.DataBrokerLoadBalancingPolicy = "random"
 	badSignoutRedirectURL := testOptions()
 	badSignoutRedirectURL.SignOutRedirectURLString = "--"
 
+	badMetricsStatsdFlavor := testOptions()
+	badMetricsStatsdFlavor.MetricsStatsdAddress = "127.0.0.1:8125"
+	badMetricsStatsdFlavor.MetricsStatsdFlavor = "graphite"
+	badMetricsPushInterval := testOptions()
+	badMetricsPushInterval.MetricsOTLPEndpoint = "http://otel-collector:4317"
+	badMetricsPushInterval.MetricsPushInterval = time.Millisecond
+	badAccessLogSink := testOptions()
+	badAccessLogSink.AccessLogSinks = []AccessLogSink{{Type: AccessLogSinkTypeFile}}
+	badAccessLogField := testOptions()
+	badAccessLogField.AccessLogFields = []string{"path", "request.header.X-Tenant"}
+
+	badSigningKeyRotationInterval := testOptions()
+	badSigningKeyRotationInterval.SigningKeyRotationInterval = time.Hour
+	badSigningKeyRotationInterval.SigningKeyRotationOverlap = 2 * time.Hour
+
+	badClientCRLURL := testOptions()
+	badClientCRLURL.ClientCRLURLs = []string{"crl.example.com"}
+	badClientRevocationMode := testOptions()
+	badClientRevocationMode.ClientRevocationMode = "fail_closed"
+	badCertificateExpiryThresholds := testOptions()
+	badCertificateExpiryThresholds.CertificateExpiryCriticalThreshold = 60 * 24 * time.Hour
+	badAcceptedSharedSecret := testOptions()
+	badAcceptedSharedSecret.AcceptedSharedKeys = []string{"not base64!"}
+	badConsulConnectUpstream := testOptions()
+	badConsulConnectUpstream.ConsulConnectServiceName = "pomerium"
+	badConsulConnectUpstream.Policies = []Policy{{From: "https://from.example.com", To: mustParseWeightedURLs(t, "consul+http://api")}}
+
 	missingSharedSecretWithPersistence := testOptions()
 	missingSharedSecretWithPersistence.SharedKey = ""
 	missingSharedSecretWithPersistence.DataBrokerStorageType = StorageRedisName
@@ -65,8 +94,19 @@ func Test_Validate(t *testing.T) {
 		{"policy file specified", badPolicyFile, true},
 		{"invalid databroker storage type", invalidStorageType, true},
 		{"missing databroker storage dsn", missingStorageDSN, true},
+		{"invalid databroker load balancing policy", invalidLoadBalancingPolicy, true},
 		{"invalid signout redirect url", badSignoutRedirectURL, true},
 		{"no shared key with databroker persistence", missingSharedSecretWithPersistence, true},
+		{"invalid metrics statsd flavor", badMetricsStatsdFlavor, true},
+		{"invalid metrics push interval", badMetricsPushInterval, true},
+		{"access log file sink without a path", badAccessLogSink, true},
+		{"access log header field with an uppercase name", badAccessLogField, true},
+		{"signing key rotation interval shorter than the overlap", badSigningKeyRotationInterval, true},
+		{"invalid client crl url", badClientCRLURL, true},
+		{"invalid client revocation mode", badClientRevocationMode, true},
+		{"certificate expiry critical threshold greater than the warning threshold", badCertificateExpiryThresholds, true},
+		{"invalid accepted shared secret", badAcceptedSharedSecret, true},
+		{"consul connect upstream without https", badConsulConnectUpstream, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -305,13 +345,18 @@ func TestOptionsFromViper(t *testing.T) {
 					"X-Frame-Options":           "SAMEORIGIN",
 					"X-XSS-Protection":          "1; mode=block",
 				},
-				RefreshDirectoryTimeout:  1 * time.Minute,
-				RefreshDirectoryInterval: 10 * time.Minute,
-				QPS:                      1.0,
-				DataBrokerStorageType:    "memory",
-				EnvoyAdminAccessLogPath:  os.DevNull,
-				EnvoyAdminProfilePath:    os.DevNull,
-				EnvoyAdminAddress:        "127.0.0.1:9901",
+				RefreshDirectoryTimeout:            1 * time.Minute,
+				RefreshDirectoryInterval:           10 * time.Minute,
+				QPS:                                1.0,
+				SigningKeyRotationOverlap:          time.Hour,
+				ClientCRLRefreshInterval:           time.Hour,
+				CertificateExpiryWarningThreshold:  30 * 24 * time.Hour,
+				CertificateExpiryCriticalThreshold: 7 * 24 * time.Hour,
+				ListenerDrainTimeout:               time.Minute,
+				DataBrokerStorageType:              "memory",
+				EnvoyAdminAccessLogPath:            os.DevNull,
+				EnvoyAdminProfilePath:              os.DevNull,
+				EnvoyAdminAddress:                  "127.0.0.1:9901",
 			},
 			false,
 		},
@@ -319,20 +364,25 @@ func TestOptionsFromViper(t *testing.T) {
 			"good disable header",
 			[]byte(`{"autocert_dir":"","insecure_server":true,"set_response_headers": {"disable":"true"},"policy":[{"from": "https://from.example","to":"https://to.example"}]}`),
 			&Options{
-				Policies:                 []Policy{{From: "https://from.example", To: mustParseWeightedURLs(t, "https://to.example")}},
-				CookieName:               "_pomerium",
-				AuthenticateCallbackPath: "/oauth2/callback",
-				CookieSecure:             true,
-				CookieHTTPOnly:           true,
-				InsecureServer:           true,
-				SetResponseHeaders:       map[string]string{"disable": "true"},
-				RefreshDirectoryTimeout:  1 * time.Minute,
-				RefreshDirectoryInterval: 10 * time.Minute,
-				QPS:                      1.0,
-				DataBrokerStorageType:    "memory",
-				EnvoyAdminAccessLogPath:  os.DevNull,
-				EnvoyAdminProfilePath:    os.DevNull,
-				EnvoyAdminAddress:        "127.0.0.1:9901",
+				Policies:                           []Policy{{From: "https://from.example", To: mustParseWeightedURLs(t, "https://to.example")}},
+				CookieName:                         "_pomerium",
+				AuthenticateCallbackPath:           "/oauth2/callback",
+				CookieSecure:                       true,
+				CookieHTTPOnly:                     true,
+				InsecureServer:                     true,
+				SetResponseHeaders:                 map[string]string{"disable": "true"},
+				RefreshDirectoryTimeout:            1 * time.Minute,
+				RefreshDirectoryInterval:           10 * time.Minute,
+				QPS:                                1.0,
+				SigningKeyRotationOverlap:          time.Hour,
+				ClientCRLRefreshInterval:           time.Hour,
+				CertificateExpiryWarningThreshold:  30 * 24 * time.Hour,
+				CertificateExpiryCriticalThreshold: 7 * 24 * time.Hour,
+				ListenerDrainTimeout:               time.Minute,
+				DataBrokerStorageType:              "memory",
+				EnvoyAdminAccessLogPath:            os.DevNull,
+				EnvoyAdminProfilePath:              os.DevNull,
+				EnvoyAdminAddress:                  "127.0.0.1:9901",
 			},
 			false,
 		},
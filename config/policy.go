@@ -6,10 +6,13 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -19,6 +22,7 @@ import (
 	"github.com/pomerium/pomerium/internal/hashutil"
 	"github.com/pomerium/pomerium/internal/identity"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/revocation"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
@@ -36,6 +40,29 @@ type Policy struct {
 	// Redirect is used for a redirect action instead of `To`
 	Redirect *PolicyRedirect `mapstructure:"redirect" yaml:"redirect"`
 
+	// Response is returned directly by the proxy instead of proxying to `To`.
+	Response *PolicyDirectResponse `mapstructure:"response" yaml:"response,omitempty" json:"response,omitempty"`
+
+	// DenyResponse customizes the response returned when access to the route is denied.
+	DenyResponse *PolicyDenyResponse `mapstructure:"deny_response" yaml:"deny_response,omitempty" json:"deny_response,omitempty"`
+
+	// ErrorPages replace the default error pages of the route.
+	ErrorPages []PolicyErrorPage `mapstructure:"error_pages" yaml:"error_pages,omitempty" json:"error_pages,omitempty"`
+
+	// Maintenance puts the route in maintenance mode, where requests are answered with a 503,
+	// unless the user is a member of one of the MaintenanceAllowedGroups.
+	Maintenance              bool     `mapstructure:"maintenance" yaml:"maintenance,omitempty" json:"maintenance,omitempty"`
+	MaintenanceAllowedGroups []string `mapstructure:"maintenance_allowed_groups" yaml:"maintenance_allowed_groups,omitempty" json:"maintenance_allowed_groups,omitempty"`
+
+	// UpstreamGroups are groups of upstreams a percentage of requests is sent to instead of To.
+	UpstreamGroups []PolicyUpstreamGroup `mapstructure:"upstream_groups" yaml:"upstream_groups,omitempty" json:"upstream_groups,omitempty"`
+
+	// MirrorTo are the upstreams requests are mirrored to. Mirrored requests are sent in
+	// the background and their responses are discarded.
+	MirrorTo WeightedURLs `mapstructure:"mirror_to" yaml:"mirror_to,omitempty"`
+	// MirrorPercent is the percentage of requests mirrored. Defaults to 100.
+	MirrorPercent *float64 `mapstructure:"mirror_percent" yaml:"mirror_percent,omitempty"`
+
 	// Identity related policy
 	AllowedUsers     []string                 `mapstructure:"allowed_users" yaml:"allowed_users,omitempty" json:"allowed_users,omitempty"`
 	AllowedGroups    []string                 `mapstructure:"allowed_groups" yaml:"allowed_groups,omitempty" json:"allowed_groups,omitempty"`
@@ -84,9 +111,19 @@ type Policy struct {
 	// Caution: Enabling this feature could result in abuse via DOS attacks.
 	AllowWebsockets bool `mapstructure:"allow_websockets"  yaml:"allow_websockets,omitempty"`
 
+	// Websocket configures the websocket connections of the route separately from its HTTP
+	// requests. Requires AllowWebsockets.
+	Websocket *PolicyWebsocket `mapstructure:"websocket" yaml:"websocket,omitempty" json:"websocket,omitempty"`
+
 	// AllowSPDY enables proxying of SPDY upgrade requests
 	AllowSPDY bool `mapstructure:"allow_spdy" yaml:"allow_spdy,omitempty"`
 
+	// GRPCWeb enables translation of gRPC-Web requests from browsers to gRPC requests to the
+	// upstream, which is always connected to using HTTP/2.
+	GRPCWeb bool `mapstructure:"grpc_web" yaml:"grpc_web,omitempty"`
+	// GRPCWebAllowedOrigins are the origins allowed to make cross-origin gRPC-Web requests.
+	GRPCWebAllowedOrigins []string `mapstructure:"grpc_web_allowed_origins" yaml:"grpc_web_allowed_origins,omitempty"`
+
 	// TLSSkipVerify controls whether a client verifies the server's certificate
 	// chain and host name.
 	// If TLSSkipVerify is true, TLS accepts any certificate presented by the
@@ -115,10 +152,19 @@ type Policy struct {
 	TLSClientKeyFile  string           `mapstructure:"tls_client_key_file" yaml:"tls_client_key_file,omitempty"`
 	ClientCertificate *tls.Certificate `yaml:",omitempty" hash:"ignore"`
 
+	// TLSUpstreamSPIFFEID is the SPIFFE ID of the X.509 SVID fetched from the SPIFFE Workload API
+	// and presented to the upstream host as a client certificate. It's rotated automatically.
+	TLSUpstreamSPIFFEID string `mapstructure:"tls_upstream_spiffe_id" yaml:"tls_upstream_spiffe_id,omitempty"`
+	// TLSUpstreamSPIFFETrustDomain verifies the upstream host presents an SVID of the trust
+	// domain, using the trust bundle fetched from the SPIFFE Workload API.
+	TLSUpstreamSPIFFETrustDomain string `mapstructure:"tls_upstream_spiffe_trust_domain" yaml:"tls_upstream_spiffe_trust_domain,omitempty"`
+
 	// TLSDownstreamClientCA defines the root certificate to use with a given route to verify
 	// downstream client certificates (e.g. from a user's browser).
 	TLSDownstreamClientCA     string `mapstructure:"tls_downstream_client_ca" yaml:"tls_downstream_client_ca,omitempty"`
 	TLSDownstreamClientCAFile string `mapstructure:"tls_downstream_client_ca_file" yaml:"tls_downstream_client_ca_file,omitempty"`
+	// TLSDownstreamClientRevocationMode overrides the client revocation mode for the route.
+	TLSDownstreamClientRevocationMode string `mapstructure:"tls_downstream_client_revocation_mode" yaml:"tls_downstream_client_revocation_mode,omitempty"`
 
 	// SetAuthorizationHeader sets the authorization request header based on the user's identity. Supported modes are
 	// `pass_through`, `access_token` and `id_token`.
@@ -127,8 +173,13 @@ type Policy struct {
 	// SetRequestHeaders adds a collection of headers to the upstream request
 	// in the form of key value pairs. Note bene, this will overwrite the
 	// value of any existing value of a given header key.
+	//
+	// Values containing `{{` are go templates rendered with the user's identity. See
+	// RenderRequestHeaderTemplates.
 	SetRequestHeaders map[string]string `mapstructure:"set_request_headers" yaml:"set_request_headers,omitempty"`
 
+	compiledRequestHeaderTemplates map[string]*template.Template
+
 	// RemoveRequestHeaders removes a collection of headers from an upstream request.
 	// Note that this has lower priority than `SetRequestHeaders`, if you specify `X-Custom-Header` in both
 	// `SetRequestHeaders` and `RemoveRequestHeaders`, then the header won't be removed.
@@ -166,15 +217,82 @@ type Policy struct {
 	// RewriteResponseHeaders rewrites response headers. This can be used to change the Location header.
 	RewriteResponseHeaders []RewriteHeader `mapstructure:"rewrite_response_headers" yaml:"rewrite_response_headers,omitempty" json:"rewrite_response_headers,omitempty"` //nolint
 
-	// SetResponseHeaders sets response headers.
+	// SetResponseHeaders sets response headers, replacing any headers with the same name set by
+	// the upstream.
+	//
+	// Values containing `{{` are go templates rendered with the user's identity. See
+	// RenderResponseHeaderTemplates.
 	SetResponseHeaders map[string]string `mapstructure:"set_response_headers" yaml:"set_response_headers,omitempty"`
 
+	// AppendResponseHeaders appends values to response headers, keeping any values set by the
+	// upstream. Values can be templates like SetResponseHeaders.
+	AppendResponseHeaders map[string]string `mapstructure:"append_response_headers" yaml:"append_response_headers,omitempty"`
+
+	// SecurityHeaders sets security headers, like the Content-Security-Policy, from a preset.
+	// SetResponseHeaders take precedence over them.
+	SecurityHeaders *PolicySecurityHeaders `mapstructure:"security_headers" yaml:"security_headers,omitempty" json:"security_headers,omitempty"`
+
+	// HMACSigning signs requests to the upstreams with an HMAC, in addition to the
+	// X-Pomerium-Jwt-Assertion JWT.
+	HMACSigning *PolicyHMACSigning `mapstructure:"hmac_signing" yaml:"hmac_signing,omitempty" json:"hmac_signing,omitempty"`
+
+	compiledResponseHeaderTemplates       map[string]*template.Template
+	compiledAppendResponseHeaderTemplates map[string]*template.Template
+
+	// RemoveResponseHeaders removes headers set by the upstream from responses.
+	RemoveResponseHeaders []string `mapstructure:"remove_response_headers" yaml:"remove_response_headers,omitempty"`
+
 	// IDPClientID is the client id used for the identity provider.
 	IDPClientID string `mapstructure:"idp_client_id" yaml:"idp_client_id,omitempty"`
 	// IDPClientSecret is the client secret used for the identity provider.
 	IDPClientSecret string `mapstructure:"idp_client_secret" yaml:"idp_client_secret,omitempty"`
+	// IdentityProviders are the names of the identity providers users may sign in with. When
+	// more than one is set, users choose an identity provider when signing in.
+	IdentityProviders []string `mapstructure:"identity_providers" yaml:"identity_providers,omitempty"`
+	// AllowIdentityProviderFailover allows sessions from the idp_failover identity provider while
+	// the identity provider of the route is unhealthy.
+	AllowIdentityProviderFailover bool `mapstructure:"allow_idp_failover" yaml:"allow_idp_failover,omitempty"`
+	// BindSessionToClientCertificate binds sessions to the client certificate presented on the
+	// first request to the route, and rejects requests presenting a different certificate.
+	BindSessionToClientCertificate bool `mapstructure:"bind_session_to_client_certificate" yaml:"bind_session_to_client_certificate,omitempty"`
+	// SessionLifetime is the maximum age of sessions used for the route. It can only shorten
+	// the lifetime of sessions set by the global cookie_expire option.
+	SessionLifetime *time.Duration `mapstructure:"session_lifetime" yaml:"session_lifetime,omitempty"`
+	// SessionIdleTimeout is the maximum time a session used for the route may go unused.
+	SessionIdleTimeout *time.Duration `mapstructure:"session_idle_timeout" yaml:"session_idle_timeout,omitempty"`
+	// MaxSessionAge is the maximum time since the user last signed in to the identity provider.
+	// When exceeded users must sign in to the identity provider again, even if they're already
+	// signed in to the identity provider.
+	MaxSessionAge *time.Duration `mapstructure:"max_session_age" yaml:"max_session_age,omitempty"`
+	// JWTAudience overrides the audience of the X-Pomerium-Jwt-Assertion JWT, which defaults
+	// to the route's hostname.
+	JWTAudience string `mapstructure:"jwt_audience" yaml:"jwt_audience,omitempty"`
+	// JWTClaims are the claims included in the X-Pomerium-Jwt-Assertion JWT. When empty all
+	// claims are included.
+	JWTClaims []string `mapstructure:"jwt_claims" yaml:"jwt_claims,omitempty"`
+	// Branding overrides the global branding for pages shown to users of the route.
+	Branding *Branding `mapstructure:"branding" yaml:"branding,omitempty" json:"branding,omitempty"`
+	// CircuitBreakerThresholds override the global circuit breaker thresholds for the route.
+	CircuitBreakerThresholds *CircuitBreakerThresholds `mapstructure:"circuit_breaker_thresholds" yaml:"circuit_breaker_thresholds,omitempty" json:"circuit_breaker_thresholds,omitempty"`
+	// RetryPolicy configures the retries of failed requests to the upstreams of the route.
+	RetryPolicy *PolicyRetryPolicy `mapstructure:"retry_policy" yaml:"retry_policy,omitempty" json:"retry_policy,omitempty"`
+	// SessionAffinity keeps the requests of a user on the same upstream of the route.
+	SessionAffinity *PolicySessionAffinity `mapstructure:"session_affinity" yaml:"session_affinity,omitempty" json:"session_affinity,omitempty"`
+	// MaxRequestBodyBytes is the maximum size of request bodies. Larger requests are rejected
+	// with a 413.
+	MaxRequestBodyBytes *uint32 `mapstructure:"max_request_body_bytes" yaml:"max_request_body_bytes,omitempty" json:"max_request_body_bytes,omitempty"`
+	// ResponseBufferLimitBytes is the soft limit on the size of the buffers of the upstream
+	// connections of the route.
+	ResponseBufferLimitBytes *uint32 `mapstructure:"response_buffer_limit_bytes" yaml:"response_buffer_limit_bytes,omitempty" json:"response_buffer_limit_bytes,omitempty"`
+	// LocalRateLimit limits the rate of requests to the route.
+	LocalRateLimit *PolicyLocalRateLimit `mapstructure:"local_rate_limit" yaml:"local_rate_limit,omitempty" json:"local_rate_limit,omitempty"`
+	// RateLimit limits the rate of requests to the route across all the instances of pomerium.
+	RateLimit *PolicyRateLimit `mapstructure:"rate_limit" yaml:"rate_limit,omitempty" json:"rate_limit,omitempty"`
 
 	Policy *PPLPolicy `mapstructure:"policy" yaml:"policy,omitempty" json:"policy,omitempty"`
+
+	// SkipDefaultPolicy disables the global default policy for this route.
+	SkipDefaultPolicy bool `mapstructure:"skip_default_policy" yaml:"skip_default_policy,omitempty" json:"skip_default_policy,omitempty"`
 }
 
 // RewriteHeader is a policy configuration option to rewrite an HTTP header.
@@ -205,6 +323,119 @@ type PolicyRedirect struct {
 	PrefixRewrite  *string `mapstructure:"prefix_rewrite" yaml:"prefix_rewrite,omitempty" json:"prefix_rewrite,omitempty"`
 	ResponseCode   *int32  `mapstructure:"response_code" yaml:"response_code,omitempty" json:"response_code,omitempty"`
 	StripQuery     *bool   `mapstructure:"strip_query" yaml:"strip_query,omitempty" json:"strip_query,omitempty"`
+	// RegexRewritePattern and RegexRewriteSubstitution rewrite the path of the redirect using a
+	// regular expression. Capture groups can be referenced in the substitution as $1 or \1.
+	RegexRewritePattern      *string `mapstructure:"regex_rewrite_pattern" yaml:"regex_rewrite_pattern,omitempty" json:"regex_rewrite_pattern,omitempty"`
+	RegexRewriteSubstitution *string `mapstructure:"regex_rewrite_substitution" yaml:"regex_rewrite_substitution,omitempty" json:"regex_rewrite_substitution,omitempty"`
+}
+
+var (
+	// envoy only supports the capture groups \0 to \9
+	redirectSubstitutionDollarRE = regexp.MustCompile(`\$(?:(\d)|\{(\d)\})`)
+	redirectSubstitutionGroupRE  = regexp.MustCompile(`\\(\d)`)
+)
+
+// Validate checks the validity of the redirect.
+func (r *PolicyRedirect) Validate() error {
+	if r.RegexRewriteSubstitution != nil && r.RegexRewritePattern == nil {
+		return fmt.Errorf("regex_rewrite_substitution requires regex_rewrite_pattern")
+	}
+	if r.RegexRewritePattern == nil {
+		return nil
+	}
+	if r.PathRedirect != nil || r.PrefixRewrite != nil {
+		return fmt.Errorf("regex_rewrite_pattern cannot be combined with path_redirect or prefix_rewrite")
+	}
+	re, err := regexp.Compile(*r.RegexRewritePattern)
+	if err != nil {
+		return fmt.Errorf("invalid regex_rewrite_pattern: %w", err)
+	}
+	for _, m := range redirectSubstitutionGroupRE.FindAllStringSubmatch(r.GetRegexRewriteSubstitution(), -1) {
+		if n, _ := strconv.Atoi(m[1]); n > re.NumSubexp() {
+			return fmt.Errorf("regex_rewrite_substitution references unknown capture group %d", n)
+		}
+	}
+	return nil
+}
+
+// GetRegexRewriteSubstitution returns the regex rewrite substitution with the $1 and ${1} capture
+// group references replaced by the \1 references envoy expects.
+func (r *PolicyRedirect) GetRegexRewriteSubstitution() string {
+	if r.RegexRewriteSubstitution == nil {
+		return ""
+	}
+	return redirectSubstitutionDollarRE.ReplaceAllString(*r.RegexRewriteSubstitution, `\$1$2`)
+}
+
+// PolicyDenyResponse is a custom response returned when a request is denied.
+type PolicyDenyResponse struct {
+	Status  int               `mapstructure:"status" yaml:"status,omitempty" json:"status,omitempty"`
+	Headers map[string]string `mapstructure:"headers" yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Body is a go template rendered with the deny reasons and the user's identity. It's escaped
+	// as HTML unless the headers set a Content-Type which isn't HTML.
+	Body string `mapstructure:"body" yaml:"body,omitempty" json:"body,omitempty"`
+
+	compiledBody bodyTemplate
+}
+
+// Validate checks the validity of the deny response and compiles the body template.
+func (r *PolicyDenyResponse) Validate() error {
+	if r.Status != 0 && (r.Status < 200 || r.Status > 599) {
+		return fmt.Errorf("invalid status code: %d", r.Status)
+	}
+	tpl, err := compileBodyTemplate("deny_response", r.Body, r.GetContentType())
+	if err != nil {
+		return fmt.Errorf("invalid body template: %w", err)
+	}
+	r.compiledBody = tpl
+	return nil
+}
+
+// GetStatus returns the status code of the deny response, defaulting to 403 Forbidden.
+func (r *PolicyDenyResponse) GetStatus() int {
+	if r.Status == 0 {
+		return http.StatusForbidden
+	}
+	return r.Status
+}
+
+// GetContentType returns the content type of the deny response, set by the Content-Type header.
+func (r *PolicyDenyResponse) GetContentType() string {
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, "Content-Type") {
+			return v
+		}
+	}
+	return DefaultErrorPageContentType
+}
+
+// RenderBody renders the body template using the given data. The deny response must be
+// validated first.
+func (r *PolicyDenyResponse) RenderBody(data interface{}) (string, error) {
+	return renderBodyTemplate(r.compiledBody, data)
+}
+
+// PolicyDirectResponse is a response returned directly by the proxy, without an upstream.
+type PolicyDirectResponse struct {
+	Status  int               `mapstructure:"status" yaml:"status,omitempty" json:"status,omitempty"`
+	Headers map[string]string `mapstructure:"headers" yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body    string            `mapstructure:"body" yaml:"body,omitempty" json:"body,omitempty"`
+}
+
+// Validate checks the validity of the direct response.
+func (r *PolicyDirectResponse) Validate() error {
+	if r.Status != 0 && (r.Status < 200 || r.Status > 599) {
+		return fmt.Errorf("invalid status code: %d", r.Status)
+	}
+	return nil
+}
+
+// GetStatus returns the status code of the direct response, defaulting to 200 OK.
+func (r *PolicyDirectResponse) GetStatus() int {
+	if r.Status == 0 {
+		return http.StatusOK
+	}
+	return r.Status
 }
 
 // NewPolicyFromProto creates a new Policy from a protobuf policy config route.
@@ -238,7 +469,10 @@ func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
 		UpstreamTimeout:                  timeout,
 		IdleTimeout:                      idleTimeout,
 		AllowWebsockets:                  pb.GetAllowWebsockets(),
+		GRPCWeb:                          pb.GetGrpcWeb(),
+		GRPCWebAllowedOrigins:            pb.GetGrpcWebAllowedOrigins(),
 		AllowSPDY:                        pb.GetAllowSpdy(),
+		SkipDefaultPolicy:                pb.GetSkipDefaultPolicy(),
 		TLSSkipVerify:                    pb.GetTlsSkipVerify(),
 		TLSServerName:                    pb.GetTlsServerName(),
 		TLSDownstreamServerName:          pb.GetTlsDownstreamServerName(),
@@ -249,6 +483,8 @@ func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
 		TLSClientKey:                     pb.GetTlsClientKey(),
 		TLSClientCertFile:                pb.GetTlsClientCertFile(),
 		TLSClientKeyFile:                 pb.GetTlsClientKeyFile(),
+		TLSUpstreamSPIFFEID:              pb.GetTlsUpstreamSpiffeId(),
+		TLSUpstreamSPIFFETrustDomain:     pb.GetTlsUpstreamSpiffeTrustDomain(),
 		TLSDownstreamClientCA:            pb.GetTlsDownstreamClientCa(),
 		TLSDownstreamClientCAFile:        pb.GetTlsDownstreamClientCaFile(),
 		SetAuthorizationHeader:           pb.GetSetAuthorizationHeader().String(),
@@ -262,12 +498,62 @@ func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
 		PassIdentityHeaders:              pb.GetPassIdentityHeaders(),
 		KubernetesServiceAccountToken:    pb.GetKubernetesServiceAccountToken(),
 		SetResponseHeaders:               pb.GetSetResponseHeaders(),
+		AppendResponseHeaders:            pb.GetAppendResponseHeaders(),
+		RemoveResponseHeaders:            pb.GetRemoveResponseHeaders(),
 		EnableGoogleCloudServerlessAuthentication: pb.GetEnableGoogleCloudServerlessAuthentication(),
-		IDPClientID:     pb.GetIdpClientId(),
-		IDPClientSecret: pb.GetIdpClientSecret(),
+		IDPClientID:       pb.GetIdpClientId(),
+		IDPClientSecret:   pb.GetIdpClientSecret(),
+		IdentityProviders: pb.GetIdentityProviders(),
+
+		AllowIdentityProviderFailover:  pb.GetAllowIdpFailover(),
+		BindSessionToClientCertificate: pb.GetBindSessionToClientCertificate(),
+		JWTAudience:                    pb.GetJwtAudience(),
+		JWTClaims:                      pb.GetJwtClaims(),
+		Branding:                       NewBrandingFromProto(pb.GetBranding()),
+		CircuitBreakerThresholds:       NewCircuitBreakerThresholdsFromProto(pb.GetCircuitBreakerThresholds()),
+		RetryPolicy:                    NewPolicyRetryPolicyFromProto(pb.GetRetryPolicy()),
+		SecurityHeaders:                NewPolicySecurityHeadersFromProto(pb.GetSecurityHeaders()),
+		HMACSigning:                    NewPolicyHMACSigningFromProto(pb.GetHmacSigning()),
+		SessionAffinity:                NewPolicySessionAffinityFromProto(pb.GetSessionAffinity()),
+		MaxRequestBodyBytes:            pb.MaxRequestBodyBytes,
+		ResponseBufferLimitBytes:       pb.ResponseBufferLimitBytes,
+		ErrorPages:                     NewPolicyErrorPagesFromProto(pb.GetErrorPages()),
+		Maintenance:                    pb.GetMaintenance(),
+		MaintenanceAllowedGroups:       pb.GetMaintenanceAllowedGroups(),
+		LocalRateLimit:                 NewPolicyLocalRateLimitFromProto(pb.GetLocalRateLimit()),
+		RateLimit:                      NewPolicyRateLimitFromProto(pb.GetRateLimit()),
+		Websocket:                      NewPolicyWebsocketFromProto(pb.GetWebsocket()),
+
+		TLSDownstreamClientRevocationMode: pb.GetTlsDownstreamClientRevocationMode(),
+	}
+	if pb.SessionLifetime != nil {
+		t := pb.GetSessionLifetime().AsDuration()
+		p.SessionLifetime = &t
+	}
+	if pb.SessionIdleTimeout != nil {
+		t := pb.GetSessionIdleTimeout().AsDuration()
+		p.SessionIdleTimeout = &t
+	}
+	if pb.MaxSessionAge != nil {
+		t := pb.GetMaxSessionAge().AsDuration()
+		p.MaxSessionAge = &t
+	}
+
+	if pb.DenyResponse != nil {
+		p.DenyResponse = &PolicyDenyResponse{
+			Status:  int(pb.DenyResponse.GetStatus()),
+			Headers: pb.DenyResponse.GetHeaders(),
+			Body:    pb.DenyResponse.GetBody(),
+		}
 	}
 
-	if pb.Redirect.IsSet() {
+	if pb.Response != nil {
+		p.Response = &PolicyDirectResponse{
+			Status:  int(pb.Response.GetStatus()),
+			Headers: pb.Response.GetHeaders(),
+			Body:    pb.Response.GetBody(),
+		}
+	} else if pb.Redirect.IsSet() {
 		p.Redirect = &PolicyRedirect{
 			HTTPSRedirect:  pb.Redirect.HttpsRedirect,
 			SchemeRedirect: pb.Redirect.SchemeRedirect,
@@ -277,6 +563,9 @@ func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
 			PrefixRewrite:  pb.Redirect.PrefixRewrite,
 			ResponseCode:   pb.Redirect.ResponseCode,
 			StripQuery:     pb.Redirect.StripQuery,
+
+			RegexRewritePattern:      pb.Redirect.RegexRewritePattern,
+			RegexRewriteSubstitution: pb.Redirect.RegexRewriteSubstitution,
 		}
 	} else {
 		to, err := ParseWeightedUrls(pb.GetTo()...)
@@ -287,6 +576,22 @@ func NewPolicyFromProto(pb *configpb.Route) (*Policy, error) {
 		p.To = to
 	}
 
+	if len(pb.GetMirrorTo()) > 0 {
+		mirrorTo, err := ParseWeightedUrls(pb.GetMirrorTo()...)
+		if err != nil {
+			return nil, err
+		}
+		p.MirrorTo = mirrorTo
+	}
+	p.MirrorPercent = pb.MirrorPercent
+	for _, g := range pb.GetUpstreamGroups() {
+		group, err := NewPolicyUpstreamGroupFromProto(g)
+		if err != nil {
+			return nil, err
+		}
+		p.UpstreamGroups = append(p.UpstreamGroups, *group)
+	}
+
 	p.EnvoyOpts = pb.EnvoyOpts
 	if p.EnvoyOpts == nil {
 		p.EnvoyOpts = new(envoy_config_cluster_v3.Cluster)
@@ -361,7 +666,10 @@ func (p *Policy) ToProto() (*configpb.Route, error) {
 		Timeout:                          timeout,
 		IdleTimeout:                      idleTimeout,
 		AllowWebsockets:                  p.AllowWebsockets,
+		GrpcWeb:                          p.GRPCWeb,
+		GrpcWebAllowedOrigins:            p.GRPCWebAllowedOrigins,
 		AllowSpdy:                        p.AllowSPDY,
+		SkipDefaultPolicy:                p.SkipDefaultPolicy,
 		TlsSkipVerify:                    p.TLSSkipVerify,
 		TlsServerName:                    p.TLSServerName,
 		TlsUpstreamServerName:            p.TLSUpstreamServerName,
@@ -372,6 +680,8 @@ func (p *Policy) ToProto() (*configpb.Route, error) {
 		TlsClientKey:                     p.TLSClientKey,
 		TlsClientCertFile:                p.TLSClientCertFile,
 		TlsClientKeyFile:                 p.TLSClientKeyFile,
+		TlsUpstreamSpiffeId:              p.TLSUpstreamSPIFFEID,
+		TlsUpstreamSpiffeTrustDomain:     p.TLSUpstreamSPIFFETrustDomain,
 		TlsDownstreamClientCa:            p.TLSDownstreamClientCA,
 		TlsDownstreamClientCaFile:        p.TLSDownstreamClientCAFile,
 		SetRequestHeaders:                p.SetRequestHeaders,
@@ -382,14 +692,63 @@ func (p *Policy) ToProto() (*configpb.Route, error) {
 		KubernetesServiceAccountToken:    p.KubernetesServiceAccountToken,
 		Policies:                         sps,
 		SetResponseHeaders:               p.SetResponseHeaders,
+		AppendResponseHeaders:            p.AppendResponseHeaders,
+		RemoveResponseHeaders:            p.RemoveResponseHeaders,
+		IdentityProviders:                p.IdentityProviders,
+		AllowIdpFailover:                 p.AllowIdentityProviderFailover,
+		BindSessionToClientCertificate:   p.BindSessionToClientCertificate,
+		JwtClaims:                        p.JWTClaims,
+		Branding:                         p.Branding.ToProto(),
+		CircuitBreakerThresholds:         p.CircuitBreakerThresholds.ToProto(),
+		RetryPolicy:                      p.RetryPolicy.ToProto(),
+		SecurityHeaders:                  p.SecurityHeaders.ToProto(),
+		HmacSigning:                      p.HMACSigning.ToProto(),
+		SessionAffinity:                  p.SessionAffinity.ToProto(),
+		MaxRequestBodyBytes:              p.MaxRequestBodyBytes,
+		ResponseBufferLimitBytes:         p.ResponseBufferLimitBytes,
+		Maintenance:                      p.Maintenance,
+		MaintenanceAllowedGroups:         p.MaintenanceAllowedGroups,
+		LocalRateLimit:                   p.LocalRateLimit.ToProto(),
+		RateLimit:                        p.RateLimit.ToProto(),
+		Websocket:                        p.Websocket.ToProto(),
+
+		TlsDownstreamClientRevocationMode: p.TLSDownstreamClientRevocationMode,
 	}
 	if p.IDPClientID != "" {
 		pb.IdpClientId = proto.String(p.IDPClientID)
 	}
+	if p.SessionLifetime != nil {
+		pb.SessionLifetime = durationpb.New(*p.SessionLifetime)
+	}
+	if p.SessionIdleTimeout != nil {
+		pb.SessionIdleTimeout = durationpb.New(*p.SessionIdleTimeout)
+	}
+	if p.MaxSessionAge != nil {
+		pb.MaxSessionAge = durationpb.New(*p.MaxSessionAge)
+	}
+	if p.JWTAudience != "" {
+		pb.JwtAudience = proto.String(p.JWTAudience)
+	}
 	if p.IDPClientSecret != "" {
 		pb.IdpClientSecret = proto.String(p.IDPClientSecret)
 	}
-	if p.Redirect != nil {
+	if p.DenyResponse != nil {
+		pb.DenyResponse = &configpb.RouteDenyResponse{
+			Status:  int32(p.DenyResponse.Status),
+			Headers: p.DenyResponse.Headers,
+			Body:    p.DenyResponse.Body,
+		}
+	}
+	for i := range p.ErrorPages {
+		pb.ErrorPages = append(pb.ErrorPages, p.ErrorPages[i].ToProto())
+	}
+	if p.Response != nil {
+		pb.Response = &configpb.RouteDirectResponse{
+			Status:  uint32(p.Response.Status),
+			Headers: p.Response.Headers,
+			Body:    p.Response.Body,
+		}
+	} else if p.Redirect != nil {
 		pb.Redirect = &configpb.RouteRedirect{
 			HttpsRedirect:  p.Redirect.HTTPSRedirect,
 			SchemeRedirect: p.Redirect.SchemeRedirect,
@@ -399,6 +758,9 @@ func (p *Policy) ToProto() (*configpb.Route, error) {
 			PrefixRewrite:  p.Redirect.PrefixRewrite,
 			ResponseCode:   p.Redirect.ResponseCode,
 			StripQuery:     p.Redirect.StripQuery,
+
+			RegexRewritePattern:      p.Redirect.RegexRewritePattern,
+			RegexRewriteSubstitution: p.Redirect.RegexRewriteSubstitution,
 		}
 	} else {
 		to, weights, err := p.To.Flatten()
@@ -409,6 +771,11 @@ func (p *Policy) ToProto() (*configpb.Route, error) {
 		pb.To = to
 		pb.LoadBalancingWeights = weights
 	}
+	pb.MirrorTo = p.MirrorTo.rawStrings()
+	pb.MirrorPercent = p.MirrorPercent
+	for i := range p.UpstreamGroups {
+		pb.UpstreamGroups = append(pb.UpstreamGroups, p.UpstreamGroups[i].ToProto())
+	}
 
 	for _, rwh := range p.RewriteResponseHeaders {
 		pb.RewriteResponseHeaders = append(pb.RewriteResponseHeaders, &configpb.RouteRewriteHeader{
@@ -443,16 +810,66 @@ func (p *Policy) Validate() error {
 
 	p.Source = &StringURL{source}
 
-	if len(p.To) == 0 && p.Redirect == nil {
+	if len(p.To) == 0 && p.Redirect == nil && p.Response == nil {
 		return errEitherToOrRedirectRequired
 	}
 
+	if urlutil.IsUDP(source) {
+		if p.Redirect != nil || p.Response != nil {
+			return fmt.Errorf("config: udp routes cannot have a redirect or response")
+		}
+		if len(p.UpstreamGroups) > 0 || len(p.MirrorTo) > 0 {
+			return fmt.Errorf("config: udp routes cannot have upstream_groups or mirror_to")
+		}
+		for _, u := range p.To {
+			if u.URL.Scheme != "udp" {
+				return fmt.Errorf("config: udp route destination (%s) should use the udp scheme", u.URL.String())
+			}
+		}
+	}
+
+	if p.Redirect != nil {
+		if err := p.Redirect.Validate(); err != nil {
+			return fmt.Errorf("config: invalid policy redirect: %w", err)
+		}
+	}
+
+	if p.Response != nil {
+		if len(p.To) > 0 || p.Redirect != nil {
+			return fmt.Errorf("config: response cannot be combined with to or redirect")
+		}
+		if len(p.UpstreamGroups) > 0 || len(p.MirrorTo) > 0 {
+			return fmt.Errorf("config: response cannot be combined with upstream_groups or mirror_to")
+		}
+		if err := p.Response.Validate(); err != nil {
+			return fmt.Errorf("config: invalid policy response: %w", err)
+		}
+	}
+
 	for _, u := range p.To {
 		if err = u.Validate(); err != nil {
 			return fmt.Errorf("config: %s: %w", u.URL.String(), err)
 		}
 	}
 
+	if err := validateDiscoveryURLs(p.To); err != nil {
+		return fmt.Errorf("config: invalid policy to: %w", err)
+	}
+	if err := validateDiscoveryURLs(p.MirrorTo); err != nil {
+		return fmt.Errorf("config: invalid policy mirror_to: %w", err)
+	}
+	for _, g := range p.UpstreamGroups {
+		if err := validateDiscoveryURLs(g.To); err != nil {
+			return fmt.Errorf("config: invalid policy upstream group %s: %w", g.Name, err)
+		}
+	}
+	if p.IsForKubernetes() && len(p.To) > 0 && IsDiscoveryURL(&p.To[0].URL) {
+		return fmt.Errorf("config: kubernetes routes cannot use discovered upstreams")
+	}
+	if err := p.validateWildcards(); err != nil {
+		return err
+	}
+
 	// Only allow public access if no other whitelists are in place
 	if p.AllowPublicUnauthenticatedAccess && (p.AllowAnyAuthenticatedUser || p.AllowedDomains != nil || p.AllowedGroups != nil || p.AllowedUsers != nil) {
 		return fmt.Errorf("config: policy route marked as public but contains whitelists")
@@ -463,6 +880,16 @@ func (p *Policy) Validate() error {
 		return fmt.Errorf("config: policy route marked accessible for any authenticated user but contains whitelists")
 	}
 
+	if p.SessionLifetime != nil && *p.SessionLifetime <= 0 {
+		return fmt.Errorf("config: policy session_lifetime must be positive")
+	}
+	if p.SessionIdleTimeout != nil && *p.SessionIdleTimeout <= 0 {
+		return fmt.Errorf("config: policy session_idle_timeout must be positive")
+	}
+	if p.MaxSessionAge != nil && *p.MaxSessionAge <= 0 {
+		return fmt.Errorf("config: policy max_session_age must be positive")
+	}
+
 	if (p.TLSClientCert == "" && p.TLSClientKey != "") || (p.TLSClientCert != "" && p.TLSClientKey == "") ||
 		(p.TLSClientCertFile == "" && p.TLSClientKeyFile != "") || (p.TLSClientCertFile != "" && p.TLSClientKeyFile == "") {
 		return fmt.Errorf("config: client certificate key and cert both must be non-empty")
@@ -480,6 +907,20 @@ func (p *Policy) Validate() error {
 		}
 	}
 
+	if p.TLSUpstreamSPIFFEID != "" {
+		if p.ClientCertificate != nil {
+			return fmt.Errorf("config: tls_upstream_spiffe_id cannot be used with a client certificate")
+		}
+		if err := validateSPIFFEID(p.TLSUpstreamSPIFFEID); err != nil {
+			return fmt.Errorf("config: invalid policy tls_upstream_spiffe_id: %w", err)
+		}
+	}
+	if p.TLSUpstreamSPIFFETrustDomain != "" {
+		if err := validateSPIFFETrustDomain(p.TLSUpstreamSPIFFETrustDomain); err != nil {
+			return fmt.Errorf("config: invalid policy tls_upstream_spiffe_trust_domain: %w", err)
+		}
+	}
+
 	if p.TLSCustomCA != "" {
 		_, err := base64.StdEncoding.DecodeString(p.TLSCustomCA)
 		if err != nil {
@@ -507,6 +948,10 @@ func (p *Policy) Validate() error {
 		p.TLSDownstreamClientCA = base64.StdEncoding.EncodeToString(bs)
 	}
 
+	if _, err := revocation.ParseMode(p.TLSDownstreamClientRevocationMode); err != nil {
+		return fmt.Errorf("config: bad tls_downstream_client_revocation_mode: %w", err)
+	}
+
 	if p.KubernetesServiceAccountTokenFile != "" {
 		if p.KubernetesServiceAccountToken != "" {
 			return fmt.Errorf("config: specified both `kubernetes_service_account_token_file` and `kubernetes_service_account_token`")
@@ -538,6 +983,119 @@ func (p *Policy) Validate() error {
 		return fmt.Errorf("config: invalid policy set_authorization_header: %v", p.SetAuthorizationHeader)
 	}
 
+	if len(p.UpstreamGroups) > 0 {
+		if p.Redirect != nil {
+			return fmt.Errorf("config: upstream_groups cannot be combined with redirect")
+		}
+		if p.IsForKubernetes() {
+			return fmt.Errorf("config: upstream_groups cannot be combined with kubernetes_service_account_token")
+		}
+		if err := validateUpstreamGroups(p.UpstreamGroups); err != nil {
+			return fmt.Errorf("config: invalid upstream_groups: %w", err)
+		}
+	}
+
+	if len(p.MirrorTo) > 0 {
+		if p.Redirect != nil {
+			return fmt.Errorf("config: mirror_to cannot be combined with redirect")
+		}
+		if _, err := p.MirrorTo.Validate(); err != nil {
+			return fmt.Errorf("config: invalid mirror_to: %w", err)
+		}
+	}
+	if p.MirrorPercent != nil && (*p.MirrorPercent <= 0 || *p.MirrorPercent > 100) {
+		return fmt.Errorf("config: policy mirror_percent must be greater than 0 and at most 100")
+	}
+
+	if p.GRPCWeb && (p.AllowWebsockets || p.AllowSPDY) {
+		return fmt.Errorf("config: grpc_web cannot be combined with allow_websockets or allow_spdy")
+	}
+	for _, origin := range p.GRPCWebAllowedOrigins {
+		u, err := urlutil.ParseAndValidateURL(origin)
+		if err != nil || u.Path != "" || u.RawQuery != "" {
+			return fmt.Errorf("config: invalid grpc_web_allowed_origins origin %q", origin)
+		}
+	}
+
+	if p.DenyResponse != nil {
+		if err := p.DenyResponse.Validate(); err != nil {
+			return fmt.Errorf("config: invalid policy deny_response: %w", err)
+		}
+	}
+
+	if err := validateErrorPages(p.ErrorPages); err != nil {
+		return fmt.Errorf("config: invalid policy error_pages: %w", err)
+	}
+
+	p.compiledRequestHeaderTemplates, err = compileHeaderTemplates(p.SetRequestHeaders)
+	if err != nil {
+		return fmt.Errorf("config: invalid policy set_request_headers: %w", err)
+	}
+	p.compiledResponseHeaderTemplates, err = compileHeaderTemplates(p.SetResponseHeaders)
+	if err != nil {
+		return fmt.Errorf("config: invalid policy set_response_headers: %w", err)
+	}
+	p.compiledAppendResponseHeaderTemplates, err = compileHeaderTemplates(p.AppendResponseHeaders)
+	if err != nil {
+		return fmt.Errorf("config: invalid policy append_response_headers: %w", err)
+	}
+
+	if err := p.Branding.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy branding: %w", err)
+	}
+
+	if err := p.CircuitBreakerThresholds.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy circuit_breaker_thresholds: %w", err)
+	}
+
+	if err := p.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy retry_policy: %w", err)
+	}
+
+	if err := p.SecurityHeaders.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy security_headers: %w", err)
+	}
+
+	if err := p.HMACSigning.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy hmac_signing: %w", err)
+	}
+
+	if err := p.SessionAffinity.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy session_affinity: %w", err)
+	}
+
+	if p.SessionAffinity != nil {
+		switch p.EnvoyOpts.GetLbPolicy() {
+		case envoy_config_cluster_v3.Cluster_ROUND_ROBIN,
+			envoy_config_cluster_v3.Cluster_RING_HASH,
+			envoy_config_cluster_v3.Cluster_MAGLEV:
+		default:
+			return fmt.Errorf("config: session_affinity requires lb_policy to be RING_HASH or MAGLEV")
+		}
+	}
+
+	if p.MaxRequestBodyBytes != nil && *p.MaxRequestBodyBytes == 0 {
+		return fmt.Errorf("config: max_request_body_bytes must be greater than 0")
+	}
+
+	if p.ResponseBufferLimitBytes != nil && *p.ResponseBufferLimitBytes == 0 {
+		return fmt.Errorf("config: response_buffer_limit_bytes must be greater than 0")
+	}
+
+	if err := p.LocalRateLimit.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy local_rate_limit: %w", err)
+	}
+	if err := p.RateLimit.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy rate_limit: %w", err)
+	}
+
+	if p.Websocket != nil && !p.AllowWebsockets {
+		return fmt.Errorf("config: websocket requires allow_websockets")
+	}
+	if err := p.Websocket.Validate(); err != nil {
+		return fmt.Errorf("config: invalid policy websocket: %w", err)
+	}
+
 	return nil
 }
 
@@ -563,6 +1121,8 @@ func (p *Policy) RouteID() (uint64, error) {
 		id.To = dst
 	} else if p.Redirect != nil {
 		id.Redirect = p.Redirect
+	} else if p.Response != nil {
+		id.Response = p.Response
 	} else {
 		return 0, errEitherToOrRedirectRequired
 	}
@@ -590,7 +1150,11 @@ func (p *Policy) Matches(requestURL url.URL) bool {
 		return false
 	}
 
-	if p.Source.Host != requestURL.Host {
+	if IsWildcardHost(p.Source.Host) {
+		if _, ok := p.GetWildcardSubdomain(requestURL.Host); !ok {
+			return false
+		}
+	} else if p.Source.Host != requestURL.Host {
 		return false
 	}
 
@@ -694,4 +1258,5 @@ type routeID struct {
 	Path     string
 	Regex    string
 	Redirect *PolicyRedirect
+	Response *PolicyDirectResponse
 }
@@ -338,7 +338,7 @@ claim_0 = [true, {"claim-ok"}] {
 	user := get_user(session)
 	user_claims := object.get(user, "claims", {})
 	all_claims := object.union(session_claims, user_claims)
-	values := object_get(all_claims, rule_path, [])
+	values := object.get(all_claims, replace(rule_path, "/", "."), object_get(all_claims, rule_path, []))
 	rule_data == values[_0]
 }
 
@@ -359,7 +359,7 @@ claim_1 = [true, {"claim-ok"}] {
 	user := get_user(session)
 	user_claims := object.get(user, "claims", {})
 	all_claims := object.union(session_claims, user_claims)
-	values := object_get(all_claims, rule_path, [])
+	values := object.get(all_claims, replace(rule_path, "/", "."), object_get(all_claims, rule_path, []))
 	rule_data == values[_0]
 }
 
@@ -380,7 +380,7 @@ claim_2 = [true, {"claim-ok"}] {
 	user := get_user(session)
 	user_claims := object.get(user, "claims", {})
 	all_claims := object.union(session_claims, user_claims)
-	values := object_get(all_claims, rule_path, [])
+	values := object.get(all_claims, replace(rule_path, "/", "."), object_get(all_claims, rule_path, []))
 	rule_data == values[_0]
 }
 
@@ -401,7 +401,7 @@ claim_3 = [true, {"claim-ok"}] {
 	user := get_user(session)
 	user_claims := object.get(user, "claims", {})
 	all_claims := object.union(session_claims, user_claims)
-	values := object_get(all_claims, rule_path, [])
+	values := object.get(all_claims, replace(rule_path, "/", "."), object_get(all_claims, rule_path, []))
 	rule_data == values[_0]
 }
 
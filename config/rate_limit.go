@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// DefaultRateLimitWindow is the window of rate limits when it isn't set.
+const DefaultRateLimitWindow = time.Minute
+
+// PolicyRateLimit limits the rate of requests to a route across all the instances of pomerium.
+// The requests are counted by the databroker, with a sliding window.
+type PolicyRateLimit struct {
+	// Requests is the number of requests allowed in the window.
+	Requests uint32 `mapstructure:"requests" yaml:"requests" json:"requests"`
+	// Window is the duration of the sliding window. Defaults to a minute.
+	Window time.Duration `mapstructure:"window" yaml:"window,omitempty" json:"window,omitempty"`
+	// KeyBy is ip or user. Requests without a user are limited by their IP address. Defaults to
+	// ip.
+	KeyBy string `mapstructure:"key_by" yaml:"key_by,omitempty" json:"key_by,omitempty"`
+}
+
+// NewPolicyRateLimitFromProto creates a new PolicyRateLimit from a protobuf message.
+func NewPolicyRateLimitFromProto(pb *configpb.RouteRateLimit) *PolicyRateLimit {
+	if pb == nil {
+		return nil
+	}
+	rl := &PolicyRateLimit{
+		Requests: pb.GetRequests(),
+		KeyBy:    pb.GetKeyBy(),
+	}
+	if pb.Window != nil {
+		rl.Window = pb.GetWindow().AsDuration()
+	}
+	return rl
+}
+
+// ToProto converts the rate limit to a protobuf message.
+func (rl *PolicyRateLimit) ToProto() *configpb.RouteRateLimit {
+	if rl == nil {
+		return nil
+	}
+	pb := &configpb.RouteRateLimit{
+		Requests: rl.Requests,
+		KeyBy:    rl.KeyBy,
+	}
+	if rl.Window != 0 {
+		pb.Window = durationpb.New(rl.Window)
+	}
+	return pb
+}
+
+// Validate checks the validity of the rate limit.
+func (rl *PolicyRateLimit) Validate() error {
+	if rl == nil {
+		return nil
+	}
+	if rl.Requests == 0 {
+		return fmt.Errorf("requests must be greater than 0")
+	}
+	if rl.Window < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+	switch rl.KeyBy {
+	case "", LocalRateLimitKeyByIP, LocalRateLimitKeyByUser:
+	default:
+		return fmt.Errorf("unknown key_by: %s", rl.KeyBy)
+	}
+	return nil
+}
+
+// GetWindow returns the duration of the sliding window.
+func (rl *PolicyRateLimit) GetWindow() time.Duration {
+	if rl.Window == 0 {
+		return DefaultRateLimitWindow
+	}
+	return rl.Window
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// DefaultRetryOn are the retry conditions used when a retry policy doesn't set any. Only
+// failures where the request wasn't processed by the upstream are retried.
+const DefaultRetryOn = "connect-failure,refused-stream"
+
+const retryOnRetriableStatusCodes = "retriable-status-codes"
+
+// retryOnConditions are the envoy retry conditions, for both HTTP and gRPC.
+var retryOnConditions = map[string]struct{}{
+	"5xx":                        {},
+	"gateway-error":              {},
+	"reset":                      {},
+	"connect-failure":            {},
+	"envoy-ratelimited":          {},
+	"retriable-4xx":              {},
+	"refused-stream":             {},
+	retryOnRetriableStatusCodes:  {},
+	"retriable-headers":          {},
+	"http3-post-connect-failure": {},
+	"cancelled":                  {},
+	"deadline-exceeded":          {},
+	"internal":                   {},
+	"resource-exhausted":         {},
+	"unavailable":                {},
+}
+
+// PolicyRetryPolicy configures the retries of failed requests to the upstreams of a route, so
+// transient upstream failures are absorbed by the proxy instead of surfacing to users.
+type PolicyRetryPolicy struct {
+	// RetryOn is a comma separated list of the envoy retry conditions.
+	RetryOn string `mapstructure:"retry_on" yaml:"retry_on,omitempty" json:"retry_on,omitempty"`
+	// NumRetries is the maximum number of retries, which defaults to 1.
+	NumRetries *uint32 `mapstructure:"num_retries" yaml:"num_retries,omitempty" json:"num_retries,omitempty"`
+	// PerTryTimeout is the timeout of each attempt. If unset, the route timeout applies.
+	PerTryTimeout *time.Duration `mapstructure:"per_try_timeout" yaml:"per_try_timeout,omitempty" json:"per_try_timeout,omitempty"`
+	// RetriableStatusCodes are the upstream response status codes which are retried.
+	RetriableStatusCodes []uint32 `mapstructure:"retriable_status_codes" yaml:"retriable_status_codes,omitempty" json:"retriable_status_codes,omitempty"`
+}
+
+// NewPolicyRetryPolicyFromProto creates a new PolicyRetryPolicy from a protobuf message.
+func NewPolicyRetryPolicyFromProto(pb *configpb.RouteRetryPolicy) *PolicyRetryPolicy {
+	if pb == nil {
+		return nil
+	}
+	rp := &PolicyRetryPolicy{
+		RetryOn:              pb.GetRetryOn(),
+		NumRetries:           pb.NumRetries,
+		RetriableStatusCodes: pb.GetRetriableStatusCodes(),
+	}
+	if pb.PerTryTimeout != nil {
+		t := pb.GetPerTryTimeout().AsDuration()
+		rp.PerTryTimeout = &t
+	}
+	return rp
+}
+
+// ToProto converts the retry policy to a protobuf message.
+func (rp *PolicyRetryPolicy) ToProto() *configpb.RouteRetryPolicy {
+	if rp == nil {
+		return nil
+	}
+	pb := &configpb.RouteRetryPolicy{
+		RetryOn:              rp.RetryOn,
+		NumRetries:           rp.NumRetries,
+		RetriableStatusCodes: rp.RetriableStatusCodes,
+	}
+	if rp.PerTryTimeout != nil {
+		pb.PerTryTimeout = durationpb.New(*rp.PerTryTimeout)
+	}
+	return pb
+}
+
+// Validate checks the validity of the retry policy.
+func (rp *PolicyRetryPolicy) Validate() error {
+	if rp == nil {
+		return nil
+	}
+	for _, condition := range strings.Split(rp.RetryOn, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		if _, ok := retryOnConditions[condition]; !ok {
+			return fmt.Errorf("unknown retry_on condition: %s", condition)
+		}
+	}
+	if rp.PerTryTimeout != nil && *rp.PerTryTimeout <= 0 {
+		return fmt.Errorf("per_try_timeout must be greater than 0")
+	}
+	for _, code := range rp.RetriableStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid retriable status code: %d", code)
+		}
+	}
+	return nil
+}
+
+// GetRetryOn returns the retry conditions of the retry policy. The retriable-status-codes
+// condition is added when retriable status codes are set.
+func (rp *PolicyRetryPolicy) GetRetryOn() string {
+	var conditions []string
+	for _, condition := range strings.Split(rp.RetryOn, ",") {
+		if condition = strings.TrimSpace(condition); condition != "" {
+			conditions = append(conditions, condition)
+		}
+	}
+	if len(conditions) == 0 {
+		conditions = strings.Split(DefaultRetryOn, ",")
+	}
+	if len(rp.RetriableStatusCodes) > 0 {
+		found := false
+		for _, condition := range conditions {
+			found = found || condition == retryOnRetriableStatusCodes
+		}
+		if !found {
+			conditions = append(conditions, retryOnRetriableStatusCodes)
+		}
+	}
+	return strings.Join(conditions, ",")
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`
+routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    retry_policy:
+      retry_on: 5xx,reset
+      num_retries: 3
+      per_try_timeout: 2s
+      retriable_status_codes: [409]
+`), 0o600))
+
+		var o Options
+		o.viper = viper.New()
+		o.viper.SetConfigFile(configFile)
+		require.NoError(t, o.viper.ReadInConfig())
+		require.NoError(t, o.parsePolicy())
+		require.Len(t, o.Routes, 1)
+		require.NoError(t, o.Routes[0].Validate())
+
+		numRetries := uint32(3)
+		perTryTimeout := time.Second * 2
+		assert.Equal(t, &PolicyRetryPolicy{
+			RetryOn:              "5xx,reset",
+			NumRetries:           &numRetries,
+			PerTryTimeout:        &perTryTimeout,
+			RetriableStatusCodes: []uint32{409},
+		}, o.Routes[0].RetryPolicy)
+	})
+	t.Run("proto", func(t *testing.T) {
+		numRetries := uint32(2)
+		perTryTimeout := time.Second
+		rp := &PolicyRetryPolicy{
+			RetryOn:              "gateway-error",
+			NumRetries:           &numRetries,
+			PerTryTimeout:        &perTryTimeout,
+			RetriableStatusCodes: []uint32{503},
+		}
+		assert.Equal(t, rp, NewPolicyRetryPolicyFromProto(rp.ToProto()))
+	})
+	t.Run("validate", func(t *testing.T) {
+		zero := time.Duration(0)
+		for _, tc := range []struct {
+			name        string
+			retryPolicy *PolicyRetryPolicy
+			err         string
+		}{
+			{"unknown condition", &PolicyRetryPolicy{RetryOn: "5xx,sometimes"}, "unknown retry_on condition: sometimes"},
+			{"zero per try timeout", &PolicyRetryPolicy{PerTryTimeout: &zero}, "per_try_timeout must be greater than 0"},
+			{"invalid status code", &PolicyRetryPolicy{RetriableStatusCodes: []uint32{600}}, "invalid retriable status code: 600"},
+		} {
+			err := tc.retryPolicy.Validate()
+			if assert.Error(t, err, tc.name) {
+				assert.Equal(t, tc.err, err.Error(), tc.name)
+			}
+		}
+		assert.NoError(t, (&PolicyRetryPolicy{RetryOn: "connect-failure, unavailable"}).Validate())
+	})
+	t.Run("retry on", func(t *testing.T) {
+		assert.Equal(t, DefaultRetryOn, (&PolicyRetryPolicy{}).GetRetryOn())
+		assert.Equal(t, "connect-failure,refused-stream,retriable-status-codes",
+			(&PolicyRetryPolicy{RetriableStatusCodes: []uint32{503}}).GetRetryOn())
+		assert.Equal(t, "retriable-status-codes,reset",
+			(&PolicyRetryPolicy{RetryOn: "retriable-status-codes,reset", RetriableStatusCodes: []uint32{503}}).GetRetryOn())
+	})
+}
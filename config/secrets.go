@@ -0,0 +1,42 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/pomerium/pomerium/internal/secrets"
+)
+
+// DefaultSecretsRefreshInterval is how often the values of secret references are re-read.
+const DefaultSecretsRefreshInterval = 5 * time.Minute
+
+// secretResolveTimeout is the timeout for resolving a single secret reference.
+const secretResolveTimeout = 30 * time.Second
+
+// GetSecretsRefreshInterval returns how often the values of secret references are re-read.
+func (o *Options) GetSecretsRefreshInterval() time.Duration {
+	if o.SecretsRefreshInterval <= 0 {
+		return DefaultSecretsRefreshInterval
+	}
+	return o.SecretsRefreshInterval
+}
+
+// resolveSecretReferences replaces the string values of the viper instance which are secret
+// references, like `vault://secret/data/pomerium#shared_secret`, with the values of the secrets.
+// It returns true if any value was a secret reference.
+func resolveSecretReferences(v *viper.Viper) (bool, error) {
+	var found bool
+	err := mapConfigStrings(v, func(s string) (string, error) {
+		if !secrets.IsReference(s) {
+			return s, nil
+		}
+		found = true
+
+		ctx, clearTimeout := context.WithTimeout(context.Background(), secretResolveTimeout)
+		defer clearTimeout()
+		return secrets.DefaultResolver().Resolve(ctx, s)
+	})
+	return found, err
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsFromViperSecretReferences(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/pomerium" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"shared_secret":"UYgnt8bxxK5G2sFaNzyqi5Z+OgF8m2akNc0xdQx718w=","client_secret":"CLIENT_SECRET"},"metadata":{}}}`))
+	}))
+	defer srv.Close()
+	t.Setenv("VAULT_ADDR", srv.URL)
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+insecure_server: true
+autocert_dir: ''
+shared_secret: vault://secret/data/pomerium#shared_secret
+idp_client_secret: vault://secret/data/pomerium#client_secret
+`), 0o600))
+
+	o, err := optionsFromViper(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "UYgnt8bxxK5G2sFaNzyqi5Z+OgF8m2akNc0xdQx718w=", o.SharedKey)
+	assert.Equal(t, "CLIENT_SECRET", o.ClientSecret)
+	assert.True(t, o.hasSecretReferences)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("insecure_server: true\nshared_secret: vault://secret/data/missing#key\n"), 0o600))
+	_, err = optionsFromViper(configFile)
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// The security headers presets.
+const (
+	// SecurityHeadersPresetStrict is for apps which only load resources from their own origin.
+	SecurityHeadersPresetStrict = "strict"
+	// SecurityHeadersPresetModerate is for apps which load resources from other origins, or use
+	// inline scripts and styles.
+	SecurityHeadersPresetModerate = "moderate"
+	// SecurityHeadersPresetLegacy is for apps which break with a content security policy.
+	SecurityHeadersPresetLegacy = "legacy"
+)
+
+// The security header names.
+const (
+	headerContentSecurityPolicy   = "Content-Security-Policy"
+	headerStrictTransportSecurity = "Strict-Transport-Security"
+	headerXFrameOptions           = "X-Frame-Options"
+	headerReferrerPolicy          = "Referrer-Policy"
+	headerPermissionsPolicy       = "Permissions-Policy"
+)
+
+var securityHeadersPresets = map[string]map[string]string{
+	SecurityHeadersPresetStrict: {
+		headerContentSecurityPolicy:   "default-src 'self'; object-src 'none'; base-uri 'self'; form-action 'self'; frame-ancestors 'none'",
+		headerStrictTransportSecurity: "max-age=63072000; includeSubDomains; preload",
+		headerXFrameOptions:           "DENY",
+		headerReferrerPolicy:          "no-referrer",
+		headerPermissionsPolicy:       "accelerometer=(), camera=(), geolocation=(), gyroscope=(), magnetometer=(), microphone=(), payment=(), usb=()",
+	},
+	SecurityHeadersPresetModerate: {
+		headerContentSecurityPolicy:   "object-src 'none'; base-uri 'self'; frame-ancestors 'self'",
+		headerStrictTransportSecurity: "max-age=31536000; includeSubDomains",
+		headerXFrameOptions:           "SAMEORIGIN",
+		headerReferrerPolicy:          "strict-origin-when-cross-origin",
+		headerPermissionsPolicy:       "camera=(), geolocation=(), microphone=()",
+	},
+	SecurityHeadersPresetLegacy: {
+		headerStrictTransportSecurity: "max-age=31536000",
+		headerXFrameOptions:           "SAMEORIGIN",
+		headerReferrerPolicy:          "strict-origin-when-cross-origin",
+	},
+}
+
+// PolicySecurityHeaders are the security headers added to the responses of a route, replacing
+// any set by the upstream. The headers of the preset can be overridden, and are removed when
+// overridden with an empty value.
+type PolicySecurityHeaders struct {
+	// Preset is the name of the preset of the headers: strict, moderate or legacy.
+	Preset string `mapstructure:"preset" yaml:"preset,omitempty" json:"preset,omitempty"`
+
+	ContentSecurityPolicy   *string `mapstructure:"content_security_policy" yaml:"content_security_policy,omitempty" json:"content_security_policy,omitempty"`
+	StrictTransportSecurity *string `mapstructure:"strict_transport_security" yaml:"strict_transport_security,omitempty" json:"strict_transport_security,omitempty"`
+	XFrameOptions           *string `mapstructure:"x_frame_options" yaml:"x_frame_options,omitempty" json:"x_frame_options,omitempty"`
+	ReferrerPolicy          *string `mapstructure:"referrer_policy" yaml:"referrer_policy,omitempty" json:"referrer_policy,omitempty"`
+	PermissionsPolicy       *string `mapstructure:"permissions_policy" yaml:"permissions_policy,omitempty" json:"permissions_policy,omitempty"`
+}
+
+// NewPolicySecurityHeadersFromProto creates a new PolicySecurityHeaders from a protobuf message.
+func NewPolicySecurityHeadersFromProto(pb *configpb.RouteSecurityHeaders) *PolicySecurityHeaders {
+	if pb == nil {
+		return nil
+	}
+	return &PolicySecurityHeaders{
+		Preset:                  pb.GetPreset(),
+		ContentSecurityPolicy:   pb.ContentSecurityPolicy,
+		StrictTransportSecurity: pb.StrictTransportSecurity,
+		XFrameOptions:           pb.XFrameOptions,
+		ReferrerPolicy:          pb.ReferrerPolicy,
+		PermissionsPolicy:       pb.PermissionsPolicy,
+	}
+}
+
+// ToProto converts the security headers to a protobuf message.
+func (sh *PolicySecurityHeaders) ToProto() *configpb.RouteSecurityHeaders {
+	if sh == nil {
+		return nil
+	}
+	return &configpb.RouteSecurityHeaders{
+		Preset:                  sh.Preset,
+		ContentSecurityPolicy:   sh.ContentSecurityPolicy,
+		StrictTransportSecurity: sh.StrictTransportSecurity,
+		XFrameOptions:           sh.XFrameOptions,
+		ReferrerPolicy:          sh.ReferrerPolicy,
+		PermissionsPolicy:       sh.PermissionsPolicy,
+	}
+}
+
+// Validate checks the validity of the security headers.
+func (sh *PolicySecurityHeaders) Validate() error {
+	if sh == nil {
+		return nil
+	}
+	if _, ok := securityHeadersPresets[sh.Preset]; sh.Preset != "" && !ok {
+		return fmt.Errorf("unknown preset: %s", sh.Preset)
+	}
+	for name, value := range sh.overrides() {
+		if value != nil && strings.ContainsAny(*value, "\r\n") {
+			return fmt.Errorf("invalid %s header value", name)
+		}
+	}
+	return nil
+}
+
+// GetHeaders returns the headers of the preset, with the overrides applied.
+func (sh *PolicySecurityHeaders) GetHeaders() map[string]string {
+	if sh == nil {
+		return nil
+	}
+	headers := make(map[string]string)
+	for name, value := range securityHeadersPresets[sh.Preset] {
+		headers[name] = value
+	}
+	for name, value := range sh.overrides() {
+		switch {
+		case value == nil:
+		case *value == "":
+			delete(headers, name)
+		default:
+			headers[name] = *value
+		}
+	}
+	return headers
+}
+
+func (sh *PolicySecurityHeaders) overrides() map[string]*string {
+	return map[string]*string{
+		headerContentSecurityPolicy:   sh.ContentSecurityPolicy,
+		headerStrictTransportSecurity: sh.StrictTransportSecurity,
+		headerXFrameOptions:           sh.XFrameOptions,
+		headerReferrerPolicy:          sh.ReferrerPolicy,
+		headerPermissionsPolicy:       sh.PermissionsPolicy,
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicySecurityHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`
+routes:
+  - from: https://from.example.com
+    to: https://to.example.com
+    security_headers:
+      preset: strict
+      x_frame_options: SAMEORIGIN
+      permissions_policy: ""
+`), 0o600))
+
+		var o Options
+		o.viper = viper.New()
+		o.viper.SetConfigFile(configFile)
+		require.NoError(t, o.viper.ReadInConfig())
+		require.NoError(t, o.parsePolicy())
+		require.Len(t, o.Routes, 1)
+		require.NoError(t, o.Routes[0].Validate())
+
+		xFrameOptions, permissionsPolicy := "SAMEORIGIN", ""
+		assert.Equal(t, &PolicySecurityHeaders{
+			Preset:            SecurityHeadersPresetStrict,
+			XFrameOptions:     &xFrameOptions,
+			PermissionsPolicy: &permissionsPolicy,
+		}, o.Routes[0].SecurityHeaders)
+	})
+	t.Run("proto", func(t *testing.T) {
+		csp := "default-src 'self'"
+		sh := &PolicySecurityHeaders{
+			Preset:                SecurityHeadersPresetModerate,
+			ContentSecurityPolicy: &csp,
+		}
+		assert.Equal(t, sh, NewPolicySecurityHeadersFromProto(sh.ToProto()))
+	})
+	t.Run("validate", func(t *testing.T) {
+		invalid := "DENY\r\nX-Injected: true"
+		for _, tc := range []struct {
+			name            string
+			securityHeaders *PolicySecurityHeaders
+			err             string
+		}{
+			{"unknown preset", &PolicySecurityHeaders{Preset: "paranoid"}, "unknown preset: paranoid"},
+			{"invalid value", &PolicySecurityHeaders{XFrameOptions: &invalid}, "invalid X-Frame-Options header value"},
+		} {
+			err := tc.securityHeaders.Validate()
+			if assert.Error(t, err, tc.name) {
+				assert.Equal(t, tc.err, err.Error(), tc.name)
+			}
+		}
+		assert.NoError(t, (&PolicySecurityHeaders{Preset: SecurityHeadersPresetLegacy}).Validate())
+	})
+	t.Run("headers", func(t *testing.T) {
+		deny, empty := "DENY", ""
+		assert.Equal(t, map[string]string{
+			"Referrer-Policy":           "strict-origin-when-cross-origin",
+			"Strict-Transport-Security": "max-age=31536000",
+			"X-Frame-Options":           "DENY",
+		}, (&PolicySecurityHeaders{Preset: SecurityHeadersPresetLegacy, XFrameOptions: &deny}).GetHeaders())
+		assert.Equal(t, map[string]string{
+			"Strict-Transport-Security": "max-age=31536000",
+			"X-Frame-Options":           "SAMEORIGIN",
+		}, (&PolicySecurityHeaders{Preset: SecurityHeadersPresetLegacy, ReferrerPolicy: &empty}).GetHeaders())
+		assert.Equal(t, map[string]string{
+			"X-Frame-Options": "DENY",
+		}, (&PolicySecurityHeaders{XFrameOptions: &deny}).GetHeaders())
+	})
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// The values session affinity can hash on.
+const (
+	SessionAffinityHashOnUser    = "user"
+	SessionAffinityHashOnSession = "session"
+	SessionAffinityHashOnCookie  = "cookie"
+)
+
+// DefaultSessionAffinityCookieName is the name of the cookie used for cookie based session
+// affinity.
+const DefaultSessionAffinityCookieName = "_pomerium_affinity"
+
+// PolicySessionAffinity keeps the requests of a user on the same upstream of a route, for
+// stateful upstreams behind multiple endpoints.
+type PolicySessionAffinity struct {
+	// HashOn is what requests are hashed on: the user, the session or a cookie. Defaults to the
+	// user.
+	HashOn string `mapstructure:"hash_on" yaml:"hash_on,omitempty" json:"hash_on,omitempty"`
+	// CookieName is the name of the cookie, which is generated by the proxy when missing.
+	CookieName string `mapstructure:"cookie_name" yaml:"cookie_name,omitempty" json:"cookie_name,omitempty"`
+	// CookieTTL is the lifetime of the generated cookie. If unset, a session cookie is generated.
+	CookieTTL *time.Duration `mapstructure:"cookie_ttl" yaml:"cookie_ttl,omitempty" json:"cookie_ttl,omitempty"`
+	// CookiePath is the path of the generated cookie.
+	CookiePath string `mapstructure:"cookie_path" yaml:"cookie_path,omitempty" json:"cookie_path,omitempty"`
+}
+
+// NewPolicySessionAffinityFromProto creates a new PolicySessionAffinity from a protobuf message.
+func NewPolicySessionAffinityFromProto(pb *configpb.RouteSessionAffinity) *PolicySessionAffinity {
+	if pb == nil {
+		return nil
+	}
+	sa := &PolicySessionAffinity{
+		HashOn:     pb.GetHashOn(),
+		CookieName: pb.GetCookieName(),
+		CookiePath: pb.GetCookiePath(),
+	}
+	if pb.CookieTtl != nil {
+		t := pb.GetCookieTtl().AsDuration()
+		sa.CookieTTL = &t
+	}
+	return sa
+}
+
+// ToProto converts the session affinity to a protobuf message.
+func (sa *PolicySessionAffinity) ToProto() *configpb.RouteSessionAffinity {
+	if sa == nil {
+		return nil
+	}
+	pb := &configpb.RouteSessionAffinity{
+		HashOn:     sa.HashOn,
+		CookieName: sa.CookieName,
+		CookiePath: sa.CookiePath,
+	}
+	if sa.CookieTTL != nil {
+		pb.CookieTtl = durationpb.New(*sa.CookieTTL)
+	}
+	return pb
+}
+
+// Validate checks the validity of the session affinity.
+func (sa *PolicySessionAffinity) Validate() error {
+	if sa == nil {
+		return nil
+	}
+	switch sa.GetHashOn() {
+	case SessionAffinityHashOnUser, SessionAffinityHashOnSession:
+		if sa.CookieName != "" || sa.CookieTTL != nil || sa.CookiePath != "" {
+			return fmt.Errorf("cookie options require hash_on to be %s", SessionAffinityHashOnCookie)
+		}
+	case SessionAffinityHashOnCookie:
+		if sa.CookieTTL != nil && *sa.CookieTTL < 0 {
+			return fmt.Errorf("cookie_ttl must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown hash_on: %s", sa.HashOn)
+	}
+	return nil
+}
+
+// GetHashOn returns what requests are hashed on.
+func (sa *PolicySessionAffinity) GetHashOn() string {
+	if sa.HashOn == "" {
+		return SessionAffinityHashOnUser
+	}
+	return sa.HashOn
+}
+
+// GetCookieName returns the name of the session affinity cookie.
+func (sa *PolicySessionAffinity) GetCookieName() string {
+	if sa.CookieName == "" {
+		return DefaultSessionAffinityCookieName
+	}
+	return sa.CookieName
+}
+
+// GetLbPolicy returns the load balancing policy of the route. Routes with session affinity use
+// maglev if it's configured and ring hash otherwise.
+func (p *Policy) GetLbPolicy() envoy_config_cluster_v3.Cluster_LbPolicy {
+	lbPolicy := p.EnvoyOpts.GetLbPolicy()
+	if p.SessionAffinity != nil && lbPolicy != envoy_config_cluster_v3.Cluster_MAGLEV {
+		return envoy_config_cluster_v3.Cluster_RING_HASH
+	}
+	return lbPolicy
+}
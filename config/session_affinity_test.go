@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	envoy_config_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicySessionAffinity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("proto", func(t *testing.T) {
+		ttl := time.Hour
+		sa := &PolicySessionAffinity{
+			HashOn:     SessionAffinityHashOnCookie,
+			CookieName: "affinity",
+			CookieTTL:  &ttl,
+			CookiePath: "/",
+		}
+		assert.Equal(t, sa, NewPolicySessionAffinityFromProto(sa.ToProto()))
+	})
+	t.Run("validate", func(t *testing.T) {
+		negative := -time.Second
+		for _, tc := range []struct {
+			name string
+			sa   *PolicySessionAffinity
+			err  string
+		}{
+			{"unknown hash on", &PolicySessionAffinity{HashOn: "ip"}, "unknown hash_on: ip"},
+			{"cookie options", &PolicySessionAffinity{CookieName: "affinity"}, "cookie options require hash_on to be cookie"},
+			{"negative ttl", &PolicySessionAffinity{HashOn: SessionAffinityHashOnCookie, CookieTTL: &negative}, "cookie_ttl must not be negative"},
+		} {
+			err := tc.sa.Validate()
+			if assert.Error(t, err, tc.name) {
+				assert.Equal(t, tc.err, err.Error(), tc.name)
+			}
+		}
+		assert.NoError(t, (&PolicySessionAffinity{}).Validate())
+		assert.NoError(t, (&PolicySessionAffinity{HashOn: SessionAffinityHashOnCookie, CookieName: "affinity"}).Validate())
+	})
+	t.Run("lb policy", func(t *testing.T) {
+		assert.Equal(t, envoy_config_cluster_v3.Cluster_ROUND_ROBIN, (&Policy{}).GetLbPolicy())
+		assert.Equal(t, envoy_config_cluster_v3.Cluster_RING_HASH, (&Policy{
+			SessionAffinity: &PolicySessionAffinity{},
+		}).GetLbPolicy())
+		assert.Equal(t, envoy_config_cluster_v3.Cluster_MAGLEV, (&Policy{
+			EnvoyOpts:       &envoy_config_cluster_v3.Cluster{LbPolicy: envoy_config_cluster_v3.Cluster_MAGLEV},
+			SessionAffinity: &PolicySessionAffinity{},
+		}).GetLbPolicy())
+	})
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"github.com/pomerium/pomerium/internal/kms"
+	"github.com/pomerium/pomerium/internal/pkcs11"
+)
+
+// GetSigningKeySigner returns the signer for a signing key kept on a PKCS#11 token or in a
+// cloud KMS, or nil when the signing key isn't kept outside of pomerium.
+func (o *Options) GetSigningKeySigner(ctx context.Context) (jose.OpaqueSigner, error) {
+	switch {
+	case o.SigningKeyPKCS11Label != "":
+		return o.getPKCS11Signer()
+	case o.SigningKeyKMS != "":
+		return kms.GetSigner(ctx, o.SigningKeyKMS)
+	default:
+		return nil, nil
+	}
+}
+
+func (o *Options) getPKCS11Signer() (jose.OpaqueSigner, error) {
+	pin := o.PKCS11PIN
+	if o.PKCS11PINFile != "" {
+		bs, err := os.ReadFile(o.PKCS11PINFile)
+		if err != nil {
+			return nil, fmt.Errorf("config: error reading pkcs11_pin_file: %w", err)
+		}
+		pin = strings.TrimSpace(string(bs))
+	}
+
+	return pkcs11.GetSigner(pkcs11.Options{
+		ModulePath: o.PKCS11ModulePath,
+		TokenLabel: o.PKCS11TokenLabel,
+		PIN:        pin,
+		KeyLabel:   o.SigningKeyPKCS11Label,
+	})
+}
+
+func (o *Options) validateSigningKeySigners() error {
+	if o.SigningKeyPKCS11Label == "" && o.SigningKeyKMS == "" {
+		return nil
+	}
+	if o.SigningKeyPKCS11Label != "" && o.SigningKeyKMS != "" {
+		return fmt.Errorf("config: signing_key_pkcs11_label and signing_key_kms cannot both be set")
+	}
+	if o.SigningKey != "" || o.SigningKeyRotationInterval > 0 {
+		return fmt.Errorf("config: signing_key_pkcs11_label and signing_key_kms cannot be used with signing_key or signing_key_rotation_interval")
+	}
+
+	if o.SigningKeyKMS != "" {
+		if err := kms.ValidateURL(o.SigningKeyKMS); err != nil {
+			return fmt.Errorf("config: invalid signing_key_kms: %w", err)
+		}
+		return nil
+	}
+
+	if o.PKCS11ModulePath == "" {
+		return fmt.Errorf("config: signing_key_pkcs11_label requires pkcs11_module_path")
+	}
+	if o.PKCS11PIN != "" && o.PKCS11PINFile != "" {
+		return fmt.Errorf("config: pkcs11_pin and pkcs11_pin_file cannot both be set")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_validateSigningKeySigners(t *testing.T) {
+	o := NewDefaultOptions()
+	assert.NoError(t, o.validateSigningKeySigners())
+
+	o.SigningKeyPKCS11Label = "signing-key"
+	assert.Error(t, o.validateSigningKeySigners(), "should require a module")
+
+	o.PKCS11ModulePath = "/usr/lib/softhsm/libsofthsm2.so"
+	assert.NoError(t, o.validateSigningKeySigners())
+
+	o.SigningKeyRotationInterval = time.Hour
+	assert.Error(t, o.validateSigningKeySigners(), "should not allow rotated signing keys")
+	o.SigningKeyRotationInterval = 0
+
+	o.PKCS11PIN = "1234"
+	o.PKCS11PINFile = "/run/secrets/pin"
+	assert.Error(t, o.validateSigningKeySigners())
+
+	o = NewDefaultOptions()
+	o.SigningKeyKMS = "gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	assert.NoError(t, o.validateSigningKeySigners())
+
+	o.SigningKeyPKCS11Label = "signing-key"
+	assert.Error(t, o.validateSigningKeySigners(), "should not allow both a token and a KMS")
+	o.SigningKeyPKCS11Label = ""
+
+	o.SigningKeyKMS = "gcpkms://signing-key"
+	assert.Error(t, o.validateSigningKeySigners())
+}
+
+func TestOptions_GetSigningKeySigner(t *testing.T) {
+	o := NewDefaultOptions()
+	signer, err := o.GetSigningKeySigner(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, signer)
+
+	o.SigningKeyPKCS11Label = "signing-key"
+	o.PKCS11ModulePath = filepath.Join(t.TempDir(), "missing.so")
+	o.PKCS11PINFile = filepath.Join(t.TempDir(), "pin")
+	_, err = o.GetSigningKeySigner(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist, "should read the PIN file")
+
+	assert.NoError(t, os.WriteFile(o.PKCS11PINFile, []byte("1234\n"), 0o600))
+	_, err = o.GetSigningKeySigner(context.Background())
+	assert.Error(t, err, "should fail to load a missing module")
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ParseSPIFFEWorkloadAPIAddress parses the address of a SPIFFE Workload API, either
+// `unix:///path/to/agent.sock` or `tcp://ip:port`, and returns its network and address.
+func ParseSPIFFEWorkloadAPIAddress(raw string) (network, address string, err error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid spiffe workload api address: %w", err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		if u.Host != "" || u.Path == "" {
+			return "", "", fmt.Errorf("invalid spiffe workload api address: %s, expected unix:///path", raw)
+		}
+		return "unix", u.Path, nil
+	case "tcp":
+		if net.ParseIP(u.Hostname()) == nil || u.Port() == "" {
+			return "", "", fmt.Errorf("invalid spiffe workload api address: %s, expected tcp://ip:port", raw)
+		}
+		return "tcp", u.Host, nil
+	default:
+		return "", "", fmt.Errorf("invalid spiffe workload api address: %s, unsupported scheme", raw)
+	}
+}
+
+// validateSPIFFEID checks that the given SPIFFE ID is a spiffe:// URI with a trust domain.
+func validateSPIFFEID(id string) error {
+	u, err := url.Parse(id)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "spiffe" || u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%s is not a valid spiffe id", id)
+	}
+	return nil
+}
+
+// validateSPIFFETrustDomain checks that the given trust domain is a name without a scheme or path.
+func validateSPIFFETrustDomain(td string) error {
+	if strings.ContainsAny(td, ":/") || strings.ToLower(td) != td {
+		return fmt.Errorf("%s is not a valid spiffe trust domain", td)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSPIFFEWorkloadAPIAddress(t *testing.T) {
+	for _, tc := range []struct {
+		raw     string
+		network string
+		address string
+		err     bool
+	}{
+		{"unix:///run/spire/sockets/agent.sock", "unix", "/run/spire/sockets/agent.sock", false},
+		{"tcp://127.0.0.1:8081", "tcp", "127.0.0.1:8081", false},
+		{"unix://run/agent.sock", "", "", true},
+		{"tcp://spire-agent:8081", "", "", true},
+		{"/run/spire/sockets/agent.sock", "", "", true},
+	} {
+		network, address, err := ParseSPIFFEWorkloadAPIAddress(tc.raw)
+		if tc.err {
+			assert.Error(t, err, tc.raw)
+			continue
+		}
+		assert.NoError(t, err, tc.raw)
+		assert.Equal(t, tc.network, network, tc.raw)
+		assert.Equal(t, tc.address, address, tc.raw)
+	}
+}
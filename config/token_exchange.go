@@ -0,0 +1,126 @@
+package config
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// DefaultTokenExchangeLifetime is the lifetime of tokens issued by token exchange when the
+// token exchange policy doesn't set one.
+const DefaultTokenExchangeLifetime = 5 * time.Minute
+
+// A TokenExchangePolicy allows upstream applications to exchange an attestation JWT for a
+// token for another audience.
+//
+// https://datatracker.ietf.org/doc/html/rfc8693
+type TokenExchangePolicy struct {
+	// FromAudience is the audience of the exchanged token, which for attestation JWTs is the
+	// hostname of the route.
+	FromAudience string `mapstructure:"from_audience" yaml:"from_audience"`
+	// ToAudience is the audience of the issued token.
+	ToAudience string `mapstructure:"to_audience" yaml:"to_audience"`
+	// Scopes are the scopes which may be requested.
+	Scopes []string `mapstructure:"scopes" yaml:"scopes,omitempty"`
+	// Lifetime is the lifetime of the issued token.
+	Lifetime time.Duration `mapstructure:"lifetime" yaml:"lifetime,omitempty"`
+	// ClientID is the id of the client allowed to exchange tokens with the policy. It's recorded
+	// as the actor in the act claim of the issued token.
+	ClientID string `mapstructure:"client_id" yaml:"client_id"`
+	// ClientSecret is the secret the client authenticates with.
+	ClientSecret string `mapstructure:"client_secret" yaml:"client_secret"`
+}
+
+// NewTokenExchangePolicyFromProto creates a new TokenExchangePolicy from a protobuf message.
+func NewTokenExchangePolicyFromProto(pb *configpb.Settings_TokenExchangePolicy) TokenExchangePolicy {
+	policy := TokenExchangePolicy{
+		FromAudience: pb.GetFromAudience(),
+		ToAudience:   pb.GetToAudience(),
+		Scopes:       pb.GetScopes(),
+		ClientID:     pb.GetClientId(),
+		ClientSecret: pb.GetClientSecret(),
+	}
+	if pb.Lifetime != nil {
+		policy.Lifetime = pb.GetLifetime().AsDuration()
+	}
+	return policy
+}
+
+// ToProto converts the TokenExchangePolicy to a protobuf message.
+func (policy TokenExchangePolicy) ToProto() *configpb.Settings_TokenExchangePolicy {
+	pb := &configpb.Settings_TokenExchangePolicy{
+		FromAudience: policy.FromAudience,
+		ToAudience:   policy.ToAudience,
+		Scopes:       policy.Scopes,
+		ClientId:     policy.ClientID,
+		ClientSecret: policy.ClientSecret,
+	}
+	if policy.Lifetime != 0 {
+		pb.Lifetime = durationpb.New(policy.Lifetime)
+	}
+	return pb
+}
+
+// GetLifetime returns the lifetime of the issued token.
+func (policy TokenExchangePolicy) GetLifetime() time.Duration {
+	if policy.Lifetime == 0 {
+		return DefaultTokenExchangeLifetime
+	}
+	return policy.Lifetime
+}
+
+// IsTokenExchangeClient returns true if the client id and secret match those of one of the token
+// exchange policies.
+func (o *Options) IsTokenExchangeClient(clientID, clientSecret string) bool {
+	if clientID == "" {
+		return false
+	}
+	ok := false
+	for _, policy := range o.TokenExchangePolicies {
+		// every policy is compared, so the time taken doesn't reveal which client ids exist
+		if subtle.ConstantTimeCompare([]byte(policy.ClientID), []byte(clientID)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(policy.ClientSecret), []byte(clientSecret)) == 1 {
+			ok = true
+		}
+	}
+	return ok
+}
+
+// GetTokenExchangePolicy returns the token exchange policy allowing the client to exchange a token
+// for one of the given audiences for a token for the requested audience.
+func (o *Options) GetTokenExchangePolicy(clientID string, fromAudiences []string, toAudience string) (TokenExchangePolicy, bool) {
+	for _, policy := range o.TokenExchangePolicies {
+		if policy.ClientID != clientID || policy.ToAudience != toAudience {
+			continue
+		}
+		for _, fromAudience := range fromAudiences {
+			if policy.FromAudience == fromAudience {
+				return policy, true
+			}
+		}
+	}
+	return TokenExchangePolicy{}, false
+}
+
+func (o *Options) validateTokenExchangePolicies() error {
+	if len(o.TokenExchangePolicies) > 0 && o.SigningKey == "" && o.SigningKeyPKCS11Label == "" && o.SigningKeyKMS == "" &&
+		o.SigningKeyRotationInterval <= 0 {
+		return fmt.Errorf("config: token_exchange_policies requires signing_key, signing_key_pkcs11_label, signing_key_kms or signing_key_rotation_interval")
+	}
+	for _, policy := range o.TokenExchangePolicies {
+		if policy.FromAudience == "" || policy.ToAudience == "" {
+			return fmt.Errorf("config: token exchange policies require from_audience and to_audience")
+		}
+		if policy.ClientID == "" || policy.ClientSecret == "" {
+			return fmt.Errorf("config: token exchange policies require client_id and client_secret")
+		}
+		if policy.Lifetime < 0 {
+			return fmt.Errorf("config: token exchange policy lifetime must not be negative: %s", policy.Lifetime)
+		}
+	}
+	return nil
+}
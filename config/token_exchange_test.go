@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_GetTokenExchangePolicy(t *testing.T) {
+	o := NewDefaultOptions()
+	o.TokenExchangePolicies = []TokenExchangePolicy{
+		{FromAudience: "a.example.com", ToAudience: "b.example.com", Lifetime: time.Minute, ClientID: "a", ClientSecret: "a-secret"},
+		{FromAudience: "b.example.com", ToAudience: "c.example.com", ClientID: "b", ClientSecret: "b-secret"},
+	}
+
+	policy, ok := o.GetTokenExchangePolicy("a", []string{"x.example.com", "a.example.com"}, "b.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, policy.GetLifetime())
+
+	policy, ok = o.GetTokenExchangePolicy("b", []string{"b.example.com"}, "c.example.com")
+	assert.True(t, ok)
+	assert.Equal(t, DefaultTokenExchangeLifetime, policy.GetLifetime())
+
+	_, ok = o.GetTokenExchangePolicy("a", []string{"a.example.com"}, "c.example.com")
+	assert.False(t, ok, "should not allow exchanges without a policy")
+	_, ok = o.GetTokenExchangePolicy("b", []string{"a.example.com"}, "b.example.com")
+	assert.False(t, ok, "should not allow exchanges with the policies of other clients")
+}
+
+func TestOptions_IsTokenExchangeClient(t *testing.T) {
+	o := NewDefaultOptions()
+	o.TokenExchangePolicies = []TokenExchangePolicy{
+		{FromAudience: "a.example.com", ToAudience: "b.example.com", ClientID: "a", ClientSecret: "a-secret"},
+	}
+	assert.True(t, o.IsTokenExchangeClient("a", "a-secret"))
+	assert.False(t, o.IsTokenExchangeClient("a", "b-secret"))
+	assert.False(t, o.IsTokenExchangeClient("b", "a-secret"))
+	assert.False(t, o.IsTokenExchangeClient("", ""))
+}
+
+func TestOptions_validateTokenExchangePolicies(t *testing.T) {
+	o := NewDefaultOptions()
+	o.TokenExchangePolicies = []TokenExchangePolicy{{
+		FromAudience: "a.example.com",
+		ToAudience:   "b.example.com",
+		ClientID:     "a",
+		ClientSecret: "a-secret",
+	}}
+	assert.Error(t, o.validateTokenExchangePolicies(), "should require a signing key")
+
+	o.SigningKeyRotationInterval = time.Hour
+	assert.NoError(t, o.validateTokenExchangePolicies())
+
+	o.TokenExchangePolicies[0].ToAudience = ""
+	assert.Error(t, o.validateTokenExchangePolicies())
+
+	o.TokenExchangePolicies[0].ToAudience = "b.example.com"
+	o.TokenExchangePolicies[0].ClientSecret = ""
+	assert.Error(t, o.validateTokenExchangePolicies(), "should require client credentials")
+
+	o.TokenExchangePolicies[0].ClientSecret = "a-secret"
+	o.TokenExchangePolicies[0].Lifetime = -time.Minute
+	assert.Error(t, o.validateTokenExchangePolicies())
+}
@@ -44,6 +44,19 @@ func NewTracingOptions(o *Options) (*TracingOptions, error) {
 			return nil, fmt.Errorf("config: invalid zipkin endpoint url: %w", err)
 		}
 		tracingOpts.ZipkinEndpoint = zipkinEndpoint
+	case trace.OTLPTracingProviderName:
+		otlpEndpoint, err := urlutil.ParseAndValidateURL(o.TracingOTLPEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid otlp endpoint url: %w", err)
+		}
+		switch o.TracingOTLPProtocol {
+		case "", trace.OTLPProtocolGRPC, trace.OTLPProtocolHTTP:
+		default:
+			return nil, fmt.Errorf("config: unknown otlp protocol: %s", o.TracingOTLPProtocol)
+		}
+		tracingOpts.OTLPEndpoint = otlpEndpoint
+		tracingOpts.OTLPProtocol = o.TracingOTLPProtocol
+		tracingOpts.OTLPHeaders = o.TracingOTLPHeaders
 	case "":
 		return &TracingOptions{}, nil
 	default:
@@ -52,6 +52,24 @@ func Test_NewTracingOptions(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"otlp_good",
+			&Options{TracingProvider: "otlp", TracingOTLPEndpoint: "http://otel-collector:4318", TracingOTLPProtocol: "http/protobuf", TracingOTLPHeaders: map[string]string{"api-key": "x"}},
+			&TracingOptions{Provider: "otlp", OTLPEndpoint: &url.URL{Scheme: "http", Host: "otel-collector:4318"}, OTLPProtocol: "http/protobuf", OTLPHeaders: map[string]string{"api-key": "x"}, Service: "pomerium"},
+			false,
+		},
+		{
+			"otlp_bad_protocol",
+			&Options{TracingProvider: "otlp", TracingOTLPEndpoint: "http://otel-collector:4317", TracingOTLPProtocol: "thrift"},
+			nil,
+			true,
+		},
+		{
+			"otlp_bad",
+			&Options{TracingProvider: "otlp", TracingOTLPEndpoint: "notaurl"},
+			nil,
+			true,
+		},
 		{
 			"noprovider",
 			&Options{},
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// PolicyUpstreamGroup is a group of upstreams a percentage of the requests to a route are sent
+// to, for example to canary a new version of a service. The remaining requests are sent to the
+// route's `to` upstreams.
+type PolicyUpstreamGroup struct {
+	Name string       `mapstructure:"name" yaml:"name" json:"name"`
+	To   WeightedURLs `mapstructure:"to" yaml:"to" json:"to"`
+	// Weight is the percentage of requests sent to the group.
+	Weight uint32 `mapstructure:"weight" yaml:"weight,omitempty" json:"weight,omitempty"`
+	// OverrideHeaders send all requests with the given header values to the group, regardless of
+	// its weight.
+	OverrideHeaders map[string]string `mapstructure:"override_headers" yaml:"override_headers,omitempty" json:"override_headers,omitempty"`
+}
+
+// NewPolicyUpstreamGroupFromProto creates a new PolicyUpstreamGroup from a protobuf message.
+func NewPolicyUpstreamGroupFromProto(pb *configpb.RouteUpstreamGroup) (*PolicyUpstreamGroup, error) {
+	to, err := ParseWeightedUrls(pb.GetTo()...)
+	if err != nil {
+		return nil, fmt.Errorf("upstream group %s: %w", pb.GetName(), err)
+	}
+	return &PolicyUpstreamGroup{
+		Name:            pb.GetName(),
+		To:              to,
+		Weight:          pb.GetWeight(),
+		OverrideHeaders: pb.GetOverrideHeaders(),
+	}, nil
+}
+
+// ToProto converts the upstream group to a protobuf message.
+func (g *PolicyUpstreamGroup) ToProto() *configpb.RouteUpstreamGroup {
+	return &configpb.RouteUpstreamGroup{
+		Name:            g.Name,
+		To:              g.To.rawStrings(),
+		Weight:          g.Weight,
+		OverrideHeaders: g.OverrideHeaders,
+	}
+}
+
+func validateUpstreamGroups(groups []PolicyUpstreamGroup) error {
+	var total uint32
+	names := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		if g.Name == "" {
+			return fmt.Errorf("upstream group name is required")
+		}
+		if _, ok := names[g.Name]; ok {
+			return fmt.Errorf("duplicate upstream group: %s", g.Name)
+		}
+		names[g.Name] = struct{}{}
+
+		if _, err := g.To.Validate(); err != nil {
+			return fmt.Errorf("upstream group %s: %w", g.Name, err)
+		}
+		if g.Weight > 100 {
+			return fmt.Errorf("upstream group %s: weight must be a percentage", g.Name)
+		}
+		total += g.Weight
+	}
+	if total > 100 {
+		return fmt.Errorf("upstream group weights must not add up to more than 100")
+	}
+	return nil
+}
+
+// GetToWeight returns the percentage of requests sent to the route's `to` upstreams.
+func (p *Policy) GetToWeight() uint32 {
+	weight := uint32(100)
+	for _, g := range p.UpstreamGroups {
+		weight -= g.Weight
+	}
+	return weight
+}
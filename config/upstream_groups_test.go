@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPolicyUpstreamGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`
+routes:
+  - from: https://from.example.com
+    to: https://stable.example.com
+    upstream_groups:
+      - name: canary
+        to:
+          - https://canary-1.example.com,2
+          - https://canary-2.example.com,1
+        weight: 10
+        override_headers:
+          X-Canary: always
+`), 0o600))
+
+		var o Options
+		o.viper = viper.New()
+		o.viper.SetConfigFile(configFile)
+		require.NoError(t, o.viper.ReadInConfig())
+		require.NoError(t, o.parsePolicy())
+		require.Len(t, o.Routes, 1)
+		require.NoError(t, o.Routes[0].Validate())
+
+		groups := o.Routes[0].UpstreamGroups
+		require.Len(t, groups, 1)
+		assert.Equal(t, "canary", groups[0].Name)
+		assert.Equal(t, []string{"https://canary-1.example.com,2", "https://canary-2.example.com,1"}, groups[0].To.rawStrings())
+		assert.Equal(t, uint32(10), groups[0].Weight)
+		assert.Equal(t, map[string]string{"X-Canary": "always"}, groups[0].OverrideHeaders)
+		assert.Equal(t, uint32(90), o.Routes[0].GetToWeight())
+	})
+	t.Run("proto", func(t *testing.T) {
+		p := &Policy{
+			From: "https://from.example.com",
+			To:   mustParseWeightedURLs(t, "https://stable.example.com"),
+			UpstreamGroups: []PolicyUpstreamGroup{{
+				Name:            "canary",
+				To:              mustParseWeightedURLs(t, "https://canary.example.com"),
+				Weight:          25,
+				OverrideHeaders: map[string]string{"X-Canary": "always"},
+			}},
+		}
+		pb, err := p.ToProto()
+		require.NoError(t, err)
+		p2, err := NewPolicyFromProto(pb)
+		require.NoError(t, err)
+		assert.Equal(t, p.UpstreamGroups, p2.UpstreamGroups)
+		assert.True(t, proto.Equal(pb.GetUpstreamGroups()[0], p2.UpstreamGroups[0].ToProto()))
+	})
+	t.Run("validate", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			groups []PolicyUpstreamGroup
+			err    string
+		}{
+			{"missing name", []PolicyUpstreamGroup{{
+				To: mustParseWeightedURLs(t, "https://canary.example.com"),
+			}}, "upstream group name is required"},
+			{"duplicate name", []PolicyUpstreamGroup{
+				{Name: "canary", To: mustParseWeightedURLs(t, "https://canary.example.com")},
+				{Name: "canary", To: mustParseWeightedURLs(t, "https://canary.example.com")},
+			}, "duplicate upstream group: canary"},
+			{"missing to", []PolicyUpstreamGroup{{Name: "canary"}}, "upstream group canary: "},
+			{"too much weight", []PolicyUpstreamGroup{
+				{Name: "a", To: mustParseWeightedURLs(t, "https://a.example.com"), Weight: 60},
+				{Name: "b", To: mustParseWeightedURLs(t, "https://b.example.com"), Weight: 50},
+			}, "upstream group weights must not add up to more than 100"},
+		} {
+			err := validateUpstreamGroups(tc.groups)
+			if assert.Error(t, err, tc.name) {
+				assert.Contains(t, err.Error(), tc.err, tc.name)
+			}
+		}
+		assert.NoError(t, validateUpstreamGroups([]PolicyUpstreamGroup{
+			{Name: "a", To: mustParseWeightedURLs(t, "https://a.example.com"), Weight: 60},
+			{Name: "b", To: mustParseWeightedURLs(t, "https://b.example.com"), Weight: 40},
+		}))
+	})
+}
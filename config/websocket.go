@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// PolicyWebsocket configures the websocket connections of a route separately from its HTTP
+// requests, so long-lived connections don't require disabling the timeouts of the route.
+type PolicyWebsocket struct {
+	// IdleTimeout is the time a websocket connection may have no activity before it's closed.
+	// If unset, websocket connections never time out for inactivity.
+	IdleTimeout *time.Duration `mapstructure:"idle_timeout" yaml:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`
+	// MaxConnectionDuration is the maximum duration of a websocket connection.
+	MaxConnectionDuration *time.Duration `mapstructure:"max_connection_duration" yaml:"max_connection_duration,omitempty" json:"max_connection_duration,omitempty"`
+}
+
+// NewPolicyWebsocketFromProto creates a new PolicyWebsocket from a protobuf message.
+func NewPolicyWebsocketFromProto(pb *configpb.RouteWebsocket) *PolicyWebsocket {
+	if pb == nil {
+		return nil
+	}
+	ws := new(PolicyWebsocket)
+	if pb.IdleTimeout != nil {
+		t := pb.GetIdleTimeout().AsDuration()
+		ws.IdleTimeout = &t
+	}
+	if pb.MaxConnectionDuration != nil {
+		t := pb.GetMaxConnectionDuration().AsDuration()
+		ws.MaxConnectionDuration = &t
+	}
+	return ws
+}
+
+// ToProto converts the websocket options to a protobuf message.
+func (ws *PolicyWebsocket) ToProto() *configpb.RouteWebsocket {
+	if ws == nil {
+		return nil
+	}
+	pb := new(configpb.RouteWebsocket)
+	if ws.IdleTimeout != nil {
+		pb.IdleTimeout = durationpb.New(*ws.IdleTimeout)
+	}
+	if ws.MaxConnectionDuration != nil {
+		pb.MaxConnectionDuration = durationpb.New(*ws.MaxConnectionDuration)
+	}
+	return pb
+}
+
+// Validate checks the validity of the websocket options.
+func (ws *PolicyWebsocket) Validate() error {
+	if ws == nil {
+		return nil
+	}
+	if ws.IdleTimeout != nil && *ws.IdleTimeout < 0 {
+		return fmt.Errorf("idle_timeout must not be negative")
+	}
+	if ws.MaxConnectionDuration != nil && *ws.MaxConnectionDuration <= 0 {
+		return fmt.Errorf("max_connection_duration must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+// IsWildcardHost returns true if the host, like `*.apps.example.com`, matches every subdomain of
+// the domain after the wildcard.
+func IsWildcardHost(host string) bool {
+	return strings.HasPrefix(host, "*.")
+}
+
+// GetWildcardSubdomain returns the subdomain of the host captured by the wildcard of the from
+// URL of the policy. For `*.apps.example.com` and `app1.apps.example.com` it's `app1`.
+func (p *Policy) GetWildcardSubdomain(host string) (string, bool) {
+	if p.Source == nil || !IsWildcardHost(p.Source.Host) {
+		return "", false
+	}
+
+	// compare the hosts without their default ports
+	wildcardHost, wildcardPort := splitWildcardHostPort(p.Source.Scheme, p.Source.Host)
+	requestHost, requestPort := splitWildcardHostPort(p.Source.Scheme, host)
+	if requestPort != wildcardPort {
+		return "", false
+	}
+
+	suffix := strings.ToLower(strings.TrimPrefix(wildcardHost, "*"))
+	requestHost = strings.ToLower(requestHost)
+	if len(requestHost) <= len(suffix) || !strings.HasSuffix(requestHost, suffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(requestHost, suffix), true
+}
+
+// HasWildcardDestination returns true if the host of a to URL of the policy contains a `*`,
+// which is replaced by the subdomain captured by the wildcard of the from URL.
+func (p *Policy) HasWildcardDestination() bool {
+	for _, u := range p.To {
+		if strings.Contains(u.URL.Host, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWildcardDestination returns the destination with the `*` in its host replaced by the
+// captured subdomain.
+func GetWildcardDestination(dst url.URL, subdomain string) url.URL {
+	dst.Host = strings.Replace(dst.Host, "*", subdomain, 1)
+	return dst
+}
+
+func splitWildcardHostPort(scheme, host string) (string, string) {
+	host = urlutil.GetDomainsForURL(url.URL{Scheme: scheme, Host: host})[0]
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		return h, port
+	}
+	return host, ""
+}
+
+func (p *Policy) validateWildcards() error {
+	isWildcard := IsWildcardHost(p.Source.Host)
+	if strings.Contains(strings.TrimPrefix(p.Source.Host, "*."), "*") {
+		return fmt.Errorf("config: policy source url (%s) can only have a wildcard as its first label", p.Source.String())
+	}
+	if isWildcard && (urlutil.IsTCP(p.Source.URL) || urlutil.IsUDP(p.Source.URL)) {
+		return fmt.Errorf("config: tcp and udp routes cannot have a wildcard source url")
+	}
+
+	if !p.HasWildcardDestination() {
+		return nil
+	}
+	if !isWildcard {
+		return fmt.Errorf("config: wildcard destinations require a wildcard source url")
+	}
+	if len(p.UpstreamGroups) > 0 || len(p.MirrorTo) > 0 {
+		return fmt.Errorf("config: routes with wildcard destinations cannot have upstream_groups or mirror_to")
+	}
+	for _, u := range p.To {
+		if strings.Count(u.URL.Host, "*") > 1 {
+			return fmt.Errorf("config: %s: destinations can only have a single wildcard", u.URL.String())
+		}
+		if IsDiscoveryURL(&u.URL) {
+			return fmt.Errorf("config: %s: discovered upstreams cannot have a wildcard", u.URL.String())
+		}
+	}
+	return nil
+}
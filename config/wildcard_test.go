@@ -0,0 +1,45 @@
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_GetWildcardSubdomain(t *testing.T) {
+	for _, tc := range []struct {
+		from      string
+		host      string
+		subdomain string
+		ok        bool
+	}{
+		{"https://*.apps.example.com", "app1.apps.example.com", "app1", true},
+		{"https://*.apps.example.com", "APP1.apps.example.com:443", "app1", true},
+		{"https://*.apps.example.com", "a.b.apps.example.com", "a.b", true},
+		{"https://*.apps.example.com:8443", "app1.apps.example.com:8443", "app1", true},
+		{"https://*.apps.example.com:8443", "app1.apps.example.com", "", false},
+		{"https://*.apps.example.com", "apps.example.com", "", false},
+		{"https://*.apps.example.com", "app1.apps.example.org", "", false},
+		{"https://app1.apps.example.com", "app1.apps.example.com", "", false},
+	} {
+		p := Policy{From: tc.from, To: mustParseWeightedURLs(t, "https://to.example.com")}
+		require.NoError(t, p.Validate(), tc.from)
+
+		subdomain, ok := p.GetWildcardSubdomain(tc.host)
+		assert.Equal(t, tc.ok, ok, tc.host)
+		assert.Equal(t, tc.subdomain, subdomain, tc.host)
+	}
+}
+
+func TestPolicy_MatchesWildcard(t *testing.T) {
+	p := Policy{From: "https://*.apps.example.com", To: mustParseWeightedURLs(t, "https://*.internal.example.com")}
+	require.NoError(t, p.Validate())
+
+	assert.True(t, p.Matches(url.URL{Scheme: "https", Host: "app1.apps.example.com"}))
+	assert.False(t, p.Matches(url.URL{Scheme: "https", Host: "apps.example.com"}))
+	assert.True(t, p.HasWildcardDestination())
+	assert.Equal(t, "app1.internal.example.com",
+		GetWildcardDestination(p.To[0].URL, "app1").Host)
+}
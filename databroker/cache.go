@@ -14,16 +14,23 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/audit"
 	"github.com/pomerium/pomerium/internal/directory"
 	"github.com/pomerium/pomerium/internal/envoy/files"
 	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/internal/identity/health"
 	"github.com/pomerium/pomerium/internal/identity/manager"
+	"github.com/pomerium/pomerium/internal/identity/oauth"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/signingkey"
 	"github.com/pomerium/pomerium/internal/telemetry"
 	"github.com/pomerium/pomerium/internal/version"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
 	"github.com/pomerium/pomerium/pkg/grpc/registry"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
 	"github.com/pomerium/pomerium/pkg/grpcutil"
 )
 
@@ -32,6 +39,9 @@ import (
 type DataBroker struct {
 	dataBrokerServer *dataBrokerServer
 	manager          *manager.Manager
+	healthChecker    *health.Checker
+	signingKeys      *signingkey.Rotator
+	auditTrail       *audit.Trail
 
 	localListener                net.Listener
 	localGRPCServer              *grpc.Server
@@ -39,8 +49,10 @@ type DataBroker struct {
 	dataBrokerStorageType        string // TODO remove in v0.11
 	deprecatedCacheClusterDomain string // TODO: remove in v0.11
 
-	mu                sync.Mutex
-	directoryProvider directory.Provider
+	mu                           sync.Mutex
+	directoryProvider            directory.Provider
+	impersonationRequesterGroups []string
+	impersonationApproverGroups  []string
 }
 
 // New creates a new databroker service.
@@ -96,6 +108,9 @@ func New(cfg *config.Config) (*DataBroker, error) {
 		localGRPCConnection:          localGRPCConnection,
 		deprecatedCacheClusterDomain: dataBrokerURLs[0].Hostname(),
 		dataBrokerStorageType:        cfg.Options.DataBrokerStorageType,
+		healthChecker:                health.New(),
+		signingKeys:                  signingkey.NewRotator(databroker.NewDataBrokerServiceClient(localGRPCConnection)),
+		auditTrail:                   audit.NewTrail(databroker.NewDataBrokerServiceClient(localGRPCConnection)),
 	}
 	c.Register(c.localGRPCServer)
 
@@ -120,8 +135,12 @@ func (c *DataBroker) OnConfigChange(ctx context.Context, cfg *config.Config) {
 // Register registers all the gRPC services with the given server.
 func (c *DataBroker) Register(grpcServer *grpc.Server) {
 	databroker.RegisterDataBrokerServiceServer(grpcServer, c.dataBrokerServer)
+	device.RegisterDeviceServiceServer(grpcServer, c)
 	directory.RegisterDirectoryServiceServer(grpcServer, c)
 	registry.RegisterRegistryServer(grpcServer, c.dataBrokerServer)
+	session.RegisterImpersonationServiceServer(grpcServer, c)
+	session.RegisterSessionServiceServer(grpcServer, c)
+	user.RegisterServiceAccountServiceServer(grpcServer, c)
 }
 
 // Run runs the databroker components.
@@ -138,6 +157,15 @@ func (c *DataBroker) Run(ctx context.Context) error {
 	eg.Go(func() error {
 		return c.manager.Run(ctx)
 	})
+	eg.Go(func() error {
+		return c.healthChecker.Run(ctx)
+	})
+	eg.Go(func() error {
+		return c.signingKeys.Run(ctx)
+	})
+	eg.Go(func() error {
+		return c.runImpersonationExpiry(ctx)
+	})
 	return eg.Wait()
 }
 
@@ -156,6 +184,26 @@ func (c *DataBroker) update(cfg *config.Config) error {
 		return fmt.Errorf("databroker: failed to create authenticator: %w", err)
 	}
 
+	authenticators := make(map[string]manager.Authenticator)
+	for _, name := range cfg.Options.GetIdentityProviderNames() {
+		idp, _ := cfg.Options.GetIdentityProviderForName(name)
+		a, err := identity.NewAuthenticator(oauth.Options{
+			RedirectURL:     oauthOptions.RedirectURL,
+			ProviderName:    idp.GetType(),
+			ProviderURL:     idp.GetUrl(),
+			ClientID:        idp.GetClientId(),
+			ClientSecret:    idp.GetClientSecret(),
+			Scopes:          idp.GetScopes(),
+			ServiceAccount:  idp.GetServiceAccount(),
+			AuthCodeOptions: idp.GetRequestParams(),
+			ClaimsMapping:   idp.GetClaimsMapping(),
+		})
+		if err != nil {
+			return fmt.Errorf("databroker: failed to create authenticator for identity provider %s: %w", name, err)
+		}
+		authenticators[idp.GetId()] = a
+	}
+
 	directoryProvider := directory.GetProvider(directory.Options{
 		ServiceAccount: cfg.Options.ServiceAccount,
 		Provider:       cfg.Options.Provider,
@@ -163,19 +211,40 @@ func (c *DataBroker) update(cfg *config.Config) error {
 		QPS:            cfg.Options.GetQPS(),
 		ClientID:       cfg.Options.ClientID,
 		ClientSecret:   cfg.Options.ClientSecret,
+		SCIMEnabled:    cfg.Options.SCIMBearerToken != "",
 	})
 	c.mu.Lock()
 	c.directoryProvider = directoryProvider
+	c.impersonationRequesterGroups = cfg.Options.ImpersonationRequesterGroups
+	c.impersonationApproverGroups = cfg.Options.ImpersonationApproverGroups
 	c.mu.Unlock()
 
 	dataBrokerClient := databroker.NewDataBrokerServiceClient(c.localGRPCConnection)
 
+	c.healthChecker.UpdateConfig(cfg.Options.IdentityProviderHealthCheckInterval, cfg.Options.GetAllIdentityProviders())
+	sharedKey, _ := cfg.Options.GetSharedKey()
+	c.signingKeys.UpdateConfig(sharedKey, cfg.Options.SigningKeyAlgorithm,
+		cfg.Options.SigningKeyRotationInterval, cfg.Options.SigningKeyRotationOverlap)
+	c.auditTrail.UpdateConfig(sharedKey)
+	if cfg.Options.AuditTrail {
+		audit.SetTrail(c.auditTrail)
+	} else {
+		audit.SetTrail(nil)
+	}
+	var identityProviderHealth manager.IdentityProviderHealth
+	if cfg.Options.IdentityProviderGraceMode {
+		identityProviderHealth = c.healthChecker
+	}
+
 	options := []manager.Option{
 		manager.WithAuthenticator(authenticator),
+		manager.WithIdentityProviderAuthenticators(authenticators),
 		manager.WithDirectoryProvider(directoryProvider),
 		manager.WithDataBrokerClient(dataBrokerClient),
 		manager.WithGroupRefreshInterval(cfg.Options.RefreshDirectoryInterval),
 		manager.WithGroupRefreshTimeout(cfg.Options.RefreshDirectoryTimeout),
+		manager.WithSessionIdleTimeout(cfg.Options.SessionIdleTimeout),
+		manager.WithIdentityProviderGraceMode(identityProviderHealth),
 	}
 
 	if c.manager == nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 	databrokerpb "github.com/pomerium/pomerium/pkg/grpc/databroker"
 	registrypb "github.com/pomerium/pomerium/pkg/grpc/registry"
 	"github.com/pomerium/pomerium/pkg/grpcutil"
@@ -16,8 +17,9 @@ import (
 
 // A dataBrokerServer implements the data broker service interface.
 type dataBrokerServer struct {
-	server    *databroker.Server
-	sharedKey atomic.Value
+	server             *databroker.Server
+	sharedKey          atomic.Value
+	acceptedSharedKeys atomic.Value
 }
 
 // newDataBrokerServer creates a new databroker service server.
@@ -25,6 +27,7 @@ func newDataBrokerServer(cfg *config.Config) *dataBrokerServer {
 	srv := &dataBrokerServer{}
 	srv.server = databroker.New(srv.getOptions(cfg)...)
 	srv.setKey(cfg)
+	metrics.SetDataBrokerStatsProvider(srv.server.GetStats)
 	return srv
 }
 
@@ -38,11 +41,14 @@ func (srv *dataBrokerServer) getOptions(cfg *config.Config) []databroker.ServerO
 	cert, _ := cfg.Options.GetDataBrokerCertificate()
 	return []databroker.ServerOption{
 		databroker.WithGetSharedKey(cfg.Options.GetSharedKey),
+		databroker.WithAcceptedSharedKeys(getAcceptedSharedKeys(cfg)),
 		databroker.WithStorageType(cfg.Options.DataBrokerStorageType),
 		databroker.WithStorageConnectionString(cfg.Options.DataBrokerStorageConnectionString),
 		databroker.WithStorageCAFile(cfg.Options.DataBrokerStorageCAFile),
 		databroker.WithStorageCertificate(cert),
 		databroker.WithStorageCertSkipVerify(cfg.Options.DataBrokerStorageCertSkipVerify),
+		databroker.WithStorageCacheSize(cfg.Options.DataBrokerStorageCacheSize),
+		databroker.WithStorageSnapshotParallelism(cfg.Options.DataBrokerStorageSnapshotParallelism),
 	}
 }
 
@@ -52,68 +58,102 @@ func (srv *dataBrokerServer) setKey(cfg *config.Config) {
 		bs = make([]byte, 0)
 	}
 	srv.sharedKey.Store(bs)
+	srv.acceptedSharedKeys.Store(getAcceptedSharedKeys(cfg))
+}
+
+// getAcceptedSharedKeys gets the accepted shared keys, without the shared key.
+func getAcceptedSharedKeys(cfg *config.Config) [][]byte {
+	keys, err := cfg.Options.GetAcceptedSharedKeys()
+	if err != nil {
+		return nil
+	}
+	return keys[1:]
+}
+
+// requireSignedJWT requires the request be signed by the shared key, or one of the accepted
+// shared keys.
+func (srv *dataBrokerServer) requireSignedJWT(ctx context.Context) error {
+	keys := [][]byte{srv.sharedKey.Load().([]byte)}
+	if acceptedSharedKeys, ok := srv.acceptedSharedKeys.Load().([][]byte); ok {
+		keys = append(keys, acceptedSharedKeys...)
+	}
+	return grpcutil.RequireSignedJWT(ctx, keys...)
 }
 
 // Databroker functions
 
 func (srv *dataBrokerServer) AcquireLease(ctx context.Context, req *databrokerpb.AcquireLeaseRequest) (*databrokerpb.AcquireLeaseResponse, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.AcquireLease(ctx, req)
 }
 
 func (srv *dataBrokerServer) Get(ctx context.Context, req *databrokerpb.GetRequest) (*databrokerpb.GetResponse, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.Get(ctx, req)
 }
 
+func (srv *dataBrokerServer) ListLeases(ctx context.Context, req *databrokerpb.ListLeasesRequest) (*databrokerpb.ListLeasesResponse, error) {
+	if err := srv.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	return srv.server.ListLeases(ctx, req)
+}
+
 func (srv *dataBrokerServer) Query(ctx context.Context, req *databrokerpb.QueryRequest) (*databrokerpb.QueryResponse, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.Query(ctx, req)
 }
 
 func (srv *dataBrokerServer) Put(ctx context.Context, req *databrokerpb.PutRequest) (*databrokerpb.PutResponse, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.Put(ctx, req)
 }
 
+func (srv *dataBrokerServer) RateLimit(ctx context.Context, req *databrokerpb.RateLimitRequest) (*databrokerpb.RateLimitResponse, error) {
+	if err := srv.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	return srv.server.RateLimit(ctx, req)
+}
+
 func (srv *dataBrokerServer) ReleaseLease(ctx context.Context, req *databrokerpb.ReleaseLeaseRequest) (*emptypb.Empty, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.ReleaseLease(ctx, req)
 }
 
 func (srv *dataBrokerServer) RenewLease(ctx context.Context, req *databrokerpb.RenewLeaseRequest) (*emptypb.Empty, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.RenewLease(ctx, req)
 }
 
 func (srv *dataBrokerServer) SetOptions(ctx context.Context, req *databrokerpb.SetOptionsRequest) (*databrokerpb.SetOptionsResponse, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.SetOptions(ctx, req)
 }
 
 func (srv *dataBrokerServer) Sync(req *databrokerpb.SyncRequest, stream databrokerpb.DataBrokerService_SyncServer) error {
-	if err := grpcutil.RequireSignedJWT(stream.Context(), srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(stream.Context()); err != nil {
 		return err
 	}
 	return srv.server.Sync(req, stream)
 }
 
 func (srv *dataBrokerServer) SyncLatest(req *databrokerpb.SyncLatestRequest, stream databrokerpb.DataBrokerService_SyncLatestServer) error {
-	if err := grpcutil.RequireSignedJWT(stream.Context(), srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(stream.Context()); err != nil {
 		return err
 	}
 	return srv.server.SyncLatest(req, stream)
@@ -122,21 +162,21 @@ func (srv *dataBrokerServer) SyncLatest(req *databrokerpb.SyncLatestRequest, str
 // Registry functions
 
 func (srv *dataBrokerServer) Report(ctx context.Context, req *registrypb.RegisterRequest) (*registrypb.RegisterResponse, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.Report(ctx, req)
 }
 
 func (srv *dataBrokerServer) List(ctx context.Context, req *registrypb.ListRequest) (*registrypb.ServiceList, error) {
-	if err := grpcutil.RequireSignedJWT(ctx, srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(ctx); err != nil {
 		return nil, err
 	}
 	return srv.server.List(ctx, req)
 }
 
 func (srv *dataBrokerServer) Watch(req *registrypb.ListRequest, stream registrypb.Registry_WatchServer) error {
-	if err := grpcutil.RequireSignedJWT(stream.Context(), srv.sharedKey.Load().([]byte)); err != nil {
+	if err := srv.requireSignedJWT(stream.Context()); err != nil {
 		return err
 	}
 	return srv.server.Watch(req, stream)
@@ -0,0 +1,248 @@
+package databroker
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// like sessions, devices are listed up to a limit
+const listDevicesLimit = 100000
+
+// The statuses of devices. A device is pending until its enrollment is approved.
+const (
+	deviceStatusPending  = "pending"
+	deviceStatusApproved = "approved"
+)
+
+// ListDevices lists the device credentials, optionally only those of a user, or those with a
+// status.
+func (c *DataBroker) ListDevices(
+	ctx context.Context,
+	req *device.ListDevicesRequest,
+) (*device.ListDevicesResponse, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	switch req.GetStatus() {
+	case "", deviceStatusPending, deviceStatusApproved:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status: %s", req.GetStatus())
+	}
+
+	credentials, err := c.listDeviceCredentials(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := c.listDeviceEnrollments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(device.ListDevicesResponse)
+	for _, credential := range credentials {
+		summary := newDeviceSummary(credential, enrollments[credential.GetEnrollmentId()])
+		if req.GetStatus() != "" && summary.GetStatus() != req.GetStatus() {
+			continue
+		}
+		res.Devices = append(res.Devices, summary)
+	}
+	return res, nil
+}
+
+// ApproveDevice approves the enrollment of a device credential, so it satisfies policies
+// requiring approved devices.
+func (c *DataBroker) ApproveDevice(
+	ctx context.Context,
+	req *device.ApproveDeviceRequest,
+) (*device.ApproveDeviceResponse, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetCredentialId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "credential_id is required")
+	}
+	if req.GetApprovedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "approved_by is required")
+	}
+
+	credential := new(device.Credential)
+	if err := c.getRecord(ctx, req.GetCredentialId(), credential); err != nil {
+		return nil, err
+	}
+	enrollment := new(device.Enrollment)
+	err := c.getRecord(ctx, credential.GetEnrollmentId(), enrollment)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.FailedPrecondition, "device has no enrollment")
+	} else if err != nil {
+		return nil, err
+	}
+	if enrollment.GetApprovedBy() != "" {
+		return nil, status.Error(codes.FailedPrecondition, "device is already approved")
+	}
+
+	enrollment.ApprovedBy = req.GetApprovedBy()
+	enrollment.ApprovedAt = timestamppb.Now()
+	_, err = c.dataBrokerServer.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{newRecord(enrollment.GetId(), enrollment)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx).
+		Str("credential-id", credential.GetId()).
+		Str("user-id", credential.GetUserId()).
+		Str("approved-by", enrollment.GetApprovedBy()).
+		Msg("databroker: device approved")
+	audit.Publish(ctx, &audit.Event{
+		Type:    audit.EventTypeDeviceApproved,
+		Message: "device approved",
+		UserId:  credential.GetUserId(),
+		Details: map[string]string{
+			"credential_id": credential.GetId(),
+			"enrollment_id": enrollment.GetId(),
+			"approved_by":   enrollment.GetApprovedBy(),
+		},
+	})
+
+	return &device.ApproveDeviceResponse{Device: newDeviceSummary(credential, enrollment)}, nil
+}
+
+// RevokeDevice deletes a device credential and its enrollment, and removes the credential from
+// its user. Policies requiring the device stop matching once the deletion is synced.
+func (c *DataBroker) RevokeDevice(
+	ctx context.Context,
+	req *device.RevokeDeviceRequest,
+) (*emptypb.Empty, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetCredentialId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "credential_id is required")
+	}
+
+	credential := new(device.Credential)
+	if err := c.getRecord(ctx, req.GetCredentialId(), credential); err != nil {
+		return nil, err
+	}
+
+	deletedCredential := newRecord(credential.GetId(), new(device.Credential))
+	deletedCredential.DeletedAt = timestamppb.Now()
+	records := []*databroker.Record{deletedCredential}
+	if credential.GetEnrollmentId() != "" {
+		deletedEnrollment := newRecord(credential.GetEnrollmentId(), new(device.Enrollment))
+		deletedEnrollment.DeletedAt = timestamppb.Now()
+		records = append(records, deletedEnrollment)
+	}
+
+	u := new(user.User)
+	err := c.getRecord(ctx, credential.GetUserId(), u)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	} else if err == nil {
+		ids := u.DeviceCredentialIds[:0]
+		for _, id := range u.GetDeviceCredentialIds() {
+			if id != credential.GetId() {
+				ids = append(ids, id)
+			}
+		}
+		u.DeviceCredentialIds = ids
+		records = append(records, newRecord(u.GetId(), u))
+	}
+
+	_, err = c.dataBrokerServer.Put(ctx, &databroker.PutRequest{Records: records})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx).
+		Str("credential-id", credential.GetId()).
+		Str("user-id", credential.GetUserId()).
+		Msg("databroker: device revoked")
+	audit.Publish(ctx, &audit.Event{
+		Type:    audit.EventTypeDeviceRevoked,
+		Message: "device revoked",
+		UserId:  credential.GetUserId(),
+		Details: map[string]string{
+			"credential_id": credential.GetId(),
+			"enrollment_id": credential.GetEnrollmentId(),
+		},
+	})
+
+	return new(emptypb.Empty), nil
+}
+
+func (c *DataBroker) listDeviceCredentials(ctx context.Context, userID string) ([]*device.Credential, error) {
+	res, err := c.dataBrokerServer.Query(ctx, &databroker.QueryRequest{
+		Type:  protoutil.GetTypeURL(new(device.Credential)),
+		Limit: listDevicesLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []*device.Credential
+	for _, record := range res.GetRecords() {
+		credential := new(device.Credential)
+		if err := record.GetData().UnmarshalTo(credential); err != nil {
+			return nil, err
+		}
+		if userID != "" && credential.GetUserId() != userID {
+			continue
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials, nil
+}
+
+func (c *DataBroker) listDeviceEnrollments(ctx context.Context) (map[string]*device.Enrollment, error) {
+	res, err := c.dataBrokerServer.Query(ctx, &databroker.QueryRequest{
+		Type:  protoutil.GetTypeURL(new(device.Enrollment)),
+		Limit: listDevicesLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments := make(map[string]*device.Enrollment, len(res.GetRecords()))
+	for _, record := range res.GetRecords() {
+		enrollment := new(device.Enrollment)
+		if err := record.GetData().UnmarshalTo(enrollment); err != nil {
+			return nil, err
+		}
+		enrollments[enrollment.GetId()] = enrollment
+	}
+	return enrollments, nil
+}
+
+func newDeviceSummary(credential *device.Credential, enrollment *device.Enrollment) *device.DeviceSummary {
+	summary := &device.DeviceSummary{
+		CredentialId: credential.GetId(),
+		TypeId:       credential.GetTypeId(),
+		UserId:       credential.GetUserId(),
+		Name:         credential.GetName(),
+		EnrollmentId: credential.GetEnrollmentId(),
+		Status:       deviceStatusPending,
+		ApprovedBy:   enrollment.GetApprovedBy(),
+		ApprovedAt:   enrollment.GetApprovedAt(),
+		EnrolledAt:   enrollment.GetEnrolledAt(),
+		LastUsedAt:   credential.GetLastUsedAt(),
+		UserAgent:    enrollment.GetUserAgent(),
+		IpAddress:    enrollment.GetIpAddress(),
+	}
+	if enrollment.GetApprovedBy() != "" {
+		summary.Status = deviceStatusApproved
+	}
+	return summary
+}
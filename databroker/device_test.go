@@ -0,0 +1,113 @@
+package databroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func TestDevices(t *testing.T) {
+	sharedKey := cryptutil.NewKey()
+	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: sharedKey}, nil)
+	require.NoError(t, err)
+	rawJWT, err := jwt.Signed(sig).Claims(jwt.Claims{
+		Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutil.JWTMetadataKey, rawJWT))
+
+	srv := &dataBrokerServer{server: internal_databroker.New()}
+	srv.sharedKey.Store(sharedKey)
+	c := &DataBroker{dataBrokerServer: srv}
+
+	_, err = c.dataBrokerServer.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{
+			newRecord("c1", &device.Credential{Id: "c1", UserId: "u1", EnrollmentId: "e1", Name: "laptop"}),
+			newRecord("c2", &device.Credential{Id: "c2", UserId: "u1", EnrollmentId: "e2"}),
+			newRecord("c3", &device.Credential{Id: "c3", UserId: "u2", EnrollmentId: "e3"}),
+			newRecord("e1", &device.Enrollment{Id: "e1", UserId: "u1", CredentialId: "c1", UserAgent: "curl/7.79.1"}),
+			newRecord("e2", &device.Enrollment{Id: "e2", UserId: "u1", CredentialId: "c2", ApprovedBy: "admin"}),
+			newRecord("e3", &device.Enrollment{Id: "e3", UserId: "u2", CredentialId: "c3"}),
+			newRecord("u1", &user.User{Id: "u1", DeviceCredentialIds: []string{"c1", "c2"}}),
+		},
+	})
+	require.NoError(t, err)
+
+	listIDs := func(req *device.ListDevicesRequest) []string {
+		res, err := c.ListDevices(ctx, req)
+		require.NoError(t, err)
+		var ids []string
+		for _, d := range res.GetDevices() {
+			ids = append(ids, d.GetCredentialId())
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"c1", "c2", "c3"}, listIDs(&device.ListDevicesRequest{}))
+	assert.Equal(t, []string{"c1", "c2"}, listIDs(&device.ListDevicesRequest{UserId: "u1"}))
+	assert.Equal(t, []string{"c1", "c3"}, listIDs(&device.ListDevicesRequest{Status: deviceStatusPending}))
+	assert.Equal(t, []string{"c2"}, listIDs(&device.ListDevicesRequest{Status: deviceStatusApproved}))
+	_, err = c.ListDevices(ctx, &device.ListDevicesRequest{Status: "unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err := c.ListDevices(ctx, &device.ListDevicesRequest{UserId: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, "laptop", res.GetDevices()[0].GetName())
+	assert.Equal(t, "curl/7.79.1", res.GetDevices()[0].GetUserAgent())
+
+	t.Run("approve", func(t *testing.T) {
+		_, err := c.ApproveDevice(ctx, &device.ApproveDeviceRequest{CredentialId: "c1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = c.ApproveDevice(ctx, &device.ApproveDeviceRequest{CredentialId: "c2", ApprovedBy: "admin"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), "should not approve twice")
+
+		res, err := c.ApproveDevice(ctx, &device.ApproveDeviceRequest{CredentialId: "c1", ApprovedBy: "admin"})
+		require.NoError(t, err)
+		assert.Equal(t, deviceStatusApproved, res.GetDevice().GetStatus())
+		assert.NotNil(t, res.GetDevice().GetApprovedAt())
+
+		enrollment := new(device.Enrollment)
+		require.NoError(t, c.getRecord(ctx, "e1", enrollment))
+		assert.Equal(t, "admin", enrollment.GetApprovedBy())
+	})
+	t.Run("revoke", func(t *testing.T) {
+		_, err := c.RevokeDevice(ctx, &device.RevokeDeviceRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = c.RevokeDevice(ctx, &device.RevokeDeviceRequest{CredentialId: "c1"})
+		require.NoError(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(c.getRecord(ctx, "c1", new(device.Credential))))
+		assert.Equal(t, codes.NotFound, status.Code(c.getRecord(ctx, "e1", new(device.Enrollment))))
+		u := new(user.User)
+		require.NoError(t, c.getRecord(ctx, "u1", u))
+		assert.Equal(t, []string{"c2"}, u.GetDeviceCredentialIds())
+
+		_, err = c.RevokeDevice(ctx, &device.RevokeDeviceRequest{CredentialId: "c1"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = c.RevokeDevice(ctx, &device.RevokeDeviceRequest{CredentialId: "c3"})
+		assert.NoError(t, err, "should revoke devices of unknown users")
+	})
+
+	_, err = c.ListDevices(context.Background(), &device.ListDevicesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	_, err = c.ApproveDevice(context.Background(), &device.ApproveDeviceRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	_, err = c.RevokeDevice(context.Background(), &device.RevokeDeviceRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+}
@@ -0,0 +1,344 @@
+package databroker
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+const (
+	defaultImpersonationDuration = time.Hour
+	maxImpersonationDuration     = 24 * time.Hour
+	// impersonationExpiryInterval is how often expired impersonations are cleared.
+	impersonationExpiryInterval = time.Minute
+	impersonationExpiryPageSize = 100
+)
+
+// RequestImpersonation requests for the session of the caller to impersonate the user of another
+// session. The caller must be a member of one of the impersonation requester groups, and the
+// impersonation does not take effect until it has been approved by another user.
+func (c *DataBroker) RequestImpersonation(
+	ctx context.Context,
+	req *session.RequestImpersonationRequest,
+) (*session.RequestImpersonationResponse, error) {
+	caller, err := c.getCallerSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	requesterGroups := c.impersonationRequesterGroups
+	c.mu.Unlock()
+	if err := c.requireGroupMember(ctx, caller, requesterGroups); err != nil {
+		return nil, err
+	}
+	if req.GetImpersonateSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "impersonate_session_id is required")
+	}
+	if caller.GetId() == req.GetImpersonateSessionId() {
+		return nil, status.Error(codes.InvalidArgument, "a session cannot impersonate itself")
+	}
+
+	duration := defaultImpersonationDuration
+	if req.Duration != nil {
+		duration = req.GetDuration().AsDuration()
+	}
+	if duration <= 0 || duration > maxImpersonationDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "duration must be between 0 and %s", maxImpersonationDuration)
+	}
+
+	if err := c.getRecord(ctx, req.GetImpersonateSessionId(), new(session.Session)); err != nil {
+		return nil, err
+	}
+
+	impersonation := &session.Impersonation{
+		Id:                   caller.GetId(),
+		ImpersonateSessionId: req.GetImpersonateSessionId(),
+		RequestedBy:          caller.GetUserId(),
+		Reason:               req.GetReason(),
+		Duration:             durationpb.New(duration),
+		RequestedAt:          timestamppb.Now(),
+	}
+	_, err = c.dataBrokerServer.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{newRecord(impersonation.GetId(), impersonation)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx).
+		Str("session-id", impersonation.GetId()).
+		Str("impersonate-session-id", impersonation.GetImpersonateSessionId()).
+		Str("requested-by", impersonation.GetRequestedBy()).
+		Str("reason", impersonation.GetReason()).
+		Dur("duration", duration).
+		Msg("databroker: impersonation requested")
+	audit.Publish(ctx, &audit.Event{
+		Type:      audit.EventTypeImpersonationRequested,
+		Message:   "impersonation requested",
+		SessionId: impersonation.GetId(),
+		Details: map[string]string{
+			"impersonate_session_id": impersonation.GetImpersonateSessionId(),
+			"requested_by":           impersonation.GetRequestedBy(),
+			"reason":                 impersonation.GetReason(),
+			"duration":               duration.String(),
+		},
+	})
+
+	return &session.RequestImpersonationResponse{Impersonation: impersonation}, nil
+}
+
+// ApproveImpersonation approves a previously requested impersonation as the user of the session
+// of the caller, who must be a member of one of the impersonation approver groups, and
+// different from the user who requested the impersonation.
+func (c *DataBroker) ApproveImpersonation(
+	ctx context.Context,
+	req *session.ApproveImpersonationRequest,
+) (*session.ApproveImpersonationResponse, error) {
+	caller, err := c.getCallerSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	approverGroups := c.impersonationApproverGroups
+	c.mu.Unlock()
+	if err := c.requireGroupMember(ctx, caller, approverGroups); err != nil {
+		return nil, err
+	}
+	if req.GetSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	impersonation := new(session.Impersonation)
+	if err := c.getRecord(ctx, req.GetSessionId(), impersonation); err != nil {
+		return nil, err
+	}
+	if impersonation.GetApprovedAt() != nil {
+		return nil, status.Error(codes.FailedPrecondition, "impersonation has already been approved")
+	}
+	if caller.GetUserId() == impersonation.GetRequestedBy() || caller.GetId() == impersonation.GetId() {
+		return nil, status.Error(codes.PermissionDenied, "impersonation must be approved by a different user")
+	}
+
+	s := new(session.Session)
+	if err := c.getRecord(ctx, impersonation.GetId(), s); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	impersonation.ApprovedBy = caller.GetUserId()
+	impersonation.ApprovedAt = timestamppb.New(now)
+	impersonation.ExpiresAt = timestamppb.New(now.Add(impersonation.GetDuration().AsDuration()))
+	s.ImpersonateSessionId = &impersonation.ImpersonateSessionId
+
+	_, err = c.dataBrokerServer.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{
+			newRecord(impersonation.GetId(), impersonation),
+			newRecord(s.GetId(), s),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx).
+		Str("session-id", impersonation.GetId()).
+		Str("impersonate-session-id", impersonation.GetImpersonateSessionId()).
+		Str("requested-by", impersonation.GetRequestedBy()).
+		Str("approved-by", impersonation.GetApprovedBy()).
+		Time("expires-at", impersonation.GetExpiresAt().AsTime()).
+		Msg("databroker: impersonation approved")
+	audit.Publish(ctx, &audit.Event{
+		Type:      audit.EventTypeImpersonationApproved,
+		Message:   "impersonation approved",
+		UserId:    s.GetUserId(),
+		SessionId: impersonation.GetId(),
+		Details: map[string]string{
+			"impersonate_session_id": impersonation.GetImpersonateSessionId(),
+			"requested_by":           impersonation.GetRequestedBy(),
+			"approved_by":            impersonation.GetApprovedBy(),
+			"expires_at":             impersonation.GetExpiresAt().AsTime().Format(time.RFC3339),
+		},
+	})
+
+	return &session.ApproveImpersonationResponse{Impersonation: impersonation}, nil
+}
+
+// getCallerSession returns the session of the caller, set in the session id metadata of the
+// request by the pomerium service calling on behalf of a signed in user.
+func (c *DataBroker) getCallerSession(ctx context.Context) (*session.Session, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	sessionID, ok := grpcutil.SessionIDFromGRPCRequest(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "a session is required")
+	}
+
+	s := new(session.Session)
+	if err := c.getRecord(ctx, sessionID, s); status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.Unauthenticated, "a session is required")
+	} else if err != nil {
+		return nil, err
+	}
+	if s.GetUserId() == "" {
+		return nil, status.Error(codes.Unauthenticated, "a session is required")
+	}
+	return s, nil
+}
+
+// requireGroupMember returns a PermissionDenied error unless the user of the session is a member
+// of one of the directory groups, by id, name or email.
+func (c *DataBroker) requireGroupMember(ctx context.Context, s *session.Session, groups []string) error {
+	errDenied := status.Error(codes.PermissionDenied, "the user is not a member of an allowed group")
+	if len(groups) == 0 {
+		return errDenied
+	}
+
+	u := new(directory.User)
+	if err := c.getRecord(ctx, s.GetUserId(), u); status.Code(err) == codes.NotFound {
+		return errDenied
+	} else if err != nil {
+		return err
+	}
+	for _, groupID := range u.GetGroupIds() {
+		names := []string{groupID}
+		g := new(directory.Group)
+		if err := c.getRecord(ctx, groupID, g); err == nil {
+			names = append(names, g.GetName(), g.GetEmail())
+		} else if status.Code(err) != codes.NotFound {
+			return err
+		}
+		for _, name := range names {
+			for _, group := range groups {
+				if name != "" && name == group {
+					return nil
+				}
+			}
+		}
+	}
+	return errDenied
+}
+
+// runImpersonationExpiry clears the expired impersonations until the context is canceled.
+func (c *DataBroker) runImpersonationExpiry(ctx context.Context) error {
+	ticker := time.NewTicker(impersonationExpiryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := c.expireImpersonations(ctx, time.Now()); err != nil {
+			log.Error(ctx).Err(err).Msg("databroker: error expiring impersonations")
+		}
+	}
+}
+
+// expireImpersonations deletes the impersonations which expired before now, and clears the
+// impersonated session of their sessions, so they go back to their own identity.
+func (c *DataBroker) expireImpersonations(ctx context.Context, now time.Time) error {
+	// the internal server is used since there's no signed JWT in the context
+	srv := c.dataBrokerServer.server
+
+	type expiredImpersonation struct {
+		impersonation *session.Impersonation
+		version       uint64
+	}
+	var expired []expiredImpersonation
+	for offset := int64(0); ; offset += impersonationExpiryPageSize {
+		res, err := srv.Query(ctx, &databroker.QueryRequest{
+			Type:   protoutil.GetTypeURL(new(session.Impersonation)),
+			Offset: offset,
+			Limit:  impersonationExpiryPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, record := range res.GetRecords() {
+			impersonation := new(session.Impersonation)
+			if err := record.GetData().UnmarshalTo(impersonation); err != nil {
+				continue
+			}
+			if impersonation.GetExpiresAt() != nil && !impersonation.GetExpiresAt().AsTime().After(now) {
+				expired = append(expired, expiredImpersonation{impersonation, record.GetVersion()})
+			}
+		}
+		if offset+int64(len(res.GetRecords())) >= res.GetTotalCount() || len(res.GetRecords()) == 0 {
+			break
+		}
+	}
+
+	for _, e := range expired {
+		impersonation := e.impersonation
+		deleted := newRecord(impersonation.GetId(), impersonation)
+		deleted.Version = e.version
+		deleted.DeletedAt = timestamppb.New(now)
+		records := []*databroker.Record{deleted}
+
+		sessionRes, err := srv.Get(ctx, &databroker.GetRequest{
+			Type: protoutil.GetTypeURL(new(session.Session)),
+			Id:   impersonation.GetId(),
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		s := new(session.Session)
+		if err == nil && sessionRes.GetRecord().GetData().UnmarshalTo(s) == nil &&
+			s.GetImpersonateSessionId() == impersonation.GetImpersonateSessionId() {
+			s.ImpersonateSessionId = nil
+			record := newRecord(s.GetId(), s)
+			record.Version = sessionRes.GetRecord().GetVersion()
+			records = append(records, record)
+		}
+
+		// the records are only changed if they weren't updated since they were read, otherwise
+		// the impersonation is expired on the next run
+		_, err = srv.Put(ctx, &databroker.PutRequest{Records: records, CompareVersions: true})
+		if status.Code(err) == codes.FailedPrecondition {
+			continue
+		} else if err != nil {
+			return err
+		}
+		log.Info(ctx).
+			Str("session-id", impersonation.GetId()).
+			Str("impersonate-session-id", impersonation.GetImpersonateSessionId()).
+			Msg("databroker: impersonation expired")
+	}
+	return nil
+}
+
+func (c *DataBroker) getRecord(ctx context.Context, id string, msg proto.Message) error {
+	res, err := c.dataBrokerServer.Get(ctx, &databroker.GetRequest{
+		Type: protoutil.GetTypeURL(msg),
+		Id:   id,
+	})
+	if err != nil {
+		return err
+	}
+	return res.GetRecord().GetData().UnmarshalTo(msg)
+}
+
+func newRecord(id string, msg proto.Message) *databroker.Record {
+	any := protoutil.NewAny(msg)
+	return &databroker.Record{
+		Type: any.GetTypeUrl(),
+		Id:   id,
+		Data: any,
+	}
+}
@@ -0,0 +1,133 @@
+package databroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func TestImpersonation(t *testing.T) {
+	ctx := context.Background()
+	srv := &dataBrokerServer{server: internal_databroker.New()}
+	srv.sharedKey.Store([]byte{})
+	c := &DataBroker{
+		dataBrokerServer:             srv,
+		impersonationRequesterGroups: []string{"admins"},
+		impersonationApproverGroups:  []string{"approvers@example.com"},
+	}
+
+	for _, id := range []string{"ADMIN", "USER", "APPROVER"} {
+		_, err := srv.Put(ctx, &databroker.PutRequest{
+			Records: []*databroker.Record{newRecord(id, &session.Session{Id: id, UserId: id})},
+		})
+		require.NoError(t, err)
+	}
+	// the admin is also an approver, but can't approve their own requests
+	_, err := srv.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{
+			newRecord("ADMIN", &directory.User{Id: "ADMIN", GroupIds: []string{"admins", "GROUP_APPROVERS"}}),
+			newRecord("APPROVER", &directory.User{Id: "APPROVER", GroupIds: []string{"GROUP_APPROVERS"}}),
+			newRecord("GROUP_APPROVERS", &directory.Group{Id: "GROUP_APPROVERS", Email: "approvers@example.com"}),
+		},
+	})
+	require.NoError(t, err)
+	asSession := func(sessionID string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(grpcutil.SessionIDMetadataKey, sessionID))
+	}
+
+	_, err = c.RequestImpersonation(ctx, &session.RequestImpersonationRequest{
+		ImpersonateSessionId: "USER",
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the session of the caller")
+
+	_, err = c.RequestImpersonation(asSession("MISSING"), &session.RequestImpersonationRequest{
+		ImpersonateSessionId: "USER",
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require an existing session for the caller")
+
+	_, err = c.RequestImpersonation(asSession("APPROVER"), &session.RequestImpersonationRequest{
+		ImpersonateSessionId: "USER",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should require a requester group")
+
+	_, err = c.ApproveImpersonation(asSession("APPROVER"), &session.ApproveImpersonationRequest{
+		SessionId: "ADMIN",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err), "should require a request")
+
+	_, err = c.RequestImpersonation(asSession("ADMIN"), &session.RequestImpersonationRequest{
+		ImpersonateSessionId: "USER",
+		Duration:             durationpb.New(48 * time.Hour),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should restrict the duration")
+
+	_, err = c.RequestImpersonation(asSession("ADMIN"), &session.RequestImpersonationRequest{
+		ImpersonateSessionId: "MISSING",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err), "should require an existing session")
+
+	res, err := c.RequestImpersonation(asSession("ADMIN"), &session.RequestImpersonationRequest{
+		ImpersonateSessionId: "USER",
+		Reason:               "support ticket",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ADMIN", res.GetImpersonation().GetId())
+	assert.Equal(t, "ADMIN", res.GetImpersonation().GetRequestedBy())
+	assert.False(t, res.GetImpersonation().IsActive(time.Now()))
+	assert.Equal(t, time.Hour, res.GetImpersonation().GetDuration().AsDuration())
+
+	s := new(session.Session)
+	require.NoError(t, c.getRecord(ctx, "ADMIN", s))
+	assert.Empty(t, s.GetImpersonateSessionId(), "should not impersonate before approval")
+
+	_, err = c.ApproveImpersonation(asSession("ADMIN"), &session.ApproveImpersonationRequest{
+		SessionId: "ADMIN",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should require a different approver")
+
+	_, err = c.ApproveImpersonation(asSession("USER"), &session.ApproveImpersonationRequest{
+		SessionId: "ADMIN",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "should require an approver group")
+
+	approved, err := c.ApproveImpersonation(asSession("APPROVER"), &session.ApproveImpersonationRequest{
+		SessionId: "ADMIN",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "APPROVER", approved.GetImpersonation().GetApprovedBy())
+	assert.True(t, approved.GetImpersonation().IsActive(time.Now()))
+	assert.False(t, approved.GetImpersonation().IsActive(time.Now().Add(2*time.Hour)))
+
+	require.NoError(t, c.getRecord(ctx, "ADMIN", s))
+	assert.Equal(t, "USER", s.GetImpersonateSessionId())
+
+	_, err = c.ApproveImpersonation(asSession("APPROVER"), &session.ApproveImpersonationRequest{
+		SessionId: "ADMIN",
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "should only be approved once")
+
+	t.Run("expire", func(t *testing.T) {
+		require.NoError(t, c.expireImpersonations(ctx, time.Now()))
+		require.NoError(t, c.getRecord(ctx, "ADMIN", s))
+		assert.Equal(t, "USER", s.GetImpersonateSessionId(), "should keep active impersonations")
+
+		require.NoError(t, c.expireImpersonations(ctx, time.Now().Add(2*time.Hour)))
+		require.NoError(t, c.getRecord(ctx, "ADMIN", s))
+		assert.Nil(t, s.ImpersonateSessionId, "should clear the impersonated session")
+		err := c.getRecord(ctx, "ADMIN", new(session.Impersonation))
+		assert.Equal(t, codes.NotFound, status.Code(err), "should delete the impersonation")
+	})
+}
@@ -0,0 +1,106 @@
+package databroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func TestServiceAccounts(t *testing.T) {
+	sharedKey := cryptutil.NewKey()
+	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: sharedKey}, nil)
+	require.NoError(t, err)
+	rawJWT, err := jwt.Signed(sig).Claims(jwt.Claims{
+		Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutil.JWTMetadataKey, rawJWT))
+
+	srv := &dataBrokerServer{server: internal_databroker.New()}
+	srv.sharedKey.Store(sharedKey)
+	c := &DataBroker{dataBrokerServer: srv}
+	decoder, err := jws.NewHS256Signer(sharedKey)
+	require.NoError(t, err)
+
+	_, err = c.CreateServiceAccount(ctx, &user.CreateServiceAccountRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should require a user id")
+
+	_, err = c.CreateServiceAccount(ctx, &user.CreateServiceAccountRequest{
+		UserId:    "USER",
+		ExpiresIn: durationpb.New(-time.Hour),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "should require a positive expiry")
+
+	created, err := c.CreateServiceAccount(ctx, &user.CreateServiceAccountRequest{
+		UserId:      "USER",
+		Description: "ci",
+		Audiences:   []string{"app.example.com"},
+		ExpiresIn:   durationpb.New(time.Hour),
+	})
+	require.NoError(t, err)
+	sa := created.GetServiceAccount()
+	assert.Equal(t, "ci", sa.GetDescription())
+	assert.False(t, sa.IsExpired(time.Now()))
+	assert.True(t, sa.IsExpired(time.Now().Add(2*time.Hour)))
+
+	var state sessions.State
+	require.NoError(t, decoder.Unmarshal([]byte(created.GetJwt()), &state))
+	assert.Equal(t, sa.GetId(), state.ID)
+	assert.Equal(t, "USER", state.Subject)
+
+	_, err = c.CreateServiceAccount(ctx, &user.CreateServiceAccountRequest{UserId: "OTHER"})
+	require.NoError(t, err)
+
+	listed, err := c.ListServiceAccounts(ctx, &user.ListServiceAccountsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, listed.GetServiceAccounts(), 2)
+	listed, err = c.ListServiceAccounts(ctx, &user.ListServiceAccountsRequest{UserId: "USER"})
+	require.NoError(t, err)
+	if assert.Len(t, listed.GetServiceAccounts(), 1) {
+		assert.Equal(t, sa.GetId(), listed.GetServiceAccounts()[0].GetId())
+	}
+
+	rotated, err := c.RotateServiceAccount(ctx, &user.RotateServiceAccountRequest{Id: sa.GetId()})
+	require.NoError(t, err)
+	assert.NotEqual(t, sa.GetId(), rotated.GetServiceAccount().GetId())
+	assert.Equal(t, sa.GetAudiences(), rotated.GetServiceAccount().GetAudiences())
+	assert.Equal(t, sa.GetExpiresAt().AsTime(), rotated.GetServiceAccount().GetExpiresAt().AsTime())
+	assert.NotEqual(t, created.GetJwt(), rotated.GetJwt())
+	assert.Equal(t, codes.NotFound, status.Code(c.getRecord(ctx, sa.GetId(), new(user.ServiceAccount))),
+		"should delete the old service account")
+
+	_, err = c.RevokeServiceAccount(ctx, &user.RevokeServiceAccountRequest{Id: rotated.GetServiceAccount().GetId()})
+	require.NoError(t, err)
+	listed, err = c.ListServiceAccounts(ctx, &user.ListServiceAccountsRequest{UserId: "USER"})
+	require.NoError(t, err)
+	assert.Empty(t, listed.GetServiceAccounts())
+
+	_, err = c.RevokeServiceAccount(ctx, &user.RevokeServiceAccountRequest{Id: rotated.GetServiceAccount().GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	unsigned, err := c.CreateServiceAccount(context.Background(), &user.CreateServiceAccountRequest{UserId: "USER"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	assert.Empty(t, unsigned.GetJwt(), "should not sign a JWT without the shared secret")
+	_, err = c.ListServiceAccounts(context.Background(), &user.ListServiceAccountsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	_, err = c.RotateServiceAccount(context.Background(), &user.RotateServiceAccountRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	_, err = c.RevokeServiceAccount(context.Background(), &user.RevokeServiceAccountRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+}
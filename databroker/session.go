@@ -0,0 +1,189 @@
+package databroker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// like service accounts, sessions are listed up to a limit
+const listSessionsLimit = 100000
+
+// ListSessions lists the sessions which haven't expired, optionally only those of a user, or of
+// the users with an email.
+func (c *DataBroker) ListSessions(
+	ctx context.Context,
+	req *session.ListSessionsRequest,
+) (*session.ListSessionsResponse, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	sessions, err := c.listSessions(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := new(session.ListSessionsResponse)
+	emails := map[string]string{}
+	for _, s := range sessions {
+		if s.GetExpiresAt().AsTime().Before(now) {
+			continue
+		}
+		email, err := c.getSessionEmail(ctx, s, emails)
+		if err != nil {
+			return nil, err
+		}
+		if req.GetEmail() != "" && !strings.EqualFold(email, req.GetEmail()) {
+			continue
+		}
+		res.Sessions = append(res.Sessions, &session.SessionSummary{
+			Id:                 s.GetId(),
+			UserId:             s.GetUserId(),
+			Email:              email,
+			IdentityProviderId: s.GetIdentityProviderId(),
+			IpAddress:          s.GetIpAddress(),
+			UserAgent:          s.GetUserAgent(),
+			IssuedAt:           s.GetIssuedAt(),
+			AccessedAt:         s.GetAccessedAt(),
+			ExpiresAt:          s.GetExpiresAt(),
+		})
+	}
+	return res, nil
+}
+
+// RevokeSession deletes a session. Authorize stops accepting the session once the deletion is
+// synced, and the user has to sign in again.
+func (c *DataBroker) RevokeSession(
+	ctx context.Context,
+	req *session.RevokeSessionRequest,
+) (*emptypb.Empty, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	s := new(session.Session)
+	if err := c.getRecord(ctx, req.GetId(), s); err != nil {
+		return nil, err
+	}
+	if err := c.deleteSessions(ctx, []*session.Session{s}); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+// RevokeUserSessions deletes all the sessions of a user.
+func (c *DataBroker) RevokeUserSessions(
+	ctx context.Context,
+	req *session.RevokeUserSessionsRequest,
+) (*session.RevokeUserSessionsResponse, error) {
+	if err := c.dataBrokerServer.requireSignedJWT(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	sessions, err := c.listSessions(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := c.deleteSessions(ctx, sessions); err != nil {
+		return nil, err
+	}
+
+	res := new(session.RevokeUserSessionsResponse)
+	for _, s := range sessions {
+		res.SessionIds = append(res.SessionIds, s.GetId())
+	}
+	return res, nil
+}
+
+func (c *DataBroker) listSessions(ctx context.Context, userID string) ([]*session.Session, error) {
+	res, err := c.dataBrokerServer.Query(ctx, &databroker.QueryRequest{
+		Type:  protoutil.GetTypeURL(new(session.Session)),
+		Limit: listSessionsLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*session.Session
+	for _, record := range res.GetRecords() {
+		s := new(session.Session)
+		if err := record.GetData().UnmarshalTo(s); err != nil {
+			return nil, err
+		}
+		if userID != "" && s.GetUserId() != userID {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+// getSessionEmail returns the email of the session from its claims, or from its user. The emails
+// of the users are cached in emails.
+func (c *DataBroker) getSessionEmail(ctx context.Context, s *session.Session, emails map[string]string) (string, error) {
+	if values := s.GetClaims()["email"].GetValues(); len(values) > 0 && values[0].GetStringValue() != "" {
+		return values[0].GetStringValue(), nil
+	}
+
+	email, ok := emails[s.GetUserId()]
+	if ok {
+		return email, nil
+	}
+	u := new(user.User)
+	err := c.getRecord(ctx, s.GetUserId(), u)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return "", err
+	}
+	emails[s.GetUserId()] = u.GetEmail()
+	return u.GetEmail(), nil
+}
+
+func (c *DataBroker) deleteSessions(ctx context.Context, sessions []*session.Session) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	records := make([]*databroker.Record, 0, len(sessions))
+	for _, s := range sessions {
+		record := newRecord(s.GetId(), new(session.Session))
+		record.DeletedAt = timestamppb.Now()
+		records = append(records, record)
+	}
+	_, err := c.dataBrokerServer.Put(ctx, &databroker.PutRequest{Records: records})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		log.Info(ctx).
+			Str("session-id", s.GetId()).
+			Str("user-id", s.GetUserId()).
+			Msg("databroker: session revoked")
+		audit.Publish(ctx, &audit.Event{
+			Type:      audit.EventTypeSessionRevoked,
+			Message:   "session revoked",
+			UserId:    s.GetUserId(),
+			SessionId: s.GetId(),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package databroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func TestSessions(t *testing.T) {
+	sharedKey := cryptutil.NewKey()
+	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: sharedKey}, nil)
+	require.NoError(t, err)
+	rawJWT, err := jwt.Signed(sig).Claims(jwt.Claims{
+		Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutil.JWTMetadataKey, rawJWT))
+
+	srv := &dataBrokerServer{server: internal_databroker.New()}
+	srv.sharedKey.Store(sharedKey)
+	c := &DataBroker{dataBrokerServer: srv}
+
+	now := time.Now()
+	_, err = c.dataBrokerServer.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{
+			newRecord("s1", &session.Session{
+				Id:         "s1",
+				UserId:     "u1",
+				IpAddress:  "10.0.0.1",
+				UserAgent:  "curl/7.79.1",
+				IssuedAt:   timestamppb.New(now.Add(-time.Hour)),
+				AccessedAt: timestamppb.New(now),
+				ExpiresAt:  timestamppb.New(now.Add(time.Hour)),
+				Claims: map[string]*structpb.ListValue{
+					"email": {Values: []*structpb.Value{structpb.NewStringValue("u1@example.com")}},
+				},
+			}),
+			newRecord("s2", &session.Session{
+				Id:        "s2",
+				UserId:    "u1",
+				ExpiresAt: timestamppb.New(now.Add(time.Hour)),
+			}),
+			newRecord("s3", &session.Session{
+				Id:        "s3",
+				UserId:    "u2",
+				ExpiresAt: timestamppb.New(now.Add(time.Hour)),
+			}),
+			newRecord("s4", &session.Session{
+				Id:        "s4",
+				UserId:    "u2",
+				ExpiresAt: timestamppb.New(now.Add(-time.Minute)),
+			}),
+			newRecord("u2", &user.User{
+				Id:    "u2",
+				Email: "u2@example.com",
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	listIDs := func(req *session.ListSessionsRequest) []string {
+		res, err := c.ListSessions(ctx, req)
+		require.NoError(t, err)
+		var ids []string
+		for _, s := range res.GetSessions() {
+			ids = append(ids, s.GetId())
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"s1", "s2", "s3"}, listIDs(&session.ListSessionsRequest{}),
+		"should skip expired sessions")
+	assert.Equal(t, []string{"s1", "s2"}, listIDs(&session.ListSessionsRequest{UserId: "u1"}))
+	assert.Equal(t, []string{"s3"}, listIDs(&session.ListSessionsRequest{Email: "U2@example.com"}),
+		"should match the email of the user")
+	assert.Equal(t, []string{"s1"}, listIDs(&session.ListSessionsRequest{Email: "u1@example.com"}),
+		"should match the email claim")
+
+	res, err := c.ListSessions(ctx, &session.ListSessionsRequest{UserId: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", res.GetSessions()[0].GetIpAddress())
+	assert.Equal(t, "curl/7.79.1", res.GetSessions()[0].GetUserAgent())
+
+	_, err = c.RevokeSession(ctx, &session.RevokeSessionRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = c.RevokeSession(ctx, &session.RevokeSessionRequest{Id: "s3"})
+	require.NoError(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(c.getRecord(ctx, "s3", new(session.Session))))
+	_, err = c.RevokeSession(ctx, &session.RevokeSessionRequest{Id: "s3"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	revoked, err := c.RevokeUserSessions(ctx, &session.RevokeUserSessionsRequest{UserId: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s1", "s2"}, revoked.GetSessionIds())
+	assert.Empty(t, listIDs(&session.ListSessionsRequest{UserId: "u1"}))
+
+	_, err = c.ListSessions(context.Background(), &session.ListSessionsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	_, err = c.RevokeSession(context.Background(), &session.RevokeSessionRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+	_, err = c.RevokeUserSessions(context.Background(), &session.RevokeUserSessionsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "should require the shared secret")
+}
@@ -152,9 +152,11 @@ module.exports = {
             "identity-providers/github",
             "identity-providers/gitlab",
             "identity-providers/google",
+            "identity-providers/ldap",
             "identity-providers/okta",
             "identity-providers/one-login",
             "identity-providers/ping",
+            "identity-providers/saml",
           ],
         },
         {
@@ -214,10 +216,12 @@ module.exports = {
             "topics/data-storage",
             "topics/device-identity",
             "topics/getting-users-identity",
+            "topics/impersonation",
             "topics/original-request-context",
             "topics/mutual-auth",
             "topics/ppl",
             "topics/programmatic-access",
+            "topics/scim",
             "topics/single-sign-out",
             "topics/load-balancing",
           ],
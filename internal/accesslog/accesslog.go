@@ -0,0 +1,225 @@
+// Package accesslog writes the access logs of the proxy to sinks, like rotated files or syslog
+// servers, each with its own format.
+package accesslog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// An Entry is an access log entry of a request. The durations are measured from the start of the
+// request.
+type Entry struct {
+	Time                      time.Time
+	UpstreamCluster           string
+	Method                    string
+	Protocol                  string
+	Authority                 string
+	Path                      string
+	UserAgent                 string
+	Referer                   string
+	ForwardedFor              string
+	RemoteAddress             string
+	RequestID                 string
+	Duration                  time.Duration
+	RequestDuration           time.Duration
+	UpstreamRequestDuration   time.Duration
+	UpstreamFirstByteDuration time.Duration
+	UpstreamDuration          time.Duration
+	FirstByteDuration         time.Duration
+	Size                      uint64
+	ResponseCode              uint32
+	ResponseCodeDetails       string
+	// RequestHeaders and ResponseHeaders are the headers of the access log fields, by their
+	// lowercase names.
+	RequestHeaders  map[string]string
+	ResponseHeaders map[string]string
+	// Authorize are the access log fields set by the authorize service.
+	Authorize map[string]interface{}
+}
+
+// Get returns the value of the access log field. Headers and fields set by the authorize
+// service are only returned if they're set.
+func (entry *Entry) Get(field string) (interface{}, bool) {
+	switch field {
+	case "upstream-cluster":
+		return entry.UpstreamCluster, true
+	case "method":
+		return entry.Method, true
+	case "protocol":
+		return entry.Protocol, true
+	case "authority":
+		return entry.Authority, true
+	case "path":
+		return entry.Path, true
+	case "user-agent":
+		return entry.UserAgent, true
+	case "referer":
+		return entry.Referer, true
+	case "forwarded-for":
+		return entry.ForwardedFor, true
+	case "remote-address":
+		return entry.RemoteAddress, true
+	case "request-id":
+		return entry.RequestID, true
+	case "duration":
+		return entry.Duration, true
+	case "request-duration":
+		return entry.RequestDuration, true
+	case "upstream-request-duration":
+		return entry.UpstreamRequestDuration, true
+	case "upstream-first-byte-duration":
+		return entry.UpstreamFirstByteDuration, true
+	case "upstream-duration":
+		return entry.UpstreamDuration, true
+	case "first-byte-duration":
+		return entry.FirstByteDuration, true
+	case "size":
+		return entry.Size, true
+	case "response-code":
+		return entry.ResponseCode, true
+	case "response-code-details":
+		return entry.ResponseCodeDetails, true
+	}
+
+	var v interface{}
+	var ok bool
+	if name := strings.TrimPrefix(field, config.AccessLogFieldRequestHeaderPrefix); name != field {
+		v, ok = entry.RequestHeaders[name]
+	} else if name := strings.TrimPrefix(field, config.AccessLogFieldResponseHeaderPrefix); name != field {
+		v, ok = entry.ResponseHeaders[name]
+	} else {
+		v, ok = entry.Authorize[field]
+	}
+	return v, ok
+}
+
+// A Manager writes access log entries to the sinks of the current config.
+type Manager struct {
+	mu      sync.Mutex
+	fields  []string
+	configs []config.AccessLogSink
+	sinks   []*sink
+}
+
+// New creates a new Manager.
+func New() *Manager {
+	return &Manager{}
+}
+
+// Update updates the sinks from the config. When the sinks are unchanged they're kept open.
+func (mgr *Manager) Update(ctx context.Context, cfg *config.Config) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	mgr.fields = cfg.Options.GetAccessLogFields()
+	if reflect.DeepEqual(mgr.configs, cfg.Options.AccessLogSinks) {
+		return
+	}
+
+	mgr.closeLocked(ctx)
+	mgr.configs = cfg.Options.AccessLogSinks
+	for i, sc := range cfg.Options.AccessLogSinks {
+		s, err := newSink(sc)
+		if err != nil {
+			log.Error(ctx).Err(err).Int("sink", i).Msg("accesslog: error opening sink")
+			continue
+		}
+		mgr.sinks = append(mgr.sinks, s)
+	}
+}
+
+// Enabled returns true if any sinks are configured. When they're not access logs are written
+// to the pomerium log.
+func (mgr *Manager) Enabled() bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	return len(mgr.configs) > 0
+}
+
+// Log writes the entry to every sink.
+func (mgr *Manager) Log(ctx context.Context, entry *Entry) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	for _, s := range mgr.sinks {
+		if _, err := s.w.Write(s.format(entry, mgr.fields)); err != nil {
+			log.Error(ctx).Err(err).Str("type", s.typ).Msg("accesslog: error writing entry")
+		}
+	}
+}
+
+// Close closes the sinks.
+func (mgr *Manager) Close() error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	mgr.closeLocked(context.Background())
+	mgr.configs = nil
+	return nil
+}
+
+func (mgr *Manager) closeLocked(ctx context.Context) {
+	for _, s := range mgr.sinks {
+		if err := s.w.Close(); err != nil {
+			log.Error(ctx).Err(err).Str("type", s.typ).Msg("accesslog: error closing sink")
+		}
+	}
+	mgr.sinks = nil
+}
+
+type sink struct {
+	typ    string
+	format func(*Entry, []string) []byte
+	w      io.WriteCloser
+}
+
+func newSink(cfg config.AccessLogSink) (*sink, error) {
+	s := &sink{typ: cfg.Type}
+	switch cfg.GetFormat() {
+	case config.AccessLogFormatJSON:
+		s.format = formatJSON
+	case config.AccessLogFormatCombined:
+		s.format = formatCombined
+	case config.AccessLogFormatCEF:
+		s.format = formatCEF
+	case config.AccessLogFormatLEEF:
+		s.format = formatLEEF
+	case config.AccessLogFormatECS:
+		s.format = formatECS
+	default:
+		return nil, fmt.Errorf("unknown format: %s", cfg.Format)
+	}
+
+	var err error
+	switch cfg.Type {
+	case config.AccessLogSinkTypeStdout:
+		s.w = nopCloser{os.Stdout}
+	case config.AccessLogSinkTypeFile:
+		s.w, err = newFileWriter(cfg.Path, cfg.MaxSize*1024*1024, cfg.MaxAge, cfg.MaxBackups)
+	case config.AccessLogSinkTypeSyslog:
+		s.w, err = newSyslogWriter(cfg.GetNetwork(), cfg.Address, cfg.GetTag())
+	default:
+		err = fmt.Errorf("unknown type: %s", cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
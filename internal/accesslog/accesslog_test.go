@@ -0,0 +1,132 @@
+package accesslog
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+var testEntry = &Entry{
+	Time:          time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+	Method:        "GET",
+	Protocol:      "HTTP/2",
+	Authority:     "www.example.com",
+	Path:          "/index.html",
+	UserAgent:     "curl/7.79.1",
+	RemoteAddress: "192.0.2.1",
+	RequestID:     "REQUEST-ID",
+	Duration:      1500 * time.Microsecond,
+	Size:          1234,
+	ResponseCode:  200,
+
+	UpstreamFirstByteDuration: time.Millisecond,
+	RequestHeaders:            map[string]string{"x-tenant": "TENANT"},
+	Authorize:                 map[string]interface{}{"allow": true, "claim.groups": []interface{}{"a", "b"}},
+}
+
+func TestFormat(t *testing.T) {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(formatJSON(testEntry, config.DefaultAccessLogFields), &m))
+	assert.Equal(t, "2022-03-04T05:06:07Z", m["time"])
+	assert.Equal(t, "www.example.com", m["authority"])
+	assert.Equal(t, 1.5, m["duration"])
+	assert.Equal(t, "http-request", m["message"])
+	assert.NotContains(t, m, "allow")
+
+	assert.Equal(t, `{"time":"2022-03-04T05:06:07Z","service":"envoy","path":"/index.html",`+
+		`"upstream-first-byte-duration":1,"request.header.x-tenant":"TENANT","allow":true,"claim.groups":["a","b"],`+
+		`"message":"http-request"}`+"\n",
+		string(formatJSON(testEntry, []string{"path", "upstream-first-byte-duration", "request.header.x-tenant",
+			"request.header.x-missing", "allow", "claim.groups"})))
+
+	assert.Equal(t, `192.0.2.1 - - [04/Mar/2022:05:06:07 +0000] "GET /index.html HTTP/2" 200 1234 "-" "curl/7.79.1"`+"\n",
+		string(formatCombined(testEntry, nil)))
+
+	cef := string(formatCEF(testEntry, []string{"request.header.x-tenant", "claim.groups"}))
+	assert.True(t, strings.HasPrefix(cef, "CEF:0|Pomerium|Pomerium|"), cef)
+	assert.True(t, strings.HasSuffix(cef, "\n"))
+	assert.Contains(t, cef, "|http-request|http-request|1|rt=1646370367000 src=192.0.2.1 outcome=success ")
+	assert.Contains(t, cef, " cn1=200 cn1Label=responseCode cn2=1 cn2Label=durationMs ")
+	assert.Contains(t, cef, " claim_groups=a,b request_header_x_tenant=TENANT\n")
+
+	leef := string(formatLEEF(testEntry, nil))
+	assert.True(t, strings.HasPrefix(leef, "LEEF:1.0|Pomerium|Pomerium|"), leef)
+	assert.Contains(t, leef, "\tsrc=192.0.2.1\t")
+
+	m = nil
+	require.NoError(t, json.Unmarshal(formatECS(testEntry, nil), &m))
+	assert.Equal(t, "2022-03-04T05:06:07Z", m["@timestamp"])
+	assert.Equal(t, map[string]interface{}{
+		"request":  map[string]interface{}{"id": "REQUEST-ID", "method": "GET"},
+		"response": map[string]interface{}{"status_code": 200.0, "bytes": 1234.0},
+		"version":  "2",
+	}, m["http"])
+	assert.Equal(t, "www.example.com", m["url"].(map[string]interface{})["domain"])
+}
+
+func TestFileWriter(t *testing.T) {
+	dir := t.TempDir()
+	fn := filepath.Join(dir, "access.log")
+	old := filepath.Join(dir, "access-2000-01-01T00-00-00.000.log")
+	require.NoError(t, os.WriteFile(old, []byte("old\n"), 0o600))
+
+	w, err := newFileWriter(fn, 10, 24*time.Hour, 2)
+	require.NoError(t, err)
+	defer w.Close()
+	assert.NoFileExists(t, old, "backups older than max age should be removed")
+
+	for i := 0; i < 4; i++ {
+		_, err := w.Write([]byte("12345678\n"))
+		require.NoError(t, err)
+		// the time of the backup is part of its name
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	bs, err := os.ReadFile(fn)
+	require.NoError(t, err)
+	assert.Equal(t, "12345678\n", string(bs))
+	assert.Len(t, w.backups(), 2, "only max backups should be kept")
+}
+
+func TestManager(t *testing.T) {
+	li, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer li.Close()
+
+	fn := filepath.Join(t.TempDir(), "access.log")
+	options := config.NewDefaultOptions()
+	options.AccessLogSinks = []config.AccessLogSink{
+		{Type: config.AccessLogSinkTypeFile, Path: fn, Format: config.AccessLogFormatCombined},
+		{Type: config.AccessLogSinkTypeSyslog, Address: li.LocalAddr().String()},
+	}
+
+	mgr := New()
+	defer mgr.Close()
+	assert.False(t, mgr.Enabled())
+	mgr.Update(context.Background(), &config.Config{Options: options})
+	assert.True(t, mgr.Enabled())
+	mgr.Log(context.Background(), testEntry)
+
+	bs, err := os.ReadFile(fn)
+	require.NoError(t, err)
+	assert.Equal(t, string(formatCombined(testEntry, nil)), string(bs))
+
+	buf := make([]byte, 4096)
+	require.NoError(t, li.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := li.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+	assert.True(t, strings.HasPrefix(msg, "<134>1 "), msg)
+	assert.Contains(t, msg, " pomerium ")
+	assert.True(t, strings.HasSuffix(msg, strings.TrimSpace(string(formatJSON(testEntry, config.DefaultAccessLogFields)))), msg)
+}
@@ -0,0 +1,155 @@
+package accesslog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the format of the time added to the names of rotated files. It sorts in
+// the order the files were rotated.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// A fileWriter appends to a file, which is rotated when it reaches its max size. Rotated files
+// are removed when there are more than max backups, or they're older than max age.
+type fileWriter struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func newFileWriter(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*fileWriter, error) {
+	w := &fileWriter{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("accesslog: error creating directory: %w", err)
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	w.removeBackups()
+	return w, nil
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return 0, os.ErrClosed
+	}
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *fileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
+
+func (w *fileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("accesslog: error opening file: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("accesslog: error opening file: %w", err)
+	}
+	w.f, w.size = f, fi.Size()
+	return nil
+}
+
+func (w *fileWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return fmt.Errorf("accesslog: error rotating file: %w", err)
+	}
+	w.f = nil
+	if err := os.Rename(w.path, w.backupName(time.Now())); err != nil {
+		return fmt.Errorf("accesslog: error rotating file: %w", err)
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	w.removeBackups()
+	return nil
+}
+
+// backupName returns the name of the file rotated at the given time, like
+// access-2006-01-02T15-04-05.000.log for access.log.
+func (w *fileWriter) backupName(t time.Time) string {
+	ext := filepath.Ext(w.path)
+	return strings.TrimSuffix(w.path, ext) + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// backups returns the names of the rotated files, newest first.
+func (w *fileWriter) backups() []string {
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(w.path))
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		ts := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, ts); err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return names
+}
+
+func (w *fileWriter) removeBackups() {
+	if w.maxAge <= 0 && w.maxBackups <= 0 {
+		return
+	}
+
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), ext) + "-"
+	cutoff := time.Now().Add(-w.maxAge)
+	for i, name := range w.backups() {
+		remove := w.maxBackups > 0 && i >= w.maxBackups
+		if w.maxAge > 0 {
+			ts, _ := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+			remove = remove || ts.Before(cutoff)
+		}
+		if remove {
+			_ = os.Remove(filepath.Join(filepath.Dir(w.path), name))
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/siem"
+)
+
+// formatJSON formats the entry as a JSON object with the fields, in order. Like the access logs
+// written to the pomerium log, durations are in milliseconds.
+func formatJSON(entry *Entry, fields []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"time":`)
+	writeJSON(&buf, entry.Time.UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"service":"envoy"`)
+	for _, field := range fields {
+		v, ok := entry.Get(field)
+		if !ok {
+			continue
+		}
+		if d, ok := v.(time.Duration); ok {
+			v = float64(d) / float64(time.Millisecond)
+		}
+		buf.WriteByte(',')
+		writeJSON(&buf, field)
+		buf.WriteByte(':')
+		writeJSON(&buf, v)
+	}
+	buf.WriteString(`,"message":"http-request"}`)
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+func writeJSON(buf *bytes.Buffer, v interface{}) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		bs = []byte("null")
+	}
+	buf.Write(bs)
+}
+
+// formatCombined formats the entry in the NCSA combined log format, which is understood by most
+// log analysis tools, so the access log fields aren't used. The authenticated user isn't known,
+// so it's always "-".
+//
+// https://httpd.apache.org/docs/current/logs.html#combined
+func formatCombined(entry *Entry, _ []string) []byte {
+	var sb strings.Builder
+	sb.WriteString(orDash(entry.RemoteAddress))
+	sb.WriteString(" - - [")
+	sb.WriteString(entry.Time.Format("02/Jan/2006:15:04:05 -0700"))
+	sb.WriteString("] ")
+	protocol := entry.Protocol
+	if protocol == "" {
+		protocol = "HTTP/1.1"
+	}
+	sb.WriteString(strconv.Quote(fmt.Sprintf("%s %s %s", entry.Method, entry.Path, protocol)))
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatUint(uint64(entry.ResponseCode), 10))
+	sb.WriteByte(' ')
+	if entry.Size == 0 {
+		sb.WriteByte('-')
+	} else {
+		sb.WriteString(strconv.FormatUint(entry.Size, 10))
+	}
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.Quote(orDash(entry.Referer)))
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.Quote(orDash(entry.UserAgent)))
+	sb.WriteByte('\n')
+	return []byte(sb.String())
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// formatCEF formats the entry in the Common Event Format.
+func formatCEF(entry *Entry, fields []string) []byte {
+	return append(siem.CEF(newSIEMEvent(entry, fields)), '\n')
+}
+
+// formatLEEF formats the entry in the Log Event Extended Format.
+func formatLEEF(entry *Entry, fields []string) []byte {
+	return append(siem.LEEF(newSIEMEvent(entry, fields)), '\n')
+}
+
+// formatECS formats the entry as an Elastic Common Schema JSON document.
+func formatECS(entry *Entry, fields []string) []byte {
+	return append(siem.ECS(newSIEMEvent(entry, fields)), '\n')
+}
+
+// newSIEMEvent converts the entry to an event of the SIEM formats. They have their own fields,
+// so only the headers and claims of the access log fields are added, as labels.
+func newSIEMEvent(entry *Entry, fields []string) *siem.Event {
+	evt := &siem.Event{
+		Time:       entry.Time,
+		ID:         "http-request",
+		Message:    "http-request",
+		Severity:   1,
+		Dataset:    "pomerium.access",
+		Categories: []string{"web"},
+		Types:      []string{"access"},
+		Outcome:    siem.OutcomeSuccess,
+		Service:    "envoy",
+		SourceIP:   entry.RemoteAddress,
+		RequestID:  entry.RequestID,
+		Method:     entry.Method,
+		URL:        entry.Path,
+		Host:       entry.Authority,
+		Path:       entry.Path,
+		Protocol:   entry.Protocol,
+		Status:     int(entry.ResponseCode),
+		Bytes:      entry.Size,
+		Duration:   entry.Duration,
+		UserAgent:  entry.UserAgent,
+		Referrer:   entry.Referer,
+	}
+	if i := strings.IndexAny(evt.Path, "?#"); i >= 0 {
+		evt.Path = evt.Path[:i]
+	}
+	evt.UserID, _ = entry.Authorize["user"].(string)
+	evt.Email, _ = entry.Authorize["email"].(string)
+	evt.SessionID, _ = entry.Authorize["session-id"].(string)
+
+	switch {
+	case entry.ResponseCode >= 500:
+		evt.Severity, evt.Outcome = 6, siem.OutcomeFailure
+	case entry.ResponseCode >= 400:
+		evt.Severity, evt.Outcome = 3, siem.OutcomeFailure
+	}
+	if allow, ok := entry.Authorize["allow"].(bool); ok && !allow {
+		evt.Severity, evt.Types = 5, []string{"access", "denied"}
+	}
+	if deny, ok := entry.Authorize["deny"].(bool); ok && deny {
+		evt.Severity, evt.Types = 5, []string{"access", "denied"}
+	}
+
+	for _, field := range fields {
+		if !strings.HasPrefix(field, config.AccessLogFieldRequestHeaderPrefix) &&
+			!strings.HasPrefix(field, config.AccessLogFieldResponseHeaderPrefix) &&
+			!strings.HasPrefix(field, config.AccessLogFieldClaimPrefix) {
+			continue
+		}
+		v, ok := entry.Get(field)
+		if !ok {
+			continue
+		}
+		if evt.Labels == nil {
+			evt.Labels = map[string]string{}
+		}
+		evt.Labels[field] = labelValue(v)
+	}
+	return evt
+}
+
+func labelValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []interface{}:
+		strs := make([]string, 0, len(v))
+		for _, vv := range v {
+			strs = append(strs, labelValue(vv))
+		}
+		return strings.Join(strs, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
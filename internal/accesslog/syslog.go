@@ -0,0 +1,102 @@
+package accesslog
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// syslogPriority is the priority of the messages, facility local0 and severity informational.
+const syslogPriority = 16*8 + 6
+
+const syslogDialTimeout = 5 * time.Second
+
+// A syslogWriter sends each write as an RFC 5424 message to a syslog server. Over TCP messages
+// are framed with octet counting (RFC 6587).
+type syslogWriter struct {
+	network  string
+	address  string
+	tag      string
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogWriter(network, address, tag string) (*syslogWriter, error) {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	w := &syslogWriter{
+		network:  network,
+		address:  address,
+		tag:      tag,
+		hostname: hostname,
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *syslogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	msg := w.format(time.Now(), p)
+	// the server may have closed the connection, so try again with a new one
+	for i := 0; i < 2; i++ {
+		if w.conn == nil {
+			if err := w.connect(); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := w.conn.Write(msg); err != nil {
+			_ = w.conn.Close()
+			w.conn = nil
+			if i == 1 {
+				return 0, fmt.Errorf("accesslog: error writing to syslog: %w", err)
+			}
+			continue
+		}
+		break
+	}
+	return len(p), nil
+}
+
+func (w *syslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+func (w *syslogWriter) connect() error {
+	conn, err := net.DialTimeout(w.network, w.address, syslogDialTimeout)
+	if err != nil {
+		return fmt.Errorf("accesslog: error connecting to syslog: %w", err)
+	}
+	w.conn = conn
+	return nil
+}
+
+func (w *syslogWriter) format(t time.Time, p []byte) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "<%d>1 %s %s %s %d - - ",
+		syslogPriority, t.UTC().Format(time.RFC3339Nano), w.hostname, w.tag, os.Getpid())
+	msg.Write(bytes.TrimRight(p, "\n"))
+	if w.network != "tcp" {
+		return msg.Bytes()
+	}
+	return append([]byte(strconv.Itoa(msg.Len())+" "), msg.Bytes()...)
+}
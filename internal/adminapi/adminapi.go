@@ -0,0 +1,219 @@
+// Package adminapi implements a REST API to manage the routes, policies, service accounts and
+// settings stored in the databroker, so they can be managed declaratively by tools like
+// Terraform.
+//
+// Resources are the JSON encoding of their protobuf messages. Every resource has an ID, and an
+// ETag which changes whenever the resource changes. Updates and deletes can be made conditional
+// with the If-Match header.
+package adminapi
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// BasePath is the path the admin API is served under.
+const BasePath = "/admin/v1"
+
+const (
+	maxRequestSize  = 1 << 20
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// A Handler serves the admin API.
+type Handler struct {
+	client      databroker.DataBrokerServiceClient
+	bearerToken string
+	encoder     encoding.Marshaler
+	router      *mux.Router
+}
+
+// New creates a new admin API Handler. Requests must be authenticated using the bearer token.
+// Service account tokens are signed with the encoder.
+func New(client databroker.DataBrokerServiceClient, bearerToken string, encoder encoding.Marshaler) *Handler {
+	h := &Handler{
+		client:      client,
+		bearerToken: bearerToken,
+		encoder:     encoder,
+		router:      mux.NewRouter(),
+	}
+
+	sr := h.router.PathPrefix(BasePath).Subrouter()
+	sr.Path("/routes").HandlerFunc(h.listRoutes).Methods(http.MethodGet)
+	sr.Path("/routes").HandlerFunc(h.createRoute).Methods(http.MethodPost)
+	sr.Path("/routes/{id}").HandlerFunc(h.getRoute).Methods(http.MethodGet)
+	sr.Path("/routes/{id}").HandlerFunc(h.replaceRoute).Methods(http.MethodPut)
+	sr.Path("/routes/{id}").HandlerFunc(h.deleteRoute).Methods(http.MethodDelete)
+	sr.Path("/policies").HandlerFunc(h.listPolicies).Methods(http.MethodGet)
+	sr.Path("/policies").HandlerFunc(h.createPolicy).Methods(http.MethodPost)
+	sr.Path("/policies/{id}").HandlerFunc(h.getPolicy).Methods(http.MethodGet)
+	sr.Path("/policies/{id}").HandlerFunc(h.replacePolicy).Methods(http.MethodPut)
+	sr.Path("/policies/{id}").HandlerFunc(h.deletePolicy).Methods(http.MethodDelete)
+	sr.Path("/service_accounts").HandlerFunc(h.listServiceAccounts).Methods(http.MethodGet)
+	sr.Path("/service_accounts").HandlerFunc(h.createServiceAccount).Methods(http.MethodPost)
+	sr.Path("/service_accounts/{id}").HandlerFunc(h.getServiceAccount).Methods(http.MethodGet)
+	sr.Path("/service_accounts/{id}").HandlerFunc(h.replaceServiceAccount).Methods(http.MethodPut)
+	sr.Path("/service_accounts/{id}").HandlerFunc(h.deleteServiceAccount).Methods(http.MethodDelete)
+	sr.Path("/service_accounts/{id}/token").HandlerFunc(h.createServiceAccountToken).Methods(http.MethodPost)
+	sr.Path("/settings").HandlerFunc(h.getSettings).Methods(http.MethodGet)
+	sr.Path("/settings").HandlerFunc(h.replaceSettings).Methods(http.MethodPut)
+	h.router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not found")
+	})
+	h.router.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	})
+	return h
+}
+
+// ServeHTTP serves an admin API request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.bearerToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.bearerToken)) != 1 {
+		writeError(w, http.StatusUnauthorized, "invalid bearer token")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	h.router.ServeHTTP(w, r)
+}
+
+// getETag returns the ETag of a record version.
+func getETag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// checkETag returns false and writes an error if the If-Match header of the request doesn't
+// match the version of the record.
+func checkETag(w http.ResponseWriter, r *http.Request, version uint64) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" || ifMatch == getETag(version) {
+		return true
+	}
+	writeError(w, http.StatusPreconditionFailed, "etag mismatch")
+	return false
+}
+
+// getPage returns the page of records requested using the page_size and page_token query
+// parameters, and the token of the next page. The records must be sorted by id.
+func getPage(r *http.Request, records []*databroker.Record) ([]*databroker.Record, string, error) {
+	pageSize := defaultPageSize
+	if v := r.FormValue("page_size"); v != "" {
+		var err error
+		pageSize, err = strconv.Atoi(v)
+		if err != nil || pageSize < 1 {
+			return nil, "", errors.New("invalid page_size")
+		}
+		if pageSize > maxPageSize {
+			pageSize = maxPageSize
+		}
+	}
+
+	if v := r.FormValue("page_token"); v != "" {
+		after, err := base64.RawURLEncoding.DecodeString(v)
+		if err != nil {
+			return nil, "", errors.New("invalid page_token")
+		}
+		i := sort.Search(len(records), func(i int) bool {
+			return records[i].GetId() > string(after)
+		})
+		records = records[i:]
+	}
+
+	if len(records) <= pageSize {
+		return records, "", nil
+	}
+	records = records[:pageSize]
+	return records, base64.RawURLEncoding.EncodeToString([]byte(records[pageSize-1].GetId())), nil
+}
+
+// readProto decodes the JSON body of the request into the message, and returns false and
+// writes an error if it's invalid. Unknown fields are invalid.
+func readProto(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	bs, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if err := protojson.Unmarshal(bs, msg); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func marshalProto(msg proto.Message) (json.RawMessage, error) {
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+}
+
+// writeProto writes a resource with the ETag of its record version.
+func writeProto(w http.ResponseWriter, r *http.Request, statusCode int, msg proto.Message, version uint64) {
+	bs, err := marshalProto(msg)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", getETag(version))
+	writeJSON(w, statusCode, bs)
+}
+
+// writeList writes a page of resources, under the key of the resource type.
+func writeList(w http.ResponseWriter, r *http.Request, key string, msgs []proto.Message, nextPageToken string) {
+	items := []json.RawMessage{}
+	for _, msg := range msgs {
+		bs, err := marshalProto(msg)
+		if err != nil {
+			writeInternalError(w, r, err)
+			return
+		}
+		items = append(items, bs)
+	}
+	res := map[string]interface{}{key: items}
+	if nextPageToken != "" {
+		res["next_page_token"] = nextPageToken
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, obj interface{}) {
+	bs, err := json.Marshal(obj)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		bs = []byte(`{"code":500,"message":"internal error"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(bs)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    statusCode,
+		Message: message,
+	})
+}
+
+func writeInternalError(w http.ResponseWriter, r *http.Request, err error) {
+	log.Error(r.Context()).Err(err).Msg("adminapi: internal error")
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
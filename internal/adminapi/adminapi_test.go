@@ -0,0 +1,295 @@
+package adminapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+const testBearerToken = "TOKEN"
+
+var testSharedKey = cryptutil.NewKey()
+
+func newTestHandler(t *testing.T) (*Handler, databroker.DataBrokerServiceClient) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(gs, internal_databroker.New())
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+
+	client := databroker.NewDataBrokerServiceClient(cc)
+	return New(client, testBearerToken, newTestEncoder(t)), client
+}
+
+func newTestEncoder(t *testing.T) encoding.MarshalUnmarshaler {
+	t.Helper()
+
+	encoder, err := jws.NewHS256Signer(testSharedKey)
+	require.NoError(t, err)
+	return encoder
+}
+
+func getConfig(ctx context.Context, t *testing.T, client databroker.DataBrokerServiceClient, id string) *configpb.Config {
+	t.Helper()
+
+	res, err := client.Get(ctx, &databroker.GetRequest{Type: configTypeURL, Id: id})
+	require.NoError(t, err)
+	var cfg configpb.Config
+	require.NoError(t, res.GetRecord().GetData().UnmarshalTo(&cfg))
+	return &cfg
+}
+
+func doRequest(t *testing.T, h http.Handler, method, path, ifMatch string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+
+	var bs []byte
+	if body != nil {
+		var err error
+		bs, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	r := httptest.NewRequest(method, "https://authenticate.example.com"+BasePath+path, strings.NewReader(string(bs)))
+	r.Header.Set("Authorization", "Bearer "+testBearerToken)
+	if ifMatch != "" {
+		r.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	var res map[string]interface{}
+	if w.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+	}
+	return w, res
+}
+
+func TestHandler(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	t.Run("unauthorized", func(t *testing.T) {
+		h, _ := newTestHandler(t)
+		for _, authorization := range []string{"", "Bearer", "Bearer WRONG"} {
+			r := httptest.NewRequest(http.MethodGet, BasePath+"/routes", nil)
+			r.Header.Set("Authorization", authorization)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+	})
+	t.Run("routes", func(t *testing.T) {
+		h, client := newTestHandler(t)
+
+		w, res := doRequest(t, h, http.MethodPost, "/routes", "", map[string]interface{}{
+			"from": "https://from.example.com",
+			"to":   []string{"https://to.example.com"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		id := res["id"].(string)
+		etag := w.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		cfg := getConfig(ctx, t, client, routeRecordPrefix+id)
+		if assert.Len(t, cfg.GetRoutes(), 1) {
+			assert.Equal(t, "https://from.example.com", cfg.GetRoutes()[0].GetFrom())
+		}
+
+		w, _ = doRequest(t, h, http.MethodPost, "/routes", "", map[string]interface{}{
+			"from": "https://from.example.com",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, "should validate routes")
+		w, _ = doRequest(t, h, http.MethodPost, "/routes", "", map[string]interface{}{
+			"unknown": true,
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, "should reject unknown fields")
+
+		w, res = doRequest(t, h, http.MethodPut, "/routes/"+id, etag, map[string]interface{}{
+			"from": "https://from.example.com",
+			"to":   []string{"https://other.example.com"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, id, res["id"])
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+		w, _ = doRequest(t, h, http.MethodPut, "/routes/"+id, etag, map[string]interface{}{
+			"from": "https://from.example.com",
+			"to":   []string{"https://to.example.com"},
+		})
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, "should reject stale etags")
+		w, _ = doRequest(t, h, http.MethodDelete, "/routes/"+id, etag, nil)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, "should reject stale etags")
+
+		w, res = doRequest(t, h, http.MethodGet, "/routes/"+id, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []interface{}{"https://other.example.com"}, res["to"])
+
+		w, _ = doRequest(t, h, http.MethodDelete, "/routes/"+id, w.Header().Get("ETag"), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w, _ = doRequest(t, h, http.MethodGet, "/routes/"+id, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		h, _ := newTestHandler(t)
+
+		var ids []string
+		for i := 0; i < 5; i++ {
+			w, res := doRequest(t, h, http.MethodPost, "/policies", "", map[string]interface{}{
+				"allowed_users": []string{"alice@example.com"},
+			})
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			ids = append(ids, res["id"].(string))
+		}
+
+		var listed []string
+		pageToken := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3)
+			w, res := doRequest(t, h, http.MethodGet, "/policies?page_size=2&page_token="+pageToken, "", nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			for _, p := range res["policies"].([]interface{}) {
+				listed = append(listed, p.(map[string]interface{})["id"].(string))
+			}
+			if res["next_page_token"] == nil {
+				break
+			}
+			pageToken = res["next_page_token"].(string)
+		}
+		assert.ElementsMatch(t, ids, listed)
+
+		w, _ := doRequest(t, h, http.MethodGet, "/policies?page_size=0", "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("policies", func(t *testing.T) {
+		h, client := newTestHandler(t)
+
+		w, res := doRequest(t, h, http.MethodPost, "/policies", "", map[string]interface{}{
+			"name":          "admins",
+			"allowed_users": []string{"alice@example.com"},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		policyID := res["id"].(string)
+		policyETag := w.Header().Get("ETag")
+
+		w, _ = doRequest(t, h, http.MethodPost, "/routes", "", map[string]interface{}{
+			"from":       "https://from.example.com",
+			"to":         []string{"https://to.example.com"},
+			"policy_ids": []string{"missing"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, "should reject missing policies")
+
+		w, res = doRequest(t, h, http.MethodPost, "/routes", "", map[string]interface{}{
+			"from":       "https://from.example.com",
+			"to":         []string{"https://to.example.com"},
+			"policy_ids": []string{policyID},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		routeID := res["id"].(string)
+		assert.Nil(t, res["policies"], "should not return referenced policies")
+
+		getStoredPolicies := func() []*configpb.Policy {
+			cfg := getConfig(ctx, t, client, routeRecordPrefix+routeID)
+			require.Len(t, cfg.GetRoutes(), 1)
+			return cfg.GetRoutes()[0].GetPolicies()
+		}
+		if policies := getStoredPolicies(); assert.Len(t, policies, 1) {
+			assert.Equal(t, []string{"alice@example.com"}, policies[0].GetAllowedUsers())
+		}
+
+		w, _ = doRequest(t, h, http.MethodPut, "/policies/"+policyID, policyETag, map[string]interface{}{
+			"name":          "admins",
+			"allowed_users": []string{"bob@example.com"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		if policies := getStoredPolicies(); assert.Len(t, policies, 1) {
+			assert.Equal(t, []string{"bob@example.com"}, policies[0].GetAllowedUsers(),
+				"should update the routes using the policy")
+		}
+
+		w, _ = doRequest(t, h, http.MethodDelete, "/policies/"+policyID, "", nil)
+		assert.Equal(t, http.StatusConflict, w.Code, "should not delete policies in use")
+
+		w, _ = doRequest(t, h, http.MethodDelete, "/routes/"+routeID, "", nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		w, _ = doRequest(t, h, http.MethodDelete, "/policies/"+policyID, "", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+	t.Run("service accounts", func(t *testing.T) {
+		h, _ := newTestHandler(t)
+
+		w, _ := doRequest(t, h, http.MethodPost, "/service_accounts", "", map[string]interface{}{
+			"description": "ci",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, "should require a user id")
+
+		w, res := doRequest(t, h, http.MethodPost, "/service_accounts", "", map[string]interface{}{
+			"user_id":     "u1",
+			"description": "ci",
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		id := res["id"].(string)
+		assert.NotNil(t, res["issued_at"])
+
+		w, res = doRequest(t, h, http.MethodPost, "/service_accounts/"+id+"/token", "", nil)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var state sessions.State
+		require.NoError(t, newTestEncoder(t).Unmarshal([]byte(res["token"].(string)), &state))
+		assert.Equal(t, id, state.ID)
+		assert.Equal(t, "u1", state.Subject)
+
+		w, _ = doRequest(t, h, http.MethodDelete, "/service_accounts/"+id, "", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w, _ = doRequest(t, h, http.MethodPost, "/service_accounts/"+id+"/token", "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("settings", func(t *testing.T) {
+		h, _ := newTestHandler(t)
+
+		w, res := doRequest(t, h, http.MethodGet, "/settings", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, res)
+		etag := w.Header().Get("ETag")
+
+		w, _ = doRequest(t, h, http.MethodPut, "/settings", etag, map[string]interface{}{
+			"log_level": "debug",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w, _ = doRequest(t, h, http.MethodPut, "/settings", etag, map[string]interface{}{
+			"log_level": "info",
+		})
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, "should reject stale etags")
+
+		w, res = doRequest(t, h, http.MethodGet, "/settings", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "debug", res["log_level"])
+	})
+}
@@ -0,0 +1,167 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// Policies are stored as their own records, and are added to the routes which reference them
+// by id. When a policy changes, the routes using it are updated.
+
+var policyTypeURL = grpcutil.GetTypeURL(new(configpb.Policy))
+
+func (h *Handler) listPolicies(w http.ResponseWriter, r *http.Request) {
+	records, err := h.listRecords(r.Context(), policyTypeURL, "")
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	page, nextPageToken, err := getPage(r, records)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var policies []proto.Message
+	for _, record := range page {
+		var p configpb.Policy
+		if err := record.GetData().UnmarshalTo(&p); err != nil {
+			writeInternalError(w, r, err)
+			return
+		}
+		policies = append(policies, &p)
+	}
+	writeList(w, r, "policies", policies, nextPageToken)
+}
+
+func (h *Handler) createPolicy(w http.ResponseWriter, r *http.Request) {
+	var p configpb.Policy
+	if !readProto(w, r, &p) {
+		return
+	}
+	p.Id = uuid.NewString()
+
+	record, err := h.putRecord(r.Context(), p.GetId(), &p, 0)
+	if err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusCreated, &p, record.GetVersion())
+}
+
+func (h *Handler) getPolicy(w http.ResponseWriter, r *http.Request) {
+	record, ok := h.loadRecord(w, r, policyTypeURL, mux.Vars(r)["id"], "policy")
+	if !ok {
+		return
+	}
+	var p configpb.Policy
+	if err := record.GetData().UnmarshalTo(&p); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusOK, &p, record.GetVersion())
+}
+
+func (h *Handler) replacePolicy(w http.ResponseWriter, r *http.Request) {
+	record, ok := h.loadRecord(w, r, policyTypeURL, mux.Vars(r)["id"], "policy")
+	if !ok || !checkETag(w, r, record.GetVersion()) {
+		return
+	}
+
+	var p configpb.Policy
+	if !readProto(w, r, &p) {
+		return
+	}
+	p.Id = record.GetId()
+
+	routeRecords, err := h.listRecords(r.Context(), configTypeURL, routeRecordPrefix)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	policies, err := h.getPolicyLookup(r.Context())
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	routeRecords, err = renderPolicyRoutes(routeRecords, policies, &p)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// the policy and the routes using it are updated atomically
+	data := protoutil.NewAny(&p)
+	res, err := h.client.Put(r.Context(), &databroker.PutRequest{
+		Records: append([]*databroker.Record{{
+			Version: record.GetVersion(),
+			Type:    data.GetTypeUrl(),
+			Id:      p.GetId(),
+			Data:    data,
+		}}, routeRecords...),
+		CompareVersions: true,
+	})
+	if err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusOK, &p, res.GetRecords()[0].GetVersion())
+}
+
+func (h *Handler) deletePolicy(w http.ResponseWriter, r *http.Request) {
+	record, ok := h.loadRecord(w, r, policyTypeURL, mux.Vars(r)["id"], "policy")
+	if !ok || !checkETag(w, r, record.GetVersion()) {
+		return
+	}
+
+	// policies can't be deleted while routes use them
+	routes, err := h.listRecords(r.Context(), configTypeURL, routeRecordPrefix)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	for _, routeRecord := range routes {
+		route, err := routeFromRecord(routeRecord)
+		if err != nil {
+			writeInternalError(w, r, err)
+			return
+		}
+		if containsString(route.GetPolicyIds(), record.GetId()) {
+			writeError(w, http.StatusConflict, "policy is used by route "+route.GetId())
+			return
+		}
+	}
+
+	if err := h.deleteRecord(r.Context(), record); err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getPolicyLookup returns the policies by id.
+func (h *Handler) getPolicyLookup(ctx context.Context) (map[string]*configpb.Policy, error) {
+	records, err := h.listRecords(ctx, policyTypeURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := make(map[string]*configpb.Policy, len(records))
+	for _, record := range records {
+		var p configpb.Policy
+		if err := record.GetData().UnmarshalTo(&p); err != nil {
+			return nil, err
+		}
+		lookup[record.GetId()] = &p
+	}
+	return lookup, nil
+}
@@ -0,0 +1,100 @@
+package adminapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+// listRecords returns the records of the type whose ids have the prefix, sorted by id.
+func (h *Handler) listRecords(ctx context.Context, typeURL, prefix string) ([]*databroker.Record, error) {
+	all, _, _, err := databroker.InitialSync(ctx, h.client, &databroker.SyncLatestRequest{
+		Type: typeURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*databroker.Record
+	for _, record := range all {
+		if record.GetDeletedAt() == nil && strings.HasPrefix(record.GetId(), prefix) {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].GetId() < records[j].GetId()
+	})
+	return records, nil
+}
+
+// loadRecord returns the record of the type with the id, and returns false and writes an error
+// if it doesn't exist.
+func (h *Handler) loadRecord(w http.ResponseWriter, r *http.Request, typeURL, id, resourceType string) (*databroker.Record, bool) {
+	res, err := h.client.Get(r.Context(), &databroker.GetRequest{
+		Type: typeURL,
+		Id:   id,
+	})
+	if status.Code(err) == codes.NotFound {
+		writeError(w, http.StatusNotFound, resourceType+" not found")
+		return nil, false
+	} else if err != nil {
+		writeInternalError(w, r, err)
+		return nil, false
+	}
+	return res.GetRecord(), true
+}
+
+// putRecord saves the message as the record with the id, if the stored record has the
+// version. A version of 0 creates a new record.
+func (h *Handler) putRecord(ctx context.Context, id string, msg proto.Message, version uint64) (*databroker.Record, error) {
+	data := protoutil.NewAny(msg)
+	res, err := h.client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Version: version,
+			Type:    data.GetTypeUrl(),
+			Id:      id,
+			Data:    data,
+		}},
+		CompareVersions: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetRecord(), nil
+}
+
+// deleteRecord deletes the record, if it hasn't changed.
+func (h *Handler) deleteRecord(ctx context.Context, record *databroker.Record) error {
+	_, err := h.client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Version:   record.GetVersion(),
+			Type:      record.GetType(),
+			Id:        record.GetId(),
+			Data:      record.GetData(),
+			DeletedAt: timestamppb.Now(),
+		}},
+		CompareVersions: true,
+	})
+	return err
+}
+
+// writeStorageError writes the error of a put or delete. Records which changed concurrently
+// fail the request's precondition.
+func writeStorageError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, storage.ErrVersionMismatch) || status.Code(err) == codes.FailedPrecondition {
+		writeError(w, http.StatusPreconditionFailed, "the resource was modified concurrently")
+		return
+	}
+	writeInternalError(w, r, err)
+}
@@ -0,0 +1,206 @@
+package adminapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/config"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// routeRecordPrefix is the prefix of the ids of the config records of routes. Every route is
+// stored as its own config, which the databroker config source applies.
+const routeRecordPrefix = "admin-route-"
+
+var configTypeURL = grpcutil.GetTypeURL(new(configpb.Config))
+
+func (h *Handler) listRoutes(w http.ResponseWriter, r *http.Request) {
+	records, err := h.listRecords(r.Context(), configTypeURL, routeRecordPrefix)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	page, nextPageToken, err := getPage(r, records)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var routes []proto.Message
+	for _, record := range page {
+		route, err := routeFromRecord(record)
+		if err != nil {
+			writeInternalError(w, r, err)
+			return
+		}
+		routes = append(routes, route)
+	}
+	writeList(w, r, "routes", routes, nextPageToken)
+}
+
+func (h *Handler) createRoute(w http.ResponseWriter, r *http.Request) {
+	var route configpb.Route
+	if !readProto(w, r, &route) {
+		return
+	}
+	route.Id = uuid.NewString()
+	h.saveRoute(w, r, &route, 0, http.StatusCreated)
+}
+
+func (h *Handler) getRoute(w http.ResponseWriter, r *http.Request) {
+	record, ok := h.loadRecord(w, r, configTypeURL, routeRecordPrefix+mux.Vars(r)["id"], "route")
+	if !ok {
+		return
+	}
+	route, err := routeFromRecord(record)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusOK, route, record.GetVersion())
+}
+
+func (h *Handler) replaceRoute(w http.ResponseWriter, r *http.Request) {
+	record, ok := h.loadRecord(w, r, configTypeURL, routeRecordPrefix+mux.Vars(r)["id"], "route")
+	if !ok || !checkETag(w, r, record.GetVersion()) {
+		return
+	}
+
+	var route configpb.Route
+	if !readProto(w, r, &route) {
+		return
+	}
+	route.Id = mux.Vars(r)["id"]
+	h.saveRoute(w, r, &route, record.GetVersion(), http.StatusOK)
+}
+
+func (h *Handler) deleteRoute(w http.ResponseWriter, r *http.Request) {
+	record, ok := h.loadRecord(w, r, configTypeURL, routeRecordPrefix+mux.Vars(r)["id"], "route")
+	if !ok || !checkETag(w, r, record.GetVersion()) {
+		return
+	}
+	if err := h.deleteRecord(r.Context(), record); err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) saveRoute(w http.ResponseWriter, r *http.Request, route *configpb.Route, version uint64, statusCode int) {
+	policies, err := h.getPolicyLookup(r.Context())
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	stored, err := renderRoute(route, policies)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	record, err := h.putRecord(r.Context(), routeRecordPrefix+route.GetId(), newRouteConfig(stored), version)
+	if err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	writeProto(w, r, statusCode, route, record.GetVersion())
+}
+
+// renderPolicyRoutes returns the route records using the policy, updated with the policy.
+func renderPolicyRoutes(records []*databroker.Record, policies map[string]*configpb.Policy, policy *configpb.Policy) ([]*databroker.Record, error) {
+	policies[policy.GetId()] = policy
+
+	var updated []*databroker.Record
+	for _, record := range records {
+		route, err := routeFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if !containsString(route.GetPolicyIds(), policy.GetId()) {
+			continue
+		}
+
+		stored, err := renderRoute(route, policies)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %w", route.GetId(), err)
+		}
+		updated = append(updated, &databroker.Record{
+			Version: record.GetVersion(),
+			Type:    record.GetType(),
+			Id:      record.GetId(),
+			Data:    protoutil.NewAny(newRouteConfig(stored)),
+		})
+	}
+	return updated, nil
+}
+
+func newRouteConfig(route *configpb.Route) *configpb.Config {
+	return &configpb.Config{
+		Name:   "admin api route " + route.GetId(),
+		Routes: []*configpb.Route{route},
+	}
+}
+
+// renderRoute returns the route as it's stored, with the policies of its policy ids added to
+// its policies. The stored route must be a valid policy.
+func renderRoute(route *configpb.Route, policies map[string]*configpb.Policy) (*configpb.Route, error) {
+	stored := proto.Clone(route).(*configpb.Route)
+	for _, p := range route.GetPolicies() {
+		if containsString(route.GetPolicyIds(), p.GetId()) {
+			return nil, fmt.Errorf("policy %s is both inline and in policy_ids", p.GetId())
+		}
+	}
+	for _, id := range route.GetPolicyIds() {
+		p, ok := policies[id]
+		if !ok {
+			return nil, fmt.Errorf("policy %s not found", id)
+		}
+		stored.Policies = append(stored.Policies, p)
+	}
+
+	policy, err := config.NewPolicyFromProto(stored)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// routeFromRecord returns the route of a config record, without the policies of its policy ids.
+func routeFromRecord(record *databroker.Record) (*configpb.Route, error) {
+	var cfg configpb.Config
+	if err := record.GetData().UnmarshalTo(&cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.GetRoutes()) != 1 {
+		return nil, fmt.Errorf("config %s: expected a single route", record.GetId())
+	}
+
+	route := cfg.GetRoutes()[0]
+	var policies []*configpb.Policy
+	for _, p := range route.GetPolicies() {
+		if !containsString(route.GetPolicyIds(), p.GetId()) {
+			policies = append(policies, p)
+		}
+	}
+	route.Policies = policies
+	return route, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package adminapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+var serviceAccountTypeURL = grpcutil.GetTypeURL(new(user.ServiceAccount))
+
+func (h *Handler) listServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	records, err := h.listRecords(r.Context(), serviceAccountTypeURL, "")
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	page, nextPageToken, err := getPage(r, records)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var serviceAccounts []proto.Message
+	for _, record := range page {
+		var sa user.ServiceAccount
+		if err := record.GetData().UnmarshalTo(&sa); err != nil {
+			writeInternalError(w, r, err)
+			return
+		}
+		serviceAccounts = append(serviceAccounts, &sa)
+	}
+	writeList(w, r, "service_accounts", serviceAccounts, nextPageToken)
+}
+
+func (h *Handler) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var sa user.ServiceAccount
+	if !readServiceAccount(w, r, &sa) {
+		return
+	}
+	sa.Id = uuid.NewString()
+	sa.IssuedAt = timestamppb.Now()
+	sa.AccessedAt = nil
+
+	record, err := h.putRecord(r.Context(), sa.GetId(), &sa, 0)
+	if err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusCreated, &sa, record.GetVersion())
+}
+
+func (h *Handler) getServiceAccount(w http.ResponseWriter, r *http.Request) {
+	record, sa, ok := h.loadServiceAccount(w, r)
+	if !ok {
+		return
+	}
+	writeProto(w, r, http.StatusOK, sa, record.GetVersion())
+}
+
+func (h *Handler) replaceServiceAccount(w http.ResponseWriter, r *http.Request) {
+	record, existing, ok := h.loadServiceAccount(w, r)
+	if !ok || !checkETag(w, r, record.GetVersion()) {
+		return
+	}
+
+	var sa user.ServiceAccount
+	if !readServiceAccount(w, r, &sa) {
+		return
+	}
+	// the id and timestamps are managed by pomerium
+	sa.Id = existing.GetId()
+	sa.IssuedAt = existing.GetIssuedAt()
+	sa.AccessedAt = existing.GetAccessedAt()
+
+	record, err := h.putRecord(r.Context(), sa.GetId(), &sa, record.GetVersion())
+	if err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusOK, &sa, record.GetVersion())
+}
+
+func (h *Handler) deleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	record, _, ok := h.loadServiceAccount(w, r)
+	if !ok || !checkETag(w, r, record.GetVersion()) {
+		return
+	}
+	if err := h.deleteRecord(r.Context(), record); err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createServiceAccountToken returns a new bearer token of the service account. Tokens are valid
+// until the service account expires or is deleted.
+func (h *Handler) createServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	_, sa, ok := h.loadServiceAccount(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := h.encoder.Marshal(&sessions.State{
+		Subject:  sa.GetUserId(),
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		ID:       sa.GetId(),
+	})
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"token": string(token)})
+}
+
+func (h *Handler) loadServiceAccount(w http.ResponseWriter, r *http.Request) (*databroker.Record, *user.ServiceAccount, bool) {
+	record, ok := h.loadRecord(w, r, serviceAccountTypeURL, mux.Vars(r)["id"], "service account")
+	if !ok {
+		return nil, nil, false
+	}
+	var sa user.ServiceAccount
+	if err := record.GetData().UnmarshalTo(&sa); err != nil {
+		writeInternalError(w, r, err)
+		return nil, nil, false
+	}
+	return record, &sa, true
+}
+
+func readServiceAccount(w http.ResponseWriter, r *http.Request, sa *user.ServiceAccount) bool {
+	if !readProto(w, r, sa) {
+		return false
+	}
+	if sa.GetUserId() == "" {
+		writeError(w, http.StatusBadRequest, "user_id is required")
+		return false
+	}
+	return true
+}
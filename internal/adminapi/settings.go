@@ -0,0 +1,70 @@
+package adminapi
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// settingsRecordID is the id of the config record of the settings. The settings are applied
+// over the settings of the config file by the databroker config source.
+const settingsRecordID = "admin-settings"
+
+func (h *Handler) getSettings(w http.ResponseWriter, r *http.Request) {
+	cfg, version, ok := h.loadSettings(w, r)
+	if !ok {
+		return
+	}
+	writeProto(w, r, http.StatusOK, cfg.GetSettings(), version)
+}
+
+func (h *Handler) replaceSettings(w http.ResponseWriter, r *http.Request) {
+	_, version, ok := h.loadSettings(w, r)
+	if !ok || !checkETag(w, r, version) {
+		return
+	}
+
+	var settings configpb.Settings
+	if !readProto(w, r, &settings) {
+		return
+	}
+
+	record, err := h.putRecord(r.Context(), settingsRecordID, &configpb.Config{
+		Name:     "admin api settings",
+		Settings: &settings,
+	}, version)
+	if err != nil {
+		writeStorageError(w, r, err)
+		return
+	}
+	writeProto(w, r, http.StatusOK, &settings, record.GetVersion())
+}
+
+// loadSettings returns the config of the settings and its version. Without any settings the
+// config is empty, and the version is 0.
+func (h *Handler) loadSettings(w http.ResponseWriter, r *http.Request) (*configpb.Config, uint64, bool) {
+	res, err := h.client.Get(r.Context(), &databroker.GetRequest{
+		Type: configTypeURL,
+		Id:   settingsRecordID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return &configpb.Config{Settings: new(configpb.Settings)}, 0, true
+	} else if err != nil {
+		writeInternalError(w, r, err)
+		return nil, 0, false
+	}
+
+	var cfg configpb.Config
+	if err := res.GetRecord().GetData().UnmarshalTo(&cfg); err != nil {
+		writeInternalError(w, r, err)
+		return nil, 0, false
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = new(configpb.Settings)
+	}
+	return &cfg, res.GetRecord().GetVersion(), true
+}
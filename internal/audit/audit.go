@@ -0,0 +1,138 @@
+// Package audit delivers audit events, like logins, logouts and denied requests, to Kafka,
+// webhooks, Amazon SQS queues and Google Cloud Pub/Sub topics.
+//
+// Events are delivered at least once: events which can't be delivered, after retrying, are
+// spilled to a file and delivered again later. Consumers can use the ID of the events to ignore
+// duplicates.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/siem"
+	"github.com/pomerium/pomerium/internal/telemetry/requestid"
+	"github.com/pomerium/pomerium/pkg/grpc/events"
+)
+
+// An Event is an audit event.
+type Event = events.AuditEvent
+
+// Types of audit events.
+const (
+	EventTypeLogin                  = "login"
+	EventTypeLogout                 = "logout"
+	EventTypeAccessDenied           = "access-denied"
+	EventTypeImpersonationRequested = "impersonation-requested"
+	EventTypeImpersonationApproved  = "impersonation-approved"
+	EventTypeConfigChanged          = "config-changed"
+	EventTypeSessionRevoked         = "session-revoked"
+	EventTypeDeviceApproved         = "device-approved"
+	EventTypeDeviceRevoked          = "device-revoked"
+	EventTypeCertificateExpiring    = "certificate-expiring"
+)
+
+// Types of sinks.
+const (
+	SinkTypeWebhook = "webhook"
+	SinkTypeKafka   = "kafka"
+	SinkTypeSQS     = "sqs"
+	SinkTypePubSub  = "pubsub"
+)
+
+// Formats of events. Events are delivered as protobuf JSON by default, or formatted for SIEM
+// systems.
+const (
+	FormatJSON = "json"
+	FormatCEF  = siem.FormatCEF
+	FormatLEEF = siem.FormatLEEF
+	FormatECS  = siem.FormatECS
+)
+
+// Options are the options of the audit event pipeline.
+type Options struct {
+	// Service is the service which publishes the events.
+	Service string
+	Sinks   []SinkOptions
+	// SpillDirectory is the directory of the file events are spilled to when they can't be
+	// delivered. When empty, they're dropped.
+	SpillDirectory string
+}
+
+// SinkOptions are the options of a sink.
+type SinkOptions struct {
+	Type string
+	// URL is the URL of a webhook or an SQS queue.
+	URL string
+	// Headers are added to webhook requests.
+	Headers map[string]string
+	// Brokers are the bootstrap brokers of a Kafka cluster.
+	Brokers []string
+	// Topic is the Kafka topic, or the resource name of the Pub/Sub topic, like
+	// projects/my-project/topics/my-topic.
+	Topic string
+	// TLS enables TLS for Kafka.
+	TLS bool
+	// Username and Password enable SASL/PLAIN authentication for Kafka.
+	Username string
+	Password string
+	// Region is the AWS region of an SQS queue. Defaults to the region of the queue URL.
+	Region string
+	// Format is the format of the events. Defaults to json.
+	Format string
+}
+
+var (
+	defaultPipeline = newPipeline()
+
+	trailMu      sync.RWMutex
+	defaultTrail *Trail
+)
+
+// Publish publishes an audit event. Its ID, time, service and request ID are set if they're
+// empty. Events are delivered asynchronously. Privileged events are also appended to the audit
+// trail, when it's enabled, before Publish returns.
+func Publish(ctx context.Context, evt *Event) {
+	if evt.Id == "" {
+		evt.Id = uuid.NewString()
+	}
+	if evt.Time == nil {
+		evt.Time = timestamppb.New(time.Now())
+	}
+	if evt.RequestId == "" {
+		evt.RequestId = requestid.FromContext(ctx)
+	}
+	defaultPipeline.publish(ctx, evt)
+
+	trailMu.RLock()
+	trail := defaultTrail
+	trailMu.RUnlock()
+	if trail != nil && IsPrivileged(evt.GetType()) {
+		if err := trail.Append(ctx, evt); err != nil {
+			log.Error(ctx).Err(err).Str("event-id", evt.GetId()).Msg("audit: error appending event to audit trail")
+		}
+	}
+}
+
+// SetTrail sets the audit trail privileged events are appended to. A nil trail disables it.
+func SetTrail(trail *Trail) {
+	trailMu.Lock()
+	defaultTrail = trail
+	trailMu.Unlock()
+}
+
+// Update updates the options of the audit event pipeline. When there are no sinks events
+// aren't published.
+func Update(ctx context.Context, opts *Options) {
+	defaultPipeline.update(ctx, opts)
+}
+
+// Close stops the audit event pipeline. Events which haven't been delivered are spilled.
+func Close() {
+	defaultPipeline.update(context.Background(), &Options{})
+}
@@ -0,0 +1,240 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+)
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+
+	var failing int32 = 1
+	var mu sync.Mutex
+	var received []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		var events []map[string]interface{}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&events)) {
+			return
+		}
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		mu.Lock()
+		received = append(received, events...)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	p := newPipeline()
+	p.maxRetryTime = 10 * time.Millisecond
+	p.replayInterval = 50 * time.Millisecond
+	p.update(ctx, &Options{
+		Service: "authenticate",
+		Sinks: []SinkOptions{{
+			Type:    SinkTypeWebhook,
+			URL:     srv.URL,
+			Headers: map[string]string{"X-Token": "secret"},
+		}},
+		SpillDirectory: dir,
+	})
+	defer p.update(ctx, &Options{})
+
+	p.publish(ctx, &Event{Id: "e1", Type: EventTypeLogin, UserId: "u1"})
+
+	// the event is spilled while the webhook fails
+	assert.Eventually(t, func() bool {
+		bs, _ := os.ReadFile(filepath.Join(dir, "audit-spill.replay.jsonl"))
+		if len(bs) == 0 {
+			bs, _ = os.ReadFile(filepath.Join(dir, "audit-spill.jsonl"))
+		}
+		return strings.Contains(string(bs), `"id":"e1"`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// and replayed once it recovers
+	atomic.StoreInt32(&failing, 0)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, "e1", received[0]["id"])
+	assert.Equal(t, "login", received[0]["type"])
+	assert.Equal(t, "authenticate", received[0]["service"])
+	assert.Equal(t, "u1", received[0]["user_id"])
+	mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		_, err1 := os.Stat(filepath.Join(dir, "audit-spill.jsonl"))
+		_, err2 := os.Stat(filepath.Join(dir, "audit-spill.replay.jsonl"))
+		return os.IsNotExist(err1) && os.IsNotExist(err2)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPipelineDisabled(t *testing.T) {
+	p := newPipeline()
+	p.publish(context.Background(), &Event{Id: "e1"})
+	assert.Len(t, p.queue, 0)
+}
+
+func TestFormatSink(t *testing.T) {
+	ctx := context.Background()
+
+	var body, contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := io.ReadAll(r.Body)
+		body, contentType = string(bs), r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	s, err := newSink(ctx, SinkOptions{Type: SinkTypeWebhook, URL: srv.URL, Format: FormatCEF})
+	require.NoError(t, err)
+	require.NoError(t, s.send(ctx, [][]byte{
+		[]byte(`{"id":"e1","type":"login","user_id":"u1","details":{"idp":"google"}}`),
+		[]byte(`{"id":"e2","type":"access-denied","url":"https://www.example.com/"}`),
+	}))
+	assert.Equal(t, "text/plain; charset=utf-8", contentType)
+	lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[0], "|login|login|3|suid=u1 externalId=e1 outcome=success idp=google")
+		assert.Contains(t, lines[1], "|access-denied|access-denied|5|externalId=e2 outcome=failure request=https://www.example.com/")
+	}
+
+	s, err = newSink(ctx, SinkOptions{Type: SinkTypeWebhook, URL: srv.URL, Format: FormatECS})
+	require.NoError(t, err)
+	require.NoError(t, s.send(ctx, [][]byte{[]byte(`{"id":"e1","type":"logout"}`)}))
+	assert.Equal(t, "application/json", contentType)
+	var events []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &events))
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"kind":     "event",
+			"action":   "logout",
+			"id":       "e1",
+			"dataset":  "pomerium.audit",
+			"category": []interface{}{"authentication"},
+			"type":     []interface{}{"end"},
+			"outcome":  "success",
+			"severity": 3.0,
+		}, events[0]["event"])
+	}
+
+	_, err = newSink(ctx, SinkOptions{Type: SinkTypeWebhook, URL: srv.URL, Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestSpillFile(t *testing.T) {
+	dir := t.TempDir()
+	f, err := newSpillFile(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, f.append([][]byte{[]byte(`{"id":"1"}`), []byte(`{"id":"2"}`)}))
+
+	err = f.replay(func(events [][]byte) error {
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+
+	require.NoError(t, f.append([][]byte{[]byte(`{"id":"3"}`)}))
+
+	// the events of the failed replay are delivered first
+	var delivered []string
+	require.NoError(t, f.replay(func(events [][]byte) error {
+		for _, evt := range events {
+			delivered = append(delivered, string(evt))
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{`{"id":"1"}`, `{"id":"2"}`}, delivered)
+
+	delivered = nil
+	require.NoError(t, f.replay(func(events [][]byte) error {
+		for _, evt := range events {
+			delivered = append(delivered, string(evt))
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{`{"id":"3"}`}, delivered)
+}
+
+func TestSQSSink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/"), r.Header.Get("Authorization"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-west-2/sqs/aws4_request")
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "SendMessageBatch", r.PostForm.Get("Action"))
+		assert.Equal(t, `{"id":"1"}`, r.PostForm.Get("SendMessageBatchRequestEntry.1.MessageBody"))
+		assert.Equal(t, `{"id":"2"}`, r.PostForm.Get("SendMessageBatchRequestEntry.2.MessageBody"))
+		_, _ = io.WriteString(w, `<SendMessageBatchResponse><SendMessageBatchResult>`+
+			`<SendMessageBatchResultEntry><Id>0</Id></SendMessageBatchResultEntry>`+
+			`<SendMessageBatchResultEntry><Id>1</Id></SendMessageBatchResultEntry>`+
+			`</SendMessageBatchResult></SendMessageBatchResponse>`)
+	}))
+	defer srv.Close()
+
+	s, err := newSQSSink(SinkOptions{URL: srv.URL + "/123456789012/audit", Region: "us-west-2"})
+	require.NoError(t, err)
+	s.getenv = func(key string) string {
+		return map[string]string{
+			"AWS_ACCESS_KEY_ID":     "AKID",
+			"AWS_SECRET_ACCESS_KEY": "SECRET",
+		}[key]
+	}
+	assert.NoError(t, s.send(context.Background(), [][]byte{[]byte(`{"id":"1"}`), []byte(`{"id":"2"}`)}))
+
+	t.Run("region", func(t *testing.T) {
+		s, err := newSQSSink(SinkOptions{URL: "https://sqs.eu-west-1.amazonaws.com/123456789012/audit"})
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", s.region)
+
+		_, err = newSQSSink(SinkOptions{URL: "https://queue.example.com/audit"})
+		assert.Error(t, err)
+	})
+}
+
+func TestPubSubSink(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/my-project/topics/audit:publish", r.URL.Path)
+		var req struct {
+			Messages []struct {
+				Data string `json:"data"`
+			} `json:"messages"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if assert.Len(t, req.Messages, 1) {
+			data, _ := base64.StdEncoding.DecodeString(req.Messages[0].Data)
+			assert.Equal(t, `{"id":"1"}`, string(data))
+		}
+		_, _ = io.WriteString(w, `{"messageIds":["1"]}`)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	s, err := newPubSubSink(ctx, SinkOptions{Topic: "projects/my-project/topics/audit"},
+		option.WithEndpoint(srv.URL+"/"), option.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+	assert.NoError(t, s.send(ctx, [][]byte{[]byte(`{"id":"1"}`)}))
+
+	_, err = newPubSubSink(ctx, SinkOptions{Topic: "audit"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/siem"
+)
+
+// A formatSink formats the events, which are queued and spilled as protobuf JSON, before they're
+// sent to the underlying sink.
+type formatSink struct {
+	sink
+	format string
+}
+
+func (s formatSink) send(ctx context.Context, events [][]byte) error {
+	formatted := make([][]byte, 0, len(events))
+	for _, bs := range events {
+		evt := new(Event)
+		if err := protojson.Unmarshal(bs, evt); err != nil {
+			// retrying won't help, so the event is dropped
+			log.Error(ctx).Err(err).Msg("audit: error decoding event")
+			continue
+		}
+		formatted = append(formatted, siem.Format(s.format, newSIEMEvent(evt)))
+	}
+	if len(formatted) == 0 {
+		return nil
+	}
+	return s.sink.send(ctx, formatted)
+}
+
+// newSIEMEvent converts the audit event to an event of the SIEM formats. The details of the
+// event are added as labels.
+func newSIEMEvent(evt *Event) *siem.Event {
+	e := &siem.Event{
+		ID:        evt.GetType(),
+		Message:   evt.GetMessage(),
+		Severity:  3,
+		Dataset:   "pomerium.audit",
+		Outcome:   siem.OutcomeSuccess,
+		Service:   evt.GetService(),
+		EventID:   evt.GetId(),
+		SourceIP:  evt.GetIp(),
+		UserID:    evt.GetUserId(),
+		Email:     evt.GetEmail(),
+		SessionID: evt.GetSessionId(),
+		RequestID: evt.GetRequestId(),
+		URL:       evt.GetUrl(),
+		Labels:    evt.GetDetails(),
+	}
+	if evt.Time != nil {
+		e.Time = evt.GetTime().AsTime()
+	}
+	if e.Message == "" {
+		e.Message = e.ID
+	}
+
+	switch evt.GetType() {
+	case EventTypeLogin:
+		e.Categories, e.Types, e.IsLogin = []string{"authentication"}, []string{"start"}, true
+	case EventTypeLogout:
+		e.Categories, e.Types, e.IsLogout = []string{"authentication"}, []string{"end"}, true
+	case EventTypeAccessDenied:
+		e.Categories, e.Types = []string{"web"}, []string{"access", "denied"}
+		e.Severity, e.Outcome = 5, siem.OutcomeFailure
+	case EventTypeImpersonationRequested, EventTypeImpersonationApproved:
+		e.Categories, e.Types = []string{"iam"}, []string{"admin"}
+		e.Severity = 6
+	case EventTypeConfigChanged:
+		e.Categories, e.Types = []string{"configuration"}, []string{"change"}
+		e.Severity = 5
+	case EventTypeCertificateExpiring:
+		e.Categories, e.Types = []string{"configuration"}, []string{"info"}
+		e.Severity = 7
+	}
+	return e
+}
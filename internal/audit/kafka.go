@@ -0,0 +1,436 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const kafkaClientID = "pomerium"
+
+// Kafka API keys.
+const (
+	kafkaAPIProduce          = 0
+	kafkaAPIMetadata         = 3
+	kafkaAPISaslHandshake    = 17
+	kafkaAPISaslAuthenticate = 36
+)
+
+var kafkaCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// A kafkaSink produces events to a Kafka topic. It's a minimal client of the Kafka protocol,
+// rather than a general purpose one: each batch of events is written to one of the partitions of
+// the topic, round robin, and is acknowledged by all of the in-sync replicas.
+//
+// https://kafka.apache.org/protocol
+type kafkaSink struct {
+	brokers  []string
+	topic    string
+	tls      *tls.Config
+	username string
+	password string
+
+	mu         sync.Mutex
+	conns      map[string]*kafkaConn
+	partitions []kafkaPartition
+	next       int
+}
+
+type kafkaPartition struct {
+	id     int32
+	leader string
+}
+
+type kafkaConn struct {
+	net.Conn
+	correlationID int32
+}
+
+func newKafkaSink(opts SinkOptions) (*kafkaSink, error) {
+	if len(opts.Brokers) == 0 || opts.Topic == "" {
+		return nil, fmt.Errorf("audit: kafka sink requires brokers and a topic")
+	}
+	s := &kafkaSink{
+		brokers:  opts.Brokers,
+		topic:    opts.Topic,
+		username: opts.Username,
+		password: opts.Password,
+		conns:    map[string]*kafkaConn{},
+	}
+	if opts.TLS {
+		s.tls = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return s, nil
+}
+
+func (s *kafkaSink) send(ctx context.Context, events [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.produce(ctx, events)
+	if err != nil {
+		// the leaders of the partitions may have changed
+		s.closeLocked()
+	}
+	return err
+}
+
+func (s *kafkaSink) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closeLocked()
+	return nil
+}
+
+func (s *kafkaSink) closeLocked() {
+	for addr, conn := range s.conns {
+		_ = conn.Close()
+		delete(s.conns, addr)
+	}
+	s.partitions = nil
+}
+
+func (s *kafkaSink) produce(ctx context.Context, events [][]byte) error {
+	if s.partitions == nil {
+		if err := s.loadMetadata(ctx); err != nil {
+			return err
+		}
+	}
+	partition := s.partitions[s.next%len(s.partitions)]
+	s.next++
+
+	conn, err := s.getConn(ctx, partition.leader)
+	if err != nil {
+		return err
+	}
+
+	var req kafkaEncoder
+	req.int16(-1) // transactional id
+	req.int16(-1) // acks, all of the in-sync replicas
+	req.int32(int32(defaultRequestTimeout / time.Millisecond))
+	req.int32(1)
+	req.string(s.topic)
+	req.int32(1)
+	req.int32(partition.id)
+	req.bytes(encodeKafkaRecordBatch(events, time.Now()))
+
+	res, err := s.roundTrip(ctx, conn, kafkaAPIProduce, 3, req.Bytes())
+	if err != nil {
+		return err
+	}
+	for i, n := 0, res.int32(); i < int(n) && res.err == nil; i++ {
+		_ = res.string()
+		for j, m := 0, res.int32(); j < int(m) && res.err == nil; j++ {
+			_ = res.int32()
+			code := res.int16()
+			_, _ = res.int64(), res.int64()
+			if code != 0 && res.err == nil {
+				return fmt.Errorf("audit: error producing events to kafka, error code %d", code)
+			}
+		}
+	}
+	if res.err != nil {
+		return fmt.Errorf("audit: invalid kafka produce response: %w", res.err)
+	}
+	return nil
+}
+
+// loadMetadata loads the partitions of the topic and the addresses of their leaders from the
+// first bootstrap broker which responds.
+func (s *kafkaSink) loadMetadata(ctx context.Context) error {
+	var req kafkaEncoder
+	req.int32(1)
+	req.string(s.topic)
+
+	var errs []error
+	for _, broker := range s.brokers {
+		conn, err := s.getConn(ctx, broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		res, err := s.roundTrip(ctx, conn, kafkaAPIMetadata, 1, req.Bytes())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return s.decodeMetadata(res)
+	}
+	return fmt.Errorf("audit: error loading kafka metadata: %v", errs)
+}
+
+func (s *kafkaSink) decodeMetadata(res *kafkaDecoder) error {
+	brokers := map[int32]string{}
+	for i, n := 0, res.int32(); i < int(n) && res.err == nil; i++ {
+		id, host, port := res.int32(), res.string(), res.int32()
+		_ = res.nullableString() // rack
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	_ = res.int32() // controller id
+
+	var partitions []kafkaPartition
+	for i, n := 0, res.int32(); i < int(n) && res.err == nil; i++ {
+		code, name := res.int16(), res.string()
+		_ = res.int8() // is internal
+		if code != 0 && res.err == nil {
+			return fmt.Errorf("audit: error loading kafka metadata for topic %s, error code %d", name, code)
+		}
+		for j, m := 0, res.int32(); j < int(m) && res.err == nil; j++ {
+			code, id, leader := res.int16(), res.int32(), res.int32()
+			res.int32s() // replicas
+			res.int32s() // in-sync replicas
+			if addr, ok := brokers[leader]; ok && code == 0 && name == s.topic {
+				partitions = append(partitions, kafkaPartition{id: id, leader: addr})
+			}
+		}
+	}
+	if res.err != nil {
+		return fmt.Errorf("audit: invalid kafka metadata response: %w", res.err)
+	}
+	if len(partitions) == 0 {
+		return fmt.Errorf("audit: kafka topic %s has no available partitions", s.topic)
+	}
+	s.partitions = partitions
+	return nil
+}
+
+func (s *kafkaSink) getConn(ctx context.Context, addr string) (*kafkaConn, error) {
+	if conn, ok := s.conns[addr]; ok {
+		return conn, nil
+	}
+
+	dialer := &net.Dialer{Timeout: defaultRequestTimeout}
+	var c net.Conn
+	var err error
+	if s.tls != nil {
+		c, err = (&tls.Dialer{NetDialer: dialer, Config: s.tls}).DialContext(ctx, "tcp", addr)
+	} else {
+		c, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("audit: error connecting to kafka broker %s: %w", addr, err)
+	}
+	conn := &kafkaConn{Conn: c}
+
+	if s.username != "" {
+		if err := s.authenticate(ctx, conn); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	s.conns[addr] = conn
+	return conn, nil
+}
+
+// authenticate authenticates the connection with SASL/PLAIN.
+func (s *kafkaSink) authenticate(ctx context.Context, conn *kafkaConn) error {
+	var req kafkaEncoder
+	req.string("PLAIN")
+	res, err := s.roundTrip(ctx, conn, kafkaAPISaslHandshake, 1, req.Bytes())
+	if err != nil {
+		return err
+	}
+	if code := res.int16(); code != 0 {
+		return backoff.Permanent(fmt.Errorf("audit: kafka broker doesn't support SASL/PLAIN, error code %d", code))
+	}
+
+	req.Reset()
+	req.bytes([]byte("\x00" + s.username + "\x00" + s.password))
+	res, err = s.roundTrip(ctx, conn, kafkaAPISaslAuthenticate, 0, req.Bytes())
+	if err != nil {
+		return err
+	}
+	if code, msg := res.int16(), res.nullableString(); code != 0 {
+		return backoff.Permanent(fmt.Errorf("audit: error authenticating to kafka, error code %d: %s", code, msg))
+	}
+	return nil
+}
+
+func (s *kafkaSink) roundTrip(ctx context.Context, conn *kafkaConn, apiKey, apiVersion int16, body []byte) (*kafkaDecoder, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultRequestTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	conn.correlationID++
+	var req kafkaEncoder
+	req.int32(0) // size, set below
+	req.int16(apiKey)
+	req.int16(apiVersion)
+	req.int32(conn.correlationID)
+	req.string(kafkaClientID)
+	req.Write(body)
+	bs := req.Bytes()
+	binary.BigEndian.PutUint32(bs, uint32(len(bs)-4))
+	if _, err := conn.Write(bs); err != nil {
+		return nil, fmt.Errorf("audit: error writing kafka request: %w", err)
+	}
+
+	var size [4]byte
+	if _, err := io.ReadFull(conn, size[:]); err != nil {
+		return nil, fmt.Errorf("audit: error reading kafka response: %w", err)
+	}
+	res := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(conn, res); err != nil {
+		return nil, fmt.Errorf("audit: error reading kafka response: %w", err)
+	}
+	d := &kafkaDecoder{b: res}
+	if id := d.int32(); id != conn.correlationID {
+		return nil, fmt.Errorf("audit: unexpected kafka response, correlation id %d", id)
+	}
+	return d, nil
+}
+
+// encodeKafkaRecordBatch encodes the events as the values of the records of an uncompressed
+// record batch.
+func encodeKafkaRecordBatch(events [][]byte, now time.Time) []byte {
+	var records kafkaEncoder
+	for i, evt := range events {
+		var record kafkaEncoder
+		record.int8(0)          // attributes
+		record.varint(0)        // timestamp delta
+		record.varint(int64(i)) // offset delta
+		record.varint(-1)       // key, null
+		record.varint(int64(len(evt)))
+		record.Write(evt)
+		record.varint(0) // headers
+		records.varint(int64(record.Len()))
+		records.Write(record.Bytes())
+	}
+
+	ts := now.UnixMilli()
+	// the checksum covers the batch from the attributes
+	var body kafkaEncoder
+	body.int16(0) // attributes
+	body.int32(int32(len(events) - 1))
+	body.int64(ts) // first timestamp
+	body.int64(ts) // max timestamp
+	body.int64(-1) // producer id
+	body.int16(-1) // producer epoch
+	body.int32(-1) // base sequence
+	body.int32(int32(len(events)))
+	body.Write(records.Bytes())
+
+	var batch kafkaEncoder
+	batch.int64(0) // base offset
+	batch.int32(int32(4 + 1 + 4 + body.Len()))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(2)   // magic
+	batch.int32(int32(crc32.Checksum(body.Bytes(), kafkaCRCTable)))
+	batch.Write(body.Bytes())
+	return batch.Bytes()
+}
+
+type kafkaEncoder struct {
+	bytes.Buffer
+}
+
+func (e *kafkaEncoder) int8(v int8) {
+	e.WriteByte(byte(v))
+}
+
+func (e *kafkaEncoder) int16(v int16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(v))
+	e.Write(b[:])
+}
+
+func (e *kafkaEncoder) int32(v int32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	e.Write(b[:])
+}
+
+func (e *kafkaEncoder) int64(v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	e.Write(b[:])
+}
+
+func (e *kafkaEncoder) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	e.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func (e *kafkaEncoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.WriteString(s)
+}
+
+func (e *kafkaEncoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.Write(b)
+}
+
+var errKafkaShortResponse = errors.New("short response")
+
+// A kafkaDecoder decodes a response. After an error the remaining values are zero.
+type kafkaDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *kafkaDecoder) next(n int) []byte {
+	if d.err != nil || n < 0 || len(d.b) < n {
+		d.err = errKafkaShortResponse
+		return nil
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+// fixed returns the next n bytes, or n zero bytes after an error.
+func (d *kafkaDecoder) fixed(n int) []byte {
+	if b := d.next(n); b != nil {
+		return b
+	}
+	return make([]byte, n)
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	return int8(d.fixed(1)[0])
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	return int16(binary.BigEndian.Uint16(d.fixed(2)))
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	return int32(binary.BigEndian.Uint32(d.fixed(4)))
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	return int64(binary.BigEndian.Uint64(d.fixed(8)))
+}
+
+func (d *kafkaDecoder) int32s() {
+	for i, n := 0, d.int32(); i < int(n) && d.err == nil; i++ {
+		_ = d.int32()
+	}
+}
+
+func (d *kafkaDecoder) string() string {
+	return string(d.next(int(d.int16())))
+}
+
+func (d *kafkaDecoder) nullableString() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.next(int(n)))
+}
@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKafkaRecord struct {
+	partition int32
+	value     string
+}
+
+// serveFakeKafka serves the metadata of a topic with two partitions and records the produced
+// records.
+func serveFakeKafka(t *testing.T, li net.Listener, records chan<- fakeKafkaRecord) {
+	host, portStr, _ := net.SplitHostPort(li.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	for {
+		conn, err := li.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			authenticated := false
+			for {
+				var size [4]byte
+				if _, err := io.ReadFull(conn, size[:]); err != nil {
+					return
+				}
+				body := make([]byte, binary.BigEndian.Uint32(size[:]))
+				if _, err := io.ReadFull(conn, body); err != nil {
+					return
+				}
+				req := &kafkaDecoder{b: body}
+				apiKey, _, correlationID, _ := req.int16(), req.int16(), req.int32(), req.string()
+
+				var res kafkaEncoder
+				res.int32(0)
+				res.int32(correlationID)
+				switch apiKey {
+				case kafkaAPISaslHandshake:
+					assert.Equal(t, "PLAIN", req.string())
+					res.int16(0)
+					res.int32(1)
+					res.string("PLAIN")
+				case kafkaAPISaslAuthenticate:
+					authBytes := req.next(int(req.int32()))
+					assert.Equal(t, "\x00user\x00pass", string(authBytes))
+					authenticated = true
+					res.int16(0)
+					res.int16(-1)
+					res.int32(0)
+				case kafkaAPIMetadata:
+					assert.True(t, authenticated)
+					res.int32(1)
+					res.int32(1)
+					res.string(host)
+					res.int32(int32(port))
+					res.int16(-1)
+					res.int32(1)
+					res.int32(1)
+					res.int16(0)
+					res.string("audit")
+					res.int8(0)
+					res.int32(2)
+					for i := int32(0); i < 2; i++ {
+						res.int16(0)
+						res.int32(i)
+						res.int32(1)
+						res.int32(1)
+						res.int32(1)
+						res.int32(1)
+						res.int32(1)
+					}
+				case kafkaAPIProduce:
+					assert.True(t, authenticated)
+					_, acks, _ := req.int16(), req.int16(), req.int32()
+					assert.Equal(t, int16(-1), acks)
+					_, topic, _, partition := req.int32(), req.string(), req.int32(), req.int32()
+					assert.Equal(t, "audit", topic)
+					for _, value := range decodeFakeKafkaRecordBatch(t, req.next(int(req.int32()))) {
+						records <- fakeKafkaRecord{partition: partition, value: value}
+					}
+					res.int32(1)
+					res.string(topic)
+					res.int32(1)
+					res.int32(partition)
+					res.int16(0)
+					res.int64(0)
+					res.int64(-1)
+					res.int32(0)
+				default:
+					t.Errorf("unexpected api key %d", apiKey)
+					return
+				}
+				bs := res.Bytes()
+				binary.BigEndian.PutUint32(bs, uint32(len(bs)-4))
+				if _, err := conn.Write(bs); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func decodeFakeKafkaRecordBatch(t *testing.T, batch []byte) []string {
+	d := &kafkaDecoder{b: batch}
+	_, length, _, magic := d.int64(), d.int32(), d.int32(), d.int8()
+	assert.Equal(t, int(length), len(batch)-12)
+	assert.Equal(t, int8(2), magic)
+	crc := uint32(d.int32())
+	assert.Equal(t, crc32.Checksum(d.b, kafkaCRCTable), crc)
+
+	_, _, _, _ = d.int16(), d.int32(), d.int64(), d.int64()
+	producerID := d.int64()
+	assert.Equal(t, int64(-1), producerID)
+	_, _ = d.int16(), d.int32()
+
+	var values []string
+	varint := func() int64 {
+		v, n := binary.Varint(d.b)
+		d.b = d.b[n:]
+		return v
+	}
+	for i, n := 0, d.int32(); i < int(n); i++ {
+		_ = varint() // length
+		_ = d.int8()
+		_ = varint() // timestamp delta
+		assert.Equal(t, int64(i), varint())
+		assert.Equal(t, int64(-1), varint())
+		values = append(values, string(d.next(int(varint()))))
+		assert.Equal(t, int64(0), varint())
+	}
+	require.NoError(t, d.err)
+	return values
+}
+
+func TestKafkaSink(t *testing.T) {
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer li.Close()
+
+	records := make(chan fakeKafkaRecord, 10)
+	go serveFakeKafka(t, li, records)
+
+	s, err := newKafkaSink(SinkOptions{
+		Brokers:  []string{li.Addr().String()},
+		Topic:    "audit",
+		Username: "user",
+		Password: "pass",
+	})
+	require.NoError(t, err)
+	defer s.close()
+
+	ctx := context.Background()
+	require.NoError(t, s.send(ctx, [][]byte{[]byte(`{"id":"1"}`), []byte(`{"id":"2"}`)}))
+	require.NoError(t, s.send(ctx, [][]byte{[]byte(`{"id":"3"}`)}))
+
+	assert.Equal(t, fakeKafkaRecord{partition: 0, value: `{"id":"1"}`}, <-records)
+	assert.Equal(t, fakeKafkaRecord{partition: 0, value: `{"id":"2"}`}, <-records)
+	assert.Equal(t, fakeKafkaRecord{partition: 1, value: `{"id":"3"}`}, <-records)
+
+	_, err = newKafkaSink(SinkOptions{Topic: "audit"})
+	assert.Error(t, err)
+}
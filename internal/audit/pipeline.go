@@ -0,0 +1,243 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+const (
+	queueSize             = 1024
+	maxBatchSize          = 100
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// A sink delivers JSON encoded events.
+type sink interface {
+	send(ctx context.Context, events [][]byte) error
+	close() error
+}
+
+type pipeline struct {
+	queue chan []byte
+	// failed deliveries are retried for up to maxRetryTime before the events are spilled, and
+	// spilled events are delivered again every replayInterval
+	maxRetryTime   time.Duration
+	replayInterval time.Duration
+
+	mu     sync.Mutex
+	opts   *Options
+	spill  *spillFile
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPipeline() *pipeline {
+	return &pipeline{
+		queue:          make(chan []byte, queueSize),
+		maxRetryTime:   30 * time.Second,
+		replayInterval: 30 * time.Second,
+		opts:           &Options{},
+	}
+}
+
+func (p *pipeline) publish(ctx context.Context, evt *Event) {
+	p.mu.Lock()
+	enabled, service, spill := len(p.opts.Sinks) > 0, p.opts.Service, p.spill
+	p.mu.Unlock()
+	if !enabled {
+		return
+	}
+
+	if evt.Service == "" {
+		evt.Service = service
+	}
+	bs, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(evt)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("audit: error encoding event")
+		return
+	}
+
+	select {
+	case p.queue <- bs:
+	default:
+		// the request isn't blocked when the queue is full
+		spillEvents(ctx, spill, [][]byte{bs})
+	}
+}
+
+func (p *pipeline) update(ctx context.Context, opts *Options) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if reflect.DeepEqual(p.opts, opts) {
+		return
+	}
+
+	// the current worker spills the events it hasn't delivered when it's stopped
+	if p.cancel != nil {
+		p.cancel()
+		<-p.done
+		p.cancel, p.done = nil, nil
+	}
+
+	p.opts, p.spill = opts, nil
+	if len(opts.Sinks) == 0 {
+		return
+	}
+
+	if opts.SpillDirectory != "" {
+		spill, err := newSpillFile(opts.SpillDirectory)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("audit: error creating spill file, undelivered events will be dropped")
+		} else {
+			p.spill = spill
+		}
+	}
+
+	var sinks []sink
+	for i, so := range opts.Sinks {
+		s, err := newSink(ctx, so)
+		if err != nil {
+			log.Error(ctx).Err(err).Int("sink", i).Msg("audit: error creating sink")
+			// events are spilled until the sink can be created
+			s = errSink{err}
+		}
+		sinks = append(sinks, s)
+	}
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	p.cancel, p.done = cancel, make(chan struct{})
+	go p.run(workerCtx, sinks, p.spill, p.done)
+}
+
+func (p *pipeline) run(ctx context.Context, sinks []sink, spill *spillFile, done chan struct{}) {
+	defer close(done)
+	defer func() {
+		for _, s := range sinks {
+			_ = s.close()
+		}
+	}()
+
+	ticker := time.NewTicker(p.replayInterval)
+	defer ticker.Stop()
+
+	p.replay(ctx, sinks, spill)
+	for {
+		select {
+		case <-ctx.Done():
+			spillEvents(context.Background(), spill, p.dequeue(nil, 0))
+			return
+		case bs := <-p.queue:
+			batch := p.dequeue([][]byte{bs}, maxBatchSize)
+			if err := p.deliver(ctx, sinks, batch); err != nil {
+				log.Error(ctx).Err(err).Int("events", len(batch)).Msg("audit: error delivering events")
+				spillEvents(ctx, spill, batch)
+			}
+		case <-ticker.C:
+			p.replay(ctx, sinks, spill)
+		}
+	}
+}
+
+// dequeue appends the queued events to the batch, up to max events when max is positive.
+func (p *pipeline) dequeue(batch [][]byte, max int) [][]byte {
+	for max <= 0 || len(batch) < max {
+		select {
+		case bs := <-p.queue:
+			batch = append(batch, bs)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// deliver delivers the events to every sink, retrying failed deliveries. Since a failed batch is
+// delivered again to all of the sinks, sinks may receive duplicates.
+func (p *pipeline) deliver(ctx context.Context, sinks []sink, events [][]byte) error {
+	for _, s := range sinks {
+		bo := backoff.NewExponentialBackOff()
+		bo.MaxElapsedTime = p.maxRetryTime
+		err := backoff.Retry(func() error {
+			return s.send(ctx, events)
+		}, backoff.WithContext(bo, ctx))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func spillEvents(ctx context.Context, spill *spillFile, events [][]byte) {
+	if len(events) == 0 {
+		return
+	}
+	if spill == nil {
+		log.Error(ctx).Int("events", len(events)).Msg("audit: dropping undelivered events")
+		return
+	}
+	if err := spill.append(events); err != nil {
+		log.Error(ctx).Err(err).Int("events", len(events)).Msg("audit: error spilling events, dropping them")
+	}
+}
+
+func (p *pipeline) replay(ctx context.Context, sinks []sink, spill *spillFile) {
+	if spill == nil {
+		return
+	}
+	err := spill.replay(func(events [][]byte) error {
+		return p.deliver(ctx, sinks, events)
+	})
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("audit: error delivering spilled events, will retry")
+	}
+}
+
+func newSink(ctx context.Context, opts SinkOptions) (sink, error) {
+	var s sink
+	var err error
+	switch opts.Type {
+	case SinkTypeWebhook:
+		s, err = newWebhookSink(opts)
+	case SinkTypeKafka:
+		s, err = newKafkaSink(opts)
+	case SinkTypeSQS:
+		s, err = newSQSSink(opts)
+	case SinkTypePubSub:
+		s, err = newPubSubSink(ctx, opts)
+	default:
+		return nil, fmt.Errorf("audit: unknown sink type: %s", opts.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Format {
+	case "", FormatJSON:
+		return s, nil
+	case FormatCEF, FormatLEEF, FormatECS:
+		return formatSink{sink: s, format: opts.Format}, nil
+	default:
+		_ = s.close()
+		return nil, fmt.Errorf("audit: unknown format: %s", opts.Format)
+	}
+}
+
+// An errSink is a sink which couldn't be created.
+type errSink struct {
+	err error
+}
+
+func (s errSink) send(_ context.Context, _ [][]byte) error {
+	return backoff.Permanent(s.err)
+}
+
+func (s errSink) close() error { return nil }
@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/pubsub/v1"
+)
+
+// pubSubMaxBatchSize is the max number of messages of a publish request.
+const pubSubMaxBatchSize = 1000
+
+// A pubSubSink publishes each event as a message to a Google Cloud Pub/Sub topic, using the
+// application default credentials.
+type pubSubSink struct {
+	topic string
+	svc   *pubsub.Service
+}
+
+func newPubSubSink(ctx context.Context, opts SinkOptions, clientOptions ...option.ClientOption) (*pubSubSink, error) {
+	parts := strings.Split(opts.Topic, "/")
+	if len(parts) != 4 || parts[0] != "projects" || parts[2] != "topics" {
+		return nil, fmt.Errorf("audit: invalid pub/sub topic %s, expected projects/PROJECT/topics/TOPIC", opts.Topic)
+	}
+	svc, err := pubsub.NewService(ctx, clientOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("audit: error creating pub/sub client: %w", err)
+	}
+	return &pubSubSink{topic: opts.Topic, svc: svc}, nil
+}
+
+func (s *pubSubSink) send(ctx context.Context, events [][]byte) error {
+	for len(events) > 0 {
+		n := pubSubMaxBatchSize
+		if n > len(events) {
+			n = len(events)
+		}
+
+		req := &pubsub.PublishRequest{Messages: make([]*pubsub.PubsubMessage, n)}
+		for i, evt := range events[:n] {
+			req.Messages[i] = &pubsub.PubsubMessage{Data: base64.StdEncoding.EncodeToString(evt)}
+		}
+		_, err := s.svc.Projects.Topics.Publish(s.topic, req).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("audit: error publishing events to pub/sub: %w", err)
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+func (s *pubSubSink) close() error { return nil }
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// A spillFile keeps events which couldn't be delivered, one JSON event per line. Events are
+// moved to a replay file while they're delivered again, so the events spilled in the meantime
+// aren't affected, and the events of a replay which didn't complete aren't lost.
+type spillFile struct {
+	path       string
+	replayPath string
+
+	mu sync.Mutex
+}
+
+func newSpillFile(dir string) (*spillFile, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("audit: error creating spill directory: %w", err)
+	}
+	return &spillFile{
+		path:       filepath.Join(dir, "audit-spill.jsonl"),
+		replayPath: filepath.Join(dir, "audit-spill.replay.jsonl"),
+	}, nil
+}
+
+func (f *spillFile) append(events [][]byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return appendLines(f.path, events)
+}
+
+// replay delivers the spilled events in batches. When a batch can't be delivered, it and the
+// events after it are kept for the next replay.
+func (f *spillFile) replay(deliver func(events [][]byte) error) error {
+	f.mu.Lock()
+	_, err := os.Stat(f.replayPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = os.Rename(f.path, f.replayPath)
+	}
+	f.mu.Unlock()
+	if errors.Is(err, fs.ErrNotExist) {
+		// nothing was spilled
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("audit: error reading spilled events: %w", err)
+	}
+
+	bs, err := os.ReadFile(f.replayPath)
+	if err != nil {
+		return fmt.Errorf("audit: error reading spilled events: %w", err)
+	}
+	var events [][]byte
+	for _, line := range bytes.Split(bs, []byte{'\n'}) {
+		if len(line) > 0 {
+			events = append(events, line)
+		}
+	}
+
+	for len(events) > 0 {
+		n := maxBatchSize
+		if n > len(events) {
+			n = len(events)
+		}
+		if err := deliver(events[:n]); err != nil {
+			if werr := writeLines(f.replayPath, events); werr != nil {
+				return fmt.Errorf("audit: error writing spilled events: %w", werr)
+			}
+			return err
+		}
+		events = events[n:]
+	}
+	return os.Remove(f.replayPath)
+}
+
+func appendLines(path string, lines [][]byte) error {
+	fh, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(fh)
+	for _, line := range lines {
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+	err = w.Flush()
+	if err == nil {
+		err = fh.Sync()
+	}
+	if cerr := fh.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// writeLines replaces the file with the lines.
+func writeLines(path string, lines [][]byte) error {
+	tmp := path + ".tmp"
+	_ = os.Remove(tmp)
+	if err := appendLines(tmp, lines); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
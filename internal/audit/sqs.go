@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/pomerium/pomerium/internal/awsutil"
+)
+
+// sqsMaxBatchSize is the max number of messages of a SendMessageBatch request.
+const sqsMaxBatchSize = 10
+
+type sqsSendMessageBatchResponse struct {
+	Errors []struct {
+		ID      string `xml:"Id"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"SendMessageBatchResult>BatchResultErrorEntry"`
+}
+
+// An sqsSink sends each event as a message to an Amazon SQS queue, with the SendMessageBatch
+// API. The credentials come from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables.
+type sqsSink struct {
+	url    string
+	region string
+	getenv func(string) string
+	client *http.Client
+}
+
+func newSQSSink(opts SinkOptions) (*sqsSink, error) {
+	u, err := url.Parse(opts.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("audit: invalid sqs queue url: %s", opts.URL)
+	}
+	region := opts.Region
+	if region == "" {
+		// like https://sqs.us-east-1.amazonaws.com/123456789012/my-queue
+		if parts := strings.Split(u.Hostname(), "."); len(parts) == 4 && parts[0] == "sqs" {
+			region = parts[1]
+		}
+	}
+	if region == "" {
+		return nil, fmt.Errorf("audit: sqs sink requires a region")
+	}
+	return &sqsSink{
+		url:    opts.URL,
+		region: region,
+		getenv: os.Getenv,
+		client: &http.Client{Timeout: defaultRequestTimeout},
+	}, nil
+}
+
+func (s *sqsSink) send(ctx context.Context, events [][]byte) error {
+	for len(events) > 0 {
+		n := sqsMaxBatchSize
+		if n > len(events) {
+			n = len(events)
+		}
+		if err := s.sendBatch(ctx, events[:n]); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+func (s *sqsSink) sendBatch(ctx context.Context, events [][]byte) error {
+	creds, err := awsutil.CredentialsFromEnv(s.getenv)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("audit: %w", err))
+	}
+
+	form := url.Values{
+		"Action":  {"SendMessageBatch"},
+		"Version": {"2012-11-05"},
+	}
+	for i, evt := range events {
+		prefix := "SendMessageBatchRequestEntry." + strconv.Itoa(i+1) + "."
+		form.Set(prefix+"Id", strconv.Itoa(i))
+		form.Set(prefix+"MessageBody", string(evt))
+	}
+	body := []byte(form.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	awsutil.SignRequest(req, body, s.region, "sqs", creds, time.Now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("audit: error sending events to sqs: %w", err)
+	}
+	defer res.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("audit: error sending events to sqs: unexpected status code %d: %s",
+			res.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var result sqsSendMessageBatchResponse
+	if err := xml.Unmarshal(msg, &result); err != nil {
+		return fmt.Errorf("audit: error decoding sqs response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("audit: error sending %d events to sqs: %s: %s",
+			len(result.Errors), result.Errors[0].Code, result.Errors[0].Message)
+	}
+	return nil
+}
+
+func (s *sqsSink) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
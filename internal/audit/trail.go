@@ -0,0 +1,281 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/events"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+const (
+	// the head record is a copy of the last record, so removing records from the end of the
+	// trail can be detected
+	trailHeadID = "head"
+
+	trailLeaseName         = "audit_trail"
+	trailLeaseTTL          = 30 * time.Second
+	trailLeasePollInterval = 100 * time.Millisecond
+)
+
+// IsPrivileged returns true if events of the type are recorded in the audit trail.
+func IsPrivileged(eventType string) bool {
+	switch eventType {
+	case EventTypeImpersonationRequested,
+		EventTypeImpersonationApproved,
+		EventTypeConfigChanged,
+		EventTypeSessionRevoked,
+		EventTypeDeviceApproved,
+		EventTypeDeviceRevoked:
+		return true
+	}
+	return false
+}
+
+// A Trail appends audit events to a chain of records stored in the databroker. Each record
+// includes the hash of the previous record. Hashes are HMACs keyed with a key derived from the
+// shared secret, so the chain can't be rewritten by anyone who can only write to the databroker.
+type Trail struct {
+	client databroker.DataBrokerServiceClient
+	mu     sync.Mutex
+	key    []byte
+}
+
+// NewTrail creates a new Trail.
+func NewTrail(client databroker.DataBrokerServiceClient) *Trail {
+	return &Trail{client: client}
+}
+
+// UpdateConfig updates the shared secret used to key the hashes of new records.
+func (t *Trail) UpdateConfig(sharedKey []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.key = nil
+	if len(sharedKey) > 0 {
+		t.key = getTrailKey(sharedKey)
+	}
+}
+
+// Append appends an event to the trail. Appends are serialized across instances with a lease.
+func (t *Trail) Append(ctx context.Context, evt *Event) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.key) == 0 {
+		return fmt.Errorf("audit: missing audit trail key")
+	}
+
+	leaseID, err := t.acquireLease(ctx)
+	if err != nil {
+		return fmt.Errorf("audit: error acquiring audit trail lease: %w", err)
+	}
+	defer func() {
+		_, err := t.client.ReleaseLease(ctx, &databroker.ReleaseLeaseRequest{
+			Name: trailLeaseName,
+			Id:   leaseID,
+		})
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("audit: error releasing audit trail lease")
+		}
+	}()
+
+	head := new(events.AuditRecord)
+	res, err := t.client.Get(ctx, &databroker.GetRequest{
+		Type: protoutil.GetTypeURL(head),
+		Id:   trailHeadID,
+	})
+	if err == nil {
+		err = res.GetRecord().GetData().UnmarshalTo(head)
+	}
+	if err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("audit: error getting audit trail head: %w", err)
+	}
+
+	record := &events.AuditRecord{
+		Sequence:     head.GetSequence() + 1,
+		PreviousHash: head.GetHash(),
+		Event:        evt,
+	}
+	record.Hash, err = hashTrailRecord(t.key, record)
+	if err != nil {
+		return err
+	}
+
+	any := protoutil.NewAny(record)
+	_, err = t.client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{
+			{Type: any.GetTypeUrl(), Id: getTrailRecordID(record.GetSequence()), Data: any},
+			{Type: any.GetTypeUrl(), Id: trailHeadID, Data: any},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("audit: error saving audit trail record: %w", err)
+	}
+
+	// the hashes in the logs can be used to check the trail wasn't rewritten
+	log.Info(ctx).
+		Uint64("sequence", record.GetSequence()).
+		Str("hash", hex.EncodeToString(record.GetHash())).
+		Str("type", evt.GetType()).
+		Msg("audit: appended audit trail record")
+	return nil
+}
+
+func (t *Trail) acquireLease(ctx context.Context) (string, error) {
+	for {
+		res, err := t.client.AcquireLease(ctx, &databroker.AcquireLeaseRequest{
+			Name:     trailLeaseName,
+			Duration: durationpb.New(trailLeaseTTL),
+			Holder:   databroker.LeaseHolder(),
+		})
+		if err == nil {
+			return res.GetId(), nil
+		} else if ctx.Err() != nil {
+			return "", ctx.Err()
+		} else if status.Code(err) != codes.AlreadyExists {
+			return "", err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(trailLeasePollInterval):
+		}
+	}
+}
+
+// A TrailReport is the result of verifying an audit trail.
+type TrailReport struct {
+	// Records is the number of records in the trail.
+	Records int `json:"records"`
+	// Hash is the hash of the last record, as hex.
+	Hash string `json:"hash,omitempty"`
+	// Problems are the missing or changed records.
+	Problems []string `json:"problems,omitempty"`
+}
+
+// VerifyTrail verifies the hash chain of the audit trail stored in the databroker. Records are
+// accepted if their hash was computed with any of the shared keys, so the trail can still be
+// verified after the shared secret is rotated.
+func VerifyTrail(ctx context.Context, client databroker.DataBrokerServiceClient, sharedKeys [][]byte) (*TrailReport, error) {
+	records, _, _, err := databroker.InitialSync(ctx, client, &databroker.SyncLatestRequest{
+		Type: protoutil.GetTypeURL(new(events.AuditRecord)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("audit: error listing audit trail records: %w", err)
+	}
+
+	var head *events.AuditRecord
+	var chain []*events.AuditRecord
+	report := new(TrailReport)
+	for _, record := range records {
+		ar := new(events.AuditRecord)
+		if err := record.GetData().UnmarshalTo(ar); err != nil {
+			return nil, fmt.Errorf("audit: error decoding audit trail record %s: %w", record.GetId(), err)
+		}
+		if record.GetId() == trailHeadID {
+			head = ar
+			continue
+		}
+		if record.GetId() != getTrailRecordID(ar.GetSequence()) {
+			report.Problems = append(report.Problems,
+				fmt.Sprintf("record %s has sequence %d", record.GetId(), ar.GetSequence()))
+			continue
+		}
+		chain = append(chain, ar)
+	}
+	keys := make([][]byte, 0, len(sharedKeys))
+	for _, sharedKey := range sharedKeys {
+		keys = append(keys, getTrailKey(sharedKey))
+	}
+	report.Problems = append(report.Problems, verifyTrailChain(keys, chain, head)...)
+	report.Records = len(chain)
+	if len(chain) > 0 {
+		report.Hash = hex.EncodeToString(chain[len(chain)-1].GetHash())
+	}
+	return report, nil
+}
+
+func verifyTrailChain(keys [][]byte, chain []*events.AuditRecord, head *events.AuditRecord) []string {
+	sort.Slice(chain, func(i, j int) bool {
+		return chain[i].GetSequence() < chain[j].GetSequence()
+	})
+
+	var problems []string
+	var previous *events.AuditRecord
+	for _, record := range chain {
+		expected := previous.GetSequence() + 1
+		if record.GetSequence() != expected {
+			problems = append(problems, fmt.Sprintf("records %d to %d are missing", expected, record.GetSequence()-1))
+		} else if !bytes.Equal(record.GetPreviousHash(), previous.GetHash()) {
+			problems = append(problems, fmt.Sprintf("record %d doesn't follow record %d", record.GetSequence(), expected-1))
+		}
+		if !isTrailRecordHash(keys, record) {
+			problems = append(problems, fmt.Sprintf("record %d was changed", record.GetSequence()))
+		}
+		previous = record
+	}
+
+	switch {
+	case head == nil && previous != nil:
+		problems = append(problems, "the head record is missing")
+	case head != nil && head.GetSequence() > previous.GetSequence():
+		problems = append(problems, fmt.Sprintf("records %d to %d are missing", previous.GetSequence()+1, head.GetSequence()))
+	case head != nil && !proto.Equal(head, previous):
+		problems = append(problems, "the head record doesn't match the last record")
+	}
+	return problems
+}
+
+// isTrailRecordHash returns true if the hash of the record was computed with one of the keys.
+func isTrailRecordHash(keys [][]byte, record *events.AuditRecord) bool {
+	for _, key := range keys {
+		if hash, err := hashTrailRecord(key, record); err == nil && hmac.Equal(hash, record.GetHash()) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTrailKey derives the key of the trail hashes from the shared secret, so the shared secret
+// itself is only used for what it's meant for.
+func getTrailKey(sharedKey []byte) []byte {
+	h := hmac.New(sha256.New, sharedKey)
+	h.Write([]byte("pomerium-audit-trail"))
+	return h.Sum(nil)
+}
+
+// hashTrailRecord returns the HMAC of the previous hash, the sequence and the event of a record.
+func hashTrailRecord(key []byte, record *events.AuditRecord) ([]byte, error) {
+	bs, err := proto.MarshalOptions{Deterministic: true}.Marshal(record.GetEvent())
+	if err != nil {
+		return nil, fmt.Errorf("audit: error encoding audit trail event: %w", err)
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write(record.GetPreviousHash())
+	_ = binary.Write(h, binary.BigEndian, record.GetSequence())
+	h.Write(bs)
+	return h.Sum(nil), nil
+}
+
+// records are sorted by sequence when sorted by id
+func getTrailRecordID(sequence uint64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
@@ -0,0 +1,137 @@
+package audit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/audit"
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/events"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func TestTrail(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	li := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(srv, internal_databroker.New())
+	go func() { _ = srv.Serve(li) }()
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return li.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := databroker.NewDataBrokerServiceClient(cc)
+
+	sharedKey := cryptutil.NewKey()
+	sharedKeys := [][]byte{sharedKey}
+
+	report, err := audit.VerifyTrail(ctx, client, sharedKeys)
+	require.NoError(t, err)
+	assert.Equal(t, &audit.TrailReport{}, report, "an empty trail should be valid")
+
+	trail := audit.NewTrail(client)
+	assert.Error(t, trail.Append(ctx, &audit.Event{Id: "e0"}), "should require a key")
+	trail.UpdateConfig(sharedKey)
+	for _, id := range []string{"e1", "e2", "e3", "e4"} {
+		require.NoError(t, trail.Append(ctx, &audit.Event{
+			Id:   id,
+			Type: audit.EventTypeSessionRevoked,
+			Time: timestamppb.Now(),
+		}))
+	}
+
+	report, err = audit.VerifyTrail(ctx, client, sharedKeys)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Records)
+	assert.NotEmpty(t, report.Hash)
+	assert.Empty(t, report.Problems)
+
+	report, err = audit.VerifyTrail(ctx, client, [][]byte{cryptutil.NewKey()})
+	require.NoError(t, err)
+	assert.Len(t, report.Problems, 4, "should reject hashes computed with another key")
+
+	t.Run("rotated", func(t *testing.T) {
+		newSharedKey := cryptutil.NewKey()
+		trail.UpdateConfig(newSharedKey)
+		require.NoError(t, trail.Append(ctx, &audit.Event{
+			Id:   "e5",
+			Type: audit.EventTypeSessionRevoked,
+			Time: timestamppb.Now(),
+		}))
+		trail.UpdateConfig(sharedKey)
+
+		report, err := audit.VerifyTrail(ctx, client, [][]byte{newSharedKey, sharedKey})
+		require.NoError(t, err)
+		assert.Equal(t, 5, report.Records)
+		assert.Empty(t, report.Problems, "should accept hashes computed with previous keys")
+
+		report, err = audit.VerifyTrail(ctx, client, sharedKeys)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"record 5 was changed"}, report.Problems)
+
+		sharedKeys = [][]byte{newSharedKey, sharedKey}
+	})
+
+	getRecord := func(id string) *events.AuditRecord {
+		res, err := client.Get(ctx, &databroker.GetRequest{
+			Type: protoutil.GetTypeURL(new(events.AuditRecord)),
+			Id:   id,
+		})
+		require.NoError(t, err)
+		record := new(events.AuditRecord)
+		require.NoError(t, res.GetRecord().GetData().UnmarshalTo(record))
+		return record
+	}
+	putRecord := func(id string, record *events.AuditRecord, deleted bool) {
+		any := protoutil.NewAny(record)
+		r := &databroker.Record{Type: any.GetTypeUrl(), Id: id, Data: any}
+		if deleted {
+			r.DeletedAt = timestamppb.Now()
+		}
+		_, err := client.Put(ctx, &databroker.PutRequest{Records: []*databroker.Record{r}})
+		require.NoError(t, err)
+	}
+
+	t.Run("changed", func(t *testing.T) {
+		record := getRecord("00000000000000000002")
+		record.Event.Type = audit.EventTypeLogin
+		putRecord("00000000000000000002", record, false)
+
+		report, err := audit.VerifyTrail(ctx, client, sharedKeys)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"record 2 was changed"}, report.Problems)
+	})
+	t.Run("missing", func(t *testing.T) {
+		putRecord("00000000000000000003", new(events.AuditRecord), true)
+
+		report, err := audit.VerifyTrail(ctx, client, sharedKeys)
+		require.NoError(t, err)
+		assert.Equal(t, 4, report.Records)
+		assert.Contains(t, report.Problems, "records 3 to 3 are missing")
+	})
+	t.Run("truncated", func(t *testing.T) {
+		putRecord("00000000000000000004", new(events.AuditRecord), true)
+		putRecord("00000000000000000005", new(events.AuditRecord), true)
+
+		report, err := audit.VerifyTrail(ctx, client, sharedKeys)
+		require.NoError(t, err)
+		assert.Contains(t, report.Problems, "records 3 to 5 are missing")
+	})
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// A webhookSink posts batches of events to a URL as a JSON array, or, for the cef and leef
+// formats, as lines of text.
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	text    bool
+	client  *http.Client
+}
+
+func newWebhookSink(opts SinkOptions) (*webhookSink, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("audit: webhook sink requires a url")
+	}
+	return &webhookSink{
+		url:     opts.URL,
+		headers: opts.Headers,
+		text:    opts.Format == FormatCEF || opts.Format == FormatLEEF,
+		client:  &http.Client{Timeout: defaultRequestTimeout},
+	}, nil
+}
+
+func (s *webhookSink) send(ctx context.Context, events [][]byte) error {
+	body, contentType := append(append([]byte{'['}, bytes.Join(events, []byte{','})...), ']'), "application/json"
+	if s.text {
+		body, contentType = append(bytes.Join(events, []byte{'\n'}), '\n'), "text/plain; charset=utf-8"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("audit: error posting events to webhook: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("audit: webhook returned unexpected status code: %d", res.StatusCode)
+	}
+	return nil
+}
+
+func (s *webhookSink) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,93 @@
+package autocert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/certmagic"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+// defaultDNSRecordTTL is the TTL of challenge records when certmagic doesn't set one.
+const defaultDNSRecordTTL = 2 * time.Minute
+
+// dnsProviderOptions are the options supported by each DNS provider.
+var dnsProviderOptions = map[string][]string{
+	config.AutocertDNSProviderCloudflare:     {"api_token"},
+	config.AutocertDNSProviderGoogleCloudDNS: {"project", "service_account"},
+	config.AutocertDNSProviderRoute53:        {"access_key_id", "secret_access_key", "session_token", "hosted_zone_id"},
+}
+
+// newDNSProvider creates the DNS provider used to solve DNS-01 challenges. Credentials which
+// aren't set in the options are read from the environment variables of the provider's CLI.
+func newDNSProvider(ctx context.Context, opts config.AutocertOptions) (certmagic.ACMEDNSProvider, error) {
+	supported, ok := dnsProviderOptions[opts.DNSProvider]
+	if !ok {
+		return nil, fmt.Errorf("autocert: unsupported dns provider: %s", opts.DNSProvider)
+	}
+	for k := range opts.DNSProviderOptions {
+		if !containsString(supported, k) {
+			return nil, fmt.Errorf("autocert: unsupported %s dns provider option: %s, supported options are %s",
+				opts.DNSProvider, k, strings.Join(supported, ", "))
+		}
+	}
+
+	get := func(key, env string) string {
+		if v := opts.DNSProviderOptions[key]; v != "" {
+			return v
+		}
+		return os.Getenv(env)
+	}
+
+	switch opts.DNSProvider {
+	case config.AutocertDNSProviderCloudflare:
+		return newCloudflareDNSProvider(get("api_token", "CLOUDFLARE_API_TOKEN"), http.DefaultClient)
+	case config.AutocertDNSProviderGoogleCloudDNS:
+		return newGoogleCloudDNSProvider(ctx, get("project", "GOOGLE_CLOUD_PROJECT"), opts.DNSProviderOptions["service_account"])
+	default:
+		return newRoute53DNSProvider(
+			get("access_key_id", "AWS_ACCESS_KEY_ID"),
+			get("secret_access_key", "AWS_SECRET_ACCESS_KEY"),
+			get("session_token", "AWS_SESSION_TOKEN"),
+			opts.DNSProviderOptions["hosted_zone_id"],
+			http.DefaultClient,
+		)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getRecordFQDN returns the fully qualified name of a record relative to the zone.
+func getRecordFQDN(name, zone string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	if name == "" || name == "@" {
+		return zone
+	}
+	return strings.TrimSuffix(name, ".") + "." + zone
+}
+
+// getRecordTTL returns the TTL of a record in seconds.
+func getRecordTTL(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		ttl = defaultDNSRecordTTL
+	}
+	return int64(ttl / time.Second)
+}
+
+// quoteTXT quotes the value of a TXT record, for the APIs which expect the record data.
+func quoteTXT(value string) string {
+	return strconv.Quote(value)
+}
@@ -0,0 +1,166 @@
+package autocert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/libdns/libdns"
+)
+
+const cloudflareEndpoint = "https://api.cloudflare.com/client/v4"
+
+// cloudflareDNSProvider manages the records of DNS-01 challenges with the Cloudflare API.
+type cloudflareDNSProvider struct {
+	apiToken   string
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newCloudflareDNSProvider(apiToken string, httpClient *http.Client) (*cloudflareDNSProvider, error) {
+	if apiToken == "" {
+		return nil, errors.New("autocert: the cloudflare dns provider requires an api_token")
+	}
+	return &cloudflareDNSProvider{
+		apiToken:   apiToken,
+		endpoint:   cloudflareEndpoint,
+		httpClient: httpClient,
+	}, nil
+}
+
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result json.RawMessage `json:"result"`
+}
+
+type cloudflareRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int64  `json:"ttl,omitempty"`
+}
+
+// AppendRecords creates the records in the zone.
+func (p *cloudflareDNSProvider) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	zoneID, err := p.getZoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []libdns.Record
+	for _, rec := range recs {
+		var result cloudflareRecord
+		err := p.do(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", cloudflareRecord{
+			Type:    rec.Type,
+			Name:    getRecordFQDN(rec.Name, zone),
+			Content: rec.Value,
+			TTL:     getRecordTTL(rec.TTL),
+		}, &result)
+		if err != nil {
+			return created, err
+		}
+		rec.ID = result.ID
+		created = append(created, rec)
+	}
+	return created, nil
+}
+
+// DeleteRecords deletes the records from the zone.
+func (p *cloudflareDNSProvider) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	zoneID, err := p.getZoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []libdns.Record
+	for _, rec := range recs {
+		id := rec.ID
+		if id == "" {
+			query := url.Values{
+				"type":    {rec.Type},
+				"name":    {getRecordFQDN(rec.Name, zone)},
+				"content": {rec.Value},
+			}
+			var results []cloudflareRecord
+			if err := p.do(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records?"+query.Encode(), nil, &results); err != nil {
+				return deleted, err
+			}
+			if len(results) == 0 {
+				continue
+			}
+			id = results[0].ID
+		}
+
+		if err := p.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+id, nil, nil); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, rec)
+	}
+	return deleted, nil
+}
+
+func (p *cloudflareDNSProvider) getZoneID(ctx context.Context, zone string) (string, error) {
+	var zones []struct {
+		ID string `json:"id"`
+	}
+	query := url.Values{"name": {strings.TrimSuffix(zone, ".")}}
+	if err := p.do(ctx, http.MethodGet, "/zones?"+query.Encode(), nil, &zones); err != nil {
+		return "", err
+	}
+	if len(zones) == 0 {
+		return "", fmt.Errorf("cloudflare: zone %s not found", zone)
+	}
+	return zones[0].ID, nil
+}
+
+func (p *cloudflareDNSProvider) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		bs, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloudflare: %w", err)
+	}
+	defer res.Body.Close()
+
+	var cfres cloudflareResponse
+	if err := json.NewDecoder(res.Body).Decode(&cfres); err != nil {
+		return fmt.Errorf("cloudflare: unexpected response with status code %d: %w", res.StatusCode, err)
+	}
+	if !cfres.Success {
+		var msgs []string
+		for _, e := range cfres.Errors {
+			msgs = append(msgs, fmt.Sprintf("%d: %s", e.Code, e.Message))
+		}
+		return fmt.Errorf("cloudflare: %s request failed with status code %d: %s",
+			method, res.StatusCode, strings.Join(msgs, ", "))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(cfres.Result, out)
+}
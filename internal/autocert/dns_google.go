@@ -0,0 +1,131 @@
+package autocert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/libdns/libdns"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+)
+
+// googleCloudDNSProvider manages the records of DNS-01 challenges with the Google Cloud DNS API.
+type googleCloudDNSProvider struct {
+	project string
+	svc     *dns.Service
+}
+
+// newGoogleCloudDNSProvider creates a Google Cloud DNS provider. The application default
+// credentials are used unless the JSON key of a service account is given.
+func newGoogleCloudDNSProvider(ctx context.Context, project, serviceAccount string, opts ...option.ClientOption) (*googleCloudDNSProvider, error) {
+	if project == "" {
+		return nil, errors.New("autocert: the google_cloud_dns dns provider requires a project")
+	}
+	if serviceAccount != "" {
+		opts = append(opts, option.WithCredentialsJSON([]byte(serviceAccount)))
+	}
+	svc, err := dns.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("autocert: error creating google cloud dns client: %w", err)
+	}
+	return &googleCloudDNSProvider{project: project, svc: svc}, nil
+}
+
+// AppendRecords adds the records to the record sets of the zone.
+func (p *googleCloudDNSProvider) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	err := p.changeRecords(ctx, zone, recs, func(rrdatas []string, value string) []string {
+		for _, v := range rrdatas {
+			if v == value {
+				return rrdatas
+			}
+		}
+		return append(rrdatas, value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// DeleteRecords removes the records from the record sets of the zone.
+func (p *googleCloudDNSProvider) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	err := p.changeRecords(ctx, zone, recs, func(rrdatas []string, value string) []string {
+		var remaining []string
+		for _, v := range rrdatas {
+			if v != value {
+				remaining = append(remaining, v)
+			}
+		}
+		return remaining
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// changeRecords replaces the record set of each record with the record set returned by update.
+// Google Cloud DNS has a single record set for each name and type, so records with the same
+// name, like the challenges of example.com and *.example.com, share a record set.
+func (p *googleCloudDNSProvider) changeRecords(
+	ctx context.Context,
+	zone string,
+	recs []libdns.Record,
+	update func(rrdatas []string, value string) []string,
+) error {
+	managedZone, err := p.getManagedZone(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recs {
+		name := getRecordFQDN(rec.Name, zone) + "."
+		value := rec.Value
+		if rec.Type == "TXT" {
+			value = quoteTXT(value)
+		}
+
+		existing, err := p.svc.ResourceRecordSets.List(p.project, managedZone).
+			Name(name).Type(rec.Type).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("google cloud dns: error listing record sets: %w", err)
+		}
+
+		change := &dns.Change{}
+		var rrdatas []string
+		for _, rrset := range existing.Rrsets {
+			change.Deletions = append(change.Deletions, rrset)
+			rrdatas = append(rrdatas, rrset.Rrdatas...)
+		}
+		if rrdatas = update(rrdatas, value); len(rrdatas) > 0 {
+			change.Additions = append(change.Additions, &dns.ResourceRecordSet{
+				Name:    name,
+				Type:    rec.Type,
+				Ttl:     getRecordTTL(rec.TTL),
+				Rrdatas: rrdatas,
+			})
+		}
+		if len(change.Additions) == 0 && len(change.Deletions) == 0 {
+			continue
+		}
+
+		if _, err := p.svc.Changes.Create(p.project, managedZone, change).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("google cloud dns: error changing record set %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (p *googleCloudDNSProvider) getManagedZone(ctx context.Context, zone string) (string, error) {
+	zone = strings.TrimSuffix(zone, ".") + "."
+	res, err := p.svc.ManagedZones.List(p.project).DnsName(zone).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("google cloud dns: error listing managed zones: %w", err)
+	}
+	if len(res.ManagedZones) == 0 {
+		return "", fmt.Errorf("google cloud dns: managed zone %s not found", zone)
+	}
+	return res.ManagedZones[0].Name, nil
+}
@@ -0,0 +1,159 @@
+package autocert
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+
+	"github.com/pomerium/pomerium/internal/awsutil"
+)
+
+const (
+	route53Endpoint = "https://route53.amazonaws.com"
+	// route53 is a global service, its requests are signed for us-east-1
+	route53SigningRegion = "us-east-1"
+)
+
+// route53DNSProvider manages the records of DNS-01 challenges with the AWS Route 53 API.
+type route53DNSProvider struct {
+	creds        awsutil.Credentials
+	hostedZoneID string
+	endpoint     string
+	httpClient   *http.Client
+}
+
+func newRoute53DNSProvider(accessKeyID, secretAccessKey, sessionToken, hostedZoneID string, httpClient *http.Client) (*route53DNSProvider, error) {
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, errors.New("autocert: the route53 dns provider requires an access_key_id and secret_access_key")
+	}
+	return &route53DNSProvider{
+		creds: awsutil.Credentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		},
+		hostedZoneID: strings.TrimPrefix(hostedZoneID, "/hostedzone/"),
+		endpoint:     route53Endpoint,
+		httpClient:   httpClient,
+	}, nil
+}
+
+type route53ChangeResourceRecordSetsRequest struct {
+	XMLName xml.Name        `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+type route53Change struct {
+	Action string   `xml:"Action"`
+	Name   string   `xml:"ResourceRecordSet>Name"`
+	Type   string   `xml:"ResourceRecordSet>Type"`
+	TTL    int64    `xml:"ResourceRecordSet>TTL"`
+	Values []string `xml:"ResourceRecordSet>ResourceRecords>ResourceRecord>Value"`
+}
+
+type route53ListHostedZonesByNameResponse struct {
+	HostedZones []struct {
+		ID   string `xml:"Id"`
+		Name string `xml:"Name"`
+	} `xml:"HostedZones>HostedZone"`
+}
+
+// AppendRecords creates the records in the zone. Existing records with the same name and type
+// are replaced, since route53 has a single record set for each name and type.
+func (p *route53DNSProvider) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	if err := p.changeRecords(ctx, "UPSERT", zone, recs); err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// DeleteRecords deletes the records from the zone.
+func (p *route53DNSProvider) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	if err := p.changeRecords(ctx, "DELETE", zone, recs); err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+func (p *route53DNSProvider) changeRecords(ctx context.Context, action, zone string, recs []libdns.Record) error {
+	zoneID, err := p.getHostedZoneID(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	req := route53ChangeResourceRecordSetsRequest{}
+	for _, rec := range recs {
+		value := rec.Value
+		if rec.Type == "TXT" {
+			value = quoteTXT(value)
+		}
+		req.Changes = append(req.Changes, route53Change{
+			Action: action,
+			Name:   getRecordFQDN(rec.Name, zone) + ".",
+			Type:   rec.Type,
+			TTL:    getRecordTTL(rec.TTL),
+			Values: []string{value},
+		})
+	}
+
+	body, err := xml.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return p.do(ctx, http.MethodPost, "/2013-04-01/hostedzone/"+url.PathEscape(zoneID)+"/rrset", body, nil)
+}
+
+func (p *route53DNSProvider) getHostedZoneID(ctx context.Context, zone string) (string, error) {
+	if p.hostedZoneID != "" {
+		return p.hostedZoneID, nil
+	}
+
+	zone = strings.TrimSuffix(zone, ".") + "."
+	query := url.Values{"dnsname": {zone}, "maxitems": {"1"}}
+	var res route53ListHostedZonesByNameResponse
+	if err := p.do(ctx, http.MethodGet, "/2013-04-01/hostedzonesbyname?"+query.Encode(), nil, &res); err != nil {
+		return "", err
+	}
+	for _, hz := range res.HostedZones {
+		if strings.EqualFold(hz.Name, zone) {
+			return strings.TrimPrefix(hz.ID, "/hostedzone/"), nil
+		}
+	}
+	return "", fmt.Errorf("route53: hosted zone %s not found", zone)
+}
+
+func (p *route53DNSProvider) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	awsutil.SignRequest(req, body, route53SigningRegion, "route53", p.creds, time.Now())
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("route53: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("route53: %s request failed with status code %d: %s",
+			method, res.StatusCode, bytes.TrimSpace(msg))
+	}
+	if out == nil {
+		return nil
+	}
+	return xml.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,213 @@
+package autocert
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libdns/libdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestNewDNSProvider(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := newDNSProvider(ctx, config.AutocertOptions{
+		DNSProvider:        config.AutocertDNSProviderCloudflare,
+		DNSProviderOptions: map[string]string{"api_token": "TOKEN"},
+	})
+	assert.NoError(t, err)
+
+	_, err = newDNSProvider(ctx, config.AutocertOptions{
+		DNSProvider:        config.AutocertDNSProviderCloudflare,
+		DNSProviderOptions: map[string]string{"api_key": "TOKEN"},
+	})
+	assert.Error(t, err, "unsupported options should be rejected")
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	_, err = newDNSProvider(ctx, config.AutocertOptions{
+		DNSProvider: config.AutocertDNSProviderRoute53,
+	})
+	assert.Error(t, err, "missing credentials should be rejected")
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	_, err = newDNSProvider(ctx, config.AutocertOptions{
+		DNSProvider: config.AutocertDNSProviderRoute53,
+	})
+	assert.NoError(t, err, "credentials should be read from the environment")
+}
+
+func TestCloudflareDNSProvider(t *testing.T) {
+	var records []cloudflareRecord
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer TOKEN" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`)
+			return
+		}
+
+		var result interface{}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/zones":
+			assert.Equal(t, "example.com", r.URL.Query().Get("name"))
+			result = []M{{"id": "ZONE"}}
+		case r.Method == http.MethodPost && r.URL.Path == "/zones/ZONE/dns_records":
+			var rec cloudflareRecord
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&rec))
+			rec.ID = "RECORD"
+			records = append(records, rec)
+			result = rec
+		case r.Method == http.MethodDelete && r.URL.Path == "/zones/ZONE/dns_records/RECORD":
+			records = nil
+			result = M{"id": "RECORD"}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"success":false,"errors":[{"code":7003,"message":"not found"}]}`)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(M{"success": true, "result": result})
+	}))
+	defer srv.Close()
+
+	p, err := newCloudflareDNSProvider("TOKEN", http.DefaultClient)
+	require.NoError(t, err)
+	p.endpoint = srv.URL
+	ctx := context.Background()
+
+	recs, err := p.AppendRecords(ctx, "example.com.", []libdns.Record{
+		{Type: "TXT", Name: "_acme-challenge.app", Value: "CHALLENGE"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "RECORD", recs[0].ID)
+	assert.Equal(t, []cloudflareRecord{
+		{ID: "RECORD", Type: "TXT", Name: "_acme-challenge.app.example.com", Content: "CHALLENGE", TTL: 120},
+	}, records)
+
+	_, err = p.DeleteRecords(ctx, "example.com.", recs)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	p.apiToken = "INVALID"
+	_, err = p.AppendRecords(ctx, "example.com.", recs)
+	assert.ErrorContains(t, err, "Authentication error")
+}
+
+func TestRoute53DNSProvider(t *testing.T) {
+	var changes []route53Change
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/route53/aws4_request")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/2013-04-01/hostedzonesbyname":
+			_, _ = io.WriteString(w, `<ListHostedZonesByNameResponse><HostedZones>`+
+				`<HostedZone><Id>/hostedzone/Z123</Id><Name>example.com.</Name></HostedZone>`+
+				`</HostedZones></ListHostedZonesByNameResponse>`)
+		case r.Method == http.MethodPost && r.URL.Path == "/2013-04-01/hostedzone/Z123/rrset":
+			var req route53ChangeResourceRecordSetsRequest
+			require.NoError(t, xml.NewDecoder(r.Body).Decode(&req))
+			changes = append(changes, req.Changes...)
+			_, _ = io.WriteString(w, `<ChangeResourceRecordSetsResponse/>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := newRoute53DNSProvider("AKID", "SECRET", "", "", http.DefaultClient)
+	require.NoError(t, err)
+	p.endpoint = srv.URL
+	ctx := context.Background()
+
+	recs := []libdns.Record{{Type: "TXT", Name: "_acme-challenge", Value: "CHALLENGE", TTL: time.Minute}}
+	_, err = p.AppendRecords(ctx, "example.com.", recs)
+	require.NoError(t, err)
+	_, err = p.DeleteRecords(ctx, "example.com.", recs)
+	require.NoError(t, err)
+	assert.Equal(t, []route53Change{
+		{Action: "UPSERT", Name: "_acme-challenge.example.com.", Type: "TXT", TTL: 60, Values: []string{`"CHALLENGE"`}},
+		{Action: "DELETE", Name: "_acme-challenge.example.com.", Type: "TXT", TTL: 60, Values: []string{`"CHALLENGE"`}},
+	}, changes)
+
+	_, err = p.AppendRecords(ctx, "other.com.", recs)
+	assert.Error(t, err, "unknown zones should return an error")
+}
+
+func TestGoogleCloudDNSProvider(t *testing.T) {
+	rrdatas := []string{`"EXISTING"`}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/dns/v1/projects/PROJECT/managedZones":
+			assert.Equal(t, "example.com.", r.URL.Query().Get("dnsName"))
+			_ = json.NewEncoder(w).Encode(M{"managedZones": []M{{"name": "example-com"}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/dns/v1/projects/PROJECT/managedZones/example-com/rrsets":
+			var rrsets []M
+			if len(rrdatas) > 0 {
+				rrsets = append(rrsets, M{"name": r.URL.Query().Get("name"), "type": "TXT", "ttl": 120, "rrdatas": rrdatas})
+			}
+			_ = json.NewEncoder(w).Encode(M{"rrsets": rrsets})
+		case r.Method == http.MethodPost && r.URL.Path == "/dns/v1/projects/PROJECT/managedZones/example-com/changes":
+			var change struct {
+				Additions []struct {
+					Rrdatas []string `json:"rrdatas"`
+				} `json:"additions"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+			rrdatas = nil
+			for _, a := range change.Additions {
+				rrdatas = append(rrdatas, a.Rrdatas...)
+			}
+			_ = json.NewEncoder(w).Encode(M{"status": "pending"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	p, err := newGoogleCloudDNSProvider(ctx, "PROJECT", "",
+		option.WithEndpoint(srv.URL+"/"), option.WithHTTPClient(http.DefaultClient))
+	require.NoError(t, err)
+
+	recs := []libdns.Record{{Type: "TXT", Name: "_acme-challenge", Value: "CHALLENGE"}}
+	_, err = p.AppendRecords(ctx, "example.com.", recs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`"EXISTING"`, `"CHALLENGE"`}, rrdatas, "existing records should be kept")
+
+	_, err = p.DeleteRecords(ctx, "example.com.", recs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`"EXISTING"`}, rrdatas)
+}
+
+func Test_sourceHostnames(t *testing.T) {
+	cfg := &config.Config{Options: config.NewDefaultOptions()}
+	cfg.Options.AuthenticateURLString = ""
+	for _, from := range []string{"https://b.example.com", "https://*.apps.example.com", "https://a.example.com"} {
+		p := config.Policy{From: from, To: mustParseWeightedURLs(t, "https://to.example.com")}
+		require.NoError(t, p.Validate())
+		cfg.Options.Policies = append(cfg.Options.Policies, p)
+	}
+
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, sourceHostnames(cfg),
+		"wildcard hosts require the dns challenge")
+
+	cfg.Options.AutocertOptions.DNSProvider = config.AutocertDNSProviderCloudflare
+	assert.Equal(t, []string{"*.apps.example.com", "a.example.com", "b.example.com"}, sourceHostnames(cfg))
+}
+
+func mustParseWeightedURLs(t *testing.T, urls ...string) config.WeightedURLs {
+	wu, err := config.ParseWeightedUrls(urls...)
+	require.NoError(t, err)
+	return wu
+}
@@ -13,15 +13,19 @@ import (
 	"time"
 
 	"github.com/caddyserver/certmagic"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/mholt/acmez/acme"
 	"github.com/rs/zerolog"
 	"go.uber.org/zap"
+	googlegrpc "google.golang.org/grpc"
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 )
 
 var (
@@ -36,6 +40,8 @@ const (
 	ocspRespCacheSize = 50000
 	renewalInterval   = time.Minute * 10
 	renewalTimeout    = time.Hour
+
+	dataBrokerReadyTimeout = 10 * time.Second
 )
 
 // Manager manages TLS certificates.
@@ -49,6 +55,10 @@ type Manager struct {
 	acmeMgr   atomic.Value
 	srv       *http.Server
 
+	dataBrokerStorage      *dataBrokerStorage
+	outboundGRPCConnection *grpc.CachedOutboundGRPClientConn
+	cancelObtain           context.CancelFunc
+
 	*ocspCache
 
 	config.ChangeDispatcher
@@ -73,23 +83,24 @@ func newManager(ctx context.Context,
 		return nil, err
 	}
 
+	mgr := &Manager{
+		src:                    src,
+		ocspCache:              ocspRespCache,
+		outboundGRPCConnection: new(grpc.CachedOutboundGRPClientConn),
+	}
+	mgr.dataBrokerStorage = newDataBrokerStorage(mgr.getDataBrokerClient)
+
 	certmagicConfig := certmagic.NewDefault()
 	// set certmagic default storage cache, otherwise cert renewal loop will be based off
 	// certmagic's own default location
-	certmagicConfig.Storage = &certmagic.FileStorage{
-		Path: src.GetConfig().Options.AutocertOptions.Folder,
-	}
+	certmagicConfig.Storage = mgr.getStorage(src.GetConfig().Options.AutocertOptions)
 
 	logger := log.ZapLogger().With(zap.String("service", "autocert"))
 	certmagicConfig.Logger = logger
 	acmeTemplate.Logger = logger
 
-	mgr := &Manager{
-		src:          src,
-		acmeTemplate: acmeTemplate,
-		certmagic:    certmagicConfig,
-		ocspCache:    ocspRespCache,
-	}
+	mgr.acmeTemplate = acmeTemplate
+	mgr.certmagic = certmagicConfig
 	err = mgr.update(ctx, src.GetConfig())
 	if err != nil {
 		return nil, err
@@ -127,7 +138,7 @@ func newManager(ctx context.Context,
 func (mgr *Manager) getCertMagicConfig(ctx context.Context, cfg *config.Config) (*certmagic.Config, error) {
 	mgr.certmagic.MustStaple = cfg.Options.AutocertOptions.MustStaple
 	mgr.certmagic.OnDemand = nil // disable on-demand
-	mgr.certmagic.Storage = &certmagic.FileStorage{Path: cfg.Options.AutocertOptions.Folder}
+	mgr.certmagic.Storage = mgr.getStorage(cfg.Options.AutocertOptions)
 	certs, err := cfg.AllCertificates()
 	if err != nil {
 		return nil, err
@@ -151,6 +162,10 @@ func (mgr *Manager) getCertMagicConfig(ctx context.Context, cfg *config.Config)
 	if err != nil {
 		return nil, err
 	}
+	err = configureDNSChallenge(ctx, acmeMgr, cfg.Options.AutocertOptions)
+	if err != nil {
+		return nil, err
+	}
 	acmeMgr.DisableTLSALPNChallenge = true
 	mgr.certmagic.Issuers = []certmagic.Issuer{acmeMgr}
 	mgr.acmeMgr.Store(acmeMgr)
@@ -223,9 +238,107 @@ func (mgr *Manager) update(ctx context.Context, cfg *config.Config) error {
 	defer func() { mgr.config = cfg }()
 
 	mgr.updateServer(ctx, cfg)
+
+	if mgr.cancelObtain != nil {
+		mgr.cancelObtain()
+		mgr.cancelObtain = nil
+	}
+	if cfg.Options.AutocertOptions.Enable && cfg.Options.AutocertOptions.UseDataBrokerStorage() {
+		// the databroker may not be running yet, so keep the current certificates until the
+		// certificates are obtained in the background
+		if mgr.config != nil {
+			cfg.AutoCertificates = mgr.config.AutoCertificates
+		}
+		mgr.obtainInBackground()
+		return nil
+	}
+
 	return mgr.updateAutocert(ctx, cfg)
 }
 
+// obtainInBackground obtains the certificates once the databroker is ready, retrying on errors,
+// until the config changes again.
+func (mgr *Manager) obtainInBackground() {
+	ctx, cancel := context.WithCancel(log.WithContext(context.Background(), func(c zerolog.Context) zerolog.Context {
+		return c.Str("service", "autocert-manager")
+	}))
+	mgr.cancelObtain = cancel
+
+	go func() {
+		bo := backoff.NewExponentialBackOff()
+		bo.MaxElapsedTime = 0
+		for {
+			err := mgr.obtainFromDataBroker(ctx)
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+			log.Error(ctx).Err(err).Msg("autocert: error obtaining certificates, retrying")
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(bo.NextBackOff()):
+			}
+		}
+	}()
+}
+
+func (mgr *Manager) obtainFromDataBroker(ctx context.Context) error {
+	cc, err := mgr.getDataBrokerConn(ctx)
+	if err != nil {
+		return err
+	}
+	if err := grpc.WaitForReady(ctx, cc, dataBrokerReadyTimeout); err != nil {
+		return err
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	// the config changed while waiting for the lock
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	cfg := mgr.src.GetConfig().Clone()
+	if err := mgr.updateAutocert(ctx, cfg); err != nil {
+		return err
+	}
+
+	mgr.config = cfg
+	mgr.Trigger(ctx, cfg)
+	return nil
+}
+
+func (mgr *Manager) getStorage(opts config.AutocertOptions) certmagic.Storage {
+	if opts.UseDataBrokerStorage() {
+		return mgr.dataBrokerStorage
+	}
+	return &certmagic.FileStorage{Path: opts.Folder}
+}
+
+func (mgr *Manager) getDataBrokerConn(ctx context.Context) (*googlegrpc.ClientConn, error) {
+	cfg := mgr.src.GetConfig()
+	sharedKey, err := cfg.Options.GetSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	return mgr.outboundGRPCConnection.Get(ctx, &grpc.OutboundOptions{
+		OutboundPort:   cfg.OutboundPort,
+		InstallationID: cfg.Options.InstallationID,
+		ServiceName:    cfg.Options.Services,
+		SignedJWTKey:   sharedKey,
+	})
+}
+
+func (mgr *Manager) getDataBrokerClient(ctx context.Context) (databroker.DataBrokerServiceClient, error) {
+	cc, err := mgr.getDataBrokerConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return databroker.NewDataBrokerServiceClient(cc), nil
+}
+
 // obtainCert obtains a certificate for given domain, use cached manager if cert exists there.
 func (mgr *Manager) obtainCert(ctx context.Context, domain string, cm *certmagic.Config) (certmagic.Certificate, error) {
 	cert, err := cm.CacheManagedCertificate(ctx, domain)
@@ -400,6 +513,20 @@ func configureTrustedRoots(acmeMgr *certmagic.ACMEIssuer, opts config.AutocertOp
 	return nil
 }
 
+// configureDNSChallenge configures the acmeMgr to solve DNS-01 challenges with the DNS provider,
+// instead of HTTP-01 challenges.
+func configureDNSChallenge(ctx context.Context, acmeMgr *certmagic.ACMEIssuer, opts config.AutocertOptions) error {
+	if opts.DNSProvider == "" {
+		return nil
+	}
+	provider, err := newDNSProvider(ctx, opts)
+	if err != nil {
+		return err
+	}
+	acmeMgr.DNS01Solver = &certmagic.DNS01Solver{DNSProvider: provider}
+	return nil
+}
+
 func sourceHostnames(cfg *config.Config) []string {
 	policies := cfg.Options.GetAllPolicies()
 
@@ -409,6 +536,10 @@ func sourceHostnames(cfg *config.Config) []string {
 
 	dedupe := map[string]struct{}{}
 	for _, p := range policies {
+		// wildcard certificates can only be issued with the dns challenge
+		if config.IsWildcardHost(p.Source.Hostname()) && cfg.Options.AutocertOptions.DNSProvider == "" {
+			continue
+		}
 		dedupe[p.Source.Hostname()] = struct{}{}
 	}
 	if cfg.Options.AuthenticateURLString != "" {
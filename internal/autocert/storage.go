@@ -0,0 +1,295 @@
+package autocert
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caddyserver/certmagic"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// dataBrokerStorageRecordType is the type of the databroker records holding the certificates,
+// account keys and challenges shared by the autocert managers.
+const dataBrokerStorageRecordType = "pomerium.io/AutocertStorage"
+
+const (
+	dataBrokerStorageLockTTL          = 2 * time.Minute
+	dataBrokerStorageLockPollInterval = time.Second
+)
+
+// dataBrokerStorage is a certmagic.Storage storing the keys as databroker records. Locks are
+// databroker leases, so only one instance obtains a certificate at a time. The leases are renewed
+// until they're unlocked, and expire when the instance holding them goes away.
+type dataBrokerStorage struct {
+	getClient func(ctx context.Context) (databroker.DataBrokerServiceClient, error)
+
+	mu    sync.Mutex
+	locks map[string]*dataBrokerStorageLock
+}
+
+type dataBrokerStorageLock struct {
+	leaseID string
+	cancel  context.CancelFunc
+}
+
+var _ certmagic.Storage = (*dataBrokerStorage)(nil)
+
+func newDataBrokerStorage(
+	getClient func(ctx context.Context) (databroker.DataBrokerServiceClient, error),
+) *dataBrokerStorage {
+	return &dataBrokerStorage{
+		getClient: getClient,
+		locks:     map[string]*dataBrokerStorageLock{},
+	}
+}
+
+// Lock acquires the lease for the key, waiting for it to be released if another instance holds it.
+func (s *dataBrokerStorage) Lock(ctx context.Context, key string) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := getDataBrokerStorageLeaseName(key)
+	for {
+		res, err := client.AcquireLease(ctx, &databroker.AcquireLeaseRequest{
+			Name:     name,
+			Duration: durationpb.New(dataBrokerStorageLockTTL),
+			Holder:   databroker.LeaseHolder(),
+		})
+		if err == nil {
+			s.addLock(client, key, res.GetId())
+			return nil
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		} else if status.Code(err) != codes.AlreadyExists {
+			return fmt.Errorf("autocert: error acquiring lock for %s: %w", key, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dataBrokerStorageLockPollInterval):
+		}
+	}
+}
+
+// Unlock releases the lease for the key.
+func (s *dataBrokerStorage) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	lock, ok := s.locks[key]
+	delete(s.locks, key)
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("autocert: %s is not locked", key)
+	}
+	lock.cancel()
+
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.ReleaseLease(ctx, &databroker.ReleaseLeaseRequest{
+		Name: getDataBrokerStorageLeaseName(key),
+		Id:   lock.leaseID,
+	})
+	return err
+}
+
+func (s *dataBrokerStorage) addLock(client databroker.DataBrokerServiceClient, key, leaseID string) {
+	// the lease outlives the context used to acquire it
+	ctx, cancel := context.WithCancel(log.WithContext(context.Background(), func(c zerolog.Context) zerolog.Context {
+		return c.Str("service", "autocert-manager").Str("lock", key)
+	}))
+
+	s.mu.Lock()
+	s.locks[key] = &dataBrokerStorageLock{leaseID: leaseID, cancel: cancel}
+	s.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(dataBrokerStorageLockTTL / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			_, err := client.RenewLease(ctx, &databroker.RenewLeaseRequest{
+				Name:     getDataBrokerStorageLeaseName(key),
+				Id:       leaseID,
+				Duration: durationpb.New(dataBrokerStorageLockTTL),
+			})
+			if status.Code(err) == codes.AlreadyExists {
+				log.Error(ctx).Msg("autocert: lost lock")
+				return
+			} else if err != nil && ctx.Err() == nil {
+				log.Warn(ctx).Err(err).Msg("autocert: error renewing lock")
+			}
+		}
+	}()
+}
+
+// Store puts the value at the key.
+func (s *dataBrokerStorage) Store(ctx context.Context, key string, value []byte) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Type: dataBrokerStorageRecordType,
+			Id:   key,
+			Data: protoutil.NewAny(wrapperspb.Bytes(value)),
+		}},
+	})
+	return err
+}
+
+// Load retrieves the value at the key.
+func (s *dataBrokerStorage) Load(ctx context.Context, key string) ([]byte, error) {
+	record, err := s.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var value wrapperspb.BytesValue
+	if err := record.GetData().UnmarshalTo(&value); err != nil {
+		return nil, fmt.Errorf("autocert: invalid record for %s: %w", key, err)
+	}
+	return value.GetValue(), nil
+}
+
+// Delete deletes the key, and all the keys prefixed by it.
+func (s *dataBrokerStorage) Delete(ctx context.Context, key string) error {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := s.List(ctx, key, true)
+	if err != nil && err != fs.ErrNotExist {
+		return err
+	}
+	keys = append(keys, key)
+
+	records := make([]*databroker.Record, 0, len(keys))
+	for _, k := range keys {
+		records = append(records, &databroker.Record{
+			Type:      dataBrokerStorageRecordType,
+			Id:        k,
+			Data:      protoutil.NewAny(new(wrapperspb.BytesValue)),
+			DeletedAt: timestamppb.Now(),
+		})
+	}
+	_, err = client.Put(ctx, &databroker.PutRequest{Records: records})
+	return err
+}
+
+// Exists returns true if the key exists.
+func (s *dataBrokerStorage) Exists(ctx context.Context, key string) bool {
+	_, err := s.get(ctx, key)
+	return err == nil
+}
+
+// List returns the keys prefixed by the prefix. Unless recursive is set, only the keys directly
+// under the prefix are returned.
+func (s *dataBrokerStorage) List(ctx context.Context, prefix string, recursive bool) ([]string, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, _, _, err := databroker.InitialSync(ctx, client, &databroker.SyncLatestRequest{
+		Type: dataBrokerStorageRecordType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dir := strings.TrimSuffix(prefix, "/")
+	if dir != "" {
+		dir += "/"
+	}
+	lookup := map[string]struct{}{}
+	for _, record := range records {
+		if !strings.HasPrefix(record.GetId(), dir) {
+			continue
+		}
+		rest := strings.TrimPrefix(record.GetId(), dir)
+		if !recursive {
+			rest = strings.SplitN(rest, "/", 2)[0]
+		}
+		lookup[dir+rest] = struct{}{}
+	}
+	if len(lookup) == 0 {
+		return nil, fs.ErrNotExist
+	}
+
+	keys := make([]string, 0, len(lookup))
+	for k := range lookup {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Stat returns information about the key.
+func (s *dataBrokerStorage) Stat(ctx context.Context, key string) (certmagic.KeyInfo, error) {
+	record, err := s.get(ctx, key)
+	if err == fs.ErrNotExist {
+		// keys prefixed by the key make it a directory
+		if _, err := s.List(ctx, key, false); err != nil {
+			return certmagic.KeyInfo{}, err
+		}
+		return certmagic.KeyInfo{Key: key}, nil
+	} else if err != nil {
+		return certmagic.KeyInfo{}, err
+	}
+
+	var value wrapperspb.BytesValue
+	if err := record.GetData().UnmarshalTo(&value); err != nil {
+		return certmagic.KeyInfo{}, fmt.Errorf("autocert: invalid record for %s: %w", key, err)
+	}
+	return certmagic.KeyInfo{
+		Key:        key,
+		Modified:   record.GetModifiedAt().AsTime(),
+		Size:       int64(len(value.GetValue())),
+		IsTerminal: true,
+	}, nil
+}
+
+func (s *dataBrokerStorage) get(ctx context.Context, key string) (*databroker.Record, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Get(ctx, &databroker.GetRequest{
+		Type: dataBrokerStorageRecordType,
+		Id:   key,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, fs.ErrNotExist
+	} else if err != nil {
+		return nil, err
+	}
+	return res.GetRecord(), nil
+}
+
+func getDataBrokerStorageLeaseName(key string) string {
+	return "autocert/" + key
+}
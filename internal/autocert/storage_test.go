@@ -0,0 +1,112 @@
+package autocert
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+func TestDataBrokerStorage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	li := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(srv, internal_databroker.New())
+	go func() { _ = srv.Serve(li) }()
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return li.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := databroker.NewDataBrokerServiceClient(cc)
+
+	s := newDataBrokerStorage(func(ctx context.Context) (databroker.DataBrokerServiceClient, error) {
+		return client, nil
+	})
+
+	t.Run("store and load", func(t *testing.T) {
+		_, err := s.Load(ctx, "certificates/acme/example.com/example.com.crt")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.False(t, s.Exists(ctx, "certificates/acme/example.com/example.com.crt"))
+
+		require.NoError(t, s.Store(ctx, "certificates/acme/example.com/example.com.crt", []byte("CERT")))
+		require.NoError(t, s.Store(ctx, "certificates/acme/example.com/example.com.key", []byte("KEY")))
+		require.NoError(t, s.Store(ctx, "certificates/acme/example.net/example.net.crt", []byte("CERT")))
+
+		value, err := s.Load(ctx, "certificates/acme/example.com/example.com.crt")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("CERT"), value)
+		assert.True(t, s.Exists(ctx, "certificates/acme/example.com/example.com.crt"))
+	})
+	t.Run("list", func(t *testing.T) {
+		keys, err := s.List(ctx, "certificates/acme", false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"certificates/acme/example.com",
+			"certificates/acme/example.net",
+		}, keys)
+
+		keys, err = s.List(ctx, "certificates", true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"certificates/acme/example.com/example.com.crt",
+			"certificates/acme/example.com/example.com.key",
+			"certificates/acme/example.net/example.net.crt",
+		}, keys)
+
+		_, err = s.List(ctx, "acme", true)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("stat", func(t *testing.T) {
+		info, err := s.Stat(ctx, "certificates/acme/example.com/example.com.key")
+		assert.NoError(t, err)
+		assert.Equal(t, "certificates/acme/example.com/example.com.key", info.Key)
+		assert.Equal(t, int64(3), info.Size)
+		assert.True(t, info.IsTerminal)
+		assert.False(t, info.Modified.IsZero())
+
+		info, err = s.Stat(ctx, "certificates/acme/example.com")
+		assert.NoError(t, err)
+		assert.False(t, info.IsTerminal)
+
+		_, err = s.Stat(ctx, "certificates/acme/example.org")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, s.Delete(ctx, "certificates/acme/example.com"))
+
+		keys, err := s.List(ctx, "certificates", true)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"certificates/acme/example.net/example.net.crt",
+		}, keys)
+	})
+	t.Run("lock", func(t *testing.T) {
+		require.NoError(t, s.Lock(ctx, "issue_cert_example.com"))
+
+		lockCtx, lockCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer lockCancel()
+		assert.ErrorIs(t, s.Lock(lockCtx, "issue_cert_example.com"), context.DeadlineExceeded,
+			"should wait for the lock to be released")
+
+		require.NoError(t, s.Unlock(ctx, "issue_cert_example.com"))
+		require.NoError(t, s.Lock(ctx, "issue_cert_example.com"))
+		require.NoError(t, s.Unlock(ctx, "issue_cert_example.com"))
+		assert.Error(t, s.Unlock(ctx, "issue_cert_example.com"))
+	})
+}
@@ -0,0 +1,85 @@
+// Package auditcmd houses the pomerium audit CLI command, which verifies the audit trail stored
+// in the databroker.
+package auditcmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/audit"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] audit <command>
+
+commands:
+  verify
+`
+
+// ErrTrailInvalid is returned by verify when records of the audit trail are missing or changed.
+var ErrTrailInvalid = errors.New("audit: the audit trail is invalid")
+
+// Run runs the audit command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch cmd := args[0]; cmd {
+	case "verify":
+		return verify(ctx, configFile, w)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+}
+
+// verify writes the report of the verification of the audit trail.
+func verify(ctx context.Context, configFile string, w io.Writer) error {
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	options := src.GetConfig().Options
+	sharedKeys, err := options.GetAcceptedSharedKeys()
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	report, err := audit.VerifyTrail(ctx, client, sharedKeys)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if len(report.Problems) > 0 {
+		return ErrTrailInvalid
+	}
+	return nil
+}
+
+func newClient(ctx context.Context, options *config.Options) (databroker.DataBrokerServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("audit")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return databroker.NewDataBrokerServiceClient(cc), nil
+}
@@ -0,0 +1,313 @@
+// Package bench houses the pomerium bench CLI command, which sends synthetic authenticated
+// traffic to a running instance and reports the latencies of authorize, of the proxy and of
+// the upstreams, so capacity can be planned without external tooling.
+package bench
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+const usage = `usage: pomerium [-config file] bench [flags]
+
+flags:
+  -duration DURATION        how long to send traffic (default 30s)
+  -rps N                    requests per second sent to each target (default 10)
+  -concurrency N            maximum requests in flight per target (default 64)
+  -sessions N               number of synthetic sessions (default 10)
+  -routes N                 number of routes to send traffic to, 0 for all (default 0)
+  -targets LIST             comma separated targets: authorize, proxy, direct (default all)
+  -proxy-address HOST:PORT  address of the proxy, instead of the address of the route's host
+  -insecure-skip-verify     don't verify the certificates of the proxy and of the upstreams
+  -metrics-url URL          metrics endpoint used to measure databroker QPS, instead of the
+                            metrics_address
+`
+
+// targets
+const (
+	targetAuthorize = "authorize"
+	targetProxy     = "proxy"
+	targetDirect    = "direct"
+)
+
+type benchFlags struct {
+	duration           time.Duration
+	rps                int
+	concurrency        int
+	sessions           int
+	routes             int
+	targets            string
+	proxyAddress       string
+	insecureSkipVerify bool
+	metricsURL         string
+}
+
+// A Report is the result of a benchmark.
+type Report struct {
+	Duration  string `json:"duration"`
+	Sessions  int    `json:"sessions"`
+	Routes    int    `json:"routes"`
+	RPS       int    `json:"rps"`
+	Authorize *Stats `json:"authorize,omitempty"`
+	Proxy     *Stats `json:"proxy,omitempty"`
+	Direct    *Stats `json:"direct,omitempty"`
+	// ProxyOverhead is the latency the proxy adds to the upstreams, by percentile
+	ProxyOverhead *Percentiles `json:"proxy_overhead,omitempty"`
+	// DataBrokerQPS is the number of databroker calls per second served while the traffic was
+	// sent, from the metrics of the instance
+	DataBrokerQPS *float64 `json:"databroker_qps,omitempty"`
+}
+
+type benchSession struct {
+	id     string
+	userID string
+	rawJWT string
+}
+
+type benchRoute struct {
+	from     url.URL
+	upstream url.URL
+}
+
+// Run runs the bench command with the given arguments. The report is written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	var flags benchFlags
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(fs.Output(), usage) }
+	fs.DurationVar(&flags.duration, "duration", 30*time.Second, "")
+	fs.IntVar(&flags.rps, "rps", 10, "")
+	fs.IntVar(&flags.concurrency, "concurrency", 64, "")
+	fs.IntVar(&flags.sessions, "sessions", 10, "")
+	fs.IntVar(&flags.routes, "routes", 0, "")
+	fs.StringVar(&flags.targets, "targets", strings.Join([]string{targetAuthorize, targetProxy, targetDirect}, ","), "")
+	fs.StringVar(&flags.proxyAddress, "proxy-address", "", "")
+	fs.BoolVar(&flags.insecureSkipVerify, "insecure-skip-verify", false, "")
+	fs.StringVar(&flags.metricsURL, "metrics-url", "", "")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if flags.duration <= 0 || flags.rps <= 0 || flags.concurrency <= 0 || flags.sessions <= 0 {
+		return errors.New("-duration, -rps, -concurrency and -sessions must be positive")
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	options := src.GetConfig().Options
+
+	routes, err := getRoutes(options, flags.routes)
+	if err != nil {
+		return err
+	}
+
+	dataBrokerClient, err := newDataBrokerClient(ctx, options)
+	if err != nil {
+		return err
+	}
+	benchSessions, err := createSessions(ctx, dataBrokerClient, options, flags.sessions, flags.duration)
+	if err != nil {
+		return err
+	}
+	defer deleteSessions(context.Background(), dataBrokerClient, benchSessions)
+
+	var targets []*target
+	for _, name := range strings.Split(flags.targets, ",") {
+		t, err := newTarget(ctx, strings.TrimSpace(name), options, &flags)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, t)
+	}
+
+	report := &Report{
+		Duration: flags.duration.String(),
+		Sessions: len(benchSessions),
+		Routes:   len(routes),
+		RPS:      flags.rps,
+	}
+
+	scraper := newMetricsScraper(options, &flags)
+	before, scrapeErr := scraper.getDataBrokerRequests(ctx)
+	start := time.Now()
+	runTargets(ctx, targets, benchSessions, routes, &flags)
+	if after, err := scraper.getDataBrokerRequests(ctx); scrapeErr == nil && err == nil {
+		qps := (after - before) / time.Since(start).Seconds()
+		report.DataBrokerQPS = &qps
+	}
+
+	for _, t := range targets {
+		stats := t.stats()
+		switch t.name {
+		case targetAuthorize:
+			report.Authorize = stats
+		case targetProxy:
+			report.Proxy = stats
+		case targetDirect:
+			report.Direct = stats
+		}
+	}
+	if report.Proxy != nil && report.Direct != nil {
+		report.ProxyOverhead = report.Proxy.Percentiles.sub(report.Direct.Percentiles)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// getRoutes returns the HTTP routes to send traffic to.
+func getRoutes(options *config.Options, limit int) ([]benchRoute, error) {
+	var routes []benchRoute
+	for _, p := range options.GetAllPolicies() {
+		if p.Source == nil || len(p.To) == 0 || p.Regex != "" ||
+			(p.Source.Scheme != "http" && p.Source.Scheme != "https") {
+			continue
+		}
+
+		r := benchRoute{from: *p.Source.URL, upstream: p.To[0].URL}
+		switch {
+		case p.Path != "":
+			r.from.Path = p.Path
+		case p.Prefix != "":
+			r.from.Path = p.Prefix
+		}
+		r.upstream.Path = strings.TrimSuffix(r.upstream.Path, "/") + r.from.Path
+		routes = append(routes, r)
+
+		if limit > 0 && len(routes) == limit {
+			break
+		}
+	}
+	if len(routes) == 0 {
+		return nil, errors.New("no http routes to send traffic to")
+	}
+	return routes, nil
+}
+
+func newDataBrokerClient(ctx context.Context, options *config.Options) (databroker.DataBrokerServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("bench")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return databroker.NewDataBrokerServiceClient(cc), nil
+}
+
+// createSessions creates synthetic sessions and users in the databroker, and signs their session
+// JWTs with the shared secret. The sessions expire shortly after the benchmark ends.
+func createSessions(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	options *config.Options,
+	n int,
+	duration time.Duration,
+) ([]benchSession, error) {
+	sharedKey, err := options.GetSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	encoder, err := jws.NewHS256Signer(sharedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var benchSessions []benchSession
+	var records []*databroker.Record
+	for i := 0; i < n; i++ {
+		s := benchSession{id: "bench-" + uuid.NewString()}
+		s.userID = s.id
+
+		rawJWT, err := encoder.Marshal(&sessions.State{ID: s.id, IssuedAt: jwt.NewNumericDate(now)})
+		if err != nil {
+			return nil, err
+		}
+		s.rawJWT = string(rawJWT)
+		benchSessions = append(benchSessions, s)
+
+		sessionData := protoutil.NewAny(&session.Session{
+			Id:        s.id,
+			UserId:    s.userID,
+			IssuedAt:  timestamppb.New(now),
+			ExpiresAt: timestamppb.New(now.Add(duration + 5*time.Minute)),
+		})
+		userData := protoutil.NewAny(&user.User{
+			Id:    s.userID,
+			Name:  "pomerium bench",
+			Email: s.userID + "@bench.invalid",
+		})
+		records = append(records,
+			&databroker.Record{Type: sessionData.GetTypeUrl(), Id: s.id, Data: sessionData},
+			&databroker.Record{Type: userData.GetTypeUrl(), Id: s.userID, Data: userData})
+	}
+
+	_, err = client.Put(ctx, &databroker.PutRequest{Records: records})
+	if err != nil {
+		return nil, fmt.Errorf("error creating sessions: %w", err)
+	}
+	return benchSessions, nil
+}
+
+// deleteSessions deletes the synthetic sessions and users.
+func deleteSessions(ctx context.Context, client databroker.DataBrokerServiceClient, benchSessions []benchSession) {
+	sessionData := protoutil.NewAny(new(session.Session))
+	userData := protoutil.NewAny(new(user.User))
+	deletedAt := timestamppb.Now()
+
+	var records []*databroker.Record
+	for _, s := range benchSessions {
+		records = append(records,
+			&databroker.Record{Type: sessionData.GetTypeUrl(), Id: s.id, Data: sessionData, DeletedAt: deletedAt},
+			&databroker.Record{Type: userData.GetTypeUrl(), Id: s.userID, Data: userData, DeletedAt: deletedAt})
+	}
+	_, _ = client.Put(ctx, &databroker.PutRequest{Records: records})
+}
+
+// newHTTPClient returns a client which doesn't follow redirects, and which connects to the
+// address instead of the host of the URLs, when it is set.
+func newHTTPClient(address string, insecureSkipVerify bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 256
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipVerify} //nolint:gosec
+	if address != "" {
+		dialer := new(net.Dialer)
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
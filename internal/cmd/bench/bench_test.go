@@ -0,0 +1,117 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestGetPercentiles(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, Percentiles{P50: 50, P90: 90, P99: 99, Max: 100}, getPercentiles(latencies))
+	assert.Equal(t, Percentiles{}, getPercentiles(nil))
+	assert.Equal(t, &Percentiles{P50: 40, P90: 80, P99: 89, Max: 90},
+		getPercentiles(latencies).sub(Percentiles{P50: 10, P90: 10, P99: 10, Max: 10}))
+}
+
+func TestGetRoutes(t *testing.T) {
+	options := config.NewDefaultOptions()
+	options.Policies = []config.Policy{
+		{From: "https://a.example.com", To: mustParseWeightedURLs(t, "https://a.internal/base/")},
+		{From: "https://b.example.com", Prefix: "/admin", To: mustParseWeightedURLs(t, "https://b.internal")},
+		{From: "https://c.example.com", Regex: "^/c", To: mustParseWeightedURLs(t, "https://c.internal")},
+		{From: "tcp+https://d.example.com:22", To: mustParseWeightedURLs(t, "tcp://d.internal:22")},
+	}
+	for i := range options.Policies {
+		require.NoError(t, options.Policies[i].Validate())
+	}
+
+	routes, err := getRoutes(options, 0)
+	require.NoError(t, err)
+	if assert.Len(t, routes, 2, "should skip regex and tcp routes") {
+		assert.Equal(t, "https://a.example.com", routes[0].from.String())
+		assert.Equal(t, "https://a.internal/base", routes[0].upstream.String())
+		assert.Equal(t, "https://b.example.com/admin", routes[1].from.String())
+		assert.Equal(t, "https://b.internal/admin", routes[1].upstream.String())
+	}
+
+	routes, err = getRoutes(options, 1)
+	require.NoError(t, err)
+	assert.Len(t, routes, 1)
+
+	_, err = getRoutes(config.NewDefaultOptions(), 0)
+	assert.Error(t, err)
+}
+
+func TestTarget(t *testing.T) {
+	var requests int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		assert.Equal(t, "Pomerium JWT", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// every request is sent to the test server, like they're sent to the proxy
+	options := config.NewDefaultOptions()
+	flags := &benchFlags{proxyAddress: srv.Listener.Addr().String(), duration: 200 * time.Millisecond, rps: 50, concurrency: 4}
+	tgt, err := newTarget(context.Background(), targetProxy, options, flags)
+	require.NoError(t, err)
+
+	runTargets(context.Background(), []*target{tgt},
+		[]benchSession{{id: "s1", rawJWT: "JWT"}},
+		[]benchRoute{{from: url.URL{Scheme: "http", Host: "from.example.com"}}},
+		flags)
+
+	stats := tgt.stats()
+	assert.Greater(t, stats.Requests, 0)
+	assert.Equal(t, int(atomic.LoadInt64(&requests)), stats.Requests)
+	assert.Equal(t, 0, stats.Errors)
+	assert.Equal(t, map[string]int{"204": stats.Requests}, stats.Results)
+
+	_, err = newTarget(context.Background(), "unknown", options, flags)
+	assert.Error(t, err)
+}
+
+func TestGetDataBrokerRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "user", username)
+		assert.Equal(t, "pass", password)
+		fmt.Fprint(w, `# TYPE pomerium_grpc_server_requests_total counter
+pomerium_grpc_server_requests_total{grpc_method="Get",grpc_service="databroker.DataBrokerService",service="pomerium"} 10
+pomerium_grpc_server_requests_total{grpc_method="Sync",grpc_service="databroker.DataBrokerService",service="pomerium"} 5
+pomerium_grpc_server_requests_total{grpc_method="Check",grpc_service="envoy.service.auth.v3.Authorization",service="pomerium"} 100
+`)
+	}))
+	defer srv.Close()
+
+	options := config.NewDefaultOptions()
+	options.MetricsBasicAuth = "dXNlcjpwYXNz" // user:pass
+	scraper := newMetricsScraper(options, &benchFlags{metricsURL: srv.URL})
+	requests, err := scraper.getDataBrokerRequests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, requests)
+
+	_, err = newMetricsScraper(config.NewDefaultOptions(), &benchFlags{}).getDataBrokerRequests(context.Background())
+	assert.Error(t, err, "should fail without a metrics address")
+}
+
+func mustParseWeightedURLs(t *testing.T, urls ...string) []config.WeightedURL {
+	wu, err := config.ParseWeightedUrls(urls...)
+	require.NoError(t, err)
+	return wu
+}
@@ -0,0 +1,78 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+// grpcServerRequestsMetric is the prometheus name of the gRPC server requests metric.
+const grpcServerRequestsMetric = "pomerium_grpc_server_requests_total"
+
+// A metricsScraper reads the metrics of the running instance.
+type metricsScraper struct {
+	client             *http.Client
+	url                string
+	username, password string
+}
+
+func newMetricsScraper(options *config.Options, flags *benchFlags) *metricsScraper {
+	scraper := &metricsScraper{
+		client: newHTTPClient("", flags.insecureSkipVerify),
+		url:    flags.metricsURL,
+	}
+	if scraper.url == "" && options.MetricsAddr != "" {
+		scheme := "http"
+		if options.MetricsCertificate != "" || options.MetricsCertificateFile != "" {
+			scheme = "https"
+		}
+		scraper.url = scheme + "://" + options.MetricsAddr + "/metrics"
+	}
+	scraper.username, scraper.password, _ = options.GetMetricsBasicAuth()
+	return scraper
+}
+
+// getDataBrokerRequests returns the number of calls to the databroker services the instance
+// served.
+func (scraper *metricsScraper) getDataBrokerRequests(ctx context.Context) (float64, error) {
+	if scraper.url == "" {
+		return 0, errors.New("no metrics address")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scraper.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if scraper.username != "" || scraper.password != "" {
+		req.SetBasicAuth(scraper.username, scraper.password)
+	}
+	res, err := scraper.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected metrics status code: %d", res.StatusCode)
+	}
+
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(res.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	var total float64
+	for _, m := range families[grpcServerRequestsMetric].GetMetric() {
+		for _, label := range m.GetLabel() {
+			if label.GetName() == "grpc_service" && strings.HasPrefix(label.GetValue(), "databroker.") {
+				total += m.GetCounter().GetValue() + m.GetUntyped().GetValue()
+			}
+		}
+	}
+	return total, nil
+}
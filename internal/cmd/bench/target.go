@@ -0,0 +1,288 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_service_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// A target is something traffic is sent to, like the proxy.
+type target struct {
+	name string
+	// do sends a request for the session and the route, and returns its result, like the status
+	// code of the response
+	do func(ctx context.Context, s *benchSession, r *benchRoute) (result string, err error)
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    int
+	skipped   int
+	results   map[string]int
+}
+
+// Stats are the statistics of the requests sent to a target.
+type Stats struct {
+	Requests int `json:"requests"`
+	Errors   int `json:"errors"`
+	// Skipped is the number of requests which weren't sent, since the maximum number of requests
+	// were in flight
+	Skipped int `json:"skipped"`
+	// Results is the number of responses by result, like the status code
+	Results map[string]int `json:"results,omitempty"`
+	Percentiles
+}
+
+// Percentiles are latency percentiles, in milliseconds.
+type Percentiles struct {
+	P50 float64 `json:"p50_ms"`
+	P90 float64 `json:"p90_ms"`
+	P99 float64 `json:"p99_ms"`
+	Max float64 `json:"max_ms"`
+}
+
+func newTarget(ctx context.Context, name string, options *config.Options, flags *benchFlags) (*target, error) {
+	t := &target{name: name, results: map[string]int{}}
+	switch name {
+	case targetAuthorize:
+		client, err := newAuthorizeClient(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		t.do = func(ctx context.Context, s *benchSession, r *benchRoute) (string, error) {
+			return checkAuthorize(ctx, client, s, r)
+		}
+	case targetProxy:
+		client := newHTTPClient(flags.proxyAddress, flags.insecureSkipVerify)
+		t.do = func(ctx context.Context, s *benchSession, r *benchRoute) (string, error) {
+			return get(ctx, client, r.from, s)
+		}
+	case targetDirect:
+		client := newHTTPClient("", flags.insecureSkipVerify)
+		t.do = func(ctx context.Context, s *benchSession, r *benchRoute) (string, error) {
+			return get(ctx, client, r.upstream, nil)
+		}
+	default:
+		return nil, fmt.Errorf("unknown target: %s\n%s", name, usage)
+	}
+	return t, nil
+}
+
+func newAuthorizeClient(ctx context.Context, options *config.Options) (envoy_service_auth_v3.AuthorizationClient, error) {
+	sharedKey, err := options.GetSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	authorizeURLs, err := options.GetAuthorizeURLs()
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, &grpcutil.PoolOptions{
+		Options: grpcutil.Options{
+			OverrideCertificateName: options.OverrideCertificateName,
+			CA:                      options.CA,
+			CAFile:                  options.CAFile,
+			RequestTimeout:          options.GRPCClientTimeout,
+			ServiceName:             "bench",
+			SignedJWTKey:            sharedKey,
+		},
+		Addresses: authorizeURLs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to authorize: %w", err)
+	}
+	return envoy_service_auth_v3.NewAuthorizationClient(cc), nil
+}
+
+// checkAuthorize sends the check request envoy sends authorize for a request to the route.
+func checkAuthorize(
+	ctx context.Context,
+	client envoy_service_auth_v3.AuthorizationClient,
+	s *benchSession,
+	r *benchRoute,
+) (string, error) {
+	res, err := client.Check(ctx, &envoy_service_auth_v3.CheckRequest{
+		Attributes: &envoy_service_auth_v3.AttributeContext{
+			Source: &envoy_service_auth_v3.AttributeContext_Peer{
+				Address: &envoy_config_core_v3.Address{
+					Address: &envoy_config_core_v3.Address_SocketAddress{
+						SocketAddress: &envoy_config_core_v3.SocketAddress{Address: "127.0.0.1"},
+					},
+				},
+			},
+			Request: &envoy_service_auth_v3.AttributeContext_Request{
+				Http: &envoy_service_auth_v3.AttributeContext_HttpRequest{
+					Method: http.MethodGet,
+					Scheme: r.from.Scheme,
+					Host:   r.from.Host,
+					Path:   r.from.RequestURI(),
+					Headers: map[string]string{
+						"authorization": httputil.AuthorizationTypePomerium + " " + s.rawJWT,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if res.GetStatus().GetCode() == 0 {
+		return "allowed", nil
+	}
+	return "denied", nil
+}
+
+// get sends a GET request, authenticated with the session when it is set.
+func get(ctx context.Context, client *http.Client, u url.URL, s *benchSession) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if s != nil {
+		req.Header.Set(httputil.HeaderAuthorization, httputil.AuthorizationTypePomerium+" "+s.rawJWT)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	return strconv.Itoa(res.StatusCode), nil
+}
+
+// runTargets sends traffic to the targets for the duration, at the given rate per target.
+func runTargets(ctx context.Context, targets []*target, benchSessions []benchSession, routes []benchRoute, flags *benchFlags) {
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		t := t
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.run(ctx, benchSessions, routes, flags)
+		}()
+	}
+	wg.Wait()
+}
+
+// run sends requests for random sessions and routes, until the duration passes, and waits for
+// the requests in flight to complete.
+func (t *target) run(ctx context.Context, benchSessions []benchSession, routes []benchRoute, flags *benchFlags) {
+	sendCtx, cancel := context.WithTimeout(ctx, flags.duration)
+	defer cancel()
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+	ticker := time.NewTicker(time.Second / time.Duration(flags.rps))
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	inFlight := make(chan struct{}, flags.concurrency)
+	for {
+		select {
+		case <-sendCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			t.mu.Lock()
+			t.skipped++
+			t.mu.Unlock()
+			continue
+		}
+
+		s := &benchSessions[rnd.Intn(len(benchSessions))]
+		r := &routes[rnd.Intn(len(routes))]
+		wg.Add(1)
+		go func() {
+			defer func() { <-inFlight; wg.Done() }()
+
+			start := time.Now()
+			result, err := t.do(ctx, s, r)
+			t.record(time.Since(start), result, err)
+		}()
+	}
+}
+
+func (t *target) record(latency time.Duration, result string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err != nil {
+		t.errors++
+		return
+	}
+	t.latencies = append(t.latencies, latency)
+	t.results[result]++
+}
+
+func (t *target) stats() *Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return &Stats{
+		Requests:    len(t.latencies) + t.errors,
+		Errors:      t.errors,
+		Skipped:     t.skipped,
+		Results:     t.results,
+		Percentiles: getPercentiles(t.latencies),
+	}
+}
+
+// getPercentiles returns the nearest-rank percentiles of the latencies.
+func getPercentiles(latencies []time.Duration) Percentiles {
+	if len(latencies) == 0 {
+		return Percentiles{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return milliseconds(sorted[i])
+	}
+	return Percentiles{
+		P50: percentile(0.5),
+		P90: percentile(0.9),
+		P99: percentile(0.99),
+		Max: milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// sub returns the difference of the percentiles.
+func (p Percentiles) sub(other Percentiles) *Percentiles {
+	return &Percentiles{
+		P50: p.P50 - other.P50,
+		P90: p.P90 - other.P90,
+		P99: p.P99 - other.P99,
+		Max: p.Max - other.Max,
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
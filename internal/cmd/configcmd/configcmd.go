@@ -0,0 +1,141 @@
+// Package configcmd houses the pomerium config CLI command, which compares a config with the
+// config of a running instance, or another config file, before it's applied.
+package configcmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/configdiff"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] config <command> [flags]
+
+commands:
+  diff  [-against running|FILE] [-address URL] [-exit-code]
+`
+
+// againstRunning compares the config with the config of the running instance.
+const againstRunning = "running"
+
+// ErrChanged is returned by diff with -exit-code when the configs differ.
+var ErrChanged = errors.New("config: configs differ")
+
+// Run runs the config command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "diff":
+		return diff(ctx, configFile, args, w)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+}
+
+// diff writes the changes from the running config, or the config file given by -against, to
+// the config.
+func diff(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	against := fs.String("against", againstRunning, "the config to compare with, running or a config file")
+	address := fs.String("address", "", "the gRPC address of the running instance, defaults to the databroker service url")
+	exitCode := fs.Bool("exit-code", false, "return an error when the configs differ")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, usage)
+	}
+
+	cfg, err := loadConfig(configFile)
+	if err != nil {
+		return err
+	}
+	to, err := configdiff.NewSnapshot(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	var from *configpb.ConfigSnapshot
+	if *against == againstRunning {
+		from, err = getRunningSnapshot(ctx, cfg.Options, *address)
+	} else {
+		var other *config.Config
+		if other, err = loadConfig(*against); err == nil {
+			from, err = configdiff.NewSnapshot(ctx, other)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := configdiff.Compare(from, to)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(res); err != nil {
+		return err
+	}
+	if *exitCode && res.Changed {
+		return ErrChanged
+	}
+	return nil
+}
+
+func loadConfig(configFile string) (*config.Config, error) {
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return nil, err
+	}
+	return src.GetConfig(), nil
+}
+
+// getRunningSnapshot gets the snapshot of the running config. Requests are signed with the
+// shared secret of the config.
+func getRunningSnapshot(ctx context.Context, options *config.Options, address string) (*configpb.ConfigSnapshot, error) {
+	sharedKey, err := options.GetSharedKey()
+	if err != nil {
+		return nil, err
+	}
+	dataBrokerURLs, err := options.GetDataBrokerURLs()
+	if err != nil {
+		return nil, err
+	}
+	target := dataBrokerURLs[0]
+	if address != "" {
+		if target, err = urlutil.ParseAndValidateURL(address); err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
+	}
+
+	cc, err := grpcutil.NewGRPCClientConn(ctx, &grpcutil.Options{
+		Address:                 target,
+		OverrideCertificateName: options.OverrideCertificateName,
+		CA:                      options.CA,
+		CAFile:                  options.CAFile,
+		RequestTimeout:          options.GRPCClientTimeout,
+		ServiceName:             "config",
+		SignedJWTKey:            sharedKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the running instance: %w", err)
+	}
+	defer cc.Close()
+
+	res, err := configpb.NewConfigServiceClient(cc).GetRunningConfig(ctx, new(configpb.GetRunningConfigRequest))
+	if err != nil {
+		return nil, fmt.Errorf("error getting the running config: %w", err)
+	}
+	return res.GetSnapshot(), nil
+}
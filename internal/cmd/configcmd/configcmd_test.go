@@ -0,0 +1,117 @@
+package configcmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/configdiff"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const sharedSecret = "YixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM="
+
+type testConfigServer struct {
+	configpb.UnimplementedConfigServiceServer
+	snapshot *configpb.ConfigSnapshot
+}
+
+func (srv *testConfigServer) GetRunningConfig(ctx context.Context, _ *configpb.GetRunningConfigRequest) (*configpb.GetRunningConfigResponse, error) {
+	sharedKey, _ := base64.StdEncoding.DecodeString(sharedSecret)
+	if err := grpcutil.RequireSignedJWT(ctx, sharedKey); err != nil {
+		return nil, err
+	}
+	return &configpb.GetRunningConfigResponse{Snapshot: srv.snapshot}, nil
+}
+
+func TestRun(t *testing.T) {
+	writeConfig := func(t *testing.T, contents string) string {
+		fn := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(fn, []byte(contents), 0o600))
+		return fn
+	}
+	run := func(t *testing.T, configFile string, args ...string) (*configdiff.Diff, error) {
+		var buf bytes.Buffer
+		err := Run(context.Background(), configFile, args, &buf)
+		var res configdiff.Diff
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		return &res, err
+	}
+
+	const base = `
+insecure_server: true
+authenticate_service_url: https://authenticate.example.com
+shared_secret: ` + sharedSecret + `
+databroker_service_url: http://127.0.0.1:5443
+`
+	runningFile := writeConfig(t, base+`
+cookie_secret: zixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM=
+routes:
+- from: https://a.example.com
+  to: https://a.internal
+  allow_public_unauthenticated_access: true
+`)
+	candidateFile := writeConfig(t, base+`
+cookie_secret: aixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM=
+routes:
+- from: https://a.example.com
+  to: https://a2.internal
+  allow_public_unauthenticated_access: true
+- from: https://b.example.com
+  to: https://b.internal
+  allow_public_unauthenticated_access: true
+`)
+
+	t.Run("file", func(t *testing.T) {
+		res, err := run(t, candidateFile, "diff", "-against", runningFile)
+		require.NoError(t, err)
+		assert.True(t, res.Changed)
+		require.Len(t, res.Settings.Changed, 1)
+		assert.Equal(t, "cookie_secret", res.Settings.Changed[0].Name)
+		assert.NotContains(t, res.Settings.Changed[0].Fields[0].To, "aixWi1MYh77NMECGGIJQ",
+			"secrets should be redacted")
+		assert.Equal(t, []string{"https://b.example.com"}, res.Routes.Added)
+		require.Len(t, res.Routes.Changed, 1)
+		assert.Equal(t, "https://a.example.com", res.Routes.Changed[0].Name)
+		assert.Empty(t, res.Listeners.Changed, "the listeners should only reference the route configs")
+		assert.NotEmpty(t, res.RouteConfigurations.Changed, "the new route should be added to the route config")
+		assert.NotEmpty(t, res.Clusters.Added, "the new route should have a cluster")
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		res, err := run(t, runningFile, "diff", "-against", runningFile, "-exit-code")
+		assert.NoError(t, err)
+		assert.False(t, res.Changed)
+
+		_, err = run(t, candidateFile, "diff", "-against", runningFile, "-exit-code")
+		assert.ErrorIs(t, err, ErrChanged)
+	})
+	t.Run("running", func(t *testing.T) {
+		src, err := config.NewFileOrEnvironmentSource(runningFile, "")
+		require.NoError(t, err)
+		snapshot, err := configdiff.NewSnapshot(context.Background(), src.GetConfig())
+		require.NoError(t, err)
+
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv := grpc.NewServer()
+		configpb.RegisterConfigServiceServer(srv, &testConfigServer{snapshot: snapshot})
+		go func() { _ = srv.Serve(li) }()
+		t.Cleanup(srv.Stop)
+
+		res, err := run(t, candidateFile, "diff", "-address", "http://"+li.Addr().String())
+		require.NoError(t, err)
+		assert.True(t, res.Changed)
+		assert.Equal(t, []string{"https://b.example.com"}, res.Routes.Added)
+	})
+}
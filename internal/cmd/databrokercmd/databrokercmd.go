@@ -0,0 +1,290 @@
+// Package databrokercmd houses the pomerium databroker CLI command, which gets, lists and
+// decodes databroker records, for debugging.
+package databrokercmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] databroker <command> [flags]
+
+commands:
+  get     -type TYPE -id ID
+  list    -type TYPE [-query TEXT] [-offset N] [-limit N]
+  decode  [-file FILE]
+`
+
+const typeURLPrefix = "type.googleapis.com/"
+
+// A Record is a databroker record with its data decoded.
+type Record struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Version    uint64          `json:"version,omitempty"`
+	ModifiedAt *time.Time      `json:"modified_at,omitempty"`
+	DeletedAt  *time.Time      `json:"deleted_at,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Run runs the databroker command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	options := src.GetConfig().Options
+
+	var res interface{}
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "get":
+		res, err = get(ctx, options, args)
+	case "list":
+		res, err = list(ctx, options, args)
+	case "decode":
+		res, err = decode(options, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+func newClient(ctx context.Context, options *config.Options) (databroker.DataBrokerServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("databroker-cli")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return databroker.NewDataBrokerServiceClient(cc), nil
+}
+
+func get(ctx context.Context, options *config.Options, args []string) (interface{}, error) {
+	var recordType, id string
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	fs.StringVar(&recordType, "type", "", "the type of the record, like session.Session")
+	fs.StringVar(&id, "id", "", "the id of the record")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if recordType == "" || id == "" {
+		return nil, fmt.Errorf("-type and -id are required\n%s", usage)
+	}
+
+	client, err := newClient(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Get(ctx, &databroker.GetRequest{
+		Type: getTypeURL(recordType),
+		Id:   id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRecord(res.GetRecord()), nil
+}
+
+func list(ctx context.Context, options *config.Options, args []string) (interface{}, error) {
+	req := new(databroker.QueryRequest)
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.StringVar(&req.Type, "type", "", "the type of the records, like session.Session")
+	fs.StringVar(&req.Query, "query", "", "only list the records matching the text")
+	fs.Int64Var(&req.Offset, "offset", 0, "the number of records to skip")
+	fs.Int64Var(&req.Limit, "limit", 100, "the maximum number of records to list")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if req.Type == "" {
+		return nil, fmt.Errorf("-type is required\n%s", usage)
+	}
+	req.Type = getTypeURL(req.Type)
+
+	client, err := newClient(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Query(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*Record, 0, len(res.GetRecords()))
+	for _, record := range res.GetRecords() {
+		records = append(records, newRecord(record))
+	}
+	return struct {
+		Records    []*Record `json:"records"`
+		TotalCount int64     `json:"total_count"`
+	}{records, res.GetTotalCount()}, nil
+}
+
+// decode decodes a record read from a file, or stdin, like a value copied from the storage
+// backend. Values encrypted with the shared secret are decrypted.
+func decode(options *config.Options, args []string) (interface{}, error) {
+	var file string
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	fs.StringVar(&file, "file", "", "the file to read the record from, defaults to stdin")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	var bs []byte
+	var err error
+	if file == "" {
+		bs, err = io.ReadAll(os.Stdin)
+	} else {
+		bs, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var aead cipher.AEAD
+	if sharedKey, err := options.GetSharedKey(); err == nil {
+		aead, _ = cryptutil.NewAEADCipher(sharedKey)
+	}
+	record, err := decodeRecord(bs, aead)
+	if err != nil {
+		return nil, err
+	}
+	return newRecord(record), nil
+}
+
+// decodeRecord decodes a record encoded as protobuf, optionally base64 encoded, or as JSON. A
+// JSON protobuf Any, like the data stored by postgres, is decoded as the data of a record.
+func decodeRecord(bs []byte, aead cipher.AEAD) (*databroker.Record, error) {
+	bs = bytes.TrimSpace(bs)
+
+	record := new(databroker.Record)
+	if bytes.HasPrefix(bs, []byte("{")) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(bs, &fields); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if _, ok := fields["@type"]; ok {
+			record.Data = new(anypb.Any)
+			if err := protojson.Unmarshal(bs, record.Data); err != nil {
+				return nil, fmt.Errorf("invalid JSON protobuf Any: %w", err)
+			}
+			record.Type = record.Data.GetTypeUrl()
+		} else if err := protojson.Unmarshal(bs, record); err != nil {
+			return nil, fmt.Errorf("invalid JSON record: %w", err)
+		}
+	} else {
+		if decoded, err := base64.StdEncoding.DecodeString(string(bs)); err == nil {
+			bs = decoded
+		}
+		if err := proto.Unmarshal(bs, record); err != nil {
+			return nil, fmt.Errorf("invalid protobuf record: %w", err)
+		}
+	}
+
+	// the redis backend encrypts the data of records
+	if aead != nil && record.GetData().MessageIs(new(wrapperspb.BytesValue)) {
+		var encrypted wrapperspb.BytesValue
+		if err := record.GetData().UnmarshalTo(&encrypted); err == nil {
+			if plaintext, err := cryptutil.Decrypt(aead, encrypted.GetValue(), nil); err == nil {
+				data := new(anypb.Any)
+				if err := proto.Unmarshal(plaintext, data); err == nil {
+					record.Data = data
+				}
+			}
+		}
+	}
+	return record, nil
+}
+
+func newRecord(record *databroker.Record) *Record {
+	r := &Record{
+		Type:    record.GetType(),
+		ID:      record.GetId(),
+		Version: record.GetVersion(),
+		Data:    decodeData(record.GetData()),
+	}
+	if record.ModifiedAt != nil {
+		tm := record.GetModifiedAt().AsTime()
+		r.ModifiedAt = &tm
+	}
+	if record.DeletedAt != nil {
+		tm := record.GetDeletedAt().AsTime()
+		r.DeletedAt = &tm
+	}
+	return r
+}
+
+// decodeData decodes the data of a record as JSON. Data of unknown types is kept as base64.
+func decodeData(data *anypb.Any) json.RawMessage {
+	if data == nil {
+		return json.RawMessage("null")
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(data.GetTypeUrl())
+	if err == nil {
+		msg := mt.New().Interface()
+		if err := proto.Unmarshal(data.GetValue(), msg); err == nil {
+			if bs, err := protojson.Marshal(msg); err == nil {
+				return reindent(bs)
+			}
+		}
+	}
+
+	bs, _ := json.Marshal(map[string]string{
+		"@type": data.GetTypeUrl(),
+		"value": base64.StdEncoding.EncodeToString(data.GetValue()),
+	})
+	return bs
+}
+
+// reindent re-indents JSON, since protojson output isn't stable.
+func reindent(bs []byte) json.RawMessage {
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return bs
+	}
+	bs, _ = json.Marshal(v)
+	return bs
+}
+
+// getTypeURL returns the type URL of a record type, which may be a protobuf message name.
+func getTypeURL(recordType string) string {
+	if strings.Contains(recordType, "/") {
+		return recordType
+	}
+	return typeURLPrefix + recordType
+}
@@ -0,0 +1,69 @@
+package databrokercmd
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+func TestDecodeRecord(t *testing.T) {
+	aead, err := cryptutil.NewAEADCipher(cryptutil.NewKey())
+	require.NoError(t, err)
+
+	data := protoutil.NewAny(&session.Session{Id: "s1", UserId: "u1"})
+	record := &databroker.Record{Type: data.GetTypeUrl(), Id: "s1", Version: 3, Data: data}
+	recordBytes, err := proto.Marshal(record)
+	require.NoError(t, err)
+	recordJSON, err := protojson.Marshal(record)
+	require.NoError(t, err)
+	dataJSON, err := protojson.Marshal(data)
+	require.NoError(t, err)
+
+	plaintext, err := proto.Marshal(data)
+	require.NoError(t, err)
+	encryptedBytes, err := proto.Marshal(&databroker.Record{
+		Type: data.GetTypeUrl(),
+		Id:   "s1",
+		Data: protoutil.NewAny(wrapperspb.Bytes(cryptutil.Encrypt(aead, plaintext, nil))),
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		input []byte
+	}{
+		{"protobuf", recordBytes},
+		{"base64", []byte(base64.StdEncoding.EncodeToString(recordBytes) + "\n")},
+		{"json", recordJSON},
+		{"json any", dataJSON},
+		{"encrypted", encryptedBytes},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := decodeRecord(tc.input, aead)
+			require.NoError(t, err)
+			assert.Equal(t, "type.googleapis.com/session.Session", decoded.GetType())
+			assert.JSONEq(t, `{"id":"s1","userId":"u1"}`, string(newRecord(decoded).Data))
+		})
+	}
+
+	_, err = decodeRecord([]byte("{"), aead)
+	assert.Error(t, err)
+}
+
+func TestDecodeData(t *testing.T) {
+	assert.JSONEq(t, `{"@type":"type.googleapis.com/unknown.Type","value":"AQI="}`,
+		string(decodeData(&anypb.Any{TypeUrl: "type.googleapis.com/unknown.Type", Value: []byte{1, 2}})))
+	assert.Equal(t, "type.googleapis.com/session.Session", getTypeURL("session.Session"))
+	assert.Equal(t, "type.googleapis.com/session.Session", getTypeURL("type.googleapis.com/session.Session"))
+}
@@ -0,0 +1,21 @@
+package databrokercmd
+
+// the packages of the types stored in the databroker register them with the global registry,
+// so their records can be decoded
+import (
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+
+	_ "github.com/pomerium/pomerium/pkg/grpc/audit"
+	_ "github.com/pomerium/pomerium/pkg/grpc/config"
+	_ "github.com/pomerium/pomerium/pkg/grpc/crypt"
+	_ "github.com/pomerium/pomerium/pkg/grpc/device"
+	_ "github.com/pomerium/pomerium/pkg/grpc/directory"
+	_ "github.com/pomerium/pomerium/pkg/grpc/events"
+	_ "github.com/pomerium/pomerium/pkg/grpc/identity"
+	_ "github.com/pomerium/pomerium/pkg/grpc/ipset"
+	_ "github.com/pomerium/pomerium/pkg/grpc/registry"
+	_ "github.com/pomerium/pomerium/pkg/grpc/scim"
+	_ "github.com/pomerium/pomerium/pkg/grpc/session"
+	_ "github.com/pomerium/pomerium/pkg/grpc/user"
+)
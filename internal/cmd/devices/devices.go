@@ -0,0 +1,120 @@
+// Package devices houses the pomerium devices CLI command, which lists, approves and revokes
+// device credentials using the databroker.
+package devices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/grpc/device"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] devices <command> [flags]
+
+commands:
+  list     [-user-id ID] [-status pending|approved]
+  approve  -id ID -approved-by NAME
+  revoke   -id ID
+`
+
+// Run runs the devices command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, src.GetConfig().Options)
+	if err != nil {
+		return err
+	}
+
+	var res proto.Message
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "list":
+		res, err = list(ctx, client, args)
+	case "approve":
+		res, err = approve(ctx, client, args)
+	case "revoke":
+		res, err = revoke(ctx, client, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, res)
+}
+
+func newClient(ctx context.Context, options *config.Options) (device.DeviceServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("devices")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return device.NewDeviceServiceClient(cc), nil
+}
+
+func list(ctx context.Context, client device.DeviceServiceClient, args []string) (proto.Message, error) {
+	req := new(device.ListDevicesRequest)
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.StringVar(&req.UserId, "user-id", "", "only list the devices of the user")
+	fs.StringVar(&req.Status, "status", "", "only list the devices with the status, pending or approved")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.ListDevices(ctx, req)
+}
+
+func approve(ctx context.Context, client device.DeviceServiceClient, args []string) (proto.Message, error) {
+	req := new(device.ApproveDeviceRequest)
+	fs := flag.NewFlagSet("approve", flag.ContinueOnError)
+	fs.StringVar(&req.CredentialId, "id", "", "the id of the device credential")
+	fs.StringVar(&req.ApprovedBy, "approved-by", "", "who approved the device")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.ApproveDevice(ctx, req)
+}
+
+func revoke(ctx context.Context, client device.DeviceServiceClient, args []string) (proto.Message, error) {
+	req := new(device.RevokeDeviceRequest)
+	fs := flag.NewFlagSet("revoke", flag.ContinueOnError)
+	fs.StringVar(&req.CredentialId, "id", "", "the id of the device credential")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.RevokeDevice(ctx, req)
+}
+
+func writeJSON(w io.Writer, msg proto.Message) error {
+	bs, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	// re-indent, since protojson output isn't stable
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,166 @@
+// Package ipsets houses the pomerium ip-sets CLI command, which creates, lists and deletes the IP
+// sets referenced by the ip_set policy criterion, using the databroker.
+package ipsets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/ipset"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] ip-sets <command> [flags]
+
+commands:
+  list
+  create  -id ID -cidr CIDR [-cidr CIDR]... [-description TEXT]
+  delete  -id ID
+`
+
+// Run runs the ip-sets command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, src.GetConfig().Options)
+	if err != nil {
+		return err
+	}
+	return run(ctx, client, args, w)
+}
+
+func run(ctx context.Context, client databroker.DataBrokerServiceClient, args []string, w io.Writer) error {
+	var res interface{}
+	var err error
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "list":
+		res, err = list(ctx, client)
+	case "create":
+		res, err = create(ctx, client, args)
+	case "delete":
+		res, err = remove(ctx, client, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+func newClient(ctx context.Context, options *config.Options) (databroker.DataBrokerServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("ip-sets")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return databroker.NewDataBrokerServiceClient(cc), nil
+}
+
+func list(ctx context.Context, client databroker.DataBrokerServiceClient) (interface{}, error) {
+	ipSets, err := ipset.List(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]interface{}, 0, len(ipSets))
+	for _, ipSet := range ipSets {
+		v, err := toJSON(ipSet)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return map[string]interface{}{"ipSets": res}, nil
+}
+
+// create creates an IP set, or replaces the IP set with the same id, so that changing the CIDRs
+// of an IP set doesn't leave policies without it in between.
+func create(ctx context.Context, client databroker.DataBrokerServiceClient, args []string) (interface{}, error) {
+	ipSet := new(ipset.IPSet)
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	fs.StringVar(&ipSet.Id, "id", "", "the name of the ip set, referenced by the ip_set criterion")
+	fs.Var((*stringsFlag)(&ipSet.Cidrs), "cidr", "a CIDR or IP address in the ip set, may be repeated")
+	fs.StringVar(&ipSet.Description, "description", "", "a description of the ip set")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if len(ipSet.Cidrs) == 0 {
+		return nil, errors.New("at least one -cidr is required")
+	}
+
+	if err := ipset.Put(ctx, client, ipSet); err != nil {
+		return nil, err
+	}
+	return toJSON(ipSet)
+}
+
+func remove(ctx context.Context, client databroker.DataBrokerServiceClient, args []string) (interface{}, error) {
+	var id string
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	fs.StringVar(&id, "id", "", "the name of the ip set")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, errors.New("an ip set id is required")
+	}
+
+	ipSet, err := ipset.Delete(ctx, client, id)
+	if err != nil {
+		return nil, err
+	} else if ipSet == nil {
+		return nil, fmt.Errorf("ip set %s doesn't exist", id)
+	}
+	return toJSON(ipSet)
+}
+
+// toJSON converts a protobuf message into a value which is encoded with stable indentation,
+// since protojson output isn't stable.
+func toJSON(msg proto.Message) (interface{}, error) {
+	bs, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// stringsFlag is a flag which may be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
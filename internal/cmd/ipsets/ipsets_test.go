@@ -0,0 +1,76 @@
+package ipsets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/ipset"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	li := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(srv, internal_databroker.New())
+	go func() { _ = srv.Serve(li) }()
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return li.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := databroker.NewDataBrokerServiceClient(cc)
+
+	list := func() []map[string]interface{} {
+		var buf bytes.Buffer
+		require.NoError(t, run(ctx, client, []string{"list"}, &buf))
+		var res struct {
+			IPSets []map[string]interface{} `json:"ipSets"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		return res.IPSets
+	}
+
+	assert.Empty(t, list())
+
+	assert.Error(t, run(ctx, client, []string{"create", "-id", "office"}, new(bytes.Buffer)),
+		"should require a cidr")
+	assert.Error(t, run(ctx, client, []string{"create", "-id", "office", "-cidr", "not-an-ip"}, new(bytes.Buffer)),
+		"should validate the cidrs")
+	require.NoError(t, run(ctx, client, []string{
+		"create", "-id", "office", "-cidr", "10.1.2.3/8", "-cidr", "192.168.1.1", "-description", "office network",
+	}, new(bytes.Buffer)))
+
+	ipSets := list()
+	if assert.Len(t, ipSets, 1) {
+		assert.Equal(t, "office", ipSets[0]["id"])
+		assert.Equal(t, []interface{}{"10.0.0.0/8", "192.168.1.1/32"}, ipSets[0]["cidrs"])
+		assert.Equal(t, "office network", ipSets[0]["description"])
+	}
+
+	require.NoError(t, run(ctx, client, []string{"create", "-id", "office", "-cidr", "10.0.0.0/16"}, new(bytes.Buffer)))
+	ipSet, err := ipset.Get(ctx, client, "office")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/16"}, ipSet.GetCidrs(), "should replace the ip set")
+
+	assert.NoError(t, run(ctx, client, []string{"delete", "-id", "office"}, new(bytes.Buffer)))
+	assert.Empty(t, list())
+	assert.Error(t, run(ctx, client, []string{"delete", "-id", "office"}, new(bytes.Buffer)),
+		"should fail to delete a missing ip set")
+}
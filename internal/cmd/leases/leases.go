@@ -0,0 +1,126 @@
+// Package leases houses the pomerium leases CLI command, which shows the databroker leases
+// components use to elect a leader, and releases them for maintenance.
+package leases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] leases <command> [flags]
+
+commands:
+  list
+  release  -name NAME [-id ID]
+`
+
+// Run runs the leases command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, src.GetConfig().Options)
+	if err != nil {
+		return err
+	}
+	return run(ctx, client, args, w)
+}
+
+func run(ctx context.Context, client databroker.DataBrokerServiceClient, args []string, w io.Writer) error {
+	var res proto.Message
+	var err error
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "list":
+		res, err = client.ListLeases(ctx, new(databroker.ListLeasesRequest))
+	case "release":
+		res, err = release(ctx, client, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, res)
+}
+
+func newClient(ctx context.Context, options *config.Options) (databroker.DataBrokerServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("leases")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return databroker.NewDataBrokerServiceClient(cc), nil
+}
+
+// release releases a lease, regardless of its holder, so another instance can acquire it. The
+// holder stops running the leased work once it fails to renew the lease.
+func release(ctx context.Context, client databroker.DataBrokerServiceClient, args []string) (proto.Message, error) {
+	req := new(databroker.ReleaseLeaseRequest)
+	fs := flag.NewFlagSet("release", flag.ContinueOnError)
+	fs.StringVar(&req.Name, "name", "", "the name of the lease")
+	fs.StringVar(&req.Id, "id", "", "only release the lease if it has this id")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errors.New("a lease name is required")
+	}
+
+	if req.Id == "" {
+		res, err := client.ListLeases(ctx, new(databroker.ListLeasesRequest))
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range res.GetLeases() {
+			if l.GetName() == req.Name && l.GetExpiresAt().AsTime().After(time.Now()) {
+				req.Id = l.GetId()
+			}
+		}
+		if req.Id == "" {
+			return nil, fmt.Errorf("lease %s isn't held", req.Name)
+		}
+	}
+
+	if _, err := client.ReleaseLease(ctx, req); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+func writeJSON(w io.Writer, msg proto.Message) error {
+	bs, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	// re-indent, since protojson output isn't stable
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,74 @@
+package leases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	li := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(srv, internal_databroker.New())
+	go func() { _ = srv.Serve(li) }()
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return li.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := databroker.NewDataBrokerServiceClient(cc)
+
+	acquired, err := client.AcquireLease(ctx, &databroker.AcquireLeaseRequest{
+		Name:     "identity_manager",
+		Duration: durationpb.New(time.Minute),
+		Holder:   "pomerium-1:123",
+	})
+	require.NoError(t, err)
+
+	list := func() []map[string]interface{} {
+		var buf bytes.Buffer
+		require.NoError(t, run(ctx, client, []string{"list"}, &buf))
+		var res struct {
+			Leases []map[string]interface{} `json:"leases"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		return res.Leases
+	}
+
+	leases := list()
+	if assert.Len(t, leases, 1) {
+		assert.Equal(t, "identity_manager", leases[0]["name"])
+		assert.Equal(t, acquired.GetId(), leases[0]["id"])
+		assert.Equal(t, "pomerium-1:123", leases[0]["holder"])
+	}
+
+	assert.Error(t, run(ctx, client, []string{"release", "-name", "signing_key_rotator"}, new(bytes.Buffer)))
+	assert.NoError(t, run(ctx, client, []string{"release", "-name", "identity_manager"}, new(bytes.Buffer)))
+	assert.Empty(t, list())
+
+	_, err = client.RenewLease(ctx, &databroker.RenewLeaseRequest{
+		Name:     "identity_manager",
+		Id:       acquired.GetId(),
+		Duration: durationpb.New(time.Minute),
+	})
+	assert.Error(t, err, "the released lease shouldn't be renewed")
+}
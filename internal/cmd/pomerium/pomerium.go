@@ -23,10 +23,15 @@ import (
 	"github.com/pomerium/pomerium/internal/databroker"
 	"github.com/pomerium/pomerium/internal/envoy"
 	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/fips"
+	"github.com/pomerium/pomerium/internal/gatewayapi"
+	"github.com/pomerium/pomerium/internal/gitops"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/registry"
+	"github.com/pomerium/pomerium/internal/secretmem"
 	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/internal/version"
+	authorizepb "github.com/pomerium/pomerium/pkg/grpc/authorize"
 	"github.com/pomerium/pomerium/proxy"
 )
 
@@ -35,8 +40,12 @@ func Run(ctx context.Context, configFile string) error {
 	log.Info(ctx).
 		Str("envoy_version", files.FullVersion()).
 		Str("version", version.FullVersion()).
+		Bool("fips", fips.Enabled()).
 		Msg("cmd/pomerium")
 
+	// zero the secrets when pomerium exits
+	defer secretmem.DestroyAll()
+
 	var src config.Source
 
 	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
@@ -45,6 +54,8 @@ func Run(ctx context.Context, configFile string) error {
 	}
 
 	src = databroker.NewConfigSource(ctx, src)
+	src = gitops.NewConfigSource(ctx, src)
+	src = gatewayapi.NewConfigSource(ctx, src)
 	logMgr := config.NewLogManager(ctx, src)
 	defer logMgr.Close()
 
@@ -56,6 +67,11 @@ func Run(ctx context.Context, configFile string) error {
 		return err
 	}
 
+	// zero the secrets which were rotated out of the config
+	src.OnConfigChange(ctx, func(ctx context.Context, cfg *config.Config) {
+		secretmem.Retain(cfg.Options.GetSecrets()...)
+	})
+
 	// override the default http transport so we can use the custom CA in the TLS client config (#1570)
 	http.DefaultTransport = config.NewHTTPTransport(src)
 
@@ -63,6 +79,10 @@ func Run(ctx context.Context, configFile string) error {
 	defer metricsMgr.Close()
 	traceMgr := config.NewTraceManager(ctx, src)
 	defer traceMgr.Close()
+	auditMgr := config.NewAuditManager(ctx, src)
+	defer auditMgr.Close()
+	certMonitor := config.NewCertificateMonitor(ctx, src)
+	defer certMonitor.Close()
 
 	// setup the control plane
 	controlPlane, err := controlplane.NewServer(src.GetConfig(), metricsMgr)
@@ -98,7 +118,8 @@ func Run(ctx context.Context, configFile string) error {
 	defer envoyServer.Close()
 
 	// add services
-	if err := setupAuthenticate(ctx, src, controlPlane); err != nil {
+	authenticateServer, err := setupAuthenticate(ctx, src, controlPlane)
+	if err != nil {
 		return err
 	}
 	var authorizeServer *authorize.Authorize
@@ -140,6 +161,11 @@ func Run(ctx context.Context, configFile string) error {
 
 	// run everything
 	eg, ctx := errgroup.WithContext(ctx)
+	if authenticateServer != nil {
+		eg.Go(func() error {
+			return authenticateServer.Run(ctx)
+		})
+	}
 	if authorizeServer != nil {
 		eg.Go(func() error {
 			return authorizeServer.Run(ctx)
@@ -156,19 +182,19 @@ func Run(ctx context.Context, configFile string) error {
 	return eg.Wait()
 }
 
-func setupAuthenticate(ctx context.Context, src config.Source, controlPlane *controlplane.Server) error {
+func setupAuthenticate(ctx context.Context, src config.Source, controlPlane *controlplane.Server) (*authenticate.Authenticate, error) {
 	if !config.IsAuthenticate(src.GetConfig().Options.Services) {
-		return nil
+		return nil, nil
 	}
 
 	svc, err := authenticate.New(src.GetConfig())
 	if err != nil {
-		return fmt.Errorf("error creating authenticate service: %w", err)
+		return nil, fmt.Errorf("error creating authenticate service: %w", err)
 	}
 
 	authenticateURL, err := src.GetConfig().Options.GetInternalAuthenticateURL()
 	if err != nil {
-		return fmt.Errorf("error getting authenticate URL: %w", err)
+		return nil, fmt.Errorf("error getting authenticate URL: %w", err)
 	}
 
 	src.OnConfigChange(ctx, svc.OnConfigChange)
@@ -178,7 +204,7 @@ func setupAuthenticate(ctx context.Context, src config.Source, controlPlane *con
 	svc.Mount(sr)
 	log.Info(context.TODO()).Str("host", host).Msg("enabled authenticate service")
 
-	return nil
+	return svc, nil
 }
 
 func setupAuthorize(ctx context.Context, src config.Source, controlPlane *controlplane.Server) (*authorize.Authorize, error) {
@@ -187,6 +213,7 @@ func setupAuthorize(ctx context.Context, src config.Source, controlPlane *contro
 		return nil, fmt.Errorf("error creating authorize service: %w", err)
 	}
 	envoy_service_auth_v3.RegisterAuthorizationServer(controlPlane.GRPCServer, svc)
+	authorizepb.RegisterAuthorizeServiceServer(controlPlane.GRPCServer, svc)
 
 	log.Info(context.TODO()).Msg("enabled authorize service")
 	src.OnConfigChange(ctx, svc.OnConfigChange)
@@ -0,0 +1,329 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIMethods are the operations of a path item, in the order they're reported.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openAPIDocument is the subset of an OpenAPI 3 or Swagger 2 document used to generate routes.
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+
+	// OpenAPI 3
+	Servers    []openAPIServer `yaml:"servers"`
+	Components struct {
+		SecuritySchemes map[string]openAPISecurityScheme `yaml:"securitySchemes"`
+	} `yaml:"components"`
+
+	// Swagger 2
+	Host                string                           `yaml:"host"`
+	BasePath            string                           `yaml:"basePath"`
+	Schemes             []string                         `yaml:"schemes"`
+	SecurityDefinitions map[string]openAPISecurityScheme `yaml:"securityDefinitions"`
+
+	Security []map[string][]string `yaml:"security"`
+	// Paths are decoded lazily, since path items also have fields other than operations.
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+type openAPIServer struct {
+	URL       string `yaml:"url"`
+	Variables map[string]struct {
+		Default string `yaml:"default"`
+	} `yaml:"variables"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+	In     string `yaml:"in"`
+	Name   string `yaml:"name"`
+}
+
+type openAPIOperation struct {
+	// Security is nil when the operation uses the security requirements of the document.
+	Security  *[]map[string][]string `yaml:"security"`
+	Responses map[string]struct {
+		Headers map[string]interface{} `yaml:"headers"`
+	} `yaml:"responses"`
+}
+
+// A generatedRoute is a route generated from an OpenAPI document. Hints about the route that
+// need to be reviewed are added as comments.
+type generatedRoute struct {
+	From                             string   `yaml:"from"`
+	To                               []string `yaml:"to"`
+	Prefix                           string   `yaml:"prefix,omitempty"`
+	CORSAllowPreflight               bool     `yaml:"cors_allow_preflight,omitempty"`
+	AllowPublicUnauthenticatedAccess bool     `yaml:"allow_public_unauthenticated_access,omitempty"`
+	AllowAnyAuthenticatedUser        bool     `yaml:"allow_any_authenticated_user,omitempty"`
+
+	hints []string
+}
+
+// parseOpenAPIDocument parses an OpenAPI 3 or Swagger 2 document in JSON or YAML.
+func parseOpenAPIDocument(raw []byte) (*openAPIDocument, error) {
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") && !strings.HasPrefix(doc.Swagger, "2.") {
+		return nil, fmt.Errorf("unsupported openapi document: only OpenAPI 3 and Swagger 2 are supported")
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("invalid openapi document: no paths are defined")
+	}
+	return &doc, nil
+}
+
+// getServerURL returns the URL of the server of the API, which is the first server of an
+// OpenAPI 3 document, with its variables set to their defaults.
+func (doc *openAPIDocument) getServerURL() string {
+	if doc.Swagger != "" {
+		if doc.Host == "" {
+			return doc.BasePath
+		}
+		scheme := "https"
+		if len(doc.Schemes) > 0 {
+			scheme = doc.Schemes[0]
+		}
+		return scheme + "://" + doc.Host + doc.BasePath
+	}
+
+	if len(doc.Servers) == 0 {
+		return ""
+	}
+	server := doc.Servers[0]
+	serverURL := server.URL
+	for name, v := range server.Variables {
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", v.Default)
+	}
+	return serverURL
+}
+
+func (doc *openAPIDocument) getSecuritySchemes() map[string]openAPISecurityScheme {
+	if doc.Swagger != "" {
+		return doc.SecurityDefinitions
+	}
+	return doc.Components.SecuritySchemes
+}
+
+// generateRoutes generates a route for each top-level path segment of the document, so the
+// operations of each resource can be given their own policy. Paths starting with a template
+// share a route for the base path of the API.
+func generateRoutes(doc *openAPIDocument, from, to string) ([]*generatedRoute, error) {
+	serverURL := doc.getServerURL()
+	if to == "" {
+		to = serverURL
+	}
+	upstream, err := url.Parse(to)
+	if err != nil || upstream.Scheme == "" || upstream.Host == "" {
+		return nil, fmt.Errorf("an absolute upstream url is required, the document's server url is %q, use -to to set one", serverURL)
+	}
+
+	// the base path of the server is kept in the prefix of the routes, rather than the
+	// upstream url, so requests are proxied with their path unchanged
+	basePath := upstream.Path
+	if u, err := url.Parse(serverURL); err == nil && u.Path != "" {
+		basePath = u.Path
+	}
+	basePath = strings.TrimSuffix(basePath, "/")
+	upstream.Path, upstream.RawQuery, upstream.Fragment = "", "", ""
+
+	type group struct {
+		operations []string
+		public     int
+		secured    int
+		cors       bool
+		schemes    map[string]struct{}
+	}
+	groups := map[string]*group{}
+	for p, item := range doc.Paths {
+		prefix := basePath + "/" + getFirstSegment(p)
+		g, ok := groups[prefix]
+		if !ok {
+			g = &group{schemes: map[string]struct{}{}}
+			groups[prefix] = g
+		}
+		for _, method := range openAPIMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op openAPIOperation
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("invalid %s operation of %s: %w", method, p, err)
+			}
+			g.operations = append(g.operations, strings.ToUpper(method)+" "+path.Join(basePath, p))
+
+			security := doc.Security
+			if op.Security != nil {
+				security = *op.Security
+			}
+			if isPublic(security) {
+				g.public++
+			} else {
+				g.secured++
+			}
+			for _, requirement := range security {
+				for name := range requirement {
+					g.schemes[name] = struct{}{}
+				}
+			}
+			if method == "options" || hasCORSHeaders(op) {
+				g.cors = true
+			}
+		}
+	}
+
+	var prefixes []string
+	for prefix := range groups {
+		prefixes = append(prefixes, prefix)
+	}
+	// the route of the base path matches every request, so it's last
+	sort.Slice(prefixes, func(i, j int) bool {
+		if isBase := prefixes[i] == basePath+"/"; isBase != (prefixes[j] == basePath+"/") {
+			return !isBase
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	securitySchemes := doc.getSecuritySchemes()
+	var routes []*generatedRoute
+	for _, prefix := range prefixes {
+		g := groups[prefix]
+		if len(g.operations) == 0 {
+			continue
+		}
+		sort.Strings(g.operations)
+
+		r := &generatedRoute{
+			From:               from,
+			To:                 []string{upstream.String()},
+			Prefix:             prefix,
+			CORSAllowPreflight: g.cors,
+		}
+		if r.Prefix == "/" {
+			r.Prefix = ""
+		}
+		r.hints = append(r.hints, "operations: "+strings.Join(g.operations, ", "))
+		switch {
+		case g.secured == 0:
+			r.AllowPublicUnauthenticatedAccess = true
+			r.hints = append(r.hints, "the operations don't require authentication")
+		default:
+			r.AllowAnyAuthenticatedUser = true
+			if g.public > 0 {
+				r.hints = append(r.hints, "some of the operations don't require authentication")
+			}
+			for _, name := range sortedKeys(g.schemes) {
+				r.hints = append(r.hints, "security scheme "+describeSecurityScheme(name, securitySchemes[name]))
+			}
+		}
+		if g.cors {
+			r.hints = append(r.hints, "the api handles CORS, preflight requests are allowed without authentication")
+		}
+		routes = append(routes, r)
+	}
+	return routes, nil
+}
+
+// encodeRoutes encodes the routes as a YAML config, with their hints as comments.
+func encodeRoutes(routes []*generatedRoute) ([]byte, error) {
+	var list yaml.Node
+	list.Kind = yaml.SequenceNode
+	for _, r := range routes {
+		var n yaml.Node
+		if err := n.Encode(r); err != nil {
+			return nil, err
+		}
+		n.HeadComment = strings.Join(r.hints, "\n")
+		list.Content = append(list.Content, &n)
+	}
+
+	doc := yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "routes"},
+		&list,
+	}}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getFirstSegment returns the first segment of the path, or an empty string if it's a
+// template, like {id}.
+func getFirstSegment(p string) string {
+	segment := strings.SplitN(strings.TrimPrefix(p, "/"), "/", 2)[0]
+	if strings.Contains(segment, "{") {
+		return ""
+	}
+	return segment
+}
+
+// isPublic returns true if the security requirements can be met without credentials, which is
+// when there are none, or one of them is empty.
+func isPublic(security []map[string][]string) bool {
+	if len(security) == 0 {
+		return true
+	}
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCORSHeaders(op openAPIOperation) bool {
+	for _, res := range op.Responses {
+		for name := range res.Headers {
+			if strings.HasPrefix(strings.ToLower(name), "access-control-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func describeSecurityScheme(name string, scheme openAPISecurityScheme) string {
+	switch scheme.Type {
+	case "":
+		return name + " is not defined"
+	case "http":
+		return fmt.Sprintf("%s: http %s, the upstream receives the Authorization header of the request", name, scheme.Scheme)
+	case "basic":
+		return name + ": http basic, the upstream receives the Authorization header of the request"
+	case "apiKey":
+		return fmt.Sprintf("%s: api key in the %s %s", name, scheme.In, scheme.Name)
+	case "oauth2", "openIdConnect":
+		return fmt.Sprintf("%s: %s, consider a policy on the claims of the identity provider", name, scheme.Type)
+	default:
+		return name + ": " + scheme.Type
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
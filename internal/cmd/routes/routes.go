@@ -0,0 +1,94 @@
+// Package routes houses the pomerium routes CLI command, which generates route definitions to
+// speed up onboarding upstreams.
+package routes
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+const usage = `usage: pomerium routes <command> [flags]
+
+commands:
+  import-openapi  -from URL [-to URL] FILE|URL
+`
+
+// Run runs the routes command with the given arguments. Generated routes are written to w as
+// YAML.
+func Run(ctx context.Context, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "import-openapi":
+		return importOpenAPI(ctx, args, w)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+}
+
+// importOpenAPI writes the routes generated from an OpenAPI document.
+func importOpenAPI(ctx context.Context, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("import-openapi", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	from := fs.String("from", "", "the external url of the routes")
+	to := fs.String("to", "", "the upstream url, defaults to the first server of the document")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, usage)
+	}
+	if fs.NArg() != 1 || *from == "" {
+		return errors.New(usage)
+	}
+	if _, err := urlutil.ParseAndValidateURL(*from); err != nil {
+		return fmt.Errorf("invalid -from url: %w", err)
+	}
+
+	raw, err := readDocument(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	doc, err := parseOpenAPIDocument(raw)
+	if err != nil {
+		return err
+	}
+	routes, err := generateRoutes(doc, *from, *to)
+	if err != nil {
+		return err
+	}
+	bs, err := encodeRoutes(routes)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bs)
+	return err
+}
+
+// readDocument reads the document from a file, or an http or https url.
+func readDocument(ctx context.Context, location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.ReadFile(location)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching openapi document: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching openapi document: unexpected status code %d", res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
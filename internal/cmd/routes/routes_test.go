@@ -0,0 +1,150 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+const openAPI3Document = `
+openapi: 3.0.3
+servers:
+- url: https://{region}.api.example.com/v1
+  variables:
+    region:
+      default: us
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+security:
+- bearer: []
+paths:
+  /users:
+    parameters:
+    - name: limit
+      in: query
+    get: {}
+    post: {}
+    options:
+      security: []
+  /users/{id}:
+    get: {}
+  /health:
+    get:
+      security: []
+  /{resource}:
+    get: {}
+`
+
+const swagger2Document = `{
+  "swagger": "2.0",
+  "host": "petstore.example.com",
+  "basePath": "/api",
+  "schemes": ["http"],
+  "securityDefinitions": {
+    "key": {"type": "apiKey", "in": "header", "name": "X-API-Key"}
+  },
+  "paths": {
+    "/pets": {
+      "get": {
+        "security": [{"key": []}],
+        "responses": {
+          "200": {"headers": {"Access-Control-Allow-Origin": {"type": "string"}}}
+        }
+      }
+    },
+    "/version": {"get": {}}
+  }
+}`
+
+func TestRun(t *testing.T) {
+	run := func(t *testing.T, args ...string) []generatedRoute {
+		var buf bytes.Buffer
+		require.NoError(t, Run(context.Background(), args, &buf))
+
+		var res struct {
+			Routes []generatedRoute `yaml:"routes"`
+		}
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &res), buf.String())
+		for _, r := range res.Routes {
+			to, err := config.ParseWeightedUrls(r.To...)
+			require.NoError(t, err)
+			p := config.Policy{
+				From:                             r.From,
+				To:                               to,
+				Prefix:                           r.Prefix,
+				CORSAllowPreflight:               r.CORSAllowPreflight,
+				AllowPublicUnauthenticatedAccess: r.AllowPublicUnauthenticatedAccess,
+				AllowAnyAuthenticatedUser:        r.AllowAnyAuthenticatedUser,
+			}
+			require.NoError(t, p.Validate())
+		}
+		return res.Routes
+	}
+	writeDocument := func(t *testing.T, contents string) string {
+		fn := filepath.Join(t.TempDir(), "openapi")
+		require.NoError(t, os.WriteFile(fn, []byte(contents), 0o600))
+		return fn
+	}
+
+	t.Run("openapi 3", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Run(context.Background(), []string{"import-openapi", "-from", "https://api.example.com", writeDocument(t, openAPI3Document)}, &buf))
+		assert.Contains(t, buf.String(), "# operations: GET /v1/users, GET /v1/users/{id}, OPTIONS /v1/users, POST /v1/users")
+		assert.Contains(t, buf.String(), "# security scheme bearer: http bearer")
+
+		routes := run(t, "import-openapi", "-from", "https://api.example.com", writeDocument(t, openAPI3Document))
+		require.Len(t, routes, 3)
+
+		assert.Equal(t, "/v1/health", routes[0].Prefix)
+		assert.True(t, routes[0].AllowPublicUnauthenticatedAccess)
+
+		assert.Equal(t, "/v1/users", routes[1].Prefix)
+		assert.Equal(t, "https://us.api.example.com", routes[1].To[0])
+		assert.True(t, routes[1].AllowAnyAuthenticatedUser)
+		assert.True(t, routes[1].CORSAllowPreflight)
+
+		assert.Equal(t, "/v1/", routes[2].Prefix, "paths starting with a template should use the base path")
+		assert.False(t, routes[2].CORSAllowPreflight)
+	})
+	t.Run("swagger 2", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(swagger2Document))
+		}))
+		t.Cleanup(srv.Close)
+
+		routes := run(t, "import-openapi", "-from", "https://pets.example.com", "-to", "http://pets.internal:8080", srv.URL)
+		require.Len(t, routes, 2)
+
+		assert.Equal(t, "/api/pets", routes[0].Prefix)
+		assert.Equal(t, "http://pets.internal:8080", routes[0].To[0])
+		assert.True(t, routes[0].AllowAnyAuthenticatedUser)
+		assert.True(t, routes[0].CORSAllowPreflight)
+
+		assert.Equal(t, "/api/version", routes[1].Prefix)
+		assert.True(t, routes[1].AllowPublicUnauthenticatedAccess)
+	})
+	t.Run("errors", func(t *testing.T) {
+		err := Run(context.Background(), []string{"import-openapi", writeDocument(t, openAPI3Document)}, io.Discard)
+		assert.Error(t, err, "-from should be required")
+
+		err = Run(context.Background(), []string{"import-openapi", "-from", "https://api.example.com", writeDocument(t, `{"openapi":"1.0"}`)}, io.Discard)
+		assert.ErrorContains(t, err, "unsupported openapi document")
+
+		err = Run(context.Background(), []string{"import-openapi", "-from", "https://api.example.com", writeDocument(t, `{"openapi":"3.0.0","paths":{"/":{"get":{}}}}`)}, io.Discard)
+		assert.ErrorContains(t, err, "use -to to set one")
+	})
+}
@@ -0,0 +1,154 @@
+// Package serviceaccounts houses the pomerium service-accounts CLI command, which manages
+// service accounts using the databroker.
+package serviceaccounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/grpc/user"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] service-accounts <command> [flags]
+
+commands:
+  create  -user-id ID [-description TEXT] [-audience HOST]... [-expires-in DURATION]
+  list    [-user-id ID]
+  rotate  -id ID
+  revoke  -id ID
+`
+
+// Run runs the service-accounts command with the given arguments. Results are written to w
+// as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, src.GetConfig().Options)
+	if err != nil {
+		return err
+	}
+
+	var res proto.Message
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "create":
+		res, err = create(ctx, client, args)
+	case "list":
+		res, err = list(ctx, client, args)
+	case "rotate":
+		res, err = rotate(ctx, client, args)
+	case "revoke":
+		res, err = revoke(ctx, client, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, res)
+}
+
+func newClient(ctx context.Context, options *config.Options) (user.ServiceAccountServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("service-accounts")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return user.NewServiceAccountServiceClient(cc), nil
+}
+
+func create(ctx context.Context, client user.ServiceAccountServiceClient, args []string) (proto.Message, error) {
+	req := new(user.CreateServiceAccountRequest)
+	var expiresIn time.Duration
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	fs.StringVar(&req.UserId, "user-id", "", "the id of the user the service account acts as")
+	fs.StringVar(&req.Description, "description", "", "a description of the service account")
+	fs.Var((*stringsFlag)(&req.Audiences), "audience", "a route host the service account may be used for, may be repeated")
+	fs.DurationVar(&expiresIn, "expires-in", 0, "how long the service account is valid for, zero never expires")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if expiresIn != 0 {
+		req.ExpiresIn = durationpb.New(expiresIn)
+	}
+	return client.CreateServiceAccount(ctx, req)
+}
+
+func list(ctx context.Context, client user.ServiceAccountServiceClient, args []string) (proto.Message, error) {
+	req := new(user.ListServiceAccountsRequest)
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.StringVar(&req.UserId, "user-id", "", "only list the service accounts of the user")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.ListServiceAccounts(ctx, req)
+}
+
+func rotate(ctx context.Context, client user.ServiceAccountServiceClient, args []string) (proto.Message, error) {
+	req := new(user.RotateServiceAccountRequest)
+	fs := flag.NewFlagSet("rotate", flag.ContinueOnError)
+	fs.StringVar(&req.Id, "id", "", "the id of the service account")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.RotateServiceAccount(ctx, req)
+}
+
+func revoke(ctx context.Context, client user.ServiceAccountServiceClient, args []string) (proto.Message, error) {
+	req := new(user.RevokeServiceAccountRequest)
+	fs := flag.NewFlagSet("revoke", flag.ContinueOnError)
+	fs.StringVar(&req.Id, "id", "", "the id of the service account")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.RevokeServiceAccount(ctx, req)
+}
+
+func writeJSON(w io.Writer, msg proto.Message) error {
+	bs, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	// re-indent, since protojson output isn't stable
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// stringsFlag is a flag which may be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package sessions houses the pomerium sessions CLI command, which lists and revokes sessions
+// using the databroker.
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+const usage = `usage: pomerium [-config file] sessions <command> [flags]
+
+commands:
+  list    [-user-id ID] [-email EMAIL]
+  revoke  -id ID | -user-id ID
+`
+
+// Run runs the sessions command with the given arguments. Results are written to w as JSON.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, src.GetConfig().Options)
+	if err != nil {
+		return err
+	}
+
+	var res proto.Message
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "list":
+		res, err = list(ctx, client, args)
+	case "revoke":
+		res, err = revoke(ctx, client, args)
+	default:
+		return fmt.Errorf("unknown command: %s\n%s", cmd, usage)
+	}
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, res)
+}
+
+func newClient(ctx context.Context, options *config.Options) (session.SessionServiceClient, error) {
+	poolOptions, err := options.GetDataBrokerPoolOptions("sessions")
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := grpcutil.NewGRPCClientConnPool(ctx, poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the databroker: %w", err)
+	}
+	return session.NewSessionServiceClient(cc), nil
+}
+
+func list(ctx context.Context, client session.SessionServiceClient, args []string) (proto.Message, error) {
+	req := new(session.ListSessionsRequest)
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.StringVar(&req.UserId, "user-id", "", "only list the sessions of the user")
+	fs.StringVar(&req.Email, "email", "", "only list the sessions of the users with the email")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return client.ListSessions(ctx, req)
+}
+
+func revoke(ctx context.Context, client session.SessionServiceClient, args []string) (proto.Message, error) {
+	var id, userID string
+	fs := flag.NewFlagSet("revoke", flag.ContinueOnError)
+	fs.StringVar(&id, "id", "", "the id of the session")
+	fs.StringVar(&userID, "user-id", "", "revoke all the sessions of the user")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case id != "" && userID != "":
+		return nil, errors.New("only one of -id or -user-id may be set")
+	case userID != "":
+		return client.RevokeUserSessions(ctx, &session.RevokeUserSessionsRequest{UserId: userID})
+	default:
+		return client.RevokeSession(ctx, &session.RevokeSessionRequest{Id: id})
+	}
+}
+
+func writeJSON(w io.Writer, msg proto.Message) error {
+	bs, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	// re-indent, since protojson output isn't stable
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,240 @@
+// Package validate houses the pomerium validate CLI command, which checks a config and reports
+// its findings as JSON, so it can be used to gate config changes in CI.
+package validate
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+const usage = `usage: pomerium [-config file] validate [-strict] [-check-idp]
+`
+
+// certificateExpiryWarning is how long before a certificate expires a warning is reported.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// ErrInvalid is returned when the config has errors, or warnings in strict mode.
+var ErrInvalid = errors.New("validate: config is invalid")
+
+// A Severity is the severity of a finding.
+type Severity string
+
+// Severities of findings.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// A Finding is a problem found in the config.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Check    string   `json:"check"`
+	Route    string   `json:"route,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// A Result is the result of validating a config.
+type Result struct {
+	Valid    bool      `json:"valid"`
+	Findings []Finding `json:"findings"`
+}
+
+type validator struct {
+	checkIdP   bool
+	httpClient *http.Client
+	now        func() time.Time
+
+	findings []Finding
+}
+
+// Run runs the validate command with the given arguments. The result is written to w as JSON,
+// and ErrInvalid is returned if the config has errors, or if strict is set, warnings.
+func Run(ctx context.Context, configFile string, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	strict := fs.Bool("strict", false, "treat warnings as errors")
+	checkIdP := fs.Bool("check-idp", false, "check the identity providers are reachable")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, usage)
+	}
+
+	v := &validator{
+		checkIdP:   *checkIdP,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+	}
+	res := v.validate(ctx, configFile, *strict)
+	if err := writeJSON(w, res); err != nil {
+		return err
+	}
+	if !res.Valid {
+		return ErrInvalid
+	}
+	return nil
+}
+
+func (v *validator) validate(ctx context.Context, configFile string, strict bool) *Result {
+	src, err := config.NewFileOrEnvironmentSource(configFile, files.FullVersion())
+	if err != nil {
+		v.add(SeverityError, "config", "", err.Error())
+	} else {
+		cfg := src.GetConfig()
+		v.validateCertificates(cfg)
+		v.validateRoutes(ctx, cfg.Options)
+		if v.checkIdP {
+			v.validateIdentityProviders(ctx, cfg.Options)
+		}
+	}
+
+	res := &Result{Valid: true, Findings: v.findings}
+	if res.Findings == nil {
+		res.Findings = []Finding{}
+	}
+	for _, f := range res.Findings {
+		if f.Severity == SeverityError || strict {
+			res.Valid = false
+		}
+	}
+	return res
+}
+
+func (v *validator) add(severity Severity, check, route, format string, args ...interface{}) {
+	v.findings = append(v.findings, Finding{
+		Severity: severity,
+		Check:    check,
+		Route:    route,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// validateCertificates checks the certificates haven't expired, and that there is a certificate
+// for every route when they aren't issued with autocert.
+func (v *validator) validateCertificates(cfg *config.Config) {
+	certs, err := cfg.AllCertificates()
+	if err != nil {
+		v.add(SeverityError, "certificate", "", "%s", err)
+		return
+	}
+
+	var leafs []*x509.Certificate
+	for _, cert := range certs {
+		if len(cert.Certificate) == 0 {
+			continue
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			v.add(SeverityError, "certificate", "", "invalid certificate: %s", err)
+			continue
+		}
+		leafs = append(leafs, leaf)
+
+		switch {
+		case v.now().After(leaf.NotAfter):
+			v.add(SeverityError, "certificate", "", "certificate %s expired at %s",
+				leaf.Subject.CommonName, leaf.NotAfter.Format(time.RFC3339))
+		case v.now().Add(certificateExpiryWarning).After(leaf.NotAfter):
+			v.add(SeverityWarning, "certificate", "", "certificate %s expires at %s",
+				leaf.Subject.CommonName, leaf.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	if cfg.Options.AutocertOptions.Enable || cfg.Options.InsecureServer {
+		return
+	}
+	for _, p := range cfg.Options.GetAllPolicies() {
+		if p.Source == nil || urlutil.IsTCP(p.Source.URL) || urlutil.IsUDP(p.Source.URL) {
+			continue
+		}
+		hostname := p.Source.Hostname()
+		if !hasCertificateForHostname(leafs, hostname) {
+			v.add(SeverityWarning, "certificate", p.From,
+				"no certificate found for %s, a self-signed certificate will be used", hostname)
+		}
+	}
+}
+
+func hasCertificateForHostname(leafs []*x509.Certificate, hostname string) bool {
+	for _, leaf := range leafs {
+		if leaf.VerifyHostname(hostname) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRoutes checks the policies of the routes compile and that no route is shadowed by an
+// earlier route with the same matcher.
+func (v *validator) validateRoutes(ctx context.Context, options *config.Options) {
+	seen := map[string]string{}
+	for _, p := range options.GetAllPolicies() {
+		p := p
+
+		if err := evaluator.ValidatePolicy(ctx, &p); err != nil {
+			v.add(SeverityError, "policy", p.From, "error compiling policy: %s", err)
+		}
+
+		key := strings.Join([]string{p.From, p.Prefix, p.Path, p.Regex}, "|")
+		if name, ok := seen[key]; ok {
+			v.add(SeverityWarning, "route", p.From,
+				"route %s is unreachable, it has the same matcher as route %s", getRouteName(&p), name)
+			continue
+		}
+		seen[key] = getRouteName(&p)
+	}
+}
+
+func getRouteName(p *config.Policy) string {
+	if name := p.EnvoyOpts.GetName(); name != "" {
+		return name
+	}
+	return p.String()
+}
+
+// validateIdentityProviders checks the OpenID configuration of the identity providers with a
+// provider URL can be fetched.
+func (v *validator) validateIdentityProviders(ctx context.Context, options *config.Options) {
+	for _, idp := range options.GetAllIdentityProviders() {
+		if idp.GetUrl() == "" {
+			continue
+		}
+
+		endpoint := strings.TrimSuffix(idp.GetUrl(), "/") + "/.well-known/openid-configuration"
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			v.add(SeverityError, "identity_provider", "", "invalid identity provider url %s: %s", idp.GetUrl(), err)
+			continue
+		}
+		res, err := v.httpClient.Do(req)
+		if err != nil {
+			v.add(SeverityError, "identity_provider", "", "identity provider %s is unreachable: %s", idp.GetUrl(), err)
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			v.add(SeverityError, "identity_provider", "", "identity provider %s returned unexpected status code %d",
+				idp.GetUrl(), res.StatusCode)
+		}
+	}
+}
+
+func writeJSON(w io.Writer, res *Result) error {
+	bs, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(bs))
+	return err
+}
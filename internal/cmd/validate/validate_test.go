@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	writeConfig := func(t *testing.T, contents string) string {
+		fn := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(fn, []byte(contents), 0o600))
+		return fn
+	}
+	run := func(t *testing.T, configFile string, args ...string) (*Result, error) {
+		var buf bytes.Buffer
+		err := Run(context.Background(), configFile, args, &buf)
+		var res Result
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		return &res, err
+	}
+
+	configFile := writeConfig(t, `
+insecure_server: true
+authenticate_service_url: https://authenticate.example.com
+shared_secret: YixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM=
+cookie_secret: zixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM=
+routes:
+- from: https://a.example.com
+  to: https://a.internal
+  allow_public_unauthenticated_access: true
+- from: https://a.example.com
+  to: https://b.internal
+  allow_public_unauthenticated_access: true
+`)
+
+	t.Run("warnings", func(t *testing.T) {
+		res, err := run(t, configFile)
+		assert.NoError(t, err)
+		assert.True(t, res.Valid)
+		require.Len(t, res.Findings, 1)
+		assert.Equal(t, SeverityWarning, res.Findings[0].Severity)
+		assert.Equal(t, "route", res.Findings[0].Check)
+		assert.Equal(t, "https://a.example.com", res.Findings[0].Route)
+	})
+	t.Run("strict", func(t *testing.T) {
+		res, err := run(t, configFile, "--strict")
+		assert.ErrorIs(t, err, ErrInvalid)
+		assert.False(t, res.Valid)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		res, err := run(t, writeConfig(t, "routes:\n- from: https://a.example.com\n"))
+		assert.ErrorIs(t, err, ErrInvalid)
+		assert.False(t, res.Valid)
+		require.Len(t, res.Findings, 1)
+		assert.Equal(t, SeverityError, res.Findings[0].Severity)
+		assert.Equal(t, "config", res.Findings[0].Check)
+	})
+}
@@ -0,0 +1,150 @@
+package configdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// A Diff is the difference between two config snapshots.
+type Diff struct {
+	Changed             bool    `json:"changed"`
+	Settings            Changes `json:"settings"`
+	Routes              Changes `json:"routes"`
+	Listeners           Changes `json:"listeners"`
+	Clusters            Changes `json:"clusters"`
+	RouteConfigurations Changes `json:"route_configurations"`
+}
+
+// Changes are the names of the added and removed items of a kind, like routes, and the fields
+// of the items that changed.
+type Changes struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []Change `json:"changed,omitempty"`
+}
+
+// A Change is a changed item.
+type Change struct {
+	Name   string        `json:"name"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// A FieldChange is a changed field of an item. The path is empty when the item is a single
+// value, like most settings.
+type FieldChange struct {
+	Path string      `json:"path,omitempty"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Compare returns the changes needed to go from one snapshot to the other.
+func Compare(from, to *configpb.ConfigSnapshot) (*Diff, error) {
+	diff := new(Diff)
+	for _, c := range []struct {
+		kind     string
+		from, to map[string]string
+		changes  *Changes
+	}{
+		{"settings", from.GetSettings(), to.GetSettings(), &diff.Settings},
+		{"routes", from.GetRoutes(), to.GetRoutes(), &diff.Routes},
+		{"listeners", from.GetListeners(), to.GetListeners(), &diff.Listeners},
+		{"clusters", from.GetClusters(), to.GetClusters(), &diff.Clusters},
+		{"route configurations", from.GetRouteConfigurations(), to.GetRouteConfigurations(), &diff.RouteConfigurations},
+	} {
+		changes, err := compareItems(c.from, c.to)
+		if err != nil {
+			return nil, fmt.Errorf("configdiff: error comparing %s: %w", c.kind, err)
+		}
+		*c.changes = changes
+		diff.Changed = diff.Changed || len(changes.Added) > 0 || len(changes.Removed) > 0 || len(changes.Changed) > 0
+	}
+	return diff, nil
+}
+
+func compareItems(from, to map[string]string) (Changes, error) {
+	var changes Changes
+	for _, name := range sortedKeys(from) {
+		if _, ok := to[name]; !ok {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+	for _, name := range sortedKeys(to) {
+		old, ok := from[name]
+		if !ok {
+			changes.Added = append(changes.Added, name)
+			continue
+		}
+		if old == to[name] {
+			continue
+		}
+
+		var a, b interface{}
+		if err := json.Unmarshal([]byte(old), &a); err != nil {
+			return changes, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := json.Unmarshal([]byte(to[name]), &b); err != nil {
+			return changes, fmt.Errorf("%s: %w", name, err)
+		}
+		if fields := compareValues("", a, b, nil); len(fields) > 0 {
+			changes.Changed = append(changes.Changed, Change{Name: name, Fields: fields})
+		}
+	}
+	return changes, nil
+}
+
+// compareValues appends the fields which differ between the decoded JSON values. Objects are
+// compared field by field, and arrays of the same length element by element.
+func compareValues(path string, a, b interface{}, fields []FieldChange) []FieldChange {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			var keys []string
+			for k := range a {
+				keys = append(keys, k)
+			}
+			for k := range b {
+				if _, ok := a[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fields = compareValues(joinPath(path, k), a[k], b[k], fields)
+			}
+			return fields
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok && len(a) == len(b) {
+			for i := range a {
+				fields = compareValues(path+"["+strconv.Itoa(i)+"]", a[i], b[i], fields)
+			}
+			return fields
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		fields = append(fields, FieldChange{Path: path, From: a, To: b})
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package configdiff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+func TestCompare(t *testing.T) {
+	diff, err := Compare(&configpb.ConfigSnapshot{
+		Settings: map[string]string{
+			"address":   `":443"`,
+			"log_level": `"info"`,
+		},
+		Routes: map[string]string{
+			"https://a.example.com": `{"from":"https://a.example.com","to":["https://a.internal"]}`,
+			"https://b.example.com": `{"from":"https://b.example.com","to":["https://b.internal"]}`,
+		},
+	}, &configpb.ConfigSnapshot{
+		Settings: map[string]string{
+			"address":   `":443"`,
+			"log_level": `"debug"`,
+		},
+		Routes: map[string]string{
+			"https://a.example.com": `{"from":"https://a.example.com","to":["https://c.internal"],"preserve_host_header":true}`,
+			"https://d.example.com": `{"from":"https://d.example.com","to":["https://d.internal"]}`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Diff{
+		Changed: true,
+		Settings: Changes{
+			Changed: []Change{{Name: "log_level", Fields: []FieldChange{{From: "info", To: "debug"}}}},
+		},
+		Routes: Changes{
+			Added:   []string{"https://d.example.com"},
+			Removed: []string{"https://b.example.com"},
+			Changed: []Change{{Name: "https://a.example.com", Fields: []FieldChange{
+				{Path: "preserve_host_header", From: nil, To: true},
+				{Path: "to[0]", From: "https://a.internal", To: "https://c.internal"},
+			}}},
+		},
+	}, diff)
+
+	diff, err = Compare(&configpb.ConfigSnapshot{}, &configpb.ConfigSnapshot{})
+	require.NoError(t, err)
+	assert.False(t, diff.Changed)
+}
+
+func TestNewSnapshot(t *testing.T) {
+	options := config.NewDefaultOptions()
+	options.SharedKey = "YixWi1MYh77NMECGGIJQevoonYtVF+ZPRkQZrrmeRqM="
+	options.Policies = []config.Policy{
+		{From: "https://a.example.com", To: mustParseWeightedURLs(t, "https://a.internal")},
+		{From: "https://a.example.com", Prefix: "/admin", To: mustParseWeightedURLs(t, "https://admin.internal")},
+	}
+	for i := range options.Policies {
+		require.NoError(t, options.Policies[i].Validate())
+	}
+
+	snapshot, err := NewSnapshot(context.Background(), &config.Config{Options: options})
+	require.NoError(t, err)
+	assert.Contains(t, snapshot.Routes, "https://a.example.com")
+	assert.Contains(t, snapshot.Routes, "https://a.example.com prefix=/admin")
+	assert.NotContains(t, snapshot.Settings, "policies", "routes shouldn't be part of the settings")
+	assert.Regexp(t, `^"redacted:[0-9a-f]{16}"$`, snapshot.Settings["shared_secret"])
+	assert.NotEmpty(t, snapshot.Listeners)
+	assert.NotEmpty(t, snapshot.Clusters)
+	assert.NotEmpty(t, snapshot.RouteConfigurations)
+
+	again, err := NewSnapshot(context.Background(), &config.Config{Options: options})
+	require.NoError(t, err)
+	diff, err := Compare(snapshot, again)
+	require.NoError(t, err)
+	assert.False(t, diff.Changed, "snapshots of the same config should be the same: %+v", diff)
+}
+
+func mustParseWeightedURLs(t *testing.T, urls ...string) config.WeightedURLs {
+	wu, err := config.ParseWeightedUrls(urls...)
+	require.NoError(t, err)
+	return wu
+}
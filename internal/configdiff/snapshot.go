@@ -0,0 +1,285 @@
+// Package configdiff compares pomerium configs, including the envoy listeners and clusters
+// built from them, so the impact of a config change can be reviewed before it's applied.
+package configdiff
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig"
+	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/hashutil"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+)
+
+// localPort is the port of the local listeners in the envoy config of a snapshot.
+const localPort = "0"
+
+// secretKeys are the keys of the settings and route fields whose values are redacted.
+var secretKeys = map[string]bool{
+	"accepted_shared_secrets":              true,
+	"admin_api_bearer_token":               true,
+	"audit_sinks":                          true,
+	"autocert_dns_provider_options":        true,
+	"autocert_eab_mac_key":                 true,
+	"certificate_key":                      true,
+	"client_secret":                        true,
+	"consul_token":                         true,
+	"cookie_secret":                        true,
+	"databroker_storage_connection_string": true,
+	"gitops_webhook_secret":                true,
+	"google_cloud_serverless_authentication_service_account": true,
+	"health_check_token":               true,
+	"idp_client_secret":                true,
+	"idp_saml_key":                     true,
+	"idp_service_account":              true,
+	"kubernetes_service_account_token": true,
+	"metrics_certificate_key":          true,
+	"metrics_otlp_headers":             true,
+	"nomad_token":                      true,
+	"scim_bearer_token":                true,
+	"secret":                           true,
+	"service_account":                  true,
+	"shared_secret":                    true,
+	"signing_key":                      true,
+	"tls_client_key":                   true,
+	"tracing_otlp_headers":             true,
+}
+
+// NewSnapshot creates a snapshot of the config. Secrets are replaced with a hash of their
+// value, so changes to them are visible without revealing them.
+func NewSnapshot(ctx context.Context, cfg *config.Config) (*configpb.ConfigSnapshot, error) {
+	// the envoy config is built with fixed local ports and a fixed file directory, rather than
+	// those of the process, and without the certificates issued by autocert, so the snapshots
+	// of different processes can be compared
+	cfg = cfg.Clone()
+	cfg.AutoCertificates = nil
+	cfg.GRPCPort = localPort
+	cfg.HTTPPort = localPort
+	cfg.OutboundPort = localPort
+	cfg.MetricsPort = localPort
+	cfg.DebugPort = localPort
+	localAddress := net.JoinHostPort("127.0.0.1", localPort)
+
+	snapshot := &configpb.ConfigSnapshot{
+		Settings:            map[string]string{},
+		Routes:              map[string]string{},
+		Listeners:           map[string]string{},
+		Clusters:            map[string]string{},
+		RouteConfigurations: map[string]string{},
+	}
+
+	if err := addSettings(snapshot.Settings, cfg.Options); err != nil {
+		return nil, fmt.Errorf("configdiff: error encoding settings: %w", err)
+	}
+	policies := cfg.Options.GetAllPolicies()
+	for i := range policies {
+		p := &policies[i]
+		name := getRouteName(p)
+		for j := 2; snapshot.Routes[name] != ""; j++ {
+			name = fmt.Sprintf("%s #%d", getRouteName(p), j)
+		}
+		var err error
+		if snapshot.Routes[name], err = EncodeRoute(p); err != nil {
+			return nil, fmt.Errorf("configdiff: error encoding route %s: %w", name, err)
+		}
+	}
+
+	builder := envoyconfig.New(localAddress, localAddress, localAddress,
+		filemgr.NewManager(filemgr.WithCacheDir(filepath.Join(os.TempDir(), "pomerium", "configdiff"))), nil)
+	listeners, err := builder.BuildListeners(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configdiff: error building envoy listeners: %w", err)
+	}
+	for _, listener := range listeners {
+		if snapshot.Listeners[listener.Name], err = encodeProto(listener, normalizeSelfSignedCertificates); err != nil {
+			return nil, fmt.Errorf("configdiff: error encoding envoy listener %s: %w", listener.Name, err)
+		}
+	}
+	clusters, err := builder.BuildClusters(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configdiff: error building envoy clusters: %w", err)
+	}
+	for _, cluster := range clusters {
+		if snapshot.Clusters[cluster.Name], err = encodeProto(cluster); err != nil {
+			return nil, fmt.Errorf("configdiff: error encoding envoy cluster %s: %w", cluster.Name, err)
+		}
+	}
+	routeConfigurations, err := builder.BuildRouteConfigurations(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configdiff: error building envoy route configurations: %w", err)
+	}
+	for _, routeConfiguration := range routeConfigurations {
+		if snapshot.RouteConfigurations[routeConfiguration.Name], err = encodeProto(routeConfiguration); err != nil {
+			return nil, fmt.Errorf("configdiff: error encoding envoy route configuration %s: %w", routeConfiguration.Name, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// addSettings adds the settings of the options, keyed by their config file key. Routes are
+// added to the snapshot separately.
+func addSettings(dst map[string]string, options *config.Options) error {
+	bs, err := yaml.Marshal(options)
+	if err != nil {
+		return err
+	}
+	var settings map[string]interface{}
+	if err := yaml.Unmarshal(bs, &settings); err != nil {
+		return err
+	}
+	delete(settings, "policies")
+	delete(settings, "routes")
+
+	for k, v := range redact(settings).(map[string]interface{}) {
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		dst[k] = string(bs)
+	}
+	return nil
+}
+
+// EncodeRoute encodes the route as JSON, with secrets replaced with a hash of their value.
+func EncodeRoute(p *config.Policy) (string, error) {
+	pb, err := p.ToProto()
+	if err != nil {
+		return "", err
+	}
+	return encodeProto(pb)
+}
+
+// getRouteName returns a name for the route from the fields which are used to match requests.
+func getRouteName(p *config.Policy) string {
+	name := p.From
+	switch {
+	case p.Prefix != "":
+		name += " prefix=" + p.Prefix
+	case p.Path != "":
+		name += " path=" + p.Path
+	case p.Regex != "":
+		name += " regex=" + p.Regex
+	}
+	return name
+}
+
+// encodeProto encodes the message as JSON, with secrets redacted and map keys sorted. The
+// normalize functions are applied to the decoded JSON before it's encoded.
+func encodeProto(msg proto.Message, normalize ...func(interface{})) (string, error) {
+	bs, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	var v interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		return "", err
+	}
+	for _, f := range normalize {
+		f(v)
+	}
+	bs, err = json.Marshal(redact(v))
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// normalizeSelfSignedCertificates replaces the self-signed certificates generated for domains
+// without a certificate with the names they're generated for, since a new one is generated
+// whenever the envoy config is built.
+func normalizeSelfSignedCertificates(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range v {
+			certs, ok := vv.([]interface{})
+			if k != "tls_certificates" || !ok {
+				normalizeSelfSignedCertificates(vv)
+				continue
+			}
+			for i, cert := range certs {
+				if names := getSelfSignedCertificateNames(cert); names != nil {
+					certs[i] = map[string]interface{}{"self_signed": names}
+				}
+			}
+		}
+	case []interface{}:
+		for _, vv := range v {
+			normalizeSelfSignedCertificates(vv)
+		}
+	}
+}
+
+func getSelfSignedCertificateNames(v interface{}) []string {
+	m, _ := v.(map[string]interface{})
+	chain, _ := m["certificate_chain"].(map[string]interface{})
+	fileName, _ := chain["filename"].(string)
+	if fileName == "" {
+		return nil
+	}
+	bs, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil
+	}
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil || cert.Issuer.String() != cert.Subject.String() ||
+		len(cert.Subject.Organization) != 1 || cert.Subject.Organization[0] != "Pomerium" {
+		return nil
+	}
+
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
+
+// redact replaces the values of secret keys with a hash of the value.
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range v {
+			if secretKeys[k] && !isEmpty(vv) {
+				v[k] = fmt.Sprintf("redacted:%016x", hashutil.MustHash(vv))
+			} else {
+				v[k] = redact(vv)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return v
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package controlplane
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/internal/configdiff"
+	"github.com/pomerium/pomerium/internal/log"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func (srv *Server) registerConfigService() {
+	configpb.RegisterConfigServiceServer(srv.GRPCServer, srv)
+}
+
+// GetRunningConfig returns a snapshot of the current config. Requests must be signed with the
+// shared secret.
+func (srv *Server) GetRunningConfig(ctx context.Context, _ *configpb.GetRunningConfigRequest) (*configpb.GetRunningConfigResponse, error) {
+	cfg := srv.currentConfig.Load()
+
+	sharedKeys, err := cfg.Options.GetAcceptedSharedKeys()
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if err := grpcutil.RequireSignedJWT(ctx, sharedKeys...); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := configdiff.NewSnapshot(ctx, cfg.Config)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("controlplane: error creating config snapshot")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &configpb.GetRunningConfigResponse{Snapshot: snapshot}, nil
+}
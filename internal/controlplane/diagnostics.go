@@ -0,0 +1,210 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/envoy/files"
+	"github.com/pomerium/pomerium/internal/fips"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/version"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	databrokerpb "github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// A diagnosticsServer serves pprof profiles and the runtime state of pomerium on the
+// diagnostics_address, to clients with a certificate signed by the diagnostics_client_ca.
+type diagnosticsServer struct {
+	srv *Server
+
+	mu      sync.Mutex
+	addr    string
+	ca      string
+	caFile  string
+	httpSrv *http.Server
+}
+
+func newDiagnosticsServer(srv *Server) *diagnosticsServer {
+	return &diagnosticsServer{srv: srv}
+}
+
+// Update starts, restarts or stops the diagnostics endpoint when its options change.
+func (ds *diagnosticsServer) Update(ctx context.Context, cfg *config.Config) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	o := cfg.Options
+	if o.DiagnosticsAddr == ds.addr && o.DiagnosticsClientCA == ds.ca && o.DiagnosticsClientCAFile == ds.caFile {
+		return
+	}
+	ds.stopLocked()
+	ds.addr, ds.ca, ds.caFile = o.DiagnosticsAddr, o.DiagnosticsClientCA, o.DiagnosticsClientCAFile
+	if ds.addr == "" {
+		return
+	}
+
+	clientCAs, err := o.GetDiagnosticsClientCA()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("controlplane: invalid diagnostics client ca, diagnostics are disabled")
+		return
+	}
+	li, err := net.Listen("tcp", ds.addr)
+	if err != nil {
+		log.Error(ctx).Err(err).Str("addr", ds.addr).Msg("controlplane: error starting diagnostics endpoint")
+		return
+	}
+
+	ds.httpSrv = &http.Server{
+		Handler:           ds.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+			GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+				certs, err := ds.srv.currentConfig.Load().AllCertificates()
+				if err != nil {
+					return nil, err
+				}
+				return cryptutil.GetCertificateForDomain(certs, hello.ServerName)
+			},
+		},
+	}
+	go func(httpSrv *http.Server) {
+		err := httpSrv.ServeTLS(li, "", "")
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(ctx).Err(err).Msg("controlplane: diagnostics endpoint stopped")
+		}
+	}(ds.httpSrv)
+	log.Info(ctx).Str("addr", ds.addr).Msg("controlplane: serving diagnostics")
+}
+
+// Close stops the diagnostics endpoint.
+func (ds *diagnosticsServer) Close() {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.stopLocked()
+	ds.addr, ds.ca, ds.caFile = "", "", ""
+}
+
+func (ds *diagnosticsServer) stopLocked() {
+	if ds.httpSrv != nil {
+		_ = ds.httpSrv.Close()
+		ds.httpSrv = nil
+	}
+}
+
+func (ds *diagnosticsServer) handler() http.Handler {
+	r := mux.NewRouter()
+	addPprofHandlers(r)
+	addLogLevelHandlers(r)
+	r.Path("/debug/buildinfo").Methods(http.MethodGet).HandlerFunc(ds.serveBuildInfo)
+	r.Path("/debug/xds").Methods(http.MethodGet).HandlerFunc(ds.serveXDS)
+	r.Path("/debug/databroker").Methods(http.MethodGet).HandlerFunc(ds.serveDataBroker)
+	return r
+}
+
+func (ds *diagnosticsServer) serveBuildInfo(w http.ResponseWriter, r *http.Request) {
+	res := map[string]interface{}{
+		"version":       version.FullVersion(),
+		"envoy_version": files.FullVersion(),
+		"go_version":    runtime.Version(),
+		"fips":          fips.Enabled(),
+		"goroutines":    runtime.NumGoroutine(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		settings := map[string]string{}
+		for _, setting := range info.Settings {
+			settings[setting.Key] = setting.Value
+		}
+		res["settings"] = settings
+	}
+	writeDiagnosticsJSON(w, http.StatusOK, res)
+}
+
+func (ds *diagnosticsServer) serveXDS(w http.ResponseWriter, r *http.Request) {
+	writeDiagnosticsJSON(w, http.StatusOK, map[string]interface{}{
+		"config_version": ds.srv.currentConfig.Load().version,
+		"snapshot":       ds.srv.xdsmgr.Snapshot(),
+	})
+}
+
+// serveDataBroker serves the status of the syncers of the process, and how many record versions
+// each of them is behind the databroker.
+func (ds *diagnosticsServer) serveDataBroker(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	type syncerStatus struct {
+		databrokerpb.SyncerStatus
+		Lag *uint64 `json:"lag,omitempty"`
+	}
+	res := struct {
+		ServerVersion uint64         `json:"server_version,omitempty"`
+		RecordVersion uint64         `json:"record_version,omitempty"`
+		Error         string         `json:"error,omitempty"`
+		Syncers       []syncerStatus `json:"syncers"`
+	}{Syncers: []syncerStatus{}}
+
+	var err error
+	res.ServerVersion, res.RecordVersion, err = ds.srv.getDataBrokerVersions(ctx)
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	for _, status := range databrokerpb.GetSyncerStatuses() {
+		s := syncerStatus{SyncerStatus: status}
+		if lag, ok := getSyncerLag(status, res.ServerVersion, res.RecordVersion); err == nil && ok {
+			s.Lag = &lag
+		}
+		res.Syncers = append(res.Syncers, s)
+	}
+	writeDiagnosticsJSON(w, http.StatusOK, res)
+}
+
+// getDataBrokerVersions returns the current server and record versions of the databroker.
+func (srv *Server) getDataBrokerVersions(ctx context.Context) (serverVersion, recordVersion uint64, err error) {
+	client, err := srv.getDataBrokerClient(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	latest, err := client.Query(ctx, &databrokerpb.QueryRequest{
+		Type:  protoutil.GetTypeURL(new(configpb.Config)),
+		Limit: 1,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return latest.GetServerVersion(), latest.GetRecordVersion(), nil
+}
+
+// getSyncerLag returns how many record versions the syncer is behind the databroker, unless it's
+// synced with another server version.
+func getSyncerLag(status databrokerpb.SyncerStatus, serverVersion, recordVersion uint64) (uint64, bool) {
+	if status.ServerVersion != serverVersion || status.RecordVersion > recordVersion {
+		return 0, false
+	}
+	return recordVersion - status.RecordVersion, true
+}
+
+func writeDiagnosticsJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
@@ -0,0 +1,83 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/controlplane/xdsmgr"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestDiagnostics(t *testing.T) {
+	ctx := context.Background()
+
+	clientCert, err := cryptutil.GenerateSelfSignedCertificate("client", func(c *x509.Certificate) {
+		c.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	})
+	require.NoError(t, err)
+	clientCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientCert.Certificate[0]})
+
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := li.Addr().String()
+	require.NoError(t, li.Close())
+
+	cfg := &config.Config{Options: config.NewDefaultOptions()}
+	cfg.Options.DiagnosticsAddr = addr
+	cfg.Options.DiagnosticsClientCA = base64.StdEncoding.EncodeToString(clientCA)
+
+	srv := &Server{xdsmgr: xdsmgr.NewManager(nil)}
+	srv.currentConfig.Store(versionedConfig{Config: cfg, version: 3})
+	srv.diagnostics = newDiagnosticsServer(srv)
+	srv.diagnostics.Update(ctx, cfg)
+	defer srv.diagnostics.Close()
+
+	get := func(certs ...tls.Certificate) (*http.Response, error) {
+		client := &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					Certificates:       certs,
+					InsecureSkipVerify: true,
+				},
+			},
+		}
+		return client.Get("https://" + addr + "/debug/xds")
+	}
+
+	t.Run("xds", func(t *testing.T) {
+		var res *http.Response
+		require.Eventually(t, func() bool {
+			res, err = get(*clientCert)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var body struct {
+			ConfigVersion uint64          `json:"config_version"`
+			Snapshot      xdsmgr.Snapshot `json:"snapshot"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+		assert.Equal(t, uint64(3), body.ConfigVersion)
+	})
+	t.Run("client certificate required", func(t *testing.T) {
+		res, err := get()
+		if err == nil {
+			res.Body.Close()
+		}
+		assert.Error(t, err)
+	})
+}
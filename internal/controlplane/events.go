@@ -7,6 +7,7 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/google/uuid"
+	googlegrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -64,6 +65,15 @@ func (srv *Server) storeEvent(ctx context.Context, evt proto.Message) error {
 }
 
 func (srv *Server) getDataBrokerClient(ctx context.Context) (databrokerpb.DataBrokerServiceClient, error) {
+	cc, err := srv.getDataBrokerConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := databrokerpb.NewDataBrokerServiceClient(cc)
+	return client, nil
+}
+
+func (srv *Server) getDataBrokerConn(ctx context.Context) (*googlegrpc.ClientConn, error) {
 	cfg := srv.currentConfig.Load()
 
 	sharedKey, err := cfg.Options.GetSharedKey()
@@ -81,8 +91,7 @@ func (srv *Server) getDataBrokerClient(ctx context.Context) (databrokerpb.DataBr
 		return nil, fmt.Errorf("controlplane: error creating databroker connection: %w", err)
 	}
 	_ = grpc.WaitForReady(ctx, cc, time.Second*10)
-	client := databrokerpb.NewDataBrokerServiceClient(cc)
-	return client, nil
+	return cc, nil
 }
 
 // withGRPCBackoff runs f. If an unavailable or resource exhausted error occurs, the request will be retried.
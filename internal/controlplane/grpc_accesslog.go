@@ -2,10 +2,14 @@ package controlplane
 
 import (
 	"strings"
+	"time"
 
+	envoy_data_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
 	envoy_service_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
 	"github.com/rs/zerolog"
 
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/accesslog"
 	"github.com/pomerium/pomerium/internal/log"
 )
 
@@ -13,7 +17,8 @@ func (srv *Server) registerAccessLogHandlers() {
 	envoy_service_accesslog_v3.RegisterAccessLogServiceServer(srv.GRPCServer, srv)
 }
 
-// StreamAccessLogs receives logs from envoy and prints them to stdout.
+// StreamAccessLogs receives logs from envoy and prints them to stdout, or writes them to the
+// access log sinks when they're configured. Per-route metrics are recorded from the logs.
 func (srv *Server) StreamAccessLogs(stream envoy_service_accesslog_v3.AccessLogService_StreamAccessLogsServer) error {
 	for {
 		msg, err := stream.Recv()
@@ -22,36 +27,86 @@ func (srv *Server) StreamAccessLogs(stream envoy_service_accesslog_v3.AccessLogS
 			return err
 		}
 
+		fields := srv.currentConfig.Load().Options.GetAccessLogFields()
 		for _, entry := range msg.GetHttpLogs().LogEntry {
-			reqPath := entry.GetRequest().GetPath()
+			srv.routeMetrics.Record(stream.Context(), entry)
+
+			logEntry := newAccessLogEntry(entry)
+			if srv.accessLog.Enabled() {
+				srv.accessLog.Log(stream.Context(), logEntry)
+				continue
+			}
+
 			var evt *zerolog.Event
-			if reqPath == "/ping" || reqPath == "/healthz" {
+			if logEntry.Path == "/ping" || logEntry.Path == "/healthz" {
 				evt = log.Debug(stream.Context())
 			} else {
 				evt = log.Info(stream.Context())
 			}
-			// common properties
 			evt = evt.Str("service", "envoy")
-			evt = evt.Str("upstream-cluster", entry.GetCommonProperties().GetUpstreamCluster())
-			// request properties
-			evt = evt.Str("method", entry.GetRequest().GetRequestMethod().String())
-			evt = evt.Str("authority", entry.GetRequest().GetAuthority())
-			evt = evt.Str("path", stripQueryString(reqPath))
-			evt = evt.Str("user-agent", entry.GetRequest().GetUserAgent())
-			evt = evt.Str("referer", stripQueryString(entry.GetRequest().GetReferer()))
-			evt = evt.Str("forwarded-for", entry.GetRequest().GetForwardedFor())
-			evt = evt.Str("request-id", entry.GetRequest().GetRequestId())
-			// response properties
-			dur := entry.CommonProperties.TimeToLastDownstreamTxByte.AsDuration()
-			evt = evt.Dur("duration", dur)
-			evt = evt.Uint64("size", entry.Response.ResponseBodyBytes)
-			evt = evt.Uint32("response-code", entry.GetResponse().GetResponseCode().GetValue())
-			evt = evt.Str("response-code-details", entry.GetResponse().GetResponseCodeDetails())
+			for _, field := range fields {
+				v, ok := logEntry.Get(field)
+				if !ok {
+					continue
+				}
+				if d, ok := v.(time.Duration); ok {
+					evt = evt.Dur(field, d)
+				} else {
+					evt = evt.Interface(field, v)
+				}
+			}
 			evt.Msg("http-request")
 		}
 	}
 }
 
+func newAccessLogEntry(entry *envoy_data_accesslog_v3.HTTPAccessLogEntry) *accesslog.Entry {
+	var protocol string
+	switch entry.GetProtocolVersion() {
+	case envoy_data_accesslog_v3.HTTPAccessLogEntry_HTTP10:
+		protocol = "HTTP/1.0"
+	case envoy_data_accesslog_v3.HTTPAccessLogEntry_HTTP11:
+		protocol = "HTTP/1.1"
+	case envoy_data_accesslog_v3.HTTPAccessLogEntry_HTTP2:
+		protocol = "HTTP/2"
+	case envoy_data_accesslog_v3.HTTPAccessLogEntry_HTTP3:
+		protocol = "HTTP/3"
+	}
+	common := entry.GetCommonProperties()
+	logEntry := &accesslog.Entry{
+		Time:                common.GetStartTime().AsTime(),
+		UpstreamCluster:     common.GetUpstreamCluster(),
+		Method:              entry.GetRequest().GetRequestMethod().String(),
+		Protocol:            protocol,
+		Authority:           entry.GetRequest().GetAuthority(),
+		Path:                stripQueryString(entry.GetRequest().GetPath()),
+		UserAgent:           entry.GetRequest().GetUserAgent(),
+		Referer:             stripQueryString(entry.GetRequest().GetReferer()),
+		ForwardedFor:        entry.GetRequest().GetForwardedFor(),
+		RemoteAddress:       common.GetDownstreamRemoteAddress().GetSocketAddress().GetAddress(),
+		RequestID:           entry.GetRequest().GetRequestId(),
+		Duration:            common.GetTimeToLastDownstreamTxByte().AsDuration(),
+		Size:                entry.GetResponse().GetResponseBodyBytes(),
+		ResponseCode:        entry.GetResponse().GetResponseCode().GetValue(),
+		ResponseCodeDetails: entry.GetResponse().GetResponseCodeDetails(),
+
+		RequestDuration:           common.GetTimeToLastRxByte().AsDuration(),
+		UpstreamRequestDuration:   common.GetTimeToLastUpstreamTxByte().AsDuration(),
+		UpstreamFirstByteDuration: common.GetTimeToFirstUpstreamRxByte().AsDuration(),
+		UpstreamDuration:          common.GetTimeToLastUpstreamRxByte().AsDuration(),
+		FirstByteDuration:         common.GetTimeToFirstDownstreamTxByte().AsDuration(),
+		RequestHeaders:            entry.GetRequest().GetRequestHeaders(),
+		ResponseHeaders:           entry.GetResponse().GetResponseHeaders(),
+	}
+	// the fields set by the authorize service are part of the ext_authz filter's metadata
+	authorize := common.GetMetadata().GetFilterMetadata()["envoy.filters.http.ext_authz"].
+		GetFields()[config.AccessLogMetadataKey].GetStructValue()
+	if authorize != nil {
+		logEntry.Authorize = authorize.AsMap()
+	}
+	return logEntry
+}
+
 func stripQueryString(str string) string {
 	if idx := strings.Index(str, "?"); idx != -1 {
 		str = str[:idx]
@@ -0,0 +1,295 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/fips"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/health"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	databrokerpb "github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// The statuses of the deep health checks. Only failures make the health check endpoints fail.
+const (
+	healthStatusOK   = "ok"
+	healthStatusWarn = "warn"
+	healthStatusFail = "fail"
+)
+
+const (
+	healthCheckTimeout = 5 * time.Second
+	// certificates which expire within this window are reported with a warning
+	certificateExpiryWarning = 14 * 24 * time.Hour
+)
+
+type healthCheckResult struct {
+	Status  string      `json:"status"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+type healthReport struct {
+	Status string                       `json:"status"`
+	Checks map[string]healthCheckResult `json:"checks"`
+}
+
+// serveHealthCheck serves the health check endpoints. With the deep query parameter and the
+// health check token, it checks the dependencies of pomerium and reports their status as JSON.
+func (srv *Server) serveHealthCheck(w http.ResponseWriter, r *http.Request) {
+	if _, deep := r.URL.Query()["deep"]; !deep {
+		httputil.HealthCheck(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg := srv.currentConfig.Load().Config
+	token := cfg.Options.HealthCheckToken
+	if token == "" {
+		http.Error(w, "deep health checks are disabled", http.StatusNotFound)
+		return
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") ||
+		subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	report := srv.checkHealth(r.Context(), cfg)
+	status := http.StatusOK
+	if report.Status == healthStatusFail {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.RenderJSON(w, status, report)
+}
+
+// checkHealth runs the deep health checks concurrently.
+func (srv *Server) checkHealth(ctx context.Context, cfg *config.Config) *healthReport {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	checks := map[string]func(context.Context, *config.Config) healthCheckResult{
+		"databroker":   srv.checkDataBrokerHealth,
+		"storage":      srv.checkStorageHealth,
+		"certificates": checkCertificatesHealth,
+		"xds":          srv.checkXDSHealth,
+		"fips":         checkFIPSHealth,
+	}
+	if config.IsAuthenticate(cfg.Options.Services) {
+		checks["identity_providers"] = checkIdentityProvidersHealth
+	}
+
+	report := &healthReport{
+		Status: healthStatusOK,
+		Checks: make(map[string]healthCheckResult, len(checks)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		name, check := name, check
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := check(ctx, cfg)
+
+			mu.Lock()
+			report.Checks[name] = res
+			if res.Status == healthStatusFail ||
+				(res.Status == healthStatusWarn && report.Status == healthStatusOK) {
+				report.Status = res.Status
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func healthCheckError(err error, details interface{}) healthCheckResult {
+	return healthCheckResult{Status: healthStatusFail, Error: err.Error(), Details: details}
+}
+
+func (srv *Server) checkDataBrokerHealth(ctx context.Context, _ *config.Config) healthCheckResult {
+	cc, err := srv.getDataBrokerConn(ctx)
+	if err != nil {
+		return healthCheckError(err, nil)
+	}
+	res, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return healthCheckError(err, nil)
+	}
+	if res.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return healthCheckError(fmt.Errorf("databroker is %s", res.GetStatus()), nil)
+	}
+	return healthCheckResult{Status: healthStatusOK}
+}
+
+// checkStorageHealth queries the databroker, which reads from its storage backend.
+func (srv *Server) checkStorageHealth(ctx context.Context, cfg *config.Config) healthCheckResult {
+	details := map[string]interface{}{
+		"type": cfg.Options.DataBrokerStorageType,
+	}
+	client, err := srv.getDataBrokerClient(ctx)
+	if err != nil {
+		return healthCheckError(err, details)
+	}
+	res, err := client.Query(ctx, &databrokerpb.QueryRequest{
+		Type:  protoutil.GetTypeURL(new(configpb.Config)),
+		Limit: 1,
+	})
+	if err != nil {
+		return healthCheckError(err, details)
+	}
+	details["server_version"] = res.GetServerVersion()
+	details["record_version"] = res.GetRecordVersion()
+	return healthCheckResult{Status: healthStatusOK, Details: details}
+}
+
+func checkIdentityProvidersHealth(ctx context.Context, cfg *config.Config) healthCheckResult {
+	type idpHealth struct {
+		URL    string `json:"url"`
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[string]bool{}
+	var idps []idpHealth
+	for _, idp := range cfg.Options.GetAllIdentityProviders() {
+		idp := idp
+		if seen[idp.GetUrl()] {
+			continue
+		}
+		seen[idp.GetUrl()] = true
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checked, err := health.CheckDiscovery(ctx, idp)
+			if !checked {
+				return
+			}
+			h := idpHealth{URL: idp.GetUrl(), Status: healthStatusOK}
+			if err != nil {
+				h.Status, h.Error = healthStatusFail, err.Error()
+			}
+			mu.Lock()
+			idps = append(idps, h)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	sort.Slice(idps, func(i, j int) bool { return idps[i].URL < idps[j].URL })
+
+	for _, h := range idps {
+		if h.Status == healthStatusFail {
+			return healthCheckResult{
+				Status:  healthStatusFail,
+				Error:   fmt.Sprintf("identity provider %s is unreachable", h.URL),
+				Details: idps,
+			}
+		}
+	}
+	return healthCheckResult{Status: healthStatusOK, Details: idps}
+}
+
+func checkCertificatesHealth(_ context.Context, cfg *config.Config) healthCheckResult {
+	type certificateHealth struct {
+		Names    []string  `json:"names"`
+		NotAfter time.Time `json:"not_after"`
+		Status   string    `json:"status"`
+	}
+
+	certs, err := cfg.AllCertificates()
+	if err != nil {
+		return healthCheckError(err, nil)
+	}
+
+	now := time.Now()
+	res := healthCheckResult{Status: healthStatusOK}
+	var details []certificateHealth
+	for _, cert := range certs {
+		if len(cert.Certificate) == 0 {
+			continue
+		}
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return healthCheckError(err, nil)
+		}
+		names := leaf.DNSNames
+		if len(names) == 0 {
+			names = []string{leaf.Subject.CommonName}
+		}
+		ch := certificateHealth{
+			Names:    names,
+			NotAfter: leaf.NotAfter.UTC(),
+			Status:   healthStatusOK,
+		}
+		switch {
+		case now.After(leaf.NotAfter):
+			ch.Status = healthStatusFail
+			res.Status = healthStatusFail
+			res.Error = fmt.Sprintf("certificate %s has expired", ch.Names[0])
+		case now.Add(certificateExpiryWarning).After(leaf.NotAfter):
+			ch.Status = healthStatusWarn
+			if res.Status == healthStatusOK {
+				res.Status = healthStatusWarn
+				res.Error = fmt.Sprintf("certificate %s expires at %s", ch.Names[0], ch.NotAfter.Format(time.RFC3339))
+			}
+		}
+		details = append(details, ch)
+	}
+	res.Details = details
+	return res
+}
+
+// checkFIPSHealth reports whether pomerium is built in FIPS mode. It never fails, so
+// deployments that require FIPS mode should check the details.
+func checkFIPSHealth(_ context.Context, _ *config.Config) healthCheckResult {
+	return healthCheckResult{
+		Status:  healthStatusOK,
+		Details: map[string]interface{}{"enabled": fips.Enabled()},
+	}
+}
+
+// checkXDSHealth checks that envoy accepted the configuration sent by the control plane.
+func (srv *Server) checkXDSHealth(_ context.Context, _ *config.Config) healthCheckResult {
+	snapshot := srv.xdsmgr.Snapshot()
+	switch {
+	case len(snapshot.Nacks) > 0:
+		var typeURLs []string
+		for typeURL := range snapshot.Nacks {
+			typeURLs = append(typeURLs, typeURL)
+		}
+		sort.Strings(typeURLs)
+		return healthCheckResult{
+			Status:  healthStatusFail,
+			Error:   fmt.Sprintf("envoy rejected the configuration: %s", snapshot.Nacks[typeURLs[0]]),
+			Details: snapshot,
+		}
+	case len(snapshot.AckedNonces) == 0:
+		return healthCheckResult{
+			Status:  healthStatusWarn,
+			Error:   "envoy hasn't acknowledged any configuration yet",
+			Details: snapshot,
+		}
+	}
+	return healthCheckResult{Status: healthStatusOK, Details: snapshot}
+}
@@ -0,0 +1,78 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/controlplane/xdsmgr"
+	"github.com/pomerium/pomerium/internal/fips"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestServeHealthCheck(t *testing.T) {
+	cfg := &config.Config{Options: config.NewDefaultOptions()}
+	srv := &Server{}
+	srv.currentConfig.Store(versionedConfig{Config: cfg})
+
+	serve := func(target, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		srv.serveHealthCheck(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/healthz", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve("/healthz?deep", "TOKEN").Code)
+
+	cfg.Options.HealthCheckToken = "TOKEN"
+	assert.Equal(t, http.StatusUnauthorized, serve("/healthz?deep", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("/healthz?deep", "OTHER").Code)
+}
+
+func TestCheckCertificatesHealth(t *testing.T) {
+	newCert := func(domain string, notAfter time.Time) tls.Certificate {
+		cert, err := cryptutil.GenerateSelfSignedCertificate(domain, func(c *x509.Certificate) {
+			c.NotAfter = notAfter
+		})
+		require.NoError(t, err)
+		return *cert
+	}
+
+	ctx := context.Background()
+	cfg := &config.Config{Options: config.NewDefaultOptions()}
+	cfg.AutoCertificates = []tls.Certificate{newCert("a.example.com", time.Now().Add(90*24*time.Hour))}
+	assert.Equal(t, healthStatusOK, checkCertificatesHealth(ctx, cfg).Status)
+
+	cfg.AutoCertificates = append(cfg.AutoCertificates, newCert("b.example.com", time.Now().Add(24*time.Hour)))
+	res := checkCertificatesHealth(ctx, cfg)
+	assert.Equal(t, healthStatusWarn, res.Status)
+	assert.Contains(t, res.Error, "b.example.com")
+
+	cfg.AutoCertificates = append(cfg.AutoCertificates, newCert("c.example.com", time.Now().Add(-time.Minute)))
+	res = checkCertificatesHealth(ctx, cfg)
+	assert.Equal(t, healthStatusFail, res.Status)
+	assert.Contains(t, res.Error, "c.example.com")
+}
+
+func TestCheckXDSHealth(t *testing.T) {
+	srv := &Server{xdsmgr: xdsmgr.NewManager(nil)}
+	assert.Equal(t, healthStatusWarn, srv.checkXDSHealth(context.Background(), nil).Status)
+}
+
+func TestCheckFIPSHealth(t *testing.T) {
+	res := checkFIPSHealth(context.Background(), nil)
+	assert.Equal(t, healthStatusOK, res.Status)
+	assert.Equal(t, map[string]interface{}{"enabled": fips.Enabled()}, res.Details)
+}
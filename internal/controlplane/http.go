@@ -8,6 +8,7 @@ import (
 
 	"github.com/CAFxX/httpcompression"
 	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
 
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/log"
@@ -45,16 +46,21 @@ func (srv *Server) addHTTPMiddleware() {
 	root.Use(telemetry.HTTPStatsHandler(func() string {
 		return srv.currentConfig.Load().Options.InstallationID
 	}, srv.name))
-	root.HandleFunc("/healthz", httputil.HealthCheck)
-	root.HandleFunc("/ping", httputil.HealthCheck)
+	root.HandleFunc("/healthz", srv.serveHealthCheck)
+	root.HandleFunc("/ping", srv.serveHealthCheck)
 
 	// pprof
-	srv.DebugRouter.Path("/debug/pprof/cmdline").HandlerFunc(pprof.Cmdline)
-	srv.DebugRouter.Path("/debug/pprof/profile").HandlerFunc(pprof.Profile)
-	srv.DebugRouter.Path("/debug/pprof/symbol").HandlerFunc(pprof.Symbol)
-	srv.DebugRouter.Path("/debug/pprof/trace").HandlerFunc(pprof.Trace)
-	srv.DebugRouter.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index)
+	addPprofHandlers(srv.DebugRouter)
+	addLogLevelHandlers(srv.DebugRouter)
 
 	// metrics
 	srv.MetricsRouter.Handle("/metrics", srv.metricsMgr)
 }
+
+func addPprofHandlers(r *mux.Router) {
+	r.Path("/debug/pprof/cmdline").HandlerFunc(pprof.Cmdline)
+	r.Path("/debug/pprof/profile").HandlerFunc(pprof.Profile)
+	r.Path("/debug/pprof/symbol").HandlerFunc(pprof.Symbol)
+	r.Path("/debug/pprof/trace").HandlerFunc(pprof.Trace)
+	r.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index)
+}
@@ -0,0 +1,271 @@
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	envoy_admin_v3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/authorize/evaluator"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig"
+	"github.com/pomerium/pomerium/internal/configdiff"
+	"github.com/pomerium/pomerium/internal/log"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	databrokerpb "github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// The sources of the active routes.
+const (
+	routeSourceConfig     = "config"
+	routeSourceDataBroker = "databroker"
+)
+
+const envoyAdminTimeout = 10 * time.Second
+
+func (srv *Server) registerIntrospectionService() {
+	configpb.RegisterIntrospectionServiceServer(srv.GRPCServer, srv)
+}
+
+// ListActiveRoutes lists the routes in use. Requests must be signed with the shared secret.
+func (srv *Server) ListActiveRoutes(ctx context.Context, _ *configpb.ListActiveRoutesRequest) (*configpb.ListActiveRoutesResponse, error) {
+	cfg, err := srv.authorizeIntrospection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &configpb.ListActiveRoutesResponse{ConfigVersion: cfg.version}
+	fromConfig := len(cfg.Options.Policies) + len(cfg.Options.Routes)
+	policies := cfg.Options.GetAllPolicies()
+	for i := range policies {
+		p := &policies[i]
+		id, err := p.RouteID()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		route, err := configdiff.EncodeRoute(p)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("controlplane: error encoding route")
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		source := routeSourceConfig
+		if i >= fromConfig {
+			source = routeSourceDataBroker
+		}
+		res.Routes = append(res.Routes, &configpb.ActiveRoute{
+			Id:          strconv.FormatUint(id, 16),
+			Name:        envoyconfig.PolicyRouteName(i),
+			ClusterName: envoyconfig.PolicyClusterName(p),
+			Source:      source,
+			Route:       route,
+		})
+	}
+	return res, nil
+}
+
+// ListCompiledPolicies lists the rego evaluated by authorize for the routes. Requests must be
+// signed with the shared secret.
+func (srv *Server) ListCompiledPolicies(ctx context.Context, _ *configpb.ListCompiledPoliciesRequest) (*configpb.ListCompiledPoliciesResponse, error) {
+	cfg, err := srv.authorizeIntrospection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(configpb.ListCompiledPoliciesResponse)
+	if cfg.Options.DefaultPolicy != nil {
+		res.DefaultPolicy, err = evaluator.GetPolicyScripts(&config.Policy{
+			From:   "default_policy",
+			Policy: cfg.Options.DefaultPolicy,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error compiling default policy: %v", err)
+		}
+	}
+	for _, p := range cfg.Options.GetAllPolicies() {
+		p := p
+		id, err := p.RouteID()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		scripts, err := evaluator.GetPolicyScripts(&p)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error compiling policy for %s: %v", p.From, err)
+		}
+		res.Policies = append(res.Policies, &configpb.CompiledPolicy{
+			RouteId:           strconv.FormatUint(id, 16),
+			From:              p.From,
+			Rego:              scripts,
+			SkipDefaultPolicy: p.SkipDefaultPolicy,
+		})
+	}
+	return res, nil
+}
+
+// GetDataBrokerStatus returns the status of the databroker syncers of the process. Requests must
+// be signed with the shared secret.
+func (srv *Server) GetDataBrokerStatus(ctx context.Context, _ *configpb.GetDataBrokerStatusRequest) (*configpb.GetDataBrokerStatusResponse, error) {
+	if _, err := srv.authorizeIntrospection(ctx); err != nil {
+		return nil, err
+	}
+
+	res := new(configpb.GetDataBrokerStatusResponse)
+	var err error
+	res.ServerVersion, res.RecordVersion, err = srv.getDataBrokerVersions(ctx)
+	if err != nil {
+		res.Error = err.Error()
+	}
+	for _, s := range databrokerpb.GetSyncerStatuses() {
+		pb := &configpb.SyncerStatus{
+			Id:            s.ID,
+			Type:          s.Type,
+			ServerVersion: s.ServerVersion,
+			RecordVersion: s.RecordVersion,
+		}
+		if !s.UpdatedAt.IsZero() {
+			pb.UpdatedAt = timestamppb.New(s.UpdatedAt)
+		}
+		if lag, ok := getSyncerLag(s, res.ServerVersion, res.RecordVersion); err == nil && ok {
+			pb.Lag = &lag
+		}
+		res.Syncers = append(res.Syncers, pb)
+	}
+	return res, nil
+}
+
+// GetClusterHealth returns the health of the envoy clusters of the routes, as reported by the
+// envoy admin API. Requests must be signed with the shared secret.
+func (srv *Server) GetClusterHealth(ctx context.Context, _ *configpb.GetClusterHealthRequest) (*configpb.GetClusterHealthResponse, error) {
+	cfg, err := srv.authorizeIntrospection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := getEnvoyClusters(ctx, cfg.Options.EnvoyAdminAddress)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("controlplane: error getting envoy clusters")
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	lookup := map[string]*envoy_admin_v3.ClusterStatus{}
+	for _, cs := range clusters.GetClusterStatuses() {
+		lookup[cs.GetName()] = cs
+	}
+
+	res := new(configpb.GetClusterHealthResponse)
+	for _, p := range cfg.Options.GetAllPolicies() {
+		p := p
+		id, err := p.RouteID()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		health := &configpb.ClusterHealth{
+			RouteId:     strconv.FormatUint(id, 16),
+			ClusterName: envoyconfig.PolicyClusterName(&p),
+		}
+		for _, hs := range lookup[health.ClusterName].GetHostStatuses() {
+			host := newClusterHost(hs)
+			if host.Healthy {
+				health.HealthyHosts++
+			}
+			health.Hosts = append(health.Hosts, host)
+		}
+		res.Clusters = append(res.Clusters, health)
+	}
+	return res, nil
+}
+
+func (srv *Server) authorizeIntrospection(ctx context.Context) (versionedConfig, error) {
+	cfg := srv.currentConfig.Load()
+
+	sharedKeys, err := cfg.Options.GetAcceptedSharedKeys()
+	if err != nil {
+		return cfg, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if err := grpcutil.RequireSignedJWT(ctx, sharedKeys...); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// getEnvoyClusters gets the status of the clusters from the envoy admin API.
+var getEnvoyClusters = func(ctx context.Context, adminAddress string) (*envoy_admin_v3.Clusters, error) {
+	ctx, cancel := context.WithTimeout(ctx, envoyAdminTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+adminAddress+"/clusters?format=json", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	bs, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from envoy admin: %d", res.StatusCode)
+	}
+
+	clusters := new(envoy_admin_v3.Clusters)
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(bs, clusters)
+	if err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// newClusterHost converts the status of a host reported by envoy. The health flags are named
+// like the ones of the text output of the envoy admin API.
+func newClusterHost(hs *envoy_admin_v3.HostStatus) *configpb.ClusterHost {
+	host := &configpb.ClusterHost{
+		Healthy: true,
+		Weight:  hs.GetWeight(),
+	}
+	if addr := hs.GetAddress().GetSocketAddress(); addr != nil {
+		host.Address = net.JoinHostPort(addr.GetAddress(), strconv.FormatUint(uint64(addr.GetPortValue()), 10))
+	} else if pipe := hs.GetAddress().GetPipe(); pipe != nil {
+		host.Address = pipe.GetPath()
+	}
+
+	h := hs.GetHealthStatus()
+	for _, flag := range []struct {
+		name      string
+		set       bool
+		unhealthy bool
+	}{
+		{"failed_active_hc", h.GetFailedActiveHealthCheck(), true},
+		{"failed_outlier_check", h.GetFailedOutlierCheck(), true},
+		{"failed_eds_health", h.GetEdsHealthStatus() == envoy_config_core_v3.HealthStatus_UNHEALTHY ||
+			h.GetEdsHealthStatus() == envoy_config_core_v3.HealthStatus_DRAINING ||
+			h.GetEdsHealthStatus() == envoy_config_core_v3.HealthStatus_TIMEOUT, true},
+		{"degraded_active_hc", h.GetFailedActiveDegradedCheck(), false},
+		{"degraded_eds_health", h.GetEdsHealthStatus() == envoy_config_core_v3.HealthStatus_DEGRADED, false},
+		{"pending_dynamic_removal", h.GetPendingDynamicRemoval(), false},
+		{"pending_active_hc", h.GetPendingActiveHc(), true},
+		{"excluded_via_immediate_hc_fail", h.GetExcludedViaImmediateHcFail(), true},
+		{"active_hc_timeout", h.GetActiveHcTimeout(), true},
+	} {
+		if !flag.set {
+			continue
+		}
+		host.HealthFlags = append(host.HealthFlags, flag.name)
+		if flag.unhealthy {
+			host.Healthy = false
+		}
+	}
+	return host
+}
@@ -0,0 +1,138 @@
+package controlplane
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	envoy_admin_v3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	configpb "github.com/pomerium/pomerium/pkg/grpc/config"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+func TestIntrospection(t *testing.T) {
+	sharedKey := cryptutil.NewKey()
+	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: sharedKey}, nil)
+	require.NoError(t, err)
+	rawJWT, err := jwt.Signed(sig).Claims(jwt.Claims{
+		Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).CompactSerialize()
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcutil.JWTMetadataKey, rawJWT))
+
+	opts := config.NewDefaultOptions()
+	opts.SharedKey = base64.StdEncoding.EncodeToString(sharedKey)
+	opts.Policies = []config.Policy{{
+		From:                             "https://from1.example.com",
+		To:                               mustParseWeightedURLs(t, "https://to1.example.com"),
+		AllowPublicUnauthenticatedAccess: true,
+	}}
+	opts.AdditionalPolicies = []config.Policy{{
+		From:         "https://from2.example.com",
+		To:           mustParseWeightedURLs(t, "https://to2.example.com"),
+		AllowedUsers: []string{"user@example.com"},
+		SubPolicies: []config.SubPolicy{{
+			Rego: []string{"package pomerium.policy\nallow = true"},
+		}},
+		KubernetesServiceAccountToken: "TOKEN",
+	}}
+	for i := range opts.AdditionalPolicies {
+		require.NoError(t, opts.AdditionalPolicies[i].Validate())
+	}
+	require.NoError(t, opts.Policies[0].Validate())
+
+	srv := &Server{}
+	srv.currentConfig.Store(versionedConfig{Config: &config.Config{Options: opts}, version: 7})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := srv.ListActiveRoutes(context.Background(), &configpb.ListActiveRoutesRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+	t.Run("routes", func(t *testing.T) {
+		res, err := srv.ListActiveRoutes(ctx, &configpb.ListActiveRoutesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), res.GetConfigVersion())
+		require.Len(t, res.GetRoutes(), 2)
+		assert.Equal(t, "policy-0", res.GetRoutes()[0].GetName())
+		assert.Equal(t, routeSourceConfig, res.GetRoutes()[0].GetSource())
+		assert.Equal(t, envoyconfig.PolicyClusterName(&opts.Policies[0]), res.GetRoutes()[0].GetClusterName())
+		assert.Equal(t, routeSourceDataBroker, res.GetRoutes()[1].GetSource())
+		assert.Contains(t, res.GetRoutes()[1].GetRoute(), "from2.example.com")
+		assert.NotContains(t, res.GetRoutes()[1].GetRoute(), `"TOKEN"`, "should redact secrets")
+	})
+	t.Run("policies", func(t *testing.T) {
+		res, err := srv.ListCompiledPolicies(ctx, &configpb.ListCompiledPoliciesRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.GetPolicies(), 2)
+		assert.Empty(t, res.GetDefaultPolicy())
+		assert.Equal(t, "https://from2.example.com", res.GetPolicies()[1].GetFrom())
+		require.Len(t, res.GetPolicies()[1].GetRego(), 2)
+		assert.Contains(t, res.GetPolicies()[1].GetRego()[0], "user@example.com")
+		assert.Equal(t, "package pomerium.policy\nallow = true", res.GetPolicies()[1].GetRego()[1])
+	})
+	t.Run("cluster health", func(t *testing.T) {
+		defer func(f func(context.Context, string) (*envoy_admin_v3.Clusters, error)) {
+			getEnvoyClusters = f
+		}(getEnvoyClusters)
+		getEnvoyClusters = func(ctx context.Context, adminAddress string) (*envoy_admin_v3.Clusters, error) {
+			assert.Equal(t, "127.0.0.1:9901", adminAddress)
+			return &envoy_admin_v3.Clusters{
+				ClusterStatuses: []*envoy_admin_v3.ClusterStatus{{
+					Name: envoyconfig.PolicyClusterName(&opts.Policies[0]),
+					HostStatuses: []*envoy_admin_v3.HostStatus{
+						newTestHostStatus("10.0.0.1", &envoy_admin_v3.HostHealthStatus{}),
+						newTestHostStatus("10.0.0.2", &envoy_admin_v3.HostHealthStatus{FailedOutlierCheck: true}),
+						newTestHostStatus("10.0.0.3", &envoy_admin_v3.HostHealthStatus{
+							EdsHealthStatus: envoy_config_core_v3.HealthStatus_DEGRADED,
+						}),
+					},
+				}},
+			}, nil
+		}
+
+		res, err := srv.GetClusterHealth(ctx, &configpb.GetClusterHealthRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.GetClusters(), 2)
+		assert.Equal(t, uint32(2), res.GetClusters()[0].GetHealthyHosts())
+		require.Len(t, res.GetClusters()[0].GetHosts(), 3)
+		assert.Equal(t, "10.0.0.2:443", res.GetClusters()[0].GetHosts()[1].GetAddress())
+		assert.False(t, res.GetClusters()[0].GetHosts()[1].GetHealthy())
+		assert.Equal(t, []string{"failed_outlier_check"}, res.GetClusters()[0].GetHosts()[1].GetHealthFlags())
+		assert.True(t, res.GetClusters()[0].GetHosts()[2].GetHealthy(), "degraded hosts should be healthy")
+		assert.Empty(t, res.GetClusters()[1].GetHosts())
+	})
+}
+
+func newTestHostStatus(ip string, health *envoy_admin_v3.HostHealthStatus) *envoy_admin_v3.HostStatus {
+	return &envoy_admin_v3.HostStatus{
+		Address: &envoy_config_core_v3.Address{
+			Address: &envoy_config_core_v3.Address_SocketAddress{
+				SocketAddress: &envoy_config_core_v3.SocketAddress{
+					Address:       ip,
+					PortSpecifier: &envoy_config_core_v3.SocketAddress_PortValue{PortValue: 443},
+				},
+			},
+		},
+		HealthStatus: health,
+		Weight:       1,
+	}
+}
+
+func mustParseWeightedURLs(t *testing.T, urls ...string) config.WeightedURLs {
+	wu, err := config.ParseWeightedUrls(urls...)
+	require.NoError(t, err)
+	return wu
+}
@@ -0,0 +1,87 @@
+package controlplane
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// addLogLevelHandlers adds the handlers which change the log levels of modules at runtime.
+func addLogLevelHandlers(r *mux.Router) {
+	r.Path("/debug/log-levels").Methods(http.MethodGet).HandlerFunc(serveLogLevels)
+	r.Path("/debug/log-levels/{module}").Methods(http.MethodPut).HandlerFunc(serveSetLogLevel)
+	r.Path("/debug/log-levels/{module}").Methods(http.MethodDelete).HandlerFunc(serveResetLogLevel)
+}
+
+type moduleLogLevel struct {
+	Level   string     `json:"level"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+func serveLogLevels(w http.ResponseWriter, r *http.Request) {
+	res := struct {
+		Level   string                    `json:"level"`
+		Modules map[string]moduleLogLevel `json:"modules"`
+	}{
+		Level:   log.GetGlobalLevel().String(),
+		Modules: map[string]moduleLogLevel{},
+	}
+	for module, ml := range log.GetModuleLevels() {
+		mll := moduleLogLevel{Level: ml.Level.String()}
+		if !ml.Expires.IsZero() {
+			expires := ml.Expires.UTC()
+			mll.Expires = &expires
+		}
+		res.Modules[module] = mll
+	}
+	writeDiagnosticsJSON(w, http.StatusOK, res)
+}
+
+// serveSetLogLevel sets the log level of a module, for the duration in the request if set. e.g.:
+//
+//	{"level": "debug", "duration": "10m"}
+func serveSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Level    string `json:"level"`
+		Duration string `json:"duration"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	level, err := zerolog.ParseLevel(req.Level)
+	if err != nil || req.Level == "" {
+		http.Error(w, fmt.Sprintf("invalid log level: %q", req.Level), http.StatusBadRequest)
+		return
+	}
+	var duration time.Duration
+	if req.Duration != "" {
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid duration: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	module := mux.Vars(r)["module"]
+	log.SetModuleLevel(module, level, duration)
+	log.Info(r.Context()).
+		Str("module", module).
+		Str("level", level.String()).
+		Dur("duration", duration).
+		Msg("controlplane: log level changed")
+	serveLogLevels(w, r)
+}
+
+func serveResetLogLevel(w http.ResponseWriter, r *http.Request) {
+	module := mux.Vars(r)["module"]
+	log.ResetModuleLevel(module)
+	log.Info(r.Context()).Str("module", module).Msg("controlplane: log level reset")
+	serveLogLevels(w, r)
+}
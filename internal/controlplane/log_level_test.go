@@ -0,0 +1,39 @@
+package controlplane
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+func TestLogLevelHandlers(t *testing.T) {
+	r := mux.NewRouter()
+	addLogLevelHandlers(r)
+	defer log.ResetModuleLevel("authorize")
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := do(http.MethodPut, "/debug/log-levels/authorize", `{"level":"debug","duration":"1h"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"authorize": {`)
+	assert.Equal(t, zerolog.DebugLevel, log.GetModuleLevel("authorize"))
+
+	w = do(http.MethodPut, "/debug/log-levels/authorize", `{"level":"verbose"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodDelete, "/debug/log-levels/authorize", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "authorize")
+	assert.Equal(t, log.GetGlobalLevel(), log.GetModuleLevel("authorize"))
+}
@@ -0,0 +1,50 @@
+package controlplane
+
+import (
+	"context"
+	"sync/atomic"
+
+	envoy_data_accesslog_v3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/config/envoyconfig"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+// routeMetrics records per-route metrics from the access logs of envoy.
+type routeMetrics struct {
+	// the tags of the routes by envoy route name, nil when per-route metrics are disabled
+	routes atomic.Value
+}
+
+func newRouteMetrics(cfg *config.Config) *routeMetrics {
+	rm := &routeMetrics{}
+	rm.Update(cfg)
+	return rm
+}
+
+// Update updates the routes.
+func (rm *routeMetrics) Update(cfg *config.Config) {
+	var routes map[string]metrics.RouteTags
+	if len(cfg.Options.MetricsRoutes) > 0 {
+		routes = map[string]metrics.RouteTags{}
+		for i, p := range cfg.Options.GetAllPolicies() {
+			policy := p
+			routes[envoyconfig.PolicyRouteName(i)] = cfg.Options.GetMetricsRouteTags(&policy)
+		}
+	}
+	rm.routes.Store(routes)
+}
+
+// Record records the request of the access log entry. Requests which weren't routed by a policy,
+// like the requests of the authenticate service, aren't recorded.
+func (rm *routeMetrics) Record(ctx context.Context, entry *envoy_data_accesslog_v3.HTTPAccessLogEntry) {
+	routes, _ := rm.routes.Load().(map[string]metrics.RouteTags)
+	route, ok := routes[entry.GetCommonProperties().GetRouteName()]
+	if !ok {
+		return
+	}
+	metrics.RecordRouteRequest(ctx, route,
+		entry.GetResponse().GetResponseCode().GetValue(),
+		entry.GetCommonProperties().GetTimeToLastDownstreamTxByte().AsDuration())
+}
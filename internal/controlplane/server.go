@@ -19,7 +19,9 @@ import (
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/config/envoyconfig"
 	"github.com/pomerium/pomerium/config/envoyconfig/filemgr"
+	"github.com/pomerium/pomerium/internal/accesslog"
 	"github.com/pomerium/pomerium/internal/controlplane/xdsmgr"
+	"github.com/pomerium/pomerium/internal/discovery"
 	"github.com/pomerium/pomerium/internal/envoy/files"
 	"github.com/pomerium/pomerium/internal/events"
 	"github.com/pomerium/pomerium/internal/httputil/reproxy"
@@ -66,6 +68,10 @@ type Server struct {
 	filemgr       *filemgr.Manager
 	metricsMgr    *config.MetricsManager
 	reproxy       *reproxy.Handler
+	discovery     *discovery.Manager
+	accessLog     *accesslog.Manager
+	routeMetrics  *routeMetrics
+	diagnostics   *diagnosticsServer
 
 	haveSetCapacity map[string]bool
 }
@@ -80,6 +86,12 @@ func NewServer(cfg *config.Config, metricsMgr *config.MetricsManager) (*Server,
 	srv.currentConfig.Store(versionedConfig{
 		Config: cfg,
 	})
+	srv.discovery = discovery.New(srv.onDiscoveryChange)
+	srv.discovery.Update(context.Background(), cfg)
+	srv.accessLog = accesslog.New()
+	srv.accessLog.Update(context.Background(), cfg)
+	srv.routeMetrics = newRouteMetrics(cfg)
+	srv.diagnostics = newDiagnosticsServer(srv)
 
 	var err error
 
@@ -101,6 +113,8 @@ func NewServer(cfg *config.Config, metricsMgr *config.MetricsManager) (*Server,
 	)
 	reflection.Register(srv.GRPCServer)
 	srv.registerAccessLogHandlers()
+	srv.registerConfigService()
+	srv.registerIntrospectionService()
 
 	grpc_health_v1.RegisterHealthServer(srv.GRPCServer, pom_grpc.NewHealthCheckServer())
 
@@ -153,6 +167,7 @@ func NewServer(cfg *config.Config, metricsMgr *config.MetricsManager) (*Server,
 
 	srv.xdsmgr = xdsmgr.NewManager(res)
 	envoy_service_discovery_v3.RegisterAggregatedDiscoveryServiceServer(srv.GRPCServer, srv.xdsmgr)
+	srv.diagnostics.Update(ctx, cfg)
 
 	return srv, nil
 }
@@ -167,6 +182,12 @@ func (srv *Server) Run(ctx context.Context) error {
 		})
 	})
 	defer events.Unregister(handle)
+	defer srv.diagnostics.Close()
+
+	// re-resolve the discovered upstreams
+	eg.Go(func() error {
+		return srv.discovery.Run(ctx)
+	})
 
 	// start the gRPC server
 	eg.Go(func() error {
@@ -240,15 +261,30 @@ func (srv *Server) Run(ctx context.Context) error {
 // OnConfigChange updates the pomerium config options.
 func (srv *Server) OnConfigChange(ctx context.Context, cfg *config.Config) error {
 	srv.reproxy.Update(ctx, cfg)
+	srv.discovery.Update(ctx, cfg)
+	srv.accessLog.Update(ctx, cfg)
+	srv.routeMetrics.Update(cfg)
 	prev := srv.currentConfig.Load()
 	srv.currentConfig.Store(versionedConfig{
 		Config:  cfg,
 		version: prev.version + 1,
 	})
+	srv.diagnostics.Update(ctx, cfg)
 	res, err := srv.buildDiscoveryResources(ctx)
 	if err != nil {
 		return err
 	}
+	srv.xdsmgr.SetListenerDrainTimeout(cfg.Options.ListenerDrainTimeout)
 	srv.xdsmgr.Update(ctx, res)
 	return nil
 }
+
+// onDiscoveryChange sends the changed endpoints of discovered upstreams to envoy.
+func (srv *Server) onDiscoveryChange(ctx context.Context) {
+	res, err := srv.buildDiscoveryResources(ctx)
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("controlplane: error building discovery resources")
+		return
+	}
+	srv.xdsmgr.Update(ctx, res)
+}
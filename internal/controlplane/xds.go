@@ -11,8 +11,11 @@ import (
 )
 
 const (
-	clusterTypeURL  = "type.googleapis.com/envoy.config.cluster.v3.Cluster"
-	listenerTypeURL = "type.googleapis.com/envoy.config.listener.v3.Listener"
+	clusterTypeURL            = "type.googleapis.com/envoy.config.cluster.v3.Cluster"
+	listenerTypeURL           = "type.googleapis.com/envoy.config.listener.v3.Listener"
+	endpointTypeURL           = "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment"
+	routeConfigurationTypeURL = "type.googleapis.com/envoy.config.route.v3.RouteConfiguration"
+	secretTypeURL             = "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret"
 )
 
 func (srv *Server) buildDiscoveryResources(ctx context.Context) (map[string][]*envoy_service_discovery_v3.Resource, error) {
@@ -32,6 +35,28 @@ func (srv *Server) buildDiscoveryResources(ctx context.Context) (map[string][]*e
 		})
 	}
 
+	assignments, err := srv.Builder.BuildClusterLoadAssignments(ctx, cfg.Config, srv.discovery.GetEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		any := protoutil.NewAny(assignment)
+		resources[endpointTypeURL] = append(resources[endpointTypeURL], &envoy_service_discovery_v3.Resource{
+			Name:     assignment.ClusterName,
+			Version:  hex.EncodeToString(cryptutil.HashProto(assignment)),
+			Resource: any,
+		})
+	}
+
+	for _, secret := range srv.Builder.BuildConsulConnectSecrets(srv.discovery.GetConsulConnectCertificates()) {
+		any := protoutil.NewAny(secret)
+		resources[secretTypeURL] = append(resources[secretTypeURL], &envoy_service_discovery_v3.Resource{
+			Name:     secret.Name,
+			Version:  hex.EncodeToString(cryptutil.HashProto(secret)),
+			Resource: any,
+		})
+	}
+
 	listeners, err := srv.Builder.BuildListeners(ctx, cfg.Config)
 	if err != nil {
 		return nil, err
@@ -44,5 +69,18 @@ func (srv *Server) buildDiscoveryResources(ctx context.Context) (map[string][]*e
 			Resource: any,
 		})
 	}
+
+	routeConfigurations, err := srv.Builder.BuildRouteConfigurations(ctx, cfg.Config)
+	if err != nil {
+		return nil, err
+	}
+	for _, routeConfiguration := range routeConfigurations {
+		any := protoutil.NewAny(routeConfiguration)
+		resources[routeConfigurationTypeURL] = append(resources[routeConfigurationTypeURL], &envoy_service_discovery_v3.Resource{
+			Name:     routeConfiguration.Name,
+			Version:  hex.EncodeToString(cryptutil.HashProto(routeConfiguration)),
+			Resource: any,
+		})
+	}
 	return resources, nil
 }
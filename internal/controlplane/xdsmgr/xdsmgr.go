@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"sync"
+	"time"
 
 	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/google/uuid"
@@ -20,10 +21,13 @@ import (
 	"github.com/pomerium/pomerium/internal/events"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/signal"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 )
 
 const (
 	maxNonceCacheSize = 1 << 12
+
+	listenerTypeURL = "type.googleapis.com/envoy.config.listener.v3.Listener"
 )
 
 type streamState struct {
@@ -41,6 +45,16 @@ type Manager struct {
 	mu        sync.Mutex
 	nonce     string
 	resources map[string][]*envoy_service_discovery_v3.Resource
+	// the nonces of the last responses which were acked, by type URL
+	ackedNonces map[string]string
+	// the errors of the last responses which were nacked and not followed by an ack, by type URL
+	nacks map[string]string
+
+	// envoy drains the connections of listeners it replaces for the drain timeout
+	listenerDrainTimeout time.Duration
+	lastListenerAck      time.Time
+	listenerReloads      int
+	drainingUntil        time.Time
 
 	nonceToConfig *lru.Cache
 
@@ -57,6 +71,8 @@ func NewManager(resources map[string][]*envoy_service_discovery_v3.Resource) *Ma
 		nonceToConfig: nonceToConfig,
 		nonce:         uuid.NewString(),
 		resources:     resources,
+		ackedNonces:   map[string]string{},
+		nacks:         map[string]string{},
 
 		hostname: getHostname(),
 	}
@@ -134,6 +150,8 @@ func (mgr *Manager) DeltaAggregatedResources(
 				state.clientResourceVersions[resource.Name] = resource.Version
 			}
 
+			mgr.nacks[req.GetTypeUrl()] = req.GetErrorDetail().GetMessage()
+
 			mgr.nackEvent(ctx, req)
 		case req.GetResponseNonce() == mgr.nonce:
 			// an ACK for the last response
@@ -142,12 +160,19 @@ func (mgr *Manager) DeltaAggregatedResources(
 			for _, resource := range mgr.resources[req.GetTypeUrl()] {
 				state.clientResourceVersions[resource.Name] = resource.Version
 			}
+			mgr.ackedNonces[req.GetTypeUrl()] = req.GetResponseNonce()
+			delete(mgr.nacks, req.GetTypeUrl())
 
 			mgr.ackEvent(ctx, req)
 		default:
 			// an ACK for a response that's not the last response
+			delete(mgr.nacks, req.GetTypeUrl())
+
 			mgr.ackEvent(ctx, req)
 		}
+		if req.GetResponseNonce() != "" && req.GetErrorDetail() == nil && req.GetTypeUrl() == listenerTypeURL {
+			mgr.listenersAcked()
+		}
 
 		// update subscriptions
 		for _, name := range req.GetResourceNamesSubscribe() {
@@ -258,6 +283,73 @@ func (mgr *Manager) Update(ctx context.Context, resources map[string][]*envoy_se
 	mgr.signal.Broadcast(ctx)
 }
 
+// SetListenerDrainTimeout sets how long envoy drains the connections of the listeners it
+// replaces.
+func (mgr *Manager) SetListenerDrainTimeout(timeout time.Duration) {
+	mgr.mu.Lock()
+	mgr.listenerDrainTimeout = timeout
+	mgr.mu.Unlock()
+}
+
+// listenersAcked records that envoy applied changed listeners. Except for the first listeners,
+// which don't replace any, the listeners they replace are drained.
+func (mgr *Manager) listenersAcked() {
+	now := time.Now()
+	if !mgr.lastListenerAck.IsZero() {
+		mgr.listenerReloads++
+		mgr.drainingUntil = now.Add(mgr.listenerDrainTimeout)
+		metrics.RecordEnvoyListenerReload(mgr.drainingUntil)
+	}
+	mgr.lastListenerAck = now
+}
+
+// A Snapshot describes the current resources of a Manager.
+type Snapshot struct {
+	Nonce         string `json:"nonce"`
+	ConfigVersion uint64 `json:"config_version"`
+	// Resources are the number of resources by type URL.
+	Resources map[string]int `json:"resources"`
+	// AckedNonces are the nonces of the last responses which were acked by envoy, by type URL.
+	// When they're the current nonce envoy has applied the current resources.
+	AckedNonces map[string]string `json:"acked_nonces"`
+	// Nacks are the errors of the last responses which were rejected by envoy, by type URL. They're
+	// removed once envoy accepts a response of the type.
+	Nacks map[string]string `json:"nacks,omitempty"`
+	// ListenerReloads are the number of times envoy replaced its listeners.
+	ListenerReloads int `json:"listener_reloads"`
+	// DrainingUntil is set while envoy drains the connections of replaced listeners, to when
+	// the remaining connections are closed.
+	DrainingUntil *time.Time `json:"draining_until,omitempty"`
+}
+
+// Snapshot returns a description of the current resources.
+func (mgr *Manager) Snapshot() Snapshot {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	snapshot := Snapshot{
+		Nonce:         mgr.nonce,
+		ConfigVersion: mgr.nonceToConfigVersion(mgr.nonce),
+		Resources:     map[string]int{},
+		AckedNonces:   map[string]string{},
+		Nacks:         map[string]string{},
+	}
+	for typeURL, resources := range mgr.resources {
+		snapshot.Resources[typeURL] = len(resources)
+	}
+	for typeURL, nonce := range mgr.ackedNonces {
+		snapshot.AckedNonces[typeURL] = nonce
+	}
+	for typeURL, nack := range mgr.nacks {
+		snapshot.Nacks[typeURL] = nack
+	}
+	snapshot.ListenerReloads = mgr.listenerReloads
+	if drainingUntil := mgr.drainingUntil; time.Now().Before(drainingUntil) {
+		snapshot.DrainingUntil = &drainingUntil
+	}
+	return snapshot
+}
+
 func (mgr *Manager) nonceToConfigVersion(nonce string) (ver uint64) {
 	val, ok := mgr.nonceToConfig.Get(nonce)
 	if !ok {
@@ -117,3 +117,25 @@ func TestManager(t *testing.T) {
 		}, time.Second*5, time.Millisecond)
 	})
 }
+
+func TestListenerDraining(t *testing.T) {
+	mgr := NewManager(nil)
+	mgr.SetListenerDrainTimeout(time.Minute)
+
+	// the first listeners don't replace any
+	mgr.listenersAcked()
+	snapshot := mgr.Snapshot()
+	assert.Equal(t, 0, snapshot.ListenerReloads)
+	assert.Nil(t, snapshot.DrainingUntil)
+
+	mgr.listenersAcked()
+	snapshot = mgr.Snapshot()
+	assert.Equal(t, 1, snapshot.ListenerReloads)
+	if assert.NotNil(t, snapshot.DrainingUntil) {
+		assert.WithinDuration(t, time.Now().Add(time.Minute), *snapshot.DrainingUntil, 5*time.Second)
+	}
+
+	mgr.SetListenerDrainTimeout(0)
+	mgr.listenersAcked()
+	assert.Nil(t, mgr.Snapshot().DrainingUntil)
+}
@@ -22,15 +22,18 @@ var (
 )
 
 type serverConfig struct {
-	deletePermanentlyAfter  time.Duration
-	secret                  []byte
-	storageType             string
-	storageConnectionString string
-	storageCAFile           string
-	storageCertSkipVerify   bool
-	storageCertificate      *tls.Certificate
-	getAllPageSize          int
-	registryTTL             time.Duration
+	deletePermanentlyAfter     time.Duration
+	secret                     []byte
+	acceptedSecrets            [][]byte
+	storageType                string
+	storageConnectionString    string
+	storageCAFile              string
+	storageCertSkipVerify      bool
+	storageCertificate         *tls.Certificate
+	storageCacheSize           int
+	storageSnapshotParallelism int
+	getAllPageSize             int
+	registryTTL                time.Duration
 }
 
 func newServerConfig(options ...ServerOption) *serverConfig {
@@ -83,6 +86,13 @@ func WithGetSharedKey(getSharedKey func() ([]byte, error)) ServerOption {
 	}
 }
 
+// WithAcceptedSharedKeys sets the secrets, besides the secret, which records can be decrypted with.
+func WithAcceptedSharedKeys(acceptedSharedKeys [][]byte) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.acceptedSecrets = acceptedSharedKeys
+	}
+}
+
 // WithStorageType sets the storage type.
 func WithStorageType(typ string) ServerOption {
 	return func(cfg *serverConfig) {
@@ -117,3 +127,19 @@ func WithStorageCertificate(certificate *tls.Certificate) ServerOption {
 		cfg.storageCertificate = certificate
 	}
 }
+
+// WithStorageCacheSize sets the number of reads of the storage backend which are cached. 0
+// disables the cache.
+func WithStorageCacheSize(size int) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.storageCacheSize = size
+	}
+}
+
+// WithStorageSnapshotParallelism sets the maximum number of ranges of records read in parallel
+// by the postgres storage backend when syncing the latest records.
+func WithStorageSnapshotParallelism(parallelism int) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.storageSnapshotParallelism = parallelism
+	}
+}
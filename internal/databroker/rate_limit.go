@@ -0,0 +1,105 @@
+package databroker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// RateLimit counts a request against a rate limit. The requests are counted with a sliding window
+// counter: the requests of the previous fixed window are weighted by how much of it overlaps the
+// sliding window, and added to the requests of the current fixed window.
+func (srv *Server) RateLimit(ctx context.Context, req *databroker.RateLimitRequest) (*databroker.RateLimitResponse, error) {
+	_, span := trace.StartSpan(ctx, "databroker.grpc.RateLimit")
+	defer span.End()
+	log.Debug(ctx).
+		Str("key", req.GetKey()).
+		Uint32("limit", req.GetLimit()).
+		Dur("window", req.GetWindow().AsDuration()).
+		Msg("rate limit")
+
+	window := req.GetWindow().AsDuration()
+	if req.GetKey() == "" || req.GetLimit() == 0 || window <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "key, limit and window are required")
+	}
+
+	db, err := srv.getBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	idx := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - idx*int64(window))
+	// the counters are needed until the end of the next window
+	ttl := 2 * window
+
+	prev, err := db.IncrementCounter(ctx, getRateLimitCounterName(req.GetKey(), idx-1), 0, ttl)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := db.IncrementCounter(ctx, getRateLimitCounterName(req.GetKey(), idx), 1, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	count := getSlidingWindowCount(prev, cur, elapsed, window)
+	if count <= float64(req.GetLimit()) {
+		return &databroker.RateLimitResponse{
+			Allowed: true,
+			Count:   count,
+		}, nil
+	}
+
+	// denied requests don't count against the limit, so clients can retry once the window slides
+	cur, err = db.IncrementCounter(ctx, getRateLimitCounterName(req.GetKey(), idx), -1, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &databroker.RateLimitResponse{
+		Allowed:    false,
+		Count:      getSlidingWindowCount(prev, cur, elapsed, window),
+		RetryAfter: durationpb.New(getSlidingWindowRetryAfter(prev, cur, req.GetLimit(), elapsed, window)),
+	}, nil
+}
+
+func getRateLimitCounterName(key string, idx int64) string {
+	return fmt.Sprintf("rate_limit/%s/%d", key, idx)
+}
+
+// getSlidingWindowCount returns the estimated number of requests in the sliding window, assuming
+// the requests of the previous window were evenly distributed.
+func getSlidingWindowCount(prev, cur int64, elapsed, window time.Duration) float64 {
+	weight := 1 - float64(elapsed)/float64(window)
+	return float64(prev)*weight + float64(cur)
+}
+
+// getSlidingWindowRetryAfter returns how long until another request would be allowed, rounded up
+// to the millisecond.
+func getSlidingWindowRetryAfter(prev, cur int64, limit uint32, elapsed, window time.Duration) time.Duration {
+	d := slidingWindowRetryAfter(prev, cur, limit, elapsed, window)
+	return (d + time.Millisecond - 1).Truncate(time.Millisecond)
+}
+
+func slidingWindowRetryAfter(prev, cur int64, limit uint32, elapsed, window time.Duration) time.Duration {
+	l := float64(limit)
+	// the requests of the previous window are discounted as the window slides
+	if float64(cur)+1 <= l && prev > 0 {
+		at := time.Duration(float64(window) * (1 - (l-float64(cur)-1)/float64(prev)))
+		if at < elapsed {
+			return 0
+		}
+		return at - elapsed
+	}
+	// otherwise the requests of the current window are discounted during the next one
+	at := time.Duration(float64(window) * (1 - (l-1)/float64(cur)))
+	return window - elapsed + at
+}
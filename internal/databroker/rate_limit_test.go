@@ -0,0 +1,63 @@
+package databroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+func TestServer_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(newServerConfig())
+
+	_, err := srv.RateLimit(ctx, &databroker.RateLimitRequest{Key: "test"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req := &databroker.RateLimitRequest{
+		Key:    "test",
+		Limit:  2,
+		Window: durationpb.New(24 * time.Hour),
+	}
+	for i := 0; i < 2; i++ {
+		res, err := srv.RateLimit(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, res.GetAllowed())
+	}
+	for i := 0; i < 2; i++ {
+		res, err := srv.RateLimit(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, res.GetAllowed(), "should deny requests over the limit")
+		assert.Equal(t, float64(2), res.GetCount(), "shouldn't count denied requests")
+		assert.Greater(t, res.GetRetryAfter().AsDuration(), time.Duration(0))
+	}
+
+	res, err := srv.RateLimit(ctx, &databroker.RateLimitRequest{
+		Key:    "other",
+		Limit:  2,
+		Window: durationpb.New(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.True(t, res.GetAllowed(), "should limit keys separately")
+}
+
+func TestSlidingWindow(t *testing.T) {
+	window := time.Minute
+
+	assert.Equal(t, float64(15), getSlidingWindowCount(10, 10, 30*time.Second, window))
+	assert.Equal(t, float64(10), getSlidingWindowCount(10, 0, 0, window))
+
+	// 10 * (1 - 36/60) + 5 + 1 = 10
+	assert.Equal(t, 6*time.Second, getSlidingWindowRetryAfter(10, 5, 10, 30*time.Second, window),
+		"should wait for the previous window to slide past")
+	// 10 * (1 - 6/60) + 1 = 10
+	assert.Equal(t, 36*time.Second, getSlidingWindowRetryAfter(0, 10, 10, 30*time.Second, window),
+		"should wait for the current window to slide past")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/registry"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
 	"github.com/pomerium/pomerium/internal/telemetry/trace"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
@@ -27,6 +28,9 @@ import (
 	"github.com/pomerium/pomerium/pkg/storage/redis"
 )
 
+// leaseHolderSeparator separates the holder of a lease from the random part of its id.
+const leaseHolderSeparator = "/"
+
 // Server implements the databroker service using an in memory database.
 type Server struct {
 	cfg *serverConfig
@@ -34,6 +38,8 @@ type Server struct {
 	mu       sync.RWMutex
 	backend  storage.Backend
 	registry registry.Interface
+
+	stats serverStats
 }
 
 // New creates a new server.
@@ -89,12 +95,17 @@ func (srv *Server) AcquireLease(ctx context.Context, req *databroker.AcquireLeas
 	}
 
 	leaseID := uuid.NewString()
+	if holder := req.GetHolder(); holder != "" {
+		// the holder is part of the id, so the backends don't have to store it
+		leaseID = holder + leaseHolderSeparator + leaseID
+	}
 	acquired, err := db.Lease(ctx, req.GetName(), leaseID, req.GetDuration().AsDuration())
 	if err != nil {
 		return nil, err
 	} else if !acquired {
 		return nil, status.Error(codes.AlreadyExists, "lease is already taken")
 	}
+	metrics.RecordStorageLeaseChange(ctx, req.GetName(), metrics.LeaseAcquired)
 
 	return &databroker.AcquireLeaseResponse{
 		Id: leaseID,
@@ -128,6 +139,45 @@ func (srv *Server) Get(ctx context.Context, req *databroker.GetRequest) (*databr
 	}, nil
 }
 
+// ListLeases lists the leases which are held.
+func (srv *Server) ListLeases(ctx context.Context, req *databroker.ListLeasesRequest) (*databroker.ListLeasesResponse, error) {
+	_, span := trace.StartSpan(ctx, "databroker.grpc.ListLeases")
+	defer span.End()
+	log.Debug(ctx).Msg("list leases")
+
+	db, err := srv.getBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	leases, err := db.ListLeases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range leases {
+		if i := strings.LastIndex(l.GetId(), leaseHolderSeparator); i >= 0 {
+			l.Holder = l.GetId()[:i]
+		}
+	}
+
+	return &databroker.ListLeasesResponse{
+		Leases: leases,
+	}, nil
+}
+
+func isLeaseHeld(ctx context.Context, db storage.Backend, leaseName, leaseID string) (bool, error) {
+	leases, err := db.ListLeases(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, l := range leases {
+		if l.GetName() == leaseName {
+			return l.GetId() == leaseID, nil
+		}
+	}
+	return false, nil
+}
+
 // Query queries for records.
 func (srv *Server) Query(ctx context.Context, req *databroker.QueryRequest) (*databroker.QueryResponse, error) {
 	_, span := trace.StartSpan(ctx, "databroker.grpc.Query")
@@ -208,7 +258,12 @@ func (srv *Server) Put(ctx context.Context, req *databroker.PutRequest) (*databr
 		return nil, err
 	}
 
-	serverVersion, err := db.Put(ctx, records)
+	put := db.Put
+	if req.GetCompareVersions() {
+		put = db.CompareAndPut
+	}
+
+	serverVersion, err := put(ctx, records)
 	if err != nil {
 		return nil, err
 	}
@@ -238,6 +293,7 @@ func (srv *Server) ReleaseLease(ctx context.Context, req *databroker.ReleaseLeas
 	if err != nil {
 		return nil, err
 	}
+	metrics.RecordStorageLeaseChange(ctx, req.GetName(), metrics.LeaseReleased)
 
 	return new(emptypb.Empty), nil
 }
@@ -257,6 +313,15 @@ func (srv *Server) RenewLease(ctx context.Context, req *databroker.RenewLeaseReq
 		return nil, err
 	}
 
+	// the backends acquire leases which aren't held when they're renewed, so leases which were
+	// released, or expired, are checked first to make their previous holder stop
+	held, err := isLeaseHeld(ctx, db, req.GetName(), req.GetId())
+	if err != nil {
+		return nil, err
+	} else if !held {
+		return nil, status.Error(codes.AlreadyExists, "lease no longer held")
+	}
+
 	acquired, err := db.Lease(ctx, req.GetName(), req.GetId(), req.GetDuration().AsDuration())
 	if err != nil {
 		return nil, err
@@ -314,13 +379,18 @@ func (srv *Server) Sync(req *databroker.SyncRequest, stream databroker.DataBroke
 	}
 	defer func() { _ = recordStream.Close() }()
 
+	client := srv.addSyncClient(ctx, req.GetRecordVersion())
+	defer srv.removeSyncClient(client)
+
 	for recordStream.Next(true) {
+		record := recordStream.Record()
 		err = stream.Send(&databroker.SyncResponse{
-			Record: recordStream.Record(),
+			Record: record,
 		})
 		if err != nil {
 			return err
 		}
+		srv.setSyncClientRecordVersion(client, record.GetVersion())
 	}
 
 	return recordStream.Err()
@@ -344,19 +414,45 @@ func (srv *Server) SyncLatest(req *databroker.SyncLatestRequest, stream databrok
 		return err
 	}
 
+	// resuming relies on the records being streamed in the same order, which redis doesn't do
+	srv.mu.RLock()
+	resumable := srv.cfg.storageType != config.StorageRedisName
+	srv.mu.RUnlock()
+
+	var token *databroker.SyncLatestResumeToken
+	if req.GetResumeToken() != "" {
+		token, err = decodeSyncLatestResumeToken(req.GetResumeToken())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		} else if !resumable || token.GetType() != req.GetType() {
+			return status.Error(codes.InvalidArgument, "invalid resume token")
+		}
+	}
+
 	serverVersion, recordVersion, recordStream, err := backend.SyncLatest(ctx, req.GetType(), nil)
 	if err != nil {
 		return err
 	}
 
+	if token != nil {
+		if token.GetServerVersion() != serverVersion {
+			return storage.ErrInvalidServerVersion
+		}
+		// the records which changed since the sync latest started will be sent by Sync
+		recordVersion = token.GetRecordVersion()
+	}
+
+	sender := newSyncLatestSender(stream, req, serverVersion, recordVersion, resumable)
+	skipping := token != nil
 	for recordStream.Next(false) {
 		record := recordStream.Record()
+		if skipping {
+			// skip the records up to the last one sent
+			skipping = record.GetType() != token.GetRecordType() || record.GetId() != token.GetRecordId()
+			continue
+		}
 		if req.GetType() == "" || req.GetType() == record.GetType() {
-			err = stream.Send(&databroker.SyncLatestResponse{
-				Response: &databroker.SyncLatestResponse_Record{
-					Record: record,
-				},
-			})
+			err = sender.send(record)
 			if err != nil {
 				return err
 			}
@@ -365,6 +461,13 @@ func (srv *Server) SyncLatest(req *databroker.SyncLatestRequest, stream databrok
 	if recordStream.Err() != nil {
 		return recordStream.Err()
 	}
+	if skipping {
+		return status.Error(codes.FailedPrecondition, "resume token expired, the last record sent was deleted")
+	}
+	err = sender.flush()
+	if err != nil {
+		return err
+	}
 
 	// always send the server version last in case there are no records
 	return stream.Send(&databroker.SyncLatestResponse{
@@ -408,7 +511,11 @@ func (srv *Server) newBackendLocked() (backend storage.Backend, err error) {
 		return inmemory.New(), nil
 	case config.StoragePostgresName:
 		log.Info(ctx).Msg("using postgres store")
-		backend = postgres.New(srv.cfg.storageConnectionString)
+		var options []postgres.Option
+		if srv.cfg.storageSnapshotParallelism > 0 {
+			options = append(options, postgres.WithSnapshotParallelism(srv.cfg.storageSnapshotParallelism))
+		}
+		backend = postgres.New(srv.cfg.storageConnectionString, options...)
 	case config.StorageRedisName:
 		log.Info(ctx).Msg("using redis store")
 		backend, err = redis.New(
@@ -419,7 +526,7 @@ func (srv *Server) newBackendLocked() (backend storage.Backend, err error) {
 			return nil, fmt.Errorf("failed to create new redis storage: %w", err)
 		}
 		if srv.cfg.secret != nil {
-			backend, err = storage.NewEncryptedBackend(srv.cfg.secret, backend)
+			backend, err = storage.NewEncryptedBackend(srv.cfg.secret, backend, srv.cfg.acceptedSecrets...)
 			if err != nil {
 				return nil, err
 			}
@@ -427,6 +534,13 @@ func (srv *Server) newBackendLocked() (backend storage.Backend, err error) {
 	default:
 		return nil, fmt.Errorf("unsupported storage type: %s", srv.cfg.storageType)
 	}
+	if srv.cfg.storageCacheSize > 0 {
+		log.Info(ctx).Int("size", srv.cfg.storageCacheSize).Msg("caching storage reads")
+		backend, err = storage.NewCachedBackend(srv.cfg.storageCacheSize, backend)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return backend, nil
 }
 
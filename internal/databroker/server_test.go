@@ -264,11 +264,109 @@ func TestServer_Sync(t *testing.T) {
 			return ctx.Err()
 
 		}
+
+		// the version of the client is recorded once the record is sent
+		assert.Eventually(t, func() bool {
+			stats := srv.GetStats(ctx)
+			return len(stats.SyncClients) == 1 &&
+				stats.SyncClients[0].RecordVersion == stats.RecordVersion
+		}, time.Second, 10*time.Millisecond)
+		stats := srv.GetStats(ctx)
+		assert.Equal(t, "TEST@127.0.0.1", stats.SyncClients[0].Name)
+		assert.Equal(t, map[string]int64{any.TypeUrl: 1}, stats.RecordCounts)
 		return nil
 	})
 	assert.NoError(t, eg.Wait())
 }
 
+func TestServer_SyncLatestChunks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := newServer(newServerConfig())
+	put := func(id string, deleted bool) {
+		s := &session.Session{Id: id}
+		any := protoutil.NewAny(s)
+		record := &databroker.Record{Type: any.TypeUrl, Id: s.Id, Data: any}
+		if deleted {
+			record.DeletedAt = timestamppb.Now()
+		}
+		_, err := srv.Put(ctx, &databroker.PutRequest{Records: []*databroker.Record{record}})
+		require.NoError(t, err)
+	}
+	for _, id := range []string{"5", "3", "1", "4", "2"} {
+		put(id, false)
+	}
+
+	gs := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(gs, srv)
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = gs.Serve(li) }()
+	defer gs.Stop()
+
+	cc, err := grpc.DialContext(ctx, li.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := databroker.NewDataBrokerServiceClient(cc)
+
+	syncLatest := func(resumeToken string) ([][]string, []string, *databroker.Versions, error) {
+		stream, err := client.SyncLatest(ctx, &databroker.SyncLatestRequest{
+			ChunkSize:   2,
+			ResumeToken: resumeToken,
+		})
+		require.NoError(t, err)
+
+		var chunks [][]string
+		var tokens []string
+		var versions *databroker.Versions
+		for {
+			res, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return chunks, tokens, versions, nil
+			} else if err != nil {
+				return nil, nil, nil, err
+			}
+
+			if chunk := res.GetChunk(); chunk != nil {
+				var ids []string
+				for _, record := range chunk.GetRecords() {
+					ids = append(ids, record.GetId())
+				}
+				chunks = append(chunks, ids)
+				tokens = append(tokens, chunk.GetResumeToken())
+			} else {
+				versions = res.GetVersions()
+			}
+		}
+	}
+
+	chunks, tokens, versions, err := syncLatest("")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}, {"5"}}, chunks, "records should be sent in order, in chunks")
+	assert.Equal(t, uint64(5), versions.GetLatestRecordVersion())
+
+	t.Run("resume", func(t *testing.T) {
+		put("6", false)
+
+		chunks, _, resumed, err := syncLatest(tokens[0])
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"3", "4"}, {"5", "6"}}, chunks)
+		assert.Equal(t, versions.GetLatestRecordVersion(), resumed.GetLatestRecordVersion(),
+			"the record version should be the one from before the changes")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, _, err := syncLatest("???")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("expired", func(t *testing.T) {
+		put("4", true)
+
+		_, _, _, err := syncLatest(tokens[1])
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
 func TestServerInvalidStorage(t *testing.T) {
 	srv := newServer(&serverConfig{
 		storageType: "<INVALID>",
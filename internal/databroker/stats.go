@@ -0,0 +1,153 @@
+package databroker
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// counting records reads all of them, so the counts are only refreshed every interval
+const recordCountsRefreshInterval = 30 * time.Second
+
+// A syncClient is a client syncing changes with Sync.
+type syncClient struct {
+	name          string
+	recordVersion uint64
+}
+
+type serverStats struct {
+	mu      sync.Mutex
+	clients map[*syncClient]struct{}
+	// the latest record version sent to any client
+	latestRecordVersion uint64
+
+	// counting records can take a while, so the counts have their own lock to not block streams
+	countsMu              sync.Mutex
+	recordCounts          map[string]int64
+	recordCountsVersion   uint64
+	recordCountsUpdatedAt time.Time
+}
+
+// addSyncClient tracks the client of a Sync stream. The client is named after the syncer id
+// it sends, and its IP address.
+func (srv *Server) addSyncClient(ctx context.Context, recordVersion uint64) *syncClient {
+	name := "unknown"
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(databroker.SyncerIDMetadataKey); len(ids) > 0 {
+			name = ids[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		name += "@" + host
+	}
+
+	client := &syncClient{name: name, recordVersion: recordVersion}
+	srv.stats.mu.Lock()
+	if srv.stats.clients == nil {
+		srv.stats.clients = map[*syncClient]struct{}{}
+	}
+	srv.stats.clients[client] = struct{}{}
+	srv.stats.mu.Unlock()
+	return client
+}
+
+func (srv *Server) removeSyncClient(client *syncClient) {
+	srv.stats.mu.Lock()
+	delete(srv.stats.clients, client)
+	srv.stats.mu.Unlock()
+}
+
+// setSyncClientRecordVersion records the version of the last record sent to a client.
+func (srv *Server) setSyncClientRecordVersion(client *syncClient, recordVersion uint64) {
+	atomic.StoreUint64(&client.recordVersion, recordVersion)
+	srv.stats.mu.Lock()
+	if recordVersion > srv.stats.latestRecordVersion {
+		srv.stats.latestRecordVersion = recordVersion
+	}
+	srv.stats.mu.Unlock()
+}
+
+// GetStats returns the number of records by type, the latest record version and the record
+// versions of the clients syncing changes.
+func (srv *Server) GetStats(ctx context.Context) *metrics.DataBrokerStats {
+	srv.stats.countsMu.Lock()
+	if time.Since(srv.stats.recordCountsUpdatedAt) >= recordCountsRefreshInterval {
+		counts, recordVersion, err := srv.countRecords(ctx)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("databroker: error counting records")
+		} else {
+			srv.stats.recordCounts = counts
+			srv.stats.recordCountsVersion = recordVersion
+			srv.stats.recordCountsUpdatedAt = time.Now()
+		}
+	}
+	stats := &metrics.DataBrokerStats{
+		RecordCounts:  srv.stats.recordCounts,
+		RecordVersion: srv.stats.recordCountsVersion,
+	}
+	srv.stats.countsMu.Unlock()
+
+	srv.stats.mu.Lock()
+	defer srv.stats.mu.Unlock()
+
+	if srv.stats.latestRecordVersion > stats.RecordVersion {
+		stats.RecordVersion = srv.stats.latestRecordVersion
+	}
+
+	// the most behind client is reported when there are several with the same name
+	versions := map[string]uint64{}
+	for client := range srv.stats.clients {
+		recordVersion := atomic.LoadUint64(&client.recordVersion)
+		if v, ok := versions[client.name]; !ok || recordVersion < v {
+			versions[client.name] = recordVersion
+		}
+	}
+	for name, recordVersion := range versions {
+		stats.SyncClients = append(stats.SyncClients, metrics.DataBrokerSyncClient{
+			Name:          name,
+			RecordVersion: recordVersion,
+		})
+	}
+	sort.Slice(stats.SyncClients, func(i, j int) bool {
+		return stats.SyncClients[i].Name < stats.SyncClients[j].Name
+	})
+	return stats
+}
+
+func (srv *Server) countRecords(ctx context.Context) (map[string]int64, uint64, error) {
+	backend, err := srv.getBackend()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	_, recordVersion, stream, err := backend.SyncLatest(ctx, "", nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	counts := map[string]int64{}
+	for stream.Next(false) {
+		if record := stream.Record(); record.GetDeletedAt() == nil {
+			counts[record.GetType()]++
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, 0, err
+	}
+	return counts, recordVersion, nil
+}
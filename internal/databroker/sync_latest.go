@@ -0,0 +1,98 @@
+package databroker
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// chunks are kept well below the default gRPC maximum message size of 4MB
+const maxSyncLatestChunkBytes = 1024 * 1024
+
+// A syncLatestSender sends the records of a sync latest, in chunks if the client asked for them.
+type syncLatestSender struct {
+	stream     databroker.DataBrokerService_SyncLatestServer
+	chunkSize  int
+	resumable  bool
+	token      *databroker.SyncLatestResumeToken
+	chunk      *databroker.RecordChunk
+	chunkBytes int
+}
+
+func newSyncLatestSender(
+	stream databroker.DataBrokerService_SyncLatestServer,
+	req *databroker.SyncLatestRequest,
+	serverVersion, recordVersion uint64,
+	resumable bool,
+) *syncLatestSender {
+	return &syncLatestSender{
+		stream:    stream,
+		chunkSize: int(req.GetChunkSize()),
+		resumable: resumable,
+		token: &databroker.SyncLatestResumeToken{
+			ServerVersion: serverVersion,
+			RecordVersion: recordVersion,
+			Type:          req.GetType(),
+		},
+		chunk: &databroker.RecordChunk{ServerVersion: serverVersion},
+	}
+}
+
+func (sender *syncLatestSender) send(record *databroker.Record) error {
+	if sender.chunkSize <= 0 {
+		return sender.stream.Send(&databroker.SyncLatestResponse{
+			Response: &databroker.SyncLatestResponse_Record{
+				Record: record,
+			},
+		})
+	}
+
+	sender.chunk.Records = append(sender.chunk.Records, record)
+	sender.chunkBytes += proto.Size(record)
+	if len(sender.chunk.Records) >= sender.chunkSize || sender.chunkBytes >= maxSyncLatestChunkBytes {
+		return sender.flush()
+	}
+	return nil
+}
+
+// flush sends the pending chunk, with a token to resume after its last record.
+func (sender *syncLatestSender) flush() error {
+	if len(sender.chunk.Records) == 0 {
+		return nil
+	}
+
+	if sender.resumable {
+		last := sender.chunk.Records[len(sender.chunk.Records)-1]
+		sender.token.RecordType = last.GetType()
+		sender.token.RecordId = last.GetId()
+		sender.chunk.ResumeToken = encodeSyncLatestResumeToken(sender.token)
+	}
+	err := sender.stream.Send(&databroker.SyncLatestResponse{
+		Response: &databroker.SyncLatestResponse_Chunk{
+			Chunk: sender.chunk,
+		},
+	})
+	sender.chunk = &databroker.RecordChunk{ServerVersion: sender.chunk.GetServerVersion()}
+	sender.chunkBytes = 0
+	return err
+}
+
+func encodeSyncLatestResumeToken(token *databroker.SyncLatestResumeToken) string {
+	bs, _ := proto.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+func decodeSyncLatestResumeToken(rawToken string) (*databroker.SyncLatestResumeToken, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resume token: %w", err)
+	}
+	token := new(databroker.SyncLatestResumeToken)
+	if err := proto.Unmarshal(bs, token); err != nil {
+		return nil, fmt.Errorf("invalid resume token: %w", err)
+	}
+	return token, nil
+}
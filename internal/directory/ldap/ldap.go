@@ -0,0 +1,190 @@
+// Package ldap implements a directory provider for LDAP and Active Directory.
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/ldap"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+)
+
+// Name is the name of the LDAP provider.
+const Name = ldap.Name
+
+type config struct {
+	url            string
+	serviceAccount *ldap.ServiceAccount
+}
+
+// An Option updates the LDAP configuration.
+type Option func(*config)
+
+// WithServiceAccount sets the service account in the config.
+func WithServiceAccount(serviceAccount *ldap.ServiceAccount) Option {
+	return func(cfg *config) {
+		cfg.serviceAccount = serviceAccount
+	}
+}
+
+// WithURL sets the LDAP server url in the config.
+func WithURL(url string) Option {
+	return func(cfg *config) {
+		cfg.url = url
+	}
+}
+
+func getConfig(options ...Option) *config {
+	cfg := new(config)
+	for _, option := range options {
+		option(cfg)
+	}
+	return cfg
+}
+
+// Provider implements a directory provider using LDAP searches.
+type Provider struct {
+	cfg *config
+}
+
+// New creates a new LDAP Provider.
+func New(options ...Option) *Provider {
+	return &Provider{
+		cfg: getConfig(options...),
+	}
+}
+
+// User returns a user's directory information. Since group membership may be nested,
+// all the groups in the directory are searched.
+func (p *Provider) User(ctx context.Context, userID, accessToken string) (*directory.User, error) {
+	_, users, err := p.UserGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.Id == userID {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("ldap: user not found")
+}
+
+// UserGroups returns all the users and groups in the directory. Groups which are members
+// of other groups are resolved, so users are members of all the groups they transitively
+// belong to.
+func (p *Provider) UserGroups(ctx context.Context) ([]*directory.Group, []*directory.User, error) {
+	sa := p.cfg.serviceAccount
+	if sa == nil {
+		return nil, nil, fmt.Errorf("ldap: service account not defined")
+	}
+
+	conn, err := sa.Connect(ctx, p.cfg.url)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	userEntries, err := conn.Search(ctx, &ldap.SearchRequest{
+		BaseDN:     sa.UserBaseDN,
+		Scope:      ldap.ScopeWholeSubtree,
+		Filter:     sa.UserFilter,
+		Attributes: sa.UserAttributes(),
+		PageSize:   sa.PageSize,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("ldap: error searching users: %w", err)
+	}
+	groupEntries, err := conn.Search(ctx, &ldap.SearchRequest{
+		BaseDN:     sa.GroupBaseDN,
+		Scope:      ldap.ScopeWholeSubtree,
+		Filter:     sa.GroupFilter,
+		Attributes: []string{sa.GroupNameAttribute, sa.GroupMemberAttribute},
+		PageSize:   sa.PageSize,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("ldap: error searching groups: %w", err)
+	}
+
+	// DNs are compared case-insensitively
+	usersByDN := map[string]*directory.User{}
+	for _, entry := range userEntries {
+		id := entry.GetAttributeValue(sa.UserIDAttribute)
+		if id == "" {
+			continue
+		}
+		usersByDN[normalizeDN(entry.DN)] = &directory.User{
+			Id:          id,
+			DisplayName: entry.GetAttributeValue(sa.UserNameAttribute),
+			Email:       entry.GetAttributeValue(sa.UserEmailAttribute),
+		}
+	}
+	groupsByDN := map[string]*ldap.Entry{}
+	for _, entry := range groupEntries {
+		groupsByDN[normalizeDN(entry.DN)] = entry
+	}
+
+	directoryGroups := make([]*directory.Group, 0, len(groupEntries))
+	for _, entry := range groupEntries {
+		directoryGroups = append(directoryGroups, &directory.Group{
+			Id:   entry.DN,
+			Name: entry.GetAttributeValue(sa.GroupNameAttribute),
+		})
+
+		for userDN := range getGroupUserDNs(sa, groupsByDN, entry, map[string]struct{}{}) {
+			if u, ok := usersByDN[userDN]; ok {
+				u.GroupIds = append(u.GroupIds, entry.DN)
+			}
+		}
+	}
+	sort.Slice(directoryGroups, func(i, j int) bool {
+		return directoryGroups[i].Id < directoryGroups[j].Id
+	})
+
+	directoryUsers := make([]*directory.User, 0, len(usersByDN))
+	for _, u := range usersByDN {
+		sort.Strings(u.GroupIds)
+		directoryUsers = append(directoryUsers, u)
+	}
+	sort.Slice(directoryUsers, func(i, j int) bool {
+		return directoryUsers[i].Id < directoryUsers[j].Id
+	})
+
+	return directoryGroups, directoryUsers, nil
+}
+
+// getGroupUserDNs returns the DNs of all the non-group members of a group, including the
+// members of nested groups. Visited groups are tracked to handle membership cycles.
+func getGroupUserDNs(
+	sa *ldap.ServiceAccount,
+	groupsByDN map[string]*ldap.Entry,
+	group *ldap.Entry,
+	visited map[string]struct{},
+) map[string]struct{} {
+	userDNs := map[string]struct{}{}
+	visited[normalizeDN(group.DN)] = struct{}{}
+	for _, memberDN := range group.GetAttributeValues(sa.GroupMemberAttribute) {
+		memberDN = normalizeDN(memberDN)
+		nested, ok := groupsByDN[memberDN]
+		if !ok {
+			userDNs[memberDN] = struct{}{}
+			continue
+		}
+		if _, ok := visited[memberDN]; ok {
+			continue
+		}
+		for userDN := range getGroupUserDNs(sa, groupsByDN, nested, visited) {
+			userDNs[userDN] = struct{}{}
+		}
+	}
+	return userDNs
+}
+
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(parts[i]))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ldapclient "github.com/pomerium/pomerium/internal/ldap"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func newTestServer(t *testing.T) *testutil.LDAPServer {
+	t.Helper()
+
+	user := func(uid, name string) *ldapclient.Entry {
+		return &ldapclient.Entry{
+			DN: "uid=" + uid + ",ou=People,dc=example,dc=com",
+			Attributes: []*ldapclient.EntryAttribute{
+				{Name: "objectClass", Values: []string{"person"}},
+				{Name: "uid", Values: []string{uid}},
+				{Name: "cn", Values: []string{name}},
+				{Name: "mail", Values: []string{uid + "@example.com"}},
+			},
+		}
+	}
+	group := func(cn string, members ...string) *ldapclient.Entry {
+		return &ldapclient.Entry{
+			DN: "cn=" + cn + ",ou=Groups,dc=example,dc=com",
+			Attributes: []*ldapclient.EntryAttribute{
+				{Name: "objectClass", Values: []string{"groupOfNames"}},
+				{Name: "cn", Values: []string{cn}},
+				{Name: "member", Values: members},
+			},
+		}
+	}
+
+	srv, err := testutil.NewLDAPServer([]*ldapclient.Entry{
+		user("alice", "Alice"),
+		user("bob", "Bob"),
+		user("carol", "Carol"),
+		// admins and engineering are members of each other
+		group("admins", "uid=alice,ou=People,dc=example,dc=com", "cn=engineering,ou=groups,dc=example,dc=com"),
+		group("engineering", "UID=bob, ou=People, dc=example, dc=com", "cn=admins,ou=Groups,dc=example,dc=com"),
+		group("sales", "uid=carol,ou=People,dc=example,dc=com"),
+		group("all", "cn=sales,ou=Groups,dc=example,dc=com", "cn=admins,ou=Groups,dc=example,dc=com"),
+	}, map[string]string{
+		"cn=pomerium,dc=example,dc=com": "secret",
+	})
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProvider_UserGroups(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	srv := newTestServer(t)
+	serviceAccount, err := ldapclient.ParseServiceAccount(`{"bind_dn": "cn=pomerium,dc=example,dc=com", "bind_password": "secret", "base_dn": "dc=example,dc=com", "page_size": 2}`)
+	require.NoError(t, err)
+
+	p := New(WithURL(srv.URL()), WithServiceAccount(serviceAccount))
+	groups, users, err := p.UserGroups(ctx)
+	require.NoError(t, err)
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "id": "cn=admins,ou=Groups,dc=example,dc=com", "name": "admins" },
+		{ "id": "cn=all,ou=Groups,dc=example,dc=com", "name": "all" },
+		{ "id": "cn=engineering,ou=Groups,dc=example,dc=com", "name": "engineering" },
+		{ "id": "cn=sales,ou=Groups,dc=example,dc=com", "name": "sales" }
+	]`, groups)
+	testutil.AssertProtoJSONEqual(t, `[
+		{ "id": "alice", "displayName": "Alice", "email": "alice@example.com", "groupIds": [
+			"cn=admins,ou=Groups,dc=example,dc=com",
+			"cn=all,ou=Groups,dc=example,dc=com",
+			"cn=engineering,ou=Groups,dc=example,dc=com"
+		] },
+		{ "id": "bob", "displayName": "Bob", "email": "bob@example.com", "groupIds": [
+			"cn=admins,ou=Groups,dc=example,dc=com",
+			"cn=all,ou=Groups,dc=example,dc=com",
+			"cn=engineering,ou=Groups,dc=example,dc=com"
+		] },
+		{ "id": "carol", "displayName": "Carol", "email": "carol@example.com", "groupIds": [
+			"cn=all,ou=Groups,dc=example,dc=com",
+			"cn=sales,ou=Groups,dc=example,dc=com"
+		] }
+	]`, users)
+
+	user, err := p.User(ctx, "carol", "")
+	require.NoError(t, err)
+	assert.Equal(t, "carol@example.com", user.GetEmail())
+}
+
+func TestProvider_InvalidCredentials(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	srv := newTestServer(t)
+	serviceAccount, err := ldapclient.ParseServiceAccount(`{"bind_dn": "cn=pomerium,dc=example,dc=com", "bind_password": "wrong", "base_dn": "dc=example,dc=com"}`)
+	require.NoError(t, err)
+
+	p := New(WithURL(srv.URL()), WithServiceAccount(serviceAccount))
+	_, _, err = p.UserGroups(ctx)
+	assert.Error(t, err)
+}
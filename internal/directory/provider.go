@@ -14,9 +14,11 @@ import (
 	"github.com/pomerium/pomerium/internal/directory/github"
 	"github.com/pomerium/pomerium/internal/directory/gitlab"
 	"github.com/pomerium/pomerium/internal/directory/google"
+	"github.com/pomerium/pomerium/internal/directory/ldap"
 	"github.com/pomerium/pomerium/internal/directory/okta"
 	"github.com/pomerium/pomerium/internal/directory/onelogin"
 	"github.com/pomerium/pomerium/internal/directory/ping"
+	ldapclient "github.com/pomerium/pomerium/internal/ldap"
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/pkg/grpc/directory"
 )
@@ -60,6 +62,11 @@ func GetProvider(options Options) (provider Provider) {
 		globalProvider.options = options
 	}()
 
+	if options.SCIMEnabled {
+		log.Info(ctx).Msg("directory: users and groups are provisioned using SCIM, directory sync is disabled")
+		return nullProvider{fmt.Errorf("directory sync is disabled when SCIM is enabled")}
+	}
+
 	var providerURL *url.URL
 	// url.Parse will succeed even if we pass an empty string
 	if options.ProviderURL != "" {
@@ -135,6 +142,19 @@ func GetProvider(options Options) (provider Provider) {
 			Str("provider", options.Provider).
 			Err(err).
 			Msg("invalid service account for Google directory provider")
+	case ldap.Name:
+		serviceAccount, err := ldapclient.ParseServiceAccount(options.ServiceAccount)
+		if err == nil {
+			return ldap.New(
+				ldap.WithURL(options.ProviderURL),
+				ldap.WithServiceAccount(serviceAccount))
+		}
+		errSyncDisabled = fmt.Errorf("invalid LDAP service account: %w", err)
+		log.Warn(ctx).
+			Str("service", "directory").
+			Str("provider", options.Provider).
+			Err(err).
+			Msg("invalid service account for ldap directory provider")
 	case okta.Name:
 		serviceAccount, err := okta.ParseServiceAccount(options.ServiceAccount)
 		if err == nil {
@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// ConsulConnectCertificates are the certificates Consul Connect upstreams are connected to with.
+type ConsulConnectCertificates struct {
+	// CertificatePEM and PrivateKeyPEM are the leaf certificate of the Connect service of
+	// Pomerium, which the intentions of upstreams are checked against.
+	CertificatePEM []byte
+	PrivateKeyPEM  []byte
+	// RootsPEM are the root certificates of the Connect CA, which the certificates of upstreams
+	// are verified with.
+	RootsPEM []byte
+}
+
+type consulServiceEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    uint16
+	}
+}
+
+func (r *resolver) resolveConsul(ctx context.Context, u *url.URL) ([]Endpoint, error) {
+	service := u.Hostname()
+	// the endpoints of connect services are their connect proxies
+	path := "/v1/health/service/"
+	if r.consulConnectServiceName != "" {
+		allowed, err := r.checkConsulIntention(ctx, service)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			log.Warn(ctx).Str("service", service).Msg("discovery: consul intentions deny connections to the service")
+			return nil, nil
+		}
+		path = "/v1/health/connect/"
+	}
+
+	// the query of the upstream URL, like the tag or datacenter, is passed to consul
+	query := u.Query()
+	query.Set("passing", "true")
+
+	var entries []consulServiceEntry
+	err := r.getConsul(ctx, path+url.PathEscape(service), query, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []Endpoint
+	for _, entry := range entries {
+		host := entry.Service.Address
+		if host == "" {
+			host = entry.Node.Address
+		}
+		eps, err := r.resolveHost(ctx, host, entry.Service.Port)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, eps...)
+	}
+	return endpoints, nil
+}
+
+// getConsul decodes the response of the consul agent HTTP API to the request of the path.
+func (r *resolver) getConsul(ctx context.Context, path string, query url.Values, dst interface{}) error {
+	endpoint := strings.TrimSuffix(r.consulAddress, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if r.consulToken != "" {
+		req.Header.Set("X-Consul-Token", r.consulToken)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error querying consul: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error querying consul: unexpected status code %d", res.StatusCode)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(dst)
+	if err != nil {
+		return fmt.Errorf("error decoding consul response: %w", err)
+	}
+	return nil
+}
+
+type consulIntentionCheck struct {
+	Allowed bool
+}
+
+// checkConsulIntention returns true if the intentions of the service allow connections from the
+// Connect service of Pomerium.
+func (r *resolver) checkConsulIntention(ctx context.Context, service string) (bool, error) {
+	var check consulIntentionCheck
+	err := r.getConsul(ctx, "/v1/connect/intentions/check", url.Values{
+		"source":      {r.consulConnectServiceName},
+		"destination": {service},
+	}, &check)
+	return check.Allowed, err
+}
+
+type consulCARoots struct {
+	Roots []struct {
+		RootCert string
+	}
+}
+
+type consulLeafCert struct {
+	CertPEM       string
+	PrivateKeyPEM string
+}
+
+// getConsulConnectCertificates returns the leaf certificate of the Connect service of Pomerium
+// and the Connect CA roots. The consul agent renews the leaf certificate before it expires.
+func (r *resolver) getConsulConnectCertificates(ctx context.Context) (*ConsulConnectCertificates, error) {
+	var roots consulCARoots
+	err := r.getConsul(ctx, "/v1/agent/connect/ca/roots", nil, &roots)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaf consulLeafCert
+	err = r.getConsul(ctx, "/v1/agent/connect/ca/leaf/"+url.PathEscape(r.consulConnectServiceName), nil, &leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	var rootsPEM bytes.Buffer
+	for _, root := range roots.Roots {
+		rootsPEM.WriteString(strings.TrimSpace(root.RootCert))
+		rootsPEM.WriteString("\n")
+	}
+	if rootsPEM.Len() == 0 {
+		return nil, fmt.Errorf("consul connect ca has no roots")
+	}
+
+	return &ConsulConnectCertificates{
+		CertificatePEM: []byte(leaf.CertPEM),
+		PrivateKeyPEM:  []byte(leaf.PrivateKeyPEM),
+		RootsPEM:       rootsPEM.Bytes(),
+	}, nil
+}
@@ -0,0 +1,212 @@
+// Package discovery resolves the endpoints of upstreams discovered with DNS SRV records, Consul
+// services, Kubernetes EndpointSlices or Nomad services.
+package discovery
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// A Manager periodically re-resolves the endpoints of the discovered upstreams of the routes,
+// and calls its change handler when they change.
+type Manager struct {
+	onChange func(context.Context)
+	updated  chan struct{}
+
+	mu        sync.RWMutex
+	resolver  *resolver
+	interval  time.Duration
+	targets   map[string]*url.URL
+	endpoints map[string][]Endpoint
+	connect   *ConsulConnectCertificates
+
+	// watches are only accessed by Run
+	watches map[string]context.CancelFunc
+}
+
+// New creates a new Manager.
+func New(onChange func(context.Context)) *Manager {
+	return &Manager{
+		onChange:  onChange,
+		updated:   make(chan struct{}, 1),
+		resolver:  newResolver(config.NewDefaultOptions()),
+		interval:  config.DefaultDiscoveryRefreshInterval,
+		targets:   map[string]*url.URL{},
+		endpoints: map[string][]Endpoint{},
+		watches:   map[string]context.CancelFunc{},
+	}
+}
+
+// Update updates the discovered upstreams from the config. They're resolved the next time the
+// manager runs.
+func (mgr *Manager) Update(ctx context.Context, cfg *config.Config) {
+	targets := map[string]*url.URL{}
+	if config.IsProxy(cfg.Options.Services) {
+		for _, p := range cfg.Options.GetAllPolicies() {
+			urls := append(append(config.WeightedURLs{}, p.To...), p.MirrorTo...)
+			for _, g := range p.UpstreamGroups {
+				urls = append(urls, g.To...)
+			}
+			for i := range urls {
+				if config.IsDiscoveryURL(&urls[i].URL) {
+					targets[urls[i].URL.String()] = &urls[i].URL
+				}
+			}
+		}
+	}
+
+	mgr.mu.Lock()
+	mgr.resolver = newResolver(cfg.Options)
+	mgr.interval = cfg.Options.GetDiscoveryRefreshInterval()
+	mgr.targets = targets
+	mgr.mu.Unlock()
+
+	mgr.triggerRefresh()
+}
+
+// GetEndpoints returns the resolved endpoints of the discovered upstream. Until it's been
+// resolved successfully nil is returned.
+func (mgr *Manager) GetEndpoints(u *url.URL) []Endpoint {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	return mgr.endpoints[u.String()]
+}
+
+// GetConsulConnectCertificates returns the certificates Consul Connect upstreams are connected to
+// with. Until they've been fetched successfully, or when Consul Connect is disabled, nil is
+// returned.
+func (mgr *Manager) GetConsulConnectCertificates() *ConsulConnectCertificates {
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+
+	return mgr.connect
+}
+
+// Run re-resolves the discovered upstreams until the context is canceled.
+func (mgr *Manager) Run(ctx context.Context) error {
+	for {
+		mgr.mu.RLock()
+		interval := mgr.interval
+		mgr.mu.RUnlock()
+
+		mgr.syncWatches(ctx)
+		if mgr.refresh(ctx) {
+			mgr.onChange(ctx)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-mgr.updated:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// syncWatches starts watching new k8s+ and nomad+ upstreams and stops watching removed ones.
+// Every change triggers a refresh of the endpoints. nomad+ upstreams are only watched when the
+// nomad address is configured.
+func (mgr *Manager) syncWatches(ctx context.Context) {
+	mgr.mu.RLock()
+	r, targets := mgr.resolver, mgr.targets
+	mgr.mu.RUnlock()
+
+	for key, cancel := range mgr.watches {
+		u, ok := targets[key]
+		if !ok || (strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixNomad) && !r.nomadWatch) {
+			cancel()
+			delete(mgr.watches, key)
+		}
+	}
+
+	for key, u := range targets {
+		if _, ok := mgr.watches[key]; ok {
+			continue
+		}
+
+		u := u
+		var watch func(context.Context)
+		switch {
+		case strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixKubernetes) && r.kubernetes != nil:
+			c := r.kubernetes
+			watch = func(ctx context.Context) { mgr.watchKubernetes(ctx, c, u) }
+		case strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixNomad) && r.nomadWatch:
+			watch = func(ctx context.Context) { mgr.watchNomad(ctx, u) }
+		default:
+			continue
+		}
+
+		watchCtx, cancel := context.WithCancel(ctx)
+		mgr.watches[key] = cancel
+		go watch(watchCtx)
+	}
+}
+
+func (mgr *Manager) triggerRefresh() {
+	select {
+	case mgr.updated <- struct{}{}:
+	default:
+	}
+}
+
+// refresh resolves the discovered upstreams, and fetches the Consul Connect certificates, and
+// returns true if they changed. When an upstream can't be resolved its previous endpoints are
+// kept, and so are the previous certificates when they can't be fetched.
+func (mgr *Manager) refresh(ctx context.Context) bool {
+	mgr.mu.RLock()
+	r, targets, previous, previousConnect := mgr.resolver, mgr.targets, mgr.endpoints, mgr.connect
+	mgr.mu.RUnlock()
+
+	endpoints := make(map[string][]Endpoint, len(targets))
+	for key, u := range targets {
+		eps, err := r.resolve(ctx, u)
+		if err != nil {
+			log.Error(ctx).Err(err).Str("upstream", key).Msg("discovery: error resolving upstream endpoints")
+			if prev, ok := previous[key]; ok {
+				endpoints[key] = prev
+			}
+			continue
+		}
+		endpoints[key] = eps
+	}
+
+	var connect *ConsulConnectCertificates
+	if r.consulConnectServiceName != "" && hasConsulTargets(targets) {
+		var err error
+		connect, err = r.getConsulConnectCertificates(ctx)
+		if err != nil {
+			log.Error(ctx).Err(err).Msg("discovery: error fetching consul connect certificates")
+			connect = previousConnect
+		}
+	}
+
+	if reflect.DeepEqual(endpoints, previous) && reflect.DeepEqual(connect, previousConnect) {
+		return false
+	}
+
+	mgr.mu.Lock()
+	mgr.endpoints = endpoints
+	mgr.connect = connect
+	mgr.mu.Unlock()
+	return true
+}
+
+func hasConsulTargets(targets map[string]*url.URL) bool {
+	for _, u := range targets {
+		if strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixConsul) {
+			return true
+		}
+	}
+	return false
+}
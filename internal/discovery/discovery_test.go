@@ -0,0 +1,173 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	lookupHost := func(ctx context.Context, host string) ([]string, error) {
+		switch host {
+		case "api-1.example.com":
+			return []string{"10.0.0.1"}, nil
+		case "api-2.example.com":
+			return []string{"10.0.0.3", "10.0.0.2"}, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	t.Run("srv", func(t *testing.T) {
+		r := &resolver{
+			lookupSRV: func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+				assert.Equal(t, "_api._tcp.example.com", name)
+				return "", []*net.SRV{
+					{Target: "api-2.example.com.", Port: 8443},
+					{Target: "api-1.example.com.", Port: 8443},
+				}, nil
+			},
+			lookupHost: lookupHost,
+		}
+		endpoints, err := r.resolve(ctx, mustParseURL(t, "srv+https://_api._tcp.example.com"))
+		require.NoError(t, err)
+		assert.Equal(t, []Endpoint{
+			{Hostname: "api-1.example.com", Address: "10.0.0.1:8443"},
+			{Hostname: "api-2.example.com", Address: "10.0.0.2:8443"},
+			{Hostname: "api-2.example.com", Address: "10.0.0.3:8443"},
+		}, endpoints)
+	})
+	t.Run("consul", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/health/service/api", r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("passing"))
+			assert.Equal(t, "v2", r.URL.Query().Get("tag"))
+			assert.Equal(t, "TOKEN", r.Header.Get("X-Consul-Token"))
+			_, _ = w.Write([]byte(`[
+				{"Node": {"Address": "10.0.0.1"}, "Service": {"Address": "", "Port": 8080}},
+				{"Node": {"Address": "10.0.0.9"}, "Service": {"Address": "api-1.example.com", "Port": 8081}}
+			]`))
+		}))
+		defer srv.Close()
+
+		r := newResolver(&config.Options{ConsulAddress: srv.URL, ConsulToken: "TOKEN"})
+		r.lookupHost = lookupHost
+		endpoints, err := r.resolve(ctx, mustParseURL(t, "consul+http://api?tag=v2"))
+		require.NoError(t, err)
+		assert.Equal(t, []Endpoint{
+			{Hostname: "10.0.0.1", Address: "10.0.0.1:8080"},
+			{Hostname: "api-1.example.com", Address: "10.0.0.1:8081"},
+		}, endpoints)
+	})
+	t.Run("consul connect", func(t *testing.T) {
+		allowed := true
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/connect/intentions/check":
+				assert.Equal(t, "pomerium", r.URL.Query().Get("source"))
+				assert.Equal(t, "api", r.URL.Query().Get("destination"))
+				_ = json.NewEncoder(w).Encode(map[string]bool{"Allowed": allowed})
+			case "/v1/health/connect/api":
+				_, _ = w.Write([]byte(`[
+					{"Node": {"Address": "10.0.0.1"}, "Service": {"Address": "", "Port": 21000}}
+				]`))
+			default:
+				t.Errorf("unexpected request: %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer srv.Close()
+
+		r := newResolver(&config.Options{ConsulAddress: srv.URL, ConsulConnectServiceName: "pomerium"})
+		endpoints, err := r.resolve(ctx, mustParseURL(t, "consul+https://api"))
+		require.NoError(t, err)
+		assert.Equal(t, []Endpoint{
+			{Hostname: "10.0.0.1", Address: "10.0.0.1:21000"},
+		}, endpoints, "should resolve the connect proxies of the service")
+
+		allowed = false
+		endpoints, err = r.resolve(ctx, mustParseURL(t, "consul+https://api"))
+		require.NoError(t, err)
+		assert.Empty(t, endpoints, "should have no endpoints when intentions deny connections")
+	})
+}
+
+func TestConsulConnectCertificates(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TOKEN", r.Header.Get("X-Consul-Token"))
+		switch r.URL.Path {
+		case "/v1/agent/connect/ca/roots":
+			_, _ = w.Write([]byte(`{"TrustDomain": "example.consul", "Roots": [
+				{"RootCert": "ROOT1\n"},
+				{"RootCert": "ROOT2"}
+			]}`))
+		case "/v1/agent/connect/ca/leaf/pomerium":
+			_, _ = w.Write([]byte(`{"CertPEM": "CERT", "PrivateKeyPEM": "KEY"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := newResolver(&config.Options{ConsulAddress: srv.URL, ConsulToken: "TOKEN", ConsulConnectServiceName: "pomerium"})
+	certs, err := r.getConsulConnectCertificates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &ConsulConnectCertificates{
+		CertificatePEM: []byte("CERT"),
+		PrivateKeyPEM:  []byte("KEY"),
+		RootsPEM:       []byte("ROOT1\nROOT2\n"),
+	}, certs)
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+
+	records := []*net.SRV{{Target: "10.0.0.1", Port: 443}}
+	var lookupErr error
+
+	mgr := New(func(ctx context.Context) {})
+	mgr.Update(ctx, &config.Config{Options: &config.Options{
+		Services: "all",
+		Policies: []config.Policy{{
+			From: "https://from.example.com",
+			To:   config.WeightedURLs{{URL: *mustParseURL(t, "srv+https://_api._tcp.example.com")}},
+		}},
+	}})
+	mgr.resolver.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		return "", records, lookupErr
+	}
+
+	u := mustParseURL(t, "srv+https://_api._tcp.example.com")
+	assert.Nil(t, mgr.GetEndpoints(u))
+
+	assert.True(t, mgr.refresh(ctx))
+	assert.Equal(t, []Endpoint{{Hostname: "10.0.0.1", Address: "10.0.0.1:443"}}, mgr.GetEndpoints(u))
+	assert.False(t, mgr.refresh(ctx), "should not change when the endpoints are the same")
+
+	lookupErr = errors.New("SERVFAIL")
+	assert.False(t, mgr.refresh(ctx), "should keep the previous endpoints on error")
+	assert.Equal(t, []Endpoint{{Hostname: "10.0.0.1", Address: "10.0.0.1:443"}}, mgr.GetEndpoints(u))
+
+	lookupErr = nil
+	records = []*net.SRV{{Target: "10.0.0.2", Port: 443}}
+	assert.True(t, mgr.refresh(ctx))
+	assert.Equal(t, []Endpoint{{Hostname: "10.0.0.2", Address: "10.0.0.2:443"}}, mgr.GetEndpoints(u))
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u
+}
@@ -0,0 +1,137 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/kubernetes"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+const kubernetesWatchRetryInterval = 5 * time.Second
+
+type kubernetesEndpointSlice struct {
+	AddressType string `json:"addressType"`
+	Endpoints   []struct {
+		Addresses  []string `json:"addresses"`
+		Conditions struct {
+			Ready *bool `json:"ready"`
+		} `json:"conditions"`
+	} `json:"endpoints"`
+	Ports []struct {
+		Name *string `json:"name"`
+		Port *int32  `json:"port"`
+	} `json:"ports"`
+}
+
+// parseKubernetesServiceURL returns the namespace, name and port name of the service of a k8s+
+// upstream URL, like `k8s+http://name.namespace?port=http`.
+func parseKubernetesServiceURL(c *kubernetes.Client, u *url.URL) (namespace, name, portName string) {
+	name, namespace = u.Hostname(), c.Namespace
+	if i := strings.Index(name, "."); i >= 0 {
+		name, namespace = name[:i], name[i+1:]
+	}
+	return namespace, name, u.Query().Get("port")
+}
+
+func kubernetesEndpointSlicesPath(c *kubernetes.Client, u *url.URL) (string, url.Values) {
+	namespace, name, _ := parseKubernetesServiceURL(c, u)
+	return "/apis/discovery.k8s.io/v1/namespaces/" + url.PathEscape(namespace) + "/endpointslices",
+		url.Values{"labelSelector": {"kubernetes.io/service-name=" + name}}
+}
+
+func listKubernetesEndpointSlices(ctx context.Context, c *kubernetes.Client, u *url.URL) ([]kubernetesEndpointSlice, error) {
+	path, query := kubernetesEndpointSlicesPath(c, u)
+	var slices []kubernetesEndpointSlice
+	err := c.List(ctx, path, query, &slices)
+	return slices, err
+}
+
+// watchKubernetesEndpointSlices watches the EndpointSlices of the service and calls onChange for
+// every change, until the watch is closed by the kubernetes API or the context is canceled.
+func watchKubernetesEndpointSlices(ctx context.Context, c *kubernetes.Client, u *url.URL, onChange func()) error {
+	path, query := kubernetesEndpointSlicesPath(c, u)
+	return c.Watch(ctx, path, query, onChange)
+}
+
+func (r *resolver) resolveKubernetes(ctx context.Context, u *url.URL) ([]Endpoint, error) {
+	if r.kubernetes == nil {
+		return nil, kubernetes.ErrNotInCluster
+	}
+
+	slices, err := listKubernetesEndpointSlices(ctx, r.kubernetes, u)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, portName := parseKubernetesServiceURL(r.kubernetes, u)
+	hostname := config.GetDiscoveryUpstreamURL(u).Host
+
+	var endpoints []Endpoint
+	for _, slice := range slices {
+		if (slice.AddressType != "IPv4" && slice.AddressType != "IPv6") || len(slice.Ports) == 0 {
+			continue
+		}
+
+		port, err := getKubernetesEndpointSlicePort(&slice, portName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range slice.Endpoints {
+			for _, addr := range e.Addresses {
+				endpoints = append(endpoints, Endpoint{
+					Hostname: hostname,
+					Address:  net.JoinHostPort(addr, strconv.Itoa(int(port))),
+					// endpoints without a ready condition are ready
+					Unready: e.Conditions.Ready != nil && !*e.Conditions.Ready,
+				})
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// getKubernetesEndpointSlicePort returns the port of the slice with the given name. Without a
+// name the slice must have a single port.
+func getKubernetesEndpointSlicePort(slice *kubernetesEndpointSlice, portName string) (int32, error) {
+	for _, p := range slice.Ports {
+		if p.Port == nil {
+			continue
+		}
+		if portName == "" && len(slice.Ports) == 1 {
+			return *p.Port, nil
+		}
+		if p.Name != nil && *p.Name == portName {
+			return *p.Port, nil
+		}
+	}
+	if portName == "" {
+		return 0, fmt.Errorf("service has multiple ports, the port name must be set")
+	}
+	return 0, fmt.Errorf("service port %s not found", portName)
+}
+
+func (mgr *Manager) watchKubernetes(ctx context.Context, c *kubernetes.Client, u *url.URL) {
+	for {
+		err := watchKubernetesEndpointSlices(ctx, c, u, mgr.triggerRefresh)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warn(ctx).Err(err).Str("upstream", u.String()).Msg("discovery: error watching endpoint slices")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(kubernetesWatchRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package discovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/kubernetes"
+)
+
+func TestKubernetes(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/discovery.k8s.io/v1/namespaces/apps/endpointslices", r.URL.Path)
+		assert.Equal(t, "kubernetes.io/service-name=api", r.URL.Query().Get("labelSelector"))
+		assert.Equal(t, "Bearer TOKEN", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("watch") == "true" {
+			_, _ = w.Write([]byte(`{"type":"ADDED","object":{}}` + "\n" + `{"type":"MODIFIED","object":{}}` + "\n"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [
+			{
+				"addressType": "IPv4",
+				"endpoints": [
+					{"addresses": ["10.0.0.1"], "conditions": {"ready": true}},
+					{"addresses": ["10.0.0.2"], "conditions": {"ready": false}},
+					{"addresses": ["10.0.0.3"], "conditions": {}}
+				],
+				"ports": [{"name": "http", "port": 8080}, {"name": "metrics", "port": 9090}]
+			},
+			{
+				"addressType": "FQDN",
+				"endpoints": [{"addresses": ["api.example.com"]}],
+				"ports": [{"name": "http", "port": 80}]
+			}
+		]}`))
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("TOKEN\n"), 0o600))
+
+	r := &resolver{kubernetes: &kubernetes.Client{
+		BaseURL:    srv.URL,
+		TokenFile:  tokenFile,
+		Namespace:  "default",
+		HTTPClient: srv.Client(),
+	}}
+
+	t.Run("resolve", func(t *testing.T) {
+		endpoints, err := r.resolve(ctx, mustParseURL(t, "k8s+http://api.apps?port=http"))
+		require.NoError(t, err)
+		assert.Equal(t, []Endpoint{
+			{Hostname: "api.apps.svc", Address: "10.0.0.1:8080"},
+			{Hostname: "api.apps.svc", Address: "10.0.0.2:8080", Unready: true},
+			{Hostname: "api.apps.svc", Address: "10.0.0.3:8080"},
+		}, endpoints)
+	})
+	t.Run("missing port name", func(t *testing.T) {
+		_, err := r.resolve(ctx, mustParseURL(t, "k8s+http://api.apps"))
+		assert.Error(t, err)
+	})
+	t.Run("watch", func(t *testing.T) {
+		changes := 0
+		err := watchKubernetesEndpointSlices(ctx, r.kubernetes, mustParseURL(t, "k8s+http://api.apps?port=http"), func() {
+			changes++
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, changes)
+	})
+	t.Run("not in cluster", func(t *testing.T) {
+		_, err := (&resolver{}).resolve(ctx, mustParseURL(t, "k8s+http://api.apps?port=http"))
+		assert.ErrorIs(t, err, kubernetes.ErrNotInCluster)
+	})
+}
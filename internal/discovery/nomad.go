@@ -0,0 +1,126 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+const (
+	nomadWatchRetryInterval = 5 * time.Second
+	// nomadWatchWait is how long a blocking query waits for the services to change.
+	nomadWatchWait = 5 * time.Minute
+	// nomadHTTPTimeout is longer than nomadWatchWait, plus the jitter nomad adds to it, so
+	// blocking queries aren't canceled before they return.
+	nomadHTTPTimeout = nomadWatchWait + time.Minute
+)
+
+type nomadServiceRegistration struct {
+	Address string
+	Port    uint16
+}
+
+func (r *resolver) resolveNomad(ctx context.Context, u *url.URL) ([]Endpoint, error) {
+	registrations, _, err := r.getNomadServices(ctx, u, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []Endpoint
+	for _, registration := range registrations {
+		eps, err := r.resolveHost(ctx, registration.Address, registration.Port)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, eps...)
+	}
+	return endpoints, nil
+}
+
+// getNomadServices returns the registrations of the service of a nomad+ upstream URL, and the
+// index of the response. If the index is set, the query blocks until the index of the
+// registrations is greater than it, or the wait time elapses.
+func (r *resolver) getNomadServices(ctx context.Context, u *url.URL, index uint64) ([]nomadServiceRegistration, uint64, error) {
+	// the query of the upstream URL, like the namespace, is passed to nomad
+	query := u.Query()
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", nomadWatchWait.String())
+	}
+	endpoint := strings.TrimSuffix(r.nomadAddress, "/") + "/v1/service/" + url.PathEscape(u.Hostname())
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if r.nomadToken != "" {
+		req.Header.Set("X-Nomad-Token", r.nomadToken)
+	}
+
+	res, err := r.nomadHTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying nomad: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("error querying nomad: unexpected status code %d", res.StatusCode)
+	}
+
+	next, err := strconv.ParseUint(res.Header.Get("X-Nomad-Index"), 10, 64)
+	if err != nil || next == 0 {
+		return nil, 0, fmt.Errorf("error querying nomad: invalid index %q", res.Header.Get("X-Nomad-Index"))
+	}
+
+	var registrations []nomadServiceRegistration
+	err = json.NewDecoder(res.Body).Decode(&registrations)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error decoding nomad response: %w", err)
+	}
+	return registrations, next, nil
+}
+
+// watchNomad watches the registrations of the service of a nomad+ upstream with blocking
+// queries, and triggers a refresh of the endpoints whenever they change.
+func (mgr *Manager) watchNomad(ctx context.Context, u *url.URL) {
+	var index uint64
+	for {
+		// the resolver is replaced when the nomad address or token change
+		mgr.mu.RLock()
+		r := mgr.resolver
+		mgr.mu.RUnlock()
+
+		_, next, err := r.getNomadServices(ctx, u, index)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warn(ctx).Err(err).Str("upstream", u.String()).Msg("discovery: error watching nomad services")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(nomadWatchRetryInterval):
+			}
+			continue
+		}
+
+		if index > 0 && next != index {
+			mgr.triggerRefresh()
+		}
+		// a lower index means nomad's state was reset, so the watch starts over
+		if next < index {
+			next = 0
+		}
+		index = next
+	}
+}
@@ -0,0 +1,100 @@
+package discovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestNomad(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/service/api", r.URL.Path)
+		assert.Equal(t, "apps", r.URL.Query().Get("namespace"))
+		assert.Equal(t, "TOKEN", r.Header.Get("X-Nomad-Token"))
+
+		index, _ := strconv.Atoi(r.URL.Query().Get("index"))
+		switch {
+		case index == 0:
+		case index < 2:
+			assert.Equal(t, "5m0s", r.URL.Query().Get("wait"))
+		default:
+			// block until the watch is canceled
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("X-Nomad-Index", strconv.Itoa(index+1))
+		_, _ = w.Write([]byte(`[
+			{"ServiceName": "api", "Address": "10.0.0.2", "Port": 8080},
+			{"ServiceName": "api", "Address": "10.0.0.1", "Port": 8081}
+		]`))
+	}))
+	defer srv.Close()
+
+	options := &config.Options{NomadAddress: srv.URL, NomadToken: "TOKEN"}
+	u := mustParseURL(t, "nomad+http://api?namespace=apps")
+
+	t.Run("resolve", func(t *testing.T) {
+		endpoints, err := newResolver(options).resolve(ctx, u)
+		require.NoError(t, err)
+		assert.Equal(t, []Endpoint{
+			{Hostname: "10.0.0.1", Address: "10.0.0.1:8081"},
+			{Hostname: "10.0.0.2", Address: "10.0.0.2:8080"},
+		}, endpoints)
+	})
+	t.Run("watch", func(t *testing.T) {
+		mgr := New(func(ctx context.Context) {})
+		mgr.resolver = newResolver(options)
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			mgr.watchNomad(ctx, u)
+			close(done)
+		}()
+
+		select {
+		case <-mgr.updated:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the watch to trigger a refresh")
+		}
+
+		cancel()
+		<-done
+	})
+	t.Run("forbidden", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer srv.Close()
+
+		_, err := newResolver(&config.Options{NomadAddress: srv.URL}).resolve(ctx, u)
+		assert.Error(t, err)
+	})
+	t.Run("default address", func(t *testing.T) {
+		r := newResolver(&config.Options{})
+		assert.Equal(t, config.DefaultNomadAddress, r.nomadAddress)
+		assert.False(t, r.nomadWatch, "should not watch the default address")
+		assert.Greater(t, r.nomadHTTPClient.Timeout, nomadWatchWait)
+
+		mgr := New(func(ctx context.Context) {})
+		mgr.resolver = newResolver(options)
+		mgr.targets = map[string]*url.URL{u.String(): u}
+		mgr.syncWatches(ctx)
+		assert.Len(t, mgr.watches, 1)
+
+		mgr.resolver = r
+		mgr.syncWatches(ctx)
+		assert.Empty(t, mgr.watches, "should stop watching when the address is unset")
+	})
+}
@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/kubernetes"
+)
+
+// An Endpoint is a discovered endpoint of an upstream.
+type Endpoint struct {
+	// Hostname is the name of the endpoint, used to rewrite the host header of requests.
+	Hostname string
+	// Address is the IP address and port of the endpoint.
+	Address string
+	// Unready is true when the endpoint isn't ready to receive requests.
+	Unready bool
+}
+
+type resolver struct {
+	consulAddress            string
+	consulToken              string
+	consulConnectServiceName string
+	kubernetes               *kubernetes.Client
+	nomadAddress             string
+	nomadToken               string
+	// nomadWatch is true when the nomad address is configured, so nomad+ upstreams aren't
+	// watched by polling the default address
+	nomadWatch bool
+
+	httpClient      *http.Client
+	nomadHTTPClient *http.Client
+	lookupSRV       func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	lookupHost      func(ctx context.Context, host string) ([]string, error)
+}
+
+func newResolver(options *config.Options) *resolver {
+	// outside a kubernetes cluster k8s+ upstreams fail to resolve
+	kc, _ := kubernetes.NewInClusterClient()
+	return &resolver{
+		consulAddress:            options.GetConsulAddress(),
+		consulToken:              options.ConsulToken,
+		consulConnectServiceName: options.ConsulConnectServiceName,
+		kubernetes:               kc,
+		nomadAddress:             options.GetNomadAddress(),
+		nomadToken:               options.NomadToken,
+		nomadWatch:               options.NomadAddress != "",
+
+		httpClient:      http.DefaultClient,
+		nomadHTTPClient: &http.Client{Timeout: nomadHTTPTimeout},
+		lookupSRV:       net.DefaultResolver.LookupSRV,
+		lookupHost:      net.DefaultResolver.LookupHost,
+	}
+}
+
+// resolve returns the endpoints of the upstream URL, sorted by address.
+func (r *resolver) resolve(ctx context.Context, u *url.URL) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	var err error
+	switch {
+	case strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixSRV):
+		endpoints, err = r.resolveSRV(ctx, u)
+	case strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixConsul):
+		endpoints, err = r.resolveConsul(ctx, u)
+	case strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixKubernetes):
+		endpoints, err = r.resolveKubernetes(ctx, u)
+	case strings.HasPrefix(u.Scheme, config.DiscoverySchemePrefixNomad):
+		endpoints, err = r.resolveNomad(ctx, u)
+	default:
+		err = fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Address < endpoints[j].Address
+	})
+	return endpoints, nil
+}
+
+func (r *resolver) resolveSRV(ctx context.Context, u *url.URL) ([]Endpoint, error) {
+	_, records, err := r.lookupSRV(ctx, "", "", u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("error looking up srv records: %w", err)
+	}
+
+	var endpoints []Endpoint
+	for _, record := range records {
+		hostname := strings.TrimSuffix(record.Target, ".")
+		eps, err := r.resolveHost(ctx, hostname, record.Port)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, eps...)
+	}
+	return endpoints, nil
+}
+
+// resolveHost resolves the IP addresses of the host, since envoy requires IP addresses for the
+// endpoints it discovers.
+func (r *resolver) resolveHost(ctx context.Context, host string, port uint16) ([]Endpoint, error) {
+	addrs := []string{host}
+	if net.ParseIP(host) == nil {
+		var err error
+		addrs, err = r.lookupHost(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("error looking up %s: %w", host, err)
+		}
+	}
+
+	endpoints := make([]Endpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, Endpoint{
+			Hostname: host,
+			Address:  net.JoinHostPort(addr, strconv.Itoa(int(port))),
+		})
+	}
+	return endpoints, nil
+}
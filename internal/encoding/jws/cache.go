@@ -0,0 +1,105 @@
+package jws
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+// cachedVerificationMaxAge is the maximum time a verification is cached, even if the JWT doesn't
+// expire before.
+const cachedVerificationMaxAge = 5 * time.Minute
+
+// A CachedVerifier caches the claims of the JWTs verified by a JWT signer, keyed by the hash of
+// the JWT, so that JWTs sent with every request are only verified once. Verifications are cached
+// until the JWT expires, and can be invalidated when the JWT is revoked.
+type CachedVerifier struct {
+	encoding.MarshalUnmarshaler
+	cache *lru.Cache
+}
+
+type cachedVerification struct {
+	payload []byte
+	expiry  time.Time
+}
+
+// NewCachedVerifier creates a new CachedVerifier which caches up to size verifications of the
+// underlying JWT signer.
+func NewCachedVerifier(size int, underlying encoding.MarshalUnmarshaler) (*CachedVerifier, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("jws: error creating verification cache: %w", err)
+	}
+	return &CachedVerifier{MarshalUnmarshaler: underlying, cache: cache}, nil
+}
+
+// Unmarshal parses and validates a signed JWT, unless it was already.
+func (c *CachedVerifier) Unmarshal(value []byte, s interface{}) error {
+	key := sha256.Sum256(value)
+	now := time.Now()
+	if v, ok := c.cache.Get(key); ok {
+		entry := v.(*cachedVerification)
+		if now.Before(entry.expiry) {
+			metrics.RecordJWTVerificationCacheLookup(true)
+			return json.Unmarshal(entry.payload, s)
+		}
+		c.cache.Remove(key)
+	}
+	metrics.RecordJWTVerificationCacheLookup(false)
+
+	// failed verifications aren't cached, so that invalid JWTs can't fill the cache
+	err := c.MarshalUnmarshaler.Unmarshal(value, s)
+	if err != nil {
+		return err
+	}
+
+	payload, err := getPayload(value)
+	if err != nil {
+		return nil
+	}
+	var claims struct {
+		Expiry *jwt.NumericDate `json:"exp,omitempty"`
+	}
+	if json.Unmarshal(payload, &claims) != nil {
+		return nil
+	}
+
+	expiry := now.Add(cachedVerificationMaxAge)
+	if claims.Expiry != nil && claims.Expiry.Time().Before(expiry) {
+		expiry = claims.Expiry.Time()
+	}
+	if now.Before(expiry) {
+		c.cache.Add(key, &cachedVerification{payload: payload, expiry: expiry})
+	}
+	return nil
+}
+
+// Invalidate removes the cached verification of a JWT, so it is verified again the next time
+// it is used.
+func (c *CachedVerifier) Invalidate(value []byte) {
+	c.cache.Remove(sha256.Sum256(value))
+}
+
+// getPayload returns the decoded payload of a compact serialized JWT.
+func getPayload(value []byte) ([]byte, error) {
+	parts := bytes.Split(value, []byte{'.'})
+	if len(parts) != 3 {
+		return nil, errors.New("jws: invalid compact serialization")
+	}
+	payload := make([]byte, base64.RawURLEncoding.DecodedLen(len(parts[1])))
+	n, err := base64.RawURLEncoding.Decode(payload, parts[1])
+	if err != nil {
+		return nil, err
+	}
+	return payload[:n], nil
+}
@@ -0,0 +1,82 @@
+package jws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+type countingUnmarshaler struct {
+	encoding.MarshalUnmarshaler
+	unmarshals int
+}
+
+func (c *countingUnmarshaler) Unmarshal(value []byte, s interface{}) error {
+	c.unmarshals++
+	return c.MarshalUnmarshaler.Unmarshal(value, s)
+}
+
+func TestCachedVerifier(t *testing.T) {
+	signer, err := NewHS256Signer(cryptutil.NewKey())
+	require.NoError(t, err)
+	underlying := &countingUnmarshaler{MarshalUnmarshaler: signer}
+	verifier, err := NewCachedVerifier(10, underlying)
+	require.NoError(t, err)
+
+	type claims struct {
+		ID     string           `json:"jti"`
+		Expiry *jwt.NumericDate `json:"exp,omitempty"`
+	}
+	unmarshal := func(t *testing.T, rawJWT []byte) (string, error) {
+		t.Helper()
+		var c claims
+		err := verifier.Unmarshal(rawJWT, &c)
+		return c.ID, err
+	}
+
+	rawJWT, err := verifier.Marshal(claims{ID: "a"})
+	require.NoError(t, err)
+
+	t.Run("cached", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			id, err := unmarshal(t, rawJWT)
+			assert.NoError(t, err)
+			assert.Equal(t, "a", id)
+		}
+		assert.Equal(t, 1, underlying.unmarshals)
+	})
+	t.Run("invalidate", func(t *testing.T) {
+		verifier.Invalidate(rawJWT)
+		_, err := unmarshal(t, rawJWT)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, underlying.unmarshals)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		otherSigner, err := NewHS256Signer(cryptutil.NewKey())
+		require.NoError(t, err)
+		otherJWT, err := otherSigner.Marshal(claims{ID: "b"})
+		require.NoError(t, err)
+
+		_, err = unmarshal(t, otherJWT)
+		assert.Error(t, err)
+		_, err = unmarshal(t, otherJWT)
+		assert.Error(t, err, "failed verifications shouldn't be cached")
+	})
+	t.Run("expired", func(t *testing.T) {
+		expiredJWT, err := verifier.Marshal(claims{ID: "c", Expiry: jwt.NewNumericDate(time.Now().Add(-time.Minute))})
+		require.NoError(t, err)
+
+		unmarshals := underlying.unmarshals
+		_, err = unmarshal(t, expiredJWT)
+		assert.NoError(t, err)
+		_, err = unmarshal(t, expiredJWT)
+		assert.NoError(t, err)
+		assert.Equal(t, unmarshals+2, underlying.unmarshals, "expired JWTs shouldn't be cached")
+	})
+}
@@ -3,6 +3,8 @@
 package jws
 
 import (
+	"errors"
+
 	"github.com/pomerium/pomerium/internal/encoding"
 
 	"github.com/go-jose/go-jose/v3"
@@ -15,16 +17,24 @@ type JSONWebSigner struct {
 	Signer jose.Signer
 
 	key interface{}
+	// acceptedKeys are also accepted when validating a JWT, so tokens signed before a key
+	// rotation stay valid.
+	acceptedKeys []interface{}
 }
 
-// NewHS256Signer creates a SHA256 JWT signer from a 32 byte key.
-func NewHS256Signer(key []byte) (encoding.MarshalUnmarshaler, error) {
+// NewHS256Signer creates a SHA256 JWT signer from a 32 byte key. JWTs signed with any of the
+// accepted keys are also valid.
+func NewHS256Signer(key []byte, acceptedKeys ...[]byte) (encoding.MarshalUnmarshaler, error) {
 	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: key},
 		(&jose.SignerOptions{}).WithType("JWT"))
 	if err != nil {
 		return nil, err
 	}
-	return &JSONWebSigner{Signer: sig, key: key}, nil
+	w := &JSONWebSigner{Signer: sig, key: key}
+	for _, k := range acceptedKeys {
+		w.acceptedKeys = append(w.acceptedKeys, k)
+	}
+	return w, nil
 }
 
 // Marshal signs, and serializes a JWT.
@@ -39,5 +49,12 @@ func (c *JSONWebSigner) Unmarshal(value []byte, s interface{}) error {
 	if err != nil {
 		return err
 	}
-	return tok.Claims(c.key, s)
+	err = tok.Claims(c.key, s)
+	for _, k := range c.acceptedKeys {
+		if !errors.Is(err, jose.ErrCryptoFailure) {
+			break
+		}
+		err = tok.Claims(k, s)
+	}
+	return err
 }
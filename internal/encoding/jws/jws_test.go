@@ -0,0 +1,30 @@
+package jws
+
+import (
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestHS256SignerAcceptedKeys(t *testing.T) {
+	oldKey, newKey := cryptutil.NewKey(), cryptutil.NewKey()
+
+	oldSigner, err := NewHS256Signer(oldKey)
+	require.NoError(t, err)
+	token, err := oldSigner.Marshal(jwt.Claims{Subject: "user-1"})
+	require.NoError(t, err)
+
+	rotated, err := NewHS256Signer(newKey, oldKey)
+	require.NoError(t, err)
+	var claims jwt.Claims
+	assert.NoError(t, rotated.Unmarshal(token, &claims), "should accept JWTs signed with an accepted key")
+	assert.Equal(t, "user-1", claims.Subject)
+
+	other, err := NewHS256Signer(newKey, cryptutil.NewKey())
+	require.NoError(t, err)
+	assert.Error(t, other.Unmarshal(token, &claims), "should reject JWTs signed with another key")
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/shirou/gopsutil/v3/process"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/config/envoyconfig"
@@ -43,6 +44,13 @@ const (
 type serverOptions struct {
 	services string
 	logLevel string
+	// drainTime is how long envoy drains listeners which are changed or replaced, in seconds.
+	drainTime int
+	// the stats sinks are part of the bootstrap, so envoy is restarted when they change
+	statsdAddress       string
+	statsdFlavor        string
+	statsdPrefix        string
+	metricsPushInterval time.Duration
 }
 
 // A Server is a pomerium proxy implemented via envoy.
@@ -144,6 +152,13 @@ func (srv *Server) update(ctx context.Context, cfg *config.Config) {
 	options := serverOptions{
 		services: cfg.Options.Services,
 		logLevel: firstNonEmpty(cfg.Options.ProxyLogLevel, cfg.Options.LogLevel, "debug"),
+		// envoy only supports whole seconds
+		drainTime: int((cfg.Options.ListenerDrainTimeout + time.Second - 1) / time.Second),
+
+		statsdAddress:       cfg.Options.MetricsStatsdAddress,
+		statsdFlavor:        cfg.Options.MetricsStatsdFlavor,
+		statsdPrefix:        cfg.Options.MetricsStatsdPrefix,
+		metricsPushInterval: cfg.Options.MetricsPushInterval,
 	}
 
 	if cmp.Equal(srv.options, options, cmp.AllowUnexported(serverOptions{})) {
@@ -173,6 +188,10 @@ func (srv *Server) run(ctx context.Context, cfg *config.Config) error {
 		"--log-level", srv.options.logLevel,
 		"--log-format", "[LOG_FORMAT]%l--%n--%v",
 		"--log-format-escaped",
+		// connections of listeners which are changed by a configuration reload, or of the
+		// previous envoy process on a hot restart, are closed gradually over the drain time
+		"--drain-time-s", strconv.Itoa(srv.options.drainTime),
+		"--drain-strategy", "gradual",
 	}
 
 	exePath, args := srv.prepareRunEnvoyCommand(ctx, args)
@@ -268,6 +287,11 @@ func (srv *Server) buildBootstrapConfig(cfg *config.Config) ([]byte, error) {
 		return nil, err
 	}
 
+	statsSinks, err := srv.builder.BuildBootstrapStatsSinks(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	layeredRuntimeCfg, err := srv.builder.BuildBootstrapLayeredRuntime()
 	if err != nil {
 		return nil, err
@@ -279,8 +303,12 @@ func (srv *Server) buildBootstrapConfig(cfg *config.Config) ([]byte, error) {
 		DynamicResources: dynamicCfg,
 		StaticResources:  staticCfg,
 		StatsConfig:      statsCfg,
+		StatsSinks:       statsSinks,
 		LayeredRuntime:   layeredRuntimeCfg,
 	}
+	if len(statsSinks) > 0 && cfg.Options.MetricsPushInterval > 0 {
+		bootstrapCfg.StatsFlushInterval = durationpb.New(cfg.Options.MetricsPushInterval)
+	}
 
 	jsonBytes, err := protojson.Marshal(bootstrapCfg)
 	if err != nil {
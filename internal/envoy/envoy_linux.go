@@ -81,9 +81,8 @@ func (srv *Server) prepareRunEnvoyCommand(ctx context.Context, sharedArgs []stri
 		args = append(args,
 			"--base-id", strconv.Itoa(baseID),
 			"--restart-epoch", strconv.Itoa(restartEpoch.value),
-			"--drain-time-s", "60",
-			"--parent-shutdown-time-s", "120",
-			"--drain-strategy", "immediate",
+			// the previous process has to outlive its drain time
+			"--parent-shutdown-time-s", strconv.Itoa(srv.options.drainTime+60),
 		)
 		restartEpoch.value++
 	} else {
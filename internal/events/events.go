@@ -41,6 +41,10 @@ type (
 	EnvoyConfigurationEvent = events.EnvoyConfigurationEvent
 	// LastError re-exports events.LastError.
 	LastError = events.LastError
+	// SigningKeyEvent re-exports events.SigningKeyEvent.
+	SigningKeyEvent = events.SigningKeyEvent
+	// SigningKeyEvent_EventKind re-exports events.SigningKeyEvent_EventKind.
+	SigningKeyEvent_EventKind = events.SigningKeyEvent_EventKind // nolint
 )
 
 // re-exported protobuf constants
@@ -48,4 +52,8 @@ const (
 	EnvoyConfigurationEvent_EVENT_DISCOVERY_REQUEST_ACK  = events.EnvoyConfigurationEvent_EVENT_DISCOVERY_REQUEST_ACK  // nolint
 	EnvoyConfigurationEvent_EVENT_DISCOVERY_REQUEST_NACK = events.EnvoyConfigurationEvent_EVENT_DISCOVERY_REQUEST_NACK // nolint
 	EnvoyConfigurationEvent_EVENT_DISCOVERY_RESPONSE     = events.EnvoyConfigurationEvent_EVENT_DISCOVERY_RESPONSE     // nolint
+
+	SigningKeyEvent_EVENT_KIND_CREATED = events.SigningKeyEvent_EVENT_KIND_CREATED // nolint
+	SigningKeyEvent_EVENT_KIND_ROTATED = events.SigningKeyEvent_EVENT_KIND_ROTATED // nolint
+	SigningKeyEvent_EVENT_KIND_DELETED = events.SigningKeyEvent_EVENT_KIND_DELETED // nolint
 )
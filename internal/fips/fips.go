@@ -0,0 +1,6 @@
+// Package fips reports whether pomerium is built in FIPS mode.
+//
+// Pomerium is built in FIPS mode with GOEXPERIMENT=boringcrypto, which replaces the Go
+// cryptography with the FIPS 140-2 validated BoringCrypto module and restricts TLS to the
+// FIPS approved versions, cipher suites, curves and signature algorithms.
+package fips
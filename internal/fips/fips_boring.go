@@ -0,0 +1,13 @@
+//go:build boringcrypto
+
+package fips
+
+import (
+	"crypto/boring"
+	_ "crypto/tls/fipsonly" // restrict TLS to the FIPS approved settings
+)
+
+// Enabled returns true when pomerium is built in FIPS mode.
+func Enabled() bool {
+	return boring.Enabled()
+}
@@ -0,0 +1,8 @@
+//go:build !boringcrypto
+
+package fips
+
+// Enabled returns true when pomerium is built in FIPS mode.
+func Enabled() bool {
+	return false
+}
@@ -0,0 +1,246 @@
+// Package gatewayapi contains a config source which programs routes from the Kubernetes Gateway
+// API resources of the cluster pomerium runs in.
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/kubernetes"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+const watchRetryInterval = 5 * time.Second
+
+// The paths of the Gateway API resources, in all namespaces.
+const (
+	gatewaysPath        = "/apis/gateway.networking.k8s.io/v1beta1/gateways"
+	httpRoutesPath      = "/apis/gateway.networking.k8s.io/v1beta1/httproutes"
+	referenceGrantsPath = "/apis/gateway.networking.k8s.io/v1beta1/referencegrants"
+)
+
+// ConfigSource provides a new Config source that decorates an underlying config with the routes
+// of the HTTPRoutes attached to the Gateways of a GatewayClass.
+//
+// The resources are watched, and every change is applied atomically: if the routes conflict
+// with the underlying config, the previously applied routes are kept.
+type ConfigSource struct {
+	mu               sync.RWMutex
+	underlyingConfig *config.Config
+	computedConfig   *config.Config
+	policies         []config.Policy
+	className        string
+	cancel           func()
+
+	newClient func() (*kubernetes.Client, error)
+
+	config.ChangeDispatcher
+}
+
+// NewConfigSource creates a new ConfigSource.
+func NewConfigSource(ctx context.Context, underlying config.Source) *ConfigSource {
+	src := &ConfigSource{newClient: kubernetes.NewInClusterClient}
+	underlying.OnConfigChange(ctx, func(ctx context.Context, cfg *config.Config) {
+		src.mu.Lock()
+		src.underlyingConfig = cfg.Clone()
+		src.mu.Unlock()
+
+		src.rebuild(ctx, firstTime(false))
+	})
+	src.underlyingConfig = underlying.GetConfig()
+	src.rebuild(ctx, firstTime(true))
+	return src
+}
+
+// GetConfig gets the current config.
+func (src *ConfigSource) GetConfig() *config.Config {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
+
+	return src.computedConfig
+}
+
+type firstTime bool
+
+func (src *ConfigSource) rebuild(ctx context.Context, firstTime firstTime) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	src.runWatcher(ctx, src.underlyingConfig.Options.KubernetesGatewayClassName)
+
+	cfg, err := src.buildConfig(src.policies)
+	if err != nil {
+		// the underlying config conflicts with the routes of the cluster, so they're dropped
+		log.Error(ctx).Err(err).Msg("gatewayapi: ignoring routes")
+		cfg = src.underlyingConfig.Clone()
+	}
+	src.computedConfig = cfg
+	if !firstTime {
+		src.Trigger(ctx, cfg)
+	}
+}
+
+// buildConfig returns the underlying config with the policies added, or an error if any of the
+// policies are invalid.
+func (src *ConfigSource) buildConfig(policies []config.Policy) (*config.Config, error) {
+	cfg := src.underlyingConfig.Clone()
+	if len(policies) == 0 || src.underlyingConfig.Options.KubernetesGatewayClassName == "" {
+		return cfg, nil
+	}
+
+	// routes of the cluster must not shadow other routes
+	seen := map[string]struct{}{}
+	for _, policy := range cfg.Options.GetAllPolicies() {
+		seen[getRouteMatcher(&policy)] = struct{}{}
+	}
+	for _, policy := range policies {
+		if _, ok := seen[getRouteMatcher(&policy)]; ok {
+			return nil, fmt.Errorf("%s: duplicate route", policy.String())
+		}
+	}
+
+	cfg.Options.AdditionalPolicies = append(cfg.Options.AdditionalPolicies, policies...)
+	if err := cfg.Options.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func getRouteMatcher(policy *config.Policy) string {
+	return strings.Join([]string{policy.From, policy.Prefix, policy.Path, policy.Regex}, "|")
+}
+
+// runWatcher restarts the watcher of the resources when the GatewayClass changes.
+func (src *ConfigSource) runWatcher(ctx context.Context, className string) {
+	// nothing changed, so don't restart the watcher
+	if src.className == className {
+		return
+	}
+	src.className = className
+
+	if src.cancel != nil {
+		src.cancel()
+		src.cancel = nil
+	}
+	src.policies = nil
+	if className == "" {
+		return
+	}
+
+	client, err := src.newClient()
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("gatewayapi: kubernetes_gateway_class_name requires running in a kubernetes cluster")
+		return
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	src.cancel = cancel
+	go src.watch(watchCtx, client, className)
+}
+
+// watch lists the resources and applies their routes on every change.
+func (src *ConfigSource) watch(ctx context.Context, client *kubernetes.Client, className string) {
+	trigger := make(chan struct{}, 1)
+	onChange := func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+	for _, path := range []string{gatewaysPath, httpRoutesPath, referenceGrantsPath} {
+		go watchResources(ctx, client, path, onChange)
+	}
+
+	for {
+		res, err := listResources(ctx, client)
+		if err != nil && ctx.Err() == nil {
+			log.Error(ctx).Err(err).Msg("gatewayapi: error listing resources")
+		}
+		if err == nil {
+			// invalid routes aren't retried until there's a change
+			if err := src.apply(ctx, className, res); err != nil {
+				log.Error(ctx).Err(err).Msg("gatewayapi: error applying routes")
+			}
+		}
+
+		// after a listing error the resources are listed again, even without any changes
+		var retry <-chan time.Time
+		if err != nil {
+			retry = time.After(watchRetryInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-trigger:
+		case <-retry:
+		}
+	}
+}
+
+func watchResources(ctx context.Context, client *kubernetes.Client, path string, onChange func()) {
+	for {
+		err := client.Watch(ctx, path, nil, onChange)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warn(ctx).Err(err).Str("path", path).Msg("gatewayapi: error watching resources")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+		// changes could have been missed while the watch was closed
+		onChange()
+	}
+}
+
+func listResources(ctx context.Context, client *kubernetes.Client) (*resources, error) {
+	res := new(resources)
+	if err := client.List(ctx, gatewaysPath, nil, &res.Gateways); err != nil {
+		return nil, err
+	}
+	if err := client.List(ctx, httpRoutesPath, nil, &res.HTTPRoutes); err != nil {
+		return nil, err
+	}
+	if err := client.List(ctx, referenceGrantsPath, nil, &res.ReferenceGrants); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// apply replaces the routes with the routes of the resources if they're valid.
+func (src *ConfigSource) apply(ctx context.Context, className string, res *resources) error {
+	policies, errs := translate(className, res)
+	for _, err := range errs {
+		log.Warn(ctx).Err(err).Msg("gatewayapi: ignoring route")
+	}
+
+	src.mu.Lock()
+	if src.className != className {
+		// the watcher was restarted
+		src.mu.Unlock()
+		return nil
+	}
+	cfg, err := src.buildConfig(policies)
+	if err != nil {
+		src.mu.Unlock()
+		metrics.SetConfigInfo(ctx, src.underlyingConfig.Options.Services, "gatewayapi", 0, false)
+		return err
+	}
+	src.policies = policies
+	src.computedConfig = cfg
+	src.mu.Unlock()
+
+	log.Info(ctx).Str("gateway_class", className).Int("routes", len(policies)).Msg("gatewayapi: applied routes")
+	metrics.SetConfigInfo(ctx, cfg.Options.Services, "gatewayapi", cfg.Checksum(), true)
+	src.Trigger(ctx, cfg)
+	return nil
+}
@@ -0,0 +1,490 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+// annotationPrefix is the prefix of the HTTPRoute annotations which set the other options of its
+// routes, like `pomerium.io/allowed_domains: "[example.com]"`.
+const annotationPrefix = "pomerium.io/"
+
+// translatedKeys are the route options set from the HTTPRoute itself.
+var translatedKeys = map[string]struct{}{
+	"from": {}, "to": {}, "path": {}, "prefix": {}, "regex": {},
+}
+
+// A translator translates the HTTPRoutes attached to the Gateways of a GatewayClass into
+// routes.
+type translator struct {
+	gateways        map[string]*gateway
+	referenceGrants []referenceGrant
+}
+
+// translate returns the routes of the HTTPRoutes attached to the Gateways of the class. Rules
+// which can't be translated are skipped, and returned as errors.
+func translate(className string, res *resources) ([]config.Policy, []error) {
+	t := &translator{
+		gateways:        make(map[string]*gateway),
+		referenceGrants: res.ReferenceGrants,
+	}
+	for i := range res.Gateways {
+		gw := &res.Gateways[i]
+		if gw.Spec.GatewayClassName == className {
+			t.gateways[gw.Metadata.Namespace+"/"+gw.Metadata.Name] = gw
+		}
+	}
+
+	// conflicting routes are resolved in favor of the oldest HTTPRoute
+	routes := append([]httpRoute(nil), res.HTTPRoutes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i].Metadata, routes[j].Metadata
+		if !a.CreationTimestamp.Equal(b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(b.CreationTimestamp)
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	var policies []config.Policy
+	var errs []error
+	seen := map[string]struct{}{}
+	for i := range routes {
+		route := &routes[i]
+		hostnames, attached := t.getHostnames(route)
+		if !attached {
+			continue
+		}
+
+		ps, err := t.translateRoute(route, hostnames)
+		errs = append(errs, err...)
+		for _, p := range ps {
+			key := strings.Join([]string{p.From, p.Prefix, p.Path, p.Regex}, "|")
+			if _, ok := seen[key]; ok {
+				errs = append(errs, fmt.Errorf("httproute %s/%s: %s: conflicts with an older route",
+					route.Metadata.Namespace, route.Metadata.Name, p.String()))
+				continue
+			}
+			seen[key] = struct{}{}
+			policies = append(policies, p)
+		}
+	}
+
+	// envoy uses the first matching route, so more specific matches must come first
+	sort.SliceStable(policies, func(i, j int) bool {
+		return matchPrecedence(&policies[i]) > matchPrecedence(&policies[j])
+	})
+	return policies, errs
+}
+
+// matchPrecedence orders exact path matches before prefix matches, and longer prefixes before
+// shorter ones, as required by the Gateway API.
+func matchPrecedence(p *config.Policy) int {
+	switch {
+	case p.Path != "":
+		return 1 << 30
+	case p.Prefix != "":
+		return 1<<29 + len(p.Prefix)
+	default:
+		return 0
+	}
+}
+
+// getHostnames returns the hostnames of the route for the listeners it's attached to, and
+// whether it's attached to any Gateway of the class.
+func (t *translator) getHostnames(route *httpRoute) ([]string, bool) {
+	attached := false
+	set := map[string]struct{}{}
+	for _, ref := range route.Spec.ParentRefs {
+		if stringOr(ref.Group, groupGateway) != groupGateway || stringOr(ref.Kind, kindGateway) != kindGateway {
+			continue
+		}
+		gw, ok := t.gateways[stringOr(ref.Namespace, route.Metadata.Namespace)+"/"+ref.Name]
+		if !ok {
+			continue
+		}
+
+		for _, l := range gw.Spec.Listeners {
+			if ref.SectionName != nil && *ref.SectionName != l.Name {
+				continue
+			}
+			if ref.Port != nil && *ref.Port != l.Port {
+				continue
+			}
+			if (l.Protocol != "HTTP" && l.Protocol != "HTTPS") || !allowsRoutesFrom(gw, &l, route.Metadata.Namespace) {
+				continue
+			}
+			attached = true
+
+			listenerHostname := stringOr(l.Hostname, "")
+			if len(route.Spec.Hostnames) == 0 && listenerHostname != "" {
+				set[listenerHostname] = struct{}{}
+			}
+			for _, h := range route.Spec.Hostnames {
+				if hostname, ok := intersectHostnames(listenerHostname, h); ok {
+					set[hostname] = struct{}{}
+				}
+			}
+		}
+	}
+
+	hostnames := make([]string, 0, len(set))
+	for h := range set {
+		hostnames = append(hostnames, h)
+	}
+	sort.Strings(hostnames)
+	return hostnames, attached
+}
+
+// allowsRoutesFrom returns true if the listener allows routes of the namespace. Only the Same
+// and All namespaces of allowed routes are supported.
+func allowsRoutesFrom(gw *gateway, l *listener, namespace string) bool {
+	from := "Same"
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != "" {
+		from = l.AllowedRoutes.Namespaces.From
+	}
+	switch from {
+	case "All":
+		return true
+	case "Same":
+		return gw.Metadata.Namespace == namespace
+	default:
+		return false
+	}
+}
+
+// intersectHostnames returns the most specific of the listener and route hostnames, if they
+// match.
+func intersectHostnames(listenerHostname, routeHostname string) (string, bool) {
+	switch {
+	case listenerHostname == "" || listenerHostname == routeHostname:
+		return routeHostname, true
+	case matchesWildcardHostname(listenerHostname, routeHostname):
+		return routeHostname, true
+	case matchesWildcardHostname(routeHostname, listenerHostname):
+		return listenerHostname, true
+	default:
+		return "", false
+	}
+}
+
+func matchesWildcardHostname(pattern, hostname string) bool {
+	return strings.HasPrefix(pattern, "*.") &&
+		len(hostname) > len(pattern)-1 &&
+		strings.HasSuffix(hostname, pattern[1:])
+}
+
+func (t *translator) translateRoute(route *httpRoute, hostnames []string) ([]config.Policy, []error) {
+	name := route.Metadata.Namespace + "/" + route.Metadata.Name
+	if len(hostnames) == 0 {
+		return nil, []error{fmt.Errorf("httproute %s: no hostnames", name)}
+	}
+
+	base, err := parseAnnotations(route.Metadata.Annotations)
+	if err != nil {
+		return nil, []error{fmt.Errorf("httproute %s: %w", name, err)}
+	}
+
+	var policies []config.Policy
+	var errs []error
+	for i := range route.Spec.Rules {
+		ps, err := t.translateRule(base, route, &route.Spec.Rules[i], hostnames)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("httproute %s: rule %d: %w", name, i, err))
+		}
+		policies = append(policies, ps...)
+	}
+	return policies, errs
+}
+
+func (t *translator) translateRule(base config.Policy, route *httpRoute, rule *httpRouteRule, hostnames []string) ([]config.Policy, error) {
+	matches := rule.Matches
+	if len(matches) == 0 {
+		matches = []httpRouteMatch{{}}
+	}
+
+	to, err := t.getBackends(route, rule.BackendRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []config.Policy
+	for _, match := range matches {
+		matchers, err := getMatchers(&match)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, matcher := range matchers {
+			p := base
+			p.Path, p.Prefix, p.Regex = matcher.Path, matcher.Prefix, matcher.Regex
+			for i := range rule.Filters {
+				if err := t.applyFilter(&p, route, &rule.Filters[i], match.Path != nil && match.Path.Type == "PathPrefix"); err != nil {
+					return nil, err
+				}
+			}
+			if p.Redirect == nil {
+				p.To = to
+				if len(to) == 0 {
+					p.Response = &config.PolicyDirectResponse{Status: 500, Body: "no valid backends"}
+				}
+			}
+
+			for _, hostname := range hostnames {
+				hp := p
+				hp.From = "https://" + hostname
+				if err := hp.Validate(); err != nil {
+					return nil, err
+				}
+				policies = append(policies, hp)
+			}
+		}
+	}
+	return policies, nil
+}
+
+// getMatchers returns the route matchers of a match. Gateway API prefixes match whole path
+// segments, so prefixes are matched both as an exact path, and with a trailing slash.
+func getMatchers(match *httpRouteMatch) ([]config.Policy, error) {
+	if len(match.Headers) > 0 || len(match.QueryParams) > 0 || match.Method != nil {
+		return nil, fmt.Errorf("only path matches are supported")
+	}
+	if match.Path == nil {
+		return []config.Policy{{Prefix: "/"}}, nil
+	}
+
+	switch match.Path.Type {
+	case "Exact":
+		return []config.Policy{{Path: match.Path.Value}}, nil
+	case "", "PathPrefix":
+		prefix := strings.TrimSuffix(match.Path.Value, "/")
+		if prefix == "" {
+			return []config.Policy{{Prefix: "/"}}, nil
+		}
+		return []config.Policy{{Path: prefix}, {Prefix: prefix + "/"}}, nil
+	case "RegularExpression":
+		return []config.Policy{{Regex: match.Path.Value}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported path match type %s", match.Path.Type)
+	}
+}
+
+func (t *translator) applyFilter(p *config.Policy, route *httpRoute, filter *httpRouteFilter, prefixMatch bool) error {
+	switch filter.Type {
+	case "RequestHeaderModifier":
+		if f := filter.RequestHeaderModifier; f != nil {
+			p.SetRequestHeaders = copyHeaders(p.SetRequestHeaders)
+			for _, h := range append(f.Set, f.Add...) {
+				p.SetRequestHeaders[h.Name] = h.Value
+			}
+			p.RemoveRequestHeaders = append(append([]string(nil), p.RemoveRequestHeaders...), f.Remove...)
+		}
+	case "ResponseHeaderModifier":
+		if f := filter.ResponseHeaderModifier; f != nil {
+			p.SetResponseHeaders = copyHeaders(p.SetResponseHeaders)
+			for _, h := range f.Set {
+				p.SetResponseHeaders[h.Name] = h.Value
+			}
+			p.AppendResponseHeaders = copyHeaders(p.AppendResponseHeaders)
+			for _, h := range f.Add {
+				p.AppendResponseHeaders[h.Name] = h.Value
+			}
+			p.RemoveResponseHeaders = append(append([]string(nil), p.RemoveResponseHeaders...), f.Remove...)
+		}
+	case "RequestRedirect":
+		if f := filter.RequestRedirect; f != nil {
+			code := int32(302)
+			if f.StatusCode != nil {
+				code = *f.StatusCode
+			}
+			p.Redirect = &config.PolicyRedirect{
+				SchemeRedirect: f.Scheme,
+				HostRedirect:   f.Hostname,
+				ResponseCode:   &code,
+			}
+			if f.Port != nil {
+				port := uint32(*f.Port)
+				p.Redirect.PortRedirect = &port
+			}
+			if f.Path != nil {
+				fullPath, prefix, err := getPathModifier(p, f.Path, prefixMatch)
+				if err != nil {
+					return err
+				}
+				p.Redirect.PathRedirect, p.Redirect.PrefixRewrite = fullPath, prefix
+			}
+		}
+	case "URLRewrite":
+		if f := filter.URLRewrite; f != nil {
+			p.HostRewrite = stringOr(f.Hostname, p.HostRewrite)
+			if f.Path != nil {
+				fullPath, prefix, err := getPathModifier(p, f.Path, prefixMatch)
+				if err != nil {
+					return err
+				}
+				if fullPath != nil {
+					p.RegexRewritePattern, p.RegexRewriteSubstitution = "^.*$", *fullPath
+				}
+				if prefix != nil {
+					p.PrefixRewrite = *prefix
+				}
+			}
+		}
+	case "RequestMirror":
+		if f := filter.RequestMirror; f != nil {
+			u, err := t.getBackendURL(route, &f.BackendRef)
+			if err != nil {
+				return err
+			}
+			p.MirrorTo = append(append(config.WeightedURLs(nil), p.MirrorTo...), config.WeightedURL{URL: *u})
+		}
+	default:
+		return fmt.Errorf("unsupported filter %s", filter.Type)
+	}
+	return nil
+}
+
+// getPathModifier returns the full path or prefix the path of a request is replaced with.
+func getPathModifier(p *config.Policy, m *httpPathModifier, prefixMatch bool) (fullPath, prefix *string, err error) {
+	switch m.Type {
+	case "ReplaceFullPath":
+		return m.ReplaceFullPath, nil, nil
+	case "ReplacePrefixMatch":
+		if !prefixMatch || m.ReplacePrefixMatch == nil {
+			return nil, nil, fmt.Errorf("ReplacePrefixMatch requires a PathPrefix match")
+		}
+		replacement := *m.ReplacePrefixMatch
+		// the trailing slash of the prefix matcher is kept
+		if p.Prefix != "" && p.Prefix != "/" {
+			replacement = strings.TrimSuffix(replacement, "/") + "/"
+		}
+		return nil, &replacement, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported path modifier %s", m.Type)
+	}
+}
+
+// getBackends returns the upstreams of the backend references. Backends with a weight of 0
+// are excluded.
+func (t *translator) getBackends(route *httpRoute, refs []httpBackendRef) (config.WeightedURLs, error) {
+	var to config.WeightedURLs
+	for i := range refs {
+		ref := &refs[i]
+		if len(ref.Filters) > 0 {
+			return nil, fmt.Errorf("backend filters are not supported")
+		}
+		weight := int32(1)
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		u, err := t.getBackendURL(route, &ref.backendObjectReference)
+		if err != nil {
+			return nil, err
+		}
+		to = append(to, config.WeightedURL{URL: *u, LbWeight: uint32(weight)})
+	}
+	return to, nil
+}
+
+// getBackendURL returns the URL of a Service backend. Services of other namespaces must be
+// permitted by a ReferenceGrant.
+func (t *translator) getBackendURL(route *httpRoute, ref *backendObjectReference) (*url.URL, error) {
+	if stringOr(ref.Group, "") != "" || stringOr(ref.Kind, kindService) != kindService {
+		return nil, fmt.Errorf("backend %s: only Service backends are supported", ref.Name)
+	}
+	if ref.Port == nil {
+		return nil, fmt.Errorf("backend %s: port is required", ref.Name)
+	}
+
+	namespace := stringOr(ref.Namespace, route.Metadata.Namespace)
+	if namespace != route.Metadata.Namespace && !t.isReferenceGranted(route.Metadata.Namespace, namespace, ref.Name) {
+		return nil, fmt.Errorf("backend %s/%s: not permitted by a ReferenceGrant", namespace, ref.Name)
+	}
+	return &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(ref.Name+"."+namespace+".svc", strconv.Itoa(int(*ref.Port))),
+	}, nil
+}
+
+// isReferenceGranted returns true if a ReferenceGrant of the namespace of the service permits
+// HTTPRoutes of the namespace of the route to reference it.
+func (t *translator) isReferenceGranted(routeNamespace, serviceNamespace, serviceName string) bool {
+	for _, grant := range t.referenceGrants {
+		if grant.Metadata.Namespace != serviceNamespace {
+			continue
+		}
+
+		from := false
+		for _, f := range grant.Spec.From {
+			from = from || (f.Group == groupGateway && f.Kind == kindRoute && f.Namespace == routeNamespace)
+		}
+		if !from {
+			continue
+		}
+		for _, to := range grant.Spec.To {
+			if to.Group == "" && to.Kind == kindService && stringOr(to.Name, serviceName) == serviceName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseAnnotations returns a route with the options of the pomerium.io/ annotations, whose
+// values are YAML.
+func parseAnnotations(annotations map[string]string) (config.Policy, error) {
+	m := make(map[string]interface{})
+	for k, v := range annotations {
+		key := strings.TrimPrefix(k, annotationPrefix)
+		if key == k {
+			continue
+		}
+		if _, ok := translatedKeys[key]; ok {
+			return config.Policy{}, fmt.Errorf("annotation %s: %s is set by the HTTPRoute", k, key)
+		}
+
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(v), &value); err != nil {
+			return config.Policy{}, fmt.Errorf("annotation %s: %w", k, err)
+		}
+		m[key] = value
+	}
+
+	var p config.Policy
+	if len(m) == 0 {
+		return p, nil
+	}
+	v := viper.New()
+	v.Set("route", m)
+	if err := v.UnmarshalKey("route", &p, config.ViperPolicyHooks); err != nil {
+		return config.Policy{}, fmt.Errorf("invalid annotations: %w", err)
+	}
+	return p, nil
+}
+
+func copyHeaders(src map[string]string) map[string]string {
+	dst := make(map[string]string, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+func stringOr(s *string, def string) string {
+	if s == nil {
+		return def
+	}
+	return *s
+}
@@ -0,0 +1,266 @@
+package gatewayapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestTranslate(t *testing.T) {
+	parse := func(t *testing.T, gateways, routes, grants string) *resources {
+		t.Helper()
+		res := new(resources)
+		require.NoError(t, json.Unmarshal([]byte(gateways), &res.Gateways))
+		require.NoError(t, json.Unmarshal([]byte(routes), &res.HTTPRoutes))
+		require.NoError(t, json.Unmarshal([]byte(grants), &res.ReferenceGrants))
+		return res
+	}
+	gateways := `[
+		{
+			"metadata": {"name": "pomerium", "namespace": "gateway"},
+			"spec": {
+				"gatewayClassName": "pomerium",
+				"listeners": [
+					{"name": "https", "hostname": "*.example.com", "port": 443, "protocol": "HTTPS",
+					 "allowedRoutes": {"namespaces": {"from": "All"}}},
+					{"name": "internal", "port": 443, "protocol": "HTTPS"}
+				]
+			}
+		},
+		{
+			"metadata": {"name": "other", "namespace": "gateway"},
+			"spec": {
+				"gatewayClassName": "other",
+				"listeners": [{"name": "http", "port": 80, "protocol": "HTTP", "allowedRoutes": {"namespaces": {"from": "All"}}}]
+			}
+		}
+	]`
+	getRoutes := func(policies []config.Policy) []string {
+		var routes []string
+		for _, p := range policies {
+			routes = append(routes, p.From+"|"+p.Prefix+"|"+p.Path+"|"+p.Regex)
+		}
+		return routes
+	}
+
+	getTo := func(p config.Policy) []string {
+		var to []string
+		for _, u := range p.To {
+			to = append(to, u.URL.String())
+		}
+		return to
+	}
+
+	t.Run("backends", func(t *testing.T) {
+		policies, errs := translate("pomerium", parse(t, gateways, `[
+			{
+				"metadata": {"name": "app", "namespace": "apps", "annotations": {
+					"pomerium.io/allowed_domains": "[example.com]",
+					"pomerium.io/pass_identity_headers": "true"
+				}},
+				"spec": {
+					"parentRefs": [{"name": "pomerium", "namespace": "gateway", "sectionName": "https"}],
+					"hostnames": ["app.example.com", "app.example.org"],
+					"rules": [
+						{
+							"matches": [{"path": {"type": "PathPrefix", "value": "/api/"}}],
+							"backendRefs": [
+								{"name": "api", "port": 8080, "weight": 3},
+								{"name": "api-canary", "port": 8080, "weight": 1},
+								{"name": "api-old", "port": 8080, "weight": 0}
+							]
+						},
+						{
+							"backendRefs": [{"name": "web", "port": 80}]
+						}
+					]
+				}
+			}
+		]`, `[]`))
+		assert.Empty(t, errs)
+		assert.Equal(t, []string{
+			"https://app.example.com||/api|",
+			"https://app.example.com|/api/||",
+			"https://app.example.com|/||",
+		}, getRoutes(policies))
+		require.Len(t, policies, 3)
+		assert.Equal(t, []string{"http://api.apps.svc:8080", "http://api-canary.apps.svc:8080"}, getTo(policies[0]))
+		assert.Equal(t, []uint32{3, 1}, []uint32{policies[0].To[0].LbWeight, policies[0].To[1].LbWeight})
+		assert.Equal(t, []string{"http://web.apps.svc:80"}, getTo(policies[2]))
+		assert.Equal(t, []string{"example.com"}, policies[2].AllowedDomains)
+		assert.True(t, policies[2].PassIdentityHeaders)
+	})
+	t.Run("filters", func(t *testing.T) {
+		policies, errs := translate("pomerium", parse(t, gateways, `[
+			{
+				"metadata": {"name": "app", "namespace": "gateway"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium"}],
+					"hostnames": ["app.internal"],
+					"rules": [
+						{
+							"matches": [{"path": {"type": "Exact", "value": "/old"}}],
+							"filters": [{"type": "RequestRedirect", "requestRedirect": {"hostname": "new.internal", "statusCode": 301}}]
+						},
+						{
+							"matches": [{"path": {"type": "PathPrefix", "value": "/v1"}}],
+							"filters": [
+								{"type": "RequestHeaderModifier", "requestHeaderModifier": {"set": [{"name": "X-Version", "value": "1"}], "remove": ["X-Debug"]}},
+								{"type": "ResponseHeaderModifier", "responseHeaderModifier": {"add": [{"name": "Vary", "value": "Origin"}]}},
+								{"type": "URLRewrite", "urlRewrite": {"hostname": "api.internal", "path": {"type": "ReplacePrefixMatch", "replacePrefixMatch": "/v2"}}}
+							],
+							"backendRefs": [{"name": "api", "port": 8080}]
+						}
+					]
+				}
+			}
+		]`, `[]`))
+		assert.Empty(t, errs)
+		assert.Equal(t, []string{
+			"https://app.internal||/old|",
+			"https://app.internal||/v1|",
+			"https://app.internal|/v1/||",
+		}, getRoutes(policies))
+		require.Len(t, policies, 3)
+
+		redirect := policies[0].Redirect
+		require.NotNil(t, redirect)
+		assert.Equal(t, "new.internal", *redirect.HostRedirect)
+		assert.Equal(t, int32(301), *redirect.ResponseCode)
+		assert.Empty(t, policies[0].To)
+
+		for _, p := range policies[1:] {
+			assert.Equal(t, map[string]string{"X-Version": "1"}, p.SetRequestHeaders)
+			assert.Equal(t, []string{"X-Debug"}, p.RemoveRequestHeaders)
+			assert.Equal(t, map[string]string{"Vary": "Origin"}, p.AppendResponseHeaders)
+			assert.Equal(t, "api.internal", p.HostRewrite)
+		}
+		assert.Equal(t, "/v2", policies[1].PrefixRewrite)
+		assert.Equal(t, "/v2/", policies[2].PrefixRewrite)
+	})
+	t.Run("reference grants", func(t *testing.T) {
+		routes := `[
+			{
+				"metadata": {"name": "app", "namespace": "apps"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium", "namespace": "gateway"}],
+					"hostnames": ["app.example.com"],
+					"rules": [{"backendRefs": [{"name": "db-admin", "namespace": "data", "port": 80}]}]
+				}
+			}
+		]`
+
+		policies, errs := translate("pomerium", parse(t, gateways, routes, `[]`))
+		assert.Len(t, errs, 1)
+		assert.Empty(t, policies)
+
+		policies, errs = translate("pomerium", parse(t, gateways, routes, `[
+			{
+				"metadata": {"name": "apps", "namespace": "data"},
+				"spec": {
+					"from": [{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": "apps"}],
+					"to": [{"group": "", "kind": "Service", "name": "db-admin"}]
+				}
+			}
+		]`))
+		assert.Empty(t, errs)
+		require.Len(t, policies, 1)
+		assert.Equal(t, []string{"http://db-admin.data.svc:80"}, getTo(policies[0]))
+	})
+	t.Run("attachment", func(t *testing.T) {
+		policies, errs := translate("pomerium", parse(t, gateways, `[
+			{
+				"metadata": {"name": "other-class", "namespace": "apps"},
+				"spec": {
+					"parentRefs": [{"name": "other", "namespace": "gateway"}],
+					"hostnames": ["other.example.com"],
+					"rules": [{"backendRefs": [{"name": "other", "port": 80}]}]
+				}
+			},
+			{
+				"metadata": {"name": "other-namespace", "namespace": "apps"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium", "namespace": "gateway", "sectionName": "internal"}],
+					"hostnames": ["app.internal"],
+					"rules": [{"backendRefs": [{"name": "app", "port": 80}]}]
+				}
+			},
+			{
+				"metadata": {"name": "mismatched-hostname", "namespace": "apps"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium", "namespace": "gateway", "sectionName": "https"}],
+					"hostnames": ["app.example.org"],
+					"rules": [{"backendRefs": [{"name": "app", "port": 80}]}]
+				}
+			}
+		]`, `[]`))
+		assert.Empty(t, policies)
+		assert.Len(t, errs, 1, "routes attached to the listeners of the gateway without a matching hostname are reported")
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		policies, errs := translate("pomerium", parse(t, gateways, `[
+			{
+				"metadata": {"name": "app", "namespace": "gateway"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium"}],
+					"hostnames": ["app.internal"],
+					"rules": [
+						{
+							"matches": [{"headers": [{"name": "X-Canary", "value": "true"}]}],
+							"backendRefs": [{"name": "canary", "port": 80}]
+						},
+						{
+							"filters": [{"type": "ExtensionRef", "extensionRef": {"group": "example.com", "kind": "Filter", "name": "f"}}],
+							"backendRefs": [{"name": "app", "port": 80}]
+						},
+						{
+							"backendRefs": [{"name": "app", "port": 80}]
+						}
+					]
+				}
+			}
+		]`, `[]`))
+		assert.Len(t, errs, 2)
+		assert.Equal(t, []string{"https://app.internal|/||"}, getRoutes(policies))
+	})
+	t.Run("conflicts", func(t *testing.T) {
+		policies, errs := translate("pomerium", parse(t, gateways, `[
+			{
+				"metadata": {"name": "new", "namespace": "gateway", "creationTimestamp": "2023-02-01T00:00:00Z"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium"}],
+					"hostnames": ["app.internal"],
+					"rules": [{"backendRefs": [{"name": "new", "port": 80}]}]
+				}
+			},
+			{
+				"metadata": {"name": "old", "namespace": "gateway", "creationTimestamp": "2023-01-01T00:00:00Z"},
+				"spec": {
+					"parentRefs": [{"name": "pomerium"}],
+					"hostnames": ["app.internal"],
+					"rules": [{"backendRefs": [{"name": "old", "port": 80}]}]
+				}
+			}
+		]`, `[]`))
+		assert.Len(t, errs, 1)
+		require.Len(t, policies, 1)
+		assert.Equal(t, []string{"http://old.gateway.svc:80"}, getTo(policies[0]))
+	})
+	t.Run("invalid annotations", func(t *testing.T) {
+		_, errs := translate("pomerium", parse(t, gateways, `[
+			{
+				"metadata": {"name": "app", "namespace": "gateway", "annotations": {"pomerium.io/to": "http://example.com"}},
+				"spec": {
+					"parentRefs": [{"name": "pomerium"}],
+					"hostnames": ["app.internal"],
+					"rules": [{"backendRefs": [{"name": "app", "port": 80}]}]
+				}
+			}
+		]`, `[]`))
+		assert.Len(t, errs, 1)
+	})
+}
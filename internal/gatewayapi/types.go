@@ -0,0 +1,153 @@
+package gatewayapi
+
+import "time"
+
+// The subset of the Gateway API resources pomerium translates into routes. See
+// https://gateway-api.sigs.k8s.io/reference/spec/ for the full resources.
+
+const (
+	groupGateway = "gateway.networking.k8s.io"
+	kindGateway  = "Gateway"
+	kindRoute    = "HTTPRoute"
+	kindService  = "Service"
+)
+
+type objectMeta struct {
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Annotations       map[string]string `json:"annotations"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+}
+
+type gateway struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		GatewayClassName string     `json:"gatewayClassName"`
+		Listeners        []listener `json:"listeners"`
+	} `json:"spec"`
+}
+
+type listener struct {
+	Name          string  `json:"name"`
+	Hostname      *string `json:"hostname"`
+	Port          int32   `json:"port"`
+	Protocol      string  `json:"protocol"`
+	AllowedRoutes *struct {
+		Namespaces *struct {
+			From string `json:"from"`
+		} `json:"namespaces"`
+	} `json:"allowedRoutes"`
+}
+
+type httpRoute struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		ParentRefs []parentReference `json:"parentRefs"`
+		Hostnames  []string          `json:"hostnames"`
+		Rules      []httpRouteRule   `json:"rules"`
+	} `json:"spec"`
+}
+
+type parentReference struct {
+	Group       *string `json:"group"`
+	Kind        *string `json:"kind"`
+	Namespace   *string `json:"namespace"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName"`
+	Port        *int32  `json:"port"`
+}
+
+type httpRouteRule struct {
+	Matches     []httpRouteMatch  `json:"matches"`
+	Filters     []httpRouteFilter `json:"filters"`
+	BackendRefs []httpBackendRef  `json:"backendRefs"`
+}
+
+type httpRouteMatch struct {
+	Path *struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"path"`
+	Headers     []interface{} `json:"headers"`
+	QueryParams []interface{} `json:"queryParams"`
+	Method      *string       `json:"method"`
+}
+
+type httpRouteFilter struct {
+	Type                   string                     `json:"type"`
+	RequestHeaderModifier  *httpHeaderFilter          `json:"requestHeaderModifier"`
+	ResponseHeaderModifier *httpHeaderFilter          `json:"responseHeaderModifier"`
+	RequestRedirect        *httpRequestRedirectFilter `json:"requestRedirect"`
+	URLRewrite             *httpURLRewriteFilter      `json:"urlRewrite"`
+	RequestMirror          *struct {
+		BackendRef backendObjectReference `json:"backendRef"`
+	} `json:"requestMirror"`
+}
+
+type httpHeaderFilter struct {
+	Set []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"set"`
+	Add []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+type httpRequestRedirectFilter struct {
+	Scheme     *string           `json:"scheme"`
+	Hostname   *string           `json:"hostname"`
+	Path       *httpPathModifier `json:"path"`
+	Port       *int32            `json:"port"`
+	StatusCode *int32            `json:"statusCode"`
+}
+
+type httpURLRewriteFilter struct {
+	Hostname *string           `json:"hostname"`
+	Path     *httpPathModifier `json:"path"`
+}
+
+type httpPathModifier struct {
+	Type               string  `json:"type"`
+	ReplaceFullPath    *string `json:"replaceFullPath"`
+	ReplacePrefixMatch *string `json:"replacePrefixMatch"`
+}
+
+type httpBackendRef struct {
+	backendObjectReference
+	Weight  *int32            `json:"weight"`
+	Filters []httpRouteFilter `json:"filters"`
+}
+
+type backendObjectReference struct {
+	Group     *string `json:"group"`
+	Kind      *string `json:"kind"`
+	Name      string  `json:"name"`
+	Namespace *string `json:"namespace"`
+	Port      *int32  `json:"port"`
+}
+
+type referenceGrant struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		From []struct {
+			Group     string `json:"group"`
+			Kind      string `json:"kind"`
+			Namespace string `json:"namespace"`
+		} `json:"from"`
+		To []struct {
+			Group string  `json:"group"`
+			Kind  string  `json:"kind"`
+			Name  *string `json:"name"`
+		} `json:"to"`
+	} `json:"spec"`
+}
+
+// resources are the Gateway API resources of the cluster.
+type resources struct {
+	Gateways        []gateway
+	HTTPRoutes      []httpRoute
+	ReferenceGrants []referenceGrant
+}
@@ -0,0 +1,243 @@
+// Package gitops contains a config source which pulls routes from a git repository.
+package gitops
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/hashutil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+// ConfigSource provides a new Config source that decorates an underlying config with the routes
+// of a git repository.
+//
+// Every commit is applied atomically: if any of its routes is invalid, the routes of the
+// previously applied commit are kept.
+type ConfigSource struct {
+	mu               sync.RWMutex
+	underlyingConfig *config.Config
+	computedConfig   *config.Config
+	policies         []config.Policy
+	commit           string
+	pollerHash       uint64
+	cancel           func()
+
+	config.ChangeDispatcher
+}
+
+type pollerOptions struct {
+	Repository         string
+	Ref                string
+	Path               string
+	PollInterval       time.Duration
+	AllowedSignersFile string
+	WebhookAddress     string
+	WebhookSecret      string
+}
+
+// NewConfigSource creates a new ConfigSource.
+func NewConfigSource(ctx context.Context, underlying config.Source) *ConfigSource {
+	src := &ConfigSource{}
+	underlying.OnConfigChange(ctx, func(ctx context.Context, cfg *config.Config) {
+		src.mu.Lock()
+		src.underlyingConfig = cfg.Clone()
+		src.mu.Unlock()
+
+		src.rebuild(ctx, firstTime(false))
+	})
+	src.underlyingConfig = underlying.GetConfig()
+	src.rebuild(ctx, firstTime(true))
+	return src
+}
+
+// GetConfig gets the current config.
+func (src *ConfigSource) GetConfig() *config.Config {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
+
+	return src.computedConfig
+}
+
+type firstTime bool
+
+func (src *ConfigSource) rebuild(ctx context.Context, firstTime firstTime) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+
+	src.runPoller(src.underlyingConfig.Options)
+
+	cfg, err := src.buildConfig(src.policies)
+	if err != nil {
+		// the underlying config conflicts with the routes of the repository, so they're dropped
+		log.Error(ctx).Err(err).Str("commit", src.commit).Msg("gitops: ignoring routes")
+		cfg = src.underlyingConfig.Clone()
+	}
+	src.computedConfig = cfg
+	if !firstTime {
+		src.Trigger(ctx, cfg)
+	}
+}
+
+// buildConfig returns the underlying config with the policies added, or an error if any of the
+// policies are invalid.
+func (src *ConfigSource) buildConfig(policies []config.Policy) (*config.Config, error) {
+	cfg := src.underlyingConfig.Clone()
+	if len(policies) == 0 || src.underlyingConfig.Options.GitOpsRepository == "" {
+		return cfg, nil
+	}
+
+	// routes of the repository must not shadow other routes
+	seen := map[string]struct{}{}
+	for _, policy := range cfg.Options.GetAllPolicies() {
+		seen[getRouteMatcher(&policy)] = struct{}{}
+	}
+	for _, policy := range policies {
+		key := getRouteMatcher(&policy)
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%s: duplicate route", policy.String())
+		}
+		seen[key] = struct{}{}
+	}
+
+	cfg.Options.AdditionalPolicies = append(cfg.Options.AdditionalPolicies, policies...)
+	if err := cfg.Options.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func getRouteMatcher(policy *config.Policy) string {
+	return strings.Join([]string{policy.From, policy.Prefix, policy.Path, policy.Regex}, "|")
+}
+
+// runPoller restarts the poller of the repository when its options change.
+func (src *ConfigSource) runPoller(options *config.Options) {
+	opts := pollerOptions{
+		Repository:         options.GitOpsRepository,
+		Ref:                options.GetGitOpsRef(),
+		Path:               options.GitOpsPath,
+		PollInterval:       options.GetGitOpsPollInterval(),
+		AllowedSignersFile: options.GitOpsAllowedSignersFile,
+		WebhookAddress:     options.GitOpsWebhookAddress,
+		WebhookSecret:      options.GitOpsWebhookSecret,
+	}
+	h, err := hashutil.Hash(opts)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	// nothing changed, so don't restart the poller
+	if src.pollerHash == h {
+		return
+	}
+	src.pollerHash = h
+
+	if src.cancel != nil {
+		src.cancel()
+		src.cancel = nil
+	}
+	if opts.Repository == "" {
+		src.policies, src.commit = nil, ""
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src.cancel = cancel
+	go src.poll(ctx, opts)
+}
+
+func (src *ConfigSource) poll(ctx context.Context, opts pollerOptions) {
+	dir, err := os.MkdirTemp("", "pomerium-gitops-")
+	if err != nil {
+		log.Error(ctx).Err(err).Msg("gitops: error creating repository directory")
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	repo := &repository{
+		url:                opts.Repository,
+		ref:                opts.Ref,
+		dir:                dir,
+		allowedSignersFile: opts.AllowedSignersFile,
+	}
+	if opts.AllowedSignersFile == "" {
+		log.Warn(ctx).Msg("gitops: commit signatures aren't verified, set gitops_allowed_signers_file to verify them")
+	}
+
+	trigger := make(chan struct{}, 1)
+	if opts.WebhookAddress != "" {
+		go runWebhookServer(ctx, opts.WebhookAddress, opts.WebhookSecret, trigger)
+	}
+
+	var fetched string
+	for {
+		commit, err := src.pull(ctx, repo, opts.Path, fetched)
+		if err != nil && ctx.Err() == nil {
+			log.Error(ctx).Err(err).Str("repository", opts.Repository).Str("ref", opts.Ref).
+				Msg("gitops: error pulling routes")
+		}
+		if commit != "" {
+			// invalid commits aren't retried until there's a new commit
+			fetched = commit
+		}
+
+		timer := time.NewTimer(opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-trigger:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// pull fetches the ref and applies the routes of its commit, unless it's the previously fetched
+// commit. The fetched commit is returned.
+func (src *ConfigSource) pull(ctx context.Context, repo *repository, path, previous string) (string, error) {
+	commit, err := repo.fetch(ctx)
+	if err != nil || commit == previous {
+		return "", err
+	}
+
+	if err := repo.verify(ctx, commit); err != nil {
+		return commit, fmt.Errorf("commit %s: invalid signature: %w", commit, err)
+	}
+	if err := repo.checkout(ctx, commit); err != nil {
+		return "", err
+	}
+
+	policies, err := config.ReadPoliciesFromPath(filepath.Join(repo.dir, path))
+	if err != nil {
+		return commit, fmt.Errorf("commit %s: %w", commit, err)
+	}
+
+	return commit, src.apply(ctx, commit, policies)
+}
+
+// apply replaces the routes with the routes of the commit if they're valid.
+func (src *ConfigSource) apply(ctx context.Context, commit string, policies []config.Policy) error {
+	src.mu.Lock()
+	cfg, err := src.buildConfig(policies)
+	if err != nil {
+		src.mu.Unlock()
+		metrics.SetConfigInfo(ctx, src.underlyingConfig.Options.Services, "gitops", 0, false)
+		return fmt.Errorf("commit %s: %w", commit, err)
+	}
+	src.policies, src.commit = policies, commit
+	src.computedConfig = cfg
+	src.mu.Unlock()
+
+	log.Info(ctx).Str("commit", commit).Int("routes", len(policies)).Msg("gitops: applied routes")
+	metrics.SetConfigInfo(ctx, cfg.Options.Services, "gitops", cfg.Checksum(), true)
+	src.Trigger(ctx, cfg)
+	return nil
+}
@@ -0,0 +1,144 @@
+package gitops
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+type testRepository struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestRepository(t *testing.T) *testRepository {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	r := &testRepository{t: t, dir: t.TempDir()}
+	r.git("init", "--quiet", "--initial-branch=main")
+	return r
+}
+
+// commit commits the files, with the git options of args.
+func (r *testRepository) commit(files map[string]string, args ...string) string {
+	r.t.Helper()
+	for name, contents := range files {
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(filepath.Join(r.dir, name)), 0o755))
+		require.NoError(r.t, os.WriteFile(filepath.Join(r.dir, name), []byte(contents), 0o600))
+	}
+	r.git("add", "--all")
+	r.git(append(args, "commit", "--quiet", "--message=update routes")...)
+	return r.git("rev-parse", "HEAD")
+}
+
+func (r *testRepository) git(args ...string) string {
+	r.t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(r.t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func TestConfigSource(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), 30*time.Second)
+	defer clearTimeout()
+
+	repo := newTestRepository(t)
+	repo.commit(map[string]string{
+		"routes/a.yaml": "routes:\n- from: https://a.example.com\n  to: https://a.internal\n",
+	})
+
+	base := config.NewDefaultOptions()
+	base.GitOpsRepository = repo.dir
+	base.GitOpsPath = "routes"
+	base.GitOpsPollInterval = time.Hour
+	to, err := config.ParseWeightedUrls("https://base.internal")
+	require.NoError(t, err)
+	base.Policies = []config.Policy{{From: "https://base.example.com", To: to}}
+	require.NoError(t, base.Policies[0].Validate())
+
+	src := NewConfigSource(ctx, config.NewStaticSource(&config.Config{Options: base}))
+	defer src.cancel()
+
+	getFroms := func(cfg *config.Config) []string {
+		var froms []string
+		for _, p := range cfg.Options.GetAllPolicies() {
+			froms = append(froms, p.From)
+		}
+		return froms
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(getFroms(src.GetConfig())) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"https://base.example.com", "https://a.example.com"}, getFroms(src.GetConfig()))
+
+	t.Run("rollback", func(t *testing.T) {
+		// a route with the same matcher as a route of the underlying config is rejected, so
+		// the routes of the previous commit are kept
+		repo.commit(map[string]string{
+			"routes/a.yaml": "routes:\n- from: https://a.example.com\n  to: https://a.internal\n- from: https://base.example.com\n  to: https://other.internal\n",
+		})
+
+		policies, err := config.ReadPoliciesFromPath(filepath.Join(repo.dir, "routes"))
+		require.NoError(t, err)
+		err = src.apply(ctx, "invalid", policies)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"https://base.example.com", "https://a.example.com"}, getFroms(src.GetConfig()))
+	})
+}
+
+func TestRepository(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not installed")
+	}
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), 30*time.Second)
+	defer clearTimeout()
+
+	upstream := newTestRepository(t)
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", key).CombinedOutput()
+	require.NoError(t, err, string(out))
+	pub, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+	allowedSigners := filepath.Join(keyDir, "allowed_signers")
+	require.NoError(t, os.WriteFile(allowedSigners, append([]byte("test@example.com "), pub...), 0o600))
+
+	r := &repository{
+		url:                upstream.dir,
+		ref:                "main",
+		dir:                t.TempDir(),
+		allowedSignersFile: allowedSigners,
+	}
+
+	unsigned := upstream.commit(map[string]string{"a.yaml": "routes: []\n"})
+	commit, err := r.fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, unsigned, commit)
+	assert.Error(t, r.verify(ctx, commit), "unsigned commits should be rejected")
+
+	signed := upstream.commit(map[string]string{"b.yaml": "routes: []\n"},
+		"-c", "gpg.format=ssh", "-c", "user.signingkey="+key, "-c", "commit.gpgsign=true")
+	commit, err = r.fetch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, signed, commit)
+	assert.NoError(t, r.verify(ctx, commit))
+	assert.NoError(t, r.checkout(ctx, commit))
+	assert.FileExists(t, filepath.Join(r.dir, "b.yaml"))
+}
@@ -0,0 +1,63 @@
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// A repository is a local clone of the git repository routes are pulled from. Only the commit
+// of the ref is fetched, since the history isn't needed.
+type repository struct {
+	url                string
+	ref                string
+	dir                string
+	allowedSignersFile string
+}
+
+// fetch fetches the ref and returns its commit.
+func (r *repository) fetch(ctx context.Context) (string, error) {
+	if _, err := os.Stat(filepath.Join(r.dir, ".git")); os.IsNotExist(err) {
+		if _, err := r.git(ctx, "init", "--quiet"); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := r.git(ctx, "fetch", "--quiet", "--depth=1", "--", r.url, r.ref); err != nil {
+		return "", err
+	}
+	return r.git(ctx, "rev-parse", "--verify", "FETCH_HEAD^{commit}")
+}
+
+// verify returns an error unless the commit is signed by one of the allowed signers.
+func (r *repository) verify(ctx context.Context, commit string) error {
+	if r.allowedSignersFile == "" {
+		return nil
+	}
+	_, err := r.git(ctx, "-c", "gpg.ssh.allowedSignersFile="+r.allowedSignersFile, "verify-commit", commit)
+	return err
+}
+
+// checkout checks out the commit into the working tree of the repository.
+func (r *repository) checkout(ctx context.Context, commit string) error {
+	_, err := r.git(ctx, "checkout", "--quiet", "--force", "--detach", commit)
+	return err
+}
+
+func (r *repository) git(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	// never prompt for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
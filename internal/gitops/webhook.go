@@ -0,0 +1,89 @@
+package gitops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// maxWebhookBodySize is the maximum size of a webhook request body.
+const maxWebhookBodySize = 1 << 20
+
+// newWebhookHandler returns a handler which signals trigger for every push event sent by
+// GitHub, Gitea or GitLab. GitHub and Gitea sign the body with the secret, while GitLab sends the
+// secret as a token.
+func newWebhookHandler(secret string, trigger chan<- struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !isValidWebhookRequest(r.Header, body, secret) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func isValidWebhookRequest(header http.Header, body []byte, secret string) bool {
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+
+	signature := header.Get("X-Hub-Signature-256")
+	if signature == "" {
+		signature = header.Get("X-Gitea-Signature")
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || len(got) == 0 {
+		return false
+	}
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+	return hmac.Equal(got, h.Sum(nil))
+}
+
+// runWebhookServer serves the webhook on the address until the context is canceled.
+func runWebhookServer(ctx context.Context, addr, secret string, trigger chan<- struct{}) {
+	li, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Error(ctx).Err(err).Str("address", addr).Msg("gitops: error starting webhook server")
+		return
+	}
+
+	srv := &http.Server{
+		Handler:           newWebhookHandler(secret, trigger),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	log.Info(ctx).Str("address", li.Addr().String()).Msg("gitops: webhook server started")
+	if err := srv.Serve(li); err != nil && err != http.ErrServerClosed {
+		log.Error(ctx).Err(err).Msg("gitops: webhook server stopped")
+	}
+}
@@ -0,0 +1,44 @@
+package gitops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	body := `{"ref":"refs/heads/main"}`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	for _, tc := range []struct {
+		name    string
+		header  http.Header
+		status  int
+		trigger bool
+	}{
+		{"github", http.Header{"X-Hub-Signature-256": {signature}}, http.StatusAccepted, true},
+		{"gitea", http.Header{"X-Gitea-Signature": {strings.TrimPrefix(signature, "sha256=")}}, http.StatusAccepted, true},
+		{"gitlab", http.Header{"X-Gitlab-Token": {"secret"}}, http.StatusAccepted, true},
+		{"bad signature", http.Header{"X-Hub-Signature-256": {"sha256=abcd"}}, http.StatusUnauthorized, false},
+		{"bad token", http.Header{"X-Gitlab-Token": {"other"}}, http.StatusUnauthorized, false},
+		{"missing signature", http.Header{}, http.StatusUnauthorized, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			trigger := make(chan struct{}, 1)
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			r.Header = tc.header
+			w := httptest.NewRecorder()
+			newWebhookHandler("secret", trigger).ServeHTTP(w, r)
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.trigger, len(trigger) == 1)
+		})
+	}
+}
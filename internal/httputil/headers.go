@@ -30,8 +30,13 @@ const (
 	HeaderPomeriumReproxyPolicy = "x-pomerium-reproxy-policy"
 	// HeaderPomeriumReproxyPolicyHMAC is an HMAC of the HeaderPomeriumReproxyPolicy header.
 	HeaderPomeriumReproxyPolicyHMAC = "x-pomerium-reproxy-policy-hmac"
+	// HeaderPomeriumReproxyHost is the header key containing the original host of a request to reproxy.
+	HeaderPomeriumReproxyHost = "x-pomerium-reproxy-host"
 	// HeaderPomeriumRoutingKey is a string used for routing user requests to a consistent upstream server.
 	HeaderPomeriumRoutingKey = "x-pomerium-routing-key"
+	// HeaderPomeriumClientCertificate is the URL encoded PEM client certificate envoy passes to the
+	// authenticate service, so sessions are bound to it when they're created.
+	HeaderPomeriumClientCertificate = "x-pomerium-client-certificate"
 )
 
 // HeadersContentSecurityPolicy are the content security headers added to the service's handlers
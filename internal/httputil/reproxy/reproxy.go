@@ -17,6 +17,7 @@ import (
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/urlutil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 )
 
@@ -25,6 +26,9 @@ import (
 //
 // This is used to forward requests to Kubernetes with headers split to multiple values instead of coalesced via a
 // comma. (https://github.com/kubernetes/kubernetes/issues/94683) If the upstream issue is fixed we will remove this.
+//
+// It is also used to relay the datagrams of UDP routes tunneled via HTTP Connect, and to forward
+// the requests of routes with wildcard destinations to the destination of their subdomain.
 type Handler struct {
 	mu       sync.RWMutex
 	key      []byte
@@ -87,13 +91,17 @@ func (h *Handler) Middleware(next http.Handler) http.Handler {
 		policy, ok := h.policies[policyID]
 		h.mu.RUnlock()
 
-		if !ok || !policy.IsForKubernetes() {
+		isUDP := ok && policy.Source != nil && urlutil.IsUDP(policy.Source.URL)
+		isWildcard := ok && policy.HasWildcardDestination()
+		if !ok || (!policy.IsForKubernetes() && !isUDP && !isWildcard) {
 			return httputil.NewError(http.StatusNotFound, errors.New("policy not found"))
 		}
 
 		// remove these headers from the request to kubernetes
 		r.Header.Del(httputil.HeaderPomeriumReproxyPolicy)
 		r.Header.Del(httputil.HeaderPomeriumReproxyPolicyHMAC)
+		originalHost := r.Header.Get(httputil.HeaderPomeriumReproxyHost)
+		r.Header.Del(httputil.HeaderPomeriumReproxyHost)
 
 		// fix the impersonate group header
 		if vs := r.Header.Values(httputil.HeaderImpersonateGroup); len(vs) > 0 {
@@ -114,6 +122,23 @@ func (h *Handler) Middleware(next http.Handler) http.Handler {
 		// regular rand is fine for this
 		dst := dsts[rand.Intn(len(dsts))] // nolint:gosec
 
+		if isUDP {
+			return serveUDP(w, r, &dst)
+		}
+
+		if isWildcard {
+			subdomain, ok := policy.GetWildcardSubdomain(originalHost)
+			if !ok {
+				return httputil.NewError(http.StatusNotFound, errors.New("policy subdomain not found"))
+			}
+			dst = config.GetWildcardDestination(dst, subdomain)
+			if policy.PreserveHostHeader {
+				r.Host = originalHost
+			} else {
+				r.Host = dst.Host
+			}
+		}
+
 		// when SPDY is being used, disable HTTP/2 because the two can't be used together with the reverse proxy
 		// Issue #2126
 		disableHTTP2 := isSPDY(r)
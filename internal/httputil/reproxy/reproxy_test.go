@@ -1,8 +1,10 @@
 package reproxy
 
 import (
+	"bufio"
 	"context"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -12,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/pkg/cryptutil"
 )
 
@@ -77,4 +80,105 @@ func TestMiddleware(t *testing.T) {
 
 		assert.Equal(t, "SERVER1", string(body))
 	})
+	t.Run("wildcard", func(t *testing.T) {
+		h := New()
+
+		srv1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.Host)
+		}))
+		defer srv1.Close()
+
+		u, err := url.Parse(srv1.URL)
+		require.NoError(t, err)
+
+		srv2 := httptest.NewServer(h.Middleware(next))
+		defer srv2.Close()
+
+		cfg := &config.Config{
+			Options: &config.Options{
+				SharedKey: cryptutil.NewBase64Key(),
+				Policies: []config.Policy{{
+					Source: &config.StringURL{URL: &url.URL{Scheme: "https", Host: "*.apps.example.com"}},
+					To:     config.WeightedURLs{{URL: url.URL{Scheme: "http", Host: "*:" + u.Port()}}},
+				}},
+			},
+		}
+		h.Update(context.Background(), cfg)
+
+		policyID, _ := cfg.Options.Policies[0].RouteID()
+
+		req, err := http.NewRequest("GET", srv2.URL, nil)
+		require.NoError(t, err)
+		for _, hdr := range h.GetPolicyIDHeaders(policyID) {
+			req.Header.Set(hdr[0], hdr[1])
+		}
+		req.Header.Set(httputil.HeaderPomeriumReproxyHost, "127.0.0.1.apps.example.com")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		assert.Equal(t, u.Host, string(body))
+	})
+	t.Run("udp", func(t *testing.T) {
+		h := New()
+
+		echo, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer echo.Close()
+		go func() {
+			buf := make([]byte, 1024)
+			for {
+				n, addr, err := echo.ReadFrom(buf)
+				if err != nil {
+					return
+				}
+				_, _ = echo.WriteTo(buf[:n], addr)
+			}
+		}()
+
+		srv := httptest.NewServer(h.Middleware(next))
+		defer srv.Close()
+
+		cfg := &config.Config{
+			Options: &config.Options{
+				SharedKey: cryptutil.NewBase64Key(),
+				Policies: []config.Policy{{
+					Source: &config.StringURL{URL: &url.URL{Scheme: "udp+https", Host: "dns.example.com:53"}},
+					To:     config.WeightedURLs{{URL: url.URL{Scheme: "udp", Host: echo.LocalAddr().String()}}},
+				}},
+			},
+		}
+		h.Update(context.Background(), cfg)
+
+		policyID, _ := cfg.Options.Policies[0].RouteID()
+
+		conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		req, err := http.NewRequest(http.MethodConnect, srv.URL, nil)
+		require.NoError(t, err)
+		req.Host = "dns.example.com:53"
+		for _, hdr := range h.GetPolicyIDHeaders(policyID) {
+			req.Header.Set(hdr[0], hdr[1])
+		}
+		require.NoError(t, req.Write(conn))
+
+		br := bufio.NewReader(conn)
+		res, err := http.ReadResponse(br, req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		_, err = conn.Write([]byte{0, 5, 'h', 'e', 'l', 'l', 'o'})
+		require.NoError(t, err)
+
+		reply := make([]byte, 7)
+		_, err = io.ReadFull(br, reply)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0, 5, 'h', 'e', 'l', 'l', 'o'}, reply)
+	})
 }
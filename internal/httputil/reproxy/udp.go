@@ -0,0 +1,104 @@
+package reproxy
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// maxUDPDatagramSize is the maximum size of a UDP datagram.
+const maxUDPDatagramSize = math.MaxUint16
+
+// serveUDP tunnels UDP datagrams over an HTTP CONNECT request. Once the connection has been
+// established each datagram is sent prefixed by its length as a 2-byte big-endian integer.
+func serveUDP(w http.ResponseWriter, r *http.Request, dst *url.URL) error {
+	if r.Method != http.MethodConnect {
+		return httputil.NewError(http.StatusMethodNotAllowed, errors.New("udp routes require CONNECT"))
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return httputil.NewError(http.StatusInternalServerError, errors.New("connection does not support hijacking"))
+	}
+
+	upstream, err := net.Dial("udp", dst.Host)
+	if err != nil {
+		return httputil.NewError(http.StatusBadGateway, fmt.Errorf("error dialing upstream: %w", err))
+	}
+	defer upstream.Close()
+
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return fmt.Errorf("error hijacking connection: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = io.WriteString(conn, "HTTP/1.1 200 OK\r\n\r\n")
+	if err != nil {
+		log.Error(r.Context()).Err(err).Msg("reproxy: error writing udp tunnel response")
+		return nil
+	}
+
+	err = relayUDP(brw.Reader, conn, upstream)
+	if err != nil {
+		log.Debug(r.Context()).Err(err).Str("upstream", dst.Host).Msg("reproxy: udp tunnel closed")
+	}
+	return nil
+}
+
+// relayUDP relays length-prefixed datagrams read from src to the upstream, and datagrams received
+// from the upstream to dst, until either side is closed.
+func relayUDP(src io.Reader, dst io.Writer, upstream net.Conn) error {
+	errc := make(chan error, 2)
+	go func() {
+		errc <- copyToUDP(upstream, src)
+	}()
+	go func() {
+		errc <- copyFromUDP(dst, upstream)
+	}()
+	return <-errc
+}
+
+func copyToUDP(upstream net.Conn, src io.Reader) error {
+	br := bufio.NewReader(src)
+	buf := make([]byte, maxUDPDatagramSize)
+	for {
+		var sz uint16
+		err := binary.Read(br, binary.BigEndian, &sz)
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadFull(br, buf[:sz])
+		if err != nil {
+			return err
+		}
+		_, err = upstream.Write(buf[:sz])
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func copyFromUDP(dst io.Writer, upstream net.Conn) error {
+	buf := make([]byte, 2+maxUDPDatagramSize)
+	for {
+		n, err := upstream.Read(buf[2:])
+		if err != nil {
+			return err
+		}
+		binary.BigEndian.PutUint16(buf, uint16(n))
+		_, err = dst.Write(buf[:2+n])
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package claimsmap transforms the claims returned by identity providers, so that policies and
+// upstream applications see the same claims regardless of the identity provider.
+package claimsmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
+
+// TemplateFuncs are the functions available in claim mapping templates.
+var TemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"join": func(sep string, values interface{}) string {
+		var strs []string
+		for _, v := range toSlice(values) {
+			strs = append(strs, fmt.Sprint(v))
+		}
+		return strings.Join(strs, sep)
+	},
+}
+
+// A Mapper applies claim mappings to claims. The mappings are applied in order, so a mapping can
+// use claims set by previous mappings. A nil Mapper doesn't change any claims.
+type Mapper struct {
+	mappings []mapping
+}
+
+type mapping struct {
+	*identity.ClaimMapping
+	tmpl *template.Template
+}
+
+// New creates a new Mapper. If there are no mappings nil is returned.
+func New(mappings []*identity.ClaimMapping) (*Mapper, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+
+	m := &Mapper{}
+	for i, cm := range mappings {
+		if cm.GetClaim() == "" {
+			return nil, fmt.Errorf("claimsmap: mapping %d: claim is required", i)
+		}
+		if cm.GetTemplate() != "" && len(cm.GetFrom()) > 0 {
+			return nil, fmt.Errorf("claimsmap: mapping %s: only one of from or template may be set", cm.GetClaim())
+		}
+
+		mm := mapping{ClaimMapping: cm}
+		if cm.GetTemplate() != "" {
+			var err error
+			mm.tmpl, err = template.New(cm.GetClaim()).
+				Option("missingkey=error").
+				Funcs(TemplateFuncs).
+				Parse(cm.GetTemplate())
+			if err != nil {
+				return nil, fmt.Errorf("claimsmap: mapping %s: invalid template: %w", cm.GetClaim(), err)
+			}
+		}
+		m.mappings = append(m.mappings, mm)
+	}
+	return m, nil
+}
+
+// Apply applies the mappings to the claims.
+func (m *Mapper) Apply(claims map[string]interface{}) {
+	if m == nil {
+		return
+	}
+	for _, mm := range m.mappings {
+		mm.apply(claims)
+	}
+}
+
+// Unmarshal unmarshals the JSON claims into v after applying the mappings.
+func (m *Mapper) Unmarshal(data []byte, v interface{}) error {
+	if m == nil {
+		return json.Unmarshal(data, v)
+	}
+
+	// use numbers so integer claims like exp survive the round trip
+	claims := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&claims); err != nil {
+		return err
+	}
+
+	m.Apply(claims)
+
+	bs, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bs, v)
+}
+
+func (mm mapping) apply(claims map[string]interface{}) {
+	var values []interface{}
+	isList := false
+	if mm.tmpl != nil {
+		var buf bytes.Buffer
+		// if a claim used by the template is missing, the claim isn't set
+		if err := mm.tmpl.Execute(&buf, claims); err != nil {
+			return
+		}
+		values = []interface{}{buf.String()}
+	} else {
+		from := mm.GetFrom()
+		if len(from) == 0 {
+			from = []string{mm.GetClaim()}
+		}
+		for _, name := range from {
+			v, ok := claims[name]
+			if !ok {
+				continue
+			}
+			if s, ok := v.([]interface{}); ok {
+				isList = true
+				values = append(values, s...)
+			} else {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			return
+		}
+		if len(from) > 1 {
+			isList = true
+		}
+	}
+
+	if mm.GetSplit() != "" {
+		isList = true
+		values = split(values, mm.GetSplit())
+	}
+	if mm.GetLowercase() {
+		for i, v := range values {
+			if s, ok := v.(string); ok {
+				values[i] = strings.ToLower(s)
+			}
+		}
+	}
+
+	if mm.GetRename() {
+		for _, name := range mm.GetFrom() {
+			delete(claims, name)
+		}
+	}
+
+	if isList {
+		if values == nil {
+			values = []interface{}{}
+		}
+		claims[mm.GetClaim()] = values
+	} else if len(values) > 0 {
+		claims[mm.GetClaim()] = values[0]
+	}
+}
+
+func split(values []interface{}, sep string) []interface{} {
+	var split []interface{}
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			split = append(split, v)
+			continue
+		}
+		for _, part := range strings.Split(s, sep) {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
+
+func toSlice(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	case []string:
+		s := make([]interface{}, len(v))
+		for i := range v {
+			s[i] = v[i]
+		}
+		return s
+	}
+	return []interface{}{v}
+}
@@ -0,0 +1,105 @@
+package claimsmap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
+
+func TestMapper(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mappings []*identity.ClaimMapping
+		claims   string
+		expect   string
+	}{
+		{
+			"no mappings",
+			nil,
+			`{"sub": "U1", "exp": 1650000000123}`,
+			`{"sub": "U1", "exp": 1650000000123}`,
+		},
+		{
+			"rename",
+			[]*identity.ClaimMapping{{Claim: "email", From: []string{"upn"}, Rename: true}},
+			`{"upn": "alice@example.com"}`,
+			`{"email": "alice@example.com"}`,
+		},
+		{
+			"copy",
+			[]*identity.ClaimMapping{{Claim: "email", From: []string{"upn"}}},
+			`{"upn": "alice@example.com"}`,
+			`{"email": "alice@example.com", "upn": "alice@example.com"}`,
+		},
+		{
+			"combine",
+			[]*identity.ClaimMapping{{Claim: "groups", From: []string{"groups", "roles"}}},
+			`{"groups": ["a", "b"], "roles": "admin"}`,
+			`{"groups": ["a", "b", "admin"], "roles": "admin"}`,
+		},
+		{
+			"template",
+			[]*identity.ClaimMapping{{Claim: "name", Template: `{{.given_name}} {{upper .family_name}}`}},
+			`{"given_name": "Alice", "family_name": "Smith"}`,
+			`{"name": "Alice SMITH", "given_name": "Alice", "family_name": "Smith"}`,
+		},
+		{
+			"template missing claim",
+			[]*identity.ClaimMapping{{Claim: "name", Template: `{{.given_name}} {{.family_name}}`}},
+			`{"given_name": "Alice"}`,
+			`{"given_name": "Alice"}`,
+		},
+		{
+			"lowercase",
+			[]*identity.ClaimMapping{{Claim: "email", Lowercase: true}},
+			`{"email": "Alice@Example.com"}`,
+			`{"email": "alice@example.com"}`,
+		},
+		{
+			"split",
+			[]*identity.ClaimMapping{{Claim: "groups", Split: ",", Lowercase: true}},
+			`{"groups": "Admin, Users,"}`,
+			`{"groups": ["admin", "users"]}`,
+		},
+		{
+			"chained",
+			[]*identity.ClaimMapping{
+				{Claim: "email", From: []string{"mail"}, Rename: true},
+				{Claim: "email", Lowercase: true},
+				{Claim: "groups", Template: `{{join "," .roles}}`},
+			},
+			`{"mail": "Alice@example.com", "roles": ["a", "b"]}`,
+			`{"email": "alice@example.com", "groups": "a,b", "roles": ["a", "b"]}`,
+		},
+		{
+			"missing",
+			[]*identity.ClaimMapping{{Claim: "email", From: []string{"upn"}, Rename: true}},
+			`{"sub": "U1"}`,
+			`{"sub": "U1"}`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := New(tc.mappings)
+			require.NoError(t, err)
+
+			var v, expect map[string]interface{}
+			require.NoError(t, m.Unmarshal([]byte(tc.claims), &v))
+			require.NoError(t, json.Unmarshal([]byte(tc.expect), &expect))
+			assert.Equal(t, expect, v)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New([]*identity.ClaimMapping{{From: []string{"upn"}}})
+	assert.Error(t, err, "should require a claim")
+	_, err = New([]*identity.ClaimMapping{{Claim: "email", From: []string{"upn"}, Template: "{{.upn}}"}})
+	assert.Error(t, err, "should not allow from and template")
+	_, err = New([]*identity.ClaimMapping{{Claim: "email", Template: "{{.upn"}})
+	assert.Error(t, err, "should validate the template")
+}
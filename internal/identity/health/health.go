@@ -0,0 +1,259 @@
+// Package health checks whether identity providers are reachable, by probing their
+// discovery, JWKS and token endpoints.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/identity/ldap"
+	"github.com/pomerium/pomerium/internal/identity/oauth/github"
+	"github.com/pomerium/pomerium/internal/identity/saml"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
+
+// The endpoints which are checked.
+const (
+	EndpointDiscovery = "discovery"
+	EndpointJWKS      = "jwks"
+	EndpointToken     = "token"
+)
+
+const checkTimeout = 10 * time.Second
+
+// A Checker periodically checks the health of identity providers.
+type Checker struct {
+	client  *http.Client
+	updated chan struct{}
+
+	mu       sync.RWMutex
+	onCheck  func(ctx context.Context)
+	interval time.Duration
+	urls     map[string]string // identity provider id -> provider url
+	healthy  map[string]bool   // provider url -> healthy
+}
+
+// New creates a new Checker. Health checks are disabled until UpdateConfig is called
+// with a non-zero interval.
+func New() *Checker {
+	return &Checker{
+		client:  &http.Client{Timeout: checkTimeout},
+		updated: make(chan struct{}, 1),
+		urls:    map[string]string{},
+		healthy: map[string]bool{},
+	}
+}
+
+// UpdateConfig updates the interval and the identity providers which are checked. Identity
+// providers without OpenID Connect discovery, like SAML, LDAP and GitHub, aren't checked.
+func (c *Checker) UpdateConfig(interval time.Duration, idps []*identity.Provider) {
+	urls := map[string]string{}
+	for _, idp := range idps {
+		if !isCheckable(idp) {
+			continue
+		}
+		urls[idp.GetId()] = strings.TrimSuffix(idp.GetUrl(), "/")
+	}
+
+	c.mu.Lock()
+	c.interval = interval
+	c.urls = urls
+	c.mu.Unlock()
+
+	select {
+	case c.updated <- struct{}{}:
+	default:
+	}
+}
+
+// OnCheck sets a function which is called after the identity providers were checked.
+func (c *Checker) OnCheck(fn func(ctx context.Context)) {
+	c.mu.Lock()
+	c.onCheck = fn
+	c.mu.Unlock()
+}
+
+// IsHealthy returns whether the identity provider with the given id is healthy. Identity
+// providers which aren't checked, or haven't been checked yet, are considered healthy.
+func (c *Checker) IsHealthy(idpID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.interval <= 0 {
+		return true
+	}
+	providerURL, ok := c.urls[idpID]
+	if !ok {
+		return true
+	}
+	healthy, ok := c.healthy[providerURL]
+	return !ok || healthy
+}
+
+// Run checks the identity providers at the configured interval until the context is canceled.
+func (c *Checker) Run(ctx context.Context) error {
+	for {
+		c.mu.RLock()
+		interval := c.interval
+		c.mu.RUnlock()
+
+		// when health checks are disabled, wait for the config to change
+		var tick <-chan time.Time
+		var timer *time.Timer
+		if interval > 0 {
+			c.checkAll(ctx)
+			timer = time.NewTimer(interval)
+			tick = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-c.updated:
+		case <-tick:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Checker) checkAll(ctx context.Context) {
+	c.mu.RLock()
+	providerURLs := map[string]struct{}{}
+	for _, providerURL := range c.urls {
+		providerURLs[providerURL] = struct{}{}
+	}
+	c.mu.RUnlock()
+
+	healthy := make(map[string]bool, len(providerURLs))
+	for providerURL := range providerURLs {
+		healthy[providerURL] = c.check(ctx, providerURL)
+	}
+
+	c.mu.Lock()
+	c.healthy = healthy
+	onCheck := c.onCheck
+	c.mu.Unlock()
+
+	if onCheck != nil {
+		onCheck(ctx)
+	}
+}
+
+// check checks a single identity provider and returns whether all of its endpoints are healthy.
+func (c *Checker) check(ctx context.Context, providerURL string) bool {
+	ctx, clearTimeout := context.WithTimeout(ctx, checkTimeout)
+	defer clearTimeout()
+
+	results := map[string]error{}
+	var discovery struct {
+		JWKSURI       string `json:"jwks_uri"`
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	results[EndpointDiscovery] = c.get(ctx, providerURL+"/.well-known/openid-configuration", &discovery)
+	if err := results[EndpointDiscovery]; err != nil {
+		results[EndpointJWKS] = err
+		results[EndpointToken] = err
+	} else {
+		results[EndpointJWKS] = c.get(ctx, discovery.JWKSURI, nil)
+		results[EndpointToken] = c.postToken(ctx, discovery.TokenEndpoint)
+	}
+
+	healthy := true
+	for _, endpoint := range []string{EndpointDiscovery, EndpointJWKS, EndpointToken} {
+		err := results[endpoint]
+		if err != nil {
+			healthy = false
+			log.Warn(ctx).Err(err).
+				Str("idp_url", providerURL).
+				Str("endpoint", endpoint).
+				Msg("identity/health: identity provider endpoint is unhealthy")
+		}
+		metrics.RecordIdentityProviderHealth(ctx, providerURL, endpoint, err == nil)
+	}
+	return healthy
+}
+
+func (c *Checker) get(ctx context.Context, rawURL string, v interface{}) error {
+	if rawURL == "" {
+		return fmt.Errorf("missing url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+	if v == nil {
+		_, err = io.Copy(io.Discard, res.Body)
+		return err
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// postToken sends an empty token request. The identity provider should reject it, so any
+// response other than a server error means the token endpoint is healthy.
+func (c *Checker) postToken(ctx context.Context, rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("missing url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, strings.NewReader(url.Values{}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+	return nil
+}
+
+// CheckDiscovery fetches the OpenID Connect discovery document of an identity provider. It returns
+// false when the identity provider doesn't support discovery.
+func CheckDiscovery(ctx context.Context, idp *identity.Provider) (bool, error) {
+	if !isCheckable(idp) {
+		return false, nil
+	}
+	ctx, clearTimeout := context.WithTimeout(ctx, checkTimeout)
+	defer clearTimeout()
+
+	c := &Checker{client: &http.Client{Timeout: checkTimeout}}
+	return true, c.get(ctx, strings.TrimSuffix(idp.GetUrl(), "/")+"/.well-known/openid-configuration", nil)
+}
+
+func isCheckable(idp *identity.Provider) bool {
+	if idp.GetUrl() == "" {
+		return false
+	}
+	switch idp.GetType() {
+	case github.Name, ldap.Name, saml.Name:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
+
+func TestChecker(t *testing.T) {
+	newServer := func(tokenStatus int) *httptest.Server {
+		var srv *httptest.Server
+		mux := http.NewServeMux()
+		mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"jwks_uri": "` + srv.URL + `/jwks", "token_endpoint": "` + srv.URL + `/token"}`))
+		})
+		mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"keys": []}`))
+		})
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tokenStatus)
+		})
+		srv = httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	healthy := newServer(http.StatusBadRequest)
+	unhealthy := newServer(http.StatusServiceUnavailable)
+	unreachable := newServer(http.StatusOK)
+	unreachable.Close()
+
+	c := New()
+	c.UpdateConfig(time.Minute, []*identity.Provider{
+		{Id: "healthy", Type: "oidc", Url: healthy.URL},
+		{Id: "unhealthy", Type: "oidc", Url: unhealthy.URL},
+		{Id: "unreachable", Type: "oidc", Url: unreachable.URL + "/"},
+		{Id: "saml", Type: "saml", Url: unreachable.URL},
+	})
+	assert.True(t, c.IsHealthy("unhealthy"), "should be healthy before the first check")
+
+	checked := false
+	c.OnCheck(func(ctx context.Context) { checked = true })
+	c.checkAll(context.Background())
+	assert.True(t, checked, "should call the check function after checking")
+	assert.True(t, c.IsHealthy("healthy"))
+	assert.False(t, c.IsHealthy("unhealthy"))
+	assert.False(t, c.IsHealthy("unreachable"))
+	assert.True(t, c.IsHealthy("saml"), "should not check saml identity providers")
+	assert.True(t, c.IsHealthy("unknown"))
+
+	c.UpdateConfig(0, nil)
+	assert.True(t, c.IsHealthy("unhealthy"), "should be healthy when health checks are disabled")
+}
@@ -0,0 +1,210 @@
+// Package ldap implements an identity provider which authenticates users by binding to an
+// LDAP or Active Directory server with their credentials.
+//
+// Since LDAP is not a redirect based protocol, the sign in url is a login form served by the
+// authenticate service. The submitted credentials are passed to Authenticate as the code.
+package ldap
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"golang.org/x/oauth2"
+
+	"github.com/pomerium/pomerium/internal/identity/claimsmap"
+	"github.com/pomerium/pomerium/internal/identity/identity"
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/ldap"
+)
+
+// Name identifies the LDAP identity provider.
+const Name = ldap.Name
+
+const (
+	// SignInPath is the path on the authenticate service which serves the login form.
+	SignInPath = "/ldap/sign_in"
+
+	// since ldap doesn't have sessions, the user is looked up again periodically
+	refreshDeadline = time.Minute * 60
+)
+
+// ErrInvalidCredentials is returned when the username or password is incorrect.
+var ErrInvalidCredentials = errors.New("ldap: invalid username or password")
+
+// Provider is an LDAP identity provider.
+type Provider struct {
+	url            string
+	signInURL      *url.URL
+	serviceAccount *ldap.ServiceAccount
+	claimsMapper   *claimsmap.Mapper
+}
+
+// New creates a new LDAP identity provider. The ProviderURL is the ldap:// or ldaps:// url of
+// the LDAP server and the ServiceAccount configures how users are searched.
+func New(ctx context.Context, o *oauth.Options) (*Provider, error) {
+	if o.ProviderURL == "" {
+		return nil, oidc.ErrMissingProviderURL
+	}
+	if o.RedirectURL == nil {
+		return nil, fmt.Errorf("ldap: missing redirect url")
+	}
+	serviceAccount, err := ldap.ParseServiceAccount(o.ServiceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid service account: %w", err)
+	}
+	claimsMapper, err := claimsmap.New(o.ClaimsMapping)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid claims mapping: %w", err)
+	}
+
+	return &Provider{
+		url:            o.ProviderURL,
+		signInURL:      o.RedirectURL.ResolveReference(&url.URL{Path: SignInPath}),
+		serviceAccount: serviceAccount,
+		claimsMapper:   claimsMapper,
+	}, nil
+}
+
+// EncodeCredentials encodes a username and password as a code which can be passed to Authenticate.
+func EncodeCredentials(username, password string) string {
+	bs, _ := json.Marshal(credentials{Username: username, Password: password})
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+// GetSignInURL returns the url of the login form.
+func (p *Provider) GetSignInURL(state string) (string, error) {
+	u := *p.signInURL
+	u.RawQuery = url.Values{"state": {state}}.Encode()
+	return u.String(), nil
+}
+
+// Authenticate verifies the credentials encoded in the code by binding to the LDAP server as the user.
+func (p *Provider) Authenticate(ctx context.Context, code string, v identity.State) (*oauth2.Token, error) {
+	creds, err := decodeCredentials(code)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := p.serviceAccount.Connect(ctx, p.url)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := p.serviceAccount.FindUser(ctx, conn, p.serviceAccount.UserLoginAttribute, creds.Username)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if err := conn.Bind(ctx, entry.DN, creds.Password); ldap.IsInvalidCredentials(err) {
+		return nil, ErrInvalidCredentials
+	} else if err != nil {
+		return nil, err
+	}
+
+	t := &oauth2.Token{
+		AccessToken: entry.GetAttributeValue(p.serviceAccount.UserIDAttribute),
+		Expiry:      time.Now().Add(refreshDeadline),
+	}
+	if t.AccessToken == "" {
+		return nil, fmt.Errorf("ldap: user is missing the %s attribute", p.serviceAccount.UserIDAttribute)
+	}
+	if err := p.setClaims(entry, v); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Refresh verifies that the user still exists in the directory and extends the session.
+func (p *Provider) Refresh(ctx context.Context, t *oauth2.Token, v identity.State) (*oauth2.Token, error) {
+	if t == nil {
+		return nil, fmt.Errorf("ldap: missing token")
+	}
+	if err := p.UpdateUserInfo(ctx, t, v); err != nil {
+		return nil, err
+	}
+	t.Expiry = time.Now().Add(refreshDeadline)
+	return t, nil
+}
+
+// UpdateUserInfo looks up the user in the directory using the service account.
+func (p *Provider) UpdateUserInfo(ctx context.Context, t *oauth2.Token, v interface{}) error {
+	conn, err := p.serviceAccount.Connect(ctx, p.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	entry, err := p.serviceAccount.FindUser(ctx, conn, p.serviceAccount.UserIDAttribute, t.AccessToken)
+	if err != nil {
+		return fmt.Errorf("ldap: could not retrieve user info: %w", err)
+	}
+	return p.setClaims(entry, v)
+}
+
+// Revoke is not implemented by LDAP.
+func (p *Provider) Revoke(ctx context.Context, token *oauth2.Token) error {
+	return oidc.ErrRevokeNotImplemented
+}
+
+// LogOut is not implemented by LDAP.
+func (p *Provider) LogOut() (*url.URL, error) {
+	return nil, oidc.ErrSignoutNotImplemented
+}
+
+// Name returns the provider name.
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) setClaims(entry *ldap.Entry, v interface{}) error {
+	var out struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email,omitempty"`
+		Name    string `json:"name,omitempty"`
+		User    string `json:"user"`
+		// needs to be set manually
+		Expiry    *jwt.NumericDate `json:"exp,omitempty"`
+		NotBefore *jwt.NumericDate `json:"nbf,omitempty"`
+		IssuedAt  *jwt.NumericDate `json:"iat,omitempty"`
+	}
+	out.Subject = entry.GetAttributeValue(p.serviceAccount.UserIDAttribute)
+	out.Email = entry.GetAttributeValue(p.serviceAccount.UserEmailAttribute)
+	out.Name = entry.GetAttributeValue(p.serviceAccount.UserNameAttribute)
+	out.User = out.Subject
+	out.Expiry = jwt.NewNumericDate(time.Now().Add(refreshDeadline))
+	out.NotBefore = jwt.NewNumericDate(time.Now())
+	out.IssuedAt = jwt.NewNumericDate(time.Now())
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return p.claimsMapper.Unmarshal(b, v)
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func decodeCredentials(code string) (*credentials, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(code)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid code: %w", err)
+	}
+	var creds credentials
+	if err := json.Unmarshal(bs, &creds); err != nil {
+		return nil, fmt.Errorf("ldap: invalid code: %w", err)
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	return &creds, nil
+}
@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/internal/ldap"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+type testClaims map[string]interface{}
+
+func (claims *testClaims) SetRawIDToken(string) {}
+
+func newTestProvider(t *testing.T) (*Provider, *testutil.LDAPServer) {
+	t.Helper()
+
+	srv, err := testutil.NewLDAPServer([]*ldap.Entry{{
+		DN: "cn=John Doe,ou=People,dc=example,dc=com",
+		Attributes: []*ldap.EntryAttribute{
+			{Name: "objectClass", Values: []string{"person"}},
+			{Name: "sAMAccountName", Values: []string{"jdoe"}},
+			{Name: "objectGUID", Values: []string{"a1b2c3"}},
+			{Name: "cn", Values: []string{"John Doe"}},
+			{Name: "mail", Values: []string{"jdoe@example.com"}},
+		},
+	}}, map[string]string{
+		"cn=pomerium,dc=example,dc=com":           "secret",
+		"cn=John Doe,ou=People,dc=example,dc=com": "hunter2",
+	})
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+
+	p, err := New(context.Background(), &oauth.Options{
+		ProviderURL: srv.URL(),
+		RedirectURL: &url.URL{Scheme: "https", Host: "authenticate.example.com", Path: "/oauth2/callback"},
+		ServiceAccount: `{
+			"bind_dn": "cn=pomerium,dc=example,dc=com",
+			"bind_password": "secret",
+			"base_dn": "dc=example,dc=com",
+			"user_id_attribute": "objectGUID",
+			"user_login_attribute": "sAMAccountName"
+		}`,
+	})
+	require.NoError(t, err)
+	return p, srv
+}
+
+func TestProvider(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	p, _ := newTestProvider(t)
+
+	t.Run("sign in url", func(t *testing.T) {
+		signInURL, err := p.GetSignInURL("STATE")
+		require.NoError(t, err)
+		assert.Equal(t, "https://authenticate.example.com/ldap/sign_in?state=STATE", signInURL)
+	})
+	t.Run("authenticate", func(t *testing.T) {
+		var claims testClaims
+		token, err := p.Authenticate(ctx, EncodeCredentials("jdoe", "hunter2"), &claims)
+		require.NoError(t, err)
+		assert.Equal(t, "a1b2c3", token.AccessToken)
+		assert.True(t, token.Valid())
+		assert.Equal(t, "a1b2c3", claims["sub"])
+		assert.Equal(t, "jdoe@example.com", claims["email"])
+		assert.Equal(t, "John Doe", claims["name"])
+
+		token.Expiry = time.Now().Add(-time.Minute)
+		token, err = p.Refresh(ctx, token, &claims)
+		require.NoError(t, err)
+		assert.True(t, token.Valid())
+	})
+	t.Run("invalid password", func(t *testing.T) {
+		var claims testClaims
+		_, err := p.Authenticate(ctx, EncodeCredentials("jdoe", "wrong"), &claims)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+	t.Run("empty password", func(t *testing.T) {
+		var claims testClaims
+		_, err := p.Authenticate(ctx, EncodeCredentials("jdoe", ""), &claims)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		var claims testClaims
+		_, err := p.Authenticate(ctx, EncodeCredentials("nobody", "hunter2"), &claims)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+	t.Run("filter injection", func(t *testing.T) {
+		var claims testClaims
+		_, err := p.Authenticate(ctx, EncodeCredentials("*", "hunter2"), &claims)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+	t.Run("refresh deleted user", func(t *testing.T) {
+		var claims testClaims
+		_, err := p.Refresh(ctx, &oauth2.Token{AccessToken: "deleted"}, &claims)
+		assert.Error(t, err)
+	})
+}
@@ -17,12 +17,15 @@ var (
 
 type config struct {
 	authenticator                 Authenticator
+	authenticators                map[string]Authenticator
 	directory                     directory.Provider
 	dataBrokerClient              databroker.DataBrokerServiceClient
 	groupRefreshInterval          time.Duration
 	groupRefreshTimeout           time.Duration
 	sessionRefreshGracePeriod     time.Duration
 	sessionRefreshCoolOffDuration time.Duration
+	sessionIdleTimeout            time.Duration
+	identityProviderHealth        IdentityProviderHealth
 	now                           func() time.Time
 }
 
@@ -49,6 +52,14 @@ func WithAuthenticator(authenticator Authenticator) Option {
 	}
 }
 
+// WithIdentityProviderAuthenticators sets the authenticators, keyed by identity provider id,
+// used for sessions created with identity providers other than the default one.
+func WithIdentityProviderAuthenticators(authenticators map[string]Authenticator) Option {
+	return func(cfg *config) {
+		cfg.authenticators = authenticators
+	}
+}
+
 // WithDirectoryProvider sets the directory provider in the config.
 func WithDirectoryProvider(directoryProvider directory.Provider) Option {
 	return func(cfg *config) {
@@ -91,6 +102,22 @@ func WithSessionRefreshCoolOffDuration(dur time.Duration) Option {
 	}
 }
 
+// WithSessionIdleTimeout sets the amount of time sessions may go unused before they're deleted.
+// Zero disables the idle timeout.
+func WithSessionIdleTimeout(dur time.Duration) Option {
+	return func(cfg *config) {
+		cfg.sessionIdleTimeout = dur
+	}
+}
+
+// WithIdentityProviderGraceMode keeps sessions which can't be refreshed because their identity
+// provider is unhealthy, until they expire. A nil health disables grace mode.
+func WithIdentityProviderGraceMode(health IdentityProviderHealth) Option {
+	return func(cfg *config) {
+		cfg.identityProviderHealth = health
+	}
+}
+
 // WithNow customizes the time.Now function used by the manager.
 func WithNow(now func() time.Time) Option {
 	return func(cfg *config) {
@@ -53,11 +53,23 @@ func (u *User) UnmarshalJSON(data []byte) error {
 // A Session is a session managed by the Manager.
 type Session struct {
 	*session.Session
-	lastRefresh time.Time
+	// recordVersion is the databroker record version of the session.
+	recordVersion uint64
+	lastRefresh   time.Time
 	// gracePeriod is the amount of time before expiration to attempt a refresh.
 	gracePeriod time.Duration
 	// coolOffDuration is the amount of time to wait before attempting another refresh.
 	coolOffDuration time.Duration
+	// idleTimeout is the amount of time the session may go unused before it's deleted.
+	idleTimeout time.Duration
+}
+
+// IsIdle returns true if the session hasn't been used within the idle timeout.
+func (s Session) IsIdle(now time.Time) bool {
+	if s.idleTimeout <= 0 || !s.GetAccessedAt().IsValid() {
+		return false
+	}
+	return now.Sub(s.GetAccessedAt().AsTime()) > s.idleTimeout
 }
 
 // NextRefresh returns the next time the session needs to be refreshed.
@@ -83,6 +95,13 @@ func (s Session) NextRefresh() time.Time {
 		}
 	}
 
+	if s.idleTimeout > 0 && s.GetAccessedAt().IsValid() {
+		expiry := s.GetAccessedAt().AsTime().Add(s.idleTimeout)
+		if tm.IsZero() || expiry.Before(tm) {
+			tm = expiry
+		}
+	}
+
 	// don't refresh any quicker than the cool-off duration
 	min := s.lastRefresh.Add(s.coolOffDuration)
 	if tm.Before(min) {
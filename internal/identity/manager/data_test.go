@@ -49,6 +49,26 @@ func TestSession_NextRefresh(t *testing.T) {
 	tm3 := time.Date(2020, 6, 5, 12, 15, 0, 0, time.UTC)
 	s.ExpiresAt = timestamppb.New(tm3)
 	assert.Equal(t, tm3, s.NextRefresh())
+
+	tm4 := time.Date(2020, 6, 5, 12, 5, 0, 0, time.UTC)
+	s.AccessedAt = timestamppb.New(tm4.Add(-time.Minute * 5))
+	s.idleTimeout = time.Minute * 5
+	assert.Equal(t, tm4, s.NextRefresh())
+}
+
+func TestSession_IsIdle(t *testing.T) {
+	tm := time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC)
+	s := Session{
+		Session: &session.Session{AccessedAt: timestamppb.New(tm)},
+	}
+	assert.False(t, s.IsIdle(tm.Add(time.Hour)), "should not be idle without an idle timeout")
+
+	s.idleTimeout = time.Minute * 30
+	assert.False(t, s.IsIdle(tm.Add(time.Minute*10)))
+	assert.True(t, s.IsIdle(tm.Add(time.Minute*31)))
+
+	s.AccessedAt = nil
+	assert.False(t, s.IsIdle(tm.Add(time.Hour)), "should not be idle without an access time")
 }
 
 func TestSession_UnmarshalJSON(t *testing.T) {
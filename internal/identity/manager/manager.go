@@ -3,8 +3,10 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -13,6 +15,8 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -39,6 +43,11 @@ type Authenticator interface {
 	UpdateUserInfo(context.Context, *oauth2.Token, interface{}) error
 }
 
+// IdentityProviderHealth reports whether identity providers are healthy.
+type IdentityProviderHealth interface {
+	IsHealthy(idpID string) bool
+}
+
 type (
 	updateRecordsMessage struct {
 		records []*databroker.Record
@@ -395,6 +404,15 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 		return
 	}
 
+	if s.IsIdle(time.Now()) {
+		log.Info(ctx).
+			Str("user_id", userID).
+			Str("session_id", sessionID).
+			Msg("deleting idle session")
+		mgr.deleteSession(ctx, s.Session)
+		return
+	}
+
 	if s.Session == nil || s.Session.OauthToken == nil {
 		log.Warn(ctx).
 			Str("user_id", userID).
@@ -403,13 +421,31 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 		return
 	}
 
-	newToken, err := mgr.cfg.Load().authenticator.Refresh(ctx, FromOAuthToken(s.OauthToken), &s)
+	authenticator := mgr.getAuthenticator(s.Session)
+	oldToken := s.OauthToken
+	newToken, err := authenticator.Refresh(ctx, FromOAuthToken(s.OauthToken), &s)
 	metrics.RecordIdentityManagerSessionRefresh(ctx, err)
-	if isTemporaryError(err) {
+	if isInvalidGrantError(err) {
+		// the refresh token was revoked, or an already rotated refresh token was used
+		log.Warn(ctx).Err(err).
+			Str("user_id", s.GetUserId()).
+			Str("session_id", s.GetId()).
+			Msg("refresh token is no longer valid, deleting session")
+		mgr.deleteSession(ctx, s.Session)
+		return
+	} else if isTemporaryError(err) {
 		log.Error(ctx).Err(err).
 			Str("user_id", s.GetUserId()).
 			Str("session_id", s.GetId()).
-			Msg("failed to refresh oauth2 token")
+			Msg("failed to refresh oauth2 token, will retry")
+		mgr.rescheduleSession(s)
+		return
+	} else if err != nil && mgr.isGracePeriod(s.Session) {
+		log.Error(ctx).Err(err).
+			Str("user_id", s.GetUserId()).
+			Str("session_id", s.GetId()).
+			Msg("failed to refresh oauth2 token, identity provider is unhealthy, will retry")
+		mgr.rescheduleSession(s)
 		return
 	} else if err != nil {
 		log.Error(ctx).Err(err).
@@ -421,7 +457,7 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 	}
 	s.OauthToken = ToOAuthToken(newToken)
 
-	err = mgr.cfg.Load().authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &s)
+	err = authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &s)
 	metrics.RecordIdentityManagerUserRefresh(ctx, err)
 	if isTemporaryError(err) {
 		log.Error(ctx).Err(err).
@@ -429,6 +465,13 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 			Str("session_id", s.GetId()).
 			Msg("failed to update user info")
 		return
+	} else if err != nil && mgr.isGracePeriod(s.Session) {
+		log.Error(ctx).Err(err).
+			Str("user_id", s.GetUserId()).
+			Str("session_id", s.GetId()).
+			Msg("failed to update user info, identity provider is unhealthy, will retry")
+		mgr.rescheduleSession(s)
+		return
 	} else if err != nil {
 		log.Error(ctx).Err(err).
 			Str("user_id", s.GetUserId()).
@@ -438,8 +481,25 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 		return
 	}
 
-	res, err := session.Put(ctx, mgr.cfg.Load().dataBrokerClient, s.Session)
-	if err != nil {
+	// identity providers may rotate the refresh token, so the session is only saved if it
+	// hasn't been changed since it was loaded. Otherwise the newer session would be overwritten.
+	res, err := session.CompareAndPut(ctx, mgr.cfg.Load().dataBrokerClient, s.Session, s.recordVersion)
+	if status.Code(err) == codes.FailedPrecondition {
+		// other fields of the session, like the access time, may have changed, in which case the
+		// refreshed session is applied to the latest session
+		var latest *session.Session
+		res, latest, err = mgr.applyRefreshedSession(ctx, s.Session, oldToken)
+		if err == nil {
+			s.Session = latest
+		}
+	}
+	if errors.Is(err, errSessionTokenChanged) {
+		log.Warn(ctx).
+			Str("user_id", s.GetUserId()).
+			Str("session_id", s.GetId()).
+			Msg("session was updated during refresh, discarding refreshed session")
+		return
+	} else if err != nil {
 		log.Error(ctx).Err(err).
 			Str("user_id", s.GetUserId()).
 			Str("session_id", s.GetId()).
@@ -450,6 +510,53 @@ func (mgr *Manager) refreshSession(ctx context.Context, userID, sessionID string
 	mgr.onUpdateSession(ctx, res.GetRecord(), s.Session)
 }
 
+var errSessionTokenChanged = errors.New("session oauth2 token was changed")
+
+// applyRefreshedSession applies the refreshed token and claims to the latest session in the
+// databroker, unless the session's token was changed since it was refreshed.
+func (mgr *Manager) applyRefreshedSession(
+	ctx context.Context,
+	refreshed *session.Session,
+	oldToken *session.OAuthToken,
+) (*databroker.PutResponse, *session.Session, error) {
+	var latest *session.Session
+	res, err := session.Update(ctx, mgr.cfg.Load().dataBrokerClient, refreshed.GetId(), func(s *session.Session) error {
+		if !proto.Equal(s.GetOauthToken(), oldToken) {
+			return errSessionTokenChanged
+		}
+		s.OauthToken = refreshed.GetOauthToken()
+		s.IdToken = refreshed.GetIdToken()
+		s.Claims = refreshed.GetClaims()
+		latest = s
+		return nil
+	})
+	return res, latest, err
+}
+
+// rescheduleSession schedules another refresh of the session after the cool-off duration.
+func (mgr *Manager) rescheduleSession(s Session) {
+	s.lastRefresh = mgr.cfg.Load().now()
+	mgr.sessions.ReplaceOrInsert(s)
+	mgr.sessionScheduler.Add(s.NextRefresh(), toSessionSchedulerKey(s.GetUserId(), s.GetId()))
+}
+
+// isGracePeriod returns whether the session should be kept, even though it couldn't be
+// refreshed, because grace mode is enabled and its identity provider is unhealthy. The session
+// is still deleted once it expires.
+func (mgr *Manager) isGracePeriod(s *session.Session) bool {
+	health := mgr.cfg.Load().identityProviderHealth
+	return health != nil && !health.IsHealthy(s.GetIdentityProviderId())
+}
+
+// getAuthenticator returns the authenticator for the identity provider the session was created with.
+func (mgr *Manager) getAuthenticator(s *session.Session) Authenticator {
+	cfg := mgr.cfg.Load()
+	if authenticator, ok := cfg.authenticators[s.GetIdentityProviderId()]; ok {
+		return authenticator
+	}
+	return cfg.authenticator
+}
+
 func (mgr *Manager) refreshUser(ctx context.Context, userID string) {
 	log.Info(ctx).
 		Str("user_id", userID).
@@ -473,7 +580,7 @@ func (mgr *Manager) refreshUser(ctx context.Context, userID string) {
 			continue
 		}
 
-		err := mgr.cfg.Load().authenticator.UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &u)
+		err := mgr.getAuthenticator(s.Session).UpdateUserInfo(ctx, FromOAuthToken(s.OauthToken), &u)
 		metrics.RecordIdentityManagerUserRefresh(ctx, err)
 		if isTemporaryError(err) {
 			log.Error(ctx).Err(err).
@@ -555,7 +662,9 @@ func (mgr *Manager) onUpdateSession(_ context.Context, record *databroker.Record
 	s.lastRefresh = time.Now()
 	s.gracePeriod = mgr.cfg.Load().sessionRefreshGracePeriod
 	s.coolOffDuration = mgr.cfg.Load().sessionRefreshCoolOffDuration
+	s.idleTimeout = mgr.cfg.Load().sessionIdleTimeout
 	s.Session = session
+	s.recordVersion = record.GetVersion()
 	mgr.sessions.ReplaceOrInsert(s)
 	mgr.sessionScheduler.Add(s.NextRefresh(), toSessionSchedulerKey(session.GetUserId(), session.GetId()))
 }
@@ -611,9 +720,34 @@ func isTemporaryError(err error) bool {
 	if e, ok := err.(interface{ Temporary() bool }); ok && e.Temporary() {
 		return true
 	}
+	// identity provider server errors are usually temporary
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil && retrieveErr.Response.StatusCode >= 500 {
+		return true
+	}
 	return false
 }
 
+// isInvalidGrantError returns true if the identity provider rejected the refresh token.
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+func isInvalidGrantError(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+
+	// most identity providers return json, but some return form values
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(retrieveErr.Body, &body) != nil {
+		vs, _ := url.ParseQuery(string(retrieveErr.Body))
+		body.Error = vs.Get("error")
+	}
+	return body.Error == "invalid_grant"
+}
+
 func minDuration(d1 time.Duration, ds ...time.Duration) time.Duration {
 	min := d1
 	for _, d := range ds {
@@ -3,12 +3,20 @@ package manager
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
 	"github.com/pomerium/pomerium/internal/directory"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/session"
@@ -99,6 +107,81 @@ func TestManager_refreshDirectoryUserGroups(t *testing.T) {
 	})
 }
 
+func TestManager_applyRefreshedSession(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	li := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(srv, internal_databroker.New())
+	go func() { _ = srv.Serve(li) }()
+	defer srv.Stop()
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return li.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := databroker.NewDataBrokerServiceClient(cc)
+
+	oldToken := &session.OAuthToken{RefreshToken: "refresh-token-1"}
+	_, err = session.Put(ctx, client, &session.Session{
+		Id:         "session1",
+		OauthToken: oldToken,
+		AccessedAt: timestamppb.Now(),
+	})
+	require.NoError(t, err)
+
+	mgr := New(WithDataBrokerClient(client))
+	newToken := &session.OAuthToken{RefreshToken: "refresh-token-2"}
+	_, latest, err := mgr.applyRefreshedSession(ctx, &session.Session{Id: "session1", OauthToken: newToken}, oldToken)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(newToken, latest.GetOauthToken()))
+	assert.True(t, latest.GetAccessedAt().IsValid(), "should keep the fields changed concurrently")
+
+	stored, err := session.Get(ctx, client, "session1")
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token-2", stored.GetOauthToken().GetRefreshToken())
+
+	_, _, err = mgr.applyRefreshedSession(ctx, &session.Session{Id: "session1", OauthToken: oldToken}, oldToken)
+	assert.ErrorIs(t, err, errSessionTokenChanged, "should not overwrite a token refreshed concurrently")
+}
+
+type mockIdentityProviderHealth map[string]bool
+
+func (mock mockIdentityProviderHealth) IsHealthy(idpID string) bool {
+	return mock[idpID]
+}
+
+func TestManager_isGracePeriod(t *testing.T) {
+	mgr := New()
+	assert.False(t, mgr.isGracePeriod(&session.Session{IdentityProviderId: "idp1"}),
+		"should not keep sessions when grace mode is disabled")
+
+	mgr.UpdateConfig(WithIdentityProviderGraceMode(mockIdentityProviderHealth{"idp1": true}))
+	assert.False(t, mgr.isGracePeriod(&session.Session{IdentityProviderId: "idp1"}))
+	assert.True(t, mgr.isGracePeriod(&session.Session{IdentityProviderId: "idp2"}))
+}
+
+func TestIsInvalidGrantError(t *testing.T) {
+	mkErr := func(statusCode int, body string) error {
+		return fmt.Errorf("identity/oidc: refresh failed: %w", &oauth2.RetrieveError{
+			Response: &http.Response{StatusCode: statusCode},
+			Body:     []byte(body),
+		})
+	}
+
+	assert.True(t, isInvalidGrantError(mkErr(400, `{"error":"invalid_grant","error_description":"token reused"}`)))
+	assert.True(t, isInvalidGrantError(mkErr(400, `error=invalid_grant`)))
+	assert.False(t, isInvalidGrantError(mkErr(400, `{"error":"invalid_client"}`)))
+	assert.False(t, isInvalidGrantError(fmt.Errorf("invalid_grant")))
+
+	assert.True(t, isTemporaryError(mkErr(503, `unavailable`)))
+	assert.False(t, isTemporaryError(mkErr(400, `{"error":"invalid_grant"}`)))
+}
+
 func mkRecord(msg recordable) *databroker.Record {
 	any := protoutil.NewAny(msg)
 	return &databroker.Record{
@@ -17,6 +17,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/claimsmap"
 	"github.com/pomerium/pomerium/internal/identity/identity"
 	"github.com/pomerium/pomerium/internal/identity/oauth"
 	"github.com/pomerium/pomerium/internal/identity/oidc"
@@ -53,11 +54,17 @@ type Provider struct {
 
 	userEndpoint  string
 	emailEndpoint string
+	claimsMapper  *claimsmap.Mapper
 }
 
 // New instantiates an OAuth2 provider for Github.
 func New(ctx context.Context, o *oauth.Options) (*Provider, error) {
-	p := Provider{}
+	claimsMapper, err := claimsmap.New(o.ClaimsMapping)
+	if err != nil {
+		return nil, fmt.Errorf("github: invalid claims mapping: %w", err)
+	}
+
+	p := Provider{claimsMapper: claimsMapper}
 	if o.ProviderURL == "" {
 		o.ProviderURL = defaultProviderURL
 	}
@@ -110,17 +117,23 @@ func (p *Provider) Authenticate(ctx context.Context, code string, v identity.Sta
 //
 // https://developer.github.com/v3/users/#get-the-authenticated-user
 func (p *Provider) UpdateUserInfo(ctx context.Context, t *oauth2.Token, v interface{}) error {
-	err := p.userInfo(ctx, t, v)
+	// the claims are collected first, so the claims mapping can use all of them
+	claims := map[string]json.RawMessage{}
+	err := p.userInfo(ctx, t, &claims)
 	if err != nil {
 		return fmt.Errorf("github: could not retrieve user info %w", err)
 	}
 
-	err = p.userEmail(ctx, t, v)
+	err = p.userEmail(ctx, t, &claims)
 	if err != nil {
 		return fmt.Errorf("github: could not retrieve user email %w", err)
 	}
 
-	return nil
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+	return p.claimsMapper.Unmarshal(b, v)
 }
 
 // Refresh is a no-op for github, because github sessions never expire.
@@ -3,7 +3,11 @@
 // authorization with Bearer JWT.
 package oauth
 
-import "net/url"
+import (
+	"net/url"
+
+	"github.com/pomerium/pomerium/pkg/grpc/identity"
+)
 
 // Options contains the fields required for an OAuth 2.0 (inc. OIDC) auth flow.
 //
@@ -33,4 +37,14 @@ type Options struct {
 	// AuthCodeOptions specifies additional key value pairs query params to add
 	// to the request flow signin url.
 	AuthCodeOptions map[string]string
+
+	// SAMLCertificate and SAMLKey are the base64 encoded PEM certificate and
+	// private key of the SAML service provider.
+	SAMLCertificate string
+	SAMLKey         string
+	// SAMLAttributeMapping maps claim names to SAML assertion attribute names.
+	SAMLAttributeMapping map[string]string
+
+	// ClaimsMapping transforms the claims returned by the identity provider.
+	ClaimsMapping []*identity.ClaimMapping
 }
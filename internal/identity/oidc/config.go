@@ -9,6 +9,8 @@ type config struct {
 	getProvider    func() (*oidc.Provider, error)
 	getVerifier    func(provider *oidc.Provider) *oidc.IDTokenVerifier
 	getOauthConfig func(provider *oidc.Provider) *oauth2.Config
+
+	getLogoutTokenVerifier func(provider *oidc.Provider) *oidc.IDTokenVerifier
 }
 
 // An Option customizes the config.
@@ -42,3 +44,10 @@ func WithGetVerifier(f func(*oidc.Provider) *oidc.IDTokenVerifier) Option {
 		cfg.getVerifier = f
 	}
 }
+
+// WithGetLogoutTokenVerifier sets the getLogoutTokenVerifier function in the config.
+func WithGetLogoutTokenVerifier(f func(*oidc.Provider) *oidc.IDTokenVerifier) Option {
+	return func(cfg *config) {
+		cfg.getLogoutTokenVerifier = f
+	}
+}
@@ -26,3 +26,7 @@ var ErrMissingRefreshToken = errors.New("identity/oidc: missing refresh token")
 
 // ErrMissingAccessToken is returned when no access token was found.
 var ErrMissingAccessToken = errors.New("identity/oidc: missing access token")
+
+// ErrInvalidLogoutToken is returned when a back-channel logout token is invalid.
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#Validation
+var ErrInvalidLogoutToken = errors.New("identity/oidc: invalid logout token")
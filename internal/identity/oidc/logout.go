@@ -0,0 +1,74 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// BackChannelLogoutPath is the path on the authenticate service which receives logout tokens.
+	BackChannelLogoutPath = "/oauth2/backchannel_logout"
+
+	// BackChannelLogoutEvent is the event which must be present in a logout token.
+	BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	// logout tokens issued too long ago are rejected
+	maxLogoutTokenAge = time.Minute * 5
+)
+
+// A LogoutToken is a verified back-channel logout token. At least one of the Subject
+// or SessionID is set.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+type LogoutToken struct {
+	Issuer    string
+	Subject   string
+	SessionID string
+}
+
+// VerifyLogoutToken verifies a logout token sent by the identity provider to the back-channel
+// logout endpoint.
+//
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#Validation
+func (p *Provider) VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (*LogoutToken, error) {
+	pp, err := p.GetProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.cfg.getLogoutTokenVerifier(pp).Verify(ctx, rawLogoutToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+
+	var claims struct {
+		SessionID string                     `json:"sid"`
+		Events    map[string]json.RawMessage `json:"events"`
+		Nonce     *string                    `json:"nonce"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+
+	now := time.Now()
+	switch {
+	case token.Subject == "" && claims.SessionID == "":
+		return nil, fmt.Errorf("%w: missing sub and sid", ErrInvalidLogoutToken)
+	case claims.Events[BackChannelLogoutEvent] == nil:
+		return nil, fmt.Errorf("%w: missing logout event", ErrInvalidLogoutToken)
+	case claims.Nonce != nil:
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrInvalidLogoutToken)
+	case token.IssuedAt.IsZero() || now.Sub(token.IssuedAt) > maxLogoutTokenAge:
+		return nil, fmt.Errorf("%w: token is too old", ErrInvalidLogoutToken)
+	case !token.Expiry.IsZero() && now.After(token.Expiry):
+		return nil, fmt.Errorf("%w: token is expired", ErrInvalidLogoutToken)
+	}
+
+	return &LogoutToken{
+		Issuer:    token.Issuer,
+		Subject:   token.Subject,
+		SessionID: claims.SessionID,
+	}, nil
+}
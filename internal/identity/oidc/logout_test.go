@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	go_oidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLogoutToken(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, nil)
+	require.NoError(t, err)
+
+	p := &Provider{cfg: getConfig(
+		WithGetProvider(func() (*go_oidc.Provider, error) {
+			return new(go_oidc.Provider), nil
+		}),
+		WithGetLogoutTokenVerifier(func(*go_oidc.Provider) *go_oidc.IDTokenVerifier {
+			return go_oidc.NewVerifier("https://idp.example.com", &go_oidc.StaticKeySet{
+				PublicKeys: []crypto.PublicKey{key.Public()},
+			}, &go_oidc.Config{
+				ClientID:             "CLIENT_ID",
+				SkipExpiryCheck:      true,
+				SupportedSigningAlgs: []string{go_oidc.ES256},
+			})
+		}),
+	)}
+
+	sign := func(claims map[string]interface{}) string {
+		rawJWT, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		require.NoError(t, err)
+		return rawJWT
+	}
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":    "https://idp.example.com",
+			"aud":    "CLIENT_ID",
+			"iat":    time.Now().Unix(),
+			"jti":    "JTI",
+			"sub":    "SUBJECT",
+			"sid":    "SESSION_ID",
+			"events": map[string]interface{}{BackChannelLogoutEvent: map[string]interface{}{}},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		token, err := p.VerifyLogoutToken(ctx, sign(validClaims()))
+		assert.NoError(t, err)
+		assert.Equal(t, &LogoutToken{
+			Issuer:    "https://idp.example.com",
+			Subject:   "SUBJECT",
+			SessionID: "SESSION_ID",
+		}, token)
+	})
+	for _, tc := range []struct {
+		name   string
+		update func(claims map[string]interface{})
+	}{
+		{"wrong audience", func(claims map[string]interface{}) { claims["aud"] = "OTHER" }},
+		{"wrong issuer", func(claims map[string]interface{}) { claims["iss"] = "https://other.example.com" }},
+		{"missing sub and sid", func(claims map[string]interface{}) { delete(claims, "sub"); delete(claims, "sid") }},
+		{"missing event", func(claims map[string]interface{}) { delete(claims, "events") }},
+		{"nonce", func(claims map[string]interface{}) { claims["nonce"] = "NONCE" }},
+		{"old", func(claims map[string]interface{}) { claims["iat"] = time.Now().Add(-time.Hour).Unix() }},
+		{"expired", func(claims map[string]interface{}) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validClaims()
+			tc.update(claims)
+			_, err := p.VerifyLogoutToken(ctx, sign(claims))
+			assert.ErrorIs(t, err, ErrInvalidLogoutToken)
+		})
+	}
+}
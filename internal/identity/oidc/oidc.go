@@ -16,6 +16,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/identity/claimsmap"
 	"github.com/pomerium/pomerium/internal/identity/identity"
 	"github.com/pomerium/pomerium/internal/identity/oauth"
 	"github.com/pomerium/pomerium/internal/urlutil"
@@ -48,6 +49,8 @@ type Provider struct {
 	// to the request flow signin url.
 	AuthCodeOptions map[string]string
 
+	claimsMapper *claimsmap.Mapper
+
 	mu       sync.Mutex
 	provider *go_oidc.Provider
 }
@@ -58,7 +61,13 @@ func New(ctx context.Context, o *oauth.Options, options ...Option) (*Provider, e
 		return nil, ErrMissingProviderURL
 	}
 
+	claimsMapper, err := claimsmap.New(o.ClaimsMapping)
+	if err != nil {
+		return nil, fmt.Errorf("identity/oidc: invalid claims mapping: %w", err)
+	}
+
 	p := new(Provider)
+	p.claimsMapper = claimsMapper
 	if len(o.Scopes) == 0 {
 		o.Scopes = defaultScopes
 	}
@@ -92,6 +101,10 @@ func New(ctx context.Context, o *oauth.Options, options ...Option) (*Provider, e
 		WithGetVerifier(func(provider *go_oidc.Provider) *go_oidc.IDTokenVerifier {
 			return provider.Verifier(&go_oidc.Config{ClientID: o.ClientID})
 		}),
+		WithGetLogoutTokenVerifier(func(provider *go_oidc.Provider) *go_oidc.IDTokenVerifier {
+			// logout tokens aren't required to have an expiry, it's checked when verifying the token
+			return provider.Verifier(&go_oidc.Config{ClientID: o.ClientID, SkipExpiryCheck: true})
+		}),
 	}, options...)...)
 	return p, nil
 }
@@ -141,7 +154,7 @@ func (p *Provider) Authenticate(ctx context.Context, code string, v identity.Sta
 
 	// hydrate `v` using claims inside the returned `id_token`
 	// https://openid.net/specs/openid-connect-core-1_0.html#TokenEndpoint
-	if err := idToken.Claims(v); err != nil {
+	if err := p.unmarshalClaims(idToken, v); err != nil {
 		return nil, fmt.Errorf("identity/oidc: couldn't unmarshal extra claims %w", err)
 	}
 
@@ -166,7 +179,7 @@ func (p *Provider) UpdateUserInfo(ctx context.Context, t *oauth2.Token, v interf
 	if err != nil {
 		return fmt.Errorf("identity/oidc: user info endpoint: %w", err)
 	}
-	if err := userInfo.Claims(v); err != nil {
+	if err := p.unmarshalClaims(userInfo, v); err != nil {
 		return fmt.Errorf("identity/oidc: failed parsing user info endpoint claims: %w", err)
 	}
 	return nil
@@ -201,13 +214,23 @@ func (p *Provider) Refresh(ctx context.Context, t *oauth2.Token, v identity.Stat
 			v.SetRawIDToken(rawIDToken)
 		}
 
-		if err := idToken.Claims(v); err != nil {
+		if err := p.unmarshalClaims(idToken, v); err != nil {
 			return nil, fmt.Errorf("identity/oidc: couldn't unmarshal extra claims %w", err)
 		}
 	}
 	return newToken, nil
 }
 
+// unmarshalClaims unmarshals the claims of an id token or user info response into v, after
+// applying the claims mapping.
+func (p *Provider) unmarshalClaims(src interface{ Claims(interface{}) error }, v interface{}) error {
+	var raw json.RawMessage
+	if err := src.Claims(&raw); err != nil {
+		return err
+	}
+	return p.claimsMapper.Unmarshal(raw, v)
+}
+
 // getIDToken returns the raw jwt payload for `id_token` from the oauth2 token
 // returned following oidc code flow
 //
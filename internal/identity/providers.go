@@ -11,6 +11,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/pomerium/pomerium/internal/identity/identity"
+	"github.com/pomerium/pomerium/internal/identity/ldap"
 	"github.com/pomerium/pomerium/internal/identity/oauth"
 	"github.com/pomerium/pomerium/internal/identity/oauth/github"
 	"github.com/pomerium/pomerium/internal/identity/oidc"
@@ -21,6 +22,7 @@ import (
 	"github.com/pomerium/pomerium/internal/identity/oidc/okta"
 	"github.com/pomerium/pomerium/internal/identity/oidc/onelogin"
 	"github.com/pomerium/pomerium/internal/identity/oidc/ping"
+	"github.com/pomerium/pomerium/internal/identity/saml"
 )
 
 // Authenticator is an interface representing the ability to authenticate with an identity provider.
@@ -48,6 +50,8 @@ func NewAuthenticator(o oauth.Options) (a Authenticator, err error) {
 		a, err = github.New(ctx, &o)
 	case google.Name:
 		a, err = google.New(ctx, &o)
+	case ldap.Name:
+		a, err = ldap.New(ctx, &o)
 	case oidc.Name:
 		a, err = oidc.New(ctx, &o)
 	case okta.Name:
@@ -56,6 +60,8 @@ func NewAuthenticator(o oauth.Options) (a Authenticator, err error) {
 		a, err = onelogin.New(ctx, &o)
 	case ping.Name:
 		a, err = ping.New(ctx, &o)
+	case saml.Name:
+		a, err = saml.New(ctx, &o)
 	default:
 		return nil, fmt.Errorf("identity: unknown provider: %s", o.ProviderName)
 	}
@@ -0,0 +1,27 @@
+package saml
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// verifySignature verifies the enveloped signature of the element using one of the given certificates
+// and returns the signed element, which must be used instead of the element, because only it is
+// covered by the signature. dsig.ErrMissingSignature is returned if the element is not signed.
+func verifySignature(el *etree.Element, certificates []*x509.Certificate, now time.Time) (*etree.Element, error) {
+	ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	ctx.Clock = dsig.NewFakeClockAt(now)
+
+	signed, err := ctx.Validate(el)
+	if errors.Is(err, dsig.ErrMissingSignature) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("saml: invalid signature: %w", err)
+	}
+	return signed, nil
+}
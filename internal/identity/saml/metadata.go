@@ -0,0 +1,208 @@
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+const (
+	namespaceMetadata = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	bindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	nameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	metadataCacheTTL     = time.Hour
+	metadataFetchTimeout = 30 * time.Second
+	metadataMaxSize      = 10 << 20
+)
+
+// metadataHTTPClient is the client used to fetch the identity provider metadata.
+var metadataHTTPClient = &http.Client{Timeout: metadataFetchTimeout}
+
+// identityProviderMetadata is the subset of the IdP metadata used by the service provider.
+type identityProviderMetadata struct {
+	EntityID            string
+	SingleSignOnURL     string
+	SingleLogoutURL     string
+	SigningCertificates []*x509.Certificate
+}
+
+type metadataCacheEntry struct {
+	metadata *identityProviderMetadata
+	expiry   time.Time
+}
+
+var metadataCache = struct {
+	sync.Mutex
+	entries map[string]metadataCacheEntry
+}{entries: map[string]metadataCacheEntry{}}
+
+// getIdentityProviderMetadata fetches the identity provider metadata from the given url. Results are
+// cached because providers are re-created whenever they are used.
+func getIdentityProviderMetadata(ctx context.Context, client *http.Client, metadataURL string) (*identityProviderMetadata, error) {
+	metadataCache.Lock()
+	entry, ok := metadataCache.entries[metadataURL]
+	metadataCache.Unlock()
+	if ok && time.Now().Before(entry.expiry) {
+		return entry.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("saml: error fetching identity provider metadata: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("saml: error fetching identity provider metadata: unexpected status code %d", res.StatusCode)
+	}
+	raw, err := io.ReadAll(io.LimitReader(res.Body, metadataMaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("saml: error reading identity provider metadata: %w", err)
+	}
+
+	metadata, err := parseIdentityProviderMetadata(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataCache.Lock()
+	metadataCache.entries[metadataURL] = metadataCacheEntry{
+		metadata: metadata,
+		expiry:   time.Now().Add(metadataCacheTTL),
+	}
+	metadataCache.Unlock()
+
+	return metadata, nil
+}
+
+func parseIdentityProviderMetadata(raw []byte) (*identityProviderMetadata, error) {
+	root, err := parseXML(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := root
+	if isElement(root, namespaceMetadata, "EntitiesDescriptor") {
+		entity = childElement(root, namespaceMetadata, "EntityDescriptor")
+	}
+	if !isElement(entity, namespaceMetadata, "EntityDescriptor") {
+		return nil, fmt.Errorf("saml: invalid identity provider metadata: missing EntityDescriptor")
+	}
+	descriptor := childElement(entity, namespaceMetadata, "IDPSSODescriptor")
+	if descriptor == nil {
+		return nil, fmt.Errorf("saml: invalid identity provider metadata: missing IDPSSODescriptor")
+	}
+
+	metadata := &identityProviderMetadata{
+		EntityID: attrValue(entity, "entityID"),
+	}
+	for _, sso := range childElements(descriptor, namespaceMetadata, "SingleSignOnService") {
+		if attrValue(sso, "Binding") == bindingHTTPRedirect {
+			metadata.SingleSignOnURL = attrValue(sso, "Location")
+		}
+	}
+	for _, slo := range childElements(descriptor, namespaceMetadata, "SingleLogoutService") {
+		if attrValue(slo, "Binding") == bindingHTTPRedirect {
+			metadata.SingleLogoutURL = attrValue(slo, "Location")
+		}
+	}
+	for _, kd := range childElements(descriptor, namespaceMetadata, "KeyDescriptor") {
+		if use := attrValue(kd, "use"); use != "" && use != "signing" {
+			continue
+		}
+		for _, x509Data := range childElements(childElement(kd, dsig.Namespace, "KeyInfo"), dsig.Namespace, "X509Data") {
+			for _, rawCert := range childElements(x509Data, dsig.Namespace, "X509Certificate") {
+				der, err := base64.StdEncoding.DecodeString(removeWhitespace(textValue(rawCert)))
+				if err != nil {
+					return nil, fmt.Errorf("saml: invalid identity provider certificate: %w", err)
+				}
+				cert, err := x509.ParseCertificate(der)
+				if err != nil {
+					return nil, fmt.Errorf("saml: invalid identity provider certificate: %w", err)
+				}
+				metadata.SigningCertificates = append(metadata.SigningCertificates, cert)
+			}
+		}
+	}
+
+	if metadata.EntityID == "" {
+		return nil, fmt.Errorf("saml: invalid identity provider metadata: missing entityID")
+	}
+	if metadata.SingleSignOnURL == "" {
+		return nil, fmt.Errorf("saml: invalid identity provider metadata: missing HTTP-Redirect SingleSignOnService")
+	}
+	if len(metadata.SigningCertificates) == 0 {
+		return nil, fmt.Errorf("saml: invalid identity provider metadata: missing signing certificate")
+	}
+	return metadata, nil
+}
+
+type spMetadata struct {
+	XMLName         xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string   `xml:"entityID,attr"`
+	SPSSODescriptor struct {
+		AuthnRequestsSigned        bool              `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool              `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string            `xml:"protocolSupportEnumeration,attr"`
+		KeyDescriptors             []spKeyDescriptor `xml:"KeyDescriptor"`
+		NameIDFormat               string            `xml:"NameIDFormat"`
+		AssertionConsumerService   struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+			Index    int    `xml:"index,attr"`
+		} `xml:"AssertionConsumerService"`
+	} `xml:"SPSSODescriptor"`
+}
+
+type spKeyDescriptor struct {
+	Use     string `xml:"use,attr"`
+	KeyInfo struct {
+		XMLName         xml.Name `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+		X509Certificate string   `xml:"X509Data>X509Certificate"`
+	}
+}
+
+// GetServiceProviderMetadata returns the SAML metadata for the service provider.
+func (p *Provider) GetServiceProviderMetadata() ([]byte, error) {
+	var md spMetadata
+	md.EntityID = p.entityID
+	md.SPSSODescriptor.AuthnRequestsSigned = p.key != nil
+	md.SPSSODescriptor.WantAssertionsSigned = true
+	md.SPSSODescriptor.ProtocolSupportEnumeration = "urn:oasis:names:tc:SAML:2.0:protocol"
+	if p.certificate != nil {
+		for _, use := range []string{"signing", "encryption"} {
+			var kd spKeyDescriptor
+			kd.Use = use
+			kd.KeyInfo.X509Certificate = base64.StdEncoding.EncodeToString(p.certificate.Raw)
+			md.SPSSODescriptor.KeyDescriptors = append(md.SPSSODescriptor.KeyDescriptors, kd)
+		}
+	}
+	md.SPSSODescriptor.NameIDFormat = nameIDFormatUnspecified
+	md.SPSSODescriptor.AssertionConsumerService.Binding = bindingHTTPPost
+	md.SPSSODescriptor.AssertionConsumerService.Location = p.acsURL
+	md.SPSSODescriptor.AssertionConsumerService.Index = 1
+
+	bs, err := xml.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), bs...), nil
+}
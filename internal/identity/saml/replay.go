@@ -0,0 +1,71 @@
+package saml
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/beevik/etree"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+type dataBrokerClientContextKey struct{}
+
+// WithDataBrokerClient returns a context with the databroker client used to store the IDs of
+// the assertions which have been used. Authenticate rejects every assertion if the context
+// doesn't have a client.
+func WithDataBrokerClient(ctx context.Context, client databroker.DataBrokerServiceClient) context.Context {
+	return context.WithValue(ctx, dataBrokerClientContextKey{}, client)
+}
+
+func getDataBrokerClient(ctx context.Context) databroker.DataBrokerServiceClient {
+	client, _ := ctx.Value(dataBrokerClientContextKey{}).(databroker.DataBrokerServiceClient)
+	return client
+}
+
+// checkAssertionReplay returns an error if the assertion of the issuer has already been used,
+// and otherwise stores it in the databroker until it expires, so it can't be used with any
+// instance of the authenticate service.
+func checkAssertionReplay(ctx context.Context, issuer string, assertion *etree.Element, now time.Time) error {
+	id := attrValue(assertion, "ID")
+	if id == "" {
+		return fmt.Errorf("saml: missing assertion ID")
+	}
+
+	client := getDataBrokerClient(ctx)
+	if client == nil {
+		return fmt.Errorf("saml: no databroker client to check assertion replay")
+	}
+
+	ok, err := databroker.UseOnce(ctx, client, "saml_assertion|"+issuer+"|"+id, getAssertionReplayExpiry(assertion, now).Sub(now))
+	if err != nil {
+		return fmt.Errorf("saml: failed to check assertion replay: %w", err)
+	} else if !ok {
+		return fmt.Errorf("saml: assertion has already been used")
+	}
+	return nil
+}
+
+// getAssertionReplayExpiry returns the time after which the assertion is no longer accepted.
+// Assertions are only accepted with the relay state they were requested with, which the
+// authenticate service only accepts for a limited time, so that's the minimum.
+func getAssertionReplayExpiry(assertion *etree.Element, now time.Time) time.Time {
+	expiry := now.Add(cryptutil.DefaultLeeway)
+
+	values := []string{attrValue(childElement(assertion, namespaceAssertion, "Conditions"), "NotOnOrAfter")}
+	subject := childElement(assertion, namespaceAssertion, "Subject")
+	for _, confirmation := range childElements(subject, namespaceAssertion, "SubjectConfirmation") {
+		values = append(values, attrValue(childElement(confirmation, namespaceAssertion, "SubjectConfirmationData"), "NotOnOrAfter"))
+	}
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if notOnOrAfter, err := parseTime(value); err == nil && notOnOrAfter.After(expiry) {
+			expiry = notOnOrAfter
+		}
+	}
+	return expiry.Add(maxClockSkew)
+}
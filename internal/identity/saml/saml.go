@@ -0,0 +1,584 @@
+// Package saml implements a SAML 2.0 service provider which authenticates users
+// with a SAML identity provider.
+//
+// Authentication requests are sent using the HTTP-Redirect binding and responses
+// are received using the HTTP-POST binding. Responses or assertions must be signed
+// by the identity provider and assertions may be encrypted. Responses must be in
+// response to a request of the service provider, and assertions can only be used once.
+//
+// https://docs.oasis-open.org/security/saml/v2.0/saml-core-2.0-os.pdf
+// https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"golang.org/x/oauth2"
+
+	"github.com/pomerium/pomerium/internal/identity/claimsmap"
+	"github.com/pomerium/pomerium/internal/identity/identity"
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/internal/identity/oidc"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// Name identifies the SAML identity provider.
+const Name = "saml"
+
+const (
+	// AssertionConsumerServicePath is the path on the authenticate service which receives SAML responses.
+	AssertionConsumerServicePath = "/saml/acs"
+	// MetadataPath is the path on the authenticate service which serves the service provider metadata.
+	MetadataPath = "/saml/metadata"
+
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	statusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+
+	// maxClockSkew is the allowed difference between the clocks of the identity provider and pomerium
+	maxClockSkew = time.Minute * 3
+)
+
+var maxTime = time.Unix(253370793661, 0) // year 9999
+
+// ErrMissingProviderURL is returned when the identity provider metadata url is not set.
+var ErrMissingProviderURL = errors.New("saml: missing identity provider metadata url")
+
+// defaultAttributeNames are the attribute names used for the standard claims when no mapping is configured.
+var defaultAttributeNames = map[string][]string{
+	"email": {
+		"email",
+		"mail",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3",
+	},
+	"name": {
+		"name",
+		"displayName",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+		"urn:oid:2.16.840.1.113730.3.1.241",
+	},
+	"groups": {
+		"groups",
+		"memberOf",
+		"http://schemas.microsoft.com/ws/2008/06/identity/claims/groups",
+		"urn:oid:1.3.6.1.4.1.5923.1.5.1.1",
+	},
+}
+
+// Provider is a SAML 2.0 service provider.
+type Provider struct {
+	ctx         context.Context
+	metadataURL string
+	entityID    string
+	acsURL      string
+	certificate *x509.Certificate
+	key         *rsa.PrivateKey
+	keyPair     *tls.Certificate
+
+	attributeMapping map[string]string
+	claimsMapper     *claimsmap.Mapper
+}
+
+// New creates a new SAML service provider. The ProviderURL is the url of the identity
+// provider metadata and the ClientID is the entity id of the service provider. If no
+// ClientID is set, the url of the service provider metadata is used as the entity id.
+func New(ctx context.Context, o *oauth.Options) (*Provider, error) {
+	if o.ProviderURL == "" {
+		return nil, ErrMissingProviderURL
+	}
+	if o.RedirectURL == nil {
+		return nil, fmt.Errorf("saml: missing redirect url")
+	}
+
+	claimsMapper, err := claimsmap.New(o.ClaimsMapping)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid claims mapping: %w", err)
+	}
+
+	p := &Provider{
+		ctx:              ctx,
+		metadataURL:      o.ProviderURL,
+		entityID:         o.ClientID,
+		acsURL:           o.RedirectURL.ResolveReference(&url.URL{Path: AssertionConsumerServicePath}).String(),
+		attributeMapping: o.SAMLAttributeMapping,
+		claimsMapper:     claimsMapper,
+	}
+	if p.entityID == "" {
+		p.entityID = o.RedirectURL.ResolveReference(&url.URL{Path: MetadataPath}).String()
+	}
+
+	if o.SAMLCertificate != "" || o.SAMLKey != "" {
+		cert, err := cryptutil.CertificateFromBase64(o.SAMLCertificate, o.SAMLKey)
+		if err != nil {
+			return nil, fmt.Errorf("saml: invalid service provider certificate: %w", err)
+		}
+		p.keyPair = cert
+		p.certificate, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("saml: invalid service provider certificate: %w", err)
+		}
+		var ok bool
+		p.key, ok = cert.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("saml: service provider key must be an RSA key")
+		}
+	}
+
+	return p, nil
+}
+
+// GetSignInURL returns the url of the identity provider's single sign-on service with
+// an authentication request using the HTTP-Redirect binding. The state is passed as
+// the RelayState and is returned to the assertion consumer service. The ID of the
+// request is derived from the state, so the response can be matched to the request
+// without storing it.
+func (p *Provider) GetSignInURL(state string) (string, error) {
+	metadata, err := p.getMetadata()
+	if err != nil {
+		return "", err
+	}
+
+	id := getRequestID(state)
+
+	var req bytes.Buffer
+	req.WriteString(`<samlp:AuthnRequest xmlns:samlp="` + namespaceProtocol + `" xmlns:saml="` + namespaceAssertion + `"`)
+	writeXMLAttr(&req, "ID", id)
+	writeXMLAttr(&req, "Version", "2.0")
+	writeXMLAttr(&req, "IssueInstant", time.Now().UTC().Format(time.RFC3339))
+	writeXMLAttr(&req, "Destination", metadata.SingleSignOnURL)
+	writeXMLAttr(&req, "AssertionConsumerServiceURL", p.acsURL)
+	writeXMLAttr(&req, "ProtocolBinding", bindingHTTPPost)
+	req.WriteString(`><saml:Issuer>`)
+	_ = xml.EscapeText(&req, []byte(p.entityID))
+	req.WriteString(`</saml:Issuer><samlp:NameIDPolicy`)
+	writeXMLAttr(&req, "Format", nameIDFormatUnspecified)
+	writeXMLAttr(&req, "AllowCreate", "true")
+	req.WriteString(`/></samlp:AuthnRequest>`)
+
+	encoded, err := deflateAndEncode(req.Bytes(), base64.StdEncoding)
+	if err != nil {
+		return "", err
+	}
+
+	// the signature for the redirect binding is computed over the url encoded query string in this order
+	query := "SAMLRequest=" + url.QueryEscape(encoded)
+	if state != "" {
+		query += "&RelayState=" + url.QueryEscape(state)
+	}
+	if p.key != nil {
+		query += "&SigAlg=" + url.QueryEscape(dsig.RSASHA256SignatureMethod)
+		hashed := sha256.Sum256([]byte(query))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, hashed[:])
+		if err != nil {
+			return "", fmt.Errorf("saml: failed to sign authentication request: %w", err)
+		}
+		query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	}
+
+	u, err := urlutil.ParseAndValidateURL(metadata.SingleSignOnURL)
+	if err != nil {
+		return "", fmt.Errorf("saml: invalid single sign-on url: %w", err)
+	}
+	if u.RawQuery != "" {
+		query = u.RawQuery + "&" + query
+	}
+	u.RawQuery = query
+	return u.String(), nil
+}
+
+// Authenticate validates a SAML response and populates the claims from its assertion. The code
+// is the SAML response and the relay state as received by the assertion consumer service,
+// encoded using EncodeResponse.
+func (p *Provider) Authenticate(ctx context.Context, code string, v identity.State) (*oauth2.Token, error) {
+	raw, relayState, err := decodeResponse(code)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := p.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, expiry, err := p.validateResponse(ctx, metadata, raw, relayState, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.claimsMapper.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: claims["sub"].(string),
+		TokenType:   "SAML",
+		Expiry:      expiry,
+	}, nil
+}
+
+// Refresh is a no-op for SAML, because SAML does not support refreshing sessions. Once
+// the session expires an error is returned and the user must sign in again.
+func (p *Provider) Refresh(ctx context.Context, t *oauth2.Token, v identity.State) (*oauth2.Token, error) {
+	if t == nil || !t.Valid() {
+		return nil, fmt.Errorf("saml: session expired")
+	}
+	return t, nil
+}
+
+// UpdateUserInfo is a no-op for SAML, because user information is only available in assertions.
+func (p *Provider) UpdateUserInfo(ctx context.Context, t *oauth2.Token, v interface{}) error {
+	return nil
+}
+
+// Revoke is not implemented by SAML.
+func (p *Provider) Revoke(ctx context.Context, token *oauth2.Token) error {
+	return oidc.ErrRevokeNotImplemented
+}
+
+// LogOut is not implemented by SAML.
+func (p *Provider) LogOut() (*url.URL, error) {
+	return nil, oidc.ErrSignoutNotImplemented
+}
+
+// Name returns the provider name.
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) getMetadata() (*identityProviderMetadata, error) {
+	return getIdentityProviderMetadata(p.ctx, metadataHTTPClient, p.metadataURL)
+}
+
+// validateResponse validates the SAML response to the request of the relay state and returns
+// the claims for the assertion and the time at which the session expires.
+func (p *Provider) validateResponse(
+	ctx context.Context,
+	metadata *identityProviderMetadata,
+	raw []byte,
+	relayState string,
+	now time.Time,
+) (map[string]interface{}, time.Time, error) {
+	response, err := parseXML(raw)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !isElement(response, namespaceProtocol, "Response") {
+		return nil, time.Time{}, fmt.Errorf("saml: expected Response, got: %s", response.Tag)
+	}
+
+	// only the signed elements are read, so that nothing outside of the signature is used
+	responseSigned := false
+	switch signed, err := verifySignature(response, metadata.SigningCertificates, now); {
+	case err == nil:
+		response, responseSigned = signed, true
+	case errors.Is(err, dsig.ErrMissingSignature):
+	default:
+		return nil, time.Time{}, err
+	}
+
+	status := attrValue(childElement(childElement(response, namespaceProtocol, "Status"), namespaceProtocol, "StatusCode"), "Value")
+	if status != statusSuccess {
+		return nil, time.Time{}, fmt.Errorf("saml: identity provider returned status: %s", status)
+	}
+	if destination := attrValue(response, "Destination"); destination != "" && destination != p.acsURL {
+		return nil, time.Time{}, fmt.Errorf("saml: invalid destination: %s", destination)
+	}
+	// unsolicited responses aren't supported, so a response can't be used to sign in with
+	// another sign in request
+	requestID := getRequestID(relayState)
+	if relayState == "" || attrValue(response, "InResponseTo") != requestID {
+		return nil, time.Time{}, fmt.Errorf("saml: response is not in response to the sign in request")
+	}
+	if issuer := childElement(response, namespaceAssertion, "Issuer"); issuer != nil && textValue(issuer) != metadata.EntityID {
+		return nil, time.Time{}, fmt.Errorf("saml: invalid response issuer: %s", textValue(issuer))
+	}
+
+	var assertion *etree.Element
+	encrypted := childElements(response, namespaceAssertion, "EncryptedAssertion")
+	assertions := childElements(response, namespaceAssertion, "Assertion")
+	switch {
+	case len(encrypted) == 1 && len(assertions) == 0:
+		assertion, err = decryptAssertion(encrypted[0], p.keyPair)
+	case len(encrypted) == 0 && len(assertions) == 1:
+		assertion, err = detach(assertions[0])
+	default:
+		err = fmt.Errorf("saml: expected exactly one assertion")
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !isElement(assertion, namespaceAssertion, "Assertion") {
+		return nil, time.Time{}, fmt.Errorf("saml: expected Assertion, got: %s", assertion.Tag)
+	}
+
+	switch signed, err := verifySignature(assertion, metadata.SigningCertificates, now); {
+	case err == nil:
+		assertion = signed
+	case errors.Is(err, dsig.ErrMissingSignature) && responseSigned:
+	case errors.Is(err, dsig.ErrMissingSignature):
+		return nil, time.Time{}, fmt.Errorf("saml: neither the response nor the assertion is signed")
+	default:
+		return nil, time.Time{}, err
+	}
+
+	if issuer := textValue(childElement(assertion, namespaceAssertion, "Issuer")); issuer != metadata.EntityID {
+		return nil, time.Time{}, fmt.Errorf("saml: invalid assertion issuer: %s", issuer)
+	}
+
+	if err := p.validateConditions(childElement(assertion, namespaceAssertion, "Conditions"), now); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	subject := childElement(assertion, namespaceAssertion, "Subject")
+	if err := p.validateSubjectConfirmation(subject, requestID, now); err != nil {
+		return nil, time.Time{}, err
+	}
+	nameID := textValue(childElement(subject, namespaceAssertion, "NameID"))
+	if nameID == "" {
+		return nil, time.Time{}, fmt.Errorf("saml: missing NameID")
+	}
+
+	expiry := maxTime
+	issuedAt := now
+	authnStatement := childElement(assertion, namespaceAssertion, "AuthnStatement")
+	if value := attrValue(authnStatement, "SessionNotOnOrAfter"); value != "" {
+		expiry, err = parseTime(value)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if !now.Before(expiry.Add(maxClockSkew)) {
+			return nil, time.Time{}, fmt.Errorf("saml: session expired")
+		}
+	}
+	if value := attrValue(authnStatement, "AuthnInstant"); value != "" {
+		issuedAt, err = parseTime(value)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+
+	if err := checkAssertionReplay(ctx, metadata.EntityID, assertion, now); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	claims := p.getClaims(assertion)
+	claims["sub"] = nameID
+	claims["iat"] = issuedAt.Unix()
+	claims["nbf"] = issuedAt.Unix()
+	if expiry != maxTime {
+		claims["exp"] = expiry.Unix()
+	}
+	return claims, expiry, nil
+}
+
+func (p *Provider) validateConditions(conditions *etree.Element, now time.Time) error {
+	if conditions == nil {
+		return nil
+	}
+	if value := attrValue(conditions, "NotBefore"); value != "" {
+		notBefore, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		if now.Add(maxClockSkew).Before(notBefore) {
+			return fmt.Errorf("saml: assertion is not yet valid")
+		}
+	}
+	if value := attrValue(conditions, "NotOnOrAfter"); value != "" {
+		notOnOrAfter, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		if !now.Add(-maxClockSkew).Before(notOnOrAfter) {
+			return fmt.Errorf("saml: assertion has expired")
+		}
+	}
+	for _, restriction := range childElements(conditions, namespaceAssertion, "AudienceRestriction") {
+		found := false
+		for _, audience := range childElements(restriction, namespaceAssertion, "Audience") {
+			if textValue(audience) == p.entityID {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("saml: assertion audience does not include %s", p.entityID)
+		}
+	}
+	return nil
+}
+
+func (p *Provider) validateSubjectConfirmation(subject *etree.Element, requestID string, now time.Time) error {
+	for _, confirmation := range childElements(subject, namespaceAssertion, "SubjectConfirmation") {
+		if attrValue(confirmation, "Method") != "urn:oasis:names:tc:SAML:2.0:cm:bearer" {
+			continue
+		}
+		data := childElement(confirmation, namespaceAssertion, "SubjectConfirmationData")
+		if recipient := attrValue(data, "Recipient"); recipient != "" && recipient != p.acsURL {
+			continue
+		}
+		if inResponseTo := attrValue(data, "InResponseTo"); inResponseTo != "" && inResponseTo != requestID {
+			continue
+		}
+		if value := attrValue(data, "NotOnOrAfter"); value != "" {
+			notOnOrAfter, err := parseTime(value)
+			if err != nil {
+				return err
+			}
+			if !now.Add(-maxClockSkew).Before(notOnOrAfter) {
+				continue
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("saml: no valid bearer subject confirmation")
+}
+
+// getClaims returns the claims for the attributes of the assertion. Every attribute is
+// added using its name and the standard email, name and groups claims are added using
+// the attribute mapping.
+func (p *Provider) getClaims(assertion *etree.Element) map[string]interface{} {
+	attributes := map[string][]string{}
+	for _, statement := range childElements(assertion, namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range childElements(statement, namespaceAssertion, "Attribute") {
+			name := attrValue(attribute, "Name")
+			for _, value := range childElements(attribute, namespaceAssertion, "AttributeValue") {
+				attributes[name] = append(attributes[name], textValue(value))
+			}
+		}
+	}
+
+	claims := map[string]interface{}{}
+	for name, values := range attributes {
+		claims[name] = attributeClaimValue(values)
+	}
+
+	claimNames := make([]string, 0, len(defaultAttributeNames)+len(p.attributeMapping))
+	for claimName := range defaultAttributeNames {
+		claimNames = append(claimNames, claimName)
+	}
+	for claimName := range p.attributeMapping {
+		if _, ok := defaultAttributeNames[claimName]; !ok {
+			claimNames = append(claimNames, claimName)
+		}
+	}
+	sort.Strings(claimNames)
+
+	for _, claimName := range claimNames {
+		attributeNames := defaultAttributeNames[claimName]
+		if attributeName, ok := p.attributeMapping[claimName]; ok {
+			attributeNames = []string{attributeName}
+		}
+		for _, attributeName := range attributeNames {
+			values, ok := attributes[attributeName]
+			if !ok {
+				continue
+			}
+			if claimName == "groups" {
+				claims[claimName] = values
+			} else {
+				claims[claimName] = attributeClaimValue(values)
+			}
+			break
+		}
+	}
+	return claims
+}
+
+func attributeClaimValue(values []string) interface{} {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return values
+}
+
+// EncodeResponse encodes a base64 encoded SAML response received via the HTTP-POST binding and
+// its relay state so that they can be passed to Authenticate as a code.
+func EncodeResponse(samlResponse, relayState string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(removeWhitespace(samlResponse))
+	if err != nil {
+		return "", fmt.Errorf("saml: invalid SAML response: %w", err)
+	}
+	return url.Values{
+		"SAMLResponse": {base64.StdEncoding.EncodeToString(raw)},
+		"RelayState":   {relayState},
+	}.Encode(), nil
+}
+
+func decodeResponse(code string) ([]byte, string, error) {
+	values, err := url.ParseQuery(code)
+	if err != nil {
+		return nil, "", fmt.Errorf("saml: invalid SAML response: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(values.Get("SAMLResponse"))
+	if err != nil {
+		return nil, "", fmt.Errorf("saml: invalid SAML response: %w", err)
+	}
+	return raw, values.Get("RelayState"), nil
+}
+
+func deflateAndEncode(raw []byte, encoding *base64.Encoding) (string, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(buf.Bytes()), nil
+}
+
+func writeXMLAttr(buf *bytes.Buffer, name, value string) {
+	buf.WriteByte(' ')
+	buf.WriteString(name)
+	buf.WriteString(`="`)
+	_ = xml.EscapeText(buf, []byte(value))
+	buf.WriteByte('"')
+}
+
+// getRequestID returns the ID of the authentication request of the relay state. The state is
+// encrypted and authenticated by the authenticate service, so a response to the request can
+// only be used with the state it was requested with.
+func getRequestID(relayState string) string {
+	h := sha256.Sum256([]byte("pomerium-saml-authn-request|" + relayState))
+	// ids must not start with a number
+	return "_" + hex.EncodeToString(h[:20])
+}
+
+func parseTime(value string) (time.Time, error) {
+	tm, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("saml: invalid time: %w", err)
+	}
+	return tm, nil
+}
@@ -0,0 +1,436 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/pomerium/pomerium/internal/identity/oauth"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+type testClaims map[string]interface{}
+
+func (claims *testClaims) SetRawIDToken(string) {}
+
+type testIdentityProvider struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+	srv  *httptest.Server
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	t.Helper()
+
+	idp := new(testIdentityProvider)
+	idp.key, idp.cert = newTestCertificate(t, "idp.example.com")
+	idp.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, base64.StdEncoding.EncodeToString(idp.cert.Raw))
+	}))
+	t.Cleanup(idp.srv.Close)
+	return idp
+}
+
+func newTestCertificate(t *testing.T, commonName string) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, cert
+}
+
+type testAssertionOptions struct {
+	id         string
+	now        time.Time
+	audience   string
+	recipient  string
+	attributes map[string][]string
+}
+
+func (idp *testIdentityProvider) assertion(o testAssertionOptions) string {
+	var attributes strings.Builder
+	for name, values := range o.attributes {
+		attributes.WriteString(`<saml:Attribute Name="` + name + `">`)
+		for _, value := range values {
+			attributes.WriteString(`<saml:AttributeValue>` + value + `</saml:AttributeValue>`)
+		}
+		attributes.WriteString(`</saml:Attribute>`)
+	}
+	if o.id == "" {
+		o.id = "_assertion"
+	}
+	return `<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="` + o.id + `" Version="2.0" IssueInstant="` + o.now.Format(time.RFC3339) + `">` +
+		`<saml:Issuer>https://idp.example.com</saml:Issuer>` +
+		`<saml:Subject><saml:NameID>user@example.com</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">` +
+		`<saml:SubjectConfirmationData Recipient="` + o.recipient + `" InResponseTo="` + getRequestID(testRelayState) + `" NotOnOrAfter="` + o.now.Add(5*time.Minute).Format(time.RFC3339) + `"/>` +
+		`</saml:SubjectConfirmation></saml:Subject>` +
+		`<saml:Conditions NotBefore="` + o.now.Add(-time.Minute).Format(time.RFC3339) + `" NotOnOrAfter="` + o.now.Add(5*time.Minute).Format(time.RFC3339) + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + o.audience + `</saml:Audience></saml:AudienceRestriction>` +
+		`</saml:Conditions>` +
+		`<saml:AuthnStatement AuthnInstant="` + o.now.Format(time.RFC3339) + `" SessionNotOnOrAfter="` + o.now.Add(time.Hour).Format(time.RFC3339) + `"/>` +
+		`<saml:AttributeStatement>` + attributes.String() + `</saml:AttributeStatement>` +
+		`</saml:Assertion>`
+}
+
+func (idp *testIdentityProvider) response(destination, assertion string) string {
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response" Version="2.0" Destination="` + destination + `" InResponseTo="` + getRequestID(testRelayState) + `">` +
+		`<saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">https://idp.example.com</saml:Issuer>` +
+		`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>` +
+		assertion +
+		`</samlp:Response>`
+}
+
+// sign adds an enveloped signature to the element with the given id.
+func (idp *testIdentityProvider) sign(t *testing.T, doc, id string) string {
+	t.Helper()
+
+	d := etree.NewDocument()
+	require.NoError(t, d.ReadFromString(doc))
+	el := d.FindElement("//[@ID='" + id + "']")
+	require.NotNil(t, el)
+
+	ctx := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(tls.Certificate{
+		Certificate: [][]byte{idp.cert.Raw},
+		PrivateKey:  idp.key,
+	}))
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signed, err := ctx.SignEnveloped(el)
+	require.NoError(t, err)
+	if parent := el.Parent(); parent != nil {
+		parent.InsertChildAt(el.Index(), signed)
+		parent.RemoveChild(el)
+	} else {
+		d.SetRoot(signed)
+	}
+
+	signedDoc, err := d.WriteToString()
+	require.NoError(t, err)
+	return signedDoc
+}
+
+func encrypt(t *testing.T, cert *x509.Certificate, plainText string) string {
+	t.Helper()
+
+	sessionKey := make([]byte, 32)
+	_, err := rand.Read(sessionKey)
+	require.NoError(t, err)
+	encryptedKey, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, cert.PublicKey.(*rsa.PublicKey), sessionKey, nil) //nolint:gosec
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(sessionKey)
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+	cipherText := aead.Seal(nonce, nonce, []byte(plainText), nil)
+
+	return `<saml:EncryptedAssertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">` +
+		`<xenc:EncryptedData xmlns:xenc="http://www.w3.org/2001/04/xmlenc#" Type="http://www.w3.org/2001/04/xmlenc#Element">` +
+		`<xenc:EncryptionMethod Algorithm="http://www.w3.org/2009/xmlenc11#aes256-gcm"/>` +
+		`<ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><xenc:EncryptedKey>` +
+		`<xenc:EncryptionMethod Algorithm="http://www.w3.org/2001/04/xmlenc#rsa-oaep-mgf1p"/>` +
+		`<xenc:CipherData><xenc:CipherValue>` + base64.StdEncoding.EncodeToString(encryptedKey) + `</xenc:CipherValue></xenc:CipherData>` +
+		`</xenc:EncryptedKey></ds:KeyInfo>` +
+		`<xenc:CipherData><xenc:CipherValue>` + base64.StdEncoding.EncodeToString(cipherText) + `</xenc:CipherValue></xenc:CipherData>` +
+		`</xenc:EncryptedData></saml:EncryptedAssertion>`
+}
+
+// testRelayState is the relay state the test responses are in response to.
+const testRelayState = "STATE"
+
+func encodeResponse(t *testing.T, response string) string {
+	t.Helper()
+
+	code, err := EncodeResponse(base64.StdEncoding.EncodeToString([]byte(response)), testRelayState)
+	require.NoError(t, err)
+	return code
+}
+
+func newTestProvider(t *testing.T, idp *testIdentityProvider, o oauth.Options) *Provider {
+	t.Helper()
+
+	o.ProviderURL = idp.srv.URL
+	o.RedirectURL = &url.URL{Scheme: "https", Host: "authenticate.example.com", Path: "/oauth2/callback"}
+	p, err := New(context.Background(), &o)
+	require.NoError(t, err)
+	return p
+}
+
+// testDataBrokerClient is a databroker client which only implements the rate limit used to
+// check assertion replay.
+type testDataBrokerClient struct {
+	databroker.DataBrokerServiceClient
+
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+func (client *testDataBrokerClient) RateLimit(_ context.Context, in *databroker.RateLimitRequest, _ ...grpc.CallOption) (*databroker.RateLimitResponse, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	allowed := !client.used[in.GetKey()]
+	client.used[in.GetKey()] = true
+	return &databroker.RateLimitResponse{Allowed: allowed}, nil
+}
+
+func newTestContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(clearTimeout)
+	return WithDataBrokerClient(ctx, &testDataBrokerClient{used: map[string]bool{}})
+}
+
+func TestProvider(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	p := newTestProvider(t, idp, oauth.Options{ClientID: "https://sp.example.com"})
+
+	ctx := newTestContext(t)
+
+	const acsURL = "https://authenticate.example.com/saml/acs"
+	assertionOptions := testAssertionOptions{
+		now:       time.Now().UTC(),
+		audience:  "https://sp.example.com",
+		recipient: acsURL,
+		attributes: map[string][]string{
+			"mail":       {"user@example.com"},
+			"memberOf":   {"admins"},
+			"department": {"engineering", "security"},
+		},
+	}
+
+	t.Run("sign in url", func(t *testing.T) {
+		signInURL, err := p.GetSignInURL("STATE")
+		require.NoError(t, err)
+
+		u, err := url.Parse(signInURL)
+		require.NoError(t, err)
+		assert.Equal(t, "idp.example.com", u.Host)
+		assert.Equal(t, "/sso", u.Path)
+		assert.Equal(t, "STATE", u.Query().Get("RelayState"))
+		assert.Empty(t, u.Query().Get("Signature"))
+
+		compressed, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+		require.NoError(t, err)
+		raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+		require.NoError(t, err)
+
+		req, err := parseXML(raw)
+		require.NoError(t, err)
+		assert.True(t, isElement(req, namespaceProtocol, "AuthnRequest"))
+		assert.Equal(t, getRequestID("STATE"), attrValue(req, "ID"))
+		assert.Equal(t, acsURL, attrValue(req, "AssertionConsumerServiceURL"))
+		assert.Equal(t, "https://sp.example.com", textValue(childElement(req, namespaceAssertion, "Issuer")))
+	})
+	t.Run("signed assertion", func(t *testing.T) {
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(assertionOptions), "_assertion"))
+
+		var claims testClaims
+		token, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		require.NoError(t, err)
+		assert.Equal(t, assertionOptions.now.Add(time.Hour).Truncate(time.Second), token.Expiry)
+		assert.Equal(t, "user@example.com", claims["sub"])
+		assert.Equal(t, "user@example.com", claims["email"])
+		assert.Equal(t, []interface{}{"admins"}, claims["groups"])
+		assert.Equal(t, []interface{}{"engineering", "security"}, claims["department"])
+
+		_, err = p.Refresh(ctx, token, &claims)
+		assert.NoError(t, err)
+	})
+	t.Run("signed response", func(t *testing.T) {
+		o := assertionOptions
+		o.id = "_signed_response_assertion"
+		response := idp.sign(t, idp.response(acsURL, idp.assertion(o)), "_response")
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.NoError(t, err)
+	})
+	t.Run("replayed", func(t *testing.T) {
+		o := assertionOptions
+		o.id = "_replayed_assertion"
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(o), "_replayed_assertion"))
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		require.NoError(t, err)
+		_, err = p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.ErrorContains(t, err, "already been used")
+	})
+	t.Run("no databroker client", func(t *testing.T) {
+		o := assertionOptions
+		o.id = "_no_databroker_client_assertion"
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(o), "_no_databroker_client_assertion"))
+
+		var claims testClaims
+		_, err := p.Authenticate(context.Background(), encodeResponse(t, response), &claims)
+		assert.ErrorContains(t, err, "no databroker client")
+	})
+	t.Run("other request", func(t *testing.T) {
+		o := assertionOptions
+		o.id = "_other_request_assertion"
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(o), "_other_request_assertion"))
+
+		code, err := EncodeResponse(base64.StdEncoding.EncodeToString([]byte(response)), "OTHER_STATE")
+		require.NoError(t, err)
+		var claims testClaims
+		_, err = p.Authenticate(ctx, code, &claims)
+		assert.ErrorContains(t, err, "not in response to the sign in request")
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		response := idp.response(acsURL, idp.assertion(assertionOptions))
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.Error(t, err)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(assertionOptions), "_assertion"))
+		response = strings.Replace(response, "<saml:NameID>user@example.com", "<saml:NameID>admin@example.com", 1)
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+	t.Run("wrong audience", func(t *testing.T) {
+		o := assertionOptions
+		o.audience = "https://other.example.com"
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(o), "_assertion"))
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.ErrorContains(t, err, "audience")
+	})
+	t.Run("expired", func(t *testing.T) {
+		o := assertionOptions
+		o.now = o.now.Add(-2 * time.Hour)
+		response := idp.response(acsURL, idp.sign(t, idp.assertion(o), "_assertion"))
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.ErrorContains(t, err, "expired")
+	})
+	t.Run("wrong destination", func(t *testing.T) {
+		response := idp.response("https://other.example.com/saml/acs", idp.sign(t, idp.assertion(assertionOptions), "_assertion"))
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		assert.ErrorContains(t, err, "destination")
+	})
+}
+
+func TestProvider_ServiceProviderCertificate(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+
+	spKey, spCert := newTestCertificate(t, "sp.example.com")
+	p := newTestProvider(t, idp, oauth.Options{
+		SAMLCertificate: base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: spCert.Raw})),
+		SAMLKey:         base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(spKey)})),
+		SAMLAttributeMapping: map[string]string{
+			"email": "urn:oid:1.2.3",
+			"team":  "department",
+		},
+	})
+
+	ctx := newTestContext(t)
+
+	const acsURL = "https://authenticate.example.com/saml/acs"
+
+	t.Run("metadata", func(t *testing.T) {
+		raw, err := p.GetServiceProviderMetadata()
+		require.NoError(t, err)
+
+		md, err := parseXML(raw)
+		require.NoError(t, err)
+		assert.Equal(t, "https://authenticate.example.com/saml/metadata", attrValue(md, "entityID"))
+		descriptor := childElement(md, namespaceMetadata, "SPSSODescriptor")
+		assert.Equal(t, "true", attrValue(descriptor, "AuthnRequestsSigned"))
+		assert.Equal(t, acsURL, attrValue(childElement(descriptor, namespaceMetadata, "AssertionConsumerService"), "Location"))
+		assert.Len(t, childElements(descriptor, namespaceMetadata, "KeyDescriptor"), 2)
+	})
+	t.Run("signed request", func(t *testing.T) {
+		signInURL, err := p.GetSignInURL("STATE")
+		require.NoError(t, err)
+
+		rawQuery := strings.SplitN(signInURL, "?", 2)[1]
+		idx := strings.Index(rawQuery, "&Signature=")
+		require.True(t, idx > 0)
+		sig, err := url.QueryUnescape(rawQuery[idx+len("&Signature="):])
+		require.NoError(t, err)
+		sigBytes, err := base64.StdEncoding.DecodeString(sig)
+		require.NoError(t, err)
+		hashed := sha256.Sum256([]byte(rawQuery[:idx]))
+		assert.NoError(t, rsa.VerifyPKCS1v15(&spKey.PublicKey, crypto.SHA256, hashed[:], sigBytes))
+	})
+	t.Run("encrypted assertion", func(t *testing.T) {
+		assertion := idp.sign(t, idp.assertion(testAssertionOptions{
+			now:       time.Now().UTC(),
+			audience:  "https://authenticate.example.com/saml/metadata",
+			recipient: acsURL,
+			attributes: map[string][]string{
+				"mail":          {"ignored@example.com"},
+				"urn:oid:1.2.3": {"mapped@example.com"},
+				"department":    {"engineering"},
+			},
+		}), "_assertion")
+		response := idp.response(acsURL, encrypt(t, spCert, assertion))
+
+		var claims testClaims
+		_, err := p.Authenticate(ctx, encodeResponse(t, response), &claims)
+		require.NoError(t, err)
+		assert.Equal(t, "mapped@example.com", claims["email"])
+		assert.Equal(t, "engineering", claims["team"])
+	})
+}
@@ -0,0 +1,108 @@
+package saml
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+	xrv "github.com/mattermost/xml-roundtrip-validator"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+// parseXML parses raw XML into an element tree. Documents which don't round trip through
+// encoding/xml unchanged are rejected, because their signatures could be verified over
+// different content than what is read from them.
+func parseXML(raw []byte) (*etree.Element, error) {
+	if err := xrv.Validate(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("saml: invalid xml: %w", err)
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		return nil, fmt.Errorf("saml: invalid xml: %w", err)
+	}
+	for _, tok := range doc.Child {
+		// DTDs are not allowed in SAML messages
+		if _, ok := tok.(*etree.Directive); ok {
+			return nil, fmt.Errorf("saml: invalid xml: directives are not supported")
+		}
+	}
+	root := doc.Root()
+	if root == nil {
+		return nil, fmt.Errorf("saml: invalid xml: missing root element")
+	}
+	return root, nil
+}
+
+// detach returns a copy of the element which declares the namespaces in scope of the element,
+// so that it can be verified and read without its parent.
+func detach(el *etree.Element) (*etree.Element, error) {
+	ctx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid xml: %w", err)
+	}
+	detached, err := etreeutils.NSDetatch(ctx, el)
+	if err != nil {
+		return nil, fmt.Errorf("saml: invalid xml: %w", err)
+	}
+	return detached, nil
+}
+
+// isElement returns true if the element has the given namespace and local name.
+func isElement(el *etree.Element, namespace, tag string) bool {
+	return el != nil && el.Tag == tag && el.NamespaceURI() == namespace
+}
+
+// childElement returns the first child element with the given namespace and local name.
+func childElement(el *etree.Element, namespace, tag string) *etree.Element {
+	if els := childElements(el, namespace, tag); len(els) > 0 {
+		return els[0]
+	}
+	return nil
+}
+
+// childElements returns all the child elements with the given namespace and local name.
+func childElements(el *etree.Element, namespace, tag string) []*etree.Element {
+	if el == nil {
+		return nil
+	}
+	var els []*etree.Element
+	for _, c := range el.ChildElements() {
+		if isElement(c, namespace, tag) {
+			els = append(els, c)
+		}
+	}
+	return els
+}
+
+// attrValue returns the value of the un-prefixed attribute with the given name.
+func attrValue(el *etree.Element, name string) string {
+	if el == nil {
+		return ""
+	}
+	for _, attr := range el.Attr {
+		if attr.Space == "" && attr.Key == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// textValue returns the text of the element, without surrounding whitespace.
+func textValue(el *etree.Element) string {
+	if el == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, tok := range el.Child {
+		if cd, ok := tok.(*etree.CharData); ok {
+			sb.WriteString(cd.Data)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func removeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
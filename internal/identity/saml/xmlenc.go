@@ -0,0 +1,93 @@
+package saml
+
+import (
+	"crypto/aes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/beevik/etree"
+	"github.com/russellhaering/gosaml2/types"
+)
+
+// decryptAssertion decrypts an EncryptedAssertion element and returns the decrypted assertion.
+func decryptAssertion(el *etree.Element, cert *tls.Certificate) (*etree.Element, error) {
+	if cert == nil {
+		return nil, fmt.Errorf("saml: received an encrypted assertion, but no service provider key is configured")
+	}
+
+	detached, err := detach(el)
+	if err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	doc.SetRoot(detached)
+	raw, err := doc.WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+	var encrypted types.EncryptedAssertion
+	if err := xml.Unmarshal(raw, &encrypted); err != nil {
+		return nil, fmt.Errorf("saml: invalid encrypted assertion: %w", err)
+	}
+	if err := checkEncryptedAssertion(&encrypted); err != nil {
+		return nil, err
+	}
+
+	plainText, err := decryptBytes(&encrypted, cert)
+	if err != nil {
+		return nil, err
+	}
+	return parseXML(plainText)
+}
+
+// checkEncryptedAssertion checks the algorithms and the length of the cipher text, which aren't
+// checked before decrypting.
+func checkEncryptedAssertion(encrypted *types.EncryptedAssertion) error {
+	encryptedKey := &encrypted.EncryptedKey
+	if encryptedKey.CipherValue == "" {
+		encryptedKey = &encrypted.DetEncryptedKey
+	}
+	// RSA PKCS#1 v1.5 key transport is vulnerable to padding oracle attacks
+	switch algorithm := encryptedKey.EncryptionMethod.Algorithm; algorithm {
+	case types.MethodRSAOAEP, types.MethodRSAOAEP2:
+	default:
+		return fmt.Errorf("saml: unsupported key encryption method: %s", algorithm)
+	}
+
+	cipherText, err := base64.StdEncoding.DecodeString(removeWhitespace(encrypted.CipherValue))
+	if err != nil {
+		return fmt.Errorf("saml: invalid cipher value: %w", err)
+	}
+	switch algorithm := encrypted.EncryptionMethod.Algorithm; algorithm {
+	case types.MethodAES128CBC, types.MethodAES256CBC:
+		if len(cipherText) < 2*aes.BlockSize || len(cipherText)%aes.BlockSize != 0 {
+			return fmt.Errorf("saml: invalid cipher text length")
+		}
+	case types.MethodAES128GCM, types.MethodAES192GCM, types.MethodAES256GCM:
+		// a 12 byte nonce and a 16 byte tag
+		if len(cipherText) < 12+16 {
+			return fmt.Errorf("saml: invalid cipher text length")
+		}
+	default:
+		return fmt.Errorf("saml: unsupported encryption method: %s", algorithm)
+	}
+	encrypted.CipherValue = base64.StdEncoding.EncodeToString(cipherText)
+	return nil
+}
+
+func decryptBytes(encrypted *types.EncryptedAssertion, cert *tls.Certificate) (plainText []byte, err error) {
+	// the padding of CBC cipher texts is removed without checking it, which panics for invalid padding
+	defer func() {
+		if r := recover(); r != nil {
+			plainText, err = nil, fmt.Errorf("saml: failed to decrypt assertion: invalid padding")
+		}
+	}()
+
+	plainText, err = encrypted.DecryptBytes(cert)
+	if err != nil {
+		return nil, fmt.Errorf("saml: failed to decrypt assertion: %w", err)
+	}
+	return plainText, nil
+}
@@ -0,0 +1,79 @@
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/go-jose/go-jose/v3"
+)
+
+type awsClient struct {
+	client *kms.Client
+	keyID  string
+}
+
+// newAWSClient returns a client for the key, using the default credentials. The region of key
+// ARNs is used, instead of the default region.
+func newAWSClient(ctx context.Context, keyID string, optFns ...func(*kms.Options)) (*awsClient, error) {
+	var loadOptions []func(*awsconfig.LoadOptions) error
+	if region := getAWSRegion(keyID); region != "" {
+		loadOptions = append(loadOptions, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, loadOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("kms: error loading AWS config: %w", err)
+	}
+	return &awsClient{client: kms.NewFromConfig(cfg, optFns...), keyID: keyID}, nil
+}
+
+// getAWSRegion returns the region of a key ARN, like arn:aws:kms:us-east-2:111122223333:key/1234abcd.
+func getAWSRegion(keyID string) string {
+	parts := strings.Split(keyID, ":")
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[3]
+}
+
+func (c *awsClient) getPublicKey(ctx context.Context) (crypto.PublicKey, error) {
+	res, err := c.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(c.keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("kms: error getting AWS KMS public key: %w", err)
+	}
+	if res.KeyUsage != types.KeyUsageTypeSignVerify {
+		return nil, fmt.Errorf("kms: AWS KMS key isn't a signing key: %s", res.KeyUsage)
+	}
+	public, err := x509.ParsePKIXPublicKey(res.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("kms: invalid AWS KMS public key: %w", err)
+	}
+	return public, nil
+}
+
+func (c *awsClient) sign(ctx context.Context, alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+	signingAlgorithm := types.SigningAlgorithmSpecRsassaPkcs1V15Sha256
+	if alg == jose.ES256 {
+		signingAlgorithm = types.SigningAlgorithmSpecEcdsaSha256
+	}
+
+	res, err := c.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(c.keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: signingAlgorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if alg == jose.ES256 {
+		return ecdsaSignatureFromDER(res.Signature)
+	}
+	return res.Signature, nil
+}
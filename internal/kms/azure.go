@@ -0,0 +1,121 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/go-jose/go-jose/v3"
+)
+
+const azureKeyVaultAPIVersion = "7.3"
+
+var azureKeyVaultScopes = []string{"https://vault.azure.net/.default"}
+
+type azureClient struct {
+	httpClient *http.Client
+	credential azcore.TokenCredential
+	// keyURL is the URL of the key, like https://<vault>.vault.azure.net/keys/<key>/<version>
+	keyURL string
+}
+
+// newAzureClient returns a client for the key, using the default Azure credentials.
+func newAzureClient(name string) (*azureClient, error) {
+	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("kms: error loading Azure credentials: %w", err)
+	}
+	return &azureClient{httpClient: http.DefaultClient, credential: credential, keyURL: "https://" + name}, nil
+}
+
+func (c *azureClient) getPublicKey(ctx context.Context) (crypto.PublicKey, error) {
+	var res struct {
+		Key map[string]interface{} `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.keyURL, nil, &res); err != nil {
+		return nil, fmt.Errorf("kms: error getting Azure Key Vault key: %w", err)
+	}
+
+	// keys stored in HSMs have the EC-HSM and RSA-HSM types, but are otherwise JWKs
+	if kty, ok := res.Key["kty"].(string); ok {
+		res.Key["kty"] = strings.TrimSuffix(kty, "-HSM")
+	}
+	bs, err := json.Marshal(res.Key)
+	if err != nil {
+		return nil, err
+	}
+	var jwk jose.JSONWebKey
+	if err := jwk.UnmarshalJSON(bs); err != nil {
+		return nil, fmt.Errorf("kms: invalid Azure Key Vault key: %w", err)
+	}
+
+	// the key ID is the URL of the current version of the key, which is used to sign so
+	// signatures match the published key after the key is rotated
+	if kid, ok := res.Key["kid"].(string); ok && kid != "" {
+		c.keyURL = kid
+	}
+	return jwk.Key, nil
+}
+
+func (c *azureClient) sign(ctx context.Context, alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+	req := map[string]string{
+		"alg":   string(alg),
+		"value": base64.RawURLEncoding.EncodeToString(digest),
+	}
+	var res struct {
+		Value string `json:"value"`
+	}
+	if err := c.do(ctx, http.MethodPost, c.keyURL+"/sign", req, &res); err != nil {
+		return nil, err
+	}
+
+	// ES256 signatures are returned in the JWS encoding
+	signature, err := base64.RawURLEncoding.DecodeString(res.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	return signature, nil
+}
+
+func (c *azureClient) do(ctx context.Context, method, rawURL string, body, out interface{}) error {
+	token, err := c.credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: azureKeyVaultScopes})
+	if err != nil {
+		return fmt.Errorf("error getting Azure access token: %w", err)
+	}
+
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL+"?api-version="+azureKeyVaultAPIVersion, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		bs, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("unexpected status code from Azure Key Vault: %d: %s", res.StatusCode, bs)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
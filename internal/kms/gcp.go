@@ -0,0 +1,68 @@
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/go-jose/go-jose/v3"
+	"google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+)
+
+type gcpClient struct {
+	versions *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService
+	name     string
+}
+
+// newGCPClient returns a client for the key version, using the application default credentials.
+func newGCPClient(ctx context.Context, name string, opts ...option.ClientOption) (*gcpClient, error) {
+	service, err := cloudkms.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("kms: error creating Google Cloud KMS client: %w", err)
+	}
+	return &gcpClient{versions: service.Projects.Locations.KeyRings.CryptoKeys.CryptoKeyVersions, name: name}, nil
+}
+
+func (c *gcpClient) getPublicKey(ctx context.Context) (crypto.PublicKey, error) {
+	res, err := c.versions.GetPublicKey(c.name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("kms: error getting Google Cloud KMS public key: %w", err)
+	}
+	// only the algorithms which match ES256 and RS256 can be used
+	if res.Algorithm != "EC_SIGN_P256_SHA256" &&
+		!(strings.HasPrefix(res.Algorithm, "RSA_SIGN_PKCS1_") && strings.HasSuffix(res.Algorithm, "_SHA256")) {
+		return nil, fmt.Errorf("kms: unsupported Google Cloud KMS key algorithm: %s", res.Algorithm)
+	}
+
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("kms: invalid Google Cloud KMS public key")
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("kms: invalid Google Cloud KMS public key: %w", err)
+	}
+	return public, nil
+}
+
+func (c *gcpClient) sign(ctx context.Context, alg jose.SignatureAlgorithm, digest []byte) ([]byte, error) {
+	res, err := c.versions.AsymmetricSign(c.name, &cloudkms.AsymmetricSignRequest{
+		Digest: &cloudkms.Digest{Sha256: base64.StdEncoding.EncodeToString(digest)},
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if alg == jose.ES256 {
+		return ecdsaSignatureFromDER(signature)
+	}
+	return signature, nil
+}
@@ -0,0 +1,207 @@
+// Package kms signs with keys managed by a cloud key management service, like AWS KMS, Google
+// Cloud KMS or Azure Key Vault, so the private keys never leave the service.
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// signTimeout is the timeout of signing requests sent to the service.
+const signTimeout = 10 * time.Second
+
+// schemes of the key URLs
+const (
+	schemeAWS   = "awskms"
+	schemeGCP   = "gcpkms"
+	schemeAzure = "azurekms"
+)
+
+var (
+	gcpKeyNameRegexp   = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+/cryptoKeyVersions/[^/]+$`)
+	azureKeyNameRegexp = regexp.MustCompile(`^keys/[^/]+(/[^/]+)?$`)
+)
+
+// A client signs with a key managed by a service.
+type client interface {
+	// getPublicKey returns the public key of the key.
+	getPublicKey(ctx context.Context) (crypto.PublicKey, error)
+	// sign signs the SHA-256 digest, and returns the signature in the JWS encoding.
+	sign(ctx context.Context, alg jose.SignatureAlgorithm, digest []byte) ([]byte, error)
+}
+
+// signers are shared, so the public keys aren't fetched again when the config changes
+var global = struct {
+	sync.Mutex
+	signers map[string]*signer
+}{
+	signers: map[string]*signer{},
+}
+
+// GetSigner returns a signer for the key with the URL, which is one of:
+//
+//   - awskms:///<key ID, key ARN or alias>
+//   - gcpkms://projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>
+//   - azurekms://<vault>.vault.azure.net/keys/<key>[/<version>]
+//
+// The public key of the key is fetched once and cached, so the JWKS is served without calling
+// the service.
+func GetSigner(ctx context.Context, rawURL string) (jose.OpaqueSigner, error) {
+	global.Lock()
+	defer global.Unlock()
+
+	if s, ok := global.signers[rawURL]; ok {
+		return s, nil
+	}
+
+	c, err := newClient(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSigner(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	global.signers[rawURL] = s
+	return s, nil
+}
+
+// ValidateURL validates a key URL, without calling the service.
+func ValidateURL(rawURL string) error {
+	_, _, err := parseURL(rawURL)
+	return err
+}
+
+func parseURL(rawURL string) (scheme, name string, err error) {
+	scheme, name, ok := strings.Cut(rawURL, "://")
+	if !ok {
+		return "", "", fmt.Errorf("kms: invalid key url: %s", rawURL)
+	}
+
+	switch scheme {
+	case schemeAWS:
+		name = strings.TrimPrefix(name, "/")
+		if name == "" {
+			return "", "", fmt.Errorf("kms: invalid AWS KMS key url, expected awskms:///<key id>: %s", rawURL)
+		}
+	case schemeGCP:
+		if !gcpKeyNameRegexp.MatchString(name) {
+			return "", "", fmt.Errorf("kms: invalid Google Cloud KMS key url, expected "+
+				"gcpkms://projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>: %s",
+				rawURL)
+		}
+	case schemeAzure:
+		host, path, _ := strings.Cut(name, "/")
+		if host == "" || !azureKeyNameRegexp.MatchString(path) {
+			return "", "", fmt.Errorf("kms: invalid Azure Key Vault key url, expected "+
+				"azurekms://<vault>.vault.azure.net/keys/<key>[/<version>]: %s", rawURL)
+		}
+	default:
+		return "", "", fmt.Errorf("kms: unsupported key url scheme: %s", scheme)
+	}
+	return scheme, name, nil
+}
+
+func newClient(ctx context.Context, rawURL string) (client, error) {
+	scheme, name, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch scheme {
+	case schemeAWS:
+		return newAWSClient(ctx, name)
+	case schemeGCP:
+		return newGCPClient(ctx, name)
+	default:
+		return newAzureClient(name)
+	}
+}
+
+type signer struct {
+	client client
+	public *jose.JSONWebKey
+}
+
+func newSigner(ctx context.Context, c client) (*signer, error) {
+	public, err := c.getPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := public.(*ecdsa.PublicKey); ok && key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("kms: unsupported EC curve: %s", key.Curve.Params().Name)
+	}
+
+	jwk, err := cryptutil.PublicJWKFromKey(public)
+	if err != nil {
+		return nil, fmt.Errorf("kms: %w", err)
+	}
+	switch jose.SignatureAlgorithm(jwk.Algorithm) {
+	case jose.ES256, jose.RS256:
+	default:
+		return nil, fmt.Errorf("kms: unsupported signing key algorithm: %s", jwk.Algorithm)
+	}
+	return &signer{client: c, public: jwk}, nil
+}
+
+// Public returns the public key of the signer.
+func (s *signer) Public() *jose.JSONWebKey {
+	return s.public
+}
+
+// Algs returns the algorithm of the key.
+func (s *signer) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{jose.SignatureAlgorithm(s.public.Algorithm)}
+}
+
+// SignPayload signs the payload with the key managed by the service.
+func (s *signer) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	if string(alg) != s.public.Algorithm {
+		return nil, fmt.Errorf("kms: unsupported algorithm for key: %s", alg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	digest := sha256.Sum256(payload)
+	signature, err := s.client.sign(ctx, alg, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("kms: error signing: %w", err)
+	}
+	return signature, nil
+}
+
+// ecdsaSignatureFromDER converts a DER-encoded ECDSA P-256 signature to the JWS encoding, which
+// is r and s as 32 bytes each.
+func ecdsaSignatureFromDER(der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("invalid ECDSA signature: %w", err)
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("invalid ECDSA signature: trailing data")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid ECDSA signature")
+	}
+
+	signature := make([]byte, 64)
+	sig.R.FillBytes(signature[:32])
+	sig.S.FillBytes(signature[32:])
+	return signature, nil
+}
@@ -0,0 +1,226 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestValidateURL(t *testing.T) {
+	for _, tc := range []struct {
+		url   string
+		valid bool
+	}{
+		{"awskms:///arn:aws:kms:us-east-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab", true},
+		{"awskms:///alias/pomerium", true},
+		{"awskms:///", false},
+		{"gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1", true},
+		{"gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k", false},
+		{"azurekms://example.vault.azure.net/keys/k", true},
+		{"azurekms://example.vault.azure.net/keys/k/0123456789abcdef", true},
+		{"azurekms://example.vault.azure.net/secrets/k", false},
+		{"vault://example.com/k", false},
+		{"arn:aws:kms:us-east-2:111122223333:key/1234abcd", false},
+	} {
+		err := ValidateURL(tc.url)
+		if tc.valid {
+			assert.NoError(t, err, tc.url)
+		} else {
+			assert.Error(t, err, tc.url)
+		}
+	}
+}
+
+func TestGetAWSRegion(t *testing.T) {
+	assert.Equal(t, "us-east-2", getAWSRegion("arn:aws:kms:us-east-2:111122223333:key/1234abcd"))
+	assert.Equal(t, "", getAWSRegion("alias/pomerium"))
+}
+
+func TestAWSSigner(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	key := newTestKey(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte
+			MessageType      string
+			SigningAlgorithm string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "alias/pomerium", req.KeyID)
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"KeyId":     req.KeyID,
+				"KeyUsage":  "SIGN_VERIFY",
+				"PublicKey": public,
+			})
+		case "TrentService.Sign":
+			assert.Equal(t, "DIGEST", req.MessageType)
+			assert.Equal(t, "ECDSA_SHA_256", req.SigningAlgorithm)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, req.Message)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"KeyId": req.KeyID, "Signature": signature})
+		default:
+			http.Error(w, "unexpected target", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := newAWSClient(context.Background(), "alias/pomerium",
+		kms.WithEndpointResolver(kms.EndpointResolverFromURL(srv.URL)))
+	require.NoError(t, err)
+	testSigner(t, c, key)
+}
+
+func TestGCPSigner(t *testing.T) {
+	const name = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	key := newTestKey(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/" + name + "/publicKey":
+			public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"algorithm": "EC_SIGN_P256_SHA256",
+				"pem":       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})),
+			})
+		case "/v1/" + name + ":asymmetricSign":
+			var req struct {
+				Digest struct {
+					SHA256 []byte `json:"sha256"`
+				} `json:"digest"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			signature, err := ecdsa.SignASN1(rand.Reader, key, req.Digest.SHA256)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"signature": signature})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := newGCPClient(context.Background(), name,
+		option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication())
+	require.NoError(t, err)
+	testSigner(t, c, key)
+}
+
+type testCredential struct{}
+
+func (testCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "TOKEN", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestAzureSigner(t *testing.T) {
+	key := newTestKey(t)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer TOKEN", r.Header.Get("Authorization"))
+		assert.Equal(t, azureKeyVaultAPIVersion, r.URL.Query().Get("api-version"))
+
+		switch r.URL.Path {
+		case "/keys/k":
+			jwk, err := json.Marshal(jose.JSONWebKey{Key: &key.PublicKey})
+			require.NoError(t, err)
+			var m map[string]interface{}
+			require.NoError(t, json.Unmarshal(jwk, &m))
+			m["kty"] = "EC-HSM"
+			m["kid"] = srv.URL + "/keys/k/v1"
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"key": m})
+		case "/keys/k/v1/sign":
+			var req struct {
+				Alg   string `json:"alg"`
+				Value string `json:"value"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "ES256", req.Alg)
+			digest, err := base64.RawURLEncoding.DecodeString(req.Value)
+			require.NoError(t, err)
+			r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+			require.NoError(t, err)
+			signature := make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": base64.RawURLEncoding.EncodeToString(signature)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &azureClient{httpClient: srv.Client(), credential: testCredential{}, keyURL: srv.URL + "/keys/k"}
+	testSigner(t, c, key)
+	assert.Equal(t, srv.URL+"/keys/k/v1", c.keyURL, "should sign with the version of the public key")
+}
+
+func TestECDSASignatureFromDER(t *testing.T) {
+	key := newTestKey(t)
+	der, err := ecdsa.SignASN1(rand.Reader, key, make([]byte, 32))
+	require.NoError(t, err)
+	signature, err := ecdsaSignatureFromDER(der)
+	require.NoError(t, err)
+	assert.Len(t, signature, 64)
+
+	_, err = ecdsaSignatureFromDER(append(der, 0))
+	assert.Error(t, err)
+	_, err = ecdsaSignatureFromDER([]byte("signature"))
+	assert.Error(t, err)
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// testSigner signs a JWS with the client, and verifies it with the key.
+func testSigner(t *testing.T, c client, key *ecdsa.PrivateKey) {
+	t.Helper()
+
+	s, err := newSigner(context.Background(), c)
+	require.NoError(t, err)
+	expected, err := cryptutil.PublicJWKFromKey(&key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, expected.KeyID, s.Public().KeyID)
+	assert.Equal(t, "ES256", s.Public().Algorithm)
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.ES256,
+		Key:       cryptutil.PrivateJWKFromSigner(s),
+	}, nil)
+	require.NoError(t, err)
+	jws, err := signer.Sign([]byte("payload"))
+	require.NoError(t, err)
+	payload, err := jws.Verify(&key.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("payload"), payload)
+}
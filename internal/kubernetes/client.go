@@ -0,0 +1,148 @@
+// Package kubernetes contains a minimal client of the kubernetes API, for pomerium running in a
+// kubernetes pod.
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// ErrNotInCluster indicates pomerium isn't running in a kubernetes cluster.
+var ErrNotInCluster = errors.New("not running in a kubernetes cluster")
+
+// A Client makes requests to the kubernetes API, using the service account of the pod.
+type Client struct {
+	BaseURL    string
+	TokenFile  string
+	Namespace  string
+	HTTPClient *http.Client
+}
+
+// NewInClusterClient creates a new Client when running in a kubernetes pod.
+func NewInClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, ErrNotInCluster
+	}
+
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubernetes service account ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid kubernetes service account ca")
+	}
+
+	namespace, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubernetes service account namespace: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	return &Client{
+		BaseURL:    "https://" + net.JoinHostPort(host, port),
+		TokenFile:  filepath.Join(serviceAccountDir, "token"),
+		Namespace:  strings.TrimSpace(string(namespace)),
+		HTTPClient: &http.Client{Transport: transport},
+	}, nil
+}
+
+// NewRequest creates a new GET request of the kubernetes API path.
+func (c *Client) NewRequest(ctx context.Context, path string, query url.Values) (*http.Request, error) {
+	endpoint := c.BaseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// service account tokens are rotated, so they're read for every request
+	token, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubernetes service account token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// List lists the items of the kubernetes API path into items, which must be a pointer to a
+// slice.
+func (c *Client) List(ctx context.Context, path string, query url.Values, items interface{}) error {
+	req, err := c.NewRequest(ctx, path, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error listing %s: %w", path, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error listing %s: unexpected status code %d", path, res.StatusCode)
+	}
+
+	var list struct {
+		Items interface{} `json:"items"`
+	}
+	list.Items = items
+	err = json.NewDecoder(res.Body).Decode(&list)
+	if err != nil {
+		return fmt.Errorf("error decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// Watch watches the kubernetes API path and calls onChange for every change, until the watch is
+// closed by the kubernetes API or the context is canceled.
+func (c *Client) Watch(ctx context.Context, path string, query url.Values, onChange func()) error {
+	watchQuery := url.Values{"watch": {"true"}}
+	for k, vs := range query {
+		watchQuery[k] = vs
+	}
+	req, err := c.NewRequest(ctx, path, watchQuery)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error watching %s: %w", path, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error watching %s: unexpected status code %d", path, res.StatusCode)
+	}
+
+	// every line is a watch event, its contents don't matter since the items are listed again
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(nil, 1<<22)
+	for scanner.Scan() {
+		onChange()
+	}
+	return scanner.Err()
+}
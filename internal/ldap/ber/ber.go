@@ -0,0 +1,266 @@
+// Package ber implements the subset of the Basic Encoding Rules used by LDAP.
+//
+// https://datatracker.ietf.org/doc/html/rfc4511#section-5.1
+package ber
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BER classes
+const (
+	ClassUniversal   = 0x00
+	ClassApplication = 0x40
+	ClassContext     = 0x80
+)
+
+// universal tags
+const (
+	TagBoolean     = 0x01
+	TagInteger     = 0x02
+	TagOctetString = 0x04
+	TagEnumerated  = 0x0a
+	TagSequence    = 0x10
+	TagSet         = 0x11
+)
+
+const maxPacketSize = 64 << 20
+
+// A Packet is a BER encoded value. Constructed packets have children, primitive packets have a value.
+type Packet struct {
+	Class       byte
+	Constructed bool
+	Tag         byte
+	Value       []byte
+	Children    []*Packet
+}
+
+// NewSequence creates a new sequence of the children.
+func NewSequence(children ...*Packet) *Packet {
+	return &Packet{Class: ClassUniversal, Constructed: true, Tag: TagSequence, Children: children}
+}
+
+// NewSet creates a new set of the children.
+func NewSet(children ...*Packet) *Packet {
+	return &Packet{Class: ClassUniversal, Constructed: true, Tag: TagSet, Children: children}
+}
+
+// NewConstructed creates a new constructed packet with the class and tag.
+func NewConstructed(class, tag byte, children ...*Packet) *Packet {
+	return &Packet{Class: class, Constructed: true, Tag: tag, Children: children}
+}
+
+// NewPrimitive creates a new primitive packet with the class, tag and value.
+func NewPrimitive(class, tag byte, value []byte) *Packet {
+	return &Packet{Class: class, Tag: tag, Value: value}
+}
+
+// NewOctetString creates a new octet string.
+func NewOctetString(value string) *Packet {
+	return NewPrimitive(ClassUniversal, TagOctetString, []byte(value))
+}
+
+// NewBoolean creates a new boolean.
+func NewBoolean(value bool) *Packet {
+	if value {
+		return NewPrimitive(ClassUniversal, TagBoolean, []byte{0xff})
+	}
+	return NewPrimitive(ClassUniversal, TagBoolean, []byte{0x00})
+}
+
+// NewInteger creates a new integer.
+func NewInteger(value int64) *Packet {
+	return NewPrimitive(ClassUniversal, TagInteger, EncodeInteger(value))
+}
+
+// NewEnumerated creates a new enumerated value.
+func NewEnumerated(value int64) *Packet {
+	return NewPrimitive(ClassUniversal, TagEnumerated, EncodeInteger(value))
+}
+
+// EncodeInteger returns the content octets of an integer.
+func EncodeInteger(value int64) []byte {
+	// two's complement, big endian, minimal length
+	var bs []byte
+	for {
+		bs = append([]byte{byte(value)}, bs...)
+		next := value >> 8
+		if (next == 0 && bs[0]&0x80 == 0) || (next == -1 && bs[0]&0x80 != 0) {
+			return bs
+		}
+		value = next
+	}
+}
+
+// DecodeInteger parses the content octets of an integer.
+func DecodeInteger(bs []byte) (int64, error) {
+	if len(bs) == 0 || len(bs) > 8 {
+		return 0, fmt.Errorf("ber: invalid integer length: %d", len(bs))
+	}
+	var value int64
+	if bs[0]&0x80 != 0 {
+		value = -1
+	}
+	for _, b := range bs {
+		value = value<<8 | int64(b)
+	}
+	return value, nil
+}
+
+// Is returns true if the packet has the given class and tag.
+func (p *Packet) Is(class, tag byte) bool {
+	return p != nil && p.Class == class && p.Tag == tag
+}
+
+// Child returns the child at the index, or nil if there is none.
+func (p *Packet) Child(i int) *Packet {
+	if p == nil || i >= len(p.Children) {
+		return nil
+	}
+	return p.Children[i]
+}
+
+// Str returns the value of the packet as a string.
+func (p *Packet) Str() string {
+	if p == nil {
+		return ""
+	}
+	return string(p.Value)
+}
+
+// Integer returns the value of the packet as an integer.
+func (p *Packet) Integer() (int64, error) {
+	if p == nil || p.Constructed {
+		return 0, fmt.Errorf("ber: expected integer")
+	}
+	return DecodeInteger(p.Value)
+}
+
+// Bytes returns the BER encoding of the packet.
+func (p *Packet) Bytes() []byte {
+	content := p.Value
+	if p.Constructed {
+		content = nil
+		for _, c := range p.Children {
+			content = append(content, c.Bytes()...)
+		}
+	}
+
+	identifier := p.Class | p.Tag
+	if p.Constructed {
+		identifier |= 0x20
+	}
+	bs := []byte{identifier}
+	bs = append(bs, encodeLength(len(content))...)
+	return append(bs, content...)
+}
+
+func encodeLength(length int) []byte {
+	if length < 0x80 {
+		return []byte{byte(length)}
+	}
+	var bs []byte
+	for length > 0 {
+		bs = append([]byte{byte(length)}, bs...)
+		length >>= 8
+	}
+	return append([]byte{0x80 | byte(len(bs))}, bs...)
+}
+
+// ReadPacket reads a BER encoded packet from the reader.
+func ReadPacket(r *bufio.Reader) (*Packet, error) {
+	identifier, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	length, err := readLength(r)
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+	return parsePacket(identifier, content)
+}
+
+// DecodePacket decodes a single BER encoded packet.
+func DecodePacket(bs []byte) (*Packet, error) {
+	packets, err := decodePackets(bs)
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) != 1 {
+		return nil, fmt.Errorf("ber: expected a single packet")
+	}
+	return packets[0], nil
+}
+
+func decodePackets(bs []byte) ([]*Packet, error) {
+	var packets []*Packet
+	r := bufio.NewReader(bytes.NewReader(bs))
+	for {
+		p, err := ReadPacket(r)
+		if errors.Is(err, io.EOF) {
+			return packets, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("ber: invalid packet: %w", err)
+		}
+		packets = append(packets, p)
+	}
+}
+
+func parsePacket(identifier byte, content []byte) (*Packet, error) {
+	if identifier&0x1f == 0x1f {
+		return nil, fmt.Errorf("ber: high tag numbers are not supported")
+	}
+	p := &Packet{
+		Class:       identifier & 0xc0,
+		Constructed: identifier&0x20 != 0,
+		Tag:         identifier & 0x1f,
+	}
+	if !p.Constructed {
+		p.Value = content
+		return p, nil
+	}
+	children, err := decodePackets(content)
+	if err != nil {
+		return nil, err
+	}
+	p.Children = children
+	return p, nil
+}
+
+func readLength(r *bufio.Reader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b&0x80 == 0 {
+		return int(b), nil
+	}
+	n := int(b & 0x7f)
+	if n == 0 || n > 4 {
+		return 0, fmt.Errorf("ber: unsupported length encoding")
+	}
+	length := 0
+	for i := 0; i < n; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		length = length<<8 | int(b)
+	}
+	if length > maxPacketSize {
+		return 0, fmt.Errorf("ber: packet too large")
+	}
+	return length, nil
+}
@@ -0,0 +1,41 @@
+package ber
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeInteger(t *testing.T) {
+	for _, tc := range []struct {
+		value  int64
+		expect string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "0080"},
+		{256, "0100"},
+		{-1, "ff"},
+		{-129, "ff7f"},
+	} {
+		bs := EncodeInteger(tc.value)
+		assert.Equal(t, tc.expect, hex.EncodeToString(bs), "encode %d", tc.value)
+		value, err := DecodeInteger(bs)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, value, "decode %d", tc.value)
+	}
+}
+
+func TestPacket(t *testing.T) {
+	long := make([]byte, 300)
+	p := NewSequence(NewInteger(1), NewOctetString(string(long)), NewBoolean(true))
+	decoded, err := DecodePacket(p.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, p.Bytes(), decoded.Bytes())
+	assert.Len(t, decoded.Child(1).Value, 300)
+
+	_, err = DecodePacket(p.Bytes()[:10])
+	assert.Error(t, err)
+}
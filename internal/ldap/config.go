@@ -0,0 +1,140 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// Name is the name of the LDAP identity and directory provider.
+const Name = "ldap"
+
+const defaultPageSize = 500
+
+// A ServiceAccount configures how the LDAP directory is queried. It is shared by the
+// LDAP identity provider and the LDAP directory provider.
+type ServiceAccount struct {
+	// BindDN and BindPassword are the credentials used to search the directory.
+	BindDN       string `json:"bind_dn"`
+	BindPassword string `json:"bind_password"`
+	// BaseDN is the base DN for user and group searches.
+	BaseDN      string `json:"base_dn"`
+	UserBaseDN  string `json:"user_base_dn"`
+	GroupBaseDN string `json:"group_base_dn"`
+
+	UserFilter         string `json:"user_filter"`
+	UserIDAttribute    string `json:"user_id_attribute"`
+	UserLoginAttribute string `json:"user_login_attribute"`
+	UserEmailAttribute string `json:"user_email_attribute"`
+	UserNameAttribute  string `json:"user_name_attribute"`
+
+	GroupFilter          string `json:"group_filter"`
+	GroupNameAttribute   string `json:"group_name_attribute"`
+	GroupMemberAttribute string `json:"group_member_attribute"`
+
+	// CA is a base64 encoded PEM certificate authority used to verify the LDAP server.
+	CA       string `json:"ca"`
+	PageSize int    `json:"page_size"`
+}
+
+// ParseServiceAccount parses the service account in the config options and sets defaults.
+func ParseServiceAccount(rawServiceAccount string) (*ServiceAccount, error) {
+	var serviceAccount ServiceAccount
+	if err := encoding.DecodeBase64OrJSON(rawServiceAccount, &serviceAccount); err != nil {
+		return nil, err
+	}
+
+	if serviceAccount.BaseDN == "" {
+		return nil, fmt.Errorf("base_dn is required")
+	}
+	if serviceAccount.BindDN != "" && serviceAccount.BindPassword == "" {
+		return nil, fmt.Errorf("bind_password is required when bind_dn is set")
+	}
+
+	setDefault := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	setDefault(&serviceAccount.UserBaseDN, serviceAccount.BaseDN)
+	setDefault(&serviceAccount.GroupBaseDN, serviceAccount.BaseDN)
+	setDefault(&serviceAccount.UserFilter, "(objectClass=person)")
+	setDefault(&serviceAccount.UserIDAttribute, "uid")
+	setDefault(&serviceAccount.UserLoginAttribute, serviceAccount.UserIDAttribute)
+	setDefault(&serviceAccount.UserEmailAttribute, "mail")
+	setDefault(&serviceAccount.UserNameAttribute, "cn")
+	setDefault(&serviceAccount.GroupFilter, "(|(objectClass=group)(objectClass=groupOfNames)(objectClass=groupOfUniqueNames))")
+	setDefault(&serviceAccount.GroupNameAttribute, "cn")
+	setDefault(&serviceAccount.GroupMemberAttribute, "member")
+	if serviceAccount.PageSize <= 0 {
+		serviceAccount.PageSize = defaultPageSize
+	}
+
+	return &serviceAccount, nil
+}
+
+// Connect connects to the LDAP server and binds using the service account credentials, if set.
+func (serviceAccount *ServiceAccount) Connect(ctx context.Context, rawURL string) (*Conn, error) {
+	tlsConfig := new(tls.Config)
+	if serviceAccount.CA != "" {
+		rootCAs, err := cryptutil.GetCertPool(serviceAccount.CA, "")
+		if err != nil {
+			return nil, fmt.Errorf("ldap: invalid ca: %w", err)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	conn, err := Dial(ctx, rawURL, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if serviceAccount.BindDN != "" {
+		if err := conn.Bind(ctx, serviceAccount.BindDN, serviceAccount.BindPassword); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("ldap: service account bind failed: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// UserAttributes returns the attributes to request for user entries.
+func (serviceAccount *ServiceAccount) UserAttributes() []string {
+	return []string{
+		serviceAccount.UserIDAttribute,
+		serviceAccount.UserEmailAttribute,
+		serviceAccount.UserNameAttribute,
+	}
+}
+
+// FindUser finds the user entry with the given attribute value. An error is returned if
+// there is not exactly one matching user.
+func (serviceAccount *ServiceAccount) FindUser(ctx context.Context, conn *Conn, attribute, value string) (*Entry, error) {
+	entries, err := conn.Search(ctx, &SearchRequest{
+		BaseDN:     serviceAccount.UserBaseDN,
+		Scope:      ScopeWholeSubtree,
+		Filter:     fmt.Sprintf("(&%s(%s=%s))", wrapFilter(serviceAccount.UserFilter), attribute, EscapeFilter(value)),
+		Attributes: serviceAccount.UserAttributes(),
+		SizeLimit:  2,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(entries) {
+	case 0:
+		return nil, fmt.Errorf("ldap: user not found")
+	case 1:
+		return entries[0], nil
+	default:
+		return nil, fmt.Errorf("ldap: multiple users found")
+	}
+}
+
+func wrapFilter(filter string) string {
+	if len(filter) > 0 && filter[0] == '(' {
+		return filter
+	}
+	return "(" + filter + ")"
+}
@@ -0,0 +1,385 @@
+// Package ldap implements a minimal LDAPv3 client supporting simple binds and paged searches.
+//
+// https://datatracker.ietf.org/doc/html/rfc4511
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/ldap/ber"
+)
+
+// protocol operations
+const (
+	appBindRequest           = 0
+	appBindResponse          = 1
+	appUnbindRequest         = 2
+	appSearchRequest         = 3
+	appSearchResultEntry     = 4
+	appSearchResultDone      = 5
+	appSearchResultReference = 19
+)
+
+// controlPagedResults is the simple paged results control.
+//
+// https://datatracker.ietf.org/doc/html/rfc2696
+const controlPagedResults = "1.2.840.113556.1.4.319"
+
+// A Scope is the scope of a search.
+type Scope int
+
+// scopes
+const (
+	ScopeBaseObject   Scope = 0
+	ScopeSingleLevel  Scope = 1
+	ScopeWholeSubtree Scope = 2
+)
+
+// result codes
+const (
+	ResultSuccess            = 0
+	ResultSizeLimitExceeded  = 4
+	ResultInvalidCredentials = 49
+)
+
+// An Error is an error returned by the LDAP server.
+type Error struct {
+	ResultCode int
+	Message    string
+}
+
+// Error returns the error message.
+func (err *Error) Error() string {
+	if err.Message == "" {
+		return fmt.Sprintf("ldap: result code %d", err.ResultCode)
+	}
+	return fmt.Sprintf("ldap: result code %d: %s", err.ResultCode, err.Message)
+}
+
+// IsInvalidCredentials returns true if the error is an invalid credentials error.
+func IsInvalidCredentials(err error) bool {
+	var ldapErr *Error
+	return errors.As(err, &ldapErr) && ldapErr.ResultCode == ResultInvalidCredentials
+}
+
+// A Conn is a connection to an LDAP server. Operations are performed one at a time.
+type Conn struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	r      *bufio.Reader
+	nextID int64
+}
+
+// Dial connects to the LDAP server at the given url. Both ldap:// and ldaps:// urls are supported.
+func Dial(ctx context.Context, rawURL string, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid url: %w", err)
+	}
+
+	host := u.Host
+	var conn net.Conn
+	var dialer net.Dialer
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "389")
+		}
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	case "ldaps":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "636")
+		}
+		if tlsConfig == nil {
+			tlsConfig = new(tls.Config)
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = u.Hostname()
+		}
+		conn, err = (&tls.Dialer{NetDialer: &dialer, Config: tlsConfig}).DialContext(ctx, "tcp", host)
+	default:
+		return nil, fmt.Errorf("ldap: unsupported url scheme: %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap: error connecting to %s: %w", host, err)
+	}
+
+	return NewConn(conn), nil
+}
+
+// NewConn creates a new Conn from an existing network connection.
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{
+		conn:   conn,
+		r:      bufio.NewReader(conn),
+		nextID: 1,
+	}
+}
+
+// Close sends an unbind request and closes the connection.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, _ = c.conn.Write(c.message(ber.NewPrimitive(ber.ClassApplication, appUnbindRequest, nil)).Bytes())
+	return c.conn.Close()
+}
+
+// Bind performs a simple bind with the given DN and password. Empty passwords are rejected
+// because they result in an unauthenticated bind which most servers treat as successful.
+func (c *Conn) Bind(ctx context.Context, dn, password string) error {
+	if password == "" {
+		return &Error{ResultCode: ResultInvalidCredentials, Message: "empty password"}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := ber.NewConstructed(ber.ClassApplication, appBindRequest,
+		ber.NewInteger(3),
+		ber.NewOctetString(dn),
+		ber.NewPrimitive(ber.ClassContext, 0, []byte(password)),
+	)
+	var res *ber.Packet
+	err := c.do(ctx, req, nil, func(op *ber.Packet, _ *ber.Packet) (bool, error) {
+		res = op
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Is(ber.ClassApplication, appBindResponse) {
+		return fmt.Errorf("ldap: unexpected response to bind request")
+	}
+	return resultError(res)
+}
+
+// A SearchRequest is a request to search the directory.
+type SearchRequest struct {
+	BaseDN     string
+	Scope      Scope
+	Filter     string
+	Attributes []string
+	// SizeLimit limits the number of entries returned. 0 means no limit.
+	SizeLimit int
+	// PageSize enables the paged results control when greater than zero.
+	PageSize int
+}
+
+// An Entry is a directory entry returned by a search.
+type Entry struct {
+	DN         string
+	Attributes []*EntryAttribute
+}
+
+// An EntryAttribute is an attribute of an entry.
+type EntryAttribute struct {
+	Name   string
+	Values []string
+}
+
+// GetAttributeValues returns the values of the attribute with the given name. Attribute names are case-insensitive.
+func (e *Entry) GetAttributeValues(name string) []string {
+	for _, attr := range e.Attributes {
+		if strings.EqualFold(attr.Name, name) {
+			return attr.Values
+		}
+	}
+	return nil
+}
+
+// GetAttributeValue returns the first value of the attribute with the given name.
+func (e *Entry) GetAttributeValue(name string) string {
+	values := e.GetAttributeValues(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Search searches the directory. If a page size is set, all the pages are retrieved.
+func (c *Conn) Search(ctx context.Context, req *SearchRequest) ([]*Entry, error) {
+	filter, err := compileFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	attributes := ber.NewSequence()
+	for _, attr := range req.Attributes {
+		attributes.Children = append(attributes.Children, ber.NewOctetString(attr))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries []*Entry
+	var cookie []byte
+	for {
+		op := ber.NewConstructed(ber.ClassApplication, appSearchRequest,
+			ber.NewOctetString(req.BaseDN),
+			ber.NewEnumerated(int64(req.Scope)),
+			ber.NewEnumerated(0), // never dereference aliases
+			ber.NewInteger(int64(req.SizeLimit)),
+			ber.NewInteger(0),
+			ber.NewBoolean(false),
+			filter,
+			attributes,
+		)
+		var controls *ber.Packet
+		if req.PageSize > 0 {
+			controls = ber.NewConstructed(ber.ClassContext, 0, ber.NewSequence(
+				ber.NewOctetString(controlPagedResults),
+				ber.NewOctetString(string(ber.NewSequence(
+					ber.NewInteger(int64(req.PageSize)),
+					ber.NewOctetString(string(cookie)),
+				).Bytes())),
+			))
+		}
+
+		var done *ber.Packet
+		err := c.do(ctx, op, controls, func(res *ber.Packet, resControls *ber.Packet) (bool, error) {
+			switch {
+			case res.Is(ber.ClassApplication, appSearchResultEntry):
+				entry, err := parseEntry(res)
+				if err != nil {
+					return false, err
+				}
+				entries = append(entries, entry)
+				return false, nil
+			case res.Is(ber.ClassApplication, appSearchResultReference):
+				// referrals are not followed
+				return false, nil
+			case res.Is(ber.ClassApplication, appSearchResultDone):
+				done = res
+				var cookieErr error
+				cookie, cookieErr = getPagedResultsCookie(resControls)
+				return true, cookieErr
+			}
+			return false, fmt.Errorf("ldap: unexpected response to search request")
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := resultError(done); err != nil {
+			var ldapErr *Error
+			if errors.As(err, &ldapErr) && ldapErr.ResultCode == ResultSizeLimitExceeded && req.SizeLimit > 0 {
+				return entries, nil
+			}
+			return nil, err
+		}
+		if req.PageSize <= 0 || len(cookie) == 0 {
+			return entries, nil
+		}
+	}
+}
+
+// do sends a request and calls handle for every response with the same message id until handle returns true.
+func (c *Conn) do(ctx context.Context, op, controls *ber.Packet, handle func(op, controls *ber.Packet) (bool, error)) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetDeadline(deadline)
+	} else {
+		_ = c.conn.SetDeadline(time.Time{})
+	}
+
+	id := c.nextID
+	msg := c.message(op)
+	if controls != nil {
+		msg.Children = append(msg.Children, controls)
+	}
+	if _, err := c.conn.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("ldap: error sending request: %w", err)
+	}
+
+	for {
+		res, err := ber.ReadPacket(c.r)
+		if err != nil {
+			return fmt.Errorf("ldap: error reading response: %w", err)
+		}
+		if !res.Is(ber.ClassUniversal, ber.TagSequence) || len(res.Children) < 2 {
+			return fmt.Errorf("ldap: invalid response")
+		}
+		resID, err := res.Child(0).Integer()
+		if err != nil {
+			return err
+		}
+		if resID != id {
+			// unsolicited notifications use message id 0
+			if resID == 0 {
+				return fmt.Errorf("ldap: server sent notice of disconnection")
+			}
+			continue
+		}
+		var resControls *ber.Packet
+		if c := res.Child(2); c.Is(ber.ClassContext, 0) {
+			resControls = c
+		}
+		finished, err := handle(res.Child(1), resControls)
+		if err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+	}
+}
+
+func (c *Conn) message(op *ber.Packet) *ber.Packet {
+	id := c.nextID
+	c.nextID++
+	return ber.NewSequence(ber.NewInteger(id), op)
+}
+
+func resultError(res *ber.Packet) error {
+	code, err := res.Child(0).Integer()
+	if err != nil {
+		return fmt.Errorf("ldap: invalid result: %w", err)
+	}
+	if code == ResultSuccess {
+		return nil
+	}
+	return &Error{ResultCode: int(code), Message: res.Child(2).Str()}
+}
+
+func parseEntry(res *ber.Packet) (*Entry, error) {
+	entry := &Entry{DN: res.Child(0).Str()}
+	for _, attr := range res.Child(1).Children {
+		if len(attr.Children) < 2 {
+			return nil, fmt.Errorf("ldap: invalid entry attribute")
+		}
+		ea := &EntryAttribute{Name: attr.Child(0).Str()}
+		for _, value := range attr.Child(1).Children {
+			ea.Values = append(ea.Values, value.Str())
+		}
+		entry.Attributes = append(entry.Attributes, ea)
+	}
+	return entry, nil
+}
+
+func getPagedResultsCookie(controls *ber.Packet) ([]byte, error) {
+	if controls == nil {
+		return nil, nil
+	}
+	for _, control := range controls.Children {
+		if control.Child(0).Str() != controlPagedResults {
+			continue
+		}
+		// the value is the last element, criticality is optional
+		value, err := ber.DecodePacket(control.Child(len(control.Children) - 1).Value)
+		if err != nil {
+			return nil, err
+		}
+		return value.Child(1).Value, nil
+	}
+	return nil, nil
+}
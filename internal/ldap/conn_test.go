@@ -0,0 +1,81 @@
+package ldap_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/ldap"
+	"github.com/pomerium/pomerium/internal/testutil"
+)
+
+func TestConn(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	var entries []*ldap.Entry
+	for i := 0; i < 25; i++ {
+		entries = append(entries, &ldap.Entry{
+			DN: fmt.Sprintf("uid=user%d,ou=people,dc=example,dc=com", i),
+			Attributes: []*ldap.EntryAttribute{
+				{Name: "objectClass", Values: []string{"person"}},
+				{Name: "uid", Values: []string{fmt.Sprintf("user%d", i)}},
+				{Name: "mail", Values: []string{fmt.Sprintf("user%d@example.com", i)}},
+			},
+		})
+	}
+	srv, err := testutil.NewLDAPServer(entries, map[string]string{
+		"uid=user1,ou=people,dc=example,dc=com": "password",
+	})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	conn, err := ldap.Dial(ctx, srv.URL(), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("bind", func(t *testing.T) {
+		assert.NoError(t, conn.Bind(ctx, "uid=user1,ou=people,dc=example,dc=com", "password"))
+		err := conn.Bind(ctx, "uid=user1,ou=people,dc=example,dc=com", "wrong")
+		assert.True(t, ldap.IsInvalidCredentials(err), "should return invalid credentials, got: %v", err)
+		err = conn.Bind(ctx, "uid=user1,ou=people,dc=example,dc=com", "")
+		assert.True(t, ldap.IsInvalidCredentials(err), "should reject empty passwords")
+	})
+	t.Run("search", func(t *testing.T) {
+		found, err := conn.Search(ctx, &ldap.SearchRequest{
+			BaseDN:     "dc=example,dc=com",
+			Scope:      ldap.ScopeWholeSubtree,
+			Filter:     "(&(objectClass=person)(uid=user3))",
+			Attributes: []string{"mail"},
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "uid=user3,ou=people,dc=example,dc=com", found[0].DN)
+		assert.Equal(t, "user3@example.com", found[0].GetAttributeValue("MAIL"))
+		assert.Empty(t, found[0].GetAttributeValue("uid"))
+	})
+	t.Run("paged search", func(t *testing.T) {
+		found, err := conn.Search(ctx, &ldap.SearchRequest{
+			BaseDN:   "dc=example,dc=com",
+			Scope:    ldap.ScopeWholeSubtree,
+			Filter:   "(objectClass=person)",
+			PageSize: 10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, found, 25)
+	})
+	t.Run("size limit", func(t *testing.T) {
+		found, err := conn.Search(ctx, &ldap.SearchRequest{
+			BaseDN:    "dc=example,dc=com",
+			Scope:     ldap.ScopeWholeSubtree,
+			Filter:    "(uid=user*)",
+			SizeLimit: 2,
+		})
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+	})
+}
@@ -0,0 +1,232 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/ldap/ber"
+)
+
+// filter choices
+const (
+	filterAnd             = 0
+	filterOr              = 1
+	filterNot             = 2
+	filterEqualityMatch   = 3
+	filterSubstrings      = 4
+	filterGreaterOrEqual  = 5
+	filterLessOrEqual     = 6
+	filterPresent         = 7
+	filterApproxMatch     = 8
+	filterExtensibleMatch = 9
+)
+
+// EscapeFilter escapes a value so that it can be safely used in a filter.
+//
+// https://datatracker.ietf.org/doc/html/rfc4515#section-3
+func EscapeFilter(value string) string {
+	var sb strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&sb, `\%02x`, c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// compileFilter compiles the string representation of a search filter into its BER encoding.
+//
+// https://datatracker.ietf.org/doc/html/rfc4515
+func compileFilter(filter string) (*ber.Packet, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return nil, fmt.Errorf("ldap: empty filter")
+	}
+	if filter[0] != '(' {
+		filter = "(" + filter + ")"
+	}
+	p, rest, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("ldap: invalid filter: unexpected trailing characters: %s", rest)
+	}
+	return p, nil
+}
+
+func parseFilter(s string) (*ber.Packet, string, error) {
+	if len(s) == 0 || s[0] != '(' {
+		return nil, "", fmt.Errorf("ldap: invalid filter: expected (")
+	}
+	s = s[1:]
+	if len(s) == 0 {
+		return nil, "", fmt.Errorf("ldap: invalid filter: unexpected end")
+	}
+
+	var p *ber.Packet
+	var err error
+	switch s[0] {
+	case '&', '|':
+		tag := byte(filterAnd)
+		if s[0] == '|' {
+			tag = filterOr
+		}
+		p = ber.NewConstructed(ber.ClassContext, tag)
+		s = s[1:]
+		for len(s) > 0 && s[0] == '(' {
+			var child *ber.Packet
+			child, s, err = parseFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			p.Children = append(p.Children, child)
+		}
+	case '!':
+		var child *ber.Packet
+		child, s, err = parseFilter(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		p = ber.NewConstructed(ber.ClassContext, filterNot, child)
+	default:
+		end := strings.IndexByte(s, ')')
+		if end < 0 {
+			return nil, "", fmt.Errorf("ldap: invalid filter: missing )")
+		}
+		p, err = parseItem(s[:end])
+		if err != nil {
+			return nil, "", err
+		}
+		s = s[end:]
+	}
+
+	if len(s) == 0 || s[0] != ')' {
+		return nil, "", fmt.Errorf("ldap: invalid filter: missing )")
+	}
+	return p, s[1:], nil
+}
+
+func parseItem(item string) (*ber.Packet, error) {
+	idx := strings.IndexByte(item, '=')
+	if idx <= 0 {
+		return nil, fmt.Errorf("ldap: invalid filter item: %s", item)
+	}
+	attr, rawValue := item[:idx], item[idx+1:]
+
+	var tag byte = filterEqualityMatch
+	switch attr[len(attr)-1] {
+	case '>':
+		tag, attr = filterGreaterOrEqual, attr[:len(attr)-1]
+	case '<':
+		tag, attr = filterLessOrEqual, attr[:len(attr)-1]
+	case '~':
+		tag, attr = filterApproxMatch, attr[:len(attr)-1]
+	case ':':
+		return parseExtensibleItem(attr[:len(attr)-1], rawValue)
+	}
+	if attr == "" {
+		return nil, fmt.Errorf("ldap: invalid filter item: %s", item)
+	}
+
+	if tag == filterEqualityMatch {
+		if rawValue == "*" {
+			return ber.NewPrimitive(ber.ClassContext, filterPresent, []byte(attr)), nil
+		}
+		if strings.Contains(rawValue, "*") {
+			return parseSubstrings(attr, rawValue)
+		}
+	}
+
+	value, err := unescapeFilterValue(rawValue)
+	if err != nil {
+		return nil, err
+	}
+	return ber.NewConstructed(ber.ClassContext, tag, ber.NewOctetString(attr), ber.NewOctetString(value)), nil
+}
+
+func parseSubstrings(attr, rawValue string) (*ber.Packet, error) {
+	parts := strings.Split(rawValue, "*")
+	substrings := ber.NewSequence()
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		value, err := unescapeFilterValue(part)
+		if err != nil {
+			return nil, err
+		}
+		var tag byte = 1 // any
+		switch i {
+		case 0:
+			tag = 0 // initial
+		case len(parts) - 1:
+			tag = 2 // final
+		}
+		substrings.Children = append(substrings.Children, ber.NewPrimitive(ber.ClassContext, tag, []byte(value)))
+	}
+	return ber.NewConstructed(ber.ClassContext, filterSubstrings, ber.NewOctetString(attr), substrings), nil
+}
+
+// parseExtensibleItem parses an extensible match such as member:1.2.840.113556.1.4.1941:=cn=group.
+func parseExtensibleItem(attr, rawValue string) (*ber.Packet, error) {
+	value, err := unescapeFilterValue(rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(attr, ":")
+	var matchingRule, attributeType string
+	dnAttributes := false
+	attributeType, parts = parts[0], parts[1:]
+	for _, part := range parts {
+		if strings.EqualFold(part, "dn") {
+			dnAttributes = true
+		} else {
+			matchingRule = part
+		}
+	}
+	if matchingRule == "" && attributeType == "" {
+		return nil, fmt.Errorf("ldap: invalid extensible filter: %s", attr)
+	}
+
+	p := ber.NewConstructed(ber.ClassContext, filterExtensibleMatch)
+	if matchingRule != "" {
+		p.Children = append(p.Children, ber.NewPrimitive(ber.ClassContext, 1, []byte(matchingRule)))
+	}
+	if attributeType != "" {
+		p.Children = append(p.Children, ber.NewPrimitive(ber.ClassContext, 2, []byte(attributeType)))
+	}
+	p.Children = append(p.Children, ber.NewPrimitive(ber.ClassContext, 3, []byte(value)))
+	if dnAttributes {
+		p.Children = append(p.Children, ber.NewPrimitive(ber.ClassContext, 4, []byte{0xff}))
+	}
+	return p, nil
+}
+
+func unescapeFilterValue(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+3 > len(s) {
+			return "", fmt.Errorf("ldap: invalid filter escape sequence")
+		}
+		bs, err := hex.DecodeString(s[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("ldap: invalid filter escape sequence: %w", err)
+		}
+		sb.Write(bs)
+		i += 2
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,46 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/ldap/ber"
+)
+
+func TestCompileFilter(t *testing.T) {
+	for _, tc := range []struct {
+		filter string
+		expect string
+	}{
+		{"(cn=John)", "a30a0402636e04044a6f686e"},
+		{"cn=John", "a30a0402636e04044a6f686e"},
+		{"(objectClass=*)", "870b6f626a656374436c617373"},
+		{"(cn=J*n)", "a40c0402636e300680014a82016e"},
+		{"(&(cn=a)(!(sn=b)))", "a014a3070402636e040161a209a3070402736e040162"},
+		{"(cn=\\2a)", "a3070402636e04012a"},
+	} {
+		p, err := compileFilter(tc.filter)
+		if assert.NoError(t, err, tc.filter) {
+			assert.Equal(t, tc.expect, hex.EncodeToString(p.Bytes()), tc.filter)
+		}
+	}
+
+	p, err := compileFilter("(member:1.2.840.113556.1.4.1941:=cn=g)")
+	require.NoError(t, err)
+	assert.True(t, p.Is(ber.ClassContext, filterExtensibleMatch))
+	assert.Equal(t, "1.2.840.113556.1.4.1941", p.Child(0).Str())
+	assert.Equal(t, "member", p.Child(1).Str())
+	assert.Equal(t, "cn=g", p.Child(2).Str())
+
+	for _, filter := range []string{"", "(cn=a", "(cn=a))", "(=a)", `(cn=\zz)`} {
+		_, err := compileFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestEscapeFilter(t *testing.T) {
+	assert.Equal(t, `a\2a\28b\29\5c`, EscapeFilter(`a*(b)\`))
+}
@@ -0,0 +1,209 @@
+package log
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/zap/zapcore"
+)
+
+// A ModuleLevel is the log level of a module, which overrides the global log level.
+type ModuleLevel struct {
+	Level zerolog.Level
+	// Expires is the time after which the global log level applies again. It is zero when the
+	// level doesn't expire.
+	Expires time.Time
+}
+
+// levels holds the global log level, the module levels and the sample rates. It's replaced on
+// every change, so that it can be read by the hook without locks.
+type levels struct {
+	global   zerolog.Level
+	modules  map[string]ModuleLevel
+	sampling map[string]float64
+}
+
+var (
+	levelsMu      sync.Mutex
+	currentLevels = func() *atomic.Value {
+		v := new(atomic.Value)
+		v.Store(&levels{global: zerolog.GlobalLevel()})
+		return v
+	}()
+	// expiryTimers are guarded by levelsMu
+	expiryTimers = map[string]*time.Timer{}
+
+	sampleMu   sync.Mutex
+	sampleRand = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+)
+
+func loadLevels() *levels {
+	return currentLevels.Load().(*levels)
+}
+
+// updateLevels applies the change to a copy of the levels and sets the zerolog global level to
+// the lowest level, so that events of modules with a lower level than the global one aren't
+// dropped before the hook sees them.
+func updateLevels(f func(l *levels)) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	prev := loadLevels()
+	next := &levels{
+		global:   prev.global,
+		modules:  make(map[string]ModuleLevel, len(prev.modules)),
+		sampling: prev.sampling,
+	}
+	for k, v := range prev.modules {
+		next.modules[k] = v
+	}
+	f(next)
+
+	lowest := next.global
+	for _, ml := range next.modules {
+		if ml.Level < lowest {
+			lowest = ml.Level
+		}
+	}
+	zerolog.SetGlobalLevel(lowest)
+	currentLevels.Store(next)
+}
+
+func setGlobalLevel(level zerolog.Level) {
+	updateLevels(func(l *levels) {
+		l.global = level
+	})
+	switch level {
+	case zerolog.InfoLevel:
+		zapLevel.SetLevel(zapcore.InfoLevel)
+	case zerolog.WarnLevel:
+		zapLevel.SetLevel(zapcore.WarnLevel)
+	case zerolog.ErrorLevel:
+		zapLevel.SetLevel(zapcore.ErrorLevel)
+	default:
+		zapLevel.SetLevel(zapcore.DebugLevel)
+	}
+}
+
+// SetModuleLevel sets the log level of a module. A module is the first word of a log message,
+// before any ':' (e.g. "authorize" for "authorize: error loading session"). If duration is
+// positive, the global log level applies again to the module once it elapses.
+func SetModuleLevel(module string, level zerolog.Level, duration time.Duration) {
+	updateLevels(func(l *levels) {
+		stopExpiryTimer(module)
+		ml := ModuleLevel{Level: level}
+		if duration > 0 {
+			ml.Expires = time.Now().Add(duration)
+			expiryTimers[module] = time.AfterFunc(duration, func() {
+				updateLevels(func(l *levels) {
+					// the level may have been changed since the timer was started
+					if l.modules[module] == ml {
+						delete(l.modules, module)
+						delete(expiryTimers, module)
+					}
+				})
+			})
+		}
+		l.modules[module] = ml
+	})
+}
+
+// ResetModuleLevel removes the log level of a module.
+func ResetModuleLevel(module string) {
+	updateLevels(func(l *levels) {
+		stopExpiryTimer(module)
+		delete(l.modules, module)
+	})
+}
+
+func stopExpiryTimer(module string) {
+	if t, ok := expiryTimers[module]; ok {
+		t.Stop()
+		delete(expiryTimers, module)
+	}
+}
+
+// GetGlobalLevel returns the global log level.
+func GetGlobalLevel() zerolog.Level {
+	return loadLevels().global
+}
+
+// GetModuleLevels returns the log levels of the modules.
+func GetModuleLevels() map[string]ModuleLevel {
+	modules := map[string]ModuleLevel{}
+	for k, v := range loadLevels().modules {
+		modules[k] = v
+	}
+	return modules
+}
+
+// GetModuleLevel returns the log level which applies to a module.
+func GetModuleLevel(module string) zerolog.Level {
+	l := loadLevels()
+	if ml, ok := l.modules[module]; ok {
+		return ml.Level
+	}
+	return l.global
+}
+
+// SetSampling sets the rates, between 0 and 1, at which the events of a category are logged. A
+// category is either a module or a category checked with Sample. Warnings and errors of a
+// module are never sampled.
+func SetSampling(rates map[string]float64) {
+	sampling := make(map[string]float64, len(rates))
+	for k, v := range rates {
+		sampling[k] = v
+	}
+	updateLevels(func(l *levels) {
+		l.sampling = sampling
+	})
+}
+
+// Sample reports whether an event of the category should be logged.
+func Sample(category string) bool {
+	rate, ok := loadLevels().sampling[category]
+	if !ok || rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	sampleMu.Lock()
+	n := sampleRand.Float64()
+	sampleMu.Unlock()
+	return n < rate
+}
+
+// levelHook drops the events below the level of their module, and samples them.
+type levelHook struct{}
+
+func (levelHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	l := loadLevels()
+	if len(l.modules) == 0 && len(l.sampling) == 0 {
+		return
+	}
+
+	module := moduleOf(msg)
+	min := l.global
+	if ml, ok := l.modules[module]; ok {
+		min = ml.Level
+	}
+	if level < min {
+		e.Discard()
+		return
+	}
+	if level < zerolog.WarnLevel && !Sample(module) {
+		e.Discard()
+	}
+}
+
+func moduleOf(msg string) string {
+	if i := strings.IndexAny(msg, ": "); i >= 0 {
+		return msg[:i]
+	}
+	return msg
+}
@@ -0,0 +1,69 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := zerolog.New(&buf)
+	SetLogger(&l)
+	defer DisableDebug()
+	SetLevel("info")
+	defer SetLevel("debug")
+
+	ctx := context.Background()
+	SetModuleLevel("authorize", zerolog.DebugLevel, 0)
+	SetModuleLevel("proxy", zerolog.ErrorLevel, 0)
+	Debug(ctx).Msg("authorize: debug")
+	Debug(ctx).Msg("authenticate: debug")
+	Info(ctx).Msg("authenticate: info")
+	Warn(ctx).Msg("proxy: warn")
+	Error(ctx).Msg("proxy: error")
+	assert.Equal(t, ""+
+		`{"level":"debug","message":"authorize: debug"}`+"\n"+
+		`{"level":"info","message":"authenticate: info"}`+"\n"+
+		`{"level":"error","message":"proxy: error"}`+"\n", buf.String())
+	assert.Equal(t, zerolog.DebugLevel, GetModuleLevel("authorize"))
+	assert.Equal(t, zerolog.InfoLevel, GetModuleLevel("authenticate"))
+
+	ResetModuleLevel("proxy")
+	ResetModuleLevel("authorize")
+	assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+
+	t.Run("expires", func(t *testing.T) {
+		SetModuleLevel("authorize", zerolog.DebugLevel, 10*time.Millisecond)
+		assert.Contains(t, GetModuleLevels(), "authorize")
+		assert.Eventually(t, func() bool {
+			return len(GetModuleLevels()) == 0
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+	})
+}
+
+func TestSampling(t *testing.T) {
+	var buf bytes.Buffer
+	l := zerolog.New(&buf)
+	SetLogger(&l)
+	defer DisableDebug()
+
+	SetSampling(map[string]float64{"authorize": 0, "proxy": 1, "category": 0})
+	defer SetSampling(nil)
+
+	ctx := context.Background()
+	Info(ctx).Msg("authorize check")
+	Warn(ctx).Msg("authorize: warn")
+	Info(ctx).Msg("proxy: info")
+	assert.Equal(t, ""+
+		`{"level":"warn","message":"authorize: warn"}`+"\n"+
+		`{"level":"info","message":"proxy: info"}`+"\n", buf.String())
+
+	assert.False(t, Sample("category"))
+	assert.True(t, Sample("other"))
+}
@@ -50,7 +50,8 @@ func EnableDebug() {
 
 // SetLogger sets zerolog the logger.
 func SetLogger(l *zerolog.Logger) {
-	logger.Store(l)
+	hooked := l.Hook(levelHook{})
+	logger.Store(&hooked)
 }
 
 // Logger returns the global logger.
@@ -64,21 +65,23 @@ func ZapLogger() *zap.Logger {
 }
 
 // SetLevel sets the minimum global log level. Options are 'debug' 'info' 'warn' and 'error'.
-// Defaults to 'debug'
+// Defaults to 'debug'. The levels set with SetModuleLevel take precedence.
 func SetLevel(level string) {
+	setGlobalLevel(ParseLevel(level))
+}
+
+// ParseLevel parses a log level. Options are 'debug' 'info' 'warn' and 'error'. Defaults to
+// 'debug'.
+func ParseLevel(level string) zerolog.Level {
 	switch level {
 	case "info":
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
-		zapLevel.SetLevel(zapcore.InfoLevel)
+		return zerolog.InfoLevel
 	case "warn":
-		zerolog.SetGlobalLevel(zerolog.WarnLevel)
-		zapLevel.SetLevel(zapcore.WarnLevel)
+		return zerolog.WarnLevel
 	case "error":
-		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
-		zapLevel.SetLevel(zapcore.ErrorLevel)
+		return zerolog.ErrorLevel
 	default:
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-		zapLevel.SetLevel(zapcore.DebugLevel)
+		return zerolog.DebugLevel
 	}
 }
 
@@ -3,6 +3,7 @@ package middleware
 import (
 	"crypto/sha256"
 	"crypto/subtle"
+	"errors"
 	"net/http"
 
 	"github.com/pomerium/pomerium/internal/httputil"
@@ -25,13 +26,13 @@ func SetHeaders(headers map[string]string) func(next http.Handler) http.Handler
 }
 
 // ValidateSignature ensures the request is valid and has been signed with
-// the correspdoning client secret key
-func ValidateSignature(sharedKey []byte) func(next http.Handler) http.Handler {
+// one of the corresponding shared keys
+func ValidateSignature(sharedKeys ...[]byte) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
 			ctx, span := trace.StartSpan(r.Context(), "middleware.ValidateSignature")
 			defer span.End()
-			if err := ValidateRequestURL(r, sharedKey); err != nil {
+			if err := ValidateRequestURL(r, sharedKeys...); err != nil {
 				return httputil.NewError(http.StatusBadRequest, err)
 			}
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -41,9 +42,22 @@ func ValidateSignature(sharedKey []byte) func(next http.Handler) http.Handler {
 }
 
 // ValidateRequestURL validates the current absolute request URL was signed
-// by a given shared key.
-func ValidateRequestURL(r *http.Request, key []byte) error {
-	return urlutil.NewSignedURL(key, urlutil.GetAbsoluteURL(r)).Validate()
+// by one of the given shared keys. The error of the first key is returned.
+func ValidateRequestURL(r *http.Request, keys ...[]byte) error {
+	var firstErr error
+	for _, key := range keys {
+		err := urlutil.NewSignedURL(key, urlutil.GetAbsoluteURL(r)).Validate()
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		firstErr = errors.New("internal/middleware: no shared keys")
+	}
+	return firstErr
 }
 
 // RequireBasicAuth creates a new handler that requires basic auth from the client before
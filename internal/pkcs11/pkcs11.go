@@ -0,0 +1,67 @@
+// Package pkcs11 signs with private keys stored on a PKCS#11 token, like an HSM, so the keys
+// never leave the token.
+//
+// Support for PKCS#11 modules requires a build with cgo enabled.
+package pkcs11
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrUnsupported indicates PKCS#11 modules can't be loaded, since the binary was built without cgo.
+var ErrUnsupported = errors.New("pkcs11: support requires a build with cgo enabled")
+
+// Options are the options used to find a private key on a PKCS#11 token.
+type Options struct {
+	// ModulePath is the path of the PKCS#11 module, which is a shared library.
+	ModulePath string
+	// TokenLabel is the label of the token. When it's empty, the first token is used.
+	TokenLabel string
+	// PIN is the user PIN of the token.
+	PIN string
+	// KeyLabel is the label of the private key. The public key with the same ID, or with the
+	// same label, is published.
+	KeyLabel string
+}
+
+// p256OID is the object identifier of the P-256 curve, which is the curve of ES256 keys.
+var p256OID = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+
+// newECPublicKey returns the public key for the CKA_EC_PARAMS and CKA_EC_POINT attributes of
+// an EC key.
+func newECPublicKey(params, point []byte) (*ecdsa.PublicKey, error) {
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &curve); err != nil {
+		return nil, fmt.Errorf("pkcs11: invalid EC params: %w", err)
+	}
+	if !curve.Equal(p256OID) {
+		return nil, fmt.Errorf("pkcs11: unsupported EC curve: %s", curve)
+	}
+
+	// the point should be DER-encoded, but some modules return the raw point
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) > 0 {
+		raw = point
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), raw)
+	if x == nil {
+		return nil, fmt.Errorf("pkcs11: invalid EC point")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+// newRSAPublicKey returns the public key for the CKA_MODULUS and CKA_PUBLIC_EXPONENT attributes
+// of an RSA key.
+func newRSAPublicKey(modulus, exponent []byte) (*rsa.PublicKey, error) {
+	e := new(big.Int).SetBytes(exponent)
+	if len(modulus) == 0 || !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("pkcs11: invalid RSA public key")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(e.Int64())}, nil
+}
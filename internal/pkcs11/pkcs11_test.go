@@ -0,0 +1,114 @@
+package pkcs11
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+func TestNewECPublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	params, err := asn1.Marshal(p256OID)
+	require.NoError(t, err)
+	raw := elliptic.Marshal(elliptic.P256(), key.X, key.Y)
+	point, err := asn1.Marshal(raw)
+	require.NoError(t, err)
+
+	public, err := newECPublicKey(params, point)
+	assert.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(public))
+
+	public, err = newECPublicKey(params, raw)
+	assert.NoError(t, err, "should accept points which aren't DER-encoded")
+	assert.True(t, key.PublicKey.Equal(public))
+
+	p384Params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 34})
+	require.NoError(t, err)
+	_, err = newECPublicKey(p384Params, point)
+	assert.Error(t, err, "should reject other curves")
+
+	_, err = newECPublicKey(params, []byte{4, 1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestNewRSAPublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	public, err := newRSAPublicKey(key.N.Bytes(), big.NewInt(int64(key.E)).Bytes())
+	assert.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(public))
+
+	_, err = newRSAPublicKey(nil, big.NewInt(int64(key.E)).Bytes())
+	assert.Error(t, err)
+}
+
+type testSigner struct {
+	key    *ecdsa.PrivateKey
+	public *jose.JSONWebKey
+}
+
+func (s *testSigner) Public() *jose.JSONWebKey { return s.public }
+
+func (s *testSigner) Algs() []jose.SignatureAlgorithm { return []jose.SignatureAlgorithm{jose.ES256} }
+
+func (s *testSigner) SignPayload(payload []byte, _ jose.SignatureAlgorithm) ([]byte, error) {
+	digest := sha256.Sum256(payload)
+	r, ss, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	ss.FillBytes(signature[32:])
+	return signature, nil
+}
+
+func TestPrivateJWK(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	public, err := cryptutil.PublicJWKFromKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	expected, err := cryptutil.PublicJWKFromBytes(mustMarshalPublicKey(t, key))
+	require.NoError(t, err)
+	assert.Equal(t, expected.KeyID, public.KeyID, "should use the same key IDs as keys which aren't on a token")
+	assert.Equal(t, "ES256", public.Algorithm)
+
+	jwk := cryptutil.PrivateJWKFromSigner(&testSigner{key: key, public: public})
+	assert.Equal(t, public.KeyID, jwk.KeyID)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jwk}, nil)
+	require.NoError(t, err)
+	jws, err := signer.Sign([]byte("payload"))
+	require.NoError(t, err)
+	raw, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	jws, err = jose.ParseSigned(raw)
+	require.NoError(t, err)
+	assert.Equal(t, public.KeyID, jws.Signatures[0].Header.KeyID)
+
+	payload, err := jws.Verify(&key.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("payload"), payload)
+}
+
+func mustMarshalPublicKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	bs, err := cryptutil.EncodePublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return bs
+}
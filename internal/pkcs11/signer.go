@@ -0,0 +1,240 @@
+//go:build cgo
+// +build cgo
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-jose/go-jose/v3"
+	p11 "github.com/miekg/pkcs11"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+)
+
+// modules can only be initialized once per process, so modules and signers are shared
+var global = struct {
+	sync.Mutex
+	modules map[string]*p11.Ctx
+	signers map[Options]*signer
+}{
+	modules: map[string]*p11.Ctx{},
+	signers: map[Options]*signer{},
+}
+
+// GetSigner returns a signer for the private key on the token.
+func GetSigner(options Options) (jose.OpaqueSigner, error) {
+	global.Lock()
+	defer global.Unlock()
+
+	if s, ok := global.signers[options]; ok {
+		return s, nil
+	}
+
+	module, err := getModule(options.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSigner(module, options)
+	if err != nil {
+		return nil, err
+	}
+	global.signers[options] = s
+	return s, nil
+}
+
+func getModule(modulePath string) (*p11.Ctx, error) {
+	if module, ok := global.modules[modulePath]; ok {
+		return module, nil
+	}
+
+	module := p11.New(modulePath)
+	if module == nil {
+		return nil, fmt.Errorf("pkcs11: error loading module: %s", modulePath)
+	}
+	err := module.Initialize()
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		module.Destroy()
+		return nil, fmt.Errorf("pkcs11: error initializing module: %w", err)
+	}
+	global.modules[modulePath] = module
+	return module, nil
+}
+
+type signer struct {
+	module *p11.Ctx
+	key    p11.ObjectHandle
+	public *jose.JSONWebKey
+
+	// operations on a session can't be interleaved
+	mu      sync.Mutex
+	session p11.SessionHandle
+}
+
+func newSigner(module *p11.Ctx, options Options) (*signer, error) {
+	slot, err := findSlot(module, options.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := module.OpenSession(slot, p11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: error opening session: %w", err)
+	}
+	s := &signer{module: module, session: session}
+
+	err = module.Login(session, p11.CKU_USER, options.PIN)
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = module.CloseSession(session)
+		return nil, fmt.Errorf("pkcs11: error logging in: %w", err)
+	}
+
+	if err := s.load(options.KeyLabel); err != nil {
+		_ = module.CloseSession(session)
+		return nil, err
+	}
+	return s, nil
+}
+
+// findSlot returns the slot of the token with the label, or the first token when the label is
+// empty.
+func findSlot(module *p11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := module.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: error listing slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := module.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("pkcs11: error getting token info: %w", err)
+		}
+		if tokenLabel == "" || info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("pkcs11: token not found: %q", tokenLabel)
+}
+
+// load finds the private key with the label and its public key.
+func (s *signer) load(keyLabel string) error {
+	for _, keyType := range []uint{p11.CKK_EC, p11.CKK_RSA} {
+		key, ok, err := s.findObject(
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY),
+			p11.NewAttribute(p11.CKA_KEY_TYPE, keyType),
+			p11.NewAttribute(p11.CKA_LABEL, keyLabel),
+		)
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		s.key = key
+
+		public, err := s.loadPublicKey(keyType, keyLabel)
+		if err != nil {
+			return err
+		}
+		s.public, err = cryptutil.PublicJWKFromKey(public)
+		return err
+	}
+	return fmt.Errorf("pkcs11: no EC or RSA private key found: %q", keyLabel)
+}
+
+func (s *signer) loadPublicKey(keyType uint, keyLabel string) (crypto.PublicKey, error) {
+	template := []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+		p11.NewAttribute(p11.CKA_KEY_TYPE, keyType),
+	}
+	attrs, err := s.module.GetAttributeValue(s.session, s.key, []*p11.Attribute{p11.NewAttribute(p11.CKA_ID, nil)})
+	if err == nil && len(attrs[0].Value) > 0 {
+		template = append(template, p11.NewAttribute(p11.CKA_ID, attrs[0].Value))
+	} else {
+		template = append(template, p11.NewAttribute(p11.CKA_LABEL, keyLabel))
+	}
+
+	public, ok, err := s.findObject(template...)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("pkcs11: public key not found: %q", keyLabel)
+	}
+
+	switch keyType {
+	case p11.CKK_EC:
+		attrs, err := s.module.GetAttributeValue(s.session, public, []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_EC_PARAMS, nil),
+			p11.NewAttribute(p11.CKA_EC_POINT, nil),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pkcs11: error reading public key: %w", err)
+		}
+		return newECPublicKey(attrs[0].Value, attrs[1].Value)
+	default:
+		attrs, err := s.module.GetAttributeValue(s.session, public, []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_MODULUS, nil),
+			p11.NewAttribute(p11.CKA_PUBLIC_EXPONENT, nil),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("pkcs11: error reading public key: %w", err)
+		}
+		return newRSAPublicKey(attrs[0].Value, attrs[1].Value)
+	}
+}
+
+// findObject returns the first object matching the template.
+func (s *signer) findObject(template ...*p11.Attribute) (p11.ObjectHandle, bool, error) {
+	if err := s.module.FindObjectsInit(s.session, template); err != nil {
+		return 0, false, fmt.Errorf("pkcs11: error finding objects: %w", err)
+	}
+	objects, _, err := s.module.FindObjects(s.session, 1)
+	_ = s.module.FindObjectsFinal(s.session)
+	if err != nil {
+		return 0, false, fmt.Errorf("pkcs11: error finding objects: %w", err)
+	}
+	if len(objects) == 0 {
+		return 0, false, nil
+	}
+	return objects[0], true, nil
+}
+
+// Public returns the public key of the signer.
+func (s *signer) Public() *jose.JSONWebKey {
+	return s.public
+}
+
+// Algs returns the algorithm of the key.
+func (s *signer) Algs() []jose.SignatureAlgorithm {
+	return []jose.SignatureAlgorithm{jose.SignatureAlgorithm(s.public.Algorithm)}
+}
+
+// SignPayload signs the payload with the private key on the token.
+func (s *signer) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	var mechanism *p11.Mechanism
+	switch {
+	case string(alg) != s.public.Algorithm:
+		return nil, fmt.Errorf("pkcs11: unsupported algorithm for key: %s", alg)
+	case alg == jose.ES256:
+		// the token signs the digest, and returns r and s, which is the JWS encoding
+		digest := sha256.Sum256(payload)
+		payload = digest[:]
+		mechanism = p11.NewMechanism(p11.CKM_ECDSA, nil)
+	default:
+		mechanism = p11.NewMechanism(p11.CKM_SHA256_RSA_PKCS, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.module.SignInit(s.session, []*p11.Mechanism{mechanism}, s.key); err != nil {
+		return nil, fmt.Errorf("pkcs11: error signing: %w", err)
+	}
+	signature, err := s.module.Sign(s.session, payload)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: error signing: %w", err)
+	}
+	return signature, nil
+}
@@ -0,0 +1,13 @@
+//go:build !cgo
+// +build !cgo
+
+package pkcs11
+
+import (
+	"github.com/go-jose/go-jose/v3"
+)
+
+// GetSigner returns a signer for the private key on the token.
+func GetSigner(options Options) (jose.OpaqueSigner, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,349 @@
+// Package revocation checks whether certificates are revoked, using certificate revocation
+// lists and OCSP.
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+var (
+	// ErrRevoked indicates a certificate is revoked.
+	ErrRevoked = errors.New("revocation: certificate is revoked")
+	// ErrUnknown indicates the revocation status of a certificate couldn't be determined.
+	ErrUnknown = errors.New("revocation: unknown certificate revocation status")
+)
+
+// A Mode determines whether a certificate is accepted when its revocation status is unknown.
+type Mode string
+
+// The revocation modes.
+const (
+	// SoftFail accepts certificates when their revocation status is unknown.
+	SoftFail Mode = "soft_fail"
+	// HardFail rejects certificates when their revocation status is unknown.
+	HardFail Mode = "hard_fail"
+)
+
+// ParseMode parses a revocation mode. The empty string is SoftFail.
+func ParseMode(raw string) (Mode, error) {
+	switch Mode(raw) {
+	case "", SoftFail:
+		return SoftFail, nil
+	case HardFail:
+		return HardFail, nil
+	}
+	return "", fmt.Errorf("revocation: unknown mode: %s", raw)
+}
+
+// DefaultCRLRefreshInterval is the default interval the CRLs are fetched from the CRL URLs at.
+const DefaultCRLRefreshInterval = time.Hour
+
+const (
+	fetchTimeout   = 10 * time.Second
+	maxCRLSize     = 64 << 20
+	maxOCSPSize    = 1 << 20
+	ocspCacheSize  = 1 << 12
+	ocspDefaultTTL = time.Hour
+	// ocspFailureTTL is how long failed OCSP lookups are cached, so checks don't wait for
+	// unavailable responders on every request
+	ocspFailureTTL = time.Minute
+)
+
+// Config is the configuration of the revocation checks.
+type Config struct {
+	// CRLs are the certificate revocation lists.
+	CRLs []*pkix.CertificateList
+	// CRLURLs are the URLs of certificate revocation lists, which are fetched every
+	// CRLRefreshInterval.
+	CRLURLs            []string
+	CRLRefreshInterval time.Duration
+	// OCSP enables checking certificates with their OCSP responders.
+	OCSP bool
+}
+
+type ocspCacheEntry struct {
+	response *ocsp.Response
+	err      error
+	expires  time.Time
+}
+
+// A Checker checks whether certificates are revoked. The CRLs fetched from URLs and the
+// OCSP responses are kept across config changes.
+type Checker struct {
+	httpClient    *http.Client
+	ocspResponses *lru.TwoQueueCache
+	ocspLookups   singleflight.Group
+	updated       chan struct{}
+
+	mu          sync.RWMutex
+	cfg         Config
+	fetchedCRLs map[string]*pkix.CertificateList
+}
+
+// New creates a new Checker.
+func New() *Checker {
+	ocspResponses, _ := lru.New2Q(ocspCacheSize)
+	return &Checker{
+		httpClient:    http.DefaultClient,
+		ocspResponses: ocspResponses,
+		updated:       make(chan struct{}, 1),
+		fetchedCRLs:   map[string]*pkix.CertificateList{},
+	}
+}
+
+// UpdateConfig updates the config of the checker. The CRLs of the CRL URLs are fetched by Run.
+func (c *Checker) UpdateConfig(cfg Config) {
+	if cfg.CRLRefreshInterval <= 0 {
+		cfg.CRLRefreshInterval = DefaultCRLRefreshInterval
+	}
+
+	c.mu.Lock()
+	urlsChanged := !equalStrings(c.cfg.CRLURLs, cfg.CRLURLs)
+	c.cfg = cfg
+	for rawURL := range c.fetchedCRLs {
+		if !containsString(cfg.CRLURLs, rawURL) {
+			delete(c.fetchedCRLs, rawURL)
+		}
+	}
+	c.mu.Unlock()
+
+	// the CRLs are only fetched again early when the URLs change
+	if urlsChanged {
+		select {
+		case c.updated <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Enabled returns true when certificates are checked with CRLs or OCSP.
+func (c *Checker) Enabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.cfg.CRLs) > 0 || len(c.cfg.CRLURLs) > 0 || c.cfg.OCSP
+}
+
+// Run fetches the CRLs from the CRL URLs until the context is canceled. A CRL which can't be
+// fetched is replaced on the next refresh.
+func (c *Checker) Run(ctx context.Context) {
+	for {
+		c.mu.RLock()
+		urls, interval := c.cfg.CRLURLs, c.cfg.CRLRefreshInterval
+		c.mu.RUnlock()
+		if interval <= 0 {
+			interval = DefaultCRLRefreshInterval
+		}
+
+		for _, rawURL := range urls {
+			crl, err := c.fetchCRL(ctx, rawURL)
+			if err != nil {
+				log.Error(ctx).Err(err).Str("url", rawURL).Msg("revocation: error fetching CRL")
+				continue
+			}
+			c.mu.Lock()
+			if containsString(c.cfg.CRLURLs, rawURL) {
+				c.fetchedCRLs[rawURL] = crl
+			}
+			c.mu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.updated:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Check checks whether the certificate, which was issued by the issuer, is revoked. It returns
+// ErrRevoked when it's revoked, and an ErrUnknown error when neither an unexpired CRL of the
+// issuer nor OCSP determined its status.
+func (c *Checker) Check(ctx context.Context, cert, issuer *x509.Certificate) error {
+	c.mu.RLock()
+	crls := make([]*pkix.CertificateList, 0, len(c.cfg.CRLs)+len(c.fetchedCRLs))
+	crls = append(crls, c.cfg.CRLs...)
+	for _, crl := range c.fetchedCRLs {
+		crls = append(crls, crl)
+	}
+	useOCSP := c.cfg.OCSP
+	c.mu.RUnlock()
+
+	now := time.Now()
+	checked := false
+	for _, crl := range crls {
+		if !isIssuedBy(crl, issuer) {
+			continue
+		}
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return ErrRevoked
+			}
+		}
+		// an expired CRL doesn't show that a certificate wasn't revoked since
+		if nextUpdate := crl.TBSCertList.NextUpdate; nextUpdate.IsZero() || now.Before(nextUpdate) {
+			checked = true
+		}
+	}
+	if checked {
+		return nil
+	}
+
+	if !useOCSP || len(cert.OCSPServer) == 0 {
+		return ErrUnknown
+	}
+	res, err := c.getOCSPResponse(ctx, cert, issuer)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnknown, err)
+	}
+	switch res.Status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return ErrRevoked
+	default:
+		return ErrUnknown
+	}
+}
+
+func (c *Checker) fetchCRL(ctx context.Context, rawURL string) (*pkix.CertificateList, error) {
+	bs, err := c.do(ctx, http.MethodGet, rawURL, nil, maxCRLSize)
+	if err != nil {
+		return nil, err
+	}
+	// x509.ParseRevocationList requires Go 1.19
+	crl, err := x509.ParseCRL(bs)
+	if err != nil {
+		return nil, fmt.Errorf("revocation: invalid CRL: %w", err)
+	}
+	return crl, nil
+}
+
+// getOCSPResponse returns the OCSP response for the certificate, which is cached until the
+// responder says it will be updated. Failed lookups are cached for ocspFailureTTL, and concurrent
+// checks of a certificate share a single lookup.
+func (c *Checker) getOCSPResponse(ctx context.Context, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	key := string(cert.Raw)
+	if value, ok := c.ocspResponses.Get(key); ok {
+		if entry := value.(ocspCacheEntry); time.Now().Before(entry.expires) {
+			return entry.response, entry.err
+		}
+	}
+
+	value, err, _ := c.ocspLookups.Do(key, func() (interface{}, error) {
+		res, expires, err := c.fetchOCSPResponse(ctx, cert, issuer)
+		if err != nil {
+			// the lookup didn't fail if the request was canceled
+			if ctx.Err() == nil {
+				c.ocspResponses.Add(key, ocspCacheEntry{err: err, expires: time.Now().Add(ocspFailureTTL)})
+			}
+			return nil, err
+		}
+		c.ocspResponses.Add(key, ocspCacheEntry{response: res, expires: expires})
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*ocsp.Response), nil
+}
+
+// fetchOCSPResponse fetches the OCSP response for the certificate from its OCSP servers, and
+// returns it with the time it expires.
+func (c *Checker) fetchOCSPResponse(ctx context.Context, cert, issuer *x509.Certificate) (*ocsp.Response, time.Time, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var lastErr error
+	for _, server := range cert.OCSPServer {
+		bs, err := c.do(ctx, http.MethodPost, server, req, maxOCSPSize)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		res, err := ocsp.ParseResponseForCert(bs, cert, issuer)
+		if err != nil {
+			lastErr = fmt.Errorf("revocation: invalid OCSP response: %w", err)
+			continue
+		}
+
+		expires := res.NextUpdate
+		if expires.IsZero() {
+			expires = time.Now().Add(ocspDefaultTTL)
+		} else if time.Now().After(expires) {
+			lastErr = fmt.Errorf("revocation: stale OCSP response from %s", server)
+			continue
+		}
+		return res, expires, nil
+	}
+	return nil, time.Time{}, lastErr
+}
+
+func (c *Checker) do(ctx context.Context, method, rawURL string, body []byte, maxSize int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/ocsp-request")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("revocation: unexpected status code from %s: %d", rawURL, res.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxSize))
+}
+
+// isIssuedBy returns true if the CRL was signed by the issuer.
+func isIssuedBy(crl *pkix.CertificateList, issuer *x509.Certificate) bool {
+	var name pkix.Name
+	name.FillFromRDNSequence(&crl.TBSCertList.Issuer)
+	return name.String() == issuer.Subject.String() && issuer.CheckCRLSignature(crl) == nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package revocation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, serial int64, ocspServers ...string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		OCSPServer:   ocspServers,
+	}, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func (ca *testCA) crl(t *testing.T, nextUpdate time.Time, revoked ...int64) []byte {
+	t.Helper()
+	var entries []pkix.RevokedCertificate
+	for _, serial := range revoked {
+		entries = append(entries, pkix.RevokedCertificate{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(1),
+		ThisUpdate:          time.Now().Add(-time.Hour),
+		NextUpdate:          nextUpdate,
+		RevokedCertificates: entries,
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+	return der
+}
+
+func parseCRL(t *testing.T, der []byte) *pkix.CertificateList {
+	t.Helper()
+	crl, err := x509.ParseCRL(der)
+	require.NoError(t, err)
+	return crl
+}
+
+func TestParseMode(t *testing.T) {
+	for raw, expect := range map[string]Mode{"": SoftFail, "soft_fail": SoftFail, "hard_fail": HardFail} {
+		mode, err := ParseMode(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, expect, mode)
+	}
+	_, err := ParseMode("fail")
+	assert.Error(t, err)
+}
+
+func TestChecker_CRL(t *testing.T) {
+	ctx := context.Background()
+	ca, other := newTestCA(t), newTestCA(t)
+	good, revoked := ca.issue(t, 2), ca.issue(t, 3)
+
+	c := New()
+	assert.False(t, c.Enabled())
+
+	c.UpdateConfig(Config{CRLs: []*pkix.CertificateList{parseCRL(t, other.crl(t, time.Now().Add(time.Hour), 2))}})
+	assert.True(t, c.Enabled())
+	assert.ErrorIs(t, c.Check(ctx, good, ca.cert), ErrUnknown, "should ignore the CRLs of other issuers")
+
+	c.UpdateConfig(Config{CRLs: []*pkix.CertificateList{parseCRL(t, ca.crl(t, time.Now().Add(time.Hour), 3))}})
+	assert.NoError(t, c.Check(ctx, good, ca.cert))
+	assert.ErrorIs(t, c.Check(ctx, revoked, ca.cert), ErrRevoked)
+
+	c.UpdateConfig(Config{CRLs: []*pkix.CertificateList{parseCRL(t, ca.crl(t, time.Now().Add(-time.Minute), 3))}})
+	assert.ErrorIs(t, c.Check(ctx, good, ca.cert), ErrUnknown, "should not trust an expired CRL")
+	assert.ErrorIs(t, c.Check(ctx, revoked, ca.cert), ErrRevoked)
+}
+
+func TestChecker_CRLURLs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ca := newTestCA(t)
+	crl := ca.crl(t, time.Now().Add(time.Hour), 3)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(crl)
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.UpdateConfig(Config{CRLURLs: []string{srv.URL}})
+	go c.Run(ctx)
+
+	revoked := ca.issue(t, 3)
+	assert.Eventually(t, func() bool {
+		return errors.Is(c.Check(ctx, revoked, ca.cert), ErrRevoked)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	c.UpdateConfig(Config{})
+	assert.ErrorIs(t, c.Check(ctx, revoked, ca.cert), ErrUnknown, "should drop the CRLs of removed URLs")
+}
+
+func TestChecker_OCSP(t *testing.T) {
+	ctx := context.Background()
+	ca := newTestCA(t)
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "application/ocsp-request", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		tpl := ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}
+		switch req.SerialNumber.Int64() {
+		case 3:
+			tpl.Status = ocsp.Revoked
+			tpl.RevokedAt = time.Now().Add(-time.Minute)
+		case 4:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		res, err := ocsp.CreateResponse(ca.cert, ca.cert, tpl, ca.key)
+		require.NoError(t, err)
+		_, _ = w.Write(res)
+	}))
+	defer srv.Close()
+
+	c := New()
+	c.UpdateConfig(Config{OCSP: true})
+
+	assert.NoError(t, c.Check(ctx, ca.issue(t, 2, srv.URL), ca.cert))
+	assert.ErrorIs(t, c.Check(ctx, ca.issue(t, 3, srv.URL), ca.cert), ErrRevoked)
+	unavailable := ca.issue(t, 4, srv.URL)
+	assert.ErrorIs(t, c.Check(ctx, unavailable, ca.cert), ErrUnknown)
+	before := atomic.LoadInt32(&requests)
+	assert.ErrorIs(t, c.Check(ctx, unavailable, ca.cert), ErrUnknown)
+	assert.Equal(t, before, atomic.LoadInt32(&requests), "should cache failed lookups")
+	assert.ErrorIs(t, c.Check(ctx, ca.issue(t, 5), ca.cert), ErrUnknown, "should require an OCSP server")
+
+	cert := ca.issue(t, 6, srv.URL)
+	assert.NoError(t, c.Check(ctx, cert, ca.cert))
+	before = atomic.LoadInt32(&requests)
+	assert.NoError(t, c.Check(ctx, cert, ca.cert))
+	assert.Equal(t, before, atomic.LoadInt32(&requests), "should cache the OCSP response")
+}
@@ -0,0 +1,145 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	scimpb "github.com/pomerium/pomerium/pkg/grpc/scim"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// directoryUserID returns the id of the directory user for a SCIM user. The external id is the
+// identity provider's id for the user, which is used as the user id of sessions.
+func directoryUserID(u *scimpb.User) string {
+	if u.GetExternalId() != "" {
+		return u.GetExternalId()
+	}
+	return u.GetUserName()
+}
+
+// directoryGroupID returns the id of the directory group for a SCIM group.
+func directoryGroupID(g *scimpb.Group) string {
+	if g.GetExternalId() != "" {
+		return g.GetExternalId()
+	}
+	return g.GetId()
+}
+
+// syncDirectoryUser updates the directory user of a SCIM user using the SCIM groups. Inactive users
+// are deleted. If the directory user id changed, the previous directory user is deleted.
+func (h *Handler) syncDirectoryUser(ctx context.Context, u *scimpb.User, previousID string, groups []*scimpb.Group) error {
+	id := directoryUserID(u)
+	if previousID != "" && previousID != id {
+		if err := h.deleteDirectoryUser(ctx, previousID); err != nil {
+			return err
+		}
+	}
+
+	if !u.GetActive() {
+		return h.deleteDirectoryUser(ctx, id)
+	}
+
+	du := &directory.User{
+		Id:          id,
+		DisplayName: u.GetDisplayName(),
+		Email:       u.GetEmail(),
+	}
+	for _, g := range groups {
+		if containsString(g.GetMemberIds(), u.GetId()) {
+			du.GroupIds = append(du.GroupIds, directoryGroupID(g))
+		}
+	}
+	sort.Strings(du.GroupIds)
+
+	if _, err := databroker.Put(ctx, h.client, du); err != nil {
+		return fmt.Errorf("scim: error saving directory user: %w", err)
+	}
+	return nil
+}
+
+// deleteDirectoryUser deletes a directory user and all of the user's sessions, so that a
+// deprovisioned user loses access immediately.
+func (h *Handler) deleteDirectoryUser(ctx context.Context, id string) error {
+	if err := scimpb.Delete(ctx, h.client, &directory.User{Id: id}); err != nil {
+		return fmt.Errorf("scim: error deleting directory user: %w", err)
+	}
+
+	records, _, _, err := databroker.InitialSync(ctx, h.client, &databroker.SyncLatestRequest{
+		Type: grpcutil.GetTypeURL(new(session.Session)),
+	})
+	if err != nil {
+		return fmt.Errorf("scim: error listing sessions: %w", err)
+	}
+	for _, record := range records {
+		var s session.Session
+		if err := record.GetData().UnmarshalTo(&s); err != nil || s.GetUserId() != id {
+			continue
+		}
+		if err := session.Delete(ctx, h.client, s.GetId()); err != nil {
+			return fmt.Errorf("scim: error deleting session: %w", err)
+		}
+		log.Info(ctx).
+			Str("user_id", id).
+			Str("session_id", s.GetId()).
+			Msg("scim: deleted session of deprovisioned user")
+	}
+	return nil
+}
+
+// syncDirectoryGroup updates the directory group of a SCIM group and the directory users of its
+// current and previous members.
+func (h *Handler) syncDirectoryGroup(ctx context.Context, g, previous *scimpb.Group) error {
+	if previous != nil && directoryGroupID(previous) != directoryGroupID(g) {
+		if err := scimpb.Delete(ctx, h.client, &directory.Group{Id: directoryGroupID(previous)}); err != nil {
+			return fmt.Errorf("scim: error deleting directory group: %w", err)
+		}
+	}
+
+	_, err := databroker.Put(ctx, h.client, &directory.Group{
+		Id:   directoryGroupID(g),
+		Name: g.GetDisplayName(),
+	})
+	if err != nil {
+		return fmt.Errorf("scim: error saving directory group: %w", err)
+	}
+
+	var memberIDs []string
+	memberIDs = append(memberIDs, g.GetMemberIds()...)
+	memberIDs = append(memberIDs, previous.GetMemberIds()...)
+	return h.syncDirectoryUsers(ctx, memberIDs)
+}
+
+// syncDirectoryUsers updates the directory users of the given SCIM users.
+func (h *Handler) syncDirectoryUsers(ctx context.Context, userIDs []string) error {
+	groups, err := scimpb.ListGroups(ctx, h.client)
+	if err != nil {
+		return fmt.Errorf("scim: error listing groups: %w", err)
+	}
+
+	seen := map[string]struct{}{}
+	for _, userID := range userIDs {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+
+		u, err := scimpb.GetUser(ctx, h.client, userID)
+		if status.Code(err) == codes.NotFound {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("scim: error getting user: %w", err)
+		}
+		if err := h.syncDirectoryUser(ctx, u, "", groups); err != nil {
+			return err
+		}
+	}
+	return nil
+}
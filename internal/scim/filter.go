@@ -0,0 +1,75 @@
+package scim
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	scimpb "github.com/pomerium/pomerium/pkg/grpc/scim"
+)
+
+var filterRegex = regexp.MustCompile(`(?i)^\s*([a-z][a-z0-9._:]*)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// A filter matches resources whose attribute equals the value. Only the eq operator is supported,
+// since that's what identity providers use to find existing users and groups.
+//
+// https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2.2
+type filter struct {
+	attribute string
+	value     string
+}
+
+func parseFilter(raw string) (*filter, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	m := filterRegex.FindStringSubmatch(raw)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported filter: %s", raw)
+	}
+	value, err := strconv.Unquote(m[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter value: %s", m[2])
+	}
+	return &filter{attribute: strings.ToLower(m[1]), value: value}, nil
+}
+
+func (f *filter) matchUser(u *scimpb.User) bool {
+	if f == nil {
+		return true
+	}
+
+	switch f.attribute {
+	case "id":
+		return u.GetId() == f.value
+	case "username":
+		return strings.EqualFold(u.GetUserName(), f.value)
+	case "externalid":
+		return u.GetExternalId() == f.value
+	case "displayname":
+		return u.GetDisplayName() == f.value
+	case "emails", "emails.value":
+		return strings.EqualFold(u.GetEmail(), f.value)
+	}
+	return false
+}
+
+func (f *filter) matchGroup(g *scimpb.Group) bool {
+	if f == nil {
+		return true
+	}
+
+	switch f.attribute {
+	case "id":
+		return g.GetId() == f.value
+	case "externalid":
+		return g.GetExternalId() == f.value
+	case "displayname":
+		return g.GetDisplayName() == f.value
+	case "members", "members.value":
+		return containsString(g.GetMemberIds(), f.value)
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package scim
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	scimpb "github.com/pomerium/pomerium/pkg/grpc/scim"
+)
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	f, err := parseFilter(r.FormValue("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	groups, err := scimpb.ListGroups(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	users, ok := h.loadUserLookup(w, r)
+	if !ok {
+		return
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].GetId() < groups[j].GetId()
+	})
+
+	baseURL := getBaseURL(r)
+	var resources []interface{}
+	for _, g := range groups {
+		if f.matchGroup(g) {
+			resources = append(resources, newGroupResource(baseURL, g, users))
+		}
+	}
+	writeJSON(w, http.StatusOK, getPage(r, resources))
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	var res groupResource
+	if !readJSON(w, r, &res) {
+		return
+	}
+	if res.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+
+	groups, err := scimpb.ListGroups(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	for _, g := range groups {
+		if g.GetDisplayName() == res.DisplayName {
+			writeError(w, http.StatusConflict, "uniqueness", "displayName is already in use")
+			return
+		}
+	}
+
+	now := timestamppb.Now()
+	g := &scimpb.Group{
+		Id:         uuid.NewString(),
+		CreatedAt:  now,
+		ModifiedAt: now,
+	}
+	applyGroupResource(g, &res)
+	h.saveGroup(w, r, g, nil, http.StatusCreated)
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	users, ok := h.loadUserLookup(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newGroupResource(getBaseURL(r), g, users))
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+
+	var res groupResource
+	if !readJSON(w, r, &res) {
+		return
+	}
+	if res.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+
+	previous := proto.Clone(g).(*scimpb.Group)
+	applyGroupResource(g, &res)
+	g.ModifiedAt = timestamppb.Now()
+	h.saveGroup(w, r, g, previous, http.StatusOK)
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+
+	var req patchRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	previous := proto.Clone(g).(*scimpb.Group)
+	if err := applyGroupPatch(g, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	if g.GetDisplayName() == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+	g.ModifiedAt = timestamppb.Now()
+	h.saveGroup(w, r, g, previous, http.StatusOK)
+}
+
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+
+	err := scimpb.Delete(r.Context(), h.client, g, &directory.Group{Id: directoryGroupID(g)})
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	if err := h.syncDirectoryUsers(r.Context(), g.GetMemberIds()); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) loadGroup(w http.ResponseWriter, r *http.Request) (*scimpb.Group, bool) {
+	g, err := scimpb.GetGroup(r.Context(), h.client, mux.Vars(r)["id"])
+	if status.Code(err) == codes.NotFound {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return nil, false
+	} else if err != nil {
+		writeInternalError(w, r, err)
+		return nil, false
+	}
+	return g, true
+}
+
+// loadUserLookup returns all the SCIM users by id, so group members can be displayed.
+func (h *Handler) loadUserLookup(w http.ResponseWriter, r *http.Request) (map[string]*scimpb.User, bool) {
+	users, err := scimpb.ListUsers(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return nil, false
+	}
+	lookup := make(map[string]*scimpb.User, len(users))
+	for _, u := range users {
+		lookup[u.GetId()] = u
+	}
+	return lookup, true
+}
+
+func (h *Handler) saveGroup(w http.ResponseWriter, r *http.Request, g, previous *scimpb.Group, statusCode int) {
+	if _, err := databroker.Put(r.Context(), h.client, g); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	if err := h.syncDirectoryGroup(r.Context(), g, previous); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+
+	users, ok := h.loadUserLookup(w, r)
+	if !ok {
+		return
+	}
+	res := newGroupResource(getBaseURL(r), g, users)
+	w.Header().Set("Location", res.Meta.Location)
+	writeJSON(w, statusCode, res)
+}
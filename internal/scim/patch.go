@@ -0,0 +1,203 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	scimpb "github.com/pomerium/pomerium/pkg/grpc/scim"
+)
+
+// members[value eq "ID"]
+var memberPathRegex = regexp.MustCompile(`(?i)^members\[value\s+eq\s+("(?:[^"\\]|\\.)*")\]$`)
+
+// https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.2
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// attributes returns the attributes changed by the operation. If the operation has no path, the
+// value is an object containing the changed attributes.
+func (op patchOperation) attributes() (map[string]json.RawMessage, error) {
+	if op.Path != "" {
+		return map[string]json.RawMessage{op.Path: op.Value}, nil
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &attributes); err != nil {
+		return nil, fmt.Errorf("invalid patch value: %w", err)
+	}
+	return attributes, nil
+}
+
+func applyUserPatch(u *scimpb.User, req *patchRequest) error {
+	for _, op := range req.Operations {
+		opName := strings.ToLower(op.Op)
+		if opName != "add" && opName != "replace" && opName != "remove" {
+			return fmt.Errorf("unsupported patch operation: %s", op.Op)
+		}
+
+		attributes, err := op.attributes()
+		if err != nil {
+			return err
+		}
+		for path, value := range attributes {
+			if opName == "remove" {
+				value = nil
+			}
+			if err := applyUserAttribute(u, strings.ToLower(path), value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyUserAttribute(u *scimpb.User, path string, value json.RawMessage) error {
+	switch {
+	case path == "active":
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = active
+	case path == "username":
+		return parseString(value, &u.UserName)
+	case path == "externalid":
+		return parseString(value, &u.ExternalId)
+	case path == "displayname", path == "name.formatted":
+		return parseString(value, &u.DisplayName)
+	case strings.HasPrefix(path, "emails"):
+		return parseEmail(value, &u.Email)
+	}
+	// other attributes aren't stored
+	return nil
+}
+
+func applyGroupPatch(g *scimpb.Group, req *patchRequest) error {
+	for _, op := range req.Operations {
+		opName := strings.ToLower(op.Op)
+		if opName != "add" && opName != "replace" && opName != "remove" {
+			return fmt.Errorf("unsupported patch operation: %s", op.Op)
+		}
+
+		// a single member can be removed using a value filter
+		if m := memberPathRegex.FindStringSubmatch(op.Path); m != nil && opName == "remove" {
+			memberID, err := strconv.Unquote(m[1])
+			if err != nil {
+				return fmt.Errorf("invalid patch path: %s", op.Path)
+			}
+			g.MemberIds = removeString(g.MemberIds, memberID)
+			continue
+		}
+
+		attributes, err := op.attributes()
+		if err != nil {
+			return err
+		}
+		for path, value := range attributes {
+			if err := applyGroupAttribute(g, opName, strings.ToLower(path), value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyGroupAttribute(g *scimpb.Group, opName, path string, value json.RawMessage) error {
+	if opName == "remove" && path != "members" {
+		value = nil
+	}
+
+	switch path {
+	case "displayname":
+		return parseString(value, &g.DisplayName)
+	case "externalid":
+		return parseString(value, &g.ExternalId)
+	case "members":
+		var members []multiValue
+		if len(value) > 0 {
+			if err := json.Unmarshal(value, &members); err != nil {
+				return fmt.Errorf("invalid members: %w", err)
+			}
+		}
+
+		switch {
+		case opName == "replace":
+			g.MemberIds = nil
+			fallthrough
+		case opName == "add":
+			for _, member := range members {
+				g.MemberIds = appendUnique(g.MemberIds, member.Value)
+			}
+		case len(members) == 0:
+			// removing without a value removes all the members
+			g.MemberIds = nil
+		default:
+			for _, member := range members {
+				g.MemberIds = removeString(g.MemberIds, member.Value)
+			}
+		}
+	}
+	// other attributes aren't stored
+	return nil
+}
+
+func parseString(value json.RawMessage, dst *string) error {
+	*dst = ""
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(value, dst); err != nil {
+		return fmt.Errorf("invalid string value: %s", value)
+	}
+	return nil
+}
+
+// parseBool parses a boolean, which some identity providers send as a string.
+func parseBool(value json.RawMessage) (bool, error) {
+	var v interface{}
+	if len(value) > 0 {
+		if err := json.Unmarshal(value, &v); err != nil {
+			return false, fmt.Errorf("invalid boolean value: %s", value)
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return false, fmt.Errorf("invalid boolean value: %s", value)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("invalid boolean value: %s", value)
+}
+
+// parseEmail parses either a single email address or a list of emails.
+func parseEmail(value json.RawMessage, dst *string) error {
+	var emails []multiValue
+	if err := json.Unmarshal(value, &emails); err == nil {
+		*dst = ""
+		for _, email := range emails {
+			if *dst == "" || email.Primary {
+				*dst = email.Value
+			}
+		}
+		return nil
+	}
+	return parseString(value, dst)
+}
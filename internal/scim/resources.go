@@ -0,0 +1,180 @@
+package scim
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	scimpb "github.com/pomerium/pomerium/pkg/grpc/scim"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type multiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// https://datatracker.ietf.org/doc/html/rfc7643#section-4.1
+type userResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Name        *name        `json:"name,omitempty"`
+	Emails      []multiValue `json:"emails,omitempty"`
+	// active defaults to true when it isn't set
+	Active *bool        `json:"active,omitempty"`
+	Groups []multiValue `json:"groups,omitempty"`
+	Meta   *meta        `json:"meta,omitempty"`
+}
+
+// https://datatracker.ietf.org/doc/html/rfc7643#section-4.2
+type groupResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []multiValue `json:"members,omitempty"`
+	Meta        *meta        `json:"meta,omitempty"`
+}
+
+type listResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// applyUserResource updates the user with the attributes of the user resource.
+func applyUserResource(u *scimpb.User, res *userResource) {
+	u.ExternalId = res.ExternalID
+	u.UserName = res.UserName
+	u.DisplayName = res.DisplayName
+	if u.DisplayName == "" && res.Name != nil {
+		u.DisplayName = res.Name.Formatted
+		if u.DisplayName == "" {
+			u.DisplayName = strings.TrimSpace(res.Name.GivenName + " " + res.Name.FamilyName)
+		}
+	}
+	u.Email = ""
+	for _, email := range res.Emails {
+		if u.Email == "" || email.Primary {
+			u.Email = email.Value
+		}
+	}
+	u.Active = res.Active == nil || *res.Active
+}
+
+func newUserResource(baseURL *url.URL, u *scimpb.User, groups []*scimpb.Group) *userResource {
+	res := &userResource{
+		Schemas:     []string{schemaUser},
+		ID:          u.GetId(),
+		ExternalID:  u.GetExternalId(),
+		UserName:    u.GetUserName(),
+		DisplayName: u.GetDisplayName(),
+		Active:      &u.Active,
+		Meta:        newMeta(baseURL, "User", u.GetId(), u.GetCreatedAt(), u.GetModifiedAt()),
+	}
+	if u.GetDisplayName() != "" {
+		res.Name = &name{Formatted: u.GetDisplayName()}
+	}
+	if u.GetEmail() != "" {
+		res.Emails = []multiValue{{Value: u.GetEmail(), Primary: true}}
+	}
+	for _, g := range groups {
+		if containsString(g.GetMemberIds(), u.GetId()) {
+			res.Groups = append(res.Groups, multiValue{Value: g.GetId(), Display: g.GetDisplayName()})
+		}
+	}
+	return res
+}
+
+// applyGroupResource updates the group with the attributes of the group resource.
+func applyGroupResource(g *scimpb.Group, res *groupResource) {
+	g.ExternalId = res.ExternalID
+	g.DisplayName = res.DisplayName
+	g.MemberIds = nil
+	for _, member := range res.Members {
+		g.MemberIds = appendUnique(g.MemberIds, member.Value)
+	}
+}
+
+func newGroupResource(baseURL *url.URL, g *scimpb.Group, users map[string]*scimpb.User) *groupResource {
+	res := &groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          g.GetId(),
+		ExternalID:  g.GetExternalId(),
+		DisplayName: g.GetDisplayName(),
+		Meta:        newMeta(baseURL, "Group", g.GetId(), g.GetCreatedAt(), g.GetModifiedAt()),
+	}
+	for _, memberID := range g.GetMemberIds() {
+		member := multiValue{Value: memberID}
+		if u, ok := users[memberID]; ok {
+			member.Display = u.GetDisplayName()
+		}
+		res.Members = append(res.Members, member)
+	}
+	return res
+}
+
+func newMeta(baseURL *url.URL, resourceType, id string, created, lastModified *timestamppb.Timestamp) *meta {
+	return &meta{
+		ResourceType: resourceType,
+		Created:      created.AsTime().Format(time.RFC3339),
+		LastModified: lastModified.AsTime().Format(time.RFC3339),
+		Location:     baseURL.ResolveReference(&url.URL{Path: resourceType + "s/" + id}).String(),
+	}
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	var filtered []string
+	for _, v := range values {
+		if v != value {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
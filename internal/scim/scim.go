@@ -0,0 +1,182 @@
+// Package scim implements a SCIM 2.0 server, so identity providers can provision users and groups.
+// Provisioned users and groups are stored in the databroker as directory users and groups, and the
+// sessions of deprovisioned users are deleted.
+//
+// https://datatracker.ietf.org/doc/html/rfc7644
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// BasePath is the path the SCIM server is served under.
+const BasePath = "/scim/v2"
+
+const (
+	contentType    = "application/scim+json"
+	maxRequestSize = 1 << 20
+)
+
+// A Handler serves the SCIM API.
+type Handler struct {
+	client      databroker.DataBrokerServiceClient
+	bearerToken string
+	router      *mux.Router
+}
+
+// New creates a new SCIM Handler. Requests must be authenticated using the bearer token.
+func New(client databroker.DataBrokerServiceClient, bearerToken string) *Handler {
+	h := &Handler{
+		client:      client,
+		bearerToken: bearerToken,
+		router:      mux.NewRouter(),
+	}
+
+	sr := h.router.PathPrefix(BasePath).Subrouter()
+	sr.Path("/ServiceProviderConfig").HandlerFunc(h.serviceProviderConfig).Methods(http.MethodGet)
+	sr.Path("/Users").HandlerFunc(h.listUsers).Methods(http.MethodGet)
+	sr.Path("/Users").HandlerFunc(h.createUser).Methods(http.MethodPost)
+	sr.Path("/Users/{id}").HandlerFunc(h.getUser).Methods(http.MethodGet)
+	sr.Path("/Users/{id}").HandlerFunc(h.replaceUser).Methods(http.MethodPut)
+	sr.Path("/Users/{id}").HandlerFunc(h.patchUser).Methods(http.MethodPatch)
+	sr.Path("/Users/{id}").HandlerFunc(h.deleteUser).Methods(http.MethodDelete)
+	sr.Path("/Groups").HandlerFunc(h.listGroups).Methods(http.MethodGet)
+	sr.Path("/Groups").HandlerFunc(h.createGroup).Methods(http.MethodPost)
+	sr.Path("/Groups/{id}").HandlerFunc(h.getGroup).Methods(http.MethodGet)
+	sr.Path("/Groups/{id}").HandlerFunc(h.replaceGroup).Methods(http.MethodPut)
+	sr.Path("/Groups/{id}").HandlerFunc(h.patchGroup).Methods(http.MethodPatch)
+	sr.Path("/Groups/{id}").HandlerFunc(h.deleteGroup).Methods(http.MethodDelete)
+	h.router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "", "not found")
+	})
+	h.router.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+	})
+	return h
+}
+
+// ServeHTTP serves a SCIM request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.bearerToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.bearerToken)) != 1 {
+		writeError(w, http.StatusUnauthorized, "", "invalid bearer token")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	h.router.ServeHTTP(w, r)
+}
+
+func (h *Handler) serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{schemaServiceProviderConfig},
+		"patch":          supported{true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": 0},
+		"changePassword": supported{false},
+		"sort":           supported{false},
+		"etag":           supported{false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication using the SCIM bearer token configured in Pomerium",
+			"primary":     true,
+		}},
+		"meta": meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     getBaseURL(r).ResolveReference(&url.URL{Path: "ServiceProviderConfig"}).String(),
+		},
+	})
+}
+
+// getBaseURL returns the url of the SCIM API, with a trailing slash so that resource paths can be resolved.
+func getBaseURL(r *http.Request) *url.URL {
+	u := urlutil.GetAbsoluteURL(r)
+	return &url.URL{Scheme: u.Scheme, Host: u.Host, Path: BasePath + "/"}
+}
+
+// getPage returns the page of resources requested using the startIndex and count query parameters.
+//
+// https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2.4
+func getPage(r *http.Request, resources []interface{}) *listResponse {
+	startIndex, err := strconv.Atoi(r.FormValue("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(r.FormValue("count"))
+	if err != nil || count < 0 {
+		count = len(resources)
+	}
+
+	res := &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		Resources:    []interface{}{},
+	}
+	if startIndex <= len(resources) {
+		resources = resources[startIndex-1:]
+		if count < len(resources) {
+			resources = resources[:count]
+		}
+		res.Resources = resources
+	}
+	res.ItemsPerPage = len(res.Resources)
+	return res
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, obj interface{}) {
+	bs, err := json.Marshal(obj)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		bs = []byte(`{"schemas":["` + schemaError + `"],"status":"500"}`)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(bs)
+}
+
+// writeError writes a SCIM error response.
+//
+// https://datatracker.ietf.org/doc/html/rfc7644#section-3.12
+func writeError(w http.ResponseWriter, statusCode int, scimType, detail string) {
+	writeJSON(w, statusCode, struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		SCIMType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(statusCode),
+		SCIMType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeInternalError(w http.ResponseWriter, r *http.Request, err error) {
+	log.Error(r.Context()).Err(err).Msg("scim: internal error")
+	writeError(w, http.StatusInternalServerError, "", "internal error")
+}
@@ -0,0 +1,233 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpc/directory"
+	"github.com/pomerium/pomerium/pkg/grpc/session"
+)
+
+const testBearerToken = "TOKEN"
+
+func newTestHandler(t *testing.T) (*Handler, databroker.DataBrokerServiceClient) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(gs, internal_databroker.New())
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+
+	client := databroker.NewDataBrokerServiceClient(cc)
+	return New(client, testBearerToken), client
+}
+
+func doRequest(t *testing.T, h http.Handler, method, path string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+
+	var bs []byte
+	if body != nil {
+		var err error
+		bs, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	r := httptest.NewRequest(method, "https://authenticate.example.com"+path, strings.NewReader(string(bs)))
+	r.Header.Set("Authorization", "Bearer "+testBearerToken)
+	r.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	var res map[string]interface{}
+	if w.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+	}
+	return w, res
+}
+
+func TestHandler(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	t.Run("unauthorized", func(t *testing.T) {
+		h, _ := newTestHandler(t)
+		for _, authorization := range []string{"", "Bearer", "Bearer WRONG"} {
+			r := httptest.NewRequest(http.MethodGet, BasePath+"/Users", nil)
+			r.Header.Set("Authorization", authorization)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+
+		h = New(nil, "")
+		r := httptest.NewRequest(http.MethodGet, BasePath+"/Users", nil)
+		r.Header.Set("Authorization", "Bearer ")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("users", func(t *testing.T) {
+		h, client := newTestHandler(t)
+
+		w, res := doRequest(t, h, http.MethodPost, BasePath+"/Users", map[string]interface{}{
+			"schemas":    []string{schemaUser},
+			"userName":   "alice@example.com",
+			"externalId": "u1",
+			"name":       map[string]interface{}{"givenName": "Alice", "familyName": "Smith"},
+			"emails": []map[string]interface{}{
+				{"value": "alice@other.example.com"},
+				{"value": "alice@example.com", "primary": true},
+			},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		id := res["id"].(string)
+		assert.NotEmpty(t, id)
+		assert.Equal(t, "https://authenticate.example.com/scim/v2/Users/"+id, w.Header().Get("Location"))
+		assert.Equal(t, true, res["active"])
+
+		du := directory.User{Id: "u1"}
+		require.NoError(t, databroker.Get(ctx, client, &du))
+		assert.Equal(t, "Alice Smith", du.GetDisplayName())
+		assert.Equal(t, "alice@example.com", du.GetEmail())
+
+		w, _ = doRequest(t, h, http.MethodPost, BasePath+"/Users", map[string]interface{}{
+			"userName": "ALICE@example.com",
+		})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w, res = doRequest(t, h, http.MethodGet, BasePath+`/Users?filter=userName+eq+"Alice@example.com"`, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, 1.0, res["totalResults"])
+
+		w, res = doRequest(t, h, http.MethodGet, BasePath+`/Users?filter=userName+eq+"bob@example.com"`, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, 0.0, res["totalResults"])
+		assert.Equal(t, []interface{}{}, res["Resources"])
+
+		w, _ = doRequest(t, h, http.MethodGet, BasePath+`/Users?filter=userName+sw+"a"`, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		_, err := session.Put(ctx, client, &session.Session{Id: "s1", UserId: "u1"})
+		require.NoError(t, err)
+		_, err = session.Put(ctx, client, &session.Session{Id: "s2", UserId: "u2"})
+		require.NoError(t, err)
+
+		w, res = doRequest(t, h, http.MethodPatch, BasePath+"/Users/"+id, map[string]interface{}{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]interface{}{
+				{"op": "Replace", "path": "active", "value": "False"},
+			},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, false, res["active"])
+
+		err = databroker.Get(ctx, client, &directory.User{Id: "u1"})
+		assert.Equal(t, codes.NotFound, status.Code(err), "should delete the directory user")
+		_, err = session.Get(ctx, client, "s1")
+		assert.Equal(t, codes.NotFound, status.Code(err), "should delete the user's sessions")
+		_, err = session.Get(ctx, client, "s2")
+		assert.NoError(t, err, "should not delete other sessions")
+
+		w, _ = doRequest(t, h, http.MethodDelete, BasePath+"/Users/"+id, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w, _ = doRequest(t, h, http.MethodGet, BasePath+"/Users/"+id, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("groups", func(t *testing.T) {
+		h, client := newTestHandler(t)
+
+		_, user1 := doRequest(t, h, http.MethodPost, BasePath+"/Users", map[string]interface{}{
+			"userName": "user1", "externalId": "u1",
+		})
+		_, user2 := doRequest(t, h, http.MethodPost, BasePath+"/Users", map[string]interface{}{
+			"userName": "user2", "externalId": "u2",
+		})
+
+		w, res := doRequest(t, h, http.MethodPost, BasePath+"/Groups", map[string]interface{}{
+			"schemas":     []string{schemaGroup},
+			"displayName": "Admins",
+			"externalId":  "g1",
+			"members":     []map[string]interface{}{{"value": user1["id"]}},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		groupID := res["id"].(string)
+
+		dg := directory.Group{Id: "g1"}
+		require.NoError(t, databroker.Get(ctx, client, &dg))
+		assert.Equal(t, "Admins", dg.GetName())
+
+		getGroupIDs := func(userID string) []string {
+			du := directory.User{Id: userID}
+			require.NoError(t, databroker.Get(ctx, client, &du))
+			return du.GetGroupIds()
+		}
+		assert.Equal(t, []string{"g1"}, getGroupIDs("u1"))
+		assert.Empty(t, getGroupIDs("u2"))
+
+		w, _ = doRequest(t, h, http.MethodPatch, BasePath+"/Groups/"+groupID, map[string]interface{}{
+			"schemas": []string{schemaPatchOp},
+			"Operations": []map[string]interface{}{
+				{"op": "add", "path": "members", "value": []map[string]interface{}{{"value": user2["id"]}}},
+				{"op": "remove", "path": `members[value eq "` + user1["id"].(string) + `"]`},
+			},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Empty(t, getGroupIDs("u1"))
+		assert.Equal(t, []string{"g1"}, getGroupIDs("u2"))
+
+		w, res = doRequest(t, h, http.MethodGet, BasePath+"/Users/"+user2["id"].(string), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"value": groupID, "display": "Admins"},
+		}, res["groups"])
+
+		w, _ = doRequest(t, h, http.MethodDelete, BasePath+"/Groups/"+groupID, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		err := databroker.Get(ctx, client, &directory.Group{Id: "g1"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Empty(t, getGroupIDs("u2"))
+	})
+}
+
+func TestGetPage(t *testing.T) {
+	resources := []interface{}{1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		query  string
+		expect []interface{}
+	}{
+		{"", []interface{}{1, 2, 3, 4, 5}},
+		{"startIndex=2&count=2", []interface{}{2, 3}},
+		{"startIndex=4&count=10", []interface{}{4, 5}},
+		{"startIndex=10", []interface{}{}},
+		{"count=0", []interface{}{}},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/?"+tc.query, nil)
+		res := getPage(r, resources)
+		assert.Equal(t, tc.expect, res.Resources, tc.query)
+		assert.Equal(t, 5, res.TotalResults, tc.query)
+		assert.Equal(t, len(tc.expect), res.ItemsPerPage, tc.query)
+	}
+}
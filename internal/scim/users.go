@@ -0,0 +1,206 @@
+package scim
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	scimpb "github.com/pomerium/pomerium/pkg/grpc/scim"
+)
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	f, err := parseFilter(r.FormValue("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	users, err := scimpb.ListUsers(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	groups, err := scimpb.ListGroups(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].GetId() < users[j].GetId()
+	})
+
+	baseURL := getBaseURL(r)
+	var resources []interface{}
+	for _, u := range users {
+		if f.matchUser(u) {
+			resources = append(resources, newUserResource(baseURL, u, groups))
+		}
+	}
+	writeJSON(w, http.StatusOK, getPage(r, resources))
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
+	var res userResource
+	if !readJSON(w, r, &res) {
+		return
+	}
+	if res.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	users, err := scimpb.ListUsers(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.GetUserName(), res.UserName) {
+			writeError(w, http.StatusConflict, "uniqueness", "userName is already in use")
+			return
+		}
+	}
+
+	now := timestamppb.Now()
+	u := &scimpb.User{
+		Id:         uuid.NewString(),
+		CreatedAt:  now,
+		ModifiedAt: now,
+	}
+	applyUserResource(u, &res)
+	h.saveUser(w, r, u, "", http.StatusCreated)
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	groups, err := scimpb.ListGroups(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newUserResource(getBaseURL(r), u, groups))
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	var res userResource
+	if !readJSON(w, r, &res) {
+		return
+	}
+	if res.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+
+	previousID := directoryUserID(u)
+	applyUserResource(u, &res)
+	u.ModifiedAt = timestamppb.Now()
+	h.saveUser(w, r, u, previousID, http.StatusOK)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req patchRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	previousID := directoryUserID(u)
+	if err := applyUserPatch(u, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+		return
+	}
+	if u.GetUserName() == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+	u.ModifiedAt = timestamppb.Now()
+	h.saveUser(w, r, u, previousID, http.StatusOK)
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	groups, err := scimpb.ListGroups(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	now := timestamppb.Now()
+	for _, g := range groups {
+		if !containsString(g.GetMemberIds(), u.GetId()) {
+			continue
+		}
+		g.MemberIds = removeString(g.MemberIds, u.GetId())
+		g.ModifiedAt = now
+		if _, err := databroker.Put(r.Context(), h.client, g); err != nil {
+			writeInternalError(w, r, err)
+			return
+		}
+	}
+
+	if err := scimpb.Delete(r.Context(), h.client, u); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	if err := h.deleteDirectoryUser(r.Context(), directoryUserID(u)); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) loadUser(w http.ResponseWriter, r *http.Request) (*scimpb.User, bool) {
+	u, err := scimpb.GetUser(r.Context(), h.client, mux.Vars(r)["id"])
+	if status.Code(err) == codes.NotFound {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return nil, false
+	} else if err != nil {
+		writeInternalError(w, r, err)
+		return nil, false
+	}
+	return u, true
+}
+
+func (h *Handler) saveUser(w http.ResponseWriter, r *http.Request, u *scimpb.User, previousID string, statusCode int) {
+	if _, err := databroker.Put(r.Context(), h.client, u); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+
+	groups, err := scimpb.ListGroups(r.Context(), h.client)
+	if err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+	if err := h.syncDirectoryUser(r.Context(), u, previousID, groups); err != nil {
+		writeInternalError(w, r, err)
+		return
+	}
+
+	res := newUserResource(getBaseURL(r), u, groups)
+	w.Header().Set("Location", res.Meta.Location)
+	writeJSON(w, statusCode, res)
+}
@@ -0,0 +1,10 @@
+//go:build linux
+
+package secretmem
+
+import "golang.org/x/sys/unix"
+
+// dontDump excludes the pages from core dumps.
+func dontDump(pages []byte) {
+	_ = unix.Madvise(pages, unix.MADV_DONTDUMP)
+}
@@ -0,0 +1,5 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package secretmem
+
+func dontDump(pages []byte) {}
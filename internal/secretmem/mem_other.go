@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package secretmem
+
+// allocPages returns nil, so secrets are kept on the Go heap, and are only zeroed when they're
+// destroyed.
+func allocPages(size int) []byte {
+	return nil
+}
+
+func protectPages(pages []byte) {}
+
+func freePages(pages []byte) {}
@@ -0,0 +1,38 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package secretmem
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// allocPages maps locked pages outside of the Go heap, so the secret is never moved or copied
+// by the runtime. The pages aren't locked when the RLIMIT_MEMLOCK limit is reached. It returns
+// nil when the pages can't be mapped.
+func allocPages(size int) []byte {
+	pageSize := os.Getpagesize()
+	length := (size/pageSize + 1) * pageSize
+	pages, err := unix.Mmap(-1, 0, length, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		return nil
+	}
+	_ = unix.Mlock(pages)
+	dontDump(pages)
+	return pages
+}
+
+func protectPages(pages []byte) {
+	if pages != nil {
+		_ = unix.Mprotect(pages, unix.PROT_READ)
+	}
+}
+
+// freePages zeroes and unlocks the pages. They aren't unmapped, so they stay readable.
+func freePages(pages []byte) {
+	_ = unix.Mprotect(pages, unix.PROT_READ|unix.PROT_WRITE)
+	zero(pages)
+	_ = unix.Mprotect(pages, unix.PROT_READ)
+	_ = unix.Munlock(pages)
+}
@@ -0,0 +1,162 @@
+// Package secretmem keeps secrets, like the shared secret and the cookie secret, in memory which
+// is locked, so it's never swapped to disk, excluded from core dumps where supported, read-only,
+// and zeroed when pomerium exits.
+package secretmem
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// retainDelay is how long the buffer of a secret which is no longer used is kept before it's
+// destroyed, so the requests which started with the previous config can finish.
+var retainDelay = time.Minute
+
+// A Buffer holds a secret in locked memory.
+type Buffer struct {
+	mu   sync.Mutex
+	data []byte
+	// pages are the pages mapped for the buffer, or nil when the data is on the Go heap.
+	pages     []byte
+	destroyed bool
+}
+
+var buffers struct {
+	sync.Mutex
+	all     []*Buffer
+	decoded map[[sha256.Size]byte]*Buffer
+}
+
+// New creates a new Buffer holding a copy of the secret. The secret isn't modified.
+func New(secret []byte) *Buffer {
+	b := &Buffer{pages: allocPages(len(secret))}
+	if b.pages != nil {
+		b.data = b.pages[:len(secret):len(secret)]
+	} else {
+		b.data = make([]byte, len(secret))
+	}
+	copy(b.data, secret)
+	protectPages(b.pages)
+
+	buffers.Lock()
+	buffers.all = append(buffers.all, b)
+	buffers.Unlock()
+	return b
+}
+
+// Bytes returns the secret. The returned slice is read-only, and is zeroed when the buffer is
+// destroyed.
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Destroy zeroes and unlocks the memory of the buffer. The memory stays readable, so a secret
+// used after it was destroyed is all zeroes.
+func (b *Buffer) Destroy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.destroyed {
+		return
+	}
+	b.destroyed = true
+	if b.pages != nil {
+		freePages(b.pages)
+	} else {
+		zero(b.data)
+	}
+}
+
+// DecodeBase64 decodes the base64-encoded secret into a Buffer, and returns its bytes. Buffers
+// are shared by secrets with the same value, so the returned slice must not be modified.
+func DecodeBase64(raw string) ([]byte, error) {
+	key := sha256.Sum256([]byte(raw))
+
+	buffers.Lock()
+	b, ok := buffers.decoded[key]
+	buffers.Unlock()
+	if ok {
+		return b.Bytes(), nil
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	b = New(secret)
+	zero(secret)
+
+	buffers.Lock()
+	if buffers.decoded == nil {
+		buffers.decoded = map[[sha256.Size]byte]*Buffer{}
+	}
+	if existing, ok := buffers.decoded[key]; ok {
+		buffers.Unlock()
+		b.Destroy()
+		return existing.Bytes(), nil
+	}
+	buffers.decoded[key] = b
+	buffers.Unlock()
+	return b.Bytes(), nil
+}
+
+// Retain destroys the buffers decoded by DecodeBase64 for any secret but the given base64-encoded
+// secrets, after a delay. It's called when the config changes, so rotated secrets don't stay in
+// memory until pomerium exits.
+func Retain(raws ...string) {
+	keep := map[[sha256.Size]byte]struct{}{}
+	for _, raw := range raws {
+		keep[sha256.Sum256([]byte(raw))] = struct{}{}
+	}
+
+	buffers.Lock()
+	var unused []*Buffer
+	for key, b := range buffers.decoded {
+		if _, ok := keep[key]; !ok {
+			delete(buffers.decoded, key)
+			unused = append(unused, b)
+		}
+	}
+	buffers.Unlock()
+
+	for _, b := range unused {
+		b := b
+		time.AfterFunc(retainDelay, func() {
+			b.Destroy()
+			forget(b)
+		})
+	}
+}
+
+// forget removes a destroyed buffer from the buffers destroyed when pomerium exits.
+func forget(b *Buffer) {
+	buffers.Lock()
+	defer buffers.Unlock()
+
+	for i, other := range buffers.all {
+		if other == b {
+			buffers.all = append(buffers.all[:i], buffers.all[i+1:]...)
+			return
+		}
+	}
+}
+
+// DestroyAll destroys all the buffers. It's called when pomerium exits.
+func DestroyAll() {
+	buffers.Lock()
+	all := buffers.all
+	buffers.all, buffers.decoded = nil, nil
+	buffers.Unlock()
+
+	for _, b := range all {
+		b.Destroy()
+	}
+}
+
+func zero(bs []byte) {
+	for i := range bs {
+		bs[i] = 0
+	}
+}
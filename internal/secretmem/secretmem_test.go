@@ -0,0 +1,70 @@
+package secretmem
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	secret := []byte("secret")
+	b := New(secret)
+	assert.Equal(t, []byte("secret"), b.Bytes())
+	assert.Equal(t, []byte("secret"), secret, "should not modify the secret")
+	assert.Equal(t, len(secret), cap(b.Bytes()), "should not allow appending in place")
+
+	b.Destroy()
+	assert.Equal(t, make([]byte, len(secret)), b.Bytes())
+	b.Destroy()
+}
+
+func TestDecodeBase64(t *testing.T) {
+	raw := base64.StdEncoding.EncodeToString([]byte("shared secret"))
+	bs1, err := DecodeBase64(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("shared secret"), bs1)
+
+	bs2, err := DecodeBase64(raw)
+	require.NoError(t, err)
+	assert.Same(t, &bs1[0], &bs2[0], "should reuse the buffer of the same secret")
+
+	_, err = DecodeBase64("not base64!")
+	assert.Error(t, err)
+
+	DestroyAll()
+	assert.Equal(t, make([]byte, len(bs1)), bs1)
+
+	bs3, err := DecodeBase64(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("shared secret"), bs3, "should decode the secret again after it was destroyed")
+}
+
+func TestRetain(t *testing.T) {
+	defer func(d time.Duration) { retainDelay = d }(retainDelay)
+	retainDelay = 0
+
+	oldRaw := base64.StdEncoding.EncodeToString([]byte("old secret"))
+	newRaw := base64.StdEncoding.EncodeToString([]byte("new secret"))
+	old, err := DecodeBase64(oldRaw)
+	require.NoError(t, err)
+	current, err := DecodeBase64(newRaw)
+	require.NoError(t, err)
+
+	Retain(newRaw)
+	assert.Eventually(t, func() bool {
+		buffers.Lock()
+		defer buffers.Unlock()
+		return len(buffers.all) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, make([]byte, len(old)), old, "should destroy the secret which is no longer used")
+	assert.Equal(t, []byte("new secret"), current, "should keep the secret which is still used")
+
+	again, err := DecodeBase64(oldRaw)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("old secret"), again, "should decode a released secret again")
+
+	DestroyAll()
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/awsutil"
+)
+
+type awsGetSecretValueRequest struct {
+	SecretID     string `json:"SecretId"`
+	VersionID    string `json:"VersionId,omitempty"`
+	VersionStage string `json:"VersionStage,omitempty"`
+}
+
+type awsGetSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+	SecretBinary string `json:"SecretBinary"`
+}
+
+// resolveAWS reads a secret with the AWS Secrets Manager GetSecretValue API. The region comes
+// from the region query parameter, the region of an ARN, or the AWS_REGION environment variable,
+// and the credentials from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables.
+func (r *Resolver) resolveAWS(ctx context.Context, ref *Reference) (string, error) {
+	region := ref.Query.Get("region")
+	if region == "" && strings.HasPrefix(ref.Name, "arn:") {
+		if parts := strings.Split(ref.Name, ":"); len(parts) > 3 {
+			region = parts[3]
+		}
+	}
+	if region == "" {
+		region = r.getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = r.getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		return "", fmt.Errorf("no region, set AWS_REGION or the region query parameter")
+	}
+
+	creds, err := awsutil.CredentialsFromEnv(r.getenv)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(awsGetSecretValueRequest{
+		SecretID:     ref.Name,
+		VersionID:    ref.Query.Get("version_id"),
+		VersionStage: ref.Query.Get("version_stage"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := "https://secretsmanager." + region + ".amazonaws.com/"
+	if r.awsEndpoint != nil {
+		endpoint = r.awsEndpoint(region)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	awsutil.SignRequest(req, body, region, "secretsmanager", creds, time.Now())
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error querying aws secrets manager: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return "", fmt.Errorf("error querying aws secrets manager: unexpected status code %d: %s",
+			res.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var secret awsGetSecretValueResponse
+	if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("error decoding aws secrets manager response: %w", err)
+	}
+
+	value := secret.SecretString
+	if value == "" && secret.SecretBinary != "" {
+		bs, err := base64.StdEncoding.DecodeString(secret.SecretBinary)
+		if err != nil {
+			return "", fmt.Errorf("error decoding binary secret: %w", err)
+		}
+		value = string(bs)
+	}
+	return getJSONKey(value, ref.Key)
+}
@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/secretmanager/v1"
+)
+
+// resolveGCP reads a secret with the Google Cloud Secret Manager API, using the application
+// default credentials. The name is the resource name of the secret, like
+// `projects/my-project/secrets/my-secret`, optionally with a version. The latest version is used
+// by default.
+func (r *Resolver) resolveGCP(ctx context.Context, ref *Reference) (string, error) {
+	name := ref.Name
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "projects" && parts[2] == "secrets":
+		name += "/versions/latest"
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "secrets" && parts[4] == "versions":
+	default:
+		return "", fmt.Errorf("invalid secret name %s, expected projects/PROJECT/secrets/SECRET", name)
+	}
+
+	var opts []option.ClientOption
+	if r.gcpEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(r.gcpEndpoint), option.WithHTTPClient(r.httpClient))
+	}
+	svc, err := secretmanager.NewService(ctx, opts...)
+	if err != nil {
+		return "", fmt.Errorf("error creating google cloud secret manager client: %w", err)
+	}
+
+	res, err := svc.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("error querying google cloud secret manager: %w", err)
+	}
+	if res.Payload == nil {
+		return "", fmt.Errorf("secret %s has no payload", name)
+	}
+	bs, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("error decoding secret: %w", err)
+	}
+	return getJSONKey(string(bs), ref.Key)
+}
@@ -0,0 +1,167 @@
+// Package secrets resolves references to secrets stored in HashiCorp Vault, AWS Secrets Manager
+// and Google Cloud Secret Manager.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Schemes of secret references.
+const (
+	SchemeVault = "vault"
+	SchemeAWS   = "awssm"
+	SchemeGCP   = "gcpsm"
+)
+
+// A Reference is a reference to a secret, like `vault://secret/data/pomerium#shared_secret`.
+type Reference struct {
+	Scheme string
+	// Name is the path of the secret in Vault, the name or ARN of the AWS secret, or the
+	// resource name of the Google Cloud secret.
+	Name  string
+	Query url.Values
+	// Key is the key of the secret's value to use, when the secret has multiple values.
+	Key string
+}
+
+// IsReference returns true if the string is a secret reference.
+func IsReference(s string) bool {
+	for _, scheme := range []string{SchemeVault, SchemeAWS, SchemeGCP} {
+		if strings.HasPrefix(s, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseReference parses a secret reference. The name isn't parsed as a URL host, since ARNs
+// contain colons.
+func ParseReference(s string) (*Reference, error) {
+	idx := strings.Index(s, "://")
+	if !IsReference(s) || idx < 0 {
+		return nil, fmt.Errorf("secrets: unsupported secret reference %s", s)
+	}
+
+	ref := &Reference{Scheme: s[:idx]}
+	rest := s[idx+3:]
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		ref.Key, rest = rest[i+1:], rest[:i]
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		q, err := url.ParseQuery(rest[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("secrets: invalid secret reference %s: %w", s, err)
+		}
+		ref.Query, rest = q, rest[:i]
+	}
+	ref.Name = strings.Trim(rest, "/")
+	if ref.Name == "" {
+		return nil, fmt.Errorf("secrets: invalid secret reference %s: missing name", s)
+	}
+	return ref, nil
+}
+
+// String returns the reference as a string.
+func (ref *Reference) String() string {
+	s := ref.Scheme + "://" + ref.Name
+	if len(ref.Query) > 0 {
+		s += "?" + ref.Query.Encode()
+	}
+	if ref.Key != "" {
+		s += "#" + ref.Key
+	}
+	return s
+}
+
+// A Resolver resolves secret references.
+type Resolver struct {
+	httpClient *http.Client
+	getenv     func(string) string
+
+	vaultAddress string
+	awsEndpoint  func(region string) string
+	gcpEndpoint  string
+}
+
+var (
+	defaultResolver     *Resolver
+	defaultResolverOnce sync.Once
+)
+
+// DefaultResolver returns the resolver configured with the environment, like the VAULT_ADDR
+// and AWS_REGION environment variables.
+func DefaultResolver() *Resolver {
+	defaultResolverOnce.Do(func() {
+		defaultResolver = &Resolver{
+			httpClient: http.DefaultClient,
+			getenv:     os.Getenv,
+		}
+	})
+	return defaultResolver
+}
+
+// Resolve resolves the secret reference and returns the value of the secret.
+func (r *Resolver) Resolve(ctx context.Context, rawReference string) (string, error) {
+	ref, err := ParseReference(rawReference)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch ref.Scheme {
+	case SchemeVault:
+		value, err = r.resolveVault(ctx, ref)
+	case SchemeAWS:
+		value, err = r.resolveAWS(ctx, ref)
+	case SchemeGCP:
+		value, err = r.resolveGCP(ctx, ref)
+	}
+	if err != nil {
+		return "", fmt.Errorf("secrets: error resolving %s: %w", ref.String(), err)
+	}
+	return value, nil
+}
+
+// getKey returns the value of the key of a secret with multiple values. Without a key, the
+// secret must have a single value.
+func getKey(values map[string]interface{}, key string) (string, error) {
+	if key == "" {
+		if len(values) != 1 {
+			return "", fmt.Errorf("the secret has %d values, a key is required", len(values))
+		}
+		for k := range values {
+			key = k
+		}
+	}
+
+	raw, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found", key)
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("key %s is not a string", key)
+	}
+	return value, nil
+}
+
+// getJSONKey returns the value of the key of a secret whose value is a JSON object, or the value
+// itself when there's no key.
+func getJSONKey(value, key string) (string, error) {
+	if key == "" {
+		return value, nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return "", fmt.Errorf("key %s requires a JSON object secret: %w", key, err)
+	}
+	return getKey(values, key)
+}
@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	for _, tc := range []struct {
+		raw    string
+		expect *Reference
+	}{
+		{"vault://secret/data/pomerium#shared_secret", &Reference{Scheme: SchemeVault, Name: "secret/data/pomerium", Key: "shared_secret"}},
+		{"awssm://arn:aws:secretsmanager:us-east-2:123456789012:secret:pomerium-AbCdEf", &Reference{Scheme: SchemeAWS, Name: "arn:aws:secretsmanager:us-east-2:123456789012:secret:pomerium-AbCdEf"}},
+		{"awssm://pomerium?region=eu-west-1#cookie_secret", &Reference{Scheme: SchemeAWS, Name: "pomerium", Query: url.Values{"region": {"eu-west-1"}}, Key: "cookie_secret"}},
+		{"gcpsm://projects/example/secrets/idp-client-secret", &Reference{Scheme: SchemeGCP, Name: "projects/example/secrets/idp-client-secret"}},
+	} {
+		actual, err := ParseReference(tc.raw)
+		if assert.NoError(t, err, tc.raw) {
+			assert.Equal(t, tc.expect, actual, tc.raw)
+		}
+	}
+
+	for _, raw := range []string{"https://example.com", "vault://", "vault://#key"} {
+		_, err := ParseReference(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func newTestResolver(env map[string]string) *Resolver {
+	return &Resolver{
+		httpClient: http.DefaultClient,
+		getenv:     func(k string) string { return env[k] },
+	}
+}
+
+func TestResolveVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "TOKEN" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/pomerium":
+			_, _ = w.Write([]byte(`{"data":{"data":{"shared_secret":"SHARED","cookie_secret":"COOKIE"},"metadata":{"version":3}}}`))
+		case "/v1/kv/pomerium":
+			_, _ = w.Write([]byte(`{"data":{"shared_secret":"SHARED_V1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := newTestResolver(map[string]string{"VAULT_ADDR": srv.URL, "VAULT_TOKEN": "TOKEN"})
+	ctx := context.Background()
+
+	value, err := r.Resolve(ctx, "vault://secret/data/pomerium#cookie_secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "COOKIE", value)
+
+	value, err = r.Resolve(ctx, "vault://kv/pomerium")
+	assert.NoError(t, err)
+	assert.Equal(t, "SHARED_V1", value)
+
+	_, err = r.Resolve(ctx, "vault://secret/data/pomerium")
+	assert.Error(t, err, "a key is required for secrets with multiple values")
+	_, err = r.Resolve(ctx, "vault://secret/data/missing#key")
+	assert.Error(t, err)
+}
+
+func TestResolveAWS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "SESSION", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/"+r.Header.Get("X-Amz-Date")[:8]+"/us-east-2/secretsmanager/aws4_request"))
+
+		var req awsGetSecretValueRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.SecretID {
+		case "arn:aws:secretsmanager:us-east-2:123456789012:secret:pomerium":
+			_ = json.NewEncoder(w).Encode(awsGetSecretValueResponse{SecretString: `{"shared_secret":"SHARED"}`})
+		case "binary":
+			_ = json.NewEncoder(w).Encode(awsGetSecretValueResponse{SecretBinary: base64.StdEncoding.EncodeToString([]byte("BINARY"))})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	r := newTestResolver(map[string]string{
+		"AWS_ACCESS_KEY_ID":     "AKID",
+		"AWS_SECRET_ACCESS_KEY": "SECRET",
+		"AWS_SESSION_TOKEN":     "SESSION",
+		"AWS_REGION":            "us-east-2",
+	})
+	r.awsEndpoint = func(region string) string { return srv.URL }
+	ctx := context.Background()
+
+	value, err := r.Resolve(ctx, "awssm://arn:aws:secretsmanager:us-east-2:123456789012:secret:pomerium#shared_secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "SHARED", value)
+
+	value, err = r.Resolve(ctx, "awssm://binary")
+	assert.NoError(t, err)
+	assert.Equal(t, "BINARY", value)
+
+	_, err = r.Resolve(ctx, "awssm://missing")
+	assert.Error(t, err)
+}
+
+func TestResolveGCP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/projects/example/secrets/pomerium/versions/latest:access":
+			_, _ = w.Write([]byte(`{"payload":{"data":"` + base64.StdEncoding.EncodeToString([]byte("CLIENT_SECRET")) + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := newTestResolver(nil)
+	r.gcpEndpoint = srv.URL + "/"
+	ctx := context.Background()
+
+	value, err := r.Resolve(ctx, "gcpsm://projects/example/secrets/pomerium")
+	assert.NoError(t, err)
+	assert.Equal(t, "CLIENT_SECRET", value)
+
+	_, err = r.Resolve(ctx, "gcpsm://projects/example/secrets/missing/versions/1")
+	assert.Error(t, err)
+	_, err = r.Resolve(ctx, "gcpsm://example/pomerium")
+	assert.Error(t, err)
+}
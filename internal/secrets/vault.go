@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type vaultResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// resolveVault reads a secret with the Vault HTTP API, using the VAULT_ADDR, VAULT_TOKEN and
+// VAULT_NAMESPACE environment variables. Both KV version 1 and 2 secrets are supported.
+func (r *Resolver) resolveVault(ctx context.Context, ref *Reference) (string, error) {
+	addr := r.vaultAddress
+	if addr == "" {
+		addr = r.getenv("VAULT_ADDR")
+	}
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+
+	endpoint := strings.TrimSuffix(addr, "/") + "/v1/" + ref.Name
+	if len(ref.Query) > 0 {
+		endpoint += "?" + ref.Query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	if token := r.getenv("VAULT_TOKEN"); token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if namespace := r.getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error querying vault: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error querying vault: unexpected status code %d", res.StatusCode)
+	}
+
+	var body vaultResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("error decoding vault response: %w", err)
+	}
+
+	values := body.Data
+	// KV version 2 secrets wrap the values with their metadata
+	if data, ok := values["data"].(map[string]interface{}); ok {
+		if _, ok := values["metadata"]; ok {
+			values = data
+		}
+	}
+	return getKey(values, ref.Key)
+}
@@ -2,9 +2,15 @@
 package cookie
 
 import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"hash/crc32"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +32,10 @@ const (
 	// base64. It's important this byte is ASCII to avoid UTF-8 variable sized runes.
 	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie#Directives
 	ChunkedCanaryByte byte = '%'
+	// CompressedCanaryByte is the byte value used as a canary prefix to distinguish if
+	// the cookie value is compressed or not. Like the ChunkedCanaryByte, it *should not*
+	// be valid base64.
+	CompressedCanaryByte byte = '!'
 	// MaxChunkSize sets the upper bound on a cookie chunks payload value.
 	// Note, this should be lower than the actual cookie's max size (4096 bytes)
 	// which includes metadata.
@@ -33,6 +43,20 @@ const (
 	// MaxNumChunks limits the number of chunks to iterate through. Conservatively
 	// set to prevent any abuse.
 	MaxNumChunks = 5
+	// MaxDecompressedSize limits the size of a decompressed cookie value.
+	MaxDecompressedSize = 64 * 1024
+)
+
+// chunkedHeaderSeparator separates the number of chunks and the checksum of the
+// value from the first chunk. It's not valid base64, nor part of a JWT.
+const chunkedHeaderSeparator = ":"
+
+var (
+	errMissingChunk      = errors.New("internal/sessions: missing cookie chunk")
+	errInvalidChecksum   = errors.New("internal/sessions: invalid cookie checksum")
+	errTooManyChunks     = errors.New("internal/sessions: too many cookie chunks")
+	errValueTooLarge     = errors.New("internal/sessions: decompressed cookie value too large")
+	errInvalidCompressed = errors.New("internal/sessions: invalid compressed cookie value")
 )
 
 // Options holds options for Store
@@ -42,6 +66,8 @@ type Options struct {
 	Expire   time.Duration
 	HTTPOnly bool
 	Secure   bool
+	// Compress compresses the cookie value, when it makes it smaller.
+	Compress bool
 }
 
 // A GetOptionsFunc is a getter for cookie options.
@@ -95,12 +121,33 @@ func (cs *Store) makeCookie(value string) *http.Cookie {
 	}
 }
 
-// ClearSession clears the session cookie from a request
+// ClearSession clears the session cookie, and its chunks, from a request
 func (cs *Store) ClearSession(w http.ResponseWriter, r *http.Request) {
 	c := cs.makeCookie("")
 	c.MaxAge = -1
 	c.Expires = timeNow().Add(-time.Hour)
 	http.SetCookie(w, c)
+	cs.clearChunks(w, r, 1)
+}
+
+// clearChunks clears the chunks of the session cookie in the request, starting
+// with the chunk numbered from.
+func (cs *Store) clearChunks(w http.ResponseWriter, r *http.Request, from int) {
+	if r == nil {
+		return
+	}
+	name := cs.getOptions().Name
+	for i := from; i <= MaxNumChunks; i++ {
+		chunkName := fmt.Sprintf("%s_%d", name, i)
+		if _, err := r.Cookie(chunkName); err != nil {
+			continue
+		}
+		c := cs.makeCookie("")
+		c.Name = chunkName
+		c.MaxAge = -1
+		c.Expires = timeNow().Add(-time.Hour)
+		http.SetCookie(w, c)
+	}
 }
 
 func getCookies(r *http.Request, name string) []*http.Cookie {
@@ -122,10 +169,17 @@ func (cs *Store) LoadSession(r *http.Request) (string, error) {
 		return "", sessions.ErrNoSessionFound
 	}
 	for _, cookie := range cookies {
-		jwt := loadChunkedCookie(r, cookie)
+		jwt, err := loadChunkedCookie(r, cookie)
+		if err != nil {
+			continue
+		}
+		jwt, err = decompress(jwt)
+		if err != nil {
+			continue
+		}
 
 		session := &sessions.State{}
-		err := cs.decoder.Unmarshal([]byte(jwt), session)
+		err = cs.decoder.Unmarshal([]byte(jwt), session)
 		if err == nil {
 			return jwt, nil
 		}
@@ -134,7 +188,7 @@ func (cs *Store) LoadSession(r *http.Request) (string, error) {
 }
 
 // SaveSession saves a session state to a request's cookie store.
-func (cs *Store) SaveSession(w http.ResponseWriter, _ *http.Request, x interface{}) error {
+func (cs *Store) SaveSession(w http.ResponseWriter, r *http.Request, x interface{}) error {
 	var value string
 	switch v := x.(type) {
 	case []byte:
@@ -152,25 +206,46 @@ func (cs *Store) SaveSession(w http.ResponseWriter, _ *http.Request, x interface
 		value = string(data)
 	}
 
-	cs.setSessionCookie(w, value)
+	if cs.getOptions().Compress {
+		value = compress(value)
+	}
+
+	n, err := cs.setSessionCookie(w, value)
+	if err != nil {
+		return err
+	}
+	// clear the chunks of a previous, larger, session cookie
+	cs.clearChunks(w, r, n)
 	return nil
 }
 
-func (cs *Store) setSessionCookie(w http.ResponseWriter, val string) {
-	cs.setCookie(w, cs.makeCookie(val))
+// setSessionCookie sets the session cookie, and returns the number of cookies set.
+func (cs *Store) setSessionCookie(w http.ResponseWriter, val string) (int, error) {
+	return cs.setCookie(w, cs.makeCookie(val))
 }
 
-func (cs *Store) setCookie(w http.ResponseWriter, cookie *http.Cookie) {
+// setCookie sets the cookie, split into chunks when it's too large, and returns
+// the number of cookies set. The first chunk starts with the ChunkedCanaryByte,
+// followed by the number of chunks and the checksum of the value, so the chunks
+// can be validated when they're reassembled.
+func (cs *Store) setCookie(w http.ResponseWriter, cookie *http.Cookie) (int, error) {
 	if len(cookie.String()) <= MaxChunkSize {
 		http.SetCookie(w, cookie)
-		return
+		return 1, nil
+	}
+	chunks := chunk(cookie.Value, MaxChunkSize)
+	if len(chunks) > MaxNumChunks+1 {
+		return 0, errTooManyChunks
 	}
-	for i, c := range chunk(cookie.Value, MaxChunkSize) {
+	for i, c := range chunks {
 		// start with a copy of our original cookie
 		nc := *cookie
 		if i == 0 {
-			// if this is the first cookie, add our canary byte
-			nc.Value = fmt.Sprintf("%s%s", string(ChunkedCanaryByte), c)
+			// if this is the first cookie, add our canary byte and header
+			nc.Value = fmt.Sprintf("%s%d%s%08x%s%s", string(ChunkedCanaryByte),
+				len(chunks), chunkedHeaderSeparator,
+				crc32.ChecksumIEEE([]byte(cookie.Value)), chunkedHeaderSeparator,
+				c)
 		} else {
 			// subsequent parts will be postfixed with their part number
 			nc.Name = fmt.Sprintf("%s_%d", cookie.Name, i)
@@ -178,30 +253,112 @@ func (cs *Store) setCookie(w http.ResponseWriter, cookie *http.Cookie) {
 		}
 		http.SetCookie(w, &nc)
 	}
+	return len(chunks), nil
 }
 
-func loadChunkedCookie(r *http.Request, c *http.Cookie) string {
+func loadChunkedCookie(r *http.Request, c *http.Cookie) (string, error) {
 	if len(c.Value) == 0 {
-		return ""
+		return "", nil
 	}
 	// if the first byte is our canary byte, we need to handle the multipart bit
 	if []byte(c.Value)[0] != ChunkedCanaryByte {
-		return c.Value
+		return c.Value, nil
+	}
+
+	numChunks, checksum, data, ok := parseChunkedHeader(c.Value[1:])
+	if !ok {
+		// cookies set before the header was added only have the canary byte
+		return loadUnvalidatedChunks(r, c.Name, c.Value[1:]), nil
+	}
+	if numChunks > MaxNumChunks+1 {
+		return "", errTooManyChunks
 	}
 
-	data := c.Value
 	var b strings.Builder
-	fmt.Fprintf(&b, "%s", data[1:])
-	for i := 1; i <= MaxNumChunks; i++ {
+	b.WriteString(data)
+	for i := 1; i < numChunks; i++ {
 		next, err := r.Cookie(fmt.Sprintf("%s_%d", c.Name, i))
 		if err != nil {
-			break // break if we can't find the next cookie
+			return "", errMissingChunk
 		}
-		fmt.Fprintf(&b, "%s", next.Value)
+		b.WriteString(next.Value)
 	}
 	data = b.String()
 
-	return data
+	if crc32.ChecksumIEEE([]byte(data)) != checksum {
+		return "", errInvalidChecksum
+	}
+	return data, nil
+}
+
+// parseChunkedHeader parses the number of chunks and the checksum at the start
+// of the first chunk, and returns the rest of the chunk.
+func parseChunkedHeader(data string) (numChunks int, checksum uint32, rest string, ok bool) {
+	parts := strings.SplitN(data, chunkedHeaderSeparator, 3)
+	if len(parts) != 3 {
+		return 0, 0, "", false
+	}
+	numChunks, err := strconv.Atoi(parts[0])
+	if err != nil || numChunks < 1 {
+		return 0, 0, "", false
+	}
+	sum, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return 0, 0, "", false
+	}
+	return numChunks, uint32(sum), parts[2], true
+}
+
+func loadUnvalidatedChunks(r *http.Request, name, data string) string {
+	var b strings.Builder
+	b.WriteString(data)
+	for i := 1; i <= MaxNumChunks; i++ {
+		next, err := r.Cookie(fmt.Sprintf("%s_%d", name, i))
+		if err != nil {
+			break // break if we can't find the next cookie
+		}
+		b.WriteString(next.Value)
+	}
+	return b.String()
+}
+
+// compress returns the compressed value, prefixed with the CompressedCanaryByte,
+// or the value when compressing it doesn't make it smaller.
+func compress(value string) string {
+	var buf bytes.Buffer
+	zw, _ := flate.NewWriter(&buf, flate.BestCompression)
+	_, _ = zw.Write([]byte(value))
+	_ = zw.Close()
+
+	compressed := string(CompressedCanaryByte) + base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	if len(compressed) >= len(value) {
+		return value
+	}
+	return compressed
+}
+
+// decompress returns the decompressed value, when it's prefixed with the
+// CompressedCanaryByte, or the value otherwise.
+func decompress(value string) (string, error) {
+	if len(value) == 0 || value[0] != CompressedCanaryByte {
+		return value, nil
+	}
+
+	compressed, err := base64.RawURLEncoding.DecodeString(value[1:])
+	if err != nil {
+		return "", errInvalidCompressed
+	}
+	zr := flate.NewReader(bytes.NewReader(compressed))
+	defer zr.Close()
+
+	decompressed, err := io.ReadAll(io.LimitReader(zr, MaxDecompressedSize+1))
+	if err != nil {
+		return "", errInvalidCompressed
+	}
+	if len(decompressed) > MaxDecompressedSize {
+		return "", errValueTooLarge
+	}
+	return string(decompressed), nil
 }
 
 func chunk(s string, size int) []string {
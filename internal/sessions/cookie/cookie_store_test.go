@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -174,3 +175,111 @@ func TestStore_SaveSession(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_ChunkedCookie(t *testing.T) {
+	c, err := cryptutil.NewAEADCipher(cryptutil.NewKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hugeString := make([]byte, 4097)
+	if _, err := rand.Read(hugeString); err != nil {
+		t.Fatal(err)
+	}
+	state := &sessions.State{ID: "xyz", Subject: fmt.Sprintf("%x", hugeString)}
+
+	newStore := func(compress bool) *Store {
+		return &Store{
+			getOptions: func() Options {
+				return Options{Name: "_pomerium", Expire: 10 * time.Second, Compress: compress}
+			},
+			encoder: ecjson.New(c),
+			decoder: ecjson.New(c),
+		}
+	}
+	saveSession := func(t *testing.T, s *Store, r *http.Request, x interface{}) []*http.Cookie {
+		t.Helper()
+		w := httptest.NewRecorder()
+		if err := s.SaveSession(w, r, x); err != nil {
+			t.Fatal(err)
+		}
+		return w.Result().Cookies()
+	}
+	newRequest := func(cookies []*http.Cookie) *http.Request {
+		r := httptest.NewRequest("GET", "/", nil)
+		for _, cookie := range cookies {
+			r.AddCookie(cookie)
+		}
+		return r
+	}
+
+	t.Run("reassembled", func(t *testing.T) {
+		s := newStore(false)
+		cookies := saveSession(t, s, nil, state)
+		if len(cookies) < 2 {
+			t.Fatalf("expected chunked cookies, got %d", len(cookies))
+		}
+		if _, err := s.LoadSession(newRequest(cookies)); err != nil {
+			t.Errorf("LoadSession() error = %v", err)
+		}
+	})
+	t.Run("missing chunk", func(t *testing.T) {
+		s := newStore(false)
+		cookies := saveSession(t, s, nil, state)
+		if _, err := s.LoadSession(newRequest(cookies[:len(cookies)-1])); !errors.Is(err, sessions.ErrMalformed) {
+			t.Errorf("LoadSession() error = %v, want %v", err, sessions.ErrMalformed)
+		}
+	})
+	t.Run("invalid checksum", func(t *testing.T) {
+		s := newStore(false)
+		cookies := saveSession(t, s, nil, state)
+		cookies[1].Value += "x"
+		if _, err := loadChunkedCookie(newRequest(cookies), cookies[0]); !errors.Is(err, errInvalidChecksum) {
+			t.Errorf("loadChunkedCookie() error = %v, want %v", err, errInvalidChecksum)
+		}
+	})
+	t.Run("without header", func(t *testing.T) {
+		s := newStore(false)
+		cookies := saveSession(t, s, nil, state)
+		_, _, rest, ok := parseChunkedHeader(cookies[0].Value[1:])
+		if !ok {
+			t.Fatal("expected a chunked cookie header")
+		}
+		cookies[0].Value = string(ChunkedCanaryByte) + rest
+		if _, err := s.LoadSession(newRequest(cookies)); err != nil {
+			t.Errorf("LoadSession() error = %v", err)
+		}
+	})
+	t.Run("compressed", func(t *testing.T) {
+		s := newStore(true)
+		jwt := strings.Repeat("a", 2*MaxChunkSize)
+		cookies := saveSession(t, s, nil, jwt)
+		if len(cookies) != 1 || cookies[0].Value[0] != CompressedCanaryByte {
+			t.Fatalf("expected a single compressed cookie, got %v", cookies)
+		}
+		got, err := loadChunkedCookie(newRequest(cookies), cookies[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err = decompress(got); err != nil || got != jwt {
+			t.Errorf("decompress() = %q, %v", got, err)
+		}
+	})
+	t.Run("too large to decompress", func(t *testing.T) {
+		if _, err := decompress(compress(strings.Repeat("a", MaxDecompressedSize+1))); !errors.Is(err, errValueTooLarge) {
+			t.Errorf("decompress() error = %v, want %v", err, errValueTooLarge)
+		}
+	})
+	t.Run("clears stale chunks", func(t *testing.T) {
+		s := newStore(false)
+		chunked := saveSession(t, s, nil, state)
+		cookies := saveSession(t, s, newRequest(chunked), &sessions.State{ID: "xyz"})
+		if len(cookies) != len(chunked) {
+			t.Fatalf("expected %d cookies, got %d", len(chunked), len(cookies))
+		}
+		for _, cookie := range cookies[1:] {
+			if cookie.MaxAge >= 0 {
+				t.Errorf("expected stale chunk %s to be cleared", cookie.Name)
+			}
+		}
+	})
+}
@@ -0,0 +1,321 @@
+// Package siem formats events for security information and event management systems, in the
+// ArcSight Common Event Format (CEF), the IBM QRadar Log Event Extended Format (LEEF) and the
+// Elastic Common Schema (ECS).
+package siem
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+// The formats of events.
+const (
+	FormatCEF  = "cef"
+	FormatLEEF = "leef"
+	FormatECS  = "ecs"
+)
+
+// ECSVersion is the version of the Elastic Common Schema of ECS events.
+const ECSVersion = "8.4.0"
+
+const (
+	vendor  = "Pomerium"
+	product = "Pomerium"
+)
+
+// Outcomes of events.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// An Event is an event to format. Empty fields are omitted.
+type Event struct {
+	Time time.Time
+	// ID identifies the class of the event, like http-request or login.
+	ID      string
+	Message string
+	// Severity is between 0 and 10.
+	Severity int
+	// Dataset, Categories and Types are the ECS event dataset, categories and types.
+	Dataset    string
+	Categories []string
+	Types      []string
+	Outcome    string
+	Service    string
+
+	// EventID is the unique ID of the event.
+	EventID   string
+	SourceIP  string
+	UserID    string
+	Email     string
+	SessionID string
+	RequestID string
+
+	Method    string
+	URL       string
+	Host      string
+	Path      string
+	Protocol  string
+	Status    int
+	Bytes     uint64
+	Duration  time.Duration
+	UserAgent string
+	Referrer  string
+	IsLogin   bool
+	IsLogout  bool
+	Labels    map[string]string
+}
+
+// Format formats the event in the format, which is cef, leef or ecs.
+func Format(format string, evt *Event) []byte {
+	switch format {
+	case FormatCEF:
+		return CEF(evt)
+	case FormatLEEF:
+		return LEEF(evt)
+	default:
+		return ECS(evt)
+	}
+}
+
+// CEF formats the event in the Common Event Format, without a trailing newline.
+//
+// https://www.microfocus.com/documentation/arcsight/arcsight-smartconnectors/pdfdoc/common-event-format-v25/common-event-format-v25.pdf
+func CEF(evt *Event) []byte {
+	var sb strings.Builder
+	sb.WriteString("CEF:0")
+	for _, v := range []string{vendor, product, version.FullVersion(), evt.ID, evt.Message, strconv.Itoa(evt.Severity)} {
+		sb.WriteByte('|')
+		sb.WriteString(cefHeaderEscaper.Replace(v))
+	}
+	sb.WriteByte('|')
+
+	var ext []string
+	add := func(k, v string) {
+		if v != "" {
+			ext = append(ext, k+"="+cefExtensionEscaper.Replace(v))
+		}
+	}
+	if !evt.Time.IsZero() {
+		add("rt", strconv.FormatInt(evt.Time.UnixNano()/int64(time.Millisecond), 10))
+	}
+	add("src", evt.SourceIP)
+	add("suid", evt.UserID)
+	add("suser", evt.Email)
+	add("externalId", evt.EventID)
+	add("outcome", evt.Outcome)
+	add("app", evt.Protocol)
+	add("requestMethod", evt.Method)
+	add("request", evt.URL)
+	add("requestClientApplication", evt.UserAgent)
+	add("requestContext", evt.Referrer)
+	if evt.Bytes > 0 {
+		add("out", strconv.FormatUint(evt.Bytes, 10))
+	}
+	if evt.Status > 0 {
+		add("cn1", strconv.Itoa(evt.Status))
+		add("cn1Label", "responseCode")
+	}
+	if evt.Duration > 0 {
+		add("cn2", strconv.FormatInt(evt.Duration.Milliseconds(), 10))
+		add("cn2Label", "durationMs")
+	}
+	if evt.SessionID != "" {
+		add("cs1", evt.SessionID)
+		add("cs1Label", "sessionId")
+	}
+	if evt.Service != "" {
+		add("cs2", evt.Service)
+		add("cs2Label", "service")
+	}
+	if evt.RequestID != "" {
+		add("cs3", evt.RequestID)
+		add("cs3Label", "requestId")
+	}
+	for _, k := range sortedKeys(evt.Labels) {
+		add(labelKey(k), evt.Labels[k])
+	}
+	sb.WriteString(strings.Join(ext, " "))
+	return []byte(sb.String())
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+// LEEF formats the event in the Log Event Extended Format version 1.0, with tab separated
+// attributes, without a trailing newline.
+//
+// https://www.ibm.com/docs/en/dsm?topic=leef-overview
+func LEEF(evt *Event) []byte {
+	var sb strings.Builder
+	sb.WriteString("LEEF:1.0")
+	for _, v := range []string{vendor, product, version.FullVersion(), evt.ID} {
+		sb.WriteByte('|')
+		sb.WriteString(leefHeaderEscaper.Replace(v))
+	}
+	sb.WriteByte('|')
+
+	var attrs []string
+	add := func(k, v string) {
+		if v != "" {
+			attrs = append(attrs, k+"="+leefAttributeEscaper.Replace(v))
+		}
+	}
+	if !evt.Time.IsZero() {
+		add("devTime", evt.Time.UTC().Format("Jan 02 2006 15:04:05.000 MST"))
+		add("devTimeFormat", "MMM dd yyyy HH:mm:ss.SSS z")
+	}
+	add("cat", evt.ID)
+	add("sev", strconv.Itoa(evt.Severity))
+	add("msg", evt.Message)
+	add("eventId", evt.EventID)
+	add("src", evt.SourceIP)
+	add("usrName", evt.Email)
+	add("userId", evt.UserID)
+	add("sessionId", evt.SessionID)
+	add("requestId", evt.RequestID)
+	add("outcome", evt.Outcome)
+	add("service", evt.Service)
+	add("proto", evt.Protocol)
+	add("method", evt.Method)
+	add("url", evt.URL)
+	add("userAgent", evt.UserAgent)
+	add("referrer", evt.Referrer)
+	if evt.Status > 0 {
+		add("responseCode", strconv.Itoa(evt.Status))
+	}
+	if evt.Bytes > 0 {
+		add("dstBytes", strconv.FormatUint(evt.Bytes, 10))
+	}
+	if evt.Duration > 0 {
+		add("durationMs", strconv.FormatInt(evt.Duration.Milliseconds(), 10))
+	}
+	if evt.IsLogin {
+		add("isLoginEvent", "true")
+	}
+	if evt.IsLogout {
+		add("isLogoutEvent", "true")
+	}
+	for _, k := range sortedKeys(evt.Labels) {
+		add(labelKey(k), evt.Labels[k])
+	}
+	sb.WriteString(strings.Join(attrs, "\t"))
+	return []byte(sb.String())
+}
+
+var (
+	leefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\t", " ", "\r", " ", "\n", " ")
+	leefAttributeEscaper = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+)
+
+// ECS formats the event as an Elastic Common Schema JSON document, without a trailing newline.
+//
+// https://www.elastic.co/guide/en/ecs/current/index.html
+func ECS(evt *Event) []byte {
+	doc := map[string]interface{}{
+		"ecs": map[string]interface{}{"version": ECSVersion},
+	}
+	if !evt.Time.IsZero() {
+		doc["@timestamp"] = evt.Time.UTC().Format(time.RFC3339Nano)
+	}
+	set(doc, evt.Message, "message")
+	set(doc, "event", "event", "kind")
+	set(doc, evt.ID, "event", "action")
+	set(doc, evt.EventID, "event", "id")
+	set(doc, evt.Dataset, "event", "dataset")
+	set(doc, evt.Categories, "event", "category")
+	set(doc, evt.Types, "event", "type")
+	set(doc, evt.Outcome, "event", "outcome")
+	set(doc, evt.Severity, "event", "severity")
+	set(doc, int64(evt.Duration), "event", "duration")
+	set(doc, evt.Service, "service", "name")
+	set(doc, evt.SourceIP, "source", "ip")
+	set(doc, evt.UserID, "user", "id")
+	set(doc, evt.Email, "user", "email")
+	set(doc, evt.Email, "user", "name")
+	set(doc, evt.SessionID, "pomerium", "session_id")
+	set(doc, evt.RequestID, "http", "request", "id")
+	set(doc, evt.Method, "http", "request", "method")
+	set(doc, evt.Referrer, "http", "request", "referrer")
+	set(doc, strings.TrimPrefix(evt.Protocol, "HTTP/"), "http", "version")
+	set(doc, evt.Status, "http", "response", "status_code")
+	set(doc, evt.Bytes, "http", "response", "bytes")
+	set(doc, evt.URL, "url", "original")
+	set(doc, evt.Host, "url", "domain")
+	set(doc, evt.Path, "url", "path")
+	set(doc, evt.UserAgent, "user_agent", "original")
+	if len(evt.Labels) > 0 {
+		labels := make(map[string]string, len(evt.Labels))
+		for k, v := range evt.Labels {
+			labels[labelKey(k)] = v
+		}
+		doc["labels"] = labels
+	}
+
+	bs, err := json.Marshal(doc)
+	if err != nil {
+		return []byte("{}")
+	}
+	return bs
+}
+
+// set sets the value at the path of nested objects, unless it's empty.
+func set(doc map[string]interface{}, v interface{}, path ...string) {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return
+		}
+	case []string:
+		if len(v) == 0 {
+			return
+		}
+	case int:
+		if v == 0 {
+			return
+		}
+	case int64:
+		if v == 0 {
+			return
+		}
+	case uint64:
+		if v == 0 {
+			return
+		}
+	}
+	for _, k := range path[:len(path)-1] {
+		child, ok := doc[k].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			doc[k] = child
+		}
+		doc = child
+	}
+	doc[path[len(path)-1]] = v
+}
+
+var invalidLabelKeyCharacters = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// labelKey replaces the characters of a label key which aren't valid in CEF extension keys, LEEF
+// attribute names and ECS labels with underscores.
+func labelKey(k string) string {
+	return invalidLabelKeyCharacters.ReplaceAllString(k, "_")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,77 @@
+package siem
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+var testEvent = &Event{
+	Time:       time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+	ID:         "login",
+	Message:    "user logged in",
+	Severity:   3,
+	Dataset:    "pomerium.audit",
+	Categories: []string{"authentication"},
+	Types:      []string{"start"},
+	Outcome:    OutcomeSuccess,
+	EventID:    "EVENT-ID",
+	SourceIP:   "192.0.2.1",
+	Email:      "user@example.com",
+	IsLogin:    true,
+	Labels:     map[string]string{"idp": "google"},
+}
+
+func TestCEF(t *testing.T) {
+	assert.Equal(t, "CEF:0|Pomerium|Pomerium|"+version.FullVersion()+"|login|user logged in|3|"+
+		"rt=1646370367000 src=192.0.2.1 suser=user@example.com externalId=EVENT-ID outcome=success idp=google",
+		string(CEF(testEvent)))
+
+	assert.Equal(t, `CEF:0|Pomerium|Pomerium|`+version.FullVersion()+`|a\|b|line one|0|request=/?a\=b\\c\nd`,
+		string(CEF(&Event{ID: "a|b", Message: "line\none", URL: "/?a=b\\c\nd"})))
+}
+
+func TestLEEF(t *testing.T) {
+	leef := string(LEEF(testEvent))
+	assert.True(t, strings.HasPrefix(leef, "LEEF:1.0|Pomerium|Pomerium|"+version.FullVersion()+"|login|"), leef)
+	assert.Equal(t, []string{
+		"devTime=Mar 04 2022 05:06:07.000 UTC", "devTimeFormat=MMM dd yyyy HH:mm:ss.SSS z",
+		"cat=login", "sev=3", "msg=user logged in", "eventId=EVENT-ID", "src=192.0.2.1",
+		"usrName=user@example.com", "outcome=success", "isLoginEvent=true", "idp=google",
+	}, strings.Split(leef[strings.LastIndex(leef, "|")+1:], "\t"))
+
+	assert.Contains(t, string(LEEF(&Event{Message: "a\tb"})), "msg=a b")
+}
+
+func TestECS(t *testing.T) {
+	var m map[string]interface{}
+	require.NoError(t, json.Unmarshal(ECS(testEvent), &m))
+	assert.Equal(t, map[string]interface{}{
+		"@timestamp": "2022-03-04T05:06:07Z",
+		"ecs":        map[string]interface{}{"version": ECSVersion},
+		"message":    "user logged in",
+		"event": map[string]interface{}{
+			"kind":     "event",
+			"action":   "login",
+			"id":       "EVENT-ID",
+			"dataset":  "pomerium.audit",
+			"category": []interface{}{"authentication"},
+			"type":     []interface{}{"start"},
+			"outcome":  "success",
+			"severity": 3.0,
+		},
+		"source": map[string]interface{}{"ip": "192.0.2.1"},
+		"user":   map[string]interface{}{"email": "user@example.com", "name": "user@example.com"},
+		"labels": map[string]interface{}{"idp": "google"},
+	}, m)
+}
+
+func TestLabelKey(t *testing.T) {
+	assert.Equal(t, "request_header_x_tenant", labelKey("request.header.x-tenant"))
+}
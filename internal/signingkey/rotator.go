@@ -0,0 +1,233 @@
+package signingkey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/internal/events"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/pkg/grpc/crypt"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+const (
+	leaseTTL              = 30 * time.Second
+	rotationRetryInterval = time.Minute
+)
+
+type rotatorConfig struct {
+	sharedKey []byte
+	algorithm jose.SignatureAlgorithm
+	interval  time.Duration
+	overlap   time.Duration
+}
+
+// A Rotator creates a new signing key at the configured interval, and removes rotated
+// signing keys once the overlap has passed. Only one Rotator runs at a time, using a
+// databroker lease.
+type Rotator struct {
+	client  databroker.DataBrokerServiceClient
+	updated chan struct{}
+
+	mu  sync.RWMutex
+	cfg rotatorConfig
+}
+
+// NewRotator creates a new Rotator. Signing keys aren't rotated until UpdateConfig is
+// called with a non-zero interval.
+func NewRotator(client databroker.DataBrokerServiceClient) *Rotator {
+	return &Rotator{
+		client:  client,
+		updated: make(chan struct{}, 1),
+	}
+}
+
+// UpdateConfig updates the rotator config. When the algorithm changes, the active signing
+// key is rotated immediately.
+func (r *Rotator) UpdateConfig(sharedKey []byte, algorithm string, interval, overlap time.Duration) {
+	cfg := rotatorConfig{
+		sharedKey: sharedKey,
+		algorithm: jose.SignatureAlgorithm(algorithm),
+		interval:  interval,
+		overlap:   overlap,
+	}
+	if cfg.algorithm == "" {
+		cfg.algorithm = jose.ES256
+	}
+
+	r.mu.Lock()
+	r.cfg = cfg
+	r.mu.Unlock()
+
+	select {
+	case r.updated <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs the rotator until the context is canceled.
+func (r *Rotator) Run(ctx context.Context) error {
+	leaser := databroker.NewLeaser("signing_key_rotator", leaseTTL, r)
+	return leaser.Run(ctx)
+}
+
+// GetDataBrokerServiceClient returns the databroker client.
+func (r *Rotator) GetDataBrokerServiceClient() databroker.DataBrokerServiceClient {
+	return r.client
+}
+
+// RunLeased rotates signing keys when a lease is acquired.
+func (r *Rotator) RunLeased(ctx context.Context) error {
+	for {
+		r.mu.RLock()
+		cfg := r.cfg
+		r.mu.RUnlock()
+
+		// when rotation is disabled, wait for the config to change
+		var tick <-chan time.Time
+		var timer *time.Timer
+		if cfg.interval > 0 {
+			next, err := r.rotate(ctx, cfg, time.Now())
+			if err != nil {
+				log.Error(ctx).Err(err).Msg("signingkey: error rotating signing keys")
+				next = rotationRetryInterval
+			}
+			timer = time.NewTimer(next)
+			tick = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-r.updated:
+		case <-tick:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// rotate creates the next signing key the overlap before the active key is due to be
+// rotated, rotates the keys replaced by the active key, and deletes expired signing keys. It
+// returns how long to wait until the next change.
+func (r *Rotator) rotate(ctx context.Context, cfg rotatorConfig, now time.Time) (time.Duration, error) {
+	keys, err := List(ctx, r.client)
+	if err != nil {
+		return 0, err
+	}
+
+	var records []*databroker.Record
+	var evts []*events.SigningKeyEvent
+	put := func(key *crypt.SigningKey, kind events.SigningKeyEvent_EventKind) {
+		record := databroker.NewRecord(key)
+		if kind == events.SigningKeyEvent_EVENT_KIND_DELETED {
+			record.DeletedAt = timestamppb.New(now)
+		}
+		records = append(records, record)
+		evts = append(evts, newEvent(key, kind, now))
+	}
+
+	active, next := Active(keys, now), nextKey(keys, now)
+	if active == nil || active.GetAlgorithm() != string(cfg.algorithm) {
+		// there's no key to replace gracefully, so the new key is activated immediately
+		key, err := New(cfg.sharedKey, cfg.algorithm, now)
+		if err != nil {
+			return 0, err
+		}
+		for _, k := range keys {
+			if k.GetRotatedAt() == nil {
+				k.RotatedAt = timestamppb.New(now)
+				put(k, events.SigningKeyEvent_EVENT_KIND_ROTATED)
+			}
+		}
+		keys = append(keys, key)
+		put(key, events.SigningKeyEvent_EVENT_KIND_CREATED)
+		active, next = key, nil
+	}
+	if rotateAt := activatedAt(active).Add(cfg.interval); next == nil && !now.Before(rotateAt.Add(-cfg.overlap)) {
+		// the next key is published the overlap before it's activated, so relying parties
+		// which cache the JWKS have it once it's used
+		key, err := New(cfg.sharedKey, cfg.algorithm, now)
+		if err != nil {
+			return 0, err
+		}
+		if rotateAt.After(now) {
+			key.ActivatesAt = timestamppb.New(rotateAt)
+			next = key
+		} else {
+			active = key
+		}
+		keys = append(keys, key)
+		put(key, events.SigningKeyEvent_EVENT_KIND_CREATED)
+	}
+
+	// the keys replaced by the active key are rotated
+	for _, key := range keys {
+		if key != active && key.GetRotatedAt() == nil && !isPending(key, now) {
+			key.RotatedAt = timestamppb.New(now)
+			put(key, events.SigningKeyEvent_EVENT_KIND_ROTATED)
+		}
+	}
+
+	wait := activatedAt(active).Add(cfg.interval - cfg.overlap).Sub(now)
+	if next != nil {
+		wait = activatedAt(next).Sub(now)
+	}
+	for _, key := range keys {
+		if key.GetRotatedAt() == nil {
+			continue
+		}
+		if isExpired(key, now, cfg.overlap) {
+			put(key, events.SigningKeyEvent_EVENT_KIND_DELETED)
+			continue
+		}
+		if d := cfg.overlap - now.Sub(key.GetRotatedAt().AsTime()); d < wait {
+			wait = d
+		}
+	}
+
+	if len(records) > 0 {
+		_, err = r.client.Put(ctx, &databroker.PutRequest{Records: records})
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, evt := range evts {
+		log.Info(ctx).
+			Str("key-id", evt.GetKeyId()).
+			Str("algorithm", evt.GetAlgorithm()).
+			Msg("signingkey: " + evt.GetMessage())
+		events.Dispatch(evt)
+	}
+
+	metrics.RecordSigningKeyRotation(ctx, activatedAt(active), len(Published(keys, now, cfg.overlap)))
+	return wait, nil
+}
+
+func newEvent(key *crypt.SigningKey, kind events.SigningKeyEvent_EventKind, now time.Time) *events.SigningKeyEvent {
+	var message string
+	switch kind {
+	case events.SigningKeyEvent_EVENT_KIND_CREATED:
+		message = "created signing key"
+	case events.SigningKeyEvent_EVENT_KIND_ROTATED:
+		message = "rotated signing key"
+	case events.SigningKeyEvent_EVENT_KIND_DELETED:
+		message = "deleted signing key"
+	}
+	return &events.SigningKeyEvent{
+		Time:        timestamppb.New(now),
+		Message:     message,
+		KeyId:       key.GetId(),
+		Algorithm:   key.GetAlgorithm(),
+		Kind:        kind,
+		ActivatesAt: timestamppb.New(activatedAt(key)),
+	}
+}
@@ -0,0 +1,167 @@
+// Package signingkey manages the keys used to sign the attestation JWT when they are
+// rotated automatically. Signing keys are stored in the databroker.
+package signingkey
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/crypt"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// there should only ever be a handful of signing keys
+const listLimit = 1000
+
+// New creates a new signing key for the given algorithm. The private key is encrypted with
+// the shared secret.
+func New(sharedKey []byte, alg jose.SignatureAlgorithm, now time.Time) (*crypt.SigningKey, error) {
+	jwk, err := cryptutil.NewPrivateJWK(alg)
+	if err != nil {
+		return nil, err
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(jwk.Key)
+	if err != nil {
+		return nil, fmt.Errorf("signingkey: error marshaling private key: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(jwk.Public().Key)
+	if err != nil {
+		return nil, fmt.Errorf("signingkey: error marshaling public key: %w", err)
+	}
+	cipher, err := cryptutil.NewAEADCipher(sharedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	return &crypt.SigningKey{
+		Id:                  jwk.KeyID,
+		Algorithm:           jwk.Algorithm,
+		PublicKey:           pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}),
+		EncryptedPrivateKey: cryptutil.Encrypt(cipher, privatePEM, []byte(jwk.KeyID)),
+		CreatedAt:           timestamppb.New(now),
+	}, nil
+}
+
+// PrivateJWK decrypts the private key of a signing key with the first of the shared keys it was
+// encrypted with, and returns it as a JSON Web Key. The accepted shared keys are passed, so keys
+// encrypted before the shared key was rotated can still be used.
+func PrivateJWK(sharedKeys [][]byte, key *crypt.SigningKey) (*jose.JSONWebKey, error) {
+	err := errors.New("no shared key")
+	for _, sharedKey := range sharedKeys {
+		cipher, cipherErr := cryptutil.NewAEADCipher(sharedKey)
+		if cipherErr != nil {
+			return nil, cipherErr
+		}
+		var privatePEM []byte
+		privatePEM, err = cryptutil.Decrypt(cipher, key.GetEncryptedPrivateKey(), []byte(key.GetId()))
+		if err == nil {
+			return cryptutil.PrivateJWKFromBytes(privatePEM)
+		}
+	}
+	return nil, fmt.Errorf("signingkey: error decrypting private key: %w", err)
+}
+
+// PublicJWK returns the public key of a signing key as a JSON Web Key.
+func PublicJWK(key *crypt.SigningKey) (*jose.JSONWebKey, error) {
+	return cryptutil.PublicJWKFromBytes(key.GetPublicKey())
+}
+
+// Active returns the active signing key, which is the newest activated key that hasn't been
+// rotated. When the key replaced by a pending key was rotated before the pending key's
+// activation time passed on this host, the pending key is active. nil is returned if all the
+// keys were rotated.
+func Active(keys []*crypt.SigningKey, now time.Time) *crypt.SigningKey {
+	var active *crypt.SigningKey
+	for _, key := range keys {
+		if key.GetRotatedAt() != nil || isPending(key, now) {
+			continue
+		}
+		if active == nil || activatedAt(key).After(activatedAt(active)) {
+			active = key
+		}
+	}
+	if active == nil {
+		return nextKey(keys, now)
+	}
+	return active
+}
+
+// activatedAt returns when the key is first used to sign.
+func activatedAt(key *crypt.SigningKey) time.Time {
+	if key.GetActivatesAt() != nil {
+		return key.GetActivatesAt().AsTime()
+	}
+	return key.GetCreatedAt().AsTime()
+}
+
+// Published returns the signing keys which are published in the JWKS, newest first: the
+// active and pending keys and the keys rotated within the overlap.
+func Published(keys []*crypt.SigningKey, now time.Time, overlap time.Duration) []*crypt.SigningKey {
+	var published []*crypt.SigningKey
+	for _, key := range keys {
+		if !isExpired(key, now, overlap) {
+			published = append(published, key)
+		}
+	}
+	sort.Slice(published, func(i, j int) bool {
+		return published[i].GetCreatedAt().AsTime().After(published[j].GetCreatedAt().AsTime())
+	})
+	return published
+}
+
+// List lists all the signing keys stored in the databroker.
+func List(ctx context.Context, client databroker.DataBrokerServiceClient) ([]*crypt.SigningKey, error) {
+	res, err := client.Query(ctx, &databroker.QueryRequest{
+		Type:  grpcutil.GetTypeURL(new(crypt.SigningKey)),
+		Limit: listLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*crypt.SigningKey, 0, len(res.GetRecords()))
+	for _, record := range res.GetRecords() {
+		key := new(crypt.SigningKey)
+		if err := record.GetData().UnmarshalTo(key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// nextKey returns the pending key which is activated first, or nil if there are no pending
+// keys.
+func nextKey(keys []*crypt.SigningKey, now time.Time) *crypt.SigningKey {
+	var next *crypt.SigningKey
+	for _, key := range keys {
+		if key.GetRotatedAt() != nil || !isPending(key, now) {
+			continue
+		}
+		if next == nil || activatedAt(key).Before(activatedAt(next)) {
+			next = key
+		}
+	}
+	return next
+}
+
+// isPending returns true if the key is published, but isn't activated yet.
+func isPending(key *crypt.SigningKey, now time.Time) bool {
+	return activatedAt(key).After(now)
+}
+
+// isExpired returns true if the key was rotated longer than the overlap ago.
+func isExpired(key *crypt.SigningKey, now time.Time, overlap time.Duration) bool {
+	return key.GetRotatedAt() != nil && now.Sub(key.GetRotatedAt().AsTime()) >= overlap
+}
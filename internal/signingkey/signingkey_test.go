@@ -0,0 +1,173 @@
+package signingkey
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	internal_databroker "github.com/pomerium/pomerium/internal/databroker"
+	"github.com/pomerium/pomerium/internal/events"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/crypt"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+func TestNew(t *testing.T) {
+	sharedKey := cryptutil.NewKey()
+	for _, alg := range []jose.SignatureAlgorithm{jose.ES256, jose.RS256, jose.EdDSA} {
+		t.Run(string(alg), func(t *testing.T) {
+			key, err := New(sharedKey, alg, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, string(alg), key.GetAlgorithm())
+
+			privateJWK, err := PrivateJWK([][]byte{sharedKey}, key)
+			require.NoError(t, err)
+			assert.Equal(t, key.GetId(), privateJWK.KeyID)
+
+			privateJWK, err = PrivateJWK([][]byte{cryptutil.NewKey(), sharedKey}, key)
+			require.NoError(t, err, "should decrypt with an accepted shared key")
+			assert.Equal(t, key.GetId(), privateJWK.KeyID)
+
+			publicJWK, err := PublicJWK(key)
+			require.NoError(t, err)
+			assert.Equal(t, key.GetId(), publicJWK.KeyID)
+			assert.True(t, publicJWK.IsPublic())
+
+			_, err = PrivateJWK([][]byte{cryptutil.NewKey()}, key)
+			assert.Error(t, err, "should not decrypt with a different shared key")
+		})
+	}
+}
+
+func TestActiveAndPublished(t *testing.T) {
+	now := time.Now()
+	k1 := &crypt.SigningKey{
+		Id:        "k1",
+		CreatedAt: timestamppb.New(now.Add(-3 * time.Hour)),
+		RotatedAt: timestamppb.New(now.Add(-2 * time.Hour)),
+	}
+	k2 := &crypt.SigningKey{
+		Id:        "k2",
+		CreatedAt: timestamppb.New(now.Add(-2 * time.Hour)),
+		RotatedAt: timestamppb.New(now.Add(-time.Minute)),
+	}
+	k3 := &crypt.SigningKey{
+		Id:        "k3",
+		CreatedAt: timestamppb.New(now.Add(-time.Minute)),
+	}
+	k4 := &crypt.SigningKey{
+		Id:          "k4",
+		CreatedAt:   timestamppb.New(now),
+		ActivatesAt: timestamppb.New(now.Add(time.Hour)),
+	}
+	keys := []*crypt.SigningKey{k1, k3, k4, k2}
+
+	assert.Equal(t, k3, Active(keys, now))
+	assert.Equal(t, k4, Active(keys, now.Add(time.Hour)), "should activate the pending key")
+	assert.Equal(t, k4, Active([]*crypt.SigningKey{k1, k2, k4}, now),
+		"should use the pending key when the key it replaces was rotated")
+	assert.Nil(t, Active([]*crypt.SigningKey{k1, k2}, now))
+	assert.Equal(t, []*crypt.SigningKey{k4, k3, k2}, Published(keys, now, time.Hour))
+}
+
+func TestRotator(t *testing.T) {
+	ctx := context.Background()
+
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	databroker.RegisterDataBrokerServiceServer(gs, internal_databroker.New())
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+
+	r := NewRotator(databroker.NewDataBrokerServiceClient(cc))
+	cfg := rotatorConfig{
+		sharedKey: cryptutil.NewKey(),
+		algorithm: jose.ES256,
+		interval:  24 * time.Hour,
+		overlap:   time.Hour,
+	}
+	now := time.Now()
+
+	var mu sync.Mutex
+	var kinds []events.SigningKeyEvent_EventKind
+	handle := events.Register(func(evt events.Event) {
+		if evt, ok := evt.(*events.SigningKeyEvent); ok {
+			mu.Lock()
+			kinds = append(kinds, evt.GetKind())
+			mu.Unlock()
+		}
+	})
+	t.Cleanup(func() { events.Unregister(handle) })
+
+	next, err := r.rotate(ctx, cfg, now)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.interval-cfg.overlap, next, "should wait until the next key is published")
+	keys, err := List(ctx, r.client)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	first := Active(keys, now)
+
+	next, err = r.rotate(ctx, cfg, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, cfg.interval-cfg.overlap-time.Hour, next, "should not publish the next key early")
+
+	next, err = r.rotate(ctx, cfg, now.Add(cfg.interval-cfg.overlap))
+	require.NoError(t, err)
+	assert.Equal(t, cfg.overlap, next, "should wait until the next key is activated")
+	keys, err = List(ctx, r.client)
+	require.NoError(t, err)
+	assert.Len(t, Published(keys, now, cfg.overlap), 2, "should publish the next key before it's activated")
+	assert.Equal(t, first.GetId(), Active(keys, now.Add(cfg.interval-cfg.overlap)).GetId())
+
+	now = now.Add(cfg.interval)
+	next, err = r.rotate(ctx, cfg, now)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.overlap, next, "should wait until the rotated key expires")
+	keys, err = List(ctx, r.client)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2, "should keep the rotated key during the overlap")
+	assert.NotEqual(t, first.GetId(), Active(keys, now).GetId())
+
+	_, err = r.rotate(ctx, cfg, now.Add(cfg.overlap))
+	require.NoError(t, err)
+	keys, err = List(ctx, r.client)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1, "should delete the rotated key after the overlap")
+
+	cfg.algorithm = jose.EdDSA
+	_, err = r.rotate(ctx, cfg, now.Add(cfg.overlap))
+	require.NoError(t, err)
+	keys, err = List(ctx, r.client)
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", Active(keys, now.Add(cfg.overlap)).GetAlgorithm(), "should rotate when the algorithm changes")
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return assert.ObjectsAreEqual([]events.SigningKeyEvent_EventKind{
+			events.SigningKeyEvent_EVENT_KIND_CREATED,
+			events.SigningKeyEvent_EVENT_KIND_CREATED,
+			events.SigningKeyEvent_EVENT_KIND_ROTATED,
+			events.SigningKeyEvent_EVENT_KIND_DELETED,
+			events.SigningKeyEvent_EVENT_KIND_ROTATED,
+			events.SigningKeyEvent_EVENT_KIND_CREATED,
+		}, kinds)
+	}, time.Second, 10*time.Millisecond, "should dispatch events for the changes")
+}
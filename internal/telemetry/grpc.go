@@ -2,6 +2,7 @@ package telemetry
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"go.opencensus.io/plugin/ocgrpc"
@@ -13,10 +14,13 @@ import (
 	grpcstats "google.golang.org/grpc/stats"
 
 	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	ptrace "github.com/pomerium/pomerium/internal/telemetry/trace"
 )
 
 const (
 	grpcTraceBinHeader = "grpc-trace-bin"
+	traceParentHeader  = "traceparent"
+	traceStateHeader   = "tracestate"
 	b3TraceIDHeader    = "x-b3-traceid"
 	b3SpanIDHeader     = "x-b3-spanid"
 )
@@ -34,7 +38,8 @@ type GRPCServerStatsHandler struct {
 
 // TagRPC implements grpc.stats.Handler and adds metrics and tracing metadata to the context of a given RPC
 func (h *GRPCServerStatsHandler) TagRPC(ctx context.Context, tagInfo *grpcstats.RPCTagInfo) context.Context {
-	// the opencensus trace handler only supports grpc-trace-bin, so we use that code and support b3 too
+	// the opencensus trace handler only supports grpc-trace-bin, so we use that code and support
+	// b3 and W3C trace context too
 
 	md, _ := metadata.FromIncomingContext(ctx)
 	name := strings.TrimPrefix(tagInfo.FullMethodName, "/")
@@ -59,6 +64,15 @@ func (h *GRPCServerStatsHandler) TagRPC(ctx context.Context, tagInfo *grpcstats.
 		}
 	}
 
+	if hdr := md[traceParentHeader]; len(hdr) > 0 {
+		if sc, ok := ptrace.SpanContextFromHeaders(http.Header{
+			"Traceparent": hdr,
+			"Tracestate":  md[traceStateHeader],
+		}); ok {
+			parent, hasParent = sc, true
+		}
+	}
+
 	if hasParent {
 		ctx, _ = trace.StartSpanWithRemoteParent(ctx, name, parent,
 			trace.WithSpanKind(trace.SpanKindServer))
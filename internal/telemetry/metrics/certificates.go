@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+
+	"github.com/pomerium/pomerium/pkg/metrics"
+)
+
+// A CertificateExpiry is the expiry of a configured certificate exported as a metric.
+type CertificateExpiry struct {
+	// Usage is what the certificate is used for, like downstream or databroker.
+	Usage string
+	// Name identifies the certificate within its usage, like the subject or the route.
+	Name string
+	// Serial is the hex-encoded serial number of the certificate.
+	Serial   string
+	NotAfter time.Time
+}
+
+var certificateExpiries struct {
+	sync.Mutex
+	expiries []CertificateExpiry
+}
+
+// RecordCertificateExpiries replaces the recorded certificate expiries. You must call
+// RegisterInfoMetrics to have them exported.
+func RecordCertificateExpiries(expiries []CertificateExpiry) {
+	certificateExpiries.Lock()
+	certificateExpiries.expiries = append([]CertificateExpiry(nil), expiries...)
+	certificateExpiries.Unlock()
+}
+
+// certificateProducer produces the certificate expiry metrics. The time series of certificates
+// which were removed from the configuration go away.
+type certificateProducer struct{}
+
+func (certificateProducer) Read() []*metricdata.Metric {
+	certificateExpiries.Lock()
+	expiries := certificateExpiries.expiries
+	certificateExpiries.Unlock()
+	if len(expiries) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	m := newInt64Gauge(metrics.CertificateExpirationTimestampSeconds,
+		"The unix timestamp each configured certificate expires at.",
+		metrics.UsageLabel, metrics.NameLabel, metrics.SerialLabel)
+	for _, e := range expiries {
+		addInt64GaugePoint(m, now, e.NotAfter.Unix(), e.Usage, e.Name, e.Serial)
+	}
+	return []*metricdata.Metric{m}
+}
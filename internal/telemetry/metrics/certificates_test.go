@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateProducer(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	RecordCertificateExpiries([]CertificateExpiry{
+		{Usage: "downstream", Name: "example.com", Serial: "1", NotAfter: notAfter},
+		{Usage: "databroker", Name: "redis", Serial: "2", NotAfter: notAfter.Add(time.Hour)},
+	})
+	defer RecordCertificateExpiries(nil)
+
+	ms := (certificateProducer{}).Read()
+	require.Len(t, ms, 1)
+	assert.Equal(t, "certificate_expiration_timestamp_seconds", ms[0].Descriptor.Name)
+	require.Len(t, ms[0].TimeSeries, 2)
+	assert.Equal(t, "databroker", ms[0].TimeSeries[1].LabelValues[0].Value)
+	assert.Equal(t, notAfter.Add(time.Hour).Unix(), ms[0].TimeSeries[1].Points[0].Value)
+
+	RecordCertificateExpiries(nil)
+	assert.Empty(t, (certificateProducer{}).Read(), "should remove the time series of removed certificates")
+}
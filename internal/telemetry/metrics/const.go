@@ -18,6 +18,18 @@ var (
 	TagKeyStorageOperation = tag.MustNewKey("operation")
 	TagKeyStorageResult    = tag.MustNewKey("result")
 	TagKeyStorageBackend   = tag.MustNewKey("backend")
+
+	TagKeyLease       = tag.MustNewKey("lease")
+	TagKeyLeaseChange = tag.MustNewKey("change")
+
+	TagKeyIdentityProviderURL = tag.MustNewKey("idp_url")
+	TagKeyEndpoint            = tag.MustNewKey("endpoint")
+
+	TagKeyRouteID           = tag.MustNewKey("route_id")
+	TagKeyRouteName         = tag.MustNewKey("route_name")
+	TagKeyResponseCodeClass = tag.MustNewKey("response_code_class")
+
+	TagKeyRateLimitResult = tag.MustNewKey("result")
 )
 
 // Default distributions used by views in this package.
@@ -43,6 +55,8 @@ var (
 		HTTPClientViews,
 		HTTPServerViews,
 		InfoViews,
+		RateLimitViews,
+		RouteViews,
 		StorageViews,
 	}
 )
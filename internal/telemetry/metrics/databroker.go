@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+
+	"github.com/pomerium/pomerium/pkg/metrics"
+)
+
+const dataBrokerStatsTimeout = 10 * time.Second
+
+// DataBrokerStats are the statistics of a databroker server exported as metrics.
+type DataBrokerStats struct {
+	// RecordCounts are the number of records by record type.
+	RecordCounts map[string]int64
+	// RecordVersion is the latest record version.
+	RecordVersion uint64
+	// SyncClients are the clients syncing changes.
+	SyncClients []DataBrokerSyncClient
+}
+
+// A DataBrokerSyncClient is a client syncing changes from the databroker.
+type DataBrokerSyncClient struct {
+	// Name identifies the client, like authorize@10.0.0.1.
+	Name string
+	// RecordVersion is the last record version sent to the client.
+	RecordVersion uint64
+}
+
+var dataBrokerStatsProvider atomic.Value
+
+// SetDataBrokerStatsProvider sets the function called to get the databroker statistics when
+// metrics are exported. You must call RegisterInfoMetrics to have them exported.
+func SetDataBrokerStatsProvider(f func(context.Context) *DataBrokerStats) {
+	dataBrokerStatsProvider.Store(f)
+}
+
+// dataBrokerProducer produces the databroker metrics. Unlike gauges in a registry, the time
+// series of clients which disconnected and record types which were removed go away.
+type dataBrokerProducer struct{}
+
+func (dataBrokerProducer) Read() []*metricdata.Metric {
+	f, ok := dataBrokerStatsProvider.Load().(func(context.Context) *DataBrokerStats)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dataBrokerStatsTimeout)
+	defer cancel()
+	stats := f(ctx)
+	if stats == nil {
+		return nil
+	}
+
+	now := time.Now()
+	records := newInt64Gauge(metrics.DataBrokerRecords,
+		"Number of records stored in the databroker by record type.", metrics.RecordTypeLabel)
+	recordTypes := make([]string, 0, len(stats.RecordCounts))
+	for recordType := range stats.RecordCounts {
+		recordTypes = append(recordTypes, recordType)
+	}
+	sort.Strings(recordTypes)
+	for _, recordType := range recordTypes {
+		addInt64GaugePoint(records, now, stats.RecordCounts[recordType], recordType)
+	}
+
+	recordVersion := newInt64Gauge(metrics.DataBrokerRecordVersion,
+		"Latest record version of the databroker change stream.")
+	addInt64GaugePoint(recordVersion, now, int64(stats.RecordVersion))
+
+	clientVersion := newInt64Gauge(metrics.DataBrokerSyncClientRecordVersion,
+		"Last record version sent to each client syncing changes from the databroker.", metrics.ClientLabel)
+	clientLag := newInt64Gauge(metrics.DataBrokerSyncClientLag,
+		"Number of record versions each client syncing changes from the databroker is behind.", metrics.ClientLabel)
+	for _, client := range stats.SyncClients {
+		var lag int64
+		if stats.RecordVersion > client.RecordVersion {
+			lag = int64(stats.RecordVersion - client.RecordVersion)
+		}
+		addInt64GaugePoint(clientVersion, now, int64(client.RecordVersion), client.Name)
+		addInt64GaugePoint(clientLag, now, lag, client.Name)
+	}
+
+	return []*metricdata.Metric{records, recordVersion, clientVersion, clientLag}
+}
+
+func newInt64Gauge(name, description string, labelKeys ...string) *metricdata.Metric {
+	m := &metricdata.Metric{
+		Descriptor: metricdata.Descriptor{
+			Name:        name,
+			Description: description,
+			Unit:        metricdata.UnitDimensionless,
+			Type:        metricdata.TypeGaugeInt64,
+		},
+	}
+	for _, key := range labelKeys {
+		m.Descriptor.LabelKeys = append(m.Descriptor.LabelKeys, metricdata.LabelKey{Key: key})
+	}
+	return m
+}
+
+func addInt64GaugePoint(m *metricdata.Metric, now time.Time, value int64, labelValues ...string) {
+	ts := &metricdata.TimeSeries{
+		Points: []metricdata.Point{metricdata.NewInt64Point(now, value)},
+	}
+	for _, v := range labelValues {
+		ts.LabelValues = append(ts.LabelValues, metricdata.NewLabelValue(v))
+	}
+	m.TimeSeries = append(m.TimeSeries, ts)
+}
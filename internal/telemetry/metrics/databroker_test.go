@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+)
+
+func TestDataBrokerProducer(t *testing.T) {
+	SetDataBrokerStatsProvider(func(ctx context.Context) *DataBrokerStats {
+		return &DataBrokerStats{
+			RecordCounts:  map[string]int64{"type.googleapis.com/user.User": 2, "type.googleapis.com/session.Session": 3},
+			RecordVersion: 10,
+			SyncClients: []DataBrokerSyncClient{
+				{Name: "authorize@10.0.0.1", RecordVersion: 7},
+				{Name: "authorize@10.0.0.2", RecordVersion: 10},
+			},
+		}
+	})
+	defer SetDataBrokerStatsProvider(func(ctx context.Context) *DataBrokerStats { return nil })
+
+	values := map[string][]int64{}
+	for _, m := range (dataBrokerProducer{}).Read() {
+		for _, ts := range m.TimeSeries {
+			require.Len(t, ts.Points, 1)
+			values[m.Descriptor.Name] = append(values[m.Descriptor.Name], ts.Points[0].Value.(int64))
+		}
+		assert.Equal(t, metricdata.TypeGaugeInt64, m.Descriptor.Type)
+	}
+	assert.Equal(t, map[string][]int64{
+		"databroker_records":                    {3, 2},
+		"databroker_record_version":             {10},
+		"databroker_sync_client_record_version": {7, 10},
+		"databroker_sync_client_lag":            {3, 0},
+	}, values)
+}
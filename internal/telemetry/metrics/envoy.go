@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/metric"
+
+	"github.com/pomerium/pomerium/pkg/metrics"
+)
+
+var (
+	envoyListenerReloadsTotal int64
+	// the unix time in nanoseconds until which replaced listeners are drained
+	envoyListenersDrainingUntil int64
+)
+
+func registerEnvoyMetrics(registry *metric.Registry) error {
+	draining, err := registry.AddInt64DerivedGauge(metrics.EnvoyListenersDraining,
+		metric.WithDescription("Returns 1 while envoy drains the connections of replaced listeners."))
+	if err != nil {
+		return err
+	}
+	err = draining.UpsertEntry(func() int64 {
+		if time.Now().UnixNano() < atomic.LoadInt64(&envoyListenersDrainingUntil) {
+			return 1
+		}
+		return 0
+	})
+	if err != nil {
+		return err
+	}
+
+	reloads, err := registry.AddInt64DerivedCumulative(metrics.EnvoyListenerReloadsTotal,
+		metric.WithDescription("Number of times envoy replaced its listeners."))
+	if err != nil {
+		return err
+	}
+	return reloads.UpsertEntry(func() int64 {
+		return atomic.LoadInt64(&envoyListenerReloadsTotal)
+	})
+}
+
+// RecordEnvoyListenerReload records that envoy replaced its listeners, whose connections are
+// drained until the given time.
+func RecordEnvoyListenerReload(drainingUntil time.Time) {
+	atomic.AddInt64(&envoyListenerReloadsTotal, 1)
+	atomic.StoreInt64(&envoyListenersDrainingUntil, drainingUntil.UnixNano())
+}
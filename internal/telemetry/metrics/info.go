@@ -39,6 +39,11 @@ var (
 		IdentityManagerLastSessionRefreshSuccessTimestampView,
 		IdentityManagerLastSessionRefreshSuccessView,
 
+		IdentityProviderHealthView,
+
+		SigningKeyLastRotationTimestampView,
+		SigningKeyPublishedKeysView,
+
 		ConfigDBVersionView,
 		ConfigDBErrorsView,
 	}
@@ -130,6 +135,23 @@ var (
 		stats.UnitDimensionless,
 	)
 
+	identityProviderHealth = stats.Int64(
+		metrics.IdentityProviderHealth,
+		"Returns 1 if the last identity provider endpoint health check succeeded",
+		stats.UnitDimensionless,
+	)
+
+	signingKeyLastRotationTimestamp = stats.Int64(
+		metrics.SigningKeyLastRotationTimestamp,
+		"Timestamp the active signing key was activated",
+		stats.UnitSeconds,
+	)
+	signingKeyPublishedKeys = stats.Int64(
+		metrics.SigningKeyPublishedKeys,
+		"Number of signing keys published in the JWKS",
+		stats.UnitDimensionless,
+	)
+
 	// ConfigDBVersionView contains last databroker config version that was processed
 	ConfigDBVersionView = &view.View{
 		Name:        configDBVersion.Name(),
@@ -263,6 +285,32 @@ var (
 		Measure:     identityManagerLastSessionRefreshErrorTimestamp,
 		Aggregation: view.LastValue(),
 	}
+
+	// IdentityProviderHealthView contains the result of the last health check of an identity
+	// provider endpoint, labeled by identity provider url and endpoint.
+	IdentityProviderHealthView = &view.View{
+		Name:        identityProviderHealth.Name(),
+		Description: identityProviderHealth.Description(),
+		Measure:     identityProviderHealth,
+		TagKeys:     []tag.Key{TagKeyIdentityProviderURL, TagKeyEndpoint},
+		Aggregation: view.LastValue(),
+	}
+
+	// SigningKeyLastRotationTimestampView contains the timestamp the active signing key was activated.
+	SigningKeyLastRotationTimestampView = &view.View{
+		Name:        signingKeyLastRotationTimestamp.Name(),
+		Description: signingKeyLastRotationTimestamp.Description(),
+		Measure:     signingKeyLastRotationTimestamp,
+		Aggregation: view.LastValue(),
+	}
+
+	// SigningKeyPublishedKeysView contains the number of signing keys published in the JWKS.
+	SigningKeyPublishedKeysView = &view.View{
+		Name:        signingKeyPublishedKeys.Name(),
+		Description: signingKeyPublishedKeys.Description(),
+		Measure:     signingKeyPublishedKeys,
+		Aggregation: view.LastValue(),
+	}
 )
 
 // RecordIdentityManagerLastRefresh records that the identity manager refreshed users and groups.
@@ -315,6 +363,33 @@ func RecordIdentityManagerSessionRefresh(ctx context.Context, err error) {
 	)
 }
 
+// RecordIdentityProviderHealth records the result of a health check of an identity provider endpoint.
+func RecordIdentityProviderHealth(ctx context.Context, providerURL, endpoint string, healthy bool) {
+	var value int64
+	if healthy {
+		value = 1
+	}
+	if err := stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{
+			tag.Insert(TagKeyIdentityProviderURL, providerURL),
+			tag.Insert(TagKeyEndpoint, endpoint),
+		},
+		identityProviderHealth.M(value),
+	); err != nil {
+		log.Error(ctx).Err(err).Msg("telemetry/metrics: failed to record identity provider health")
+	}
+}
+
+// RecordSigningKeyRotation records the activation time of the active signing key and the
+// number of published signing keys.
+func RecordSigningKeyRotation(ctx context.Context, activeActivatedAt time.Time, publishedKeys int) {
+	stats.Record(ctx,
+		signingKeyLastRotationTimestamp.M(activeActivatedAt.Unix()),
+		signingKeyPublishedKeys.M(int64(publishedKeys)),
+	)
+}
+
 func storeLastErrorEvent(id string, err error) {
 	events.Dispatch(&events.LastError{
 		Time:    timestamppb.Now(),
@@ -403,6 +478,8 @@ func SetBuildInfo(service, hostname, envoyVersion string) {
 // RegisterInfoMetrics registers non-view based metrics registry globally for export
 func RegisterInfoMetrics() {
 	metricproducer.GlobalManager().AddProducer(registry.registry)
+	metricproducer.GlobalManager().AddProducer(dataBrokerProducer{})
+	metricproducer.GlobalManager().AddProducer(certificateProducer{})
 }
 
 // AddPolicyCountCallback sets the function to call when exporting the
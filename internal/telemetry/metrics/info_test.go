@@ -97,12 +97,14 @@ func Test_AddPolicyCountCallback(t *testing.T) {
 
 func Test_RegisterInfoMetrics(t *testing.T) {
 	metricproducer.GlobalManager().DeleteProducer(registry.registry)
+	metricproducer.GlobalManager().DeleteProducer(dataBrokerProducer{})
+	metricproducer.GlobalManager().DeleteProducer(certificateProducer{})
 	RegisterInfoMetrics()
 	// Make sure registration de-dupes on multiple calls
 	RegisterInfoMetrics()
 
 	r := metricproducer.GlobalManager().GetAll()
-	if len(r) != 2 {
+	if len(r) != 4 {
 		t.Error("Did not find enough registries")
 	}
 }
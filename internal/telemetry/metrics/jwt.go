@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"sync/atomic"
+
+	"go.opencensus.io/metric"
+
+	"github.com/pomerium/pomerium/pkg/metrics"
+)
+
+var (
+	jwtVerificationCacheHitsTotal   int64
+	jwtVerificationCacheMissesTotal int64
+)
+
+func registerJWTVerificationCacheMetrics(registry *metric.Registry) error {
+	cumulativeMetrics := []struct {
+		name string
+		desc string
+		ptr  *int64
+	}{
+		{metrics.JWTVerificationCacheHitsTotal, "Number of JWT verifications answered by the cache.", &jwtVerificationCacheHitsTotal},
+		{metrics.JWTVerificationCacheMissesTotal, "Number of JWT verifications not answered by the cache.", &jwtVerificationCacheMissesTotal},
+	}
+	for _, cm := range cumulativeMetrics {
+		m, err := registry.AddInt64DerivedCumulative(cm.name, metric.WithDescription(cm.desc))
+		if err != nil {
+			return err
+		}
+		ptr := cm.ptr
+		err = m.UpsertEntry(func() int64 {
+			return atomic.LoadInt64(ptr)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordJWTVerificationCacheLookup records whether a JWT verification was answered by the cache.
+func RecordJWTVerificationCacheLookup(hit bool) {
+	if hit {
+		atomic.AddInt64(&jwtVerificationCacheHitsTotal, 1)
+	} else {
+		atomic.AddInt64(&jwtVerificationCacheMissesTotal, 1)
+	}
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+
+	"github.com/pomerium/pomerium/internal/telemetry/otlpexport"
+	"github.com/pomerium/pomerium/internal/version"
+	"github.com/pomerium/pomerium/pkg/grpc/otlp"
+)
+
+const otlpExportTimeout = 10 * time.Second
+
+// An otlpExporter exports metrics to an OpenTelemetry collector. Cumulative metrics are
+// exported with cumulative temporality, the OpenCensus start time of a time series is the
+// start time of the data points.
+type otlpExporter struct {
+	client   *otlpexport.Client
+	resource *otlp.Resource
+}
+
+func newOTLPExporter(opts *PushOptions, attributes map[string]string) (*otlpExporter, error) {
+	client, err := otlpexport.NewClient(opts.OTLPEndpoint, opts.OTLPProtocol, opts.OTLPHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry/metrics: %w", err)
+	}
+	resource := new(otlp.Resource)
+	for _, k := range sortedKeys(attributes) {
+		resource.Attributes = append(resource.Attributes, otlpexport.NewKeyValue(k, attributes[k]))
+	}
+	return &otlpExporter{client: client, resource: resource}, nil
+}
+
+func (exporter *otlpExporter) Close() error {
+	return exporter.client.Close()
+}
+
+func (exporter *otlpExporter) ExportMetrics(ctx context.Context, data []*metricdata.Metric) error {
+	var metrics []*otlp.Metric
+	for _, m := range data {
+		if metric := newOTLPMetric(m); metric != nil {
+			metrics = append(metrics, metric)
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, otlpExportTimeout)
+	defer cancel()
+	err := exporter.client.ExportMetrics(ctx, &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlp.ResourceMetrics{{
+			Resource: exporter.resource,
+			ScopeMetrics: []*otlp.ScopeMetrics{{
+				Scope:   &otlp.InstrumentationScope{Name: "github.com/pomerium/pomerium", Version: version.FullVersion()},
+				Metrics: metrics,
+			}},
+		}},
+	})
+	logPushError(ctx, "otlp", err)
+	return err
+}
+
+// newOTLPMetric converts the metric. Summaries aren't supported and return nil.
+func newOTLPMetric(m *metricdata.Metric) *otlp.Metric {
+	metric := &otlp.Metric{
+		Name:        "pomerium_" + getMetricName(m),
+		Description: m.Descriptor.Description,
+		Unit:        string(m.Descriptor.Unit),
+	}
+
+	switch m.Descriptor.Type {
+	case metricdata.TypeGaugeInt64, metricdata.TypeGaugeFloat64:
+		gauge := new(otlp.Gauge)
+		for _, ts := range m.TimeSeries {
+			gauge.DataPoints = append(gauge.DataPoints, newOTLPNumberDataPoints(m, ts)...)
+		}
+		metric.Data = &otlp.Metric_Gauge{Gauge: gauge}
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		sum := &otlp.Sum{
+			AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}
+		for _, ts := range m.TimeSeries {
+			sum.DataPoints = append(sum.DataPoints, newOTLPNumberDataPoints(m, ts)...)
+		}
+		metric.Data = &otlp.Metric_Sum{Sum: sum}
+	case metricdata.TypeCumulativeDistribution, metricdata.TypeGaugeDistribution:
+		histogram := &otlp.Histogram{
+			AggregationTemporality: otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}
+		for _, ts := range m.TimeSeries {
+			histogram.DataPoints = append(histogram.DataPoints, newOTLPHistogramDataPoints(m, ts)...)
+		}
+		metric.Data = &otlp.Metric_Histogram{Histogram: histogram}
+	default:
+		return nil
+	}
+	return metric
+}
+
+func newOTLPAttributes(m *metricdata.Metric, ts *metricdata.TimeSeries) []*otlp.KeyValue {
+	labels := getLabels(m, ts)
+	var attributes []*otlp.KeyValue
+	for _, k := range sortedKeys(labels) {
+		attributes = append(attributes, otlpexport.NewKeyValue(k, labels[k]))
+	}
+	return attributes
+}
+
+func newOTLPNumberDataPoints(m *metricdata.Metric, ts *metricdata.TimeSeries) []*otlp.NumberDataPoint {
+	attributes := newOTLPAttributes(m, ts)
+	var points []*otlp.NumberDataPoint
+	for _, p := range ts.Points {
+		point := &otlp.NumberDataPoint{
+			Attributes:   attributes,
+			TimeUnixNano: uint64(p.Time.UnixNano()),
+		}
+		if !ts.StartTime.IsZero() {
+			point.StartTimeUnixNano = uint64(ts.StartTime.UnixNano())
+		}
+		switch v := p.Value.(type) {
+		case int64:
+			point.Value = &otlp.NumberDataPoint_AsInt{AsInt: v}
+		case float64:
+			point.Value = &otlp.NumberDataPoint_AsDouble{AsDouble: v}
+		default:
+			continue
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+func newOTLPHistogramDataPoints(m *metricdata.Metric, ts *metricdata.TimeSeries) []*otlp.HistogramDataPoint {
+	attributes := newOTLPAttributes(m, ts)
+	var points []*otlp.HistogramDataPoint
+	for _, p := range ts.Points {
+		d, ok := p.Value.(*metricdata.Distribution)
+		if !ok {
+			continue
+		}
+		sum := d.Sum
+		point := &otlp.HistogramDataPoint{
+			Attributes:   attributes,
+			TimeUnixNano: uint64(p.Time.UnixNano()),
+			Count:        uint64(d.Count),
+			Sum:          &sum,
+		}
+		if !ts.StartTime.IsZero() {
+			point.StartTimeUnixNano = uint64(ts.StartTime.UnixNano())
+		}
+		if d.BucketOptions != nil && len(d.Buckets) == len(d.BucketOptions.Bounds)+1 {
+			point.ExplicitBounds = d.BucketOptions.Bounds
+			for _, b := range d.Buckets {
+				point.BucketCounts = append(point.BucketCounts, uint64(b.Count))
+			}
+		}
+		points = append(points, point)
+	}
+	return points
+}
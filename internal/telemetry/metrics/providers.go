@@ -48,9 +48,8 @@ var (
 
 func getGlobalExporter() (*ocprom.Exporter, error) {
 	globalExporterOnce.Do(func() {
-		globalExporterErr = registerDefaultViews()
+		globalExporterErr = registerDefaultViewsOnce()
 		if globalExporterErr != nil {
-			globalExporterErr = fmt.Errorf("telemetry/metrics: failed registering views: %w", globalExporterErr)
 			return
 		}
 
@@ -70,7 +69,26 @@ func getGlobalExporter() (*ocprom.Exporter, error) {
 	return globalExporter, globalExporterErr
 }
 
+var (
+	defaultViewsErr  error
+	defaultViewsOnce sync.Once
+)
+
+// registerDefaultViewsOnce registers the default views, which are exported to Prometheus and
+// pushed.
+func registerDefaultViewsOnce() error {
+	defaultViewsOnce.Do(func() {
+		if err := registerDefaultViews(); err != nil {
+			defaultViewsErr = fmt.Errorf("telemetry/metrics: failed registering views: %w", err)
+		}
+	})
+	return defaultViewsErr
+}
+
 func registerDefaultViews() error {
+	routeLatencyMu.Lock()
+	defer routeLatencyMu.Unlock()
+
 	var views []*view.View
 	for _, v := range DefaultViews {
 		views = append(views, v...)
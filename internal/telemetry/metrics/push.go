@@ -0,0 +1,180 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricexport"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// DefaultPushInterval is the default interval metrics are pushed at.
+const DefaultPushInterval = 10 * time.Second
+
+// Statsd flavors.
+const (
+	StatsdFlavorStatsd    = "statsd"
+	StatsdFlavorDogStatsd = "dogstatsd"
+)
+
+// PushOptions are the options for pushing metrics to an OpenTelemetry collector or a statsd
+// server, for environments where the metrics endpoint can't be scraped.
+type PushOptions struct {
+	Service  string
+	Interval time.Duration
+
+	// OTLPEndpoint is the url of the OpenTelemetry collector.
+	OTLPEndpoint *url.URL
+	// OTLPProtocol is the protocol used to export metrics, grpc or http/protobuf.
+	OTLPProtocol string
+	// OTLPHeaders are sent with each export.
+	OTLPHeaders map[string]string
+
+	// StatsdAddress is the host:port of the statsd server, metrics are sent over UDP.
+	StatsdAddress string
+	// StatsdFlavor is statsd or dogstatsd. Labels are only sent as dogstatsd tags.
+	StatsdFlavor string
+	// StatsdPrefix is prepended to the names of the metrics.
+	StatsdPrefix string
+
+	// ResourceAttributes are added to the resource of the OTLP metrics, and as tags of the
+	// dogstatsd metrics.
+	ResourceAttributes map[string]string
+}
+
+// Enabled returns true if metrics are pushed anywhere.
+func (opts *PushOptions) Enabled() bool {
+	return opts.OTLPEndpoint != nil || opts.StatsdAddress != ""
+}
+
+// A Pusher pushes metrics periodically.
+type Pusher struct {
+	readers []*metricexport.IntervalReader
+	closers []func() error
+}
+
+type closingExporter interface {
+	metricexport.Exporter
+	Close() error
+}
+
+// NewPusher creates a new Pusher and starts pushing metrics.
+func NewPusher(opts *PushOptions) (*Pusher, error) {
+	if err := registerDefaultViewsOnce(); err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "__none__"
+	}
+	attributes := map[string]string{
+		"service.name": opts.Service,
+		"host.name":    hostname,
+	}
+	for k, v := range opts.ResourceAttributes {
+		attributes[k] = v
+	}
+
+	var exporters []closingExporter
+	if opts.OTLPEndpoint != nil {
+		exporter, err := newOTLPExporter(opts, attributes)
+		if err != nil {
+			return nil, err
+		}
+		exporters = append(exporters, exporter)
+	}
+	if opts.StatsdAddress != "" {
+		exporter, err := newStatsdExporter(opts, attributes)
+		if err != nil {
+			for _, e := range exporters {
+				_ = e.Close()
+			}
+			return nil, err
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultPushInterval
+	}
+
+	p := new(Pusher)
+	for _, exporter := range exporters {
+		ir, err := metricexport.NewIntervalReader(metricexport.NewReader(), exporter)
+		if err == nil {
+			ir.ReportingInterval = interval
+			err = ir.Start()
+		}
+		if err != nil {
+			_ = p.Close()
+			_ = exporter.Close()
+			return nil, fmt.Errorf("telemetry/metrics: error starting metrics push: %w", err)
+		}
+		p.readers = append(p.readers, ir)
+		p.closers = append(p.closers, exporter.Close)
+	}
+	return p, nil
+}
+
+// Close pushes the current metrics one last time and stops pushing metrics.
+func (p *Pusher) Close() error {
+	var err error
+	for i, ir := range p.readers {
+		ir.Stop()
+		ir.Flush()
+		if cerr := p.closers[i](); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	p.readers, p.closers = nil, nil
+	return err
+}
+
+// getMetricName returns the name of the metric, like it's exported to Prometheus.
+func getMetricName(m *metricdata.Metric) string {
+	return sanitizeMetricName(m.Descriptor.Name)
+}
+
+func sanitizeMetricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// getLabels returns the labels of the time series which are present.
+func getLabels(m *metricdata.Metric, ts *metricdata.TimeSeries) map[string]string {
+	labels := make(map[string]string, len(ts.LabelValues))
+	for i, v := range ts.LabelValues {
+		if v.Present && i < len(m.Descriptor.LabelKeys) {
+			labels[m.Descriptor.LabelKeys[i].Key] = v.Value
+		}
+	}
+	return labels
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func logPushError(ctx context.Context, exporter string, err error) {
+	if err != nil {
+		log.Warn(ctx).Err(err).Str("exporter", exporter).Msg("telemetry/metrics: error pushing metrics")
+	}
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/grpc/otlp"
+)
+
+func testPushMetrics(requests int64) []*metricdata.Metric {
+	now := time.Now()
+	return []*metricdata.Metric{{
+		Descriptor: metricdata.Descriptor{
+			Name:      "http/server/requests_total",
+			Type:      metricdata.TypeCumulativeInt64,
+			LabelKeys: []metricdata.LabelKey{{Key: "service"}, {Key: "host"}},
+		},
+		TimeSeries: []*metricdata.TimeSeries{{
+			LabelValues: []metricdata.LabelValue{metricdata.NewLabelValue("proxy"), {}},
+			Points:      []metricdata.Point{metricdata.NewInt64Point(now, requests)},
+			StartTime:   now.Add(-time.Minute),
+		}},
+	}, {
+		Descriptor: metricdata.Descriptor{
+			Name: "config_last_reload_success",
+			Type: metricdata.TypeGaugeInt64,
+		},
+		TimeSeries: []*metricdata.TimeSeries{{
+			Points: []metricdata.Point{metricdata.NewInt64Point(now, 1)},
+		}},
+	}}
+}
+
+func TestStatsdExporter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	read := func() string {
+		buf := make([]byte, statsdMaxPacketSize)
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	t.Run("statsd", func(t *testing.T) {
+		exporter, err := newStatsdExporter(&PushOptions{
+			StatsdAddress: conn.LocalAddr().String(),
+			StatsdPrefix:  "pomerium",
+		}, map[string]string{"env": "test"})
+		require.NoError(t, err)
+		defer exporter.Close()
+
+		assert.NoError(t, exporter.ExportMetrics(context.Background(), testPushMetrics(3)))
+		assert.Equal(t, "pomerium.http_server_requests_total:3|c\npomerium.config_last_reload_success:1|g", read())
+
+		assert.NoError(t, exporter.ExportMetrics(context.Background(), testPushMetrics(5)))
+		assert.Equal(t, "pomerium.http_server_requests_total:2|c\npomerium.config_last_reload_success:1|g", read(),
+			"counters should be the change since the last push")
+	})
+	t.Run("dogstatsd", func(t *testing.T) {
+		exporter, err := newStatsdExporter(&PushOptions{
+			StatsdAddress: conn.LocalAddr().String(),
+			StatsdFlavor:  StatsdFlavorDogStatsd,
+		}, map[string]string{"env": "test"})
+		require.NoError(t, err)
+		defer exporter.Close()
+
+		assert.NoError(t, exporter.ExportMetrics(context.Background(), testPushMetrics(3)))
+		assert.Equal(t, "http_server_requests_total:3|c|#env:test,service:proxy\nconfig_last_reload_success:1|g|#env:test", read())
+	})
+	t.Run("invalid flavor", func(t *testing.T) {
+		_, err := newStatsdExporter(&PushOptions{StatsdAddress: conn.LocalAddr().String(), StatsdFlavor: "graphite"}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan *otlp.ExportMetricsServiceRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		var req otlp.ExportMetricsServiceRequest
+		assert.NoError(t, proto.Unmarshal(body, &req))
+		requests <- &req
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	exporter, err := newOTLPExporter(&PushOptions{OTLPEndpoint: u, OTLPProtocol: "http/protobuf"},
+		map[string]string{"service.name": "pomerium"})
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	require.NoError(t, exporter.ExportMetrics(context.Background(), testPushMetrics(3)))
+	req := <-requests
+	require.Len(t, req.GetResourceMetrics(), 1)
+	assert.Equal(t, "service.name", req.GetResourceMetrics()[0].GetResource().GetAttributes()[0].GetKey())
+
+	metrics := req.GetResourceMetrics()[0].GetScopeMetrics()[0].GetMetrics()
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "pomerium_http_server_requests_total", metrics[0].GetName())
+	sum := metrics[0].GetSum()
+	require.NotNil(t, sum)
+	assert.True(t, sum.GetIsMonotonic())
+	assert.Equal(t, otlp.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.GetAggregationTemporality())
+	require.Len(t, sum.GetDataPoints(), 1)
+	assert.Equal(t, int64(3), sum.GetDataPoints()[0].GetAsInt())
+	require.Len(t, sum.GetDataPoints()[0].GetAttributes(), 1, "missing labels should be skipped")
+	assert.Equal(t, "service", sum.GetDataPoints()[0].GetAttributes()[0].GetKey())
+	assert.NotNil(t, metrics[1].GetGauge())
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// The results of rate limit checks.
+const (
+	RateLimitAllowed = "allowed"
+	RateLimitLimited = "limited"
+	RateLimitError   = "error"
+)
+
+var (
+	// RateLimitViews contains opencensus views for the rate limits shared across instances
+	RateLimitViews = []*view.View{RateLimitRequestCountView, RateLimitCheckDurationView}
+
+	rateLimitCheckDuration = stats.Int64(
+		"rate_limit_check_duration_ms",
+		"Rate limit check duration in ms",
+		"ms")
+
+	// RateLimitRequestCountView is an OpenCensus view that counts the requests checked against
+	// the rate limits by route and result
+	RateLimitRequestCountView = &view.View{
+		Name:        "rate_limit/requests_total",
+		Measure:     rateLimitCheckDuration,
+		Description: "Total requests checked against rate limits by route and result",
+		TagKeys:     []tag.Key{TagKeyRouteID, TagKeyRouteName, TagKeyRateLimitResult},
+		Aggregation: view.Count(),
+	}
+
+	// RateLimitCheckDurationView is an OpenCensus view that tracks the latency of the rate limit
+	// checks made to the databroker
+	RateLimitCheckDurationView = &view.View{
+		Name:        rateLimitCheckDuration.Name(),
+		Measure:     rateLimitCheckDuration,
+		Description: rateLimitCheckDuration.Description(),
+		TagKeys:     []tag.Key{TagKeyRateLimitResult},
+		Aggregation: DefaultMillisecondsDistribution,
+	}
+)
+
+// RecordRateLimitCheck records the result of checking a request against the rate limit of its
+// route.
+func RecordRateLimitCheck(ctx context.Context, route RouteTags, result string, duration time.Duration) {
+	err := stats.RecordWithTags(ctx,
+		[]tag.Mutator{
+			tag.Upsert(TagKeyRouteID, route.ID),
+			tag.Upsert(TagKeyRouteName, route.Name),
+			tag.Upsert(TagKeyRateLimitResult, result),
+		},
+		rateLimitCheckDuration.M(duration.Milliseconds()),
+	)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("internal/telemetry/metrics: failed to record")
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+)
+
+func Test_RecordRateLimitCheck(t *testing.T) {
+	view.Unregister(RateLimitViews...)
+	view.Register(RateLimitViews...)
+	defer view.Unregister(RateLimitViews...)
+
+	route := RouteTags{ID: "app", Name: "App"}
+	RecordRateLimitCheck(context.Background(), route, RateLimitAllowed, 2*time.Millisecond)
+	RecordRateLimitCheck(context.Background(), route, RateLimitLimited, 4*time.Millisecond)
+
+	data, err := view.RetrieveData(RateLimitRequestCountView.Name)
+	assert.NoError(t, err)
+	assert.Len(t, data, 2, "should have a row per result")
+
+	data, err = view.RetrieveData(RateLimitCheckDurationView.Name)
+	assert.NoError(t, err)
+	if assert.Len(t, data, 2) {
+		assert.Equal(t, int64(1), data[0].Data.(*view.DistributionData).Count)
+	}
+}
@@ -75,6 +75,16 @@ func (r *metricRegistry) init() {
 			if err != nil {
 				log.Error(ctx).Err(err).Msg("telemetry/metrics: failed to register autocert metrics")
 			}
+
+			err = registerEnvoyMetrics(r.registry)
+			if err != nil {
+				log.Error(ctx).Err(err).Msg("telemetry/metrics: failed to register envoy metrics")
+			}
+
+			err = registerJWTVerificationCacheMetrics(r.registry)
+			if err != nil {
+				log.Error(ctx).Err(err).Msg("telemetry/metrics: failed to register jwt verification cache metrics")
+			}
 		})
 }
 
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// MaxRouteLatencyBuckets is the max number of buckets of the route request duration histogram.
+const MaxRouteLatencyBuckets = 30
+
+// DefaultRouteLatencyBuckets are the buckets, in ms, of the route request duration histogram.
+var DefaultRouteLatencyBuckets = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+var (
+	// RouteViews contains opencensus views for per-route metrics
+	RouteViews = []*view.View{
+		RouteRequestCountView,
+		newRouteRequestDurationView(DefaultRouteLatencyBuckets),
+		RouteErrorCountView,
+	}
+
+	routeRequestDuration = stats.Int64(
+		"route_request_duration_ms",
+		"Route request duration in ms",
+		"ms")
+	routeErrors = stats.Int64(
+		"route_errors",
+		"Route requests which failed with a 5xx status code",
+		stats.UnitDimensionless)
+
+	// RouteRequestCountView is an OpenCensus view that tracks proxied requests by route and
+	// status code class
+	RouteRequestCountView = &view.View{
+		Name:        "route/requests_total",
+		Measure:     routeRequestDuration,
+		Description: "Total requests by route",
+		TagKeys:     []tag.Key{TagKeyRouteID, TagKeyRouteName, TagKeyResponseCodeClass},
+		Aggregation: view.Count(),
+	}
+
+	// RouteErrorCountView is an OpenCensus view that tracks proxied requests which failed with a
+	// 5xx status code by route
+	RouteErrorCountView = &view.View{
+		Name:        "route/errors_total",
+		Measure:     routeErrors,
+		Description: "Total requests by route which failed with a 5xx status code",
+		TagKeys:     []tag.Key{TagKeyRouteID, TagKeyRouteName},
+		Aggregation: view.Count(),
+	}
+
+	// routeLatencyMu guards the route request duration view, which is replaced when the buckets
+	// change
+	routeLatencyMu      sync.Mutex
+	routeLatencyBuckets = DefaultRouteLatencyBuckets
+)
+
+// newRouteRequestDurationView creates an OpenCensus view that tracks the duration of proxied
+// requests by route.
+func newRouteRequestDurationView(buckets []float64) *view.View {
+	return &view.View{
+		Name:        "route/request_duration_ms",
+		Measure:     routeRequestDuration,
+		Description: "Request duration by route in ms",
+		TagKeys:     []tag.Key{TagKeyRouteID, TagKeyRouteName},
+		Aggregation: view.Distribution(buckets...),
+	}
+}
+
+// SetRouteLatencyBuckets sets the buckets, in ms, of the route request duration histogram. When
+// empty the default buckets are used. Changing the buckets resets the histogram.
+func SetRouteLatencyBuckets(buckets []float64) error {
+	if len(buckets) == 0 {
+		buckets = DefaultRouteLatencyBuckets
+	}
+	if err := ValidateRouteLatencyBuckets(buckets); err != nil {
+		return err
+	}
+
+	routeLatencyMu.Lock()
+	defer routeLatencyMu.Unlock()
+
+	if reflect.DeepEqual(buckets, routeLatencyBuckets) {
+		return nil
+	}
+
+	prev, next := RouteViews[1], newRouteRequestDurationView(buckets)
+	if view.Find(prev.Name) != nil {
+		view.Unregister(prev)
+		if err := view.Register(next); err != nil {
+			return fmt.Errorf("telemetry/metrics: failed registering view: %w", err)
+		}
+	}
+	RouteViews[1], routeLatencyBuckets = next, buckets
+	return nil
+}
+
+// ValidateRouteLatencyBuckets validates the buckets of the route request duration histogram.
+func ValidateRouteLatencyBuckets(buckets []float64) error {
+	if len(buckets) > MaxRouteLatencyBuckets {
+		return fmt.Errorf("at most %d buckets are allowed", MaxRouteLatencyBuckets)
+	}
+	for i, b := range buckets {
+		if b <= 0 {
+			return fmt.Errorf("buckets must be positive")
+		}
+		if i > 0 && b <= buckets[i-1] {
+			return fmt.Errorf("buckets must be in increasing order")
+		}
+	}
+	return nil
+}
+
+// RouteTags contains the tags of a route
+type RouteTags struct {
+	ID   string
+	Name string
+}
+
+// RecordRouteRequest records a proxied request with the tags of its route
+func RecordRouteRequest(ctx context.Context, route RouteTags, responseCode uint32, duration time.Duration) {
+	mutators := []tag.Mutator{
+		tag.Upsert(TagKeyRouteID, route.ID),
+		tag.Upsert(TagKeyRouteName, route.Name),
+	}
+	err := stats.RecordWithTags(ctx,
+		append(mutators, tag.Upsert(TagKeyResponseCodeClass, fmt.Sprintf("%dxx", responseCode/100))),
+		routeRequestDuration.M(duration.Milliseconds()))
+	if err == nil && responseCode >= 500 {
+		err = stats.RecordWithTags(ctx, mutators, routeErrors.M(1))
+	}
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("internal/telemetry/metrics: failed to record")
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+)
+
+func Test_RecordRouteRequest(t *testing.T) {
+	view.Unregister(RouteViews...)
+	view.Register(RouteViews...)
+	defer view.Unregister(RouteViews...)
+
+	route := RouteTags{ID: "app", Name: "App"}
+	RecordRouteRequest(context.Background(), route, 200, 20*time.Millisecond)
+	RecordRouteRequest(context.Background(), route, 503, 40*time.Millisecond)
+
+	data, err := view.RetrieveData(RouteRequestCountView.Name)
+	assert.NoError(t, err)
+	assert.Len(t, data, 2, "should have a row per status code class")
+
+	data, err = view.RetrieveData(RouteErrorCountView.Name)
+	assert.NoError(t, err)
+	if assert.Len(t, data, 1) {
+		assert.Equal(t, int64(1), data[0].Data.(*view.CountData).Value)
+	}
+
+	testDataRetrieval(RouteViews[1], t, "{ { {route_id app}{route_name App} }&{2 20 40 30")
+}
+
+func TestSetRouteLatencyBuckets(t *testing.T) {
+	defer func() { assert.NoError(t, SetRouteLatencyBuckets(nil)) }()
+
+	view.Register(RouteViews...)
+	defer view.Unregister(RouteViews...)
+
+	assert.NoError(t, SetRouteLatencyBuckets([]float64{100, 1000}))
+	assert.Equal(t, []float64{100, 1000}, view.Find(RouteViews[1].Name).Aggregation.Buckets)
+
+	assert.Error(t, SetRouteLatencyBuckets([]float64{1000, 100}))
+	assert.Error(t, SetRouteLatencyBuckets([]float64{0, 100}))
+	assert.Error(t, SetRouteLatencyBuckets(make([]float64, MaxRouteLatencyBuckets+1)))
+}
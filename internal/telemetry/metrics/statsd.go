@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opencensus.io/metric/metricdata"
+)
+
+// statsdMaxPacketSize keeps packets below the usual MTU, so they aren't fragmented.
+const statsdMaxPacketSize = 1432
+
+// A statsdExporter sends metrics to a statsd server over UDP. Cumulative metrics are sent as
+// counters of the change since the last push, other metrics as gauges. Distributions are sent
+// as a count and a sum.
+type statsdExporter struct {
+	conn      net.Conn
+	prefix    string
+	dogstatsd bool
+	tags      []string
+
+	mu   sync.Mutex
+	last map[string]float64
+}
+
+func newStatsdExporter(opts *PushOptions, attributes map[string]string) (*statsdExporter, error) {
+	exporter := &statsdExporter{
+		prefix: opts.StatsdPrefix,
+		last:   map[string]float64{},
+	}
+	switch opts.StatsdFlavor {
+	case StatsdFlavorStatsd, "":
+	case StatsdFlavorDogStatsd:
+		exporter.dogstatsd = true
+		for _, k := range sortedKeys(attributes) {
+			exporter.tags = append(exporter.tags, formatStatsdTag(k, attributes[k]))
+		}
+	default:
+		return nil, fmt.Errorf("telemetry/metrics: unknown statsd flavor: %s", opts.StatsdFlavor)
+	}
+
+	conn, err := net.Dial("udp", opts.StatsdAddress)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry/metrics: invalid statsd address: %w", err)
+	}
+	exporter.conn = conn
+	return exporter, nil
+}
+
+func (exporter *statsdExporter) Close() error {
+	return exporter.conn.Close()
+}
+
+func (exporter *statsdExporter) ExportMetrics(ctx context.Context, data []*metricdata.Metric) error {
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+
+	var lines []string
+	for _, m := range data {
+		name := getMetricName(m)
+		if exporter.prefix != "" {
+			name = exporter.prefix + "." + name
+		}
+		cumulative := m.Descriptor.Type == metricdata.TypeCumulativeInt64 ||
+			m.Descriptor.Type == metricdata.TypeCumulativeFloat64 ||
+			m.Descriptor.Type == metricdata.TypeCumulativeDistribution
+
+		for _, ts := range m.TimeSeries {
+			if len(ts.Points) == 0 {
+				continue
+			}
+			tags := exporter.getTags(m, ts)
+			switch v := ts.Points[len(ts.Points)-1].Value.(type) {
+			case int64:
+				lines = exporter.appendValue(lines, name, tags, float64(v), cumulative)
+			case float64:
+				lines = exporter.appendValue(lines, name, tags, v, cumulative)
+			case *metricdata.Distribution:
+				lines = exporter.appendValue(lines, name+".count", tags, float64(v.Count), cumulative)
+				lines = exporter.appendValue(lines, name+".sum", tags, v.Sum, cumulative)
+			}
+		}
+	}
+
+	err := exporter.send(lines)
+	logPushError(ctx, "statsd", err)
+	return err
+}
+
+// appendValue appends the line of the value. For cumulative values it's a counter of the
+// change since the last push, or the value if it was reset.
+func (exporter *statsdExporter) appendValue(lines []string, name, tags string, value float64, cumulative bool) []string {
+	if !cumulative {
+		return append(lines, name+":"+formatStatsdValue(value)+"|g"+tags)
+	}
+
+	key := name + tags
+	delta := value
+	if last, ok := exporter.last[key]; ok && value >= last {
+		delta = value - last
+	}
+	exporter.last[key] = value
+	if delta == 0 {
+		return lines
+	}
+	return append(lines, name+":"+formatStatsdValue(delta)+"|c"+tags)
+}
+
+// getTags returns the dogstatsd tags of the time series. Plain statsd doesn't have tags.
+func (exporter *statsdExporter) getTags(m *metricdata.Metric, ts *metricdata.TimeSeries) string {
+	if !exporter.dogstatsd {
+		return ""
+	}
+	tags := append([]string(nil), exporter.tags...)
+	labels := getLabels(m, ts)
+	for _, k := range sortedKeys(labels) {
+		tags = append(tags, formatStatsdTag(k, labels[k]))
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return "|#" + strings.Join(tags, ",")
+}
+
+// send sends the lines in as few packets as possible.
+func (exporter *statsdExporter) send(lines []string) error {
+	var buf bytes.Buffer
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		_, err := exporter.conn.Write(buf.Bytes())
+		buf.Reset()
+		return err
+	}
+	for _, line := range lines {
+		if buf.Len() > 0 && buf.Len()+1+len(line) > statsdMaxPacketSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(line)
+	}
+	return flush()
+}
+
+func formatStatsdValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatStatsdTag(key, value string) string {
+	r := strings.NewReplacer(",", "_", "|", "_", ":", "_", "\n", "_")
+	return r.Replace(key) + ":" + r.Replace(value)
+}
@@ -13,7 +13,7 @@ import (
 
 var (
 	// StorageViews contains opencensus views for storage system metrics
-	StorageViews = []*view.View{StorageOperationDurationView}
+	StorageViews = []*view.View{StorageOperationDurationView, StorageLeaseChangesView}
 
 	storageOperationDuration = stats.Int64(
 		"storage_operation_duration_ms",
@@ -29,8 +29,43 @@ var (
 		TagKeys:     []tag.Key{TagKeyStorageOperation, TagKeyStorageResult, TagKeyStorageBackend, TagKeyService},
 		Aggregation: DefaultMillisecondsDistribution,
 	}
+
+	storageLeaseChanges = stats.Int64(
+		"storage_lease_changes_total",
+		"Total number of leases acquired and released",
+		stats.UnitDimensionless)
+
+	// StorageLeaseChangesView is an OpenCensus view that counts the leases acquired and
+	// released by lease name, which shows how often leadership changes.
+	StorageLeaseChangesView = &view.View{
+		Name:        storageLeaseChanges.Name(),
+		Description: storageLeaseChanges.Description(),
+		Measure:     storageLeaseChanges,
+		TagKeys:     []tag.Key{TagKeyLease, TagKeyLeaseChange},
+		Aggregation: view.Count(),
+	}
 )
 
+// Lease changes.
+const (
+	LeaseAcquired = "acquired"
+	LeaseReleased = "released"
+)
+
+// RecordStorageLeaseChange records that a lease was acquired or released.
+func RecordStorageLeaseChange(ctx context.Context, leaseName, change string) {
+	err := stats.RecordWithTags(ctx,
+		[]tag.Mutator{
+			tag.Upsert(TagKeyLease, leaseName),
+			tag.Upsert(TagKeyLeaseChange, change),
+		},
+		storageLeaseChanges.M(1),
+	)
+	if err != nil {
+		log.Warn(ctx).Err(err).Msg("internal/telemetry/metrics: failed to record")
+	}
+}
+
 // StorageOperationTags contains tags to apply when recording a storage operation
 type StorageOperationTags struct {
 	Operation string
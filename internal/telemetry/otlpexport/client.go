@@ -0,0 +1,142 @@
+// Package otlpexport contains a client which exports telemetry to an OpenTelemetry
+// collector with the OpenTelemetry protocol (OTLP).
+package otlpexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/grpc/otlp"
+)
+
+const (
+	// ProtocolGRPC exports telemetry with OTLP over gRPC.
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP exports telemetry with OTLP over HTTP, encoded as protobuf.
+	ProtocolHTTP = "http/protobuf"
+)
+
+// A Client exports telemetry to a collector.
+type Client struct {
+	endpoint *url.URL
+	headers  map[string]string
+
+	// set for gRPC
+	cc *grpc.ClientConn
+	// set for HTTP, the exporter's own requests aren't traced, so they don't produce
+	// telemetry of their own
+	httpClient *http.Client
+}
+
+// NewClient creates a new Client. For gRPC, http urls are insecure and https urls use TLS.
+// For HTTP, the path of the url defaults to the OTLP path of the signal, like /v1/traces.
+func NewClient(endpoint *url.URL, protocol string, headers map[string]string) (*Client, error) {
+	c := &Client{endpoint: endpoint, headers: headers}
+	switch protocol {
+	case ProtocolGRPC, "":
+		dialOption := grpc.WithInsecure()
+		if endpoint.Scheme == "https" {
+			dialOption = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+		}
+		cc, err := grpc.Dial(endpoint.Host, dialOption)
+		if err != nil {
+			return nil, fmt.Errorf("otlpexport: error connecting to %s: %w", endpoint.Host, err)
+		}
+		c.cc = cc
+	case ProtocolHTTP:
+		c.httpClient = &http.Client{Transport: http.DefaultTransport}
+	default:
+		return nil, fmt.Errorf("otlpexport: unknown protocol: %s", protocol)
+	}
+	return c, nil
+}
+
+// Close closes the connection to the collector.
+func (c *Client) Close() error {
+	if c.cc != nil {
+		return c.cc.Close()
+	}
+	return nil
+}
+
+// ExportTraces exports spans.
+func (c *Client) ExportTraces(ctx context.Context, req *otlp.ExportTraceServiceRequest) error {
+	if c.cc != nil {
+		_, err := otlp.NewTraceServiceClient(c.cc).Export(c.outgoingContext(ctx), req)
+		return err
+	}
+	return c.post(ctx, "/v1/traces", req)
+}
+
+// ExportMetrics exports metrics.
+func (c *Client) ExportMetrics(ctx context.Context, req *otlp.ExportMetricsServiceRequest) error {
+	if c.cc != nil {
+		_, err := otlp.NewMetricsServiceClient(c.cc).Export(c.outgoingContext(ctx), req)
+		return err
+	}
+	return c.post(ctx, "/v1/metrics", req)
+}
+
+func (c *Client) outgoingContext(ctx context.Context) context.Context {
+	return metadata.NewOutgoingContext(ctx, metadata.New(c.headers))
+}
+
+func (c *Client) post(ctx context.Context, defaultPath string, msg proto.Message) error {
+	u := *c.endpoint
+	if u.Path == "" || u.Path == "/" {
+		u.Path = defaultPath
+	}
+
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("otlpexport: unexpected status code: %d", res.StatusCode)
+	}
+	return nil
+}
+
+// NewKeyValue creates an attribute. Values other than strings, bools, int64s and float64s are
+// formatted as strings.
+func NewKeyValue(key string, value interface{}) *otlp.KeyValue {
+	v := new(otlp.AnyValue)
+	switch value := value.(type) {
+	case string:
+		v.Value = &otlp.AnyValue_StringValue{StringValue: value}
+	case bool:
+		v.Value = &otlp.AnyValue_BoolValue{BoolValue: value}
+	case int64:
+		v.Value = &otlp.AnyValue_IntValue{IntValue: value}
+	case float64:
+		v.Value = &otlp.AnyValue_DoubleValue{DoubleValue: value}
+	default:
+		v.Value = &otlp.AnyValue_StringValue{StringValue: fmt.Sprint(value)}
+	}
+	return &otlp.KeyValue{Key: key, Value: v}
+}
@@ -0,0 +1,250 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	octrace "go.opencensus.io/trace"
+	"go.opencensus.io/trace/tracestate"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/otlpexport"
+	"github.com/pomerium/pomerium/internal/version"
+	"github.com/pomerium/pomerium/pkg/grpc/otlp"
+)
+
+const (
+	// OTLPProtocolGRPC exports spans with OTLP over gRPC.
+	OTLPProtocolGRPC = otlpexport.ProtocolGRPC
+	// OTLPProtocolHTTP exports spans with OTLP over HTTP, encoded as protobuf.
+	OTLPProtocolHTTP = otlpexport.ProtocolHTTP
+
+	otlpBatchSize     = 512
+	otlpBufferSize    = 4096
+	otlpFlushInterval = 5 * time.Second
+	otlpExportTimeout = 10 * time.Second
+)
+
+type otlpProvider struct {
+	exporter *otlpExporter
+}
+
+func (provider *otlpProvider) Register(opts *TracingOptions) error {
+	exporter, err := newOTLPExporter(opts)
+	if err != nil {
+		return err
+	}
+	octrace.RegisterExporter(exporter)
+	provider.exporter = exporter
+	return nil
+}
+
+func (provider *otlpProvider) Unregister() error {
+	if provider.exporter == nil {
+		return nil
+	}
+	octrace.UnregisterExporter(provider.exporter)
+	err := provider.exporter.Close()
+	provider.exporter = nil
+	return err
+}
+
+// An otlpExporter exports spans to an OpenTelemetry collector in batches.
+type otlpExporter struct {
+	resource *otlp.Resource
+	client   *otlpexport.Client
+
+	spans     chan *octrace.SpanData
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newOTLPExporter(opts *TracingOptions) (*otlpExporter, error) {
+	if opts.OTLPEndpoint == nil {
+		return nil, fmt.Errorf("telemetry/trace: otlp endpoint is required")
+	}
+
+	exporter := &otlpExporter{
+		resource: &otlp.Resource{Attributes: []*otlp.KeyValue{
+			otlpexport.NewKeyValue("service.name", opts.Service),
+			otlpexport.NewKeyValue("service.version", version.FullVersion()),
+		}},
+		spans: make(chan *octrace.SpanData, otlpBufferSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	client, err := otlpexport.NewClient(opts.OTLPEndpoint, opts.OTLPProtocol, opts.OTLPHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry/trace: %w", err)
+	}
+	exporter.client = client
+
+	go exporter.run()
+	return exporter, nil
+}
+
+// ExportSpan implements octrace.Exporter. Spans are dropped when the buffer is full, so a
+// collector that's down doesn't slow down requests.
+func (exporter *otlpExporter) ExportSpan(sd *octrace.SpanData) {
+	select {
+	case exporter.spans <- sd:
+	default:
+	}
+}
+
+// Close flushes the buffered spans and closes the connection to the collector.
+func (exporter *otlpExporter) Close() error {
+	var err error
+	exporter.closeOnce.Do(func() {
+		close(exporter.stop)
+		<-exporter.done
+		err = exporter.client.Close()
+	})
+	return err
+}
+
+func (exporter *otlpExporter) run() {
+	defer close(exporter.done)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*octrace.SpanData
+	for {
+		select {
+		case <-exporter.stop:
+			for {
+				select {
+				case sd := <-exporter.spans:
+					batch = append(batch, sd)
+				default:
+					exporter.flush(batch)
+					return
+				}
+			}
+		case sd := <-exporter.spans:
+			batch = append(batch, sd)
+			if len(batch) >= otlpBatchSize {
+				exporter.flush(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			exporter.flush(batch)
+			batch = nil
+		}
+	}
+}
+
+func (exporter *otlpExporter) flush(batch []*octrace.SpanData) {
+	if len(batch) == 0 {
+		return
+	}
+
+	spans := make([]*otlp.Span, 0, len(batch))
+	for _, sd := range batch {
+		spans = append(spans, newOTLPSpan(sd))
+	}
+	req := &otlp.ExportTraceServiceRequest{ResourceSpans: []*otlp.ResourceSpans{{
+		Resource: exporter.resource,
+		ScopeSpans: []*otlp.ScopeSpans{{
+			Scope: &otlp.InstrumentationScope{Name: "github.com/pomerium/pomerium", Version: version.FullVersion()},
+			Spans: spans,
+		}},
+	}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	if err := exporter.client.ExportTraces(ctx, req); err != nil {
+		log.Warn(ctx).Err(err).Int("spans", len(spans)).Msg("telemetry/trace: error exporting spans")
+	}
+}
+
+func newOTLPSpan(sd *octrace.SpanData) *otlp.Span {
+	span := &otlp.Span{
+		TraceId:                sd.TraceID[:],
+		SpanId:                 sd.SpanID[:],
+		Name:                   sd.Name,
+		Kind:                   otlp.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano:      uint64(sd.StartTime.UnixNano()),
+		EndTimeUnixNano:        uint64(sd.EndTime.UnixNano()),
+		Attributes:             newOTLPAttributes(sd.Attributes),
+		DroppedAttributesCount: uint32(sd.DroppedAttributeCount),
+		DroppedEventsCount:     uint32(sd.DroppedAnnotationCount + sd.DroppedMessageEventCount),
+		DroppedLinksCount:      uint32(sd.DroppedLinkCount),
+		Status:                 &otlp.Status{},
+	}
+	if sd.ParentSpanID != (octrace.SpanID{}) {
+		span.ParentSpanId = sd.ParentSpanID[:]
+	}
+	span.TraceState = formatTracestate(sd.Tracestate)
+	switch sd.SpanKind {
+	case octrace.SpanKindServer:
+		span.Kind = otlp.Span_SPAN_KIND_SERVER
+	case octrace.SpanKindClient:
+		span.Kind = otlp.Span_SPAN_KIND_CLIENT
+	}
+	// OpenCensus status codes are gRPC codes, where 0 is OK
+	if sd.Code != 0 {
+		span.Status.Code = otlp.Status_STATUS_CODE_ERROR
+		span.Status.Message = sd.Message
+	}
+
+	for _, a := range sd.Annotations {
+		span.Events = append(span.Events, &otlp.Span_Event{
+			TimeUnixNano: uint64(a.Time.UnixNano()),
+			Name:         a.Message,
+			Attributes:   newOTLPAttributes(a.Attributes),
+		})
+	}
+	for _, e := range sd.MessageEvents {
+		span.Events = append(span.Events, &otlp.Span_Event{
+			TimeUnixNano: uint64(e.Time.UnixNano()),
+			Name:         "message",
+			Attributes: []*otlp.KeyValue{
+				otlpexport.NewKeyValue("message.id", e.MessageID),
+				otlpexport.NewKeyValue("message.uncompressed_size", e.UncompressedByteSize),
+				otlpexport.NewKeyValue("message.compressed_size", e.CompressedByteSize),
+			},
+		})
+	}
+	for _, l := range sd.Links {
+		span.Links = append(span.Links, &otlp.Span_Link{
+			TraceId:    l.TraceID[:],
+			SpanId:     l.SpanID[:],
+			Attributes: newOTLPAttributes(l.Attributes),
+		})
+	}
+	return span
+}
+
+// newOTLPAttributes converts the attributes, sorted by key so the spans are deterministic.
+func newOTLPAttributes(attributes map[string]interface{}) []*otlp.KeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]*otlp.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, otlpexport.NewKeyValue(k, attributes[k]))
+	}
+	return kvs
+}
+
+func formatTracestate(ts *tracestate.Tracestate) string {
+	var entries []string
+	for _, e := range ts.Entries() {
+		entries = append(entries, e.Key+"="+e.Value)
+	}
+	return strings.Join(entries, ",")
+}
@@ -0,0 +1,134 @@
+package trace
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	octrace "go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/grpc/otlp"
+)
+
+type testTraceServer struct {
+	otlp.UnimplementedTraceServiceServer
+	requests chan *otlp.ExportTraceServiceRequest
+	apiKeys  chan []string
+}
+
+func (srv *testTraceServer) Export(ctx context.Context, req *otlp.ExportTraceServiceRequest) (*otlp.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	srv.apiKeys <- md.Get("api-key")
+	srv.requests <- req
+	return new(otlp.ExportTraceServiceResponse), nil
+}
+
+func TestOTLPExporter(t *testing.T) {
+	sd := &octrace.SpanData{
+		SpanContext: octrace.SpanContext{
+			TraceID: octrace.TraceID{1, 2, 3},
+			SpanID:  octrace.SpanID{4, 5, 6},
+		},
+		ParentSpanID: octrace.SpanID{7, 8, 9},
+		SpanKind:     octrace.SpanKindServer,
+		Name:         "authorize.grpc.Check",
+		StartTime:    time.Unix(1, 0),
+		EndTime:      time.Unix(2, 0),
+		Attributes:   map[string]interface{}{"b": int64(1), "a": "x"},
+		Status:       octrace.Status{Code: 7, Message: "denied"},
+	}
+	checkRequest := func(t *testing.T, req *otlp.ExportTraceServiceRequest) {
+		require.Len(t, req.GetResourceSpans(), 1)
+		assert.Equal(t, "service.name", req.GetResourceSpans()[0].GetResource().GetAttributes()[0].GetKey())
+		assert.Equal(t, "pomerium", req.GetResourceSpans()[0].GetResource().GetAttributes()[0].GetValue().GetStringValue())
+		spans := req.GetResourceSpans()[0].GetScopeSpans()[0].GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, sd.TraceID[:], spans[0].GetTraceId())
+		assert.Equal(t, sd.ParentSpanID[:], spans[0].GetParentSpanId())
+		assert.Equal(t, otlp.Span_SPAN_KIND_SERVER, spans[0].GetKind())
+		assert.Equal(t, uint64(1e9), spans[0].GetStartTimeUnixNano())
+		assert.Equal(t, "a", spans[0].GetAttributes()[0].GetKey())
+		assert.Equal(t, int64(1), spans[0].GetAttributes()[1].GetValue().GetIntValue())
+		assert.Equal(t, otlp.Status_STATUS_CODE_ERROR, spans[0].GetStatus().GetCode())
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		li, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv := &testTraceServer{
+			requests: make(chan *otlp.ExportTraceServiceRequest, 1),
+			apiKeys:  make(chan []string, 1),
+		}
+		gs := grpc.NewServer()
+		otlp.RegisterTraceServiceServer(gs, srv)
+		go func() { _ = gs.Serve(li) }()
+		t.Cleanup(gs.Stop)
+
+		exporter, err := newOTLPExporter(&TracingOptions{
+			Service:      "pomerium",
+			OTLPEndpoint: &url.URL{Scheme: "http", Host: li.Addr().String()},
+			OTLPHeaders:  map[string]string{"api-key": "secret"},
+		})
+		require.NoError(t, err)
+		exporter.ExportSpan(sd)
+		require.NoError(t, exporter.Close(), "close should flush the spans")
+
+		assert.Equal(t, []string{"secret"}, <-srv.apiKeys)
+		checkRequest(t, <-srv.requests)
+	})
+	t.Run("http", func(t *testing.T) {
+		requests := make(chan *otlp.ExportTraceServiceRequest, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/traces", r.URL.Path)
+			assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(r.Body)
+			var req otlp.ExportTraceServiceRequest
+			assert.NoError(t, proto.Unmarshal(body, &req))
+			requests <- &req
+		}))
+		t.Cleanup(srv.Close)
+
+		u, _ := url.Parse(srv.URL)
+		exporter, err := newOTLPExporter(&TracingOptions{
+			Service:      "pomerium",
+			OTLPEndpoint: u,
+			OTLPProtocol: OTLPProtocolHTTP,
+		})
+		require.NoError(t, err)
+		exporter.ExportSpan(sd)
+		require.NoError(t, exporter.Close())
+
+		checkRequest(t, <-requests)
+	})
+}
+
+func TestHTTPFormat(t *testing.T) {
+	sc := octrace.SpanContext{
+		TraceID:      octrace.TraceID{1, 2, 3},
+		SpanID:       octrace.SpanID{4, 5, 6},
+		TraceOptions: 1,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	HTTPFormat.SpanContextToRequest(sc, r)
+	assert.Equal(t, "00-01020300000000000000000000000000-0405060000000000-01", r.Header.Get("traceparent"))
+	assert.Equal(t, "01020300000000000000000000000000", r.Header.Get("X-B3-TraceId"))
+
+	got, ok := SpanContextFromHeaders(http.Header{"Traceparent": {r.Header.Get("traceparent")}})
+	assert.True(t, ok)
+	assert.Equal(t, sc.TraceID, got.TraceID)
+
+	got, ok = SpanContextFromHeaders(http.Header{"X-B3-Traceid": {"01020300000000000000000000000000"}, "X-B3-Spanid": {"0405060000000000"}})
+	assert.True(t, ok, "b3 headers should still be supported")
+	assert.Equal(t, sc.SpanID, got.SpanID)
+}
@@ -0,0 +1,45 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+
+	"go.opencensus.io/plugin/ochttp/propagation/b3"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	octrace "go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+)
+
+// HTTPFormat propagates spans in HTTP requests with the W3C trace context headers, and the B3
+// headers used by earlier versions of pomerium and by envoy's zipkin tracer. The trace context
+// headers take precedence when a request has both.
+var HTTPFormat propagation.HTTPFormat = httpFormat{}
+
+type httpFormat struct{}
+
+func (httpFormat) SpanContextFromRequest(req *http.Request) (octrace.SpanContext, bool) {
+	if sc, ok := (&tracecontext.HTTPFormat{}).SpanContextFromRequest(req); ok {
+		return sc, true
+	}
+	return (&b3.HTTPFormat{}).SpanContextFromRequest(req)
+}
+
+func (httpFormat) SpanContextToRequest(sc octrace.SpanContext, req *http.Request) {
+	(&tracecontext.HTTPFormat{}).SpanContextToRequest(sc, req)
+	(&b3.HTTPFormat{}).SpanContextToRequest(sc, req)
+}
+
+// SpanContextFromHeaders returns the span context propagated in the headers.
+func SpanContextFromHeaders(headers http.Header) (octrace.SpanContext, bool) {
+	return HTTPFormat.SpanContextFromRequest(&http.Request{Header: headers})
+}
+
+// SetTraceContextHeaders sets the W3C trace context headers of the span in the context, so
+// the span is the parent of the spans of the request's recipient.
+func SetTraceContextHeaders(ctx context.Context, headers http.Header) {
+	span := octrace.FromContext(ctx)
+	if span == nil {
+		return
+	}
+	(&tracecontext.HTTPFormat{}).SpanContextToRequest(span.SpanContext(), &http.Request{Header: headers})
+}
@@ -15,6 +15,9 @@ const (
 	DatadogTracingProviderName = "datadog"
 	// JaegerTracingProviderName is the name of the tracing provider Jaeger.
 	JaegerTracingProviderName = "jaeger"
+	// OTLPTracingProviderName is the name of the tracing provider OpenTelemetry, which exports
+	// spans with the OpenTelemetry protocol.
+	OTLPTracingProviderName = "otlp"
 	// ZipkinTracingProviderName is the name of the tracing provider Zipkin.
 	ZipkinTracingProviderName = "zipkin"
 )
@@ -50,6 +53,17 @@ type TracingOptions struct {
 	// Example: http://zipkin:9411/api/v2/spans
 	ZipkinEndpoint *url.URL
 
+	// OTLP
+
+	// OTLPEndpoint is the url of the OpenTelemetry collector. For gRPC, http urls are
+	// insecure and https urls use TLS. For HTTP, the path defaults to /v1/traces.
+	// Example: http://otel-collector:4317
+	OTLPEndpoint *url.URL
+	// OTLPProtocol is the protocol used to export spans, grpc or http/protobuf.
+	OTLPProtocol string
+	// OTLPHeaders are sent with each export, like an API key of the collector.
+	OTLPHeaders map[string]string
+
 	// SampleRate is percentage of requests which are sampled
 	SampleRate float64
 }
@@ -67,6 +81,8 @@ func GetProvider(opts *TracingOptions) (Provider, error) {
 		provider = new(datadogProvider)
 	case JaegerTracingProviderName:
 		provider = new(jaegerProvider)
+	case OTLPTracingProviderName:
+		provider = new(otlpProvider)
 	case ZipkinTracingProviderName:
 		provider = new(zipkinProvider)
 	default:
@@ -86,3 +102,9 @@ func GetProvider(opts *TracingOptions) (Provider, error) {
 func StartSpan(ctx context.Context, name string, o ...octrace.StartOption) (context.Context, *octrace.Span) {
 	return octrace.StartSpan(ctx, name, o...)
 }
+
+// StartSpanWithRemoteParent starts a new child span of the span from a different process,
+// like the span propagated in the headers of a request.
+func StartSpanWithRemoteParent(ctx context.Context, name string, parent octrace.SpanContext, o ...octrace.StartOption) (context.Context, *octrace.Span) {
+	return octrace.StartSpanWithRemoteParent(ctx, name, parent, o...)
+}
@@ -0,0 +1,288 @@
+package testutil
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pomerium/pomerium/internal/ldap"
+	"github.com/pomerium/pomerium/internal/ldap/ber"
+)
+
+// LDAP protocol operations
+//
+// https://datatracker.ietf.org/doc/html/rfc4511#section-4.2
+const (
+	ldapBindRequest       = 0
+	ldapBindResponse      = 1
+	ldapUnbindRequest     = 2
+	ldapSearchRequest     = 3
+	ldapSearchResultEntry = 4
+	ldapSearchResultDone  = 5
+)
+
+// LDAP filter choices
+const (
+	ldapFilterAnd             = 0
+	ldapFilterOr              = 1
+	ldapFilterNot             = 2
+	ldapFilterEqualityMatch   = 3
+	ldapFilterSubstrings      = 4
+	ldapFilterPresent         = 7
+	ldapFilterApproxMatch     = 8
+	ldapFilterExtensibleMatch = 9
+)
+
+// ldapControlPagedResults is the simple paged results control.
+const ldapControlPagedResults = "1.2.840.113556.1.4.319"
+
+// An LDAPServer is an in-memory LDAP server used for testing. It supports simple binds and
+// searches with the paged results control.
+type LDAPServer struct {
+	entries   []*ldap.Entry
+	passwords map[string]string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewLDAPServer creates a new LDAPServer listening on a random local port. Passwords are keyed by DN.
+func NewLDAPServer(entries []*ldap.Entry, passwords map[string]string) (*LDAPServer, error) {
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	srv := &LDAPServer{
+		entries:   entries,
+		passwords: passwords,
+		listener:  li,
+	}
+	srv.wg.Add(1)
+	go srv.serve()
+	return srv, nil
+}
+
+// URL returns the ldap:// url of the server.
+func (srv *LDAPServer) URL() string {
+	return "ldap://" + srv.listener.Addr().String()
+}
+
+// Close stops the server.
+func (srv *LDAPServer) Close() {
+	_ = srv.listener.Close()
+	srv.wg.Wait()
+}
+
+func (srv *LDAPServer) serve() {
+	defer srv.wg.Done()
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return
+		}
+		srv.wg.Add(1)
+		go func() {
+			defer srv.wg.Done()
+			defer conn.Close()
+			srv.handle(conn)
+		}()
+	}
+}
+
+func (srv *LDAPServer) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		msg, err := ber.ReadPacket(r)
+		if err != nil {
+			return
+		}
+		id := msg.Child(0)
+		op := msg.Child(1)
+		write := func(op *ber.Packet, controls ...*ber.Packet) bool {
+			res := ber.NewSequence(id, op)
+			res.Children = append(res.Children, controls...)
+			_, err := conn.Write(res.Bytes())
+			return err == nil
+		}
+
+		switch {
+		case op.Is(ber.ClassApplication, ldapBindRequest):
+			code := ldap.ResultSuccess
+			if password, ok := srv.passwords[op.Child(1).Str()]; !ok || password != op.Child(2).Str() {
+				code = ldap.ResultInvalidCredentials
+			}
+			if !write(newLDAPResult(ldapBindResponse, code)) {
+				return
+			}
+		case op.Is(ber.ClassApplication, ldapUnbindRequest):
+			return
+		case op.Is(ber.ClassApplication, ldapSearchRequest):
+			if !srv.search(op, msg.Child(2), write) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (srv *LDAPServer) search(op, controls *ber.Packet, write func(*ber.Packet, ...*ber.Packet) bool) bool {
+	baseDN := strings.ToLower(op.Child(0).Str())
+	sizeLimit, _ := op.Child(3).Integer()
+	filter := op.Child(6)
+	var attributes []string
+	for _, attr := range op.Child(7).Children {
+		attributes = append(attributes, attr.Str())
+	}
+
+	var matches []*ldap.Entry
+	for _, entry := range srv.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.DN), baseDN) {
+			continue
+		}
+		if matchLDAPFilter(entry, filter) {
+			matches = append(matches, entry)
+		}
+	}
+
+	code := ldap.ResultSuccess
+	if sizeLimit > 0 && int64(len(matches)) > sizeLimit {
+		matches = matches[:sizeLimit]
+		code = ldap.ResultSizeLimitExceeded
+	}
+
+	// paged results use the offset of the next page as the cookie
+	var resControls []*ber.Packet
+	if controls == nil {
+		controls = ber.NewSequence()
+	}
+	for _, control := range controls.Children {
+		if control.Child(0).Str() != ldapControlPagedResults {
+			continue
+		}
+		value, err := ber.DecodePacket(control.Child(len(control.Children) - 1).Value)
+		if err != nil {
+			return false
+		}
+		pageSize, _ := value.Child(0).Integer()
+		offset, _ := strconv.Atoi(value.Child(1).Str())
+		if offset > len(matches) {
+			offset = len(matches)
+		}
+		matches = matches[offset:]
+		cookie := ""
+		if int64(len(matches)) > pageSize {
+			matches = matches[:pageSize]
+			cookie = strconv.Itoa(offset + int(pageSize))
+		}
+		resControls = append(resControls, ber.NewConstructed(ber.ClassContext, 0, ber.NewSequence(
+			ber.NewOctetString(ldapControlPagedResults),
+			ber.NewOctetString(string(ber.NewSequence(ber.NewInteger(0), ber.NewOctetString(cookie)).Bytes())),
+		)))
+	}
+
+	for _, entry := range matches {
+		attrs := ber.NewSequence()
+		for _, attr := range entry.Attributes {
+			if len(attributes) > 0 && !containsFold(attributes, attr.Name) {
+				continue
+			}
+			values := ber.NewSet()
+			for _, value := range attr.Values {
+				values.Children = append(values.Children, ber.NewOctetString(value))
+			}
+			attrs.Children = append(attrs.Children, ber.NewSequence(ber.NewOctetString(attr.Name), values))
+		}
+		if !write(ber.NewConstructed(ber.ClassApplication, ldapSearchResultEntry, ber.NewOctetString(entry.DN), attrs)) {
+			return false
+		}
+	}
+	return write(newLDAPResult(ldapSearchResultDone, code), resControls...)
+}
+
+func newLDAPResult(tag byte, code int) *ber.Packet {
+	return ber.NewConstructed(ber.ClassApplication, tag,
+		ber.NewEnumerated(int64(code)),
+		ber.NewOctetString(""),
+		ber.NewOctetString(""),
+	)
+}
+
+func matchLDAPFilter(entry *ldap.Entry, filter *ber.Packet) bool {
+	switch {
+	case filter.Is(ber.ClassContext, ldapFilterAnd):
+		for _, c := range filter.Children {
+			if !matchLDAPFilter(entry, c) {
+				return false
+			}
+		}
+		return true
+	case filter.Is(ber.ClassContext, ldapFilterOr):
+		for _, c := range filter.Children {
+			if matchLDAPFilter(entry, c) {
+				return true
+			}
+		}
+		return false
+	case filter.Is(ber.ClassContext, ldapFilterNot):
+		return !matchLDAPFilter(entry, filter.Child(0))
+	case filter.Is(ber.ClassContext, ldapFilterPresent):
+		return len(entry.GetAttributeValues(filter.Str())) > 0
+	case filter.Is(ber.ClassContext, ldapFilterEqualityMatch), filter.Is(ber.ClassContext, ldapFilterApproxMatch):
+		return containsFold(entry.GetAttributeValues(filter.Child(0).Str()), filter.Child(1).Str())
+	case filter.Is(ber.ClassContext, ldapFilterSubstrings):
+		for _, value := range entry.GetAttributeValues(filter.Child(0).Str()) {
+			if matchLDAPSubstrings(strings.ToLower(value), filter.Child(1)) {
+				return true
+			}
+		}
+		return false
+	case filter.Is(ber.ClassContext, ldapFilterExtensibleMatch):
+		var attr, value string
+		for _, c := range filter.Children {
+			switch c.Tag {
+			case 2:
+				attr = c.Str()
+			case 3:
+				value = c.Str()
+			}
+		}
+		return containsFold(entry.GetAttributeValues(attr), value)
+	}
+	return false
+}
+
+func matchLDAPSubstrings(value string, substrings *ber.Packet) bool {
+	for _, c := range substrings.Children {
+		s := strings.ToLower(c.Str())
+		switch c.Tag {
+		case 0:
+			if !strings.HasPrefix(value, s) {
+				return false
+			}
+			value = value[len(s):]
+		case 1:
+			idx := strings.Index(value, s)
+			if idx < 0 {
+				return false
+			}
+			value = value[idx+len(s):]
+		case 2:
+			if !strings.HasSuffix(value, s) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
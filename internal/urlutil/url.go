@@ -97,7 +97,7 @@ func GetAbsoluteURL(r *http.Request) *url.URL {
 // For standard HTTP (80)/HTTPS (443) ports, it returns `example.com` and `example.com:<port>`.
 // Otherwise, return the URL.Host value.
 func GetDomainsForURL(u url.URL) []string {
-	if IsTCP(&u) {
+	if IsTCP(&u) || IsUDP(&u) {
 		return []string{u.Host}
 	}
 
@@ -124,6 +124,11 @@ func IsTCP(u *url.URL) bool {
 	return u.Scheme == "tcp+http" || u.Scheme == "tcp+https"
 }
 
+// IsUDP returns whether or not the given URL is for UDP tunneled via HTTP Connect.
+func IsUDP(u *url.URL) bool {
+	return u.Scheme == "udp+http" || u.Scheme == "udp+https"
+}
+
 // Join joins elements of a URL with '/'.
 func Join(elements ...string) string {
 	var builder strings.Builder
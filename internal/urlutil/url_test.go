@@ -147,6 +147,7 @@ func TestGetDomainsForURL(t *testing.T) {
 		{"http scheme with host contain 443", &url.URL{Scheme: "http", Host: "example.com:443"}, []string{"example.com:443"}},
 		{"https", &url.URL{Scheme: "https", Host: "example.com"}, []string{"example.com", "example.com:443"}},
 		{"Host contains other port", &url.URL{Scheme: "https", Host: "example.com:1234"}, []string{"example.com:1234"}},
+		{"udp", &url.URL{Scheme: "udp+https", Host: "dns.example.com:53"}, []string{"dns.example.com:53"}},
 	}
 	for _, tc := range tests {
 		tc := tc
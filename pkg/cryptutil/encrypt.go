@@ -48,3 +48,17 @@ func Decrypt(a cipher.AEAD, data, ad []byte) ([]byte, error) {
 	}
 	return plaintext, nil
 }
+
+// DecryptWithAny decrypts a value with optional associated data using the first of the ciphers
+// which authenticates it, so values encrypted with a rotated key can still be decrypted.
+func DecryptWithAny(ciphers []cipher.AEAD, data, ad []byte) ([]byte, error) {
+	err := fmt.Errorf("cryptutil: no cipher")
+	for _, a := range ciphers {
+		var plaintext []byte
+		plaintext, err = Decrypt(a, data, ad)
+		if err == nil {
+			return plaintext, nil
+		}
+	}
+	return nil, err
+}
@@ -1,11 +1,13 @@
 package cryptutil
 
 import (
+	"crypto/cipher"
 	"encoding/base64"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncodeAndDecodeAccessToken(t *testing.T) {
@@ -63,6 +65,25 @@ func TestNewAEADCipher(t *testing.T) {
 	}
 }
 
+func TestDecryptWithAny(t *testing.T) {
+	t.Parallel()
+
+	oldCipher, err := NewAEADCipher(NewKey())
+	require.NoError(t, err)
+	newCipher, err := NewAEADCipher(NewKey())
+	require.NoError(t, err)
+
+	ciphertext := Encrypt(oldCipher, []byte("secret"), nil)
+	plaintext, err := DecryptWithAny([]cipher.AEAD{newCipher, oldCipher}, ciphertext, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret"), plaintext)
+
+	_, err = DecryptWithAny([]cipher.AEAD{newCipher}, ciphertext, nil)
+	assert.Error(t, err)
+	_, err = DecryptWithAny(nil, ciphertext, nil)
+	assert.Error(t, err)
+}
+
 func BenchmarkAEADCipher(b *testing.B) {
 	plaintext := []byte("my plain text value")
 
@@ -3,6 +3,9 @@ package cryptutil
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/hex"
@@ -23,6 +26,23 @@ func PublicJWKFromBytes(data []byte) (*jose.JSONWebKey, error) {
 	return loadKey(data, loadPublicKey)
 }
 
+// PublicJWKFromKey returns a jose JSON Web _Public_ Key for a public key.
+func PublicJWKFromKey(key crypto.PublicKey) (*jose.JSONWebKey, error) {
+	return newJWK(key)
+}
+
+// PrivateJWKFromSigner returns a jose JSON Web _Private_ Key for a signer which doesn't expose
+// its private key, like a signer for a key stored on an HSM.
+func PrivateJWKFromSigner(signer jose.OpaqueSigner) *jose.JSONWebKey {
+	public := signer.Public()
+	return &jose.JSONWebKey{
+		Key:       signer,
+		KeyID:     public.KeyID,
+		Algorithm: public.Algorithm,
+		Use:       public.Use,
+	}
+}
+
 func loadKey(data []byte, unmarshal func([]byte) (interface{}, error)) (*jose.JSONWebKey, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
@@ -32,6 +52,30 @@ func loadKey(data []byte, unmarshal func([]byte) (interface{}, error)) (*jose.JS
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal key: %w", err)
 	}
+	return newJWK(priv)
+}
+
+// NewPrivateJWK generates a new jose JSON Web _Private_ Key for the given signature algorithm.
+func NewPrivateJWK(alg jose.SignatureAlgorithm) (*jose.JSONWebKey, error) {
+	var priv interface{}
+	var err error
+	switch alg {
+	case jose.ES256:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jose.RS256:
+		priv, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jose.EdDSA:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("crypto: unsupported signature algorithm: %s", alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newJWK(priv)
+}
+
+func newJWK(priv interface{}) (*jose.JSONWebKey, error) {
 	alg, err := SignatureAlgorithmForKey(priv)
 	if err != nil {
 		return nil, err
@@ -79,6 +123,8 @@ func loadPublicKey(b []byte) (interface{}, error) {
 			return k.Public(), nil
 		case *ecdsa.PrivateKey:
 			return k.Public(), nil
+		case ed25519.PrivateKey:
+			return k.Public(), nil
 		default:
 			return nil, fmt.Errorf("private key is unsupported type")
 		}
@@ -105,6 +151,8 @@ func SignatureAlgorithmForKey(key interface{}) (jose.SignatureAlgorithm, error)
 		return jose.ES256, nil
 	case *rsa.PrivateKey, *rsa.PublicKey:
 		return jose.RS256, nil
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return jose.EdDSA, nil
 	default:
 		return "", fmt.Errorf("crypto: unsupported key type for signing: %T", key)
 	}
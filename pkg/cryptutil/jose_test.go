@@ -135,9 +135,26 @@ func TestSignatureAlgorithmForKey(t *testing.T) {
 	t.Run("ed25519", func(t *testing.T) {
 		pub, priv, err := ed25519.GenerateKey(rand.Reader)
 		require.NoError(t, err)
-		_, err = SignatureAlgorithmForKey(priv)
-		assert.Error(t, err)
-		_, err = SignatureAlgorithmForKey(pub)
-		assert.Error(t, err)
+		alg, err := SignatureAlgorithmForKey(priv)
+		assert.NoError(t, err)
+		assert.Equal(t, jose.EdDSA, alg)
+		alg, err = SignatureAlgorithmForKey(pub)
+		assert.NoError(t, err)
+		assert.Equal(t, jose.EdDSA, alg)
 	})
 }
+
+func TestNewPrivateJWK(t *testing.T) {
+	for _, alg := range []jose.SignatureAlgorithm{jose.ES256, jose.RS256, jose.EdDSA} {
+		t.Run(string(alg), func(t *testing.T) {
+			key, err := NewPrivateJWK(alg)
+			require.NoError(t, err)
+			assert.Equal(t, string(alg), key.Algorithm)
+			assert.NotEmpty(t, key.KeyID)
+			assert.False(t, key.IsPublic())
+		})
+	}
+
+	_, err := NewPrivateJWK(jose.HS256)
+	assert.Error(t, err)
+}
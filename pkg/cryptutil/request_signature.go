@@ -0,0 +1,78 @@
+package cryptutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestSignatureVersion is the version of the request signature scheme.
+const RequestSignatureVersion = "v1"
+
+var (
+	errRequestSignatureMalformed = errors.New("internal/cryptutil: request signature malformed")
+	errRequestSignatureInvalid   = errors.New("internal/cryptutil: request signature invalid")
+)
+
+// SignRequest signs the method, path, body and timestamp of a request with the key, using
+// HMAC-SHA256. The nonce is random data which makes each signature unique, so upstreams can
+// reject replayed requests. It returns the signature in the form:
+//
+//	t=<unix timestamp>,nonce=<nonce>,v1=<hex encoded signature>
+func SignRequest(key []byte, method, path string, body []byte, timestamp time.Time, nonce string) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := requestSignatureMAC(key, ts, nonce, method, path, body)
+	return fmt.Sprintf("t=%s,nonce=%s,%s=%s", ts, nonce, RequestSignatureVersion, hex.EncodeToString(mac))
+}
+
+// VerifyRequestSignature verifies a signature created by SignRequest, and that its timestamp
+// is within the DefaultLeeway.
+func VerifyRequestSignature(key []byte, signature, method, path string, body []byte) error {
+	var ts, nonce, sig string
+	for _, part := range strings.Split(signature, ",") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return errRequestSignatureMalformed
+		}
+		switch k {
+		case "t":
+			ts = v
+		case "nonce":
+			nonce = v
+		case RequestSignatureVersion:
+			sig = v
+		}
+	}
+	if ts == "" || sig == "" {
+		return errRequestSignatureMalformed
+	}
+
+	mac, err := hex.DecodeString(sig)
+	if err != nil {
+		return errRequestSignatureMalformed
+	}
+	if !hmac.Equal(mac, requestSignatureMAC(key, ts, nonce, method, path, body)) {
+		return errRequestSignatureInvalid
+	}
+	return ValidTimestamp(ts)
+}
+
+// requestSignatureMAC returns the HMAC-SHA256 of the timestamp, nonce, method, path and the
+// hex encoded SHA256 hash of the body, separated by newlines.
+func requestSignatureMAC(key []byte, ts, nonce, method, path string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(strings.Join([]string{
+		ts,
+		nonce,
+		method,
+		path,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")))
+	return h.Sum(nil)
+}
@@ -0,0 +1,24 @@
+package cryptutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestSignature(t *testing.T) {
+	key := NewKey()
+	now := time.Now()
+	sig := SignRequest(key, "POST", "/api?x=1", []byte(`{"hello":"world"}`), now, "NONCE")
+
+	assert.NoError(t, VerifyRequestSignature(key, sig, "POST", "/api?x=1", []byte(`{"hello":"world"}`)))
+	assert.ErrorIs(t, VerifyRequestSignature(NewKey(), sig, "POST", "/api?x=1", []byte(`{"hello":"world"}`)), errRequestSignatureInvalid)
+	assert.ErrorIs(t, VerifyRequestSignature(key, sig, "PUT", "/api?x=1", []byte(`{"hello":"world"}`)), errRequestSignatureInvalid)
+	assert.ErrorIs(t, VerifyRequestSignature(key, sig, "POST", "/api?x=2", []byte(`{"hello":"world"}`)), errRequestSignatureInvalid)
+	assert.ErrorIs(t, VerifyRequestSignature(key, sig, "POST", "/api?x=1", []byte(`{"hello":"there"}`)), errRequestSignatureInvalid)
+	assert.ErrorIs(t, VerifyRequestSignature(key, "garbage", "POST", "/api?x=1", nil), errRequestSignatureMalformed)
+
+	old := SignRequest(key, "GET", "/", nil, now.Add(-time.Hour), "NONCE")
+	assert.ErrorIs(t, VerifyRequestSignature(key, old, "GET", "/", nil), errTimestampExpired)
+}
@@ -9,7 +9,9 @@ func (rr *RouteRedirect) IsSet() bool {
 		return false
 	}
 
-	return rr.StripQuery != nil ||
+	return rr.RegexRewritePattern != nil ||
+		rr.RegexRewriteSubstitution != nil ||
+		rr.StripQuery != nil ||
 		rr.ResponseCode != nil ||
 		rr.PrefixRewrite != nil ||
 		rr.PathRedirect != nil ||
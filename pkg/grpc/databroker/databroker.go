@@ -5,9 +5,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/pkg/grpcutil"
 	"github.com/pomerium/pomerium/pkg/protoutil"
 )
@@ -51,6 +56,22 @@ func Put(ctx context.Context, client DataBrokerServiceClient, objects ...recordO
 	return client.Put(ctx, &PutRequest{Records: records})
 }
 
+// UseOnce marks the key as used, for values which may only be used once, like nonces. It
+// returns false if the key was already used during the last ttl. The key is counted against a
+// rate limit of a single request, so the check is shared by all the instances using the
+// databroker, and the key is forgotten once its counters expire.
+func UseOnce(ctx context.Context, client DataBrokerServiceClient, key string, ttl time.Duration) (bool, error) {
+	res, err := client.RateLimit(ctx, &RateLimitRequest{
+		Key:    "use_once|" + key,
+		Limit:  1,
+		Window: durationpb.New(ttl),
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.GetAllowed(), nil
+}
+
 // ApplyOffsetAndLimit applies the offset and limit to the list of records.
 func ApplyOffsetAndLimit(all []*Record, offset, limit int) (records []*Record, totalCount int) {
 	records = all
@@ -92,6 +113,8 @@ loop:
 			serverVersion = res.Versions.GetServerVersion()
 		case *SyncLatestResponse_Record:
 			records = append(records, res.Record)
+		case *SyncLatestResponse_Chunk:
+			records = append(records, res.Chunk.GetRecords()...)
 		default:
 			panic(fmt.Sprintf("unexpected response: %T", res))
 		}
@@ -100,6 +123,98 @@ loop:
 	return records, recordVersion, serverVersion, nil
 }
 
+const (
+	// syncLatestChunkSize is the number of records in each chunk requested by SyncLatestChunks.
+	syncLatestChunkSize = 1000
+	// maxSyncLatestResumes is the number of times SyncLatestChunks resumes an interrupted stream.
+	maxSyncLatestResumes = 5
+)
+
+// SyncLatestChunks performs a sync latest and calls fn with each chunk of records, so they
+// don't all have to be held in memory. When the stream is interrupted it's resumed after the
+// last chunk received, if the storage backend supports it.
+//
+// Records may change while a sync latest is resumed. The returned record version is the one
+// from before any change, so syncing from it catches up.
+func SyncLatestChunks(
+	ctx context.Context,
+	client DataBrokerServiceClient,
+	req *SyncLatestRequest,
+	fn func(serverVersion uint64, records []*Record) error,
+) (recordVersion, serverVersion uint64, err error) {
+	req = proto.Clone(req).(*SyncLatestRequest)
+	if req.ChunkSize == 0 {
+		req.ChunkSize = syncLatestChunkSize
+	}
+
+	for resumes := 0; ; resumes++ {
+		var handlerErr error
+		recordVersion, serverVersion, err = syncLatestChunks(ctx, client, req, func(chunk *RecordChunk) error {
+			handlerErr = fn(chunk.GetServerVersion(), chunk.GetRecords())
+			if handlerErr == nil {
+				req.ResumeToken = chunk.GetResumeToken()
+			}
+			return handlerErr
+		})
+		if err == nil || handlerErr != nil || req.GetResumeToken() == "" || resumes >= maxSyncLatestResumes {
+			return recordVersion, serverVersion, err
+		}
+
+		switch status.Code(err) {
+		case codes.Unavailable, codes.Internal:
+		default:
+			return recordVersion, serverVersion, err
+		}
+		log.Warn(ctx).Err(err).Int("resumes", resumes+1).Msg("databroker: resuming interrupted sync latest")
+	}
+}
+
+func syncLatestChunks(
+	ctx context.Context,
+	client DataBrokerServiceClient,
+	req *SyncLatestRequest,
+	fn func(chunk *RecordChunk) error,
+) (recordVersion, serverVersion uint64, err error) {
+	stream, err := client.SyncLatest(ctx, req)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// servers which don't support chunks send the records one at a time, with the server version last
+	var records []*Record
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, 0, err
+		}
+
+		switch res := res.GetResponse().(type) {
+		case *SyncLatestResponse_Versions:
+			recordVersion = res.Versions.GetLatestRecordVersion()
+			serverVersion = res.Versions.GetServerVersion()
+		case *SyncLatestResponse_Record:
+			records = append(records, res.Record)
+		case *SyncLatestResponse_Chunk:
+			err = fn(res.Chunk)
+			if err != nil {
+				return 0, 0, err
+			}
+		default:
+			panic(fmt.Sprintf("unexpected response: %T", res))
+		}
+	}
+
+	if len(records) > 0 {
+		err = fn(&RecordChunk{Records: records, ServerVersion: serverVersion})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return recordVersion, serverVersion, nil
+}
+
 // GetRecord gets the first record, or nil if there are none.
 func (x *PutRequest) GetRecord() *Record {
 	records := x.GetRecords()
@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -111,6 +114,75 @@ func TestInitialSync(t *testing.T) {
 	assert.Equal(t, []*Record{r1, r2}, records)
 }
 
+func TestSyncLatestChunks(t *testing.T) {
+	ctx, clearTimeout := context.WithTimeout(context.Background(), time.Second*10)
+	defer clearTimeout()
+
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer li.Close()
+
+	r1 := &Record{Id: "r1"}
+	r2 := &Record{Id: "r2"}
+	r3 := &Record{Id: "r3"}
+
+	var calls int
+	m := &mockServer{
+		syncLatest: func(req *SyncLatestRequest, stream DataBrokerService_SyncLatestServer) error {
+			calls++
+			assert.Equal(t, uint32(syncLatestChunkSize), req.GetChunkSize())
+			switch req.GetResumeToken() {
+			case "":
+				_ = stream.Send(&SyncLatestResponse{Response: &SyncLatestResponse_Chunk{Chunk: &RecordChunk{
+					Records:       []*Record{r1, r2},
+					ServerVersion: 1,
+					ResumeToken:   "after-r2",
+				}}})
+				// the stream is interrupted
+				return status.Error(codes.Unavailable, "connection reset")
+			case "after-r2":
+				_ = stream.Send(&SyncLatestResponse{Response: &SyncLatestResponse_Chunk{Chunk: &RecordChunk{
+					Records:       []*Record{r3},
+					ServerVersion: 1,
+					ResumeToken:   "after-r3",
+				}}})
+				_ = stream.Send(&SyncLatestResponse{Response: &SyncLatestResponse_Versions{Versions: &Versions{
+					LatestRecordVersion: 3,
+					ServerVersion:       1,
+				}}})
+				return nil
+			}
+			return status.Error(codes.InvalidArgument, "invalid resume token")
+		},
+	}
+
+	srv := grpc.NewServer()
+	RegisterDataBrokerServiceServer(srv, m)
+	go srv.Serve(li)
+	defer srv.Stop()
+
+	cc, err := grpc.Dial(li.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	var chunks [][]string
+	recordVersion, serverVersion, err := SyncLatestChunks(ctx, NewDataBrokerServiceClient(cc), new(SyncLatestRequest),
+		func(serverVersion uint64, records []*Record) error {
+			assert.Equal(t, uint64(1), serverVersion)
+			var ids []string
+			for _, record := range records {
+				ids = append(ids, record.GetId())
+			}
+			chunks = append(chunks, ids)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, uint64(3), recordVersion)
+	assert.Equal(t, uint64(1), serverVersion)
+	assert.Equal(t, [][]string{{"r1", "r2"}, {"r3"}}, chunks)
+}
+
 func TestOptimumPutRequestsFromRecords(t *testing.T) {
 	var records []*Record
 	for i := 0; i < 10_000; i++ {
@@ -131,6 +203,53 @@ func TestOptimumPutRequestsFromRecords(t *testing.T) {
 	}
 }
 
+func TestUseOnce(t *testing.T) {
+	ctx := context.Background()
+
+	var requests []*RateLimitRequest
+	client := mockClient{
+		rateLimit: func(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error) {
+			requests = append(requests, in)
+			for _, req := range requests[:len(requests)-1] {
+				if req.GetKey() == in.GetKey() {
+					return &RateLimitResponse{Allowed: false}, nil
+				}
+			}
+			return &RateLimitResponse{Allowed: true}, nil
+		},
+	}
+
+	used, err := UseOnce(ctx, client, "KEY", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, used)
+	used, err = UseOnce(ctx, client, "KEY", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, used, "should only use keys once")
+	used, err = UseOnce(ctx, client, "OTHER", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, used)
+
+	assert.Equal(t, uint32(1), requests[0].GetLimit())
+	assert.Equal(t, time.Minute, requests[0].GetWindow().AsDuration())
+
+	client.rateLimit = func(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error) {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	used, err = UseOnce(ctx, client, "NEW", time.Minute)
+	assert.Error(t, err)
+	assert.False(t, used, "should not use keys when the databroker is unavailable")
+}
+
+type mockClient struct {
+	DataBrokerServiceClient
+
+	rateLimit func(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error)
+}
+
+func (m mockClient) RateLimit(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error) {
+	return m.rateLimit(ctx, in, opts...)
+}
+
 type mockServer struct {
 	DataBrokerServiceServer
 
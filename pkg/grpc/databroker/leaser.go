@@ -3,6 +3,8 @@ package databroker
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -26,6 +28,20 @@ func (err retryableError) Is(target error) bool {
 	return false
 }
 
+// leaseHolder identifies this process as the holder of the leases it acquires.
+var leaseHolder = func() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}()
+
+// LeaseHolder returns the holder of the leases acquired by this process, its hostname and pid.
+func LeaseHolder() string {
+	return leaseHolder
+}
+
 // A LeaserHandler is a handler for the locker.
 type LeaserHandler interface {
 	GetDataBrokerServiceClient() DataBrokerServiceClient
@@ -91,6 +107,7 @@ func (locker *Leaser) runOnce(ctx context.Context, resetBackoff func()) error {
 	res, err := locker.handler.GetDataBrokerServiceClient().AcquireLease(ctx, &AcquireLeaseRequest{
 		Name:     locker.leaseName,
 		Duration: durationpb.New(locker.ttl),
+		Holder:   leaseHolder,
 	})
 	// if the lease already exists, retry later
 	if status.Code(err) == codes.AlreadyExists {
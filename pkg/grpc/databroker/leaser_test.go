@@ -3,6 +3,7 @@ package databroker_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -26,10 +27,7 @@ func TestLeaser(t *testing.T) {
 	t.Run("acquires lease", func(t *testing.T) {
 		client := mock_databroker.NewMockDataBrokerServiceClient(ctrl)
 		client.EXPECT().
-			AcquireLease(gomock.Any(), &databroker.AcquireLeaseRequest{
-				Name:     "TEST",
-				Duration: durationpb.New(time.Second * 30),
-			}).
+			AcquireLease(gomock.Any(), acquireLeaseRequest("TEST", time.Second*30)).
 			Return(&databroker.AcquireLeaseResponse{
 				Id: "lease1",
 			}, nil).
@@ -58,17 +56,11 @@ func TestLeaser(t *testing.T) {
 	t.Run("retries acquire", func(t *testing.T) {
 		client := mock_databroker.NewMockDataBrokerServiceClient(ctrl)
 		client.EXPECT().
-			AcquireLease(gomock.Any(), &databroker.AcquireLeaseRequest{
-				Name:     "TEST",
-				Duration: durationpb.New(time.Second * 30),
-			}).
+			AcquireLease(gomock.Any(), acquireLeaseRequest("TEST", time.Second*30)).
 			Return(nil, status.Error(codes.Unavailable, "UNAVAILABLE")).
 			Times(2)
 		client.EXPECT().
-			AcquireLease(gomock.Any(), &databroker.AcquireLeaseRequest{
-				Name:     "TEST",
-				Duration: durationpb.New(time.Second * 30),
-			}).
+			AcquireLease(gomock.Any(), acquireLeaseRequest("TEST", time.Second*30)).
 			Return(&databroker.AcquireLeaseResponse{
 				Id: "lease1",
 			}, nil).
@@ -97,10 +89,7 @@ func TestLeaser(t *testing.T) {
 	t.Run("renews", func(t *testing.T) {
 		client := mock_databroker.NewMockDataBrokerServiceClient(ctrl)
 		client.EXPECT().
-			AcquireLease(gomock.Any(), &databroker.AcquireLeaseRequest{
-				Name:     "TEST",
-				Duration: durationpb.New(time.Millisecond),
-			}).
+			AcquireLease(gomock.Any(), acquireLeaseRequest("TEST", time.Millisecond)).
 			Return(&databroker.AcquireLeaseResponse{
 				Id: "lease1",
 			}, nil).
@@ -146,10 +135,7 @@ func TestLeasers(t *testing.T) {
 
 	client := mock_databroker.NewMockDataBrokerServiceClient(ctrl)
 	client.EXPECT().
-		AcquireLease(gomock.Any(), &databroker.AcquireLeaseRequest{
-			Name:     "TEST",
-			Duration: durationpb.New(time.Second * 30),
-		}).
+		AcquireLease(gomock.Any(), acquireLeaseRequest("TEST", time.Second*30)).
 		Return(&databroker.AcquireLeaseResponse{
 			Id: "lease1",
 		}, nil).
@@ -176,3 +162,22 @@ func TestLeasers(t *testing.T) {
 	assert.Equal(t, exitErr, err)
 	assert.EqualValues(t, 11, counter)
 }
+
+// acquireLeaseRequest matches an AcquireLeaseRequest for the lease, from any holder.
+func acquireLeaseRequest(name string, duration time.Duration) gomock.Matcher {
+	return acquireLeaseRequestMatcher{name, duration}
+}
+
+type acquireLeaseRequestMatcher struct {
+	name     string
+	duration time.Duration
+}
+
+func (m acquireLeaseRequestMatcher) Matches(x interface{}) bool {
+	req, ok := x.(*databroker.AcquireLeaseRequest)
+	return ok && req.GetName() == m.name && req.GetDuration().AsDuration() == m.duration && req.GetHolder() != ""
+}
+
+func (m acquireLeaseRequestMatcher) String() string {
+	return fmt.Sprintf("is an acquire lease request for %s with a duration of %s", m.name, m.duration)
+}
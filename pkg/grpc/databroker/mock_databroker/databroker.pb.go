@@ -113,6 +113,26 @@ func (mr *MockDataBrokerServiceClientMockRecorder) Get(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).Get), varargs...)
 }
 
+// ListLeases mocks base method.
+func (m *MockDataBrokerServiceClient) ListLeases(ctx context.Context, in *databroker.ListLeasesRequest, opts ...grpc.CallOption) (*databroker.ListLeasesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListLeases", varargs...)
+	ret0, _ := ret[0].(*databroker.ListLeasesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLeases indicates an expected call of ListLeases.
+func (mr *MockDataBrokerServiceClientMockRecorder) ListLeases(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLeases", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).ListLeases), varargs...)
+}
+
 // Put mocks base method.
 func (m *MockDataBrokerServiceClient) Put(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +173,26 @@ func (mr *MockDataBrokerServiceClientMockRecorder) Query(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).Query), varargs...)
 }
 
+// RateLimit mocks base method.
+func (m *MockDataBrokerServiceClient) RateLimit(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RateLimit", varargs...)
+	ret0, _ := ret[0].(*databroker.RateLimitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockDataBrokerServiceClientMockRecorder) RateLimit(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockDataBrokerServiceClient)(nil).RateLimit), varargs...)
+}
+
 // ReleaseLease mocks base method.
 func (m *MockDataBrokerServiceClient) ReleaseLease(ctx context.Context, in *databroker.ReleaseLeaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -552,6 +592,21 @@ func (mr *MockDataBrokerServiceServerMockRecorder) Get(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).Get), arg0, arg1)
 }
 
+// ListLeases mocks base method.
+func (m *MockDataBrokerServiceServer) ListLeases(arg0 context.Context, arg1 *databroker.ListLeasesRequest) (*databroker.ListLeasesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLeases", arg0, arg1)
+	ret0, _ := ret[0].(*databroker.ListLeasesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLeases indicates an expected call of ListLeases.
+func (mr *MockDataBrokerServiceServerMockRecorder) ListLeases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLeases", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).ListLeases), arg0, arg1)
+}
+
 // Put mocks base method.
 func (m *MockDataBrokerServiceServer) Put(arg0 context.Context, arg1 *databroker.PutRequest) (*databroker.PutResponse, error) {
 	m.ctrl.T.Helper()
@@ -582,6 +637,21 @@ func (mr *MockDataBrokerServiceServerMockRecorder) Query(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).Query), arg0, arg1)
 }
 
+// RateLimit mocks base method.
+func (m *MockDataBrokerServiceServer) RateLimit(arg0 context.Context, arg1 *databroker.RateLimitRequest) (*databroker.RateLimitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimit", arg0, arg1)
+	ret0, _ := ret[0].(*databroker.RateLimitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateLimit indicates an expected call of RateLimit.
+func (mr *MockDataBrokerServiceServerMockRecorder) RateLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimit", reflect.TypeOf((*MockDataBrokerServiceServer)(nil).RateLimit), arg0, arg1)
+}
+
 // ReleaseLease mocks base method.
 func (m *MockDataBrokerServiceServer) ReleaseLease(arg0 context.Context, arg1 *databroker.ReleaseLeaseRequest) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -3,17 +3,24 @@ package databroker
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/pomerium/pomerium/internal/contextkeys"
 	"github.com/pomerium/pomerium/internal/log"
 )
 
+// SyncerIDMetadataKey is the gRPC metadata key syncers send their id with, so the databroker
+// can tell the clients syncing changes apart.
+const SyncerIDMetadataKey = "x-pomerium-syncer-id"
+
 type syncerConfig struct {
 	typeURL         string
 	withFastForward bool
@@ -69,6 +76,40 @@ type Syncer struct {
 	closeCtxCancel func()
 
 	id string
+
+	statusMu sync.Mutex
+	status   SyncerStatus
+}
+
+// SyncerStatus is the status of a Syncer.
+type SyncerStatus struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type,omitempty"`
+	ServerVersion uint64    `json:"server_version"`
+	RecordVersion uint64    `json:"record_version"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+var runningSyncers = struct {
+	sync.Mutex
+	m map[*Syncer]struct{}
+}{m: map[*Syncer]struct{}{}}
+
+// GetSyncerStatuses returns the status of the running syncers of the process.
+func GetSyncerStatuses() []SyncerStatus {
+	runningSyncers.Lock()
+	statuses := make([]SyncerStatus, 0, len(runningSyncers.m))
+	for syncer := range runningSyncers.m {
+		syncer.statusMu.Lock()
+		statuses = append(statuses, syncer.status)
+		syncer.statusMu.Unlock()
+	}
+	runningSyncers.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses
 }
 
 // NewSyncer creates a new Syncer.
@@ -87,6 +128,7 @@ func NewSyncer(id string, handler SyncerHandler, options ...SyncerOption) *Synce
 
 		id: id,
 	}
+	s.status = SyncerStatus{ID: id, Type: s.cfg.typeURL}
 	if s.cfg.withFastForward {
 		s.handler = newFastForwardHandler(s.logCtx(closeCtx), handler)
 	}
@@ -107,6 +149,15 @@ func (syncer *Syncer) Run(ctx context.Context) error {
 		cancel()
 	}()
 
+	runningSyncers.Lock()
+	runningSyncers.m[syncer] = struct{}{}
+	runningSyncers.Unlock()
+	defer func() {
+		runningSyncers.Lock()
+		delete(runningSyncers.m, syncer)
+		runningSyncers.Unlock()
+	}()
+
 	ctx = syncer.logCtx(ctx)
 	for {
 		var err error
@@ -129,8 +180,18 @@ func (syncer *Syncer) Run(ctx context.Context) error {
 
 func (syncer *Syncer) init(ctx context.Context) error {
 	log.Info(ctx).Msg("initial sync")
-	records, recordVersion, serverVersion, err := InitialSync(ctx, syncer.handler.GetDataBrokerServiceClient(), &SyncLatestRequest{
+	// the records are updated in chunks, so they don't all have to be held in memory
+	cleared := false
+	recordVersion, serverVersion, err := SyncLatestChunks(ctx, syncer.handler.GetDataBrokerServiceClient(), &SyncLatestRequest{
 		Type: syncer.cfg.typeURL,
+	}, func(serverVersion uint64, records []*Record) error {
+		if !cleared {
+			// reset the records as we have to sync latest
+			syncer.handler.ClearRecords(ctx)
+			cleared = true
+		}
+		syncer.handler.UpdateRecords(ctx, serverVersion, records)
+		return nil
 	})
 	if err != nil {
 		log.Error(ctx).Err(err).Msg("error during initial sync")
@@ -138,17 +199,20 @@ func (syncer *Syncer) init(ctx context.Context) error {
 	}
 	syncer.backoff.Reset()
 
-	// reset the records as we have to sync latest
-	syncer.handler.ClearRecords(ctx)
+	if !cleared {
+		syncer.handler.ClearRecords(ctx)
+		syncer.handler.UpdateRecords(ctx, serverVersion, nil)
+	}
 
 	syncer.recordVersion = recordVersion
 	syncer.serverVersion = serverVersion
-	syncer.handler.UpdateRecords(ctx, serverVersion, records)
+	syncer.updateStatus()
 
 	return nil
 }
 
 func (syncer *Syncer) sync(ctx context.Context) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, SyncerIDMetadataKey, syncer.id)
 	stream, err := syncer.handler.GetDataBrokerServiceClient().Sync(ctx, &SyncRequest{
 		ServerVersion: syncer.serverVersion,
 		RecordVersion: syncer.recordVersion,
@@ -187,9 +251,18 @@ func (syncer *Syncer) sync(ctx context.Context) error {
 				context.WithValue(ctx, contextkeys.UpdateRecordsVersion, rec.GetVersion()),
 				syncer.serverVersion, []*Record{rec})
 		}
+		syncer.updateStatus()
 	}
 }
 
+func (syncer *Syncer) updateStatus() {
+	syncer.statusMu.Lock()
+	syncer.status.ServerVersion = syncer.serverVersion
+	syncer.status.RecordVersion = syncer.recordVersion
+	syncer.status.UpdatedAt = time.Now()
+	syncer.statusMu.Unlock()
+}
+
 // logCtxRecRec adds log params to context related to particular record
 func logCtxRec(ctx context.Context, rec *Record) context.Context {
 	return log.WithContext(ctx, func(c zerolog.Context) zerolog.Context {
@@ -27,4 +27,6 @@ type Options struct {
 	ClientID       string
 	ClientSecret   string
 	QPS            float64
+	// SCIMEnabled disables the directory provider, since users and groups are provisioned using SCIM.
+	SCIMEnabled bool
 }
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.14.0
+// source: signing_key.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SigningKeyEvent_EventKind int32
+
+const (
+	SigningKeyEvent_EVENT_KIND_UNDEFINED SigningKeyEvent_EventKind = 0
+	// a new key was created, and is published in the JWKS
+	SigningKeyEvent_EVENT_KIND_CREATED SigningKeyEvent_EventKind = 1
+	// the key was replaced by a newer key, and is published until the overlap passes
+	SigningKeyEvent_EVENT_KIND_ROTATED SigningKeyEvent_EventKind = 2
+	// the key was deleted, once the overlap passed
+	SigningKeyEvent_EVENT_KIND_DELETED SigningKeyEvent_EventKind = 3
+)
+
+// Enum value maps for SigningKeyEvent_EventKind.
+var (
+	SigningKeyEvent_EventKind_name = map[int32]string{
+		0: "EVENT_KIND_UNDEFINED",
+		1: "EVENT_KIND_CREATED",
+		2: "EVENT_KIND_ROTATED",
+		3: "EVENT_KIND_DELETED",
+	}
+	SigningKeyEvent_EventKind_value = map[string]int32{
+		"EVENT_KIND_UNDEFINED": 0,
+		"EVENT_KIND_CREATED":   1,
+		"EVENT_KIND_ROTATED":   2,
+		"EVENT_KIND_DELETED":   3,
+	}
+)
+
+func (x SigningKeyEvent_EventKind) Enum() *SigningKeyEvent_EventKind {
+	p := new(SigningKeyEvent_EventKind)
+	*p = x
+	return p
+}
+
+func (x SigningKeyEvent_EventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SigningKeyEvent_EventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_signing_key_proto_enumTypes[0].Descriptor()
+}
+
+func (SigningKeyEvent_EventKind) Type() protoreflect.EnumType {
+	return &file_signing_key_proto_enumTypes[0]
+}
+
+func (x SigningKeyEvent_EventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SigningKeyEvent_EventKind.Descriptor instead.
+func (SigningKeyEvent_EventKind) EnumDescriptor() ([]byte, []int) {
+	return file_signing_key_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// SigningKeyEvent is a change to the signing keys, when they are rotated automatically.
+type SigningKeyEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// the JWK key id of the signing key
+	KeyId     string                    `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Algorithm string                    `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Kind      SigningKeyEvent_EventKind `protobuf:"varint,5,opt,name=kind,proto3,enum=pomerium.events.SigningKeyEvent_EventKind" json:"kind,omitempty"`
+	// when the key is first used to sign
+	ActivatesAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=activates_at,json=activatesAt,proto3" json:"activates_at,omitempty"`
+}
+
+func (x *SigningKeyEvent) Reset() {
+	*x = SigningKeyEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_signing_key_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SigningKeyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningKeyEvent) ProtoMessage() {}
+
+func (x *SigningKeyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_key_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningKeyEvent.ProtoReflect.Descriptor instead.
+func (*SigningKeyEvent) Descriptor() ([]byte, []int) {
+	return file_signing_key_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SigningKeyEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SigningKeyEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SigningKeyEvent) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *SigningKeyEvent) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *SigningKeyEvent) GetKind() SigningKeyEvent_EventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SigningKeyEvent_EVENT_KIND_UNDEFINED
+}
+
+func (x *SigningKeyEvent) GetActivatesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivatesAt
+	}
+	return nil
+}
+
+var File_signing_key_proto protoreflect.FileDescriptor
+
+var file_signing_key_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x70, 0x6f, 0x6d, 0x65, 0x72, 0x69, 0x75, 0x6d, 0x2e, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfe, 0x02, 0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e,
+	0x67, 0x4b, 0x65, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x3e, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70, 0x6f, 0x6d, 0x65, 0x72, 0x69, 0x75,
+	0x6d, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67,
+	0x4b, 0x65, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x73, 0x41, 0x74, 0x22, 0x6d, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x4b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49,
+	0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16,
+	0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x43, 0x52, 0x45,
+	0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x52, 0x4f, 0x54, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x16,
+	0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x4c,
+	0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x6f, 0x6d, 0x65, 0x72, 0x69, 0x75, 0x6d, 0x2f, 0x70, 0x6f,
+	0x6d, 0x65, 0x72, 0x69, 0x75, 0x6d, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_signing_key_proto_rawDescOnce sync.Once
+	file_signing_key_proto_rawDescData = file_signing_key_proto_rawDesc
+)
+
+func file_signing_key_proto_rawDescGZIP() []byte {
+	file_signing_key_proto_rawDescOnce.Do(func() {
+		file_signing_key_proto_rawDescData = protoimpl.X.CompressGZIP(file_signing_key_proto_rawDescData)
+	})
+	return file_signing_key_proto_rawDescData
+}
+
+var file_signing_key_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_signing_key_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_signing_key_proto_goTypes = []interface{}{
+	(SigningKeyEvent_EventKind)(0), // 0: pomerium.events.SigningKeyEvent.EventKind
+	(*SigningKeyEvent)(nil),        // 1: pomerium.events.SigningKeyEvent
+	(*timestamppb.Timestamp)(nil),  // 2: google.protobuf.Timestamp
+}
+var file_signing_key_proto_depIdxs = []int32{
+	2, // 0: pomerium.events.SigningKeyEvent.time:type_name -> google.protobuf.Timestamp
+	0, // 1: pomerium.events.SigningKeyEvent.kind:type_name -> pomerium.events.SigningKeyEvent.EventKind
+	2, // 2: pomerium.events.SigningKeyEvent.activates_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_signing_key_proto_init() }
+func file_signing_key_proto_init() {
+	if File_signing_key_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_signing_key_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SigningKeyEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_signing_key_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_signing_key_proto_goTypes,
+		DependencyIndexes: file_signing_key_proto_depIdxs,
+		EnumInfos:         file_signing_key_proto_enumTypes,
+		MessageInfos:      file_signing_key_proto_msgTypes,
+	}.Build()
+	File_signing_key_proto = out.File
+	file_signing_key_proto_rawDesc = nil
+	file_signing_key_proto_goTypes = nil
+	file_signing_key_proto_depIdxs = nil
+}
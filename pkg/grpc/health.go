@@ -3,9 +3,7 @@ package grpc
 import (
 	"context"
 
-	"google.golang.org/grpc/codes"
 	grpc_health "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/status"
 
 	"github.com/pomerium/pomerium/internal/log"
 )
@@ -27,8 +25,16 @@ func (h *healthCheckSrv) Check(ctx context.Context, req *grpc_health.HealthCheck
 	}, nil
 }
 
-// Watch is not implemented as is not used by Envoy
-func (h *healthCheckSrv) Watch(req *grpc_health.HealthCheckRequest, _ grpc_health.Health_WatchServer) error {
-	log.Error(context.Background()).Str("service", req.Service).Msg("health check watch")
-	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+// Watch reports the service is serving until the stream is closed. It is used by the client
+// side health checking of gRPC connection pools.
+func (h *healthCheckSrv) Watch(req *grpc_health.HealthCheckRequest, srv grpc_health.Health_WatchServer) error {
+	log.Debug(srv.Context()).Str("service", req.Service).Msg("health check watch")
+	err := srv.Send(&grpc_health.HealthCheckResponse{
+		Status: grpc_health.HealthCheckResponse_SERVING,
+	})
+	if err != nil {
+		return err
+	}
+	<-srv.Context().Done()
+	return nil
 }
@@ -0,0 +1,138 @@
+// Package ipset contains protobuf types for IP sets.
+package ipset
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// Delete deletes an IP set from the databroker.
+func Delete(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	id string,
+) (*IPSet, error) {
+	ipSet, err := Get(ctx, client, id)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	any := protoutil.NewAny(ipSet)
+	_, err = client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Type:      any.GetTypeUrl(),
+			Id:        id,
+			Data:      any,
+			DeletedAt: timestamppb.Now(),
+		}},
+	})
+	return ipSet, err
+}
+
+// Get gets an IP set from the databroker.
+func Get(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	id string,
+) (*IPSet, error) {
+	any := protoutil.NewAny(new(IPSet))
+
+	res, err := client.Get(ctx, &databroker.GetRequest{
+		Type: any.GetTypeUrl(),
+		Id:   id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var obj IPSet
+	err = res.GetRecord().GetData().UnmarshalTo(&obj)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling ip set from databroker: %w", err)
+	}
+
+	return &obj, nil
+}
+
+// List lists all the IP sets in the databroker.
+func List(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+) ([]*IPSet, error) {
+	records, _, _, err := databroker.InitialSync(ctx, client, &databroker.SyncLatestRequest{
+		Type: protoutil.GetTypeURL(new(IPSet)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ipSets := make([]*IPSet, 0, len(records))
+	for _, record := range records {
+		var obj IPSet
+		if err := record.GetData().UnmarshalTo(&obj); err != nil {
+			return nil, fmt.Errorf("error unmarshaling ip set from databroker: %w", err)
+		}
+		ipSets = append(ipSets, &obj)
+	}
+	return ipSets, nil
+}
+
+// Put puts an IP set in the databroker. The CIDRs are validated and normalized, so that single IP addresses
+// are stored as a /32 or /128 network.
+func Put(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	ipSet *IPSet,
+) error {
+	if err := ipSet.Normalize(); err != nil {
+		return err
+	}
+	ipSet.ModifiedAt = timestamppb.Now()
+
+	any := protoutil.NewAny(ipSet)
+	_, err := client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Type: any.GetTypeUrl(),
+			Id:   ipSet.GetId(),
+			Data: any,
+		}},
+	})
+	return err
+}
+
+// Normalize validates the IP set and converts its CIDRs to their canonical form.
+func (x *IPSet) Normalize() error {
+	if x.GetId() == "" {
+		return fmt.Errorf("ipset: id is required")
+	}
+
+	cidrs := make([]string, 0, len(x.GetCidrs()))
+	for _, cidr := range x.GetCidrs() {
+		if ip := net.ParseIP(cidr); ip != nil {
+			bits := 128
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			cidrs = append(cidrs, (&net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}).String())
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("ipset: invalid cidr %q: %w", cidr, err)
+		}
+		cidrs = append(cidrs, ipNet.String())
+	}
+	x.Cidrs = cidrs
+	return nil
+}
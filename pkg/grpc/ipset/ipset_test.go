@@ -0,0 +1,16 @@
+package ipset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPSet_Normalize(t *testing.T) {
+	x := &IPSet{Id: "office", Cidrs: []string{"10.1.2.3/8", "192.168.1.1", "2001:db8::1"}}
+	assert.NoError(t, x.Normalize())
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1/32", "2001:db8::1/128"}, x.GetCidrs())
+
+	assert.Error(t, (&IPSet{Cidrs: []string{"10.0.0.0/8"}}).Normalize())
+	assert.Error(t, (&IPSet{Id: "office", Cidrs: []string{"not-an-ip"}}).Normalize())
+}
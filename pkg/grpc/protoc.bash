@@ -76,6 +76,10 @@ _import_paths=$(join_by , "${_imports[@]}")
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./audit/." \
   ./audit/audit.proto
 
+../../scripts/protoc -I ./authorize/ \
+  --go_out="$_import_paths,plugins=grpc,paths=source_relative:./authorize/." \
+  ./authorize/authorize.proto
+
 ../../scripts/protoc -I ./crypt/ \
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./crypt/." \
   ./crypt/crypt.proto
@@ -101,6 +105,18 @@ _import_paths=$(join_by , "${_imports[@]}")
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./identity/." \
   ./identity/identity.proto
 
+../../scripts/protoc -I ./ipset/ \
+  --go_out="$_import_paths,plugins=grpc,paths=source_relative:./ipset/." \
+  ./ipset/ipset.proto
+
+../../scripts/protoc -I ./otlp/ \
+  --go_out="$_import_paths,plugins=grpc,paths=source_relative:./otlp/." \
+  ./otlp/otlp.proto ./otlp/otlp_metrics.proto
+
+../../scripts/protoc -I ./scim/ \
+  --go_out="$_import_paths,plugins=grpc,paths=source_relative:./scim/." \
+  ./scim/scim.proto
+
 ../../scripts/protoc -I ./registry/ \
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./registry/." \
   --validate_out="lang=go,paths=source_relative:./registry" \
@@ -116,7 +132,7 @@ _import_paths=$(join_by , "${_imports[@]}")
 
 ../../scripts/protoc -I ./events/ -I ./ \
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./events/." \
-  ./events/xds.proto ./events/last_error.proto
+  ./events/xds.proto ./events/last_error.proto ./events/audit_event.proto ./events/signing_key.proto
 
 ../../scripts/protoc -I ./cli/ -I ./ \
   --go_out="$_import_paths,plugins=grpc,paths=source_relative:./cli/." \
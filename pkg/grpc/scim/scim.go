@@ -0,0 +1,78 @@
+// Package scim contains protobuf types for users and groups provisioned using SCIM.
+package scim
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/grpcutil"
+)
+
+// GetUser gets a SCIM user from the databroker.
+func GetUser(ctx context.Context, client databroker.DataBrokerServiceClient, userID string) (*User, error) {
+	u := User{Id: userID}
+	return &u, databroker.Get(ctx, client, &u)
+}
+
+// GetGroup gets a SCIM group from the databroker.
+func GetGroup(ctx context.Context, client databroker.DataBrokerServiceClient, groupID string) (*Group, error) {
+	g := Group{Id: groupID}
+	return &g, databroker.Get(ctx, client, &g)
+}
+
+// ListUsers lists all the SCIM users in the databroker.
+func ListUsers(ctx context.Context, client databroker.DataBrokerServiceClient) ([]*User, error) {
+	var users []*User
+	err := list(ctx, client, new(User), func(msg proto.Message) {
+		users = append(users, msg.(*User))
+	})
+	return users, err
+}
+
+// ListGroups lists all the SCIM groups in the databroker.
+func ListGroups(ctx context.Context, client databroker.DataBrokerServiceClient) ([]*Group, error) {
+	var groups []*Group
+	err := list(ctx, client, new(Group), func(msg proto.Message) {
+		groups = append(groups, msg.(*Group))
+	})
+	return groups, err
+}
+
+type recordObject interface {
+	proto.Message
+	GetId() string
+}
+
+// Delete deletes SCIM users, SCIM groups or any other objects from the databroker.
+func Delete(ctx context.Context, client databroker.DataBrokerServiceClient, objects ...recordObject) error {
+	records := make([]*databroker.Record, len(objects))
+	for i, object := range objects {
+		records[i] = databroker.NewRecord(object)
+		records[i].DeletedAt = timestamppb.Now()
+	}
+	_, err := client.Put(ctx, &databroker.PutRequest{Records: records})
+	return err
+}
+
+func list(ctx context.Context, client databroker.DataBrokerServiceClient, msg proto.Message, add func(proto.Message)) error {
+	records, _, _, err := databroker.InitialSync(ctx, client, &databroker.SyncLatestRequest{
+		Type: grpcutil.GetTypeURL(msg),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		obj := proto.Clone(msg)
+		proto.Reset(obj)
+		if err := record.GetData().UnmarshalTo(obj); err != nil {
+			return fmt.Errorf("error unmarshaling %s from databroker: %w", record.GetType(), err)
+		}
+		add(obj)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+// GetDeviceAuthorization gets a device authorization from the databroker.
+func GetDeviceAuthorization(ctx context.Context, client databroker.DataBrokerServiceClient, userCode string) (*DeviceAuthorization, error) {
+	da := DeviceAuthorization{Id: userCode}
+	return &da, databroker.Get(ctx, client, &da)
+}
+
+// PutDeviceAuthorization sets a device authorization in the databroker.
+func PutDeviceAuthorization(ctx context.Context, client databroker.DataBrokerServiceClient, da *DeviceAuthorization) (*databroker.PutResponse, error) {
+	return databroker.Put(ctx, client, da)
+}
+
+// DeleteDeviceAuthorization deletes a device authorization from the databroker.
+func DeleteDeviceAuthorization(ctx context.Context, client databroker.DataBrokerServiceClient, userCode string) error {
+	record := databroker.NewRecord(&DeviceAuthorization{Id: userCode})
+	record.DeletedAt = timestamppb.Now()
+	_, err := client.Put(ctx, &databroker.PutRequest{Records: []*databroker.Record{record}})
+	return err
+}
+
+// IsExpired returns true if the device authorization has expired.
+func (x *DeviceAuthorization) IsExpired(now time.Time) bool {
+	return !now.Before(x.GetExpiresAt().AsTime())
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	context "context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+// GetImpersonation gets an impersonation from the databroker.
+func GetImpersonation(ctx context.Context, client databroker.DataBrokerServiceClient, sessionID string) (*Impersonation, error) {
+	any := protoutil.NewAny(new(Impersonation))
+	res, err := client.Get(ctx, &databroker.GetRequest{
+		Type: any.GetTypeUrl(),
+		Id:   sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var impersonation Impersonation
+	err = res.GetRecord().GetData().UnmarshalTo(&impersonation)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling impersonation from databroker: %w", err)
+	}
+	return &impersonation, nil
+}
+
+// PutImpersonation sets an impersonation in the databroker.
+func PutImpersonation(ctx context.Context, client databroker.DataBrokerServiceClient, impersonation *Impersonation) (*databroker.PutResponse, error) {
+	impersonation = proto.Clone(impersonation).(*Impersonation)
+	any := protoutil.NewAny(impersonation)
+	res, err := client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Type: any.GetTypeUrl(),
+			Id:   impersonation.Id,
+			Data: any,
+		}},
+	})
+	return res, err
+}
+
+// IsActive returns true if the impersonation has been approved and has not yet expired.
+func (x *Impersonation) IsActive(now time.Time) bool {
+	if x.GetApprovedAt() == nil || x.GetExpiresAt() == nil {
+		return false
+	}
+	return now.Before(x.GetExpiresAt().AsTime())
+}
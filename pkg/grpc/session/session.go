@@ -3,13 +3,18 @@ package session
 
 import (
 	context "context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/internal/identity"
+	"github.com/pomerium/pomerium/pkg/cryptutil"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/protoutil"
 )
@@ -61,6 +66,79 @@ func Put(ctx context.Context, client databroker.DataBrokerServiceClient, s *Sess
 	return res, err
 }
 
+// CompareAndPut sets a session in the databroker if the stored session's record version matches
+// the given version. If the session was changed, an error with a FailedPrecondition code is returned.
+func CompareAndPut(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	s *Session,
+	recordVersion uint64,
+) (*databroker.PutResponse, error) {
+	s = proto.Clone(s).(*Session)
+	any := protoutil.NewAny(s)
+	res, err := client.Put(ctx, &databroker.PutRequest{
+		Records: []*databroker.Record{{
+			Version: recordVersion,
+			Type:    any.GetTypeUrl(),
+			Id:      s.Id,
+			Data:    any,
+		}},
+		CompareVersions: true,
+	})
+	return res, err
+}
+
+// maxUpdateAttempts is how many times Update writes a session which is changed concurrently.
+const maxUpdateAttempts = 5
+
+// Update gets a session from the databroker, changes it with the update function, and writes it
+// back with CompareAndPut, so the changes of concurrent writers aren't overwritten. When the
+// session was changed concurrently, the update is applied to the latest session again. If the
+// update function returns an error, the session isn't written and the error is returned.
+func Update(
+	ctx context.Context,
+	client databroker.DataBrokerServiceClient,
+	sessionID string,
+	update func(s *Session) error,
+) (*databroker.PutResponse, error) {
+	for attempt := 1; ; attempt++ {
+		res, err := client.Get(ctx, &databroker.GetRequest{
+			Type: protoutil.GetTypeURL(new(Session)),
+			Id:   sessionID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var s Session
+		err = res.GetRecord().GetData().UnmarshalTo(&s)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshaling session from databroker: %w", err)
+		}
+		err = update(&s)
+		if err != nil {
+			return nil, err
+		}
+
+		putRes, err := CompareAndPut(ctx, client, &s, res.GetRecord().GetVersion())
+		if status.Code(err) == codes.FailedPrecondition && attempt < maxUpdateAttempts {
+			continue
+		}
+		return putRes, err
+	}
+}
+
+// GetClientCertificateFingerprint returns the fingerprint sessions are bound to for the PEM
+// encoded client certificate, which is the sha256 hash of its public key. The public key is used
+// so the binding survives the certificate being renewed with the same key.
+func GetClientCertificateFingerprint(rawClientCertificate string) (string, error) {
+	cert, err := cryptutil.ParsePEMCertificate([]byte(rawClientCertificate))
+	if err != nil {
+		return "", fmt.Errorf("error parsing client certificate: %w", err)
+	}
+	h := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(h[:]), nil
+}
+
 // AddClaims adds the flattened claims to the session.
 func (x *Session) AddClaims(claims identity.FlattenedClaims) {
 	if x.Claims == nil {
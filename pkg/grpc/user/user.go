@@ -3,6 +3,7 @@ package user
 
 import (
 	context "context"
+	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -27,6 +28,25 @@ func PutServiceAccount(ctx context.Context, client databroker.DataBrokerServiceC
 	return databroker.Put(ctx, client, serviceAccount)
 }
 
+// IsExpired returns true if the service account has expired. Service accounts without an
+// expiry never expire.
+func (x *ServiceAccount) IsExpired(now time.Time) bool {
+	return x.GetExpiresAt().IsValid() && !now.Before(x.GetExpiresAt().AsTime())
+}
+
+// IsAudienceAllowed returns true if the service account may be used for the given host.
+func (x *ServiceAccount) IsAudienceAllowed(host string) bool {
+	if len(x.GetAudiences()) == 0 {
+		return true
+	}
+	for _, audience := range x.GetAudiences() {
+		if audience == host {
+			return true
+		}
+	}
+	return false
+}
+
 // AddClaims adds the flattened claims to the user.
 func (x *User) AddClaims(claims identity.FlattenedClaims) {
 	if x.Claims == nil {
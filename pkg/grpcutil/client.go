@@ -48,19 +48,33 @@ type Options struct {
 
 // NewGRPCClientConn returns a new gRPC pomerium service client connection.
 func NewGRPCClientConn(ctx context.Context, opts *Options, other ...grpc.DialOption) (*grpc.ClientConn, error) {
-	hostport := opts.Address.Host
+	dialOptions, err := getDialOptions(opts, opts.Address.Scheme, nil)
+	if err != nil {
+		return nil, err
+	}
+	dialOptions = append(dialOptions, other...)
+	return grpc.DialContext(ctx, getHostPort(opts.Address), dialOptions...)
+}
+
+// getHostPort returns the host and port of an address, with the default port of the scheme
+// when the address has none.
+func getHostPort(u *url.URL) string {
+	hostport := u.Host
 	// no colon exists in the connection string, assume one must be added manually
 	if _, _, err := net.SplitHostPort(hostport); err != nil {
-		if opts.Address.Scheme == "https" {
+		if u.Scheme == "https" {
 			hostport = net.JoinHostPort(hostport, strconv.Itoa(defaultGRPCSecurePort))
 		} else {
 			hostport = net.JoinHostPort(hostport, strconv.Itoa(defaultGRPCInsecurePort))
 		}
 	}
+	return hostport
+}
 
-	unaryClientInterceptors := []grpc.UnaryClientInterceptor{
+func getDialOptions(opts *Options, scheme string, unaryClientInterceptors []grpc.UnaryClientInterceptor) ([]grpc.DialOption, error) {
+	unaryClientInterceptors = append([]grpc.UnaryClientInterceptor{
 		grpcTimeoutInterceptor(opts.RequestTimeout),
-	}
+	}, unaryClientInterceptors...)
 	streamClientInterceptors := []grpc.StreamClientInterceptor{}
 	if opts.SignedJWTKey != nil {
 		unaryClientInterceptors = append(unaryClientInterceptors, WithUnarySignedJWT(opts.SignedJWTKey))
@@ -74,9 +88,7 @@ func NewGRPCClientConn(ctx context.Context, opts *Options, other ...grpc.DialOpt
 		grpc.WithDisableServiceConfig(),
 	}
 
-	dialOptions = append(dialOptions, other...)
-
-	if opts.Address.Scheme == "http" {
+	if scheme == "http" {
 		dialOptions = append(dialOptions, grpc.WithInsecure())
 	} else {
 		rootCAs, err := cryptutil.GetCertPool(opts.CA, opts.CAFile)
@@ -101,8 +113,7 @@ func NewGRPCClientConn(ctx context.Context, opts *Options, other ...grpc.DialOpt
 		// finally add our credential
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(cert))
 	}
-
-	return grpc.DialContext(ctx, hostport, dialOptions...)
+	return dialOptions, nil
 }
 
 // grpcTimeoutInterceptor enforces per-RPC request timeouts
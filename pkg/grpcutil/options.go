@@ -84,30 +84,43 @@ func StreamRequireSignedJWT(key string) grpc.StreamServerInterceptor {
 	}
 }
 
-// RequireSignedJWT requires a JWT in the gRPC metadata and that it be signed by the given key.
-func RequireSignedJWT(ctx context.Context, key []byte) error {
-	if len(key) > 0 {
-		rawjwt, ok := JWTFromGRPCRequest(ctx)
-		if !ok {
-			return status.Error(codes.Unauthenticated, "unauthenticated")
+// RequireSignedJWT requires a JWT in the gRPC metadata and that it be signed by one of the
+// given keys. Empty keys are ignored, and when all the keys are empty no JWT is required.
+func RequireSignedJWT(ctx context.Context, keys ...[]byte) error {
+	var nonEmptyKeys [][]byte
+	for _, key := range keys {
+		if len(key) > 0 {
+			nonEmptyKeys = append(nonEmptyKeys, key)
 		}
+	}
+	if len(nonEmptyKeys) == 0 {
+		return nil
+	}
 
-		tok, err := jwt.ParseSigned(rawjwt)
-		if err != nil {
-			return status.Errorf(codes.Unauthenticated, "invalid JWT: %v", err)
-		}
+	rawjwt, ok := JWTFromGRPCRequest(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
 
-		var claims struct {
-			Expiry *jwt.NumericDate `json:"exp,omitempty"`
-		}
-		err = tok.Claims(key, &claims)
-		if err != nil {
-			return status.Errorf(codes.Unauthenticated, "invalid JWT: %v", err)
-		}
+	tok, err := jwt.ParseSigned(rawjwt)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "invalid JWT: %v", err)
+	}
 
-		if claims.Expiry == nil || time.Now().After(claims.Expiry.Time()) {
-			return status.Errorf(codes.Unauthenticated, "expired JWT: %v", err)
+	var claims struct {
+		Expiry *jwt.NumericDate `json:"exp,omitempty"`
+	}
+	for _, key := range nonEmptyKeys {
+		if err = tok.Claims(key, &claims); err == nil {
+			break
 		}
 	}
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "invalid JWT: %v", err)
+	}
+
+	if claims.Expiry == nil || time.Now().After(claims.Expiry.Time()) {
+		return status.Errorf(codes.Unauthenticated, "expired JWT: %v", err)
+	}
 	return nil
 }
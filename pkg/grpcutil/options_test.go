@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
@@ -100,3 +101,22 @@ func TestSignedJWT(t *testing.T) {
 		assert.Equal(t, codes.OK, status.Code(err))
 	})
 }
+
+func TestRequireSignedJWT(t *testing.T) {
+	current, previous, other := cryptutil.NewKey(), cryptutil.NewKey(), cryptutil.NewKey()
+	signedBy := func(key []byte) context.Context {
+		ctx, err := withSignedJWT(context.Background(), key)
+		if !assert.NoError(t, err) {
+			return ctx
+		}
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return metadata.NewIncomingContext(ctx, md)
+	}
+
+	assert.NoError(t, RequireSignedJWT(signedBy(current), current, previous))
+	assert.NoError(t, RequireSignedJWT(signedBy(previous), current, previous))
+	assert.Equal(t, codes.Unauthenticated, status.Code(RequireSignedJWT(signedBy(other), current, previous)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(RequireSignedJWT(context.Background(), nil, previous)),
+		"should require a JWT when any key is set")
+	assert.NoError(t, RequireSignedJWT(context.Background(), nil, []byte{}))
+}
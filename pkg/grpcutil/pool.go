@@ -0,0 +1,225 @@
+package grpcutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/health" // enables client side health checking
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/protobuf/proto"
+)
+
+// load balancing policies of a pool
+const (
+	// RoundRobin spreads calls across all the healthy addresses.
+	RoundRobin = "round_robin"
+	// PickFirst sends calls to the first healthy address, and fails over to the next one.
+	PickFirst = "pick_first"
+)
+
+const (
+	defaultPoolMaxAttempts  = 3
+	defaultPoolHedgingDelay = 50 * time.Millisecond
+)
+
+var poolID uint64
+
+// PoolOptions contains options for connecting to several instances of a pomerium rpc service.
+type PoolOptions struct {
+	// Options are the connection options. The address is ignored, in favor of Addresses.
+	Options
+	// Addresses are the locations of the instances of the service. They must all use the same
+	// scheme.
+	Addresses []*url.URL
+	// LoadBalancingPolicy is how calls are spread across the addresses, RoundRobin by default.
+	LoadBalancingPolicy string
+	// HealthCheckServiceName is the name of the service checked with the gRPC health service.
+	// Connections to unhealthy instances aren't used. When empty, health isn't checked.
+	HealthCheckServiceName string
+	// RetriedServices are the full names of the services whose calls are retried when an
+	// instance is unavailable.
+	RetriedServices []string
+	// MaxAttempts is the maximum number of attempts of a retried call, 3 by default.
+	MaxAttempts int
+	// HedgedMethods are the full names of read methods, like
+	// /databroker.DataBrokerService/Get, whose calls are sent again to another instance when
+	// there is no response after HedgingDelay. The first response is used.
+	HedgedMethods []string
+	// HedgingDelay is how long to wait for a response before hedging, 50ms by default.
+	HedgingDelay time.Duration
+}
+
+// NewGRPCClientConnPool returns a new gRPC client connection to several instances of a pomerium
+// rpc service. Calls are load balanced across the instances and the connection to each instance
+// is re-established when it is lost.
+func NewGRPCClientConnPool(ctx context.Context, opts *PoolOptions, other ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if len(opts.Addresses) == 0 {
+		return nil, errors.New("grpcutil: no addresses to connect to")
+	}
+	scheme := opts.Addresses[0].Scheme
+	addresses := make([]resolver.Address, 0, len(opts.Addresses))
+	for _, u := range opts.Addresses {
+		if u.Scheme != scheme {
+			return nil, fmt.Errorf("grpcutil: all addresses must use the same scheme, %s and %s differ",
+				opts.Addresses[0].String(), u.String())
+		}
+		addresses = append(addresses, resolver.Address{
+			Addr:       getHostPort(u),
+			ServerName: u.Hostname(),
+		})
+	}
+
+	serviceConfig, err := getPoolServiceConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var unaryClientInterceptors []grpc.UnaryClientInterceptor
+	if len(opts.HedgedMethods) > 0 {
+		hedgingDelay := opts.HedgingDelay
+		if hedgingDelay <= 0 {
+			hedgingDelay = defaultPoolHedgingDelay
+		}
+		unaryClientInterceptors = append(unaryClientInterceptors, hedgingInterceptor(opts.HedgedMethods, hedgingDelay))
+	}
+	dialOptions, err := getDialOptions(&opts.Options, scheme, unaryClientInterceptors)
+	if err != nil {
+		return nil, err
+	}
+
+	r := manual.NewBuilderWithScheme(fmt.Sprintf("pomerium-pool-%d", atomic.AddUint64(&poolID, 1)))
+	r.InitialState(resolver.State{Addresses: addresses})
+	dialOptions = append(dialOptions,
+		grpc.WithResolvers(r),
+		grpc.WithDefaultServiceConfig(serviceConfig))
+	dialOptions = append(dialOptions, other...)
+	return grpc.DialContext(ctx, r.Scheme()+":///"+opts.ServiceName, dialOptions...)
+}
+
+func getPoolServiceConfig(opts *PoolOptions) (string, error) {
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type methodName struct {
+		Service string `json:"service"`
+	}
+	type methodConfig struct {
+		Name        []methodName `json:"name"`
+		RetryPolicy retryPolicy  `json:"retryPolicy"`
+	}
+	type healthCheckConfig struct {
+		ServiceName string `json:"serviceName"`
+	}
+	var cfg struct {
+		LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+		HealthCheckConfig   *healthCheckConfig    `json:"healthCheckConfig,omitempty"`
+		MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+	}
+
+	switch opts.LoadBalancingPolicy {
+	case "", RoundRobin:
+		cfg.LoadBalancingConfig = []map[string]struct{}{{RoundRobin: {}}}
+	case PickFirst:
+		cfg.LoadBalancingConfig = []map[string]struct{}{{PickFirst: {}}}
+	default:
+		return "", fmt.Errorf("grpcutil: unknown load balancing policy: %s", opts.LoadBalancingPolicy)
+	}
+
+	if opts.HealthCheckServiceName != "" {
+		cfg.HealthCheckConfig = &healthCheckConfig{ServiceName: opts.HealthCheckServiceName}
+	}
+
+	if len(opts.RetriedServices) > 0 {
+		mc := methodConfig{
+			RetryPolicy: retryPolicy{
+				MaxAttempts:          opts.MaxAttempts,
+				InitialBackoff:       "0.1s",
+				MaxBackoff:           "1s",
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{codes.Unavailable.String()},
+			},
+		}
+		if mc.RetryPolicy.MaxAttempts <= 0 {
+			mc.RetryPolicy.MaxAttempts = defaultPoolMaxAttempts
+		}
+		for _, service := range opts.RetriedServices {
+			mc.Name = append(mc.Name, methodName{Service: service})
+		}
+		cfg.MethodConfig = append(cfg.MethodConfig, mc)
+	}
+
+	bs, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// hedgingInterceptor sends a call of one of the methods a second time when there is no
+// response after the delay, and uses the first successful response. The load balancer picks
+// the instance of each attempt, so with round robin the second attempt goes to another instance.
+func hedgingInterceptor(methods []string, delay time.Duration) grpc.UnaryClientInterceptor {
+	hedged := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		hedged[method] = struct{}{}
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		msg, ok := reply.(proto.Message)
+		if _, isHedged := hedged[method]; !isHedged || !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			reply proto.Message
+			err   error
+		}
+		results := make(chan result, 2)
+		call := func() {
+			attemptReply := msg.ProtoReflect().New().Interface()
+			err := invoker(ctx, method, req, attemptReply, cc, opts...)
+			results <- result{attemptReply, err}
+		}
+
+		go call()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		var err error
+		for attempts, pending := 1, 1; pending > 0; {
+			select {
+			case <-timer.C:
+				attempts++
+				pending++
+				go call()
+			case res := <-results:
+				pending--
+				if res.err == nil {
+					proto.Reset(msg)
+					proto.Merge(msg, res.reply)
+					return nil
+				}
+				err = res.err
+				// a call which failed before the hedging delay is not hedged, it was retried
+				if attempts == 1 && timer.Stop() {
+					return err
+				}
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,126 @@
+package grpcutil
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testHealthServer struct {
+	*health.Server
+	calls int64
+	delay int64
+}
+
+func (srv *testHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	atomic.AddInt64(&srv.calls, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(time.Duration(atomic.LoadInt64(&srv.delay))):
+	}
+	return srv.Server.Check(ctx, req)
+}
+
+func startTestHealthServer(t *testing.T) (*testHealthServer, *url.URL) {
+	t.Helper()
+
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	hs := &testHealthServer{Server: health.NewServer()}
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(li) }()
+	t.Cleanup(srv.Stop)
+
+	return hs, &url.URL{Scheme: "http", Host: li.Addr().String()}
+}
+
+func TestNewGRPCClientConnPool(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	check := func(t *testing.T, opts *PoolOptions, n int) {
+		t.Helper()
+
+		cc, err := NewGRPCClientConnPool(ctx, opts)
+		require.NoError(t, err)
+		defer cc.Close()
+
+		client := grpc_health_v1.NewHealthClient(cc)
+		for i := 0; i < n; i++ {
+			_, err := client.Check(ctx, new(grpc_health_v1.HealthCheckRequest))
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("round robin", func(t *testing.T) {
+		srv1, u1 := startTestHealthServer(t)
+		srv2, u2 := startTestHealthServer(t)
+
+		check(t, &PoolOptions{Addresses: []*url.URL{u1, u2}}, 10)
+		assert.NotZero(t, atomic.LoadInt64(&srv1.calls))
+		assert.NotZero(t, atomic.LoadInt64(&srv2.calls))
+	})
+	t.Run("pick first", func(t *testing.T) {
+		srv1, u1 := startTestHealthServer(t)
+		srv2, u2 := startTestHealthServer(t)
+
+		check(t, &PoolOptions{Addresses: []*url.URL{u1, u2}, LoadBalancingPolicy: PickFirst}, 10)
+		assert.Equal(t, int64(10), atomic.LoadInt64(&srv1.calls))
+		assert.Equal(t, int64(0), atomic.LoadInt64(&srv2.calls))
+	})
+	t.Run("unhealthy", func(t *testing.T) {
+		srv1, u1 := startTestHealthServer(t)
+		srv2, u2 := startTestHealthServer(t)
+		srv1.SetServingStatus("example", grpc_health_v1.HealthCheckResponse_SERVING)
+		srv2.SetServingStatus("example", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+		check(t, &PoolOptions{Addresses: []*url.URL{u1, u2}, HealthCheckServiceName: "example"}, 10)
+		assert.Equal(t, int64(10), atomic.LoadInt64(&srv1.calls))
+		assert.Equal(t, int64(0), atomic.LoadInt64(&srv2.calls))
+	})
+	t.Run("hedging", func(t *testing.T) {
+		srv1, u1 := startTestHealthServer(t)
+		srv2, u2 := startTestHealthServer(t)
+
+		cc, err := NewGRPCClientConnPool(ctx, &PoolOptions{
+			Addresses:     []*url.URL{u1, u2},
+			HedgedMethods: []string{"/grpc.health.v1.Health/Check"},
+			HedgingDelay:  10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		defer cc.Close()
+		client := grpc_health_v1.NewHealthClient(cc)
+
+		// wait for both connections to be ready
+		require.Eventually(t, func() bool {
+			_, err := client.Check(ctx, new(grpc_health_v1.HealthCheckRequest))
+			return err == nil && atomic.LoadInt64(&srv1.calls) > 0 && atomic.LoadInt64(&srv2.calls) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		atomic.StoreInt64(&srv1.delay, int64(time.Minute))
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			_, err := client.Check(ctx, new(grpc_health_v1.HealthCheckRequest))
+			require.NoError(t, err)
+		}
+		assert.Less(t, time.Since(start), 5*time.Second, "calls to the slow server should be hedged")
+	})
+	t.Run("mixed schemes", func(t *testing.T) {
+		_, err := NewGRPCClientConnPool(ctx, &PoolOptions{Addresses: []*url.URL{
+			{Scheme: "http", Host: "127.0.0.1:1"},
+			{Scheme: "https", Host: "127.0.0.1:2"},
+		}})
+		assert.Error(t, err)
+	})
+}
@@ -7,6 +7,14 @@ const (
 	AutocertRenewalsTotal                 = "autocert_renewals_total"
 	AutocertCertificatesTotal             = "autocert_certificates_total"
 	AutocertCertificateNextExpiresSeconds = "autocert_certificate_next_expires_seconds"
+	// EnvoyListenerReloadsTotal is the number of times envoy replaced its listeners
+	EnvoyListenerReloadsTotal = "envoy_listener_reloads_total"
+	// EnvoyListenersDraining is set to 1 while envoy drains the connections of replaced listeners
+	EnvoyListenersDraining = "envoy_listeners_draining"
+	// JWTVerificationCacheHitsTotal is the number of JWT verifications answered by the cache
+	JWTVerificationCacheHitsTotal = "jwt_verification_cache_hits_total"
+	// JWTVerificationCacheMissesTotal is the number of JWT verifications not answered by the cache
+	JWTVerificationCacheMissesTotal = "jwt_verification_cache_misses_total"
 	// ConfigLastReloadTimestampSeconds is unix timestamp when configuration was last reloaded
 	ConfigLastReloadTimestampSeconds = "config_last_reload_success_timestamp"
 	// ConfigLastReloadSuccess is set to 1 if last configuration was successfully reloaded
@@ -40,6 +48,22 @@ const (
 	IdentityManagerLastSessionRefreshError = "identity_manager_last_session_refresh_errors"
 	// IdentityManagerLastSessionRefreshSuccess is a counter of last session refresh success
 	IdentityManagerLastSessionRefreshSuccess = "identity_manager_last_session_refresh_success"
+	// IdentityProviderHealth is set to 1 if the last health check of an identity provider endpoint succeeded
+	IdentityProviderHealth = "identity_provider_health"
+	// SigningKeyLastRotationTimestamp is the timestamp the active signing key was activated
+	SigningKeyLastRotationTimestamp = "signing_key_last_rotation_timestamp"
+	// SigningKeyPublishedKeys is the number of signing keys published in the JWKS
+	SigningKeyPublishedKeys = "signing_key_published_keys"
+	// DataBrokerRecords is the number of records stored in the databroker by record type
+	DataBrokerRecords = "databroker_records"
+	// DataBrokerRecordVersion is the latest record version of the databroker change stream
+	DataBrokerRecordVersion = "databroker_record_version"
+	// DataBrokerSyncClientRecordVersion is the last record version sent to each syncing client
+	DataBrokerSyncClientRecordVersion = "databroker_sync_client_record_version"
+	// DataBrokerSyncClientLag is the number of record versions each syncing client is behind
+	DataBrokerSyncClientLag = "databroker_sync_client_lag"
+	// CertificateExpirationTimestampSeconds is the unix timestamp each configured certificate expires at
+	CertificateExpirationTimestampSeconds = "certificate_expiration_timestamp_seconds"
 
 	// BuildInfo is a gauge that may be used to detect whether component is live, and also has version
 	BuildInfo = "build_info"
@@ -68,4 +92,9 @@ const (
 	RevisionLabel       = "revision"
 	GoVersionLabel      = "goversion"
 	HostLabel           = "host"
+	RecordTypeLabel     = "record_type"
+	ClientLabel         = "client"
+	UsageLabel          = "usage"
+	NameLabel           = "name"
+	SerialLabel         = "serial"
 )
@@ -1,6 +1,9 @@
 package criteria
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/open-policy-agent/opa/ast"
 
 	"github.com/pomerium/pomerium/pkg/policy/generator"
@@ -25,10 +28,7 @@ var claimsBody = ast.Body{
 		all_claims := object.union(session_claims, user_claims)
 	`),
 	ast.MustParseExpr(`
-		values := object_get(all_claims, rule_path, [])
-	`),
-	ast.MustParseExpr(`
-		rule_data == values[_]
+		values := object.get(all_claims, replace(rule_path, "/", "."), object_get(all_claims, rule_path, []))
 	`),
 }
 
@@ -45,12 +45,31 @@ func (claimsCriterion) Name() string {
 }
 
 func (c claimsCriterion) GenerateRule(subPath string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	if obj, ok := data.(parser.Object); ok && isClaimMatcher(obj) {
+		body := ast.Body{
+			ast.Assign.Expr(ast.VarTerm("rule_path"), ast.NewTerm(ast.MustInterfaceToValue(subPath))),
+		}
+		body = append(body, claimsBody...)
+		err := matchClaim(&body, ast.VarTerm("values"), obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		return c.generateRule(body)
+	}
+
+	body := ast.Body{
+		ast.Assign.Expr(ast.VarTerm("rule_data"), ast.NewTerm(data.RegoValue())),
+		ast.Assign.Expr(ast.VarTerm("rule_path"), ast.NewTerm(ast.MustInterfaceToValue(subPath))),
+	}
+	body = append(body, claimsBody...)
+	body = append(body, ast.MustParseExpr(`rule_data == values[_]`))
+	return c.generateRule(body)
+}
+
+func (c claimsCriterion) generateRule(body ast.Body) (*ast.Rule, []*ast.Rule, error) {
 	rule := NewCriterionSessionRule(c.g, c.Name(),
 		ReasonClaimOK, ReasonClaimUnauthorized,
-		append(ast.Body{
-			ast.Assign.Expr(ast.VarTerm("rule_data"), ast.NewTerm(data.RegoValue())),
-			ast.Assign.Expr(ast.VarTerm("rule_path"), ast.NewTerm(ast.MustInterfaceToValue(subPath))),
-		}, claimsBody...))
+		body)
 	return rule, []*ast.Rule{
 		rules.GetSession(),
 		rules.GetUser(),
@@ -58,6 +77,66 @@ func (c claimsCriterion) GenerateRule(subPath string, data parser.Value) (*ast.R
 	}, nil
 }
 
+var claimMatchers = map[string]matcher{
+	"contains":    matchStringContains,
+	"ends_with":   matchStringEndsWith,
+	"glob":        matchStringGlob,
+	"in":          matchStringIn,
+	"is":          matchStringIs,
+	"matches":     matchStringMatches,
+	"starts_with": matchStringStartsWith,
+}
+
+// isClaimMatcher returns true if every key in the object is a claim matcher operator. Other objects are
+// compared to the claim value exactly.
+func isClaimMatcher(obj parser.Object) bool {
+	if len(obj) == 0 {
+		return false
+	}
+	for k := range obj {
+		if _, ok := claimMatchers[k]; !ok && k != "exists" {
+			return false
+		}
+	}
+	return true
+}
+
+// matchClaim matches the list of claim values. Value operators must all match a single claim value.
+func matchClaim(dst *ast.Body, values *ast.Term, obj parser.Object) error {
+	if v, ok := obj["exists"]; ok {
+		exists, ok := v.(parser.Boolean)
+		if !ok {
+			return fmt.Errorf("expected boolean for claim exists operator, got: %T", v)
+		}
+		op := ast.Equal
+		if exists {
+			op = ast.GreaterThan
+		}
+		*dst = append(*dst, op.Expr(ast.Count.Call(values), ast.IntNumberTerm(0)))
+	}
+
+	var operators []string
+	for k := range obj {
+		if _, ok := claimMatchers[k]; ok {
+			operators = append(operators, k)
+		}
+	}
+	if len(operators) == 0 {
+		return nil
+	}
+	sort.Strings(operators)
+
+	value := ast.VarTerm("claim_value")
+	*dst = append(*dst, ast.Assign.Expr(value, ast.RefTerm(values, ast.VarTerm("_"))))
+	for _, k := range operators {
+		err := claimMatchers[k](dst, value, obj[k])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Claims returns a Criterion on allowed IDP claims.
 func Claims(generator *Generator) Criterion {
 	return claimsCriterion{g: generator}
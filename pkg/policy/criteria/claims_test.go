@@ -86,4 +86,85 @@ allow:
 		require.Equal(t, A{true, A{ReasonClaimOK}, M{}}, res["allow"])
 		require.Equal(t, A{false, A{}}, res["deny"])
 	})
+	t.Run("by nested claim", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - claim/address/country: US
+`,
+			[]dataBrokerRecord{
+				&session.Session{
+					Id:     "SESSION_ID",
+					UserId: "USER_ID",
+					Claims: map[string]*structpb.ListValue{
+						"address.country": {Values: []*structpb.Value{structpb.NewStringValue("US")}},
+					},
+				},
+				&user.User{
+					Id:    "USER_ID",
+					Email: "test@example.com",
+				},
+			},
+			Input{Session: InputSession{ID: "SESSION_ID"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonClaimOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("matchers", func(t *testing.T) {
+		records := []dataBrokerRecord{
+			&session.Session{
+				Id:     "SESSION_ID",
+				UserId: "USER_ID",
+				Claims: map[string]*structpb.ListValue{
+					"groups": {Values: []*structpb.Value{
+						structpb.NewStringValue("/engineering/backend"),
+						structpb.NewStringValue("/sales"),
+					}},
+					"org.department": {Values: []*structpb.Value{structpb.NewStringValue("r&d")}},
+				},
+			},
+			&user.User{
+				Id:    "USER_ID",
+				Email: "test@example.com",
+			},
+		}
+		for _, tc := range []struct {
+			policy string
+			expect bool
+		}{
+			{`claim/groups: { matches: "^/engineering/.*$" }`, true},
+			{`claim/groups: { matches: "^/marketing/.*$" }`, false},
+			{`claim/groups: { glob: "/engineering/*" }`, true},
+			{`claim/groups: { glob: "/*" }`, true},
+			{`claim/groups: { glob: "/engineering" }`, false},
+			{`claim/groups: { starts_with: "/eng", ends_with: "end" }`, true},
+			{`claim/groups: { starts_with: "/sales", ends_with: "end" }`, false},
+			{`claim/groups: { exists: true }`, true},
+			{`claim/groups: { exists: false }`, false},
+			{`claim/missing: { exists: false }`, true},
+			{`claim/missing: { exists: true }`, false},
+			{`claim/org/department: { is: "r&d" }`, true},
+			{`claim/org.department: { contains: "&" }`, true},
+		} {
+			res, err := evaluate(t, `
+allow:
+  and:
+    - `+tc.policy+`
+`, records, Input{Session: InputSession{ID: "SESSION_ID"}})
+			require.NoError(t, err, tc.policy)
+			if tc.expect {
+				require.Equal(t, A{true, A{ReasonClaimOK}, M{}}, res["allow"], tc.policy)
+			} else {
+				require.Equal(t, A{false, A{ReasonClaimUnauthorized}, M{}}, res["allow"], tc.policy)
+			}
+		}
+	})
+	t.Run("invalid regex", func(t *testing.T) {
+		_, err := evaluate(t, `
+allow:
+  and:
+    - claim/groups: { matches: "(" }
+`, []dataBrokerRecord{}, Input{Session: InputSession{ID: "SESSION_ID"}})
+		require.Error(t, err)
+	})
 }
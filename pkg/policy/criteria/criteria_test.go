@@ -27,13 +27,15 @@ var testingNow = time.Date(2021, 5, 11, 13, 43, 0, 0, time.Local)
 
 type (
 	Input struct {
-		HTTP    InputHTTP    `json:"http"`
-		Session InputSession `json:"session"`
+		HTTP      InputHTTP    `json:"http"`
+		Session   InputSession `json:"session"`
+		Subdomain string       `json:"subdomain"`
 	}
 	InputHTTP struct {
 		Method  string              `json:"method"`
 		Path    string              `json:"path"`
 		Headers map[string][]string `json:"headers"`
+		IP      string              `json:"ip"`
 	}
 	InputSession struct {
 		ID string `json:"id"`
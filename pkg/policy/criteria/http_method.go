@@ -1,6 +1,9 @@
 package criteria
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/open-policy-agent/opa/ast"
 
 	"github.com/pomerium/pomerium/pkg/policy/parser"
@@ -21,6 +24,23 @@ func (httpMethodCriterion) Name() string {
 func (c httpMethodCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
 	var body ast.Body
 	ref := ast.RefTerm(ast.VarTerm("input"), ast.VarTerm("http"), ast.VarTerm("method"))
+
+	// a single method or a list of methods may be used as a shorthand for the in operator
+	if str, ok := data.(parser.String); ok {
+		data = parser.Array{str}
+	}
+	if arr, ok := data.(parser.Array); ok {
+		methods := make(parser.Array, 0, len(arr))
+		for _, method := range arr {
+			str, ok := method.(parser.String)
+			if !ok {
+				return nil, nil, fmt.Errorf("expected string for http method, got: %T", method)
+			}
+			methods = append(methods, parser.String(strings.ToUpper(string(str))))
+		}
+		data = parser.Object{"in": methods}
+	}
+
 	err := matchString(&body, ref, data)
 	if err != nil {
 		return nil, nil, err
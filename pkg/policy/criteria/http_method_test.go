@@ -29,4 +29,26 @@ allow:
 		require.Equal(t, A{false, A{ReasonHTTPMethodUnauthorized}, M{}}, res["allow"])
 		require.Equal(t, A{false, A{}}, res["deny"])
 	})
+	t.Run("list", func(t *testing.T) {
+		for _, tc := range []struct {
+			method string
+			expect bool
+		}{
+			{"GET", true},
+			{"HEAD", true},
+			{"POST", false},
+		} {
+			res, err := evaluate(t, `
+allow:
+  and:
+    - http_method: [get, HEAD]
+`, []dataBrokerRecord{}, Input{HTTP: InputHTTP{Method: tc.method}})
+			require.NoError(t, err)
+			if tc.expect {
+				require.Equal(t, A{true, A{ReasonHTTPMethodOK}, M{}}, res["allow"], tc.method)
+			} else {
+				require.Equal(t, A{false, A{ReasonHTTPMethodUnauthorized}, M{}}, res["allow"], tc.method)
+			}
+		}
+	})
 }
@@ -0,0 +1,76 @@
+package criteria
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/grpc/ipset"
+	"github.com/pomerium/pomerium/pkg/policy/generator"
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+)
+
+var ipSetTypeURL = protoutil.GetTypeURL(new(ipset.IPSet))
+
+var ipSetBody = ast.Body{
+	ast.MustParseExpr(`
+		ip_set := get_databroker_record(ip_set_type_url, rule_data[ip_set_index])
+	`),
+	ast.MustParseExpr(`
+		ip_set != null
+	`),
+	ast.MustParseExpr(`
+		net.cidr_contains(ip_set.cidrs[cidr_index], input.http.ip)
+	`),
+}
+
+type ipSetCriterion struct {
+	g *Generator
+}
+
+func (ipSetCriterion) DataType() CriterionDataType {
+	return generator.CriterionDataTypeUnknown
+}
+
+func (ipSetCriterion) Name() string {
+	return "ip_set"
+}
+
+func (c ipSetCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	var names parser.Array
+	switch v := data.(type) {
+	case parser.String:
+		names = parser.Array{v}
+	case parser.Array:
+		for _, name := range v {
+			if _, ok := name.(parser.String); !ok {
+				return nil, nil, fmt.Errorf("expected string for ip set name, got: %T", name)
+			}
+		}
+		names = v
+	default:
+		return nil, nil, fmt.Errorf("expected string or array of strings for ip_set criterion, got: %T", data)
+	}
+
+	body := ast.Body{
+		ast.Assign.Expr(ast.VarTerm("ip_set_type_url"), ast.StringTerm(ipSetTypeURL)),
+		ast.Assign.Expr(ast.VarTerm("rule_data"), ast.NewTerm(names.RegoValue())),
+	}
+	body = append(body, ipSetBody...)
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonIPSetOK, ReasonIPSetUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// IPSet returns a Criterion which matches the client IP address against named IP sets stored in the databroker.
+func IPSet(generator *Generator) Criterion {
+	return ipSetCriterion{g: generator}
+}
+
+func init() {
+	Register(IPSet)
+}
@@ -0,0 +1,56 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pomerium/pomerium/pkg/grpc/ipset"
+)
+
+func TestIPSet(t *testing.T) {
+	records := []dataBrokerRecord{
+		&ipset.IPSet{Id: "office", Cidrs: []string{"10.0.0.0/8", "192.168.1.1/32"}},
+		&ipset.IPSet{Id: "vpn", Cidrs: []string{"172.16.0.0/12"}},
+	}
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - ip_set: office
+`, records, Input{HTTP: InputHTTP{IP: "10.1.2.3"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonIPSetOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("list", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - ip_set: [office, vpn]
+`, records, Input{HTTP: InputHTTP{IP: "172.16.0.1"}})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonIPSetOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - ip_set: office
+`, records, Input{HTTP: InputHTTP{IP: "172.16.0.1"}})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonIPSetUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("missing", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - ip_set: unknown
+`, records, Input{HTTP: InputHTTP{IP: "10.1.2.3"}})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonIPSetUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+}
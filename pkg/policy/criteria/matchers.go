@@ -2,6 +2,7 @@ package criteria
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/open-policy-agent/opa/ast"
 
@@ -19,7 +20,10 @@ func matchString(dst *ast.Body, left *ast.Term, right parser.Value) error {
 	lookup := map[string]matcher{
 		"contains":    matchStringContains,
 		"ends_with":   matchStringEndsWith,
+		"glob":        matchStringGlob,
+		"in":          matchStringIn,
 		"is":          matchStringIs,
+		"matches":     matchStringMatches,
 		"starts_with": matchStringStartsWith,
 	}
 	for k, v := range obj {
@@ -45,11 +49,48 @@ func matchStringEndsWith(dst *ast.Body, left *ast.Term, right parser.Value) erro
 	return nil
 }
 
+func matchStringGlob(dst *ast.Body, left *ast.Term, right parser.Value) error {
+	if _, ok := right.(parser.String); !ok {
+		return fmt.Errorf("expected string for glob operator, got: %T", right)
+	}
+	*dst = append(*dst, ast.GlobMatch.Expr(
+		ast.NewTerm(right.RegoValue()),
+		ast.ArrayTerm(ast.StringTerm("/")),
+		left,
+	))
+	return nil
+}
+
+func matchStringIn(dst *ast.Body, left *ast.Term, right parser.Value) error {
+	arr, ok := right.(parser.Array)
+	if !ok {
+		return fmt.Errorf("expected array for in operator, got: %T", right)
+	}
+	terms := make([]*ast.Term, 0, len(arr))
+	for _, v := range arr {
+		terms = append(terms, ast.NewTerm(v.RegoValue()))
+	}
+	*dst = append(*dst, ast.NewExpr(ast.RefTerm(ast.SetTerm(terms...), left)))
+	return nil
+}
+
 func matchStringIs(dst *ast.Body, left *ast.Term, right parser.Value) error {
 	*dst = append(*dst, ast.Equal.Expr(left, ast.NewTerm(right.RegoValue())))
 	return nil
 }
 
+func matchStringMatches(dst *ast.Body, left *ast.Term, right parser.Value) error {
+	pattern, ok := right.(parser.String)
+	if !ok {
+		return fmt.Errorf("expected string for matches operator, got: %T", right)
+	}
+	if _, err := regexp.Compile(string(pattern)); err != nil {
+		return fmt.Errorf("invalid matches pattern: %w", err)
+	}
+	*dst = append(*dst, ast.RegexMatch.Expr(ast.NewTerm(right.RegoValue()), left))
+	return nil
+}
+
 func matchStringStartsWith(dst *ast.Body, left *ast.Term, right parser.Value) error {
 	*dst = append(*dst, ast.StartsWith.Expr(left, ast.NewTerm(right.RegoValue())))
 	return nil
@@ -34,6 +34,22 @@ func TestStringMatcher(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, `endswith(example, "test")`, str(body))
 	})
+	t.Run("glob", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"glob": parser.String("/api/*"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `glob.match("/api/*", ["/"], example)`, str(body))
+	})
+	t.Run("in", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"in": parser.Array{parser.String("a"), parser.String("b")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"a", "b"}[example]`, str(body))
+	})
 	t.Run("is", func(t *testing.T) {
 		var body ast.Body
 		err := matchString(&body, ast.VarTerm("example"), parser.Object{
@@ -42,6 +58,19 @@ func TestStringMatcher(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, `example == "test"`, str(body))
 	})
+	t.Run("matches", func(t *testing.T) {
+		var body ast.Body
+		err := matchString(&body, ast.VarTerm("example"), parser.Object{
+			"matches": parser.String("^test$"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `regex.match("^test$", example)`, str(body))
+
+		err = matchString(&body, ast.VarTerm("example"), parser.Object{
+			"matches": parser.String("("),
+		})
+		assert.Error(t, err)
+	})
 	t.Run("starts_with", func(t *testing.T) {
 		var body ast.Body
 		err := matchString(&body, ast.VarTerm("example"), parser.Object{
@@ -25,11 +25,15 @@ const (
 	ReasonHTTPPathOK                           = "http-path-ok"
 	ReasonHTTPPathUnauthorized                 = "http-path-unauthorized"
 	ReasonInvalidClientCertificate             = "invalid-client-certificate"
+	ReasonIPSetOK                              = "ip-set-ok"
+	ReasonIPSetUnauthorized                    = "ip-set-unauthorized"
 	ReasonNonCORSRequest                       = "non-cors-request"
 	ReasonNonPomeriumRoute                     = "non-pomerium-route"
 	ReasonPomeriumRoute                        = "pomerium-route"
 	ReasonReject                               = "reject"
 	ReasonRouteNotFound                        = "route-not-found"
+	ReasonSubdomainOK                          = "subdomain-ok"
+	ReasonSubdomainUnauthorized                = "subdomain-unauthorized"
 	ReasonUserOK                               = "user-ok"
 	ReasonUserUnauthenticated                  = "user-unauthenticated" // user needs to log in
 	ReasonUserUnauthorized                     = "user-unauthorized"    // user does not have access
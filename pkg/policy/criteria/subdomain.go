@@ -0,0 +1,43 @@
+package criteria
+
+import (
+	"github.com/open-policy-agent/opa/ast"
+
+	"github.com/pomerium/pomerium/pkg/policy/parser"
+)
+
+type subdomainCriterion struct {
+	g *Generator
+}
+
+func (subdomainCriterion) DataType() CriterionDataType {
+	return CriterionDataTypeStringMatcher
+}
+
+func (subdomainCriterion) Name() string {
+	return "subdomain"
+}
+
+func (c subdomainCriterion) GenerateRule(_ string, data parser.Value) (*ast.Rule, []*ast.Rule, error) {
+	var body ast.Body
+	ref := ast.RefTerm(ast.VarTerm("input"), ast.VarTerm("subdomain"))
+	err := matchString(&body, ref, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := NewCriterionRule(c.g, c.Name(),
+		ReasonSubdomainOK, ReasonSubdomainUnauthorized,
+		body)
+
+	return rule, nil, nil
+}
+
+// Subdomain returns a Criterion which matches the subdomain captured by the wildcard of a route.
+func Subdomain(generator *Generator) Criterion {
+	return subdomainCriterion{g: generator}
+}
+
+func init() {
+	Register(Subdomain)
+}
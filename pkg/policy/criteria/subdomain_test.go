@@ -0,0 +1,32 @@
+package criteria
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubdomain(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - subdomain:
+        is: app1
+`, []dataBrokerRecord{}, Input{Subdomain: "app1"})
+		require.NoError(t, err)
+		require.Equal(t, A{true, A{ReasonSubdomainOK}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		res, err := evaluate(t, `
+allow:
+  and:
+    - subdomain:
+        starts_with: app
+`, []dataBrokerRecord{}, Input{Subdomain: "admin"})
+		require.NoError(t, err)
+		require.Equal(t, A{false, A{ReasonSubdomainUnauthorized}, M{}}, res["allow"])
+		require.Equal(t, A{false, A{}}, res["deny"])
+	})
+}
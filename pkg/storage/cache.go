@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+)
+
+const (
+	// cachedBackendMaxAge bounds how stale an entry can be if a change is missed, since changes
+	// are only seen once the change stream delivers them.
+	cachedBackendMaxAge = time.Minute
+	// cachedBackendMaxQueryRecords is the maximum number of records of a cached query. Larger
+	// queries, like the ones of syncers, are streamed from the underlying backend.
+	cachedBackendMaxQueryRecords = 1000
+	// cachedBackendRetryInterval is how long to wait before following the change stream again
+	// after an error.
+	cachedBackendRetryInterval = 5 * time.Second
+)
+
+// A cachedBackend is a read-through cache for the Get and SyncLatest calls of a backend. The
+// entries are invalidated by the change stream of the underlying backend, and by the writes made
+// through the cache. While the change stream isn't followed, the cache isn't used.
+type cachedBackend struct {
+	underlying Backend
+	cache      *lru.Cache
+
+	closeCtx context.Context
+	close    context.CancelFunc
+	done     chan struct{}
+
+	mu sync.Mutex
+	// watching is true when the change stream is being followed
+	watching bool
+	// epoch is incremented whenever the cache is purged
+	epoch uint64
+	// generations are incremented whenever a record of a type changes. An entry read from the
+	// underlying backend is only stored if neither the epoch nor the generation of its type
+	// changed while it was read.
+	generations map[string]uint64
+}
+
+type cachedBackendEntry struct {
+	expiry time.Time
+
+	// Get entries
+	record *databroker.Record
+	err    error
+
+	// SyncLatest entries, which are only valid for the generation of their type
+	generation                   uint64
+	serverVersion, recordVersion uint64
+	records                      []*databroker.Record
+}
+
+// NewCachedBackend creates a new backend which caches up to size Get and SyncLatest results of
+// the underlying backend.
+func NewCachedBackend(size int, underlying Backend) (Backend, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("storage: error creating cache: %w", err)
+	}
+
+	backend := &cachedBackend{
+		underlying:  underlying,
+		cache:       cache,
+		done:        make(chan struct{}),
+		generations: map[string]uint64{},
+	}
+	backend.closeCtx, backend.close = context.WithCancel(context.Background())
+	go backend.run()
+	return backend, nil
+}
+
+func (backend *cachedBackend) Close() error {
+	backend.close()
+	<-backend.done
+	return backend.underlying.Close()
+}
+
+func (backend *cachedBackend) CompareAndPut(ctx context.Context, records []*databroker.Record) (uint64, error) {
+	serverVersion, err := backend.underlying.CompareAndPut(ctx, records)
+	backend.invalidate(records...)
+	return serverVersion, err
+}
+
+func (backend *cachedBackend) Get(ctx context.Context, recordType, id string) (*databroker.Record, error) {
+	key := "get\x00" + recordType + "\x00" + id
+	if entry, ok := backend.get(key); ok {
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		return proto.Clone(entry.record).(*databroker.Record), nil
+	}
+
+	epoch, generation, ok := backend.getGeneration(recordType)
+	record, err := backend.underlying.Get(ctx, recordType, id)
+	switch {
+	case !ok:
+	case err == nil:
+		backend.set(key, recordType, epoch, generation, &cachedBackendEntry{
+			record: proto.Clone(record).(*databroker.Record),
+		})
+	case errors.Is(err, ErrNotFound):
+		// missing records are cached too, since they are looked up as often
+		backend.set(key, recordType, epoch, generation, &cachedBackendEntry{err: err})
+	}
+	return record, err
+}
+
+func (backend *cachedBackend) GetOptions(ctx context.Context, recordType string) (*databroker.Options, error) {
+	return backend.underlying.GetOptions(ctx, recordType)
+}
+
+func (backend *cachedBackend) IncrementCounter(ctx context.Context, name string, delta int64, ttl time.Duration) (int64, error) {
+	return backend.underlying.IncrementCounter(ctx, name, delta, ttl)
+}
+
+func (backend *cachedBackend) Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
+	return backend.underlying.Lease(ctx, leaseName, leaseID, ttl)
+}
+
+func (backend *cachedBackend) ListLeases(ctx context.Context) ([]*databroker.Lease, error) {
+	return backend.underlying.ListLeases(ctx)
+}
+
+func (backend *cachedBackend) Put(ctx context.Context, records []*databroker.Record) (uint64, error) {
+	serverVersion, err := backend.underlying.Put(ctx, records)
+	backend.invalidate(records...)
+	return serverVersion, err
+}
+
+func (backend *cachedBackend) SetOptions(ctx context.Context, recordType string, options *databroker.Options) error {
+	return backend.underlying.SetOptions(ctx, recordType, options)
+}
+
+func (backend *cachedBackend) Sync(ctx context.Context, serverVersion, recordVersion uint64) (RecordStream, error) {
+	return backend.underlying.Sync(ctx, serverVersion, recordVersion)
+}
+
+func (backend *cachedBackend) SyncLatest(
+	ctx context.Context,
+	recordType string,
+	filter FilterExpression,
+) (serverVersion, recordVersion uint64, stream RecordStream, err error) {
+	// syncing every record is too large to be cached
+	if recordType == "" {
+		return backend.underlying.SyncLatest(ctx, recordType, filter)
+	}
+
+	key := fmt.Sprintf("sync-latest\x00%s\x00%#v", recordType, filter)
+	if entry, ok := backend.get(key); ok {
+		_, generation, _ := backend.getGeneration(recordType)
+		if entry.generation == generation {
+			return entry.serverVersion, entry.recordVersion, RecordListToStream(ctx, cloneRecords(entry.records)), nil
+		}
+	}
+
+	epoch, generation, ok := backend.getGeneration(recordType)
+	serverVersion, recordVersion, stream, err = backend.underlying.SyncLatest(ctx, recordType, filter)
+	if err != nil || !ok {
+		return serverVersion, recordVersion, stream, err
+	}
+
+	var records []*databroker.Record
+	for len(records) <= cachedBackendMaxQueryRecords && stream.Next(false) {
+		records = append(records, stream.Record())
+	}
+	if stream.Err() != nil {
+		_ = stream.Close()
+		return 0, 0, nil, stream.Err()
+	}
+	if len(records) > cachedBackendMaxQueryRecords {
+		return serverVersion, recordVersion, NewConcatenatedRecordStream(RecordListToStream(ctx, records), stream), nil
+	}
+	_ = stream.Close()
+
+	backend.set(key, recordType, epoch, generation, &cachedBackendEntry{
+		generation:    generation,
+		serverVersion: serverVersion,
+		recordVersion: recordVersion,
+		records:       cloneRecords(records),
+	})
+	return serverVersion, recordVersion, RecordListToStream(ctx, records), nil
+}
+
+func (backend *cachedBackend) get(key string) (*cachedBackendEntry, bool) {
+	backend.mu.Lock()
+	watching := backend.watching
+	backend.mu.Unlock()
+	if !watching {
+		return nil, false
+	}
+
+	v, ok := backend.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	entry := v.(*cachedBackendEntry)
+	if !time.Now().Before(entry.expiry) {
+		backend.cache.Remove(key)
+		return nil, false
+	}
+	return entry, true
+}
+
+// getGeneration returns the epoch and the generation of the record type, and whether the cache
+// can be used.
+func (backend *cachedBackend) getGeneration(recordType string) (epoch, generation uint64, ok bool) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	return backend.epoch, backend.generations[recordType], backend.watching
+}
+
+// set stores an entry read at the epoch and generation, unless they changed.
+func (backend *cachedBackend) set(key, recordType string, epoch, generation uint64, entry *cachedBackendEntry) {
+	entry.expiry = time.Now().Add(cachedBackendMaxAge)
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	// the record changed while it was read, so the entry may already be stale
+	if !backend.watching || backend.epoch != epoch || backend.generations[recordType] != generation {
+		return
+	}
+	backend.cache.Add(key, entry)
+}
+
+// invalidate removes the entries of changed records.
+func (backend *cachedBackend) invalidate(records ...*databroker.Record) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	for _, record := range records {
+		backend.generations[record.GetType()]++
+		backend.cache.Remove("get\x00" + record.GetType() + "\x00" + record.GetId())
+	}
+}
+
+// purge removes all the entries, and sets whether the change stream is being followed.
+func (backend *cachedBackend) purge(watching bool) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	backend.watching = watching
+	backend.epoch++
+	backend.cache.Purge()
+}
+
+// run follows the change stream of the underlying backend until the backend is closed.
+func (backend *cachedBackend) run() {
+	defer close(backend.done)
+
+	ctx := backend.closeCtx
+	for {
+		err := backend.watch(ctx)
+		backend.purge(false)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn(ctx).Err(err).Msg("storage: error following changes, cache disabled")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cachedBackendRetryInterval):
+		}
+	}
+}
+
+func (backend *cachedBackend) watch(ctx context.Context) error {
+	serverVersion, recordVersion, stream, err := backend.underlying.SyncLatest(ctx, "", nil)
+	if err != nil {
+		return err
+	}
+	// only the versions are needed
+	_ = stream.Close()
+
+	stream, err = backend.underlying.Sync(ctx, serverVersion, recordVersion)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close() }()
+
+	// changes before the versions may have been missed
+	backend.purge(true)
+	for stream.Next(true) {
+		backend.invalidate(stream.Record())
+	}
+	return stream.Err()
+}
+
+func cloneRecords(records []*databroker.Record) []*databroker.Record {
+	cloned := make([]*databroker.Record, len(records))
+	for i, record := range records {
+		cloned[i] = proto.Clone(record).(*databroker.Record)
+	}
+	return cloned
+}
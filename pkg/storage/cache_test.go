@@ -0,0 +1,125 @@
+package storage_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/protoutil"
+	"github.com/pomerium/pomerium/pkg/storage"
+	"github.com/pomerium/pomerium/pkg/storage/inmemory"
+)
+
+type countingBackend struct {
+	storage.Backend
+	gets, syncLatests int64
+}
+
+func (backend *countingBackend) Get(ctx context.Context, recordType, id string) (*databroker.Record, error) {
+	atomic.AddInt64(&backend.gets, 1)
+	return backend.Backend.Get(ctx, recordType, id)
+}
+
+func (backend *countingBackend) SyncLatest(
+	ctx context.Context,
+	recordType string,
+	filter storage.FilterExpression,
+) (serverVersion, recordVersion uint64, stream storage.RecordStream, err error) {
+	if recordType != "" {
+		atomic.AddInt64(&backend.syncLatests, 1)
+	}
+	return backend.Backend.SyncLatest(ctx, recordType, filter)
+}
+
+func TestCachedBackend(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	underlying := &countingBackend{Backend: inmemory.New()}
+	backend, err := storage.NewCachedBackend(100, underlying)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	put := func(t *testing.T, b storage.Backend, id, value string) {
+		t.Helper()
+		_, err := b.Put(ctx, []*databroker.Record{{
+			Type: "example",
+			Id:   id,
+			Data: protoutil.NewAny(structpb.NewStringValue(value)),
+		}})
+		require.NoError(t, err)
+	}
+	getValue := func(t *testing.T, id string) string {
+		t.Helper()
+		record, err := backend.Get(ctx, "example", id)
+		if err != nil {
+			return err.Error()
+		}
+		var v structpb.Value
+		require.NoError(t, record.GetData().UnmarshalTo(&v))
+		return v.GetStringValue()
+	}
+	queryIDs := func(t *testing.T) []string {
+		t.Helper()
+		_, _, stream, err := backend.SyncLatest(ctx, "example", nil)
+		require.NoError(t, err)
+		records, err := storage.RecordStreamToList(stream)
+		require.NoError(t, err)
+		var ids []string
+		for _, record := range records {
+			ids = append(ids, record.GetId())
+		}
+		return ids
+	}
+
+	put(t, backend, "1", "a")
+
+	// wait for the change stream to be followed
+	require.Eventually(t, func() bool {
+		gets := atomic.LoadInt64(&underlying.gets)
+		getValue(t, "1")
+		return atomic.LoadInt64(&underlying.gets) == gets
+	}, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("get", func(t *testing.T) {
+		gets := atomic.LoadInt64(&underlying.gets)
+		assert.Equal(t, "a", getValue(t, "1"))
+		assert.Equal(t, gets, atomic.LoadInt64(&underlying.gets), "should be cached")
+
+		assert.Equal(t, storage.ErrNotFound.Error(), getValue(t, "2"))
+		assert.Equal(t, storage.ErrNotFound.Error(), getValue(t, "2"))
+		assert.Equal(t, gets+1, atomic.LoadInt64(&underlying.gets), "missing records should be cached")
+	})
+	t.Run("write through the cache", func(t *testing.T) {
+		put(t, backend, "1", "b")
+		assert.Equal(t, "b", getValue(t, "1"))
+	})
+	t.Run("change stream", func(t *testing.T) {
+		put(t, underlying.Backend, "1", "c")
+		assert.Eventually(t, func() bool {
+			return getValue(t, "1") == "c"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		put(t, underlying.Backend, "2", "d")
+		assert.Eventually(t, func() bool {
+			return getValue(t, "2") == "d"
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("sync latest", func(t *testing.T) {
+		syncLatests := atomic.LoadInt64(&underlying.syncLatests)
+		assert.Equal(t, []string{"1", "2"}, queryIDs(t))
+		assert.Equal(t, []string{"1", "2"}, queryIDs(t))
+		assert.Equal(t, syncLatests+1, atomic.LoadInt64(&underlying.syncLatests), "should be cached")
+
+		put(t, underlying.Backend, "3", "e")
+		assert.Eventually(t, func() bool {
+			return len(queryIDs(t)) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -50,19 +50,31 @@ func (e *encryptedRecordStream) Err() error {
 type encryptedBackend struct {
 	underlying Backend
 	cipher     cipher.AEAD
+	// acceptedCiphers are also tried to decrypt records, so records encrypted before a secret
+	// rotation can still be read.
+	acceptedCiphers []cipher.AEAD
 }
 
-// NewEncryptedBackend creates a new encrypted backend.
-func NewEncryptedBackend(secret []byte, underlying Backend) (Backend, error) {
+// NewEncryptedBackend creates a new encrypted backend. Records are encrypted with the secret,
+// and decrypted with the secret or any of the accepted secrets.
+func NewEncryptedBackend(secret []byte, underlying Backend, acceptedSecrets ...[]byte) (Backend, error) {
 	c, err := cryptutil.NewAEADCipher(secret)
 	if err != nil {
 		return nil, err
 	}
 
-	return &encryptedBackend{
+	e := &encryptedBackend{
 		underlying: underlying,
 		cipher:     c,
-	}, nil
+	}
+	for _, acceptedSecret := range acceptedSecrets {
+		c, err := cryptutil.NewAEADCipher(acceptedSecret)
+		if err != nil {
+			return nil, err
+		}
+		e.acceptedCiphers = append(e.acceptedCiphers, c)
+	}
+	return e, nil
 }
 
 func (e *encryptedBackend) Close() error {
@@ -85,11 +97,31 @@ func (e *encryptedBackend) GetOptions(ctx context.Context, recordType string) (*
 	return e.underlying.GetOptions(ctx, recordType)
 }
 
+func (e *encryptedBackend) IncrementCounter(ctx context.Context, name string, delta int64, ttl time.Duration) (int64, error) {
+	return e.underlying.IncrementCounter(ctx, name, delta, ttl)
+}
+
 func (e *encryptedBackend) Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
 	return e.underlying.Lease(ctx, leaseName, leaseID, ttl)
 }
 
+func (e *encryptedBackend) ListLeases(ctx context.Context) ([]*databroker.Lease, error) {
+	return e.underlying.ListLeases(ctx)
+}
+
 func (e *encryptedBackend) Put(ctx context.Context, records []*databroker.Record) (uint64, error) {
+	return e.put(ctx, records, e.underlying.Put)
+}
+
+func (e *encryptedBackend) CompareAndPut(ctx context.Context, records []*databroker.Record) (uint64, error) {
+	return e.put(ctx, records, e.underlying.CompareAndPut)
+}
+
+func (e *encryptedBackend) put(
+	ctx context.Context,
+	records []*databroker.Record,
+	put func(context.Context, []*databroker.Record) (uint64, error),
+) (uint64, error) {
 	encryptedRecords := make([]*databroker.Record, len(records))
 	for i, record := range records {
 		encrypted, err := e.encrypt(record.GetData())
@@ -102,7 +134,7 @@ func (e *encryptedBackend) Put(ctx context.Context, records []*databroker.Record
 		encryptedRecords[i] = newRecord
 	}
 
-	serverVersion, err := e.underlying.Put(ctx, encryptedRecords)
+	serverVersion, err := put(ctx, encryptedRecords)
 	if err != nil {
 		return 0, err
 	}
@@ -173,6 +205,12 @@ func (e *encryptedBackend) decrypt(in *anypb.Any) (out *anypb.Any, err error) {
 	}
 
 	plaintext, err := cryptutil.Decrypt(e.cipher, encrypted.Value, nil)
+	for _, c := range e.acceptedCiphers {
+		if err == nil {
+			break
+		}
+		plaintext, err = cryptutil.Decrypt(c, encrypted.Value, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,8 @@ func TestEncryptedBackend(t *testing.T) {
 		},
 	}
 
-	e, err := NewEncryptedBackend(cryptutil.NewKey(), backend)
+	oldKey := cryptutil.NewKey()
+	e, err := NewEncryptedBackend(oldKey, backend)
 	if !assert.NoError(t, err) {
 		return
 	}
@@ -72,4 +73,22 @@ func TestEncryptedBackend(t *testing.T) {
 	assert.Equal(t, any.TypeUrl, record.Data.TypeUrl, "type should be preserved")
 	assert.Equal(t, any.Value, record.Data.Value, "value should be preserved")
 	assert.NotEqual(t, any.TypeUrl, record.Type, "record type should be preserved")
+
+	t.Run("rotated", func(t *testing.T) {
+		rotated, err := NewEncryptedBackend(cryptutil.NewKey(), backend, oldKey)
+		if !assert.NoError(t, err) {
+			return
+		}
+		record, err := rotated.Get(ctx, "", "TEST-1")
+		if assert.NoError(t, err, "should decrypt records encrypted with an accepted secret") {
+			assert.Equal(t, any.Value, record.Data.Value)
+		}
+
+		other, err := NewEncryptedBackend(cryptutil.NewKey(), backend)
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = other.Get(ctx, "", "TEST-1")
+		assert.Error(t, err, "should not decrypt records encrypted with another secret")
+	})
 }
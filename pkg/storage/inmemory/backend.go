@@ -4,6 +4,7 @@ package inmemory
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +26,11 @@ type lease struct {
 	expiry time.Time
 }
 
+type counter struct {
+	value  int64
+	expiry time.Time
+}
+
 type recordChange struct {
 	record *databroker.Record
 }
@@ -53,6 +59,7 @@ type Backend struct {
 	capacity map[string]*uint64
 	changes  *btree.BTree
 	leases   map[string]*lease
+	counters map[string]*counter
 }
 
 // New creates a new in-memory backend storage.
@@ -67,25 +74,38 @@ func New(options ...Option) *Backend {
 		capacity:      map[string]*uint64{},
 		changes:       btree.New(cfg.degree),
 		leases:        make(map[string]*lease),
+		counters:      make(map[string]*counter),
 	}
-	if cfg.expiry != 0 {
-		go func() {
-			ticker := time.NewTicker(time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-backend.closed:
-					return
-				case <-ticker.C:
-				}
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-backend.closed:
+				return
+			case <-ticker.C:
+			}
 
+			if cfg.expiry != 0 {
 				backend.removeChangesBefore(time.Now().Add(-cfg.expiry))
 			}
-		}()
-	}
+			backend.removeCountersExpiredBefore(time.Now())
+		}
+	}()
 	return backend
 }
 
+func (backend *Backend) removeCountersExpiredBefore(cutoff time.Time) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	for name, c := range backend.counters {
+		if c.expiry.Before(cutoff) {
+			delete(backend.counters, name)
+		}
+	}
+}
+
 func (backend *Backend) removeChangesBefore(cutoff time.Time) {
 	backend.mu.Lock()
 	defer backend.mu.Unlock()
@@ -120,6 +140,7 @@ func (backend *Backend) Close() error {
 		backend.lookup = map[string]*RecordCollection{}
 		backend.capacity = map[string]*uint64{}
 		backend.changes = btree.New(backend.cfg.degree)
+		backend.counters = map[string]*counter{}
 	})
 	return nil
 }
@@ -155,6 +176,21 @@ func (backend *Backend) GetOptions(_ context.Context, recordType string) (*datab
 	return options, nil
 }
 
+// IncrementCounter adds delta to a counter and returns its new value.
+func (backend *Backend) IncrementCounter(_ context.Context, name string, delta int64, ttl time.Duration) (int64, error) {
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+
+	now := time.Now()
+	c, ok := backend.counters[name]
+	if !ok || c.expiry.Before(now) {
+		c = &counter{expiry: now.Add(ttl)}
+		backend.counters[name] = c
+	}
+	c.value += delta
+	return c.value, nil
+}
+
 // Lease acquires or renews a lease.
 func (backend *Backend) Lease(_ context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
 	backend.mu.Lock()
@@ -186,12 +222,54 @@ func (backend *Backend) Lease(_ context.Context, leaseName, leaseID string, ttl
 	return true, nil
 }
 
+// ListLeases lists the leases which haven't expired.
+func (backend *Backend) ListLeases(_ context.Context) ([]*databroker.Lease, error) {
+	backend.mu.RLock()
+	defer backend.mu.RUnlock()
+
+	now := time.Now()
+	leases := make([]*databroker.Lease, 0, len(backend.leases))
+	for name, l := range backend.leases {
+		if l.expiry.Before(now) {
+			continue
+		}
+		leases = append(leases, &databroker.Lease{
+			Name:      name,
+			Id:        l.id,
+			ExpiresAt: timestamppb.New(l.expiry),
+		})
+	}
+	sort.Slice(leases, func(i, j int) bool { return leases[i].Name < leases[j].Name })
+	return leases, nil
+}
+
 // Put puts a record into the in-memory store.
 func (backend *Backend) Put(ctx context.Context, records []*databroker.Record) (serverVersion uint64, err error) {
+	return backend.put(ctx, records, false)
+}
+
+// CompareAndPut puts records into the in-memory store if their versions match the stored records.
+func (backend *Backend) CompareAndPut(ctx context.Context, records []*databroker.Record) (serverVersion uint64, err error) {
+	return backend.put(ctx, records, true)
+}
+
+func (backend *Backend) put(ctx context.Context, records []*databroker.Record, compareVersions bool) (serverVersion uint64, err error) {
 	backend.mu.Lock()
 	defer backend.mu.Unlock()
 	defer backend.onChange.Broadcast(ctx)
 
+	if compareVersions {
+		for _, record := range records {
+			var version uint64
+			if c, ok := backend.lookup[record.GetType()]; ok {
+				version = c.Get(record.GetId()).GetVersion()
+			}
+			if version != record.GetVersion() {
+				return backend.serverVersion, storage.ErrVersionMismatch
+			}
+		}
+	}
+
 	recordTypes := map[string]struct{}{}
 	for _, record := range records {
 		if record == nil {
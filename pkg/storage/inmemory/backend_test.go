@@ -72,6 +72,39 @@ func TestBackend(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, record)
 	})
+	t.Run("compare and put", func(t *testing.T) {
+		_, err := backend.CompareAndPut(ctx, []*databroker.Record{{
+			Type: "CAS",
+			Id:   "a",
+			Data: new(anypb.Any),
+		}})
+		require.NoError(t, err)
+		record, err := backend.Get(ctx, "CAS", "a")
+		require.NoError(t, err)
+
+		_, err = backend.CompareAndPut(ctx, []*databroker.Record{{
+			Type: "CAS",
+			Id:   "a",
+			Data: new(anypb.Any),
+		}})
+		assert.ErrorIs(t, err, storage.ErrVersionMismatch, "should not create a record which exists")
+
+		_, err = backend.CompareAndPut(ctx, []*databroker.Record{{
+			Version: record.GetVersion(),
+			Type:    "CAS",
+			Id:      "a",
+			Data:    new(anypb.Any),
+		}})
+		assert.NoError(t, err)
+
+		_, err = backend.CompareAndPut(ctx, []*databroker.Record{{
+			Version: record.GetVersion(),
+			Type:    "CAS",
+			Id:      "a",
+			Data:    new(anypb.Any),
+		}})
+		assert.ErrorIs(t, err, storage.ErrVersionMismatch, "should not update a changed record")
+	})
 }
 
 func TestExpiry(t *testing.T) {
@@ -237,6 +270,14 @@ func TestLease(t *testing.T) {
 		require.NoError(t, err)
 		assert.False(t, ok, "expected b to fail to acquire the lease")
 	}
+	{
+		leases, err := backend.ListLeases(ctx)
+		require.NoError(t, err)
+		if assert.Len(t, leases, 1) {
+			assert.Equal(t, "test", leases[0].GetName())
+			assert.Equal(t, "a", leases[0].GetId())
+		}
+	}
 	{
 		ok, err := backend.Lease(ctx, "test", "a", 0)
 		require.NoError(t, err)
@@ -248,3 +289,31 @@ func TestLease(t *testing.T) {
 		assert.True(t, ok, "expected b to to acquire the lease")
 	}
 }
+
+func TestIncrementCounter(t *testing.T) {
+	ctx := context.Background()
+	backend := New()
+	defer func() { _ = backend.Close() }()
+
+	n, err := backend.IncrementCounter(ctx, "test", 0, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	n, err = backend.IncrementCounter(ctx, "test", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = backend.IncrementCounter(ctx, "test", -1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = backend.IncrementCounter(ctx, "expired", 1, -time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = backend.IncrementCounter(ctx, "expired", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "should reset expired counters")
+
+	backend.removeCountersExpiredBefore(time.Now().Add(time.Hour))
+	assert.Empty(t, backend.counters)
+}
@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/storage"
@@ -34,6 +35,13 @@ func newSyncLatestRecordStream(
 			}
 		}
 		backend.mu.RUnlock()
+		// records are streamed in a stable order so an interrupted sync latest can be resumed
+		sort.Slice(ready, func(i, j int) bool {
+			if ready[i].GetType() != ready[j].GetType() {
+				return ready[i].GetType() < ready[j].GetType()
+			}
+			return ready[i].GetId() < ready[j].GetId()
+		})
 		return nil, storage.ErrStreamDone
 	}
 
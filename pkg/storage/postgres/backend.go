@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -30,6 +31,8 @@ type Backend struct {
 	mu            sync.RWMutex
 	pool          *pgxpool.Pool
 	serverVersion uint64
+
+	snapshotMu sync.Mutex
 }
 
 // New creates a new Backend.
@@ -46,7 +49,12 @@ func New(dsn string, options ...Option) *Backend {
 			return err
 		}
 
-		return deleteChangesBefore(ctx, pool, time.Now().Add(-backend.cfg.expiry))
+		err = deleteChangesBefore(ctx, pool, time.Now().Add(-backend.cfg.expiry))
+		if err != nil {
+			return err
+		}
+
+		return deleteCountersExpiredBefore(ctx, pool, time.Now())
 	}, time.Minute)
 	go backend.doPeriodically(func(ctx context.Context) error {
 		_, pool, err := backend.init(backend.closeCtx)
@@ -123,6 +131,24 @@ func (backend *Backend) GetOptions(
 	return getOptions(ctx, conn, recordType)
 }
 
+// IncrementCounter adds delta to a counter and returns its new value.
+func (backend *Backend) IncrementCounter(
+	ctx context.Context,
+	name string,
+	delta int64,
+	ttl time.Duration,
+) (int64, error) {
+	ctx, cancel := contextutil.Merge(ctx, backend.closeCtx)
+	defer cancel()
+
+	_, conn, err := backend.init(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return incrementCounter(ctx, conn, name, delta, ttl)
+}
+
 // Lease attempts to acquire a lease for the given name.
 func (backend *Backend) Lease(
 	ctx context.Context,
@@ -145,10 +171,39 @@ func (backend *Backend) Lease(
 	return leaseHolderID == leaseID, nil
 }
 
+// ListLeases lists the leases which haven't expired.
+func (backend *Backend) ListLeases(ctx context.Context) ([]*databroker.Lease, error) {
+	ctx, cancel := contextutil.Merge(ctx, backend.closeCtx)
+	defer cancel()
+
+	_, conn, err := backend.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return listLeases(ctx, conn)
+}
+
 // Put puts a record into Postgres.
 func (backend *Backend) Put(
 	ctx context.Context,
 	records []*databroker.Record,
+) (serverVersion uint64, err error) {
+	return backend.put(ctx, records, false)
+}
+
+// CompareAndPut puts records into Postgres if their versions match the stored records.
+func (backend *Backend) CompareAndPut(
+	ctx context.Context,
+	records []*databroker.Record,
+) (serverVersion uint64, err error) {
+	return backend.put(ctx, records, true)
+}
+
+func (backend *Backend) put(
+	ctx context.Context,
+	records []*databroker.Record,
+	compareVersions bool,
 ) (serverVersion uint64, err error) {
 	ctx, cancel := contextutil.Merge(ctx, backend.closeCtx)
 	defer cancel()
@@ -169,6 +224,21 @@ func (backend *Backend) Put(
 			return fmt.Errorf("storage/postgres: error getting latest record version: %w", err)
 		}
 
+		if compareVersions {
+			for _, record := range records {
+				var version uint64
+				existing, err := getRecord(ctx, tx, record.GetType(), record.GetId())
+				if err == nil {
+					version = existing.GetVersion()
+				} else if !errors.Is(err, storage.ErrNotFound) {
+					return fmt.Errorf("storage/postgres: error getting record: %w", err)
+				}
+				if version != record.GetVersion() {
+					return storage.ErrVersionMismatch
+				}
+			}
+		}
+
 		// add all the records
 		recordTypes := map[string]struct{}{}
 		for i, record := range records {
@@ -263,11 +333,6 @@ func (backend *Backend) SyncLatest(
 		return 0, 0, nil, err
 	}
 
-	recordVersion, err = getLatestRecordVersion(callCtx, pool)
-	if err != nil {
-		return 0, 0, nil, err
-	}
-
 	if recordType != "" {
 		f := storage.EqualsFilterExpression{
 			Fields: []string{"type"},
@@ -280,10 +345,34 @@ func (backend *Backend) SyncLatest(
 		}
 	}
 
+	if parallelism := backend.getSnapshotParallelism(pool); parallelism > 1 {
+		recordVersion, stream, err = newSnapshotRecordStream(ctx, backend, pool, expr, parallelism)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		return serverVersion, recordVersion, stream, nil
+	}
+
+	recordVersion, err = getLatestRecordVersion(callCtx, pool)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
 	stream = newRecordStream(ctx, backend, expr)
 	return serverVersion, recordVersion, stream, nil
 }
 
+// getSnapshotParallelism returns the number of ranges of records to read in parallel. The scans
+// of a snapshot use at most half of the connections of the pool, so that other calls aren't
+// blocked while the records are streamed.
+func (backend *Backend) getSnapshotParallelism(pool *pgxpool.Pool) int {
+	parallelism := backend.cfg.snapshotParallelism
+	if maxParallelism := int(pool.Config().MaxConns) / 2; parallelism > maxParallelism {
+		parallelism = maxParallelism
+	}
+	return parallelism
+}
+
 func (backend *Backend) init(ctx context.Context) (serverVersion uint64, pool *pgxpool.Pool, err error) {
 	backend.mu.RLock()
 	serverVersion = backend.serverVersion
@@ -84,6 +84,23 @@ func TestBackend(t *testing.T) {
 			assert.False(t, acquired)
 		})
 
+		t.Run("counter", func(t *testing.T) {
+			n, err := backend.IncrementCounter(ctx, "counter-test", 2, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), n)
+
+			n, err = backend.IncrementCounter(ctx, "counter-test", -1, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+
+			n, err = backend.IncrementCounter(ctx, "counter-expired", 1, -time.Second)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+			n, err = backend.IncrementCounter(ctx, "counter-expired", 1, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), n, "should reset expired counters")
+		})
+
 		t.Run("latest", func(t *testing.T) {
 			for i := 0; i < 100; i++ {
 				_, err := backend.Put(ctx, []*databroker.Record{{
@@ -110,6 +127,41 @@ func TestBackend(t *testing.T) {
 			}
 		})
 
+		t.Run("parallel latest", func(t *testing.T) {
+			var records []*databroker.Record
+			for i := 0; i < 2500; i++ {
+				records = append(records, &databroker.Record{
+					Type: "parallel-latest-test",
+					Id:   fmt.Sprintf("%04d", i),
+					Data: protoutil.NewAny(protoutil.NewStructMap(map[string]*structpb.Value{})),
+				})
+			}
+			_, err := backend.Put(ctx, records)
+			require.NoError(t, err)
+
+			parallelBackend := New(dsn, WithSnapshotParallelism(4))
+			defer parallelBackend.Close()
+
+			_, expectedRecordVersion, stream, err := backend.SyncLatest(ctx, "", nil)
+			require.NoError(t, err)
+			require.NoError(t, stream.Close())
+
+			_, recordVersion, stream, err := parallelBackend.SyncLatest(ctx, "parallel-latest-test", nil)
+			require.NoError(t, err)
+			defer stream.Close()
+			assert.Equal(t, expectedRecordVersion, recordVersion)
+
+			var ids []string
+			for stream.Next(false) {
+				ids = append(ids, stream.Record().GetId())
+			}
+			assert.NoError(t, stream.Err())
+			require.Len(t, ids, len(records))
+			for i, id := range ids {
+				assert.Equal(t, records[i].GetId(), id, "should stream the records in order")
+			}
+		})
+
 		t.Run("changed", func(t *testing.T) {
 			serverVersion, recordVersion, stream, err := backend.SyncLatest(ctx, "", nil)
 			require.NoError(t, err)
@@ -77,6 +77,22 @@ var migrations = []func(context.Context, pgx.Tx) error{
 			return err
 		}
 
+		return nil
+	},
+	2: func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			CREATE TABLE `+schemaName+`.`+countersTableName+` (
+				name TEXT NOT NULL,
+				value BIGINT NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL,
+
+				PRIMARY KEY (name)
+			)
+		`)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	},
 }
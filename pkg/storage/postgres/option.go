@@ -7,7 +7,8 @@ import (
 const defaultExpiry = time.Hour * 24
 
 type config struct {
-	expiry time.Duration
+	expiry              time.Duration
+	snapshotParallelism int
 }
 
 // Option customizes a Backend.
@@ -20,9 +21,18 @@ func WithExpiry(expiry time.Duration) Option {
 	}
 }
 
+// WithSnapshotParallelism sets the maximum number of ranges of records read in parallel by
+// SyncLatest. With 1, records are read by a single scan.
+func WithSnapshotParallelism(parallelism int) Option {
+	return func(cfg *config) {
+		cfg.snapshotParallelism = parallelism
+	}
+}
+
 func getConfig(options ...Option) *config {
 	cfg := new(config)
 	WithExpiry(defaultExpiry)(cfg)
+	WithSnapshotParallelism(1)(cfg)
 	for _, o := range options {
 		o(cfg)
 	}
@@ -4,6 +4,8 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgconn"
@@ -26,6 +28,7 @@ var (
 	recordChangeNotifyName = "pomerium_record_change"
 	recordOptionsTableName = "record_options"
 	leasesTableName        = "leases"
+	countersTableName      = "counters"
 )
 
 type querier interface {
@@ -34,6 +37,14 @@ type querier interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
+func deleteCountersExpiredBefore(ctx context.Context, q querier, cutoff time.Time) error {
+	_, err := q.Exec(ctx, `
+		DELETE FROM `+schemaName+`.`+countersTableName+`
+		WHERE expires_at < $1
+	`, cutoff)
+	return err
+}
+
 func deleteChangesBefore(ctx context.Context, q querier, cutoff time.Time) error {
 	_, err := q.Exec(ctx, `
 		DELETE FROM `+schemaName+`.`+recordChangesTableName+`
@@ -158,23 +169,49 @@ func getRecord(ctx context.Context, q querier, recordType, recordID string) (*da
 	}, nil
 }
 
-func listRecords(ctx context.Context, q querier, expr storage.FilterExpression, offset, limit int) ([]*databroker.Record, error) {
-	args := []interface{}{offset, limit}
+// A recordKey is the primary key of a record. Records are listed in the order of their keys.
+type recordKey struct {
+	recordType, id string
+}
+
+// listRecords lists up to limit records matching the filter expression, in the order of their
+// keys. When set, only the records with keys after the after key, and up to the until key, are
+// listed.
+func listRecords(
+	ctx context.Context,
+	q querier,
+	expr storage.FilterExpression,
+	after, until *recordKey,
+	limit int,
+) ([]*databroker.Record, error) {
+	args := []interface{}{limit}
 	query := `
 		SELECT type, id, version, data, modified_at
 		FROM ` + schemaName + `.` + recordsTableName + `
 	`
+	var conditions []string
 	if expr != nil {
-		query += "WHERE "
-		err := addFilterExpressionToQuery(&query, &args, expr)
+		var condition string
+		err := addFilterExpressionToQuery(&condition, &args, expr)
 		if err != nil {
 			return nil, err
 		}
+		conditions = append(conditions, condition)
+	}
+	if after != nil {
+		conditions = append(conditions, fmt.Sprintf("(type, id) > ($%d, $%d)", len(args)+1, len(args)+2))
+		args = append(args, after.recordType, after.id)
+	}
+	if until != nil {
+		conditions = append(conditions, fmt.Sprintf("(type, id) <= ($%d, $%d)", len(args)+1, len(args)+2))
+		args = append(args, until.recordType, until.id)
+	}
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ")
 	}
 	query += `
 		ORDER BY type, id
-		LIMIT $2
-		OFFSET $1
+		LIMIT $1
 	`
 	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
@@ -210,6 +247,67 @@ func listRecords(ctx context.Context, q querier, expr storage.FilterExpression,
 	return records, rows.Err()
 }
 
+// listRecordRangeLastKeys splits the records matching the filter expression into up to n ranges
+// of the same size, and returns the key of the last record of each range, along with the total
+// number of records.
+func listRecordRangeLastKeys(
+	ctx context.Context,
+	q querier,
+	expr storage.FilterExpression,
+	n int,
+) (lastKeys []recordKey, total int, err error) {
+	args := []interface{}{n}
+	query := `
+		SELECT DISTINCT ON (bucket) type, id, total
+		FROM (
+			SELECT type, id,
+			       ntile($1) OVER (ORDER BY type, id) AS bucket,
+			       count(*) OVER () AS total
+			FROM ` + schemaName + `.` + recordsTableName + `
+	`
+	if expr != nil {
+		query += "WHERE "
+		err := addFilterExpressionToQuery(&query, &args, expr)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	query += `
+		) AS buckets
+		ORDER BY bucket, type DESC, id DESC
+	`
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key recordKey
+		err = rows.Scan(&key.recordType, &key.id, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		lastKeys = append(lastKeys, key)
+	}
+	return lastKeys, total, rows.Err()
+}
+
+func incrementCounter(ctx context.Context, q querier, name string, delta int64, ttl time.Duration) (value int64, err error) {
+	tbl := schemaName + "." + countersTableName
+	expiresAt := timestamptzFromTimestamppb(timestamppb.New(time.Now().Add(ttl)))
+	now := timestamptzFromTimestamppb(timestamppb.Now())
+	err = q.QueryRow(ctx, `
+		INSERT INTO `+tbl+` (name, value, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE
+		SET value=CASE WHEN `+tbl+`.expires_at<$4 THEN $2 ELSE `+tbl+`.value+$2 END,
+		    expires_at=CASE WHEN `+tbl+`.expires_at<$4 THEN $3 ELSE `+tbl+`.expires_at END
+		RETURNING `+tbl+`.value
+	`, name, delta, expiresAt, now).Scan(&value)
+	return value, err
+}
+
 func maybeAcquireLease(ctx context.Context, q querier, leaseName, leaseID string, ttl time.Duration) (leaseHolderID string, err error) {
 	tbl := schemaName + "." + leasesTableName
 	expiresAt := timestamptzFromTimestamppb(timestamppb.New(time.Now().Add(ttl)))
@@ -225,6 +323,37 @@ func maybeAcquireLease(ctx context.Context, q querier, leaseName, leaseID string
 	return leaseHolderID, err
 }
 
+func listLeases(ctx context.Context, q querier) ([]*databroker.Lease, error) {
+	now := timestamptzFromTimestamppb(timestamppb.Now())
+	rows, err := q.Query(ctx, `
+		SELECT name, id, expires_at
+		FROM `+schemaName+`.`+leasesTableName+`
+		WHERE expires_at>=$1
+		ORDER BY name
+	`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leases []*databroker.Lease
+	for rows.Next() {
+		var name, id string
+		var expiresAt pgtype.Timestamptz
+		err = rows.Scan(&name, &id, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		leases = append(leases, &databroker.Lease{
+			Name:      name,
+			Id:        id,
+			ExpiresAt: timestamppbFromTimestamptz(expiresAt),
+		})
+	}
+	return leases, rows.Err()
+}
+
 func putRecordChange(ctx context.Context, q querier, record *databroker.Record) error {
 	data, err := jsonbFromAny(record.GetData())
 	if err != nil {
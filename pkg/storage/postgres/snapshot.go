@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/pomerium/pomerium/pkg/contextutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
+	"github.com/pomerium/pomerium/pkg/storage"
+)
+
+const (
+	// snapshotBatchSize is the number of records read at a time by each scan of a snapshot.
+	snapshotBatchSize = 256
+	// snapshotBufferedBatches is the number of batches each scan reads ahead of the stream.
+	snapshotBufferedBatches = 4
+	// snapshotMinRangeRecords is the minimum number of records of a range scanned in parallel,
+	// so that small snapshots aren't split.
+	snapshotMinRangeRecords = 1000
+)
+
+var snapshotTxOptions = pgx.TxOptions{
+	IsoLevel:   pgx.RepeatableRead,
+	AccessMode: pgx.ReadOnly,
+}
+
+type snapshotBatch struct {
+	records []*databroker.Record
+	err     error
+}
+
+// A snapshotRecordStream streams records read by parallel scans of ranges of record keys. The
+// scans read the same exported snapshot, so the records are consistent with each other and with
+// the record version of the snapshot. The records of each range are streamed in order, once the
+// records of the previous ranges were.
+type snapshotRecordStream struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	ranges  []chan snapshotBatch
+	pending []*databroker.Record
+	err     error
+}
+
+func newSnapshotRecordStream(
+	ctx context.Context,
+	backend *Backend,
+	pool *pgxpool.Pool,
+	expr storage.FilterExpression,
+	parallelism int,
+) (recordVersion uint64, _ storage.RecordStream, err error) {
+	stream := new(snapshotRecordStream)
+	stream.ctx, stream.cancel = contextutil.Merge(ctx, backend.closeCtx)
+
+	// the connections of the scans are acquired while the snapshot is exported, so snapshots are
+	// exported one at a time to not exhaust the pool
+	backend.snapshotMu.Lock()
+	defer backend.snapshotMu.Unlock()
+
+	// the transaction exporting the snapshot is kept open until every scan imported it
+	tx, err := pool.BeginTx(stream.ctx, snapshotTxOptions)
+	if err != nil {
+		stream.cancel()
+		return 0, nil, err
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	recordVersion, lastKeys, snapshotID, err := exportSnapshot(stream.ctx, tx, expr, parallelism)
+	if err != nil {
+		stream.cancel()
+		return 0, nil, err
+	}
+
+	var wg sync.WaitGroup
+	importErrs := make([]error, len(lastKeys))
+	for i := range lastKeys {
+		var after, until *recordKey
+		if i > 0 {
+			after = &lastKeys[i-1]
+		}
+		// records are never added to the snapshot, but the last range is left open anyway
+		if i < len(lastKeys)-1 {
+			until = &lastKeys[i]
+		}
+
+		batches := make(chan snapshotBatch, snapshotBufferedBatches)
+		stream.ranges = append(stream.ranges, batches)
+
+		i := i
+		wg.Add(1)
+		go scanSnapshotRange(stream.ctx, pool, snapshotID, expr, after, until, batches, func(err error) {
+			importErrs[i] = err
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	for _, err := range importErrs {
+		if err != nil {
+			stream.cancel()
+			return 0, nil, err
+		}
+	}
+	return recordVersion, stream, nil
+}
+
+// exportSnapshot exports the snapshot of the transaction, and returns its record version and the
+// last keys of the ranges of records to scan.
+func exportSnapshot(
+	ctx context.Context,
+	tx pgx.Tx,
+	expr storage.FilterExpression,
+	parallelism int,
+) (recordVersion uint64, lastKeys []recordKey, snapshotID string, err error) {
+	recordVersion, err = getLatestRecordVersion(ctx, tx)
+	if err != nil {
+		return 0, nil, "", err
+	}
+
+	err = tx.QueryRow(ctx, `SELECT pg_export_snapshot()`).Scan(&snapshotID)
+	if err != nil {
+		return 0, nil, "", err
+	}
+
+	lastKeys, total, err := listRecordRangeLastKeys(ctx, tx, expr, parallelism)
+	if err != nil {
+		return 0, nil, "", err
+	}
+
+	// merge the ranges which are too small
+	n := (total + snapshotMinRangeRecords - 1) / snapshotMinRangeRecords
+	if n < len(lastKeys) {
+		merged := make([]recordKey, 0, n)
+		for j := 0; j < n; j++ {
+			merged = append(merged, lastKeys[(j+1)*len(lastKeys)/n-1])
+		}
+		lastKeys = merged
+	}
+
+	return recordVersion, lastKeys, snapshotID, nil
+}
+
+// scanSnapshotRange sends batches of the records of a range of the snapshot, until the range is
+// done, or the context is. imported is called once the scan imported the snapshot, or failed to.
+func scanSnapshotRange(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	snapshotID string,
+	expr storage.FilterExpression,
+	after, until *recordKey,
+	batches chan<- snapshotBatch,
+	imported func(err error),
+) {
+	defer close(batches)
+
+	var importDone bool
+	err := pool.BeginTxFunc(ctx, snapshotTxOptions, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `SET TRANSACTION SNAPSHOT '`+strings.ReplaceAll(snapshotID, `'`, `''`)+`'`)
+		importDone = true
+		imported(err)
+		if err != nil {
+			return err
+		}
+
+		for {
+			records, err := listRecords(ctx, tx, expr, after, until, snapshotBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				return nil
+			}
+
+			select {
+			case batches <- snapshotBatch{records: records}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			last := records[len(records)-1]
+			after = &recordKey{recordType: last.GetType(), id: last.GetId()}
+		}
+	})
+	if !importDone {
+		imported(err)
+		return
+	}
+	if err != nil {
+		select {
+		case batches <- snapshotBatch{err: err}:
+		case <-ctx.Done():
+		}
+	}
+}
+
+func (stream *snapshotRecordStream) Close() error {
+	stream.cancel()
+	return nil
+}
+
+func (stream *snapshotRecordStream) Next(block bool) bool {
+	if stream.err != nil {
+		return false
+	}
+
+	if len(stream.pending) > 1 {
+		stream.pending = stream.pending[1:]
+		return true
+	}
+	stream.pending = nil
+
+	for len(stream.ranges) > 0 {
+		select {
+		case <-stream.ctx.Done():
+			stream.err = stream.ctx.Err()
+			return false
+		case batch, ok := <-stream.ranges[0]:
+			if !ok {
+				stream.ranges = stream.ranges[1:]
+				continue
+			}
+			if batch.err != nil {
+				stream.err = batch.err
+				return false
+			}
+			stream.pending = batch.records
+			return true
+		}
+	}
+	return false
+}
+
+func (stream *snapshotRecordStream) Record() *databroker.Record {
+	if len(stream.pending) == 0 {
+		return nil
+	}
+	return stream.pending[0]
+}
+
+func (stream *snapshotRecordStream) Err() error {
+	return stream.err
+}
@@ -19,7 +19,7 @@ type recordStream struct {
 
 	ctx     context.Context
 	cancel  context.CancelFunc
-	offset  int
+	after   *recordKey
 	pending []*databroker.Record
 	err     error
 }
@@ -58,13 +58,14 @@ func (stream *recordStream) Next(block bool) bool {
 		return false
 	}
 
-	stream.pending, stream.err = listRecords(stream.ctx, pool, stream.expr, stream.offset, recordBatchSize)
-	if stream.err != nil {
+	stream.pending, stream.err = listRecords(stream.ctx, pool, stream.expr, stream.after, nil, recordBatchSize)
+	if stream.err != nil || len(stream.pending) == 0 {
 		return false
 	}
-	stream.offset += recordBatchSize
+	last := stream.pending[len(stream.pending)-1]
+	stream.after = &recordKey{recordType: last.GetType(), id: last.GetId()}
 
-	return len(stream.pending) > 0
+	return true
 }
 
 func (stream *recordStream) Record() *databroker.Record {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,8 +39,22 @@ const (
 
 	recordTypeChangesKeyTpl = redisutil.KeyPrefix + "changes.%s"
 	leaseKeyTpl             = "{pomerium_v3}.lease.%s"
+	// the names of the leases, which can't be scanned in a cluster
+	leaseNamesKey = "{pomerium_v3}.leases"
+	// counters aren't used in transactions with other keys, so they're spread across the nodes
+	// of a cluster
+	counterKeyTpl = "pomerium_v3.counter.%s"
 )
 
+// incrementCounterScript increments a counter and sets its ttl when it's created.
+var incrementCounterScript = redis.NewScript(`
+local value = redis.call('INCRBY', KEYS[1], ARGV[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return value
+`)
+
 // custom errors
 var (
 	ErrExceededMaxRetries = errors.New("redis: transaction reached maximum number of retries")
@@ -56,6 +71,10 @@ var (
 // - options: a Hash of options. The hash key is {recordType}, the hash value the protobuf options.
 // - changes.{recordType}: a Sorted Set of the changes for a record type. The score is the current time,
 //   the value the record id.
+// - lease.{leaseName}: the id of the lease holder, which expires with the lease.
+// - leases: a Set of the names of the leases.
+// - counter.{name}: an integer counter, which expires with its ttl. Unlike the other keys it's
+//   not under the hash tag.
 //
 // Records stored in these keys are typically encrypted.
 type Backend struct {
@@ -155,6 +174,14 @@ func (backend *Backend) GetOptions(ctx context.Context, recordType string) (*dat
 	return &options, nil
 }
 
+// IncrementCounter adds delta to a counter and returns its new value.
+func (backend *Backend) IncrementCounter(ctx context.Context, name string, delta int64, ttl time.Duration) (value int64, err error) {
+	defer func(start time.Time) { recordOperation(ctx, start, "increment_counter", err) }(time.Now())
+
+	return incrementCounterScript.Run(ctx, backend.client, []string{getCounterKey(name)},
+		delta, ttl.Milliseconds()).Int64()
+}
+
 // Lease acquires or renews a lease.
 func (backend *Backend) Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error) {
 	acquired := false
@@ -175,6 +202,7 @@ func (backend *Backend) Lease(ctx context.Context, leaseName, leaseID string, tt
 				p.Del(ctx, key)
 			} else {
 				p.Set(ctx, key, leaseID, ttl)
+				p.SAdd(ctx, leaseNamesKey, leaseName)
 			}
 			return nil
 		})
@@ -192,18 +220,63 @@ func (backend *Backend) Lease(ctx context.Context, leaseName, leaseID string, tt
 	return acquired, err
 }
 
+// ListLeases lists the leases which haven't expired.
+func (backend *Backend) ListLeases(ctx context.Context) ([]*databroker.Lease, error) {
+	names, err := backend.client.SMembers(ctx, leaseNamesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var leases []*databroker.Lease
+	for _, name := range names {
+		key := getLeaseKey(name)
+		id, err := backend.client.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			// the lease expired or was released
+			backend.client.SRem(ctx, leaseNamesKey, name)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		ttl, err := backend.client.PTTL(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		leases = append(leases, &databroker.Lease{
+			Name:      name,
+			Id:        id,
+			ExpiresAt: timestamppb.New(time.Now().Add(ttl)),
+		})
+	}
+	return leases, nil
+}
+
 // Put puts a record into redis.
 func (backend *Backend) Put(ctx context.Context, records []*databroker.Record) (serverVersion uint64, err error) {
 	ctx, span := trace.StartSpan(ctx, "databroker.redis.Put")
 	defer span.End()
 	defer func(start time.Time) { recordOperation(ctx, start, "put", err) }(time.Now())
 
+	return backend.putAndEnforceOptions(ctx, records, false)
+}
+
+// CompareAndPut puts records into redis if their versions match the stored records.
+func (backend *Backend) CompareAndPut(ctx context.Context, records []*databroker.Record) (serverVersion uint64, err error) {
+	ctx, span := trace.StartSpan(ctx, "databroker.redis.CompareAndPut")
+	defer span.End()
+	defer func(start time.Time) { recordOperation(ctx, start, "compare_and_put", err) }(time.Now())
+
+	return backend.putAndEnforceOptions(ctx, records, true)
+}
+
+func (backend *Backend) putAndEnforceOptions(ctx context.Context, records []*databroker.Record, compareVersions bool) (serverVersion uint64, err error) {
 	serverVersion, err = backend.getOrCreateServerVersion(ctx)
 	if err != nil {
 		return serverVersion, err
 	}
 
-	err = backend.put(ctx, records)
+	err = backend.put(ctx, records, compareVersions)
 	if err != nil {
 		return serverVersion, err
 	}
@@ -276,9 +349,27 @@ func (backend *Backend) SyncLatest(
 	return serverVersion, recordVersion, stream, err
 }
 
-func (backend *Backend) put(ctx context.Context, records []*databroker.Record) error {
+func (backend *Backend) put(ctx context.Context, records []*databroker.Record, compareVersions bool) error {
 	return backend.incrementVersion(ctx,
 		func(tx *redis.Tx, version uint64) error {
+			// the last version key is watched, so any concurrent change to a record aborts the transaction
+			if compareVersions {
+				for _, record := range records {
+					var existing databroker.Record
+					key, field := getHashKey(record.GetType(), record.GetId())
+					raw, err := tx.HGet(ctx, key, field).Result()
+					if err == nil {
+						err = proto.Unmarshal([]byte(raw), &existing)
+					}
+					if err != nil && !errors.Is(err, redis.Nil) {
+						return err
+					}
+					if existing.GetVersion() != record.GetVersion() {
+						return storage.ErrVersionMismatch
+					}
+				}
+			}
+
 			for i, record := range records {
 				record.ModifiedAt = timestamppb.Now()
 				record.Version = version + uint64(i)
@@ -340,7 +431,7 @@ func (backend *Backend) enforceOptions(ctx context.Context, recordType string) e
 		if err == nil {
 			// mark the record as deleted and re-submit
 			record.DeletedAt = timestamppb.Now()
-			err = backend.put(ctx, []*databroker.Record{record})
+			err = backend.put(ctx, []*databroker.Record{record}, false)
 			if err != nil {
 				return err
 			}
@@ -503,6 +594,10 @@ func (backend *Backend) getOrCreateServerVersion(ctx context.Context) (serverVer
 	return serverVersion, err
 }
 
+func getCounterKey(name string) string {
+	return fmt.Sprintf(counterKeyTpl, name)
+}
+
 func getLeaseKey(leaseName string) string {
 	return fmt.Sprintf(leaseKeyTpl, leaseName)
 }
@@ -75,6 +75,39 @@ func TestBackend(t *testing.T) {
 			assert.Error(t, err)
 			assert.Nil(t, record)
 		})
+		t.Run("compare and put", func(t *testing.T) {
+			_, err := backend.CompareAndPut(ctx, []*databroker.Record{{
+				Type: "CAS",
+				Id:   "a",
+				Data: new(anypb.Any),
+			}})
+			require.NoError(t, err)
+			record, err := backend.Get(ctx, "CAS", "a")
+			require.NoError(t, err)
+
+			_, err = backend.CompareAndPut(ctx, []*databroker.Record{{
+				Type: "CAS",
+				Id:   "a",
+				Data: new(anypb.Any),
+			}})
+			assert.ErrorIs(t, err, storage.ErrVersionMismatch, "should not create a record which exists")
+
+			_, err = backend.CompareAndPut(ctx, []*databroker.Record{{
+				Version: record.GetVersion(),
+				Type:    "CAS",
+				Id:      "a",
+				Data:    new(anypb.Any),
+			}})
+			assert.NoError(t, err)
+
+			_, err = backend.CompareAndPut(ctx, []*databroker.Record{{
+				Version: record.GetVersion(),
+				Type:    "CAS",
+				Id:      "a",
+				Data:    new(anypb.Any),
+			}})
+			assert.ErrorIs(t, err, storage.ErrVersionMismatch, "should not update a changed record")
+		})
 		return nil
 	}
 
@@ -293,3 +326,30 @@ func TestLease(t *testing.T) {
 		return nil
 	}))
 }
+
+func TestIncrementCounter(t *testing.T) {
+	if os.Getenv("GITHUB_ACTION") != "" && runtime.GOOS == "darwin" {
+		t.Skip("Github action can not run docker on MacOS")
+	}
+
+	ctx := context.Background()
+	require.NoError(t, testutil.WithTestRedis(false, func(rawURL string) error {
+		backend, err := New(rawURL)
+		require.NoError(t, err)
+		defer func() { _ = backend.Close() }()
+
+		n, err := backend.IncrementCounter(ctx, "test", 2, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+
+		n, err = backend.IncrementCounter(ctx, "test", -1, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		ttl, err := backend.client.PTTL(ctx, getCounterKey("test")).Result()
+		require.NoError(t, err)
+		assert.Greater(t, ttl, time.Duration(0), "should expire counters")
+
+		return nil
+	}))
+}
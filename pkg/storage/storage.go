@@ -21,18 +21,28 @@ var (
 	ErrNotFound             = errors.New("record not found")
 	ErrStreamDone           = errors.New("record stream done")
 	ErrInvalidServerVersion = status.Error(codes.Aborted, "invalid server version")
+	ErrVersionMismatch      = status.Error(codes.FailedPrecondition, "record version mismatch")
 )
 
 // Backend is the interface required for a storage backend.
 type Backend interface {
 	// Close closes the backend.
 	Close() error
+	// CompareAndPut is used to insert or update records if none of them changed. The version of
+	// each record must match the version of the stored record, or be 0 if no record is stored.
+	// If any of the versions doesn't match, ErrVersionMismatch is returned and nothing is changed.
+	CompareAndPut(ctx context.Context, records []*databroker.Record) (serverVersion uint64, err error)
 	// Get is used to retrieve a record.
 	Get(ctx context.Context, recordType, id string) (*databroker.Record, error)
 	// GetOptions gets the options for a type.
 	GetOptions(ctx context.Context, recordType string) (*databroker.Options, error)
+	// IncrementCounter adds delta to a counter and returns its new value. A counter which
+	// doesn't exist is created with a value of 0 and deleted after the ttl.
+	IncrementCounter(ctx context.Context, name string, delta int64, ttl time.Duration) (int64, error)
 	// Lease acquires a lease, or renews an existing one. If the lease is acquired true is returned.
 	Lease(ctx context.Context, leaseName, leaseID string, ttl time.Duration) (bool, error)
+	// ListLeases lists the leases which haven't expired.
+	ListLeases(ctx context.Context) ([]*databroker.Lease, error)
 	// Put is used to insert or update records.
 	Put(ctx context.Context, records []*databroker.Record) (serverVersion uint64, err error)
 	// SetOptions sets the options for a type.
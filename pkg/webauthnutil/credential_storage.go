@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pomerium/pomerium/pkg/encoding/base58"
 	"github.com/pomerium/pomerium/pkg/grpc/databroker"
@@ -12,6 +13,8 @@ import (
 	"github.com/pomerium/webauthn"
 )
 
+const maxAuthenticateResponses = 5
+
 // CredentialStorage stores credentials in the databroker.
 type CredentialStorage struct {
 	client databroker.DataBrokerServiceClient
@@ -59,3 +62,15 @@ func (storage *CredentialStorage) SetCredential(
 func GetDeviceCredentialID(credentialID []byte) string {
 	return base58.Encode(credentialID)
 }
+
+// AddAuthenticateResponse adds an authenticate response to the device credential, removing the
+// oldest responses when there are too many, and records when the credential was used.
+func AddAuthenticateResponse(deviceCredential *device.Credential, credentialJSON []byte) {
+	if webauthnCredential := deviceCredential.GetWebauthn(); webauthnCredential != nil {
+		webauthnCredential.AuthenticateResponse = append(webauthnCredential.AuthenticateResponse, credentialJSON)
+		for len(webauthnCredential.AuthenticateResponse) > maxAuthenticateResponses {
+			webauthnCredential.AuthenticateResponse = webauthnCredential.AuthenticateResponse[1:]
+		}
+	}
+	deviceCredential.LastUsedAt = timestamppb.Now()
+}
@@ -16,8 +16,9 @@ import (
 type mockDataBrokerServiceClient struct {
 	databroker.DataBrokerServiceClient
 
-	get func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error)
-	put func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error)
+	get       func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error)
+	put       func(ctx context.Context, in *databroker.PutRequest, opts ...grpc.CallOption) (*databroker.PutResponse, error)
+	rateLimit func(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error)
 }
 
 func (m mockDataBrokerServiceClient) Get(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
@@ -28,6 +29,10 @@ func (m mockDataBrokerServiceClient) Put(ctx context.Context, in *databroker.Put
 	return m.put(ctx, in, opts...)
 }
 
+func (m mockDataBrokerServiceClient) RateLimit(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error) {
+	return m.rateLimit(ctx, in, opts...)
+}
+
 func TestCredentialStorage(t *testing.T) {
 	m := map[string]*databroker.Record{}
 	client := &mockDataBrokerServiceClient{
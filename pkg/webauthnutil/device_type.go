@@ -13,8 +13,13 @@ import (
 	"github.com/pomerium/webauthn/cose"
 )
 
-// DefaultDeviceType is the default device type when none is specified.
-const DefaultDeviceType = urlutil.DefaultDeviceType
+const (
+	// DefaultDeviceType is the default device type when none is specified.
+	DefaultDeviceType = urlutil.DefaultDeviceType
+	// PasskeyDeviceType is the device type for passkeys, discoverable credentials which can
+	// be used to sign in without an identity provider.
+	PasskeyDeviceType = "passkey"
+)
 
 var supportedPublicKeyCredentialParameters = []*device.WebAuthnOptions_PublicKeyCredentialParameters{
 	{Type: device.WebAuthnOptions_PUBLIC_KEY, Alg: int64(cose.AlgorithmES256)},
@@ -55,6 +60,23 @@ var predefinedDeviceTypes = map[string]*device.Type{
 			},
 		},
 	},
+	PasskeyDeviceType: {
+		Id:   PasskeyDeviceType,
+		Name: "Passkey",
+		Specifier: &device.Type_Webauthn{
+			Webauthn: &device.Type_WebAuthn{
+				Options: &device.WebAuthnOptions{
+					Attestation: device.WebAuthnOptions_NONE.Enum(),
+					AuthenticatorSelection: &device.WebAuthnOptions_AuthenticatorSelectionCriteria{
+						UserVerification:       device.WebAuthnOptions_USER_VERIFICATION_REQUIRED.Enum(),
+						RequireResidentKey:     proto.Bool(true),
+						ResidentKeyRequirement: device.WebAuthnOptions_RESIDENT_KEY_REQUIRED.Enum(),
+					},
+					PubKeyCredParams: supportedPublicKeyCredentialParameters,
+				},
+			},
+		},
+	},
 }
 
 // GetDeviceType gets the device type from the databroker. If the device type does not exist in the databroker
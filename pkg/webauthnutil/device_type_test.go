@@ -47,4 +47,15 @@ func TestGetDeviceType(t *testing.T) {
 		deviceType := GetDeviceType(ctx, client, "any")
 		assert.Equal(t, "Any", deviceType.GetName())
 	})
+	t.Run("passkey", func(t *testing.T) {
+		client := &mockDataBrokerServiceClient{
+			get: func(ctx context.Context, in *databroker.GetRequest, opts ...grpc.CallOption) (*databroker.GetResponse, error) {
+				return nil, status.Error(codes.NotFound, "not found")
+			},
+		}
+		deviceType := GetDeviceType(ctx, client, PasskeyDeviceType)
+		assert.Equal(t, "Passkey", deviceType.GetName())
+		assert.Equal(t, device.WebAuthnOptions_RESIDENT_KEY_REQUIRED,
+			deviceType.GetWebauthn().GetOptions().GetAuthenticatorSelection().GetResidentKeyRequirement())
+	})
 }
@@ -1,7 +1,9 @@
 package webauthnutil
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/pomerium/webauthn/cose"
 
 	"github.com/pomerium/pomerium/pkg/cryptutil"
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/device"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 )
@@ -18,6 +21,9 @@ const (
 	rpName          = "Pomerium"
 )
 
+// ErrChallengeUsed indicates that a challenge was already used by another ceremony.
+var ErrChallengeUsed = errors.New("webauthn challenge was already used")
+
 // GenerateChallenge generates a new Challenge.
 func GenerateChallenge(key []byte, expiry time.Time) cryptutil.SecureToken {
 	return cryptutil.GenerateSecureToken(key, expiry, cryptutil.NewRandomToken())
@@ -107,6 +113,23 @@ func GetRequestOptionsForCredential(
 	return newRequestOptions(challenge.Bytes(), deviceType, knownDeviceCredentials), nil
 }
 
+// UseChallenge marks the challenge as used in the databroker, so that a credential verified with it
+// can't be replayed while the challenge is valid. ErrChallengeUsed is returned if the challenge was
+// already used.
+func UseChallenge(ctx context.Context, client databroker.DataBrokerServiceClient, challenge []byte) error {
+	var token cryptutil.SecureToken
+	copy(token[:], challenge)
+
+	// challenges are valid for the ceremony timeout, so they only need to be remembered as long
+	ok, err := databroker.UseOnce(ctx, client, "webauthn_challenge|"+token.Token().String(), ceremonyTimeout)
+	if err != nil {
+		return fmt.Errorf("error using challenge: %w", err)
+	} else if !ok {
+		return ErrChallengeUsed
+	}
+	return nil
+}
+
 // newCreationOptions gets the creation options for WebAuthn with the provided challenge.
 func newCreationOptions(
 	challenge []byte,
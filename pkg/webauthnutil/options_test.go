@@ -1,10 +1,14 @@
 package webauthnutil
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 
+	"github.com/pomerium/pomerium/pkg/grpc/databroker"
 	"github.com/pomerium/pomerium/pkg/grpc/device"
 	"github.com/pomerium/pomerium/pkg/grpc/user"
 	"github.com/pomerium/webauthn"
@@ -87,6 +91,26 @@ func TestGenerateRequestOptions(t *testing.T) {
 	})
 }
 
+func TestUseChallenge(t *testing.T) {
+	ctx := context.Background()
+
+	used := map[string]bool{}
+	client := &mockDataBrokerServiceClient{
+		rateLimit: func(ctx context.Context, in *databroker.RateLimitRequest, opts ...grpc.CallOption) (*databroker.RateLimitResponse, error) {
+			allowed := !used[in.GetKey()]
+			used[in.GetKey()] = true
+			return &databroker.RateLimitResponse{Allowed: allowed}, nil
+		},
+	}
+
+	key := []byte{1, 2, 3}
+	challenge1 := GenerateChallenge(key, time.Now().Add(time.Minute)).Bytes()
+	challenge2 := GenerateChallenge(key, time.Now().Add(time.Minute)).Bytes()
+	assert.NoError(t, UseChallenge(ctx, client, challenge1))
+	assert.ErrorIs(t, UseChallenge(ctx, client, challenge1), ErrChallengeUsed)
+	assert.NoError(t, UseChallenge(ctx, client, challenge2))
+}
+
 func TestFillAttestationConveyance(t *testing.T) {
 	for _, testCase := range []struct {
 		expect webauthn.AttestationConveyancePreference
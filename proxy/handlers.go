@@ -30,7 +30,7 @@ func (p *Proxy) registerDashboardHandlers(r *mux.Router) *mux.Router {
 	// the route specific cookie is returned in a signed query params
 	c := r.PathPrefix(dashboardPath + "/callback").Subrouter()
 	c.Use(func(h http.Handler) http.Handler {
-		return middleware.ValidateSignature(p.state.Load().sharedKey)(h)
+		return middleware.ValidateSignature(p.state.Load().acceptedSharedKeys...)(h)
 	})
 	c.Path("/").Handler(httputil.HandlerFunc(p.Callback)).Methods(http.MethodGet)
 
@@ -135,7 +135,7 @@ func (p *Proxy) saveCallbackSession(w http.ResponseWriter, r *http.Request, enct
 		return nil, fmt.Errorf("proxy: malfromed callback token: %w", err)
 	}
 	// 2. decrypt the JWT using the cipher using the _shared_ secret key
-	rawJWT, err := cryptutil.Decrypt(state.sharedCipher, encryptedJWT, nil)
+	rawJWT, err := cryptutil.DecryptWithAny(state.acceptedSharedCiphers, encryptedJWT, nil)
 	if err != nil {
 		return nil, fmt.Errorf("proxy: callback token decrypt error: %w", err)
 	}
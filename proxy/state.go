@@ -2,7 +2,6 @@ package proxy
 
 import (
 	"crypto/cipher"
-	"encoding/base64"
 	"net/url"
 	"sync/atomic"
 
@@ -17,8 +16,11 @@ import (
 )
 
 type proxyState struct {
-	sharedKey    []byte
-	sharedCipher cipher.AEAD
+	sharedKey          []byte
+	acceptedSharedKeys [][]byte
+	sharedCipher       cipher.AEAD
+	// acceptedSharedCiphers are the ciphers of the accepted shared keys, the sharedCipher first
+	acceptedSharedCiphers []cipher.AEAD
 
 	authenticateURL          *url.URL
 	authenticateDashboardURL *url.URL
@@ -46,18 +48,30 @@ func newProxyStateFromConfig(cfg *config.Config) (*proxyState, error) {
 		return nil, err
 	}
 
+	state.acceptedSharedKeys, err = cfg.Options.GetAcceptedSharedKeys()
+	if err != nil {
+		return nil, err
+	}
+
 	state.sharedCipher, err = cryptutil.NewAEADCipher(state.sharedKey)
 	if err != nil {
 		return nil, err
 	}
+	for _, key := range state.acceptedSharedKeys {
+		c, err := cryptutil.NewAEADCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		state.acceptedSharedCiphers = append(state.acceptedSharedCiphers, c)
+	}
 
-	state.cookieSecret, err = base64.StdEncoding.DecodeString(cfg.Options.CookieSecret)
+	state.cookieSecret, err = cfg.Options.GetCookieSecret()
 	if err != nil {
 		return nil, err
 	}
 
 	// used to load and verify JWT tokens signed by the authenticate service
-	state.encoder, err = jws.NewHS256Signer(state.sharedKey)
+	state.encoder, err = jws.NewHS256Signer(state.sharedKey, state.acceptedSharedKeys[1:]...)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +95,7 @@ func newProxyStateFromConfig(cfg *config.Config) (*proxyState, error) {
 			Secure:   cfg.Options.CookieSecure,
 			HTTPOnly: cfg.Options.CookieHTTPOnly,
 			Expire:   cfg.Options.CookieExpire,
+			Compress: cfg.Options.CookieCompression,
 		}
 	}, state.encoder)
 	if err != nil {
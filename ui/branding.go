@@ -0,0 +1,15 @@
+package ui
+
+import "context"
+
+type brandingKey struct{}
+
+// WithBranding returns a new context with the branding data of the pages served by ServePage.
+func WithBranding(ctx context.Context, branding map[string]interface{}) context.Context {
+	return context.WithValue(ctx, brandingKey{}, branding)
+}
+
+func brandingFromContext(ctx context.Context) map[string]interface{} {
+	branding, _ := ctx.Value(brandingKey{}).(map[string]interface{})
+	return branding
+}
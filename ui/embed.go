@@ -41,6 +41,9 @@ func ServePage(w http.ResponseWriter, r *http.Request, page string, data map[str
 	}
 	data["csrfToken"] = csrf.Token(r)
 	data["page"] = page
+	if branding := brandingFromContext(r.Context()); branding != nil {
+		data["branding"] = branding
+	}
 
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -1,11 +1,16 @@
 import Box from "@mui/material/Box";
 import CssBaseline from "@mui/material/CssBaseline";
 import { ThemeProvider } from "@mui/material/styles";
-import React, { FC } from "react";
+import React, { FC, useEffect } from "react";
 
+import DeviceVerificationPage from "./components/DeviceVerificationPage";
 import ErrorPage from "./components/ErrorPage";
 import Footer from "./components/Footer";
 import Header from "./components/Header";
+import ImpersonationPage from "./components/ImpersonationPage";
+import LDAPSignInPage from "./components/LDAPSignInPage";
+import PasskeySignInPage from "./components/PasskeySignInPage";
+import SelectIdentityProviderPage from "./components/SelectIdentityProviderPage";
 import SignOutConfirmPage from "./components/SignOutConfirmPage";
 import { ToolbarOffset } from "./components/ToolbarOffset";
 import UserInfoPage from "./components/UserInfoPage";
@@ -13,16 +18,46 @@ import WebAuthnRegistrationPage from "./components/WebAuthnRegistrationPage";
 import { SubpageContextProvider } from "./context/Subpage";
 import { createTheme } from "./theme";
 import { PageData, UserInfoPageData } from "./types";
+import { getBranding } from "./util/branding";
 
-const theme = createTheme();
+const branding = getBranding();
+const theme = createTheme(branding);
 
 const App: FC = () => {
   const data = (window["POMERIUM_DATA"] || {}) as PageData;
+  useEffect(() => {
+    if (branding.title) {
+      document.title = branding.title;
+    }
+    if (branding.language) {
+      document.documentElement.lang = branding.language;
+    }
+    if (branding.faviconUrl) {
+      document
+        .querySelectorAll<HTMLLinkElement>("link[rel~='icon']")
+        .forEach((link) => (link.href = branding.faviconUrl));
+    }
+  }, []);
   let body: React.ReactNode = <></>;
   switch (data?.page) {
+    case "DeviceVerification":
+      body = <DeviceVerificationPage data={data} />;
+      break;
     case "Error":
       body = <ErrorPage data={data} />;
       break;
+    case "Impersonation":
+      body = <ImpersonationPage data={data} />;
+      break;
+    case "LDAPSignIn":
+      body = <LDAPSignInPage data={data} />;
+      break;
+    case "PasskeySignIn":
+      body = <PasskeySignInPage data={data} />;
+      break;
+    case "SelectIdentityProvider":
+      body = <SelectIdentityProviderPage data={data} />;
+      break;
     case "SignOutConfirm":
       body = <SignOutConfirmPage data={data} />;
       break;
@@ -7,15 +7,20 @@ import TableCell from "@mui/material/TableCell";
 import TableContainer from "@mui/material/TableContainer";
 import TableHead from "@mui/material/TableHead";
 import TableRow from "@mui/material/TableRow";
+import TextField from "@mui/material/TextField";
 import React, { FC } from "react";
 
+import { DeviceCredential } from "../types";
+
 export type DeviceCredentialsTableProps = {
   csrfToken: string;
+  deviceCredentials?: DeviceCredential[];
   ids: string[];
   webAuthnUrl: string;
 };
 export const DeviceCredentialsTable: FC<DeviceCredentialsTableProps> = ({
   csrfToken,
+  deviceCredentials,
   ids,
   webAuthnUrl
 }) => {
@@ -25,6 +30,8 @@ export const DeviceCredentialsTable: FC<DeviceCredentialsTableProps> = ({
         <TableHead>
           <TableRow>
             <TableCell>ID</TableCell>
+            <TableCell>Name</TableCell>
+            <TableCell>Last Used</TableCell>
             <TableCell></TableCell>
           </TableRow>
         </TableHead>
@@ -35,6 +42,38 @@ export const DeviceCredentialsTable: FC<DeviceCredentialsTableProps> = ({
                 <TableCell>
                   <IDField value={id} />
                 </TableCell>
+                <TableCell>
+                  <form action={webAuthnUrl} method="POST">
+                    <input
+                      type="hidden"
+                      name="_pomerium_csrf"
+                      value={csrfToken}
+                    />
+                    <input type="hidden" name="action" value="rename" />
+                    <input
+                      type="hidden"
+                      name="pomerium_device_credential_id"
+                      value={id}
+                    />
+                    <TextField
+                      name="name"
+                      size="small"
+                      variant="standard"
+                      defaultValue={
+                        deviceCredentials?.find((c) => c?.id === id)?.name
+                      }
+                      inputProps={{ maxLength: 100 }}
+                      required
+                    />
+                    <Button size="small" type="submit">
+                      Rename
+                    </Button>
+                  </form>
+                </TableCell>
+                <TableCell>
+                  {deviceCredentials?.find((c) => c?.id === id)?.lastUsedAt ||
+                    "Never"}
+                </TableCell>
                 <TableCell>
                   <form action={webAuthnUrl} method="POST">
                     <input
@@ -57,7 +96,7 @@ export const DeviceCredentialsTable: FC<DeviceCredentialsTableProps> = ({
             ))
           ) : (
             <TableRow>
-              <TableCell colSpan={2} padding="none">
+              <TableCell colSpan={4} padding="none">
                 <Alert severity="warning" square>
                   No device credentials found.
                 </Alert>
@@ -0,0 +1,76 @@
+import Alert from "@mui/material/Alert";
+import Button from "@mui/material/Button";
+import Container from "@mui/material/Container";
+import Stack from "@mui/material/Stack";
+import TextField from "@mui/material/TextField";
+import Typography from "@mui/material/Typography";
+import React, { FC } from "react";
+import { DeviceVerificationPageData } from "src/types";
+
+import { t } from "../util/branding";
+import CsrfInput from "./CsrfInput";
+import Section from "./Section";
+
+type DeviceVerificationPageProps = {
+  data: DeviceVerificationPageData;
+};
+const DeviceVerificationPage: FC<DeviceVerificationPageProps> = ({ data }) => {
+  if (data?.result) {
+    return (
+      <Container maxWidth="sm">
+        <Section title={t("deviceVerification.title", "Connect a Device")}>
+          <Alert severity={data.result === "approved" ? "success" : "info"}>
+            {data.result === "approved"
+              ? t(
+                  "deviceVerification.approved",
+                  "The device is now signed in. You may close this window."
+                )
+              : t("deviceVerification.denied", "The device was denied access.")}
+          </Alert>
+        </Section>
+      </Container>
+    );
+  }
+
+  return (
+    <Container maxWidth="sm">
+      <Section title={t("deviceVerification.title", "Connect a Device")}>
+        <form method="post">
+          <CsrfInput csrfToken={data?.csrfToken} />
+          <Stack spacing={2}>
+            {data?.error && <Alert severity="error">{data.error}</Alert>}
+            <Typography>
+              {t(
+                "deviceVerification.instructions",
+                "Enter the code displayed on the device. Only approve devices you started signing in yourself."
+              )}
+            </Typography>
+            <TextField
+              name="user_code"
+              label={t("deviceVerification.code", "Code")}
+              defaultValue={data?.userCode}
+              autoComplete="off"
+              autoFocus
+              required
+              fullWidth
+            />
+            <Stack direction="row" spacing={2} justifyContent="flex-end">
+              <Button type="submit" name="action" value="deny">
+                {t("deviceVerification.deny", "Deny")}
+              </Button>
+              <Button
+                type="submit"
+                name="action"
+                value="approve"
+                variant="contained"
+              >
+                {t("deviceVerification.approve", "Approve")}
+              </Button>
+            </Stack>
+          </Stack>
+        </form>
+      </Section>
+    </Container>
+  );
+};
+export default DeviceVerificationPage;
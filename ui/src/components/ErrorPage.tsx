@@ -1,4 +1,5 @@
 import { ErrorPageData } from "../types";
+import { t } from "../util/branding";
 import SectionFooter from "./SectionFooter";
 import Alert from "@mui/material/Alert";
 import AlertTitle from "@mui/material/AlertTitle";
@@ -29,8 +30,11 @@ export const ErrorPage: FC<ErrorPageProps> = ({ data }) => {
           {data?.requestId ? (
             <SectionFooter>
               <Typography variant="caption">
-                If you should have access, contact your administrator with your
-                request id {data?.requestId}.
+                {t(
+                  "error.contactAdministrator",
+                  "If you should have access, contact your administrator with your request id {requestId}.",
+                  { requestId: data?.requestId }
+                )}
               </Typography>
             </SectionFooter>
           ) : (
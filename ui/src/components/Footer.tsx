@@ -3,9 +3,11 @@ import Box from "@mui/material/Box";
 import Stack from "@mui/material/Stack";
 import React, { FC } from "react";
 
+import { getBranding, t } from "../util/branding";
 import { FooterLink } from "./FooterLink";
 
 const Footer: FC = () => {
+  const branding = getBranding();
   return (
     <AppBar
       position="fixed"
@@ -27,15 +29,31 @@ const Footer: FC = () => {
           paddingTop: "16px",
         }}
       >
-        <Box>
-          <FooterLink href="https://pomerium.com/">Home</FooterLink>
-        </Box>
-        <Box>
-          <FooterLink href="https://pomerium.com/docs">Docs</FooterLink>
-        </Box>
-        <Box>
-          <FooterLink href="https://discuss.pomerium.com">Support</FooterLink>
-        </Box>
+        {branding.supportUrl ? (
+          <Box>
+            <FooterLink href={branding.supportUrl}>
+              {t("footer.support", "Support")}
+            </FooterLink>
+          </Box>
+        ) : (
+          <>
+            <Box>
+              <FooterLink href="https://pomerium.com/">
+                {t("footer.home", "Home")}
+              </FooterLink>
+            </Box>
+            <Box>
+              <FooterLink href="https://pomerium.com/docs">
+                {t("footer.docs", "Docs")}
+              </FooterLink>
+            </Box>
+            <Box>
+              <FooterLink href="https://discuss.pomerium.com">
+                {t("footer.support", "Support")}
+              </FooterLink>
+            </Box>
+          </>
+        )}
       </Stack>
     </AppBar>
   );
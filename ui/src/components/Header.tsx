@@ -1,4 +1,5 @@
 import { PageData } from "../types";
+import { t } from "../util/branding";
 import { Avatar } from "./Avatar";
 import Logo from "./Logo";
 import { ToolbarOffset } from "./ToolbarOffset";
@@ -130,7 +131,9 @@ const Header: FC<HeaderProps> = ({ includeSidebar, data }) => {
               open={!!anchorEl}
               anchorEl={anchorEl}
             >
-              <MenuItem onClick={handleLogout}>Logout</MenuItem>
+              <MenuItem onClick={handleLogout}>
+                {t("header.logout", "Logout")}
+              </MenuItem>
             </Menu>
           </>
         )}
@@ -0,0 +1,123 @@
+import Alert from "@mui/material/Alert";
+import Button from "@mui/material/Button";
+import Container from "@mui/material/Container";
+import Stack from "@mui/material/Stack";
+import TextField from "@mui/material/TextField";
+import Typography from "@mui/material/Typography";
+import React, { FC } from "react";
+import { ImpersonationPageData } from "src/types";
+
+import { t } from "../util/branding";
+import CsrfInput from "./CsrfInput";
+import Section from "./Section";
+
+type ImpersonationPageProps = {
+  data: ImpersonationPageData;
+};
+const ImpersonationPage: FC<ImpersonationPageProps> = ({ data }) => {
+  return (
+    <Container maxWidth="sm">
+      <Stack spacing={2}>
+        {data?.error && <Alert severity="error">{data.error}</Alert>}
+        {data?.result === "requested" && (
+          <Alert severity="info">
+            {t(
+              "impersonation.requested",
+              "The impersonation was requested. It takes effect once an approver approves the request of session {sessionId}.",
+              { sessionId: data.selfSessionId }
+            )}
+          </Alert>
+        )}
+        {data?.result === "approved" && (
+          <Alert severity="success">
+            {t(
+              "impersonation.approved",
+              "The impersonation was approved until {expiresAt}.",
+              { expiresAt: data.expiresAt || "" }
+            )}
+          </Alert>
+        )}
+        <Section
+          title={t("impersonation.request.title", "Request Impersonation")}
+        >
+          <form method="post">
+            <CsrfInput csrfToken={data?.csrfToken} />
+            <Stack spacing={2}>
+              <Typography>
+                {t(
+                  "impersonation.request.instructions",
+                  "Request to impersonate the user of another session. An approver must approve the request before it takes effect."
+                )}
+              </Typography>
+              <TextField
+                name="impersonate_session_id"
+                label={t("impersonation.impersonateSessionId", "Session ID")}
+                defaultValue={data?.impersonateSessionId}
+                autoComplete="off"
+                required
+                fullWidth
+              />
+              <TextField
+                name="reason"
+                label={t("impersonation.reason", "Reason")}
+                defaultValue={data?.reason}
+                fullWidth
+              />
+              <TextField
+                name="duration"
+                label={t("impersonation.duration", "Duration")}
+                defaultValue={data?.duration}
+                placeholder="1h"
+                fullWidth
+              />
+              <Stack direction="row" justifyContent="flex-end">
+                <Button
+                  type="submit"
+                  name="action"
+                  value="request"
+                  variant="contained"
+                >
+                  {t("impersonation.request", "Request")}
+                </Button>
+              </Stack>
+            </Stack>
+          </form>
+        </Section>
+        <Section
+          title={t("impersonation.approve.title", "Approve Impersonation")}
+        >
+          <form method="post">
+            <CsrfInput csrfToken={data?.csrfToken} />
+            <Stack spacing={2}>
+              <Typography>
+                {t(
+                  "impersonation.approve.instructions",
+                  "Enter the session ID of the user who requested the impersonation. You cannot approve your own requests."
+                )}
+              </Typography>
+              <TextField
+                name="session_id"
+                label={t("impersonation.sessionId", "Session ID")}
+                defaultValue={data?.sessionId}
+                autoComplete="off"
+                required
+                fullWidth
+              />
+              <Stack direction="row" justifyContent="flex-end">
+                <Button
+                  type="submit"
+                  name="action"
+                  value="approve"
+                  variant="contained"
+                >
+                  {t("impersonation.approve", "Approve")}
+                </Button>
+              </Stack>
+            </Stack>
+          </form>
+        </Section>
+      </Stack>
+    </Container>
+  );
+};
+export default ImpersonationPage;
@@ -0,0 +1,49 @@
+import Alert from "@mui/material/Alert";
+import Button from "@mui/material/Button";
+import Container from "@mui/material/Container";
+import Stack from "@mui/material/Stack";
+import TextField from "@mui/material/TextField";
+import React, { FC } from "react";
+import { LDAPSignInPageData } from "src/types";
+
+import CsrfInput from "./CsrfInput";
+import Section from "./Section";
+
+type LDAPSignInPageProps = {
+  data: LDAPSignInPageData;
+};
+const LDAPSignInPage: FC<LDAPSignInPageProps> = ({ data }) => {
+  return (
+    <Container maxWidth="sm">
+      <Section title="Sign In">
+        <form method="post">
+          <CsrfInput csrfToken={data?.csrfToken} />
+          <input type="hidden" name="state" value={data?.state} />
+          <Stack spacing={2}>
+            {data?.error && <Alert severity="error">{data.error}</Alert>}
+            <TextField
+              name="username"
+              label="Username"
+              autoComplete="username"
+              autoFocus
+              required
+              fullWidth
+            />
+            <TextField
+              name="password"
+              label="Password"
+              type="password"
+              autoComplete="current-password"
+              required
+              fullWidth
+            />
+            <Button type="submit" variant="contained">
+              Sign In
+            </Button>
+          </Stack>
+        </form>
+      </Section>
+    </Container>
+  );
+};
+export default LDAPSignInPage;
@@ -1,9 +1,17 @@
 import LogoURL from "../static/logo_white.svg";
+import { getBranding } from "../util/branding";
 import React from "react";
 import type { FC } from "react";
 
 const Logo: FC = () => {
-  return <img alt="Logo" src={LogoURL} height="30px" />;
+  const branding = getBranding();
+  return (
+    <img
+      alt={branding.title || "Logo"}
+      src={branding.logoUrl || LogoURL}
+      height="30px"
+    />
+  );
 };
 
 export default Logo;
@@ -0,0 +1,33 @@
+import Button from "@mui/material/Button";
+import Container from "@mui/material/Container";
+import Stack from "@mui/material/Stack";
+import React, { FC } from "react";
+import { PasskeySignInPageData } from "src/types";
+
+import Section from "./Section";
+import WebAuthnAuthenticateButton from "./WebAuthnAuthenticateButton";
+
+type PasskeySignInPageProps = {
+  data: PasskeySignInPageData;
+};
+const PasskeySignInPage: FC<PasskeySignInPageProps> = ({ data }) => {
+  return (
+    <Container maxWidth="sm">
+      <Section title="Sign In">
+        <Stack spacing={2}>
+          <WebAuthnAuthenticateButton
+            csrfToken={data?.csrfToken}
+            discoverable
+            requestOptions={data?.requestOptions}
+            text="Sign In With a Passkey"
+            url={data?.passkeyUrl}
+          />
+          <Button href={data?.signInUrl} variant="outlined">
+            Continue With Identity Provider
+          </Button>
+        </Stack>
+      </Section>
+    </Container>
+  );
+};
+export default PasskeySignInPage;
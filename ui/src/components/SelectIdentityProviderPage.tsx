@@ -0,0 +1,30 @@
+import Button from "@mui/material/Button";
+import Container from "@mui/material/Container";
+import Stack from "@mui/material/Stack";
+import React, { FC } from "react";
+import { SelectIdentityProviderPageData } from "src/types";
+
+import { t } from "../util/branding";
+import Section from "./Section";
+
+type SelectIdentityProviderPageProps = {
+  data: SelectIdentityProviderPageData;
+};
+const SelectIdentityProviderPage: FC<SelectIdentityProviderPageProps> = ({
+  data,
+}) => {
+  return (
+    <Container maxWidth="sm">
+      <Section title={t("selectIdentityProvider.title", "Sign In With")}>
+        <Stack spacing={2}>
+          {data?.identityProviders?.map((idp) => (
+            <Button key={idp.url} href={idp.url} variant="contained">
+              {idp.name}
+            </Button>
+          ))}
+        </Stack>
+      </Section>
+    </Container>
+  );
+};
+export default SelectIdentityProviderPage;
@@ -37,6 +37,10 @@ export const SessionDetails: FC<SessionDetailsProps> = ({ session }) => {
                 <TableCell variant="head">Expires At</TableCell>
                 <TableCell align="left">{session?.expiresAt || ""}</TableCell>
               </TableRow>
+              <TableRow>
+                <TableCell variant="head">Last Accessed At</TableCell>
+                <TableCell align="left">{session?.accessedAt || ""}</TableCell>
+              </TableRow>
               {Object.entries(session?.claims || {}).map(
                 ([key, values]) => (
                 <TableRow key={key}>
@@ -7,6 +7,7 @@ import React, { FC } from "react";
 
 import DeviceCredentialsTable from "../components/DeviceCredentialsTable";
 import {
+  DeviceCredential,
   Session,
   User,
   WebAuthnCreationOptions,
@@ -17,6 +18,9 @@ import WebAuthnRegisterButton from "./WebAuthnRegisterButton";
 
 export type SessionDeviceCredentialsProps = {
   csrfToken: string;
+  deviceCredentials?: DeviceCredential[];
+  passkeyCreationOptions?: WebAuthnCreationOptions;
+  passkeyUrl?: string;
   user: User;
   session: Session;
   webAuthnCreationOptions: WebAuthnCreationOptions;
@@ -25,6 +29,9 @@ export type SessionDeviceCredentialsProps = {
 };
 export const SessionDeviceCredentials: FC<SessionDeviceCredentialsProps> = ({
   csrfToken,
+  deviceCredentials,
+  passkeyCreationOptions,
+  passkeyUrl,
   user,
   session,
   webAuthnCreationOptions,
@@ -57,6 +64,15 @@ export const SessionDeviceCredentials: FC<SessionDeviceCredentialsProps> = ({
                 url={webAuthnUrl}
                 size="small"
               />
+              {passkeyCreationOptions && (
+                <WebAuthnRegisterButton
+                  creationOptions={passkeyCreationOptions}
+                  csrfToken={csrfToken}
+                  text="Register Passkey"
+                  url={passkeyUrl}
+                  size="small"
+                />
+              )}
               <WebAuthnAuthenticateButton
                 requestOptions={webAuthnRequestOptions}
                 csrfToken={csrfToken}
@@ -68,6 +84,7 @@ export const SessionDeviceCredentials: FC<SessionDeviceCredentialsProps> = ({
           <Box sx={{ padding: 3, paddingTop: 0 }}>
             <DeviceCredentialsTable
               csrfToken={csrfToken}
+              deviceCredentials={deviceCredentials}
               ids={currentSessionDeviceCredentialIds}
               webAuthnUrl={webAuthnUrl}
             />
@@ -87,6 +104,7 @@ export const SessionDeviceCredentials: FC<SessionDeviceCredentialsProps> = ({
               <Box sx={{ padding: 3, paddingTop: 0 }}>
                 <DeviceCredentialsTable
                   csrfToken={csrfToken}
+                  deviceCredentials={deviceCredentials}
                   ids={otherDeviceCredentialIds}
                   webAuthnUrl={webAuthnUrl}
                 />
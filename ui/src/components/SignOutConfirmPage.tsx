@@ -9,6 +9,8 @@ import styled from "@mui/material/styles/styled";
 import React, { FC } from "react";
 import { SignOutConfirmPageData } from "src/types";
 
+import { t } from "../util/branding";
+
 type SignOutConfirmPageProps = {
   data: SignOutConfirmPageData;
 };
@@ -26,15 +28,19 @@ const SignOutConfirmPage: FC<SignOutConfirmPageProps> = ({ data }) => {
   return (
     <Container>
       <Dialog open={true} disableEscapeKeyDown={true}>
-        <DialogTitle>Logout?</DialogTitle>
+        <DialogTitle>{t("signOut.title", "Logout?")}</DialogTitle>
         <DialogContent>
           <DialogContentText>
-            Are you sure you want to logout?
+            {t("signOut.message", "Are you sure you want to logout?")}
           </DialogContentText>
         </DialogContent>
         <DialogActions>
-          <Button onClick={handleClickCancel}>Cancel</Button>
-          <Button onClick={handleClickLogout}>Logout</Button>
+          <Button onClick={handleClickCancel}>
+            {t("signOut.cancel", "Cancel")}
+          </Button>
+          <Button onClick={handleClickLogout}>
+            {t("signOut.logout", "Logout")}
+          </Button>
         </DialogActions>
       </Dialog>
     </Container>
@@ -90,6 +90,9 @@ const UserInfoPage: FC<UserInfoPageProps> = ({ data }) => {
         {subpage === "Devices Info" && (
           <SessionDeviceCredentials
             csrfToken={data?.csrfToken}
+            deviceCredentials={data?.deviceCredentials}
+            passkeyCreationOptions={data?.passkeyCreationOptions}
+            passkeyUrl={data?.passkeyUrl}
             session={data?.session}
             user={data?.user}
             webAuthnCreationOptions={data?.webAuthnCreationOptions}
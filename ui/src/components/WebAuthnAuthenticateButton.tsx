@@ -38,10 +38,13 @@ export type WebAuthnAuthenticateButtonProps = Omit<
   "action" | "enable" | "onClick" | "text"
 > & {
   requestOptions: WebAuthnRequestOptions;
+  // discoverable credentials (passkeys) aren't listed in the request options
+  discoverable?: boolean;
+  text?: string;
 };
 export const WebAuthnAuthenticateButton: FC<
   WebAuthnAuthenticateButtonProps
-> = ({ requestOptions, ...props }) => {
+> = ({ requestOptions, discoverable, text, ...props }) => {
   async function authenticate(): Promise<unknown> {
     const credential = await authenticateCredential(requestOptions);
     return {
@@ -60,9 +63,9 @@ export const WebAuthnAuthenticateButton: FC<
   return (
     <WebAuthnButton
       action="authenticate"
-      enable={requestOptions?.allowCredentials?.length > 0}
+      enable={discoverable || requestOptions?.allowCredentials?.length > 0}
       onClick={authenticate}
-      text={"Authenticate Existing Device"}
+      text={text || "Authenticate Existing Device"}
       {...props}
     />
   );
@@ -57,10 +57,12 @@ export type WebAuthnRegisterButtonProps = Omit<
 > & {
   creationOptions: WebAuthnCreationOptions;
   csrfToken: string;
+  text?: string;
   url: string;
 };
 export const WebAuthnRegisterButton: FC<WebAuthnRegisterButtonProps> = ({
   creationOptions,
+  text,
   ...props
 }) => {
   async function register(): Promise<unknown> {
@@ -81,7 +83,7 @@ export const WebAuthnRegisterButton: FC<WebAuthnRegisterButtonProps> = ({
       action="register"
       enable={!!creationOptions}
       onClick={register}
-      text={"Register New Device"}
+      text={text || "Register New Device"}
       {...props}
     />
   );
@@ -1,3 +1,4 @@
+import { Branding } from "../types";
 import { softShadows } from "./shadows";
 import "@fontsource/dm-mono";
 import "@fontsource/dm-sans";
@@ -6,7 +7,7 @@ import muiCreateTheme, {
   Theme as MuiTheme,
 } from "@mui/material/styles/createTheme";
 
-export const createTheme = (): MuiTheme =>
+export const createTheme = (branding?: Branding): MuiTheme =>
   muiCreateTheme({
     components: {
       MuiBackdrop: {
@@ -109,10 +110,10 @@ export const createTheme = (): MuiTheme =>
         paper: common.white,
       },
       primary: {
-        main: "#6F43E7",
+        main: branding?.primaryColor || "#6F43E7",
       },
       secondary: {
-        main: "#49AAA1",
+        main: branding?.secondaryColor || "#49AAA1",
       },
     },
     shadows: softShadows,
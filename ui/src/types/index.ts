@@ -14,6 +14,7 @@ export type Group = {
 };
 
 export type Session = {
+  accessedAt: string;
   audience: string[];
   claims: Claims;
   deviceCredentials: Array<{
@@ -77,9 +78,21 @@ export type WebAuthnRequestOptions = {
   userVerification: UserVerificationRequirement;
 };
 
+export type Branding = {
+  title?: string;
+  logoUrl?: string;
+  faviconUrl?: string;
+  primaryColor?: string;
+  secondaryColor?: string;
+  supportUrl?: string;
+  language?: string;
+  texts?: Record<string, string>;
+};
+
 // page data
 
 type BasePageData = {
+  branding?: Branding;
   csrfToken?: string;
 };
 
@@ -94,8 +107,16 @@ export type ErrorPageData = BasePageData & {
   statusText?: string;
 };
 
+export type DeviceCredential = {
+  id: string;
+  typeId: string;
+  name?: string;
+  lastUsedAt?: string;
+};
+
 export type UserInfoData = {
   csrfToken: string;
+  deviceCredentials?: DeviceCredential[];
   directoryGroups?: Group[];
   directoryUser?: DirectoryUser;
   session?: Session;
@@ -103,6 +124,8 @@ export type UserInfoData = {
   webAuthnCreationOptions?: WebAuthnCreationOptions;
   webAuthnRequestOptions?: WebAuthnRequestOptions;
   webAuthnUrl?: string;
+  passkeyCreationOptions?: WebAuthnCreationOptions;
+  passkeyUrl?: string;
 };
 
 export type DeviceEnrolledPageData = BasePageData &
@@ -110,6 +133,51 @@ export type DeviceEnrolledPageData = BasePageData &
     page: "DeviceEnrolled";
   };
 
+export type DeviceVerificationPageData = BasePageData & {
+  page: "DeviceVerification";
+
+  error?: string;
+  result?: "approved" | "denied";
+  userCode?: string;
+};
+
+export type ImpersonationPageData = BasePageData & {
+  page: "Impersonation";
+
+  duration?: string;
+  error?: string;
+  expiresAt?: string;
+  impersonateSessionId?: string;
+  reason?: string;
+  result?: "requested" | "approved";
+  selfSessionId: string;
+  sessionId?: string;
+};
+
+export type LDAPSignInPageData = BasePageData & {
+  page: "LDAPSignIn";
+
+  error?: string;
+  state: string;
+};
+
+export type PasskeySignInPageData = BasePageData & {
+  page: "PasskeySignIn";
+
+  passkeyUrl: string;
+  requestOptions: WebAuthnRequestOptions;
+  signInUrl: string;
+};
+
+export type SelectIdentityProviderPageData = BasePageData & {
+  page: "SelectIdentityProvider";
+
+  identityProviders: {
+    name: string;
+    url: string;
+  }[];
+};
+
 export type SignOutConfirmPageData = BasePageData & {
   page: "SignOutConfirm";
   url: string;
@@ -132,6 +200,11 @@ export type WebAuthnRegistrationPageData = BasePageData & {
 export type PageData =
   | ErrorPageData
   | DeviceEnrolledPageData
+  | DeviceVerificationPageData
+  | ImpersonationPageData
+  | LDAPSignInPageData
+  | PasskeySignInPageData
+  | SelectIdentityProviderPageData
   | SignOutConfirmPageData
   | UserInfoPageData
   | WebAuthnRegistrationPageData;
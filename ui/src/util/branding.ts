@@ -0,0 +1,18 @@
+import { Branding } from "../types";
+
+export const getBranding = (): Branding =>
+  (window["POMERIUM_DATA"]?.branding || {}) as Branding;
+
+// t returns the text for the given key, which may be replaced by the branding texts. Values
+// like {requestId} are replaced with the given values.
+export const t = (
+  key: string,
+  defaultText: string,
+  values?: Record<string, string | number>
+): string => {
+  let text = getBranding().texts?.[key] || defaultText;
+  for (const [k, v] of Object.entries(values || {})) {
+    text = text.split(`{${k}}`).join(`${v}`);
+  }
+  return text;
+};